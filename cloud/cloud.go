@@ -79,6 +79,7 @@ import (
 	"os"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -164,6 +165,15 @@ type Resources struct {
 	Servers      map[string]*Server // the serverID => *Server mapping of any servers Spawn()ed with an external ip
 }
 
+// CreatedResource describes something that was created in the cloud, as found
+// by Discover() or TaggedResources().
+type CreatedResource struct {
+	Kind string // eg. "server", "volume", "network"
+	ID   string
+	Name string            // name or other human-readable description
+	Tags map[string]string // the Tag* tags it has, if any
+}
+
 // Quota struct describes the limit on what resources you are allowed to use (0
 // values mean that resource is unlimited), and how much you have already used.
 type Quota struct {
@@ -185,8 +195,9 @@ type provideri interface {
 	maybeEnv() []string
 	// do any initial config set up such as authentication
 	initialize(logger log15.Logger) error
-	// achieve the aims of Deploy(), recording what you create in resources.Details and resources.PrivateKey
-	deploy(resources *Resources, requiredPorts []int, useConfigDrive bool, gatewayIP, cidr string, dnsNameServers []string) error
+	// achieve the aims of Deploy(), recording what you create in resources.Details and resources.PrivateKey,
+	// and attaching the given tags (plus TagCreated) to what you create and later spawn where possible
+	deploy(resources *Resources, requiredPorts []int, useConfigDrive bool, gatewayIP, cidr string, dnsNameServers []string, tags map[string]string) error
 	// achieve the aims of InCloud()
 	inCloud() bool
 	// return the details of any existing servers, as a slice of the details
@@ -200,13 +211,27 @@ type provideri interface {
 	// soon as the new server has been requested and is counted as using up
 	// quota (or the request fails), then create sentinelFilePath once the new
 	// server is in powered up (but not necessarily fully booted up).
-	spawn(resources *Resources, os string, flavor string, diskGB int, externalIP bool, usingQuotaCh chan bool) (serverID, serverIP, serverName, adminPass string, err error)
+	spawn(resources *Resources, os string, flavor string, diskGB int, externalIP bool, securityGroups []string, metadata map[string]string, usingQuotaCh chan bool) (serverID, serverIP, serverName, adminPass string, err error)
 	// achieve the aims of ErrIsNoHardware()
 	errIsNoHardware(err error) bool
 	// achieve the aims of CheckServer()
 	checkServer(serverID string) (bool, error)
 	// achieve the aims of DestroyServer()
 	destroyServer(serverID string) error
+	// achieve the aims of Snapshot()
+	snapshot(serverID, name string, timeout time.Duration) (imageID string, err error)
+	// achieve the aims of Server.AttachVolume(), creating a new volume of the
+	// given size and name and attaching it to the given server, returning the
+	// volume's id and the device it was probably attached as
+	attachVolume(serverID, name string, sizeGB int) (volumeID, device string, err error)
+	// achieve the aims of Server.DetachVolume(), detaching and then deleting
+	// the given volume
+	detachVolume(serverID, volumeID string) error
+	// achieve the aims of Discover(), also adding any missing entries to
+	// resources.Details that tearDown() needs to delete what was found
+	discover(resources *Resources) ([]*CreatedResource, error)
+	// achieve the aims of TaggedResources()
+	taggedResources() ([]*CreatedResource, error)
 	// achieve the aims of TearDown()
 	tearDown(resources *Resources) error
 }
@@ -254,8 +279,27 @@ type DeployConfig struct {
 	// DNSNameServers is a slice of DNS name server IPs. It defaults to
 	// Google's: []string{"8.8.4.4", "8.8.8.8"}.
 	DNSNameServers []string
+
+	// ManagerHost is recorded in the TagManagerHost tag of everything created,
+	// and should be the host that manages this deployment. It defaults to the
+	// current host name.
+	ManagerHost string
+
+	// Version is recorded in the TagVersion tag of everything created, and
+	// should be the version of the software doing the deployment.
+	Version string
 }
 
+// These are the keys of the tags (or metadata) attached to created resources
+// that support them (servers, networks, subnets and routers), so that they can
+// be found with TaggedResources().
+const (
+	TagDeployment  = "wr_deployment" // the resourceName supplied to New()
+	TagManagerHost = "wr_manager_host"
+	TagCreated     = "wr_created" // RFC 3339 time of creation
+	TagVersion     = "wr_version"
+)
+
 // RequiredEnv returns the environment variables that are needed by the given
 // provider before New() will work for it. See New() for possible providerNames.
 func RequiredEnv(providerName string) ([]string, error) {
@@ -398,11 +442,20 @@ func (p *Provider) Deploy(config *DeployConfig) error {
 	if dnsNameServers == nil {
 		dnsNameServers = defaultDNSNameServers[:]
 	}
+	managerHost := config.ManagerHost
+	if managerHost == "" {
+		managerHost, _ = os.Hostname() // an empty tag is fine on error
+	}
+	tags := map[string]string{
+		TagDeployment:  p.resources.ResourceName,
+		TagManagerHost: managerHost,
+		TagVersion:     config.Version,
+	}
 
 	// impl.deploy should overwrite any existing values in p.resources with
 	// updated values, but should leave other things - such as an existing
 	// PrivateKey when we have not just made a new one - alone
-	err := p.impl.deploy(p.resources, config.RequiredPorts, config.UseConfigDrive, gatewayIP, cidr, dnsNameServers)
+	err := p.impl.deploy(p.resources, config.RequiredPorts, config.UseConfigDrive, gatewayIP, cidr, dnsNameServers, tags)
 	if err != nil {
 		return err
 	}
@@ -642,6 +695,21 @@ type SpawnUsingQuotaCallback func()
 // boot up; call server.WaitUntilReady() before trying to use the server for
 // anything.
 func (p *Provider) Spawn(os string, osUser string, flavorID string, diskGB int, ttd time.Duration, externalIP bool, usingQuotaCB ...SpawnUsingQuotaCallback) (*Server, error) {
+	return p.SpawnInSecurityGroups(os, osUser, flavorID, diskGB, ttd, externalIP, nil, usingQuotaCB...)
+}
+
+// SpawnInSecurityGroups is like Spawn(), but the new server will also be in the
+// given existing security groups, eg. ones that allow it access to particular
+// networks. The server's SecurityGroups will be set to the sorted groups.
+func (p *Provider) SpawnInSecurityGroups(os string, osUser string, flavorID string, diskGB int, ttd time.Duration, externalIP bool, securityGroups []string, usingQuotaCB ...SpawnUsingQuotaCallback) (*Server, error) {
+	return p.SpawnWithMetadata(os, osUser, flavorID, diskGB, ttd, externalIP, securityGroups, nil, usingQuotaCB...)
+}
+
+// SpawnWithMetadata is like SpawnInSecurityGroups(), but the new server will
+// also have the given metadata, in addition to the tags we always give it
+// (which can't be overridden). The server's Metadata will be set to the given
+// metadata.
+func (p *Provider) SpawnWithMetadata(os string, osUser string, flavorID string, diskGB int, ttd time.Duration, externalIP bool, securityGroups []string, metadata map[string]string, usingQuotaCB ...SpawnUsingQuotaCallback) (*Server, error) {
 	f, found := p.impl.flavors()[flavorID]
 	if !found {
 		return nil, Error{"cloud", "Spawn", ErrBadFlavor}
@@ -655,7 +723,12 @@ func (p *Provider) Spawn(os string, osUser string, flavorID string, diskGB int,
 			usingQuotaCB[0]()
 		}
 	}()
-	serverID, serverIP, serverName, adminPass, err := p.impl.spawn(p.resources, os, flavorID, diskGB, externalIP, usingQuota)
+	var groups []string
+	if len(securityGroups) > 0 {
+		groups = append(groups, securityGroups...)
+		sort.Strings(groups)
+	}
+	serverID, serverIP, serverName, adminPass, err := p.impl.spawn(p.resources, os, flavorID, diskGB, externalIP, groups, metadata, usingQuota)
 
 	if err != nil && serverID == "" {
 		return nil, err
@@ -681,6 +754,8 @@ func (p *Provider) Spawn(os string, osUser string, flavorID string, diskGB int,
 		logger:       p.Logger.New("server", serverID),
 		created:      true,
 	}
+	server.SecurityGroups = groups
+	server.Metadata = metadata
 
 	p.Lock()
 	p.servers[nameToHostName(serverName)] = server
@@ -746,6 +821,15 @@ func (p *Provider) DestroyServer(serverID string) error {
 	return p.saveResources()
 }
 
+// Snapshot creates a new OS image with the given name from the current disk of
+// the given server (id retrieved via Spawn() or Servers()), waiting up to
+// timeout for the image to become usable. The returned image ID can be supplied
+// as the os argument to Spawn(). Anything still writing to the server's disk
+// should be stopped first.
+func (p *Provider) Snapshot(serverID, name string, timeout time.Duration) (imageID string, err error) {
+	return p.impl.snapshot(serverID, name, timeout)
+}
+
 // Servers returns a mapping of serverID => *Server for all servers that were
 // Spawn()ed with an external IP (including those spawned in past sessions where
 // the same arguments to New() were used). You should use s.Alive() before
@@ -825,14 +909,23 @@ func (p *Provider) PrivateKey() string {
 }
 
 // TearDown deletes all resources recorded during Deploy() or loaded from a
-// previous session during New(). It also deletes any servers with names
-// prefixed with the resourceName given to the initial New() call. If currently
-// running on a cloud server, however, it will not delete anything needed by
-// this server, including the resource file that contains the private key.
+// previous session during New(), along with everything else Discover() finds,
+// including any servers spawned with the resourceName given to the initial
+// New() call, and their floating IPs and volumes. If currently running on a
+// cloud server, however, it will not delete anything needed by this server,
+// including the resource file that contains the private key.
 func (p *Provider) TearDown() error {
-	p.RLock()
-	defer p.RUnlock()
-	err := p.impl.tearDown(p.resources)
+	p.Lock()
+	defer p.Unlock()
+
+	// find anything we created that our saved resources don't know about, eg.
+	// because the save file was lost
+	_, err := p.impl.discover(p.resources)
+	if err != nil {
+		p.Warn("discovering resources to tear down failed", "err", err)
+	}
+
+	err = p.impl.tearDown(p.resources)
 	if err != nil {
 		return err
 	}
@@ -848,6 +941,26 @@ func (p *Provider) TearDown() error {
 	return err
 }
 
+// Discover finds the resources in the cloud that were created with the
+// resourceName supplied to New() (servers, volumes and floating IPs, whether
+// still attached to those servers or not, networking, security groups and
+// keypairs), including any that aren't recorded in the savePath file, eg.
+// because it was lost. It returns descriptions of those resources, which are
+// what TearDown() would delete, without deleting anything.
+func (p *Provider) Discover() ([]*CreatedResource, error) {
+	p.Lock()
+	defer p.Unlock()
+	return p.impl.discover(p.resources)
+}
+
+// TaggedResources finds all the resources in the cloud (that you can see) that
+// have a TagDeployment tag, regardless of the resourceName supplied to New(),
+// so you can find resources left behind by any deployment. Only resources
+// that support tags are found.
+func (p *Provider) TaggedResources() ([]*CreatedResource, error) {
+	return p.impl.taggedResources()
+}
+
 // saveResources saves our resources to our savePath, overwriting any existing
 // content. This is not thread safe!
 func (p *Provider) saveResources() error {
@@ -23,13 +23,18 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	"github.com/inconshreveable/log15"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -47,6 +52,135 @@ func TestUtility(t *testing.T) {
 		So(nameToHostName("test_123-one"), ShouldEqual, "test-123-one")
 		So(nameToHostName("test_123*ONE"), ShouldEqual, "test-123-one")
 	})
+
+	Convey("Server.HasSecurityGroups works", t, func() {
+		s := &Server{}
+		So(s.HasSecurityGroups(nil), ShouldBeTrue)
+		So(s.HasSecurityGroups([]string{"a"}), ShouldBeFalse)
+		s.SecurityGroups = []string{"a", "b"}
+		So(s.HasSecurityGroups([]string{"b", "a"}), ShouldBeTrue)
+		So(s.HasSecurityGroups([]string{"a"}), ShouldBeFalse)
+		So(s.HasSecurityGroups([]string{"a", "c"}), ShouldBeFalse)
+		So(s.HasSecurityGroups(nil), ShouldBeFalse)
+	})
+
+	Convey("Our tags can be picked out of metadata and tag lists", t, func() {
+		So(ourTags(map[string]string{"foo": "bar"}), ShouldBeNil)
+		So(ourTags(map[string]string{"foo": "bar", TagDeployment: "wr-prod-me", TagVersion: "v1"}), ShouldResemble, map[string]string{TagDeployment: "wr-prod-me", TagVersion: "v1"})
+		So(ourNetworkTags([]string{"foo", TagDeployment + "=wr-prod-me", TagCreated + "=2020-01-02T03:04:05Z"}), ShouldResemble, map[string]string{TagDeployment: "wr-prod-me", TagCreated: "2020-01-02T03:04:05Z"})
+		So(ourNetworkTags(nil), ShouldBeNil)
+	})
+
+	Convey("Servers are only ours if they were spawned by our deployment", t, func() {
+		id := "8ba1ab0c-7bc4-4d5b-8e0d-4b6fbb1a2a63"
+		tests := []struct {
+			name     string
+			metadata map[string]string
+			ours     bool
+		}{
+			{"wr-test-" + id, map[string]string{TagDeployment: "wr-test"}, true},
+			{"wr-test-" + id, nil, true},
+			{"renamed", map[string]string{TagDeployment: "wr-test"}, true},
+			{"wr-test-image-" + id, map[string]string{TagDeployment: "wr-test-image"}, false},
+			{"wr-test-image-" + id, nil, false},
+			{"wr-test-" + id, map[string]string{TagDeployment: "wr-other"}, false},
+			{"wr-test-manager", nil, false},
+			{"wr-test", nil, false},
+			{"wr-testing-" + id, nil, false},
+		}
+		for _, test := range tests {
+			So(ourServer(servers.Server{Name: test.name, Metadata: test.metadata}, "wr-test"), ShouldEqual, test.ours)
+		}
+	})
+}
+
+func TestOpenStackDiscover(t *testing.T) {
+	Convey("Discovering openstack resources finds only those of our deployment", t, func() {
+		ourID := "wr-test-8ba1ab0c-7bc4-4d5b-8e0d-4b6fbb1a2a63"
+		legacyID := "wr-test-0d6a6f0c-3e0e-4b3a-9d52-8f3b0c4b8b11"
+		imageID := "wr-test-image-5a2c3f1e-6b7d-4c8e-9f0a-1b2c3d4e5f60"
+		responses := map[string]string{
+			"/compute/servers/detail": `{"servers": [
+				{"id": "s1", "name": "` + ourID + `", "metadata": {"wr_deployment": "wr-test"},
+				 "os-extended-volumes:volumes_attached": [{"id": "v1"}]},
+				{"id": "s2", "name": "` + imageID + `", "metadata": {"wr_deployment": "wr-test-image"},
+				 "os-extended-volumes:volumes_attached": [{"id": "v4"}]},
+				{"id": "s3", "name": "` + legacyID + `", "metadata": {}},
+				{"id": "s4", "name": "wr-test-manager", "metadata": {"wr_deployment": "wr-test"}}
+			]}`,
+			"/compute/os-floating-ips": `{"floating_ips": [
+				{"id": "fip1", "ip": "10.0.0.1", "instance_id": "s1"},
+				{"id": "fip2", "ip": "10.0.0.2", "instance_id": "s2"},
+				{"id": "fip6", "ip": "10.0.0.6", "instance_id": null}
+			]}`,
+			"/volume/volumes/detail": `{"volumes": [
+				{"id": "v1", "name": "attached", "metadata": {"wr_deployment": "wr-test"}, "attachments": [{"server_id": "s1"}]},
+				{"id": "v2", "name": "detached", "metadata": {"wr_deployment": "wr-test"}, "attachments": []},
+				{"id": "v3", "name": "image detached", "metadata": {"wr_deployment": "wr-test-image"}, "attachments": []},
+				{"id": "v5", "name": "untagged", "metadata": {}, "attachments": []}
+			]}`,
+			"/network/v2.0/floatingips": `{"floatingips": [
+				{"id": "fip3", "floating_ip_address": "10.0.0.3", "port_id": null, "tags": ["wr_deployment=wr-test"]},
+				{"id": "fip4", "floating_ip_address": "10.0.0.4", "port_id": null, "tags": ["wr_deployment=wr-test-image"]},
+				{"id": "fip5", "floating_ip_address": "10.0.0.5", "port_id": "p1", "tags": ["wr_deployment=wr-test"]},
+				{"id": "fip6", "floating_ip_address": "10.0.0.6", "port_id": null, "tags": []}
+			]}`,
+		}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, exists := responses[r.URL.Path]
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, body)
+		}))
+		defer ts.Close()
+
+		client := func(path string) *gophercloud.ServiceClient {
+			return &gophercloud.ServiceClient{ProviderClient: &gophercloud.ProviderClient{}, Endpoint: ts.URL + path}
+		}
+		p := &openstackp{
+			Logger:        testLogger,
+			ownName:       "wr-test-manager",
+			computeClient: client("/compute/"),
+			volumeClient:  client("/volume/"),
+			networkClient: client("/network/"),
+		}
+		p.networkClient.ResourceBase = p.networkClient.Endpoint + "v2.0/"
+
+		resources := &Resources{ResourceName: "wr-test", Details: make(map[string]string)}
+		found, err := p.discover(resources)
+		So(err, ShouldBeNil)
+
+		var kindIDs []string
+		for _, cr := range found {
+			kindIDs = append(kindIDs, cr.Kind+":"+cr.ID)
+		}
+		sort.Strings(kindIDs)
+		So(kindIDs, ShouldResemble, []string{
+			"floating ip:fip1",
+			"floating ip:fip3",
+			"server:s1",
+			"server:s3",
+			"volume:v1",
+			"volume:v2",
+		})
+
+		Convey("Without a block storage service, volumes are only found on servers", func() {
+			p.volumeClient = nil
+			found, err = p.discover(resources)
+			So(err, ShouldBeNil)
+			volumes := 0
+			for _, cr := range found {
+				if cr.Kind == "volume" {
+					volumes++
+					So(cr.ID, ShouldEqual, "v1")
+				}
+			}
+			So(volumes, ShouldEqual, 1)
+		})
+	})
 }
 
 func TestOpenStack(t *testing.T) {
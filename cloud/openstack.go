@@ -30,6 +30,7 @@ import (
 	"net"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -37,16 +38,21 @@ import (
 
 	"github.com/VertebrateResequencing/wr/internal"
 	"github.com/VividCortex/ewma"
+	"github.com/gofrs/uuid"
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/bootfromvolume"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/floatingips"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/quotasets"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/secgroups"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/attributestags"
+	networkfips "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
@@ -70,6 +76,19 @@ const initialServerSpawnTimeout = 20 * time.Minute
 // learning.
 const minimumServerSpawnTimeoutSecs = 180
 
+// snapshotPollInterval is how often we check on the status of an image being
+// created by snapshot().
+const snapshotPollInterval = 5 * time.Second
+
+// volumeStatusTimeoutSecs is how long we wait for volumes created by
+// attachVolume() to become available, and for detached volumes to be free to
+// delete.
+const volumeStatusTimeoutSecs = 300
+
+// uniqueLocalIPv6 is the range of IPv6 addresses that are not globally
+// routable, despite net.IP.IsGlobalUnicast() being true for them.
+var uniqueLocalIPv6 = &net.IPNet{IP: net.ParseIP("fc00::"), Mask: net.CIDRMask(7, 128)}
+
 // invalidFlavorIDMsg is used to report when a certain flavor ID does not exist
 const invalidFlavorIDMsg = "invalid flavor ID"
 
@@ -104,8 +123,10 @@ type openstackp struct {
 	errorBackoff    *backoff.Backoff
 	fmap            map[string]*Flavor
 	imap            map[string]*images.Image
+	tags            map[string]string
 	ipNet           *net.IPNet
 	networkClient   *gophercloud.ServiceClient
+	volumeClient    *gophercloud.ServiceClient
 	ownServer       *servers.Server
 	fmapMutex       sync.RWMutex
 	imapMutex       sync.RWMutex
@@ -191,6 +212,16 @@ func (p *openstackp) initialize(logger log15.Logger) error {
 		return err
 	}
 
+	// make a block storage client, which we only use to clean up volumes
+	// during teardown; not all installs have this service, so failure is fine
+	p.volumeClient, err = openstack.NewBlockStorageV3(provider, gophercloud.EndpointOpts{
+		Region: os.Getenv("OS_REGION_NAME"),
+	})
+	if err != nil {
+		p.Debug("no block storage service", "err", err)
+		p.volumeClient = nil
+	}
+
 	// flavors and images are retrieved on-demand via caching methods that store
 	// in these maps
 	p.fmap = make(map[string]*Flavor)
@@ -335,7 +366,7 @@ func (p *openstackp) getImageFromCache(prefix string) *images.Image {
 }
 
 // deploy achieves the aims of Deploy().
-func (p *openstackp) deploy(resources *Resources, requiredPorts []int, useConfigDrive bool, gatewayIP, cidr string, dnsNameServers []string) error {
+func (p *openstackp) deploy(resources *Resources, requiredPorts []int, useConfigDrive bool, gatewayIP, cidr string, dnsNameServers []string, tags map[string]string) error {
 	// the resource name can only contain letters, numbers, underscores,
 	// spaces and hyphens
 	if !openstackValidResourceNameRegexp.MatchString(resources.ResourceName) {
@@ -351,6 +382,7 @@ func (p *openstackp) deploy(resources *Resources, requiredPorts []int, useConfig
 	}
 
 	p.useConfigDrive = useConfigDrive
+	p.tags = tags
 
 	// get/create key pair
 	kp, err := keypairs.Get(p.computeClient, resources.ResourceName).Extract()
@@ -440,6 +472,20 @@ func (p *openstackp) deploy(resources *Resources, requiredPorts []int, useConfig
 				if err != nil {
 					return err
 				}
+
+				// also allow access over IPv6, for clouds where servers get
+				// v6 addresses; not all clouds support this, so failure isn't
+				// fatal
+				_, errr := secgroups.CreateRule(p.computeClient, secgroups.CreateRuleOpts{
+					ParentGroupID: group.ID,
+					FromPort:      port,
+					ToPort:        port,
+					IPProtocol:    "TCP",
+					CIDR:          "::/0",
+				}).Extract()
+				if errr != nil {
+					p.Warn("could not create IPv6 security group rule", "port", port, "err", errr)
+				}
 			}
 
 			// ICMP may help networking work as expected
@@ -503,6 +549,7 @@ func (p *openstackp) deploy(resources *Resources, requiredPorts []int, useConfig
 			if err != nil {
 				return err
 			}
+			p.tagNetworkResource("networks", network.ID)
 			networkID = network.ID
 		} else {
 			return err
@@ -539,6 +586,7 @@ func (p *openstackp) deploy(resources *Resources, requiredPorts []int, useConfig
 			return err
 		}
 		subnetID = subnet.ID
+		p.tagNetworkResource("subnets", subnetID)
 	}
 	resources.Details["subnet"] = subnetID
 
@@ -577,6 +625,7 @@ func (p *openstackp) deploy(resources *Resources, requiredPorts []int, useConfig
 		}
 
 		routerID = router.ID
+		p.tagNetworkResource("routers", routerID)
 
 		// add our subnet
 		_, err = routers.AddInterface(p.networkClient, routerID, routers.AddInterfaceOpts{SubnetID: subnetID}).Extract()
@@ -620,6 +669,324 @@ func (p *openstackp) getCurrentServers(resources *Resources) ([][]string, error)
 	return sdetails, err
 }
 
+// discover achieves the aims of Discover()
+func (p *openstackp) discover(resources *Resources) ([]*CreatedResource, error) {
+	var found []*CreatedResource
+	var merr *multierror.Error
+
+	// servers, except for ourselves, and their volumes and floating IPs
+	serverIDs := make(map[string]bool)
+	foundIDs := make(map[string]bool)
+	pager := servers.List(p.computeClient, servers.ListOpts{})
+	err := pager.EachPage(func(page pagination.Page) (bool, error) {
+		serverList, errf := servers.ExtractServers(page)
+		if errf != nil {
+			return false, errf
+		}
+
+		for _, server := range serverList {
+			if p.ownName != server.Name && ourServer(server, resources.ResourceName) {
+				serverIDs[server.ID] = true
+				found = append(found, &CreatedResource{Kind: "server", ID: server.ID, Name: server.Name, Tags: ourTags(server.Metadata)})
+				for _, volume := range server.AttachedVolumes {
+					foundIDs[volume.ID] = true
+					found = append(found, &CreatedResource{Kind: "volume", ID: volume.ID, Name: "attached to " + server.Name})
+				}
+			}
+		}
+
+		return true, nil
+	})
+	merr = p.combineError(merr, err)
+
+	fipPages, err := floatingips.List(p.computeClient).AllPages()
+	if err == nil {
+		var fips []floatingips.FloatingIP
+		fips, err = floatingips.ExtractFloatingIPs(fipPages)
+		for _, fip := range fips {
+			if serverIDs[fip.InstanceID] {
+				foundIDs[fip.ID] = true
+				found = append(found, &CreatedResource{Kind: "floating ip", ID: fip.ID, Name: fip.IP})
+			}
+		}
+	}
+	merr = p.combineError(merr, err)
+
+	// volumes and floating IPs we created that are no longer attached to our
+	// servers
+	volumeList, err := p.ourDetachedVolumes(resources.ResourceName)
+	for _, volume := range volumeList {
+		if !foundIDs[volume.ID] {
+			found = append(found, &CreatedResource{Kind: "volume", ID: volume.ID, Name: volume.Name, Tags: ourTags(volume.Metadata)})
+		}
+	}
+	merr = p.combineError(merr, err)
+
+	fipList, err := p.ourUnassociatedFloatingIPs(resources.ResourceName)
+	for _, fip := range fipList {
+		if !foundIDs[fip.ID] {
+			found = append(found, &CreatedResource{Kind: "floating ip", ID: fip.ID, Name: fip.FloatingIP, Tags: ourNetworkTags(fip.Tags)})
+		}
+	}
+	merr = p.combineError(merr, err)
+
+	if p.ownName == "" {
+		// router, network and subnet, all named after the resource name
+		err = routers.List(p.networkClient, routers.ListOpts{Name: resources.ResourceName}).EachPage(func(page pagination.Page) (bool, error) {
+			routerList, errf := routers.ExtractRouters(page)
+			if errf != nil {
+				return false, errf
+			}
+			for _, router := range routerList {
+				found = append(found, &CreatedResource{Kind: "router", ID: router.ID, Name: router.Name, Tags: ourNetworkTags(router.Tags)})
+				if resources.Details["router"] == "" {
+					resources.Details["router"] = router.ID
+				}
+			}
+			return true, nil
+		})
+		merr = p.combineError(merr, err)
+
+		err = subnets.List(p.networkClient, subnets.ListOpts{Name: resources.ResourceName}).EachPage(func(page pagination.Page) (bool, error) {
+			subnetList, errf := subnets.ExtractSubnets(page)
+			if errf != nil {
+				return false, errf
+			}
+			for _, subnet := range subnetList {
+				found = append(found, &CreatedResource{Kind: "subnet", ID: subnet.ID, Name: subnet.Name, Tags: ourNetworkTags(subnet.Tags)})
+				if resources.Details["subnet"] == "" {
+					resources.Details["subnet"] = subnet.ID
+				}
+			}
+			return true, nil
+		})
+		merr = p.combineError(merr, err)
+
+		networkID, errn := networks.IDFromName(p.networkClient, resources.ResourceName)
+		if errn == nil {
+			found = append(found, &CreatedResource{Kind: "network", ID: networkID, Name: resources.ResourceName})
+			if resources.Details["network"] == "" {
+				resources.Details["network"] = networkID
+			}
+		} else if _, notfound := errn.(gophercloud.ErrResourceNotFound); !notfound {
+			merr = p.combineError(merr, errn)
+		}
+
+		// security group
+		err = secgroups.List(p.computeClient).EachPage(func(page pagination.Page) (bool, error) {
+			groupList, errf := secgroups.ExtractSecurityGroups(page)
+			if errf != nil {
+				return false, errf
+			}
+			for _, g := range groupList {
+				if g.Name == resources.ResourceName {
+					found = append(found, &CreatedResource{Kind: "security group", ID: g.ID, Name: g.Name})
+					if resources.Details["secgroup"] == "" {
+						resources.Details["secgroup"] = g.ID
+					}
+				}
+			}
+			return true, nil
+		})
+		merr = p.combineError(merr, err)
+	}
+
+	// keypair
+	kp, err := keypairs.Get(p.computeClient, resources.ResourceName).Extract()
+	if err == nil {
+		found = append(found, &CreatedResource{Kind: "keypair", ID: kp.Name, Name: kp.Name})
+		if resources.Details["keypair"] == "" {
+			resources.Details["keypair"] = kp.Name
+		}
+	} else if _, notfound := err.(gophercloud.ErrDefault404); !notfound {
+		merr = p.combineError(merr, err)
+	}
+
+	return found, merr.ErrorOrNil()
+}
+
+// ourServer returns true if the given server was spawned by the deployment
+// with the given resourceName: it has that deployment's TagDeployment
+// metadata, or it has no TagDeployment (it was spawned before we tagged
+// servers) and is named exactly as uniqueResourceName(resourceName) would name
+// it. Servers of other deployments whose names merely start with resourceName,
+// such as those that build images, don't count.
+func ourServer(server servers.Server, resourceName string) bool {
+	if deployment, tagged := server.Metadata[TagDeployment]; tagged {
+		return deployment == resourceName
+	}
+	if !strings.HasPrefix(server.Name, resourceName+"-") {
+		return false
+	}
+	_, err := uuid.FromString(strings.TrimPrefix(server.Name, resourceName+"-"))
+	return err == nil
+}
+
+// ourDetachedVolumes returns the volumes that have the TagDeployment metadata
+// of the given resourceName, and aren't attached to any server. Returns
+// nothing if there is no block storage service.
+func (p *openstackp) ourDetachedVolumes(resourceName string) ([]volumes.Volume, error) {
+	if p.volumeClient == nil {
+		return nil, nil
+	}
+	var ours []volumes.Volume
+	opts := volumes.ListOpts{Metadata: map[string]string{TagDeployment: resourceName}}
+	err := volumes.List(p.volumeClient, opts).EachPage(func(page pagination.Page) (bool, error) {
+		volumeList, errf := volumes.ExtractVolumes(page)
+		if errf != nil {
+			return false, errf
+		}
+		for _, volume := range volumeList {
+			// (not all installs filter on metadata, so check it ourselves)
+			if volume.Metadata[TagDeployment] == resourceName && len(volume.Attachments) == 0 {
+				ours = append(ours, volume)
+			}
+		}
+		return true, nil
+	})
+	return ours, err
+}
+
+// ourUnassociatedFloatingIPs returns the floating IPs that have the
+// TagDeployment tag of the given resourceName, and aren't associated with any
+// server.
+func (p *openstackp) ourUnassociatedFloatingIPs(resourceName string) ([]networkfips.FloatingIP, error) {
+	var ours []networkfips.FloatingIP
+	opts := networkfips.ListOpts{Tags: TagDeployment + "=" + resourceName}
+	err := networkfips.List(p.networkClient, opts).EachPage(func(page pagination.Page) (bool, error) {
+		fipList, errf := networkfips.ExtractFloatingIPs(page)
+		if errf != nil {
+			return false, errf
+		}
+		for _, fip := range fipList {
+			// (not all installs filter on tags, so check it ourselves)
+			if ourNetworkTags(fip.Tags)[TagDeployment] == resourceName && fip.PortID == "" {
+				ours = append(ours, fip)
+			}
+		}
+		return true, nil
+	})
+	return ours, err
+}
+
+// createdTags returns the tags we were given during deploy(), along with
+// TagCreated set to now.
+func (p *openstackp) createdTags() map[string]string {
+	tags := make(map[string]string, len(p.tags)+1)
+	for key, val := range p.tags {
+		tags[key] = val
+	}
+	tags[TagCreated] = time.Now().UTC().Format(time.RFC3339)
+	return tags
+}
+
+// tagNetworkResource attaches our createdTags() as key=value tags to the
+// given networking resource of the given type (eg. "networks"). Not all
+// installs support tags, so failure is only logged.
+func (p *openstackp) tagNetworkResource(resourceType, id string) {
+	var tags []string
+	for key, val := range p.createdTags() {
+		tags = append(tags, key+"="+val)
+	}
+	sort.Strings(tags)
+	_, err := attributestags.ReplaceAll(p.networkClient, resourceType, id, attributestags.ReplaceAllOpts{Tags: tags}).Extract()
+	if err != nil {
+		p.Warn("failed to tag resource", "type", resourceType, "id", id, "err", err)
+	}
+}
+
+// ourTags returns the Tag* entries of the given server metadata, or nil if
+// there aren't any.
+func ourTags(metadata map[string]string) map[string]string {
+	var tags map[string]string
+	for _, key := range []string{TagDeployment, TagManagerHost, TagCreated, TagVersion} {
+		if val, exists := metadata[key]; exists {
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[key] = val
+		}
+	}
+	return tags
+}
+
+// ourNetworkTags is like ourTags(), but for the key=value tags of networking
+// resources.
+func ourNetworkTags(tagList []string) map[string]string {
+	metadata := make(map[string]string)
+	for _, tag := range tagList {
+		parts := strings.SplitN(tag, "=", 2)
+		if len(parts) == 2 {
+			metadata[parts[0]] = parts[1]
+		}
+	}
+	return ourTags(metadata)
+}
+
+// taggedResources achieves the aims of TaggedResources()
+func (p *openstackp) taggedResources() ([]*CreatedResource, error) {
+	var found []*CreatedResource
+	var merr *multierror.Error
+
+	err := servers.List(p.computeClient, servers.ListOpts{}).EachPage(func(page pagination.Page) (bool, error) {
+		serverList, errf := servers.ExtractServers(page)
+		if errf != nil {
+			return false, errf
+		}
+		for _, server := range serverList {
+			if tags := ourTags(server.Metadata); tags[TagDeployment] != "" {
+				found = append(found, &CreatedResource{Kind: "server", ID: server.ID, Name: server.Name, Tags: tags})
+			}
+		}
+		return true, nil
+	})
+	merr = p.combineError(merr, err)
+
+	err = networks.List(p.networkClient, networks.ListOpts{}).EachPage(func(page pagination.Page) (bool, error) {
+		networkList, errf := networks.ExtractNetworks(page)
+		if errf != nil {
+			return false, errf
+		}
+		for _, network := range networkList {
+			if tags := ourNetworkTags(network.Tags); tags[TagDeployment] != "" {
+				found = append(found, &CreatedResource{Kind: "network", ID: network.ID, Name: network.Name, Tags: tags})
+			}
+		}
+		return true, nil
+	})
+	merr = p.combineError(merr, err)
+
+	err = subnets.List(p.networkClient, subnets.ListOpts{}).EachPage(func(page pagination.Page) (bool, error) {
+		subnetList, errf := subnets.ExtractSubnets(page)
+		if errf != nil {
+			return false, errf
+		}
+		for _, subnet := range subnetList {
+			if tags := ourNetworkTags(subnet.Tags); tags[TagDeployment] != "" {
+				found = append(found, &CreatedResource{Kind: "subnet", ID: subnet.ID, Name: subnet.Name, Tags: tags})
+			}
+		}
+		return true, nil
+	})
+	merr = p.combineError(merr, err)
+
+	err = routers.List(p.networkClient, routers.ListOpts{}).EachPage(func(page pagination.Page) (bool, error) {
+		routerList, errf := routers.ExtractRouters(page)
+		if errf != nil {
+			return false, errf
+		}
+		for _, router := range routerList {
+			if tags := ourNetworkTags(router.Tags); tags[TagDeployment] != "" {
+				found = append(found, &CreatedResource{Kind: "router", ID: router.ID, Name: router.Name, Tags: tags})
+			}
+		}
+		return true, nil
+	})
+	merr = p.combineError(merr, err)
+
+	return found, merr.ErrorOrNil()
+}
+
 // inCloud checks if we're currently running on an OpenStack server based on our
 // hostname matching a host in OpenStack.
 func (p *openstackp) inCloud() bool {
@@ -728,7 +1095,7 @@ func (p *openstackp) getQuota() (*Quota, error) {
 }
 
 // spawn achieves the aims of Spawn()
-func (p *openstackp) spawn(resources *Resources, osPrefix string, flavorID string, diskGB int, externalIP bool, usingQuotaCh chan bool) (serverID, serverIP, serverName, adminPass string, err error) {
+func (p *openstackp) spawn(resources *Resources, osPrefix string, flavorID string, diskGB int, externalIP bool, securityGroups []string, metadata map[string]string, usingQuotaCh chan bool) (serverID, serverIP, serverName, adminPass string, err error) {
 	// get the image that matches desired OS
 	image, err := p.getImage(osPrefix)
 	if err != nil {
@@ -756,7 +1123,7 @@ func (p *openstackp) spawn(resources *Resources, osPrefix string, flavorID strin
 	}
 
 	// we'll use the security group we created, and the "default" one if it
-	// exists
+	// exists, along with any others requested
 	var secGroups []string
 	if p.securityGroup != "" {
 		secGroups = append(secGroups, p.securityGroup)
@@ -764,6 +1131,16 @@ func (p *openstackp) spawn(resources *Resources, osPrefix string, flavorID strin
 			secGroups = append(secGroups, "default")
 		}
 	}
+	secGroups = append(secGroups, securityGroups...)
+
+	// our own tags take precedence over any user supplied metadata
+	serverMetadata := make(map[string]string, len(metadata)+len(p.tags)+1)
+	for key, val := range metadata {
+		serverMetadata[key] = val
+	}
+	for key, val := range p.createdTags() {
+		serverMetadata[key] = val
+	}
 
 	// create the server with a unique name
 	var server *servers.Server
@@ -776,6 +1153,7 @@ func (p *openstackp) spawn(resources *Resources, osPrefix string, flavorID strin
 		Networks:       []servers.Network{{UUID: p.networkUUID}},
 		ConfigDrive:    &p.useConfigDrive,
 		UserData:       sentinelInitScript,
+		Metadata:       serverMetadata,
 	}
 	var createdVolume bool
 	if diskGB > flavor.Disk {
@@ -912,6 +1290,13 @@ func (p *openstackp) spawn(resources *Resources, osPrefix string, flavorID strin
 		// give it a floating ip
 		floatingIP, errf := p.getAvailableFloatingIP()
 		if errf != nil {
+			// floating IPs are IPv4 and can be scarce, but a globally routable
+			// IPv6 address is just as good
+			if v6IP, errg := p.getServerGlobalIPv6(serverID); errg == nil && v6IP != "" {
+				p.Warn("no floating IP available, using IPv6 address instead", "server", serverID, "ip", v6IP, "err", errf)
+				return serverID, v6IP, serverName, adminPass, nil
+			}
+
 			errd := p.destroyServer(serverID)
 			if errd != nil {
 				p.Warn("server destruction after no IP failed", "server", serverID, "err", errd)
@@ -955,7 +1340,9 @@ func (p *openstackp) errIsNoHardware(err error) bool {
 }
 
 // getServerIP tries to find the auto-assigned internal ip address of the server
-// with the given ID.
+// with the given ID. An address in our CIDR is preferred (which may be IPv4 or
+// IPv6, depending on the CIDR), falling back on a global IPv6 address for
+// servers on v6-only networks.
 func (p *openstackp) getServerIP(serverID string) (string, error) {
 	// *** there must be a better way of doing this...
 	allNetworkAddressPages, err := servers.ListAddressesByNetwork(p.computeClient, serverID, p.networkName).AllPages()
@@ -966,13 +1353,42 @@ func (p *openstackp) getServerIP(serverID string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	var v6IP string
 	for _, address := range allNetworkAddresses {
-		if address.Version == 4 {
+		ip := net.ParseIP(address.Address)
+		if ip == nil {
+			continue
+		}
+		if p.ipNet.Contains(ip) {
+			return address.Address, nil
+		}
+		if v6IP == "" && address.Version == 6 && ip.IsGlobalUnicast() {
+			v6IP = address.Address
+		}
+	}
+	return v6IP, nil
+}
+
+// getServerGlobalIPv6 returns a globally routable IPv6 address of the server
+// with the given ID, on any of its networks. Returns an empty string if it has
+// none.
+func (p *openstackp) getServerGlobalIPv6(serverID string) (string, error) {
+	allAddressPages, err := servers.ListAddresses(p.computeClient, serverID).AllPages()
+	if err != nil {
+		return "", err
+	}
+	allAddresses, err := servers.ExtractAddresses(allAddressPages)
+	if err != nil {
+		return "", err
+	}
+	for _, addresses := range allAddresses {
+		for _, address := range addresses {
+			if address.Version != 6 {
+				continue
+			}
 			ip := net.ParseIP(address.Address)
-			if ip != nil {
-				if p.ipNet.Contains(ip) {
-					return address.Address, nil
-				}
+			if ip != nil && ip.IsGlobalUnicast() && !uniqueLocalIPv6.Contains(ip) {
+				return address.Address, nil
 			}
 		}
 	}
@@ -1010,6 +1426,106 @@ func (p *openstackp) destroyServer(serverID string) error {
 	return err
 }
 
+// snapshot achieves the aims of Snapshot()
+func (p *openstackp) snapshot(serverID, name string, timeout time.Duration) (string, error) {
+	imageID, err := servers.CreateImage(p.computeClient, serverID, servers.CreateImageOpts{Name: name}).ExtractImageID()
+	if err != nil {
+		return "", err
+	}
+
+	// images can take a long time to upload, so we poll infrequently until it
+	// is usable
+	limit := time.After(timeout)
+	ticker := time.NewTicker(snapshotPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			image, errg := images.Get(p.computeClient, imageID).Extract()
+			if errg != nil {
+				return imageID, errg
+			}
+			switch image.Status {
+			case "ACTIVE":
+				p.imapMutex.Lock()
+				p.imap[image.ID] = image
+				p.imap[image.Name] = image
+				p.imapMutex.Unlock()
+				return imageID, nil
+			case "ERROR", "DELETED":
+				return imageID, fmt.Errorf("snapshot image %s ended up with status %s", imageID, image.Status)
+			}
+		case <-limit:
+			return imageID, fmt.Errorf("snapshot image %s did not become active within %s", imageID, timeout)
+		}
+	}
+}
+
+// attachVolume achieves the aims of Server.AttachVolume()
+func (p *openstackp) attachVolume(serverID, name string, sizeGB int) (string, string, error) {
+	if p.volumeClient == nil {
+		return "", "", errors.New("no block storage service is available")
+	}
+
+	volume, err := volumes.Create(p.volumeClient, volumes.CreateOpts{
+		Size:     sizeGB,
+		Name:     name,
+		Metadata: p.createdTags(),
+	}).Extract()
+	if err != nil {
+		return "", "", err
+	}
+
+	err = volumes.WaitForStatus(p.volumeClient, volume.ID, "available", volumeStatusTimeoutSecs)
+	if err != nil {
+		p.deleteVolume(volume.ID)
+		return "", "", err
+	}
+
+	attachment, err := volumeattach.Create(p.computeClient, serverID, volumeattach.CreateOpts{VolumeID: volume.ID}).Extract()
+	if err != nil {
+		p.deleteVolume(volume.ID)
+		return "", "", err
+	}
+
+	err = volumes.WaitForStatus(p.volumeClient, volume.ID, "in-use", volumeStatusTimeoutSecs)
+	if err != nil {
+		errd := p.detachVolume(serverID, volume.ID)
+		if errd != nil {
+			p.Warn("failed to detach volume after failed attachment", "volume", volume.ID, "err", errd)
+		}
+		return "", "", err
+	}
+	return volume.ID, attachment.Device, nil
+}
+
+// detachVolume achieves the aims of Server.DetachVolume()
+func (p *openstackp) detachVolume(serverID, volumeID string) error {
+	if p.volumeClient == nil {
+		return errors.New("no block storage service is available")
+	}
+
+	// the attachment ID is the volume ID
+	err := volumeattach.Delete(p.computeClient, serverID, volumeID).ExtractErr()
+	if err != nil {
+		return err
+	}
+
+	err = volumes.WaitForStatus(p.volumeClient, volumeID, "available", volumeStatusTimeoutSecs)
+	if err != nil {
+		return err
+	}
+	return volumes.Delete(p.volumeClient, volumeID, volumes.DeleteOpts{}).ExtractErr()
+}
+
+// deleteVolume deletes an unattached volume, only logging any failure.
+func (p *openstackp) deleteVolume(volumeID string) {
+	err := volumes.Delete(p.volumeClient, volumeID, volumes.DeleteOpts{}).ExtractErr()
+	if err != nil {
+		p.Warn("failed to delete volume", "volume", volumeID, "err", err)
+	}
+}
+
 // tearDown achieves the aims of TearDown()
 func (p *openstackp) tearDown(resources *Resources) error {
 	// throughout we'll ignore errors because we want to try and delete
@@ -1017,17 +1533,39 @@ func (p *openstackp) tearDown(resources *Resources) error {
 	// them though
 	var merr *multierror.Error
 
+	// note the floating IPs of our servers, so we can release them once the
+	// servers are gone
+	ourFIPs := make(map[string]string)
+	fipPages, err := floatingips.List(p.computeClient).AllPages()
+	if err == nil {
+		var fips []floatingips.FloatingIP
+		fips, err = floatingips.ExtractFloatingIPs(fipPages)
+		for _, fip := range fips {
+			if fip.InstanceID != "" {
+				ourFIPs[fip.InstanceID] = fip.ID
+			}
+		}
+	}
+	merr = p.combineError(merr, err)
+	var fipIDs, volumeIDs []string
+
 	// delete servers, except for ourselves
 	t := time.Now()
 	pager := servers.List(p.computeClient, servers.ListOpts{})
-	err := pager.EachPage(func(page pagination.Page) (bool, error) {
+	err = pager.EachPage(func(page pagination.Page) (bool, error) {
 		serverList, err := servers.ExtractServers(page)
 		if err != nil {
 			return false, err
 		}
 
 		for _, server := range serverList {
-			if p.ownName != server.Name && strings.HasPrefix(server.Name, resources.ResourceName) {
+			if p.ownName != server.Name && ourServer(server, resources.ResourceName) {
+				if id, found := ourFIPs[server.ID]; found {
+					fipIDs = append(fipIDs, id)
+				}
+				for _, volume := range server.AttachedVolumes {
+					volumeIDs = append(volumeIDs, volume.ID)
+				}
 				t = time.Now()
 				errd := p.destroyServer(server.ID)
 				p.Debug("delete server", "time", time.Since(t), "id", server.ID)
@@ -1042,6 +1580,46 @@ func (p *openstackp) tearDown(resources *Resources) error {
 	})
 	merr = p.combineError(merr, err)
 
+	// also release floating IPs we created that are no longer associated with
+	// our servers
+	fipList, err := p.ourUnassociatedFloatingIPs(resources.ResourceName)
+	for _, fip := range fipList {
+		fipIDs = append(fipIDs, fip.ID)
+	}
+	merr = p.combineError(merr, err)
+
+	// release the floating IPs our servers had
+	for _, id := range fipIDs {
+		t = time.Now()
+		err = floatingips.Delete(p.computeClient, id).ExtractErr()
+		p.Debug("delete floating ip", "time", time.Since(t), "id", id, "err", err)
+		merr = p.combineError(merr, err)
+	}
+
+	// volumes are normally deleted along with their servers, but make sure,
+	// along with any that were detached from our servers
+	volumeList, err := p.ourDetachedVolumes(resources.ResourceName)
+	for _, volume := range volumeList {
+		volumeIDs = append(volumeIDs, volume.ID)
+	}
+	merr = p.combineError(merr, err)
+	if p.volumeClient != nil {
+		for _, id := range volumeIDs {
+			volume, errg := volumes.Get(p.volumeClient, id).Extract()
+			if errg != nil {
+				merr = p.combineError(merr, errg)
+				continue
+			}
+			if volume.Status == "deleting" {
+				continue
+			}
+			t = time.Now()
+			err = volumes.Delete(p.volumeClient, id, volumes.DeleteOpts{}).ExtractErr()
+			p.Debug("delete volume", "time", time.Since(t), "id", id, "err", err)
+			merr = p.combineError(merr, err)
+		}
+	}
+
 	if p.ownName == "" {
 		// delete router
 		if id := resources.Details["router"]; id != "" {
@@ -1144,7 +1722,10 @@ func (p *openstackp) getAvailableFloatingIP() (string, error) {
 			return "", err
 		}
 		floatingIP = fIP.IP
-		// *** should we delete these during TearDown? fIP.Delete(p.computeClient, fIP.ID) ...
+
+		// tag it so that TearDown() can release it even if it's no longer
+		// associated with one of our servers
+		p.tagNetworkResource("floatingips", fIP.ID)
 	}
 
 	return floatingIP, nil
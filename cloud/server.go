@@ -28,9 +28,11 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -110,6 +112,8 @@ type Server struct {
 	Script            []byte // the content of a start-up script run on the server
 	sshClients        []*ssh.Client
 	sshClientSessions []int
+	SecurityGroups    []string
+	Metadata          map[string]string
 	AdminPass         string
 	ID                string
 	IP                string // ip address that you could SSH to
@@ -210,57 +214,69 @@ SENTINEL:
 
 	// run the postCreationScript
 	if len(postCreationScript) > 0 {
-		pcsPath := "/tmp/.postCreationScript"
-		err = s.CreateFile(ctx, string(postCreationScript), pcsPath)
+		err = s.RunScript(ctx, postCreationScript)
 		if err != nil {
-			return fmt.Errorf("cloud server start up script failed to upload: %s", err)
+			return err
 		}
+		s.Script = postCreationScript
+	}
 
-		_, _, err = s.RunCmd(ctx, "chmod u+x "+pcsPath, false)
-		if err != nil {
-			return fmt.Errorf("cloud server start up script could not be made executable: %s", err)
-		}
+	return nil
+}
 
-		// protect running the script with a timeout
-		limit := time.After(pcsTimeOut)
-		exiterr := make(chan error, 1)
-		var stderr string
-		go func() {
-			var runerr error
-			_, stderr, runerr = s.RunCmd(ctx, pcsPath, false)
-			exiterr <- runerr
-		}()
-		select {
-		case err = <-exiterr:
-			if err != nil {
-				err = fmt.Errorf("cloud server start up script failed: %s", err.Error())
-				if len(stderr) > 0 {
-					err = fmt.Errorf("%s\nSTDERR:\n%s", err.Error(), stderr)
-				}
-				return err
-			}
-		case <-limit:
-			return fmt.Errorf("cloud server start up script failed to complete within %s", pcsTimeOut)
-		}
+// RunScript runs the given []byte content of a script on the server (as the
+// user supplied to Spawn()) in the same way as WaitUntilReady() runs its
+// postCreationScript, with the same time limit, but without recording it in
+// Script. Call it after WaitUntilReady().
+func (s *Server) RunScript(ctx context.Context, script []byte) error {
+	pcsPath := "/tmp/.postCreationScript"
+	err := s.CreateFile(ctx, string(script), pcsPath)
+	if err != nil {
+		return fmt.Errorf("cloud server start up script failed to upload: %s", err)
+	}
 
-		_, _, rmErr := s.RunCmd(ctx, "rm "+pcsPath, false)
-		if rmErr != nil {
-			s.logger.Warn("failed to remove post creation script", "path", pcsPath, "err", rmErr)
+	_, _, err = s.RunCmd(ctx, "chmod u+x "+pcsPath, false)
+	if err != nil {
+		return fmt.Errorf("cloud server start up script could not be made executable: %s", err)
+	}
+
+	// protect running the script with a timeout
+	limit := time.After(pcsTimeOut)
+	exiterr := make(chan error, 1)
+	var stderr string
+	go func() {
+		var runerr error
+		_, stderr, runerr = s.RunCmd(ctx, pcsPath, false)
+		exiterr <- runerr
+	}()
+	select {
+	case err = <-exiterr:
+		if err != nil {
+			err = fmt.Errorf("cloud server start up script failed: %s", err.Error())
+			if len(stderr) > 0 {
+				err = fmt.Errorf("%s\nSTDERR:\n%s", err.Error(), stderr)
+			}
+			return err
 		}
+	case <-limit:
+		return fmt.Errorf("cloud server start up script failed to complete within %s", pcsTimeOut)
+	}
 
-		s.Script = postCreationScript
+	_, _, rmErr := s.RunCmd(ctx, "rm "+pcsPath, false)
+	if rmErr != nil {
+		s.logger.Warn("failed to remove post creation script", "path", pcsPath, "err", rmErr)
+	}
 
-		// because the postCreationScript may have altered PATH and other things
-		// that subsequent RunCmd may rely on, clear the clients
-		for _, client := range s.sshClients {
-			err = client.Close()
-			if err != nil {
-				s.logger.Warn("failed to close client ssh connection", "err", err)
-			}
+	// because the script may have altered PATH and other things that
+	// subsequent RunCmd may rely on, clear the clients
+	for _, client := range s.sshClients {
+		err = client.Close()
+		if err != nil {
+			s.logger.Warn("failed to close client ssh connection", "err", err)
 		}
-		s.sshClients = []*ssh.Client{}
-		s.sshClientSessions = []int{}
 	}
+	s.sshClients = []*ssh.Client{}
+	s.sshClientSessions = []int{}
 
 	return nil
 }
@@ -273,6 +289,39 @@ func (s *Server) Matches(os string, script []byte, configFiles string, flavor *F
 	return s.OS == os && bytes.Equal(s.Script, script) && s.ConfigFiles == configFiles && (flavor == nil || flavor.ID == s.Flavor.ID) && s.SharedDisk == sharedDisk
 }
 
+// HasSecurityGroups tells you if a Server was spawned in exactly the given
+// extra security groups (in any order), as supplied to SpawnInSecurityGroups()
+// and recorded, sorted, in SecurityGroups. Like Matches(), useful before
+// calling HasSpaceFor.
+func (s *Server) HasSecurityGroups(groups []string) bool {
+	if len(groups) != len(s.SecurityGroups) {
+		return false
+	}
+	sorted := append([]string{}, groups...)
+	sort.Strings(sorted)
+	for i, group := range sorted {
+		if s.SecurityGroups[i] != group {
+			return false
+		}
+	}
+	return true
+}
+
+// HasMetadata tells you if a Server was spawned with exactly the given
+// metadata, as supplied to SpawnWithMetadata() and recorded in Metadata. Like
+// HasSecurityGroups(), useful before calling HasSpaceFor.
+func (s *Server) HasMetadata(metadata map[string]string) bool {
+	if len(metadata) != len(s.Metadata) {
+		return false
+	}
+	for key, val := range metadata {
+		if existing, exists := s.Metadata[key]; !exists || existing != val {
+			return false
+		}
+	}
+	return true
+}
+
 // Allocate considers the current usage (according to prior calls)
 // and records the given resources have now been used up on this server, if
 // there was enough space. Returns true if there was enough space and the
@@ -493,7 +542,7 @@ func (s *Server) SSHClient(ctx context.Context) (*ssh.Client, int, error) {
 	// dial in to the server, allowing certain errors that indicate that the
 	// network or server isn't really ready for ssh yet; wait for up to
 	// 5mins for success, if we had only just created this server
-	hostAndPort := s.IP + ":22"
+	hostAndPort := net.JoinHostPort(s.IP, "22")
 	client, err := sshDial(ctx, hostAndPort, s.sshClientConfig, s.logger)
 	if err != nil {
 		limit := time.After(sshTimeOut)
@@ -1015,6 +1064,49 @@ func (s *Server) MkDir(ctx context.Context, dir string) error {
 	return nil
 }
 
+// AttachVolume creates a new block storage volume of the given size in GB,
+// attaches it to this server, formats it and mounts it at mountPath (which
+// will be owned by our UserName). The returned volume ID should be supplied to
+// DetachVolume() when you no longer need the volume. Requires sudo.
+func (s *Server) AttachVolume(ctx context.Context, sizeGB int, mountPath string) (string, error) {
+	volumeID, device, err := s.provider.impl.attachVolume(s.ID, s.Name+"-volume", sizeGB)
+	if err != nil {
+		return "", err
+	}
+
+	// the device the provider says it attached as is not reliable, so we
+	// prefer to find the volume by id, only falling back to that device if it
+	// doesn't already have a filesystem on it
+	byID := volumeID
+	if len(byID) > 20 {
+		byID = byID[:20]
+	}
+	cmd := fmt.Sprintf("dev=/dev/disk/by-id/virtio-%s; "+
+		"for i in $(seq 60); do [ -e $dev ] && break; sleep 1; done; "+
+		"if [ ! -e $dev ]; then dev=%s; sudo blkid $dev > /dev/null && exit 1; fi; "+
+		"sudo mkfs.ext4 -q $dev && sudo mkdir -p %s && sudo mount $dev %s && sudo chown %s:%s %s",
+		byID, device, mountPath, mountPath, s.UserName, s.UserName, mountPath)
+	_, e, err := s.RunCmd(ctx, cmd, false)
+	if err != nil {
+		errd := s.provider.impl.detachVolume(s.ID, volumeID)
+		if errd != nil {
+			s.logger.Warn("failed to remove volume after failing to mount it", "volume", volumeID, "err", errd)
+		}
+		return "", fmt.Errorf("failed to mount volume %s: %s; %s", volumeID, e, err.Error())
+	}
+	return volumeID, nil
+}
+
+// DetachVolume unmounts the volume at mountPath and then detaches and deletes
+// the given volume, which was created by AttachVolume(). Requires sudo.
+func (s *Server) DetachVolume(ctx context.Context, volumeID, mountPath string) error {
+	_, e, err := s.RunCmd(ctx, "sudo umount "+mountPath, false)
+	if err != nil {
+		s.logger.Warn("failed to unmount volume", "volume", volumeID, "path", mountPath, "err", e)
+	}
+	return s.provider.impl.detachVolume(s.ID, volumeID)
+}
+
 // CreateSharedDisk creates an NFS share at /shared, which must be empty or not
 // exist. This does not work for remote Servers, so only call this on the return
 // value of LocalhostServer(). Does nothing and returns nil if the share was
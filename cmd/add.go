@@ -20,10 +20,14 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -31,6 +35,7 @@ import (
 	"github.com/VertebrateResequencing/wr/internal"
 	"github.com/VertebrateResequencing/wr/jobqueue"
 	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
 )
 
 // maxScanTokenSize defines the size of bufio scan's buffer, enabling us to
@@ -38,6 +43,10 @@ import (
 // shells such as bash.
 const maxScanTokenSize = 4096 * 1024
 
+// frontMatterDelimiter is the line that starts and ends the front matter of a
+// file in a --dir.
+const frontMatterDelimiter = "---"
+
 // options for this cmd
 var reqGroup string
 var cmdTime string
@@ -47,18 +56,44 @@ var cmdDisk int
 var cmdOvr int
 var cmdPri int
 var cmdRet int
+var cmdRetryBudgets string
 var cmdFile string
 var cmdCwdMatters bool
 var cmdChangeHome bool
+var cmdCwdTemplate string
+var cmdCwdBase string
+var cmdCwdLink bool
 var cmdRepGroup string
 var cmdLimitGroups string
 var cmdDepGroups string
 var cmdCmdDeps string
 var cmdGroupDeps string
+var cmdRepGroupDeps string
+var cmdAtomicGroup string
+var cmdScatterInputs string
+var cmdGatherCmd string
+var cmdChunkDir string
 var cmdOnFailure string
 var cmdOnSuccess string
 var cmdOnExit string
 var cmdEnv string
+var cmdNoCaptureEnv bool
+var cmdEnvWhitelist string
+var cmdSecrets string
+var cmdInputFiles string
+var cmdRunnerInputCheck bool
+var cmdOutputFiles string
+var cmdOutputMinSize int
+var cmdOutputCheckCmd string
+var cmdOutputChecksums bool
+var cmdCacheable bool
+var cmdIRODSInputs string
+var cmdIRODSCollection string
+var cmdIRODSMeta string
+var cmdNetworkAccess string
+var cmdProxy string
+var cmdTraceID string
+var cmdRefAssets string
 var cmdReRun bool
 var cmdOsPrefix string
 var cmdOsUsername string
@@ -67,12 +102,30 @@ var cmdBsubMode bool
 var cmdPostCreationScript string
 var cmdCloudConfigs string
 var cmdCloudSharedDisk bool
+var cmdCloudVolume int
 var cmdFlavor string
 var cmdQueue string
 var cmdMisc string
+var cmdScheduler string
+var cmdSchedulerArgs []string
 var cmdMonitorDocker string
+var cmdRunAs string
+var cmdShell string
+var cmdNice int
+var cmdIONice string
+var cmdOOMScoreAdj int
+var cmdUmask string
+var cmdFileGroup string
+var cmdReportCmd string
+var cmdAffinity string
+var cmdMaxPerHost int
 var rtimeoutint int
 var simpleOutput bool
+var cmdSpool bool
+var cmdDir string
+var cmdAddToken string
+var cmdRejectsFile string
+var cmdWorkQueue string
 
 // addCmd represents the add command
 var addCmd = &cobra.Command{
@@ -86,10 +139,16 @@ specific options using a JSON object in (tab separated) column 2, or
 alternatively have only a JSON object in column 1 that also specifies the
 command as one of the name:value pairs. The possible options are:
 
-cmd cwd cwd_matters change_home on_failure on_success on_exit mounts req_grp
-memory time override cpus disk queue misc priority retries rep_grp dep_grps deps
-cmd_deps monitor_docker cloud_os cloud_username cloud_ram cloud_script
-cloud_config_files cloud_flavor cloud_shared env bsub_mode
+cmd cwd cwd_matters change_home cwd_template cwd_base cwd_link on_failure
+on_success on_exit mounts req_grp memory time override cpus disk queue misc
+priority retries retry_budgets rep_grp dep_grps deps cmd_deps rep_grp_deps
+atomic_grp monitor_docker cloud_os cloud_username cloud_ram cloud_script
+cloud_config_files cloud_flavor cloud_shared cloud_volume env clean_env secrets
+input_files runner_input_check output_files output_min_size output_check_cmd
+output_checksums irods_inputs irods_collection irods_meta network_access proxy
+ref_assets bsub_mode run_as shell nice ionice oom_score_adj umask group
+scheduler affinity max_per_host report_cmd work_queue trace_id scheduler_args
+cacheable
 
 If any of these will be the same for all your commands, you can instead specify
 them as flags (which are treated as defaults in the case that they are
@@ -115,8 +174,9 @@ directory to create a unique working directory inside. This unique directory can
 be deleted after the cmd finishes running (according to cleanup behaviour), and
 enables tracking of how much disk space your cmd uses. If using mounts and not
 specifying a mount point, the mount point will be the actual working directory.
-It also sets $TMPDIR to a sister directory of the actual working directory, and
-this is always deleted after the cmd runs. If, on the other hand, you set
+It also sets $TMPDIR to a sister directory of the actual working directory
+(or, whatever cwd_matters is, to a unique directory in the runnerscratchdir set
+in the config file, if any), and this is always deleted after the cmd runs. If, on the other hand, you set
 cwd_matters, then "cwd" is the literal command working directory, you can't
 clean up afterwards, you don't get disk space tracking and undefined mounts are
 mounted in the "mnt" subdirectory of cwd. One benefit is that any output files
@@ -129,6 +189,20 @@ paths to your input files in your cmd, assuming they are in your cwd.
 the $HOME environment variable to the actual command working directory before
 running the cmd.
 
+"cwd_template", "cwd_base" and "cwd_link" also only have an effect when
+"cwd_matters" is false, and control where the unique working directory is made.
+By default it is made within a hashed directory structure in a "wr_cwd"
+subdirectory of "cwd", which is safe for very large numbers of commands but
+hard to browse. "cwd_template" instead gives a relative path to make it at,
+which can contain the placeholders {key} (the command's unique key), {repgroup},
+{reqgroup} and {date} (YYYY-MM-DD), eg. "{repgroup}/{key}". A unique suffix is
+still added to the final directory. "cwd_base" is a directory to make the unique
+working directory within instead of "cwd", eg. a fast local disk when "cwd" is
+on a network file system. If you also set "cwd_link", a symlink to the actual
+working directory is made at the equivalent location within "cwd", so that you
+can find your command's outputs from there (it is removed by the cleanup
+behaviours).
+
 "on_failure" determines what behaviours are triggered if your cmd exits non-0.
 Behaviours are described using an array of objects, where each object has a key
 corresponding to the name of the desired behaviour, and the relevant value. The
@@ -137,11 +211,43 @@ and if true will completely delete the actual working directory created when
 cwd_matters is false (no effect when cwd_matters is true); "cleanup", which is
 like cleanup_all except that it doesn't delete files that have been specified as
 inputs or outputs [since you can't currently specify this, the current behaviour
-is identical to cleanup_all]; and "run", which takes a string command to run
-after the main cmd runs. For example [{"run":"cp error.log
-/shared/logs/this.log"},{"cleanup":true}] would copy a log file that your cmd
-generated to describe its problems to some shared location and then delete all
-files created by your cmd.
+is identical to cleanup_all]; "cleanup_dry_run", which deletes nothing but logs
+what cleanup_all would have deleted to the runner's log; "resubmit", which takes
+an object describing how to change your cmd before it is tried again (even if it
+has no retries left), and only applies when your cmd failed; "add_jobs", which
+takes an array of job objects (in the same form as accepted by this command), or
+the path (relative to the actual working directory) of a file your cmd wrote
+containing such an array, and adds those jobs dependent on your cmd completing,
+so that your cmd can decide what runs next; "copy_to_manager", which takes an
+array of paths (relative to the actual working directory, or absolute) of files
+your cmd wrote, and copies them to a sub-directory named after your cmd's
+internal id within the manager's managercopydir (the manager limits how many
+cmds copy at once and how fast, so this may wait its turn; see 'wr status
+--transfers'); and "run", which takes a string command to run after the main
+cmd runs. For example
+[{"run":"cp error.log /shared/logs/this.log"},{"cleanup":true}] would copy a log
+file that your cmd generated to describe its problems to some shared location
+and then delete all files created by your cmd. As a safety measure, the cleanup
+behaviours will not delete directories that wr did not create for your cmd.
+"run" behaviours are killed if they take longer than an hour, or the number of
+seconds given as "timeout" in the same object, eg.
+{"run":"./checks.sh","timeout":60}. How long each behaviour took and the output
+of "run" behaviours can be seen with 'wr status'. Any behaviour can be made
+conditional on how your cmd exited by adding any of these keys to its object:
+"exit_codes", an array of exit codes, one of which your cmd must have exited
+with; "fail_reason", a regular expression that the reason your cmd failed (as
+shown by 'wr status', eg. "too much RAM") must match; "attempt", the
+attempt number (1 for the first run) your cmd must have been on; and
+"final_attempt", which if true means your cmd must not be going to be retried.
+For example [{"run":"rescue.sh","exit_codes":[137],"final_attempt":true}] would
+only run rescue.sh if your cmd was killed on its last attempt.
+The object that "resubmit" takes can have the keys: "ram_multiplier", what to
+multiply the memory reserved for your cmd by; "rep_group", a new reporting
+group; "cmd_replace", an object mapping parts of your cmd to what they should be
+replaced with; and "cmd_append", text to add to the end of your cmd. For example
+[{"resubmit":{"ram_multiplier":2,"cmd_replace":{"--mem 4G":"--mem 8G"}},
+"exit_codes":[3],"attempt":1}] would try your cmd again with twice the memory
+and a different option if it exited 3 on its first attempt.
 
 "on_success" is exactly like on_failure, except that the behaviours trigger when
 your cmd exits 0.
@@ -150,6 +256,13 @@ your cmd exits 0.
 your cmd exits, regardless of exit code. These behaviours will trigger after any
 behaviours defined in on_failure or on_success.
 
+Instead of JSON, the --on_failure, --on_success and --on_exit options can take a
+reference to a set of behaviours previously saved in the manager with 'wr
+behaviours save', like --on_success @irods-archive for the latest version of the
+set, or @irods-archive:2 for a specific version. Your commands get a copy of the
+set's behaviours when they're added, so saving a new version of the set later
+won't change what happens to them.
+
 "mounts" (or the --mount_json option) describes the remote file systems or
 object stores you would like to be fuse mounted locally before running your
 command. See the help text for 'wr mount' for an explanation of how to formulate
@@ -210,7 +323,10 @@ space usage checking and learning only occurs for jobs where cwd doesn't matter
 "queue" tells wr which queue a job should be submitted to, when using a job
 scheduler that has queues (eg. LSF). If queue is not specified, wr will use
 heuristics to pick the most appropriate queue based on the time, memory and cpu
-requirements of the job.
+requirements of the job. You can also supply a comma separated list of queues,
+in which case wr will pick the most appropriate of those. If none of the queues
+you specify are usable and able to meet the job's requirements, the job will be
+buried.
 
 "misc" will be used as-is to form the command line used to submit jobs to
 external job schedulers (eg. LSF). For example, --misc '-R avx' might result
@@ -218,6 +334,22 @@ in a command line containing: bsub -R avx. To avoid quoting issues, surround
 the --misc value in single quotes and if necessary use double quotes within the
 value; do NOT use single quotes within the value. Eg. --misc '-R "foo bar"'.
 
+"scheduler" is only relevant when wr manager was started with more than one
+scheduler (eg. -s local,lsf,openstack), and forces the job to be run using the
+named one, instead of the one chosen by the manager's routing rules.
+
+"scheduler_args" lets you pass site-specific options through to a particular
+scheduler, without affecting how the job would be run by any other. In JSON it
+is an object keyed on scheduler name, eg. {"lsf":"-P myproject",
+"openstack":"project=foo,billing=bar"}; on the command line, supply
+--scheduler_args name:args once per scheduler. For "lsf", args are extra bsub
+flags, quoted as for "misc"; the flags wr sets itself (-q -M -n -J -o -e) are
+not allowed. For "openstack", args are comma separated key=value pairs that
+become the metadata of the servers spawned to run the job, which will only be
+reused for jobs with the same metadata; keys may not start with "wr_". The
+"local" and "kubernetes" schedulers don't take any args. Invalid args result in
+the job not being added.
+
 "priority" defines how urgent a particular command is; those with higher
 priorities will start running before those with lower priorities. The range of
 possible values is 0 (default, for lowest priority) to 255 (highest priority).
@@ -235,6 +367,14 @@ more memory/time reserved). Once this number of retries is reached, the command
 will be 'buried' until you take manual action to fix the problem and press the
 retry button in the web interface.
 
+"retry_budgets" overrides the number of retries allowed for particular classes
+of failure, as named in the manager's failure rules (see the
+managerfailurerules option in your config file). In JSON, it is an object of
+class names to numbers, eg. {"lost contact":-1,"OOM":3,"command not found":0},
+and as a flag it is a comma separated list like "lost contact=-1,OOM=3". A
+value of -1 means failures of that class can be retried forever, while 0 means
+the command will be buried as soon as it fails in that way.
+
 "rep_grp" is an arbitrary group you can give your commands so you can query
 their status later. This is only used for reporting and presentation purposes
 when viewing status.
@@ -260,6 +400,32 @@ name:value pairs (if cwd doesn't matter for a cmd, provide it as an empty
 string). These are static dependencies; once resolved they do not get re-
 evaluated.
 
+"rep_grp_deps" is an array of rep_grps; this command will not start until every
+incomplete command with any of those rep_grps has completed, including commands
+added to those rep_grps after this one, as long as this command hasn't started
+yet. Unlike "deps", adding more commands to those rep_grps won't cause this
+command to be re-run if it has already completed.
+
+"atomic_grp" is an arbitrary name that makes this command a member of an atomic
+group, whose members succeed or fail together; useful for scattered chunks of
+work where partial results are useless. If any member gets buried, the other
+members are buried (or killed if running) with the reason "another job in its
+atomic group was buried", and members that already completed are marked as
+having failed atomic groups, meaning their outputs should be considered
+invalid. Commands that depend on a member only start once every member of its
+atomic group has completed.
+
+"work_queue" is the name of a wr queue to add this command to, to isolate it
+from unrelated workloads sharing the same manager (not to be confused with
+"queue", the queue of your job scheduler). Commands in different wr queues are
+never run by the same runners, and each wr queue can be given a limit on how
+many of its commands run at once, and scheduler settings that apply to the
+commands added to it, using "wr queue". Names can only contain letters,
+numbers, _, . and -. Commands are identified by their command line, cwd (if it
+matters) and mounts, not their work_queue, so you can't add the same command to
+2 different wr queues at once. View the commands in a wr queue with
+"wr status --work_queue".
+
 "monitor_docker" turns on monitoring of a docker container identified by the
 given string, which could be the container's --name or path to its --cidfile. If
 the string contains ? or * symbols and doesn't match a name or file name
@@ -277,6 +443,69 @@ NB: does not handle monitoring of multiple docker containers run by a single
 command. A side effect of monitoring a container is that if you use wr to kill
 the job for this command, wr will also kill the container.
 
+"run_as" is the name of a user that the command should be run as, instead of the
+user that wr's runners are running as. This is for multi-user deployments where
+the manager runs as a service account, so that output files are owned by the
+person who submitted the command. It requires that the service account can run
+commands as this user via 'sudo -n -E -u [user]' (ie. without a password and
+with SETENV), and that the working directory is writable by this user (when
+cwd_matters is false, wr gives the user access to the unique directory it
+creates using "group" if set, or else an ACL). You can only run commands as the
+users the manager's managerrunasusers config option lists, and never as root. NB: peak memory usage may not be measurable for commands run
+as a different user.
+
+"shell" is the shell your command (and its report_cmd and any "run" behaviours)
+will be run with, instead of the runner_exec_shell in your config (bash by
+default). It can include arguments, so "bash -l" runs a login shell that sources
+/etc/profile and /etc/profile.d scripts, which is needed for environment module
+systems that provide 'module load'. Other shells such as "zsh" can also be used.
+The special value "none" runs your command directly without any shell: it is
+split in to words respecting quotes and backslashes, but pipes, redirection,
+variables and the like won't work.
+
+"nice", "ionice" and "oom_score_adj" let your command behave politely on
+machines shared with other users, such as login nodes. "nice" is a niceness
+from 1 to 19 (higher being nicer) that your command's processes will have,
+lowering their CPU priority. "ionice" is their IO scheduling class: "idle", or
+"best-effort" or "realtime" optionally followed by a colon and a level from 0
+(highest) to 7, eg. "best-effort:7" (ionice must be installed). "oom_score_adj"
+is a number from -1000 to 1000; positive values make your command more likely
+to be killed than other processes (such as the runner that reports on it) when
+the machine runs out of memory. Commands that don't specify these get the
+runnernice, runnerionice and runneroomscoreadj from the config.
+
+"umask" and "group" let pipelines in shared projects produce files the rest of
+the project can use, without wrapping every command. "umask" is an octal umask,
+eg. "0002" to make the files your command creates group writable. "group" is
+the name or id of a group (which the runner's user must be a member of) that
+wr gives the working directory it creates for your command when cwd_matters
+isn't set, making it setgid so that files created within it get the group too.
+After your command exits, everything in that directory and your output_files
+are also given the group. If wr can't find the group, your command is buried
+without being run. Commands that don't specify these get the runnerumask and
+runnergroup from the config.
+
+"report_cmd" is a command that will be run after the command succeeds, in the
+same working directory and environment, whose output reports on the command's
+results. Each line of its output that looks like key=value (eg.
+reads_mapped=123456) is stored as a metric of the command, which you can see
+with 'wr status' and in exported reports. If the report_cmd fails, the command
+still succeeds, but won't have any metrics.
+
+"affinity" is an arbitrary name, such as an identifier for a large input
+dataset, shared by commands that would benefit from running on the same machine.
+Commands are preferentially run on machines that recently ran other commands
+with the same affinity (within the last hour), so that they're more likely to
+find their input in the machine's page cache or local scratch space. It never
+causes a lower priority command to run before a higher priority one.
+
+"max_per_host" is the maximum number of commands with the same resource
+requirements, limit groups and max_per_host that may run at once on the same
+machine, regardless of how many cores it has. Use it for commands that thrash
+a machine's local disks when too many of them run together. (To limit commands
+across different resource requirements, use a limit group with a per-host
+limit instead; see 'wr limit -h'.)
+
 The "cloud_*" related options let you override the defaults of your cloud
 deployment. For example, if you do 'wr cloud deploy --os "Ubuntu 16" --os_ram
 2048 -u ubuntu -s ~/my_ubuntu_post_creation_script.sh', any commands you add
@@ -303,6 +532,15 @@ for writing lots of large files. (If you need a high performance shared disk,
 don't use this option, and instead set up your own shared filesystem, eg.
 GlusterFS, and specify a cloud_script that mounts it.)
 
+"cloud_volume" only works when using a cloud scheduler. It is the size in GB of
+a block storage volume that will be created, formatted and mounted (at the
+deployment's volume mount path, /mnt/wr_volume by default; see the
+--volume_mount option of 'wr cloud deploy') on the server your command runs on,
+before your command starts. The volume is deleted after your command finishes,
+so copy anything you want to keep elsewhere. Use this for scratch space larger
+than the local disk of any available flavor. Only one command with a
+cloud_volume runs on a server at a time.
+
 "env" is an array of "key=value" environment variables, which override or add to
 the environment variables the command will see when it runs. The base variables
 that are overwritten depend on if you run 'wr add' on the same machine as you
@@ -313,18 +551,199 @@ prior to calling 'wr add'. In the remote case the command will use base
 variables as they were on the machine where the command is executed when that
 machine was started.
 
+"clean_env" is a boolean that makes the command run with the clean login
+environment of the machine it runs on, instead of base variables as described
+above, with only "env" (and any --env_whitelist variables) applied on top. The
+--no_capture_env flag turns this on for all commands, and stops your current
+environment being stored in the manager's database, which avoids bloating it and
+potentially leaking secrets. --env_whitelist is like --no_capture_env, but the
+comma separated list of variable names you supply will still be captured (in the
+local case) and provided to your commands, eg. --env_whitelist PATH,PERL5LIB.
+
+"secrets" is an array of the names of secrets previously stored with 'wr secret
+set'. Just before the command runs, each will be set as an environment variable
+of the same name, overriding any in "env". The values are never stored in the
+manager's database, and are redacted from the command's stored output. You need
+the grants for the secrets (see 'wr secret') for the command to be accepted.
+
+"input_files" is an array of paths to files your command reads. The manager
+checks they exist and are readable as soon as the command is ready to run, and
+if not, buries it with the reason "missing input" instead of having a runner
+fail it later. They're checked again just before the command runs. Relative
+paths are relative to the command's working directory; the manager only checks
+those when cwd_matters, and doesn't check commands with mounts at all. If the
+files aren't visible on the manager's machine (eg. not on a shared file system),
+set "runner_input_check" to true to only have them checked before running.
+
+"output_files" is an array of paths to files your command creates. After the
+command exits 0, they are checked to exist and be at least "output_min_size"
+bytes (default 1, ie. non-empty); if not, the command is treated as having
+failed with the reason "missing expected output", instead of being archived as
+a success. If you also supply "output_check_cmd", it is run for each output
+with the WR_OUTPUT environment variable set to the output's path, and a
+non-zero exit also counts as a failure, eg. 'gzip -t "$WR_OUTPUT"'. Relative
+paths are relative to the command's working directory. Set "output_checksums"
+to true to also have the size and MD5 checksum of each output recorded when the
+command succeeds; see 'wr outputs --checksums'.
+
+"cacheable", if true, lets your command be satisfied from the results of a
+previous run instead of running again. When you add it, if the same command
+(with the same cwd, if cwd_matters) previously completed after also being added
+as cacheable, it is queued again, but when it gets to run, the MD5 checksums of
+its "input_files" are compared to what they were when it ran before. If they
+are the same, the command is not run, but is marked as complete (and "cached")
+with the results of that run, under your new rep_grp. If the inputs changed, it
+is run again, even though it completed before. Commands with no "input_files"
+or dependencies are marked as complete straight away. --rerun disables this,
+so that cacheable commands always run.
+
+"irods_inputs" is an array of paths of iRODS data objects that are fetched (with
+iget) in to your command's working directory before it runs, keeping their base
+names. "irods_collection" is an iRODS collection that each of your
+"output_files" is put (with iput) in to once your command succeeds and its
+outputs have been validated, keeping their base names. "irods_meta" is an
+object of attribute:value metadata that gets set on those data objects, in
+addition to wr_job_key and wr_rep_group, which record the internal id and
+report group of your command; as a flag it is a comma separated list of
+attribute=value pairs. The icommands must be installed and configured on the
+machines your commands run on. Failed transfers are retried, and if they still
+fail, your command is treated as having failed with the reason "iRODS transfer
+failed". See the runnerirodstransfers and runnerirodsretries config options.
+
+"network_access" is an array declaring the network access your command needs,
+each being either "internet" or a host:port. Before your command runs, each is
+checked to be reachable, and if not your command doesn't run and is treated as
+having failed with the reason "required network access unavailable". When
+using a cloud scheduler, servers are spawned in the security groups configured
+for those needs with the cloudnetworkgroups config option. "proxy" is a URL
+that your command's http_proxy and https_proxy (and upper-case equivalent)
+environment variables are set to; "internet" access is then checked by
+connecting to the proxy.
+
+"ref_assets" is an array of objects describing large, read-only files that
+many of your commands need, such as reference genomes. Each object has the
+keys "source", the absolute path or http(s) URL of the file; "checksum", its
+expected checksum as "md5:<hex>" or "sha256:<hex>"; and optionally "name", what
+to call it in your command's working directory (defaults to the basename of
+the source). Eg. [{"source":"https://example.com/hg38.fa","checksum":
+"md5:e10adc3949ba59abbe56e057f20f883e"}]. Instead of every command copying
+these files, the runners on each machine share a cache of them (see the
+runnerrefcachedir and runnerrefcachesize config options), fetching and
+checking each file only once, then symlinking it in to the working directory
+of each command that needs it. If that fails, your command doesn't run and is
+treated as having failed with the reason "could not fetch reference assets".
+As a flag, supply the array as a JSON string.
+
 "bsub_mode" is a boolean that results in the job being assigned a unique (for
 this manager session) job id, and turns on bsub emulation, which means that if
 your Cmd calls bsub, it will instead result in a command being added to wr. The
-new job will have this job's mount and cloud_* options.`,
+new job will have this job's mount and cloud_* options.
+
+"trace_id" is an OpenTelemetry trace id (32 lower-case hexadecimal characters)
+that what happens to your command is recorded under, when the manager has been
+configured with managertraceendpoint. Supplying the same trace_id for all the
+commands of a workflow, even when added by different 'wr add' calls, lets you
+see the whole workflow run as one trace in eg. Jaeger, to analyse where the time
+went. Without it, all the commands of each 'wr add' get a new trace id. Your
+command is given the trace parent of its run in the TRACEPARENT environment
+variable, so that if it is instrumented itself, its spans join the trace.
+
+Instead of --file, you can supply --dir to add the commands in every file in a
+directory (ignoring hidden files and sub-directories), letting you organise a
+big workflow as a set of simple files without writing a program to generate
+them. Each file is in the same format as for --file, but can optionally start
+with a YAML "front matter" block between 2 lines of just "---", setting the
+defaults for that file's commands, eg.:
+
+---
+rep_grp: align
+memory: 4G
+time: 2h
+deps: [index]
+---
+bwa mem ref.fa sample1.fq > sample1.sam
+bwa mem ref.fa sample2.fq > sample2.sam
+
+The front matter options are rep_grp, req_grp, memory, time, cpus, disk,
+priority, retries, limit_grps, dep_grps, deps and atomic_grp, with the same
+meaning as the equivalent command options described above. They override the
+corresponding command line options, and options in a command's JSON override
+them in turn.
+rep_grp defaults to the --rep_grp if you supplied one, or otherwise to the
+file's name without its extension. Every command in a
+file also gets the file's rep_grp as a dep_grp, so that one file's deps can just
+list the rep_grps of the other files whose commands must complete first (in the
+above example, the commands in a file called eg. index.txt).
+
+To scatter a task over many inputs and then gather the results, supply a single
+command in the --file that contains the placeholders {input} and {chunk}, a
+--scatter_inputs file with 1 input per line, and a --gather_cmd, eg.:
+
+echo 'split_stats {input} > {chunk}' | wr add --scatter_inputs regions.txt \
+  --gather_cmd 'merge_stats {chunks} > all.stats' --cwd_matters
+
+This adds a scatter command per input, with {input} replaced by the input as-is,
+{index} by its line number and {chunk} by the path to a chunk output file in the
+--chunk_dir (which defaults to the working directory when --cwd_matters), plus
+the gather command with {chunks} replaced by the paths of all the chunk outputs
+(they're appended to the end if {chunks} isn't present). The scatter commands
+form an atomic group (named after --atomic_grp if supplied), so that if any of
+them gets buried, the others are cancelled and the gather command never runs;
+the gather command only starts once every scatter command has completed. All
+the other options apply to both the scatter and gather commands.
+
+If the manager can't be reached (eg. because it is being restarted), 'wr add'
+normally fails. With --spool it will instead store your commands in a local
+spool file (see the managerspoolfile config option) and exit successfully, which
+is useful for submission scripts run by cron. Spooled commands are added the
+next time 'wr add' successfully connects to the manager, or when you run 'wr
+spool flush'. Since the manager's location can't be known at spool time, they
+are treated as if the manager were on the same machine as you.
+
+Each submission is identified by an add token, so that if 'wr add' fails (eg.
+because it timed out waiting for the manager) and you don't know whether your
+commands were added, you can re-run exactly the same 'wr add' with the
+--add_token it told you, to be told what was originally added instead of
+adding anything again. The manager remembers add tokens for 24 hours. You can
+also supply your own --add_token (any string that is unique to this
+submission) in the first place, eg. in scripts that retry on failure.
+
+Commands that can't be added because they're invalid (eg. they have bad
+behaviours or limit groups) don't stop the rest from being added: 'wr add'
+tells you how many were rejected and why, then exits non-0. With --rejects, it
+also writes a tab separated file with a line for every command that was not
+newly added, giving its internal id, what happened to it ("existed" if it was
+already in the queue, "complete" if it had already completed, "cached" if it
+was completed from the results of a previous run, or "invalid"),
+the reason it was invalid (if it was) and the command line.`,
 	Run: func(combraCmd *cobra.Command, args []string) {
 		// check the command line options
-		if cmdFile == "" {
+		if cmdDir != "" && combraCmd.Flags().Changed("file") {
+			die("--file and --dir are mutually exclusive")
+		}
+		if cmdFile == "" && cmdDir == "" {
 			die("--file is required")
 		}
+		if cmdScatterInputs != "" && cmdDir != "" {
+			die("--scatter_inputs and --dir are mutually exclusive")
+		}
+		if (cmdScatterInputs == "") != (cmdGatherCmd == "") {
+			die("--scatter_inputs and --gather_cmd must be used together")
+		}
 
 		timeout := time.Duration(timeoutint) * time.Second
-		jq := connect(timeout)
+		jq := connect(timeout, cmdSpool)
+		if jq == nil {
+			// we could only have failed to connect if spooling
+			jobs, _, _ := parseCmdFile(jq, combraCmd.Flags().Changed("disk"), combraCmd.Flags().Changed("rep_grp"))
+			createWorkingDir()
+			err := jobqueue.SpoolJobs(config.ManagerSpoolFile, jobs, addEnvVars(true), !cmdReRun)
+			if err != nil {
+				die("the manager could not be reached, and spooling the commands failed: %s", err)
+			}
+			info("The manager could not be reached, so spooled %d commands to be added later", len(jobs))
+			return
+		}
 		var err error
 		defer func() {
 			err = jq.Disconnect()
@@ -333,37 +752,62 @@ new job will have this job's mount and cloud_* options.`,
 			}
 		}()
 
-		jobs, isLocal, defaultedRepG := parseCmdFile(jq, combraCmd.Flags().Changed("disk"))
+		// now that we can reach the manager, first add anything we previously
+		// spooled
+		flushSpool(jq)
 
-		var envVars []string
-		if isLocal {
-			envVars = os.Environ()
+		jobs, isLocal, defaultedRepG := parseCmdFile(jq, combraCmd.Flags().Changed("disk"), combraCmd.Flags().Changed("rep_grp"))
+		envVars := addEnvVars(isLocal)
+
+		token := cmdAddToken
+		if token == "" {
+			token, err = jobqueue.NewAddToken()
+			if err != nil {
+				die("could not create an add token: %s", err)
+			}
 		}
 
 		// add the jobs to the queue *** should add at most 1,000,000 jobs at a
 		// time to avoid time out issues...
-		if simpleOutput {
-			ids, err := jq.AddAndReturnIDs(jobs, envVars, !cmdReRun)
-			if err != nil {
+		inserts, dups, results, err := jq.AddWithToken(jobs, envVars, !cmdReRun, token)
+		if err != nil {
+			if jqerr, ok := err.(jobqueue.Error); ok && jqerr.Err == jobqueue.ErrAddTokenReused {
 				die("%s", err)
 			}
-			if len(ids) == 0 {
-				os.Exit(1)
-			}
-			for _, id := range ids {
-				fmt.Printf("%s\n", id)
+			die("%s\n(to find out if your commands were added, re-run the same command with --add_token %s)", err, token)
+		}
+
+		if cmdRejectsFile != "" {
+			writeAddRejects(cmdRejectsFile, jobs, results)
+		}
+		invalid := summariseInvalidAdds(results)
+
+		if simpleOutput {
+			printed := make(map[string]bool)
+			for _, result := range results {
+				if result.Outcome == jobqueue.AddOutcomeComplete || result.Outcome == jobqueue.AddOutcomeCached ||
+					result.Outcome == jobqueue.AddOutcomeInvalid || printed[result.Key] {
+					continue
+				}
+				printed[result.Key] = true
+				fmt.Printf("%s\n", result.Key)
 			}
-		} else {
-			inserts, dups, err := jq.Add(jobs, envVars, !cmdReRun)
-			if err != nil {
-				die("%s", err)
+			if len(printed) == 0 || invalid > 0 {
+				os.Exit(1)
 			}
+			return
+		}
 
-			if defaultedRepG {
-				info("Added %d new commands (%d were duplicates) to the queue using default identifier '%s'", inserts, dups, cmdRepGroup)
-			} else {
-				info("Added %d new commands (%d were duplicates) to the queue", inserts, dups)
-			}
+		if defaultedRepG {
+			info("Added %d new commands (%d were duplicates) to the queue using default identifier '%s'", inserts, dups, cmdRepGroup)
+		} else {
+			info("Added %d new commands (%d were duplicates) to the queue", inserts, dups)
+		}
+		if cached := countAddOutcomes(results, jobqueue.AddOutcomeCached); cached > 0 {
+			info("%d commands were completed using the results of previous runs", cached)
+		}
+		if invalid > 0 {
+			die("%d commands were invalid and not added", invalid)
 		}
 	},
 }
@@ -373,12 +817,16 @@ func init() {
 
 	// flags specific to this sub-command
 	addCmd.Flags().StringVarP(&cmdFile, "file", "f", "-", "file containing your commands; - means read from STDIN")
+	addCmd.Flags().StringVar(&cmdDir, "dir", "", "directory containing files of commands, each with optional front matter defaults")
 	addCmd.Flags().StringVarP(&cmdRepGroup, "rep_grp", "i", "manually_added", "reporting group for your commands")
 	addCmd.Flags().StringVarP(&cmdLimitGroups, "limit_grps", "l", "", "comma-separated list of limit groups")
 	addCmd.Flags().StringVarP(&cmdDepGroups, "dep_grps", "e", "", "comma-separated list of dependency groups")
 	addCmd.Flags().StringVarP(&cmdCwd, "cwd", "c", "", "base for the command's working dir")
 	addCmd.Flags().BoolVar(&cmdCwdMatters, "cwd_matters", false, "--cwd should be used as the actual working directory")
 	addCmd.Flags().BoolVar(&cmdChangeHome, "change_home", false, "when not --cwd_matters, set $HOME to the actual working directory")
+	addCmd.Flags().StringVar(&cmdCwdTemplate, "cwd_template", "", "when not --cwd_matters, relative path of the unique working directory, eg. {repgroup}/{key}")
+	addCmd.Flags().StringVar(&cmdCwdBase, "cwd_base", "", "when not --cwd_matters, make the unique working directory in here instead of --cwd")
+	addCmd.Flags().BoolVar(&cmdCwdLink, "cwd_link", false, "when using --cwd_base, symlink to the actual working directory from --cwd")
 	addCmd.Flags().StringVarP(&reqGroup, "req_grp", "g", "", "group name for commands with similar reqs")
 	addCmd.Flags().StringVarP(&cmdMem, "memory", "m", "1G", "peak mem est. [specify units such as M for Megabytes or G for Gigabytes]")
 	addCmd.Flags().StringVarP(&cmdTime, "time", "t", "1h", "max time est. [specify units such as m for minutes or h for hours]")
@@ -387,9 +835,25 @@ func init() {
 	addCmd.Flags().IntVarP(&cmdOvr, "override", "o", 0, "[0|1|2] should your mem/time estimates override? (default 0)")
 	addCmd.Flags().IntVarP(&cmdPri, "priority", "p", 0, "[0-255] command priority (default 0)")
 	addCmd.Flags().IntVarP(&cmdRet, "retries", "r", 3, "[0-255] number of automatic retries for failed commands")
+	addCmd.Flags().StringVar(&cmdRetryBudgets, "retry_budgets", "", "[-1-255] retries for particular classes of failure, in the form \"class1=retries,class2=retries...\"")
 	addCmd.Flags().StringVar(&cmdCmdDeps, "cmd_deps", "", "dependencies of your commands, in the form \"command1,cwd1,command2,cwd2...\"")
 	addCmd.Flags().StringVarP(&cmdGroupDeps, "deps", "d", "", "dependencies of your commands, in the form \"dep_grp1,dep_grp2...\"")
+	addCmd.Flags().StringVar(&cmdRepGroupDeps, "rep_grp_deps", "", "commands in these comma-separated rep_grps must complete before yours start")
+	addCmd.Flags().StringVar(&cmdAtomicGroup, "atomic_grp", "", "atomic group your commands belong to, so they succeed or fail together")
+	addCmd.Flags().StringVar(&cmdScatterInputs, "scatter_inputs", "", "file of inputs, 1 per line, to run your single command on as scatter jobs")
+	addCmd.Flags().StringVar(&cmdGatherCmd, "gather_cmd", "", "command to run on the outputs of the scatter jobs once they all complete")
+	addCmd.Flags().StringVar(&cmdChunkDir, "chunk_dir", "", "directory the scatter jobs write their outputs to (default --cwd when --cwd_matters)")
 	addCmd.Flags().StringVar(&cmdMonitorDocker, "monitor_docker", "", "monitor resource usage of docker container with given --name or --cidfile path")
+	addCmd.Flags().StringVar(&cmdRunAs, "run_as", "", "name of the user to run the commands as, via sudo")
+	addCmd.Flags().StringVar(&cmdShell, "shell", "", "shell to run the commands with, eg. \"bash -l\", or none to run them directly (default runner_exec_shell config)")
+	addCmd.Flags().IntVar(&cmdNice, "nice", 0, "[1-19] niceness to run the commands with (default runnernice config)")
+	addCmd.Flags().StringVar(&cmdIONice, "ionice", "", "IO class to run the commands with, eg. idle or best-effort:7 (default runnerionice config)")
+	addCmd.Flags().IntVar(&cmdOOMScoreAdj, "oom_score_adj", 0, "[-1000-1000] oom_score_adj to run the commands with (default runneroomscoreadj config)")
+	addCmd.Flags().StringVar(&cmdUmask, "umask", "", "octal umask to run the commands with, eg. 0002 (default runnerumask config)")
+	addCmd.Flags().StringVar(&cmdFileGroup, "group", "", "group to give the commands' working directories and output files (default runnergroup config)")
+	addCmd.Flags().StringVar(&cmdReportCmd, "report_cmd", "", "command to run after commands succeed, that outputs key=value metrics")
+	addCmd.Flags().StringVar(&cmdAffinity, "affinity", "", "prefer to run commands on machines that recently ran commands with the same affinity")
+	addCmd.Flags().IntVar(&cmdMaxPerHost, "max_per_host", 0, "maximum number of these commands to run at once on the same machine (default 0 means unlimited)")
 	addCmd.Flags().StringVar(&cmdOnFailure, "on_failure", "", "behaviours to carry out when cmds fails, in JSON format")
 	addCmd.Flags().StringVar(&cmdOnSuccess, "on_success", "", "behaviours to carry out when cmds succeed, in JSON format")
 	addCmd.Flags().StringVar(&cmdOnExit, "on_exit", `[{"cleanup":true}]`, "behaviours to carry out when cmds finish running, in JSON format")
@@ -402,15 +866,39 @@ func init() {
 	addCmd.Flags().StringVar(&cmdPostCreationScript, "cloud_script", "", "in the cloud, path to a start-up script that will be run on the servers created to run these commands")
 	addCmd.Flags().StringVar(&cmdCloudConfigs, "cloud_config_files", "", "in the cloud, comma separated paths of config files to copy to servers created to run these commands")
 	addCmd.Flags().BoolVar(&cmdCloudSharedDisk, "cloud_shared", false, "mount /shared")
+	addCmd.Flags().IntVar(&cmdCloudVolume, "cloud_volume", 0, "in the cloud, size (GB) of a scratch volume to attach while each command runs")
 	addCmd.Flags().StringVar(&cmdQueue, "queue", "", "name of queue to submit to, for schedulers with queues")
+	addCmd.Flags().StringVar(&cmdWorkQueue, "work_queue", "", "name of the wr queue to add your commands to, isolating them from other workloads")
 	addCmd.Flags().StringVar(&cmdMisc, "misc", "", "miscellaneous options to pass through to scheduler when submitting")
+	addCmd.Flags().StringVar(&cmdScheduler, "scheduler", "", "name of the scheduler to use, when the manager is using more than one")
+	addCmd.Flags().StringArrayVar(&cmdSchedulerArgs, "scheduler_args", nil, "site-specific options for a particular scheduler, in the form name:args; may be supplied more than once")
 	addCmd.Flags().StringVar(&cmdEnv, "env", "", "comma-separated list of key=value environment variables to set before running the commands")
+	addCmd.Flags().BoolVar(&cmdNoCaptureEnv, "no_capture_env", false, "don't store your current environment variables; run commands in a clean login environment")
+	addCmd.Flags().StringVar(&cmdEnvWhitelist, "env_whitelist", "", "like --no_capture_env, but still store these comma-separated environment variables")
+	addCmd.Flags().StringVar(&cmdSecrets, "secrets", "", "comma-separated list of names of secrets (see 'wr secret') to set as environment variables when running the commands")
+	addCmd.Flags().StringVar(&cmdInputFiles, "input_files", "", "comma-separated list of files the commands read, which must exist before they run")
+	addCmd.Flags().BoolVar(&cmdRunnerInputCheck, "runner_input_check", false, "only check --input_files on the runner, not the manager")
+	addCmd.Flags().StringVar(&cmdOutputFiles, "output_files", "", "comma-separated list of files the commands create, which must exist and be non-empty after they succeed")
+	addCmd.Flags().IntVar(&cmdOutputMinSize, "output_min_size", 0, "minimum size in bytes of each of --output_files")
+	addCmd.Flags().StringVar(&cmdOutputCheckCmd, "output_check_cmd", "", "command to validate each of --output_files, given as $WR_OUTPUT")
+	addCmd.Flags().BoolVar(&cmdOutputChecksums, "output_checksums", false, "record the size and MD5 checksum of each of --output_files on success")
+	addCmd.Flags().BoolVar(&cmdCacheable, "cacheable", false, "complete the commands with the results of previous runs that had the same --input_files checksums")
+	addCmd.Flags().StringVar(&cmdIRODSInputs, "irods_inputs", "", "comma-separated list of iRODS data objects to fetch in to the working dir before the commands run")
+	addCmd.Flags().StringVar(&cmdIRODSCollection, "irods_collection", "", "iRODS collection to put --output_files in to after the commands succeed")
+	addCmd.Flags().StringVar(&cmdIRODSMeta, "irods_meta", "", "comma-separated list of attribute=value metadata to set on outputs put in to iRODS")
+	addCmd.Flags().StringVar(&cmdNetworkAccess, "network_access", "", "comma-separated list of network access the commands need: internet or host:port")
+	addCmd.Flags().StringVar(&cmdProxy, "proxy", "", "URL of an HTTP(S) proxy the commands should use")
+	addCmd.Flags().StringVar(&cmdTraceID, "trace_id", "", "OpenTelemetry trace id to record what happens to the commands under")
+	addCmd.Flags().StringVar(&cmdRefAssets, "ref_assets", "", "reference assets the commands need, cached per host, in JSON format")
 	addCmd.Flags().BoolVar(&cmdReRun, "rerun", false, "re-run any commands that you add that had been previously added and have since completed")
 	addCmd.Flags().BoolVar(&cmdBsubMode, "bsub", false, "enable bsub emulation mode")
 
 	addCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
 	addCmd.Flags().IntVar(&rtimeoutint, "reserve_timeout", 1, "how long (seconds) to wait before a runner exits when there is no more work'")
 	addCmd.Flags().BoolVarP(&simpleOutput, "simple", "s", false, "simplify output to only queued job ids")
+	addCmd.Flags().StringVar(&cmdAddToken, "add_token", "", "token identifying this submission, to safely re-run it if it fails")
+	addCmd.Flags().StringVar(&cmdRejectsFile, "rejects", "", "write details of commands that were not newly added to this file")
+	addCmd.Flags().BoolVar(&cmdSpool, "spool", false, "if the manager can't be reached, spool the commands to be added later instead of failing")
 
 	err := addCmd.Flags().MarkHidden("reserve_timeout")
 	if err != nil {
@@ -418,6 +906,78 @@ func init() {
 	}
 }
 
+// summariseInvalidAdds warns about the given results of adding commands that
+// were invalid, grouped by reason, and returns how many there were.
+func summariseInvalidAdds(results []*jobqueue.AddResult) int {
+	reasons := make(map[string]int)
+	var order []string
+	invalid := 0
+	for _, result := range results {
+		if result.Outcome != jobqueue.AddOutcomeInvalid {
+			continue
+		}
+		invalid++
+		if _, seen := reasons[result.Reason]; !seen {
+			order = append(order, result.Reason)
+		}
+		reasons[result.Reason]++
+	}
+	for _, reason := range order {
+		warn("%d commands were rejected: %s", reasons[reason], reason)
+	}
+	return invalid
+}
+
+// countAddOutcomes returns how many of the given results have the given
+// outcome.
+func countAddOutcomes(results []*jobqueue.AddResult, outcome jobqueue.AddOutcome) int {
+	n := 0
+	for _, result := range results {
+		if result.Outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+// writeAddRejects writes a tab separated line to path for each of the given
+// jobs whose result was not that it got added.
+func writeAddRejects(path string, jobs []*jobqueue.Job, results []*jobqueue.AddResult) {
+	f, err := os.Create(path)
+	if err != nil {
+		die("could not create rejects file: %s", err)
+	}
+	w := bufio.NewWriter(f)
+	for i, result := range results {
+		if result.Outcome == jobqueue.AddOutcomeAdded || i >= len(jobs) {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Key, result.Outcome, result.Reason, jobs[i].Cmd)
+	}
+	err = w.Flush()
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		die("could not write rejects file: %s", err)
+	}
+}
+
+// addEnvVars returns the environment variables that should be stored with the
+// commands being added, given whether the manager is on the same host as us.
+func addEnvVars(isLocal bool) []string {
+	if !isLocal {
+		return nil
+	}
+	envVars := os.Environ()
+	if cmdEnvWhitelist != "" {
+		envVars = jobqueue.FilterEnv(envVars, strings.Split(cmdEnvWhitelist, ","))
+	} else if cmdNoCaptureEnv {
+		envVars = nil
+	}
+	return envVars
+}
+
 // convert cmd,cwd columns in to Dependency.
 func colsToDeps(cols []string) (deps jobqueue.Dependencies) {
 	for i := 0; i < len(cols); i += 2 {
@@ -437,14 +997,15 @@ func groupsToDeps(groups string) (deps jobqueue.Dependencies) {
 // parseCmdFile reads the given cmd file to get desired jobs, modified by
 // defaults specified in other command line args. Returns job slice, bool for if
 // the manager is on the same host as us, and bool for if any job defaulted to
-// the default repgrp.
-func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, bool) {
-	var isLocal bool
+// the default repgrp. jq can be nil if the manager couldn't be reached, in which
+// case the manager is assumed to be on the same host.
+func parseCmdFile(jq *jobqueue.Client, diskSet, repGrpSet bool) ([]*jobqueue.Job, bool, bool) {
+	isLocal := jq == nil
 	currentIP, errc := internal.CurrentIP("")
 	if errc != nil {
 		warn("Could not get current IP: %s", errc)
 	}
-	if currentIP+":"+config.ManagerPort == jq.ServerInfo.Addr {
+	if jq != nil && net.JoinHostPort(currentIP, config.ManagerPort) == jq.ServerInfo.Addr {
 		isLocal = true
 	}
 
@@ -466,10 +1027,16 @@ func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, boo
 
 	jd := &jobqueue.JobDefaults{
 		RepGrp:           cmdRepGroup,
+		AtomicGrp:        cmdAtomicGroup,
+		WorkQueue:        cmdWorkQueue,
 		ReqGrp:           reqGroup,
 		Cwd:              cmdCwd,
 		CwdMatters:       cmdCwdMatters,
 		ChangeHome:       cmdChangeHome,
+		CwdTemplate:      cmdCwdTemplate,
+		CwdBase:          cmdCwdBase,
+		CwdLink:          cmdCwdLink,
+		CleanEnv:         cmdNoCaptureEnv || cmdEnvWhitelist != "",
 		CPUs:             cmdCPUs,
 		Disk:             cmdDisk,
 		DiskSet:          diskSet,
@@ -478,6 +1045,16 @@ func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, boo
 		Retries:          cmdRet,
 		Env:              cmdEnv,
 		MonitorDocker:    cmdMonitorDocker,
+		RunAs:            cmdRunAs,
+		Shell:            cmdShell,
+		Nice:             cmdNice,
+		IONice:           cmdIONice,
+		OOMScoreAdj:      cmdOOMScoreAdj,
+		Umask:            cmdUmask,
+		Group:            cmdFileGroup,
+		ReportCmd:        cmdReportCmd,
+		Affinity:         cmdAffinity,
+		MaxPerHost:       cmdMaxPerHost,
 		CloudOS:          cmdOsPrefix,
 		CloudUser:        cmdOsUsername,
 		CloudScript:      cmdPostCreationScript,
@@ -485,8 +1062,18 @@ func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, boo
 		CloudOSRam:       cmdOsRAM,
 		CloudFlavor:      cmdFlavor,
 		CloudShared:      cmdCloudSharedDisk,
+		CloudVolume:      cmdCloudVolume,
+		RunnerInputCheck: cmdRunnerInputCheck,
+		OutputMinSize:    cmdOutputMinSize,
+		OutputCheck:      cmdOutputCheckCmd,
+		OutputChecksums:  cmdOutputChecksums,
+		Cacheable:        cmdCacheable,
+		IRODSColl:        cmdIRODSCollection,
+		Proxy:            cmdProxy,
+		TraceID:          cmdTraceID,
 		SchedulerQueue:   cmdQueue,
 		SchedulerMisc:    cmdMisc,
+		Scheduler:        cmdScheduler,
 		BsubMode:         bsubMode,
 		RTimeout:         rtimeoutint,
 	}
@@ -495,7 +1082,55 @@ func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, boo
 		jd.RepGrp = "manually_added"
 	}
 
+	if cmdSecrets != "" {
+		jd.Secrets = strings.Split(cmdSecrets, ",")
+	}
+
+	if cmdInputFiles != "" {
+		jd.InputFiles = strings.Split(cmdInputFiles, ",")
+	}
+
+	if cmdOutputFiles != "" {
+		jd.OutputFiles = strings.Split(cmdOutputFiles, ",")
+	}
+
+	if cmdIRODSInputs != "" {
+		jd.IRODSInputs = strings.Split(cmdIRODSInputs, ",")
+	}
+
+	if cmdNetworkAccess != "" {
+		jd.Networks = strings.Split(cmdNetworkAccess, ",")
+	}
+
 	var err error
+	if cmdRefAssets != "" {
+		err = json.Unmarshal([]byte(cmdRefAssets), &jd.RefAssets)
+		if err != nil {
+			die("--ref_assets was not specified correctly: %s", err)
+		}
+	}
+
+	jd.IRODSMeta, err = jobqueue.ParseIRODSMetadata(cmdIRODSMeta)
+	if err != nil {
+		die("--irods_meta was not specified correctly: %s", err)
+	}
+
+	jd.RetryBudgets, err = jobqueue.ParseRetryBudgets(cmdRetryBudgets)
+	if err != nil {
+		die("--retry_budgets was not specified correctly: %s", err)
+	}
+
+	for _, nameArgs := range cmdSchedulerArgs {
+		parts := strings.SplitN(nameArgs, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			die("--scheduler_args [%s] is not in name:args format", nameArgs)
+		}
+		if jd.SchedulerArgs == nil {
+			jd.SchedulerArgs = make(map[string]string)
+		}
+		jd.SchedulerArgs[parts[0]] = parts[1]
+	}
+
 	if cmdMem == "" {
 		jd.Memory = 0
 	} else {
@@ -532,36 +1167,34 @@ func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, boo
 	if cmdGroupDeps != "" {
 		jd.Deps = append(jd.Deps, groupsToDeps(cmdGroupDeps)...)
 	}
+	if cmdRepGroupDeps != "" {
+		for _, repgroup := range strings.Split(cmdRepGroupDeps, ",") {
+			jd.Deps = append(jd.Deps, jobqueue.NewRepGroupDependency(repgroup))
+		}
+	}
 
 	if cmdOnFailure != "" {
-		var bjs jobqueue.BehavioursViaJSON
-		err = json.Unmarshal([]byte(cmdOnFailure), &bjs)
-		if err != nil {
-			die("bad --on_failure: %s", err)
-		}
-		jd.OnFailure = bjs.Behaviours(jobqueue.OnFailure)
+		jd.OnFailure = parseBehavioursFlag(jq, "on_failure", cmdOnFailure, jobqueue.OnFailure)
 	}
 	if cmdOnSuccess != "" {
-		var bjs jobqueue.BehavioursViaJSON
-		err = json.Unmarshal([]byte(cmdOnSuccess), &bjs)
-		if err != nil {
-			die("bad --on_success: %s", err)
-		}
-		jd.OnSuccess = bjs.Behaviours(jobqueue.OnSuccess)
+		jd.OnSuccess = parseBehavioursFlag(jq, "on_success", cmdOnSuccess, jobqueue.OnSuccess)
 	}
 	if cmdOnExit != "" {
-		var bjs jobqueue.BehavioursViaJSON
-		err = json.Unmarshal([]byte(cmdOnExit), &bjs)
-		if err != nil {
-			die("bad --on_exit: %s", err)
-		}
-		jd.OnExit = bjs.Behaviours(jobqueue.OnExit)
+		jd.OnExit = parseBehavioursFlag(jq, "on_exit", cmdOnExit, jobqueue.OnExit)
 	}
 
 	if mountJSON != "" || mountSimple != "" {
 		jd.MountConfigs = mountParse(mountJSON, mountSimple)
 	}
 
+	if cmdDir != "" {
+		jobs, errd := parseCmdDir(jq, isLocal, jd, cmdDir, repGrpSet)
+		if errd != nil {
+			die("%s", errd)
+		}
+		return jobs, isLocal, false
+	}
+
 	// open file or set up to read from STDIN
 	var reader io.Reader
 	if cmdFile == "-" {
@@ -574,6 +1207,23 @@ func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, boo
 		defer internal.LogClose(appLogger, reader.(*os.File), "cmds file", "path", cmdFile)
 	}
 
+	jobs, defaultedRepG := parseCmds(jq, isLocal, jd, reader, "", 0)
+	return jobs, isLocal, defaultedRepG
+}
+
+// parseCmds reads commands (1 per line, in the format described in the add
+// command's help) from the given reader, converting them to jobs using the
+// given defaults. Problems are reported with the given description of the
+// source of the commands (blank for the --file), with line numbers counted
+// from linesBefore. Also returns true if any job defaulted to the default
+// repgrp.
+func parseCmds(jq *jobqueue.Client, isLocal bool, jd *jobqueue.JobDefaults, reader io.Reader, source string, linesBefore int) ([]*jobqueue.Job, bool) {
+	file, prefix := "file", ""
+	if source != "" {
+		file = "file '" + source + "'"
+		prefix = file + " "
+	}
+
 	// we'll default to pwd if the manager is on the same host as us, or if
 	// cwd matters, /tmp otherwise (and cmdCwd has not been supplied)
 	var pwd string
@@ -594,11 +1244,12 @@ func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, boo
 	// for network efficiency, read in all commands and create a big slice
 	// of Jobs and Add() them in one go afterwards
 	var jobs []*jobqueue.Job
+	var scattered bool
 	scanner := bufio.NewScanner(reader)
 	buf := make([]byte, maxScanTokenSize)
 	scanner.Buffer(buf, maxScanTokenSize)
 	defaultedRepG := false
-	lineNum := 0
+	lineNum := linesBefore
 	for scanner.Scan() {
 		lineNum++
 		cols := strings.Split(scanner.Text(), "\t")
@@ -607,7 +1258,7 @@ func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, boo
 			continue
 		}
 		if colsn > 2 {
-			die("line %d has too many columns; check `wr add -h`", lineNum)
+			die("%sline %d has too many columns; check `wr add -h`", prefix, lineNum)
 		}
 
 		// determine all the options for this command
@@ -627,11 +1278,11 @@ func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, boo
 		}
 
 		if jsonErr != nil {
-			die("line %d had a problem with the JSON: %s", lineNum, jsonErr)
+			die("%sline %d had a problem with the JSON: %s", prefix, lineNum, jsonErr)
 		}
 
 		if jvj.CPUs != nil && *jvj.CPUs < 0 {
-			die("line %d has a negative cpus count", lineNum)
+			die("%sline %d has a negative cpus count", prefix, lineNum)
 		}
 
 		if jvj.Cwd == "" && jd.Cwd == "" {
@@ -649,20 +1300,268 @@ func parseCmdFile(jq *jobqueue.Client, diskSet bool) ([]*jobqueue.Job, bool, boo
 			jvj.CloudConfigFiles = copyCloudConfigFiles(jq, jvj.CloudConfigFiles)
 		}
 
-		job, errf := jvj.Convert(jd)
-		if errf != nil {
-			die("line %d had a problem: %s", lineNum, errf)
+		jvjs := []*jobqueue.JobViaJSON{jvj}
+		if cmdScatterInputs != "" {
+			if scattered {
+				die("%sline %d is a second command, but only 1 scatter command can be given with --scatter_inputs", prefix, lineNum)
+			}
+			scattered = true
+			jvjs = scatterJobs(jvj, jd)
 		}
 
-		jobs = append(jobs, job)
+		for _, jvj := range jvjs {
+			job, errf := jvj.Convert(jd)
+			if errf != nil {
+				die("%sline %d had a problem: %s", prefix, lineNum, errf)
+			}
+
+			jobs = append(jobs, job)
+		}
 	}
 
 	serr := scanner.Err()
 	if serr != nil {
-		die("failed to read whole file: %s", serr.Error())
+		die("failed to read whole %s: %s", file, serr.Error())
 	}
 
-	return jobs, isLocal, defaultedRepG
+	return jobs, defaultedRepG
+}
+
+// scatterJobs expands the given scatter command in to a job per line of the
+// --scatter_inputs file, plus the --gather_cmd job. The jobs are made with the
+// given defaults, which may be altered.
+func scatterJobs(scatter *jobqueue.JobViaJSON, jd *jobqueue.JobDefaults) []*jobqueue.JobViaJSON {
+	inputs, err := readScatterInputs(cmdScatterInputs)
+	if err != nil {
+		die("could not read --scatter_inputs file '%s': %s", cmdScatterInputs, err)
+	}
+
+	chunkDir := cmdChunkDir
+	if chunkDir == "" {
+		if !scatter.CwdMatters && !jd.CwdMatters {
+			die("--chunk_dir is required with --scatter_inputs, unless --cwd_matters")
+		}
+		chunkDir = scatter.Cwd
+		if chunkDir == "" {
+			chunkDir = jd.Cwd
+		}
+	}
+	chunkDir, err = filepath.Abs(chunkDir)
+	if err != nil {
+		die("could not resolve the chunk directory: %s", err)
+	}
+
+	group := scatter.AtomicGrp
+	if group == "" {
+		group = jd.AtomicGrp
+	}
+	jd.AtomicGrp = ""
+	if len(scatter.DepGrps) == 0 {
+		scatter.DepGrps = jd.DepGroups
+	}
+
+	sg := &jobqueue.ScatterGather{
+		Inputs:    inputs,
+		GatherCmd: cmdGatherCmd,
+		ChunkDir:  chunkDir,
+		Group:     group,
+	}
+	jvjs, err := sg.Expand(scatter)
+	if err != nil {
+		die("%s", err)
+	}
+	return jvjs
+}
+
+// readScatterInputs returns the non-blank lines of the file at the given path.
+func readScatterInputs(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.LogClose(appLogger, f, "scatter inputs file", "path", path)
+
+	var inputs []string
+	scanner := bufio.NewScanner(f)
+	buf := make([]byte, maxScanTokenSize)
+	scanner.Buffer(buf, maxScanTokenSize)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			inputs = append(inputs, line)
+		}
+	}
+	return inputs, scanner.Err()
+}
+
+// cmdFileDefaults are the defaults that can be set in the front matter of a
+// file in a --dir.
+type cmdFileDefaults struct {
+	RepGrp    string   `yaml:"rep_grp"`
+	ReqGrp    string   `yaml:"req_grp"`
+	Memory    string   `yaml:"memory"`
+	Time      string   `yaml:"time"`
+	CPUs      *float64 `yaml:"cpus"`
+	Disk      *int     `yaml:"disk"`
+	Priority  *int     `yaml:"priority"`
+	Retries   *int     `yaml:"retries"`
+	LimitGrps []string `yaml:"limit_grps"`
+	DepGrps   []string `yaml:"dep_grps"`
+	Deps      []string `yaml:"deps"`
+	AtomicGrp string   `yaml:"atomic_grp"`
+}
+
+// apply returns a copy of the given defaults, overridden by our own.
+func (fd *cmdFileDefaults) apply(jd *jobqueue.JobDefaults) (*jobqueue.JobDefaults, error) {
+	fjd := *jd
+	if fd.RepGrp != "" {
+		fjd.RepGrp = fd.RepGrp
+	}
+	if fd.ReqGrp != "" {
+		fjd.ReqGrp = fd.ReqGrp
+	}
+	if fd.Memory != "" {
+		mb, err := bytefmt.ToMegabytes(fd.Memory)
+		if err != nil {
+			return nil, fmt.Errorf("memory was not specified correctly: %s", err)
+		}
+		fjd.Memory = int(mb)
+	}
+	if fd.Time != "" {
+		t, err := time.ParseDuration(fd.Time)
+		if err != nil {
+			return nil, fmt.Errorf("time was not specified correctly: %s", err)
+		}
+		fjd.Time = t
+	}
+	if fd.CPUs != nil {
+		if *fd.CPUs < 0 {
+			return nil, fmt.Errorf("cpus can't be negative")
+		}
+		fjd.CPUs = *fd.CPUs
+	}
+	if fd.Disk != nil {
+		fjd.Disk = *fd.Disk
+		fjd.DiskSet = true
+	}
+	if fd.Priority != nil {
+		fjd.Priority = *fd.Priority
+	}
+	if fd.Retries != nil {
+		fjd.Retries = *fd.Retries
+	}
+	if len(fd.LimitGrps) > 0 {
+		fjd.LimitGroups = fd.LimitGrps
+	}
+	if len(fd.DepGrps) > 0 {
+		fjd.DepGroups = fd.DepGrps
+	}
+	if len(fd.Deps) > 0 {
+		fjd.Deps = append(append(jobqueue.Dependencies{}, jd.Deps...), groupsToDeps(strings.Join(fd.Deps, ","))...)
+	}
+	if fd.AtomicGrp != "" {
+		fjd.AtomicGrp = fd.AtomicGrp
+	}
+	return &fjd, nil
+}
+
+// parseCmdDir parses every non-hidden file in the given directory as a cmd
+// file that can have front matter defaults, and returns the jobs of all of
+// them. Files without a rep_grp in their front matter get jd's RepGrp if
+// repGrpSet, otherwise one based on their name.
+func parseCmdDir(jq *jobqueue.Client, isLocal bool, jd *jobqueue.JobDefaults, dir string, repGrpSet bool) ([]*jobqueue.Job, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read directory '%s': %w", dir, err)
+	}
+
+	var jobs []*jobqueue.Job
+	repGroups := make(map[string]bool)
+	var deps []string
+	files := 0
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		files++
+		path := filepath.Join(dir, entry.Name())
+
+		fd, reader, frontLines, errr := readFrontMatter(path)
+		if errr != nil {
+			return nil, errr
+		}
+		if fd.RepGrp == "" {
+			if repGrpSet {
+				fd.RepGrp = jd.RepGrp
+			} else {
+				fd.RepGrp = strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+			}
+		}
+		repGroups[fd.RepGrp] = true
+		deps = append(deps, fd.Deps...)
+
+		fjd, errf := fd.apply(jd)
+		if errf != nil {
+			return nil, fmt.Errorf("file '%s' front matter %w", path, errf)
+		}
+
+		fileJobs, _ := parseCmds(jq, isLocal, fjd, reader, path, frontLines)
+		for _, job := range fileJobs {
+			addDepGroup(job, fd.RepGrp)
+		}
+		jobs = append(jobs, fileJobs...)
+	}
+
+	if files == 0 {
+		return nil, fmt.Errorf("directory '%s' contains no files of commands", dir)
+	}
+
+	for _, dep := range deps {
+		if !repGroups[dep] {
+			warn("deps on '%s', which is not the rep_grp of any file in %s", dep, dir)
+		}
+	}
+
+	return jobs, nil
+}
+
+// addDepGroup adds the given dep group to the job, if it doesn't already have
+// it.
+func addDepGroup(job *jobqueue.Job, group string) {
+	for _, dg := range job.DepGroups {
+		if dg == group {
+			return
+		}
+	}
+	job.DepGroups = append(job.DepGroups, group)
+}
+
+// readFrontMatter reads the file at the given path, parsing any front matter
+// it starts with. Returns the front matter defaults, a reader of the rest of
+// the file, and the number of lines the front matter took up.
+func readFrontMatter(path string) (*cmdFileDefaults, io.Reader, int, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("could not read file '%s': %w", path, err)
+	}
+
+	fd := &cmdFileDefaults{}
+	lines := strings.SplitAfter(string(content), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return fd, bytes.NewReader(content), 0, nil
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != frontMatterDelimiter {
+			continue
+		}
+		err = yaml.UnmarshalStrict([]byte(strings.Join(lines[1:i], "")), fd)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("file '%s' has bad front matter: %w", path, err)
+		}
+		return fd, strings.NewReader(strings.Join(lines[i+1:], "")), i + 1, nil
+	}
+
+	return nil, nil, 0, fmt.Errorf("file '%s' has front matter that was not ended with a %s line", path, frontMatterDelimiter)
 }
 
 // copyCloudConfigFiles copies local config files to the manager's machine to a
@@ -699,3 +1598,21 @@ func copyCloudConfigFiles(jq *jobqueue.Client, configFiles string) string {
 	}
 	return strings.Join(remoteConfigFiles, ",")
 }
+
+// parseBehavioursFlag parses the value of one of the --on_* options, which can
+// be JSON or a reference to a behaviour set stored in the manager, dying if it
+// is invalid. References can't be resolved when we have no connection to the
+// manager (jq is nil).
+func parseBehavioursFlag(jq *jobqueue.Client, flag, spec string, when jobqueue.BehaviourTrigger) jobqueue.Behaviours {
+	var bs jobqueue.Behaviours
+	var err error
+	if jq == nil {
+		bs, err = jobqueue.ParseBehaviours(spec, when)
+	} else {
+		bs, err = jq.ResolveBehaviours(spec, when)
+	}
+	if err != nil {
+		die("bad --%s: %s", flag, err)
+	}
+	return bs
+}
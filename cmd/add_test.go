@@ -0,0 +1,180 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAddDir(t *testing.T) {
+	Convey("Given a directory of cmd files", t, func() {
+		dir, err := ioutil.TempDir("", "wr_cmd_test_add_dir_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		write := func(name, content string) string {
+			path := filepath.Join(dir, name)
+			errw := ioutil.WriteFile(path, []byte(content), 0600)
+			So(errw, ShouldBeNil)
+			return path
+		}
+
+		jd := &jobqueue.JobDefaults{
+			RepGrp: "from_flag",
+			Cwd:    dir,
+			Memory: 100,
+			Time:   1 * time.Hour,
+		}
+
+		jobsByRepGrp := func(jobs []*jobqueue.Job) map[string][]*jobqueue.Job {
+			byRepGrp := make(map[string][]*jobqueue.Job)
+			for _, job := range jobs {
+				byRepGrp[job.RepGroup] = append(byRepGrp[job.RepGroup], job)
+			}
+			return byRepGrp
+		}
+
+		Convey("An empty directory is an error", func() {
+			jobs, errp := parseCmdDir(nil, true, jd, dir, false)
+			So(errp, ShouldNotBeNil)
+			So(errp.Error(), ShouldContainSubstring, "contains no files of commands")
+			So(jobs, ShouldBeNil)
+
+			write(".hidden", "echo hidden\n")
+			So(os.Mkdir(filepath.Join(dir, "subdir"), 0700), ShouldBeNil)
+			_, errp = parseCmdDir(nil, true, jd, dir, false)
+			So(errp, ShouldNotBeNil)
+		})
+
+		Convey("A missing directory is an error", func() {
+			_, errp := parseCmdDir(nil, true, jd, filepath.Join(dir, "missing"), false)
+			So(errp, ShouldNotBeNil)
+		})
+
+		Convey("Front matter sets the defaults of its file's commands", func() {
+			write("index.txt", "echo index\n")
+			write("align.txt", "---\nmemory: 4G\ntime: 2h\ndeps: [index]\n---\necho align1\necho align2\t{\"memory\":\"1G\"}\n")
+
+			jobs, errp := parseCmdDir(nil, true, jd, dir, false)
+			So(errp, ShouldBeNil)
+			So(len(jobs), ShouldEqual, 3)
+
+			byRepGrp := jobsByRepGrp(jobs)
+			So(len(byRepGrp["index"]), ShouldEqual, 1)
+			So(len(byRepGrp["align"]), ShouldEqual, 2)
+
+			index := byRepGrp["index"][0]
+			So(index.Requirements.RAM, ShouldEqual, 100)
+			So(index.Requirements.Time, ShouldEqual, 1*time.Hour)
+			So(index.DepGroups, ShouldResemble, []string{"index"})
+
+			aligns := byRepGrp["align"]
+			sort.Slice(aligns, func(i, j int) bool { return aligns[i].Cmd < aligns[j].Cmd })
+			So(aligns[0].Requirements.RAM, ShouldEqual, 4096)
+			So(aligns[0].Requirements.Time, ShouldEqual, 2*time.Hour)
+			So(aligns[0].DepGroups, ShouldResemble, []string{"align"})
+			So(aligns[0].Dependencies.DepGroups(), ShouldResemble, []string{"index"})
+			So(aligns[1].Requirements.RAM, ShouldEqual, 1024)
+		})
+
+		Convey("A rep_grp flag overrides the file name default, but not front matter", func() {
+			write("index.txt", "echo index\n")
+			write("align.txt", "---\nrep_grp: aligning\n---\necho align\n")
+
+			jobs, errp := parseCmdDir(nil, true, jd, dir, true)
+			So(errp, ShouldBeNil)
+			byRepGrp := jobsByRepGrp(jobs)
+			So(len(byRepGrp["from_flag"]), ShouldEqual, 1)
+			So(byRepGrp["from_flag"][0].Cmd, ShouldEqual, "echo index")
+			So(len(byRepGrp["aligning"]), ShouldEqual, 1)
+
+			jobs, errp = parseCmdDir(nil, true, jd, dir, false)
+			So(errp, ShouldBeNil)
+			byRepGrp = jobsByRepGrp(jobs)
+			So(len(byRepGrp["index"]), ShouldEqual, 1)
+			So(len(byRepGrp["from_flag"]), ShouldEqual, 0)
+		})
+
+		Convey("Bad front matter is an error", func() {
+			path := write("unknown.txt", "---\nmemry: 4G\n---\necho unknown\n")
+			_, _, _, errf := readFrontMatter(path)
+			So(errf, ShouldNotBeNil)
+			So(errf.Error(), ShouldContainSubstring, "bad front matter")
+
+			_, errp := parseCmdDir(nil, true, jd, dir, false)
+			So(errp, ShouldNotBeNil)
+
+			path = write("unknown.txt", "---\nmemory: 4G\necho unended\n")
+			_, _, _, errf = readFrontMatter(path)
+			So(errf, ShouldNotBeNil)
+			So(errf.Error(), ShouldContainSubstring, "not ended")
+
+			path = write("unknown.txt", "---\nmemory: [4G\n---\necho unparsable\n")
+			_, _, _, errf = readFrontMatter(path)
+			So(errf, ShouldNotBeNil)
+
+			write("unknown.txt", "---\nmemory: lots\n---\necho bad memory\n")
+			_, errp = parseCmdDir(nil, true, jd, dir, false)
+			So(errp, ShouldNotBeNil)
+			So(errp.Error(), ShouldContainSubstring, "memory was not specified correctly")
+
+			write("unknown.txt", "---\ncpus: -1\n---\necho bad cpus\n")
+			_, errp = parseCmdDir(nil, true, jd, dir, false)
+			So(errp, ShouldNotBeNil)
+		})
+
+		Convey("Files without front matter are read whole", func() {
+			path := write("plain.txt", "echo plain\n---\n")
+			fd, reader, lines, errf := readFrontMatter(path)
+			So(errf, ShouldBeNil)
+			So(lines, ShouldEqual, 0)
+			So(fd, ShouldResemble, &cmdFileDefaults{})
+			content, errr := ioutil.ReadAll(reader)
+			So(errr, ShouldBeNil)
+			So(string(content), ShouldEqual, "echo plain\n---\n")
+		})
+	})
+
+	Convey("File defaults only override the flags they set", t, func() {
+		cpus := float64(2)
+		jd := &jobqueue.JobDefaults{RepGrp: "flag", ReqGrp: "flagreq", Memory: 100, Time: 1 * time.Hour, CPUs: 1, Priority: 3}
+		fd := &cmdFileDefaults{Memory: "2G", CPUs: &cpus}
+		fjd, err := fd.apply(jd)
+		So(err, ShouldBeNil)
+		So(fjd.RepGrp, ShouldEqual, "flag")
+		So(fjd.ReqGrp, ShouldEqual, "flagreq")
+		So(fjd.Memory, ShouldEqual, 2048)
+		So(fjd.Time, ShouldEqual, 1*time.Hour)
+		So(fjd.CPUs, ShouldEqual, 2)
+		So(fjd.Priority, ShouldEqual, 3)
+		So(jd.Memory, ShouldEqual, 100)
+
+		fd = &cmdFileDefaults{Time: "forever"}
+		_, err = fd.apply(jd)
+		So(err, ShouldNotBeNil)
+	})
+}
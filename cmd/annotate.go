@@ -0,0 +1,111 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// options for this cmd
+var annotateKey string
+var annotateRepGroup string
+var annotateNote string
+
+// annotateCmd represents the annotate command
+var annotateCmd = &cobra.Command{
+	Use:   "annotate",
+	Short: "Leave notes on commands",
+	Long: `Leave notes on commands, or see the notes left on them.
+
+When several people look after the same deployment of wr, it helps to be able
+to say why you did something, eg. that you retried some commands after fixing a
+reference file. This command lets you leave a freeform note on a single command
+(--key, its id as shown by "wr status -o d") or on every command in a report
+group (-i):
+
+wr annotate --key 58cef10e7a340c3b7fa09ea304a3cb98 --note "rerun after fixing ref"
+wr annotate -i mygroup --note "paused while the cluster is serviced"
+
+Notes are stored by the manager along with who left them and when, and are
+shown to everyone looking at the commands with "wr status -o d" or the web
+interface (where notes can also be added), and in "wr events" (as annotate
+events).
+
+Without --note, the notes left on the given command, or on the given report
+group, are listed as tab separated columns of when they were left, who left
+them, the command id (or - for notes on the report group) and the note.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if (annotateKey == "") == (annotateRepGroup == "") {
+			die("exactly one of --key or -i is required")
+		}
+
+		timeout := time.Duration(timeoutint) * time.Second
+		jq := connect(timeout)
+		var err error
+		defer func() {
+			err = jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		if annotateNote == "" {
+			var keys, repGroups []string
+			if annotateKey != "" {
+				keys = []string{annotateKey}
+			} else {
+				repGroups = []string{annotateRepGroup}
+			}
+			notes, errg := jq.GetAnnotations(keys, repGroups)
+			if errg != nil {
+				die("failed to get notes: %s", errg)
+			}
+			for _, note := range notes {
+				fmt.Printf("%s\t%s\t%s\t%s\n", note.Time.Format(shortTimeFormat), orDash(note.User), orDash(note.Key), note.Note)
+			}
+			return
+		}
+
+		var a *jobqueue.Annotation
+		if annotateKey != "" {
+			a, err = jq.AnnotateJob(annotateKey, annotateNote)
+		} else {
+			a, err = jq.AnnotateRepGroup(annotateRepGroup, annotateNote)
+		}
+		if err != nil {
+			die("failed to leave the note: %s", err)
+		}
+		info("Note left at %s", a.Time.Format(shortTimeFormat))
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(annotateCmd)
+
+	// flags specific to this sub-command
+	annotateCmd.Flags().StringVar(&annotateKey, "key", "", "id of the command to leave a note on")
+	annotateCmd.Flags().StringVarP(&annotateRepGroup, "identifier", "i", "", "report group to leave a note on")
+	annotateCmd.Flags().StringVar(&annotateNote, "note", "", "the note to leave; if not supplied, existing notes are listed")
+
+	annotateCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
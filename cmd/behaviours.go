@@ -0,0 +1,181 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// behavioursCmd represents the behaviours command
+var behavioursCmd = &cobra.Command{
+	Use:   "behaviours",
+	Short: "Manage named sets of behaviours",
+	Long: `Manage named sets of behaviours.
+
+If you often give your commands the same behaviours, eg. to archive their output
+when they succeed, you can save those behaviours in the manager under a name,
+then refer to them with @name when you add commands, eg.
+
+wr behaviours save irods-archive '[{"run":"iput -r . /archive"},{"cleanup":true}]'
+wr add -f cmds.txt --on_success @irods-archive
+
+Saving a set with the name of an existing one creates a new version of it; the
+old versions remain available as @name:version. Commands get a copy of the
+behaviours when they are added, so new versions won't change what happens to
+commands already added.
+
+Use the sub-commands to save, show, list and delete behaviour sets.`,
+}
+
+// save sub-command stores a behaviour set
+var behavioursSaveCmd = &cobra.Command{
+	Use:   "save NAME [JSON]",
+	Short: "Save a set of behaviours",
+	Long: `Save a set of behaviours under a name.
+
+The name must start with a letter or number, and consist of letters, numbers,
+_, . and -.
+
+The behaviours are specified in the same JSON format as the --on_failure,
+--on_success and --on_exit options of 'wr add' (see its help text for details).
+If not supplied as an argument, the JSON is read from STDIN.
+
+If a set with the given name already exists, this becomes its next version.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		var behavioursJSON string
+		if len(args) == 2 {
+			behavioursJSON = args[1]
+		} else {
+			b, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				die("could not read the behaviours JSON: %s", err)
+			}
+			behavioursJSON = string(b)
+		}
+
+		var bset *jobqueue.BehaviourSet
+		err := behaviourSetClient(func(jq *jobqueue.Client) error {
+			var errs error
+			bset, errs = jq.SaveBehaviourSet(args[0], behavioursJSON)
+			return errs
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		info("behaviour set %s saved as version %d", bset.Name, bset.Version)
+	},
+}
+
+// show sub-command displays a behaviour set
+var behavioursShowCmd = &cobra.Command{
+	Use:   "show NAME[:VERSION]",
+	Short: "Show a set of behaviours",
+	Long: `Show the JSON of a saved set of behaviours.
+
+Without a version, the latest version is shown.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, version, err := jobqueue.ParseBehaviourSetRef(args[0])
+		if err != nil {
+			die("%s", err)
+		}
+
+		var bset *jobqueue.BehaviourSet
+		err = behaviourSetClient(func(jq *jobqueue.Client) error {
+			var errg error
+			bset, errg = jq.GetBehaviourSet(name, version)
+			return errg
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		info("behaviour set %s version %d, saved %s", bset.Name, bset.Version, bset.Saved.Format(time.RFC3339))
+		fmt.Println(bset.JSON)
+	},
+}
+
+// list sub-command shows behaviour set names
+var behavioursListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved sets of behaviours",
+	Long:  `List the names and latest versions of saved sets of behaviours.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var bsets []*jobqueue.BehaviourSet
+		err := behaviourSetClient(func(jq *jobqueue.Client) error {
+			var errl error
+			bsets, errl = jq.ListBehaviourSets()
+			return errl
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		for _, bset := range bsets {
+			fmt.Printf("%s:%d\n", bset.Name, bset.Version)
+		}
+	},
+}
+
+// delete sub-command removes a behaviour set
+var behavioursDeleteCmd = &cobra.Command{
+	Use:   "delete NAME",
+	Short: "Delete a set of behaviours",
+	Long: `Delete all versions of a saved set of behaviours.
+
+Commands that were added referring to the set are not affected.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := behaviourSetClient(func(jq *jobqueue.Client) error {
+			return jq.DeleteBehaviourSet(args[0])
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		info("behaviour set %s deleted", args[0])
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(behavioursCmd)
+	behavioursCmd.AddCommand(behavioursSaveCmd)
+	behavioursCmd.AddCommand(behavioursShowCmd)
+	behavioursCmd.AddCommand(behavioursListCmd)
+	behavioursCmd.AddCommand(behavioursDeleteCmd)
+
+	behavioursCmd.PersistentFlags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// behaviourSetClient connects to the manager, calls the given function with the
+// client, and disconnects.
+func behaviourSetClient(f func(jq *jobqueue.Client) error) error {
+	jq := connect(time.Duration(timeoutint) * time.Second)
+	defer func() {
+		err := jq.Disconnect()
+		if err != nil {
+			warn("Disconnecting from the server failed: %s", err)
+		}
+	}()
+	return f(jq)
+}
@@ -0,0 +1,506 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	jqs "github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+	"github.com/spf13/cobra"
+)
+
+const (
+	benchRepGroup    = "wr_bench"
+	benchCertDomain  = "localhost"
+	benchReserveWait = 100 * time.Millisecond
+)
+
+// options for this cmd
+var benchJobs int
+var benchBatch int
+var benchWorkers int
+var benchDuration string
+var benchDistribution string
+var benchFailureRate float64
+var benchRetries int
+var benchFanout int
+var benchDir string
+var benchSeed int64
+var benchTimeout int
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Load test a manager with a synthetic workload",
+	Long: `Load test a manager with a synthetic workload.
+
+Starts a temporary development manager of its own (with a fresh database, on
+free ports, so it doesn't interfere with any manager you have running), adds
+--jobs synthetic commands to it, then has --workers simulated runners reserve
+and "run" them, and reports on how the manager coped:
+
+ - submission throughput: how quickly the commands could be added
+ - reservation latency: percentiles of how long runners waited for the manager
+   to give them a command
+ - websocket broadcast lag: percentiles of the time between a runner asking the
+   manager to mark a command complete and the status web interface hearing
+   about it
+ - database growth: how much bigger the database got
+
+Nothing is actually executed: each simulated run just takes a duration drawn
+from --distribution (fixed, uniform or exponential) with a mean of --duration,
+then succeeds, or fails with probability --failure-rate. Failed commands are
+retried (immediately) up to --retries times before being buried.
+
+With --fanout, every command has that many dependent commands that only become
+ready once it completes (so 1 in fanout+1 of the --jobs are the parents).
+Dependents of buried parents never run, and are reported as blocked.
+
+Run it before and after a change to catch performance regressions. Use --seed
+to get the same durations and failures each time.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if benchJobs < 1 || benchBatch < 1 || benchWorkers < 1 {
+			die("--jobs, --batch and --workers must be at least 1")
+		}
+		if benchFailureRate < 0 || benchFailureRate > 1 {
+			die("--failure-rate must be between 0 and 1")
+		}
+		if benchRetries < 0 || benchRetries > 255 {
+			die("--retries must be between 0 and 255")
+		}
+		if benchFanout < 0 {
+			die("--fanout can't be negative")
+		}
+		mean, err := time.ParseDuration(benchDuration)
+		if err != nil || mean < 0 {
+			die("--duration must be a duration like 100ms")
+		}
+		switch benchDistribution {
+		case "fixed", "uniform", "exponential":
+		default:
+			die("--distribution must be one of fixed, uniform or exponential")
+		}
+
+		dir := benchDir
+		if dir == "" {
+			dir, err = ioutil.TempDir("", "wr_bench")
+			if err != nil {
+				die("could not create a temporary directory: %s", err)
+			}
+			defer func() {
+				errr := os.RemoveAll(dir)
+				if errr != nil {
+					warn("could not remove %s: %s", dir, errr)
+				}
+			}()
+		}
+
+		b := &bench{
+			total:    benchJobs,
+			mean:     mean,
+			finished: make(chan struct{}),
+			parents:  make(map[string]int),
+		}
+		b.run(dir, time.Duration(benchTimeout)*time.Second)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(benchCmd)
+
+	// flags specific to this sub-command
+	benchCmd.Flags().IntVarP(&benchJobs, "jobs", "n", 1000, "number of commands to add")
+	benchCmd.Flags().IntVar(&benchBatch, "batch", 1000, "number of commands to add at a time")
+	benchCmd.Flags().IntVarP(&benchWorkers, "workers", "w", 10, "number of simulated runners")
+	benchCmd.Flags().StringVarP(&benchDuration, "duration", "d", "100ms", "mean time each command takes to run")
+	benchCmd.Flags().StringVar(&benchDistribution, "distribution", "exponential", "distribution of run times: fixed, uniform or exponential")
+	benchCmd.Flags().Float64VarP(&benchFailureRate, "failure-rate", "f", 0, "probability (0..1) that a run fails")
+	benchCmd.Flags().IntVarP(&benchRetries, "retries", "r", 3, "number of times failed commands are retried before being buried")
+	benchCmd.Flags().IntVar(&benchFanout, "fanout", 0, "number of dependent commands each parent command has")
+	benchCmd.Flags().StringVar(&benchDir, "dir", "", "directory for the manager's files, kept afterwards (defaults to a temporary directory)")
+	benchCmd.Flags().Int64Var(&benchSeed, "seed", 0, "random seed for run times and failures (defaults to the current time)")
+	benchCmd.Flags().IntVar(&benchTimeout, "timeout", 120, "how long (seconds) to wait to get a reply from the manager")
+}
+
+// bench holds the state of a benchmark run.
+type bench struct {
+	total        int
+	mean         time.Duration
+	finished     chan struct{}
+	parents      map[string]int // cmd of parents to their number of dependents
+	complete     int
+	buried       int
+	blocked      int
+	attempts     int
+	reserveLats  []time.Duration
+	archiveTimes []time.Time
+	wsLags       []time.Duration
+	mutex        sync.Mutex
+}
+
+// run starts a manager using dir for its files, runs the benchmark against it,
+// and prints the results.
+func (b *bench) run(dir string, timeout time.Duration) {
+	server, addr, webAddr, caFile, token := startBenchManager(dir)
+	defer server.Stop(true)
+	dbFile := filepath.Join(dir, "db")
+
+	jq, err := jobqueue.Connect(addr, caFile, benchCertDomain, token, timeout)
+	if err != nil {
+		die("could not connect to the bench manager: %s", err)
+	}
+	defer disconnectBench(jq)
+
+	// failures should be retried immediately, else we'd mostly be measuring
+	// the retry delay
+	_, err = jq.SetSetting(jobqueue.SettingRetryDelay, "0s")
+	if err != nil {
+		die("could not set the retry delay: %s", err)
+	}
+
+	sw, err := jobqueue.WatchStatus(webAddr, caFile, benchCertDomain, token, timeout)
+	if err != nil {
+		die("could not watch the bench manager's status: %s", err)
+	}
+	defer func() {
+		errc := sw.Close()
+		if errc != nil {
+			warn("Disconnecting from the web interface failed: %s", errc)
+		}
+	}()
+	sw.OnStateChange(b.stateChanged)
+
+	dbStart := benchFileSize(dbFile)
+
+	info("adding %d commands", b.total)
+	submitStart := time.Now()
+	b.submit(jq)
+	submitTime := time.Since(submitStart)
+
+	info("running them with %d workers", benchWorkers)
+	seed := benchSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	runStart := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < benchWorkers; i++ {
+		wg.Add(1)
+		go func(r *rand.Rand) {
+			defer wg.Done()
+			b.work(addr, caFile, token, timeout, r)
+		}(rand.New(rand.NewSource(seed + int64(i))))
+	}
+	wg.Wait()
+	runTime := time.Since(runStart)
+
+	// give the websocket a moment to tell us about the last completions
+	<-time.After(2 * time.Second)
+
+	b.report(submitTime, runTime, dbStart, benchFileSize(dbFile))
+}
+
+// startBenchManager starts a development manager on free ports with all its
+// files in dir, returning it along with the addresses of its command and web
+// interfaces, its CA file and its token.
+func startBenchManager(dir string) (*jobqueue.Server, string, string, string, []byte) {
+	port, err := benchFreePort()
+	if err != nil {
+		die("could not find a free port: %s", err)
+	}
+	webPort, err := benchFreePort()
+	if err != nil {
+		die("could not find a free port: %s", err)
+	}
+
+	caFile := filepath.Join(dir, "ca.pem")
+	server, msg, token, err := jobqueue.Serve(jobqueue.ServerConfig{
+		Port:            port,
+		WebPort:         webPort,
+		SchedulerName:   "local",
+		SchedulerConfig: &jqs.ConfigLocal{Shell: config.RunnerExecShell},
+		DBFile:          filepath.Join(dir, "db"),
+		DBFileBackup:    filepath.Join(dir, "db_bk"),
+		TokenFile:       filepath.Join(dir, "token"),
+		UploadDir:       filepath.Join(dir, "uploads"),
+		CAFile:          caFile,
+		CertFile:        filepath.Join(dir, "cert.pem"),
+		KeyFile:         filepath.Join(dir, "key.pem"),
+		CertDomain:      benchCertDomain,
+		Deployment:      internal.Development,
+	})
+	if msg != "" {
+		info("bench manager: %s", msg)
+	}
+	if err != nil {
+		die("bench manager failed to start: %s", err)
+	}
+
+	return server, net.JoinHostPort(benchCertDomain, port), net.JoinHostPort(benchCertDomain, webPort), caFile, token
+}
+
+// benchFreePort returns a port that nothing is currently listening on.
+func benchFreePort() (string, error) {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return "", err
+	}
+	port := strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+	return port, l.Close()
+}
+
+// benchFileSize returns the size of the given file, or 0 if it can't be
+// determined.
+func benchFileSize(path string) int64 {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return fi.Size()
+}
+
+func disconnectBench(jq *jobqueue.Client) {
+	err := jq.Disconnect()
+	if err != nil {
+		warn("Disconnecting from the server failed: %s", err)
+	}
+}
+
+// submit adds our jobs to the queue, --batch at a time. Parents come before
+// their dependents.
+func (b *bench) submit(jq *jobqueue.Client) {
+	cwd := os.TempDir()
+	req := &jqs.Requirements{RAM: 1, Time: 1 * time.Minute, Cores: 1}
+	var jobs []*jobqueue.Job
+	var parent string
+	for i := 0; i < b.total; i++ {
+		job := &jobqueue.Job{
+			RepGroup:     benchRepGroup,
+			Cmd:          fmt.Sprintf("true # wr bench job %d", i),
+			Cwd:          cwd,
+			ReqGroup:     "wr_bench",
+			Requirements: req,
+			Retries:      uint8(benchRetries),
+		}
+
+		if benchFanout > 0 {
+			if i%(benchFanout+1) == 0 {
+				parent = fmt.Sprintf("wr_bench_%d", i)
+				job.DepGroups = []string{parent}
+				b.parents[job.Cmd] = 0
+			} else {
+				job.Dependencies = jobqueue.Dependencies{jobqueue.NewDepGroupDependency(parent)}
+				b.parents[fmt.Sprintf("true # wr bench job %d", i-i%(benchFanout+1))]++
+			}
+		}
+
+		jobs = append(jobs, job)
+		if len(jobs) == benchBatch || i == b.total-1 {
+			_, _, err := jq.Add(jobs, nil, false)
+			if err != nil {
+				die("failed to add commands: %s", err)
+			}
+			jobs = nil
+		}
+	}
+}
+
+// work is a simulated runner that reserves and "runs" jobs until they have
+// all completed or been buried.
+func (b *bench) work(addr, caFile string, token []byte, timeout time.Duration, r *rand.Rand) {
+	jq, err := jobqueue.Connect(addr, caFile, benchCertDomain, token, timeout)
+	if err != nil {
+		die("could not connect to the bench manager: %s", err)
+	}
+	defer disconnectBench(jq)
+
+	pid := os.Getpid()
+	for {
+		select {
+		case <-b.finished:
+			return
+		default:
+		}
+
+		start := time.Now()
+		job, err := jq.Reserve(benchReserveWait)
+		if err != nil {
+			die("failed to reserve a command: %s", err)
+		}
+		if job == nil {
+			continue
+		}
+		b.reserved(time.Since(start))
+
+		err = jq.Started(job, pid)
+		if err != nil {
+			die("failed to start a command: %s", err)
+		}
+		took := b.duration(r)
+		<-time.After(took)
+
+		jes := &jobqueue.JobEndState{Cwd: job.Cwd, Exited: true, CPUtime: took, EndTime: time.Now()}
+		if r.Float64() < benchFailureRate {
+			jes.Exitcode = 1
+			err = jq.Release(job, jes, jobqueue.FailReasonExit)
+			if err != nil {
+				die("failed to release a command: %s", err)
+			}
+			if job.State == jobqueue.JobStateBuried {
+				b.ended(job, false)
+			}
+			continue
+		}
+
+		b.archiving()
+		err = jq.Archive(job, jes)
+		if err != nil {
+			die("failed to archive a command: %s", err)
+		}
+		b.ended(job, true)
+	}
+}
+
+// duration returns how long a job should take to run, according to the
+// requested distribution.
+func (b *bench) duration(r *rand.Rand) time.Duration {
+	switch benchDistribution {
+	case "uniform":
+		return time.Duration(r.Float64() * 2 * float64(b.mean))
+	case "exponential":
+		return time.Duration(r.ExpFloat64() * float64(b.mean))
+	}
+	return b.mean
+}
+
+// reserved records that a job was reserved after the given latency.
+func (b *bench) reserved(latency time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.attempts++
+	b.reserveLats = append(b.reserveLats, latency)
+}
+
+// archiving notes when we're about to ask the server to archive a job, for
+// working out websocket lag.
+func (b *bench) archiving() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.archiveTimes = append(b.archiveTimes, time.Now())
+}
+
+// ended records that a job completed, or was buried, in which case any
+// dependents it has are blocked. Once all jobs have ended or are blocked, the
+// workers are told to stop.
+func (b *bench) ended(job *jobqueue.Job, completed bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if completed {
+		b.complete++
+	} else {
+		b.buried++
+		b.blocked += b.parents[job.Cmd]
+	}
+	if b.complete+b.buried+b.blocked == b.total {
+		close(b.finished)
+	}
+}
+
+// stateChanged is our StatusWatcher callback, used to work out the lag between
+// asking the server to archive jobs and it reporting their completion.
+func (b *bench) stateChanged(repGroup string, from, to jobqueue.JobState, count int) {
+	if repGroup != benchRepGroup || from == jobqueue.JobStateNew || to != jobqueue.JobStateComplete {
+		return
+	}
+	now := time.Now()
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for i := 0; i < count && len(b.archiveTimes) > 0; i++ {
+		b.wsLags = append(b.wsLags, now.Sub(b.archiveTimes[0]))
+		b.archiveTimes = b.archiveTimes[1:]
+	}
+}
+
+// report prints the results of the benchmark.
+func (b *bench) report(submitTime, runTime time.Duration, dbStart, dbEnd int64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "submission\t%d commands in %s (%.0f/s)\n", b.total, submitTime.Round(time.Millisecond), perSecond(b.total, submitTime))
+	fmt.Fprintf(w, "processing\t%d complete, %d buried, %d blocked in %s (%.0f/s); %d runs\n",
+		b.complete, b.buried, b.blocked, runTime.Round(time.Millisecond), perSecond(b.complete+b.buried, runTime), b.attempts)
+	fmt.Fprintf(w, "reservation latency\t%s\n", percentiles(b.reserveLats))
+	fmt.Fprintf(w, "websocket lag\t%s\n", percentiles(b.wsLags))
+	if missed := len(b.archiveTimes); missed > 0 {
+		fmt.Fprintf(w, "\t(%d completions were not heard about)\n", missed)
+	}
+	fmt.Fprintf(w, "database growth\t%s -> %s (%s per command)\n", benchBytes(dbStart), benchBytes(dbEnd), benchBytes((dbEnd-dbStart)/int64(b.total)))
+	err := w.Flush()
+	if err != nil {
+		warn("failed to write the report: %s", err)
+	}
+}
+
+// perSecond returns how many per second n in d is.
+func perSecond(n int, d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return float64(n) / d.Seconds()
+}
+
+// percentiles describes the 50th, 90th and 99th percentiles and maximum of the
+// given durations, which get sorted.
+func percentiles(ds []time.Duration) string {
+	if len(ds) == 0 {
+		return "no samples"
+	}
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+	p := func(q float64) time.Duration {
+		return ds[int(q*float64(len(ds)-1))].Round(time.Microsecond)
+	}
+	return fmt.Sprintf("p50 %s, p90 %s, p99 %s, max %s (%d samples)", p(0.5), p(0.9), p(0.99), p(1), len(ds))
+}
+
+// benchBytes formats a number of bytes in a human readable way.
+func benchBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,191 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// options for this cmd
+var budgetCPUHours float64
+var budgetCost float64
+var budgetCostPerCoreHour float64
+var budgetPause bool
+var budgetOutput string
+
+// budgetCmd represents the budget command
+var budgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Manage report group budgets",
+	Long: `Manage report group budgets.
+
+To stop a runaway pipeline consuming an entire allocation, you can give a
+report group (the -i of "wr add") a budget: a maximum number of CPU-hours, or a
+maximum cost, that its commands may consume in total, eg.
+
+wr budget set myproject --cpu_hours 5000 --pause
+
+CPU-hours are the cores a command requested multiplied by how long it ran for,
+summed over every run of every command in the report group, including failed
+attempts. Cost is CPU-hours multiplied by --cost_per_core_hour, eg. the hourly
+price of your cloud flavors divided by their cores.
+
+When 80% of the budget has been used, and again when all of it has, the manager
+records a "budget" event (see "wr events") and logs a warning. With --pause,
+once the budget is exceeded the report group's waiting commands are buried, as
+are any of its commands that become ready later; running commands are left to
+finish. To continue, raise the budget with "wr budget set" (consumption so far
+is kept) or delete it, then "wr retry" the buried commands.
+
+Use the sub-commands to set, list and delete budgets.`,
+}
+
+// set sub-command sets a budget
+var budgetSetCmd = &cobra.Command{
+	Use:   "set REPORT_GROUP",
+	Short: "Set the budget of a report group",
+	Long: `Set the budget of a report group.
+
+Supply --cpu_hours and/or --cost (which requires --cost_per_core_hour). If the
+report group already has a budget, it is replaced, but the record of how much
+has been consumed so far is kept.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		budget := &jobqueue.Budget{
+			RepGroup:        args[0],
+			CPUHours:        budgetCPUHours,
+			Cost:            budgetCost,
+			CostPerCoreHour: budgetCostPerCoreHour,
+			Pause:           budgetPause,
+		}
+		err := budgetClient(func(jq *jobqueue.Client) error {
+			var errs error
+			budget, errs = jq.SetBudget(budget)
+			return errs
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		info("budget set; %s", budget)
+	},
+}
+
+// list sub-command shows budgets
+var budgetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List report group budgets",
+	Long: `List report group budgets and how much of them has been consumed.
+
+The default -o plain output has tab separated columns of the report group, the
+CPU-hours used and limit, the cost used and limit, whether the report group
+will be paused, the alarm state (ok, warning or exceeded) and when consumption
+started being recorded. Limits of 0 mean no limit. -o json outputs the budgets
+as an array of JSON objects.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var budgets []*jobqueue.Budget
+		err := budgetClient(func(jq *jobqueue.Client) error {
+			var errg error
+			budgets, errg = jq.GetBudgets()
+			return errg
+		})
+		if err != nil {
+			die("%s", err)
+		}
+
+		switch budgetOutput {
+		case "json", "j":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetEscapeHTML(false)
+			if budgets == nil {
+				budgets = []*jobqueue.Budget{}
+			}
+			err = encoder.Encode(budgets)
+			if err != nil {
+				die("failed to encode budgets: %s", err)
+			}
+		case "plain", "p":
+			for _, b := range budgets {
+				alarm := string(b.Alarm)
+				if b.Alarm == jobqueue.BudgetAlarmNone {
+					alarm = "ok"
+				}
+				fmt.Printf("%s\t%.2f\t%g\t%.2f\t%g\t%t\t%s\t%s\n", b.RepGroup, b.UsedCPUHours, b.CPUHours,
+					b.UsedCost(), b.Cost, b.Pause, alarm, b.Since.Format(time.RFC3339))
+			}
+		default:
+			die("invalid -o format specified")
+		}
+	},
+}
+
+// delete sub-command removes a budget
+var budgetDeleteCmd = &cobra.Command{
+	Use:   "delete REPORT_GROUP",
+	Short: "Delete the budget of a report group",
+	Long: `Delete the budget of a report group, along with the record of how much
+of it was consumed.
+
+Commands that were buried because the budget was exceeded stay buried until you
+"wr retry" them.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := budgetClient(func(jq *jobqueue.Client) error {
+			return jq.DeleteBudget(args[0])
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		info("budget of %s deleted", args[0])
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(budgetCmd)
+	budgetCmd.AddCommand(budgetSetCmd)
+	budgetCmd.AddCommand(budgetListCmd)
+	budgetCmd.AddCommand(budgetDeleteCmd)
+
+	// flags specific to these sub-commands
+	budgetSetCmd.Flags().Float64Var(&budgetCPUHours, "cpu_hours", 0, "maximum CPU-hours the report group may consume; 0 for no limit")
+	budgetSetCmd.Flags().Float64Var(&budgetCost, "cost", 0, "maximum cost the report group may incur; 0 for no limit")
+	budgetSetCmd.Flags().Float64Var(&budgetCostPerCoreHour, "cost_per_core_hour", 0, "the cost of 1 CPU-hour, for --cost")
+	budgetSetCmd.Flags().BoolVar(&budgetPause, "pause", false, "bury the report group's waiting commands once the budget is exceeded")
+	budgetListCmd.Flags().StringVarP(&budgetOutput, "output", "o", "plain", "['plain','json'] output format")
+
+	budgetCmd.PersistentFlags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// budgetClient connects to the manager, calls the given function with the
+// client, and disconnects.
+func budgetClient(f func(jq *jobqueue.Client) error) error {
+	jq := connect(time.Duration(timeoutint) * time.Second)
+	defer func() {
+		err := jq.Disconnect()
+		if err != nil {
+			warn("Disconnecting from the server failed: %s", err)
+		}
+	}()
+	return f(jq)
+}
@@ -21,11 +21,14 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -64,6 +67,9 @@ var osDisk int
 var flavorRegex string
 var managerFlavor string
 var flavorSets string
+var cloudNetworkGroups string
+var cloudFlavorScripts string
+var cloudVolumeMount string
 var postCreationScript string
 var postDeploymentScript string
 var cloudSpawns int
@@ -72,6 +78,7 @@ var cloudCIDR string
 var cloudDNS string
 var cloudConfigFiles string
 var forceTearDown bool
+var cloudTearDownDryRun bool
 var setDomainIP bool
 var cloudDebug bool
 var cloudManagerTimeoutSeconds int
@@ -82,6 +89,9 @@ var cloudServersAll bool
 var cloudServerID string
 var cloudServersConfirmDead bool
 var cloudServersAutoConfirmDead int
+var cloudListAll bool
+var cloudImageName string
+var cloudImageTimeout int
 
 // cloudCmd represents the cloud command
 var cloudCmd = &cobra.Command{
@@ -208,11 +218,24 @@ http:// urls, this is most likely to succeed if you use an IP address instead of
 a domain name. For https:// urls you'll need a domain name, and will have to
 ask your administrator for the appropriate --network_dns settings (or clouddns
 config option) to use; the DNS must be able to resolve the domain name from
-within OpenStack.`,
+within OpenStack.
+
+If you have built an image with 'wr cloud image build', it is used by default
+instead of the cloudos and clouduser config options; supply --os (and
+--username) to use a different image.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if providerName == "" {
 			die("--provider is required")
 		}
+		if !cmd.Flags().Changed("os") {
+			if image := registeredCloudImage(providerName); image != nil {
+				osPrefix = image.ID
+				if !cmd.Flags().Changed("username") {
+					osUsername = image.Username
+				}
+				info("using image %s (%s) built by 'wr cloud image build'", image.Name, image.ID)
+			}
+		}
 		if osPrefix == "" {
 			die("--os is required")
 		}
@@ -284,6 +307,7 @@ within OpenStack.`,
 			GatewayIP:      cloudGatewayIP,
 			CIDR:           cloudCIDR,
 			DNSNameServers: strings.Split(cloudDNS, ","),
+			Version:        jobqueue.ServerVersion,
 		})
 		if err != nil {
 			die("failed to create resources in %s: %s", providerName, err)
@@ -450,7 +474,13 @@ that cloud deployment.
 
 If you don't back up to S3, the teardown command tries to copy the remote
 database locally, which is only possible while the remote server is still up
-and accessible.`,
+and accessible.
+
+Resources are found by the names wr gave them, so teardown deletes everything
+created for your --resource_name (servers, their volumes and floating IPs,
+networks, security groups and keys) even if the local record of what was
+created has been lost; use --force in that case. To see what would be deleted
+without deleting anything or stopping the manager, use --dry-run.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if providerName == "" {
 			die("--provider is required")
@@ -465,6 +495,22 @@ and accessible.`,
 			die("failed to connect to %s: %s", providerName, err)
 		}
 
+		if cloudTearDownDryRun {
+			found, errd := provider.Discover()
+			if errd != nil {
+				warn("not all resources could be looked for: %s", errd)
+			}
+			if len(found) == 0 {
+				info("no %s resources were found for %s", providerName, cloudResourceName(cloudResourceNameUniquer))
+				return
+			}
+			info("teardown would delete these %s resources:", providerName)
+			for _, r := range found {
+				fmt.Println(" " + describeCloudResource(r))
+			}
+			return
+		}
+
 		// now check if the ssh forwarding is up
 		fmPidFile := filepath.Join(config.ManagerDir, "cloud_resources."+providerName+".fm.pid")
 		fmPid, fmRunning := checkProcess(fmPidFile)
@@ -712,11 +758,211 @@ lost, at which point they will automatically be confirmed dead.`,
 	},
 }
 
+// list sub-command shows what cloud resources exist
+var cloudListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List cloud resources created by wr",
+	Long: `List cloud resources created by wr.
+
+Without --all, lists the resources that belong to your --resource_name
+deployment, which are what 'wr cloud teardown' would delete.
+
+Resources that support it (servers, networks, subnets and routers) are tagged
+with the name of the deployment they belong to, the host that managed it, when
+they were created and the version of wr that created them. With --all, every
+resource with those tags that you can see is listed, grouped by deployment,
+regardless of who created them. This lets you find resources left behind by
+deployments whose local records were lost, or that belong to other users of
+your tenant.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if providerName == "" {
+			die("--provider is required")
+		}
+
+		logger := setupLogging(cloudDebug)
+		provider, err := cloud.New(providerName, cloudResourceName(cloudResourceNameUniquer), filepath.Join(config.ManagerDir, "cloud_resources."+providerName), logger)
+		if err != nil {
+			die("failed to connect to %s: %s", providerName, err)
+		}
+
+		if !cloudListAll {
+			found, errd := provider.Discover()
+			if errd != nil {
+				warn("not all resources could be looked for: %s", errd)
+			}
+			if len(found) == 0 {
+				info("no %s resources were found for %s", providerName, cloudResourceName(cloudResourceNameUniquer))
+				return
+			}
+			for _, r := range found {
+				fmt.Println(describeCloudResource(r))
+			}
+			return
+		}
+
+		found, err := provider.TaggedResources()
+		if err != nil {
+			warn("not all resources could be looked for: %s", err)
+		}
+		if len(found) == 0 {
+			info("no tagged %s resources were found", providerName)
+			return
+		}
+		sort.Slice(found, func(i, j int) bool {
+			if found[i].Tags[cloud.TagDeployment] != found[j].Tags[cloud.TagDeployment] {
+				return found[i].Tags[cloud.TagDeployment] < found[j].Tags[cloud.TagDeployment]
+			}
+			return found[i].Tags[cloud.TagCreated] < found[j].Tags[cloud.TagCreated]
+		})
+		var deployment string
+		for _, r := range found {
+			if r.Tags[cloud.TagDeployment] != deployment {
+				deployment = r.Tags[cloud.TagDeployment]
+				fmt.Printf("%s:\n", deployment)
+			}
+			fmt.Println(" " + describeCloudResource(r))
+		}
+	},
+}
+
+// image sub-command lets you build OS images for spawned servers to use
+var cloudImageCmd = &cobra.Command{
+	Use:   "image",
+	Short: "Cloud OS image creation",
+	Long: `Cloud OS image creation.
+
+Rather than install the software your commands need on every server that gets
+spawned, with a slow and error-prone --script to 'wr cloud deploy', you can
+build an OS image with the software already installed using the image
+sub-commands.`,
+}
+
+// image build sub-command spawns a server, provisions it, and snapshots it
+var cloudImageBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build an OS image with your software installed",
+	Long: `Build an OS image with your software installed.
+
+Build creates temporary cloud resources and spawns a server using the --os base
+image, on which it runs your --script provisioning script (as --username, so
+prefix commands with 'sudo' as necessary; the script has a time limit of 15
+mins) after copying over any --config_files. The server is then snapshotted to
+create a new image called --name (defaulting to one based on --resource_name
+and the current time), and the temporary resources are torn down.
+
+The new image is registered as the default for subsequent 'wr cloud deploy'
+runs with the same --provider, which will use it (and --username) instead of
+your cloudos and clouduser config options unless you supply --os. Because the
+image is built from a script, you can rebuild it reproducibly whenever your
+software or the base image changes.
+
+The same environment variables are needed as for 'wr cloud deploy'. Snapshots
+can take a long time to upload, so build waits up to --timeout minutes for the
+new image to become usable.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if providerName == "" {
+			die("--provider is required")
+		}
+		if osPrefix == "" {
+			die("--os is required")
+		}
+		if osUsername == "" {
+			die("--username is required")
+		}
+		if postCreationScript == "" {
+			die("--script is required")
+		}
+		script, err := ioutil.ReadFile(postCreationScript)
+		if err != nil {
+			die("--script %s could not be read: %s", postCreationScript, err)
+		}
+		if len(cloudResourceNameUniquer) > maxCloudResourceUsernameLength {
+			die("--resource_name must be %d characters or less", maxCloudResourceUsernameLength)
+		}
+
+		createWorkingDir()
+
+		name := cloudImageName
+		if name == "" {
+			name = cloudResourceName(cloudResourceNameUniquer) + "-" + time.Now().Format("20060102-150405")
+		}
+
+		// we use our own resources, so that we don't interfere with any
+		// deployment
+		cloudLogger := setupLogging(cloudDebug)
+		provider, err := cloud.New(providerName, cloudResourceName(cloudResourceNameUniquer)+"-image", filepath.Join(config.ManagerDir, "cloud_resources."+providerName+".image"), cloudLogger)
+		if err != nil {
+			die("failed to connect to %s: %s", providerName, err)
+		}
+		info("please wait while %s resources are created...", providerName)
+		err = provider.Deploy(&cloud.DeployConfig{
+			RequiredPorts:  []int{22},
+			GatewayIP:      cloudGatewayIP,
+			CIDR:           cloudCIDR,
+			DNSNameServers: strings.Split(cloudDNS, ","),
+			Version:        jobqueue.ServerVersion,
+		})
+		if err != nil {
+			teardown(provider)
+			die("failed to create resources in %s: %s", providerName, err)
+		}
+
+		info("please wait while a server is spawned on %s...", providerName)
+		flavor, err := provider.CheapestServerFlavor(1, osRAM, flavorRegex)
+		if err != nil {
+			teardown(provider)
+			die("failed to launch a server in %s: %s", providerName, err)
+		}
+		server, err := provider.Spawn(osPrefix, osUsername, flavor.ID, osDisk, 0*time.Second, true)
+		if err != nil {
+			teardown(provider)
+			die("failed to launch a server in %s: %s", providerName, err)
+		}
+
+		info("please wait while --script provisions the server at %s...", server.IP)
+		err = server.WaitUntilReady(context.Background(), cloudConfigFiles, script)
+		if err != nil {
+			teardown(provider)
+			die("failed to provision the server: %s", err)
+		}
+
+		// make sure everything the script wrote is on disk before we snapshot
+		_, _, err = server.RunCmd(context.Background(), "sync", false)
+		if err != nil {
+			warn("failed to sync the server's disk: %s", err)
+		}
+
+		info("please wait while the server is snapshotted as image %s...", name)
+		imageID, err := provider.Snapshot(server.ID, name, time.Duration(cloudImageTimeout)*time.Minute)
+		teardown(provider)
+		if err != nil {
+			if imageID != "" {
+				die("failed to create image %s (%s): %s", name, imageID, err)
+			}
+			die("failed to create image %s: %s", name, err)
+		}
+
+		err = registerCloudImage(providerName, &cloudImage{
+			ID:       imageID,
+			Name:     name,
+			Username: osUsername,
+			Built:    time.Now(),
+		})
+		if err != nil {
+			die("created image %s (%s), but failed to register it as the default: %s", name, imageID, err)
+		}
+		info("created image %s (%s), which 'wr cloud deploy' will now use by default", name, imageID)
+	},
+}
+
 func init() {
 	RootCmd.AddCommand(cloudCmd)
 	cloudCmd.AddCommand(cloudDeployCmd)
 	cloudCmd.AddCommand(cloudTearDownCmd)
 	cloudCmd.AddCommand(cloudServersCmd)
+	cloudCmd.AddCommand(cloudListCmd)
+	cloudCmd.AddCommand(cloudImageCmd)
+	cloudImageCmd.AddCommand(cloudImageBuildCmd)
 
 	// flags specific to these sub-commands
 	defaultConfig := internal.DefaultConfig(appLogger)
@@ -733,6 +979,9 @@ func init() {
 	}
 	cloudDeployCmd.Flags().StringVar(&managerFlavor, "manager_flavor", defaultConfig.CloudFlavorManager, "like --flavor, but specific to the first server created to run the manager"+defaultNote)
 	cloudDeployCmd.Flags().StringVar(&flavorSets, "flavor_sets", defaultConfig.CloudFlavorSets, "sets of flavors assigned to different hardware, in the form f1,f2;f3,f4")
+	cloudDeployCmd.Flags().StringVar(&cloudNetworkGroups, "network_groups", defaultConfig.CloudNetworkGroups, "security groups giving jobs the network access they need, in the form need1=group1,need2=group2")
+	cloudDeployCmd.Flags().StringVar(&cloudFlavorScripts, "flavor_scripts", defaultConfig.CloudFlavorScripts, "templated scripts to run on servers with particular flavors after --script, in the form regex1=path1;regex2=path2")
+	cloudDeployCmd.Flags().StringVar(&cloudVolumeMount, "volume_mount", defaultConfig.CloudVolumeMount, "path to mount the volumes of commands added with --cloud_volume at")
 	cloudDeployCmd.Flags().StringVarP(&postCreationScript, "script", "s", defaultConfig.CloudScript, "path to a start-up script that will be run on each server created")
 	cloudDeployCmd.Flags().IntVar(&cloudSpawns, "max_spawns", defaultConfig.CloudSpawns, "maximum number of simultaneous server spawns during scale-up")
 	cloudDeployCmd.Flags().IntVar(&maxManagerCores, "max_local_cores", -1, "maximum number of manager cores to use to run cmds; -1 means unlimited")
@@ -752,8 +1001,30 @@ func init() {
 	cloudTearDownCmd.Flags().StringVarP(&providerName, "provider", "p", "openstack", "['openstack'] cloud provider")
 	cloudTearDownCmd.Flags().StringVar(&cloudResourceNameUniquer, "resource_name", realUsername(), "name you set during deploy")
 	cloudTearDownCmd.Flags().BoolVarP(&forceTearDown, "force", "f", false, "force teardown even when the remote manager cannot be accessed")
+	cloudTearDownCmd.Flags().BoolVar(&cloudTearDownDryRun, "dry-run", false, "only list the resources that would be deleted")
 	cloudTearDownCmd.Flags().BoolVar(&cloudDebug, "debug", false, "show details of the teardown process")
 
+	cloudListCmd.Flags().StringVarP(&providerName, "provider", "p", "openstack", "['openstack'] cloud provider")
+	cloudListCmd.Flags().StringVar(&cloudResourceNameUniquer, "resource_name", realUsername(), "name you set during deploy")
+	cloudListCmd.Flags().BoolVarP(&cloudListAll, "all", "a", false, "list tagged resources of all deployments")
+	cloudListCmd.Flags().BoolVar(&cloudDebug, "debug", false, "show details of the search process")
+
+	cloudImageBuildCmd.Flags().StringVarP(&providerName, "provider", "p", "openstack", "['openstack'] cloud provider")
+	cloudImageBuildCmd.Flags().StringVar(&cloudResourceNameUniquer, "resource_name", realUsername(), fmt.Sprintf("name to be included when naming cloud resources (should be unique to you, max length %d)", maxCloudResourceUsernameLength))
+	cloudImageBuildCmd.Flags().StringVarP(&osPrefix, "os", "o", defaultConfig.CloudOS, "prefix of name, or ID, of the base OS image to build on")
+	cloudImageBuildCmd.Flags().StringVarP(&osUsername, "username", "u", defaultConfig.CloudUser, "username needed to log in to the OS image specified by --os")
+	cloudImageBuildCmd.Flags().IntVarP(&osRAM, "os_ram", "r", defaultConfig.CloudRAM, "ram (MB) needed by the OS image specified by --os")
+	cloudImageBuildCmd.Flags().IntVarP(&osDisk, "os_disk", "d", defaultConfig.CloudDisk, "minimum disk (GB) for the server")
+	cloudImageBuildCmd.Flags().StringVarP(&flavorRegex, "flavor", "f", defaultConfig.CloudFlavor, "a regular expression to limit the server flavor that can be picked")
+	cloudImageBuildCmd.Flags().StringVarP(&postCreationScript, "script", "s", "", "path to a provisioning script to run on the server before snapshotting it")
+	cloudImageBuildCmd.Flags().StringVarP(&cloudConfigFiles, "config_files", "c", "", "comma separated paths of config files to copy to the server")
+	cloudImageBuildCmd.Flags().StringVarP(&cloudImageName, "name", "n", "", "name of the image to create")
+	cloudImageBuildCmd.Flags().IntVarP(&cloudImageTimeout, "timeout", "t", 60, "how long to wait in minutes for the image to become usable")
+	cloudImageBuildCmd.Flags().StringVar(&cloudGatewayIP, "network_gateway_ip", defaultConfig.CloudGateway, "gateway IP for the created subnet")
+	cloudImageBuildCmd.Flags().StringVar(&cloudCIDR, "network_cidr", defaultConfig.CloudCIDR, "CIDR of the created subnet")
+	cloudImageBuildCmd.Flags().StringVar(&cloudDNS, "network_dns", defaultConfig.CloudDNS, "comma separated DNS name server IPs to use in the created subnet")
+	cloudImageBuildCmd.Flags().BoolVar(&cloudDebug, "debug", false, "show details of the build process")
+
 	cloudServersCmd.Flags().BoolVarP(&cloudServersAll, "all", "a", false, "confirm all maybe dead servers as dead")
 	cloudServersCmd.Flags().StringVarP(&cloudServerID, "identifier", "i", "", "identifier of a server to confirm as dead")
 	cloudServersCmd.Flags().BoolVarP(&cloudServersConfirmDead, "confirmdead", "d", false, "confirm that 1 (-i) or all (-a) servers are dead [default: just list possibly dead servers]")
@@ -915,6 +1186,27 @@ func bootstrapOnRemote(provider *cloud.Provider, server *cloud.Server, exe strin
 			postCreationArg = " -p " + remoteScriptFile
 		}
 
+		if cloudFlavorScripts != "" {
+			// copy over the flavor scripts as well, pointing the remote manager
+			// at the uploaded copies
+			specs, errp := parseFlavorScripts(cloudFlavorScripts)
+			if errp != nil {
+				teardown(provider)
+				die("bad --flavor_scripts: %s", errp)
+			}
+			remoteSpecs := make([]string, len(specs))
+			for i, spec := range specs {
+				remoteScriptFile := filepath.Join("./.wr_"+config.Deployment, "cloud_resources."+providerName+".flavor_script."+strconv.Itoa(i))
+				err = server.UploadFile(ctx, spec.path, remoteScriptFile)
+				if err != nil && !wrMayHaveStarted {
+					teardown(provider)
+					die("failed to upload wr cloud flavor script file to the server at %s: %s", server.IP, err)
+				}
+				remoteSpecs[i] = spec.regex + "=" + remoteScriptFile
+			}
+			postCreationArg += " --cloud_flavor_scripts '" + strings.Join(remoteSpecs, ";") + "'"
+		}
+
 		var configFilesArg string
 		if cloudConfigFiles != "" {
 			// strip any local file locations
@@ -938,6 +1230,12 @@ func bootstrapOnRemote(provider *cloud.Provider, server *cloud.Server, exe strin
 		if flavorSets != "" {
 			flavorArg += " --cloud_flavor_sets '" + flavorSets + "'"
 		}
+		if cloudNetworkGroups != "" {
+			flavorArg += " --cloud_network_groups '" + cloudNetworkGroups + "'"
+		}
+		if cloudVolumeMount != "" {
+			flavorArg += " --cloud_volume_mount '" + cloudVolumeMount + "'"
+		}
 
 		var osDiskArg string
 		if osDisk > 0 {
@@ -1074,6 +1372,93 @@ func killProcess(pid int) error {
 	return process.Signal(syscall.Signal(9))
 }
 
+// cloudImage describes an image created by 'wr cloud image build'.
+type cloudImage struct {
+	ID       string
+	Name     string
+	Username string
+	Built    time.Time
+}
+
+// cloudImagePath returns the path to the file that records the image built for
+// the given provider.
+func cloudImagePath(providerName string) string {
+	return filepath.Join(config.ManagerDir, "cloud_image."+providerName)
+}
+
+// registerCloudImage records the given image as the default for future
+// deployments to the given provider.
+func registerCloudImage(providerName string, image *cloudImage) error {
+	data, err := json.Marshal(image)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(cloudImagePath(providerName), data, 0600)
+}
+
+// registeredCloudImage returns the image previously recorded with
+// registerCloudImage() for the given provider, or nil if there isn't one.
+func registeredCloudImage(providerName string) *cloudImage {
+	data, err := ioutil.ReadFile(cloudImagePath(providerName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			warn("could not read the registered image: %s", err)
+		}
+		return nil
+	}
+	image := &cloudImage{}
+	err = json.Unmarshal(data, image)
+	if err != nil || image.ID == "" {
+		warn("ignoring bad registered image file %s", cloudImagePath(providerName))
+		return nil
+	}
+	return image
+}
+
+// describeCloudResource returns a one line description of the given resource,
+// including any tags it has.
+func describeCloudResource(r *cloud.CreatedResource) string {
+	desc := fmt.Sprintf("%s %s (%s)", r.Kind, r.ID, r.Name)
+	if created := r.Tags[cloud.TagCreated]; created != "" {
+		desc += "; created " + created
+	}
+	if host := r.Tags[cloud.TagManagerHost]; host != "" {
+		desc += "; managed from " + host
+	}
+	if version := r.Tags[cloud.TagVersion]; version != "" {
+		desc += "; wr " + version
+	}
+	return desc
+}
+
+// flavorScriptSpec is one regex=path entry of a --flavor_scripts value.
+type flavorScriptSpec struct {
+	regex string
+	path  string
+}
+
+// parseFlavorScripts parses a regex1=path1;regex2=path2 value, as given to
+// --flavor_scripts or --cloud_flavor_scripts. The split is made on the last
+// "=" of each entry, since regexes may contain "=" themselves.
+func parseFlavorScripts(value string) ([]flavorScriptSpec, error) {
+	var specs []flavorScriptSpec
+	for _, entry := range strings.Split(value, ";") {
+		if entry == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, "=")
+		if i < 1 || i == len(entry)-1 {
+			return nil, fmt.Errorf("%q is not in the form regex=path", entry)
+		}
+		regex := entry[:i]
+		if _, err := regexp.Compile(regex); err != nil {
+			return nil, fmt.Errorf("%q is not a valid regular expression: %s", regex, err)
+		}
+		specs = append(specs, flavorScriptSpec{regex: regex, path: internal.TildaToHome(entry[i+1:])})
+	}
+	return specs, nil
+}
+
 func teardown(p *cloud.Provider) {
 	err := p.TearDown()
 	if err != nil {
@@ -0,0 +1,396 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// completionTimeout is how long the completion values sub-command waits for the
+// manager, which must be short so that interactive completion doesn't hang.
+const completionTimeout = 2 * time.Second
+
+// completion value kinds understood by the completion values sub-command
+const (
+	completeRepGroups   = "repgroups"
+	completeJobKeys     = "jobkeys"
+	completeDeployments = "deployments"
+)
+
+// names of the shell functions that complete flag values; they are defined in
+// both bashCompletionFunctions and fishCompletionFunctions
+const (
+	completeFuncIdentifier = "__wr_complete_identifier"
+	completeFuncRepGroups  = "__wr_complete_repgroups"
+	completeFuncDeployment = "__wr_complete_deployment"
+)
+
+// bashCompletionFunctions are the custom functions used by our generated bash
+// completion script to complete flag values by asking the manager.
+const bashCompletionFunctions = `
+__wr_deployment_args()
+{
+    local i
+    for (( i=1; i < ${#words[@]}; i++ )); do
+        if [[ ${words[i]} == --deployment ]]; then
+            echo "--deployment ${words[i+1]}"
+            return
+        elif [[ ${words[i]} == --deployment=* ]]; then
+            echo "${words[i]}"
+            return
+        fi
+    done
+}
+
+__wr_complete_values()
+{
+    local values
+    values=$(wr completion values "$1" $(__wr_deployment_args) 2>/dev/null)
+    COMPREPLY=( $(compgen -W "${values}" -- "${cur}") )
+}
+
+__wr_complete_repgroups()
+{
+    __wr_complete_values repgroups
+}
+
+__wr_complete_identifier()
+{
+    local w
+    for w in "${words[@]}"; do
+        if [[ ${w} == -y || ${w} == --internal ]]; then
+            __wr_complete_values jobkeys
+            return
+        fi
+    done
+    __wr_complete_values repgroups
+}
+
+__wr_complete_deployment()
+{
+    __wr_complete_values deployments
+}
+`
+
+// fishCompletionFunctions are the equivalent of bashCompletionFunctions for
+// our generated fish completion script, along with a function to tell which
+// sub-command is being completed.
+const fishCompletionFunctions = `function __wr_using_command
+    set -l words
+    for w in (commandline -opc)[2..-1]
+        if not string match -q -- '-*' $w
+            set words $words $w
+        end
+    end
+    test "$words[1..(count $argv)]" = "$argv"
+end
+
+function __wr_complete_command_exactly
+    set -l words
+    for w in (commandline -opc)[2..-1]
+        if not string match -q -- '-*' $w
+            set words $words $w
+        end
+    end
+    test "$words" = "$argv"
+end
+
+function __wr_deployment_args
+    set -l tokens (commandline -opc)
+    for i in (seq (count $tokens))
+        if test "$tokens[$i]" = --deployment
+            set -l next (math $i + 1)
+            echo --deployment $tokens[$next]
+            return
+        else if string match -q -- '--deployment=*' $tokens[$i]
+            echo $tokens[$i]
+            return
+        end
+    end
+end
+
+function __wr_complete_values
+    wr completion values $argv[1] (__wr_deployment_args) 2>/dev/null
+end
+
+function __wr_complete_repgroups
+    __wr_complete_values repgroups
+end
+
+function __wr_complete_identifier
+    if contains -- -y (commandline -opc); or contains -- --internal (commandline -opc)
+        __wr_complete_values jobkeys
+    else
+        __wr_complete_values repgroups
+    end
+end
+
+function __wr_complete_deployment
+    __wr_complete_values deployments
+end
+`
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion",
+	Short: "Generate shell completion scripts",
+	Long: `Generate shell completion scripts.
+
+The sub-commands output a script that lets your shell complete wr's sub-commands
+and options when you press tab. The values of options that identify your
+commands (eg. the -i of "wr status", "wr retry" and "wr remove"), and
+--deployment, are completed by asking the running manager, so you don't have to
+copy and paste long report group names or internal job ids (with -y).
+
+To load completions in your current shell:
+
+bash:  source <(wr completion bash)
+zsh:   source <(wr completion zsh)
+fish:  wr completion fish | source
+
+To load them for every new shell, add the appropriate line to your ~/.bashrc or
+~/.zshrc, or for fish save the output to
+~/.config/fish/completions/wr.fish.`,
+}
+
+// bash sub-command generates a bash completion script
+var completionBashCmd = &cobra.Command{
+	Use:   "bash",
+	Short: "Generate a bash completion script",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := genBashCompletion(os.Stdout); err != nil {
+			die("failed to generate bash completion: %s", err)
+		}
+	},
+}
+
+// zsh sub-command generates a zsh completion script
+var completionZshCmd = &cobra.Command{
+	Use:   "zsh",
+	Short: "Generate a zsh completion script",
+	Long: `Generate a zsh completion script.
+
+The script uses zsh's bash completion compatibility, so that it can complete
+option values by asking the running manager.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("autoload -U +X compinit && compinit")
+		fmt.Println("autoload -U +X bashcompinit && bashcompinit")
+		if err := genBashCompletion(os.Stdout); err != nil {
+			die("failed to generate zsh completion: %s", err)
+		}
+	},
+}
+
+// fish sub-command generates a fish completion script
+var completionFishCmd = &cobra.Command{
+	Use:   "fish",
+	Short: "Generate a fish completion script",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := genFishCompletion(os.Stdout); err != nil {
+			die("failed to generate fish completion: %s", err)
+		}
+	},
+}
+
+// values sub-command is used by the completion scripts to get the possible
+// values of options from the manager
+var completionValuesCmd = &cobra.Command{
+	Use:    "values KIND",
+	Short:  "Print possible option values for shell completion",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// we never output errors, since that would just confuse the user's
+		// shell
+		for _, value := range completionValues(args[0]) {
+			fmt.Println(value)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(completionCmd)
+	completionCmd.AddCommand(completionBashCmd)
+	completionCmd.AddCommand(completionZshCmd)
+	completionCmd.AddCommand(completionFishCmd)
+	completionCmd.AddCommand(completionValuesCmd)
+}
+
+// completionValues returns the possible values of the given kind, asking the
+// manager if necessary. Returns nothing if the manager can't be reached.
+func completionValues(kind string) []string {
+	if kind == completeDeployments {
+		return []string{internal.Production, internal.Development}
+	}
+
+	token, err := token()
+	if err != nil {
+		return nil
+	}
+	jq, err := jobqueue.Connect(net.JoinHostPort(config.ManagerHost, config.ManagerPort), caFile, config.ManagerCertDomain, token, completionTimeout)
+	if err != nil {
+		return nil
+	}
+	defer func() {
+		err = jq.Disconnect()
+		if err != nil {
+			appLogger.Debug("disconnecting from the server failed", "err", err)
+		}
+	}()
+
+	switch kind {
+	case completeRepGroups:
+		rgs, errg := jq.GetRepGroups()
+		if errg != nil {
+			return nil
+		}
+		return rgs
+	case completeJobKeys:
+		jobs, errg := jq.GetIncomplete(0, "", false, false)
+		if errg != nil {
+			return nil
+		}
+		keys := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			keys = append(keys, job.Key())
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	return nil
+}
+
+// markCompletionFlags annotates the flags of all our commands that should have
+// their values completed by one of our custom completion functions.
+func markCompletionFlags() {
+	markFlagCompletion(RootCmd.PersistentFlags(), "deployment", completeFuncDeployment)
+	walkCommands(RootCmd, func(c *cobra.Command) {
+		flags := c.Flags()
+		switch {
+		case flags.Lookup("identifier") != nil && flags.Lookup("internal") != nil:
+			markFlagCompletion(flags, "identifier", completeFuncIdentifier)
+		case c == addCmd:
+			markFlagCompletion(flags, "rep_grp", completeFuncRepGroups)
+		}
+	})
+}
+
+// markFlagCompletion annotates the named flag to be completed by the given
+// shell function, logging any failure to do so.
+func markFlagCompletion(flags *pflag.FlagSet, name, function string) {
+	if err := cobra.MarkFlagCustom(flags, name, function); err != nil {
+		appLogger.Debug("failed to mark flag for completion", "flag", name, "err", err)
+	}
+}
+
+// walkCommands calls the given function on the given command and all its
+// non-hidden descendants.
+func walkCommands(c *cobra.Command, f func(*cobra.Command)) {
+	f(c)
+	for _, sub := range c.Commands() {
+		if sub.Hidden || sub.Name() == "help" {
+			continue
+		}
+		walkCommands(sub, f)
+	}
+}
+
+// genBashCompletion writes our bash completion script to the given writer.
+func genBashCompletion(w io.Writer) error {
+	markCompletionFlags()
+	RootCmd.BashCompletionFunction = bashCompletionFunctions
+	return RootCmd.GenBashCompletion(w)
+}
+
+// genFishCompletion writes our fish completion script to the given writer.
+func genFishCompletion(w io.Writer) error {
+	markCompletionFlags()
+	var buf bytes.Buffer
+	buf.WriteString("# fish completion for wr\n\n")
+	buf.WriteString(fishCompletionFunctions)
+	buf.WriteString("\ncomplete -c wr -f\n")
+
+	writeFishFlags(&buf, "", RootCmd.PersistentFlags())
+	walkCommands(RootCmd, func(c *cobra.Command) {
+		path := fishCommandPath(c)
+		for _, sub := range c.Commands() {
+			if sub.Hidden || sub.Name() == "help" {
+				continue
+			}
+			fmt.Fprintf(&buf, "complete -c wr -n '%s' -a %s -d %s\n", strings.TrimSpace("__wr_complete_command_exactly "+path), sub.Name(), fishQuote(sub.Short))
+		}
+		if c != RootCmd {
+			writeFishFlags(&buf, "__wr_using_command "+path, c.LocalNonPersistentFlags())
+		}
+	})
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// fishCommandPath returns the names of the sub-commands leading to the given
+// command, space separated.
+func fishCommandPath(c *cobra.Command) string {
+	var names []string
+	for ; c != nil && c != RootCmd; c = c.Parent() {
+		names = append([]string{c.Name()}, names...)
+	}
+	return strings.Join(names, " ")
+}
+
+// writeFishFlags writes fish complete lines for the given flags, that apply
+// when the given fish condition is true (or always, if it is blank).
+func writeFishFlags(buf *bytes.Buffer, condition string, flags *pflag.FlagSet) {
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		line := "complete -c wr"
+		if condition != "" {
+			line += fmt.Sprintf(" -n '%s'", condition)
+		}
+		line += " -l " + flag.Name
+		if flag.Shorthand != "" {
+			line += " -s " + flag.Shorthand
+		}
+		if funcs, custom := flag.Annotations[cobra.BashCompCustom]; custom && len(funcs) > 0 {
+			line += fmt.Sprintf(" -x -a '(%s)'", funcs[0])
+		} else if flag.Value.Type() != "bool" {
+			line += " -r -F"
+		}
+		buf.WriteString(line + " -d " + fishQuote(flag.Usage) + "\n")
+	})
+}
+
+// fishQuote returns the given string single quoted for fish.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
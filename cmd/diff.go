@@ -0,0 +1,255 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// options for this cmd
+var diffRepGroups []string
+var diffSearch bool
+var diffThreshold int
+var diffOutput string
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the commands of two runs of a workflow",
+	Long: `Compare the commands of two runs of a workflow.
+
+To help you validate a change to your pipeline against a previous run of it,
+compare the commands in 2 report groups (the ones you supplied to "wr add -i"),
+A and B, by giving --repgroup twice: first A, then B. With -z, each --repgroup is
+treated as a substring to match against all report groups, so you can compare
+workflow runs that each span several report groups.
+
+Commands are matched between A and B by their command line. Reported are:
+
+ - the commands that are only in A, or only in B
+ - the commands that completed in A but were buried in B, and vice versa
+ - the commands that completed in both, but whose wall time in B was more than
+   --threshold percent longer than in A
+
+Commands that haven't finished in one of the runs are only considered for the
+first of these.
+
+The default -o table output lists each of these in turn. -o json outputs them
+as a JSON object.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(diffRepGroups) != 2 {
+			die("--repgroup must be supplied exactly twice")
+		}
+		if diffThreshold < 0 {
+			die("--threshold can't be negative")
+		}
+
+		timeout := time.Duration(timeoutint) * time.Second
+		jq := connect(timeout)
+		var err error
+		defer func() {
+			err = jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		runs := make([][]*jobqueue.Job, 2)
+		for i, rg := range diffRepGroups {
+			runs[i], err = jq.GetByRepGroup(rg, diffSearch, 0, "", false, false)
+			if err != nil {
+				die("failed to get the commands of %s: %s", rg, err)
+			}
+			if len(runs[i]) == 0 {
+				die("no commands found in %s", rg)
+			}
+		}
+
+		diff := jobqueue.DiffJobs(runs[0], runs[1], float64(diffThreshold)/100)
+
+		switch diffOutput {
+		case "json", "j":
+			printDiffJSON(diff, len(runs[0]), len(runs[1]))
+		case "table", "t":
+			printDiffTable(diff, len(runs[0]), len(runs[1]))
+		default:
+			die("invalid -o format specified")
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(diffCmd)
+
+	// flags specific to this sub-command
+	diffCmd.Flags().StringArrayVar(&diffRepGroups, "repgroup", nil, "report group of the commands to compare; supply twice, A then B")
+	diffCmd.Flags().BoolVarP(&diffSearch, "search", "z", false, "treat --repgroup as a substring to match against all report groups")
+	diffCmd.Flags().IntVar(&diffThreshold, "threshold", 20, "percentage by which wall time in B must exceed that in A to be reported")
+	diffCmd.Flags().StringVarP(&diffOutput, "output", "o", "table", "['table','json'] output format")
+
+	diffCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// printDiffTable prints each kind of difference in the given JobsDiff in turn.
+func printDiffTable(diff *jobqueue.JobsDiff, numA, numB int) {
+	fmt.Printf("A: %s (%d commands)\nB: %s (%d commands)\n%d commands are in both\n",
+		diffRepGroups[0], numA, diffRepGroups[1], numB, diff.Matched)
+
+	printDiffJobs("Only in A", diff.OnlyA)
+	printDiffJobs("Only in B", diff.OnlyB)
+	printDiffFlips("Completed in A, buried in B", diff.Broken, func(p *jobqueue.JobPair) *jobqueue.Job { return p.B })
+	printDiffFlips("Buried in A, completed in B", diff.Fixed, func(p *jobqueue.JobPair) *jobqueue.Job { return p.A })
+
+	if len(diff.Slower) == 0 {
+		return
+	}
+	fmt.Printf("\nMore than %d%% slower in B (%d):\n", diffThreshold, len(diff.Slower))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "slower\twall time in A\twall time in B\tcmd\t")
+	for _, pair := range diff.Slower {
+		fmt.Fprintf(w, "+%.0f%%\t%s\t%s\t%s\t\n", pair.Regression()*100,
+			pair.A.WallTime().Round(time.Second), pair.B.WallTime().Round(time.Second), pair.A.Cmd)
+	}
+	flushDiffTable(w)
+}
+
+// printDiffJobs prints the Cmds of the given jobs under the given heading, if
+// there are any.
+func printDiffJobs(heading string, jobs []*jobqueue.Job) {
+	if len(jobs) == 0 {
+		return
+	}
+	fmt.Printf("\n%s (%d):\n", heading, len(jobs))
+	for _, job := range jobs {
+		fmt.Printf("  %s\n", job.Cmd)
+	}
+}
+
+// printDiffFlips prints the Cmds of the given pairs under the given heading,
+// along with why the failed job of each pair (as returned by failed()) was
+// buried.
+func printDiffFlips(heading string, pairs []*jobqueue.JobPair, failed func(*jobqueue.JobPair) *jobqueue.Job) {
+	if len(pairs) == 0 {
+		return
+	}
+	fmt.Printf("\n%s (%d):\n", heading, len(pairs))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "exit code\tfailure reason\tcmd\t")
+	for _, pair := range pairs {
+		job := failed(pair)
+		fmt.Fprintf(w, "%d\t%s\t%s\t\n", job.Exitcode, job.FailReason, job.Cmd)
+	}
+	flushDiffTable(w)
+}
+
+// flushDiffTable flushes the given tabwriter, dying on failure.
+func flushDiffTable(w *tabwriter.Writer) {
+	if err := w.Flush(); err != nil {
+		die("failed to write diff: %s", err)
+	}
+}
+
+// diffJob is how we output a job in a diff as JSON.
+type diffJob struct {
+	Cmd        string  `json:"cmd"`
+	Key        string  `json:"key"`
+	RepGroup   string  `json:"rep_grp"`
+	State      string  `json:"state"`
+	Exitcode   int     `json:"exit_code"`
+	FailReason string  `json:"fail_reason,omitempty"`
+	Walltime   float64 `json:"walltime"`
+}
+
+// newDiffJob creates a diffJob from a job.
+func newDiffJob(job *jobqueue.Job) *diffJob {
+	return &diffJob{
+		Cmd:        job.Cmd,
+		Key:        job.Key(),
+		RepGroup:   job.RepGroup,
+		State:      string(job.State),
+		Exitcode:   job.Exitcode,
+		FailReason: job.FailReason,
+		Walltime:   job.WallTime().Seconds(),
+	}
+}
+
+// diffPair is how we output a JobPair as JSON.
+type diffPair struct {
+	A          *diffJob `json:"a"`
+	B          *diffJob `json:"b"`
+	Regression float64  `json:"regression"`
+}
+
+// printDiffJSON prints the given JobsDiff as a JSON object.
+func printDiffJSON(diff *jobqueue.JobsDiff, numA, numB int) {
+	jobs := func(js []*jobqueue.Job) []*diffJob {
+		djs := make([]*diffJob, len(js))
+		for i, job := range js {
+			djs[i] = newDiffJob(job)
+		}
+		return djs
+	}
+	pairs := func(ps []*jobqueue.JobPair) []*diffPair {
+		dps := make([]*diffPair, len(ps))
+		for i, pair := range ps {
+			dps[i] = &diffPair{A: newDiffJob(pair.A), B: newDiffJob(pair.B), Regression: pair.Regression()}
+		}
+		return dps
+	}
+
+	report := struct {
+		A         string      `json:"a"`
+		B         string      `json:"b"`
+		NumA      int         `json:"num_a"`
+		NumB      int         `json:"num_b"`
+		Matched   int         `json:"matched"`
+		Threshold float64     `json:"threshold"`
+		OnlyA     []*diffJob  `json:"only_a"`
+		OnlyB     []*diffJob  `json:"only_b"`
+		Broken    []*diffPair `json:"broken"`
+		Fixed     []*diffPair `json:"fixed"`
+		Slower    []*diffPair `json:"slower"`
+	}{
+		A:         diffRepGroups[0],
+		B:         diffRepGroups[1],
+		NumA:      numA,
+		NumB:      numB,
+		Matched:   diff.Matched,
+		Threshold: float64(diffThreshold) / 100,
+		OnlyA:     jobs(diff.OnlyA),
+		OnlyB:     jobs(diff.OnlyB),
+		Broken:    pairs(diff.Broken),
+		Fixed:     pairs(diff.Fixed),
+		Slower:    pairs(diff.Slower),
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		die("failed to encode diff: %s", err)
+	}
+}
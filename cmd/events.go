@@ -0,0 +1,156 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// options for this cmd
+var eventsSince string
+var eventsTypes string
+var eventsLimit int
+var eventsOutput string
+
+// eventsCmd represents the events command
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show the manager's event log",
+	Long: `Show the manager's event log.
+
+The manager keeps a log of significant things that have happened, giving you an
+audit trail that doesn't depend on log files. The types of event are:
+
+add             commands were added
+start           a command started running on a host
+manual_run      a command was taken to be run manually (wr runner --exec-key)
+bury            a command was buried (failed too many times, or a user buried it
+                from the web interface)
+behaviour       a behaviour ran after a command (with how long it took)
+retry           buried commands were retried by a user
+remove          commands were removed by a user (wr remove)
+restore         removed commands were restored by a user (wr undo)
+kill            running commands were killed by a user (wr kill)
+pause           a user paused the manager (wr manager pause)
+resume          a user resumed the manager (wr manager resume)
+scheduler_error the manager had a problem asking its scheduler for runners
+scale_up        more runners were requested for a scheduler group
+scale_down      runners are no longer needed for a scheduler group
+budget          a report group used 80% or all of its budget (see wr budget)
+limit           a limit group's limit was tuned by its health probe (see wr limit)
+annotate        a user left a note on a command or report group (wr annotate)
+
+Events are kept for 30 days.
+
+Events about things users asked for say who asked: the username their command
+line client claimed, marked "(unverified)" since the manager can't check it; the
+name they logged in to the web interface with, followed by the subject their
+OpenID Connect provider verified; or "web interface" or "REST API" if they used
+the token instead.
+
+By default all events from the last hour are shown, oldest first. Use --since
+to change how far back to look (eg. --since 24h), --type to only show certain
+types of event (eg. --type bury,retry), and --limit to only show that many of
+the most recent matching events.
+
+The default -o plain output has tab separated columns of the event time, type,
+report group, job id, scheduler group, host, user, count and message, with blank
+values shown as -. -o json outputs the events as an array of JSON objects.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var since time.Time
+		if eventsSince != "" {
+			d, err := time.ParseDuration(eventsSince)
+			if err != nil {
+				die("--since was not specified correctly: %s", err)
+			}
+			since = time.Now().Add(-d)
+		}
+
+		var types []jobqueue.EventType
+		if eventsTypes != "" {
+			for _, t := range strings.Split(eventsTypes, ",") {
+				types = append(types, jobqueue.EventType(t))
+			}
+		}
+
+		timeout := time.Duration(timeoutint) * time.Second
+		jq := connect(timeout)
+		defer func() {
+			err := jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		events, err := jq.GetEvents(since, types, eventsLimit)
+		if err != nil {
+			die("failed to get events: %s", err)
+		}
+
+		switch eventsOutput {
+		case "json", "j":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetEscapeHTML(false)
+			if events == nil {
+				events = []*jobqueue.Event{}
+			}
+			err = encoder.Encode(events)
+			if err != nil {
+				die("failed to encode events: %s", err)
+			}
+		case "plain", "p":
+			for _, e := range events {
+				count := ""
+				if e.Count != 0 {
+					count = fmt.Sprintf("%d", e.Count)
+				}
+				fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.Time.Format(time.RFC3339), e.Type,
+					orDash(e.RepGroup), orDash(e.Key), orDash(e.SchedulerGroup), orDash(e.Host), orDash(e.User), orDash(count), orDash(e.Msg))
+			}
+		default:
+			die("invalid -o format specified")
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(eventsCmd)
+
+	// flags specific to this sub-command
+	eventsCmd.Flags().StringVar(&eventsSince, "since", "1h", "show events that happened within this long ago; blank for all")
+	eventsCmd.Flags().StringVar(&eventsTypes, "type", "", "comma separated types of event to show")
+	eventsCmd.Flags().IntVar(&eventsLimit, "limit", 0, "show at most this many of the most recent events; 0 for all")
+	eventsCmd.Flags().StringVarP(&eventsOutput, "output", "o", "plain", "['plain','json'] output format")
+	eventsCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// orDash returns the given string, or "-" if it is blank.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
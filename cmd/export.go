@@ -0,0 +1,87 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export incomplete commands to a file",
+	Long: `Export incomplete commands to a file, to import in to another manager.
+
+You can use this command to move a workflow from one deployment to another (eg.
+from development to production), or from one manager host to another, without
+having to regenerate and re-add its commands:
+
+wr export -i myworkflow > jobs.wrx
+wr import --deployment production jobs.wrx
+
+All the commands in the queue that have not yet completed are written to STDOUT
+(which must be redirected to a file), or only those in the given report group
+(-z to treat it as a substring to match against all report groups). Everything
+about how they were added is kept, such as their resource requirements,
+dependencies, behaviours and environment variables, but not anything about
+their attempts to run so far.
+
+The commands remain in this manager's queue; if the other manager will be
+running them instead, you should "wr remove" them here after importing them
+there.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if terminal.IsTerminal(int(os.Stdout.Fd())) {
+			die("the export must be redirected to a file")
+		}
+
+		timeout := time.Duration(timeoutint) * time.Second
+		jq := connect(timeout)
+		defer func() {
+			err := jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		w := bufio.NewWriter(os.Stdout)
+		n, err := jq.ExportJobs(w, cmdIDStatus, cmdIDIsSubStr)
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			die("failed to export commands: %s", err)
+		}
+		info("Exported %d incomplete commands", n)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+
+	// flags specific to this sub-command
+	exportCmd.Flags().StringVarP(&cmdIDStatus, "identifier", "i", "", "identifier of the commands you want to export")
+	exportCmd.Flags().BoolVarP(&cmdIDIsSubStr, "search", "z", false, "treat -i as a substring to match against all report groups")
+
+	exportCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
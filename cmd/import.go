@@ -0,0 +1,79 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <file.wrx>",
+	Short: "Add commands exported from another manager",
+	Long: `Add commands exported from another manager.
+
+Given a file written by "wr export" (or - to read it from STDIN), the commands
+in it are added to the queue, just as they were originally added to the
+manager they were exported from, including their environment variables.
+
+Commands that are already in the queue, or that have already completed, are not
+added again.
+
+The same secrets, limit groups and queues that the commands use should be set up
+in this manager before importing them.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var r io.Reader = os.Stdin
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				die("could not open %s: %s", args[0], err)
+			}
+			defer internal.LogClose(appLogger, f, "import file", "path", args[0])
+			r = f
+		}
+
+		timeout := time.Duration(timeoutint) * time.Second
+		jq := connect(timeout)
+		defer func() {
+			err := jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		added, existed, err := jq.ImportJobs(r)
+		if err != nil {
+			die("failed to import commands: %s", err)
+		}
+		info("Added %d new commands (%d were duplicates)", added, existed)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(importCmd)
+
+	// flags specific to this sub-command
+	importCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
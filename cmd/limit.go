@@ -20,13 +20,25 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/VertebrateResequencing/wr/jobqueue"
 	"github.com/spf13/cobra"
 )
 
 // options for this cmd
 var limitGroup string
+var limitPerHost bool
+var limitProbe string
+var limitNoProbe bool
+var limitListProbes bool
+var limitProbeInterval string
+var limitProbeTimeout string
+var limitMin int
+var limitMax int
+var limitStep int
+var limitBackoff float64
 
 // limitCmd represents the remove command
 var limitCmd = &cobra.Command{
@@ -51,9 +63,35 @@ Suffixing the name with :n, where n is an integer, will set the group's limit to
 that number.
 
 Setting a limit of 0 stops any more jobs in that group from running. Setting a
-limit of -1 makes that group unlimited.`,
+limit of -1 makes that group unlimited.
+
+With --per_host, you instead view or set the maximum number of jobs in the
+group that may run at once on any single machine, regardless of how many cores
+it has or what the group's overall limit is. This is useful for jobs that
+thrash a machine's local disks when too many of them run together. A per-host
+limit of -1 removes it.
+
+With --probe, you instead put the group in to feedback mode, where the manager
+automatically tunes the group's limit to keep an external service healthy, eg.
+backing off when a database the jobs use gets slow:
+
+wr limit -g mydb --probe https://db.example.com/health --min 5 --max 200
+
+Every --probe_interval, the manager probes the service. A probe URL (starting
+http:// or https://) is healthy if fetching it gives a 2xx status; anything else
+is treated as a command line that the manager runs with sh, which is healthy if
+it exits 0. Either way, a probe that takes longer than --probe_timeout is
+unhealthy. Each healthy probe raises the limit by --step, up to --max, and each
+unhealthy one multiplies the limit by --backoff (lowering it by at least 1),
+down to --min. Changes to the limit are recorded as "limit" events (see
+wr events).
+
+Viewing a group's limit also tells you about its feedback mode, if any.
+--probes lists all the groups in feedback mode, along with the result of their
+last probe. --no_probe takes the group out of feedback mode, leaving its limit
+as it was last tuned.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if limitGroup == "" {
+		if limitGroup == "" && !limitListProbes {
 			die("--group required")
 		}
 
@@ -67,11 +105,38 @@ limit of -1 makes that group unlimited.`,
 			}
 		}()
 
-		limit, err := jq.GetOrSetLimitGroup(limitGroup)
+		switch {
+		case limitListProbes:
+			listLimitFeedback(jq)
+			return
+		case limitProbe != "":
+			setLimitFeedback(jq)
+			return
+		case limitNoProbe:
+			err = jq.DeleteLimitFeedback(limitGroup)
+			if err != nil {
+				die(err.Error())
+			}
+			info("%s is no longer in feedback mode", limitGroup)
+			return
+		}
+
+		var limit int
+		if limitPerHost {
+			limit, err = jq.GetOrSetHostLimit(limitGroup)
+		} else {
+			limit, err = jq.GetOrSetLimitGroup(limitGroup)
+		}
 		if err != nil {
 			die(err.Error())
 		}
 		fmt.Printf("%d\n", limit)
+
+		if !limitPerHost && !strings.Contains(limitGroup, ":") {
+			if f := limitFeedbackOf(jq, limitGroup); f != nil {
+				info("%s", f)
+			}
+		}
 	},
 }
 
@@ -80,4 +145,68 @@ func init() {
 
 	// flags specific to this sub-command
 	limitCmd.Flags().StringVarP(&limitGroup, "group", "g", "", "name of the limit group to view, suffixed with :n to set limit")
+	limitCmd.Flags().BoolVar(&limitPerHost, "per_host", false, "view or set the group's limit per host instead")
+	limitCmd.Flags().StringVar(&limitProbe, "probe", "", "URL or command to probe to tune the group's limit")
+	limitCmd.Flags().BoolVar(&limitNoProbe, "no_probe", false, "take the group out of feedback mode")
+	limitCmd.Flags().BoolVar(&limitListProbes, "probes", false, "list the groups in feedback mode")
+	limitCmd.Flags().StringVar(&limitProbeInterval, "probe_interval", "30s", "how often to probe")
+	limitCmd.Flags().StringVar(&limitProbeTimeout, "probe_timeout", "5s", "how long a probe can take before it is considered unhealthy")
+	limitCmd.Flags().IntVar(&limitMin, "min", 0, "the lowest limit feedback mode can set")
+	limitCmd.Flags().IntVar(&limitMax, "max", 0, "the highest limit feedback mode can set")
+	limitCmd.Flags().IntVar(&limitStep, "step", 1, "how much to raise the limit by after a healthy probe")
+	limitCmd.Flags().Float64Var(&limitBackoff, "backoff", 0.5, "what to multiply the limit by after an unhealthy probe")
+}
+
+// setLimitFeedback puts --group in to feedback mode with the user's options.
+func setLimitFeedback(jq *jobqueue.Client) {
+	interval, err := time.ParseDuration(limitProbeInterval)
+	if err != nil {
+		die("--probe_interval was not specified correctly: %s", err)
+	}
+	timeout, err := time.ParseDuration(limitProbeTimeout)
+	if err != nil {
+		die("--probe_timeout was not specified correctly: %s", err)
+	}
+
+	f, err := jq.SetLimitFeedback(&jobqueue.LimitFeedback{
+		Group:    limitGroup,
+		Probe:    limitProbe,
+		Interval: interval,
+		Timeout:  timeout,
+		Min:      limitMin,
+		Max:      limitMax,
+		Step:     limitStep,
+		Backoff:  limitBackoff,
+	})
+	if err != nil {
+		die(err.Error())
+	}
+	info("%s", f)
+}
+
+// listLimitFeedback prints the feedback of all groups in feedback mode.
+func listLimitFeedback(jq *jobqueue.Client) {
+	feedbacks, err := jq.GetLimitFeedback()
+	if err != nil {
+		die(err.Error())
+	}
+	for _, f := range feedbacks {
+		fmt.Println(f)
+	}
+}
+
+// limitFeedbackOf returns the feedback of the given group, or nil if it isn't
+// in feedback mode.
+func limitFeedbackOf(jq *jobqueue.Client, group string) *jobqueue.LimitFeedback {
+	feedbacks, err := jq.GetLimitFeedback()
+	if err != nil {
+		warn("could not get the group's feedback mode: %s", err)
+		return nil
+	}
+	for _, f := range feedbacks {
+		if f.Group == group {
+			return f
+		}
+	}
+	return nil
 }
@@ -45,7 +45,9 @@ var jobStateToLSFState = map[jobqueue.JobState]string{
 	jobqueue.JobStateDependent: "PEND",
 	jobqueue.JobStateReady:     "PEND",
 	jobqueue.JobStateReserved:  "PEND",
+	jobqueue.JobStateStaging:   "PEND",
 	jobqueue.JobStateRunning:   "RUN",
+	jobqueue.JobStateUploading: "RUN",
 	jobqueue.JobStateLost:      "UNKWN",
 	jobqueue.JobStateBuried:    "EXIT",
 	jobqueue.JobStateComplete:  "DONE",
@@ -23,6 +23,7 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -56,10 +57,26 @@ var managerDebug bool
 var maxServers int
 var maxLocalCores int
 var maxLocalRAM int
+var localReservedCores int
+var localReservedRAM int
+var localCPUOvercommit float64
+var localRAMOvercommit float64
+var localPinCores bool
+var localNUMABind bool
+var burstSpec string
+var burstEnable bool
+var burstDisable bool
 var cloudNoSecurityGroups bool
 var cloudUseConfigDrive bool
+var cloudFlavorScriptContents map[string][]byte
 var useCertDomain bool
+var publicWebPort string
 var runnerDebug bool
+var drainHostGrace int
+var drainHostWait bool
+var drainHostUndo bool
+var fsckRepair bool
+var startFsck bool
 
 const kubernetes = "kubernetes"
 const deadlockTimeout = 5 * time.Minute
@@ -130,7 +147,17 @@ you've created yourself, note that --local_username will need to be globally
 unique, since it is used to name the private key that will be created in
 OpenStack, and if a key with that name already exists, the manager will not be
 able to create a new one (or get the existing one), and so will not function
-fully.`,
+fully.
+
+The --public_port option makes a read-only copy of the status web page
+available on the given port, without needing the token. Anyone who can reach
+it can see the state of all jobs and their details (including their commands,
+environment variables and output), but can't retry, remove, kill or otherwise
+change anything, so it is suitable for sharing progress widely within a
+trusted network.
+
+To have systemd or launchd start the manager for you, and restart it if it dies
+or hangs, see "wr manager install-service".`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// first we need our working directory to exist
 		createWorkingDir()
@@ -166,6 +193,35 @@ fully.`,
 			}
 		}
 
+		if cloudFlavorScripts != "" {
+			specs, err := parseFlavorScripts(cloudFlavorScripts)
+			if err != nil {
+				die("bad --cloud_flavor_scripts: %s", err)
+			}
+
+			// as with --cloud_script, read the scripts now and re-specify the
+			// option with absolute paths for the daemon
+			cloudFlavorScriptContents = make(map[string][]byte)
+			absSpecs := make([]string, len(specs))
+			for i, spec := range specs {
+				content, err := ioutil.ReadFile(spec.path)
+				if err != nil {
+					die("--cloud_flavor_scripts %s could not be read: %s", spec.path, err)
+				}
+				cloudFlavorScriptContents[spec.regex] = content
+
+				abs, err := filepath.Abs(spec.path)
+				if err != nil {
+					die("--cloud_flavor_scripts %s could not be converted to an absolute path: %s", spec.path, err)
+				}
+				absSpecs[i] = spec.regex + "=" + abs
+			}
+			if absValue := strings.Join(absSpecs, ";"); absValue != cloudFlavorScripts {
+				extraArgs = append(extraArgs, "--cloud_flavor_scripts")
+				extraArgs = append(extraArgs, absValue)
+			}
+		}
+
 		if scheduler == kubernetes {
 			if len(kubeNamespace) == 0 {
 				die("namespace must be specified when using the kubernetes scheduler")
@@ -288,7 +344,7 @@ commands they were running. It is more graceful to use 'drain' instead.`,
 		if err != nil {
 			warn("Could not get current IP: %s", err)
 		}
-		myAddr := currentIP + ":" + config.ManagerPort
+		myAddr := net.JoinHostPort(currentIP, config.ManagerPort)
 		sAddr := jq.ServerInfo.Addr
 		if myAddr == sAddr {
 			err = jq.Disconnect()
@@ -444,6 +500,200 @@ of the manager.`,
 	},
 }
 
+// burst sub-command views or changes the burst policy
+var managerBurstCmd = &cobra.Command{
+	Use:   "burst",
+	Short: "View or change the policy for spilling jobs between schedulers",
+	Long: `View or change the policy for spilling jobs between schedulers.
+
+When the manager was started with more than one scheduler (eg.
+-s lsf,openstack), jobs that would normally go to one of them can spill over in
+to another when there is too much outstanding work. The initial policy comes
+from the managerburst config option; see wr_config.yml for details.
+
+With no options, this shows the current policy and whether jobs are currently
+spilling over, along with how many runners have been sent to the other
+scheduler and the accumulated cost.
+
+--set takes the same comma separated key=value options as the managerburst
+config option, and changes only the ones you specify. Eg.
+wr manager burst --set max=50,cap=2000
+
+--disable stops bursting without forgetting the policy, and --enable turns it
+back on again.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if burstEnable && burstDisable {
+			die("--enable and --disable are mutually exclusive")
+		}
+
+		jq := connect(5*time.Second, true)
+		if jq == nil {
+			die("could not connect to the manager on port %s", config.ManagerPort)
+		}
+		defer func() {
+			err := jq.Disconnect()
+			if err != nil {
+				warn("disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		status, err := jq.GetBurstStatus()
+		if burstSpec != "" || burstEnable || burstDisable {
+			var spec string
+			if err == nil {
+				spec = status.Policy.String() + ","
+			}
+			spec += burstSpec
+			if burstEnable {
+				spec += ",disabled=false"
+			} else if burstDisable {
+				spec += ",disabled=true"
+			}
+
+			policy, errp := jqs.ParseBurstPolicy(spec)
+			if errp != nil {
+				die("%s", errp)
+			}
+
+			status, err = jq.SetBurstPolicy(policy)
+		}
+		if err != nil {
+			die("%s", err)
+		}
+
+		info("policy: %s", status.Policy)
+		info("bursting: %t; pending: %d; runners in %s: %d; cost: %.2f", status.Bursting, status.Pending, status.Policy.To, status.Instances, status.Cost)
+	},
+}
+
+// drain-host sub-command stops jobs running on a particular host
+var managerDrainHostCmd = &cobra.Command{
+	Use:   "drain-host <hostname>",
+	Short: "Move jobs off a host so it can be taken down for maintenance",
+	Long: `Move jobs off a host so it can be taken down for maintenance.
+
+The manager stops giving jobs to runners on the given host, and runners that are
+already running jobs there are told to requeue them. Requeueing sends the job's
+command SIGTERM, which it can trap to checkpoint its work and exit, and then
+kills it if it has not exited after --grace seconds. The job is then put back in
+the queue to run on another host, without it counting against its retries.
+(Commands that checkpoint should do so to a location that other hosts can
+read, and should use cwd_matters or mounts, since the unique working directory
+they otherwise get will be cleaned up.)
+
+You are told how many jobs are still running on the host. It is safe to repeat
+this command to get an update; once there are none, the host is empty. With
+--wait, this command only returns once the host is empty.
+
+The host stays drained (even once empty) until you run this command again with
+--undo. Draining is forgotten if the manager is restarted.
+
+The hostname must be as the host's runners report it, which is the Host shown
+by 'wr status' for jobs that ran there.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		host := args[0]
+
+		jq := connect(5*time.Second, true)
+		if jq == nil {
+			die("could not connect to the manager on port %s", config.ManagerPort)
+		}
+		defer func() {
+			err := jq.Disconnect()
+			if err != nil {
+				warn("disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		if drainHostUndo {
+			err := jq.UndrainHost(host)
+			if err != nil {
+				die("failed to stop draining %s: %s", host, err)
+			}
+			info("%s is no longer being drained", host)
+			return
+		}
+
+		grace := time.Duration(drainHostGrace) * time.Second
+		for {
+			running, err := jq.DrainHost(host, grace)
+			if err != nil {
+				die("failed to drain %s: %s", host, err)
+			}
+
+			if running == 0 {
+				info("%s is drained: there are no jobs running on it", host)
+				return
+			}
+
+			if !drainHostWait {
+				info("%s is being drained; there are %d jobs still running on it", host, running)
+				return
+			}
+
+			<-time.After(5 * time.Second)
+		}
+	},
+}
+
+// set sub-command changes manager settings while it runs
+var managerSetCmd = &cobra.Command{
+	Use:   "set [<setting> <value>]",
+	Short: "Change manager settings without restarting it",
+	Long: `Change manager settings without restarting it.
+
+Some of the manager's behaviour can be changed while it is running, without
+affecting the jobs it is currently running. Changes are remembered, so still
+apply if the manager is restarted.
+
+Without arguments, the current value of each setting is shown. The settings
+are:
+
+log_level             the minimum level of message written to the manager's
+                      log file: one of debug, info, warn, error or crit.
+max_runners_per_group the most runners the manager will have the scheduler
+                      run at once for each group of jobs with the same
+                      resource requirements; 0 means no limit.
+retry_delay           how long failed jobs wait before they can be retried,
+                      eg. 30s or 5m.`,
+	Args: cobra.RangeArgs(0, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			die("a setting and a value must be supplied")
+		}
+		if len(args) == 2 {
+			if err := jobqueue.ValidateSetting(args[0], args[1]); err != nil {
+				die("%s", err)
+			}
+		}
+
+		jq := connect(5 * time.Second)
+		defer func() {
+			err := jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		var settings map[string]string
+		var err error
+		if len(args) == 2 {
+			settings, err = jq.SetSetting(args[0], args[1])
+		} else {
+			settings, err = jq.GetSettings()
+		}
+		if err != nil {
+			die("%s", err)
+		}
+
+		for _, name := range jobqueue.SettingNames {
+			if value, exists := settings[name]; exists {
+				fmt.Printf("%s: %s\n", name, value)
+			}
+		}
+	},
+}
+
 // status sub-command tells if the manger is up or down
 var managerStatusCmd = &cobra.Command{
 	Use:   "status",
@@ -508,6 +758,126 @@ somewhere.)`,
 	},
 }
 
+// fsck sub-command checks the manager's database and queue for consistency
+var managerFsckCmd = &cobra.Command{
+	Use:   "fsck",
+	Short: "Check wr's database and queue for inconsistencies",
+	Long: `Check wr's database and queue for inconsistencies.
+
+Following an unclean shutdown of the manager (eg. its machine lost power), or
+failures to write to its database, the jobs in the database and those in the
+manager's in-memory queue may no longer agree. This command cross-checks them,
+reporting:
+
+unqueued          incomplete jobs in the database that are not in the queue, so
+                  will never run.
+complete & live   jobs that completed, but which also remain in the database as
+                  incomplete jobs.
+unstored          jobs in the queue that are not in the database, so would be
+                  lost if the manager were restarted.
+bad dependencies  jobs that depend on jobs that were completed or deleted
+                  without that being noticed, so will never run.
+unindexed         jobs in the queue that can not be found by their --rep_grp.
+stale lookups     entries in the database's --rep_grp and --dep_grps lookups
+                  for jobs that no longer exist.
+
+With --repair, each problem is also fixed: unqueued jobs are added to the queue,
+complete jobs are removed from the incomplete jobs in the database, unstored
+jobs are stored, dependencies are recalculated, unindexed jobs are indexed, and
+stale lookups are deleted.
+
+If started with "wr manager start --fsck", the manager also does this check
+(without repairing anything) after it recovers incomplete jobs, warning in its
+log if it finds any problems. This is off by default, since it reads every
+incomplete job in the database, which is slow for large databases.
+
+Jobs that are in the middle of being added or completed may appear to be
+inconsistent, so it is best to run this when the manager is quiet, eg. paused
+with no jobs being added.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		timeout := time.Duration(timeoutint) * time.Second
+
+		jq := connect(timeout)
+		defer func() {
+			err := jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		report, err := jq.Fsck(fsckRepair)
+		if err != nil {
+			die("%s", err)
+		}
+
+		reportFsckKeys("unqueued", report.Unqueued)
+		reportFsckKeys("complete & live", report.CompleteLive)
+		reportFsckKeys("unstored", report.Unstored)
+		reportFsckKeys("bad dependencies", report.BadDependencies)
+		reportFsckKeys("unindexed", report.Unindexed)
+		if report.StaleLookups > 0 {
+			fmt.Printf("stale lookups: %d\n", report.StaleLookups)
+		}
+
+		problems := report.Problems()
+		switch {
+		case problems == 0:
+			info("no inconsistencies were found")
+		case report.Repaired:
+			info("%d inconsistencies were found and repaired", problems)
+		default:
+			warn("%d inconsistencies were found; use --repair to fix them", problems)
+		}
+	},
+}
+
+// reportFsckKeys prints the keys of the jobs with the given kind of problem
+// found by fsck, if any.
+func reportFsckKeys(problem string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	fmt.Printf("%s: %d\n", problem, len(keys))
+	for _, key := range keys {
+		fmt.Printf("  %s\n", key)
+	}
+}
+
+// oidcConfig returns the OpenID Connect login configuration for the web
+// interface, based on the user's config, or nil if they didn't configure an
+// issuer.
+func oidcConfig() *jobqueue.OIDCConfig {
+	if config.ManagerOIDCIssuer == "" {
+		return nil
+	}
+	if config.ManagerOIDCClientID == "" {
+		die("manageroidcclientid must be set when using manageroidcissuer")
+	}
+	return &jobqueue.OIDCConfig{
+		Issuer:        config.ManagerOIDCIssuer,
+		ClientID:      config.ManagerOIDCClientID,
+		ClientSecret:  config.ManagerOIDCSecret,
+		RedirectURL:   config.ManagerOIDCRedirect,
+		UsernameClaim: config.ManagerOIDCUserClaim,
+		RolesClaim:    config.ManagerOIDCRoleClaim,
+		Viewers:       splitConfigList(config.ManagerOIDCViewers),
+		Operators:     splitConfigList(config.ManagerOIDCOperators),
+		Admins:        splitConfigList(config.ManagerOIDCAdmins),
+	}
+}
+
+// splitConfigList splits a comma separated config value in to its trimmed,
+// non-empty parts.
+func splitConfigList(value string) []string {
+	var parts []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
 // reportLiveStatus is used by the status command on a working connection to
 // distinguish between the server being in a normal 'started' state or the
 // 'drain' state.
@@ -529,14 +899,25 @@ func init() {
 	managerCmd.AddCommand(managerStopCmd)
 	managerCmd.AddCommand(managerStatusCmd)
 	managerCmd.AddCommand(managerBackupCmd)
+	managerCmd.AddCommand(managerBurstCmd)
+	managerCmd.AddCommand(managerDrainHostCmd)
+	managerCmd.AddCommand(managerSetCmd)
+	managerCmd.AddCommand(managerFsckCmd)
 
 	// flags specific to these sub-commands
 	defaultConfig := internal.DefaultConfig(appLogger)
 	managerStartCmd.Flags().BoolVarP(&foreground, "foreground", "f", false, "do not daemonize")
-	managerStartCmd.Flags().StringVarP(&scheduler, "scheduler", "s", defaultConfig.ManagerScheduler, "['local','lsf','openstack'] job scheduler")
+	managerStartCmd.Flags().StringVarP(&scheduler, "scheduler", "s", defaultConfig.ManagerScheduler, "['local','lsf','openstack'] job scheduler, or a comma separated list of them to use all at once")
 	managerStartCmd.Flags().IntVarP(&managerTimeoutSeconds, "timeout", "t", 10, "how long to wait in seconds for the manager to start up")
+	managerStartCmd.Flags().StringVar(&publicWebPort, "public_port", "", "port to serve a read-only status page on, that needs no token")
 	managerStartCmd.Flags().IntVar(&maxLocalCores, "max_cores", runtime.NumCPU(), "maximum number of local cores to use to run cmds; -1 means unlimited")
 	managerStartCmd.Flags().IntVar(&maxLocalRAM, "max_ram", defaultMaxRAM, "maximum MB of local memory to use to run cmds; -1 means unlimited")
+	managerStartCmd.Flags().IntVar(&localReservedCores, "reserve_cores", 0, "for the local scheduler, number of local cores to keep free for the manager")
+	managerStartCmd.Flags().IntVar(&localReservedRAM, "reserve_ram", 0, "for the local scheduler, MB of local memory to keep free for the manager")
+	managerStartCmd.Flags().Float64Var(&localCPUOvercommit, "cpu_overcommit", 1, "for the local scheduler, factor to multiply available cores by when deciding how many cmds can run")
+	managerStartCmd.Flags().Float64Var(&localRAMOvercommit, "ram_overcommit", 1, "for the local scheduler, factor to multiply available memory by when deciding how many cmds can run")
+	managerStartCmd.Flags().BoolVar(&localPinCores, "pin_cores", false, "for the local scheduler, pin each cmd to its own cores using taskset, within a single NUMA node where possible")
+	managerStartCmd.Flags().BoolVar(&localNUMABind, "numa_bind", false, "for the local scheduler with --pin_cores, pin using numactl to also bind each cmd's memory to its NUMA node")
 	managerStartCmd.Flags().IntVar(&cloudSpawns, "cloud_spawns", defaultConfig.CloudSpawns, "for cloud schedulers, maximum number of simultaneous server spawns during scale-up")
 	managerStartCmd.Flags().StringVarP(&osPrefix, "cloud_os", "o", defaultConfig.CloudOS, "for cloud schedulers, prefix name of the OS image your servers should use")
 	managerStartCmd.Flags().StringVarP(&osUsername, "cloud_username", "u", defaultConfig.CloudUser, "for cloud schedulers, username needed to log in to the OS image specified by --cloud_os")
@@ -545,6 +926,9 @@ func init() {
 	managerStartCmd.Flags().IntVarP(&osDisk, "cloud_disk", "d", defaultConfig.CloudDisk, "for cloud schedulers, minimum disk (GB) for servers")
 	managerStartCmd.Flags().StringVarP(&flavorRegex, "cloud_flavor", "l", defaultConfig.CloudFlavor, "for cloud schedulers, a regular expression to limit server flavors that can be automatically picked")
 	managerStartCmd.Flags().StringVar(&flavorSets, "cloud_flavor_sets", defaultConfig.CloudFlavorSets, "for cloud schedulers, sets of flavors assigned to different hardware, in the form f1,f2;f3,f4")
+	managerStartCmd.Flags().StringVar(&cloudNetworkGroups, "cloud_network_groups", defaultConfig.CloudNetworkGroups, "for cloud schedulers, security groups giving jobs the network access they need, in the form need1=group1,need2=group2")
+	managerStartCmd.Flags().StringVar(&cloudFlavorScripts, "cloud_flavor_scripts", defaultConfig.CloudFlavorScripts, "for cloud schedulers, templated scripts to run on servers with particular flavors after --cloud_script, in the form regex1=path1;regex2=path2")
+	managerStartCmd.Flags().StringVar(&cloudVolumeMount, "cloud_volume_mount", defaultConfig.CloudVolumeMount, "for cloud schedulers, path to mount the volumes of commands added with --cloud_volume at")
 	managerStartCmd.Flags().StringVarP(&postCreationScript, "cloud_script", "p", defaultConfig.CloudScript, "for cloud schedulers, path to a start-up script that will be run on each server created")
 	managerStartCmd.Flags().StringVarP(&kubeNamespace, "namespace", "", "", "for the kubernetes scheduler, the namespace to use")
 	managerStartCmd.Flags().StringVarP(&configMapName, "config_map", "", "", "for the kubernetes scheduler, provide an existing config map to initialise all pods with. To be used instead of --cloud_script")
@@ -558,9 +942,18 @@ func init() {
 	managerStartCmd.Flags().BoolVar(&setDomainIP, "set_domain_ip", defaultConfig.ManagerSetDomainIP, "on success, use infoblox to set your domain's IP")
 	managerStartCmd.Flags().BoolVar(&useCertDomain, "use_cert_domain", false, "if cert domain is configured, provide it to spawned clients instead of our IP address")
 	managerStartCmd.Flags().BoolVar(&managerDebug, "debug", false, "include extra debugging information in the logs")
+	managerStartCmd.Flags().BoolVar(&startFsck, "fsck", false, "after recovering incomplete jobs, check the database and queue for inconsistencies (see wr manager fsck)")
 	managerStartCmd.Flags().BoolVar(&runnerDebug, "runner_debug", false, "have runners log to syslog on their machines")
 
 	managerBackupCmd.Flags().StringVarP(&backupPath, "path", "p", "", "backup file path")
+	managerFsckCmd.Flags().BoolVar(&fsckRepair, "repair", false, "fix the inconsistencies found")
+
+	managerBurstCmd.Flags().StringVar(&burstSpec, "set", "", "comma separated key=value burst policy options to change")
+	managerBurstCmd.Flags().BoolVar(&burstEnable, "enable", false, "turn bursting on")
+	managerBurstCmd.Flags().BoolVar(&burstDisable, "disable", false, "turn bursting off")
+	managerDrainHostCmd.Flags().IntVarP(&drainHostGrace, "grace", "g", 60, "seconds to give commands to checkpoint and exit before they are killed")
+	managerDrainHostCmd.Flags().BoolVarP(&drainHostWait, "wait", "w", false, "wait until there are no jobs running on the host")
+	managerDrainHostCmd.Flags().BoolVar(&drainHostUndo, "undo", false, "stop draining the host, letting it run jobs again")
 }
 
 func logStarted(s *jobqueue.ServerInfo, token []byte) {
@@ -570,6 +963,9 @@ func logStarted(s *jobqueue.ServerInfo, token []byte) {
 	// server logging)
 	appLogger.SetHandler(log15.LvlFilterHandler(log15.LvlInfo, log15.StderrHandler))
 	info("wr's web interface can be reached at https://%s:%s/?token=%s", s.Host, s.WebPort, string(token))
+	if s.PublicPort != "" {
+		info("wr's read-only status page can be reached at https://%s:%s/", s.Host, s.PublicPort)
+	}
 
 	if setDomainIP {
 		ip, err := internal.CurrentIP("")
@@ -596,6 +992,7 @@ func startJQ(postCreation []byte) {
 	// change the app logger to log to both STDERR and our configured log file;
 	// we also create a new logger for internal use by the server later
 	serverLogger := log15.New()
+	var logFilter *jobqueue.LevelFilter
 	fh, err := log15.FileHandler(config.ManagerLogFile, log15.LogfmtFormat())
 	if err != nil {
 		warn("wr manager could not log to %s: %s", config.ManagerLogFile, err)
@@ -607,7 +1004,8 @@ func startJQ(postCreation []byte) {
 		if managerDebug {
 			logLevel = log15.LvlDebug
 		}
-		serverLogger.SetHandler(log15.LvlFilterHandler(logLevel, l15h.CallerInfoHandler(fh)))
+		logFilter = jobqueue.NewLevelFilter(logLevel, l15h.CallerInfoHandler(fh))
+		serverLogger.SetHandler(logFilter)
 	}
 
 	// we will spawn runners, which means we need to know the path to ourselves
@@ -618,16 +1016,229 @@ func startJQ(postCreation []byte) {
 	}
 
 	var schedulerConfig interface{}
-	serverCIDR := ""
+	var serverCIDR string
+	schedulerName := scheduler
+	names := strings.Split(scheduler, ",")
+	backends := make([]*jqs.MultiBackend, 0, len(names))
+	for _, name := range names {
+		sc, cidr := schedulerConfigFor(name, exe, postCreation)
+		if cidr != "" {
+			serverCIDR = cidr
+		}
+		prepareCloudScheduler(name, sc)
+		backends = append(backends, &jqs.MultiBackend{Name: name, Config: sc})
+	}
+
+	if len(backends) == 1 {
+		schedulerConfig = backends[0].Config
+	} else {
+		// we're driving multiple schedulers at once, routing jobs between them
+		rules, errp := jqs.ParseRoutingRules(config.ManagerSchedRoutes)
+		if errp != nil {
+			die("bad scheduler routing rules: %s", errp)
+		}
+		var burst *jqs.BurstPolicy
+		if config.ManagerBurst != "" {
+			burst, errp = jqs.ParseBurstPolicy(config.ManagerBurst)
+			if errp != nil {
+				die("bad burst policy: %s", errp)
+			}
+		}
+		schedulerName = "multi"
+		schedulerConfig = &jqs.ConfigMulti{Backends: backends, Rules: rules, Burst: burst}
+	}
+
+	runnerCmd := exe + " runner -s '%s' --deployment %s --server '%s' --domain %s -r %d -m %d"
+	if runnerDebug {
+		runnerCmd += " --debug"
+	}
+
+	var failureRules jobqueue.FailureRules
+	if config.ManagerFailureRules != "" {
+		var errf error
+		failureRules, errf = jobqueue.FailureRulesFromFile(config.ManagerFailureRules)
+		if errf != nil {
+			die("failed to load failure rules: %s", errf)
+		}
+	}
+
+	var redactionRules jobqueue.RedactionRules
+	if config.ManagerRedactRules != "" {
+		var errr error
+		redactionRules, errr = jobqueue.RedactionRulesFromFile(config.ManagerRedactRules)
+		if errr != nil {
+			die("failed to load redaction rules: %s", errr)
+		}
+	}
+
+	var notifications *jobqueue.NotifyConfig
+	if config.ManagerNotifyRules != "" {
+		var errn error
+		notifications, errn = jobqueue.NotifyConfigFromFile(config.ManagerNotifyRules)
+		if errn != nil {
+			die("failed to load notification rules: %s", errn)
+		}
+	}
+
+	deadlockBuf := new(bytes.Buffer)
+	sync.Opts.LogBuf = deadlockBuf
+	sync.Opts.DeadlockTimeout = deadlockTimeout
+	sync.Opts.OnPotentialDeadlock = func() {
+		serverLogger.Crit("deadlock", "err", deadlockBuf.String())
+	}
+	waitgroup.Opts.Disable = true
+
+	// start the jobqueue server
+	server, msg, token, err := jobqueue.Serve(jobqueue.ServerConfig{
+		Port:            config.ManagerPort,
+		WebPort:         config.ManagerWeb,
+		PublicWebPort:   publicWebPort,
+		SchedulerName:   schedulerName,
+		SchedulerConfig: schedulerConfig,
+		RunnerCmd:       runnerCmd,
+		DBFile:          config.ManagerDbFile,
+		DBFileBackup:    config.ManagerDbBkFile,
+		TokenFile:       config.ManagerTokenFile,
+		SecretsFile:     config.ManagerSecretsFile,
+		UploadDir:       config.ManagerUploadDir,
+		CopyDir:         config.ManagerCopyDir,
+		TransferSlots:   config.ManagerTransferSlots,
+		TransferRate:    int64(config.ManagerTransferRate) * 1024 * 1024,
+		FailureArchive:  config.ManagerFailureArchive,
+		CAFile:          config.ManagerCAFile,
+		CertFile:        config.ManagerCertFile,
+		KeyFile:         config.ManagerKeyFile,
+		CertDomain:      config.ManagerCertDomain,
+		DomainMatchesIP: useCertDomain,
+		AutoConfirmDead: time.Duration(cloudServersAutoConfirmDead) * time.Minute,
+		Deployment:      config.Deployment,
+		CIDR:            serverCIDR,
+		Logger:          serverLogger,
+		LogLevelFilter:  logFilter,
+		FailureRules:    failureRules,
+		RunAsUsers:      splitConfigList(config.ManagerRunAsUsers),
+		RedactionRules:  redactionRules,
+		WebOverlayDir:   config.ManagerWebOverlay,
+		OIDC:            oidcConfig(),
+
+		HeartbeatInterval:  time.Duration(config.ManagerHeartbeat) * time.Second,
+		LostContactTimeout: time.Duration(config.ManagerLostAfter) * time.Second,
+		LostRequeueGrace:   time.Duration(config.ManagerLostRequeue) * time.Minute,
+		RecycleWindow:      time.Duration(config.ManagerRecycleWindow) * time.Minute,
+		CheckConsistency:   startFsck,
+		RequestTimeout:     time.Duration(config.ManagerRequestTimeout) * time.Second,
+		TraceEndpoint:      config.ManagerTraceEndpoint,
+		Notifications:      notifications,
+	})
+
+	if msg != "" {
+		info("wr manager : %s", msg)
+	}
+
+	if err != nil {
+		die("wr manager failed to start : %s", err)
+	}
+
+	logStarted(server.ServerInfo, token)
+	l15h.AddHandler(appLogger, fh) // logStarted disabled logging to file; reenable to get final message below
+	notifySystemd(server)
+
+	// block forever while the jobqueue does its work
+	err = server.Block()
+	if _, errn := internal.SdNotify("STOPPING=1"); errn != nil {
+		warn("could not tell systemd we are stopping: %s", errn)
+	}
+	if err != nil {
+		saddr := sAddr(server.ServerInfo)
+		jqerr, ok := err.(jobqueue.Error)
+		switch {
+		case ok && jqerr.Err == jobqueue.ErrClosedTerm:
+			info("wr manager on %s gracefully stopped (received SIGTERM)", saddr)
+		case ok && jqerr.Err == jobqueue.ErrClosedInt:
+			info("wr manager on %s gracefully stopped (received SIGINT)", saddr)
+		case ok && jqerr.Err == jobqueue.ErrClosedStop:
+			info("wr manager on %s gracefully stopped (following a drain)", saddr)
+		default:
+			warn("wr manager on %s exited unexpectedly: %s", saddr, err)
+		}
+	}
+}
+
+// notifySystemd tells systemd that the manager is ready, if it started us as a
+// Type=notify service (see "wr manager install-service"). If systemd is also
+// watching us, keeps telling it we're alive for as long as the server can still
+// report its stats, so that a hung manager gets restarted.
+func notifySystemd(server *jobqueue.Server) {
+	notified, err := internal.SdNotify(fmt.Sprintf("READY=1\nMAINPID=%d", os.Getpid()))
+	if err != nil {
+		warn("could not tell systemd we are ready: %s", err)
+		return
+	}
+	if !notified {
+		return
+	}
+
+	interval, err := internal.SdWatchdogInterval()
+	if err != nil {
+		warn("could not get the systemd watchdog interval: %s", err)
+		return
+	}
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval / 2)
+		defer ticker.Stop()
+		for range ticker.C {
+			stats := server.GetServerStats()
+			state := fmt.Sprintf("WATCHDOG=1\nSTATUS=%d running, %d ready, %d delayed, %d buried", stats.Running, stats.Ready, stats.Delayed, stats.Buried)
+			if _, errn := internal.SdNotify(state); errn != nil {
+				warn("could not tell systemd we are alive: %s", errn)
+			}
+		}
+	}()
+}
+
+// schedulerShell returns the shell executable that schedulers should run
+// commands with: that of the runnerexecshell config option without any of its
+// arguments, or bash if it is "none".
+func schedulerShell() string {
+	fields := strings.Fields(config.RunnerExecShell)
+	if config.RunnerExecShell == jobqueue.ShellNone || len(fields) == 0 {
+		return "bash"
+	}
+	return fields[0]
+}
+
+// schedulerConfigFor returns the config for the given scheduler, along with the
+// CIDR the server should use, if any.
+func schedulerConfigFor(scheduler, exe string, postCreation []byte) (schedulerConfig interface{}, serverCIDR string) {
 	switch scheduler {
 	case "local":
 		schedulerConfig = &jqs.ConfigLocal{
-			Shell:    config.RunnerExecShell,
-			MaxCores: maxLocalCores,
-			MaxRAM:   maxLocalRAM,
+			Shell:         schedulerShell(),
+			MaxCores:      maxLocalCores,
+			MaxRAM:        maxLocalRAM,
+			ReservedCores: localReservedCores,
+			ReservedRAM:   localReservedRAM,
+			CPUOvercommit: localCPUOvercommit,
+			RAMOvercommit: localRAMOvercommit,
+			PinCores:      localPinCores,
+			NUMABind:      localNUMABind,
 		}
 	case "lsf":
-		schedulerConfig = &jqs.ConfigLSF{Deployment: config.Deployment, Shell: config.RunnerExecShell}
+		var queues []string
+		if config.ManagerLSFQueues != "" {
+			queues = strings.Split(config.ManagerLSFQueues, ",")
+		}
+		schedulerConfig = &jqs.ConfigLSF{
+			Deployment:    config.Deployment,
+			Shell:         schedulerShell(),
+			Queues:        queues,
+			BjobsCacheTTL: time.Duration(config.ManagerLSFBjobsTTL) * time.Second,
+			BatchWindow:   time.Duration(config.ManagerLSFBatch) * time.Second,
+		}
 	case "openstack":
 		mport, errf := strconv.Atoi(config.ManagerPort)
 		if errf != nil {
@@ -650,7 +1261,10 @@ func startJQ(postCreation []byte) {
 			OSDisk:               osDisk,
 			FlavorRegex:          flavorRegex,
 			FlavorSets:           flavorSets,
+			NetworkGroups:        cloudNetworkGroups,
 			PostCreationScript:   postCreation,
+			FlavorScripts:        cloudFlavorScriptContents,
+			VolumeMountPath:      cloudVolumeMount,
 			ConfigFiles:          cloudConfigFiles,
 			ServerKeepTime:       time.Duration(serverKeepAlive) * time.Second,
 			StateUpdateFrequency: 1 * time.Minute,
@@ -658,7 +1272,8 @@ func startJQ(postCreation []byte) {
 			SimultaneousSpawns:   cloudSpawns,
 			MaxLocalCores:        &maxLocalCores,
 			MaxLocalRAM:          &maxLocalRAM,
-			Shell:                config.RunnerExecShell,
+			Shell:                schedulerShell(),
+			Version:              jobqueue.ServerVersion,
 			CIDR:                 cloudCIDR,
 			Umask:                config.ManagerUmask,
 		}
@@ -669,16 +1284,21 @@ func startJQ(postCreation []byte) {
 			PostCreationScript: postCreation,
 			ConfigMap:          configMapName,
 			ConfigFiles:        cloudConfigFiles,
-			Shell:              config.RunnerExecShell,
+			Shell:              schedulerShell(),
 			TempMountPath:      filepath.Dir(exe) + "/",
 			LocalBinaryPath:    exe,
 			Namespace:          kubeNamespace,
 			ManagerDir:         config.ManagerDir,
 			Debug:              managerDebug,
 		}
-
 	}
+	return schedulerConfig, serverCIDR
+}
 
+// prepareCloudScheduler adds our ca.pem and client.token files to the given
+// config if it is for a cloud scheduler, and dies if we're not actually in the
+// relevant cloud.
+func prepareCloudScheduler(scheduler string, schedulerConfig interface{}) {
 	if cloudConfig, ok := schedulerConfig.(jqs.CloudConfig); ok {
 		// this is a cloud scheduler, so include our ca.pem and client.token
 		// files in ConfigFiles, so that they will be copied to all servers
@@ -706,69 +1326,6 @@ func startJQ(postCreation []byte) {
 			}
 		}
 	}
-
-	runnerCmd := exe + " runner -s '%s' --deployment %s --server '%s' --domain %s -r %d -m %d"
-	if runnerDebug {
-		runnerCmd += " --debug"
-	}
-
-	deadlockBuf := new(bytes.Buffer)
-	sync.Opts.LogBuf = deadlockBuf
-	sync.Opts.DeadlockTimeout = deadlockTimeout
-	sync.Opts.OnPotentialDeadlock = func() {
-		serverLogger.Crit("deadlock", "err", deadlockBuf.String())
-	}
-	waitgroup.Opts.Disable = true
-
-	// start the jobqueue server
-	server, msg, token, err := jobqueue.Serve(jobqueue.ServerConfig{
-		Port:            config.ManagerPort,
-		WebPort:         config.ManagerWeb,
-		SchedulerName:   scheduler,
-		SchedulerConfig: schedulerConfig,
-		RunnerCmd:       runnerCmd,
-		DBFile:          config.ManagerDbFile,
-		DBFileBackup:    config.ManagerDbBkFile,
-		TokenFile:       config.ManagerTokenFile,
-		UploadDir:       config.ManagerUploadDir,
-		CAFile:          config.ManagerCAFile,
-		CertFile:        config.ManagerCertFile,
-		KeyFile:         config.ManagerKeyFile,
-		CertDomain:      config.ManagerCertDomain,
-		DomainMatchesIP: useCertDomain,
-		AutoConfirmDead: time.Duration(cloudServersAutoConfirmDead) * time.Minute,
-		Deployment:      config.Deployment,
-		CIDR:            serverCIDR,
-		Logger:          serverLogger,
-	})
-
-	if msg != "" {
-		info("wr manager : %s", msg)
-	}
-
-	if err != nil {
-		die("wr manager failed to start : %s", err)
-	}
-
-	logStarted(server.ServerInfo, token)
-	l15h.AddHandler(appLogger, fh) // logStarted disabled logging to file; reenable to get final message below
-
-	// block forever while the jobqueue does its work
-	err = server.Block()
-	if err != nil {
-		saddr := sAddr(server.ServerInfo)
-		jqerr, ok := err.(jobqueue.Error)
-		switch {
-		case ok && jqerr.Err == jobqueue.ErrClosedTerm:
-			info("wr manager on %s gracefully stopped (received SIGTERM)", saddr)
-		case ok && jqerr.Err == jobqueue.ErrClosedInt:
-			info("wr manager on %s gracefully stopped (received SIGINT)", saddr)
-		case ok && jqerr.Err == jobqueue.ErrClosedStop:
-			info("wr manager on %s gracefully stopped (following a drain)", saddr)
-		default:
-			warn("wr manager on %s exited unexpectedly: %s", saddr, err)
-		}
-	}
 }
 
 // deleteToken should be called on successful, known clean stop of the manager,
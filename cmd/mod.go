@@ -19,7 +19,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -35,6 +34,7 @@ import (
 // options for this cmd
 var cmdCwdMattersUnset bool
 var cmdChangeHomeUnset bool
+var cmdCwdLinkUnset bool
 var cmdCloudSharedDiskUnset bool
 
 const nothingBehaviour = `[{"Nothing":true}]`
@@ -143,6 +143,46 @@ new internal ids is printed.`,
 		if cobraCmd.Flags().Changed("limit_grps") {
 			jm.SetLimitGroups(strings.Split(cmdLimitGroups, ","))
 		}
+		if cobraCmd.Flags().Changed("input_files") {
+			var inputFiles []string
+			if cmdInputFiles != "" {
+				inputFiles = strings.Split(cmdInputFiles, ",")
+			}
+			jm.SetInputFiles(inputFiles)
+		}
+		if cobraCmd.Flags().Changed("output_files") {
+			var outputFiles []string
+			if cmdOutputFiles != "" {
+				outputFiles = strings.Split(cmdOutputFiles, ",")
+			}
+			jm.SetOutputFiles(outputFiles)
+		}
+		if cobraCmd.Flags().Changed("output_min_size") {
+			jm.SetOutputMinSize(int64(cmdOutputMinSize))
+		}
+		if cobraCmd.Flags().Changed("output_check_cmd") {
+			jm.SetOutputCheckCmd(cmdOutputCheckCmd)
+		}
+		if cobraCmd.Flags().Changed("output_checksums") {
+			jm.SetOutputChecksums(cmdOutputChecksums)
+		}
+		if cobraCmd.Flags().Changed("irods_inputs") {
+			var irodsInputs []string
+			if cmdIRODSInputs != "" {
+				irodsInputs = strings.Split(cmdIRODSInputs, ",")
+			}
+			jm.SetIRODSInputs(irodsInputs)
+		}
+		if cobraCmd.Flags().Changed("irods_collection") {
+			jm.SetIRODSCollection(cmdIRODSCollection)
+		}
+		if cobraCmd.Flags().Changed("irods_meta") {
+			meta, err := jobqueue.ParseIRODSMetadata(cmdIRODSMeta)
+			if err != nil {
+				die("--irods_meta was not specified correctly: %s", err)
+			}
+			jm.SetIRODSMetadata(meta)
+		}
 
 		// *** implementing dep_grps modification is complex; not done for now
 		// if cobraCmd.Flags().Changed("dep_grps") {
@@ -162,6 +202,22 @@ new internal ids is printed.`,
 		} else if cmdChangeHomeUnset {
 			jm.SetChangeHome(false)
 		}
+		if cobraCmd.Flags().Changed("cwd_template") {
+			if cmdCwdTemplate != "" {
+				if err := jobqueue.ValidateCwdTemplate(cmdCwdTemplate); err != nil {
+					die("%s", err)
+				}
+			}
+			jm.SetCwdTemplate(cmdCwdTemplate)
+		}
+		if cobraCmd.Flags().Changed("cwd_base") {
+			jm.SetCwdBase(cmdCwdBase)
+		}
+		if cmdCwdLink {
+			jm.SetCwdLink(true)
+		} else if cmdCwdLinkUnset {
+			jm.SetCwdLink(false)
+		}
 		if cobraCmd.Flags().Changed("req_grp") {
 			jm.SetReqGroup(reqGroup)
 		}
@@ -236,6 +292,13 @@ new internal ids is printed.`,
 		} else if cmdCloudSharedDiskUnset {
 			other["cloud_shared"] = "false"
 		}
+		if cobraCmd.Flags().Changed("cloud_volume") {
+			if cmdCloudVolume > 0 {
+				other[jqs.VolumeOtherKey] = strconv.Itoa(cmdCloudVolume)
+			} else {
+				otherSet = true
+			}
+		}
 		if len(other) > 0 || otherSet {
 			req.Other = other
 			req.OtherSet = true
@@ -278,45 +341,70 @@ new internal ids is printed.`,
 			jm.SetMonitorDocker(cmdMonitorDocker)
 		}
 
+		if cobraCmd.Flags().Changed("run_as") {
+			jm.SetRunAs(cmdRunAs)
+		}
+
+		if cobraCmd.Flags().Changed("shell") {
+			jm.SetShell(cmdShell)
+		}
+
+		politeness := jobqueue.Politeness{Nice: cmdNice, IONice: cmdIONice, OOMScoreAdj: cmdOOMScoreAdj}
+		if err = politeness.Validate(); err != nil {
+			die("%s", err)
+		}
+		if cobraCmd.Flags().Changed("nice") {
+			jm.SetNice(cmdNice)
+		}
+		if cobraCmd.Flags().Changed("ionice") {
+			jm.SetIONice(cmdIONice)
+		}
+		if cobraCmd.Flags().Changed("oom_score_adj") {
+			jm.SetOOMScoreAdj(cmdOOMScoreAdj)
+		}
+
+		perms := jobqueue.FilePermissions{Umask: cmdUmask, Group: cmdFileGroup}
+		if err = perms.Validate(); err != nil {
+			die("%s", err)
+		}
+		if cobraCmd.Flags().Changed("umask") {
+			jm.SetUmask(cmdUmask)
+		}
+		if cobraCmd.Flags().Changed("group") {
+			jm.SetGroup(cmdFileGroup)
+		}
+
+		if cobraCmd.Flags().Changed("report_cmd") {
+			jm.SetReportCmd(cmdReportCmd)
+		}
+
 		var behaviours jobqueue.Behaviours
 		var behavioursSet bool
 		if cobraCmd.Flags().Changed("on_failure") {
 			if cmdOnFailure == "" {
 				cmdOnFailure = nothingBehaviour
 			}
-			var bjs jobqueue.BehavioursViaJSON
-			err = json.Unmarshal([]byte(cmdOnFailure), &bjs)
-			if err != nil {
-				die("bad --on_failure: %s", err)
-			}
-			behaviours = bjs.Behaviours(jobqueue.OnFailure)
+			behaviours = parseBehavioursFlag(jq, "on_failure", cmdOnFailure, jobqueue.OnFailure)
 			behavioursSet = true
 		}
 		if cobraCmd.Flags().Changed("on_success") {
 			if cmdOnSuccess == "" {
 				cmdOnSuccess = nothingBehaviour
 			}
-			var bjs jobqueue.BehavioursViaJSON
-			err = json.Unmarshal([]byte(cmdOnSuccess), &bjs)
-			if err != nil {
-				die("bad --on_success: %s", err)
-			}
-			behaviours = append(behaviours, bjs.Behaviours(jobqueue.OnSuccess)...)
+			behaviours = append(behaviours, parseBehavioursFlag(jq, "on_success", cmdOnSuccess, jobqueue.OnSuccess)...)
 			behavioursSet = true
 		}
 		if cobraCmd.Flags().Changed("on_exit") {
 			if cmdOnExit == "" {
 				cmdOnExit = nothingBehaviour
 			}
-			var bjs jobqueue.BehavioursViaJSON
-			err = json.Unmarshal([]byte(cmdOnExit), &bjs)
-			if err != nil {
-				die("bad --on_exit: %s", err)
-			}
-			behaviours = append(behaviours, bjs.Behaviours(jobqueue.OnExit)...)
+			behaviours = append(behaviours, parseBehavioursFlag(jq, "on_exit", cmdOnExit, jobqueue.OnExit)...)
 			behavioursSet = true
 		}
 		if behavioursSet {
+			if err = behaviours.Validate(); err != nil {
+				die("bad behaviours: %s", err)
+			}
 			jm.SetBehaviours(behaviours)
 		}
 
@@ -366,12 +454,24 @@ func init() {
 
 	modCmd.Flags().StringVar(&cmdLine, "cmdline", "", "new command line")
 	modCmd.Flags().StringVarP(&cmdLimitGroups, "limit_grps", "l", "", "comma-separated list of limit groups")
+	modCmd.Flags().StringVar(&cmdInputFiles, "input_files", "", "comma-separated list of files the commands read, which must exist before they run")
+	modCmd.Flags().StringVar(&cmdOutputFiles, "output_files", "", "comma-separated list of files the commands create, which must exist and be non-empty after they succeed")
+	modCmd.Flags().IntVar(&cmdOutputMinSize, "output_min_size", 0, "minimum size in bytes of each of --output_files")
+	modCmd.Flags().StringVar(&cmdOutputCheckCmd, "output_check_cmd", "", "command to validate each of --output_files, given as $WR_OUTPUT")
+	modCmd.Flags().BoolVar(&cmdOutputChecksums, "output_checksums", false, "record the size and MD5 checksum of each of --output_files on success")
+	modCmd.Flags().StringVar(&cmdIRODSInputs, "irods_inputs", "", "comma-separated list of iRODS data objects to fetch in to the working dir before the commands run")
+	modCmd.Flags().StringVar(&cmdIRODSCollection, "irods_collection", "", "iRODS collection to put --output_files in to after the commands succeed")
+	modCmd.Flags().StringVar(&cmdIRODSMeta, "irods_meta", "", "comma-separated list of attribute=value metadata to set on outputs put in to iRODS")
 	// modCmd.Flags().StringVarP(&cmdDepGroups, "dep_grps", "e", "", "comma-separated list of dependency groups")
 	modCmd.Flags().StringVarP(&cmdCwd, "cwd", "c", "", "base for the command's working dir")
 	modCmd.Flags().BoolVar(&cmdCwdMatters, "cwd_matters", false, "--cwd should be used as the actual working directory")
 	modCmd.Flags().BoolVar(&cmdCwdMattersUnset, "unset_cwd_matters", false, "unset --cwd_matters")
 	modCmd.Flags().BoolVar(&cmdChangeHome, "change_home", false, "when not --cwd_matters, set $HOME to the actual working directory")
 	modCmd.Flags().BoolVar(&cmdChangeHomeUnset, "unset_change_home", false, "unset --change_home")
+	modCmd.Flags().StringVar(&cmdCwdTemplate, "cwd_template", "", "when not --cwd_matters, relative path of the unique working directory, eg. {repgroup}/{key}")
+	modCmd.Flags().StringVar(&cmdCwdBase, "cwd_base", "", "when not --cwd_matters, make the unique working directory in here instead of --cwd")
+	modCmd.Flags().BoolVar(&cmdCwdLink, "cwd_link", false, "when using --cwd_base, symlink to the actual working directory from --cwd")
+	modCmd.Flags().BoolVar(&cmdCwdLinkUnset, "unset_cwd_link", false, "unset --cwd_link")
 	modCmd.Flags().StringVarP(&reqGroup, "req_grp", "g", "", "group name for commands with similar reqs")
 	modCmd.Flags().StringVarP(&cmdMem, "memory", "m", "1G", "peak mem est. [specify units such as M for Megabytes or G for Gigabytes]")
 	modCmd.Flags().StringVarP(&cmdTime, "time", "t", "1h", "max time est. [specify units such as m for minutes or h for hours]")
@@ -383,6 +483,14 @@ func init() {
 	modCmd.Flags().StringVar(&cmdCmdDeps, "cmd_deps", "", "dependencies of your commands, in the form \"command1,cwd1,command2,cwd2...\"")
 	modCmd.Flags().StringVarP(&cmdGroupDeps, "deps", "d", "", "dependencies of your commands, in the form \"dep_grp1,dep_grp2...\"")
 	modCmd.Flags().StringVar(&cmdMonitorDocker, "monitor_docker", "", "monitor resource usage of docker container with given --name or --cidfile path")
+	modCmd.Flags().StringVar(&cmdRunAs, "run_as", "", "name of the user to run the commands as, via sudo")
+	modCmd.Flags().StringVar(&cmdShell, "shell", "", "shell to run the commands with, eg. \"bash -l\", or none to run them directly")
+	modCmd.Flags().IntVar(&cmdNice, "nice", 0, "[0-19] niceness to run the commands with (0 means runnernice config)")
+	modCmd.Flags().StringVar(&cmdIONice, "ionice", "", "IO class to run the commands with, eg. idle or best-effort:7")
+	modCmd.Flags().IntVar(&cmdOOMScoreAdj, "oom_score_adj", 0, "[-1000-1000] oom_score_adj to run the commands with (0 means runneroomscoreadj config)")
+	modCmd.Flags().StringVar(&cmdUmask, "umask", "", "octal umask to run the commands with, eg. 0002 (blank means runnerumask config)")
+	modCmd.Flags().StringVar(&cmdFileGroup, "group", "", "group to give the commands' working directories and output files (blank means runnergroup config)")
+	modCmd.Flags().StringVar(&cmdReportCmd, "report_cmd", "", "command to run after commands succeed, that outputs key=value metrics")
 	modCmd.Flags().StringVar(&cmdOnFailure, "on_failure", "", "behaviours to carry out when cmds fails, in JSON format")
 	modCmd.Flags().StringVar(&cmdOnSuccess, "on_success", "", "behaviours to carry out when cmds succeed, in JSON format")
 	modCmd.Flags().StringVar(&cmdOnExit, "on_exit", `[{"cleanup":true}]`, "behaviours to carry out when cmds finish running, in JSON format")
@@ -396,6 +504,7 @@ func init() {
 	modCmd.Flags().StringVar(&cmdCloudConfigs, "cloud_config_files", "", "in the cloud, comma separated paths of config files to copy to servers created to run these commands")
 	modCmd.Flags().BoolVar(&cmdCloudSharedDisk, "cloud_shared", false, "mount /shared")
 	modCmd.Flags().BoolVar(&cmdCloudSharedDiskUnset, "unset_cloud_shared", false, "unset --cloud_shared")
+	modCmd.Flags().IntVar(&cmdCloudVolume, "cloud_volume", 0, "in the cloud, size (GB) of a scratch volume to attach while each command runs")
 	modCmd.Flags().StringVar(&cmdEnv, "env", "", "comma-separated list of key=value environment variables to set before running the commands")
 	// modCmd.Flags().BoolVar(&cmdBsubMode, "bsub", false, "enable bsub emulation mode")
 
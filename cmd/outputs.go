@@ -0,0 +1,148 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// options for this cmd
+var outputsChecksums bool
+
+// outputsCmd represents the outputs command
+var outputsCmd = &cobra.Command{
+	Use:   "outputs",
+	Short: "Show the output files of commands",
+	Long: `Show the output files of commands you've previously added with
+"wr add --output_files".
+
+Specify one of the flags -f, -l or -i to choose which commands you want the
+outputs of.
+
+-i is the report group (-i) you supplied to "wr add" when you added the job(s)
+you want the outputs of. Combining with -z lets you get the outputs of jobs in
+multiple report groups, assuming you have arranged that related groups share
+some substring. Alternatively -y lets you specify -i as the internal job id
+reported during "wr status".
+
+The file to provide -f is in the format taken by "wr add".
+
+In -f and -l mode you must provide the cwd the commands were set to run in, if
+CwdMatters (and must NOT be provided otherwise). Likewise provide the mounts
+options that was used when the command was added, if any. You can do this by
+using the -c and --mounts/--mounts_json options in -l mode, or by providing the
+same file you gave to "wr add" in -f mode.
+
+By default the path of each output is shown, 1 per line, with relative paths
+made absolute using the directory the command ran in (if known).
+
+With --checksums, only the outputs of complete commands that were added with
+--output_checksums are shown, as 3 tab separated columns: MD5 checksum, size in
+bytes and path, as recorded when the command succeeded. You can use these to
+check that the outputs haven't changed since.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		set := countGetJobArgs()
+		if set > 1 {
+			die("-f, -i and -l are mutually exclusive; only specify one of them")
+		}
+		if set == 0 {
+			die("1 of -f, -i or -l is required")
+		}
+
+		timeout := time.Duration(timeoutint) * time.Second
+		jq := connect(timeout)
+		var err error
+		defer func() {
+			err = jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		jobs := getJobs(jq, "", false, 0, false, false)
+
+		if len(jobs) == 0 {
+			die("No matching jobs found")
+		}
+
+		unrecorded := 0
+		for _, job := range jobs {
+			if len(job.OutputFiles) == 0 {
+				continue
+			}
+
+			if !outputsChecksums {
+				for _, output := range job.OutputFiles {
+					fmt.Println(outputPath(job, output))
+				}
+				continue
+			}
+
+			if job.State != jobqueue.JobStateComplete || len(job.OutputRecords) == 0 {
+				unrecorded++
+				continue
+			}
+
+			for _, record := range job.OutputRecords {
+				fmt.Printf("%s\t%d\t%s\n", record.MD5, record.Size, record.Path)
+			}
+		}
+
+		if unrecorded > 0 {
+			warn("%d commands with outputs are incomplete or were not added with --output_checksums", unrecorded)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(outputsCmd)
+
+	// flags specific to this sub-command
+	outputsCmd.Flags().BoolVar(&outputsChecksums, "checksums", false, "show the recorded checksums and sizes of complete commands' outputs")
+	outputsCmd.Flags().StringVarP(&cmdFileStatus, "file", "f", "", "file containing commands you want the outputs of; - means read from STDIN")
+	outputsCmd.Flags().StringVarP(&cmdIDStatus, "identifier", "i", "", "identifier of the commands you want the outputs of")
+	outputsCmd.Flags().BoolVarP(&cmdIDIsSubStr, "search", "z", false, "treat -i as a substring to match against all report groups")
+	outputsCmd.Flags().BoolVarP(&cmdIDIsInternal, "internal", "y", false, "treat -i as an internal job id")
+	outputsCmd.Flags().StringVarP(&cmdLine, "cmdline", "l", "", "a command line you want the outputs of")
+	outputsCmd.Flags().StringVarP(&cmdCwd, "cwd", "c", "", "working dir that the command(s) specified by -l or -f were set to run in")
+	outputsCmd.Flags().StringVarP(&mountJSON, "mount_json", "j", "", "mounts that the command(s) specified by -l or -f were set to use (JSON format)")
+	outputsCmd.Flags().StringVar(&mountSimple, "mounts", "", "mounts that the command(s) specified by -l or -f were set to use (simple format)")
+
+	outputsCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// outputPath returns the given output of the given job, made absolute using
+// the directory the job ran in, or would run in if its Cwd matters.
+func outputPath(job *jobqueue.Job, output string) string {
+	if filepath.IsAbs(output) {
+		return output
+	}
+	switch {
+	case job.ActualCwd != "":
+		return filepath.Join(job.ActualCwd, output)
+	case job.CwdMatters:
+		return filepath.Join(job.Cwd, output)
+	}
+	return output
+}
@@ -0,0 +1,188 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// options for this cmd
+var queueLimit int
+var queueScheduler string
+var queueSchedulerQueue string
+var queueSchedulerMisc string
+var queueOutput string
+
+// queueCmd represents the queue command
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Manage named wr queues",
+	Long: `Manage named wr queues.
+
+To isolate unrelated workloads that share a manager without needing separate
+deployments, you can add commands to a named wr queue with the --work_queue
+option of "wr add" (not to be confused with --queue, which is the queue of your
+job scheduler), eg.
+
+wr add --work_queue analysis -f analysis_cmds.txt
+wr add --work_queue qc -f qc_cmds.txt
+
+Commands in different wr queues are never run by the same runners. A wr queue
+exists as soon as commands are added to it, but you can also give it settings:
+
+wr queue set analysis --limit 100 --scheduler_queue long
+
+--limit is the maximum number of the queue's commands that may run at once,
+which is implemented as a limit group (see "wr limit") called "queue." followed
+by the queue's name. --scheduler, --scheduler_queue and --scheduler_misc are
+applied to commands subsequently added to the queue that don't set their own,
+as if you had supplied the --scheduler, --queue and --misc options of
+"wr add".
+
+See the commands in a wr queue with "wr status --work_queue".
+
+Use the sub-commands to set, list and delete queue settings.`,
+}
+
+// set sub-command sets queue settings
+var queueSetCmd = &cobra.Command{
+	Use:   "set QUEUE_NAME",
+	Short: "Set the settings of a wr queue",
+	Long: `Set the settings of a wr queue.
+
+Any existing settings of the queue are replaced. A --limit of 0 means no limit.
+Changing the limit affects the queue's commands straight away, but changes to
+the scheduler settings only affect commands added afterwards.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		qc := &jobqueue.QueueConfig{
+			Name:           args[0],
+			Limit:          queueLimit,
+			Scheduler:      queueScheduler,
+			SchedulerQueue: queueSchedulerQueue,
+			SchedulerMisc:  queueSchedulerMisc,
+		}
+		err := queueClient(func(jq *jobqueue.Client) error {
+			var errs error
+			qc, errs = jq.SetQueueConfig(qc)
+			return errs
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		info("queue set; %s", qc)
+	},
+}
+
+// list sub-command shows queue settings
+var queueListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the settings of wr queues",
+	Long: `List the settings of wr queues.
+
+The default -o plain output has tab separated columns of the queue name, its
+limit (0 means no limit), scheduler, scheduler queue and scheduler misc
+settings. -o json outputs the settings as an array of JSON objects. Queues that
+have commands added to them but no settings are not listed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var qcs []*jobqueue.QueueConfig
+		err := queueClient(func(jq *jobqueue.Client) error {
+			var errg error
+			qcs, errg = jq.GetQueueConfigs()
+			return errg
+		})
+		if err != nil {
+			die("%s", err)
+		}
+
+		switch queueOutput {
+		case "json", "j":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetEscapeHTML(false)
+			if qcs == nil {
+				qcs = []*jobqueue.QueueConfig{}
+			}
+			err = encoder.Encode(qcs)
+			if err != nil {
+				die("failed to encode queue settings: %s", err)
+			}
+		case "plain", "p":
+			for _, qc := range qcs {
+				fmt.Printf("%s\t%d\t%s\t%s\t%s\n", qc.Name, qc.Limit, qc.Scheduler, qc.SchedulerQueue, qc.SchedulerMisc)
+			}
+		default:
+			die("invalid -o format specified")
+		}
+	},
+}
+
+// delete sub-command removes queue settings
+var queueDeleteCmd = &cobra.Command{
+	Use:   "delete QUEUE_NAME",
+	Short: "Delete the settings of a wr queue",
+	Long: `Delete the settings of a wr queue, including its limit.
+
+Commands already added to the queue stay in it, and are still run separately
+from other queues' commands.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := queueClient(func(jq *jobqueue.Client) error {
+			return jq.DeleteQueueConfig(args[0])
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		info("settings of queue %s deleted", args[0])
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(queueCmd)
+	queueCmd.AddCommand(queueSetCmd)
+	queueCmd.AddCommand(queueListCmd)
+	queueCmd.AddCommand(queueDeleteCmd)
+
+	// flags specific to these sub-commands
+	queueSetCmd.Flags().IntVar(&queueLimit, "limit", 0, "maximum number of the queue's commands that may run at once; 0 for no limit")
+	queueSetCmd.Flags().StringVar(&queueScheduler, "scheduler", "", "scheduler to route the queue's commands to, when the manager uses more than one")
+	queueSetCmd.Flags().StringVar(&queueSchedulerQueue, "scheduler_queue", "", "job scheduler queue to submit the queue's commands to")
+	queueSetCmd.Flags().StringVar(&queueSchedulerMisc, "scheduler_misc", "", "miscellaneous job scheduler options for the queue's commands")
+	queueListCmd.Flags().StringVarP(&queueOutput, "output", "o", "plain", "['plain','json'] output format")
+
+	queueCmd.PersistentFlags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// queueClient connects to the manager, calls the given function with the
+// client, and disconnects.
+func queueClient(f func(jq *jobqueue.Client) error) error {
+	jq := connect(time.Duration(timeoutint) * time.Second)
+	defer func() {
+		err := jq.Disconnect()
+		if err != nil {
+			warn("Disconnecting from the server failed: %s", err)
+		}
+	}()
+	return f(jq)
+}
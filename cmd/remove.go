@@ -34,7 +34,8 @@ are currently incomplete and not running using this command.
 
 For use when you've made a mistake when specifying the command and it will never
 work. If you want to remove commands that are currently running you will need to
-"wr kill" them first.
+"wr kill" them first. If you remove the wrong commands, you can restore them
+with "wr undo" for a while afterwards.
 
 Specify one of the flags -f, -l, -i or -a to choose which commands you want to
 remove. Amongst those, only currently incomplete, non-running jobs will be
@@ -93,7 +94,7 @@ command line of a job with dependants, you can either:
 		if err != nil {
 			die("failed to remove desired jobs: %s", err)
 		}
-		info("Removed %d incomplete, non-running commands (out of %d eligible)", removed, len(jobs))
+		info("Removed %d incomplete, non-running commands (out of %d eligible)%s", removed, len(jobs), undoHint(jq))
 	},
 }
 
@@ -23,6 +23,7 @@ package cmd
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"syscall"
 	"time"
@@ -112,7 +113,7 @@ func init() {
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	config = internal.ConfigLoad(deployment, false, appLogger)
-	addr = config.ManagerHost + ":" + config.ManagerPort
+	addr = net.JoinHostPort(config.ManagerHost, config.ManagerPort)
 	caFile = config.ManagerCAFile
 }
 
@@ -280,7 +281,7 @@ func sAddr(s *jobqueue.ServerInfo) string {
 	if saddr == "localhost" {
 		saddr = s.Addr
 	} else {
-		saddr += ":" + s.Port
+		saddr = net.JoinHostPort(saddr, s.Port)
 	}
 	return saddr
 }
@@ -294,10 +295,13 @@ func connect(wait time.Duration, expectedToBeDown ...bool) *jobqueue.Client {
 		die("could not read token file; has the manager been started? [%s]", err)
 	}
 
-	jq, err := jobqueue.Connect(config.ManagerHost+":"+config.ManagerPort, caFile, config.ManagerCertDomain, token, wait)
+	jq, err := jobqueue.Connect(net.JoinHostPort(config.ManagerHost, config.ManagerPort), caFile, config.ManagerCertDomain, token, wait)
 	if err != nil && !(len(expectedToBeDown) == 1 && expectedToBeDown[0]) {
 		die("%s", err)
 	}
+	if jq != nil {
+		jq.SetSecretGrants(secretGrants())
+	}
 	return jq
 }
 
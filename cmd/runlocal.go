@@ -0,0 +1,325 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	jqs "github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+	"github.com/inconshreveable/log15"
+	"github.com/kardianos/osext"
+	"github.com/spf13/cobra"
+)
+
+const (
+	runLocalCertDomain  = "localhost"
+	runLocalMaxReported = 10
+
+	// runners are on the same machine as us, so we can hear from them often,
+	// which also lets us stop quickly once everything has run
+	runLocalHeartbeat   = 2 * time.Second
+	runLocalLostContact = 30 * time.Second
+)
+
+// options for this cmd
+var runLocalPersist string
+var runLocalMaxCores int
+var runLocalMaxRAM int
+var runLocalInterval int
+
+// runLocalCmd represents the run-local command
+var runLocalCmd = &cobra.Command{
+	Use:   "run-local",
+	Short: "Run commands on this machine without a manager",
+	Long: `Run commands on this machine without a manager.
+
+For small workflows on a laptop or workstation, where starting, stopping and
+looking after a manager would be overkill, this reads a file of commands (in
+exactly the same format as "wr add", including per-command JSON options such
+as dependencies), runs them all on this machine, reporting on progress as it
+goes, and exits once nothing more can be run.
+
+Commands are run the same way the manager's local scheduler would run them, so
+as many run at once as fit in --max_cores and --max_ram, and commands only
+start once the commands they depend on have completed. Failed commands are
+retried as normal, and then buried.
+
+No daemon is started; a private manager is run inside this process (on free
+ports, so it doesn't interfere with any manager you have running), which stops
+when this command exits. Its files, including its database, are kept in a
+temporary directory that is deleted afterwards, unless you use --persist to
+keep them in a directory of your choosing. Running again with the same
+--persist directory skips any commands that previously completed (except in
+the development deployment, where the database is always started afresh).
+
+The exit code is 0 if all commands completed, and 1 otherwise, in which case
+up to 10 of the buried commands are listed. Commands that were blocked from
+running because something they depend on was buried are also counted.
+
+Ctrl-C stops everything, including any commands that are running.`,
+	Run: func(combraCmd *cobra.Command, args []string) {
+		if runLocalInterval < 1 {
+			die("--interval must be at least 1")
+		}
+
+		jobs, _, _ := parseCmdFile(nil, false, false)
+		if len(jobs) == 0 {
+			die("no commands were supplied")
+		}
+		envVars := addEnvVars(true)
+
+		// (we don't die() beyond this point, so that we always get to clean up
+		// our manager and its files before exiting)
+		if exitCode := runLocal(jobs, envVars); exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	},
+}
+
+func init() {
+	defaultMaxRAM, err := internal.ProcMeminfoMBs()
+	if err != nil {
+		defaultMaxRAM = 0
+	}
+
+	RootCmd.AddCommand(runLocalCmd)
+
+	// flags specific to this sub-command
+	runLocalCmd.Flags().StringVarP(&cmdFile, "file", "f", "-", "file containing your commands; - means read from STDIN")
+	runLocalCmd.Flags().StringVarP(&cmdRepGroup, "rep_grp", "i", "manually_added", "reporting group for your commands")
+	runLocalCmd.Flags().StringVarP(&cmdCwd, "cwd", "c", "", "base for the command's working dir")
+	runLocalCmd.Flags().StringVar(&runLocalPersist, "persist", "", "directory to keep the database and other files in (defaults to a temporary directory)")
+	runLocalCmd.Flags().IntVar(&runLocalMaxCores, "max_cores", runtime.NumCPU(), "maximum number of local cores to use to run cmds; -1 means unlimited")
+	runLocalCmd.Flags().IntVar(&runLocalMaxRAM, "max_ram", defaultMaxRAM, "maximum MB of local memory to use to run cmds; -1 means unlimited")
+	runLocalCmd.Flags().IntVar(&runLocalInterval, "interval", 5, "how often (seconds) to report on progress")
+}
+
+// runLocal runs the given jobs with a private manager, as described for the
+// run-local command, cleaning up after itself. Returns the exit code the
+// command should exit with.
+func runLocal(jobs []*jobqueue.Job, envVars []string) int {
+	dir := runLocalPersist
+	if dir == "" {
+		var err error
+		dir, err = ioutil.TempDir("", "wr_run_local")
+		if err != nil {
+			appLogger.Error(fmt.Sprintf("could not create a temporary directory: %s", err))
+			return 1
+		}
+		defer func() {
+			errr := os.RemoveAll(dir)
+			if errr != nil {
+				warn("could not remove %s: %s", dir, errr)
+			}
+		}()
+	} else if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		appLogger.Error(fmt.Sprintf("could not create %s: %s", dir, err))
+		return 1
+	}
+
+	server, jq, err := startLocalManager(dir)
+	if err != nil {
+		appLogger.Error(err.Error())
+		return 1
+	}
+	defer server.Stop(true)
+	defer disconnectBench(jq)
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- server.Block()
+	}()
+
+	added, existed, err := jq.Add(jobs, envVars, true)
+	if err != nil {
+		appLogger.Error(fmt.Sprintf("failed to add commands: %s", err))
+		return 1
+	}
+	info("running %d commands (%d were duplicates or already complete)", added, existed)
+
+	counts, buried, err := waitForLocalJobs(jq, jobs, time.Duration(runLocalInterval)*time.Second, stopped)
+	if err != nil {
+		appLogger.Error(err.Error())
+		return 1
+	}
+
+	if len(buried) == 0 && counts[jobqueue.JobStateDependent] == 0 {
+		info("all %d commands completed", counts[jobqueue.JobStateComplete])
+		return 0
+	}
+
+	reportBuriedLocalJobs(buried)
+	warn("%d commands completed, %d were buried and %d were blocked by buried dependencies",
+		counts[jobqueue.JobStateComplete], len(buried), counts[jobqueue.JobStateDependent])
+	return 1
+}
+
+// startLocalManager starts a private manager using the local scheduler on free
+// ports with all its files in dir, returning it along with a client connected
+// to it.
+func startLocalManager(dir string) (*jobqueue.Server, *jobqueue.Client, error) {
+	port, err := benchFreePort()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not find a free port: %w", err)
+	}
+	webPort, err := benchFreePort()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not find a free port: %w", err)
+	}
+
+	// the runners we spawn read the locations of our token and CA files from
+	// their config, which they take from the environment they inherit from us
+	caFile := filepath.Join(dir, "ca.pem")
+	tokenFile := filepath.Join(dir, "token")
+	for key, val := range map[string]string{"WR_MANAGERCAFILE": caFile, "WR_MANAGERTOKENFILE": tokenFile} {
+		if err = os.Setenv(key, val); err != nil {
+			return nil, nil, fmt.Errorf("could not set %s: %w", key, err)
+		}
+	}
+
+	exe, err := osext.Executable()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not find the path to wr: %w", err)
+	}
+
+	serverLogger := log15.New()
+	fh, err := log15.FileHandler(filepath.Join(dir, "log"), log15.LogfmtFormat())
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not log to %s: %w", dir, err)
+	}
+	serverLogger.SetHandler(log15.LvlFilterHandler(log15.LvlWarn, fh))
+
+	server, msg, token, err := jobqueue.Serve(jobqueue.ServerConfig{
+		Port:          port,
+		WebPort:       webPort,
+		SchedulerName: "local",
+		SchedulerConfig: &jqs.ConfigLocal{
+			Shell:    schedulerShell(),
+			MaxCores: runLocalMaxCores,
+			MaxRAM:   runLocalMaxRAM,
+		},
+		RunnerCmd:    exe + " runner -s '%s' --deployment %s --server '%s' --domain %s -r %d -m %d",
+		DBFile:       filepath.Join(dir, "db"),
+		DBFileBackup: filepath.Join(dir, "db_bk"),
+		TokenFile:    tokenFile,
+		UploadDir:    filepath.Join(dir, "uploads"),
+		CAFile:       caFile,
+		CertFile:     filepath.Join(dir, "cert.pem"),
+		KeyFile:      filepath.Join(dir, "key.pem"),
+		CertDomain:   runLocalCertDomain,
+		Deployment:   config.Deployment,
+		Logger:       serverLogger,
+
+		HeartbeatInterval:  runLocalHeartbeat,
+		LostContactTimeout: runLocalLostContact,
+	})
+	if msg != "" {
+		info("local manager: %s", msg)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("local manager failed to start: %w", err)
+	}
+
+	jq, err := jobqueue.Connect(net.JoinHostPort(runLocalCertDomain, port), caFile, runLocalCertDomain, token, time.Duration(timeoutint)*time.Second)
+	if err != nil {
+		server.Stop(true)
+		return nil, nil, fmt.Errorf("could not connect to the local manager: %w", err)
+	}
+	return server, jq, nil
+}
+
+// waitForLocalJobs reports on the progress of the given jobs every interval,
+// until none of them are able to run any more. Returns how many ended up in
+// each state, along with the buried jobs. Returns an error if the manager stops
+// early (eg. due to ctrl-c).
+func waitForLocalJobs(jq *jobqueue.Client, jobs []*jobqueue.Job, interval time.Duration, stopped chan error) (map[jobqueue.JobState]int, []*jobqueue.Job, error) {
+	essences := make([]*jobqueue.JobEssence, len(jobs))
+	for i, job := range jobs {
+		essences[i] = job.ToEssense()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var lastReport string
+	for {
+		current, err := jq.GetByEssences(essences)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get the state of the commands: %w", err)
+		}
+
+		counts := make(map[jobqueue.JobState]int)
+		var buried []*jobqueue.Job
+		for _, job := range current {
+			state := job.State
+			switch state {
+			case jobqueue.JobStateReserved, jobqueue.JobStateStaging, jobqueue.JobStateUploading:
+				state = jobqueue.JobStateRunning
+			case jobqueue.JobStateNew, jobqueue.JobStateDelayed:
+				state = jobqueue.JobStateReady
+			case jobqueue.JobStateBuried:
+				buried = append(buried, job)
+			}
+			counts[state]++
+		}
+
+		report := fmt.Sprintf("%d complete, %d running, %d pending, %d waiting on dependencies, %d buried",
+			counts[jobqueue.JobStateComplete], counts[jobqueue.JobStateRunning]+counts[jobqueue.JobStateLost],
+			counts[jobqueue.JobStateReady], counts[jobqueue.JobStateDependent], counts[jobqueue.JobStateBuried])
+		if report != lastReport {
+			info(report)
+			lastReport = report
+		}
+
+		// dependent jobs can only be waiting on buried jobs once nothing else
+		// is left to run
+		if counts[jobqueue.JobStateRunning]+counts[jobqueue.JobStateLost]+counts[jobqueue.JobStateReady] == 0 {
+			return counts, buried, nil
+		}
+
+		select {
+		case err = <-stopped:
+			return nil, nil, fmt.Errorf("the local manager stopped early: %w", err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// reportBuriedLocalJobs prints the first few of the given buried jobs, with
+// the reason they failed.
+func reportBuriedLocalJobs(buried []*jobqueue.Job) {
+	sort.Slice(buried, func(i, j int) bool {
+		return buried[i].Cmd < buried[j].Cmd
+	})
+	for i, job := range buried {
+		if i == runLocalMaxReported {
+			info("(and %d more buried commands)", len(buried)-runLocalMaxReported)
+			break
+		}
+		info("buried: %s [%s]", strings.TrimSpace(job.Cmd), job.FailReason)
+	}
+}
@@ -21,6 +21,7 @@ package cmd
 import (
 	"fmt"
 	"log/syslog"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -41,6 +42,7 @@ var rserver string
 var rdomain string
 var maxtime int
 var logToSyslog bool
+var execKey string
 
 // runnerCmd represents the runner command
 var runnerCmd = &cobra.Command{
@@ -56,7 +58,19 @@ runner will pick up another and so on. Once max_time has been used (or would be
 used based on the expected time to complete of the next queued command), the
 runner stops picking up new commands and exits instead; max_time does not cause
 the runner to kill itself if the cmd it is running takes longer than max_time to
-complete.`,
+complete.
+
+To debug a command that keeps failing, you can run it yourself with
+--exec-key <key>, where key is the command's internal identifier, as shown by
+'wr status -o plain' or 'wr status -o details'. The command is taken from the
+queue even if it is delayed or buried, and run in the foreground on the current
+host, exactly as a runner would run it (with the same environment variables and
+working directory handling), but with your terminal connected to its input and
+output. Its exit is then handled as normal, so if it succeeds it will be
+complete, and if it fails it will be retried or buried. The manager's event log
+records that the command was run manually (see 'wr events'). Commands that use
+secrets (see 'wr secret') can't be run this way, since the manager won't give
+out their secrets for manual runs.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if runtime.NumCPU() == 1 {
 			// we might lock up with only 1 proc if we mount
@@ -72,7 +86,9 @@ complete.`,
 			}
 		}
 
-		info("wr runner started for scheduler group '%s'", schedgrp)
+		if execKey == "" {
+			info("wr runner started for scheduler group '%s'", schedgrp)
+		}
 
 		// the server receive timeout must be greater than the time we'll wait
 		// to Reserve()
@@ -103,6 +119,40 @@ complete.`,
 			jq.SetLogger(appLogger)
 		}
 
+		// survive brief outages of the manager
+		jq.SetOutageTolerance(time.Duration(config.RunnerOutageTolerance) * time.Second)
+
+		// guard against cleanup behaviours deleting shallow directories
+		jobqueue.BehaviourCleanupMinDepth = config.RunnerCleanupMinDepth
+
+		// be polite to other users of the machine by default
+		jobqueue.DefaultPoliteness = jobqueue.Politeness{
+			Nice:        config.RunnerNice,
+			IONice:      config.RunnerIONice,
+			OOMScoreAdj: config.RunnerOOMScoreAdj,
+		}
+
+		// let shared projects use the files that commands create
+		jobqueue.DefaultFilePermissions = jobqueue.FilePermissions{
+			Umask: config.RunnerUmask,
+			Group: config.RunnerGroup,
+		}
+
+		// don't overload iRODS, but survive its hiccups
+		jobqueue.IRODSMaxTransfers = config.RunnerIRODSTransfers
+		jobqueue.IRODSRetries = config.RunnerIRODSRetries
+
+		// share the reference asset cache with the other runners on this host
+		if config.RunnerRefCacheDir != "" {
+			jobqueue.RefAssetCacheDir = internal.TildaToHome(config.RunnerRefCacheDir)
+		}
+		jobqueue.RefAssetCacheSize = int64(config.RunnerRefCacheSize) * 1024 * 1024 * 1024
+
+		// keep commands' temporary files off the host's /tmp
+		if config.RunnerScratchDir != "" {
+			jobqueue.JobScratchDir = internal.TildaToHome(config.RunnerScratchDir)
+		}
+
 		// in case any job we execute has a Cmd that calls `wr add`, we will
 		// override their environment to make that call work
 		var envOverrides []string
@@ -123,6 +173,11 @@ complete.`,
 			exePath = filepath.Dir(exe)
 		}
 
+		if execKey != "" {
+			runKeyManually(jq, execKey, envOverrides, exePath)
+			return
+		}
+
 		// we'll stop the below loop before using up too much time
 		var endTime time.Time
 		if maxtime > 0 {
@@ -185,14 +240,8 @@ complete.`,
 					exitReason = "Env failed"
 					break
 				}
-				for _, envvar := range env {
-					pair := strings.Split(envvar, "=")
-					if pair[0] == "PATH" {
-						if !strings.Contains(pair[1], exePath) {
-							envOverrides = append(envOverrides, envvar+":"+exePath)
-						}
-						break
-					}
+				if path := pathOverride(env, exePath); path != "" {
+					envOverrides = append(envOverrides, path)
 				}
 
 				err = job.EnvAddOverride(envOverrides)
@@ -219,6 +268,15 @@ complete.`,
 					} else if strings.Contains(jqerr.Err, jobqueue.ErrStopReserving) {
 						exitReason = "we reconnected to a new server"
 						break
+					} else if jqerr.Err == jobqueue.FailReasonDrained {
+						exitReason = "this host is being drained"
+						break
+					} else if jqerr.Err == jobqueue.FailReasonAbandoned {
+						exitReason = "we lost contact with the manager"
+						break
+					} else if jqerr.Err == jobqueue.FailReasonHostDisk {
+						exitReason = "this host has insufficient disk space for our commands"
+						break
 					}
 				}
 			} else {
@@ -230,6 +288,58 @@ complete.`,
 	},
 }
 
+// pathOverride returns a PATH environment variable that adds exePath to the
+// PATH in the given env, or "" if it is already there (or there is no PATH).
+func pathOverride(env []string, exePath string) string {
+	for _, envvar := range env {
+		pair := strings.Split(envvar, "=")
+		if pair[0] == "PATH" {
+			if !strings.Contains(pair[1], exePath) {
+				return envvar + ":" + exePath
+			}
+			break
+		}
+	}
+	return ""
+}
+
+// runKeyManually reserves the job with the given key and runs it in the
+// foreground, connected to our terminal.
+func runKeyManually(jq *jobqueue.Client, key string, envOverrides []string, exePath string) {
+	job, err := jq.ReserveKey(key)
+	if err != nil {
+		die("could not get command %s: %s", key, err)
+	}
+
+	release := func(reason string, err error) {
+		if errr := jq.Release(job, nil, reason); errr != nil {
+			warn("job release failed: %s", errr)
+		}
+		die("%s: %s", reason, err)
+	}
+
+	if len(envOverrides) > 0 {
+		env, erre := job.Env()
+		if erre != nil {
+			release("failed to read job's Env", erre)
+		}
+		if path := pathOverride(env, exePath); path != "" {
+			envOverrides = append(envOverrides, path)
+		}
+		if erre = job.EnvAddOverride(envOverrides); erre != nil {
+			release("failed to add env var overrides", erre)
+		}
+	}
+
+	jq.SetExecuteIO(os.Stdin, os.Stdout, os.Stderr)
+	info("will start executing [%s]", job.Cmd)
+	err = jq.Execute(job, config.RunnerExecShell)
+	if err != nil {
+		die("%s", err)
+	}
+	info("command [%s] ran OK (exit code %d)", job.Cmd, job.Exitcode)
+}
+
 func init() {
 	RootCmd.AddCommand(runnerCmd)
 
@@ -241,4 +351,5 @@ func init() {
 	runnerCmd.Flags().StringVar(&rserver, "server", internal.DefaultServer(appLogger), "ip:port of wr manager")
 	runnerCmd.Flags().StringVar(&rdomain, "domain", internal.DefaultConfig(appLogger).ManagerCertDomain, "domain the manager's cert is valid for")
 	runnerCmd.Flags().BoolVar(&logToSyslog, "debug", false, "enable logging to syslog")
+	runnerCmd.Flags().StringVar(&execKey, "exec-key", "", "run the command with this key in the foreground, instead of running queued commands")
 }
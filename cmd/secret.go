@@ -0,0 +1,226 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// secretCmd represents the secret command
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage secrets that commands can use",
+	Long: `Manage secrets that commands can use.
+
+Commands sometimes need passwords or tokens, but putting these in the command
+line or environment of 'wr add' would result in them being stored in wr's
+database and being visible on the status page.
+
+Instead you can store them as secrets in the manager, which keeps them in an
+encrypted file (see the managersecretsfile config option). When adding commands,
+name the secrets they need with --secrets (or the "secrets" option), and they
+will be set as environment variables of the same name only at the moment the
+command is run. Their values are redacted from the stored output of the command.
+
+Since anyone with access to the manager could otherwise add a command that
+reveals a secret, commands can only use a secret if they are added by someone
+with its grant. Setting a secret gives you a new grant for it (and stops any
+previous grant from working), which is stored in your managergrantsfile and
+used automatically by 'wr add' and 'wr mod'. To let someone else use the secret,
+give them the grant and have them store it with 'wr secret grant'.
+
+Use the sub-commands to set, delete and list secrets, and store grants.`,
+}
+
+// set sub-command stores a secret
+var secretSetCmd = &cobra.Command{
+	Use:   "set NAME",
+	Short: "Store a secret",
+	Long: `Store a secret, replacing any existing one with the same name.
+
+The name must be valid as an environment variable name, eg. DB_PASS.
+
+The value is read from STDIN; if that is a terminal you will be prompted for it
+without it being echoed. Eg. wr secret set DB_PASS < pass.txt`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		value := readSecretValue()
+		if value == "" {
+			die("a secret value must be supplied")
+		}
+
+		var grant string
+		names, err := secretClient(func(jq *jobqueue.Client) ([]string, error) {
+			var names []string
+			var errs error
+			grant, names, errs = jq.SetSecret(args[0], value)
+			return names, errs
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		if err = storeSecretGrant(args[0], grant); err != nil {
+			warn("could not store the grant for secret %s: %s", args[0], err)
+		}
+		info("secret %s stored; there are now %d secrets", args[0], len(names))
+		info("grant for secret %s (needed by anyone else adding commands that use it): %s", args[0], grant)
+	},
+}
+
+// grant sub-command stores a grant someone else gave us
+var secretGrantCmd = &cobra.Command{
+	Use:   "grant NAME",
+	Short: "Store the grant to use a secret",
+	Long: `Store the grant to use a secret that someone else set.
+
+The grant, as output when the secret was set with 'wr secret set', is read from
+STDIN in the same way as a secret value, and stored in your managergrantsfile,
+so that you can add commands that use the secret.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		grant := readSecretValue()
+		if grant == "" {
+			die("a grant must be supplied")
+		}
+		if err := storeSecretGrant(args[0], grant); err != nil {
+			die("could not store the grant: %s", err)
+		}
+		info("grant for secret %s stored", args[0])
+	},
+}
+
+// delete sub-command removes a secret
+var secretDeleteCmd = &cobra.Command{
+	Use:   "delete NAME",
+	Short: "Delete a secret",
+	Long: `Delete a secret.
+
+Commands that need the secret will be buried when they try to run.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		_, err := secretClient(func(jq *jobqueue.Client) ([]string, error) {
+			return jq.DeleteSecret(args[0])
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		info("secret %s deleted", args[0])
+	},
+}
+
+// list sub-command shows secret names
+var secretListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the names of stored secrets",
+	Long:  `List the names (but not the values) of stored secrets.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		names, err := secretClient(func(jq *jobqueue.Client) ([]string, error) {
+			return jq.ListSecrets()
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(secretCmd)
+	secretCmd.AddCommand(secretSetCmd)
+	secretCmd.AddCommand(secretDeleteCmd)
+	secretCmd.AddCommand(secretListCmd)
+	secretCmd.AddCommand(secretGrantCmd)
+
+	secretCmd.PersistentFlags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// readSecretValue reads a secret value from STDIN, prompting without echo if
+// STDIN is a terminal.
+func readSecretValue() string {
+	fd := int(os.Stdin.Fd())
+	if terminal.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "secret value: ")
+		value, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			die("could not read the secret value: %s", err)
+		}
+		return string(value)
+	}
+
+	value, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		die("could not read the secret value: %s", err)
+	}
+	return strings.TrimRight(string(value), "\r\n")
+}
+
+// secretGrants returns the grants stored in our managergrantsfile, keyed on
+// secret name. Returns nil if there are none.
+func secretGrants() map[string]string {
+	content, err := ioutil.ReadFile(config.ManagerGrantsFile)
+	if err != nil {
+		return nil
+	}
+	var grants map[string]string
+	if err = json.Unmarshal(content, &grants); err != nil {
+		warn("could not parse the secret grants in %s: %s", config.ManagerGrantsFile, err)
+		return nil
+	}
+	return grants
+}
+
+// storeSecretGrant adds the given grant to our managergrantsfile, replacing any
+// previous grant for the same secret.
+func storeSecretGrant(name, grant string) error {
+	grants := secretGrants()
+	if grants == nil {
+		grants = make(map[string]string)
+	}
+	grants[name] = grant
+	content, err := json.Marshal(grants)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(config.ManagerGrantsFile, content, 0600)
+}
+
+// secretClient connects to the manager, calls the given function with the
+// client, and disconnects.
+func secretClient(f func(jq *jobqueue.Client) ([]string, error)) ([]string, error) {
+	jq := connect(time.Duration(timeoutint) * time.Second)
+	defer func() {
+		err := jq.Disconnect()
+		if err != nil {
+			warn("Disconnecting from the server failed: %s", err)
+		}
+	}()
+	return f(jq)
+}
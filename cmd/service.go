@@ -0,0 +1,339 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/kardianos/osext"
+	"github.com/spf13/cobra"
+)
+
+// options for this cmd
+var serviceSystem bool
+var serviceOutput string
+var serviceWatchdog int
+var serviceStartTimeout int
+
+// serviceEnvPrefixes are the prefixes of the environment variables we copy in
+// to the service, so that the manager can find its config and talk to its
+// schedulers.
+var serviceEnvPrefixes = []string{"WR_", "OS_", "LSF_", "KUBECONFIG"}
+
+// serviceUnitTemplate is the systemd unit we generate. KillMode=process means
+// that only the manager is stopped when the service is stopped or restarted,
+// so the commands it is running carry on as they would if it died.
+var serviceUnitTemplate = template.Must(template.New("unit").Parse(`[Unit]
+Description=wr manager ({{.Deployment}})
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+Type=notify
+NotifyAccess=main
+ExecStart={{.ExecStart}}
+{{if .User}}User={{.User}}
+{{end}}{{range .Env}}Environment={{.}}
+{{end}}KillMode=process
+Restart=on-failure
+RestartSec=10
+TimeoutStartSec={{.StartTimeout}}
+{{if .Watchdog}}WatchdogSec={{.Watchdog}}
+{{end}}
+[Install]
+WantedBy={{.WantedBy}}
+`))
+
+// servicePlistTemplate is the launchd property list we generate. Values must
+// already be XML escaped.
+var servicePlistTemplate = template.Must(template.New("plist").Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{.Label}}</string>
+	<key>ProgramArguments</key>
+	<array>
+{{range .Args}}		<string>{{.}}</string>
+{{end}}	</array>
+	<key>EnvironmentVariables</key>
+	<dict>
+{{range .Env}}		<key>{{.Key}}</key>
+		<string>{{.Value}}</string>
+{{end}}	</dict>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<dict>
+		<key>SuccessfulExit</key>
+		<false/>
+	</dict>
+</dict>
+</plist>
+`))
+
+// systemdPlainArg matches command line arguments that don't need quoting in a
+// systemd ExecStart line.
+var systemdPlainArg = regexp.MustCompile(`^[A-Za-z0-9_./:=,+@-]+$`)
+
+// serviceEnvVar is an environment variable for servicePlistTemplate.
+type serviceEnvVar struct {
+	Key   string
+	Value string
+}
+
+// managerInstallServiceCmd represents the manager install-service command
+var managerInstallServiceCmd = &cobra.Command{
+	Use:   "install-service [-- manager start options]",
+	Short: "Set up the manager to run as a supervised service",
+	Long: `Set up the manager to run as a supervised service.
+
+Rather than starting the manager with "wr manager start" (and having to start it
+again yourself if it dies or the machine reboots), in production you can have
+systemd (or launchd on macOS) run it for you. This writes the service
+definition needed to do that.
+
+On Linux, a systemd unit named wr-manager-[deployment].service is written to
+your systemd user unit directory (~/.config/systemd/user), or with --system, to
+/etc/systemd/system with the unit set to run the manager as you. Enable and
+start it with:
+systemctl --user daemon-reload
+systemctl --user enable --now wr-manager-production.service
+(without --user and as root for --system units). User services only run while
+you are logged in unless you also "loginctl enable-linger".
+
+The unit runs "wr manager start --foreground", which tells systemd when the
+manager is ready to accept commands. With --watchdog, the manager also
+periodically tells systemd that it is still working, and systemd will restart
+it if it stops doing so (eg. because it has hung). systemd will also restart it
+if it dies. Stopping the service stops only the manager; commands that are
+running carry on, and are picked up again when the manager next starts, as
+described in "wr manager -h".
+
+On macOS, a launchd agent named uk.ac.sanger.wr.manager.[deployment] is written
+to ~/Library/LaunchAgents, which you load with "launchctl load -w [path]".
+launchd has no readiness or watchdog support, but will restart the manager if
+it dies.
+
+The service gets your current $PATH, along with any WR_*, OS_*, LSF_* and
+KUBECONFIG environment variables you have set, so that it behaves as the
+manager would if you started it now. Since these may include secrets, the
+service file is only readable by you (or root). Regenerate it if your
+environment changes.
+
+Options to pass to "wr manager start" can be given after --, eg.
+wr manager install-service -- -s lsf --max_ram 10000
+
+Use -o to write the service file somewhere else, or -o - to just print it.
+
+You should stop any manager you already have running for this deployment before
+starting the service.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, arg := range args {
+			if arg == "--deployment" || strings.HasPrefix(arg, "--deployment=") {
+				die("specify --deployment before --, not as a manager start option")
+			}
+		}
+
+		exe, err := osext.Executable()
+		if err != nil {
+			die("could not find the path to wr: %s", err)
+		}
+
+		startArgs := append([]string{exe, "manager", "start", "--foreground", "--deployment", config.Deployment}, args...)
+		env := serviceEnv()
+
+		var content []byte
+		var path, name string
+		launchd := runtime.GOOS == "darwin"
+		if launchd {
+			if serviceSystem {
+				die("--system is not supported on macOS")
+			}
+			name = "uk.ac.sanger.wr.manager." + config.Deployment
+			content = servicePlist(name, startArgs, env)
+			path = filepath.Join(internal.TildaToHome("~/Library/LaunchAgents"), name+".plist")
+		} else {
+			content = serviceUnit(startArgs, env)
+			name = "wr-manager-" + config.Deployment + ".service"
+			if serviceSystem {
+				path = filepath.Join("/etc/systemd/system", name)
+			} else {
+				dir := os.Getenv("XDG_CONFIG_HOME")
+				if dir == "" {
+					dir = internal.TildaToHome("~/.config")
+				}
+				path = filepath.Join(dir, "systemd", "user", name)
+			}
+		}
+
+		if serviceOutput == "-" {
+			fmt.Print(string(content))
+			return
+		}
+		if serviceOutput != "" {
+			path = serviceOutput
+		}
+
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			die("could not create the directory for %s: %s", path, err)
+		}
+		err = ioutil.WriteFile(path, content, 0600)
+		if err != nil {
+			die("could not write %s: %s", path, err)
+		}
+		info("wrote %s", path)
+
+		switch {
+		case launchd:
+			info("load it with: launchctl load -w %s", path)
+		case serviceSystem:
+			info("as root, start it with: systemctl daemon-reload && systemctl enable --now %s", name)
+		default:
+			info("start it with: systemctl --user daemon-reload && systemctl --user enable --now %s", name)
+		}
+	},
+}
+
+func init() {
+	managerCmd.AddCommand(managerInstallServiceCmd)
+
+	// flags specific to this sub-command
+	managerInstallServiceCmd.Flags().BoolVar(&serviceSystem, "system", false, "write a system-wide systemd unit instead of a user one")
+	managerInstallServiceCmd.Flags().StringVarP(&serviceOutput, "output", "o", "", "path to write the service file to; - to print it")
+	managerInstallServiceCmd.Flags().IntVar(&serviceWatchdog, "watchdog", 120, "seconds after which systemd restarts a manager that stops responding; 0 to disable")
+	managerInstallServiceCmd.Flags().IntVar(&serviceStartTimeout, "start_timeout", 300, "seconds systemd waits for the manager to be ready to accept commands")
+}
+
+// serviceEnv returns the environment variables from our current environment
+// that the service should have, sorted by name.
+func serviceEnv() []serviceEnvVar {
+	var env []serviceEnvVar
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		keep := parts[0] == "PATH"
+		for _, prefix := range serviceEnvPrefixes {
+			if strings.HasPrefix(parts[0], prefix) {
+				keep = true
+				break
+			}
+		}
+		if keep {
+			env = append(env, serviceEnvVar{Key: parts[0], Value: parts[1]})
+		}
+	}
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Key < env[j].Key
+	})
+	return env
+}
+
+// serviceUnit returns a systemd unit that runs the given command with the
+// given environment.
+func serviceUnit(args []string, env []serviceEnvVar) []byte {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if systemdPlainArg.MatchString(arg) {
+			quoted[i] = arg
+		} else {
+			// unlike in Environment lines, $ is expanded in ExecStart
+			quoted[i] = systemdQuote(strings.Replace(arg, "$", "$$", -1))
+		}
+	}
+
+	envLines := make([]string, len(env))
+	for i, ev := range env {
+		envLines[i] = systemdQuote(ev.Key + "=" + ev.Value)
+	}
+
+	data := struct {
+		Deployment   string
+		ExecStart    string
+		User         string
+		Env          []string
+		StartTimeout int
+		Watchdog     int
+		WantedBy     string
+	}{
+		Deployment:   config.Deployment,
+		ExecStart:    strings.Join(quoted, " "),
+		Env:          envLines,
+		StartTimeout: serviceStartTimeout,
+		Watchdog:     serviceWatchdog,
+		WantedBy:     "default.target",
+	}
+	if serviceSystem {
+		data.User = realUsername()
+		data.WantedBy = "multi-user.target"
+	}
+
+	var b bytes.Buffer
+	err := serviceUnitTemplate.Execute(&b, data)
+	if err != nil {
+		die("could not create the systemd unit: %s", err)
+	}
+	return b.Bytes()
+}
+
+// systemdQuote returns the given string double quoted for use in a systemd
+// unit, escaping characters that would otherwise be interpreted (or, in the
+// case of newlines, end the line).
+func systemdQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `%`, `%%`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+// servicePlist returns a launchd property list with the given label that runs
+// the given command with the given environment.
+func servicePlist(label string, args []string, env []serviceEnvVar) []byte {
+	escapedArgs := make([]string, len(args))
+	for i, arg := range args {
+		escapedArgs[i] = html.EscapeString(arg)
+	}
+	escapedEnv := make([]serviceEnvVar, len(env))
+	for i, ev := range env {
+		escapedEnv[i] = serviceEnvVar{Key: html.EscapeString(ev.Key), Value: html.EscapeString(ev.Value)}
+	}
+
+	var b bytes.Buffer
+	err := servicePlistTemplate.Execute(&b, struct {
+		Label string
+		Args  []string
+		Env   []serviceEnvVar
+	}{html.EscapeString(label), escapedArgs, escapedEnv})
+	if err != nil {
+		die("could not create the launchd property list: %s", err)
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,214 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// systemdWords splits the value of a systemd unit setting in to words the way
+// systemd does: specifiers are expanded first, then words are split on
+// whitespace, with double quoted words unescaped.
+func systemdWords(value string) []string {
+	value = strings.Replace(value, "%%", "%", -1)
+	var words []string
+	var word strings.Builder
+	inWord, quoted := false, false
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case quoted && c == '\\' && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				word.WriteByte('\n')
+			default:
+				word.WriteByte(value[i])
+			}
+		case quoted && c == '"':
+			quoted = false
+		case !quoted && c == '"':
+			quoted, inWord = true, true
+		case !quoted && c == ' ':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+// unitSettings returns the values of the settings with the given key in the
+// given systemd unit.
+func unitSettings(unit []byte, key string) []string {
+	var values []string
+	for _, line := range strings.Split(string(unit), "\n") {
+		if strings.HasPrefix(line, key+"=") {
+			values = append(values, strings.TrimPrefix(line, key+"="))
+		}
+	}
+	return values
+}
+
+// plistDoc is the part of a launchd property list we care about.
+type plistDoc struct {
+	Dict struct {
+		Keys    []string `xml:"key"`
+		Strings []string `xml:"string"`
+		Array   struct {
+			Strings []string `xml:"string"`
+		} `xml:"array"`
+		Dicts []struct {
+			Keys    []string `xml:"key"`
+			Strings []string `xml:"string"`
+		} `xml:"dict"`
+	} `xml:"dict"`
+}
+
+func TestService(t *testing.T) {
+	origDeployment, origSystem := config.Deployment, serviceSystem
+	origWatchdog, origTimeout := serviceWatchdog, serviceStartTimeout
+	defer func() {
+		config.Deployment, serviceSystem = origDeployment, origSystem
+		serviceWatchdog, serviceStartTimeout = origWatchdog, origTimeout
+	}()
+	config.Deployment = "production"
+	serviceSystem = false
+	serviceStartTimeout = 300
+
+	tests := []struct {
+		desc string
+		args []string
+		env  []serviceEnvVar
+	}{
+		{
+			"plain paths and args",
+			[]string{"/usr/local/bin/wr", "manager", "start", "--foreground", "-s", "lsf"},
+			[]serviceEnvVar{{"PATH", "/usr/bin:/bin"}, {"WR_MANAGERPORT", "11301"}},
+		},
+		{
+			"paths and args with spaces",
+			[]string{"/home/my user/bin/wr", "manager", "start", "--cloud_flavor", "m1 large"},
+			[]serviceEnvVar{{"PATH", "/home/my user/bin:/usr/bin"}},
+		},
+		{
+			"args with quotes and backslashes",
+			[]string{"/opt/wr", "manager", "start", `--cloud_script=echo "hi" 'there'`, `C:\dir\`},
+			[]serviceEnvVar{{"WR_QUOTED", `say "hi" it's \fine\`}},
+		},
+		{
+			"args with specifiers, variables, markup and newlines",
+			[]string{"/opt/wr", "manager", "start", "--max_ram", "50%", "--script", "$HOME/a&b<c>\nline2"},
+			[]serviceEnvVar{{"OS_PASSWORD", "100%$ecret<&>"}, {"WR_MULTI", "one\ntwo"}},
+		},
+	}
+
+	Convey("systemd units run the manager with exactly the given args and env", t, func() {
+		for _, test := range tests {
+			Convey(test.desc, func() {
+				unit := serviceUnit(test.args, test.env)
+
+				execStart := unitSettings(unit, "ExecStart")
+				So(len(execStart), ShouldEqual, 1)
+				words := systemdWords(execStart[0])
+				for i := range words {
+					words[i] = strings.Replace(words[i], "$$", "$", -1)
+				}
+				So(words, ShouldResemble, test.args)
+
+				var env []serviceEnvVar
+				for _, setting := range unitSettings(unit, "Environment") {
+					words := systemdWords(setting)
+					So(len(words), ShouldEqual, 1)
+					parts := strings.SplitN(words[0], "=", 2)
+					So(len(parts), ShouldEqual, 2)
+					env = append(env, serviceEnvVar{parts[0], parts[1]})
+				}
+				So(env, ShouldResemble, test.env)
+
+				So(string(unit), ShouldContainSubstring, "\nType=notify\n")
+				So(string(unit), ShouldContainSubstring, "\nKillMode=process\n")
+			})
+		}
+	})
+
+	Convey("systemd units are notify services with an optional watchdog", t, func() {
+		args := []string{"/usr/local/bin/wr", "manager", "start"}
+
+		serviceWatchdog = 120
+		unit := serviceUnit(args, nil)
+		So(unitSettings(unit, "Type"), ShouldResemble, []string{"notify"})
+		So(unitSettings(unit, "NotifyAccess"), ShouldResemble, []string{"main"})
+		So(unitSettings(unit, "WatchdogSec"), ShouldResemble, []string{"120"})
+		So(unitSettings(unit, "TimeoutStartSec"), ShouldResemble, []string{"300"})
+		So(unitSettings(unit, "User"), ShouldBeEmpty)
+		So(unitSettings(unit, "WantedBy"), ShouldResemble, []string{"default.target"})
+		So(unitSettings(unit, "Description"), ShouldResemble, []string{"wr manager (production)"})
+
+		serviceWatchdog = 0
+		unit = serviceUnit(args, nil)
+		So(unitSettings(unit, "WatchdogSec"), ShouldBeEmpty)
+		So(bytes.Contains(unit, []byte("\n\n[Install]")), ShouldBeTrue)
+
+		serviceSystem = true
+		unit = serviceUnit(args, nil)
+		So(unitSettings(unit, "User"), ShouldResemble, []string{realUsername()})
+		So(unitSettings(unit, "WantedBy"), ShouldResemble, []string{"multi-user.target"})
+		serviceSystem = false
+	})
+
+	Convey("launchd property lists are valid XML that run the manager with exactly the given args and env", t, func() {
+		for _, test := range tests {
+			Convey(test.desc, func() {
+				label := "uk.ac.sanger.wr.manager.production"
+				plist := servicePlist(label, test.args, test.env)
+
+				doc := &plistDoc{}
+				err := xml.Unmarshal(plist, doc)
+				So(err, ShouldBeNil)
+				So(doc.Dict.Keys, ShouldResemble, []string{"Label", "ProgramArguments", "EnvironmentVariables", "RunAtLoad", "KeepAlive"})
+				So(doc.Dict.Strings, ShouldResemble, []string{label})
+				So(doc.Dict.Array.Strings, ShouldResemble, test.args)
+
+				So(len(doc.Dict.Dicts), ShouldEqual, 2)
+				envDict := doc.Dict.Dicts[0]
+				So(len(envDict.Keys), ShouldEqual, len(test.env))
+				var env []serviceEnvVar
+				for i, key := range envDict.Keys {
+					env = append(env, serviceEnvVar{key, envDict.Strings[i]})
+				}
+				So(env, ShouldResemble, test.env)
+				So(doc.Dict.Dicts[1].Keys, ShouldResemble, []string{"SuccessfulExit"})
+			})
+		}
+	})
+}
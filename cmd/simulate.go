@@ -0,0 +1,261 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// options for this cmd
+var simulateCores float64
+var simulateExtraCores float64
+var simulatePriority string
+var simulateHistory string
+var simulateOutput string
+
+// simulateCmd represents the simulate command
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Project when queued commands will complete",
+	Long: `Project when queued commands will complete, under what-if scenarios.
+
+This takes the commands currently in the queue and simulates them being
+scheduled, to project when each report group's commands would all be complete.
+You can compare this to a what-if scenario with more cores and/or different
+priorities, eg. to help you negotiate a bigger resource allocation.
+
+How long each command is expected to take is the mean wall time of the commands
+with the same requirements group (the --req_grp of "wr add") that completed
+within --history ago (30 days by default), or if there were none, the command's
+expected time. Commands that are already running are assumed to take their
+expected time minus how long they have been running for so far. Dependencies
+between commands are respected.
+
+The simulation only considers cores: commands are started, in order of
+priority, as soon as their dependencies are complete and there are enough free
+cores for them. The baseline capacity is --cores, which defaults to the total
+cores being used by the currently running commands (so you should specify it if
+you're not currently using your whole allocation).
+
+The what-if scenario has --extra_cores more cores than the baseline, and
+--priority changes the priority of some commands. --priority takes a comma
+separated list of rules that multiply the priority+1 of the commands they
+select by a factor:
+cores>=8:2        commands needing at least 8 cores get double priority
+ram>=16000:2      commands needing at least 16000 MB of memory
+time>=2h:2        commands expected to take at least 2 hours
+rep_grp=name:3    commands in the report group "name" get triple priority
+rep_grp~sub:0.5   commands in report groups containing "sub" get half priority
+eg. to ask "what if I had 500 more cores and big jobs had 2x priority":
+wr simulate --extra_cores 500 --priority cores>=8:2
+
+The default -o table output shows, for each report group, its number of
+commands and how long from now until they would all be complete, along with
+when that would be, under the baseline and what-if scenarios. Commands that
+would never complete (because they're buried, need more cores than there are,
+or depend on commands that won't complete) are counted as stuck. -o json outputs
+the results of both scenarios as a JSON object.
+
+These projections are only as good as the history they're based on, and assume
+nothing else competes for your capacity and no more commands are added.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		history, err := time.ParseDuration(simulateHistory)
+		if err != nil {
+			die("--history was not specified correctly: %s", err)
+		}
+
+		var rules []*jobqueue.SimulationPriorityRule
+		for _, spec := range strings.Split(simulatePriority, ",") {
+			spec = strings.TrimSpace(spec)
+			if spec == "" {
+				continue
+			}
+			rule, errp := jobqueue.ParseSimulationPriorityRule(spec)
+			if errp != nil {
+				die("--priority was not specified correctly: %s", errp)
+			}
+			rules = append(rules, rule)
+		}
+		if simulateCores < 0 || simulateExtraCores < 0 {
+			die("--cores and --extra_cores can't be negative")
+		}
+
+		timeout := time.Duration(timeoutint) * time.Second
+		jq := connect(timeout)
+		defer func() {
+			errd := jq.Disconnect()
+			if errd != nil {
+				warn("Disconnecting from the server failed: %s", errd)
+			}
+		}()
+
+		now := time.Now()
+		incomplete, err := jq.GetIncomplete(0, "", false, false)
+		if err != nil {
+			die("failed to get the commands in the queue: %s", err)
+		}
+		if len(incomplete) == 0 {
+			info("There are no commands in the queue")
+			return
+		}
+		complete, err := jq.GetComplete("", false, now.Add(-history), time.Time{}, 0, false, false)
+		if err != nil {
+			die("failed to get the commands that completed: %s", err)
+		}
+
+		sim := jobqueue.NewSimulation(incomplete, complete, now)
+		cores := simulateCores
+		if cores == 0 {
+			cores = sim.RunningCores()
+			if cores == 0 {
+				die("no commands are running, so the cores available can't be guessed; please specify --cores")
+			}
+		}
+
+		baseline := sim.Run(&jobqueue.SimulationScenario{Name: "baseline", Cores: cores})
+		var whatIf *jobqueue.SimulationResult
+		if simulateExtraCores > 0 || len(rules) > 0 {
+			whatIf = sim.Run(&jobqueue.SimulationScenario{
+				Name:          "what-if",
+				Cores:         cores + simulateExtraCores,
+				PriorityRules: rules,
+			})
+		}
+
+		switch simulateOutput {
+		case "json", "j":
+			printSimulationJSON(now, baseline, whatIf)
+		case "table", "t":
+			printSimulationTable(now, baseline, whatIf)
+		default:
+			die("invalid -o format specified")
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(simulateCmd)
+
+	// flags specific to this sub-command
+	simulateCmd.Flags().Float64Var(&simulateCores, "cores", 0, "cores available in the baseline scenario (defaults to those used by running commands)")
+	simulateCmd.Flags().Float64Var(&simulateExtraCores, "extra_cores", 0, "additional cores available in the what-if scenario")
+	simulateCmd.Flags().StringVar(&simulatePriority, "priority", "", "comma separated priority rules for the what-if scenario, eg. cores>=8:2")
+	simulateCmd.Flags().StringVar(&simulateHistory, "history", "720h", "base expected wall times on commands that completed within this long ago")
+	simulateCmd.Flags().StringVarP(&simulateOutput, "output", "o", "table", "['table','json'] output format")
+
+	simulateCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// printSimulationTable prints a table of the projected completion of each
+// RepGroup under the baseline and (if not nil) what-if scenarios.
+func printSimulationTable(now time.Time, baseline, whatIf *jobqueue.SimulationResult) {
+	describe := func(r *jobqueue.SimulationResult) string {
+		desc := fmt.Sprintf("%s: %g cores", r.Scenario.Name, r.Scenario.Cores)
+		if len(r.Scenario.PriorityRules) > 0 {
+			specs := make([]string, len(r.Scenario.PriorityRules))
+			for i, rule := range r.Scenario.PriorityRules {
+				specs[i] = rule.String()
+			}
+			desc += ", priority " + strings.Join(specs, ",")
+		}
+		return desc
+	}
+	fmt.Println(describe(baseline))
+	if whatIf != nil {
+		fmt.Println(describe(whatIf))
+	}
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := "report group\tcommands\tstuck\tbaseline\t"
+	if whatIf != nil {
+		header += "what-if\tchange\t"
+	}
+	fmt.Fprintln(w, header)
+
+	row := func(name string, jobs, stuck int, completion time.Duration, other *time.Duration) {
+		line := fmt.Sprintf("%s\t%d\t%d\t%s\t", name, jobs, stuck, simulationCompletion(now, completion))
+		if other != nil {
+			line += fmt.Sprintf("%s\t%s\t", simulationCompletion(now, *other), simulationChange(completion, *other))
+		}
+		fmt.Fprintln(w, line)
+	}
+	for _, srg := range baseline.RepGroups {
+		var other *time.Duration
+		if whatIf != nil {
+			if wrg := whatIf.RepGroup(srg.RepGroup); wrg != nil {
+				other = &wrg.Completion
+			}
+		}
+		row(srg.RepGroup, srg.Jobs, srg.Stuck, srg.Completion, other)
+	}
+	var other *time.Duration
+	if whatIf != nil {
+		other = &whatIf.Completion
+	}
+	row(statsTotal, baseline.Jobs, baseline.Stuck, baseline.Completion, other)
+
+	if err := w.Flush(); err != nil {
+		die("failed to write the simulation results: %s", err)
+	}
+}
+
+// simulationCompletion describes a projected completion that is the given
+// duration from now.
+func simulationCompletion(now time.Time, d time.Duration) string {
+	return fmt.Sprintf("%s (%s)", d.Round(time.Minute), now.Add(d).Format(shortTimeFormat))
+}
+
+// simulationChange describes the difference between a baseline and what-if
+// completion.
+func simulationChange(baseline, whatIf time.Duration) string {
+	diff := (whatIf - baseline).Round(time.Minute)
+	switch {
+	case diff < 0:
+		return fmt.Sprintf("%s sooner", -diff)
+	case diff > 0:
+		return fmt.Sprintf("%s later", diff)
+	}
+	return "none"
+}
+
+// printSimulationJSON prints the results of the baseline and (if not nil)
+// what-if scenarios as a JSON object.
+func printSimulationJSON(now time.Time, baseline, whatIf *jobqueue.SimulationResult) {
+	report := struct {
+		Start    time.Time                  `json:"start"`
+		Baseline *jobqueue.SimulationResult `json:"baseline"`
+		WhatIf   *jobqueue.SimulationResult `json:"what_if,omitempty"`
+	}{now, baseline, whatIf}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		die("failed to encode the simulation results: %s", err)
+	}
+}
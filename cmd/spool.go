@@ -0,0 +1,111 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// spoolCmd represents the spool command
+var spoolCmd = &cobra.Command{
+	Use:   "spool",
+	Short: "Manage commands spooled while the manager was down",
+	Long: `Manage commands spooled while the manager was down.
+
+When 'wr add --spool' can't reach the manager, it stores the commands in a
+local spool file (see the managerspoolfile config option) instead of failing.
+They are added automatically the next time 'wr add' connects to the manager, but
+you can use the sub-commands to add them yourself.`,
+}
+
+// flush sub-command adds spooled commands
+var spoolFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Add spooled commands to the queue",
+	Long: `Add spooled commands to the queue.
+
+All the commands previously spooled by 'wr add --spool' are added to the queue,
+in the order they were spooled, and removed from the spool. If some can't be
+added, they and any spooled after them are left in the spool.
+
+Any spooled commands that were corrupted (eg. by a crash while they were being
+spooled) are moved to a file with the same path as the spool file plus a
+'.corrupt' suffix, for you to inspect, and the others are still added.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		jq := connect(time.Duration(timeoutint) * time.Second)
+		defer func() {
+			err := jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		added, existed, err := flushSpoolFile(jq)
+		if err != nil {
+			die("adding spooled commands failed: %s", err)
+		}
+		info("Added %d spooled commands (%d were duplicates) to the queue", added, existed)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(spoolCmd)
+	spoolCmd.AddCommand(spoolFlushCmd)
+
+	spoolCmd.PersistentFlags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// flushSpool adds any commands previously spooled by 'wr add --spool', only
+// warning if that fails.
+func flushSpool(jq *jobqueue.Client) {
+	added, existed, err := flushSpoolFile(jq)
+	if err != nil {
+		warn("Adding previously spooled commands failed: %s", err)
+		return
+	}
+	if added+existed > 0 {
+		info("Added %d previously spooled commands (%d were duplicates) to the queue", added, existed)
+	}
+}
+
+// flushSpoolFile calls FlushSpool() on our spool file, warning if any corrupt
+// spooled commands had to be quarantined.
+func flushSpoolFile(jq *jobqueue.Client) (int, int, error) {
+	quarantine := config.ManagerSpoolFile + jobqueue.SpoolQuarantineSuffix
+	before := spoolFileSize(quarantine)
+	added, existed, err := jq.FlushSpool(config.ManagerSpoolFile)
+	if spoolFileSize(quarantine) > before {
+		warn("Some spooled commands were corrupt and could not be added; they were moved to %s", quarantine)
+	}
+	return added, existed, err
+}
+
+// spoolFileSize returns the size of the file at the given path, or 0 if it
+// doesn't exist.
+func spoolFileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
@@ -0,0 +1,176 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// options for this cmd
+var startRateStarts int
+var startRateInterval string
+var startRateRamp float64
+var startRateOutput string
+
+// startRateCmd represents the startrate command
+var startRateCmd = &cobra.Command{
+	Use:   "startrate",
+	Short: "Manage report group start rates",
+	Long: `Manage report group start rates.
+
+If the commands in a report group (the -i of "wr add") all use a shared service,
+such as a database or file server, starting thousands of them at once can
+overwhelm it. You can give the report group a start rate to limit how many of
+its commands may start running in each interval, eg.
+
+wr startrate set myproject --starts 50 --interval 1m --ramp 2
+
+would let 50 commands start in the first minute, 100 in the second, 200 in the
+third, and so on, giving the service time to warm up. Without --ramp (or with
+--ramp 1), the rate stays at --starts per --interval. The ramp starts again from
+--starts if none of the report group's commands try to start for a whole
+interval.
+
+Commands that would start once the interval's starts have been used up are
+delayed until the next interval, showing as "delayed" in "wr status".
+
+Start rates can be changed at any time, taking effect for commands that start
+from then on. Use the sub-commands to set, list and delete start rates.`,
+}
+
+// set sub-command sets a start rate
+var startRateSetCmd = &cobra.Command{
+	Use:   "set REPORT_GROUP",
+	Short: "Set the start rate of a report group",
+	Long: `Set the start rate of a report group.
+
+Supply --starts, the number of commands that may start in each --interval (a
+duration like 30s or 5m, at least 1s), optionally ramping up by a factor of
+--ramp each interval. If the report group already has a start rate, it is
+replaced and the ramp starts again.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		interval, err := time.ParseDuration(startRateInterval)
+		if err != nil {
+			die("--interval was not specified correctly: %s", err)
+		}
+		rate := &jobqueue.StartRate{
+			RepGroup: args[0],
+			Starts:   startRateStarts,
+			Interval: interval,
+			Ramp:     startRateRamp,
+		}
+		err = budgetClient(func(jq *jobqueue.Client) error {
+			var errs error
+			rate, errs = jq.SetStartRate(rate)
+			return errs
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		info("start rate set; %s", rate)
+	},
+}
+
+// list sub-command shows start rates
+var startRateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List report group start rates",
+	Long: `List report group start rates.
+
+The default -o plain output has tab separated columns of the report group, the
+starts allowed per interval, the interval and the ramp factor. -o json outputs
+the start rates as an array of JSON objects.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var rates []*jobqueue.StartRate
+		err := budgetClient(func(jq *jobqueue.Client) error {
+			var errg error
+			rates, errg = jq.GetStartRates()
+			return errg
+		})
+		if err != nil {
+			die("%s", err)
+		}
+
+		switch startRateOutput {
+		case "json", "j":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetEscapeHTML(false)
+			if rates == nil {
+				rates = []*jobqueue.StartRate{}
+			}
+			err = encoder.Encode(rates)
+			if err != nil {
+				die("failed to encode start rates: %s", err)
+			}
+		case "plain", "p":
+			for _, r := range rates {
+				ramp := r.Ramp
+				if ramp < 1 {
+					ramp = 1
+				}
+				fmt.Printf("%s\t%d\t%s\t%g\n", r.RepGroup, r.Starts, r.Interval, ramp)
+			}
+		default:
+			die("invalid -o format specified")
+		}
+	},
+}
+
+// delete sub-command removes a start rate
+var startRateDeleteCmd = &cobra.Command{
+	Use:   "delete REPORT_GROUP",
+	Short: "Delete the start rate of a report group",
+	Long: `Delete the start rate of a report group, so that its commands can start
+as quickly as they otherwise would.
+
+Commands that were already delayed because of the start rate start once their
+delay (at most one interval) is up.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := budgetClient(func(jq *jobqueue.Client) error {
+			return jq.DeleteStartRate(args[0])
+		})
+		if err != nil {
+			die("%s", err)
+		}
+		info("start rate of %s deleted", args[0])
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(startRateCmd)
+	startRateCmd.AddCommand(startRateSetCmd)
+	startRateCmd.AddCommand(startRateListCmd)
+	startRateCmd.AddCommand(startRateDeleteCmd)
+
+	// flags specific to these sub-commands
+	startRateSetCmd.Flags().IntVar(&startRateStarts, "starts", 0, "number of commands that may start in each interval")
+	startRateSetCmd.Flags().StringVar(&startRateInterval, "interval", "1m", "duration of each interval")
+	startRateSetCmd.Flags().Float64Var(&startRateRamp, "ramp", 1, "factor to multiply the allowed starts by each interval")
+	startRateListCmd.Flags().StringVarP(&startRateOutput, "output", "o", "plain", "['plain','json'] output format")
+
+	startRateCmd.PersistentFlags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
@@ -0,0 +1,320 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// statsTotal is the name of the row/source that sums all the others.
+const statsTotal = "total"
+
+// options for this cmd
+var statsDeployments string
+var statsManagers string
+var statsTokenFile string
+var statsSince string
+var statsTop int
+var statsOutput string
+var statsTimeout int
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarise work done across deployments",
+	Long: `Summarise work done across deployments.
+
+Gives you totals of the work done by all the managers running on this host
+(both the production and development deployments), suitable for periodic
+reporting:
+
+ - the number of commands that ran (completed or were buried)
+ - CPU hours and wall time hours used by the commands that completed
+ - the failure rate, and the number of buried commands by failure reason
+ - the report groups that used the most wall time
+
+By default, stats cover commands that ran today (since midnight). Use --since to
+look back further, eg. --since 168h for the past week.
+
+Use --deployments to only consider some of the deployments on this host, or
+--managers to instead get stats from a comma separated list of manager
+host:port addresses (which will be authenticated using --token_file, which
+defaults to the current deployment's token file, so the managers will need to
+share a token).
+
+Managers that aren't running are skipped with a warning.
+
+Stats on buried commands come from the managers' event logs (see 'wr events'),
+so only cover the last 30 days.
+
+The default -o table output shows a row per deployment (or manager) and their
+total, followed by the total failure reasons and top report groups. -o json
+outputs all of this as a JSON object.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		since := statsSinceTime()
+		timeout := time.Duration(statsTimeout) * time.Second
+
+		var names []string
+		stats := make(map[string]*jobqueue.JobStats)
+		total := jobqueue.NewJobStats(nil, nil)
+		for _, src := range statsSources() {
+			js, err := src.stats(since, timeout)
+			if err != nil {
+				warn("skipping %s: %s", src.name, err)
+				continue
+			}
+			names = append(names, src.name)
+			stats[src.name] = js
+			total.Merge(js)
+		}
+		if len(names) == 0 {
+			die("could not get stats from any manager")
+		}
+
+		switch statsOutput {
+		case "json", "j":
+			printStatsJSON(since, names, stats, total)
+		case "table", "t":
+			printStatsTable(since, names, stats, total)
+		default:
+			die("invalid -o format specified")
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(statsCmd)
+
+	// flags specific to this sub-command
+	statsCmd.Flags().StringVar(&statsDeployments, "deployments", internal.Production+","+internal.Development, "comma separated deployments on this host to get stats from")
+	statsCmd.Flags().StringVar(&statsManagers, "managers", "", "comma separated host:port addresses of managers to get stats from, instead of --deployments")
+	statsCmd.Flags().StringVar(&statsTokenFile, "token_file", "", "path to the token file to authenticate with --managers (defaults to this deployment's)")
+	statsCmd.Flags().StringVar(&statsSince, "since", "", "get stats on commands that ran within this long ago (defaults to since midnight)")
+	statsCmd.Flags().IntVar(&statsTop, "top", 10, "number of report groups to show; 0 for all")
+	statsCmd.Flags().StringVarP(&statsOutput, "output", "o", "table", "['table','json'] output format")
+	statsCmd.Flags().IntVar(&statsTimeout, "timeout", 10, "how long (seconds) to wait to get a reply from each manager")
+}
+
+// statsSource describes a manager to get stats from.
+type statsSource struct {
+	name       string
+	addr       string
+	caFile     string
+	certDomain string
+	tokenFile  string
+}
+
+// statsSources returns the managers the user wants stats from.
+func statsSources() []*statsSource {
+	var sources []*statsSource
+	if statsManagers != "" {
+		tokenFile := statsTokenFile
+		if tokenFile == "" {
+			tokenFile = config.ManagerTokenFile
+		}
+		for _, addr := range strings.Split(statsManagers, ",") {
+			addr = strings.TrimSpace(addr)
+			if addr == "" {
+				continue
+			}
+			sources = append(sources, &statsSource{
+				name:       addr,
+				addr:       addr,
+				caFile:     config.ManagerCAFile,
+				certDomain: config.ManagerCertDomain,
+				tokenFile:  tokenFile,
+			})
+		}
+		return sources
+	}
+
+	for _, dep := range strings.Split(statsDeployments, ",") {
+		dep = strings.TrimSpace(dep)
+		if dep != internal.Production && dep != internal.Development {
+			die("--deployments can only contain %s and %s", internal.Production, internal.Development)
+		}
+		c := internal.ConfigLoad(dep, false, appLogger)
+		sources = append(sources, &statsSource{
+			name:       dep,
+			addr:       net.JoinHostPort(c.ManagerHost, c.ManagerPort),
+			caFile:     c.ManagerCAFile,
+			certDomain: c.ManagerCertDomain,
+			tokenFile:  c.ManagerTokenFile,
+		})
+	}
+	return sources
+}
+
+// stats connects to the source's manager and summarises the commands that ran
+// there since the given time.
+func (src *statsSource) stats(since time.Time, timeout time.Duration) (*jobqueue.JobStats, error) {
+	token, err := ioutil.ReadFile(src.tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read token file; is the manager running? [%w]", err)
+	}
+
+	jq, err := jobqueue.Connect(src.addr, src.caFile, src.certDomain, token, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		errd := jq.Disconnect()
+		if errd != nil {
+			warn("Disconnecting from %s failed: %s", src.name, errd)
+		}
+	}()
+
+	complete, err := jq.GetComplete("", false, since, time.Time{}, 0, false, false)
+	if err != nil {
+		return nil, err
+	}
+	buries, err := jq.GetEvents(since, []jobqueue.EventType{jobqueue.EventTypeBury}, 0)
+	if err != nil {
+		return nil, err
+	}
+	return jobqueue.NewJobStats(complete, buries), nil
+}
+
+// statsSinceTime returns the time the user wants stats from: --since ago, or
+// the most recent midnight.
+func statsSinceTime() time.Time {
+	if statsSince != "" {
+		d, err := time.ParseDuration(statsSince)
+		if err != nil {
+			die("--since was not specified correctly: %s", err)
+		}
+		return time.Now().Add(-d)
+	}
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// printStatsTable prints a table of the stats for each source and their total,
+// followed by the total failure reasons and top RepGroups.
+func printStatsTable(since time.Time, names []string, stats map[string]*jobqueue.JobStats, total *jobqueue.JobStats) {
+	fmt.Printf("Commands that ran since %s\n\n", since.Format(shortTimeFormat))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "source\tran\tcomplete\tburied\tfailure rate\tcpu hours\twall hours\t")
+	row := func(name string, js *jobqueue.JobStats) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.1f%%\t%.1f\t%.1f\t\n", name, js.Run(), js.Completed, js.Buried, js.FailureRate()*100, js.CPUHours, js.WallHours)
+	}
+	for _, name := range names {
+		row(name, stats[name])
+	}
+	if len(names) > 1 {
+		row(statsTotal, total)
+	}
+	flushStatsTable(w)
+
+	if len(total.FailureReasons) > 0 {
+		reasons := make([]string, 0, len(total.FailureReasons))
+		for reason := range total.FailureReasons {
+			reasons = append(reasons, reason)
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			ci, cj := total.FailureReasons[reasons[i]], total.FailureReasons[reasons[j]]
+			if ci == cj {
+				return reasons[i] < reasons[j]
+			}
+			return ci > cj
+		})
+
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "failure reason\tburied\t")
+		for _, reason := range reasons {
+			fmt.Fprintf(w, "%s\t%d\t\n", reason, total.FailureReasons[reason])
+		}
+		flushStatsTable(w)
+	}
+
+	top := total.TopRepGroups(statsTop)
+	if len(top) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "report group\twall hours\t")
+		for _, rgwt := range top {
+			fmt.Fprintf(w, "%s\t%.1f\t\n", rgwt.RepGroup, rgwt.WallHours)
+		}
+		flushStatsTable(w)
+	}
+}
+
+// flushStatsTable flushes the given tabwriter, dying on failure.
+func flushStatsTable(w *tabwriter.Writer) {
+	if err := w.Flush(); err != nil {
+		die("failed to write stats: %s", err)
+	}
+}
+
+// statsSummary is how we output the stats of a source as JSON.
+type statsSummary struct {
+	Source string `json:"source"`
+	*jobqueue.JobStats
+	Run          int                          `json:"ran"`
+	FailureRate  float64                      `json:"failure_rate"`
+	TopRepGroups []*jobqueue.RepGroupWallTime `json:"top_rep_grps"`
+}
+
+// newStatsSummary creates a statsSummary for the given source.
+func newStatsSummary(name string, js *jobqueue.JobStats) *statsSummary {
+	return &statsSummary{
+		Source:       name,
+		JobStats:     js,
+		Run:          js.Run(),
+		FailureRate:  js.FailureRate(),
+		TopRepGroups: js.TopRepGroups(statsTop),
+	}
+}
+
+// printStatsJSON prints the stats for each source and their total as a JSON
+// object.
+func printStatsJSON(since time.Time, names []string, stats map[string]*jobqueue.JobStats, total *jobqueue.JobStats) {
+	report := struct {
+		Since   time.Time       `json:"since"`
+		Sources []*statsSummary `json:"sources"`
+		Total   *statsSummary   `json:"total"`
+	}{
+		Since: since,
+		Total: newStatsSummary(statsTotal, total),
+	}
+	for _, name := range names {
+		report.Sources = append(report.Sources, newStatsSummary(name, stats[name]))
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		die("failed to encode stats: %s", err)
+	}
+}
@@ -46,6 +46,16 @@ var showStd bool
 var showEnv bool
 var outputFormat string
 var statusLimit int
+var showSchedGroups bool
+var showTransfers bool
+var showResources bool
+var showComplete bool
+var completeSince string
+var completeUntil string
+var statusAt string
+var showKey bool
+var statusWorkQueue string
+var statusState string
 
 // statusCmd represents the status command
 var statusCmd = &cobra.Command{
@@ -56,7 +66,8 @@ var statusCmd = &cobra.Command{
 
 Specify one of the flags -f, -l  or -i to choose which commands you want the
 status of. If none are supplied, you will get the status of all your currently
-incomplete commands.
+incomplete commands, or with --work_queue, just those that were added to that
+wr queue (see "wr queue").
 
 -i is the report group (-i) you supplied to "wr add" when you added the job(s)
 you want the status of now. Combining with -z lets you get the status of jobs
@@ -67,10 +78,18 @@ reported when using this command.
 The file to provide -f is in the format taken by "wr add".
 
 In -f and -l mode you must provide the cwd the commands were set to run in, if
-CwdMatters (and must NOT be provided otherwise). Likewise provide the mounts
-options that was used when the command was added, if any. You can do this by
-using the -c and --mounts/--mounts_json options in -l mode, or by providing the
-same file you gave to "wr add" in -f mode.
+CwdMatters (and must NOT be provided otherwise in -f mode; in -l mode a command
+added without CwdMatters will still be found if you provide -c). Likewise
+provide the mounts options that was used when the command was added, if any.
+You can do this by using the -c and --mounts/--mounts_json options in -l mode,
+or by providing the same file you gave to "wr add" in -f mode.
+
+The internal job id of a command is derived from its command line, its cwd if
+CwdMatters, and its mounts, so can be worked out without asking the manager.
+Combining --key with -l (and -c if CwdMatters, and any mounts options) just
+prints the id the command would have, letting external systems find out the
+id of a command they added earlier without having stored it. (The same
+derivation is available to Go code as jobqueue.JobKey().)
 
 There are 4 output formats to choose from with -o (you can shorten the output
 name to just the first letter, eg. -o c):
@@ -86,20 +105,90 @@ name to just the first letter, eg. -o c):
     a timeout if retrieving the details of very many (tens of thousands+)
 	commands.
   "plain" outputs 2 tab separated columns: internal job id and current state of
-	that job. Possible states are: delayed, ready, reserved, running, lost,
-	buried, complete. If any jobs are buried, exits non-0 as well.
+	that job. Possible states are: delayed, ready, reserved, running, staging,
+	uploading, lost, buried, complete. If any jobs are buried, exits non-0 as
+	well.
   "json" simply dumps the complete details of every job out as an array of
     JSON objects. The properties of the JSON objects are described in the
-    documentation for wr's REST API.`,
+    documentation for wr's REST API.
+
+Commands that use mounts, iRODS inputs or outputs, reference assets or
+copy_to_manager behaviours can spend a long time moving data. While a runner is
+mounting and fetching inputs before starting a command, the command is in the
+"staging" state, and while it is uploading outputs after the command exits, it
+is in the "uploading" state; these are counted separately from "running". Use
+--state (eg. --state staging) to only see commands in a particular state;
+--state running also includes staging and uploading commands.
+
+Instead of showing the status of commands, --scheduler_groups shows how the
+manager has grouped your incomplete commands by their resource requirements in
+order to ask its scheduler to run runners for them. For each group you'll see
+the requirement groups (--req_grp of "wr add") of its commands, how many runners
+have been requested and have connected, and how long the group has been waiting
+for a runner. This can help you work out why commands are staying ready instead
+of running. (-o json is the only other output format supported in this mode.)
+
+Similarly, --transfers shows the commands that are currently copying files to
+the manager due to their copy_to_manager behaviours (see "wr add -h"), and
+those queued waiting for their turn to do so, along with the host they're
+copying from and how much has been copied. (-o json is also supported in this
+mode.)
+
+Also instead of showing the status of commands, --resources combined with -i
+compares the memory, time and cpus that the completed commands in the report
+group(s) requested against what they actually used, showing the minimum,
+median, 95th percentile and maximum of each. Resources where the median request
+was at least twice the 95th percentile of actual usage are flagged as
+over-requested; reducing these requirements in future would let more of your
+commands run at once and waste less of your cluster's reservations. (-o json is
+the only other output format supported in this mode.)
+
+--complete shows only commands that have completed, most recently completed
+first, looking at the history of all your complete commands, or just those in
+the report group(s) given by -i (optionally with -z). Combine with --since to
+only consider commands that completed within that long ago (eg. --since 24h),
+and --until to exclude those that completed more recently than that long ago.
+In this mode --limit is the maximum number of the most recently completed
+commands to show, defaulting to all of them in the time range. This is the
+efficient way to look at recent history in a long-lived deployment where very
+many commands have completed.
+
+--at reconstructs, from the manager's event log, how many incomplete commands
+were in each state in each report group at a past moment, which is useful for
+working out what happened during an incident after the fact. Give it a local
+date and time (eg. --at '2017-05-01 14:00'), an RFC3339 timestamp, or a
+duration to look that long ago (eg. --at 12h). Combine with -i (optionally with
+-z) to only see certain report groups. "-o counts" shows the totals across
+report groups, "-o json" outputs the counts keyed on report group and state,
+and the other output formats show the counts of each report group. Only the
+period covered by the event log (the last 30 days) can be looked at, and
+reserved commands are counted as running.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		set := countGetJobArgs()
 		if set > 1 {
 			die("-f, -i and -l are mutually exclusive; only specify one of them")
 		}
+		if statusWorkQueue != "" && (set > 0 || showComplete || showResources || statusAt != "" || showSchedGroups || showTransfers) {
+			die("--work_queue can only be combined with the default mode of showing all incomplete commands")
+		}
+		if showKey {
+			if cmdLine == "" {
+				die("--key requires -l")
+			}
+			fmt.Println(jobqueue.JobKey(cmdLine, cmdCwd, statusMounts()))
+			return
+		}
+
 		var cmdState jobqueue.JobState
 		if showBuried {
 			cmdState = jobqueue.JobStateBuried
 		}
+		if statusState != "" {
+			if showBuried {
+				die("-b and --state are mutually exclusive")
+			}
+			cmdState = parseStatusState(statusState)
+		}
 		timeout := time.Duration(timeoutint) * time.Second
 
 		jq := connect(timeout)
@@ -111,17 +200,59 @@ name to just the first letter, eg. -o c):
 			}
 		}()
 
+		if showSchedGroups {
+			showSchedulerGroups(jq)
+			return
+		}
+
+		if showTransfers {
+			showTransferQueue(jq)
+			return
+		}
+
+		if statusAt != "" {
+			if cmdFileStatus != "" || cmdLine != "" || cmdIDIsInternal || showBuried || statusState != "" || showComplete || showResources {
+				die("--at can only be combined with -i as a report group")
+			}
+			showStateCountsAt(jq, parseStatusAt(statusAt))
+			return
+		}
+
+		if (completeSince != "" || completeUntil != "") && !showComplete {
+			die("--since and --until require --complete")
+		}
+		if showComplete && (cmdFileStatus != "" || cmdLine != "" || cmdIDIsInternal || showBuried || statusState != "") {
+			die("--complete can only be combined with -i as a report group")
+		}
+		completeLimit := 0
+		if cmd.Flags().Changed("limit") {
+			completeLimit = statusLimit
+		}
+
+		if showResources {
+			if cmdIDStatus == "" || cmdIDIsInternal {
+				die("--resources requires -i to be a report group")
+			}
+			showResourceUsage(getJobs(jq, jobqueue.JobStateComplete, false, 0, false, false))
+			return
+		}
+
 		if outputFormat != "details" && outputFormat != "d" {
 			statusLimit = 0
 			showStd = false
 			showEnv = false
 		}
-		jobs := getJobs(jq, cmdState, set == 0, statusLimit, showStd, showEnv)
+		var jobs []*jobqueue.Job
+		if showComplete {
+			jobs = getCompleteJobs(jq, completeLimit, showStd, showEnv)
+		} else {
+			jobs = getJobs(jq, cmdState, set == 0, statusLimit, showStd, showEnv)
+		}
 		showextra := cmdFileStatus == ""
 
 		switch outputFormat {
 		case "counts", "c":
-			var d, re, b, ru, l, c, dep int
+			var d, re, b, ru, st, up, l, c, dep int
 			for _, job := range jobs {
 				switch job.State {
 				case jobqueue.JobStateDelayed:
@@ -132,6 +263,10 @@ name to just the first letter, eg. -o c):
 					b += 1 + job.Similar
 				case jobqueue.JobStateReserved, jobqueue.JobStateRunning:
 					ru += 1 + job.Similar
+				case jobqueue.JobStateStaging:
+					st += 1 + job.Similar
+				case jobqueue.JobStateUploading:
+					up += 1 + job.Similar
 				case jobqueue.JobStateLost:
 					l += 1 + job.Similar
 				case jobqueue.JobStateComplete:
@@ -140,7 +275,7 @@ name to just the first letter, eg. -o c):
 					dep += 1 + job.Similar
 				}
 			}
-			fmt.Printf("complete: %d\nrunning: %d\nready: %d\ndependent: %d\nlost contact: %d\ndelayed: %d\nburied: %d\n", c, ru, re, dep, l, d, b)
+			fmt.Printf("complete: %d\nrunning: %d\nstaging: %d\nuploading: %d\nready: %d\ndependent: %d\nlost contact: %d\ndelayed: %d\nburied: %d\n", c, ru, st, up, re, dep, l, d, b)
 		case "plain", "p":
 			buried := false
 			for _, job := range jobs {
@@ -257,10 +392,11 @@ name to just the first letter, eg. -o c):
 					}
 				}
 
-				fmt.Printf("%s : complete=%d running=%d ready=%d dependent=%d lost=%d delayed=%d buried=%d%s%s\n", rg, counts[rg][jobqueue.JobStateComplete], counts[rg][jobqueue.JobStateRunning], counts[rg][jobqueue.JobStateReady], counts[rg][jobqueue.JobStateDependent], counts[rg][jobqueue.JobStateLost], counts[rg][jobqueue.JobStateDelayed], counts[rg][jobqueue.JobStateBuried], usage, dead)
+				fmt.Printf("%s : complete=%d running=%d staging=%d uploading=%d ready=%d dependent=%d lost=%d delayed=%d buried=%d%s%s\n", rg, counts[rg][jobqueue.JobStateComplete], counts[rg][jobqueue.JobStateRunning], counts[rg][jobqueue.JobStateStaging], counts[rg][jobqueue.JobStateUploading], counts[rg][jobqueue.JobStateReady], counts[rg][jobqueue.JobStateDependent], counts[rg][jobqueue.JobStateLost], counts[rg][jobqueue.JobStateDelayed], counts[rg][jobqueue.JobStateBuried], usage, dead)
 			}
 		case "details", "d":
 			// print out status information for each job
+			notes := getNotes(jq, jobs)
 			for _, job := range jobs {
 				cwd := job.Cwd
 				var mounts string
@@ -278,6 +414,9 @@ name to just the first letter, eg. -o c):
 				if len(job.LimitGroups) > 0 {
 					limitGroups = fmt.Sprintf("Limit groups: %s; ", strings.Join(job.LimitGroups, ", "))
 				}
+				if job.Queue != "" {
+					limitGroups = fmt.Sprintf("Work queue: %s; ", job.Queue) + limitGroups
+				}
 				var dockerMonitored string
 				if job.MonitorDocker != "" {
 					dockerID := job.MonitorDocker
@@ -286,6 +425,10 @@ name to just the first letter, eg. -o c):
 					}
 					dockerMonitored = fmt.Sprintf("Docker container monitoring turned on for: %s\n", dockerID)
 				}
+				var reportCmd string
+				if job.ReportCmd != "" {
+					reportCmd = fmt.Sprintf("Report command: %s\n", job.ReportCmd)
+				}
 				var behaviours string
 				if len(job.Behaviours) > 0 {
 					behaviours = fmt.Sprintf("Behaviours: %s\n", job.Behaviours)
@@ -298,7 +441,7 @@ name to just the first letter, eg. -o c):
 					}
 					other = fmt.Sprintf("Resource requirements: %s\n", strings.Join(others, ", "))
 				}
-				fmt.Printf("\n# %s\nCwd: %s\n%s%s%s%s%sId: %s (%s); Requirements group: %s; %sPriority: %d; Attempts: %d\nExpected requirements: { memory: %dMB; time: %s; cpus: %s disk: %dGB }\n", job.Cmd, cwd, mounts, homeChanged, dockerMonitored, behaviours, other, job.RepGroup, job.Key(), job.ReqGroup, limitGroups, job.Priority, job.Attempts, job.Requirements.RAM, job.Requirements.Time, strconv.FormatFloat(job.Requirements.Cores, 'f', -1, 64), job.Requirements.Disk)
+				fmt.Printf("\n# %s\nCwd: %s\n%s%s%s%s%s%sId: %s (%s); Requirements group: %s; %sPriority: %d; Attempts: %d\nExpected requirements: { memory: %dMB; time: %s; cpus: %s disk: %dGB }\n", job.Cmd, cwd, mounts, homeChanged, dockerMonitored, reportCmd, behaviours, other, job.RepGroup, job.Key(), job.ReqGroup, limitGroups, job.Priority, job.Attempts, job.Requirements.RAM, job.Requirements.Time, strconv.FormatFloat(job.Requirements.Cores, 'f', -1, 64), job.Requirements.Disk)
 
 				switch job.State {
 				case jobqueue.JobStateDelayed:
@@ -311,6 +454,10 @@ name to just the first letter, eg. -o c):
 					fmt.Printf("Status: buried - you need to fix the problem and then `wr retry` (attempted at %s)\n", job.StartTime.Format(shortTimeFormat))
 				case jobqueue.JobStateReserved, jobqueue.JobStateRunning:
 					fmt.Printf("Status: running (started %s)\n", job.StartTime.Format(shortTimeFormat))
+				case jobqueue.JobStateStaging:
+					fmt.Println("Status: staging - mounting file systems and fetching inputs before running")
+				case jobqueue.JobStateUploading:
+					fmt.Printf("Status: uploading outputs (started %s)\n", job.StartTime.Format(shortTimeFormat))
 				case jobqueue.JobStateLost:
 					fmt.Printf("Status: lost contact (started %s; lost %s)\n", job.StartTime.Format(shortTimeFormat), job.EndTime.Format(shortTimeFormat))
 				case jobqueue.JobStateComplete:
@@ -321,17 +468,53 @@ name to just the first letter, eg. -o c):
 					fmt.Printf("Previous problem: %s\n", job.FailReason)
 				}
 
+				if job.LastAction != nil {
+					fmt.Printf("Last intervention: %s\n", job.LastAction)
+				}
+
+				for _, note := range notes[job.Key()] {
+					fmt.Printf("Note: %s (%s, %s)\n", note.Note, orDash(note.User), note.Time.Format(shortTimeFormat))
+				}
+
+				if job.AtomicGroup != "" {
+					var invalid string
+					if job.AtomicGroupFailed {
+						invalid = " (another member was buried after this completed, so its outputs are invalid)"
+					}
+					fmt.Printf("Atomic group: %s%s\n", job.AtomicGroup, invalid)
+				}
+
 				var hostID string
 				if job.HostID != "" {
 					hostID = ", ID: " + job.HostID
 				}
+				var sched string
+				if job.Scheduler != "" {
+					sched = "; Scheduler: " + job.Scheduler
+				}
 
 				if job.Exited {
 					prefix := "Stats"
-					if job.State != jobqueue.JobStateComplete {
+					switch {
+					case job.State != jobqueue.JobStateComplete:
 						prefix = "Stats of previous attempt"
+					case job.Cached:
+						prefix = "Stats of cached run"
+					}
+					fmt.Printf("%s: { Exit code: %d; Peak memory: %dMB; Peak disk: %dMB; Wall time: %s; CPU time: %s }\nHost: %s (IP: %s%s); Pid: %d%s\n", prefix, job.Exitcode, job.PeakRAM, job.PeakDisk, job.WallTime(), job.CPUtime, job.Host, job.HostIP, hostID, job.Pid, sched)
+					if len(job.Metrics) > 0 {
+						fmt.Printf("Metrics: { %s }\n", job.MetricsString("; "))
+					}
+					for _, br := range job.BehaviourResults {
+						problem := ""
+						if br.Error != "" {
+							problem = " (failed)"
+						}
+						fmt.Printf("Behaviour: %s took %s%s\n", br.Behaviour, br.Duration, problem)
+						if showextra && showStd && br.Output != "" {
+							fmt.Printf("Behaviour output:\n%s\n", br.Output)
+						}
 					}
-					fmt.Printf("%s: { Exit code: %d; Peak memory: %dMB; Peak disk: %dMB; Wall time: %s; CPU time: %s }\nHost: %s (IP: %s%s); Pid: %d\n", prefix, job.Exitcode, job.PeakRAM, job.PeakDisk, job.WallTime(), job.CPUtime, job.Host, job.HostIP, hostID, job.Pid)
 					if showextra && showStd && job.Exitcode != 0 {
 						stdout, errs := job.StdOut()
 						if errs != nil {
@@ -351,7 +534,7 @@ name to just the first letter, eg. -o c):
 						}
 					}
 				} else if job.State == jobqueue.JobStateRunning || job.State == jobqueue.JobStateLost {
-					fmt.Printf("Stats: { Wall time: %s }\nHost: %s (IP: %s%s); Pid: %d\n", job.WallTime(), job.Host, job.HostIP, hostID, job.Pid)
+					fmt.Printf("Stats: { Wall time: %s }\nHost: %s (IP: %s%s); Pid: %d%s\n", job.WallTime(), job.Host, job.HostIP, hostID, job.Pid, sched)
 					//*** we should be able to peek at STDOUT & STDERR, and see
 					// Peak memory during a run... but is that possible/ too
 					// expensive? Maybe we could communicate directly with the
@@ -426,14 +609,36 @@ func init() {
 	statusCmd.Flags().StringVarP(&mountJSON, "mount_json", "j", "", "mounts that the command(s) specified by -l or -f were set to use (JSON format)")
 	statusCmd.Flags().StringVar(&mountSimple, "mounts", "", "mounts that the command(s) specified by -l or -f were set to use (simple format)")
 	statusCmd.Flags().BoolVarP(&showBuried, "buried", "b", false, "in default or -i mode only, only show the status of buried commands")
+	statusCmd.Flags().StringVar(&statusState, "state", "", "in default or -i mode only, only show the status of commands in this state (eg. staging)")
 	statusCmd.Flags().BoolVarP(&showStd, "std", "s", false, "in -o d mode, except in -f mode, also show the most recent STDOUT and STDERR of incomplete commands")
 	statusCmd.Flags().BoolVarP(&showEnv, "env", "e", false, "in -o d mode, except in -f mode, also show the environment variables the command(s) ran with")
 	statusCmd.Flags().StringVarP(&outputFormat, "output", "o", "details", "['counts','summary','details','json'] output format")
 	statusCmd.Flags().IntVar(&statusLimit, "limit", 1, "in -o d mode, number of commands that share the same properties to display; 0 displays all")
+	statusCmd.Flags().BoolVar(&showSchedGroups, "scheduler_groups", false, "show the manager's scheduler groups instead of the status of commands")
+	statusCmd.Flags().BoolVar(&showTransfers, "transfers", false, "show copies of files to the manager instead of the status of commands")
+	statusCmd.Flags().BoolVar(&showResources, "resources", false, "in -i mode, compare requested vs used resources of completed commands instead")
+	statusCmd.Flags().BoolVar(&showComplete, "complete", false, "only show completed commands, most recent first")
+	statusCmd.Flags().StringVar(&completeSince, "since", "", "in --complete mode, only show commands that completed within this long ago (eg. 24h)")
+	statusCmd.Flags().StringVar(&completeUntil, "until", "", "in --complete mode, only show commands that completed at least this long ago (eg. 1h)")
+	statusCmd.Flags().StringVar(&statusWorkQueue, "work_queue", "", "only show the status of incomplete commands in this wr queue")
+	statusCmd.Flags().BoolVar(&showKey, "key", false, "just print the internal job id of the command given by -l, without contacting the manager")
+	statusCmd.Flags().StringVar(&statusAt, "at", "", "show the state counts of incomplete commands as they were at this past time (eg. '2017-05-01 14:00' or 12h)")
 
 	statusCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
 }
 
+// parseStatusState converts the user's --state to a JobState, dying if it isn't
+// a state that incomplete commands can be in.
+func parseStatusState(state string) jobqueue.JobState {
+	switch js := jobqueue.JobState(state); js {
+	case jobqueue.JobStateDelayed, jobqueue.JobStateReady, jobqueue.JobStateRunning, jobqueue.JobStateStaging,
+		jobqueue.JobStateUploading, jobqueue.JobStateLost, jobqueue.JobStateBuried, jobqueue.JobStateDependent:
+		return js
+	}
+	die("--state must be one of delayed|ready|running|staging|uploading|lost|buried|dependent")
+	return ""
+}
+
 func countGetJobArgs() int {
 	set := 0
 	if cmdFileStatus != "" {
@@ -456,6 +661,9 @@ func getJobs(jq *jobqueue.Client, cmdState jobqueue.JobState, all bool, statusLi
 	var err error
 
 	switch {
+	case all && statusWorkQueue != "":
+		// get all jobs in a named queue
+		jobs, err = jq.GetIncompleteInQueue(statusWorkQueue, statusLimit, cmdState, showStd, showEnv)
 	case all:
 		// get all jobs
 		jobs, err = jq.GetIncomplete(statusLimit, cmdState, showStd, showEnv)
@@ -475,7 +683,7 @@ func getJobs(jq *jobqueue.Client, cmdState jobqueue.JobState, all bool, statusLi
 		}
 	case cmdFileStatus != "":
 		// parse the supplied commands
-		parsedJobs, _, _ := parseCmdFile(jq, false)
+		parsedJobs, _, _ := parseCmdFile(jq, false, false)
 
 		// round-trip via the server to get those that actually exist in
 		// the queue
@@ -486,12 +694,8 @@ func getJobs(jq *jobqueue.Client, cmdState jobqueue.JobState, all bool, statusLi
 		}
 	case cmdLine != "":
 		// get job that has the supplied command
-		var defaultMounts jobqueue.MountConfigs
-		if mountJSON != "" || mountSimple != "" {
-			defaultMounts = mountParse(mountJSON, mountSimple)
-		}
 		var job *jobqueue.Job
-		job, err = jq.GetByEssence(&jobqueue.JobEssence{Cmd: cmdLine, Cwd: cmdCwd, MountConfigs: defaultMounts}, showStd, showEnv)
+		job, err = jq.GetByCmd(cmdLine, cmdCwd, statusMounts(), showStd, showEnv)
 		if job != nil {
 			jobs = append(jobs, job)
 		}
@@ -504,6 +708,42 @@ func getJobs(jq *jobqueue.Client, cmdState jobqueue.JobState, all bool, statusLi
 	return jobs
 }
 
+// getCompleteJobs gets the most recently completed jobs (optionally just those
+// in the -i report group) that completed within the --since and --until
+// durations ago.
+func getCompleteJobs(jq *jobqueue.Client, limit int, showStd, showEnv bool) []*jobqueue.Job {
+	now := time.Now()
+	var since, until time.Time
+	if completeSince != "" {
+		d, err := time.ParseDuration(completeSince)
+		if err != nil {
+			die("--since was not specified correctly: %s", err)
+		}
+		since = now.Add(-d)
+	}
+	if completeUntil != "" {
+		d, err := time.ParseDuration(completeUntil)
+		if err != nil {
+			die("--until was not specified correctly: %s", err)
+		}
+		until = now.Add(-d)
+	}
+
+	jobs, err := jq.GetComplete(cmdIDStatus, cmdIDIsSubStr, since, until, limit, showStd, showEnv)
+	if err != nil {
+		die("failed to get complete jobs: %s", err)
+	}
+	return jobs
+}
+
+// statusMounts returns the mounts given by --mounts or --mount_json, if any.
+func statusMounts() jobqueue.MountConfigs {
+	if mountJSON == "" && mountSimple == "" {
+		return nil
+	}
+	return mountParse(mountJSON, mountSimple)
+}
+
 func jobsToJobEssenses(jobs []*jobqueue.Job) []*jobqueue.JobEssence {
 	jes := make([]*jobqueue.JobEssence, 0, len(jobs))
 	for _, job := range jobs {
@@ -511,3 +751,256 @@ func jobsToJobEssenses(jobs []*jobqueue.Job) []*jobqueue.JobEssence {
 	}
 	return jes
 }
+
+// showSchedulerGroups prints details of the manager's scheduler groups.
+func showSchedulerGroups(jq *jobqueue.Client) {
+	sgs, err := jq.GetSchedulerGroups()
+	if err != nil {
+		die("failed to get scheduler groups: %s", err)
+	}
+
+	if outputFormat == "json" || outputFormat == "j" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		err = encoder.Encode(sgs)
+		if err != nil {
+			die("failed to encode scheduler groups: %s", err)
+		}
+		return
+	}
+
+	if len(sgs) == 0 {
+		info("there are no scheduler groups")
+		return
+	}
+
+	now := time.Now()
+	for _, sg := range sgs {
+		fmt.Printf("\n# %s\n", sg.Name)
+		if req := sg.Requirements; req != nil {
+			fmt.Printf("Requirements: %dMB RAM, %s time, %g cores, %dGB disk\n", req.RAM, req.Time, req.Cores, req.Disk)
+		}
+		fmt.Printf("Requirement groups: %s\n", strings.Join(sg.ReqGroups, ", "))
+		if sg.Scheduler != "" {
+			fmt.Printf("Scheduler: %s (priority %d)\n", sg.Scheduler, sg.Priority)
+		}
+		fmt.Printf("Commands ready: %d; running: %d\n", sg.Ready, sg.Running)
+		fmt.Printf("Runners requested: %d; connected: %d\n", sg.Requested, sg.Connected)
+
+		switch {
+		case sg.Since.IsZero():
+			fmt.Printf("Runners have not been scheduled\n")
+		case sg.LastConnected.IsZero():
+			fmt.Printf("Waiting for a runner since %s (%s)\n", sg.Since.Format(shortTimeFormat), sg.Waited(now).Round(time.Second))
+		default:
+			fmt.Printf("Runners first scheduled %s; last runner connected %s (%s ago)\n", sg.Since.Format(shortTimeFormat), sg.LastConnected.Format(shortTimeFormat), sg.Waited(now).Round(time.Second))
+		}
+	}
+	fmt.Printf("\n")
+}
+
+// showTransferQueue prints details of the active and queued copies of files to
+// the manager.
+func showTransferQueue(jq *jobqueue.Client) {
+	transfers, err := jq.GetTransfers()
+	if err != nil {
+		die("failed to get transfers: %s", err)
+	}
+
+	if outputFormat == "json" || outputFormat == "j" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		err = encoder.Encode(transfers)
+		if err != nil {
+			die("failed to encode transfers: %s", err)
+		}
+		return
+	}
+
+	if len(transfers) == 0 {
+		info("there are no transfers to the manager")
+		return
+	}
+
+	now := time.Now()
+	for _, t := range transfers {
+		fmt.Printf("%s %s from %s since %s (%s); %d bytes copied\n", t.JobKey, t.State, t.Host, t.Since.Format(shortTimeFormat), now.Sub(t.Since).Round(time.Second), t.Bytes)
+	}
+}
+
+// statusAtFormats are the local time formats --at can be given in, in addition
+// to RFC3339 and durations.
+var statusAtFormats = []string{
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseStatusAt parses the value of --at, which is either a time or how long
+// ago, dying if it can't be parsed or is in the future.
+func parseStatusAt(value string) time.Time {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d)
+	}
+
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		for _, format := range statusAtFormats {
+			if at, err = time.ParseInLocation(format, value, time.Local); err == nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		die("--at was not specified correctly; use a form like '2017-05-01 14:00' or 12h")
+	}
+	if at.After(time.Now()) {
+		die("--at must be in the past")
+	}
+	return at
+}
+
+// showStateCountsAt prints the state counts of incomplete jobs, per RepGroup,
+// as they were at the given time.
+func showStateCountsAt(jq *jobqueue.Client, at time.Time) {
+	counts, err := jq.GetStateCountsAt(at)
+	if err != nil {
+		die("failed to get state counts: %s", err)
+	}
+
+	if cmdIDStatus != "" {
+		for rg := range counts {
+			if (cmdIDIsSubStr && !strings.Contains(rg, cmdIDStatus)) || (!cmdIDIsSubStr && rg != cmdIDStatus) {
+				delete(counts, rg)
+			}
+		}
+	}
+
+	states := []jobqueue.JobState{jobqueue.JobStateRunning, jobqueue.JobStateStaging, jobqueue.JobStateUploading,
+		jobqueue.JobStateReady, jobqueue.JobStateDependent, jobqueue.JobStateLost, jobqueue.JobStateDelayed,
+		jobqueue.JobStateBuried}
+	printCounts := func(c map[jobqueue.JobState]int) {
+		fmt.Printf("running: %d\nstaging: %d\nuploading: %d\nready: %d\ndependent: %d\nlost contact: %d\ndelayed: %d\nburied: %d\n",
+			c[states[0]], c[states[1]], c[states[2]], c[states[3]], c[states[4]], c[states[5]], c[states[6]], c[states[7]])
+	}
+
+	switch outputFormat {
+	case "json", "j":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		err = encoder.Encode(counts)
+		if err != nil {
+			die("failed to encode state counts: %s", err)
+		}
+	case "counts", "c":
+		totals := make(map[jobqueue.JobState]int)
+		for _, c := range counts {
+			for _, state := range states {
+				totals[state] += c[state]
+			}
+		}
+		printCounts(totals)
+	default:
+		if len(counts) == 0 {
+			info("there were no matching incomplete commands at %s", at.Format(shortTimeFormat))
+			return
+		}
+		rgs := make([]string, 0, len(counts))
+		for rg := range counts {
+			rgs = append(rgs, rg)
+		}
+		sort.Strings(rgs)
+		fmt.Printf("As of %s:\n", at.Format(shortTimeFormat))
+		for _, rg := range rgs {
+			fmt.Printf("\n# %s\n", rg)
+			printCounts(counts[rg])
+		}
+	}
+}
+
+// showResourceUsage prints a comparison of the requested and actual resource
+// usage of the given jobs' RepGroups, in the desired output format.
+func showResourceUsage(jobs []*jobqueue.Job) {
+	reports := jobqueue.ResourceUsageReport(jobs)
+
+	if outputFormat == "json" || outputFormat == "j" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		err := encoder.Encode(reports)
+		if err != nil {
+			die("failed to encode resource usage: %s", err)
+		}
+		return
+	}
+
+	if len(reports) == 0 {
+		info("there are no completed commands in that report group")
+		return
+	}
+
+	for _, ru := range reports {
+		fmt.Printf("\n# %s (%d completed commands)\n", ru.RepGroup, ru.Jobs)
+		showResourceComparison("Memory (MB)", ru.Memory, jobqueue.ResourceMemory, ru.OverRequested, func(v float64) string {
+			return strconv.Itoa(int(v))
+		})
+		showResourceComparison("Time", ru.Time, jobqueue.ResourceTime, ru.OverRequested, func(v float64) string {
+			return (time.Duration(v) * time.Second).String()
+		})
+		showResourceComparison("CPUs", ru.CPUs, jobqueue.ResourceCPUs, ru.OverRequested, func(v float64) string {
+			return strconv.FormatFloat(v, 'f', 2, 64)
+		})
+	}
+	fmt.Printf("\n")
+}
+
+// getNotes gets the notes left with "wr annotate" on the given jobs, and on
+// their RepGroups, keyed on job key. Problems are only warned about, since the
+// notes are not essential.
+func getNotes(jq *jobqueue.Client, jobs []*jobqueue.Job) map[string][]*jobqueue.Annotation {
+	keys := make([]string, len(jobs))
+	rgSet := make(map[string]bool)
+	var rgs []string
+	for i, job := range jobs {
+		keys[i] = job.Key()
+		if !rgSet[job.RepGroup] {
+			rgSet[job.RepGroup] = true
+			rgs = append(rgs, job.RepGroup)
+		}
+	}
+
+	notes := make(map[string][]*jobqueue.Annotation)
+	if len(jobs) == 0 {
+		return notes
+	}
+	annotations, err := jq.GetAnnotations(keys, rgs)
+	if err != nil {
+		warn("failed to get notes: %s", err)
+		return notes
+	}
+
+	for _, job := range jobs {
+		key := job.Key()
+		for _, a := range annotations {
+			if a.Key == key || (a.Key == "" && a.RepGroup == job.RepGroup) {
+				notes[key] = append(notes[key], a)
+			}
+		}
+	}
+	return notes
+}
+
+// showResourceComparison prints one line of showResourceUsage() output.
+func showResourceComparison(label string, rc jobqueue.ResourceComparison, resource string, overRequested []string, format func(float64) string) {
+	dist := func(rd jobqueue.ResourceDistribution) string {
+		return fmt.Sprintf("min=%s median=%s p95=%s max=%s", format(rd.Min), format(rd.Median), format(rd.P95), format(rd.Max))
+	}
+	var flag string
+	for _, r := range overRequested {
+		if r == resource {
+			flag = " [over-requested]"
+		}
+	}
+	fmt.Printf("%s: requested { %s }; used { %s }%s\n", label, dist(rc.Requested), dist(rc.Used), flag)
+}
@@ -0,0 +1,313 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	topAltScreen     = "\033[?1049h\033[?25l"
+	topNormalScreen  = "\033[?25h\033[?1049l"
+	topHome          = "\033[H\033[2J"
+	topDefaultWidth  = 80
+	topDefaultHeight = 24
+	topMaxHosts      = 10
+)
+
+// topStates are the job states shown as columns, in display order.
+var topStates = []jobqueue.JobState{
+	jobqueue.JobStateDelayed,
+	jobqueue.JobStateDependent,
+	jobqueue.JobStateReady,
+	jobqueue.JobStateStaging,
+	jobqueue.JobStateRunning,
+	jobqueue.JobStateUploading,
+	jobqueue.JobStateLost,
+	jobqueue.JobStateBuried,
+	jobqueue.JobStateComplete,
+}
+
+// options for this cmd
+var topInterval int
+
+// topCmd represents the top command
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Live dashboard of commands",
+	Long: `Live dashboard of commands.
+
+Shows an automatically refreshing overview of your commands in the terminal,
+based on the same information the web interface shows: the number of commands
+in each state per report group, the hosts commands are running on, how many
+commands completed per minute over the last 5 minutes, and recent failures.
+
+This is useful when you're working over ssh and can't reach the manager's web
+interface port with a browser.
+
+Counts are only shown for report groups that had incomplete commands when you
+started the dashboard, or that gained some since.
+
+Press q (or ctrl-c) to quit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if topInterval < 1 {
+			die("--interval must be at least 1")
+		}
+
+		token, err := token()
+		if err != nil {
+			die("could not read token file; has the manager been started? [%s]", err)
+		}
+
+		timeout := time.Duration(timeoutint) * time.Second
+		webAddr := net.JoinHostPort(config.ManagerHost, config.ManagerWeb)
+		sw, err := jobqueue.WatchStatus(webAddr, caFile, config.ManagerCertDomain, token, timeout)
+		if err != nil {
+			die("could not connect to the manager's web interface at %s: %s", webAddr, err)
+		}
+		defer func() {
+			errc := sw.Close()
+			if errc != nil {
+				warn("Disconnecting from the server failed: %s", errc)
+			}
+		}()
+
+		runTop(sw, webAddr, time.Duration(topInterval)*time.Second)
+		if errw := sw.Err(); errw != nil {
+			die("lost connection to the manager: %s", errw)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(topCmd)
+
+	// flags specific to this sub-command
+	topCmd.Flags().IntVarP(&topInterval, "interval", "n", 2, "how often (seconds) to refresh the display")
+	topCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// runTop draws the dashboard every interval until the user quits or the
+// connection to the server is lost.
+func runTop(sw *jobqueue.StatusWatcher, webAddr string, interval time.Duration) {
+	quit := make(chan bool, 1)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	// in a terminal, read key presses unbuffered so that q works; raw mode
+	// means we have to do our own carriage returns
+	eol := "\n"
+	stdin := int(os.Stdin.Fd())
+	if terminal.IsTerminal(stdin) {
+		oldState, err := terminal.MakeRaw(stdin)
+		if err == nil {
+			defer func() {
+				errr := terminal.Restore(stdin, oldState)
+				if errr != nil {
+					warn("could not restore the terminal: %s", errr)
+				}
+			}()
+			eol = "\r\n"
+			go readTopKeys(quit)
+		}
+	}
+
+	fmt.Print(topAltScreen)
+	defer fmt.Print(topNormalScreen)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := sw.RequestHosts()
+		if err != nil {
+			return
+		}
+
+		// give the hosts answer a moment to arrive before drawing
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-quit:
+			return
+		case <-sigs:
+			return
+		case <-sw.Done():
+			return
+		}
+
+		width, height := topDefaultWidth, topDefaultHeight
+		if w, h, errs := terminal.GetSize(int(os.Stdout.Fd())); errs == nil {
+			width, height = w, h
+		}
+		lines := topLines(sw.Snapshot(), webAddr, time.Now(), width, height)
+		fmt.Print(topHome + strings.Join(lines, eol))
+
+		select {
+		case <-ticker.C:
+		case <-quit:
+			return
+		case <-sigs:
+			return
+		case <-sw.Done():
+			return
+		}
+	}
+}
+
+// readTopKeys reads key presses from STDIN, which must be in raw mode, telling
+// quit when the user presses q or ctrl-c.
+func readTopKeys(quit chan bool) {
+	buf := make([]byte, 1)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			quit <- true
+			return
+		}
+		if n == 1 && (buf[0] == 'q' || buf[0] == 'Q' || buf[0] == 3) {
+			quit <- true
+			return
+		}
+	}
+}
+
+// topLines returns the lines of the dashboard for the given snapshot, fitted
+// to the given terminal width and height.
+func topLines(ss *jobqueue.StatusSnapshot, webAddr string, now time.Time, width, height int) []string {
+	var lines []string
+	lines = append(lines, fmt.Sprintf("wr top - %s - %s - %.1f cmds/min completed (last 5 mins) - q to quit", webAddr, now.Format(shortTimeFormat), ss.Throughput))
+
+	all := ss.Counts[jobqueue.StatusAllRepGroups]
+	incomplete := 0
+	var allCounts []string
+	for _, state := range topStates {
+		if state == jobqueue.JobStateComplete {
+			continue
+		}
+		incomplete += all[state]
+		allCounts = append(allCounts, fmt.Sprintf("%s %d", state, all[state]))
+	}
+	lines = append(lines, fmt.Sprintf("Incomplete: %d (%s)", incomplete, strings.Join(allCounts, ", ")), "")
+
+	// work out the other sections first, since the report group table gets
+	// whatever space is left
+	var tail []string
+	if len(ss.Hosts) > 0 {
+		tail = append(tail, "", "Running on:")
+		tail = append(tail, topHostLines(ss.Hosts)...)
+	}
+	if len(ss.Failures) > 0 {
+		tail = append(tail, "", "Recent failures:")
+		for _, f := range ss.Failures {
+			tail = append(tail, fmt.Sprintf("  %s %d buried in %s", f.Time.Format(shortTimeFormat), f.Count, f.RepGroup))
+		}
+	}
+	if len(ss.BadServers) > 0 || len(ss.Messages) > 0 {
+		tail = append(tail, "", "Problems:")
+		for _, bs := range ss.BadServers {
+			tail = append(tail, fmt.Sprintf("  server %s (%s) is bad: %s", bs.Name, bs.IP, bs.Problem))
+		}
+		for _, msg := range ss.Messages {
+			tail = append(tail, "  "+msg)
+		}
+	}
+
+	table := topRepGroupLines(ss)
+	if space := height - 1 - len(lines) - len(tail); len(table) > space {
+		if space < 2 {
+			space = 2
+		}
+		hidden := len(table) - space + 1
+		table = append(table[:space-1], fmt.Sprintf("(%d more report groups not shown)", hidden))
+	}
+	lines = append(lines, table...)
+	lines = append(lines, tail...)
+
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for i, line := range lines {
+		if len(line) > width {
+			lines[i] = line[:width]
+		}
+	}
+	return lines
+}
+
+// topRepGroupLines returns a table of state counts per report group.
+func topRepGroupLines(ss *jobqueue.StatusSnapshot) []string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	header := []string{"report group"}
+	for _, state := range topStates {
+		header = append(header, string(state))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t")+"\t")
+	for _, rg := range ss.RepGroups() {
+		counts := ss.Counts[rg]
+		row := []string{rg}
+		for _, state := range topStates {
+			row = append(row, fmt.Sprintf("%d", counts[state]))
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t")+"\t")
+	}
+	err := w.Flush()
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+}
+
+// topHostLines returns lines describing the hosts with the most running jobs.
+func topHostLines(hosts map[string]int) []string {
+	names := make([]string, 0, len(hosts))
+	for host := range hosts {
+		names = append(names, host)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if hosts[names[i]] == hosts[names[j]] {
+			return names[i] < names[j]
+		}
+		return hosts[names[i]] > hosts[names[j]]
+	})
+
+	var lines []string
+	for i, host := range names {
+		if i == topMaxHosts {
+			lines = append(lines, fmt.Sprintf("  (%d more hosts not shown)", len(names)-topMaxHosts))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %d", host, hosts[host]))
+	}
+	return lines
+}
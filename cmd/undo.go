@@ -0,0 +1,119 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue"
+	"github.com/spf13/cobra"
+)
+
+// options for this cmd
+var undoSince string
+var undoList bool
+
+// undoCmd represents the undo command
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Restore removed commands",
+	Long: `Restore commands that were recently removed by mistake.
+
+When you remove commands with "wr remove" or the web interface, the manager
+keeps them for the managerrecyclewindow set in your config file (60 minutes by
+default), so that if you removed the wrong ones, you can add them back again
+with this command. They are added back as if they were newly added with
+"wr add".
+
+By default, all the commands removed within the window are restored. Use -i to
+only restore those in the given report group (-z to treat it as a substring to
+match against all report groups), and --since to only restore those removed
+within that long ago (eg. --since 10m).
+
+--list shows the commands that would be restored, as tab separated columns of
+when they were removed, their report group and their command line, without
+restoring them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var since time.Time
+		if undoSince != "" {
+			d, err := time.ParseDuration(undoSince)
+			if err != nil {
+				die("--since was not specified correctly: %s", err)
+			}
+			since = time.Now().Add(-d)
+		}
+
+		timeout := time.Duration(timeoutint) * time.Second
+		jq := connect(timeout)
+		var err error
+		defer func() {
+			err = jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		if jq.ServerInfo.RecycleWindow <= 0 {
+			die("the manager does not keep removed commands (managerrecyclewindow is 0), so they can't be restored")
+		}
+
+		if undoList {
+			recycled, errg := jq.GetRecycled(cmdIDStatus, cmdIDIsSubStr, since)
+			if errg != nil {
+				die("failed to get removed commands: %s", errg)
+			}
+			for _, rj := range recycled {
+				fmt.Printf("%s\t%s\t%s\n", rj.Deleted.Format(shortTimeFormat), rj.Job.RepGroup, rj.Job.Cmd)
+			}
+			return
+		}
+
+		restored, err := jq.Undelete(cmdIDStatus, cmdIDIsSubStr, since)
+		if err != nil {
+			die("failed to restore removed commands: %s", err)
+		}
+		if restored == 0 {
+			info("No removed commands were restored")
+			return
+		}
+		info("Restored %d removed commands", restored)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(undoCmd)
+
+	// flags specific to this sub-command
+	undoCmd.Flags().StringVarP(&cmdIDStatus, "identifier", "i", "", "identifier of the commands you want to restore")
+	undoCmd.Flags().BoolVarP(&cmdIDIsSubStr, "search", "z", false, "treat -i as a substring to match against all report groups")
+	undoCmd.Flags().StringVar(&undoSince, "since", "", "only restore commands removed within this long ago (eg. 10m)")
+	undoCmd.Flags().BoolVar(&undoList, "list", false, "just list the commands that would be restored")
+
+	undoCmd.Flags().IntVar(&timeoutint, "timeout", 120, "how long (seconds) to wait to get a reply from 'wr manager'")
+}
+
+// undoHint returns a note on how to restore removed commands, if the manager
+// keeps them.
+func undoHint(jq *jobqueue.Client) string {
+	if jq.ServerInfo == nil || jq.ServerInfo.RecycleWindow <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("; if this was a mistake, use \"wr undo\" within %s to restore them", jq.ServerInfo.RecycleWindow)
+}
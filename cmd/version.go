@@ -20,21 +20,75 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/VertebrateResequencing/wr/jobqueue"
 	"github.com/spf13/cobra"
 )
 
+// versionTimeout is how long we wait for the manager when finding out its
+// version.
+const versionTimeout = 5 * time.Second
+
+// options for this cmd
+var versionClientOnly bool
+
 // versionCmd represents the version command
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print wr version",
-	Long:  `Print wr version.`,
+	Long: `Print wr version.
+
+Shows the version of this wr executable and the protocol version it uses to talk
+to the manager. If the manager can be reached, its version and protocol version
+are also shown.
+
+Different versions of wr using the same protocol version can work together,
+though it's best if the manager, runners and your wr commands are all the same
+version. If the protocol versions differ, the manager will refuse requests from
+this executable, and you'll need to use a wr that matches the manager's version.
+
+With --client, only the version of this executable is printed, without any
+other text.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println(jobqueue.ServerVersion)
+		if versionClientOnly {
+			fmt.Println(jobqueue.ServerVersion)
+			return
+		}
+		fmt.Printf("client: %s (protocol %d)\n", jobqueue.ServerVersion, jobqueue.ProtocolVersion)
+
+		jq := connect(versionTimeout, true)
+		if jq == nil {
+			fmt.Printf("manager: could not be reached\n")
+			return
+		}
+		defer func() {
+			err := jq.Disconnect()
+			if err != nil {
+				warn("Disconnecting from the server failed: %s", err)
+			}
+		}()
+
+		si := jq.ServerInfo
+		if si.Protocol == 0 {
+			fmt.Printf("manager: unknown (too old to report its version)\n")
+			warn("the manager is older than this client; commands may fail")
+			return
+		}
+		fmt.Printf("manager: %s (protocol %d)\n", si.Version, si.Protocol)
+
+		switch {
+		case si.Protocol != jobqueue.ProtocolVersion:
+			warn("the manager uses a different protocol version, so will refuse requests from this client; use wr %s", si.Version)
+		case si.Version != jobqueue.ServerVersion:
+			warn("the manager is a different version, but should still work with this client")
+		}
 	},
 }
 
 func init() {
 	RootCmd.AddCommand(versionCmd)
+
+	// flags specific to this sub-command
+	versionCmd.Flags().BoolVar(&versionClientOnly, "client", false, "only print the version of this executable")
 }
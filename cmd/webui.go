@@ -0,0 +1,266 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	webuiMinUptime    = 1 * time.Minute
+	webuiInitialDelay = 1 * time.Second
+	webuiMaxDelay     = 30 * time.Second
+)
+
+// options for this cmd
+var webuiVia string
+var webuiUser string
+var webuiIdentity string
+var webuiRemoteHost string
+var webuiLocalPort string
+var webuiOpen bool
+
+// webuiCmd represents the webui command
+var webuiCmd = &cobra.Command{
+	Use:   "webui",
+	Short: "Reach the manager's web interface",
+	Long: `Reach the manager's web interface.
+
+Without options, this just tells you the URL of the web interface, including
+the authentication token.
+
+If the manager is running on a host you can't reach directly from your
+machine, but you can ssh to it (or to another host that can reach it), use
+--via to name the host to ssh to. Your normal ssh configuration (~/.ssh/config,
+keys and agent) is used, so --via can be a Host alias from your ssh config. If
+you need a particular user or key, supply --user and --identity.
+
+An ssh tunnel is then set up that forwards a local port (by default the same
+port as the web interface uses, which you can change with --local_port) to the
+manager's web interface, and you're told the local URL to use. If the tunnel
+drops it is automatically re-established. Press ctrl-c to close the tunnel.
+
+The manager is assumed to be running on the managerhost from your config; if
+that is localhost, it's assumed to be running on the --via host itself. Use
+--remote_host to say otherwise. The token is read from your local token file,
+or failing that, from the same location on the --via host.
+
+With --open, the URL is also opened in your web browser. You'll probably get a
+certificate warning unless the manager's certificate is valid for localhost.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if webuiVia == "" {
+			url := webuiURL(config.ManagerHost, config.ManagerWeb, webuiToken(""))
+			info("wr's web interface can be reached at %s", url)
+			if webuiOpen {
+				openInBrowser(url)
+			}
+			return
+		}
+
+		sshPath, err := exec.LookPath("ssh")
+		if err != nil {
+			die("ssh could not be found in your PATH: %s", err)
+		}
+
+		localPort := webuiLocalPort
+		if localPort == "" {
+			localPort = config.ManagerWeb
+		}
+		remoteHost := webuiRemoteHost
+		if remoteHost == "" {
+			remoteHost = config.ManagerHost
+		}
+		sshArgs := webuiSSHArgs(localPort, remoteHost, config.ManagerWeb)
+
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigs)
+
+		first := true
+		delay := webuiInitialDelay
+		for {
+			started := time.Now()
+			tunnel := exec.Command(sshPath, sshArgs...) // #nosec
+			tunnel.Stderr = os.Stderr
+			err = tunnel.Start()
+			if err != nil {
+				die("could not start ssh: %s", err)
+			}
+			exited := make(chan error, 1)
+			go func() {
+				exited <- tunnel.Wait()
+			}()
+
+			if first {
+				// ssh exits immediately if it can't connect or forward
+				select {
+				case err = <-exited:
+					die("could not set up the ssh tunnel via %s: %v", webuiVia, err)
+				case <-sigs:
+					webuiStopTunnel(tunnel, exited)
+					return
+				case <-time.After(2 * time.Second):
+				}
+
+				url := webuiURL("localhost", localPort, webuiToken(sshPath))
+				info("tunnel via %s established; wr's web interface can be reached at %s", webuiVia, url)
+				info("press ctrl-c to close the tunnel")
+				if webuiOpen {
+					openInBrowser(url)
+				}
+				first = false
+			}
+
+			select {
+			case err = <-exited:
+			case <-sigs:
+				webuiStopTunnel(tunnel, exited)
+				return
+			}
+
+			if time.Since(started) > webuiMinUptime {
+				delay = webuiInitialDelay
+			}
+			warn("the ssh tunnel via %s closed (%v); reconnecting in %s", webuiVia, err, delay)
+			select {
+			case <-time.After(delay):
+			case <-sigs:
+				return
+			}
+			delay *= 2
+			if delay > webuiMaxDelay {
+				delay = webuiMaxDelay
+			}
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(webuiCmd)
+
+	// flags specific to this sub-command
+	webuiCmd.Flags().StringVar(&webuiVia, "via", "", "ssh host to tunnel through to reach the manager")
+	webuiCmd.Flags().StringVarP(&webuiUser, "user", "u", "", "username to ssh as (defaults to your ssh config)")
+	webuiCmd.Flags().StringVarP(&webuiIdentity, "identity", "i", "", "private key file to ssh with (defaults to your ssh config)")
+	webuiCmd.Flags().StringVar(&webuiRemoteHost, "remote_host", "", "host the manager runs on, as seen from the --via host (defaults to managerhost)")
+	webuiCmd.Flags().StringVarP(&webuiLocalPort, "local_port", "p", "", "local port to forward to the web interface (defaults to managerweb)")
+	webuiCmd.Flags().BoolVarP(&webuiOpen, "open", "o", false, "open the web interface in your browser")
+}
+
+// webuiSSHArgs returns the arguments to ssh that forward localPort to
+// remotePort on remoteHost via the --via host.
+func webuiSSHArgs(localPort, remoteHost, remotePort string) []string {
+	args := []string{
+		"-o", "ExitOnForwardFailure=yes",
+		"-o", "ServerAliveInterval=15",
+		"-o", "ServerAliveCountMax=3",
+		"-NT",
+		"-L", fmt.Sprintf("%s:%s:%s", localPort, remoteHost, remotePort),
+	}
+	return append(args, webuiSSHTarget()...)
+}
+
+// webuiSSHTarget returns the ssh arguments that identify who to connect as and
+// where.
+func webuiSSHTarget() []string {
+	var args []string
+	if webuiIdentity != "" {
+		args = append(args, "-i", webuiIdentity)
+	}
+	target := webuiVia
+	if webuiUser != "" {
+		target = webuiUser + "@" + webuiVia
+	}
+	return append(args, target)
+}
+
+// webuiToken returns our token. If it can't be read locally and sshPath is
+// supplied, tries to read it from the same location (relative to the home
+// directory) on the --via host. Returns "" if it can't be found.
+func webuiToken(sshPath string) string {
+	token, err := token()
+	if err == nil {
+		return string(token)
+	}
+	if sshPath == "" {
+		warn("could not read the token file %s: %s", config.ManagerTokenFile, err)
+		return ""
+	}
+
+	path := config.ManagerTokenFile
+	if home, errh := os.UserHomeDir(); errh == nil {
+		if rel, errr := filepath.Rel(home, path); errr == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(sshPath, append(append([]string{"-T"}, webuiSSHTarget()...), "cat", path)...) // #nosec
+	cmd.Stdout = &stdout
+	err = cmd.Run()
+	if err != nil || stdout.Len() == 0 {
+		warn("could not read the token file %s locally or on %s; add ?token=[your token] to the URL", path, webuiVia)
+		return ""
+	}
+	return strings.TrimSpace(stdout.String())
+}
+
+// webuiURL returns the URL of the web interface at the given host and port,
+// with the token appended if not blank.
+func webuiURL(host, port, token string) string {
+	url := fmt.Sprintf("https://%s:%s/", host, port)
+	if token != "" {
+		url += "?token=" + token
+	}
+	return url
+}
+
+// webuiStopTunnel kills the given ssh process and waits for it to exit.
+func webuiStopTunnel(tunnel *exec.Cmd, exited chan error) {
+	err := tunnel.Process.Kill()
+	if err != nil {
+		warn("failed to kill the ssh tunnel: %s", err)
+		return
+	}
+	<-exited
+	info("tunnel closed")
+}
+
+// openInBrowser opens the given URL in the user's web browser.
+func openInBrowser(url string) {
+	opener := "xdg-open"
+	if runtime.GOOS == "darwin" {
+		opener = "open"
+	}
+	err := exec.Command(opener, url).Start() // #nosec
+	if err != nil {
+		warn("could not open your web browser: %s", err)
+	}
+}
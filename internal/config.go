@@ -21,6 +21,7 @@ package internal
 // this file implements the config system used by the cmd package
 
 import (
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -45,43 +46,90 @@ const (
 
 // Config holds the configuration options for jobqueue server and client
 type Config struct {
-	ManagerPort          string `default:""`
-	ManagerWeb           string `default:""`
-	ManagerHost          string `default:"localhost"`
-	ManagerDir           string `default:"~/.wr"`
-	ManagerPidFile       string `default:"pid"`
-	ManagerLogFile       string `default:"log"`
-	ManagerDbFile        string `default:"db"`
-	ManagerDbBkFile      string `default:"db_bk"`
-	ManagerTokenFile     string `default:"client.token"`
-	ManagerUploadDir     string `default:"uploads"`
-	ManagerUmask         int    `default:"007"`
-	ManagerScheduler     string `default:"local"`
-	ManagerCAFile        string `default:"ca.pem"`
-	ManagerCertFile      string `default:"cert.pem"`
-	ManagerKeyFile       string `default:"key.pem"`
-	ManagerCertDomain    string `default:"localhost"`
-	ManagerSetDomainIP   bool   `default:"false"`
-	RunnerExecShell      string `default:"bash"`
-	Deployment           string `default:"production"`
-	CloudFlavor          string `default:""`
-	CloudFlavorManager   string `default:""`
-	CloudFlavorSets      string `default:""`
-	CloudKeepAlive       int    `default:"120"`
-	CloudServers         int    `default:"-1"`
-	CloudCIDR            string `default:"192.168.0.0/18"`
-	CloudGateway         string `default:"192.168.0.1"`
-	CloudDNS             string `default:"8.8.4.4,8.8.8.8"`
-	CloudOS              string `default:"bionic-server"`
-	ContainerImage       string `default:"ubuntu:latest"`
-	CloudUser            string `default:"ubuntu"`
-	CloudRAM             int    `default:"2048"`
-	CloudDisk            int    `default:"1"`
-	CloudScript          string `default:""`
-	CloudConfigFiles     string `default:"~/.s3cfg,~/.aws/credentials,~/.aws/config"`
-	CloudSpawns          int    `default:"10"`
-	CloudAutoConfirmDead int    `default:"30"`
-	DeploySuccessScript  string `default:""`
+	ManagerPort           string `default:""`
+	ManagerWeb            string `default:""`
+	ManagerHost           string `default:"localhost"`
+	ManagerDir            string `default:"~/.wr"`
+	ManagerPidFile        string `default:"pid"`
+	ManagerLogFile        string `default:"log"`
+	ManagerDbFile         string `default:"db"`
+	ManagerDbBkFile       string `default:"db_bk"`
+	ManagerTokenFile      string `default:"client.token"`
+	ManagerSecretsFile    string `default:"secrets"`
+	ManagerGrantsFile     string `default:"secret_grants"`
+	ManagerUploadDir      string `default:"uploads"`
+	ManagerCopyDir        string `default:"copies"`
+	ManagerTransferSlots  int    `default:"10"`
+	ManagerTransferRate   int    `default:"0"`
+	ManagerFailureArchive string `default:""`
+	ManagerSpoolFile      string `default:"spool"`
+	ManagerUmask          int    `default:"007"`
+	ManagerScheduler      string `default:"local"`
+	ManagerSchedRoutes    string `default:""`
+	ManagerBurst          string `default:""`
+	ManagerCAFile         string `default:"ca.pem"`
+	ManagerCertFile       string `default:"cert.pem"`
+	ManagerKeyFile        string `default:"key.pem"`
+	ManagerCertDomain     string `default:"localhost"`
+	ManagerSetDomainIP    bool   `default:"false"`
+	ManagerFailureRules   string `default:""`
+	ManagerRunAsUsers     string `default:""`
+	ManagerRedactRules    string `default:""`
+	ManagerNotifyRules    string `default:""`
+	ManagerWebOverlay     string `default:""`
+	ManagerOIDCIssuer     string `default:""`
+	ManagerOIDCClientID   string `default:""`
+	ManagerOIDCSecret     string `default:""`
+	ManagerOIDCRedirect   string `default:""`
+	ManagerOIDCUserClaim  string `default:"email"`
+	ManagerOIDCRoleClaim  string `default:"groups"`
+	ManagerOIDCViewers    string `default:""`
+	ManagerOIDCOperators  string `default:""`
+	ManagerOIDCAdmins     string `default:""`
+	ManagerLSFQueues      string `default:""`
+	ManagerLSFBjobsTTL    int    `default:"5"`
+	ManagerLSFBatch       int    `default:"0"`
+	ManagerHeartbeat      int    `default:"15"`
+	ManagerLostAfter      int    `default:"60"`
+	ManagerLostRequeue    int    `default:"0"`
+	ManagerRecycleWindow  int    `default:"60"`
+	ManagerRequestTimeout int    `default:"0"`
+	ManagerTraceEndpoint  string `default:""`
+	RunnerExecShell       string `default:"bash"`
+	RunnerOutageTolerance int    `default:"600"`
+	RunnerCleanupMinDepth int    `default:"3"`
+	RunnerNice            int    `default:"0"`
+	RunnerIONice          string `default:""`
+	RunnerOOMScoreAdj     int    `default:"0"`
+	RunnerUmask           string `default:""`
+	RunnerGroup           string `default:""`
+	RunnerIRODSTransfers  int    `default:"4"`
+	RunnerIRODSRetries    int    `default:"3"`
+	RunnerRefCacheDir     string `default:""`
+	RunnerRefCacheSize    int    `default:"0"`
+	RunnerScratchDir      string `default:""`
+	Deployment            string `default:"production"`
+	CloudFlavor           string `default:""`
+	CloudFlavorManager    string `default:""`
+	CloudFlavorSets       string `default:""`
+	CloudNetworkGroups    string `default:""`
+	CloudFlavorScripts    string `default:""`
+	CloudVolumeMount      string `default:"/mnt/wr_volume"`
+	CloudKeepAlive        int    `default:"120"`
+	CloudServers          int    `default:"-1"`
+	CloudCIDR             string `default:"192.168.0.0/18"`
+	CloudGateway          string `default:"192.168.0.1"`
+	CloudDNS              string `default:"8.8.4.4,8.8.8.8"`
+	CloudOS               string `default:"bionic-server"`
+	ContainerImage        string `default:"ubuntu:latest"`
+	CloudUser             string `default:"ubuntu"`
+	CloudRAM              int    `default:"2048"`
+	CloudDisk             int    `default:"1"`
+	CloudScript           string `default:""`
+	CloudConfigFiles      string `default:"~/.s3cfg,~/.aws/credentials,~/.aws/config"`
+	CloudSpawns           int    `default:"10"`
+	CloudAutoConfirmDead  int    `default:"30"`
+	DeploySuccessScript   string `default:""`
 }
 
 /*
@@ -197,9 +245,36 @@ func ConfigLoad(deployment string, useparentdir bool, logger log15.Logger) Confi
 	if !filepath.IsAbs(config.ManagerTokenFile) {
 		config.ManagerTokenFile = filepath.Join(config.ManagerDir, config.ManagerTokenFile)
 	}
+	if !filepath.IsAbs(config.ManagerSecretsFile) {
+		config.ManagerSecretsFile = filepath.Join(config.ManagerDir, config.ManagerSecretsFile)
+	}
+	if !filepath.IsAbs(config.ManagerGrantsFile) {
+		config.ManagerGrantsFile = filepath.Join(config.ManagerDir, config.ManagerGrantsFile)
+	}
 	if !filepath.IsAbs(config.ManagerUploadDir) {
 		config.ManagerUploadDir = filepath.Join(config.ManagerDir, config.ManagerUploadDir)
 	}
+	if !filepath.IsAbs(config.ManagerCopyDir) {
+		config.ManagerCopyDir = filepath.Join(config.ManagerDir, config.ManagerCopyDir)
+	}
+	if !filepath.IsAbs(config.ManagerSpoolFile) {
+		config.ManagerSpoolFile = filepath.Join(config.ManagerDir, config.ManagerSpoolFile)
+	}
+	if config.ManagerFailureArchive != "" && !IsRemote(config.ManagerFailureArchive) && !filepath.IsAbs(config.ManagerFailureArchive) {
+		config.ManagerFailureArchive = filepath.Join(config.ManagerDir, config.ManagerFailureArchive)
+	}
+	if config.ManagerFailureRules != "" && !filepath.IsAbs(config.ManagerFailureRules) {
+		config.ManagerFailureRules = filepath.Join(config.ManagerDir, config.ManagerFailureRules)
+	}
+	if config.ManagerRedactRules != "" && !filepath.IsAbs(config.ManagerRedactRules) {
+		config.ManagerRedactRules = filepath.Join(config.ManagerDir, config.ManagerRedactRules)
+	}
+	if config.ManagerNotifyRules != "" && !filepath.IsAbs(config.ManagerNotifyRules) {
+		config.ManagerNotifyRules = filepath.Join(config.ManagerDir, config.ManagerNotifyRules)
+	}
+	if config.ManagerWebOverlay != "" && !filepath.IsAbs(config.ManagerWebOverlay) {
+		config.ManagerWebOverlay = filepath.Join(config.ManagerDir, config.ManagerWebOverlay)
+	}
 
 	// if not explicitly set, calculate ports that no one else would be
 	// assigned by us (and hope no other software is using it...)
@@ -262,7 +337,7 @@ func DefaultConfig(logger log15.Logger) Config {
 // before we have a final config).
 func DefaultServer(logger log15.Logger) (server string) {
 	config := DefaultConfig(logger)
-	return config.ManagerHost + ":" + config.ManagerPort
+	return net.JoinHostPort(config.ManagerHost, config.ManagerPort)
 }
 
 // Calculate a port number that will be unique to this user, deployment and
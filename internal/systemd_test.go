@@ -0,0 +1,163 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// setEnv sets (or with an empty value, unsets) the given environment variable,
+// returning a function that restores its original value.
+func setEnv(key, value string) func() {
+	orig, existed := os.LookupEnv(key)
+	if value == "" {
+		os.Unsetenv(key)
+	} else {
+		os.Setenv(key, value)
+	}
+	return func() {
+		if existed {
+			os.Setenv(key, orig)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+// listenNotify listens on a unixgram socket with the given name, returning the
+// socket and a channel that receives the first message sent to it.
+func listenNotify(name string) (*net.UnixConn, chan string) {
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: name, Net: "unixgram"})
+	So(err, ShouldBeNil)
+	received := make(chan string, 1)
+	go func() {
+		buf := make([]byte, 1024)
+		n, errr := conn.Read(buf)
+		if errr != nil {
+			received <- errr.Error()
+			return
+		}
+		received <- string(buf[:n])
+	}()
+	return conn, received
+}
+
+// receive returns the message from the given channel, or an empty string if
+// one doesn't arrive soon.
+func receive(received chan string) string {
+	select {
+	case msg := <-received:
+		return msg
+	case <-time.After(5 * time.Second):
+		return ""
+	}
+}
+
+func TestSystemd(t *testing.T) {
+	Convey("SdNotify only notifies when started by systemd", t, func() {
+		defer setEnv("NOTIFY_SOCKET", "")()
+
+		notified, err := SdNotify("READY=1")
+		So(err, ShouldBeNil)
+		So(notified, ShouldBeFalse)
+
+		dir, err := ioutil.TempDir("", "wr_internal_test_systemd_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		Convey("It sends the state to a socket path", func() {
+			socket := filepath.Join(dir, "notify")
+			conn, received := listenNotify(socket)
+			defer conn.Close()
+			os.Setenv("NOTIFY_SOCKET", socket)
+
+			state := fmt.Sprintf("READY=1\nMAINPID=%d", os.Getpid())
+			notified, err = SdNotify(state)
+			So(err, ShouldBeNil)
+			So(notified, ShouldBeTrue)
+			So(receive(received), ShouldEqual, state)
+		})
+
+		Convey("It sends the state to an abstract socket", func() {
+			if runtime.GOOS != "linux" {
+				SkipConvey("abstract sockets are only supported on linux", func() {})
+				return
+			}
+			name := "wr_internal_test_systemd_" + strconv.Itoa(os.Getpid())
+			conn, received := listenNotify("\x00" + name)
+			defer conn.Close()
+			os.Setenv("NOTIFY_SOCKET", "@"+name)
+
+			notified, err = SdNotify("WATCHDOG=1")
+			So(err, ShouldBeNil)
+			So(notified, ShouldBeTrue)
+			So(receive(received), ShouldEqual, "WATCHDOG=1")
+		})
+
+		Convey("It fails if the socket doesn't exist", func() {
+			os.Setenv("NOTIFY_SOCKET", filepath.Join(dir, "missing"))
+			notified, err = SdNotify("READY=1")
+			So(err, ShouldNotBeNil)
+			So(notified, ShouldBeFalse)
+		})
+	})
+
+	Convey("SdWatchdogInterval returns the interval systemd wants for us", t, func() {
+		defer setEnv("WATCHDOG_USEC", "")()
+		defer setEnv("WATCHDOG_PID", "")()
+
+		ourPid := strconv.Itoa(os.Getpid())
+		tests := []struct {
+			usec     string
+			pid      string
+			interval time.Duration
+			fails    bool
+		}{
+			{"", "", 0, false},
+			{"", ourPid, 0, false},
+			{"5000000", "", 5 * time.Second, false},
+			{"5000000", ourPid, 5 * time.Second, false},
+			{"5000000", strconv.Itoa(os.Getpid() + 1), 0, false},
+			{"500", "", 500 * time.Microsecond, false},
+			{"5s", "", 0, true},
+			{"5000000", "me", 0, true},
+		}
+		for _, test := range tests {
+			setEnv("WATCHDOG_USEC", test.usec)
+			setEnv("WATCHDOG_PID", test.pid)
+			interval, err := SdWatchdogInterval()
+			if test.fails {
+				So(err, ShouldNotBeNil)
+			} else {
+				So(err, ShouldBeNil)
+			}
+			So(interval, ShouldEqual, test.interval)
+		}
+	})
+}
@@ -188,7 +188,7 @@ func TildaToHome(path string) string {
 	return path
 }
 
-// ProcMeminfoMBs uses gopsutil (amd64 freebsd, linux, windows, darwin, openbds
+// ProcMeminfoMBs uses gopsutil (freebsd, linux, windows, darwin and openbsd
 // only!) to find the total number of MBs of memory physically installed on the
 // current system.
 func ProcMeminfoMBs() (int, error) {
@@ -424,6 +424,11 @@ func CurrentIP(cidr string) (string, error) {
 	}
 
 	conn, err := net.Dial("udp", "8.8.8.8:80") // doesn't actually connect, dest doesn't need to exist
+	if err != nil {
+		// on an IPv6-only network there's no route to the above, so see what
+		// address we'd use to get to an IPv6 address instead
+		conn, err = net.Dial("udp", "[2001:4860:4860::8888]:80")
+	}
 	if err != nil {
 		// fall-back on the old method we had...
 
@@ -474,29 +479,32 @@ func CurrentIP(cidr string) (string, error) {
 }
 
 // currentIPFallback is an older fallback method for figuring out our IP
-// address by going through all our network interfaces.
+// address by going through all our network interfaces. IPv4 addresses are
+// preferred, but a global IPv6 address will be returned if that's all we have.
 func currentIPFallback(ipNet *net.IPNet) (string, error) {
 	var addrs []net.Addr
 	addrs, err := net.InterfaceAddrs()
 	if err != nil {
 		return "", err
 	}
-	var ip string
+	var ip, v6IP string
 	for _, address := range addrs {
 		if thisIPNet, ok := address.(*net.IPNet); ok && !thisIPNet.IP.IsLoopback() {
+			if ipNet != nil && !ipNet.Contains(thisIPNet.IP) {
+				continue
+			}
 			if thisIPNet.IP.To4() != nil {
-				if ipNet != nil {
-					if ipNet.Contains(thisIPNet.IP) {
-						ip = thisIPNet.IP.String()
-						break
-					}
-				} else {
-					ip = thisIPNet.IP.String()
-					break
-				}
+				ip = thisIPNet.IP.String()
+				break
+			}
+			if v6IP == "" && thisIPNet.IP.IsGlobalUnicast() {
+				v6IP = thisIPNet.IP.String()
 			}
 		}
 	}
+	if ip == "" {
+		ip = v6IP
+	}
 	return ip, nil
 }
 
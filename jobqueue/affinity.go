@@ -0,0 +1,106 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of Job Affinity, where we prefer to
+// give jobs to runners on hosts that recently ran jobs with the same Affinity.
+
+import (
+	"time"
+
+	sync "github.com/sasha-s/go-deadlock"
+)
+
+// affinityTracker remembers which hosts recently started jobs with which
+// Affinity.
+type affinityTracker struct {
+	hosts  map[string]map[string]time.Time // host -> affinity -> last start
+	expiry time.Duration
+	mutex  sync.Mutex
+}
+
+// newAffinityTracker creates an affinityTracker that forgets about jobs that
+// started longer than expiry ago.
+func newAffinityTracker(expiry time.Duration) *affinityTracker {
+	return &affinityTracker{
+		hosts:  make(map[string]map[string]time.Time),
+		expiry: expiry,
+	}
+}
+
+// started notes that a job with the given affinity started running on the
+// given host.
+func (at *affinityTracker) started(affinity, host string) {
+	if affinity == "" || host == "" {
+		return
+	}
+
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+	affinities, exists := at.hosts[host]
+	if !exists {
+		affinities = make(map[string]time.Time)
+		at.hosts[host] = affinities
+	}
+	affinities[affinity] = time.Now()
+}
+
+// warm returns the affinities of jobs that recently started running on the
+// given host, or nil if there were none.
+func (at *affinityTracker) warm(host string) map[string]bool {
+	if host == "" {
+		return nil
+	}
+
+	at.mutex.Lock()
+	defer at.mutex.Unlock()
+	affinities, exists := at.hosts[host]
+	if !exists {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-at.expiry)
+	var warm map[string]bool
+	for affinity, started := range affinities {
+		if started.Before(cutoff) {
+			delete(affinities, affinity)
+			continue
+		}
+		if warm == nil {
+			warm = make(map[string]bool)
+		}
+		warm[affinity] = true
+	}
+	if len(affinities) == 0 {
+		delete(at.hosts, host)
+	}
+	return warm
+}
+
+// preferWarm returns a function suitable for passing to
+// queue.ReservePreferring() that prefers jobs with one of the given
+// affinities.
+func preferWarm(warm map[string]bool) func(data interface{}) bool {
+	return func(data interface{}) bool {
+		// Affinity is never changed once a job has been added, so we don't
+		// need to (and must not, since the queue is locked) lock the job
+		job := data.(*Job)
+		return job.Affinity != "" && warm[job.Affinity]
+	}
+}
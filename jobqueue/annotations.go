@@ -0,0 +1,133 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of annotations: freeform notes that
+// users attach to jobs or RepGroups, so that the operators of a shared
+// deployment can leave context for each other, such as why something was
+// retried.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// maxAnnotationLength is the longest Note an Annotation can have.
+const maxAnnotationLength = 4096
+
+// annotationTarget* prefix the database keys of Annotations, so that notes on
+// a job and on a RepGroup with the same name as its key are kept apart.
+const (
+	annotationTargetJob      = "k"
+	annotationTargetRepGroup = "r"
+)
+
+// Annotation is a note that a User left on a job (with Key) or on a RepGroup
+// at a particular Time.
+type Annotation struct {
+	Key      string    `json:"key,omitempty"`
+	RepGroup string    `json:"rep_grp,omitempty"`
+	Note     string    `json:"note"`
+	User     string    `json:"user,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// Validate checks that the annotation has a note, and is for exactly one job or
+// RepGroup.
+func (a *Annotation) Validate() error {
+	switch {
+	case a.Note == "":
+		return fmt.Errorf("an annotation needs a note")
+	case len(a.Note) > maxAnnotationLength:
+		return fmt.Errorf("annotation notes can't be longer than %d characters", maxAnnotationLength)
+	case a.Key == "" && a.RepGroup == "":
+		return fmt.Errorf("an annotation needs a job key or a report group")
+	case a.Key != "" && a.RepGroup != "":
+		return fmt.Errorf("an annotation is for a job key or a report group, not both")
+	}
+	return nil
+}
+
+// annotate stores the given annotation on behalf of the given user, recording
+// an EventTypeAnnotate event. Notes on jobs require the job to exist (though it
+// may be complete).
+func (s *Server) annotate(annotation *Annotation, user string) (*Annotation, error) {
+	if err := annotation.Validate(); err != nil {
+		return nil, err
+	}
+
+	a := *annotation
+	a.User = user
+	a.Time = time.Now()
+
+	rg := a.RepGroup
+	if a.Key != "" {
+		jobs, _, errstr := s.getJobsByKeys(context.Background(), []string{a.Key}, false, false)
+		if errstr != "" || len(jobs) != 1 {
+			return nil, fmt.Errorf("job %s not found", a.Key)
+		}
+		rg = jobs[0].RepGroup
+	}
+
+	if err := s.db.storeAnnotation(&a); err != nil {
+		return nil, err
+	}
+
+	s.recordEvent(&Event{Type: EventTypeAnnotate, Key: a.Key, RepGroup: rg, User: user, Msg: a.Note})
+	return &a, nil
+}
+
+// annotations returns the notes left on any of the jobs with the given keys and
+// on any of the given RepGroups, oldest first.
+func (s *Server) annotations(keys, repGroups []string) ([]*Annotation, error) {
+	var all []*Annotation
+	for _, key := range keys {
+		as, err := s.db.retrieveAnnotations(annotationTargetJob, key)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, as...)
+	}
+
+	for _, rg := range repGroups {
+		as, err := s.db.retrieveAnnotations(annotationTargetRepGroup, rg)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, as...)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Time.Before(all[j].Time)
+	})
+	return all, nil
+}
+
+// jobAnnotations returns the notes left on the given job and on its RepGroup,
+// for the web interface. Problems are logged, and nil returned.
+func (s *Server) jobAnnotations(key, repGroup string) []*Annotation {
+	notes, err := s.annotations([]string{key}, []string{repGroup})
+	if err != nil {
+		s.Warn("failed to retrieve annotations", "key", key, "err", err)
+		return nil
+	}
+	return notes
+}
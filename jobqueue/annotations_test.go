@@ -0,0 +1,41 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAnnotations(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Annotations can be validated", t, func() {
+		So((&Annotation{Key: "k"}).Validate(), ShouldNotBeNil)
+		So((&Annotation{Note: "n"}).Validate(), ShouldNotBeNil)
+		So((&Annotation{Key: "k", RepGroup: "rg", Note: "n"}).Validate(), ShouldNotBeNil)
+		So((&Annotation{Key: "k", Note: strings.Repeat("n", maxAnnotationLength+1)}).Validate(), ShouldNotBeNil)
+		So((&Annotation{Key: "k", Note: "n"}).Validate(), ShouldBeNil)
+		So((&Annotation{RepGroup: "rg", Note: "n"}).Validate(), ShouldBeNil)
+	})
+}
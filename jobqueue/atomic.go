@@ -0,0 +1,184 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of atomic groups, whose member jobs
+// succeed or fail together.
+
+import (
+	"github.com/VertebrateResequencing/wr/queue"
+)
+
+// atomicGroupDepGroupPrefix is prefixed to AtomicGroups to name the queue
+// dependency groups that their members join, which the dependants of those
+// members depend upon.
+const atomicGroupDepGroupPrefix = "atomic_grp:"
+
+// atomicGroupDepGroup returns the name of the queue dependency group that jobs
+// with the given AtomicGroup join.
+func atomicGroupDepGroup(group string) string {
+	return atomicGroupDepGroupPrefix + group
+}
+
+// addAtomicGroupDependencies makes the given items also depend on the atomic
+// groups of the jobs they depend on, so that they don't start until every
+// member of those groups has completed. The jobs depended upon are looked for
+// amongst the given items as well as in the queue.
+func (s *Server) addAtomicGroupDependencies(itemdefs []*queue.ItemDef) {
+	batch := make(map[string]*Job, len(itemdefs))
+	for _, def := range itemdefs {
+		batch[def.Key] = def.Data.(*Job)
+	}
+
+	for _, def := range itemdefs {
+		own := def.Data.(*Job).AtomicGroup
+		seen := make(map[string]bool)
+		for _, key := range def.Dependencies {
+			group := s.atomicGroupOf(key, batch)
+			if group == "" || group == own || seen[group] {
+				continue
+			}
+			seen[group] = true
+			def.GroupDependencies = append(def.GroupDependencies, atomicGroupDepGroup(group))
+		}
+	}
+}
+
+// withAtomicGroupMembers returns the given dependency keys of the given job
+// plus the keys of the other members currently in the queue of the atomic
+// groups of the jobs they refer to, for use when updating the dependencies of
+// jobs already in the queue.
+func (s *Server) withAtomicGroupMembers(job *Job, deps []string) []string {
+	seen := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		seen[dep] = true
+	}
+
+	merged := deps
+	for _, dep := range deps {
+		group := s.atomicGroupOf(dep, nil)
+		if group == "" || group == job.AtomicGroup {
+			continue
+		}
+		for _, key := range s.q.DepGroupMembers(atomicGroupDepGroup(group)) {
+			if !seen[key] {
+				seen[key] = true
+				merged = append(merged, key)
+			}
+		}
+	}
+	return merged
+}
+
+// atomicGroupOf returns the AtomicGroup of the job with the given key, which is
+// looked for in the given batch of jobs before the queue. Returns an empty
+// string if the job isn't found.
+func (s *Server) atomicGroupOf(key string, batch map[string]*Job) string {
+	job, found := batch[key]
+	if !found {
+		item, err := s.q.Get(key)
+		if err != nil {
+			return ""
+		}
+		job = item.Data().(*Job)
+	}
+	return job.AtomicGroup
+}
+
+// failAtomicGroup should be called after the given job has been buried. If it
+// is a member of an atomic group, the other members are cancelled: those
+// waiting to run (including those still waiting on dependencies) are buried
+// and those running are killed (to then be buried when their runner notices),
+// all with FailReasonAtomic. Members that already completed are marked
+// AtomicGroupFailed.
+func (s *Server) failAtomicGroup(failed *Job) {
+	failed.RLock()
+	group := failed.AtomicGroup
+	failed.RUnlock()
+	if group == "" {
+		return
+	}
+	failedKey := failed.Key()
+
+	var cancelled int
+	for _, key := range s.q.DepGroupMembers(atomicGroupDepGroup(group)) {
+		if key == failedKey {
+			continue
+		}
+		item, err := s.q.Get(key)
+		if err != nil {
+			continue
+		}
+		job := item.Data().(*Job)
+
+		switch item.Stats().State {
+		case queue.ItemStateRun:
+			job.Lock()
+			job.atomicCancelled = true
+			job.Unlock()
+			if _, err = s.killJob(key); err != nil {
+				s.Warn("failed to kill a member of a failed atomic group", "group", group, "cmd", job.Cmd, "err", err)
+				continue
+			}
+		case queue.ItemStateDelay, queue.ItemStateReady, queue.ItemStateDependent:
+			if err = s.buryWaitingJob(job, FailReasonAtomic); err != nil {
+				s.Warn("failed to bury a member of a failed atomic group", "group", group, "cmd", job.Cmd, "err", err)
+				continue
+			}
+			s.recordBuryEvent(job, FailReasonAtomic)
+		default:
+			continue
+		}
+		cancelled++
+	}
+
+	invalidated, err := s.db.invalidateAtomicGroup(group)
+	if err != nil {
+		s.Error("failed to mark complete members of a failed atomic group", "group", group, "err", err)
+	}
+
+	s.Debug("atomic group failed", "group", group, "cancelled", cancelled, "invalidated", len(invalidated))
+}
+
+// buryWaitingJob buries the given delayed, ready or dependent job for the given
+// reason.
+func (s *Server) buryWaitingJob(job *Job, failReason string) error {
+	job.RLock()
+	wasReady := job.State == JobStateReady
+	sgroup := job.schedulerGroup
+	job.RUnlock()
+
+	err := s.q.BuryWaiting(job.Key())
+	if err != nil {
+		return err
+	}
+
+	job.Lock()
+	job.State = JobStateBuried
+	job.FailReason = failReason
+	job.UntilBuried = 0
+	job.Unlock()
+	s.db.updateJobAfterChange(job)
+	s.Debug("buried job", "cmd", job.Cmd)
+
+	if wasReady {
+		s.decrementGroupCount(sgroup)
+	}
+	return nil
+}
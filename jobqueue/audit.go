@@ -0,0 +1,148 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the recording of who asked for jobs to be retried,
+// removed, restored, killed or buried, or for the server to be paused or
+// resumed, so that in shared deployments users can see if a colleague already
+// intervened.
+
+import (
+	"fmt"
+	"time"
+)
+
+// userREST and userWebToken are the users we record for actions taken via the
+// REST API, and via the status webpage by someone who logged in with the
+// token instead of with OIDC, since we can't tell who they are.
+const (
+	userREST     = "REST API"
+	userWebToken = "web interface"
+)
+
+// claimedUserSuffix is appended to the usernames that command line clients
+// tell us, since we have no way of verifying them.
+const claimedUserSuffix = " (unverified)"
+
+// userActionTimeFormat is how the time of UserActions is shown, preceded by
+// the date in userActionDayFormat if it wasn't today.
+const (
+	userActionTimeFormat = "15:04"
+	userActionDayFormat  = "2006-01-02"
+)
+
+// UserAction describes something a user asked to happen to a Job.
+type UserAction struct {
+	// Action is one of EventTypeRetry, EventTypeRemove, EventTypeRestore,
+	// EventTypeKill or EventTypeBury.
+	Action EventType
+
+	// User is who asked for the action: the local username their command line
+	// client claimed, marked as unverified; the name they logged in to the web
+	// interface with, followed by the subject their OpenID Connect provider
+	// verified; or userREST or userWebToken.
+	User string
+
+	Time time.Time
+}
+
+// String returns a description of the action like "retried by alice at 14:02".
+// The time includes the date if it wasn't today.
+func (ua *UserAction) String() string {
+	var did string
+	switch ua.Action {
+	case EventTypeRetry:
+		did = "retried"
+	case EventTypeRemove:
+		did = "removed"
+	case EventTypeRestore:
+		did = "restored"
+	case EventTypeKill:
+		did = "killed"
+	case EventTypeBury:
+		did = "buried"
+	default:
+		did = string(ua.Action)
+	}
+
+	by := ""
+	if ua.User != "" {
+		by = " by " + ua.User
+	}
+
+	format := userActionTimeFormat
+	if ua.Time.Format(userActionDayFormat) != time.Now().Format(userActionDayFormat) {
+		format = userActionDayFormat + " " + userActionTimeFormat
+	}
+	return fmt.Sprintf("%s%s at %s", did, by, ua.Time.Format(format))
+}
+
+// claimedUser returns the given username that a command line client told us,
+// marked as unverified. Returns blank if user is blank.
+func claimedUser(user string) string {
+	if user == "" {
+		return ""
+	}
+	return user + claimedUserSuffix
+}
+
+// recordUserAction notes on each of the given jobs that the given user asked
+// for the given action to be carried out on them, and records an event about
+// it. Does nothing if there are no jobs.
+func (s *Server) recordUserAction(action EventType, user string, jobs []*Job) {
+	if len(jobs) == 0 {
+		return
+	}
+
+	ua := &UserAction{Action: action, User: user, Time: time.Now()}
+	for _, job := range jobs {
+		job.Lock()
+		job.LastAction = ua
+		job.Unlock()
+	}
+
+	event := &Event{Type: action, RepGroup: commonRepGroup(jobs), Count: len(jobs), User: user}
+	if action == EventTypeBury {
+		event.Msg = FailReasonBuried
+	}
+	if len(jobs) == 1 {
+		event.Key = jobs[0].Key()
+	}
+	s.recordEvent(event)
+}
+
+// recordServerAction records an event about the given user asking for the
+// given action, EventTypePause or EventTypeResume, to be carried out on the
+// server.
+func (s *Server) recordServerAction(action EventType, user string) {
+	s.recordEvent(&Event{Type: action, User: user})
+}
+
+// jobsWithKeys returns the jobs in the queue that have the given keys.
+func (s *Server) jobsWithKeys(keys []string) []*Job {
+	jobs := make([]*Job, 0, len(keys))
+	for _, key := range keys {
+		item, err := s.q.Get(key)
+		if err != nil || item == nil {
+			continue
+		}
+		jobs = append(jobs, item.Data().(*Job))
+	}
+	return jobs
+}
@@ -26,11 +26,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
 )
 
 // BehaviourTrigger is supplied to a Behaviour to define under what circumstance
@@ -80,7 +84,8 @@ const (
 	// CopyToManager is a BehaviourAction that copies the given files (specified
 	// as a slice of string paths Arg to the Behaviour) from the Job's actual
 	// cwd to a configured location on the machine that the jobqueue server is
-	// running on. *** not yet implemented!
+	// running on. The server limits how many jobs can copy at once, so this
+	// may wait for its turn. Can only be triggered by Client.Execute().
 	CopyToManager
 
 	// Nothing is a BehaviourAction that does nothing. It allows you to define
@@ -88,36 +93,292 @@ const (
 	// for situations where you want to store a desire to change another
 	// Behaviour to turn it off.
 	Nothing
+
+	// CleanupDryRun is a BehaviourAction that deletes nothing, but logs what
+	// CleanupAll would have deleted (using the logger of the Client that
+	// Execute()d the Job), after carrying out the same safety checks. It takes
+	// no arguments.
+	CleanupDryRun
+
+	// Resubmit is a BehaviourAction that, if the Job's Cmd failed, has the
+	// server re-enqueue the Job with a fresh set of retries (even if it would
+	// otherwise have been buried), after modifying it as described by the
+	// *ResubmitOptions Arg. Use Conditions to avoid resubmitting forever.
+	Resubmit
+
+	// AddJobs is a BehaviourAction that adds new Jobs to the queue, dependent
+	// on the Job that triggered it completing. The string Arg is either a
+	// JSON array of JobViaJSON objects, or the path (relative to the Job's
+	// actual cwd) of a file containing one, which would typically be written
+	// by the Job's Cmd; if that file doesn't exist, no Jobs are added. The
+	// RepGroup and Cwd of the new Jobs default to those of the triggering Job.
+	// This allows for simple dynamic workflows, where one step determines the
+	// cmds of the next.
+	AddJobs
 )
 
+// BehaviourCleanupMinDepth is the minimum number of directories deep that the
+// unique working directory deleted by the cleanup Behaviours must be, as a
+// guard against wiping out something like a home directory due to a bug or bad
+// Cwd.
+var BehaviourCleanupMinDepth = 3
+
+// BehaviourRunTimeout is how long Run Behaviours that don't specify their own
+// Timeout are allowed to run for before they are killed.
+var BehaviourRunTimeout = 1 * time.Hour
+
 // Behaviour describes something that should happen in response to a Job's Cmd
 // exiting a certain way.
 type Behaviour struct {
 	When BehaviourTrigger
 	Do   BehaviourAction
 	Arg  interface{} // the arg needed by your chosen action
+
+	// Timeout is how long a Run action is allowed to run for before it is
+	// killed; if 0, BehaviourRunTimeout applies.
+	Timeout time.Duration
+
+	// Conditions optionally restrict the Behaviour to only trigger when the
+	// Cmd exited in a particular way, in addition to When.
+	Conditions *BehaviourConditions
+}
+
+// BehaviourConditions restrict a Behaviour to only trigger when a Job's Cmd
+// exited in a particular way. All the conditions that are set must be met.
+type BehaviourConditions struct {
+	// ExitCodes, if not empty, are the exit codes the Cmd must have exited
+	// with one of.
+	ExitCodes []int
+
+	// FailReason, if not blank, is a regular expression that the reason the
+	// Cmd failed (one of the FailReason* constants, eg. FailReasonRAM) must
+	// match. Successful Cmds have a blank reason.
+	FailReason string
+
+	// Attempt, if not 0, is the attempt number (1 for the first run of the
+	// Cmd) the Cmd must have been on.
+	Attempt int
+
+	// FinalAttempt, if true, means the Cmd must have been on its final
+	// attempt: it will not be retried if it failed.
+	FinalAttempt bool
+}
+
+// behaviourOutcome describes how a Job's Cmd exited, for checking
+// BehaviourConditions against.
+type behaviourOutcome struct {
+	exitcode   int
+	failReason string
+	attempt    int
+	final      bool
+}
+
+// validate checks that the conditions are usable, ie. that FailReason is a
+// valid regular expression. It's ok to call this on nil.
+func (bc *BehaviourConditions) validate() error {
+	if bc == nil || bc.FailReason == "" {
+		return nil
+	}
+	if _, err := regexp.Compile(bc.FailReason); err != nil {
+		return fmt.Errorf("behaviour fail_reason condition [%s] is invalid: %w", bc.FailReason, err)
+	}
+	return nil
+}
+
+// met tells you if the given outcome meets all the conditions. nil conditions
+// are always met, while non-nil conditions are never met by a nil outcome.
+func (bc *BehaviourConditions) met(o *behaviourOutcome) (bool, error) {
+	if bc == nil {
+		return true, nil
+	}
+	if o == nil {
+		return false, nil
+	}
+
+	if len(bc.ExitCodes) > 0 {
+		found := false
+		for _, code := range bc.ExitCodes {
+			if code == o.exitcode {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+
+	if bc.FailReason != "" {
+		re, err := regexp.Compile(bc.FailReason)
+		if err != nil {
+			return false, bc.validate()
+		}
+		if !re.MatchString(o.failReason) {
+			return false, nil
+		}
+	}
+
+	if bc.Attempt != 0 && bc.Attempt != o.attempt {
+		return false, nil
+	}
+
+	return !bc.FinalAttempt || o.final, nil
+}
+
+// BehaviourResult records what happened when one of a Job's Behaviours was
+// triggered.
+type BehaviourResult struct {
+	// Behaviour is the String() of the Behaviour that was triggered.
+	Behaviour string
+
+	// Output is the combined STDOUT and STDERR of a Run Behaviour (only the
+	// start and end of it, if it was long).
+	Output string
+
+	// Error is the problem the Behaviour had, if any.
+	Error string
+
+	// Duration is how long the Behaviour took.
+	Duration time.Duration
 }
 
 // Trigger will carry out our BehaviourAction if the supplied status matches our
 // BehaviourTrigger.
 func (b *Behaviour) Trigger(status BehaviourTrigger, j *Job) error {
+	_, err := b.trigger(status, j)
+	return err
+}
+
+// trigger is like Trigger(), but also returns the output of Run actions.
+func (b *Behaviour) trigger(status BehaviourTrigger, j *Job) ([]byte, error) {
 	if b.When&status == 0 {
-		return nil
+		return nil, nil
+	}
+	if met, err := b.conditionsMet(j); !met {
+		return nil, err
 	}
 
 	switch b.Do {
 	case CleanupAll:
-		return b.cleanup(j, true)
+		return nil, b.cleanup(j, true)
 	case Cleanup:
-		return b.cleanup(j, false)
+		return nil, b.cleanup(j, false)
+	case CleanupDryRun:
+		return nil, b.cleanupDryRun(j)
 	case Run:
 		return b.run(j)
 	case CopyToManager:
-		return b.copyToManager(j)
+		return nil, b.copyToManager(j)
+	case Resubmit:
+		return nil, b.resubmit(j)
+	case AddJobs:
+		return b.addJobs(j)
 	case Nothing:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("invalid status %d", status)
+}
+
+// triggerAndRecord is like Trigger(), but if we actually did something, also
+// appends a BehaviourResult to the Job's BehaviourResults.
+func (b *Behaviour) triggerAndRecord(status BehaviourTrigger, j *Job) error {
+	if b.When&status == 0 || b.Do == Nothing {
 		return nil
 	}
-	return fmt.Errorf("invalid status %d", status)
+	if met, err := b.conditionsMet(j); !met {
+		return err
+	}
+
+	start := time.Now()
+	out, err := b.trigger(status, j)
+	result := BehaviourResult{
+		Behaviour: b.String(),
+		Output:    string(out),
+		Duration:  time.Since(start),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	j.Lock()
+	j.BehaviourResults = append(j.BehaviourResults, result)
+	j.Unlock()
+	return err
+}
+
+// validate checks that we have a valid When and Do, an Arg of the right type
+// for our Do, and usable Conditions. Errors are phrased to follow a description
+// of the Behaviour.
+func (b *Behaviour) validate() error {
+	switch b.When {
+	case OnFailure, OnSuccess, OnFailure | OnSuccess, OnExit:
+	default:
+		return fmt.Errorf("has an invalid trigger (%d)", b.When)
+	}
+
+	var err error
+	switch b.Do {
+	case CleanupAll, Cleanup, CleanupDryRun, Nothing:
+	case Run:
+		if cmd, wasStr := b.Arg.(string); !wasStr || cmd == "" {
+			err = fmt.Errorf("needs a command string to run, not %T %v", b.Arg, b.Arg)
+		}
+	case CopyToManager:
+		if _, wasStrSlice := stringSliceArg(b.Arg); !wasStrSlice {
+			err = fmt.Errorf("needs a list of files to copy, not %T %v", b.Arg, b.Arg)
+		}
+	case Resubmit:
+		_, err = resubmitOptions(b.Arg)
+	case AddJobs:
+		_, err = addJobsArg(b.Arg)
+	default:
+		return fmt.Errorf("has an invalid action (%d)", b.Do)
+	}
+	if err != nil {
+		return fmt.Errorf("(%s) %w", b.String(), err)
+	}
+
+	if b.Timeout < 0 {
+		return fmt.Errorf("(%s) has a negative timeout", b.String())
+	}
+
+	if err = b.Conditions.validate(); err != nil {
+		return fmt.Errorf("(%s) %w", b.String(), err)
+	}
+	return nil
+}
+
+// stringSliceArg returns an Arg as a []string, handling the []interface{} it
+// will have become if its Job was sent over the network or stored in the
+// database. The bool is false if the Arg wasn't a slice of strings.
+func stringSliceArg(arg interface{}) ([]string, bool) {
+	switch a := arg.(type) {
+	case []string:
+		return a, true
+	case []interface{}:
+		strs := make([]string, len(a))
+		for i, val := range a {
+			str, wasStr := val.(string)
+			if !wasStr {
+				return nil, false
+			}
+			strs[i] = str
+		}
+		return strs, true
+	}
+	return nil, false
+}
+
+// conditionsMet tells you if our Conditions are met by the way the Job's Cmd
+// exited, as noted by Execute().
+func (b *Behaviour) conditionsMet(j *Job) (bool, error) {
+	if b.Conditions == nil {
+		return true, nil
+	}
+	j.RLock()
+	outcome := j.execOutcome
+	j.RUnlock()
+	return b.Conditions.met(outcome)
 }
 
 // fillBVJM converts to a bvjMapping. Supply an empty or existing one and this
@@ -135,7 +396,7 @@ func (b *Behaviour) fillBVJM(bvjm *bvjMapping) {
 		bvj = BehaviourViaJSON{Run: arg}
 	case CopyToManager:
 		var arg []string
-		if files, wasStrSlice := b.Arg.([]string); wasStrSlice {
+		if files, wasStrSlice := stringSliceArg(b.Arg); wasStrSlice {
 			arg = files
 		} else {
 			arg = []string{"!invalid!"}
@@ -145,12 +406,40 @@ func (b *Behaviour) fillBVJM(bvjm *bvjMapping) {
 		bvj = BehaviourViaJSON{Cleanup: true}
 	case CleanupAll:
 		bvj = BehaviourViaJSON{CleanupAll: true}
+	case CleanupDryRun:
+		bvj = BehaviourViaJSON{CleanupDryRun: true}
+	case Resubmit:
+		arg, err := resubmitOptions(b.Arg)
+		if err != nil {
+			arg = &ResubmitOptions{RepGroup: "!invalid!"}
+		}
+		bvj = BehaviourViaJSON{Resubmit: arg}
+	case AddJobs:
+		var arg json.RawMessage
+		jobs, wasStr := b.Arg.(string)
+		switch {
+		case wasStr && addJobsIsJSON(jobs) && json.Valid([]byte(jobs)):
+			arg = json.RawMessage(jobs)
+		case wasStr && !addJobsIsJSON(jobs):
+			arg, _ = json.Marshal(jobs)
+		default:
+			arg = json.RawMessage(`"!invalid!"`)
+		}
+		bvj = BehaviourViaJSON{AddJobs: arg}
 	case Nothing:
 		bvj = BehaviourViaJSON{Nothing: true}
 	default:
 		return
 	}
 
+	bvj.Timeout = int(b.Timeout.Seconds())
+	if bc := b.Conditions; bc != nil {
+		bvj.ExitCodes = bc.ExitCodes
+		bvj.FailReason = bc.FailReason
+		bvj.Attempt = bc.Attempt
+		bvj.FinalAttempt = bc.FinalAttempt
+	}
+
 	switch b.When {
 	case OnFailure:
 		bvjm.OnFailure = append(bvjm.OnFailure, bvj)
@@ -193,7 +482,7 @@ func (b *Behaviour) cleanup(j *Job, all bool) error {
 		// *** not yet implemented, we just wipe everything!
 	}
 
-	if j.ActualCwd == "" {
+	if j.ActualCwd == "" || j.CwdMatters {
 		// must be a CwdMatters job, or somehow ActualCwd didn't get set; we do
 		// nothing in this case
 		return nil
@@ -203,6 +492,9 @@ func (b *Behaviour) cleanup(j *Job, all bool) error {
 	// that should be deleted; it contains tmp, cwd and possibly mount cache
 	// dirs (that we don't want to delete).
 	workSpace := filepath.Dir(j.ActualCwd)
+	if err := j.cleanupSafe(workSpace); err != nil {
+		return err
+	}
 
 	if len(j.MountConfigs) > 0 {
 		// if we have mounts, we don't want to delete the cache dirs or any
@@ -256,12 +548,89 @@ func (b *Behaviour) cleanup(j *Job, all bool) error {
 		}
 	}
 
-	// delete any empty parent directories up to Cwd
-	return rmEmptyDirs(workSpace, j.Cwd)
+	// delete any empty parent directories up to where it was created, and any
+	// link to it from Cwd
+	err := rmEmptyDirs(workSpace, j.cwdBase())
+	if err != nil {
+		return err
+	}
+	return j.unlinkActualCwd()
 }
 
-// run simply runs the given command from Job's actual cwd.
-func (b *Behaviour) run(j *Job) error {
+// cleanupSafe checks that the given unique working directory of the Job is
+// safe for a cleanup Behaviour to delete: it must be at least
+// BehaviourCleanupMinDepth directories deep, be within the Job's cwdBase(), and
+// contain the marker file that mkActualCwd() created for this Job.
+func (j *Job) cleanupSafe(workSpace string) error {
+	workSpace = filepath.Clean(workSpace)
+	if !filepath.IsAbs(workSpace) {
+		return fmt.Errorf("cleanup refused: [%s] is not an absolute path", workSpace)
+	}
+
+	depth := 0
+	if workSpace != "/" {
+		depth = len(strings.Split(strings.TrimPrefix(workSpace, "/"), "/"))
+	}
+	if depth < BehaviourCleanupMinDepth {
+		return fmt.Errorf("cleanup refused: [%s] is fewer than %d directories deep", workSpace, BehaviourCleanupMinDepth)
+	}
+
+	rel, err := filepath.Rel(filepath.Clean(j.cwdBase()), workSpace)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return fmt.Errorf("cleanup refused: [%s] is not within [%s]", workSpace, j.cwdBase())
+	}
+
+	marker, err := ioutil.ReadFile(filepath.Join(workSpace, cwdMarkerFile))
+	if err != nil || string(marker) != j.Key() {
+		return fmt.Errorf("cleanup refused: [%s] was not created for this job", workSpace)
+	}
+	return nil
+}
+
+// cleanupDryRun logs what cleanup(j, true) would delete, without deleting
+// anything.
+func (b *Behaviour) cleanupDryRun(j *Job) error {
+	if j.ActualCwd == "" || j.CwdMatters {
+		return nil
+	}
+
+	workSpace := filepath.Dir(j.ActualCwd)
+	if err := j.cleanupSafe(workSpace); err != nil {
+		return err
+	}
+
+	j.RLock()
+	logger := j.execLogger
+	j.RUnlock()
+	if logger == nil {
+		logger = log15.New()
+	}
+
+	var files int
+	var size int64
+	err := filepath.Walk(workSpace, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			files++
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info("cleanup dry run would delete", "dir", workSpace, "files", files, "bytes", size)
+	if link := j.cwdLinkPath(); link != "" {
+		logger.Info("cleanup dry run would delete", "link", link)
+	}
+	return nil
+}
+
+// run simply runs the given command from Job's actual cwd, killing it if it
+// takes longer than our Timeout, and returns its combined STDOUT and STDERR.
+func (b *Behaviour) run(j *Job) ([]byte, error) {
 	actualCwd := j.ActualCwd
 	if actualCwd == "" {
 		actualCwd = j.Cwd
@@ -269,35 +638,182 @@ func (b *Behaviour) run(j *Job) error {
 
 	bc, wasStr := b.Arg.(string)
 	if !wasStr {
-		return fmt.Errorf("arg %s is type %T, not string", b.Arg, b.Arg)
+		return nil, fmt.Errorf("arg %s is type %T, not string", b.Arg, b.Arg)
 	}
-	if strings.Contains(bc, " | ") {
-		bc = "set -o pipefail; " + bc
+	// we use the same shell that client.Execute() ran the Cmd with. And yes,
+	// we're allowing user to run absolutely any command they like, but that is
+	// the very nature of this app. This runs as them, so can do whatever they
+	// can do...
+	j.RLock()
+	shell := j.execShell
+	j.RUnlock()
+	if shell == "" {
+		shell = defaultBehaviourShell
+	}
+	cmd, err := shellCommand(shell, bc, "")
+	if err != nil {
+		return nil, fmt.Errorf("run behaviour could not be run: %w", err)
 	}
-	// *** hardcoding bash here, when we could in theory have client.Execute()
-	// pass shell in? And yes, we're allowing user to run absolutely any command
-	// they like, but that is the very nature of this app. This runs as them,
-	// so can do whatever they can do...
-	cmd := exec.Command("/bin/bash", "-c", bc) // #nosec
 	cmd.Dir = actualCwd
-	out, err := cmd.CombinedOutput()
+	out := &prefixSuffixSaver{N: 4096}
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	// we run the command in its own process group so that on timeout we can
+	// kill everything it started, which might otherwise keep our pipes open
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	timeout := b.Timeout
+	if timeout <= 0 {
+		timeout = BehaviourRunTimeout
+	}
+
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("run behaviour could not be started: %w", err)
+	}
+	timer := time.AfterFunc(timeout, func() {
+		errk := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		if errk != nil {
+			errk = cmd.Process.Kill()
+		}
+		if errk != nil {
+			j.RLock()
+			logger := j.execLogger
+			j.RUnlock()
+			if logger != nil {
+				logger.Warn("failed to kill run behaviour after timeout", "err", errk)
+			}
+		}
+	})
+	err = cmd.Wait()
+	if !timer.Stop() {
+		return out.Bytes(), fmt.Errorf("run behaviour took longer than %s and was killed\n%s", timeout, out.Bytes())
+	}
 	if err != nil {
-		return fmt.Errorf("run behaviour failed: %s\n%s", err, string(out))
+		return out.Bytes(), fmt.Errorf("run behaviour failed: %s\n%s", err, out.Bytes())
 	}
-	return err
+	return out.Bytes(), nil
 }
 
 // copyToManager copies the files specified in the Arg slice to the configured
 // location on the manager's machine.
 func (b *Behaviour) copyToManager(j *Job) error {
-	_, wasStrSlice := b.Arg.([]string)
+	paths, wasStrSlice := stringSliceArg(b.Arg)
 	if !wasStrSlice {
 		return fmt.Errorf("arg %s is type %T, not []string", b.Arg, b.Arg)
 	}
 
-	// *** not yet implemented
+	j.RLock()
+	client := j.execClient
+	actualCwd := j.ActualCwd
+	if actualCwd == "" {
+		actualCwd = j.Cwd
+	}
+	j.RUnlock()
+	if client == nil {
+		return fmt.Errorf("copy_to_manager behaviour can only be triggered by Execute()")
+	}
 
-	return nil
+	_, err := client.CopyToManager(j, actualCwd, paths)
+	return err
+}
+
+// addJobsArg checks the Arg of an AddJobs Behaviour, returning it as a string.
+// If it's a JSON array, the jobs it describes are checked as well.
+func addJobsArg(arg interface{}) (string, error) {
+	jobs, wasStr := arg.(string)
+	if !wasStr || strings.TrimSpace(jobs) == "" {
+		return "", fmt.Errorf("arg %v is type %T, not a non-empty string", arg, arg)
+	}
+	if addJobsIsJSON(jobs) {
+		if _, err := parseAddJobs([]byte(jobs)); err != nil {
+			return "", err
+		}
+	}
+	return jobs, nil
+}
+
+// addJobsIsJSON tells you if an AddJobs Arg is a JSON array, as opposed to a
+// file path.
+func addJobsIsJSON(arg string) bool {
+	return strings.HasPrefix(strings.TrimSpace(arg), "[")
+}
+
+// parseAddJobs parses a JSON array of JobViaJSON objects.
+func parseAddJobs(jobsJSON []byte) ([]*JobViaJSON, error) {
+	var jvjs []*JobViaJSON
+	if err := json.Unmarshal(jobsJSON, &jvjs); err != nil {
+		return nil, fmt.Errorf("add_jobs behaviour jobs are not a JSON array of jobs: %w", err)
+	}
+	for _, jvj := range jvjs {
+		if jvj == nil || jvj.Cmd == "" {
+			return nil, fmt.Errorf("add_jobs behaviour has a job with no cmd")
+		}
+	}
+	return jvjs, nil
+}
+
+// addJobs adds the Jobs described by the Arg to the queue of the Client that
+// Execute()d the Job, dependent on the Job completing. Returns a summary of
+// what was added.
+func (b *Behaviour) addJobs(j *Job) ([]byte, error) {
+	arg, err := addJobsArg(b.Arg)
+	if err != nil {
+		return nil, err
+	}
+
+	j.RLock()
+	client := j.execClient
+	jd := &JobDefaults{RepGrp: j.RepGroup, Cwd: j.Cwd}
+	dep := &Dependency{Essence: &JobEssence{JobKey: j.Key()}}
+	actualCwd := j.ActualCwd
+	if actualCwd == "" {
+		actualCwd = j.Cwd
+	}
+	j.RUnlock()
+	if client == nil {
+		return nil, fmt.Errorf("add_jobs behaviour can only be triggered by Execute()")
+	}
+
+	jobsJSON := []byte(arg)
+	if !addJobsIsJSON(arg) {
+		path := arg
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(actualCwd, path)
+		}
+		jobsJSON, err = ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			return []byte("no jobs to add\n"), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	jvjs, err := parseAddJobs(jobsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*Job, len(jvjs))
+	for i, jvj := range jvjs {
+		job, errc := jvj.Convert(jd)
+		if errc != nil {
+			return nil, fmt.Errorf("add_jobs behaviour job %d is invalid: %w", i+1, errc)
+		}
+		job.Dependencies = append(job.Dependencies, dep)
+		jobs[i] = job
+	}
+
+	env, err := j.Env()
+	if err != nil {
+		return nil, err
+	}
+
+	added, existed, err := client.Add(jobs, env, false)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("added %d jobs (%d already existed)\n", added, existed)), nil
 }
 
 // Behaviours are a slice of Behaviour.
@@ -305,6 +821,8 @@ type Behaviours []*Behaviour
 
 // Trigger calls Trigger on each constituent Behaviour, first all those for
 // OnSuccess if success = true or OnFailure otherwise, then those for OnExit.
+// The results of those that did something are appended to the Job's
+// BehaviourResults.
 func (bs Behaviours) Trigger(success bool, j *Job) error {
 	if len(bs) == 0 {
 		return nil
@@ -319,7 +837,7 @@ func (bs Behaviours) Trigger(success bool, j *Job) error {
 
 	var merr *multierror.Error
 	for _, b := range bs {
-		err := b.Trigger(status, j)
+		err := b.triggerAndRecord(status, j)
 		if err != nil {
 			merr = multierror.Append(merr, err)
 		}
@@ -327,7 +845,7 @@ func (bs Behaviours) Trigger(success bool, j *Job) error {
 
 	status = OnExit
 	for _, b := range bs {
-		err := b.Trigger(status, j)
+		err := b.triggerAndRecord(status, j)
 		if err != nil {
 			merr = multierror.Append(merr, err)
 		}
@@ -336,6 +854,21 @@ func (bs Behaviours) Trigger(success bool, j *Job) error {
 	return merr.ErrorOrNil()
 }
 
+// Validate checks that each constituent Behaviour has a valid When and Do, an
+// Arg of the right type for its Do, and usable Conditions, returning an error
+// describing the first problem found.
+func (bs Behaviours) Validate() error {
+	for i, b := range bs {
+		if b == nil {
+			return fmt.Errorf("behaviour %d is nil", i+1)
+		}
+		if err := b.validate(); err != nil {
+			return fmt.Errorf("behaviour %d %w", i+1, err)
+		}
+	}
+	return nil
+}
+
 // String provides a nice string representation of Behaviours for user
 // interface display purposes. It takes the form of a JSON string that can
 // be converted back to Behaviours using a BehavioursViaJSON for each key. The
@@ -367,7 +900,26 @@ type BehaviourViaJSON struct {
 	CopyToManager []string `json:"copy_to_manager,omitempty"`
 	Cleanup       bool     `json:"cleanup,omitempty"`
 	CleanupAll    bool     `json:"cleanup_all,omitempty"`
+	CleanupDryRun bool     `json:"cleanup_dry_run,omitempty"`
 	Nothing       bool     `json:"nothing,omitempty"`
+
+	// Resubmit describes how to modify the Job before it is re-enqueued.
+	Resubmit *ResubmitOptions `json:"resubmit,omitempty"`
+
+	// AddJobs is a JSON array of JobViaJSON objects, or a JSON string path to
+	// a file containing one.
+	AddJobs json.RawMessage `json:"add_jobs,omitempty"`
+
+	// Timeout is the number of seconds a Run behaviour may take before it is
+	// killed.
+	Timeout int `json:"timeout,omitempty"`
+
+	// ExitCodes, FailReason, Attempt and FinalAttempt are optional conditions
+	// on the behaviour triggering; see BehaviourConditions.
+	ExitCodes    []int  `json:"exit_codes,omitempty"`
+	FailReason   string `json:"fail_reason,omitempty"`
+	Attempt      int    `json:"attempt,omitempty"`
+	FinalAttempt bool   `json:"final_attempt,omitempty"`
 }
 
 // Behaviour converts the friendly BehaviourViaJSON struct to real Behaviour.
@@ -386,22 +938,109 @@ func (bj BehaviourViaJSON) Behaviour(when BehaviourTrigger) *Behaviour {
 		do = Cleanup
 	case bj.CleanupAll:
 		do = CleanupAll
+	case bj.CleanupDryRun:
+		do = CleanupDryRun
+	case bj.Resubmit != nil:
+		do = Resubmit
+		arg = bj.Resubmit
+	case len(bj.AddJobs) > 0:
+		do = AddJobs
+		var path string
+		if err := json.Unmarshal(bj.AddJobs, &path); err == nil {
+			arg = path
+		} else {
+			arg = string(bj.AddJobs)
+		}
 	default:
 		do = Nothing
 	}
 
+	var conditions *BehaviourConditions
+	if len(bj.ExitCodes) > 0 || bj.FailReason != "" || bj.Attempt != 0 || bj.FinalAttempt {
+		conditions = &BehaviourConditions{
+			ExitCodes:    bj.ExitCodes,
+			FailReason:   bj.FailReason,
+			Attempt:      bj.Attempt,
+			FinalAttempt: bj.FinalAttempt,
+		}
+	}
+
 	return &Behaviour{
-		When: when,
-		Do:   do,
-		Arg:  arg,
+		When:       when,
+		Do:         do,
+		Arg:        arg,
+		Timeout:    time.Duration(bj.Timeout) * time.Second,
+		Conditions: conditions,
 	}
 }
 
+// behaviourActionKeys are the JSON keys of BehaviourViaJSON that specify an
+// action, exactly one of which must be present in each behaviour object.
+var behaviourActionKeys = []string{"run", "copy_to_manager", "cleanup", "cleanup_all", "cleanup_dry_run", "resubmit", "add_jobs", "nothing"}
+
+// parse strictly decodes a single JSON behaviour object, returning an error if
+// it has unknown keys, values of the wrong type, or doesn't specify exactly one
+// action.
+func (bj *BehaviourViaJSON) parse(data []byte) error {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("is not a JSON object: %w", err)
+	}
+
+	var actions []string
+	for key := range keys {
+		for _, action := range behaviourActionKeys {
+			if strings.EqualFold(key, action) {
+				actions = append(actions, action)
+			}
+		}
+	}
+	switch len(actions) {
+	case 0:
+		return fmt.Errorf("does not specify an action (one of %s)", strings.Join(behaviourActionKeys, ", "))
+	case 1:
+	default:
+		sort.Strings(actions)
+		return fmt.Errorf("specifies multiple actions (%s); use a separate object for each", strings.Join(actions, ", "))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(bj); err != nil {
+		return fmt.Errorf("is invalid: %w", err)
+	}
+	return nil
+}
+
 // BehavioursViaJSON is a slice of BehaviourViaJSON. It is a convenience to
 // allow users to specify behaviours in a more natural way if they're trying to
 // describe them in a JSON string. You'd have one of these per BehaviourTrigger.
 type BehavioursViaJSON []BehaviourViaJSON
 
+// UnmarshalJSON strictly parses a JSON array of behaviour objects, so that
+// mistakes like misspelt keys, values of the wrong type, or multiple actions in
+// one object result in an error describing the problem, instead of a Behaviour
+// that does nothing.
+func (bjs *BehavioursViaJSON) UnmarshalJSON(data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return fmt.Errorf("behaviours must be a JSON array of objects: %w", err)
+	}
+	if raws == nil {
+		*bjs = nil
+		return nil
+	}
+
+	parsed := make(BehavioursViaJSON, len(raws))
+	for i, raw := range raws {
+		if err := parsed[i].parse(raw); err != nil {
+			return fmt.Errorf("behaviour %d %s %w", i+1, raw, err)
+		}
+	}
+	*bjs = parsed
+	return nil
+}
+
 // Behaviours converts a BehavioursViaJSON to real Behaviours.
 func (bjs BehavioursViaJSON) Behaviours(when BehaviourTrigger) Behaviours {
 	bs := make(Behaviours, 0, len(bjs))
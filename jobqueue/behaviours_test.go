@@ -19,6 +19,7 @@
 package jobqueue
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -26,8 +27,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/inconshreveable/log15"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/ugorji/go/codec"
 )
 
 func TestBehaviours(t *testing.T) {
@@ -57,6 +61,8 @@ func TestBehaviours(t *testing.T) {
 		adir := filepath.Join(cwd, "a")
 		job1 := &Job{Cwd: cwd, ActualCwd: actualCwd}
 		job2 := &Job{Cwd: cwd}
+		err = writeCwdMarker(filepath.Dir(actualCwd), job1.Key())
+		So(err, ShouldBeNil)
 
 		Convey("Individual Behaviour can be nicely stringified", func() {
 			So(fmt.Sprintf("test Sprintf %s", b1), ShouldEqual, `test Sprintf {"on_exit":[{"cleanup_all":true}]}`)
@@ -82,10 +88,10 @@ func TestBehaviours(t *testing.T) {
 
 		Convey("Individual Behaviour Trigger() correctly", func() {
 			err = b7.Trigger(OnSuccess, job1)
-			So(err, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "can only be triggered by Execute()")
 			err = b8.Trigger(OnSuccess, job1)
 			So(err, ShouldNotBeNil)
-			//*** CopyToManager not yet implemented, so no proper tests for it yet
 
 			err = b6.Trigger(OnSuccess, job1)
 			So(err, ShouldNotBeNil)
@@ -199,6 +205,145 @@ func TestBehaviours(t *testing.T) {
 			_, err = os.Stat(adir)
 			So(err, ShouldNotBeNil)
 		})
+
+		Convey("Cleanup refuses to delete directories that aren't safe to", func() {
+			job3 := &Job{Cmd: "other", Cwd: cwd, ActualCwd: actualCwd}
+			err = b1.Trigger(OnExit, job3)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "not created for this job")
+
+			job3 = &Job{Cwd: filepath.Join(cwd, "other"), ActualCwd: actualCwd}
+			err = b1.Trigger(OnExit, job3)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "is not within")
+
+			job3 = &Job{Cwd: cwd, ActualCwd: actualCwd, CwdMatters: true}
+			err = b1.Trigger(OnExit, job3)
+			So(err, ShouldBeNil)
+
+			defer func() { BehaviourCleanupMinDepth = 3 }()
+			BehaviourCleanupMinDepth = 100
+			err = b1.Trigger(OnExit, job1)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "directories deep")
+
+			_, err = os.Stat(actualCwd)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Run Behaviours are killed after their Timeout and have results recorded", func() {
+			b := &Behaviour{When: OnSuccess, Do: Run, Arg: "echo started; sleep 10", Timeout: 100 * time.Millisecond}
+			So(b.String(), ShouldEqual, `{"on_success":[{"run":"echo started; sleep 10"}]}`)
+			job3 := &Job{Cwd: cwd}
+			start := time.Now()
+			err = Behaviours{b, b5}.Trigger(true, job3)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "took longer than 100ms and was killed")
+			So(time.Since(start), ShouldBeLessThan, 5*time.Second)
+
+			So(len(job3.BehaviourResults), ShouldEqual, 2)
+			So(job3.BehaviourResults[0].Behaviour, ShouldEqual, b.String())
+			So(job3.BehaviourResults[0].Output, ShouldEqual, "started\n")
+			So(job3.BehaviourResults[0].Error, ShouldContainSubstring, "was killed")
+			So(job3.BehaviourResults[0].Duration, ShouldBeGreaterThanOrEqualTo, 100*time.Millisecond)
+			So(job3.BehaviourResults[1].Behaviour, ShouldEqual, b5.String())
+			So(job3.BehaviourResults[1].Error, ShouldBeBlank)
+		})
+
+		Convey("Behaviours with Conditions only Trigger() when they are met", func() {
+			rescue := filepath.Join(cwd, "rescued")
+			b := &Behaviour{When: OnFailure, Do: Run, Arg: "touch rescued", Conditions: &BehaviourConditions{
+				ExitCodes:    []int{137, 143},
+				FailReason:   "signal|RAM",
+				FinalAttempt: true,
+			}}
+			So(b.String(), ShouldEqual, `{"on_failure":[{"run":"touch rescued","exit_codes":[137,143],"fail_reason":"signal|RAM","final_attempt":true}]}`)
+
+			triggered := func(o *behaviourOutcome) bool {
+				defer os.Remove(rescue)
+				job2.execOutcome = o
+				errt := b.Trigger(OnFailure, job2)
+				So(errt, ShouldBeNil)
+				_, errs := os.Stat(rescue)
+				return errs == nil
+			}
+
+			So(triggered(nil), ShouldBeFalse)
+			So(triggered(&behaviourOutcome{exitcode: 1, failReason: FailReasonSignal, attempt: 3, final: true}), ShouldBeFalse)
+			So(triggered(&behaviourOutcome{exitcode: 137, failReason: FailReasonExit, attempt: 3, final: true}), ShouldBeFalse)
+			So(triggered(&behaviourOutcome{exitcode: 137, failReason: FailReasonSignal, attempt: 2, final: false}), ShouldBeFalse)
+			So(triggered(&behaviourOutcome{exitcode: 137, failReason: FailReasonSignal, attempt: 3, final: true}), ShouldBeTrue)
+			So(triggered(&behaviourOutcome{exitcode: 143, failReason: FailReasonRAM, attempt: 1, final: true}), ShouldBeTrue)
+
+			b.Conditions = &BehaviourConditions{Attempt: 2}
+			So(triggered(&behaviourOutcome{exitcode: 1, attempt: 1}), ShouldBeFalse)
+			So(triggered(&behaviourOutcome{exitcode: 1, attempt: 2}), ShouldBeTrue)
+
+			b.Conditions = &BehaviourConditions{FailReason: "("}
+			So(Behaviours{b}.Validate(), ShouldNotBeNil)
+			job2.execOutcome = &behaviourOutcome{exitcode: 1}
+			So(b.Trigger(OnFailure, job2), ShouldNotBeNil)
+			So(Behaviours{b5}.Validate(), ShouldBeNil)
+		})
+
+		Convey("Resubmit notes the options for the server when the cmd failed", func() {
+			opts := &ResubmitOptions{RAMMultiplier: 2, CmdReplace: map[string]string{"-t 1": "-t 2"}}
+			b := &Behaviour{When: OnExit, Do: Resubmit, Arg: opts}
+			So(b.String(), ShouldEqual, `{"on_exit":[{"resubmit":{"ram_multiplier":2,"cmd_replace":{"-t 1":"-t 2"}}}]}`)
+
+			job2.execOutcome = &behaviourOutcome{exitcode: 0}
+			err = b.Trigger(OnExit, job2)
+			So(err, ShouldNotBeNil)
+			So(job2.execResubmit, ShouldBeNil)
+
+			job2.execOutcome = &behaviourOutcome{exitcode: 1, failReason: FailReasonExit}
+			err = b.Trigger(OnExit, job2)
+			So(err, ShouldBeNil)
+			So(job2.execResubmit, ShouldResemble, opts)
+
+			b.Arg = []string{"invalid"}
+			So(Behaviours{b}.Validate(), ShouldNotBeNil)
+			b.Arg = "invalid"
+			So(Behaviours{b}.Validate(), ShouldNotBeNil)
+			b.Arg = &ResubmitOptions{RAMMultiplier: -1}
+			So(Behaviours{b}.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("AddJobs needs valid jobs and a Client to add them with", func() {
+			b := &Behaviour{When: OnSuccess, Do: AddJobs, Arg: `[{"cmd":"echo next"}]`}
+			So(b.String(), ShouldEqual, `{"on_success":[{"add_jobs":[{"cmd":"echo next"}]}]}`)
+			So(Behaviours{b}.Validate(), ShouldBeNil)
+			err = b.Trigger(OnSuccess, job1)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "can only be triggered by Execute()")
+
+			b.Arg = "next.json"
+			So(b.String(), ShouldEqual, `{"on_success":[{"add_jobs":"next.json"}]}`)
+			So(Behaviours{b}.Validate(), ShouldBeNil)
+
+			b.Arg = `[{"cwd":"/tmp"}]`
+			So(Behaviours{b}.Validate(), ShouldNotBeNil)
+			b.Arg = `[{"cmd":`
+			So(Behaviours{b}.Validate(), ShouldNotBeNil)
+			So(b.String(), ShouldEqual, `{"on_success":[{"add_jobs":"!invalid!"}]}`)
+			b.Arg = []string{"echo next"}
+			So(Behaviours{b}.Validate(), ShouldNotBeNil)
+		})
+
+		Convey("CleanupDryRun logs what would be deleted without deleting it", func() {
+			b := &Behaviour{When: OnExit, Do: CleanupDryRun}
+			So(b.String(), ShouldEqual, `{"on_exit":[{"cleanup_dry_run":true}]}`)
+
+			var buf bytes.Buffer
+			job1.execLogger = log15.New()
+			job1.execLogger.SetHandler(log15.StreamHandler(&buf, log15.LogfmtFormat()))
+			err = b.Trigger(OnExit, job1)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, "cleanup dry run would delete")
+			So(buf.String(), ShouldContainSubstring, "files=3")
+			_, err = os.Stat(actualCwd)
+			So(err, ShouldBeNil)
+		})
 	})
 
 	Convey("You can go from JSON to Behaviours", t, func() {
@@ -232,6 +377,43 @@ func TestBehaviours(t *testing.T) {
 		So(bs[3].When, ShouldEqual, OnSuccess)
 		So(bs[3].Do, ShouldEqual, Cleanup)
 
+		jsonStr = `[{"run":"sleep 1","timeout":30}]`
+		var bjst BehavioursViaJSON
+		err = json.Unmarshal([]byte(jsonStr), &bjst)
+		So(err, ShouldBeNil)
+		bst := bjst.Behaviours(OnExit)
+		So(bst[0].Timeout, ShouldEqual, 30*time.Second)
+		So(bst.String(), ShouldEqual, `{"on_exit":[{"run":"sleep 1","timeout":30}]}`)
+
+		jsonStr = `[{"run":"rescue.sh","exit_codes":[137],"fail_reason":"signal","attempt":2,"final_attempt":true}]`
+		var bjsc BehavioursViaJSON
+		err = json.Unmarshal([]byte(jsonStr), &bjsc)
+		So(err, ShouldBeNil)
+		bsc := bjsc.Behaviours(OnFailure)
+		So(bsc[0].Conditions, ShouldResemble, &BehaviourConditions{ExitCodes: []int{137}, FailReason: "signal", Attempt: 2, FinalAttempt: true})
+		So(bsc.String(), ShouldEqual, `{"on_failure":[`+jsonStr[1:len(jsonStr)-1]+`]}`)
+		So(bjs2.Behaviours(OnFailure)[0].Conditions, ShouldBeNil)
+
+		jsonStr = `[{"resubmit":{"ram_multiplier":1.5,"rep_group":"again","cmd_replace":{"a":"b"},"cmd_append":"--more"}}]`
+		var bjsr BehavioursViaJSON
+		err = json.Unmarshal([]byte(jsonStr), &bjsr)
+		So(err, ShouldBeNil)
+		bsr := bjsr.Behaviours(OnFailure)
+		So(bsr[0].Do, ShouldEqual, Resubmit)
+		So(bsr.Validate(), ShouldBeNil)
+		So(bsr.String(), ShouldEqual, `{"on_failure":`+jsonStr+`}`)
+
+		jsonStr = `[{"add_jobs":[{"cmd":"echo next","rep_grp":"step2"}]},{"add_jobs":"next.json"}]`
+		var bjsa BehavioursViaJSON
+		err = json.Unmarshal([]byte(jsonStr), &bjsa)
+		So(err, ShouldBeNil)
+		bsa := bjsa.Behaviours(OnSuccess)
+		So(bsa[0].Do, ShouldEqual, AddJobs)
+		So(bsa[0].Arg, ShouldEqual, `[{"cmd":"echo next","rep_grp":"step2"}]`)
+		So(bsa[1].Arg, ShouldEqual, "next.json")
+		So(bsa.Validate(), ShouldBeNil)
+		So(bsa.String(), ShouldEqual, `{"on_success":`+jsonStr+`}`)
+
 		jsonStr = `[{"run":"true"}]`
 		var bjs3 BehavioursViaJSON
 		err = json.Unmarshal([]byte(jsonStr), &bjs3)
@@ -249,3 +431,172 @@ func TestBehaviours(t *testing.T) {
 		})
 	})
 }
+
+func TestResubmitOptions(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("ResubmitOptions can modify a Cmd", t, func() {
+		opts := &ResubmitOptions{CmdReplace: map[string]string{"-t 1": "-t 2", "-m 1": "-m 4"}, CmdAppend: "--retry"}
+		So(opts.cmd("prog -t 1 -m 1 in"), ShouldEqual, "prog -t 2 -m 4 in --retry")
+		So((&ResubmitOptions{}).cmd("prog"), ShouldEqual, "prog")
+
+		Convey("They survive being encoded and decoded as a Behaviour Arg", func() {
+			opts.RAMMultiplier = 1.5
+			opts.RepGroup = "again"
+			job := &Job{Cmd: "prog", Behaviours: Behaviours{{When: OnFailure, Do: Resubmit, Arg: opts}}}
+
+			var buf bytes.Buffer
+			ch := new(codec.BincHandle)
+			err := codec.NewEncoder(&buf, ch).Encode(job)
+			So(err, ShouldBeNil)
+			decoded := &Job{}
+			err = codec.NewDecoder(&buf, ch).Decode(decoded)
+			So(err, ShouldBeNil)
+
+			got, err := resubmitOptions(decoded.Behaviours[0].Arg)
+			So(err, ShouldBeNil)
+			So(got, ShouldResemble, opts)
+			So(decoded.Behaviours.String(), ShouldEqual, job.Behaviours.String())
+
+			buf.Reset()
+			err = codec.NewEncoder(&buf, ch).Encode(decoded)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Invalid ones are rejected", func() {
+			_, err := resubmitOptions(&ResubmitOptions{CmdReplace: map[string]string{"": "a"}})
+			So(err, ShouldNotBeNil)
+			_, err = resubmitOptions([]string{"a"})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestBehavioursStrictness(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Bad behaviour JSON is rejected with a descriptive error", t, func() {
+		var bjs BehavioursViaJSON
+		err := json.Unmarshal([]byte(`[{"run":"true"},{"clean":true}]`), &bjs)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldStartWith, `behaviour 2 {"clean":true} does not specify an action`)
+
+		err = json.Unmarshal([]byte(`[{"run":"true","cleanup":true}]`), &bjs)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "specifies multiple actions (cleanup, run)")
+
+		err = json.Unmarshal([]byte(`[{"run":"true","timout":5}]`), &bjs)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, `unknown field "timout"`)
+
+		err = json.Unmarshal([]byte(`[{"run":5}]`), &bjs)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "cannot unmarshal number")
+
+		err = json.Unmarshal([]byte(`[{"resubmit":{"ram":2}}]`), &bjs)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, `unknown field "ram"`)
+
+		err = json.Unmarshal([]byte(`{"run":"true"}`), &bjs)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldStartWith, "behaviours must be a JSON array of objects")
+
+		err = json.Unmarshal([]byte(`[{"Nothing":true},{"cleanup":false}]`), &bjs)
+		So(err, ShouldBeNil)
+		So(len(bjs), ShouldEqual, 2)
+		So(bjs.Behaviours(OnExit).Validate(), ShouldBeNil)
+
+		var jvj JobViaJSON
+		err = json.Unmarshal([]byte(`{"cmd":"true","on_failure":[{"rn":"true"}]}`), &jvj)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "does not specify an action")
+	})
+
+	Convey("Behaviours can be validated", t, func() {
+		So(Behaviours{{When: OnExit, Do: CleanupAll}}.Validate(), ShouldBeNil)
+		So(Behaviours{{When: OnExit, Do: CopyToManager, Arg: []interface{}{"a.file"}}}.Validate(), ShouldBeNil)
+
+		err := Behaviours{{When: OnExit, Do: CleanupAll}, {When: 10, Do: CleanupAll}}.Validate()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "behaviour 2 has an invalid trigger (10)")
+
+		err = Behaviours{{When: OnExit, Do: 0}}.Validate()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "behaviour 1 has an invalid action (0)")
+
+		err = Behaviours{{When: OnExit, Do: Run}}.Validate()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "needs a command string to run")
+
+		err = Behaviours{{When: OnExit, Do: CopyToManager, Arg: []interface{}{1}}}.Validate()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "needs a list of files to copy")
+
+		err = Behaviours{{When: OnExit, Do: Run, Arg: "true", Timeout: -1}}.Validate()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "negative timeout")
+
+		err = Behaviours{nil}.Validate()
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestBehaviourSetRefs(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Behaviour set references can be parsed", t, func() {
+		So(IsBehaviourSetRef("@irods-archive"), ShouldBeTrue)
+		So(IsBehaviourSetRef(` [{"cleanup":true}]`), ShouldBeFalse)
+
+		name, version, err := ParseBehaviourSetRef("@irods-archive")
+		So(err, ShouldBeNil)
+		So(name, ShouldEqual, "irods-archive")
+		So(version, ShouldEqual, 0)
+
+		name, version, err = ParseBehaviourSetRef("@irods_archive.v2:3")
+		So(err, ShouldBeNil)
+		So(name, ShouldEqual, "irods_archive.v2")
+		So(version, ShouldEqual, 3)
+
+		name, _, err = ParseBehaviourSetRef("irods")
+		So(err, ShouldBeNil)
+		So(name, ShouldEqual, "irods")
+
+		_, _, err = ParseBehaviourSetRef("@irods:0")
+		So(err, ShouldNotBeNil)
+		_, _, err = ParseBehaviourSetRef("@irods:latest")
+		So(err, ShouldNotBeNil)
+		_, _, err = ParseBehaviourSetRef("@-irods")
+		So(err, ShouldNotBeNil)
+		_, _, err = ParseBehaviourSetRef("@")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Behaviours can be parsed from JSON, but not from references", t, func() {
+		bs, err := ParseBehaviours(`[{"cleanup":true}]`, OnSuccess)
+		So(err, ShouldBeNil)
+		So(len(bs), ShouldEqual, 1)
+		So(bs[0].When, ShouldEqual, OnSuccess)
+		So(bs[0].Do, ShouldEqual, Cleanup)
+
+		_, err = ParseBehaviours(`[{"run":["true"]}]`, OnSuccess)
+		So(err, ShouldNotBeNil)
+
+		_, err = ParseBehaviours("@irods-archive", OnSuccess)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "needs to be resolved by the server")
+	})
+
+	Convey("Behaviour sets are validated", t, func() {
+		So((&BehaviourSet{Name: "ok", JSON: `[{"cleanup":true}]`}).validate(), ShouldBeNil)
+		So((&BehaviourSet{Name: "not ok", JSON: `[{"cleanup":true}]`}).validate(), ShouldNotBeNil)
+		So((&BehaviourSet{Name: "ok", JSON: `[]`}).validate(), ShouldNotBeNil)
+		So((&BehaviourSet{Name: "ok", JSON: `[{"cleanp":true}]`}).validate(), ShouldNotBeNil)
+	})
+}
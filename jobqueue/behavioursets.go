@@ -0,0 +1,216 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of the behaviour library, where sets
+// of behaviours are stored by the server under a name, so that they can be
+// reused by referring to them as @name when adding jobs.
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BehaviourSetRefPrefix is the prefix that distinguishes a reference to a
+// stored BehaviourSet, like "@irods-archive" or "@irods-archive:2", from
+// behaviours specified directly in JSON.
+const BehaviourSetRefPrefix = "@"
+
+// behaviourSetNameRegexp is what the names of BehaviourSets must match.
+var behaviourSetNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// BehaviourSet is a named set of behaviours, specified in the JSON format of
+// BehavioursViaJSON, stored by the server so that it can be referred to when
+// adding jobs.
+//
+// Saving a set with the name of an existing one creates a new Version of it,
+// with the older versions remaining available. Jobs get a copy of the
+// behaviours in the version current at the time they are added, so saving a
+// new version does not change the behaviour of jobs already in the queue.
+type BehaviourSet struct {
+	Name    string
+	Version int
+	JSON    string
+	Saved   time.Time
+}
+
+// Behaviours parses the set's JSON in to Behaviours that will be triggered
+// according to the given BehaviourTrigger.
+func (bset *BehaviourSet) Behaviours(when BehaviourTrigger) (Behaviours, error) {
+	var bjs BehavioursViaJSON
+	if err := json.Unmarshal([]byte(bset.JSON), &bjs); err != nil {
+		return nil, err
+	}
+	return bjs.Behaviours(when), nil
+}
+
+// validate checks that the set has a valid name and that its JSON describes
+// valid behaviours.
+func (bset *BehaviourSet) validate() error {
+	if err := validateBehaviourSetName(bset.Name); err != nil {
+		return err
+	}
+	bs, err := bset.Behaviours(OnExit)
+	if err != nil {
+		return err
+	}
+	if len(bs) == 0 {
+		return fmt.Errorf("behaviour set %s contains no behaviours", bset.Name)
+	}
+	return bs.Validate()
+}
+
+// validateBehaviourSetName returns an error if the given name isn't suitable
+// for a BehaviourSet.
+func validateBehaviourSetName(name string) error {
+	if !behaviourSetNameRegexp.MatchString(name) {
+		return fmt.Errorf("behaviour set name %q is invalid; it must start with a letter or number, and consist of letters, numbers, _, . and -", name)
+	}
+	return nil
+}
+
+// IsBehaviourSetRef tells you if the given string is a reference to a stored
+// BehaviourSet (ie. it starts with BehaviourSetRefPrefix), as opposed to JSON.
+func IsBehaviourSetRef(spec string) bool {
+	return strings.HasPrefix(strings.TrimSpace(spec), BehaviourSetRefPrefix)
+}
+
+// ParseBehaviourSetRef parses a reference to a stored BehaviourSet of the form
+// "@name" or "@name:version", returning the name and version. Version will be
+// 0 if not specified, meaning the latest version. The leading @ is optional.
+func ParseBehaviourSetRef(ref string) (string, int, error) {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), BehaviourSetRefPrefix)
+	name := ref
+	version := 0
+	if i := strings.LastIndex(ref, ":"); i != -1 {
+		name = ref[:i]
+		v, err := strconv.Atoi(ref[i+1:])
+		if err != nil || v < 1 {
+			return "", 0, fmt.Errorf("behaviour set reference %q has an invalid version; it must be a number, 1 or more", ref)
+		}
+		version = v
+	}
+	if err := validateBehaviourSetName(name); err != nil {
+		return "", 0, err
+	}
+	return name, version, nil
+}
+
+// ParseBehaviours parses behaviours specified in the JSON format of
+// BehavioursViaJSON, returning validated Behaviours that will be triggered
+// according to the given BehaviourTrigger. To also handle references to stored
+// BehaviourSets, use Client.ResolveBehaviours().
+func ParseBehaviours(behavioursJSON string, when BehaviourTrigger) (Behaviours, error) {
+	if IsBehaviourSetRef(behavioursJSON) {
+		return nil, fmt.Errorf("%s is a behaviour set reference, which needs to be resolved by the server", behavioursJSON)
+	}
+	var bjs BehavioursViaJSON
+	if err := json.Unmarshal([]byte(behavioursJSON), &bjs); err != nil {
+		return nil, err
+	}
+	bs := bjs.Behaviours(when)
+	return bs, bs.Validate()
+}
+
+// saveBehaviourSet validates the given set and stores it as the next version of
+// the set with its name. The given set has its Version and Saved time filled
+// in.
+func (s *Server) saveBehaviourSet(bset *BehaviourSet) error {
+	if err := bset.validate(); err != nil {
+		return err
+	}
+
+	s.blmutex.Lock()
+	defer s.blmutex.Unlock()
+	versions, err := s.db.retrieveBehaviourSet(bset.Name)
+	if err != nil {
+		return err
+	}
+	bset.Version = 1
+	if len(versions) > 0 {
+		bset.Version = versions[len(versions)-1].Version + 1
+	}
+	bset.Saved = time.Now()
+	versions = append(versions, bset)
+	if err = s.db.storeBehaviourSet(bset.Name, versions); err != nil {
+		return err
+	}
+	s.Info("saved behaviour set", "name", bset.Name, "version", bset.Version)
+	return nil
+}
+
+// behaviourSet returns the given version of the named BehaviourSet, or the
+// latest version if version is 0. Returns nil if there is no such set.
+func (s *Server) behaviourSet(name string, version int) (*BehaviourSet, error) {
+	s.blmutex.RLock()
+	defer s.blmutex.RUnlock()
+	versions, err := s.db.retrieveBehaviourSet(name)
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+	if version == 0 {
+		return versions[len(versions)-1], nil
+	}
+	for _, bset := range versions {
+		if bset.Version == version {
+			return bset, nil
+		}
+	}
+	return nil, nil
+}
+
+// behaviourSets returns the latest version of every stored BehaviourSet,
+// sorted by name.
+func (s *Server) behaviourSets() ([]*BehaviourSet, error) {
+	s.blmutex.RLock()
+	defer s.blmutex.RUnlock()
+	all, err := s.db.retrieveBehaviourSets()
+	if err != nil {
+		return nil, err
+	}
+	latest := make([]*BehaviourSet, 0, len(all))
+	for _, versions := range all {
+		if len(versions) > 0 {
+			latest = append(latest, versions[len(versions)-1])
+		}
+	}
+	sort.Slice(latest, func(i, j int) bool {
+		return latest[i].Name < latest[j].Name
+	})
+	return latest, nil
+}
+
+// deleteBehaviourSet removes all versions of the named BehaviourSet. Jobs that
+// were added using it keep their copies of its behaviours. Returns false if
+// there was no such set.
+func (s *Server) deleteBehaviourSet(name string) (bool, error) {
+	s.blmutex.Lock()
+	defer s.blmutex.Unlock()
+	versions, err := s.db.retrieveBehaviourSet(name)
+	if err != nil || len(versions) == 0 {
+		return false, err
+	}
+	s.Info("deleted behaviour set", "name", name, "versions", len(versions))
+	return true, s.db.deleteBehaviourSet(name)
+}
@@ -0,0 +1,362 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of RepGroup budgets, which limit the
+// aggregate CPU-hours or cost the jobs in a RepGroup may consume, raising
+// alarms as they are approached and optionally pausing the RepGroup once they
+// are exceeded.
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/VertebrateResequencing/wr/queue"
+)
+
+// ServerBudgetWarnFraction is the fraction of a RepGroup's budget that, once
+// consumed, raises a warning alarm.
+var ServerBudgetWarnFraction = 0.8
+
+// BudgetAlarm describes how much of its Budget a RepGroup has consumed.
+type BudgetAlarm string
+
+// BudgetAlarm* are the possible BudgetAlarms. BudgetAlarmWarning means at
+// least ServerBudgetWarnFraction of the budget has been consumed.
+const (
+	BudgetAlarmNone     BudgetAlarm = ""
+	BudgetAlarmWarning  BudgetAlarm = "warning"
+	BudgetAlarmExceeded BudgetAlarm = "exceeded"
+)
+
+// Budget limits the resources the jobs in a RepGroup may consume. Consumption
+// is measured in CPU-hours: the cores a job requested multiplied by how long it
+// was running for, summed over every run of every job in the RepGroup,
+// including failed attempts. Cost is CPU-hours multiplied by CostPerCoreHour.
+//
+// As consumption crosses ServerBudgetWarnFraction and then the whole budget,
+// the server records EventTypeBudget events and logs warnings. If Pause is
+// true, once the budget is exceeded the RepGroup's waiting jobs are buried
+// with FailReasonBudget, as are any of its jobs that become ready later, until
+// the budget is raised or deleted and the jobs are retried. Jobs that are
+// already running are left to finish.
+type Budget struct {
+	RepGroup string
+
+	// CPUHours is the maximum CPU-hours the RepGroup may consume; 0 means no
+	// limit on CPU-hours.
+	CPUHours float64
+
+	// Cost is the maximum cost the RepGroup may incur; 0 means no limit on
+	// cost. It requires CostPerCoreHour.
+	Cost float64
+
+	// CostPerCoreHour is what 1 CPU-hour costs, eg. the hourly price of a
+	// cloud flavor divided by its cores.
+	CostPerCoreHour float64
+
+	// Pause makes the server bury the RepGroup's jobs once the budget is
+	// exceeded.
+	Pause bool
+
+	// UsedCPUHours is how many CPU-hours the RepGroup has consumed Since the
+	// budget was first set.
+	UsedCPUHours float64
+	Since        time.Time
+
+	// Alarm is the current state of the budget.
+	Alarm BudgetAlarm
+}
+
+// UsedCost returns the cost of the CPU-hours consumed so far.
+func (b *Budget) UsedCost() float64 {
+	return b.UsedCPUHours * b.CostPerCoreHour
+}
+
+// Validate checks that the budget is for a RepGroup and has sensible limits.
+func (b *Budget) Validate() error {
+	switch {
+	case b.RepGroup == "":
+		return fmt.Errorf("a budget needs a report group")
+	case b.CPUHours < 0 || b.Cost < 0 || b.CostPerCoreHour < 0:
+		return fmt.Errorf("budget limits can't be negative")
+	case b.CPUHours == 0 && b.Cost == 0:
+		return fmt.Errorf("a budget needs a CPU-hours or cost limit")
+	case b.Cost > 0 && b.CostPerCoreHour == 0:
+		return fmt.Errorf("a budget with a cost limit needs a cost per core hour")
+	}
+	return nil
+}
+
+// Consumed returns the fraction of the budget consumed so far, taking whichever
+// of its limits is closest to being reached.
+func (b *Budget) Consumed() float64 {
+	var fraction float64
+	if b.CPUHours > 0 {
+		fraction = b.UsedCPUHours / b.CPUHours
+	}
+	if b.Cost > 0 {
+		fraction = math.Max(fraction, b.UsedCost()/b.Cost)
+	}
+	return fraction
+}
+
+// String describes the budget's consumption against its limits.
+func (b *Budget) String() string {
+	var parts []string
+	if b.CPUHours > 0 {
+		parts = append(parts, fmt.Sprintf("%.2f of %g CPU-hours", b.UsedCPUHours, b.CPUHours))
+	}
+	if b.Cost > 0 {
+		parts = append(parts, fmt.Sprintf("%.2f of %g cost", b.UsedCost(), b.Cost))
+	}
+	return fmt.Sprintf("%s used %s (%.0f%%)", b.RepGroup, strings.Join(parts, " and "), b.Consumed()*100)
+}
+
+// alarm returns the BudgetAlarm appropriate for the budget's consumption.
+func (b *Budget) alarm() BudgetAlarm {
+	consumed := b.Consumed()
+	switch {
+	case consumed >= 1:
+		return BudgetAlarmExceeded
+	case consumed >= ServerBudgetWarnFraction:
+		return BudgetAlarmWarning
+	default:
+		return BudgetAlarmNone
+	}
+}
+
+// paused tells you if the budget requires its RepGroup's jobs to be buried.
+func (b *Budget) paused() bool {
+	return b.Pause && b.Alarm == BudgetAlarmExceeded
+}
+
+// budgetAlarmLevels orders BudgetAlarms by severity.
+var budgetAlarmLevels = map[BudgetAlarm]int{
+	BudgetAlarmNone:     0,
+	BudgetAlarmWarning:  1,
+	BudgetAlarmExceeded: 2,
+}
+
+// restoreBudgets loads the budgets stored in the database by setBudget().
+func (s *Server) restoreBudgets() {
+	budgets, err := s.db.retrieveBudgets()
+	if err != nil {
+		s.Warn("failed to retrieve stored budgets", "err", err)
+		return
+	}
+	s.bgmutex.Lock()
+	defer s.bgmutex.Unlock()
+	for _, budget := range budgets {
+		s.budgets[budget.RepGroup] = budget
+	}
+}
+
+// setBudget validates the given budget and sets it as the budget of its
+// RepGroup, replacing any existing budget but keeping that budget's
+// consumption so far. Returns a copy of the budget as set.
+func (s *Server) setBudget(budget *Budget) (*Budget, error) {
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.bgmutex.Lock()
+	b := *budget
+	b.UsedCPUHours = 0
+	b.Since = time.Now()
+	prev := BudgetAlarmNone
+	if existing, exists := s.budgets[b.RepGroup]; exists {
+		b.UsedCPUHours = existing.UsedCPUHours
+		b.Since = existing.Since
+		prev = existing.Alarm
+	}
+	b.Alarm = b.alarm()
+	s.budgets[b.RepGroup] = &b
+	set := b
+	s.bgmutex.Unlock()
+
+	if err := s.db.storeBudget(&set); err != nil {
+		return nil, err
+	}
+	s.Info("set budget", "rg", set.RepGroup, "cpuhours", set.CPUHours, "cost", set.Cost, "pause", set.Pause)
+	s.budgetAlarmChanged(&set, prev)
+	return &set, nil
+}
+
+// deleteBudget removes the budget of the given RepGroup. Returns false if it
+// didn't have one.
+func (s *Server) deleteBudget(repGroup string) (bool, error) {
+	s.bgmutex.Lock()
+	_, exists := s.budgets[repGroup]
+	delete(s.budgets, repGroup)
+	s.bgmutex.Unlock()
+	if !exists {
+		return false, nil
+	}
+	s.Info("deleted budget", "rg", repGroup)
+	return true, s.db.deleteBudget(repGroup)
+}
+
+// budgetList returns copies of all the budgets, sorted by RepGroup.
+func (s *Server) budgetList() []*Budget {
+	s.bgmutex.RLock()
+	budgets := make([]*Budget, 0, len(s.budgets))
+	for _, budget := range s.budgets {
+		b := *budget
+		budgets = append(budgets, &b)
+	}
+	s.bgmutex.RUnlock()
+	sort.Slice(budgets, func(i, j int) bool {
+		return budgets[i].RepGroup < budgets[j].RepGroup
+	})
+	return budgets
+}
+
+// noteBudgetTransitions is called when the given jobs change from one state to
+// another. Jobs in RepGroups with budgets that stop running have their
+// CPU-hours charged to those budgets, and jobs that become ready or delayed in
+// paused RepGroups are buried. This happens in the background.
+func (s *Server) noteBudgetTransitions(from, to JobState, data []interface{}) {
+	s.bgmutex.RLock()
+	defer s.bgmutex.RUnlock()
+	if len(s.budgets) == 0 {
+		return
+	}
+
+	now := time.Now()
+	usage := make(map[string]float64)
+	var toBury []string
+	for _, inter := range data {
+		job := inter.(*Job)
+		job.RLock()
+		rg := job.RepGroup
+		budget, exists := s.budgets[rg]
+		if !exists {
+			job.RUnlock()
+			continue
+		}
+		if from == JobStateRunning && !job.StartTime.IsZero() {
+			end := job.EndTime
+			if end.Before(job.StartTime) {
+				end = now
+			}
+			usage[rg] += job.Requirements.Cores * end.Sub(job.StartTime).Hours()
+		}
+		job.RUnlock()
+		if (to == JobStateReady || to == JobStateDelayed) && budget.paused() {
+			toBury = append(toBury, job.Key())
+		}
+	}
+	if len(usage) == 0 && len(toBury) == 0 {
+		return
+	}
+
+	go func() {
+		defer internal.LogPanic(s.Logger, "budget charging", true)
+		for rg, hours := range usage {
+			s.chargeBudget(rg, hours)
+		}
+		s.buryOverBudgetJobs(toBury)
+	}()
+}
+
+// chargeBudget adds the given CPU-hours to the consumption of the given
+// RepGroup's budget, raising alarms and pausing the RepGroup as necessary.
+func (s *Server) chargeBudget(repGroup string, hours float64) {
+	s.bgmutex.Lock()
+	budget, exists := s.budgets[repGroup]
+	if !exists {
+		s.bgmutex.Unlock()
+		return
+	}
+	budget.UsedCPUHours += hours
+	prev := budget.Alarm
+	budget.Alarm = budget.alarm()
+	charged := *budget
+	s.bgmutex.Unlock()
+
+	if err := s.db.storeBudget(&charged); err != nil {
+		s.Warn("failed to store budget", "rg", repGroup, "err", err)
+	}
+	s.budgetAlarmChanged(&charged, prev)
+}
+
+// budgetAlarmChanged records an event and logs a warning if the given budget's
+// alarm became more severe than the given previous alarm, and if it was
+// exceeded and should pause its RepGroup, buries the RepGroup's waiting jobs.
+func (s *Server) budgetAlarmChanged(budget *Budget, prev BudgetAlarm) {
+	if budgetAlarmLevels[budget.Alarm] <= budgetAlarmLevels[prev] {
+		return
+	}
+	msg := fmt.Sprintf("budget %s: %s", budget.Alarm, budget)
+	s.Warn("budget alarm", "rg", budget.RepGroup, "alarm", budget.Alarm, "consumed", budget.Consumed())
+	s.recordEvent(&Event{Type: EventTypeBudget, RepGroup: budget.RepGroup, Msg: msg})
+
+	if !budget.paused() {
+		return
+	}
+	jobs, _, qerr := s.getJobsByRepGroup(context.Background(), budget.RepGroup, false, 0, "", false, false)
+	if qerr != "" {
+		s.Warn("failed to get jobs to pause over budget report group", "rg", budget.RepGroup, "err", qerr)
+		return
+	}
+	var keys []string
+	for _, job := range jobs {
+		if job.State == JobStateReady || job.State == JobStateDelayed {
+			keys = append(keys, job.Key())
+		}
+	}
+	s.buryOverBudgetJobs(keys)
+}
+
+// buryOverBudgetJobs buries the waiting jobs with the given keys because their
+// RepGroup's budget was exceeded. Jobs that have started running in the
+// meantime are left alone.
+func (s *Server) buryOverBudgetJobs(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	buried := 0
+	for _, key := range keys {
+		item, err := s.q.Get(key)
+		if err != nil {
+			continue
+		}
+		state := item.Stats().State
+		if state != queue.ItemStateDelay && state != queue.ItemStateReady {
+			continue
+		}
+		job := item.Data().(*Job)
+		if err = s.buryWaitingJob(job, FailReasonBudget); err != nil {
+			s.Debug("could not bury job over budget", "cmd", job.Cmd, "err", err)
+			continue
+		}
+		s.recordBuryEvent(job, FailReasonBudget)
+		s.failAtomicGroup(job)
+		buried++
+	}
+	if buried > 0 {
+		s.Info("buried jobs over budget", "count", buried)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBudgets(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Budgets can be validated", t, func() {
+		So((&Budget{CPUHours: 1}).Validate(), ShouldNotBeNil)
+		So((&Budget{RepGroup: "a"}).Validate(), ShouldNotBeNil)
+		So((&Budget{RepGroup: "a", CPUHours: -1}).Validate(), ShouldNotBeNil)
+		So((&Budget{RepGroup: "a", Cost: 10}).Validate(), ShouldNotBeNil)
+		So((&Budget{RepGroup: "a", CPUHours: 1}).Validate(), ShouldBeNil)
+		So((&Budget{RepGroup: "a", Cost: 10, CostPerCoreHour: 0.5}).Validate(), ShouldBeNil)
+	})
+
+	Convey("Budget consumption is judged against the closest limit", t, func() {
+		b := &Budget{RepGroup: "a", CPUHours: 100, Cost: 10, CostPerCoreHour: 0.2, UsedCPUHours: 10}
+		So(b.UsedCost(), ShouldEqual, 2)
+		So(b.Consumed(), ShouldEqual, 0.2)
+		So(b.alarm(), ShouldEqual, BudgetAlarmNone)
+
+		b.UsedCPUHours = 40
+		So(b.Consumed(), ShouldEqual, 0.8)
+		So(b.alarm(), ShouldEqual, BudgetAlarmWarning)
+		So(b.String(), ShouldEqual, "a used 40.00 of 100 CPU-hours and 8.00 of 10 cost (80%)")
+
+		b.UsedCPUHours = 50
+		So(b.alarm(), ShouldEqual, BudgetAlarmExceeded)
+		b.Alarm = b.alarm()
+		So(b.paused(), ShouldBeFalse)
+		b.Pause = true
+		So(b.paused(), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of job result caching, where a
+// Cacheable job that is added when an identical job previously completed with
+// the same input files is completed with the results of that run, instead of
+// being run again. Since only runners can be relied on to see the input files,
+// and checksumming them can take a long time, it is the runner that reserves
+// the job that checks if they changed.
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/inconshreveable/log15"
+)
+
+// checksumInputs returns the MD5 checksums of our InputFiles, keyed on their
+// paths as given in InputFiles, with relative paths being treated as relative
+// to the given directory. If dir is blank, relative paths can't be
+// checksummed and an error is returned.
+func (j *Job) checksumInputs(dir string, logger log15.Logger) (map[string]string, error) {
+	sums := make(map[string]string, len(j.InputFiles))
+	for _, input := range j.InputFiles {
+		path := input
+		if !filepath.IsAbs(path) {
+			if dir == "" {
+				return nil, fmt.Errorf("relative input %s has no known directory", input)
+			}
+			path = filepath.Join(dir, path)
+		}
+
+		md5, err := internal.FileMD5(path, logger)
+		if err != nil {
+			return nil, err
+		}
+		sums[input] = md5
+	}
+	return sums, nil
+}
+
+// completeFromCache makes this Job complete with the results of the given
+// previous run of it, which had the given input checksums.
+func (j *Job) completeFromCache(prev *Job, sums map[string]string) {
+	j.Lock()
+	defer j.Unlock()
+	j.State = JobStateComplete
+	j.Cached = true
+	j.Exited = true
+	j.Exitcode = prev.Exitcode
+	j.PeakRAM = prev.PeakRAM
+	j.PeakDisk = prev.PeakDisk
+	j.CPUtime = prev.CPUtime
+	j.StartTime = prev.StartTime
+	j.EndTime = prev.EndTime
+	j.Host = prev.Host
+	j.HostID = prev.HostID
+	j.HostIP = prev.HostIP
+	j.ActualCwd = prev.ActualCwd
+	j.Metrics = prev.Metrics
+	j.OutputRecords = prev.OutputRecords
+	j.InputChecksums = sums
+}
+
+// sameInputs tells you if the given non-empty input checksums are the same as
+// the other given ones.
+func sameInputs(sums, others map[string]string) bool {
+	if len(sums) == 0 || len(sums) != len(others) {
+		return false
+	}
+	for path, sum := range sums {
+		if others[path] != sum {
+			return false
+		}
+	}
+	return true
+}
+
+// satisfyFromCache goes through the given jobs, which are being added with
+// ignoreComplete true, looking for Cacheable ones where an identical Job that
+// was also Cacheable previously completed.
+//
+// Those with no InputFiles or Dependencies are completed straight away with
+// the previous results, marked Cached, and stored in the complete bucket;
+// cached is the number of these. The others are returned in rerun, to be stored
+// without ignoring their previous completion, with their CachedInputChecksums
+// set to the InputChecksums of the previous run, for the runner that reserves
+// them to compare against. All other jobs are returned in jobs.
+func (s *Server) satisfyFromCache(inputJobs []*Job) (jobs, rerun []*Job, cached int, err error) {
+	var keys []string
+	for _, job := range inputJobs {
+		if job.Cacheable {
+			keys = append(keys, job.Key())
+		}
+	}
+	if len(keys) == 0 {
+		return inputJobs, nil, 0, nil
+	}
+
+	prevJobs, err := s.db.retrieveCompleteJobsByKeys(context.Background(), keys)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	prevs := make(map[string]*Job, len(prevJobs))
+	for _, prev := range prevJobs {
+		prevs[prev.Key()] = prev
+	}
+
+	var hits []*Job
+	for _, job := range inputJobs {
+		prev, found := prevs[job.Key()]
+		if !job.Cacheable || !found {
+			jobs = append(jobs, job)
+			continue
+		}
+
+		var live bool
+		live, err = s.db.checkIfLive(job.Key())
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if live {
+			// it's being re-run, so this is just a duplicate
+			jobs = append(jobs, job)
+			continue
+		}
+
+		if !prev.Cacheable {
+			rerun = append(rerun, job)
+			continue
+		}
+
+		if len(job.InputFiles) == 0 && len(job.Dependencies) == 0 {
+			// nothing it uses could have changed since the previous run
+			job.completeFromCache(prev, nil)
+			hits = append(hits, job)
+			continue
+		}
+
+		job.Lock()
+		job.CachedInputChecksums = prev.InputChecksums
+		job.Unlock()
+		rerun = append(rerun, job)
+	}
+
+	if len(hits) > 0 {
+		err = s.db.archiveCachedJobs(hits)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		s.Debug("completed jobs from cache", "count", len(hits))
+	}
+
+	return jobs, rerun, len(hits), nil
+}
+
+// completeFromCache completes the given reserved Cacheable job with the
+// results of its previous run, for when a runner found that its inputs had the
+// given checksums, the same as they were for that run. Returns ErrBadRequest
+// if that run is no longer known about or had different inputs, in which case
+// the runner should run the job after all.
+func (s *Server) completeFromCache(job *Job, sums map[string]string) (string, string) {
+	prevs, err := s.db.retrieveCompleteJobsByKeys(context.Background(), []string{job.Key()})
+	if err != nil {
+		return ErrDBError, err.Error()
+	}
+	if len(prevs) == 0 || !prevs[0].Cacheable || !sameInputs(sums, prevs[0].InputChecksums) {
+		return ErrBadRequest, "inputs differ from those of the previous run"
+	}
+	job.completeFromCache(prevs[0], sums)
+	return "", ""
+}
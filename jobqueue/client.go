@@ -22,6 +22,7 @@ package jobqueue
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -29,19 +30,21 @@ import (
 	"io"
 	"io/ioutil"
 	"math/rand"
+	"net"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/VertebrateResequencing/wr/jobqueue/scheduler"
 	"github.com/gofrs/uuid"
 	"github.com/inconshreveable/log15"
+	"github.com/jpillora/backoff"
 	"github.com/ugorji/go/codec"
 	"nanomsg.org/go-mangos"
 	"nanomsg.org/go-mangos/protocol/req"
@@ -50,24 +53,39 @@ import (
 
 // FailReason* are the reasons for cmd line failure stored on Jobs
 const (
-	FailReasonEnv      = "failed to get environment variables"
-	FailReasonCwd      = "working directory does not exist"
-	FailReasonStart    = "command failed to start"
-	FailReasonCPerm    = "command permission problem"
-	FailReasonCFound   = "command not found"
-	FailReasonCExit    = "command invalid exit code"
-	FailReasonExit     = "command exited non-zero"
-	FailReasonRAM      = "command used too much RAM"
-	FailReasonDisk     = "ran out of disk space"
-	FailReasonTime     = "command used too much time"
-	FailReasonDocker   = "could not interact with docker"
-	FailReasonAbnormal = "command failed to complete normally"
-	FailReasonLost     = "lost contact with runner"
-	FailReasonSignal   = "runner received a signal to stop"
-	FailReasonResource = "resource requirements cannot be met"
-	FailReasonMount    = "mounting of remote file system(s) failed"
-	FailReasonUpload   = "failed to upload files to remote file system"
-	FailReasonKilled   = "killed by user request"
+	FailReasonEnv       = "failed to get environment variables"
+	FailReasonCwd       = "working directory does not exist"
+	FailReasonStart     = "command failed to start"
+	FailReasonCPerm     = "command permission problem"
+	FailReasonCFound    = "command not found"
+	FailReasonCExit     = "command invalid exit code"
+	FailReasonExit      = "command exited non-zero"
+	FailReasonRAM       = "command used too much RAM"
+	FailReasonDisk      = "ran out of disk space"
+	FailReasonTime      = "command used too much time"
+	FailReasonDocker    = "could not interact with docker"
+	FailReasonAbnormal  = "command failed to complete normally"
+	FailReasonLost      = "lost contact with runner"
+	FailReasonSignal    = "runner received a signal to stop"
+	FailReasonResource  = "resource requirements cannot be met"
+	FailReasonQueue     = "requested queue(s) unusable or cannot meet resource requirements"
+	FailReasonMount     = "mounting of remote file system(s) failed"
+	FailReasonUpload    = "failed to upload files to remote file system"
+	FailReasonKilled    = "killed by user request"
+	FailReasonBuried    = "buried by user request"
+	FailReasonAtomic    = "another job in its atomic group was buried"
+	FailReasonInput     = "missing input"
+	FailReasonOutput    = "missing expected output"
+	FailReasonIRODS     = "iRODS transfer failed"
+	FailReasonNetwork   = "required network access unavailable"
+	FailReasonRefAsset  = "could not fetch reference assets"
+	FailReasonRunAs     = "could not run as the requested user"
+	FailReasonSecret    = "could not get the requested secrets"
+	FailReasonPerms     = "could not apply the requested file permissions"
+	FailReasonHostDisk  = "insufficient disk on host"
+	FailReasonDrained   = "host is being drained"
+	FailReasonAbandoned = "runner lost contact with the manager"
+	FailReasonBudget    = "report group's budget was exceeded"
 )
 
 // lsfEmulationDir is the name of the directory we store our LSF emulation
@@ -85,6 +103,8 @@ var (
 	ClientReleaseDelay                 = 30 * time.Second
 	ClientPercentMemoryKill            = 90
 	ClientRetryWait                    = 15 * time.Second
+	ClientRetryMinWait                 = 250 * time.Millisecond
+	ClientKeepAliveInterval            = 30 * time.Second
 	ClientRetryTime                    = 24 * time.Hour
 	ClientShutdownTimeout              = 120 * time.Second
 	ClientShutdownTestInterval         = 100 * time.Millisecond
@@ -107,14 +127,31 @@ type clientRequest struct {
 	LimitGroup              string
 	Method                  string
 	SchedulerGroup          string
+	Host                    string // hostname of the client when reserving, or of a host to drain
 	State                   JobState
 	Path                    string // desired path File should be stored at, can be blank
 	CloudServerID           string
 	Job                     *Job
 	JobEndState             *JobEndState
 	Modifier                *JobModifier
+	BurstPolicy             *scheduler.BurstPolicy
+	SecretName              string
+	SecretValue             string
+	SecretGrants            map[string]string // when adding or modifying jobs, the grants of the secrets they use
+	SettingName             string
+	SettingValue            string
+	BehaviourSet            *BehaviourSet
+	Budget                  *Budget
+	StartRate               *StartRate
+	LimitFeedback           *LimitFeedback
+	QueueConfig             *QueueConfig
+	Annotation              *Annotation
+	RepGroups               []string // when getting annotations, the RepGroups to get them for
 	Limit                   int
 	Timeout                 time.Duration
+	Since                   time.Time // when getting complete jobs or events, those that completed or happened at or after this time
+	Until                   time.Time // when getting complete jobs, those that completed before this time; when getting state counts, the time to get them for
+	EventTypes              []EventType
 	ClientID                uuid.UUID
 	FirstReserve            bool
 	GetEnv                  bool
@@ -122,23 +159,36 @@ type clientRequest struct {
 	IgnoreComplete          bool
 	Search                  bool
 	ConfirmDeadCloudServers bool
-	ReturnIDs               bool // when adding jobs, return the IDs of the added jobs
+	Repair                  bool      // when checking consistency, also repair any inconsistencies
+	ReturnIDs               bool      // when adding jobs, return the IDs of the added jobs
+	AddToken                string    // when adding jobs, identifies the batch so that resubmissions of it aren't carried out again
+	ReturnResults           bool      // when adding jobs, reject invalid ones individually and return the outcome for each job
+	RequestID               uuid.UUID // the same for retries of a request, so the server only carries it out once
+	ClientVersion           string    // the build version of the client
+	User                    string    // username of the client, recorded (as unverified) as who asked for the changes it requests
+	ProtocolVersion         int       // the ProtocolVersion of the client
 }
 
 // Client represents the client side of the socket that the jobqueue server is
-// Serve()ing, specific to a particular queue.
+// Serve()ing, specific to a particular queue. It is safe for concurrent use by
+// multiple goroutines; see WithContext() for cancelling its requests.
 type Client struct {
-	ch          codec.Handle
-	clientid    uuid.UUID
-	hasReserved bool
-	sock        mangos.Socket
+	ch       codec.Handle
+	clientid uuid.UUID
+	conn     *clientConn
+	ctx      context.Context
 	sync.Mutex
-	teMutex    sync.Mutex // to protect Touch() from other methods during Execute()
-	token      []byte
-	ServerInfo *ServerInfo
-	host       string
-	port       string
-	args       []string // allowing internal reconnects
+	token        []byte
+	user         string
+	secretGrants map[string]string
+	ServerInfo   *ServerInfo
+	host         string
+	port         string
+	args         []string // allowing internal reconnects
+	timeout      time.Duration
+	execIn       io.Reader
+	execOut      io.Writer
+	execErr      io.Writer
 	log15.Logger
 }
 
@@ -167,35 +217,11 @@ type envStr struct {
 // while connecting, but for all subsequent interactions with it using the
 // returned Client.
 func Connect(addr, caFile, certDomain string, token []byte, timeout time.Duration) (*Client, error) {
-	sock, err := req.NewSocket()
-	if err != nil {
-		return nil, err
-	}
-
-	if err = sock.SetOption(mangos.OptionMaxRecvSize, 0); err != nil {
-		return nil, err
-	}
-
-	err = sock.SetOption(mangos.OptionRecvDeadline, timeout)
+	sock, err := newClientSocket(addr, caFile, certDomain, timeout)
 	if err != nil {
 		return nil, err
 	}
 
-	sock.AddTransport(tlstcp.NewTransport())
-	tlsConfig := &tls.Config{ServerName: certDomain}
-	caCert, err := ioutil.ReadFile(caFile)
-	if err == nil {
-		certPool := x509.NewCertPool()
-		certPool.AppendCertsFromPEM(caCert)
-		tlsConfig.RootCAs = certPool
-	}
-
-	dialOpts := make(map[string]interface{})
-	dialOpts[mangos.OptionTLSConfig] = tlsConfig
-	if err = sock.DialOptions("tls+tcp://"+addr, dialOpts); err != nil {
-		return nil, err
-	}
-
 	// clients identify themselves (only for the purpose of calling methods that
 	// require the client has previously used Reserve()) with a UUID; v4 is used
 	// since speed doesn't matter: a typical client executable will only
@@ -205,25 +231,47 @@ func Connect(addr, caFile, certDomain string, token []byte, timeout time.Duratio
 	if err != nil {
 		return nil, err
 	}
-	addrParts := strings.Split(addr, ":")
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		errc := sock.Close()
+		if errc != nil {
+			return nil, errc
+		}
+		return nil, err
+	}
+
+	// we tell the server who we are, so it can record who asked for changes
+	// to jobs; if we can't tell, the server just won't know
+	user, _ := internal.Username()
+
 	c := &Client{
-		sock:     sock,
 		ch:       new(codec.BincHandle),
 		token:    token,
+		user:     user,
 		clientid: u,
-		host:     addrParts[0],
-		port:     addrParts[1],
+		host:     host,
+		port:     port,
 		args:     []string{addr, caFile, certDomain},
+		timeout:  timeout,
 	}
 
 	c.Logger = log15.New()
 	c.Logger.SetHandler(log15.DiscardHandler())
 
+	// further sockets are connected on demand, when concurrent requests need
+	// them, to the server's current address
+	c.conn = &clientConn{pool: newSocketPool(sock, ClientPoolSize, func() (mangos.Socket, error) {
+		c.Lock()
+		addr, caFile, certDomain := c.args[0], c.args[1], c.args[2]
+		c.Unlock()
+		return newClientSocket(addr, caFile, certDomain, timeout)
+	})}
+
 	// Dial succeeds even when there's no server up, so we test the connection
 	// works with a Ping()
 	si, err := c.Ping(timeout)
 	if err != nil {
-		errc := sock.Close()
+		errc := c.conn.pool.close()
 		if errc != nil {
 			return c, errc
 		}
@@ -238,12 +286,153 @@ func Connect(addr, caFile, certDomain string, token []byte, timeout time.Duratio
 	return c, err
 }
 
+// newClientSocket creates a socket connected to the server at the given
+// address, using the same arguments as Connect().
+func newClientSocket(addr, caFile, certDomain string, timeout time.Duration) (mangos.Socket, error) {
+	sock, err := req.NewSocket()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = sock.SetOption(mangos.OptionMaxRecvSize, 0); err != nil {
+		return nil, err
+	}
+
+	err = sock.SetOption(mangos.OptionRecvDeadline, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	sock.AddTransport(tlstcp.NewTransport())
+	tlsConfig := &tls.Config{ServerName: certDomain}
+	caCert, err := ioutil.ReadFile(caFile)
+	if err == nil {
+		certPool := x509.NewCertPool()
+		certPool.AppendCertsFromPEM(caCert)
+		tlsConfig.RootCAs = certPool
+	}
+
+	dialOpts := make(map[string]interface{})
+	dialOpts[mangos.OptionTLSConfig] = tlsConfig
+	if err = sock.DialOptions("tls+tcp://"+dualStackAddr(addr, timeout), dialOpts); err != nil {
+		return nil, err
+	}
+
+	return sock, err
+}
+
+// dualStackAddr returns addr with its host name replaced by the IP address we
+// should connect to. The mangos dialer only ever uses the first address a name
+// resolves to (preferring IPv4), which is no good for a manager that is only
+// reachable over IPv6 on a dual-stack network, so when a name resolves to
+// both IPv4 and IPv6 addresses we race connections to them using the "happy
+// eyeballs" algorithm of net.Dialer, and use whichever connects first. addr is
+// returned unchanged if its host is already an IP address, resolves to only
+// one address family, or can't be connected to.
+func dualStackAddr(addr string, timeout time.Duration) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) != nil {
+		return addr
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return addr
+	}
+	var v4, v6 bool
+	for _, ip := range ips {
+		if ip.IP.To4() != nil {
+			v4 = true
+		} else {
+			v6 = true
+		}
+	}
+	if !v4 || !v6 {
+		return addr
+	}
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return addr
+	}
+	remote, ok := conn.RemoteAddr().(*net.TCPAddr)
+	if errc := conn.Close(); errc != nil || !ok {
+		return addr
+	}
+	return net.JoinHostPort(remote.IP.String(), port)
+}
+
 // Disconnect closes the connection to the jobqueue server. It is CRITICAL that
 // you call Disconnect() before calling Connect() again in the same process.
+// This also disconnects any copies of the client made with WithContext().
 func (c *Client) Disconnect() error {
-	c.Lock()
-	defer c.Unlock()
-	return c.sock.Close()
+	c.conn.Lock()
+	c.conn.closed = true
+	if c.conn.stopKA != nil {
+		close(c.conn.stopKA)
+		c.conn.stopKA = nil
+	}
+	pool := c.conn.pool
+	c.conn.Unlock()
+	return pool.close()
+}
+
+// SetOutageTolerance makes the client robust to the server being briefly
+// unreachable: when a request fails due to a network problem, instead of
+// returning an error immediately, the client reconnects and retries the
+// request with an exponential backoff, for up to the given tolerance. Retries
+// are recognised by the server, so requests are never carried out twice.
+//
+// While the tolerance is greater than 0, the client also pings the server when
+// it hasn't otherwise made a request for ClientKeepAliveInterval, so that a
+// lost connection is noticed and re-established while idle.
+//
+// The default tolerance is 0, meaning that requests are not retried.
+func (c *Client) SetOutageTolerance(tolerance time.Duration) {
+	c.conn.Lock()
+	defer c.conn.Unlock()
+	c.conn.tolerance = tolerance
+	switch {
+	case tolerance > 0 && c.conn.stopKA == nil && !c.conn.closed:
+		c.conn.stopKA = make(chan struct{})
+		go c.keepAlive(c.conn.stopKA)
+	case tolerance <= 0 && c.conn.stopKA != nil:
+		close(c.conn.stopKA)
+		c.conn.stopKA = nil
+	}
+}
+
+// outageTolerance returns the tolerance set with SetOutageTolerance().
+func (c *Client) outageTolerance() time.Duration {
+	c.conn.Lock()
+	defer c.conn.Unlock()
+	return c.conn.tolerance
+}
+
+// keepAlive pings the server whenever we've been idle for
+// ClientKeepAliveInterval, until the given channel is closed.
+func (c *Client) keepAlive(stop chan struct{}) {
+	ticker := time.NewTicker(ClientKeepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.conn.Lock()
+			idle := time.Since(c.conn.lastReq)
+			c.conn.Unlock()
+			if idle < ClientKeepAliveInterval {
+				continue
+			}
+			if _, err := c.Ping(c.timeout); err != nil {
+				c.Warn("keepalive ping failed", "err", err)
+			}
+		case <-stop:
+			return
+		}
+	}
 }
 
 // SetLogger sets the logger, if you want to get debug type messages when
@@ -365,27 +554,116 @@ func (c *Client) BackupDB(path string) error {
 // The envVars argument is a slice of ("key=value") strings with the environment
 // variables you want to be set when the job's Cmd actually runs. Typically you
 // would pass in os.Environ().
+//
+// The jobs' Behaviours are Validate()d first, and nothing is added if any are
+// invalid.
 func (c *Client) Add(jobs []*Job, envVars []string, ignoreComplete bool) (added, existed int, err error) {
+	if err = validateBehaviours(jobs); err != nil {
+		return 0, 0, err
+	}
 	compressed, err := c.CompressEnv(envVars)
 	if err != nil {
 		return 0, 0, err
 	}
-	resp, err := c.request(&clientRequest{Method: "add", Jobs: jobs, Env: compressed, IgnoreComplete: ignoreComplete})
+	resp, err := c.request(&clientRequest{Method: "add", Jobs: jobs, Env: compressed, IgnoreComplete: ignoreComplete, SecretGrants: c.grants()})
 	if err != nil {
 		return 0, 0, err
 	}
 	return resp.Added, resp.Existed, err
 }
 
+// AddOutcome describes what happened to a Job when it was added.
+type AddOutcome string
+
+// AddOutcome* constants are the possible AddOutcomes. AddOutcomeAdded means the
+// Job was accepted and newly added to the queue. AddOutcomeExisted means an
+// identical Job (one with the same Key()) was already live in the queue, so it
+// was not added again. AddOutcomeComplete means an identical Job had already
+// completed and was not added again because ignoreComplete was true.
+// AddOutcomeCached means the Job was Cacheable and was completed with the
+// results of a previous run instead of being added. AddOutcomeInvalid means
+// the Job could not be added, for the AddResult's Reason.
+const (
+	AddOutcomeAdded    AddOutcome = "added"
+	AddOutcomeExisted  AddOutcome = "existed"
+	AddOutcomeComplete AddOutcome = "complete"
+	AddOutcomeCached   AddOutcome = "cached"
+	AddOutcomeInvalid  AddOutcome = "invalid"
+)
+
+// AddResult describes what happened to one of the Jobs given to
+// AddWithToken().
+type AddResult struct {
+	Key     string
+	Outcome AddOutcome
+	Reason  string // why an AddOutcomeInvalid Job could not be added
+}
+
+// NewAddToken returns a new random token suitable for use with AddWithToken().
+func NewAddToken() (string, error) {
+	u, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// AddWithToken is like Add(), but makes the submission idempotent: the server
+// remembers its response to the batch of jobs identified by the given token
+// (eg. one from NewAddToken()) for ServerAddTokenTime, and if you submit the
+// same batch with the same token again in that time, perhaps because the first
+// attempt timed out and you don't know if the jobs were accepted, you get the
+// original response instead of the jobs being added again. If the first
+// attempt is still being worked on, the resubmission waits for it to finish.
+//
+// Submitting a different batch of jobs with a token that was already used
+// returns an error (ErrAddTokenReused). If the original attempt failed, the
+// token is forgotten, so a resubmission is carried out afresh.
+//
+// As well as the counts that Add() returns, you get the outcome for each of
+// the given jobs, in the same order. Unlike Add(), jobs that are invalid (eg.
+// because of bad Behaviours or LimitGroups) don't cause the whole batch to
+// fail; they are just not added, and their results have AddOutcomeInvalid and
+// the reason.
+func (c *Client) AddWithToken(jobs []*Job, envVars []string, ignoreComplete bool, token string) (added, existed int, results []*AddResult, err error) {
+	if token == "" {
+		return 0, 0, nil, Error{"add", "", ErrBadRequest}
+	}
+	compressed, err := c.CompressEnv(envVars)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	resp, err := c.request(&clientRequest{Method: "add", Jobs: jobs, Env: compressed, IgnoreComplete: ignoreComplete, AddToken: token, ReturnResults: true, SecretGrants: c.grants()})
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return resp.Added, resp.Existed, resp.AddResults, err
+}
+
+// validateBehaviours checks the Behaviours of the given jobs, so that Add()
+// can return a descriptive error, instead of the server's generic
+// ErrBadBehaviour.
+func validateBehaviours(jobs []*Job) error {
+	for _, job := range jobs {
+		if err := job.Behaviours.Validate(); err != nil {
+			return fmt.Errorf("job [%s]: %w", job.Cmd, err)
+		}
+	}
+	return nil
+}
+
 // AddAndReturnIDs is like Add(), except that the internal IDs of jobs that are
 // now in the queue are returned (including dups, excluding complete jobs). This
 // is potentially expensive, so use Add() if you don't need these.
 func (c *Client) AddAndReturnIDs(jobs []*Job, envVars []string, ignoreComplete bool) ([]string, error) {
+	if err := validateBehaviours(jobs); err != nil {
+		return nil, err
+	}
 	compressed, err := c.CompressEnv(envVars)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.request(&clientRequest{Method: "add", Jobs: jobs, Env: compressed, IgnoreComplete: ignoreComplete, ReturnIDs: true})
+	resp, err := c.request(&clientRequest{Method: "add", Jobs: jobs, Env: compressed, IgnoreComplete: ignoreComplete, ReturnIDs: true, SecretGrants: c.grants()})
 	if err != nil {
 		return nil, err
 	}
@@ -406,7 +684,7 @@ func (c *Client) AddAndReturnIDs(jobs []*Job, envVars []string, ignoreComplete b
 // like the command line was changed).
 func (c *Client) Modify(jes []*JobEssence, modifier *JobModifier) (modified map[string]string, err error) {
 	keys := c.jesToKeys(jes)
-	resp, err := c.request(&clientRequest{Method: "jmod", Keys: keys, Modifier: modifier})
+	resp, err := c.request(&clientRequest{Method: "jmod", Keys: keys, Modifier: modifier, SecretGrants: c.grants()})
 	if err != nil {
 		return nil, err
 	}
@@ -427,12 +705,7 @@ func (c *Client) Modify(jes []*JobEssence, modifier *JobModifier) (modified map[
 // server configured with a RunnerCmd), this will most likely not return any
 // jobs; use ReserveScheduled() instead.
 func (c *Client) Reserve(timeout time.Duration) (*Job, error) {
-	fr := false
-	if !c.hasReserved {
-		fr = true
-		c.hasReserved = true
-	}
-	resp, err := c.request(&clientRequest{Method: "reserve", Timeout: timeout, FirstReserve: fr})
+	resp, err := c.request(&clientRequest{Method: "reserve", Timeout: timeout, FirstReserve: c.conn.firstReserve(), Host: reservingHost()})
 	if err != nil {
 		return nil, err
 	}
@@ -451,18 +724,48 @@ func (c *Client) Reserve(timeout time.Duration) (*Job, error) {
 // does not make sense for you to call this yourself; it is only for use by
 // runners spawned by the server.
 func (c *Client) ReserveScheduled(timeout time.Duration, schedulerGroup string) (*Job, error) {
-	fr := false
-	if !c.hasReserved {
-		fr = true
-		c.hasReserved = true
+	resp, err := c.request(&clientRequest{Method: "reserve", Timeout: timeout, SchedulerGroup: schedulerGroup, FirstReserve: c.conn.firstReserve(), Host: reservingHost()})
+	if err != nil {
+		return nil, err
 	}
-	resp, err := c.request(&clientRequest{Method: "reserve", Timeout: timeout, SchedulerGroup: schedulerGroup, FirstReserve: fr})
+	return resp.Job, err
+}
+
+// ReserveKey is like Reserve(), but reserves the job with the given key,
+// regardless of its scheduler group or priority, and whether it is delayed,
+// ready or buried. This is for running a particular job yourself, eg. to debug
+// it; the server records that the job was taken to be run manually.
+func (c *Client) ReserveKey(key string) (*Job, error) {
+	resp, err := c.request(&clientRequest{Method: "reservekey", Keys: []string{key}, Host: reservingHost()})
 	if err != nil {
 		return nil, err
 	}
 	return resp.Job, err
 }
 
+// SetExecuteIO makes subsequent Execute() calls connect the Cmd's STDIN to the
+// given reader, and copy its STDOUT and STDERR to the given writers (as well as
+// storing them as usual). Supply nils to go back to the default of the Cmd
+// having no input and its output only being stored.
+func (c *Client) SetExecuteIO(stdin io.Reader, stdout, stderr io.Writer) {
+	c.Lock()
+	defer c.Unlock()
+	c.execIn = stdin
+	c.execOut = stdout
+	c.execErr = stderr
+}
+
+// reservingHost returns the hostname we tell the server when reserving, so that
+// it can consider job Affinity, per-host limits and host draining. Returns "" if
+// the hostname can't be determined.
+func reservingHost() string {
+	host, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
 // Execute runs the given Job's Cmd and blocks until it exits. Then any Job
 // Behaviours get triggered as appropriate for the exit status.
 //
@@ -476,14 +779,28 @@ func (c *Client) ReserveScheduled(timeout time.Duration, schedulerGroup string)
 // same Cwd (that is, we will not break the directory listing of Cwd).
 // Furthermore, a sister folder will be created in the unique location for this
 // Job, the path to which will become the value of the TMPDIR environment
-// variable. Once the Cmd exits, this temp directory will be deleted and the
-// path to the actual working directory created will be in the Job's ActualCwd
-// property. The unique folder structure itself can be wholly deleted through
-// the Job behaviour "cleanup".
+// variable. (If JobScratchDir is set, TMPDIR is instead a unique folder within
+// that, whatever CwdMatters is, and the Cmd is killed if it uses more of it
+// than the Job's disk Requirements, or fills its disk.) Once the Cmd exits, this
+// temp directory will be deleted and the path to the actual working directory
+// created will be in the Job's ActualCwd property. The unique folder structure itself can be wholly deleted through
+// the Job behaviour "cleanup". The Job's CwdTemplate, CwdBase and CwdLink can
+// change where and how the unique subdirectory is created.
 //
 // If any remote file system mounts have been configured for the Job, these are
 // mounted prior to running the Cmd, and unmounted afterwards.
 //
+// If the Job's Requirements say it needs more disk space than is currently
+// available on the file system holding its Cwd, the Cmd is not run; instead
+// the Job is Release()d with FailReasonHostDisk, so that it can run on another
+// host, and an Error with that Err is returned. You should check for this and
+// stop reserving jobs that need as much disk.
+//
+// If the Cmd exits successfully and the Job has a ReportCmd, that is run
+// afterwards in the same working directory and environment, and the key=value
+// lines it outputs are stored as the Job's Metrics. If the ReportCmd fails, the
+// Job still succeeds, but without Metrics.
+//
 // Internally, Execute() calls Mount() and Started() and keeps track of peak RAM
 // and disk used. It regularly calls Touch() on the Job so that the server knows
 // we are still alive and handling the Job successfully. It also intercepts
@@ -491,19 +808,44 @@ func (c *Client) ReserveScheduled(timeout time.Duration, schedulerGroup string)
 // Cmd and returning Error.Err(FailReasonSignal); you should check for this and
 // exit your process. Finally it calls Unmount() and TriggerBehaviours().
 //
+// While it mounts file systems and fetches inputs before starting the Cmd, and
+// while it uploads outputs after the Cmd exits, Execute() tells the server, so
+// that the Job is reported as being in JobStateStaging or JobStateUploading
+// instead of JobStateRunning.
+//
 // If Kill() is called while executing the Cmd, the next internal Touch() call
 // will result in the Cmd being killed and the job being Bury()ied.
 //
+// If the host we're running on is being drained (see DrainHost()), the next
+// internal Touch() call will result in the Cmd being sent SIGTERM, giving it
+// the chance to checkpoint its work and exit, and then SIGKILL if it hasn't
+// exited by the end of the drain's grace period. The job is then Release()d
+// without it counting against its Retries, and Error.Err(FailReasonDrained) is
+// returned; you should check for this and stop reserving jobs.
+//
+// If SetOutageTolerance() has been used and we fail to Touch() the job for
+// longer than the tolerance, the manager is assumed to have given up on us: the
+// Cmd is killed, we briefly try to Release() the job without it counting
+// against its Retries, and Error.Err(FailReasonAbandoned) is returned.
+//
 // If no error is returned, the Cmd will have run OK, exited with status 0, and
 // been Archive()d from the queue while being placed in the permanent store.
 // Otherwise, it will have been Release()d or Bury()ied as appropriate.
 //
-// The supplied shell is the shell to execute the Cmd under, ideally bash
-// (something that understands the command "set -o pipefail").
+// The Cmd's processes are given the Job's Nice, IONice and OOMScoreAdj, or
+// those of DefaultPoliteness for any the Job doesn't specify. Likewise, the
+// Cmd is run with the Job's Umask and its files are given its Group, or those
+// of DefaultFilePermissions.
+//
+// The supplied shell is the shell to execute the Cmd under if the Job didn't
+// specify its own Shell, ideally bash (something that understands the command
+// "set -o pipefail"), optionally followed by arguments such as -l for a login
+// shell, or ShellNone to run the Cmd directly.
 //
 // You have to have been the one to Reserve() the supplied Job, or this will
-// immediately return an error. NB: the peak RAM tracking assumes we are running
-// on a modern linux system with /proc/*/smaps.
+// immediately return an error. NB: peak RAM tracking is most accurate on modern
+// linux systems with /proc/*/smaps; elsewhere (eg. macOS) the resident set size
+// of the Cmd's processes is used instead.
 func (c *Client) Execute(job *Job, shell string) error {
 	logger := c.Logger.New("job", job.Key())
 
@@ -516,12 +858,51 @@ func (c *Client) Execute(job *Job, shell string) error {
 	}
 
 	// we support arbitrary shell commands that may include semi-colons,
-	// quoted stuff and pipes, so it's best if we just pass it to bash
+	// quoted stuff and pipes, so it's best if we just pass it to a shell (the
+	// job's own choice if it made one), unless it wants to be run directly
+	shell = jobShell(job, shell)
+	job.Lock()
+	job.execShell = shell
+	job.execLogger = logger
+	job.execClient = c
+	job.Unlock()
 	jc := job.Cmd
-	if strings.Contains(jc, " | ") {
-		jc = "set -o pipefail; " + jc
+
+	// if we're to run as a different user, do so via sudo, and fail early if
+	// we're not allowed to
+	runAs := needsRunAs(job.RunAs)
+	runAsUser := ""
+	if runAs {
+		if errr := checkRunAs(job.RunAs); errr != nil {
+			errb := c.Bury(job, nil, FailReasonRunAs, errr)
+			if errb != nil {
+				errr = fmt.Errorf("%v (and burying the job failed: %w)", errr, errb)
+			}
+			return errr
+		}
+		runAsUser = job.RunAs
+	}
+
+	// fail early if we won't be able to give the cmd's files the desired group
+	perms := job.filePermissions(DefaultFilePermissions)
+	gid, errg := perms.gid()
+	if errg != nil {
+		errb := c.Bury(job, nil, FailReasonPerms, errg)
+		if errb != nil {
+			errg = fmt.Errorf("%v (and burying the job failed: %w)", errg, errb)
+		}
+		return errg
+	}
+
+	cmd, err := shellCommand(shell, jc, runAsUser)
+	if err != nil {
+		err = fmt.Errorf("could not run cmd [%s] with shell %s: %w", jc, shell, err)
+		errb := c.Bury(job, nil, FailReasonStart, err)
+		if errb != nil {
+			err = fmt.Errorf("%v (and burying the job failed: %w)", err, errb)
+		}
+		return err
 	}
-	cmd := exec.Command(shell, "-c", jc) // #nosec Our whole purpose is to allow users to run arbitrary commands via us...
 
 	// we'll filter STDERR/OUT of the cmd to keep only the first and last line
 	// of any contiguous block of \r terminated lines (to mostly eliminate
@@ -531,13 +912,25 @@ func (c *Client) Execute(job *Job, shell string) error {
 		return fmt.Errorf("failed to create a pipe for STDERR from cmd [%s]: %w", jc, err)
 	}
 	stderr := &prefixSuffixSaver{N: 4096}
-	stderrWait := stdFilter(errReader, stderr)
 	outReader, err := cmd.StdoutPipe()
 	if err != nil {
 		return fmt.Errorf("failed to create a pipe for STDOUT from cmd [%s]: %w", jc, err)
 	}
 	stdout := &prefixSuffixSaver{N: 4096}
-	stdoutWait := stdFilter(outReader, stdout)
+
+	// (if SetExecuteIO() was used, the user also sees the output as it happens)
+	c.Lock()
+	cmd.Stdin = c.execIn
+	var errSource, outSource io.Reader = errReader, outReader
+	if c.execErr != nil {
+		errSource = io.TeeReader(errReader, c.execErr)
+	}
+	if c.execOut != nil {
+		outSource = io.TeeReader(outReader, c.execOut)
+	}
+	c.Unlock()
+	stderrWait := stdFilter(errSource, stderr)
+	stdoutWait := stdFilter(outSource, stdout)
 
 	// we'll run the command from the desired directory, which must exist or
 	// it will fail
@@ -552,13 +945,30 @@ func (c *Client) Execute(job *Job, shell string) error {
 			return fmt.Errorf("working directory [%s] does not exist%s: %w", job.Cwd, extra, errm)
 		}
 	}
+	// rather than let the cmd fail for lack of space hours into its run, check
+	// now that there's as much disk as it said it needed where it will work
+	diskDir := job.cwdBase()
+	if job.Requirements != nil && job.Requirements.Disk > 0 {
+		free, errd := diskAvailable(diskDir)
+		if errd != nil {
+			logger.Warn("could not check available disk space", "dir", diskDir, "err", errd)
+		} else if free < job.Requirements.Disk {
+			logger.Warn("insufficient disk space", "dir", diskDir, "available", free, "required", job.Requirements.Disk)
+			errr := c.Release(job, nil, FailReasonHostDisk)
+			if errr != nil {
+				return fmt.Errorf("%s (only %dGB available at %s, %dGB required), and releasing the job failed: %w", FailReasonHostDisk, free, diskDir, job.Requirements.Disk, errr)
+			}
+			return Error{"Execute", job.Key(), FailReasonHostDisk}
+		}
+	}
+
 	var actualCwd, tmpDir string
 	var dirsToCheckDiskSpace []string
 	if job.CwdMatters {
 		cmd.Dir = job.Cwd
 	} else {
 		// we'll create a unique location to work in
-		actualCwd, tmpDir, err = mkHashedDir(job.Cwd, job.Key())
+		actualCwd, tmpDir, err = job.mkActualCwd()
 		if err != nil {
 			buryErr := fmt.Errorf("could not create working directory: %w", err)
 			errb := c.Bury(job, nil, FailReasonCwd, buryErr)
@@ -568,26 +978,97 @@ func (c *Client) Execute(job *Job, shell string) error {
 			return buryErr
 		}
 		cmd.Dir = actualCwd
+		var errp error
+		if runAs {
+			// the other user must be able to write to the dirs we created
+			errp = grantRunAsAccess(job.RunAs, gid, actualCwd, tmpDir)
+		} else {
+			errp = perms.applyToDirs(gid, actualCwd, tmpDir)
+		}
+		if errp != nil {
+			buryErr := fmt.Errorf("could not set the permissions of the working directory: %w", errp)
+			errb := c.Bury(job, nil, FailReasonPerms, buryErr)
+			if errb != nil {
+				buryErr = fmt.Errorf("%v (and burying the job failed: %w)", buryErr, errb)
+			}
+			return buryErr
+		}
 		job.Lock()
 		job.ActualCwd = actualCwd
+		errl := job.linkActualCwd()
 		job.Unlock()
+		if errl != nil {
+			logger.Warn("could not link to the working directory from Cwd", "err", errl)
+		}
+	}
+
+	var scratchDir string
+	if JobScratchDir != "" {
+		// the job's TMPDIR will be on the scratch disk instead
+		scratchDir, err = mkScratchDir(JobScratchDir, job.Key())
+		if err == nil {
+			if runAs {
+				err = grantRunAsAccess(job.RunAs, gid, scratchDir)
+			} else {
+				err = perms.applyToDirs(gid, scratchDir)
+			}
+		}
+		if err != nil {
+			buryErr := fmt.Errorf("could not create scratch directory: %w", err)
+			errb := c.Bury(job, nil, FailReasonCwd, buryErr)
+			if errb != nil {
+				buryErr = fmt.Errorf("%v (and burying the job failed: %w)", buryErr, errb)
+			}
+			return buryErr
+		}
+		if tmpDir != "" {
+			if errr := os.Remove(tmpDir); errr != nil {
+				logger.Warn("could not remove unused tmp dir", "dir", tmpDir, "err", errr)
+			}
+		}
+		tmpDir = scratchDir
+	}
+	if tmpDir != "" {
 		dirsToCheckDiskSpace = append(dirsToCheckDiskSpace, tmpDir)
+
+		// however we exit, and whatever behaviours run, don't leave the tmp
+		// dir behind
+		defer func() {
+			if errr := os.RemoveAll(tmpDir); errr != nil {
+				logger.Warn("removing the tmpdir failed", "dir", tmpDir, "err", errr)
+			}
+		}()
 	}
 
 	// before doing any other pre-start tasks, which might take time, start
 	// touching the job, and keep doing so until after we've run the job and
 	// carried out post-exit tasks
-	touchTicker := time.NewTicker(ClientTouchInterval) //*** this should be less than the ServerItemTTR set when the server started, not a fixed value
+	touchTicker := time.NewTicker(c.heartbeatInterval())
 
 	var wkbsMutex sync.RWMutex
 	whenKilledByServer := func() {}
+	var whenRequeuedByServer func(grace time.Duration)
+	var whenAbandoned func()
 	stopTouching := make(chan bool, 2)
 	stopChecking := make(chan bool, 2)
 	go func() {
+		requeueStarted := false
+		lastTouched := time.Now()
 		for {
 			select {
 			case <-touchTicker.C:
-				kc, errf := c.Touch(job)
+				kc, requeue, grace, errf := c.touch(job)
+				if requeue && !requeueStarted {
+					wkbsMutex.RLock()
+					whenRequeued := whenRequeuedByServer
+					wkbsMutex.RUnlock()
+					if whenRequeued != nil {
+						// keep touching while the cmd checkpoints and exits
+						requeueStarted = true
+						logger.Warn("requeue requested because host is being drained")
+						go whenRequeued(grace)
+					}
+				}
 				if kc {
 					wkbsMutex.RLock()
 					defer wkbsMutex.RUnlock()
@@ -599,10 +1080,27 @@ func (c *Client) Execute(job *Job, shell string) error {
 				}
 				if errf != nil {
 					// we may have lost contact with the manager; this is OK. We
-					// will keep trying to touch until it works
+					// will keep trying to touch until it works, unless we've
+					// been out of contact for longer than our outage tolerance,
+					// in which case the manager will have given up on us and we
+					// abandon the job
 					logger.Warn("could not touch", "err", errf)
+					tolerance := c.outageTolerance()
+					if tolerance > 0 && time.Since(lastTouched) > tolerance {
+						wkbsMutex.RLock()
+						abandon := whenAbandoned
+						wkbsMutex.RUnlock()
+						if abandon != nil {
+							logger.Error("abandoning job after losing contact with the manager", "tolerance", tolerance)
+							abandon()
+							touchTicker.Stop()
+							stopChecking <- true
+							return
+						}
+					}
 					continue
 				}
+				lastTouched = time.Now()
 			case <-stopTouching:
 				touchTicker.Stop()
 				return
@@ -665,6 +1163,14 @@ func (c *Client) Execute(job *Job, shell string) error {
 		}
 	}
 
+	// let the server know that we'll be spending time moving data before the
+	// cmd can start
+	if job.stagesData() {
+		if errp := c.reportPhase(job, JobStateStaging); errp != nil {
+			logger.Warn("failed to report staging", "err", errp)
+		}
+	}
+
 	// we'll mount any configured remote file systems
 	uniqueCacheDirs, uniqueMountedDirs, err := job.Mount(onCwd)
 	if err != nil && !mountCouldFail {
@@ -685,6 +1191,41 @@ func (c *Client) Execute(job *Job, shell string) error {
 		}
 	}
 
+	// make sure the cmd will be able to read its input files
+	if missing := job.missingInputs(cmd.Dir); len(missing) > 0 {
+		stopTouching <- true
+		buryErr := fmt.Errorf("%s: %s", FailReasonInput, strings.Join(missing, "; "))
+		errb := c.Bury(job, nil, FailReasonInput, buryErr)
+		if errb != nil {
+			buryErr = fmt.Errorf("%v (and burying the job failed: %w)", buryErr, errb)
+		}
+		_, erru := job.Unmount(true)
+		if erru != nil {
+			buryErr = fmt.Errorf("%v (and unmounting the job failed: %w)", buryErr, erru)
+		}
+		return buryErr
+	}
+
+	// note what the cmd's inputs were, so later identical jobs can use our
+	// results if their inputs are the same. If they're the same as those of a
+	// previous run, we use its results instead of running the cmd
+	var inputChecksums map[string]string
+	if job.Cacheable {
+		var errc error
+		inputChecksums, errc = job.checksumInputs(cmd.Dir, logger)
+		if errc != nil {
+			logger.Warn("could not checksum inputs, so results won't be reusable", "err", errc)
+		} else if sameInputs(inputChecksums, job.CachedInputChecksums) {
+			errc = c.archiveCached(job, inputChecksums)
+			if errc == nil {
+				logger.Info("completed using the results of a previous run with the same inputs")
+				stopTouching <- true
+				return c.finishCached(job)
+			}
+			logger.Warn("could not use the results of a previous run, so running", "err", errc)
+		}
+	}
+
 	// later, check mount cache dirs for disk usage
 	if len(uniqueCacheDirs) > 0 {
 		dirsToCheckDiskSpace = append(dirsToCheckDiskSpace, uniqueCacheDirs...)
@@ -732,8 +1273,12 @@ func (c *Client) Execute(job *Job, shell string) error {
 	// and we'll run it with the environment variables that were present when
 	// the command was first added to the queue (or if none, current env vars,
 	// and in either case, including any overrides) *** we need a way for users
-	// to update a job with new env vars
+	// to update a job with new env vars. If the job wants a clean environment,
+	// those captured are instead applied on top of a fresh login environment
 	env, err := job.Env()
+	if err == nil && job.CleanEnv {
+		env = envOverride(cleanLoginEnv(loginShell(shell)), env)
+	}
 	if err != nil {
 		stopTouching <- true
 		errb := c.Bury(job, nil, FailReasonEnv)
@@ -747,21 +1292,31 @@ func (c *Client) Execute(job *Job, shell string) error {
 		}
 		return fmt.Errorf("failed to extract environment variables for job [%s]: %w%s", job.Key(), err, extra)
 	}
+
+	// secrets are only ever fetched now, and are only set in the cmd's env
+	var secretEnv []string
+	if len(job.Secrets) > 0 {
+		secretEnv, err = c.getSecrets(job)
+		if err != nil {
+			stopTouching <- true
+			errb := c.Bury(job, nil, FailReasonSecret, err)
+			extra := ""
+			if errb != nil {
+				extra = fmt.Sprintf(" (and burying the job failed: %s)", errb)
+			}
+			_, erru := job.Unmount(true)
+			if erru != nil {
+				extra += fmt.Sprintf(" (and unmounting the job failed: %s)", erru)
+			}
+			return fmt.Errorf("failed to get secrets for job [%s]: %w%s", job.Key(), err, extra)
+		}
+		env = envOverride(env, secretEnv)
+	}
 	if tmpDir != "" {
 		// (this works fine even if tmpDir has a space in one of the dir names)
 		env = envOverride(env, []string{"TMPDIR=" + tmpDir})
-		defer func() {
-			errr := os.RemoveAll(tmpDir)
-			if errr != nil {
-				if myerr == nil {
-					myerr = errr
-				} else {
-					myerr = fmt.Errorf("%v (and removing the tmpdir failed: %w)", myerr, errr)
-				}
-			}
-		}()
 
-		if job.ChangeHome {
+		if job.ChangeHome && actualCwd != "" {
 			env = envOverride(env, []string{"HOME=" + actualCwd})
 		}
 	}
@@ -810,18 +1365,97 @@ func (c *Client) Execute(job *Job, shell string) error {
 			"LSF_BINDIR=" + prependPath,
 		})
 	}
+
+	// if the local scheduler pinned us to particular cores, tell the cmd which
+	// ones, so it can size its thread pools accordingly
+	var allotment []string
+	for _, name := range []string{scheduler.EnvCores, scheduler.EnvNUMANode} {
+		if val, set := os.LookupEnv(name); set {
+			allotment = append(allotment, name+"="+val)
+		}
+	}
+	if len(allotment) > 0 {
+		env = envOverride(env, allotment)
+	}
+	if job.Proxy != "" {
+		env = envOverride(env, job.proxyEnv())
+	}
+
+	// let instrumented cmds add their own spans to the trace of this run
+	if tp := job.traceParent(job.Attempts + 1); tp != "" {
+		env = envOverride(env, []string{traceParentEnv + "=" + tp})
+	}
 	cmd.Env = env
 
-	// if docker monitoring has been requested, try and get the docker client
-	// now and fail early if we can't
-	var dockerClient *internal.DockerClient
-	var monitorDocker, getFirstDockerContainer bool
-	if job.MonitorDocker != "" {
-		monitorDocker = true
-		dockerClient, err = internal.NewDockerClient()
-		if err != nil {
+	// fail early if we don't have the network access the cmd needs
+	if len(job.NetworkAccess) > 0 {
+		if unreachable := job.unreachableNetworks(); len(unreachable) > 0 {
 			stopTouching <- true
-			buryErr := fmt.Errorf("failed to create docker client: %w", err)
+			errr := c.Release(job, nil, FailReasonNetwork)
+			extra := ""
+			if errr != nil {
+				extra = fmt.Sprintf(" (and releasing the job failed: %s)", errr)
+			}
+			_, erru := job.Unmount(true)
+			if erru != nil {
+				extra += fmt.Sprintf(" (and unmounting the job failed: %s)", erru)
+			}
+			return fmt.Errorf("%s for command [%s]: %s%s", FailReasonNetwork, jc, strings.Join(unreachable, "; "), extra)
+		}
+	}
+
+	// fetch any inputs from iRODS
+	if len(job.IRODSInputs) > 0 {
+		errs := newIRODSTransferer(job, shell, cmd.Dir, env, runAs, logger).stageIn(job)
+		if errs != nil {
+			stopTouching <- true
+			errr := c.Release(job, nil, FailReasonIRODS)
+			extra := ""
+			if errr != nil {
+				extra = fmt.Sprintf(" (and releasing the job failed: %s)", errr)
+			}
+			_, erru := job.Unmount(true)
+			if erru != nil {
+				extra += fmt.Sprintf(" (and unmounting the job failed: %s)", erru)
+			}
+			return fmt.Errorf("could not fetch iRODS inputs for command [%s]: %w%s", jc, errs, extra)
+		}
+	}
+
+	// get any reference assets from our host's cache
+	if len(job.RefAssets) > 0 {
+		cache := newRefAssetCache()
+		errc := cache.acquire(job, cmd.Dir)
+		defer func() {
+			if errr := cache.release(job, cmd.Dir); errr != nil {
+				logger.Warn("failed to release reference assets", "err", errr)
+			}
+		}()
+		if errc != nil {
+			stopTouching <- true
+			errr := c.Release(job, nil, FailReasonRefAsset)
+			extra := ""
+			if errr != nil {
+				extra = fmt.Sprintf(" (and releasing the job failed: %s)", errr)
+			}
+			_, erru := job.Unmount(true)
+			if erru != nil {
+				extra += fmt.Sprintf(" (and unmounting the job failed: %s)", erru)
+			}
+			return fmt.Errorf("could not fetch reference assets for command [%s]: %w%s", jc, errc, extra)
+		}
+	}
+
+	// if docker monitoring has been requested, try and get the docker client
+	// now and fail early if we can't
+	var dockerClient *internal.DockerClient
+	var monitorDocker, getFirstDockerContainer bool
+	if job.MonitorDocker != "" {
+		monitorDocker = true
+		dockerClient, err = internal.NewDockerClient()
+		if err != nil {
+			stopTouching <- true
+			buryErr := fmt.Errorf("failed to create docker client: %w", err)
 			errb := c.Bury(job, nil, FailReasonDocker, buryErr)
 			if errb != nil {
 				buryErr = fmt.Errorf("%v (and burying the job failed: %w)", buryErr, errb)
@@ -855,7 +1489,7 @@ func (c *Client) Execute(job *Job, shell string) error {
 
 	// start running the command
 	endT := time.Now().Add(job.Requirements.Time)
-	err = cmd.Start()
+	err = perms.start(cmd)
 	if err != nil {
 		// some obscure internal error about setting things up
 		stopTouching <- true
@@ -871,6 +1505,12 @@ func (c *Client) Execute(job *Job, shell string) error {
 		return fmt.Errorf("could not start command [%s]: %w%s", jc, err, extra)
 	}
 
+	// be polite to other users of the machine; failing to be isn't a reason to
+	// fail the job
+	if errp := job.politeness(DefaultPoliteness).apply(cmd.Process.Pid); errp != nil {
+		logger.Warn("could not make the cmd polite", "err", errp)
+	}
+
 	// update the server that we've started the job
 	err = c.Started(job, cmd.Process.Pid)
 	if err != nil {
@@ -904,9 +1544,13 @@ func (c *Client) Execute(job *Job, shell string) error {
 	ranoutDisk := false
 	signalled := false
 	killCalled := false
+	requeued := false
+	abandoned := false
+	cmdExited := make(chan bool)
 	var killErr error
 	var closeErr error
 	var stateMutex sync.Mutex
+	var scratchUsed int64
 	diskUsageCheck := func() (int64, error) {
 		var used int64
 		for _, dir := range dirsToCheckDiskSpace {
@@ -920,6 +1564,9 @@ func (c *Client) Execute(job *Job, shell string) error {
 			if thisErr != nil {
 				return 0, thisErr
 			}
+			if dir == scratchDir {
+				scratchUsed = thisUsed
+			}
 			used += thisUsed
 		}
 		return used, nil
@@ -933,7 +1580,12 @@ func (c *Client) Execute(job *Job, shell string) error {
 			children, errc := getChildProcesses(int32(cmd.Process.Pid))
 
 			// then kill *** race condition if cmd spawns more children...
-			errk := cmd.Process.Kill()
+			// (when running as another user, we can't kill sudo itself, but it
+			// will exit when we kill its children below)
+			var errk error
+			if !runAs {
+				errk = cmd.Process.Kill()
+			}
 
 			if errc != nil {
 				if errk == nil {
@@ -956,7 +1608,11 @@ func (c *Client) Execute(job *Job, shell string) error {
 			for _, child := range children {
 				// try and kill any children in case the above didn't already
 				// result in their death
-				errc = child.Kill()
+				if runAs {
+					errc = killAsUser(job.RunAs, child.Pid)
+				} else {
+					errc = child.Kill()
+				}
 				if errk == nil {
 					errk = errc
 				} else {
@@ -978,6 +1634,23 @@ func (c *Client) Execute(job *Job, shell string) error {
 			}
 		}
 
+		// when requeued we give the cmd the chance to checkpoint by sending
+		// it SIGTERM, and only kill it if it doesn't exit in time
+		terminateCmd := func() {
+			children, errc := getChildProcesses(int32(cmd.Process.Pid))
+			if errs := cmd.Process.Signal(syscall.SIGTERM); errs != nil {
+				logger.Warn("failed to send SIGTERM to cmd", "err", errs)
+			}
+			if errc != nil {
+				return
+			}
+			for _, child := range children {
+				if errt := child.Terminate(); errt != nil {
+					logger.Warn("failed to send SIGTERM to cmd child process", "err", errt)
+				}
+			}
+		}
+
 		wkbsMutex.Lock()
 		whenKilledByServer = func() {
 			killErr = killCmd()
@@ -986,6 +1659,35 @@ func (c *Client) Execute(job *Job, shell string) error {
 			stateMutex.Unlock()
 			closeReaders()
 		}
+		whenRequeuedByServer = func(grace time.Duration) {
+			stateMutex.Lock()
+			select {
+			case <-cmdExited:
+				// too late, the cmd already finished
+				stateMutex.Unlock()
+				return
+			default:
+			}
+			requeued = true
+			stateMutex.Unlock()
+			if !runAs {
+				terminateCmd()
+			}
+			select {
+			case <-cmdExited:
+				return
+			case <-time.After(grace):
+			}
+			killErr = killCmd()
+			closeReaders()
+		}
+		whenAbandoned = func() {
+			stateMutex.Lock()
+			abandoned = true
+			stateMutex.Unlock()
+			killErr = killCmd()
+			closeReaders()
+		}
 		wkbsMutex.Unlock()
 
 	CHECKING:
@@ -1006,7 +1708,7 @@ func (c *Client) Execute(job *Job, shell string) error {
 			case <-resourceTicker.C:
 				// always see if we've run out of disk space on the machine, in
 				// which case abort
-				if internal.NoDiskSpaceLeft(filepath.Dir(job.Cwd)) {
+				if internal.NoDiskSpaceLeft(filepath.Dir(job.cwdBase())) {
 					killErr = killCmd()
 					stateMutex.Lock()
 					ranoutDisk = true
@@ -1086,6 +1788,16 @@ func (c *Client) Execute(job *Job, shell string) error {
 				if errd == nil && disk > peakdisk {
 					peakdisk = disk
 				}
+
+				// don't let the job fill up the scratch disk, which is shared
+				// with other jobs
+				if scratchDir != "" && errd == nil && scratchFull(scratchDir, scratchUsed, job.Requirements.Disk) {
+					killErr = killCmd()
+					ranoutDisk = true
+					stateMutex.Unlock()
+					closeReaders()
+					break CHECKING
+				}
 				stateMutex.Unlock()
 			case <-stopChecking:
 				break CHECKING
@@ -1098,6 +1810,7 @@ func (c *Client) Execute(job *Job, shell string) error {
 	errsew := <-stderrWait
 	errsow := <-stdoutWait
 	err = cmd.Wait()
+	close(cmdExited)
 	resourceTicker.Stop()
 	stopChecking <- true
 	<-finishedChecking
@@ -1109,15 +1822,7 @@ func (c *Client) Execute(job *Job, shell string) error {
 	// know if we use too much memory and kill during a run), our method might
 	// miss a peak that cmd.ProcessState can tell us about, so use that if
 	// higher
-	peakRSS := cmd.ProcessState.SysUsage().(*syscall.Rusage).Maxrss
-	var peakRSSMB int
-	if runtime.GOOS == "darwin" {
-		// Maxrss values are bytes
-		peakRSSMB = int((peakRSS / 1024) / 1024)
-	} else {
-		// Maxrss values are kb
-		peakRSSMB = int(peakRSS / 1024)
-	}
+	peakRSSMB := maxRSSMB(cmd.ProcessState.SysUsage().(*syscall.Rusage))
 	if peakRSSMB > peakmem {
 		peakmem = peakRSSMB
 	}
@@ -1149,7 +1854,24 @@ func (c *Client) Execute(job *Job, shell string) error {
 	if job.UntilBuried > 1 {
 		mayBeTemp = ", which may be a temporary issue, so it will be tried again"
 	}
-	if err != nil {
+	if requeued || abandoned {
+		// regardless of how the command exited, it didn't get to finish
+		// because its host is being drained, or because we lost contact with
+		// the manager, so it should run again elsewhere
+		if exitError, ok := err.(*exec.ExitError); ok {
+			exitcode = exitError.Sys().(syscall.WaitStatus).ExitStatus()
+		} else if err == nil {
+			exitcode = cmd.ProcessState.Sys().(syscall.WaitStatus).ExitStatus()
+		} else {
+			exitcode = 255
+		}
+		dorelease = true
+		failreason = FailReasonDrained
+		if abandoned {
+			failreason = FailReasonAbandoned
+		}
+		myerr = Error{"Execute", job.Key(), failreason}
+	} else if err != nil {
 		// there was a problem running the command
 		if exitError, ok := err.(*exec.ExitError); ok {
 			exitcode = exitError.Sys().(syscall.WaitStatus).ExitStatus()
@@ -1208,6 +1930,61 @@ func (c *Client) Execute(job *Job, shell string) error {
 
 	finalStdErr := bytes.TrimSpace(stderr.Bytes())
 
+	// give the cmd's files the desired group; failing to isn't a reason to
+	// fail the job, but the user should know
+	if gid != -1 {
+		if problems := applyGroupToOutputs(gid, actualCwd, cmd.Dir, job.OutputFiles); len(problems) > 0 {
+			logger.Warn("could not give all files the desired group", "group", perms.Group, "problems", len(problems))
+			finalStdErr = append(finalStdErr, "\n\nFile group problems:\n"...)
+			finalStdErr = append(finalStdErr, strings.Join(problems, "\n")...)
+		}
+	}
+
+	// make sure the cmd really created the outputs it was supposed to, before
+	// behaviours might clean them up, so that truncated results aren't
+	// archived as a success
+	if doarchive && len(job.OutputFiles) > 0 {
+		if problems := validateOutputs(job, shell, cmd.Dir, cmd.Env, runAs, logger); len(problems) > 0 {
+			doarchive = false
+			dorelease = true
+			failreason = FailReasonOutput
+			myerr = fmt.Errorf("command [%s] exited 0 but had a %s%s", job.Cmd, FailReasonOutput, mayBeTemp)
+			finalStdErr = append(finalStdErr, "\n\nOutput problems:\n"...)
+			finalStdErr = append(finalStdErr, strings.Join(problems, "\n")...)
+		}
+	}
+
+	var outputRecords []OutputRecord
+	if doarchive && job.OutputChecksums && len(job.OutputFiles) > 0 {
+		var cerr error
+		outputRecords, cerr = recordOutputs(job, cmd.Dir, logger)
+		if cerr != nil {
+			finalStdErr = append(finalStdErr, "\n\nOutput checksum problems:\n"...)
+			finalStdErr = append(finalStdErr, cerr.Error()...)
+		}
+	}
+
+	// let the server know that we'll be spending time uploading outputs before
+	// we can finish
+	if job.uploadsData() {
+		if errp := c.reportPhase(job, JobStateUploading); errp != nil {
+			logger.Warn("failed to report uploading", "err", errp)
+		}
+	}
+
+	// archive the outputs in iRODS, treating failure to do so as failure of
+	// the cmd, since downstream consumers will expect to find them there
+	if doarchive && job.IRODSCollection != "" && len(job.OutputFiles) > 0 {
+		if ierr := newIRODSTransferer(job, shell, cmd.Dir, cmd.Env, runAs, logger).archive(job); ierr != nil {
+			doarchive = false
+			dorelease = true
+			failreason = FailReasonIRODS
+			myerr = fmt.Errorf("command [%s] exited 0 but its outputs could not be put in to iRODS%s", job.Cmd, mayBeTemp)
+			finalStdErr = append(finalStdErr, "\n\niRODS problems:\n"...)
+			finalStdErr = append(finalStdErr, ierr.Error()...)
+		}
+	}
+
 	if killErr != nil {
 		if myerr != nil {
 			myerr = fmt.Errorf("%v; killing the cmd also failed: %w", myerr, killErr)
@@ -1224,7 +2001,45 @@ func (c *Client) Execute(job *Job, shell string) error {
 		}
 	}
 
-	// run behaviours
+	// now that the cmd has worked, and before behaviours might clean up its
+	// working directory, find out what it wants to report about its results
+	var metrics map[string]string
+	if doarchive && job.ReportCmd != "" {
+		var rerr error
+		metrics, rerr = runReportCmd(job, shell, cmd.Dir, cmd.Env, secretEnv, runAs, logger)
+		if rerr != nil {
+			logger.Warn("report command failed", "err", rerr)
+			finalStdErr = append(finalStdErr, "\n\nReport command problems:\n"...)
+			finalStdErr = append(finalStdErr, rerr.Error()...)
+		}
+	}
+
+	// if the cmd has failed for good, and before behaviours might clean up its
+	// working directory, keep the evidence of what happened
+	job.RLock()
+	failedForGood := dobury || (dorelease && job.UntilBuried <= 1)
+	job.RUnlock()
+	var failureArchive string
+	if failedForGood && actualCwd != "" && c.ServerInfo.ArchiveFailures {
+		var aerr error
+		failureArchive, aerr = c.ArchiveFailure(job, actualCwd, uniqueMountedDirs...)
+		if aerr != nil {
+			logger.Warn("archiving the working directory failed", "err", aerr)
+			finalStdErr = append(finalStdErr, "\n\nFailure archive problems:\n"...)
+			finalStdErr = append(finalStdErr, aerr.Error()...)
+		}
+	}
+
+	// run behaviours, letting them know how the cmd exited so that they can
+	// be conditional on it
+	job.Lock()
+	job.execOutcome = &behaviourOutcome{
+		exitcode:   exitcode,
+		failReason: failreason,
+		attempt:    int(job.Attempts),
+		final:      doarchive || failedForGood,
+	}
+	job.Unlock()
 	berr := job.TriggerBehaviours(myerr == nil)
 	if berr != nil {
 		if myerr != nil {
@@ -1286,6 +2101,11 @@ func (c *Client) Execute(job *Job, shell string) error {
 		finalStdOut = append(finalStdOut, errsow.Error()...)
 	}
 
+	if len(secretEnv) > 0 {
+		finalStdOut = redactSecrets(finalStdOut, secretEnv)
+		finalStdErr = redactSecrets(finalStdErr, secretEnv)
+	}
+
 	// now we've done everything time-consuming so can stop touching the job
 	stopTouching <- true
 
@@ -1308,7 +2128,16 @@ func (c *Client) Execute(job *Job, shell string) error {
 		Stdout:   finalStdOut,
 		Stderr:   finalStdErr,
 		Exited:   true,
+		Metrics:  metrics,
+
+		FailureArchive: failureArchive,
 	}
+	job.RLock()
+	jes.BehaviourResults = job.BehaviourResults
+	jes.OutputRecords = outputRecords
+	jes.InputChecksums = inputChecksums
+	jes.Resubmit = job.execResubmit
+	job.RUnlock()
 	for {
 		if time.Now().After(retryEnd) {
 			logger.Warn("giving up trying to connect to server")
@@ -1332,9 +2161,9 @@ func (c *Client) Execute(job *Job, shell string) error {
 			// timeout, but that should be good enough just to get through this)
 			logger.Info("reconnected to server")
 			disconnected = false
-			c.Lock()
-			c.sock = newC.sock
-			c.Unlock()
+			if erra := c.conn.adopt(newC.conn.pool); erra != nil {
+				logger.Warn("failed to close old connection", "err", erra)
+			}
 		}
 
 		// update the database with our final state
@@ -1364,6 +2193,14 @@ func (c *Client) Execute(job *Job, shell string) error {
 				break
 			}
 
+			if abandoned {
+				// the manager will have already given up on us (and our
+				// request was retried for our outage tolerance), so there's
+				// no point trying for long to tell it about a job that didn't
+				// finish
+				break
+			}
+
 			<-time.After(ClientRetryWait)
 			continue
 		}
@@ -1372,6 +2209,10 @@ func (c *Client) Execute(job *Job, shell string) error {
 	}
 
 	if !worked {
+		if abandoned {
+			// we didn't expect to be able to tell the manager
+			return myerr
+		}
 		errt := job.TriggerBehaviours(false)
 		extra := ""
 		if errt != nil {
@@ -1465,20 +2306,46 @@ func (c *Client) Started(job *Job, pid int) error {
 	return err
 }
 
+// heartbeatInterval returns how often we should Touch() jobs we're running:
+// the interval the server advertised, or ClientTouchInterval if it didn't.
+func (c *Client) heartbeatInterval() time.Duration {
+	if c.ServerInfo != nil && c.ServerInfo.Heartbeat > 0 {
+		return c.ServerInfo.Heartbeat
+	}
+	return ClientTouchInterval
+}
+
 // Touch adds to a job's ttr, allowing you more time to work on it. Note that
 // you must have reserved the job before you can touch it. If the returned bool
 // is true, you stop doing what you're doing and bury the job, since this means
 // that Kill() has been called for this job.
 func (c *Client) Touch(job *Job) (bool, error) {
-	c.teMutex.Lock()
-	defer c.teMutex.Unlock()
+	kc, _, _, err := c.touch(job)
+	return kc, err
+}
+
+// touch does the work of Touch(), additionally returning true and a grace
+// period if the job should be requeued because its host is being drained.
+func (c *Client) touch(job *Job) (bool, bool, time.Duration, error) {
+	c.conn.teMutex.Lock()
+	defer c.conn.teMutex.Unlock()
 	job.RLock()
 	defer job.RUnlock()
 	resp, err := c.request(&clientRequest{Method: "jtouch", Job: job})
 	if err != nil {
-		return false, err
+		return false, false, 0, err
 	}
-	return resp.KillCalled, err
+	return resp.KillCalled, resp.Requeue, resp.Grace, err
+}
+
+// reportPhase tells the server that we've started (phase JobStateStaging or
+// JobStateUploading) or finished (blank phase) moving data for the given job,
+// which you must have reserved, so that users can see it in the job's state.
+func (c *Client) reportPhase(job *Job, phase JobState) error {
+	job.RLock()
+	defer job.RUnlock()
+	_, err := c.request(&clientRequest{Method: "jphase", Job: job, State: phase})
+	return err
 }
 
 // JobEndState is used to describe the state of a job after it has (tried to)
@@ -1487,7 +2354,13 @@ func (c *Client) Touch(job *Job) (bool, error) {
 // different to the Job's Cwd property; if not, supply empty string. Always set
 // exited to true, and populate all other fields, unless you never actually
 // tried to execute the Cmd, in which case you would just provide a nil
-// JobEndState to the methods that need one.
+// JobEndState to the methods that need one. Metrics are the key=value pairs
+// output by the Job's ReportCmd, if it had one, BehaviourResults record what
+// happened when its Behaviours were triggered, OutputRecords describe its
+// OutputFiles if it had OutputChecksums, InputChecksums are those of its
+// InputFiles if it was Cacheable, Cached is set if the Cmd wasn't run because
+// those were the same as the Job's CachedInputChecksums, and Resubmit is set if
+// a Resubmit Behaviour wants the Job re-enqueued with modified options.
 type JobEndState struct {
 	Cwd      string
 	Exitcode int
@@ -1498,6 +2371,16 @@ type JobEndState struct {
 	Stdout   []byte
 	Stderr   []byte
 	Exited   bool
+	Metrics  map[string]string
+
+	// FailureArchive is where ArchiveFailure() stored the job's ActualCwd.
+	FailureArchive string
+
+	BehaviourResults []BehaviourResult
+	OutputRecords    []OutputRecord
+	InputChecksums   map[string]string
+	Cached           bool
+	Resubmit         *ResubmitOptions
 }
 
 // ended updates a Job for the benefit of the client only; this has no effect on
@@ -1507,8 +2390,8 @@ func (c *Client) ended(job *Job, jes *JobEndState) error {
 	if jes == nil || !jes.Exited {
 		return nil
 	}
-	c.teMutex.Lock()
-	defer c.teMutex.Unlock()
+	c.conn.teMutex.Lock()
+	defer c.conn.teMutex.Unlock()
 	job.Lock()
 	defer job.Unlock()
 	job.Exited = true
@@ -1520,6 +2403,11 @@ func (c *Client) ended(job *Job, jes *JobEndState) error {
 	if jes.Cwd != "" {
 		job.ActualCwd = jes.Cwd
 	}
+	job.Metrics = jes.Metrics
+	job.FailureArchive = jes.FailureArchive
+	job.BehaviourResults = jes.BehaviourResults
+	job.OutputRecords = jes.OutputRecords
+	job.InputChecksums = jes.InputChecksums
 	var err error
 	if len(jes.Stdout) > 0 {
 		job.StdOutC, err = compress(jes.Stdout)
@@ -1545,8 +2433,8 @@ func (c *Client) Archive(job *Job, jes *JobEndState) error {
 	if err != nil {
 		return err
 	}
-	c.teMutex.Lock()
-	defer c.teMutex.Unlock()
+	c.conn.teMutex.Lock()
+	defer c.conn.teMutex.Unlock()
 	job.RLock()
 	defer job.RUnlock()
 	_, err = c.request(&clientRequest{Method: "jarchive", Job: job, JobEndState: jes})
@@ -1557,6 +2445,51 @@ func (c *Client) Archive(job *Job, jes *JobEndState) error {
 	return err
 }
 
+// archiveCached is like Archive(), but for when we didn't run a Cacheable
+// job's Cmd because the checksums of its inputs were the given ones, the same
+// as its CachedInputChecksums, so the server completes it with the results of
+// that previous run. If this fails, the Cmd should be run after all.
+func (c *Client) archiveCached(job *Job, sums map[string]string) error {
+	c.conn.teMutex.Lock()
+	defer c.conn.teMutex.Unlock()
+	job.Lock()
+	defer job.Unlock()
+	jes := &JobEndState{Exited: true, Cached: true, InputChecksums: sums}
+	_, err := c.request(&clientRequest{Method: "jarchive", Job: job, JobEndState: jes})
+	if err != nil {
+		return err
+	}
+	job.State = JobStateComplete
+	job.Cached = true
+	job.InputChecksums = sums
+	return err
+}
+
+// finishCached does what Execute() would have done after running the Cmd of a
+// job that archiveCached() completed instead: it triggers the job's success
+// Behaviours and unmounts, then removes the unused unique working directory.
+func (c *Client) finishCached(job *Job) error {
+	job.Lock()
+	job.execOutcome = &behaviourOutcome{
+		attempt: int(job.Attempts),
+		final:   true,
+	}
+	job.Unlock()
+
+	berr := job.TriggerBehaviours(true)
+	_, err := job.Unmount(true)
+	if err == nil {
+		err = job.rmActualCwd()
+	}
+	if berr != nil {
+		if err != nil {
+			return fmt.Errorf("%v; behaviour(s) also had problem(s): %w", err, berr)
+		}
+		return berr
+	}
+	return err
+}
+
 // Release places a job back on the jobqueue, for use when you can't handle the
 // job right now (eg. there was a suspected transient error) but maybe someone
 // else can later. Note that you must reserve a job before you can release it.
@@ -1569,8 +2502,8 @@ func (c *Client) Release(job *Job, jes *JobEndState, failreason string) error {
 	if err != nil {
 		return err
 	}
-	c.teMutex.Lock()
-	defer c.teMutex.Unlock()
+	c.conn.teMutex.Lock()
+	defer c.conn.teMutex.Unlock()
 	job.Lock()
 	defer job.Unlock()
 	job.FailReason = failreason
@@ -1580,7 +2513,7 @@ func (c *Client) Release(job *Job, jes *JobEndState, failreason string) error {
 	}
 
 	// update our process with what the server would have done
-	if job.Exited && job.Exitcode != 0 {
+	if job.Exited && job.Exitcode != 0 && failreason != FailReasonDrained && failreason != FailReasonAbandoned {
 		job.UntilBuried--
 	}
 	if job.UntilBuried <= 0 {
@@ -1600,8 +2533,8 @@ func (c *Client) Bury(job *Job, jes *JobEndState, failreason string, stderr ...e
 	if err != nil {
 		return err
 	}
-	c.teMutex.Lock()
-	defer c.teMutex.Unlock()
+	c.conn.teMutex.Lock()
+	defer c.conn.teMutex.Unlock()
 	job.Lock()
 	defer job.Unlock()
 	job.FailReason = failreason
@@ -1635,6 +2568,9 @@ func (c *Client) Kick(jes []*JobEssence) (int, error) {
 // completely. For use when jobs were created incorrectly/ by accident, or they
 // can never be fixed. It returns a count of jobs that it actually removed.
 // Errors will only be related to not being able to contact the server.
+//
+// If the server has a RecycleWindow (see ServerInfo), the removed jobs can be
+// restored with Undelete() until that much time has passed.
 func (c *Client) Delete(jes []*JobEssence) (int, error) {
 	keys := c.jesToKeys(jes)
 	resp, err := c.request(&clientRequest{Method: "jdel", Keys: keys})
@@ -1644,6 +2580,31 @@ func (c *Client) Delete(jes []*JobEssence) (int, error) {
 	return resp.Existed, err
 }
 
+// GetRecycled gets the jobs that were Delete()d within the server's
+// RecycleWindow, and so could be restored with Undelete(). If repgroup is not
+// blank, only jobs with that RepGroup are returned (or any RepGroup containing
+// it, if subStr is true). Only jobs deleted at or after since are returned;
+// supply a zero time to get all of them.
+func (c *Client) GetRecycled(repgroup string, subStr bool, since time.Time) ([]*RecycledJob, error) {
+	resp, err := c.request(&clientRequest{Method: "getrecycled", Job: &Job{RepGroup: repgroup}, Search: subStr, Since: since})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Recycled, err
+}
+
+// Undelete adds back to the queue the jobs that GetRecycled() would return for
+// the same arguments, as if they were newly added. It returns a count of jobs
+// that it actually added back; jobs that were added again (or added again and
+// completed) after they were deleted are not counted.
+func (c *Client) Undelete(repgroup string, subStr bool, since time.Time) (int, error) {
+	resp, err := c.request(&clientRequest{Method: "undelete", Job: &Job{RepGroup: repgroup}, Search: subStr, Since: since})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Added, err
+}
+
 // Kill will cause the next Touch() call for the job(s) described by the input
 // to return a kill signal. Touches happening as part of an Execute() will
 // respond to this signal by terminating their execution and burying the job. As
@@ -1679,6 +2640,34 @@ func (c *Client) GetByEssence(je *JobEssence, getstd bool, getenv bool) (*Job, e
 	return jobs[0], err
 }
 
+// GetByCmd gets the Job that was added with the given Cmd, Cwd and (possibly
+// nil) MountConfigs, without you needing to know whether it was added with
+// CwdMatters: a Job with CwdMatters is preferred, but if there isn't one, a Job
+// with the given Cmd and mounts that was added without CwdMatters is returned
+// (the cwd is not used to identify such Jobs). Supply an empty cwd to only find
+// a Job added without CwdMatters. Returns nil if there is no such Job.
+//
+// The boolean args are as for GetByEssence().
+func (c *Client) GetByCmd(cmd, cwd string, mounts MountConfigs, getstd bool, getenv bool) (*Job, error) {
+	keys := []string{JobKey(cmd, "", mounts)}
+	if cwd != "" {
+		keys = append(keys, JobKey(cmd, cwd, mounts))
+	}
+	resp, err := c.request(&clientRequest{Method: "getbc", Keys: keys, GetStd: getstd, GetEnv: getenv})
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Job
+	for _, job := range resp.Jobs {
+		if job.CwdMatters {
+			return job, err
+		}
+		found = job
+	}
+	return found, err
+}
+
 // GetByEssences gets multiple Jobs at once given JobEssences that describe
 // them.
 func (c *Client) GetByEssences(jes []*JobEssence) ([]*Job, error) {
@@ -1722,6 +2711,26 @@ func (c *Client) GetByRepGroup(repgroup string, subStr bool, limit int, state Jo
 	return resp.Jobs, err
 }
 
+// GetComplete gets Jobs that have been Archive()d, most recently completed
+// first, without having to retrieve everything that ever completed.
+//
+// If repgroup is not blank, only jobs that have ever had that RepGroup are
+// returned (or any RepGroup containing it, if subStr is true), with their
+// RepGroup set to the matching one. Only jobs that completed at or after since
+// and before until are returned; supply zero times to not bound the range at
+// that end. A limit greater than 0 returns at most that many of the most
+// recently completed matching jobs. getStd and getEnv are as in
+// GetByRepGroup().
+//
+// Jobs that completed but are currently being re-run are not returned.
+func (c *Client) GetComplete(repgroup string, subStr bool, since, until time.Time, limit int, getStd bool, getEnv bool) ([]*Job, error) {
+	resp, err := c.request(&clientRequest{Method: "getcomplete", Job: &Job{RepGroup: repgroup}, Search: subStr, Since: since, Until: until, Limit: limit, GetStd: getStd, GetEnv: getEnv})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Jobs, err
+}
+
 // GetIncomplete gets all Jobs that are currently in the jobqueue, ie. excluding
 // those that are complete and have been Archive()d. The args are as in
 // GetByRepGroup().
@@ -1733,6 +2742,62 @@ func (c *Client) GetIncomplete(limit int, state JobState, getStd bool, getEnv bo
 	return resp.Jobs, err
 }
 
+// GetIncompleteInQueue is like GetIncomplete(), but only gets the Jobs that
+// were added to the given named queue (see QueueConfig).
+func (c *Client) GetIncompleteInQueue(queueName string, limit int, state JobState, getStd bool, getEnv bool) ([]*Job, error) {
+	if err := validateQueueName(queueName); err != nil {
+		return nil, err
+	}
+	resp, err := c.request(&clientRequest{Method: "getin", Job: &Job{Queue: queueName}, Limit: limit, State: state, GetStd: getStd, GetEnv: getEnv})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Jobs, err
+}
+
+// GetRepGroups gets the RepGroups of all the Jobs that have ever been added
+// to the queue, sorted by name.
+func (c *Client) GetRepGroups() ([]string, error) {
+	resp, err := c.request(&clientRequest{Method: "getrgs"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.RepGroups, err
+}
+
+// GetEvents gets the events recorded by the server that happened at or after
+// the given time (supply the zero time to get all of them), optionally only
+// those of the given types, in the order they happened. A limit greater than 0
+// gets only that many of the most recent events. The server only keeps events
+// for ServerEventRetention.
+func (c *Client) GetEvents(since time.Time, types []EventType, limit int) ([]*Event, error) {
+	resp, err := c.request(&clientRequest{Method: "getevents", Since: since, EventTypes: types, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Events, err
+}
+
+// GetStateCountsAt reconstructs, from the server's event log, the number of
+// incomplete jobs that were in each state (delayed, dependent, ready, running,
+// lost and buried) at the given time, keyed on RepGroup. Reserved jobs are
+// counted as running. Only times within ServerEventRetention can be
+// reconstructed.
+func (c *Client) GetStateCountsAt(at time.Time) (map[string]map[JobState]int, error) {
+	resp, err := c.request(&clientRequest{Method: "getstatecounts", Until: at})
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]map[JobState]int)
+	for _, sc := range resp.StateCounts {
+		if _, exists := counts[sc.RepGroup]; !exists {
+			counts[sc.RepGroup] = make(map[JobState]int)
+		}
+		counts[sc.RepGroup][sc.State] = sc.Count
+	}
+	return counts, err
+}
+
 // GetOrSetLimitGroup takes the name of a limit group and returns the current
 // limit for that group. If the group isn't known about, returns -1.
 //
@@ -1747,6 +2812,390 @@ func (c *Client) GetOrSetLimitGroup(group string) (int, error) {
 	return resp.Limit, err
 }
 
+// DrainHost tells the server to stop giving jobs to runners on the given host,
+// and to have runners already running jobs there requeue them: their commands
+// are sent SIGTERM, giving them the chance to checkpoint their work, and then
+// SIGKILL if they haven't exited after the given grace period. The jobs are
+// then released to run elsewhere, without that counting against their
+// Retries. You get back the number of jobs still reserved or running on the
+// host; call this again (with the same grace) to find out when it reaches 0
+// and the host is empty.
+func (c *Client) DrainHost(host string, grace time.Duration) (int, error) {
+	resp, err := c.request(&clientRequest{Method: "drainhost", Host: host, Timeout: grace})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Running, err
+}
+
+// UndrainHost undoes DrainHost(), letting the server give jobs to runners on
+// the given host again.
+func (c *Client) UndrainHost(host string) error {
+	_, err := c.request(&clientRequest{Method: "undrainhost", Host: host})
+	return err
+}
+
+// GetOrSetHostLimit is like GetOrSetLimitGroup(), but concerns the maximum
+// number of jobs in the given limit group that may run at once on a single
+// host, regardless of the group's overall limit. Jobs in the group will not be
+// given to runners on hosts already running that many of them. Supplying
+// group:-1 removes the per-host limit.
+func (c *Client) GetOrSetHostLimit(group string) (int, error) {
+	resp, err := c.request(&clientRequest{Method: "getsethl", LimitGroup: group})
+	if err != nil {
+		return -1, err
+	}
+	return resp.Limit, err
+}
+
+// GetBurstStatus tells you the current state of the server's burst policy,
+// which only exists if the server is using more than one scheduler and was
+// configured with one (or had one set with SetBurstPolicy()).
+func (c *Client) GetBurstStatus() (*scheduler.BurstStatus, error) {
+	resp, err := c.request(&clientRequest{Method: "burst"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Burst, err
+}
+
+// SetBurstPolicy sets or replaces the server's burst policy, which decides
+// when jobs spill over from one of the server's schedulers to another. This
+// only works if the server is using more than one scheduler. You get back the
+// status following the change.
+func (c *Client) SetBurstPolicy(policy *scheduler.BurstPolicy) (*scheduler.BurstStatus, error) {
+	resp, err := c.request(&clientRequest{Method: "burst", BurstPolicy: policy})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Burst, err
+}
+
+// GetSchedulerGroups tells you about the groups of jobs the server is
+// scheduling runners for: their requirements, how many runners it has asked
+// the scheduler for, how many have connected and how long the groups have been
+// waiting. This is useful for working out why jobs are staying ready instead of
+// running.
+func (c *Client) GetSchedulerGroups() ([]*SchedulerGroup, error) {
+	resp, err := c.request(&clientRequest{Method: "sgroups"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.SGroups, err
+}
+
+// SetSetting changes one of the server's settings (see SettingNames and
+// ValidateSetting()) while it runs. The change is remembered, so still applies
+// if the server is restarted. Returns the current values of all the settings.
+func (c *Client) SetSetting(name, value string) (map[string]string, error) {
+	resp, err := c.request(&clientRequest{Method: "setsetting", SettingName: name, SettingValue: value})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Settings, err
+}
+
+// GetSettings returns the current values of the server's settings that can be
+// changed with SetSetting().
+func (c *Client) GetSettings() (map[string]string, error) {
+	resp, err := c.request(&clientRequest{Method: "getsettings"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Settings, err
+}
+
+// SetSecret stores a secret in the server's encrypted secret store, replacing
+// any existing secret with the same name. Jobs with the name in their Secrets
+// will have it set as an environment variable when they run. The name must be
+// a valid environment variable name.
+//
+// Returns the secret's new grant, which must be given to SetSecretGrants() by
+// anyone who wants to add jobs that use the secret (any previous grant for it
+// stops working), and the names of all stored secrets.
+func (c *Client) SetSecret(name, value string) (string, []string, error) {
+	resp, err := c.request(&clientRequest{Method: "setsecret", SecretName: name, SecretValue: value})
+	if err != nil {
+		return "", nil, err
+	}
+	return resp.SecretGrant, resp.Secrets, err
+}
+
+// SetSecretGrants sets the grants (as returned by SetSecret(), keyed on secret
+// name) that we present when adding or modifying jobs. The server refuses jobs
+// with Secrets that we don't have the grants for.
+func (c *Client) SetSecretGrants(grants map[string]string) {
+	c.Lock()
+	defer c.Unlock()
+	c.secretGrants = grants
+}
+
+// grants returns the grants set with SetSecretGrants().
+func (c *Client) grants() map[string]string {
+	c.Lock()
+	defer c.Unlock()
+	return c.secretGrants
+}
+
+// DeleteSecret removes a secret from the server's secret store. Returns the
+// names of all remaining secrets.
+func (c *Client) DeleteSecret(name string) ([]string, error) {
+	resp, err := c.request(&clientRequest{Method: "delsecret", SecretName: name})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Secrets, err
+}
+
+// ListSecrets returns the names (but not the values) of all the secrets in the
+// server's secret store.
+func (c *Client) ListSecrets() ([]string, error) {
+	resp, err := c.request(&clientRequest{Method: "listsecrets"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Secrets, err
+}
+
+// SaveBehaviourSet stores the given behaviours JSON (in the format of
+// BehavioursViaJSON) on the server under the given name, so that jobs can be
+// given those behaviours by referring to the name (see ResolveBehaviours()). If
+// a set with that name already exists, this becomes its next version. Returns
+// the saved set, with its Version filled in.
+func (c *Client) SaveBehaviourSet(name, behavioursJSON string) (*BehaviourSet, error) {
+	bset := &BehaviourSet{Name: name, JSON: behavioursJSON}
+	if err := bset.validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.request(&clientRequest{Method: "savebset", BehaviourSet: bset})
+	if err != nil {
+		return nil, err
+	}
+	return resp.BehaviourSets[0], err
+}
+
+// GetBehaviourSet gets the given version of the named BehaviourSet from the
+// server, or the latest version if version is 0.
+func (c *Client) GetBehaviourSet(name string, version int) (*BehaviourSet, error) {
+	resp, err := c.request(&clientRequest{Method: "getbset", BehaviourSet: &BehaviourSet{Name: name, Version: version}})
+	if err != nil {
+		return nil, err
+	}
+	return resp.BehaviourSets[0], err
+}
+
+// ListBehaviourSets returns the latest version of every BehaviourSet stored on
+// the server, sorted by name.
+func (c *Client) ListBehaviourSets() ([]*BehaviourSet, error) {
+	resp, err := c.request(&clientRequest{Method: "listbsets"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.BehaviourSets, err
+}
+
+// DeleteBehaviourSet removes all versions of the named BehaviourSet from the
+// server. Jobs that were added with its behaviours are not affected.
+func (c *Client) DeleteBehaviourSet(name string) error {
+	_, err := c.request(&clientRequest{Method: "delbset", BehaviourSet: &BehaviourSet{Name: name}})
+	return err
+}
+
+// SetBudget sets the given budget as the budget of its RepGroup on the server,
+// replacing any existing budget for that RepGroup, but keeping its consumption
+// so far. Returns the budget as set, with its consumption and Alarm filled in.
+func (c *Client) SetBudget(budget *Budget) (*Budget, error) {
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.request(&clientRequest{Method: "setbudget", Budget: budget})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Budgets[0], err
+}
+
+// GetBudgets returns every RepGroup Budget set on the server, with their
+// consumption so far, sorted by RepGroup.
+func (c *Client) GetBudgets() ([]*Budget, error) {
+	resp, err := c.request(&clientRequest{Method: "getbudgets"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Budgets, err
+}
+
+// DeleteBudget removes the budget of the given RepGroup from the server,
+// discarding its record of the RepGroup's consumption. Jobs buried because the
+// budget was exceeded stay buried until retried.
+func (c *Client) DeleteBudget(repGroup string) error {
+	_, err := c.request(&clientRequest{Method: "delbudget", Budget: &Budget{RepGroup: repGroup}})
+	return err
+}
+
+// SetStartRate sets the given start rate as the start rate of its RepGroup on
+// the server, replacing any existing start rate for that RepGroup. It applies
+// to jobs reserved from now on. Returns the start rate as set.
+func (c *Client) SetStartRate(rate *StartRate) (*StartRate, error) {
+	if err := rate.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.request(&clientRequest{Method: "setstartrate", StartRate: rate})
+	if err != nil {
+		return nil, err
+	}
+	return resp.StartRates[0], err
+}
+
+// GetStartRates returns every RepGroup StartRate set on the server.
+func (c *Client) GetStartRates() ([]*StartRate, error) {
+	resp, err := c.request(&clientRequest{Method: "getstartrates"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.StartRates, err
+}
+
+// DeleteStartRate removes the start rate of the given RepGroup from the
+// server. Jobs that were already delayed because of it will start once their
+// delay is up.
+func (c *Client) DeleteStartRate(repGroup string) error {
+	_, err := c.request(&clientRequest{Method: "delstartrate", StartRate: &StartRate{RepGroup: repGroup}})
+	return err
+}
+
+// SetLimitFeedback puts the given limit group in to feedback mode on the
+// server, replacing any existing feedback for that group. Returns the feedback
+// as set, with defaults filled in.
+func (c *Client) SetLimitFeedback(feedback *LimitFeedback) (*LimitFeedback, error) {
+	if err := feedback.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.request(&clientRequest{Method: "setlimitfb", LimitFeedback: feedback})
+	if err != nil {
+		return nil, err
+	}
+	return resp.LimitFeedback[0], err
+}
+
+// GetLimitFeedback returns the LimitFeedback of every limit group in feedback
+// mode on the server, along with the result of their last probe.
+func (c *Client) GetLimitFeedback() ([]*LimitFeedback, error) {
+	resp, err := c.request(&clientRequest{Method: "getlimitfbs"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.LimitFeedback, err
+}
+
+// DeleteLimitFeedback takes the given limit group out of feedback mode on the
+// server, leaving its limit at whatever it was last tuned to.
+func (c *Client) DeleteLimitFeedback(group string) error {
+	_, err := c.request(&clientRequest{Method: "dellimitfb", LimitFeedback: &LimitFeedback{Group: group}})
+	return err
+}
+
+// SetQueueConfig sets the given settings as the settings of their named queue
+// on the server, replacing any existing settings for that queue. Returns the
+// settings as set.
+func (c *Client) SetQueueConfig(qc *QueueConfig) (*QueueConfig, error) {
+	if err := qc.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.request(&clientRequest{Method: "setqueue", QueueConfig: qc})
+	if err != nil {
+		return nil, err
+	}
+	return resp.QueueConfigs[0], err
+}
+
+// GetQueueConfigs returns the settings of every named queue that has any,
+// sorted by name.
+func (c *Client) GetQueueConfigs() ([]*QueueConfig, error) {
+	resp, err := c.request(&clientRequest{Method: "getqueues"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.QueueConfigs, err
+}
+
+// DeleteQueueConfig removes the settings of the given named queue from the
+// server, including its limit. Jobs already added to the queue stay in it.
+func (c *Client) DeleteQueueConfig(name string) error {
+	_, err := c.request(&clientRequest{Method: "delqueue", QueueConfig: &QueueConfig{Name: name}})
+	return err
+}
+
+// AnnotateJob leaves the given note on the job with the given key (which may
+// be complete), recorded as being from the user this client is running as.
+// Everyone looking at the job's details will see it.
+func (c *Client) AnnotateJob(key, note string) (*Annotation, error) {
+	return c.annotate(&Annotation{Key: key, Note: note})
+}
+
+// AnnotateRepGroup is like AnnotateJob(), but leaves the note on the given
+// RepGroup, so it is seen on all of the RepGroup's jobs.
+func (c *Client) AnnotateRepGroup(repGroup, note string) (*Annotation, error) {
+	return c.annotate(&Annotation{RepGroup: repGroup, Note: note})
+}
+
+// annotate sends the given annotation to the server to be stored.
+func (c *Client) annotate(annotation *Annotation) (*Annotation, error) {
+	if err := annotation.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.request(&clientRequest{Method: "annotate", Annotation: annotation})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Annotations[0], err
+}
+
+// GetAnnotations gets the notes that were left with AnnotateJob() on any of the
+// jobs with the given keys, and with AnnotateRepGroup() on any of the given
+// RepGroups, oldest first.
+func (c *Client) GetAnnotations(keys, repGroups []string) ([]*Annotation, error) {
+	resp, err := c.request(&clientRequest{Method: "getannotations", Keys: keys, RepGroups: repGroups})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Annotations, err
+}
+
+// ResolveBehaviours is like ParseBehaviours(), but the spec can also be a
+// reference to a BehaviourSet stored on the server with SaveBehaviourSet(), of
+// the form "@name" (for the latest version) or "@name:version". The returned
+// Behaviours are a copy of those in the set, so later versions of the set will
+// not affect jobs you give them to.
+func (c *Client) ResolveBehaviours(spec string, when BehaviourTrigger) (Behaviours, error) {
+	if !IsBehaviourSetRef(spec) {
+		return ParseBehaviours(spec, when)
+	}
+	name, version, err := ParseBehaviourSetRef(spec)
+	if err != nil {
+		return nil, err
+	}
+	bset, err := c.GetBehaviourSet(name, version)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := bset.Behaviours(when)
+	if err != nil {
+		return nil, err
+	}
+	return bs, bs.Validate()
+}
+
+// getSecrets gets the "name=value" environment variables for the given job's
+// Secrets from the server. The job must have been reserved by us.
+func (c *Client) getSecrets(job *Job) ([]string, error) {
+	resp, err := c.request(&clientRequest{Method: "getsecrets", Job: job})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Secrets, err
+}
+
 // UploadFile uploads a local file to the machine where the server is running,
 // so you can add cloud jobs that need a script or config file on your local
 // machine to be copied over to created cloud instances.
@@ -1805,37 +3254,109 @@ func (c *Client) ConfirmCloudServersDead(id string) ([]*BadServer, []*Job, error
 	return resp.BadServers, resp.Jobs, err
 }
 
-// request the server do something and get back its response. We can only cope
-// with one request at a time per client, or we'll get replies back in the
-// wrong order, hence we lock.
+// request the server do something and get back its response. Each socket can
+// only cope with one request at a time, or we'll get replies back in the wrong
+// order, so concurrent requests each use their own socket from our pool.
 func (c *Client) request(cr *clientRequest) (*serverResponse, error) {
-	c.Lock()
-	defer c.Unlock()
+	// retries of this request will use the same id, so that the server knows
+	// not to carry it out again
+	rid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	cr.RequestID = rid
 
-	// encode and send the request
-	var encoded []byte
-	enc := codec.NewEncoderBytes(&encoded, c.ch)
+	call := &ClientCall{Method: cr.Method, Keys: cr.Keys}
+	if cr.Job != nil && cr.Job.Cmd != "" {
+		call.Keys = []string{cr.Job.Key()}
+	}
+	var sr *serverResponse
+	err = c.withMiddleware(func(ctx context.Context, _ *ClientCall) error {
+		var errr error
+		sr, errr = c.retryRequest(ctx, cr)
+		return errr
+	})(c.Context(), call)
+	if sr == nil && err == nil {
+		err = Error{cr.Method, "", ErrNotRequested}
+	}
+	return sr, err
+}
+
+// retryRequest does attemptRequest(), retrying on network errors according to
+// SetOutageTolerance().
+func (c *Client) retryRequest(ctx context.Context, cr *clientRequest) (*serverResponse, error) {
+	tolerance := c.outageTolerance()
+	giveUp := time.Now().Add(tolerance)
+	b := &backoff.Backoff{
+		Min:    ClientRetryMinWait,
+		Max:    ClientRetryWait,
+		Factor: 2,
+		Jitter: true,
+	}
+
+	for {
+		sr, networkErr, err := c.attemptRequest(ctx, cr)
+		if !networkErr || tolerance <= 0 {
+			return sr, err
+		}
+
+		wait := b.Duration()
+		if time.Now().Add(wait).After(giveUp) {
+			return sr, err
+		}
+		c.Warn("request to server failed, will reconnect and retry", "method", cr.Method, "err", err, "wait", wait)
+
+		// (the failed socket was discarded, so the retry will connect afresh)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// attemptRequest does a single try of request(), additionally returning true
+// if the error was due to a problem communicating with the server.
+func (c *Client) attemptRequest(ctx context.Context, cr *clientRequest) (*serverResponse, bool, error) {
+	// encode the request
 	cr.Token = c.token
 	cr.ClientID = c.clientid
-	err := enc.Encode(cr)
+	cr.ClientVersion = ServerVersion
+	cr.User = c.user
+	cr.ProtocolVersion = ProtocolVersion
+	msg, err := encodeRequest(cr, c.ch)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	err = c.sock.Send(encoded)
+
+	// send it on a socket of our own and get the response
+	pool := c.conn.socketPool()
+	sock, err := pool.get(ctx)
 	if err != nil {
-		return nil, err
+		if err == ctx.Err() {
+			return nil, false, err
+		}
+		return nil, !c.conn.isClosed(), err
+	}
+	resp, err := sendAndRecv(ctx, sock, msg)
+	if errp := pool.put(sock, err != nil); errp != nil {
+		c.Warn("failed to close broken socket", "err", errp)
 	}
-
-	// get the response and decode it
-	resp, err := c.sock.Recv()
 	if err != nil {
-		return nil, err
+		if err == ctx.Err() {
+			return nil, false, err
+		}
+		return nil, !c.conn.isClosed(), err
 	}
+
+	// decode the response, recycling its buffer unless sr refers to it
 	sr := &serverResponse{}
-	dec := codec.NewDecoderBytes(resp, c.ch)
-	err = dec.Decode(sr)
+	shared, err := decodeFrame(resp.Body, sr, c.ch, false)
+	if !shared {
+		resp.Free()
+	}
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// pull the error out of sr
@@ -1844,9 +3365,9 @@ func (c *Client) request(cr *clientRequest) (*serverResponse, error) {
 		if cr.Job != nil {
 			key = cr.Job.Key()
 		}
-		return sr, Error{cr.Method, key, sr.Err}
+		return sr, false, Error{cr.Method, key, sr.Err}
 	}
-	return sr, err
+	return sr, false, err
 }
 
 // CompressEnv encodes the given environment variables (slice of "key=value"
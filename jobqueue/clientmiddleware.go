@@ -0,0 +1,82 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of client middleware, which lets
+// applications that embed a Client wrap every request it makes to the server,
+// eg. to add tracing, metrics or their own retries.
+
+import "context"
+
+// ClientCall describes a request that a Client is about to make to the
+// server, as seen by ClientMiddleware.
+type ClientCall struct {
+	// Method is the name of the server method being called, eg. "add",
+	// "reserve" or "ping".
+	Method string
+
+	// Keys are the keys of the jobs the request is about, for requests that
+	// are about particular jobs. Don't alter it.
+	Keys []string
+}
+
+// ClientInvoker carries out a ClientCall, returning the same error the Client
+// method making the call would return.
+type ClientInvoker func(ctx context.Context, call *ClientCall) error
+
+// ClientMiddleware wraps the requests a Client makes to the server. It should
+// call next to actually carry out the request, and return the error next
+// returns, though it is free to act before and after, replace the context
+// (eg. to add a deadline or tracing span), or to call next more than once.
+//
+// Calling next again is safe even for requests that change things: all calls
+// of next for the same ClientCall are recognised by the server as the same
+// request, so it is only carried out once. (This also means that a request
+// that the server refused with an error will be refused again.)
+type ClientMiddleware func(ctx context.Context, call *ClientCall, next ClientInvoker) error
+
+// Use adds middleware that will wrap all subsequent requests made by the
+// client, and by any copies of it made with WithContext(). The first
+// middleware added is the outermost, called first for each request.
+//
+// Middleware is called for every request, including the pings that
+// SetOutageTolerance() causes to be sent while idle. Retries due to
+// SetOutageTolerance() happen within next, so middleware sees each request
+// once, however many attempts it took.
+func (c *Client) Use(middleware ...ClientMiddleware) {
+	c.conn.Lock()
+	defer c.conn.Unlock()
+	c.conn.middleware = append(c.conn.middleware, middleware...)
+}
+
+// withMiddleware returns an invoker that calls the given one via all the
+// client's middleware, or the given one itself if there's no middleware.
+func (c *Client) withMiddleware(invoke ClientInvoker) ClientInvoker {
+	c.conn.Lock()
+	middleware := c.conn.middleware
+	c.conn.Unlock()
+
+	for i := len(middleware) - 1; i >= 0; i-- {
+		mw, next := middleware[i], invoke
+		invoke = func(ctx context.Context, call *ClientCall) error {
+			return mw(ctx, call, next)
+		}
+	}
+	return invoke
+}
@@ -0,0 +1,301 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of the client's pool of connections to
+// the server, which lets a single Client be used by many goroutines at once,
+// and of the context support that lets callers cancel requests or give them
+// deadlines.
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"nanomsg.org/go-mangos"
+)
+
+// ClientPoolSize is the most connections to the server a Client (along with
+// any copies of it made by WithContext()) will have open at once, and so the
+// most requests it can have in flight at once. Further concurrent requests wait
+// for one of those to complete.
+var ClientPoolSize = 8
+
+// socketPool is a pool of sockets connected to the server. Each socket can only
+// have one request in flight at a time, so a request gets a socket of its own
+// from the pool, dialling a new one if none are idle and we have fewer than
+// our size, and returns it when done.
+type socketPool struct {
+	dial   func() (mangos.Socket, error)
+	idle   chan mangos.Socket
+	slots  chan struct{}
+	open   map[mangos.Socket]bool
+	closed bool
+	mutex  sync.Mutex
+}
+
+// newSocketPool creates a pool of up to size sockets, starting with the given
+// already-connected socket, that uses the given function to connect further
+// sockets.
+func newSocketPool(first mangos.Socket, size int, dial func() (mangos.Socket, error)) *socketPool {
+	if size < 1 {
+		size = 1
+	}
+	p := &socketPool{
+		dial:  dial,
+		idle:  make(chan mangos.Socket, size),
+		slots: make(chan struct{}, size),
+		open:  map[mangos.Socket]bool{first: true},
+	}
+	for i := 0; i < size; i++ {
+		p.slots <- struct{}{}
+	}
+	p.idle <- first
+	return p
+}
+
+// get returns a socket for your sole use until you put() it back, waiting for
+// one to be free if necessary. Returns mangos.ErrClosed if the pool has been
+// closed, or the context's error if it is done before a socket is free.
+func (p *socketPool) get(ctx context.Context) (mangos.Socket, error) {
+	select {
+	case <-p.slots:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		p.slots <- struct{}{}
+		return nil, mangos.ErrClosed
+	}
+	p.mutex.Unlock()
+
+	select {
+	case sock := <-p.idle:
+		return sock, nil
+	default:
+	}
+
+	sock, err := p.dial()
+	if err != nil {
+		p.slots <- struct{}{}
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		p.slots <- struct{}{}
+		if errc := sock.Close(); errc != nil {
+			return nil, errc
+		}
+		return nil, mangos.ErrClosed
+	}
+	p.open[sock] = true
+	return sock, nil
+}
+
+// put returns a socket you got from get() to the pool. If broken is true, eg.
+// because a request on it failed or was abandoned, or closeSockets() was called
+// while you had it, the socket is closed instead of being reused.
+func (p *socketPool) put(sock mangos.Socket, broken bool) error {
+	defer func() {
+		p.slots <- struct{}{}
+	}()
+	p.mutex.Lock()
+	if broken || p.closed || !p.open[sock] {
+		delete(p.open, sock)
+		p.mutex.Unlock()
+		err := sock.Close()
+		if err == mangos.ErrClosed {
+			err = nil
+		}
+		return err
+	}
+	p.mutex.Unlock()
+	p.idle <- sock
+	return nil
+}
+
+// closeSockets closes all the pool's sockets, so that requests in flight fail
+// and future requests connect afresh.
+func (p *socketPool) closeSockets() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	var err error
+	for sock := range p.open {
+		if errc := sock.Close(); errc != nil && errc != mangos.ErrClosed {
+			err = errc
+		}
+		delete(p.open, sock)
+	}
+	for {
+		select {
+		case <-p.idle:
+		default:
+			return err
+		}
+	}
+}
+
+// close closes all the pool's sockets and makes future get()s fail.
+func (p *socketPool) close() error {
+	p.mutex.Lock()
+	p.closed = true
+	p.mutex.Unlock()
+	return p.closeSockets()
+}
+
+// clientConn is the connection state shared by a Client and the copies of it
+// made by WithContext().
+type clientConn struct {
+	pool        *socketPool
+	tolerance   time.Duration
+	lastReq     time.Time
+	stopKA      chan struct{}
+	closed      bool
+	hasReserved bool
+	teMutex     sync.Mutex // to protect Touch() from other methods during Execute()
+	middleware  []ClientMiddleware
+	sync.Mutex
+}
+
+// firstReserve returns true the first time it is called, so that the server
+// can be told about a client's first attempt to reserve a job.
+func (cc *clientConn) firstReserve() bool {
+	cc.Lock()
+	defer cc.Unlock()
+	if cc.hasReserved {
+		return false
+	}
+	cc.hasReserved = true
+	return true
+}
+
+// socketPool returns the current pool of sockets.
+func (cc *clientConn) socketPool() *socketPool {
+	cc.Lock()
+	defer cc.Unlock()
+	cc.lastReq = time.Now()
+	return cc.pool
+}
+
+// isClosed tells you if Disconnect() has been called.
+func (cc *clientConn) isClosed() bool {
+	cc.Lock()
+	defer cc.Unlock()
+	return cc.closed
+}
+
+// adopt replaces our pool with the given one, which must be newly connected,
+// closing our old pool.
+func (cc *clientConn) adopt(pool *socketPool) error {
+	cc.Lock()
+	old := cc.pool
+	cc.pool = pool
+	cc.closed = false
+	cc.Unlock()
+	if old == pool {
+		return nil
+	}
+	return old.close()
+}
+
+// WithContext returns a copy of the client whose methods carry out their
+// requests to the server under the given context: if it is cancelled or its
+// deadline passes before the server responds, the method returns the
+// context's error. (The server may still carry out the request.) Waits between
+// retries due to SetOutageTolerance() are also cut short.
+//
+// The copy shares its connections to the server with the original, so you
+// must still only Disconnect() once, and copies are cheap to make, eg. per
+// call:
+//
+//	job, err := client.WithContext(ctx).Reserve(timeout)
+//
+// Clients, including copies, are safe for concurrent use by multiple
+// goroutines, with up to ClientPoolSize requests in flight at once.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+	c.Lock()
+	defer c.Unlock()
+	return &Client{
+		ch:         c.ch,
+		clientid:   c.clientid,
+		conn:       c.conn,
+		ctx:        ctx,
+		token:      c.token,
+		ServerInfo: c.ServerInfo,
+		host:       c.host,
+		port:       c.port,
+		args:       c.args,
+		timeout:    c.timeout,
+		execIn:     c.execIn,
+		execOut:    c.execOut,
+		execErr:    c.execErr,
+		Logger:     c.Logger,
+	}
+}
+
+// Context returns the client's context, as set by WithContext(). The default
+// is context.Background().
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// sendAndRecv sends the encoded request on the given socket and returns the
+// server's response. If the context is done first, its error is returned
+// instead; the socket must then be put() back as broken, which also ends our
+// wait for the response to the abandoned request.
+func sendAndRecv(ctx context.Context, sock mangos.Socket, msg *mangos.Message) (*mangos.Message, error) {
+	if ctx.Done() == nil {
+		if err := sock.SendMsg(msg); err != nil {
+			return nil, err
+		}
+		return sock.RecvMsg()
+	}
+
+	type result struct {
+		resp *mangos.Message
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		if err := sock.SendMsg(msg); err != nil {
+			done <- result{err: err}
+			return
+		}
+		resp, err := sock.RecvMsg()
+		done <- result{resp, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.resp, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
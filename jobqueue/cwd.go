@@ -0,0 +1,191 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the functions that implement the options for where and
+// how the unique working directory of a Job that doesn't have CwdMatters is
+// created.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// cwdMarkerFile is the name of the file that mkActualCwd() creates in the
+// unique directory it makes for a Job, containing the Job's key, so that the
+// cleanup Behaviours can be sure they only delete directories made for the Job.
+const cwdMarkerFile = ".wr_job"
+
+// cwdTemplatePlaceholder matches the placeholders in a Job's CwdTemplate.
+var cwdTemplatePlaceholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// cwdTemplateUnsafe matches the characters we replace in the values
+// substituted in to a CwdTemplate, so that they can't create extra levels of
+// directories or otherwise be awkward to work with.
+var cwdTemplateUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// cwdTemplateValues returns the values of the placeholders that can be used in
+// a CwdTemplate for the given job.
+func cwdTemplateValues(j *Job) map[string]string {
+	return map[string]string{
+		"key":      j.Key(),
+		"repgroup": j.RepGroup,
+		"reqgroup": j.ReqGroup,
+		"date":     time.Now().Format("2006-01-02"),
+	}
+}
+
+// ValidateCwdTemplate checks that the given Job.CwdTemplate only uses known
+// placeholders and is a relative path that stays within the directory it will
+// be created in.
+func ValidateCwdTemplate(template string) error {
+	_, err := expandCwdTemplate(template, cwdTemplateValues(&Job{}))
+	return err
+}
+
+// expandCwdTemplate replaces the placeholders in the given template with the
+// given values (made safe to use as a single directory name), and returns the
+// resulting cleaned relative path.
+func expandCwdTemplate(template string, values map[string]string) (string, error) {
+	var unknown string
+	expanded := cwdTemplatePlaceholder.ReplaceAllStringFunc(template, func(ph string) string {
+		val, known := values[ph[1:len(ph)-1]]
+		if !known {
+			unknown = ph
+			return ph
+		}
+		val = cwdTemplateUnsafe.ReplaceAllString(val, "_")
+		if val == "" || val == "." || val == ".." {
+			val = "_"
+		}
+		return val
+	})
+	if unknown != "" {
+		return "", fmt.Errorf("cwd template [%s] has unknown placeholder %s", template, unknown)
+	}
+
+	expanded = filepath.Clean(expanded)
+	if filepath.IsAbs(expanded) || expanded == "." || expanded == ".." || strings.HasPrefix(expanded, "../") {
+		return "", fmt.Errorf("cwd template [%s] must be a relative path within the working directory", template)
+	}
+	return expanded, nil
+}
+
+// cwdBase returns the directory in which the Job's working directory gets
+// created: CwdBase if set and not CwdMatters, otherwise Cwd.
+func (j *Job) cwdBase() string {
+	if j.CwdBase != "" && !j.CwdMatters {
+		return j.CwdBase
+	}
+	return j.Cwd
+}
+
+// mkActualCwd creates the unique working directory of a Job that doesn't have
+// CwdMatters, using its CwdTemplate if it has one, or else a hashed directory
+// structure, within its cwdBase(), marking it as belonging to the Job. Returns
+// the paths to the cwd and tmp dirs created.
+func (j *Job) mkActualCwd() (cwd, tmpDir string, err error) {
+	if j.CwdTemplate == "" {
+		cwd, tmpDir, err = mkHashedDir(j.cwdBase(), j.Key())
+	} else {
+		var rel string
+		rel, err = expandCwdTemplate(j.CwdTemplate, cwdTemplateValues(j))
+		if err != nil {
+			return cwd, tmpDir, err
+		}
+		path := filepath.Join(j.cwdBase(), rel)
+		cwd, tmpDir, err = mkUniqueDir(filepath.Dir(path), filepath.Base(path)+".")
+	}
+	if err != nil {
+		return cwd, tmpDir, err
+	}
+	return cwd, tmpDir, writeCwdMarker(filepath.Dir(cwd), j.Key())
+}
+
+// writeCwdMarker creates the cwdMarkerFile in the given directory, noting that
+// it was made for the Job with the given key.
+func writeCwdMarker(dir, key string) error {
+	return ioutil.WriteFile(filepath.Join(dir, cwdMarkerFile), []byte(key), 0644)
+}
+
+// cwdLinkPath returns the path within Cwd that should be a symlink to the Job's
+// ActualCwd, for Jobs with CwdLink that have a CwdBase different to their Cwd.
+// Returns blank if there should be no link.
+func (j *Job) cwdLinkPath() string {
+	if !j.CwdLink || j.ActualCwd == "" || j.CwdBase == "" || filepath.Clean(j.CwdBase) == filepath.Clean(j.Cwd) {
+		return ""
+	}
+	rel, err := filepath.Rel(j.CwdBase, filepath.Dir(j.ActualCwd))
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(j.Cwd, rel)
+}
+
+// linkActualCwd creates the symlink given by cwdLinkPath(), if any.
+func (j *Job) linkActualCwd() error {
+	link := j.cwdLinkPath()
+	if link == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(link), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Symlink(j.ActualCwd, link)
+}
+
+// rmActualCwd removes the unique working directory that mkActualCwd() created,
+// along with any empty parent dirs and the link to it from Cwd, for when the
+// Cmd never ran in it. Any mounts within it must have been unmounted first.
+func (j *Job) rmActualCwd() error {
+	if j.ActualCwd == "" || j.CwdMatters {
+		return nil
+	}
+
+	workSpace := filepath.Dir(j.ActualCwd)
+	if _, err := os.Stat(workSpace); err == nil {
+		if err = j.cleanupSafe(workSpace); err != nil {
+			return err
+		}
+		if err = os.RemoveAll(workSpace); err != nil {
+			return err
+		}
+		if err = rmEmptyDirs(workSpace, j.cwdBase()); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	return j.unlinkActualCwd()
+}
+
+// unlinkActualCwd removes the symlink given by cwdLinkPath(), if any, along
+// with any parent directories within Cwd that are then empty.
+func (j *Job) unlinkActualCwd() error {
+	link := j.cwdLinkPath()
+	if link == "" {
+		return nil
+	}
+	return rmEmptyDirs(link, j.Cwd)
+}
@@ -0,0 +1,55 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCwdTemplate(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Cwd templates can be expanded", t, func() {
+		values := map[string]string{"key": "abc", "repgroup": "my/rep group", "reqgroup": "..", "date": "2020-01-02"}
+
+		path, err := expandCwdTemplate("{repgroup}/{date}/{key}", values)
+		So(err, ShouldBeNil)
+		So(path, ShouldEqual, "my_rep_group/2020-01-02/abc")
+
+		path, err = expandCwdTemplate("out/{reqgroup}/./x_{key}/", values)
+		So(err, ShouldBeNil)
+		So(path, ShouldEqual, "out/_/x_abc")
+
+		_, err = expandCwdTemplate("{repgroup}/{cmd}", values)
+		So(err, ShouldNotBeNil)
+		_, err = expandCwdTemplate("/abs/{key}", values)
+		So(err, ShouldNotBeNil)
+		_, err = expandCwdTemplate("../{key}", values)
+		So(err, ShouldNotBeNil)
+		_, err = expandCwdTemplate("{key}/../..", values)
+		So(err, ShouldNotBeNil)
+
+		So(ValidateCwdTemplate("{repgroup}/{key}"), ShouldBeNil)
+		So(ValidateCwdTemplate("{nope}"), ShouldNotBeNil)
+	})
+}
@@ -25,7 +25,11 @@ package jobqueue
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
@@ -54,21 +58,34 @@ const (
 )
 
 var (
-	bucketJobsLive     = []byte("jobslive")
-	bucketJobsComplete = []byte("jobscomplete")
-	bucketRTK          = []byte("repgroupToKey")
-	bucketRGs          = []byte("repgroups")
-	bucketLGs          = []byte("limitgroups")
-	bucketDTK          = []byte("depgroupToKey")
-	bucketRDTK         = []byte("reverseDepgroupToKey")
-	bucketEnvs         = []byte("envs")
-	bucketStdO         = []byte("stdo")
-	bucketStdE         = []byte("stde")
-	bucketJobRAM       = []byte("jobRAM")
-	bucketJobDisk      = []byte("jobDisk")
-	bucketJobSecs      = []byte("jobSecs")
-	wipeDevDBOnInit    = true
-	forceBackups       = false
+	bucketJobsLive      = []byte("jobslive")
+	bucketJobsComplete  = []byte("jobscomplete")
+	bucketRTK           = []byte("repgroupToKey")
+	bucketCTK           = []byte("completeTimeToKey")
+	bucketEvents        = []byte("events")
+	bucketRGs           = []byte("repgroups")
+	bucketLGs           = []byte("limitgroups")
+	bucketHLs           = []byte("hostlimits")
+	bucketDTK           = []byte("depgroupToKey")
+	bucketRDTK          = []byte("reverseDepgroupToKey")
+	bucketEnvs          = []byte("envs")
+	bucketStdO          = []byte("stdo")
+	bucketStdE          = []byte("stde")
+	bucketJobRAM        = []byte("jobRAM")
+	bucketJobDisk       = []byte("jobDisk")
+	bucketJobSecs       = []byte("jobSecs")
+	bucketWebPrefs      = []byte("webPrefs")
+	bucketSettings      = []byte("settings")
+	bucketBehaviourSets = []byte("behaviourSets")
+	bucketATK           = []byte("atomicgroupToKey")
+	bucketBudgets       = []byte("budgets")
+	bucketStartRates    = []byte("startRates")
+	bucketLimitFeedback = []byte("limitFeedback")
+	bucketQueues        = []byte("queues")
+	bucketRecycle       = []byte("recycle")
+	bucketAnnotations   = []byte("annotations")
+	wipeDevDBOnInit     = true
+	forceBackups        = false
 )
 
 // Rec* variables are only exported for testing purposes (*** though they should
@@ -253,6 +270,22 @@ func initDB(dbFile string, dbBkFile string, deployment string, logger log15.Logg
 		if errf != nil {
 			return fmt.Errorf("create bucket %s: %s", bucketRTK, errf)
 		}
+		_, errf = tx.CreateBucketIfNotExists(bucketEvents)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketEvents, errf)
+		}
+		if tx.Bucket(bucketCTK) == nil {
+			// older databases don't have our index of complete jobs by the
+			// time they completed, so build it
+			b, errc := tx.CreateBucket(bucketCTK)
+			if errc != nil {
+				return fmt.Errorf("create bucket %s: %s", bucketCTK, errc)
+			}
+			errf = indexCompleteJobs(tx.Bucket(bucketJobsComplete), b)
+			if errf != nil {
+				return fmt.Errorf("index bucket %s: %s", bucketJobsComplete, errf)
+			}
+		}
 		_, errf = tx.CreateBucketIfNotExists(bucketRGs)
 		if errf != nil {
 			return fmt.Errorf("create bucket %s: %s", bucketRGs, errf)
@@ -261,6 +294,10 @@ func initDB(dbFile string, dbBkFile string, deployment string, logger log15.Logg
 		if errf != nil {
 			return fmt.Errorf("create bucket %s: %s", bucketLGs, errf)
 		}
+		_, errf = tx.CreateBucketIfNotExists(bucketHLs)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketHLs, errf)
+		}
 		_, errf = tx.CreateBucketIfNotExists(bucketDTK)
 		if errf != nil {
 			return fmt.Errorf("create bucket %s: %s", bucketDTK, errf)
@@ -293,6 +330,46 @@ func initDB(dbFile string, dbBkFile string, deployment string, logger log15.Logg
 		if errf != nil {
 			return fmt.Errorf("create bucket %s: %s", bucketJobSecs, errf)
 		}
+		_, errf = tx.CreateBucketIfNotExists(bucketWebPrefs)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketWebPrefs, errf)
+		}
+		_, errf = tx.CreateBucketIfNotExists(bucketSettings)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketSettings, errf)
+		}
+		_, errf = tx.CreateBucketIfNotExists(bucketBehaviourSets)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketBehaviourSets, errf)
+		}
+		_, errf = tx.CreateBucketIfNotExists(bucketATK)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketATK, errf)
+		}
+		_, errf = tx.CreateBucketIfNotExists(bucketBudgets)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketBudgets, errf)
+		}
+		_, errf = tx.CreateBucketIfNotExists(bucketStartRates)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketStartRates, errf)
+		}
+		_, errf = tx.CreateBucketIfNotExists(bucketLimitFeedback)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketLimitFeedback, errf)
+		}
+		_, errf = tx.CreateBucketIfNotExists(bucketQueues)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketQueues, errf)
+		}
+		_, errf = tx.CreateBucketIfNotExists(bucketRecycle)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketRecycle, errf)
+		}
+		_, errf = tx.CreateBucketIfNotExists(bucketAnnotations)
+		if errf != nil {
+			return fmt.Errorf("create bucket %s: %s", bucketAnnotations, errf)
+		}
 		return nil
 	})
 	if err != nil {
@@ -331,10 +408,22 @@ func initDB(dbFile string, dbBkFile string, deployment string, logger log15.Logg
 // database; any existing entry is removed and the name is returned in the
 // removed slice.
 func (db *db) storeLimitGroups(limitGroups map[string]int) (changed []string, removed []string, err error) {
+	return db.storeLimits(bucketLGs, limitGroups)
+}
+
+// storeHostLimits is like storeLimitGroups(), but stores the maximum number of
+// jobs in each limit group that may run at once on a single host.
+func (db *db) storeHostLimits(hostLimits map[string]int) (changed []string, removed []string, err error) {
+	return db.storeLimits(bucketHLs, hostLimits)
+}
+
+// storeLimits does the work of storeLimitGroups() and storeHostLimits() in the
+// given bucket.
+func (db *db) storeLimits(bucket []byte, limits map[string]int) (changed []string, removed []string, err error) {
 	err = db.bolt.Batch(func(tx *bolt.Tx) error {
-		b := tx.Bucket(bucketLGs)
+		b := tx.Bucket(bucket)
 
-		for group, limit := range limitGroups {
+		for group, limit := range limits {
 			key := []byte(group)
 
 			v := b.Get(key)
@@ -379,6 +468,16 @@ func (db *db) retrieveLimitGroup(group string) int {
 	return int(binary.BigEndian.Uint64(v))
 }
 
+// retrieveHostLimit gets a value for a particular group from the db that was
+// stored with storeHostLimits(). If the group wasn't stored, returns -1.
+func (db *db) retrieveHostLimit(group string) int {
+	v := db.retrieve(bucketHLs, group)
+	if v == nil {
+		return -1
+	}
+	return int(binary.BigEndian.Uint64(v))
+}
+
 // storeNewJobs stores jobs in the live bucket, where they will only be used for
 // disaster recovery. It also stores a lookup from the Job.RepGroup to the Job's
 // key, and since this is independent, and we call this prior to checking for
@@ -628,6 +727,73 @@ func (db *db) checkIfAdded(key string) (bool, error) {
 	return isInDB, err
 }
 
+// completeKeys tells you which of the given keys are those of jobs in the
+// complete bucket.
+func (db *db) completeKeys(keys []string) (map[string]bool, error) {
+	complete := make(map[string]bool)
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketJobsComplete)
+		for _, key := range keys {
+			if b.Get([]byte(key)) != nil {
+				complete[key] = true
+			}
+		}
+		return nil
+	})
+	return complete, err
+}
+
+// staleLookups counts the entries in the RepGroup and dependency group lookup
+// buckets for jobs that are no longer in the live, complete or recycle buckets,
+// as left behind by deleteLiveJobs(). If remove is true, those entries are also
+// deleted.
+func (db *db) staleLookups(remove bool) (int, error) {
+	var count int
+	find := func(tx *bolt.Tx) error {
+		live := tx.Bucket(bucketJobsLive)
+		complete := tx.Bucket(bucketJobsComplete)
+		recycle := tx.Bucket(bucketRecycle)
+		delim := []byte(dbDelimiter)
+		for _, bucket := range [][]byte{bucketRTK, bucketDTK, bucketRDTK} {
+			b := tx.Bucket(bucket)
+			var stale [][]byte
+			errf := b.ForEach(func(k, v []byte) error {
+				i := bytes.LastIndex(k, delim)
+				if i == -1 {
+					return nil
+				}
+				key := k[i+len(delim):]
+				if live.Get(key) == nil && complete.Get(key) == nil && recycle.Get(key) == nil {
+					stale = append(stale, k)
+				}
+				return nil
+			})
+			if errf != nil {
+				return errf
+			}
+			count += len(stale)
+
+			if !remove {
+				continue
+			}
+			for _, k := range stale {
+				if errd := b.Delete(k); errd != nil {
+					return errd
+				}
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if remove {
+		err = db.bolt.Update(find)
+	} else {
+		err = db.bolt.View(find)
+	}
+	return count, err
+}
+
 // archiveJob deletes a job from the live bucket, and adds a new version of it
 // (with different properties) to the complete bucket.
 //
@@ -671,6 +837,20 @@ func (db *db) archiveJob(key string, job *Job) error {
 			return errf
 		}
 
+		b = tx.Bucket(bucketCTK)
+		errf = b.Put(completeTimeKey(job.EndTime, key), nil)
+		if errf != nil {
+			return errf
+		}
+
+		if job.AtomicGroup != "" {
+			b = tx.Bucket(bucketATK)
+			errf = b.Put(db.generateLookupKey(job.AtomicGroup, key), nil)
+			if errf != nil {
+				return errf
+			}
+		}
+
 		b = tx.Bucket(bucketJobRAM)
 		errf = b.Put([]byte(fmt.Sprintf("%s%s%20d", job.ReqGroup, dbDelimiter, job.PeakRAM)), []byte(strconv.Itoa(job.PeakRAM)))
 		if errf != nil {
@@ -691,6 +871,63 @@ func (db *db) archiveJob(key string, job *Job) error {
 	return err
 }
 
+// archiveCachedJobs adds jobs that were completed without running (using the
+// results of a previous run) straight to the complete bucket, along with the
+// lookups storeNewJobs() would have stored for them. Unlike archiveJob(), their
+// resource usage is not recorded again. A backgroundBackup() is triggered
+// afterwards.
+func (db *db) archiveCachedJobs(jobs []*Job) error {
+	encodedJobs := make(sobsd, 0, len(jobs))
+	for _, job := range jobs {
+		var encoded []byte
+		enc := codec.NewEncoderBytes(&encoded, db.ch)
+		job.RLock()
+		err := enc.Encode(job)
+		job.RUnlock()
+		if err != nil {
+			return err
+		}
+		encodedJobs = append(encodedJobs, [2][]byte{[]byte(job.Key()), encoded})
+	}
+
+	err := db.bolt.Batch(func(tx *bolt.Tx) error {
+		complete := tx.Bucket(bucketJobsComplete)
+		for i, job := range jobs {
+			key := encodedJobs[i][0]
+			if errf := complete.Put(key, encodedJobs[i][1]); errf != nil {
+				return errf
+			}
+
+			job.RLock()
+			lookups := [][2][]byte{
+				{bucketCTK, completeTimeKey(job.EndTime, key)},
+				{bucketRTK, db.generateLookupKey(job.RepGroup, key)},
+				{bucketRGs, []byte(job.RepGroup)},
+			}
+			for _, depGroup := range job.DepGroups {
+				if depGroup != "" {
+					lookups = append(lookups, [2][]byte{bucketDTK, db.generateLookupKey(depGroup, key)})
+				}
+			}
+			for _, depGroup := range job.Dependencies.DepGroups() {
+				lookups = append(lookups, [2][]byte{bucketRDTK, db.generateLookupKey(depGroup, key)})
+			}
+			job.RUnlock()
+
+			for _, lookup := range lookups {
+				if errf := tx.Bucket(lookup[0]).Put(lookup[1], nil); errf != nil {
+					return errf
+				}
+			}
+		}
+		return nil
+	})
+
+	db.backgroundBackup()
+
+	return err
+}
+
 // deleteLiveJob remove a job from the live bucket, for use when jobs were
 // added in error.
 func (db *db) deleteLiveJob(key string) {
@@ -722,6 +959,103 @@ func (db *db) deleteLiveJobs(keys []string) error {
 	return nil
 }
 
+// recycleLiveJobs moves multiple jobs from the live bucket to the recycle
+// bucket, noting that they were deleted at the given time, so that they can be
+// restored with retrieveRecycledJobs() until deleted with deleteRecycledJobs().
+func (db *db) recycleLiveJobs(keys []string, deleted time.Time) error {
+	stamp := make([]byte, 8)
+	binary.BigEndian.PutUint64(stamp, uint64(deleted.UnixNano()))
+	err := db.bolt.Batch(func(tx *bolt.Tx) error {
+		live := tx.Bucket(bucketJobsLive)
+		recycle := tx.Bucket(bucketRecycle)
+		for _, key := range keys {
+			if encoded := live.Get([]byte(key)); encoded != nil {
+				val := make([]byte, 0, len(stamp)+len(encoded))
+				val = append(append(val, stamp...), encoded...)
+				if errp := recycle.Put([]byte(key), val); errp != nil {
+					return errp
+				}
+			}
+			if errd := live.Delete([]byte(key)); errd != nil {
+				return errd
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	db.backgroundBackup()
+
+	return nil
+}
+
+// retrieveRecycledJobs returns all the jobs in the recycle bucket that were
+// deleted at or after the given time, along with when they were deleted.
+func (db *db) retrieveRecycledJobs(since time.Time) ([]*RecycledJob, error) {
+	var recycled []*RecycledJob
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketRecycle)
+		return b.ForEach(func(key, val []byte) error {
+			if len(val) < 8 {
+				return nil
+			}
+			deleted := time.Unix(0, int64(binary.BigEndian.Uint64(val[:8])))
+			if deleted.Before(since) {
+				return nil
+			}
+			dec := codec.NewDecoderBytes(val[8:], db.ch)
+			job := &Job{}
+			if errf := dec.Decode(job); errf != nil {
+				return errf
+			}
+			recycled = append(recycled, &RecycledJob{Job: job, Deleted: deleted})
+			return nil
+		})
+	})
+	return recycled, err
+}
+
+// deleteRecycledJobs permanently removes the jobs with the given keys from the
+// recycle bucket.
+func (db *db) deleteRecycledJobs(keys []string) error {
+	return db.bolt.Batch(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketRecycle)
+		for _, key := range keys {
+			if err := b.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// deleteRecycledBefore permanently removes the jobs in the recycle bucket that
+// were deleted before the given time.
+func (db *db) deleteRecycledBefore(before time.Time) error {
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketRecycle)
+		var keys [][]byte
+		err := b.ForEach(func(key, val []byte) error {
+			if len(val) < 8 || time.Unix(0, int64(binary.BigEndian.Uint64(val[:8]))).Before(before) {
+				keys = append(keys, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := b.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // recoverIncompleteJobs returns all jobs in the live bucket, for use when
 // restarting the server, allowing you start working on any jobs that were
 // stored with storeNewJobs() but not yet archived with archiveJob().
@@ -751,11 +1085,15 @@ func (db *db) recoverIncompleteJobs() ([]*Job, error) {
 
 // retrieveCompleteJobsByKeys gets jobs with the given keys from the completed
 // jobs bucket (ie. those that have gone through the queue and been Remove()d).
-func (db *db) retrieveCompleteJobsByKeys(keys []string) ([]*Job, error) {
+// Returns the context's error if it is done before we finish.
+func (db *db) retrieveCompleteJobsByKeys(ctx context.Context, keys []string) ([]*Job, error) {
 	var jobs []*Job
 	err := db.bolt.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket(bucketJobsComplete)
 		for _, key := range keys {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			encoded := b.Get([]byte(key))
 			if encoded != nil {
 				dec := codec.NewDecoderBytes(encoded, db.ch)
@@ -787,8 +1125,8 @@ func (db *db) retrieveRepGroups() ([]string, error) {
 // retrieveCompleteJobsByRepGroup gets jobs with the given RepGroup from the
 // completed jobs bucket (ie. those that have gone through the queue and been
 // Archive()d), but not those that are also currently live (ie. are being
-// re-run).
-func (db *db) retrieveCompleteJobsByRepGroup(repgroup string) ([]*Job, error) {
+// re-run). Returns the context's error if it is done before we finish.
+func (db *db) retrieveCompleteJobsByRepGroup(ctx context.Context, repgroup string) ([]*Job, error) {
 	var jobs []*Job
 	err := db.bolt.View(func(tx *bolt.Tx) error {
 		newJobBucket := tx.Bucket(bucketJobsLive)
@@ -796,6 +1134,9 @@ func (db *db) retrieveCompleteJobsByRepGroup(repgroup string) ([]*Job, error) {
 		lookupBucket := tx.Bucket(bucketRTK).Cursor()
 		prefix := []byte(repgroup + dbDelimiter)
 		for k, _ := lookupBucket.Seek(prefix); bytes.HasPrefix(k, prefix); k, _ = lookupBucket.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			key := bytes.TrimPrefix(k, prefix)
 			encoded := completeJobBucket.Get(key)
 			if len(encoded) > 0 && newJobBucket.Get(key) == nil {
@@ -813,6 +1154,163 @@ func (db *db) retrieveCompleteJobsByRepGroup(repgroup string) ([]*Job, error) {
 	return jobs, err
 }
 
+// invalidateAtomicGroup marks the jobs with the given AtomicGroup in the
+// completed jobs bucket (but not those that are also currently live) as
+// AtomicGroupFailed, returning the keys of the jobs that weren't already
+// marked.
+func (db *db) invalidateAtomicGroup(group string) ([]string, error) {
+	var keys []string
+	err := db.bolt.Update(func(tx *bolt.Tx) error {
+		newJobBucket := tx.Bucket(bucketJobsLive)
+		completeJobBucket := tx.Bucket(bucketJobsComplete)
+		lookupBucket := tx.Bucket(bucketATK).Cursor()
+		prefix := []byte(group + dbDelimiter)
+		for k, _ := lookupBucket.Seek(prefix); bytes.HasPrefix(k, prefix); k, _ = lookupBucket.Next() {
+			key := bytes.TrimPrefix(k, prefix)
+			encoded := completeJobBucket.Get(key)
+			if len(encoded) == 0 || newJobBucket.Get(key) != nil {
+				continue
+			}
+
+			dec := codec.NewDecoderBytes(encoded, db.ch)
+			job := &Job{}
+			if err := dec.Decode(job); err != nil {
+				return err
+			}
+			if job.AtomicGroupFailed {
+				continue
+			}
+			job.AtomicGroupFailed = true
+
+			var reencoded []byte
+			enc := codec.NewEncoderBytes(&reencoded, db.ch)
+			if err := enc.Encode(job); err != nil {
+				return err
+			}
+			if err := completeJobBucket.Put(key, reencoded); err != nil {
+				return err
+			}
+			keys = append(keys, string(key))
+		}
+		return nil
+	})
+	if err == nil && len(keys) > 0 {
+		db.backgroundBackup()
+	}
+	return keys, err
+}
+
+// completeTimeKey returns the key of our index of complete jobs by the time
+// they completed, for the given job key and end time. The keys sort by time.
+func completeTimeKey(endTime time.Time, key []byte) []byte {
+	return append([]byte(fmt.Sprintf("%020d%s", endTime.UnixNano(), dbDelimiter)), key...)
+}
+
+// indexCompleteJobs adds all the jobs in the given complete jobs bucket to the
+// given index bucket, keyed by completeTimeKey(). You must be inside a bolt
+// transaction when calling this.
+func indexCompleteJobs(complete, index *bolt.Bucket) error {
+	ch := new(codec.BincHandle)
+	return complete.ForEach(func(key, encoded []byte) error {
+		job := &Job{}
+		dec := codec.NewDecoderBytes(encoded, ch)
+		if err := dec.Decode(job); err != nil {
+			return err
+		}
+		return index.Put(completeTimeKey(job.EndTime, key), nil)
+	})
+}
+
+// retrieveCompleteJobsInRange gets jobs from the completed jobs bucket that
+// completed at or after since and before until (zero times mean no bound), most
+// recently completed first, but not those that are currently live (ie. are
+// being re-run).
+//
+// If repgroups are supplied, only jobs that have ever had one of them as their
+// RepGroup are returned, with their RepGroup set to the one they matched. A
+// limit greater than 0 returns at most that many jobs.
+//
+// Only the jobs that completed in the time range are considered, so this is
+// efficient for recent history even when very many jobs have completed.
+// Returns the context's error if it is done before we finish.
+func (db *db) retrieveCompleteJobsInRange(ctx context.Context, repgroups []string, since, until time.Time, limit int) ([]*Job, error) {
+	var jobs []*Job
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		newJobBucket := tx.Bucket(bucketJobsLive)
+		completeJobBucket := tx.Bucket(bucketJobsComplete)
+		lookupBucket := tx.Bucket(bucketRTK).Cursor()
+		index := tx.Bucket(bucketCTK).Cursor()
+
+		var k []byte
+		if until.IsZero() {
+			k, _ = index.Last()
+		} else {
+			// position just before the first key at or after until
+			if k, _ = index.Seek([]byte(fmt.Sprintf("%020d", until.UnixNano()))); k == nil {
+				k, _ = index.Last()
+			} else {
+				k, _ = index.Prev()
+			}
+		}
+		var min []byte
+		if !since.IsZero() {
+			min = []byte(fmt.Sprintf("%020d", since.UnixNano()))
+		}
+
+		for ; k != nil; k, _ = index.Prev() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if min != nil && bytes.Compare(k, min) < 0 {
+				break
+			}
+			parts := bytes.SplitN(k, []byte(dbDelimiter), 2)
+			if len(parts) != 2 {
+				continue
+			}
+			key := parts[1]
+
+			rg := ""
+			if len(repgroups) > 0 {
+				for _, repgroup := range repgroups {
+					lookup := []byte(repgroup + dbDelimiter + string(key))
+					if lk, _ := lookupBucket.Seek(lookup); bytes.Equal(lk, lookup) {
+						rg = repgroup
+						break
+					}
+				}
+				if rg == "" {
+					continue
+				}
+			}
+
+			encoded := completeJobBucket.Get(key)
+			if len(encoded) == 0 || newJobBucket.Get(key) != nil {
+				continue
+			}
+			dec := codec.NewDecoderBytes(encoded, db.ch)
+			job := &Job{}
+			if errd := dec.Decode(job); errd != nil {
+				return errd
+			}
+			if !bytes.Equal(completeTimeKey(job.EndTime, key), k) {
+				// the job was re-run and completed again at a different time
+				continue
+			}
+			if rg != "" {
+				job.RepGroup = rg
+			}
+			jobs = append(jobs, job)
+
+			if limit > 0 && len(jobs) >= limit {
+				break
+			}
+		}
+		return nil
+	})
+	return jobs, err
+}
+
 // retrieveDependentJobs gets previously stored jobs that had a dependency on
 // one for the input depGroups. If the job is found in the live bucket, then it
 // is returned in the jobsToUpdate return value. If it is found in the complete
@@ -923,7 +1421,10 @@ func (db *db) storeEnv(env []byte) (string, error) {
 		if err != nil {
 			return envkey, err
 		}
-		db.envcache.Add(envkey, env)
+
+		// env may be a view of a message buffer that will be reused, so we
+		// cache a copy
+		db.envcache.Add(envkey, append([]byte(nil), env...))
 	}
 	return envkey, nil
 }
@@ -941,6 +1442,260 @@ func (db *db) retrieveEnv(envkey string) []byte {
 	return envc
 }
 
+// storeWebPrefs stores the status webpage preferences of the user with the
+// given auth token. The token itself is not stored, only a hash of it.
+func (db *db) storeWebPrefs(token []byte, prefs []byte) error {
+	return db.store(bucketWebPrefs, webPrefsKey(token), prefs)
+}
+
+// retrieveWebPrefs gets the preferences stored with storeWebPrefs() for the
+// given auth token, returning nil if there are none.
+func (db *db) retrieveWebPrefs(token []byte) []byte {
+	return db.retrieve(bucketWebPrefs, webPrefsKey(token))
+}
+
+// storeSetting stores the value of a manager setting, so that it can be
+// reapplied after a restart.
+func (db *db) storeSetting(name, value string) error {
+	return db.store(bucketSettings, name, []byte(value))
+}
+
+// retrieveSettings gets all the settings stored with storeSetting().
+func (db *db) retrieveSettings() (map[string]string, error) {
+	settings := make(map[string]string)
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketSettings)
+		return b.ForEach(func(k, v []byte) error {
+			settings[string(k)] = string(v)
+			return nil
+		})
+	})
+	return settings, err
+}
+
+// storeBehaviourSet stores all the versions of a BehaviourSet under its name.
+func (db *db) storeBehaviourSet(name string, versions []*BehaviourSet) error {
+	encoded, err := json.Marshal(versions)
+	if err != nil {
+		return err
+	}
+	return db.store(bucketBehaviourSets, name, encoded)
+}
+
+// retrieveBehaviourSet gets all the versions of the named BehaviourSet stored
+// with storeBehaviourSet(), oldest first.
+func (db *db) retrieveBehaviourSet(name string) ([]*BehaviourSet, error) {
+	encoded := db.retrieve(bucketBehaviourSets, name)
+	if encoded == nil {
+		return nil, nil
+	}
+	var versions []*BehaviourSet
+	err := json.Unmarshal(encoded, &versions)
+	return versions, err
+}
+
+// retrieveBehaviourSets gets all the versions of all the BehaviourSets stored
+// with storeBehaviourSet(), keyed on name.
+func (db *db) retrieveBehaviourSets() (map[string][]*BehaviourSet, error) {
+	all := make(map[string][]*BehaviourSet)
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketBehaviourSets)
+		return b.ForEach(func(k, v []byte) error {
+			var versions []*BehaviourSet
+			if err := json.Unmarshal(v, &versions); err != nil {
+				return err
+			}
+			all[string(k)] = versions
+			return nil
+		})
+	})
+	return all, err
+}
+
+// deleteBehaviourSet removes all versions of the named BehaviourSet.
+func (db *db) deleteBehaviourSet(name string) error {
+	return db.bolt.Batch(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketBehaviourSets).Delete([]byte(name))
+	})
+}
+
+// storeBudget stores a Budget, including its consumption so far, under its
+// RepGroup.
+func (db *db) storeBudget(budget *Budget) error {
+	encoded, err := json.Marshal(budget)
+	if err != nil {
+		return err
+	}
+	return db.store(bucketBudgets, budget.RepGroup, encoded)
+}
+
+// retrieveBudgets gets all the Budgets stored with storeBudget().
+func (db *db) retrieveBudgets() ([]*Budget, error) {
+	var budgets []*Budget
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketBudgets)
+		return b.ForEach(func(k, v []byte) error {
+			budget := &Budget{}
+			if err := json.Unmarshal(v, budget); err != nil {
+				return err
+			}
+			budgets = append(budgets, budget)
+			return nil
+		})
+	})
+	return budgets, err
+}
+
+// deleteBudget removes the Budget of the given RepGroup.
+func (db *db) deleteBudget(repGroup string) error {
+	return db.bolt.Batch(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketBudgets).Delete([]byte(repGroup))
+	})
+}
+
+// storeStartRate stores a StartRate under its RepGroup.
+func (db *db) storeStartRate(rate *StartRate) error {
+	encoded, err := json.Marshal(rate)
+	if err != nil {
+		return err
+	}
+	return db.store(bucketStartRates, rate.RepGroup, encoded)
+}
+
+// retrieveStartRates gets all the StartRates stored with storeStartRate().
+func (db *db) retrieveStartRates() ([]*StartRate, error) {
+	var rates []*StartRate
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketStartRates)
+		return b.ForEach(func(k, v []byte) error {
+			rate := &StartRate{}
+			if err := json.Unmarshal(v, rate); err != nil {
+				return err
+			}
+			rates = append(rates, rate)
+			return nil
+		})
+	})
+	return rates, err
+}
+
+// deleteStartRate removes the StartRate of the given RepGroup.
+func (db *db) deleteStartRate(repGroup string) error {
+	return db.bolt.Batch(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketStartRates).Delete([]byte(repGroup))
+	})
+}
+
+// storeLimitFeedback stores a LimitFeedback under its Group.
+func (db *db) storeLimitFeedback(feedback *LimitFeedback) error {
+	encoded, err := json.Marshal(feedback)
+	if err != nil {
+		return err
+	}
+	return db.store(bucketLimitFeedback, feedback.Group, encoded)
+}
+
+// retrieveLimitFeedbacks gets all the LimitFeedback stored with
+// storeLimitFeedback().
+func (db *db) retrieveLimitFeedbacks() ([]*LimitFeedback, error) {
+	var feedbacks []*LimitFeedback
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketLimitFeedback)
+		return b.ForEach(func(k, v []byte) error {
+			feedback := &LimitFeedback{}
+			if err := json.Unmarshal(v, feedback); err != nil {
+				return err
+			}
+			feedbacks = append(feedbacks, feedback)
+			return nil
+		})
+	})
+	return feedbacks, err
+}
+
+// deleteLimitFeedback removes the LimitFeedback of the given limit group.
+func (db *db) deleteLimitFeedback(group string) error {
+	return db.bolt.Batch(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketLimitFeedback).Delete([]byte(group))
+	})
+}
+
+// storeQueueConfig stores a QueueConfig under its Name.
+func (db *db) storeQueueConfig(qc *QueueConfig) error {
+	encoded, err := json.Marshal(qc)
+	if err != nil {
+		return err
+	}
+	return db.store(bucketQueues, qc.Name, encoded)
+}
+
+// retrieveQueueConfigs gets all the QueueConfigs stored with
+// storeQueueConfig().
+func (db *db) retrieveQueueConfigs() ([]*QueueConfig, error) {
+	var qcs []*QueueConfig
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketQueues)
+		return b.ForEach(func(k, v []byte) error {
+			qc := &QueueConfig{}
+			if err := json.Unmarshal(v, qc); err != nil {
+				return err
+			}
+			qcs = append(qcs, qc)
+			return nil
+		})
+	})
+	return qcs, err
+}
+
+// deleteQueueConfig removes the QueueConfig of the given queue.
+func (db *db) deleteQueueConfig(name string) error {
+	return db.bolt.Batch(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketQueues).Delete([]byte(name))
+	})
+}
+
+// storeAnnotation stores an Annotation under its job key or RepGroup and its
+// time, so that all the notes on a job or RepGroup can be retrieved in order.
+func (db *db) storeAnnotation(a *Annotation) error {
+	encoded, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	target, name := annotationTargetJob, a.Key
+	if a.Key == "" {
+		target, name = annotationTargetRepGroup, a.RepGroup
+	}
+	key := target + dbDelimiter + name + dbDelimiter + fmt.Sprintf("%020d", a.Time.UnixNano())
+	return db.store(bucketAnnotations, key, encoded)
+}
+
+// retrieveAnnotations gets the Annotations stored with storeAnnotation() for
+// the job with the given key (if target is annotationTargetJob) or the given
+// RepGroup (if target is annotationTargetRepGroup), oldest first.
+func (db *db) retrieveAnnotations(target, name string) ([]*Annotation, error) {
+	var annotations []*Annotation
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketAnnotations).Cursor()
+		prefix := []byte(target + dbDelimiter + name + dbDelimiter)
+		for k, v := c.Seek(prefix); bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			a := &Annotation{}
+			if err := json.Unmarshal(v, a); err != nil {
+				return err
+			}
+			annotations = append(annotations, a)
+		}
+		return nil
+	})
+	return annotations, err
+}
+
+// webPrefsKey returns the key to store web preferences under for the given
+// auth token.
+func webPrefsKey(token []byte) string {
+	sum := sha256.Sum256(token)
+	return hex.EncodeToString(sum[:])
+}
+
 // updateJobAfterExit stores the Job's peak RAM usage and wall time against the
 // Job's ReqGroup, but only if the job failed for using too much RAM or time,
 // allowing recommendedReqGroup*(ReqGroup) to work.
@@ -1498,6 +2253,130 @@ func (db *db) putEncodedJobs(tx *bolt.Tx, bucket []byte, encodes sobsd) error {
 	return nil
 }
 
+// storeEvents stores the given events in the events bucket, keyed on their
+// Time so that they can be retrieved in order.
+func (db *db) storeEvents(events []*Event) error {
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketEvents)
+		for i, event := range events {
+			var encoded []byte
+			enc := codec.NewEncoderBytes(&encoded, db.ch)
+			if err := enc.Encode(event); err != nil {
+				return err
+			}
+			key := []byte(fmt.Sprintf("%020d%s%06d", event.Time.UnixNano(), dbDelimiter, i))
+			if err := b.Put(key, encoded); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// retrieveEvents gets the events that happened at or after since (a zero time
+// means from the start), optionally only those of the given types, in the
+// order they happened. A limit greater than 0 returns only that many of the
+// most recent matching events.
+func (db *db) retrieveEvents(since time.Time, types []EventType, limit int) ([]*Event, error) {
+	wanted := make(map[EventType]bool)
+	for _, t := range types {
+		wanted[t] = true
+	}
+
+	var events []*Event
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketEvents).Cursor()
+		var min []byte
+		if !since.IsZero() {
+			min = []byte(fmt.Sprintf("%020d", since.UnixNano()))
+		}
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			if min != nil && bytes.Compare(k, min) < 0 {
+				break
+			}
+			event := &Event{}
+			dec := codec.NewDecoderBytes(v, db.ch)
+			if err := dec.Decode(event); err != nil {
+				return err
+			}
+			if (len(wanted) > 0 && !wanted[event.Type]) || (len(wanted) == 0 && stateHistoryEventTypes[event.Type]) {
+				continue
+			}
+			events = append(events, event)
+			if limit > 0 && len(events) >= limit {
+				break
+			}
+		}
+		return nil
+	})
+
+	// we gathered most recent first, but want them in the order they happened
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events, err
+}
+
+// retrieveStateHistory returns the most recent state snapshot event that
+// happened at or before the given time (nil if there isn't one), along with the
+// state change events that happened after that snapshot up to and including
+// the given time, in the order they happened.
+func (db *db) retrieveStateHistory(at time.Time) (*Event, []*Event, error) {
+	var snapshot *Event
+	var changes []*Event
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketEvents).Cursor()
+		max := []byte(fmt.Sprintf("%020d", at.UnixNano()+1))
+
+		// find the last key before max, then work backwards
+		k, v := c.Seek(max)
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		for ; k != nil; k, v = c.Prev() {
+			event := &Event{}
+			dec := codec.NewDecoderBytes(v, db.ch)
+			if err := dec.Decode(event); err != nil {
+				return err
+			}
+			switch event.Type {
+			case EventTypeStateSnapshot:
+				snapshot = event
+				return nil
+			case EventTypeStateChange:
+				changes = append(changes, event)
+			}
+		}
+		return nil
+	})
+
+	for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+		changes[i], changes[j] = changes[j], changes[i]
+	}
+	return snapshot, changes, err
+}
+
+// deleteEventsBefore deletes all events that happened before the given time.
+func (db *db) deleteEventsBefore(before time.Time) error {
+	max := []byte(fmt.Sprintf("%020d", before.UnixNano()))
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketEvents)
+		var keys [][]byte
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k, max) < 0; k, _ = c.Next() {
+			keys = append(keys, k)
+		}
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // close shuts down the db, should be used prior to exiting. Ensures any
 // ongoing backgroundBackup() completes first (but does not wait for backup() to
 // complete).
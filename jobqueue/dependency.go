@@ -20,6 +20,16 @@ package jobqueue
 
 // This file contains the dependency related code.
 
+// repGroupDepGroupPrefix is prefixed to RepGroups to name the queue dependency
+// groups that jobs join, which RepGroup based Dependency structs depend upon.
+const repGroupDepGroupPrefix = "rep_grp:"
+
+// repGroupDepGroup returns the name of the queue dependency group that jobs
+// with the given RepGroup join.
+func repGroupDepGroup(repGroup string) string {
+	return repGroupDepGroupPrefix + repGroup
+}
+
 // Dependencies is a slice of *Dependency, for use in Job.Dependencies. It
 // describes the jobs that must be complete before the Job you associate this
 // with will start.
@@ -66,13 +76,28 @@ func (d Dependencies) DepGroups() []string {
 	return depGroups
 }
 
+// repGroupDepGroups returns the queue dependency groups corresponding to the
+// RepGroups of our constituent Dependency structs.
+func (d Dependencies) repGroupDepGroups() []string {
+	var groups []string
+	for _, dep := range d {
+		if dep.RepGroup != "" && dep.DepGroup == "" {
+			groups = append(groups, repGroupDepGroup(dep.RepGroup))
+		}
+	}
+	return groups
+}
+
 // Stringify converts our constituent Dependency structs in to a slice of
-// strings, each of which could be JobEssence or DepGroup based.
+// strings, each of which could be JobEssence, DepGroup or RepGroup based (the
+// latter being prefixed with "rep_grp:").
 func (d Dependencies) Stringify() []string {
 	var strings []string
 	for _, dep := range d {
 		if dep.DepGroup != "" {
 			strings = append(strings, dep.DepGroup)
+		} else if dep.RepGroup != "" {
+			strings = append(strings, repGroupDepGroup(dep.RepGroup))
 		} else if dep.Essence != nil {
 			strings = append(strings, dep.Essence.Stringify())
 		}
@@ -81,11 +106,19 @@ func (d Dependencies) Stringify() []string {
 }
 
 // Dependency is a struct that describes a Job purely in terms of a JobEssence,
-// or in terms of a Job's DepGroup, for use in Dependencies. If DepGroup is
-// specified, then Essence is ignored.
+// or in terms of a Job's DepGroup, or describes all the Jobs with a certain
+// RepGroup, for use in Dependencies. If DepGroup is specified, then RepGroup
+// and Essence are ignored, and if RepGroup is specified, Essence is ignored.
+//
+// A RepGroup based Dependency means waiting for every incomplete job with that
+// RepGroup, including those added after the dependent job, as long as the
+// dependent job hasn't started running yet. Unlike DepGroups, jobs with the
+// RepGroup being added later will not cause already complete dependent jobs to
+// be re-run.
 type Dependency struct {
 	Essence  *JobEssence
 	DepGroup string
+	RepGroup string
 }
 
 // incompleteJobKeys calculates the job keys that this dependency refers to. For
@@ -99,6 +132,10 @@ func (d *Dependency) incompleteJobKeys(db *db) ([]string, error) {
 		keys, err := db.retrieveIncompleteJobKeysByDepGroup(d.DepGroup)
 		return keys, err
 	}
+	if d.RepGroup != "" {
+		// these are handled by the queue's dependency groups
+		return []string{}, nil
+	}
 	if d.Essence != nil {
 		jobKey := d.Essence.Key()
 		live, err := db.checkIfLive(jobKey)
@@ -128,3 +165,11 @@ func NewDepGroupDependency(depgroup string) *Dependency {
 		DepGroup: depgroup,
 	}
 }
+
+// NewRepGroupDependency makes it a little easier to make a new *Dependency
+// based on a RepGroup, for use in NewDependencies().
+func NewRepGroupDependency(repgroup string) *Dependency {
+	return &Dependency{
+		RepGroup: repgroup,
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of host draining, where we stop
+// giving jobs to runners on a host and have the runners already there requeue
+// their jobs, so that the host can be taken down for maintenance.
+
+import (
+	"time"
+)
+
+// drainHost notes that the given host is being drained, so that runners on it
+// will no longer be able to reserve jobs, and the runners running jobs on it
+// will be told to requeue them, giving their commands the given grace period to
+// checkpoint and exit after being sent SIGTERM. Returns the number of jobs
+// still reserved or running on the host.
+func (s *Server) drainHost(host string, grace time.Duration) int {
+	s.dhmutex.Lock()
+	if _, already := s.drainingHosts[host]; !already {
+		s.Info("draining host", "host", host, "grace", grace)
+	}
+	s.drainingHosts[host] = grace
+	s.dhmutex.Unlock()
+	return s.jobsOnHost(host)
+}
+
+// undrainHost stops draining the given host, so that it can be given jobs
+// again.
+func (s *Server) undrainHost(host string) {
+	s.dhmutex.Lock()
+	defer s.dhmutex.Unlock()
+	if _, draining := s.drainingHosts[host]; draining {
+		s.Info("stopped draining host", "host", host)
+		delete(s.drainingHosts, host)
+	}
+}
+
+// hostDraining tells you if the given host is being drained, and if so, the
+// grace period its jobs' commands have to exit once told to requeue.
+func (s *Server) hostDraining(host string) (bool, time.Duration) {
+	if host == "" {
+		return false, 0
+	}
+	s.dhmutex.RLock()
+	defer s.dhmutex.RUnlock()
+	grace, draining := s.drainingHosts[host]
+	return draining, grace
+}
+
+// jobsOnHost returns the number of jobs reserved or running on the given host.
+func (s *Server) jobsOnHost(host string) int {
+	count := 0
+	for _, inter := range s.q.GetRunningData() {
+		job := inter.(*Job)
+		if job.runningOn() == host {
+			count++
+		}
+	}
+	return count
+}
+
+// runningOn returns the host the job is running on, or the host of the runner
+// that reserved it if it hasn't started running yet.
+func (j *Job) runningOn() string {
+	j.RLock()
+	defer j.RUnlock()
+	if j.Host != "" {
+		return j.Host
+	}
+	return j.reservedHost
+}
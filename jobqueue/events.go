@@ -0,0 +1,395 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of the server's event log, a
+// persistent audit trail of significant things that happened to jobs and
+// runners, which can be queried by clients and streamed to the web interface.
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+)
+
+// EventType is the kind of thing an Event records.
+type EventType string
+
+// EventType* are the types of Event the server records.
+const (
+	EventTypeAdd            EventType = "add"
+	EventTypeStart          EventType = "start"
+	EventTypeManualRun      EventType = "manual_run"
+	EventTypeBury           EventType = "bury"
+	EventTypeBehaviour      EventType = "behaviour"
+	EventTypeRetry          EventType = "retry"
+	EventTypeRemove         EventType = "remove"
+	EventTypeRestore        EventType = "restore"
+	EventTypeKill           EventType = "kill"
+	EventTypePause          EventType = "pause"
+	EventTypeResume         EventType = "resume"
+	EventTypeSchedulerError EventType = "scheduler_error"
+	EventTypeScaleUp        EventType = "scale_up"
+	EventTypeScaleDown      EventType = "scale_down"
+	EventTypeBudget         EventType = "budget"
+	EventTypeLimit          EventType = "limit"
+	EventTypeStateChange    EventType = "state_change"
+	EventTypeStateSnapshot  EventType = "state_snapshot"
+	EventTypeAnnotate       EventType = "annotate"
+)
+
+// stateHistoryEventTypes are the EventTypes only used to reconstruct past
+// state counts; they are not streamed to subscribers or returned by
+// Client.GetEvents() unless specifically asked for.
+var stateHistoryEventTypes = map[EventType]bool{
+	EventTypeStateChange:   true,
+	EventTypeStateSnapshot: true,
+}
+
+// ServerEventBuffer is how many events can be waiting to be stored before new
+// ones are dropped.
+var ServerEventBuffer = 10000
+
+// ServerEventRetention is how long the server keeps events for before
+// deleting them.
+var ServerEventRetention = 30 * 24 * time.Hour
+
+// ServerEventPruneInterval is how often the server deletes events older than
+// ServerEventRetention.
+var ServerEventPruneInterval = 1 * time.Hour
+
+// ServerEventSnapshotInterval is how often the server records the current
+// per-RepGroup state counts in the event log, so that past state counts can be
+// reconstructed from the nearest earlier snapshot plus the state changes since.
+var ServerEventSnapshotInterval = 1 * time.Hour
+
+// Event is something that happened in the server, as returned by
+// Client.GetEvents().
+type Event struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+
+	// Key is the key of the job the event is about, if it is about a single
+	// job.
+	Key string `json:"key,omitempty"`
+
+	// RepGroup is the RepGroup of the job(s) the event is about.
+	RepGroup string `json:"rep_grp,omitempty"`
+
+	// SchedulerGroup is the scheduler group scale events are about.
+	SchedulerGroup string `json:"scheduler_group,omitempty"`
+
+	// Host is the host a job started running on, or was reserved to be run
+	// manually on.
+	Host string `json:"host,omitempty"`
+
+	// User is who asked for jobs to be retried, removed, restored, killed or
+	// buried, or for the server to be paused or resumed, or who left a note.
+	// Names claimed by command line clients can't be verified, and are marked
+	// as such (see UserAction).
+	User string `json:"user,omitempty"`
+
+	// Count is the number of jobs added, retried, removed, restored or
+	// killed, the number of runners now requested when scaling, or the number
+	// of jobs that changed state.
+	Count int `json:"count,omitempty"`
+
+	// From and To are the states jobs changed between, for state changes.
+	From JobState `json:"from,omitempty"`
+	To   JobState `json:"to,omitempty"`
+
+	// Snapshot holds the live job state counts per RepGroup at the time of a
+	// state snapshot.
+	Snapshot []*StateCount `json:"snapshot,omitempty"`
+
+	// Msg holds the fail reason of buried jobs, the details of a scheduler
+	// error, the behaviour that ran after a job, how long it took and any
+	// problem it had, the state of a RepGroup's budget, how and why a limit
+	// group's limit was tuned, or the note left on a job or RepGroup.
+	Msg string `json:"msg,omitempty"`
+}
+
+// StateCount is the number of jobs in a RepGroup that were in a particular
+// state.
+type StateCount struct {
+	RepGroup string   `json:"rep_grp"`
+	State    JobState `json:"state"`
+	Count    int      `json:"count"`
+}
+
+// eventsReq is what the status webpage sends to subscribe to events.
+const eventsReq = "events"
+
+// recordEvent timestamps the given event and queues it to be stored and sent to
+// subscribers. It never blocks; if too many events are waiting to be stored,
+// the event is dropped.
+func (s *Server) recordEvent(event *Event) {
+	event.Time = time.Now()
+	select {
+	case s.events <- event:
+	default:
+		s.Warn("event log is backed up; dropped an event", "type", event.Type)
+	}
+}
+
+// recordRetryEvent records that the given jobs were retried by the user. Does
+// nothing if there are no jobs.
+func (s *Server) recordRetryEvent(jobs []*Job) {
+	if len(jobs) == 0 {
+		return
+	}
+	event := &Event{Type: EventTypeRetry, RepGroup: commonRepGroup(jobs), Count: len(jobs)}
+	if len(jobs) == 1 {
+		event.Key = jobs[0].Key()
+	}
+	s.recordEvent(event)
+}
+
+// recordBuryEvent records that the given job was buried for the given reason.
+func (s *Server) recordBuryEvent(job *Job, failReason string) {
+	job.RLock()
+	rg := job.RepGroup
+	job.RUnlock()
+	s.recordEvent(&Event{Type: EventTypeBury, Key: job.Key(), RepGroup: rg, Msg: failReason})
+}
+
+// recordBehaviourEvents records the results of the behaviours that ran after
+// the given job's command, as given in the end state of the job.
+func (s *Server) recordBehaviourEvents(job *Job, jes *JobEndState) {
+	if jes == nil || len(jes.BehaviourResults) == 0 {
+		return
+	}
+	job.RLock()
+	rg := job.RepGroup
+	job.RUnlock()
+	key := job.Key()
+	for _, br := range jes.BehaviourResults {
+		msg := fmt.Sprintf("%s took %s", br.Behaviour, br.Duration)
+		if br.Error != "" {
+			msg += ": " + br.Error
+		}
+		s.recordEvent(&Event{Type: EventTypeBehaviour, Key: key, RepGroup: rg, Msg: msg})
+	}
+}
+
+// recordStateChange records that count jobs in the given RepGroup changed from
+// one state to another. Does nothing if count is 0.
+func (s *Server) recordStateChange(repGroup string, from, to JobState, count int) {
+	if count == 0 {
+		return
+	}
+	s.recordEvent(&Event{Type: EventTypeStateChange, RepGroup: repGroup, From: from, To: to, Count: count})
+}
+
+// recordStateSnapshot records the current live job state counts of every
+// RepGroup.
+func (s *Server) recordStateSnapshot() {
+	s.recordEvent(&Event{Type: EventTypeStateSnapshot, Snapshot: flattenStateCounts(s.liveStateCounts())})
+}
+
+// liveStateCounts returns the number of incomplete jobs in each state, per
+// RepGroup. Reserved jobs are counted as running, or staging or uploading if
+// their runner said so.
+func (s *Server) liveStateCounts() map[string]map[JobState]int {
+	counts := make(map[string]map[JobState]int)
+	for _, item := range s.q.AllItems() {
+		job := item.Data().(*Job)
+		job.RLock()
+		rg := job.RepGroup
+		state := s.itemStateToJobState(item.Stats().State, job.Lost)
+		if state == JobStateReserved {
+			state = job.runningState()
+		}
+		job.RUnlock()
+		if _, exists := counts[rg]; !exists {
+			counts[rg] = make(map[JobState]int)
+		}
+		counts[rg][state]++
+	}
+	return counts
+}
+
+// stateCountsAt reconstructs the live job state counts per RepGroup as they
+// were at the given time, from the nearest earlier state snapshot and the state
+// changes recorded since. Only times within ServerEventRetention can be
+// reconstructed, and counts are approximate if events were dropped.
+func (s *Server) stateCountsAt(at time.Time) (map[string]map[JobState]int, error) {
+	snapshot, changes, err := s.db.retrieveStateHistory(at)
+	if err != nil {
+		return nil, err
+	}
+	return applyStateChanges(snapshot, changes), nil
+}
+
+// applyStateChanges returns a copy of the given snapshot's counts (which may be
+// nil), altered by the given state change events. Only live states are
+// counted, and RepGroups left with no live jobs are not included.
+func applyStateChanges(snapshot *Event, changes []*Event) map[string]map[JobState]int {
+	counts := make(map[string]map[JobState]int)
+	alter := func(rg string, state JobState, by int) {
+		switch state {
+		case JobStateNew, JobStateComplete, JobStateDeleted:
+			return
+		case JobStateReserved:
+			state = JobStateRunning
+		}
+		if _, exists := counts[rg]; !exists {
+			counts[rg] = make(map[JobState]int)
+		}
+		counts[rg][state] += by
+	}
+
+	if snapshot != nil {
+		for _, sc := range snapshot.Snapshot {
+			alter(sc.RepGroup, sc.State, sc.Count)
+		}
+	}
+
+	for _, event := range changes {
+		alter(event.RepGroup, event.From, -event.Count)
+		alter(event.RepGroup, event.To, event.Count)
+	}
+
+	for rg, states := range counts {
+		for state, count := range states {
+			if count <= 0 {
+				delete(states, state)
+			}
+		}
+		if len(states) == 0 {
+			delete(counts, rg)
+		}
+	}
+	return counts
+}
+
+// flattenStateCounts converts per-RepGroup state counts to a slice, sorted by
+// RepGroup and then state, suitable for encoding.
+func flattenStateCounts(counts map[string]map[JobState]int) []*StateCount {
+	var scs []*StateCount
+	for rg, states := range counts {
+		for state, count := range states {
+			scs = append(scs, &StateCount{RepGroup: rg, State: state, Count: count})
+		}
+	}
+	sort.Slice(scs, func(i, j int) bool {
+		if scs[i].RepGroup == scs[j].RepGroup {
+			return scs[i].State < scs[j].State
+		}
+		return scs[i].RepGroup < scs[j].RepGroup
+	})
+	return scs
+}
+
+// commonRepGroup returns the RepGroup that all the given jobs have, or blank if
+// they don't all have the same one.
+func commonRepGroup(jobs []*Job) string {
+	var rg string
+	for i, job := range jobs {
+		job.RLock()
+		jrg := job.RepGroup
+		job.RUnlock()
+		if i == 0 {
+			rg = jrg
+		} else if jrg != rg {
+			return ""
+		}
+	}
+	return rg
+}
+
+// storeEvents should be run in a goroutine; it stores events given to
+// recordEvent() in our database and sends them to the event caster, until the
+// server stops. It also periodically deletes old events and recycled jobs.
+func (s *Server) storeEvents() {
+	defer internal.LogPanic(s.Logger, "jobqueue event storing", true)
+
+	ticker := time.NewTicker(ServerEventPruneInterval)
+	defer ticker.Stop()
+	s.pruneEvents()
+	s.pruneRecycled()
+
+	snapshotTicker := time.NewTicker(ServerEventSnapshotInterval)
+	defer snapshotTicker.Stop()
+
+	for {
+		select {
+		case event := <-s.events:
+			// store everything that's waiting in one go
+			events := []*Event{event}
+			for len(s.events) > 0 {
+				events = append(events, <-s.events)
+			}
+
+			if err := s.db.storeEvents(events); err != nil {
+				s.Warn("failed to store events", "err", err)
+			}
+			s.sendEvents(events)
+		case <-ticker.C:
+			s.pruneEvents()
+			s.pruneRecycled()
+		case <-snapshotTicker.C:
+			s.recordStateSnapshot()
+		case <-s.stopClientHandling:
+			return
+		}
+	}
+}
+
+// subscribeEvents returns a channel that will receive events as they are
+// stored, and a function you must call when you no longer want them.
+// Subscribers that don't keep up will miss events.
+func (s *Server) subscribeEvents() (chan *Event, func()) {
+	ch := make(chan *Event, ServerEventBuffer)
+	s.esmutex.Lock()
+	s.eventSubs[ch] = true
+	s.esmutex.Unlock()
+	return ch, func() {
+		s.esmutex.Lock()
+		delete(s.eventSubs, ch)
+		s.esmutex.Unlock()
+	}
+}
+
+// sendEvents sends the given events to all subscribers, without waiting for
+// any of them. State history events are not sent.
+func (s *Server) sendEvents(events []*Event) {
+	s.esmutex.RLock()
+	defer s.esmutex.RUnlock()
+	for ch := range s.eventSubs {
+		for _, event := range events {
+			if stateHistoryEventTypes[event.Type] {
+				continue
+			}
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// pruneEvents deletes events older than ServerEventRetention.
+func (s *Server) pruneEvents() {
+	if err := s.db.deleteEventsBefore(time.Now().Add(-ServerEventRetention)); err != nil {
+		s.Warn("failed to delete old events", "err", err)
+	}
+}
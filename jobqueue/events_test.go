@@ -0,0 +1,60 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStateHistory(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Given a state snapshot and some later state changes", t, func() {
+		snapshot := &Event{Type: EventTypeStateSnapshot, Snapshot: flattenStateCounts(map[string]map[JobState]int{
+			"a": {JobStateReady: 2, JobStateRunning: 1},
+			"b": {JobStateBuried: 1},
+		})}
+		changes := []*Event{
+			{Type: EventTypeStateChange, RepGroup: "a", From: JobStateReady, To: JobStateRunning, Count: 2},
+			{Type: EventTypeStateChange, RepGroup: "a", From: JobStateRunning, To: JobStateComplete, Count: 1},
+			{Type: EventTypeStateChange, RepGroup: "a", From: JobStateRunning, To: JobStateLost, Count: 1},
+			{Type: EventTypeStateChange, RepGroup: "b", From: JobStateBuried, To: JobStateDeleted, Count: 1},
+			{Type: EventTypeStateChange, RepGroup: "c", From: JobStateNew, To: JobStateDependent, Count: 3},
+		}
+
+		Convey("applyStateChanges() reconstructs the live state counts", func() {
+			counts := applyStateChanges(snapshot, changes)
+			So(counts, ShouldResemble, map[string]map[JobState]int{
+				"a": {JobStateRunning: 1, JobStateLost: 1},
+				"c": {JobStateDependent: 3},
+			})
+			So(snapshot.Snapshot[0], ShouldResemble, &StateCount{RepGroup: "a", State: JobStateReady, Count: 2})
+		})
+
+		Convey("applyStateChanges() works without a snapshot", func() {
+			counts := applyStateChanges(nil, changes[4:])
+			So(counts, ShouldResemble, map[string]map[JobState]int{"c": {JobStateDependent: 3}})
+			So(applyStateChanges(nil, nil), ShouldResemble, map[string]map[JobState]int{})
+		})
+	})
+}
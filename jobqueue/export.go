@@ -0,0 +1,201 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of job reports that can be downloaded
+// from the status webpage or REST API.
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// exportFormat* are the formats that exportJobs() can write.
+const (
+	exportFormatCSV  = "csv"
+	exportFormatJSON = "json"
+)
+
+// exportColumns are the CSV column headers of exported job reports, in the
+// same order as the fields of exportRecord.
+var exportColumns = []string{"key", "rep_group", "cmd", "state", "exit_code", "host", "walltime", "peak_ram", "metrics"}
+
+// exportRecord describes a job in an exported report.
+type exportRecord struct {
+	Key      string   `json:"key"`
+	RepGroup string   `json:"rep_group"`
+	Cmd      string   `json:"cmd"`
+	State    JobState `json:"state"`
+	Exitcode int      `json:"exit_code"`
+	Host     string   `json:"host"`
+	Walltime float64  `json:"walltime"` // seconds
+	PeakRAM  int      `json:"peak_ram"` // MB
+
+	// Metrics are the job's Metrics, which in CSV form are written as
+	// key=value pairs separated by semi-colons, sorted by key.
+	Metrics map[string]string `json:"metrics,omitempty"`
+}
+
+// newExportRecord creates an exportRecord from a Job.
+func newExportRecord(job *Job) *exportRecord {
+	return &exportRecord{
+		Key:      job.Key(),
+		RepGroup: job.RepGroup,
+		Cmd:      job.Cmd,
+		State:    job.State,
+		Exitcode: job.Exitcode,
+		Host:     job.Host,
+		Walltime: job.WallTime().Seconds(),
+		PeakRAM:  job.PeakRAM,
+		Metrics:  job.Metrics,
+	}
+}
+
+// csv returns the record's values in the order of exportColumns.
+func (r *exportRecord) csv() []string {
+	return []string{
+		r.Key,
+		r.RepGroup,
+		r.Cmd,
+		string(r.State),
+		strconv.Itoa(r.Exitcode),
+		r.Host,
+		strconv.FormatFloat(r.Walltime, 'f', 3, 64),
+		strconv.Itoa(r.PeakRAM),
+		joinMetrics(r.Metrics, ";"),
+	}
+}
+
+// exportFilename returns a suitable file name for a report in the given
+// format.
+func exportFilename(format string) string {
+	return "wr_jobs." + format
+}
+
+// exportJobs writes a report in the given format (exportFormatCSV or
+// exportFormatJSON) to w, describing the jobs (current and complete) with a
+// RepGroup that contains any of the given filters (or all jobs if there are
+// none), optionally limited to those in the given state. Jobs are written as
+// they are found, so that large reports can be streamed. Stops early if the
+// context is done.
+func (s *Server) exportJobs(ctx context.Context, w io.Writer, format string, filters []string, state JobState) error {
+	var write func(*exportRecord) error
+	var finish func() error
+	switch format {
+	case exportFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(exportColumns); err != nil {
+			return err
+		}
+		write = func(r *exportRecord) error {
+			return cw.Write(r.csv())
+		}
+		finish = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case exportFormatJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		first := true
+		write = func(r *exportRecord) error {
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			return encoder.Encode(r)
+		}
+		finish = func() error {
+			_, err := io.WriteString(w, "]\n")
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown export format %s", format)
+	}
+
+	rgs, err := s.exportRepGroups(filters)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, rg := range rgs {
+		jobs, srerr, qerr := s.getJobsByRepGroup(ctx, rg, false, 0, state, false, false)
+		if srerr != "" {
+			return fmt.Errorf("%s: %s", srerr, qerr)
+		}
+
+		for _, job := range jobs {
+			key := job.Key()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if err = write(newExportRecord(job)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return finish()
+}
+
+// exportRepGroups returns the RepGroups that contain any of the given filters,
+// or all RepGroups if there are no filters.
+func (s *Server) exportRepGroups(filters []string) ([]string, error) {
+	rgs, err := s.db.retrieveRepGroups()
+	if err != nil || len(filters) == 0 {
+		return rgs, err
+	}
+
+	var matching []string
+	for _, rg := range rgs {
+		for _, filter := range filters {
+			if strings.Contains(rg, filter) {
+				matching = append(matching, rg)
+				break
+			}
+		}
+	}
+	return matching, nil
+}
+
+// parseExportFilters splits a comma separated list of RepGroup filters,
+// ignoring empty ones.
+func parseExportFilters(list string) []string {
+	var filters []string
+	for _, filter := range strings.Split(list, ",") {
+		filter = strings.TrimSpace(filter)
+		if filter != "" {
+			filters = append(filters, filter)
+		}
+	}
+	return filters
+}
@@ -0,0 +1,343 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of archiving the working directories
+// of jobs that fail for good. Runners tar up the unique working directory of
+// such a job before its behaviours get a chance to clean it up, and send it to
+// the manager (taking turns via transfer slots, like CopyToManager), which
+// stores it in a local directory or S3, so that users can later download the
+// evidence they need to debug rare failures.
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/VertebrateResequencing/muxfys/v4"
+	"github.com/VertebrateResequencing/wr/internal"
+)
+
+// failArchiveExt is the extension of the files we store archives in.
+const failArchiveExt = ".tar.gz"
+
+// failArchiveStore is somewhere the server can keep archives of the working
+// directories of jobs that failed for good.
+type failArchiveStore interface {
+	// store saves the archive of the job with the given key, returning where
+	// it was stored.
+	store(key string, r io.Reader) (string, error)
+
+	// open lets you read a previously stored archive of the job with the given
+	// key.
+	open(key string) (io.ReadCloser, error)
+}
+
+// newFailArchiveStore returns a failArchiveStore that stores archives in the
+// given local directory, or in S3 if location is like
+// s3://[profile@]bucket/path.
+func newFailArchiveStore(location string) (failArchiveStore, error) {
+	if !internal.InS3(location) {
+		return &dirFailArchiveStore{dir: location}, nil
+	}
+
+	path := strings.TrimPrefix(location, internal.S3Prefix)
+	profile := "default"
+	if pp := strings.Split(path, "@"); len(pp) == 2 {
+		profile = pp[0]
+		path = pp[1]
+	}
+
+	accessorConfig, err := muxfys.S3ConfigFromEnvironment(profile, path)
+	if err != nil {
+		return nil, err
+	}
+	accessor, err := muxfys.NewS3Accessor(accessorConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &s3FailArchiveStore{location: strings.TrimSuffix(location, "/"), accessor: accessor}, nil
+}
+
+// dirFailArchiveStore is a failArchiveStore that keeps archives in a local
+// directory.
+type dirFailArchiveStore struct {
+	dir string
+}
+
+// path returns the path of the archive of the job with the given key.
+func (d *dirFailArchiveStore) path(key string) string {
+	return filepath.Join(d.dir, key+failArchiveExt)
+}
+
+// store implements failArchiveStore.
+func (d *dirFailArchiveStore) store(key string, r io.Reader) (string, error) {
+	if err := os.MkdirAll(d.dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	path := d.path(key)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = io.Copy(f, r)
+	if errc := f.Close(); errc != nil && err == nil {
+		err = errc
+	}
+	return path, err
+}
+
+// open implements failArchiveStore.
+func (d *dirFailArchiveStore) open(key string) (io.ReadCloser, error) {
+	return os.Open(d.path(key))
+}
+
+// s3FailArchiveStore is a failArchiveStore that keeps archives in S3.
+type s3FailArchiveStore struct {
+	location string
+	accessor *muxfys.S3Accessor
+}
+
+// store implements failArchiveStore.
+func (s *s3FailArchiveStore) store(key string, r io.Reader) (string, error) {
+	err := s.accessor.UploadData(r, s.accessor.RemotePath(key+failArchiveExt))
+	return s.location + "/" + key + failArchiveExt, err
+}
+
+// open implements failArchiveStore.
+func (s *s3FailArchiveStore) open(key string) (io.ReadCloser, error) {
+	return s.accessor.OpenFile(s.accessor.RemotePath(key+failArchiveExt), 0)
+}
+
+// restFailArchive receives the archives of jobs that failed for good from
+// clients calling ArchiveFailure(), and lets users download them again.
+//
+// PUT, with a job parameter, stores an archive. The job must have been granted
+// a transfer slot.
+//
+// GET, with a job parameter, returns the archive of that job.
+func restFailArchive(s *Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer internal.LogPanic(s.Logger, "jobqueue web server restFailArchive", false)
+
+		if r.Method == http.MethodGet {
+			if _, _, _, ok := s.webAuthorized(w, r, false); !ok {
+				return
+			}
+		} else if !s.httpAuthorized(w, r) {
+			return
+		}
+
+		if s.failArchive == nil {
+			http.Error(w, "failure archiving is not configured", http.StatusNotFound)
+			return
+		}
+
+		key := r.FormValue("job")
+		if key == "" || strings.ContainsAny(key, "/\\.") {
+			http.Error(w, fmt.Sprintf("invalid job key [%s]", key), http.StatusBadRequest)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			s.sendFailArchive(w, key)
+		case http.MethodPut:
+			s.receiveFailArchive(w, r, key)
+		default:
+			http.Error(w, "Only GET and PUT are supported", http.StatusBadRequest)
+		}
+	}
+}
+
+// sendFailArchive writes the stored archive of the job with the given key to
+// w.
+func (s *Server) sendFailArchive(w http.ResponseWriter, key string) {
+	rc, err := s.failArchive.open(key)
+	if err != nil {
+		http.Error(w, "no archive found for that job", http.StatusNotFound)
+		return
+	}
+	defer internal.LogClose(s.Logger, rc, "failure archive", "job", key)
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+key+failArchiveExt+`"`)
+	w.WriteHeader(http.StatusOK)
+	if _, err = io.Copy(w, rc); err != nil {
+		s.Warn("restFailArchive failed to send archive", "job", key, "err", err)
+	}
+}
+
+// receiveFailArchive stores the archive in the body of r as the one for the
+// job with the given key.
+func (s *Server) receiveFailArchive(w http.ResponseWriter, r *http.Request, key string) {
+	if !s.transfers.uploadStarted(key) {
+		http.Error(w, "job does not have a transfer slot", http.StatusConflict)
+		return
+	}
+	defer s.transfers.uploadEnded(key)
+
+	location, err := s.failArchive.store(key, &transferCounter{r: r.Body, ts: s.transfers, key: key})
+	if err != nil {
+		s.Error("restFailArchive failed to store archive", "job", key, "err", err)
+		http.Error(w, "archive storage failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(map[string]string{"path": location})
+	if err != nil {
+		s.Warn("restFailArchive failed to encode success msg", "err", err)
+	}
+}
+
+// ArchiveFailure tars up the given working directory of the given job, which
+// has failed for good, and sends it to the server, which stores it in its
+// configured FailureArchive location. Any of the skip paths within dir (such
+// as the mount points of remote file systems) are left out. Like
+// CopyToManager(), this first waits for a transfer slot.
+//
+// Returns where the server stored the archive.
+func (c *Client) ArchiveFailure(job *Job, dir string, skip ...string) (string, error) {
+	tmp, err := ioutil.TempFile("", "wr_failure_archive_")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if errr := os.Remove(tmp.Name()); errr != nil {
+			c.Warn("failed to remove temporary failure archive", "path", tmp.Name(), "err", errr)
+		}
+	}()
+
+	err = tarDir(tmp, dir, skip)
+	if errc := tmp.Close(); errc != nil && err == nil {
+		err = errc
+	}
+	if err != nil {
+		return "", fmt.Errorf("archiving %s failed: %w", dir, err)
+	}
+
+	key := job.Key()
+	rate, err := c.transferSlot(key)
+	if err != nil {
+		return "", err
+	}
+	defer c.releaseTransferSlot(key)
+
+	httpClient, err := c.webClient()
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("job", key)
+	return c.putFile(httpClient, restArchiveEndpoint, params, tmp.Name(), rate)
+}
+
+// tarDir writes a gzipped tar of the contents of dir to w, skipping the given
+// paths, and anything that isn't a regular file, directory or symlink.
+func tarDir(w io.Writer, dir string, skip []string) error {
+	skipping := make(map[string]bool, len(skip))
+	for _, path := range skip {
+		skipping[filepath.Clean(path)] = true
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if skipping[path] {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		var link string
+		switch mode := info.Mode(); {
+		case mode&os.ModeSymlink != 0:
+			link, err = os.Readlink(path)
+			if err != nil {
+				return err
+			}
+		case !mode.IsRegular() && !mode.IsDir():
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFileTo(tw, path)
+	})
+
+	if errc := tw.Close(); errc != nil && err == nil {
+		err = errc
+	}
+	if errc := gw.Close(); errc != nil && err == nil {
+		err = errc
+	}
+	return err
+}
+
+// copyFileTo copies the content of the file at path to w.
+func copyFileTo(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	if errc := f.Close(); errc != nil && err == nil {
+		err = errc
+	}
+	return err
+}
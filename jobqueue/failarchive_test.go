@@ -0,0 +1,121 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFailArchive(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Given a working directory", t, func() {
+		dir, err := ioutil.TempDir("", "wr_jobqueue_test_failarchive_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(os.MkdirAll(filepath.Join(dir, "sub", "empty"), 0700), ShouldBeNil)
+		So(os.MkdirAll(filepath.Join(dir, "mnt", "remote"), 0700), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0600), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("b"), 0600), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "mnt", "remote", "c.txt"), []byte("c"), 0600), ShouldBeNil)
+		So(os.Symlink("a.txt", filepath.Join(dir, "link")), ShouldBeNil)
+
+		Convey("tarDir archives it, leaving out skipped paths", func() {
+			var buf bytes.Buffer
+			err = tarDir(&buf, dir, []string{filepath.Join(dir, "mnt") + "/"})
+			So(err, ShouldBeNil)
+
+			entries, err := tarEntries(&buf)
+			So(err, ShouldBeNil)
+			So(entries, ShouldResemble, map[string]string{
+				"a.txt":      "a",
+				"link":       "-> a.txt",
+				"sub/":       "",
+				"sub/b.txt":  "b",
+				"sub/empty/": "",
+			})
+		})
+
+		Convey("A dirFailArchiveStore can store and open archives", func() {
+			store, err := newFailArchiveStore(filepath.Join(dir, "archives"))
+			So(err, ShouldBeNil)
+
+			path, err := store.store("key", strings.NewReader("archive"))
+			So(err, ShouldBeNil)
+			So(path, ShouldEqual, filepath.Join(dir, "archives", "key"+failArchiveExt))
+
+			rc, err := store.open("key")
+			So(err, ShouldBeNil)
+			content, err := ioutil.ReadAll(rc)
+			So(err, ShouldBeNil)
+			So(rc.Close(), ShouldBeNil)
+			So(string(content), ShouldEqual, "archive")
+
+			_, err = store.open("other")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+// tarEntries reads a gzipped tar, returning the contents of its files keyed on
+// their names. Directories have empty contents, and symlinks have "-> target".
+func tarEntries(r io.Reader) (map[string]string, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gr)
+
+	entries := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeSymlink:
+			entries[hdr.Name] = "-> " + hdr.Linkname
+		case tar.TypeDir:
+			entries[hdr.Name] = ""
+		default:
+			content, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			entries[hdr.Name] = string(content)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of failure classification rules.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FailureAction is supplied to a FailureRule to define what should happen to a
+// Job whose failure matched that rule.
+type FailureAction string
+
+const (
+	// FailureActionRetry releases the Job so it will be tried again. If the
+	// FailureRule has Retries set, that many retries are allowed for failures
+	// in this class regardless of the Job's own Retries, after which the Job
+	// is buried. This is the default action.
+	FailureActionRetry FailureAction = "retry"
+
+	// FailureActionBury buries the Job immediately, regardless of how many
+	// retries it has left.
+	FailureActionBury FailureAction = "bury"
+
+	// FailureActionRetryElsewhere is like FailureActionRetry, but notes the
+	// host the Job failed on so that schedulers able to target hosts (currently
+	// LSF) will avoid running it there again.
+	FailureActionRetryElsewhere FailureAction = "retry_elsewhere"
+
+	// FailureActionEscalate is like FailureActionRetry, but increases the
+	// memory and time reserved for the Job before it is tried again.
+	FailureActionEscalate FailureAction = "escalate"
+)
+
+// FailureRetriesUnlimited can be used as the Retries of a FailureRule, or as a
+// value in a Job's RetryBudgets, to say that failures in that class should
+// never result in the Job being buried.
+const FailureRetriesUnlimited = -1
+
+// failureAvoidHostsKey is the key in a Job's Requirements.Other that holds the
+// comma separated hosts that FailureActionRetryElsewhere wants avoided.
+const failureAvoidHostsKey = "avoid_hosts"
+
+// FailureRule describes how to recognise a certain class of failure, and what
+// to do about it. A rule matches if all of its set criteria match; a rule with
+// no criteria never matches.
+type FailureRule struct {
+	// Name is the canonical FailReason that matching Jobs will be given, eg.
+	// "network blip", "input missing" or "OOM".
+	Name string `json:"name"`
+
+	// Exitcodes, if set, restricts the rule to Jobs that exited with one of
+	// these codes.
+	Exitcodes []int `json:"exit_codes,omitempty"`
+
+	// StdErr, if set, is a regular expression that must match somewhere in the
+	// (truncated) STDERR of the Job's Cmd.
+	StdErr string `json:"stderr,omitempty"`
+
+	// FailReasons, if set, restricts the rule to Jobs that a runner considered
+	// to have failed for one of these FailReason* reasons. Jobs that failed
+	// because they were killed, lost contact with the manager (eg. because
+	// their spot instance was reclaimed) or received a signal are only matched
+	// by rules that explicitly name FailReasonKilled, FailReasonLost or
+	// FailReasonSignal here.
+	FailReasons []string `json:"fail_reasons,omitempty"`
+
+	// Action is what to do with matching Jobs. Defaults to FailureActionRetry.
+	Action FailureAction `json:"action,omitempty"`
+
+	// Retries is the number of retries allowed for failures in this class. 0
+	// means the Job's own Retries is obeyed, and FailureRetriesUnlimited means
+	// failures in this class never cause the Job to be buried. A Job can
+	// override this with its RetryBudgets.
+	Retries int `json:"retries,omitempty"`
+
+	stderrRegex *regexp.Regexp
+}
+
+// validate checks the rule is sensible and compiles its StdErr regex.
+func (r *FailureRule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("failure rule has no name")
+	}
+
+	if len(r.Exitcodes) == 0 && r.StdErr == "" && len(r.FailReasons) == 0 {
+		return fmt.Errorf("failure rule %s has no criteria", r.Name)
+	}
+
+	switch r.Action {
+	case "":
+		r.Action = FailureActionRetry
+	case FailureActionRetry, FailureActionBury, FailureActionRetryElsewhere, FailureActionEscalate:
+	default:
+		return fmt.Errorf("failure rule %s has invalid action %s", r.Name, r.Action)
+	}
+
+	if r.Retries < FailureRetriesUnlimited || r.Retries > 255 {
+		return fmt.Errorf("failure rule %s retries value (%d) is not in the range -1..255", r.Name, r.Retries)
+	}
+
+	if r.StdErr != "" {
+		re, err := regexp.Compile(r.StdErr)
+		if err != nil {
+			return fmt.Errorf("failure rule %s has a bad stderr regex: %s", r.Name, err)
+		}
+		r.stderrRegex = re
+	}
+
+	return nil
+}
+
+// matches tells you if this rule applies to a failure with the given details.
+func (r *FailureRule) matches(exitcode int, failReason, stderr string) bool {
+	if len(r.Exitcodes) > 0 {
+		found := false
+		for _, code := range r.Exitcodes {
+			if code == exitcode {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(r.FailReasons) > 0 {
+		found := false
+		for _, reason := range r.FailReasons {
+			if reason == failReason {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	} else if failReasonNeedsExplicitRule(failReason) {
+		return false
+	}
+
+	if r.stderrRegex != nil && !r.stderrRegex.MatchString(stderr) {
+		return false
+	}
+
+	return len(r.Exitcodes) > 0 || len(r.FailReasons) > 0 || r.stderrRegex != nil
+}
+
+// failReasonNeedsExplicitRule tells you if the given FailReason is one where
+// the Cmd didn't fail by itself, so only rules naming it should match.
+func failReasonNeedsExplicitRule(failReason string) bool {
+	switch failReason {
+	case FailReasonKilled, FailReasonLost, FailReasonSignal, FailReasonDrained, FailReasonAbandoned:
+		return true
+	}
+	return false
+}
+
+// FailureRules is an ordered slice of FailureRule. The first rule that matches
+// a failure is the one that applies.
+type FailureRules []*FailureRule
+
+// Validate checks all the rules are sensible, compiling their regular
+// expressions ready for use by Classify(). You must call this before using
+// rules you constructed yourself.
+func (frs FailureRules) Validate() error {
+	for _, r := range frs {
+		if err := r.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Classify returns the first rule that matches a failure with the given exit
+// code, FailReason* and STDERR, or nil if none match.
+func (frs FailureRules) Classify(exitcode int, failReason, stderr string) *FailureRule {
+	for _, r := range frs {
+		if r.matches(exitcode, failReason, stderr) {
+			return r
+		}
+	}
+	return nil
+}
+
+// FailureRulesFromFile reads a JSON array of FailureRule objects from the given
+// file and returns them validated.
+func FailureRulesFromFile(path string) (FailureRules, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var frs FailureRules
+	err = json.Unmarshal(content, &frs)
+	if err != nil {
+		return nil, fmt.Errorf("failure rules file %s could not be parsed: %s", path, err)
+	}
+
+	return frs, frs.Validate()
+}
+
+// ParseRetryBudgets parses a comma separated list of name=retries pairs, such
+// as "lost contact=-1,OOM=3,command not found=0", into a map suitable for a
+// Job's RetryBudgets.
+func ParseRetryBudgets(list string) (map[string]int, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	budgets := make(map[string]int)
+	for _, pair := range strings.Split(list, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" {
+			return nil, fmt.Errorf("retry budget [%s] is not in name=retries format", pair)
+		}
+
+		retries, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("retry budget [%s] does not have a numeric number of retries", pair)
+		}
+		budgets[name] = retries
+	}
+
+	return budgets, validateRetryBudgets(budgets)
+}
+
+// validateRetryBudgets checks that the given RetryBudgets values are sensible.
+func validateRetryBudgets(budgets map[string]int) error {
+	for name, retries := range budgets {
+		if retries < FailureRetriesUnlimited || retries > 255 {
+			return fmt.Errorf("retry budget for %s (%d) is not in the range -1..255", name, retries)
+		}
+	}
+	return nil
+}
+
+// classifyFailure is used by the server when a runner releases or buries a Job
+// that it executed, or when a lost Job is released. It applies the configured
+// FailureRules and the Job's RetryBudgets, returning the FailReason and whether
+// the Job should be buried. stderrc is the compressed STDERR of the Job's Cmd.
+func (s *Server) classifyFailure(job *Job, jes *JobEndState, failReason string, stderrc []byte, bury bool) (string, bool) {
+	if len(s.failureRules) == 0 || jes == nil || !jes.Exited {
+		return failReason, bury
+	}
+
+	var stderr string
+	if len(stderrc) > 0 {
+		decomp, err := decompress(stderrc)
+		if err != nil {
+			s.Warn("failed to decompress stderr for failure classification", "err", err)
+		} else {
+			stderr = string(decomp)
+		}
+	}
+
+	rule := s.failureRules.Classify(jes.Exitcode, failReason, stderr)
+	if rule == nil {
+		return failReason, bury
+	}
+
+	job.Lock()
+	defer job.Unlock()
+
+	if job.failureCounts == nil {
+		job.failureCounts = make(map[string]int)
+	}
+	job.failureCounts[rule.Name]++
+
+	retries, overridden := job.RetryBudgets[rule.Name]
+	if !overridden {
+		retries = rule.Retries
+	}
+
+	switch rule.Action {
+	case FailureActionBury:
+		// only a Job's own budget for this class can save it
+		if !overridden || retries == 0 {
+			return rule.Name, true
+		}
+	case FailureActionRetryElsewhere:
+		if job.Host != "" {
+			job.avoidHost(job.Host)
+		}
+	case FailureActionEscalate:
+		job.escalateReqs = true
+	}
+
+	switch {
+	case retries == FailureRetriesUnlimited:
+		if job.UntilBuried < 2 {
+			job.UntilBuried = 2
+		}
+	case retries > 0:
+		if job.failureCounts[rule.Name] > retries {
+			return rule.Name, true
+		}
+
+		// make sure the Job's own Retries doesn't bury it before this class's
+		// retries are used up
+		if job.UntilBuried < 2 {
+			job.UntilBuried = 2
+		}
+	case overridden:
+		// the Job explicitly wants no retries for this class
+		return rule.Name, true
+	}
+
+	return rule.Name, false
+}
+
+// avoidHost adds the given host to the hosts this Job's Requirements want to
+// avoid. You must hold the Job's lock before calling this.
+func (j *Job) avoidHost(host string) {
+	other := make(map[string]string, len(j.Requirements.Other)+1)
+	for key, val := range j.Requirements.Other {
+		other[key] = val
+	}
+
+	if current := other[failureAvoidHostsKey]; current != "" {
+		for _, h := range strings.Split(current, ",") {
+			if h == host {
+				return
+			}
+		}
+		other[failureAvoidHostsKey] = current + "," + host
+	} else {
+		other[failureAvoidHostsKey] = host
+	}
+
+	j.Requirements.Other = other
+}
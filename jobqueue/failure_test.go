@@ -0,0 +1,341 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+	"github.com/inconshreveable/log15"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFailureRules(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("FailureRules can be validated", t, func() {
+		frs := FailureRules{{Name: "foo", Exitcodes: []int{1}}}
+		So(frs.Validate(), ShouldBeNil)
+		So(frs[0].Action, ShouldEqual, FailureActionRetry)
+
+		frs = FailureRules{{Exitcodes: []int{1}}}
+		So(frs.Validate(), ShouldNotBeNil)
+
+		frs = FailureRules{{Name: "foo"}}
+		So(frs.Validate(), ShouldNotBeNil)
+
+		frs = FailureRules{{Name: "foo", Exitcodes: []int{1}, Action: "explode"}}
+		So(frs.Validate(), ShouldNotBeNil)
+
+		frs = FailureRules{{Name: "foo", StdErr: "("}}
+		So(frs.Validate(), ShouldNotBeNil)
+
+		frs = FailureRules{{Name: "foo", Exitcodes: []int{1}, Retries: FailureRetriesUnlimited}}
+		So(frs.Validate(), ShouldBeNil)
+
+		frs = FailureRules{{Name: "foo", Exitcodes: []int{1}, Retries: -2}}
+		So(frs.Validate(), ShouldNotBeNil)
+	})
+
+	Convey("FailureRules classify failures in order", t, func() {
+		frs := FailureRules{
+			{Name: "input missing", Exitcodes: []int{1, 2}, StdErr: `No such file`, Action: FailureActionBury},
+			{Name: "network blip", StdErr: `(?i)connection reset`, Retries: 5},
+			{Name: "OOM", FailReasons: []string{FailReasonRAM}, Action: FailureActionEscalate},
+			{Name: "generic", Exitcodes: []int{1}},
+		}
+		So(frs.Validate(), ShouldBeNil)
+
+		rule := frs.Classify(1, FailReasonExit, "cat: foo: No such file or directory")
+		So(rule, ShouldNotBeNil)
+		So(rule.Name, ShouldEqual, "input missing")
+
+		rule = frs.Classify(3, FailReasonExit, "cat: foo: No such file or directory")
+		So(rule, ShouldBeNil)
+
+		rule = frs.Classify(1, FailReasonExit, "Connection Reset by peer")
+		So(rule, ShouldNotBeNil)
+		So(rule.Name, ShouldEqual, "network blip")
+
+		rule = frs.Classify(137, FailReasonRAM, "")
+		So(rule, ShouldNotBeNil)
+		So(rule.Action, ShouldEqual, FailureActionEscalate)
+
+		rule = frs.Classify(1, FailReasonExit, "")
+		So(rule, ShouldNotBeNil)
+		So(rule.Name, ShouldEqual, "generic")
+
+		rule = frs.Classify(2, FailReasonExit, "")
+		So(rule, ShouldBeNil)
+
+		Convey("Lost, killed and signalled jobs only match rules that name them", func() {
+			rule = frs.Classify(1, FailReasonLost, "")
+			So(rule, ShouldBeNil)
+
+			frs = append(frs, &FailureRule{Name: "lost contact", FailReasons: []string{FailReasonLost, FailReasonSignal}, Retries: FailureRetriesUnlimited})
+			So(frs.Validate(), ShouldBeNil)
+
+			rule = frs.Classify(-1, FailReasonLost, "")
+			So(rule, ShouldNotBeNil)
+			So(rule.Name, ShouldEqual, "lost contact")
+
+			rule = frs.Classify(1, FailReasonSignal, "")
+			So(rule, ShouldNotBeNil)
+			So(rule.Name, ShouldEqual, "lost contact")
+
+			rule = frs.Classify(1, FailReasonKilled, "")
+			So(rule, ShouldBeNil)
+		})
+	})
+
+	Convey("Retry budgets can be parsed", t, func() {
+		budgets, err := ParseRetryBudgets("lost contact=-1, OOM=3,command not found=0")
+		So(err, ShouldBeNil)
+		So(budgets, ShouldResemble, map[string]int{"lost contact": FailureRetriesUnlimited, "OOM": 3, "command not found": 0})
+
+		budgets, err = ParseRetryBudgets("")
+		So(err, ShouldBeNil)
+		So(budgets, ShouldBeNil)
+
+		_, err = ParseRetryBudgets("OOM")
+		So(err, ShouldNotBeNil)
+
+		_, err = ParseRetryBudgets("OOM=lots")
+		So(err, ShouldNotBeNil)
+
+		_, err = ParseRetryBudgets("OOM=-2")
+		So(err, ShouldNotBeNil)
+
+		_, err = ParseRetryBudgets("OOM=256")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Failures are retried according to the budget for their class", t, func() {
+		frs := FailureRules{
+			{Name: "command not found", Exitcodes: []int{127}, Action: FailureActionBury},
+			{Name: "lost contact", FailReasons: []string{FailReasonLost}, Retries: FailureRetriesUnlimited},
+			{Name: "non-zero exit", Exitcodes: []int{1}, Retries: 3},
+		}
+		So(frs.Validate(), ShouldBeNil)
+		s := &Server{failureRules: frs}
+		exit := func(code int) *JobEndState {
+			return &JobEndState{Exitcode: code, Exited: true}
+		}
+
+		job := &Job{UntilBuried: 1}
+		for i := 0; i < 10; i++ {
+			reason, bury := s.classifyFailure(job, exit(-1), FailReasonLost, nil, false)
+			So(reason, ShouldEqual, "lost contact")
+			So(bury, ShouldBeFalse)
+			So(job.UntilBuried, ShouldEqual, 2)
+			job.UntilBuried = 1
+		}
+
+		job = &Job{UntilBuried: 1}
+		for i := 0; i < 3; i++ {
+			_, bury := s.classifyFailure(job, exit(1), FailReasonExit, nil, false)
+			So(bury, ShouldBeFalse)
+		}
+		reason, bury := s.classifyFailure(job, exit(1), FailReasonExit, nil, false)
+		So(reason, ShouldEqual, "non-zero exit")
+		So(bury, ShouldBeTrue)
+
+		reason, bury = s.classifyFailure(&Job{UntilBuried: 4}, exit(127), FailReasonExit, nil, false)
+		So(reason, ShouldEqual, "command not found")
+		So(bury, ShouldBeTrue)
+
+		Convey("Jobs can override the budgets", func() {
+			budgets := map[string]int{"lost contact": 1, "non-zero exit": 0, "command not found": 2}
+
+			job = &Job{UntilBuried: 4, RetryBudgets: budgets}
+			_, bury = s.classifyFailure(job, exit(-1), FailReasonLost, nil, false)
+			So(bury, ShouldBeFalse)
+			_, bury = s.classifyFailure(job, exit(-1), FailReasonLost, nil, false)
+			So(bury, ShouldBeTrue)
+
+			_, bury = s.classifyFailure(&Job{UntilBuried: 4, RetryBudgets: budgets}, exit(1), FailReasonExit, nil, false)
+			So(bury, ShouldBeTrue)
+
+			job = &Job{UntilBuried: 1, RetryBudgets: budgets}
+			_, bury = s.classifyFailure(job, exit(127), FailReasonExit, nil, false)
+			So(bury, ShouldBeFalse)
+			So(job.UntilBuried, ShouldEqual, 2)
+			_, bury = s.classifyFailure(job, exit(127), FailReasonExit, nil, false)
+			So(bury, ShouldBeFalse)
+			_, bury = s.classifyFailure(job, exit(127), FailReasonExit, nil, false)
+			So(bury, ShouldBeTrue)
+
+			job = &Job{UntilBuried: 1, RetryBudgets: map[string]int{"non-zero exit": FailureRetriesUnlimited}}
+			for i := 0; i < 10; i++ {
+				_, bury = s.classifyFailure(job, exit(1), FailReasonExit, nil, false)
+				So(bury, ShouldBeFalse)
+			}
+		})
+	})
+
+	Convey("FailureRules can be read from a file", t, func() {
+		dir, err := ioutil.TempDir("", "wr_jobqueue_test_failure_rules_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "rules.json")
+		err = ioutil.WriteFile(path, []byte(`[{"name":"input missing","stderr":"No such file","action":"bury"},{"name":"bad node","exit_codes":[99],"action":"retry_elsewhere","retries":2}]`), 0600)
+		So(err, ShouldBeNil)
+
+		frs, err := FailureRulesFromFile(path)
+		So(err, ShouldBeNil)
+		So(len(frs), ShouldEqual, 2)
+		So(frs[1].Action, ShouldEqual, FailureActionRetryElsewhere)
+		So(frs[1].Retries, ShouldEqual, 2)
+		So(frs.Classify(0, FailReasonExit, "No such file").Name, ShouldEqual, "input missing")
+
+		err = ioutil.WriteFile(path, []byte(`[{"name":"bad","action":"bury"}]`), 0600)
+		So(err, ShouldBeNil)
+		_, err = FailureRulesFromFile(path)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Jobs can be told to avoid hosts", t, func() {
+		job := &Job{Requirements: &scheduler.Requirements{Other: map[string]string{"foo": "bar"}}}
+		job.avoidHost("host1")
+		So(job.Requirements.Other[failureAvoidHostsKey], ShouldEqual, "host1")
+		job.avoidHost("host2")
+		job.avoidHost("host1")
+		So(job.Requirements.Other[failureAvoidHostsKey], ShouldEqual, "host1,host2")
+		So(job.Requirements.Other["foo"], ShouldEqual, "bar")
+	})
+}
+
+func TestFailureEscalation(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	testLogger := log15.New()
+	testLogger.SetHandler(log15.LvlFilterHandler(log15.LvlWarn, log15.StderrHandler))
+
+	dir, errt := ioutil.TempDir("", "wr_failure_escalation_tests")
+	if errt != nil {
+		log.Fatalf("could not create tempdir: %s\n", errt)
+	}
+	defer os.RemoveAll(dir)
+
+	config := internal.ConfigLoad("development", true, testLogger)
+	serverConfig := ServerConfig{
+		Port:            config.ManagerPort,
+		WebPort:         config.ManagerWeb,
+		SchedulerName:   "local",
+		SchedulerConfig: &scheduler.ConfigLocal{Shell: config.RunnerExecShell},
+		UploadDir:       filepath.Join(dir, "uploads"),
+		DBFile:          config.ManagerDbFile,
+		DBFileBackup:    config.ManagerDbFile + "_bk",
+		CAFile:          config.ManagerCAFile,
+		CertFile:        config.ManagerCertFile,
+		CertDomain:      config.ManagerCertDomain,
+		KeyFile:         config.ManagerKeyFile,
+		Deployment:      config.Deployment,
+		Logger:          testLogger,
+		FailureRules:    FailureRules{{Name: "needs more", Exitcodes: []int{1}, Action: FailureActionEscalate}},
+	}
+	addr := "localhost:" + config.ManagerPort
+
+	setDomainIP(config.ManagerCertDomain)
+
+	ServerInterruptTime = 10 * time.Millisecond
+	ServerReserveTicker = 10 * time.Millisecond
+	ClientReleaseDelay = 100 * time.Millisecond
+	clientConnectTime := 1500 * time.Millisecond
+
+	Convey("Jobs that fail with an escalating class are only escalated once", t, func() {
+		server, _, token, err := Serve(serverConfig)
+		So(err, ShouldBeNil)
+		defer server.Stop(true)
+
+		jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+		So(err, ShouldBeNil)
+		defer func() {
+			errd := jq.Disconnect()
+			So(errd, ShouldBeNil)
+		}()
+
+		req := &scheduler.Requirements{RAM: 10, Time: 10 * time.Second, Cores: 1}
+		jobs := []*Job{{Cmd: "false", Cwd: dir, ReqGroup: "fake_group", Requirements: req, Override: 2, RepGroup: "escalate"}}
+		added, _, err := jq.Add(jobs, os.Environ(), true)
+		So(err, ShouldBeNil)
+		So(added, ShouldEqual, 1)
+
+		job, err := jq.Reserve(50 * time.Millisecond)
+		So(err, ShouldBeNil)
+		So(job, ShouldNotBeNil)
+		jes := []*JobEssence{{JobKey: job.Key()}}
+		err = jq.Execute(job, config.RunnerExecShell)
+		So(err, ShouldNotBeNil)
+
+		got, err := jq.GetByEssence(jes[0], false, false)
+		So(err, ShouldBeNil)
+		So(got.State, ShouldEqual, JobStateBuried)
+		So(got.FailReason, ShouldEqual, "needs more")
+		So(got.Requirements.RAM, ShouldEqual, 10)
+
+		kicked, err := jq.Kick(jes)
+		So(err, ShouldBeNil)
+		So(kicked, ShouldEqual, 1)
+		<-time.After(50 * time.Millisecond)
+
+		got, err = jq.GetByEssence(jes[0], false, false)
+		So(err, ShouldBeNil)
+		So(got.State, ShouldEqual, JobStateReady)
+		So(got.Requirements.RAM, ShouldBeGreaterThanOrEqualTo, RAMIncreaseMin)
+		So(got.Requirements.Time, ShouldBeGreaterThan, 1*time.Hour)
+
+		// the user decides the escalation was excessive
+		modifier := NewJobModifer()
+		modifier.SetRequirements(&scheduler.Requirements{RAM: 10, Time: 10 * time.Second})
+		modified, err := jq.Modify(jes, modifier)
+		So(err, ShouldBeNil)
+		So(len(modified), ShouldEqual, 1)
+
+		for i := 0; i < 2; i++ {
+			job, err = jq.Reserve(50 * time.Millisecond)
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			err = jq.Bury(job, nil, "test bury")
+			So(err, ShouldBeNil)
+
+			kicked, err = jq.Kick(jes)
+			So(err, ShouldBeNil)
+			So(kicked, ShouldEqual, 1)
+			<-time.After(50 * time.Millisecond)
+
+			got, err = jq.GetByEssence(jes[0], false, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateReady)
+			So(got.Requirements.RAM, ShouldEqual, 10)
+			So(got.Requirements.Time, ShouldEqual, 10*time.Second)
+		}
+	})
+}
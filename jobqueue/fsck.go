@@ -0,0 +1,327 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of checking that the database, the
+// server's RepGroup lookup and the in-memory queue agree with each other, and
+// repairing any inconsistencies, such as might be left behind following an
+// unclean shutdown.
+
+import (
+	"sort"
+
+	"github.com/VertebrateResequencing/wr/queue"
+)
+
+// FsckReport describes the inconsistencies found by Client.Fsck(). The job
+// keys in it are sorted.
+type FsckReport struct {
+	// Unqueued are the keys of incomplete jobs in the database's live bucket
+	// that were not in the queue.
+	Unqueued []string
+
+	// CompleteLive are the keys of complete jobs that were also left in the
+	// live bucket, but not in the queue.
+	CompleteLive []string
+
+	// Unstored are the keys of jobs in the queue that were not in the
+	// database's live bucket, and were not in the middle of being archived.
+	Unstored []string
+
+	// BadDependencies are the keys of jobs in the queue that were dependent on
+	// keys of jobs that are neither queued nor live, so would never run.
+	BadDependencies []string
+
+	// Unindexed are the keys of jobs in the queue that were missing from the
+	// lookup of RepGroup to keys, so would not be found by their RepGroup.
+	Unindexed []string
+
+	// StaleLookups is the number of entries in the database's RepGroup and
+	// dependency group lookups that point at jobs that no longer exist.
+	StaleLookups int
+
+	// Repaired is true if the inconsistencies were repaired.
+	Repaired bool
+}
+
+// Problems returns the total number of inconsistencies in the report.
+func (r *FsckReport) Problems() int {
+	return len(r.Unqueued) + len(r.CompleteLive) + len(r.Unstored) + len(r.BadDependencies) +
+		len(r.Unindexed) + r.StaleLookups
+}
+
+// fsck cross-checks our database, rpl lookup and queue, and if repair is true,
+// fixes what it finds:
+//
+// Unqueued jobs are added to the queue (in their recorded state), and the live
+// copies of CompleteLive jobs are deleted. Unstored jobs are stored in the live
+// bucket. BadDependencies are recalculated from the jobs' Dependencies.
+// Unindexed jobs are added to the rpl lookup, and StaleLookups are deleted.
+func (s *Server) fsck(repair bool) (*FsckReport, error) {
+	report := &FsckReport{Repaired: repair}
+
+	liveJobs, err := s.db.recoverIncompleteJobs()
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[string]*Job, len(liveJobs))
+	for _, job := range liveJobs {
+		live[job.Key()] = job
+	}
+
+	items := s.q.AllItems()
+	queued := make(map[string]*Job, len(items))
+	for _, item := range items {
+		queued[item.Key] = item.Data().(*Job)
+	}
+
+	var notQueued, notLive []string
+	for key := range live {
+		if _, errg := s.q.Get(key); errg != nil {
+			notQueued = append(notQueued, key)
+		}
+	}
+
+	var badDeps []*Job
+	s.rpl.RLock()
+	for _, item := range items {
+		job := queued[item.Key]
+		if live[item.Key] == nil {
+			notLive = append(notLive, item.Key)
+		}
+
+		if !s.rpl.lookup[job.RepGroup][item.Key] {
+			report.Unindexed = append(report.Unindexed, item.Key)
+		}
+
+		if item.State() != queue.ItemStateDependent {
+			continue
+		}
+		for _, dep := range item.UnresolvedDependencies() {
+			if queued[dep] == nil && live[dep] == nil {
+				report.BadDependencies = append(report.BadDependencies, item.Key)
+				badDeps = append(badDeps, job)
+				break
+			}
+		}
+	}
+	s.rpl.RUnlock()
+
+	// jobs that are complete as well as queued are just in the middle of being
+	// archived, but those complete as well as live should not be re-queued
+	complete, err := s.db.completeKeys(append(notQueued, notLive...))
+	if err != nil {
+		return nil, err
+	}
+	var unqueued []*Job
+	for _, key := range notQueued {
+		if complete[key] {
+			report.CompleteLive = append(report.CompleteLive, key)
+		} else {
+			report.Unqueued = append(report.Unqueued, key)
+			unqueued = append(unqueued, live[key])
+		}
+	}
+	var unstored []*Job
+	for _, key := range notLive {
+		if !complete[key] && !archiving(queued[key]) {
+			report.Unstored = append(report.Unstored, key)
+			unstored = append(unstored, queued[key])
+		}
+	}
+
+	report.StaleLookups, err = s.db.staleLookups(repair)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, keys := range [][]string{report.Unqueued, report.CompleteLive, report.Unstored, report.BadDependencies, report.Unindexed} {
+		sort.Strings(keys)
+	}
+
+	if problems := report.Problems(); problems > 0 {
+		s.Warn("fsck found inconsistencies", "problems", problems, "repair", repair)
+	}
+
+	if !repair {
+		return report, nil
+	}
+
+	err = s.fsckRepair(report, unqueued, unstored, badDeps)
+	return report, err
+}
+
+// fsckRepair fixes the inconsistencies that fsck() found, other than stale
+// lookups, which it will already have deleted.
+func (s *Server) fsckRepair(report *FsckReport, unqueued, unstored, badDeps []*Job) error {
+	if len(report.CompleteLive) > 0 {
+		if err := s.db.deleteLiveJobs(report.CompleteLive); err != nil {
+			return err
+		}
+	}
+
+	if len(unstored) > 0 {
+		if err := s.storeUnstoredJobs(unstored); err != nil {
+			return err
+		}
+	}
+
+	if len(unqueued) > 0 {
+		if err := s.requeueJobs(unqueued); err != nil {
+			return err
+		}
+	}
+
+	if len(report.Unindexed) > 0 {
+		s.rpl.Lock()
+		for _, key := range report.Unindexed {
+			item, err := s.q.Get(key)
+			if err != nil {
+				continue
+			}
+			rg := item.Data().(*Job).RepGroup
+			if _, exists := s.rpl.lookup[rg]; !exists {
+				s.rpl.lookup[rg] = make(map[string]bool)
+			}
+			s.rpl.lookup[rg][key] = true
+		}
+		s.rpl.Unlock()
+	}
+
+	if len(badDeps) > 0 {
+		if _, err := s.updateJobDependencies(badDeps); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storeUnstoredJobs stores the given queued jobs in the live bucket, skipping
+// any that have since left the queue or started to be archived. Since a job
+// could still finish being archived after we checked, any that turn out to be
+// complete afterwards have their new live copies deleted again.
+func (s *Server) storeUnstoredJobs(jobs []*Job) error {
+	keys := make([]string, 0, len(jobs))
+	candidates := make([]*Job, 0, len(jobs))
+	for _, job := range jobs {
+		key := job.Key()
+		item, err := s.q.Get(key)
+		if err != nil || item.Data().(*Job) != job || archiving(job) {
+			continue
+		}
+		keys = append(keys, key)
+		candidates = append(candidates, job)
+	}
+
+	complete, err := s.db.completeKeys(keys)
+	if err != nil {
+		return err
+	}
+	var toStoreKeys []string
+	var toStore []*Job
+	for i, key := range keys {
+		if !complete[key] {
+			toStoreKeys = append(toStoreKeys, key)
+			toStore = append(toStore, candidates[i])
+		}
+	}
+	if len(toStore) == 0 {
+		return nil
+	}
+
+	if err = s.db.modifyLiveJobs(toStoreKeys, toStore); err != nil {
+		return err
+	}
+
+	complete, err = s.db.completeKeys(toStoreKeys)
+	if err != nil || len(complete) == 0 {
+		return err
+	}
+	archived := make([]string, 0, len(complete))
+	for key := range complete {
+		archived = append(archived, key)
+	}
+	return s.db.deleteLiveJobs(archived)
+}
+
+// archiving returns true if the given job has been marked complete, which
+// happens just before it is archived.
+func archiving(job *Job) bool {
+	job.RLock()
+	defer job.RUnlock()
+	return job.State == JobStateComplete
+}
+
+// requeueJobs adds the given jobs, which are in the live bucket, back to the
+// queue in the state they were last recorded as being in, like we do for all
+// live jobs when we start up.
+func (s *Server) requeueJobs(jobs []*Job) error {
+	itemdefs := make([]*queue.ItemDef, 0, len(jobs))
+	for _, job := range jobs {
+		deps, err := job.Dependencies.incompleteJobKeys(s.db)
+		if err != nil {
+			return err
+		}
+
+		itemdef := s.jobItemDef(job, deps)
+		switch job.State {
+		case JobStateRunning:
+			itemdef.StartQueue = queue.SubQueueRun
+			if len(job.LimitGroups) > 0 && s.limiter.Increment(job.LimitGroups) {
+				job.noteIncrementedLimitGroups(job.LimitGroups)
+			}
+		case JobStateBuried:
+			itemdef.StartQueue = queue.SubQueueBury
+		}
+		itemdefs = append(itemdefs, itemdef)
+	}
+
+	s.addAtomicGroupDependencies(itemdefs)
+	_, _, err := s.enqueueItems(itemdefs)
+	return err
+}
+
+// checkConsistency runs fsck() without repairing anything, logging a warning
+// if there were any problems, for use after recovering jobs on start up when
+// configured with CheckConsistency.
+func (s *Server) checkConsistency() {
+	report, err := s.fsck(false)
+	if err != nil {
+		s.Warn("consistency check failed", "err", err)
+		return
+	}
+	if problems := report.Problems(); problems > 0 {
+		s.Warn("the database and queue are inconsistent; run `wr manager fsck --repair` to fix", "problems", problems)
+	}
+}
+
+// Fsck asks the server to check that its database and queue are consistent
+// with each other, as they may not be following an unclean shutdown. If repair
+// is true, the inconsistencies found are also fixed.
+//
+// This is best done when no jobs are being added or completed, since jobs that
+// are in the middle of that may appear to be inconsistent.
+func (c *Client) Fsck(repair bool) (*FsckReport, error) {
+	resp, err := c.request(&clientRequest{Method: "fsck", Repair: repair})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Fsck, err
+}
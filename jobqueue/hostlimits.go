@@ -0,0 +1,315 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of per-host limits, where we restrict
+// how many jobs of a kind can run at once on a single host, regardless of how
+// many of them the host's resources could otherwise accommodate.
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/queue"
+	sync "github.com/sasha-s/go-deadlock"
+)
+
+// jobSchedMaxPerHostSeparator is the separator between the rest of a
+// scheduler group name and the MaxPerHost of its jobs.
+const jobSchedMaxPerHostSeparator = "^"
+
+// hostLimitRetryInterval is how often reserveWithinHostLimits() tries again to
+// claim a job while the queue has nothing ready for it.
+const hostLimitRetryInterval = 100 * time.Millisecond
+
+// hostLimiter knows the per-host limits of limit groups, and serialises
+// reservations made on behalf of each host, so that the limits can't be
+// exceeded by runners on the same host reserving at the same time. It also
+// keeps track of the jobs reserved on each host, so that we don't have to
+// look through every running job to see what's on a host.
+type hostLimiter struct {
+	limits  map[string]int // limit group -> max per host, or -1 if unlimited
+	cb      func(string) int
+	hosts   map[string]*sync.Mutex
+	running map[string]map[*Job]bool
+	mutex   sync.Mutex
+}
+
+// newHostLimiter creates a hostLimiter that uses the given callback to find
+// out the per-host limit of limit groups it hasn't been told about. The
+// callback should return -1 for groups that have no per-host limit.
+func newHostLimiter(cb func(string) int) *hostLimiter {
+	return &hostLimiter{
+		limits:  make(map[string]int),
+		cb:      cb,
+		hosts:   make(map[string]*sync.Mutex),
+		running: make(map[string]map[*Job]bool),
+	}
+}
+
+// getLimit returns the per-host limit of the given limit group, or -1 if it
+// has none.
+func (hl *hostLimiter) getLimit(group string) int {
+	hl.mutex.Lock()
+	defer hl.mutex.Unlock()
+	limit, known := hl.limits[group]
+	if !known {
+		limit = hl.cb(group)
+		hl.limits[group] = limit
+	}
+	return limit
+}
+
+// setLimit sets the per-host limit of the given limit group. Supply -1 to
+// remove the limit.
+func (hl *hostLimiter) setLimit(group string, limit int) {
+	if limit < 0 {
+		limit = -1
+	}
+	hl.mutex.Lock()
+	defer hl.mutex.Unlock()
+	hl.limits[group] = limit
+}
+
+// hostMutex returns a mutex that should be held while checking and acting on
+// the per-host limits of the given host.
+func (hl *hostLimiter) hostMutex(host string) *sync.Mutex {
+	hl.mutex.Lock()
+	defer hl.mutex.Unlock()
+	m, exists := hl.hosts[host]
+	if !exists {
+		m = &sync.Mutex{}
+		hl.hosts[host] = m
+	}
+	return m
+}
+
+// noteRunning notes that the given job was reserved or started on the given
+// host. Don't call this while holding the job's lock.
+func (hl *hostLimiter) noteRunning(host string, job *Job) {
+	if host == "" {
+		return
+	}
+	hl.mutex.Lock()
+	defer hl.mutex.Unlock()
+	jobs, exists := hl.running[host]
+	if !exists {
+		jobs = make(map[*Job]bool)
+		hl.running[host] = jobs
+	}
+	jobs[job] = true
+}
+
+// runningOn returns the jobs noted as running on the given host. Some of them
+// may have stopped running since.
+func (hl *hostLimiter) runningOn(host string) []*Job {
+	hl.mutex.Lock()
+	defer hl.mutex.Unlock()
+	jobs := make([]*Job, 0, len(hl.running[host]))
+	for job := range hl.running[host] {
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// forgetStopped forgets that the given job was running on any host that the
+// given callback says it is no longer running on.
+func (hl *hostLimiter) forgetStopped(job *Job, stillRunningOn func(host string) bool) {
+	hl.mutex.Lock()
+	defer hl.mutex.Unlock()
+	for host, jobs := range hl.running {
+		if !jobs[job] || stillRunningOn(host) {
+			continue
+		}
+		delete(jobs, job)
+		if len(jobs) == 0 {
+			delete(hl.running, host)
+		}
+	}
+}
+
+// schedGroupToMaxPerHost takes a scheduler group that may be suffixed with a
+// MaxPerHost (by Job.generateSchedulerGroup()), and returns it, or 0 if it
+// wasn't.
+func schedGroupToMaxPerHost(group string) int {
+	i := strings.LastIndex(group, jobSchedMaxPerHostSeparator)
+	if i == -1 {
+		return 0
+	}
+	max, err := strconv.Atoi(group[i+1:])
+	if err != nil {
+		return 0
+	}
+	return max
+}
+
+// trimSchedGroupMaxPerHost returns the given scheduler group without any
+// MaxPerHost suffix.
+func trimSchedGroupMaxPerHost(group string) string {
+	if schedGroupToMaxPerHost(group) == 0 {
+		return group
+	}
+	return group[:strings.LastIndex(group, jobSchedMaxPerHostSeparator)]
+}
+
+// hostLimits returns the MaxPerHost of the given scheduler group, and the
+// per-host limits of those of the given limit groups that have one.
+func (s *Server) hostLimits(group string, limitGroups []string) (int, map[string]int) {
+	lgLimits := make(map[string]int)
+	for _, lg := range limitGroups {
+		if limit := s.hostLimiter.getLimit(lg); limit >= 0 {
+			lgLimits[lg] = limit
+		}
+	}
+	return schedGroupToMaxPerHost(group), lgLimits
+}
+
+// hostLimited returns true if jobs from the given scheduler group (which has
+// the given limit groups) are subject to any per-host limits.
+func (s *Server) hostLimited(group string, limitGroups []string) bool {
+	maxPerHost, lgLimits := s.hostLimits(group, limitGroups)
+	return maxPerHost > 0 || len(lgLimits) > 0
+}
+
+// atHostLimits checks the jobs currently reserved or running on the given
+// host, and returns true if another job from the given scheduler group (which
+// has the given limit groups) would exceed the group's MaxPerHost, or the
+// per-host limit of one of its limit groups.
+func (s *Server) atHostLimits(host, group string, limitGroups []string) bool {
+	maxPerHost, lgLimits := s.hostLimits(group, limitGroups)
+	if maxPerHost <= 0 && len(lgLimits) == 0 {
+		return false
+	}
+
+	sameGroup := 0
+	lgCounts := make(map[string]int)
+	for _, job := range s.hostLimiter.runningOn(host) {
+		if !s.stillRunningOn(host, job) {
+			s.forgetStoppedJob(job)
+			continue
+		}
+		job.RLock()
+		if job.schedulerGroup == group {
+			sameGroup++
+		}
+		for _, lg := range job.LimitGroups {
+			if _, limited := lgLimits[lg]; limited {
+				lgCounts[lg]++
+			}
+		}
+		job.RUnlock()
+	}
+
+	if maxPerHost > 0 && sameGroup >= maxPerHost {
+		return true
+	}
+	for lg, limit := range lgLimits {
+		if lgCounts[lg] >= limit {
+			return true
+		}
+	}
+	return false
+}
+
+// stillRunningOn returns true if the given job, noted as running on the given
+// host, is still reserved or running there.
+func (s *Server) stillRunningOn(host string, job *Job) bool {
+	item, err := s.q.Get(job.Key())
+	if err != nil || item.Data().(*Job) != job || item.Stats().State != queue.ItemStateRun {
+		return false
+	}
+	return job.runningOn() == host
+}
+
+// forgetStoppedJob makes our hostLimiter forget the given job on any hosts it
+// is no longer reserved or running on.
+func (s *Server) forgetStoppedJob(job *Job) {
+	s.hostLimiter.forgetStopped(job, func(host string) bool {
+		return s.stillRunningOn(host, job)
+	})
+}
+
+// reserveWithinHostLimits is like reserving from the given scheduler group
+// (which has the given limit groups) with the given wait, except that we act
+// as if the queue was empty if the given host is already running as many jobs
+// as the group's per-host limits allow. The host's mutex is only held while
+// checking the limits and claiming a job, not while waiting for one, so that
+// other runners on the host aren't kept waiting.
+func (s *Server) reserveWithinHostLimits(ctx context.Context, host, group string, limitGroups []string, wait time.Duration, prefer func(data interface{}) bool) (*queue.Item, error) {
+	hm := s.hostLimiter.hostMutex(host)
+	deadline := time.Now().Add(wait)
+	for {
+		hm.Lock()
+		if s.atHostLimits(host, group, limitGroups) {
+			hm.Unlock()
+			return nil, queue.Error{Queue: s.q.Name, Op: "Reserve", Item: "", Err: queue.ErrNothingReady}
+		}
+		item, err := s.q.ReserveContext(ctx, group, 0, prefer)
+		item, err = s.holdRateLimited(ctx, item, err, group, prefer)
+		if item != nil {
+			// note our host before anyone else can check the limits
+			job := item.Data().(*Job)
+			job.Lock()
+			job.reservedHost = host
+			job.Unlock()
+			s.hostLimiter.noteRunning(host, job)
+		}
+		hm.Unlock()
+
+		remaining := time.Until(deadline)
+		if item != nil || remaining <= 0 {
+			return item, err
+		}
+		if qerr, ok := err.(queue.Error); !ok || qerr.Err != queue.ErrNothingReady {
+			return item, err
+		}
+		if remaining > hostLimitRetryInterval {
+			remaining = hostLimitRetryInterval
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(remaining):
+		}
+	}
+}
+
+// getSetHostLimit does the server side of Client.GetOrSetHostLimit(), taking
+// the same argument. The string return value is one of our Err* constants.
+func (s *Server) getSetHostLimit(group string) (int, string, error) {
+	name, limit, suffixed, err := s.splitSuffixedLimitGroup(group)
+	if err != nil {
+		return 0, ErrBadLimitGroup, err
+	}
+	if suffixed {
+		_, _, err = s.db.storeHostLimits(map[string]int{name: limit})
+		if err != nil {
+			return -1, ErrDBError, err
+		}
+		s.hostLimiter.setLimit(name, limit)
+		if limit < 0 {
+			limit = -1
+		}
+		s.q.TriggerReadyAddedCallback()
+		return limit, "", nil
+	}
+	return s.hostLimiter.getLimit(name), "", nil
+}
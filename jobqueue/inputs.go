@@ -0,0 +1,87 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of checking that the input files of
+// jobs exist before they are run.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// missingInputs returns a description of each of our InputFiles that doesn't
+// exist or can't be read, with relative paths being treated as relative to the
+// given directory. If dir is blank, relative paths are not checked.
+func (j *Job) missingInputs(dir string) []string {
+	var missing []string
+	for _, path := range j.InputFiles {
+		if !filepath.IsAbs(path) {
+			if dir == "" {
+				continue
+			}
+			path = filepath.Join(dir, path)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			missing = append(missing, err.Error())
+			continue
+		}
+		if err = f.Close(); err != nil {
+			missing = append(missing, fmt.Sprintf("close %s: %s", path, err))
+		}
+	}
+	return missing
+}
+
+// inputsMissing is for when the given job becomes ready to run: unless it is
+// only to be checked on the runner, it checks (once) that its InputFiles are
+// there, and if not, buries it with FailReasonInput and returns true.
+func (s *Server) inputsMissing(job *Job) bool {
+	job.RLock()
+	skip := len(job.InputFiles) == 0 || job.inputsChecked || job.InputCheckOnRunner || len(job.MountConfigs) > 0
+	var dir string
+	if job.CwdMatters {
+		dir = job.Cwd
+	}
+	job.RUnlock()
+	if skip {
+		return false
+	}
+
+	missing := job.missingInputs(dir)
+	if len(missing) == 0 {
+		job.Lock()
+		job.inputsChecked = true
+		job.Unlock()
+		return false
+	}
+
+	if err := s.buryWaitingJob(job, FailReasonInput); err != nil {
+		// it probably got reserved already; the runner will check for us
+		s.Debug("could not bury a job with missing inputs", "cmd", job.Cmd, "err", err)
+		return false
+	}
+	s.recordBuryEvent(job, FailReasonInput)
+	s.Warn("buried job with missing inputs", "cmd", job.Cmd, "missing", missing)
+	s.failAtomicGroup(job)
+	return true
+}
@@ -0,0 +1,245 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of iRODS staging and archiving, where
+// a job's IRODSInputs are fetched before its Cmd runs, and its OutputFiles are
+// put in to its IRODSCollection, with metadata, after it succeeds. Transfers
+// are done with the icommands, limited to IRODSMaxTransfers at once, and
+// retried on failure.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/rp"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+)
+
+// IRODSMaxTransfers is the maximum number of icommands a client will run at
+// once, across all the Jobs it is executing. It should be set before the first
+// Job with iRODS options is executed.
+var IRODSMaxTransfers = 4
+
+// IRODSRetries is how many times a failed icommand is retried before giving
+// up.
+var IRODSRetries = 3
+
+// IRODSRetryDelay is how long to wait before retrying a failed icommand the
+// first time; the delay doubles for each subsequent retry.
+var IRODSRetryDelay = 5 * time.Second
+
+// irodsMeta* are the attributes of the metadata that is always set on data
+// objects put in to iRODS, in addition to a Job's IRODSMetadata.
+const (
+	irodsMetaJobKey   = "wr_job_key"
+	irodsMetaRepGroup = "wr_rep_group"
+)
+
+// irodsTouchInterval is how often a running icommand keeps its transfer slot;
+// slots not kept for twice this long are freed for other icommands.
+const irodsTouchInterval = 30 * time.Second
+
+var (
+	irodsProtector   *rp.Protector
+	irodsProtectorMu sync.Mutex
+)
+
+// irodsTransferProtector returns the Protector that limits the number of
+// icommands running at once, creating it on first use.
+func irodsTransferProtector() *rp.Protector {
+	irodsProtectorMu.Lock()
+	defer irodsProtectorMu.Unlock()
+	if irodsProtector == nil {
+		max := IRODSMaxTransfers
+		if max < 1 {
+			max = 1
+		}
+		irodsProtector = rp.New("irods", 0, max, 2*irodsTouchInterval)
+	}
+	return irodsProtector
+}
+
+// ParseIRODSMetadata parses a comma separated list of attribute=value pairs in
+// to a map suitable for a Job's IRODSMetadata.
+func ParseIRODSMetadata(meta string) (map[string]string, error) {
+	if meta == "" {
+		return nil, nil
+	}
+	parsed := make(map[string]string)
+	for _, pair := range strings.Split(meta, ",") {
+		i := strings.IndexByte(pair, '=')
+		if i < 0 || strings.TrimSpace(pair[:i]) == "" {
+			return nil, fmt.Errorf("iRODS metadata [%s] is not of the form attribute=value", pair)
+		}
+		parsed[strings.TrimSpace(pair[:i])] = pair[i+1:]
+	}
+	return parsed, nil
+}
+
+// irodsTransferer runs icommands for a Job, in the Job's working directory and
+// environment, as its RunAs user.
+type irodsTransferer struct {
+	shell  string
+	dir    string
+	env    []string
+	runAs  string
+	logger log15.Logger
+}
+
+// newIRODSTransferer returns an irodsTransferer that will use the given shell,
+// working directory and environment, running as the given job's RunAs user if
+// runAs is true.
+func newIRODSTransferer(job *Job, shell, dir string, env []string, runAs bool, logger log15.Logger) *irodsTransferer {
+	it := &irodsTransferer{shell: shell, dir: dir, env: env, logger: logger}
+	if runAs {
+		it.runAs = job.RunAs
+	}
+	return it
+}
+
+// stageIn fetches the given job's IRODSInputs in to our working directory,
+// with their base names.
+func (it *irodsTransferer) stageIn(job *Job) error {
+	return it.parallel(job.IRODSInputs, func(obj string) error {
+		return it.run("iget", "-f", "-K", obj, path.Base(obj))
+	})
+}
+
+// archive puts the given job's OutputFiles in to its IRODSCollection, with
+// their base names, and sets its IRODSMetadata on them.
+func (it *irodsTransferer) archive(job *Job) error {
+	coll := strings.TrimSuffix(job.IRODSCollection, "/")
+	if err := it.run("imkdir", "-p", coll); err != nil {
+		return err
+	}
+
+	meta := make(map[string]string, len(job.IRODSMetadata)+2)
+	for attr, val := range job.IRODSMetadata {
+		meta[attr] = val
+	}
+	meta[irodsMetaJobKey] = job.Key()
+	meta[irodsMetaRepGroup] = job.RepGroup
+	attrs := make([]string, 0, len(meta))
+	for attr := range meta {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+
+	return it.parallel(job.OutputFiles, func(output string) error {
+		obj := path.Join(coll, filepath.Base(output))
+		if err := it.run("iput", "-f", "-K", output, obj); err != nil {
+			return err
+		}
+		for _, attr := range attrs {
+			if err := it.run("imeta", "set", "-d", obj, attr, meta[attr]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// parallel calls the given function on each of the given items concurrently,
+// returning all the errors they had.
+func (it *irodsTransferer) parallel(items []string, do func(string) error) error {
+	errCh := make(chan error, len(items))
+	for _, item := range items {
+		go func(item string) {
+			errCh <- do(item)
+		}(item)
+	}
+
+	var merr *multierror.Error
+	for range items {
+		if err := <-errCh; err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// run runs the given icommand once there is a free transfer slot, retrying it
+// up to IRODSRetries times if it fails.
+func (it *irodsTransferer) run(args ...string) error {
+	p := irodsTransferProtector()
+	receipt, err := p.Request(1)
+	if err != nil {
+		return err
+	}
+	if !p.WaitUntilGranted(receipt) {
+		return errors.New("could not get a slot to run an icommand in")
+	}
+	defer p.Release(receipt)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		ticker := time.NewTicker(irodsTouchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.Touch(receipt)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	cmdLine := strings.Join(quoted, " ")
+
+	delay := IRODSRetryDelay
+	for attempt := 0; ; attempt++ {
+		err = it.runOnce(cmdLine)
+		if err == nil || attempt >= IRODSRetries {
+			return err
+		}
+		it.logger.Warn("icommand failed, will retry", "cmd", cmdLine, "err", err)
+		<-time.After(delay)
+		delay *= 2
+	}
+}
+
+// runOnce runs the given icommand line.
+func (it *irodsTransferer) runOnce(cmdLine string) error {
+	cmd, err := shellCommand(it.shell, cmdLine, it.runAs)
+	if err != nil {
+		return fmt.Errorf("icommand [%s] could not be run: %w", cmdLine, err)
+	}
+	cmd.Dir = it.dir
+	cmd.Env = it.env
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("icommand [%s] failed: %w (%s)", cmdLine, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIRODS(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("ParseIRODSMetadata() parses attribute=value pairs", t, func() {
+		meta, err := ParseIRODSMetadata("study=123, sample=a=b")
+		So(err, ShouldBeNil)
+		So(meta, ShouldResemble, map[string]string{"study": "123", "sample": "a=b"})
+
+		meta, err = ParseIRODSMetadata("")
+		So(err, ShouldBeNil)
+		So(meta, ShouldBeNil)
+
+		_, err = ParseIRODSMetadata("study=123,=foo")
+		So(err, ShouldNotBeNil)
+		_, err = ParseIRODSMetadata("study")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("With fake icommands", t, func() {
+		tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_irods_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(tmpdir)
+
+		bin := filepath.Join(tmpdir, "bin")
+		zone := filepath.Join(tmpdir, "zone")
+		cwd := filepath.Join(tmpdir, "cwd")
+		for _, dir := range []string{bin, zone, cwd} {
+			err = os.Mkdir(dir, 0700)
+			So(err, ShouldBeNil)
+		}
+		log := filepath.Join(tmpdir, "log")
+
+		icommands := map[string]string{
+			"iget":   `[ -e "$ZONE$3" ] && cp "$ZONE$3" "$4"`,
+			"iput":   `cp "$3" "$ZONE$4"`,
+			"imkdir": `mkdir -p "$ZONE$2"`,
+			"imeta":  `true`,
+		}
+		for name, script := range icommands {
+			err = ioutil.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\necho \""+name+" $*\" >> \"$LOG\"\n"+script+"\n"), 0700) // #nosec
+			So(err, ShouldBeNil)
+		}
+		env := []string{"PATH=" + bin + ":" + os.Getenv("PATH"), "ZONE=" + zone, "LOG=" + log}
+
+		origDelay := IRODSRetryDelay
+		IRODSRetryDelay = 10 * time.Millisecond
+		defer func() {
+			IRODSRetryDelay = origDelay
+		}()
+
+		logged := func() []string {
+			content, errr := ioutil.ReadFile(log)
+			So(errr, ShouldBeNil)
+			return strings.Split(strings.TrimSpace(string(content)), "\n")
+		}
+
+		job := &Job{Cmd: "true", RepGroup: "rg", Cwd: cwd}
+		it := newIRODSTransferer(job, "sh", cwd, env, false, testLogger)
+
+		Convey("stageIn fetches IRODSInputs in to the working directory", func() {
+			err = os.MkdirAll(filepath.Join(zone, "seq", "run1"), 0700)
+			So(err, ShouldBeNil)
+			for _, name := range []string{"a.cram", "b.cram"} {
+				err = ioutil.WriteFile(filepath.Join(zone, "seq", "run1", name), []byte(name), 0600)
+				So(err, ShouldBeNil)
+			}
+
+			job.IRODSInputs = []string{"/seq/run1/a.cram", "/seq/run1/b.cram"}
+			err = it.stageIn(job)
+			So(err, ShouldBeNil)
+			content, errr := ioutil.ReadFile(filepath.Join(cwd, "b.cram"))
+			So(errr, ShouldBeNil)
+			So(string(content), ShouldEqual, "b.cram")
+
+			Convey("Failures are retried IRODSRetries times, then reported", func() {
+				job.IRODSInputs = []string{"/seq/run1/missing.cram"}
+				err = it.stageIn(job)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "iget -f -K /seq/run1/missing.cram missing.cram")
+				So(len(logged()), ShouldEqual, 2+IRODSRetries+1)
+			})
+		})
+
+		Convey("archive puts OutputFiles in the IRODSCollection with metadata", func() {
+			err = ioutil.WriteFile(filepath.Join(cwd, "out.txt"), []byte("out"), 0600)
+			So(err, ShouldBeNil)
+
+			job.OutputFiles = []string{"out.txt"}
+			job.IRODSCollection = "/archive/project/"
+			job.IRODSMetadata = map[string]string{"study": "my study"}
+			err = it.archive(job)
+			So(err, ShouldBeNil)
+
+			content, errr := ioutil.ReadFile(filepath.Join(zone, "archive", "project", "out.txt"))
+			So(errr, ShouldBeNil)
+			So(string(content), ShouldEqual, "out")
+
+			So(logged(), ShouldResemble, []string{
+				"imkdir -p /archive/project",
+				"iput -f -K out.txt /archive/project/out.txt",
+				"imeta set -d /archive/project/out.txt study my study",
+				"imeta set -d /archive/project/out.txt wr_job_key " + job.Key(),
+				"imeta set -d /archive/project/out.txt wr_rep_group rg",
+			})
+		})
+	})
+}
@@ -22,9 +22,11 @@ package jobqueue
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"syscall"
@@ -38,6 +40,7 @@ import (
 	"github.com/VertebrateResequencing/wr/queue"
 	"github.com/gofrs/uuid"
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
 	"github.com/ugorji/go/codec"
 )
 
@@ -49,7 +52,10 @@ type JobState string
 // "lost" is also a "fake" state indicating the job was running and we lost
 // contact with it; it may be dead. "unknown" is an error case that shouldn't
 // happen. "deletable" is a meta state that can be used when filtering jobs to
-// mean !(running|complete).
+// mean !(running|complete). "staging" and "uploading" are sub-states of running
+// reported by the runner while it is fetching inputs and mounting file systems
+// before the Cmd starts, and uploading outputs after it exits, respectively;
+// filtering on running includes jobs in these states.
 const (
 	JobStateNew       JobState = "new"
 	JobStateDelayed   JobState = "delayed"
@@ -57,6 +63,8 @@ const (
 	JobStateReserved  JobState = "reserved"
 	JobStateRunning   JobState = "running"
 	JobStateLost      JobState = "lost"
+	JobStateStaging   JobState = "staging"
+	JobStateUploading JobState = "uploading"
 	JobStateBuried    JobState = "buried"
 	JobStateDependent JobState = "dependent"
 	JobStateComplete  JobState = "complete"
@@ -116,13 +124,39 @@ type Job struct {
 	// Cwd, enabling features like tracking disk space usage and clean up of the
 	// working directory by simply deleting the whole thing. The TMPDIR
 	// environment variable is also set to a sister folder of the unique
-	// subfolder, and this is always cleaned up after the Cmd exits.
+	// subfolder (or to a unique folder in JobScratchDir, if set, regardless of
+	// CwdMatters), and this is always cleaned up after the Cmd exits.
 	CwdMatters bool
 
 	// ChangeHome sets the $HOME environment variable to the actual working
 	// directory before running Cmd, but only when CwdMatters is false.
 	ChangeHome bool
 
+	// CwdTemplate, when CwdMatters is false, replaces the hashed directory
+	// structure that the unique working directory is normally created within
+	// with a readable one: it is a relative path that may contain the
+	// placeholders {key}, {repgroup}, {reqgroup} and {date} (YYYY-MM-DD), eg.
+	// "{repgroup}/{key}". A unique suffix is still added to the last directory.
+	CwdTemplate string
+
+	// CwdBase, when CwdMatters is false, is the directory that the unique
+	// working directory is created within, instead of Cwd. You might use this
+	// to have Cmd work on a different (eg. faster, local) file system to Cwd.
+	CwdBase string
+
+	// CwdLink, when CwdBase is in effect, creates a symlink within Cwd (at the
+	// same relative path as the unique directory within CwdBase) to the actual
+	// working directory, so that outputs can be found from Cwd. The cleanup
+	// Behaviour removes the link along with the directory.
+	CwdLink bool
+
+	// CleanEnv means that Cmd is run in a clean login environment of the
+	// machine it runs on, with only the environment variables that were
+	// explicitly captured when the Job was added (and any overrides) applied
+	// on top. Use this when you don't want the full environment of the adder
+	// stored and used.
+	CleanEnv bool
+
 	// RepGroup is a name associated with related Jobs to help group them
 	// together when reporting on their status etc.
 	RepGroup string
@@ -154,6 +188,27 @@ type Job struct {
 	// Retries is the number of times to retry running a Cmd if it fails.
 	Retries uint8
 
+	// RetryBudgets overrides the number of retries the server's FailureRules
+	// allow for certain classes of failure. Keys are FailureRule names, and
+	// values are the number of retries allowed for failures of that class: 0
+	// means it will be buried on the first such failure, and
+	// FailureRetriesUnlimited means such failures never bury it.
+	RetryBudgets map[string]int
+
+	// Affinity is an optional hint, such as the identifier of a large input
+	// dataset, that lets the server prefer to give this job to a runner on a
+	// host that recently ran other jobs with the same Affinity, so they might
+	// benefit from data in that host's page cache or local scratch space. It
+	// can't be changed once the job has been added.
+	Affinity string
+
+	// MaxPerHost is the maximum number of jobs like this one (with the same
+	// Requirements, LimitGroups and MaxPerHost) that may run at once on a
+	// single host, for commands that cause trouble when too many of them share
+	// a host's local disks, regardless of how many cores it has. 0 means no
+	// limit. It can't be changed once the job has been added.
+	MaxPerHost int
+
 	// LimitGroups are names of limit groups that this job belongs to. If any
 	// of these groups are defined (elsewhere) to have a limit, then if as many
 	// other jobs as the limit are currently running, this job will not start
@@ -168,6 +223,20 @@ type Job struct {
 	// starts.
 	Dependencies Dependencies
 
+	// AtomicGroup makes this job a member of the named atomic group, whose
+	// members succeed or fail together. If any member gets buried, the other
+	// members are cancelled (buried if waiting to run, killed if running), and
+	// members that already completed are marked as AtomicGroupFailed, since
+	// their outputs are useless on their own. Jobs that depend on a member of
+	// an atomic group only start once every member has completed. It can't be
+	// changed once the job has been added.
+	AtomicGroup string
+
+	// AtomicGroupFailed is true for complete jobs whose AtomicGroup had a
+	// member that got buried after they completed; their outputs should be
+	// considered invalid.
+	AtomicGroupFailed bool
+
 	// Behaviours describe what should happen after Cmd is executed, depending
 	// on its success.
 	Behaviours Behaviours
@@ -188,6 +257,88 @@ type Job struct {
 	// ActualCwd.
 	MountConfigs MountConfigs
 
+	// InputFiles are the paths of files that the Cmd needs to read. Before the
+	// Cmd is run, they are checked to exist and be readable, and if any aren't
+	// the job is buried with FailReasonInput instead. Relative paths are
+	// relative to the directory the Cmd runs in. The manager checks them as
+	// soon as the job becomes ready to run, so that no runner is spawned for a
+	// job that would fail, unless InputCheckOnRunner is set or the job has
+	// MountConfigs. It can only check absolute paths, or relative ones when
+	// CwdMatters. The runner always checks them just before running the Cmd.
+	InputFiles []string
+
+	// InputCheckOnRunner means that InputFiles are only checked by the runner,
+	// for when they are not visible on the manager's host, such as when they
+	// aren't on a shared file system.
+	InputCheckOnRunner bool
+
+	// Cacheable means that when this Job is added with ignoreComplete true, if
+	// an identical Cacheable Job previously completed, this Job is queued
+	// again, but the runner that reserves it checks the MD5 checksums of the
+	// InputFiles, and if they are the same as they were when that one ran, it
+	// doesn't run the Cmd, but has the Job completed with the results of that
+	// run and marked Cached. If the inputs changed, it is run again even though
+	// it completed before. A Job with no InputFiles or Dependencies is
+	// completed from the cache immediately.
+	Cacheable bool
+
+	// OutputFiles are the paths of files that the Cmd is expected to create.
+	// After the Cmd exits 0, they are checked to exist and be at least
+	// OutputMinSize bytes (or non-empty, if that is 0), and if any aren't, the
+	// Cmd is treated as having failed with FailReasonOutput, so that truncated
+	// results are not archived as a success. Relative paths are relative to
+	// the directory the Cmd ran in.
+	OutputFiles []string
+
+	// OutputMinSize is the minimum size in bytes of each of the OutputFiles.
+	OutputMinSize int64
+
+	// OutputCheckCmd, if set, is run for each of the OutputFiles once they
+	// have passed the size check, with the WR_OUTPUT environment variable set
+	// to the output's path, eg. to verify a checksum. If it exits non-zero,
+	// the output is considered invalid.
+	OutputCheckCmd string
+
+	// OutputChecksums, if true, has the size and MD5 checksum of each of the
+	// OutputFiles recorded in OutputRecords once the Cmd succeeds.
+	OutputChecksums bool
+
+	// IRODSInputs are the paths of iRODS data objects that are fetched in to
+	// the Cmd's working directory, keeping their base names, before it runs.
+	// If that fails, the Cmd doesn't run and is treated as having failed with
+	// FailReasonIRODS.
+	IRODSInputs []string
+
+	// IRODSCollection, if set, is the iRODS collection that each of the
+	// OutputFiles is put in to, keeping its base name, once the Cmd succeeds
+	// and the outputs have been validated. If that fails, the Cmd is treated as
+	// having failed with FailReasonIRODS.
+	IRODSCollection string
+
+	// IRODSMetadata are attribute/value pairs set on the data objects put in
+	// to IRODSCollection, along with wr_job_key and wr_rep_group.
+	IRODSMetadata map[string]string
+
+	// NetworkAccess declares the network access the Cmd needs, each being
+	// either NetworkInternet or a host:port. Before the Cmd runs, each is
+	// checked to be reachable, and if not the Cmd doesn't run and is treated
+	// as having failed with FailReasonNetwork. Cloud schedulers use these to
+	// pick the security groups of the servers they spawn.
+	NetworkAccess []string
+
+	// Proxy, if set, is a URL that the Cmd's http_proxy and https_proxy (and
+	// upper-case equivalent) environment variables are set to. A NetworkAccess
+	// of NetworkInternet is then checked by connecting to the proxy.
+	Proxy string
+
+	// RefAssets are large, read-only files (such as reference genomes) that
+	// the Cmd needs, which are shared by many Jobs. Before the Cmd runs, each
+	// is fetched in to a cache (RefAssetCacheDir) shared by all the runners on
+	// the host, unless already there, and symlinked in to the Cmd's working
+	// directory. If that fails, the Cmd doesn't run and is treated as having
+	// failed with FailReasonRefAsset.
+	RefAssets []*RefAsset
+
 	// BsubMode set to either Production or Development when Add()ing a job will
 	// result in the job being assigned a BsubID. Such jobs, when they run, will
 	// see bsub, bjobs and bkill as symlinks to wr, thus if they call bsub, they
@@ -212,6 +363,81 @@ type Job struct {
 	// monitoring of multiple docker containers run by a single Cmd.
 	MonitorDocker string
 
+	// RunAs is the name of a user that the Cmd should be run as, instead of the
+	// user that the runner is running as. This is useful when the manager is
+	// running as a service account in a multi-user deployment, so that files
+	// written to shared file systems are owned by the submitting user.
+	//
+	// Requires that the runner user is able to run commands as this user via
+	// `sudo -n -E -u <RunAs>`; ie. sudo must be configured to allow this
+	// without a password and with SETENV. Cwd (or the unique directory created
+	// within it when CwdMatters is false) must be writable by this user; the
+	// unique directory is made so by giving it the job's Group if it has one,
+	// or otherwise with a POSIX ACL.
+	//
+	// The server only accepts jobs whose RunAs is one of the users in its
+	// ServerConfig.RunAsUsers, and never root.
+	RunAs string
+
+	// Shell is the shell the Cmd (and ReportCmd and any Run Behaviours) should
+	// be run with, instead of the runner's default (configured with
+	// runner_exec_shell, normally bash). It is a shell executable optionally
+	// followed by its arguments, eg. "bash -l" to run via a login shell so that
+	// /etc/profile.d scripts are sourced and `module load` works, or "zsh". The
+	// special value "none" (ShellNone) runs the Cmd directly without a shell,
+	// after splitting it in to words respecting quotes and backslashes.
+	Shell string
+
+	// Nice, IONice and OOMScoreAdj let the Cmd behave politely on machines it
+	// shares with other users. Nice is a niceness of 1 to 19 to run the Cmd's
+	// processes with (higher being nicer). IONice is their IO scheduling class,
+	// one of "idle", "best-effort" or "realtime", where the latter 2 can be
+	// followed by a colon and a level of 0 to 7, eg. "best-effort:7".
+	// OOMScoreAdj is an oom_score_adj of -1000 to 1000, where higher values
+	// make the Cmd's processes more likely to be killed by the kernel when
+	// memory runs out, sparing the runner and other processes. Zero values
+	// mean the runner's defaults (see DefaultPoliteness) are used.
+	Nice        int
+	IONice      string
+	OOMScoreAdj int
+
+	// Umask and Group control the permissions of the files the Cmd creates,
+	// so that pipelines in shared projects produce outputs that the rest of
+	// the project can use. Umask is an octal umask, eg. "0002", to run the Cmd
+	// with. Group is the name or id of a group that the runner gives to the
+	// unique working directory it creates when CwdMatters is false (which is
+	// also made setgid, so that files created within it get the group too),
+	// and, after the Cmd exits, to everything in its ActualCwd and to its
+	// OutputFiles. The runner's user must be a member of the group. Blank
+	// values mean the runner's defaults (see DefaultFilePermissions) are used.
+	// NB: with RunAs, sudo may further restrict the Umask.
+	Umask string
+	Group string
+
+	// TraceID is an OpenTelemetry trace id (32 lower-case hexadecimal
+	// characters) that the server records the spans of this Job under, when
+	// it is configured with a TraceEndpoint. Give all the Jobs of a workflow
+	// the same TraceID (see NewTraceID()) to be able to analyse the end-to-end
+	// latency of the whole workflow. When blank, the server gives each batch
+	// of Jobs added together a new TraceID, if it is recording traces. The Cmd
+	// gets the trace parent of the span of its run in the TRACEPARENT
+	// environment variable, so it can add its own spans to the trace.
+	TraceID string
+
+	// Secrets are the names of secrets stored by the server that the Cmd needs.
+	// They will be set as environment variables (named after the secret) only
+	// at the time the Cmd is run, and their values are never stored with the
+	// Job, and are redacted from its stored STDOUT and STDERR.
+	Secrets []string
+
+	// ReportCmd is an optional command line that will be run via the shell in
+	// the same working directory and environment as Cmd, after Cmd exits
+	// successfully. Each line of its STDOUT that looks like key=value is
+	// captured as one of the job's Metrics (eg. reads_mapped=123456), letting
+	// you record and query the results of your Cmds. Its failure does not
+	// cause the job to fail.
+	ReportCmd string
+
 	// The remaining properties are used to record information about what
 	// happened when Cmd was executed, or otherwise provide its current state.
 	// It is meaningless to set these yourself.
@@ -241,12 +467,36 @@ type Job struct {
 	HostID string
 	// host ip the process is running or did run on (cloud specific).
 	HostIP string
+	// name of the scheduler the server has routed the job to (of interest when
+	// the manager is using more than one scheduler).
+	Scheduler string
 	// time the cmd started running.
 	StartTime time.Time
 	// time the cmd stopped running.
 	EndTime time.Time
 	// CPU time used.
 	CPUtime time.Duration
+	// the key=value pairs output by ReportCmd after the cmd succeeded.
+	Metrics map[string]string
+	// where the archive of ActualCwd was stored, if the cmd failed for good
+	// and the server has a FailureArchive.
+	FailureArchive string
+	// what happened when the Behaviours were triggered after the cmd exited.
+	BehaviourResults []BehaviourResult
+
+	// OutputRecords describe the OutputFiles of a Job with OutputChecksums
+	// that completed successfully.
+	OutputRecords []OutputRecord
+	// InputChecksums are the MD5 checksums of the InputFiles of a Cacheable
+	// Job, keyed on their paths as given in InputFiles, taken just before the
+	// cmd ran.
+	InputChecksums map[string]string
+	// CachedInputChecksums are the InputChecksums of the previous run of a
+	// Cacheable Job that was added again, for the runner to compare against.
+	CachedInputChecksums map[string]string
+	// Cached is true if the Job didn't run, but was completed with the results
+	// of a previous run of a Cacheable Job with the same cmd and inputs.
+	Cached bool
 	// to read, call job.StdErr() instead; if the job ran, its (truncated)
 	// STDERR will be here.
 	StdErrC []byte
@@ -279,11 +529,15 @@ type Job struct {
 	// when retrieving jobs with a limit, this tells you how many jobs were
 	// excluded.
 	Similar int
-	// name of the queue the Job was added to.
+	// name of the named queue (see QueueConfig) the Job was added to; blank
+	// for the default queue.
 	Queue string
 	// unique (for this manager session) id of the job submission, present if
 	// BsubMode was set when the job was added.
 	BsubID uint64
+	// the most recent thing a user asked to happen to the job, and who asked
+	// for it.
+	LastAction *UserAction
 
 	// we add this internally to match up runners we spawn via the scheduler to
 	// the Jobs they're allowed to ReserveFiltered().
@@ -304,10 +558,64 @@ type Job struct {
 	// killCalled is set for running jobs if Kill() is called on them.
 	killCalled bool
 
+	// phase is JobStateStaging or JobStateUploading while the runner of this
+	// reserved job tells the server it is moving data for it.
+	phase JobState
+
+	// inputsChecked notes that the manager found all the InputFiles.
+	inputsChecked bool
+
+	// atomicCancelled is set for running jobs we killed because another member
+	// of their AtomicGroup was buried.
+	atomicCancelled bool
+
 	// incrementedLimitGroups notes that we have incremented limit groups for
 	// this job, so they should be decremented when the job finishes running.
 	incrementedLimitGroups []string
 
+	// reservedHost is the host of the runner that reserved this job, which
+	// the server uses to enforce per-host limits before the job has started.
+	reservedHost string
+
+	// reservedManually is true if this job was last reserved by key to be run
+	// manually, in which case the server won't give out its secrets.
+	reservedManually bool
+
+	// traceStart, traceSince and tracePhaseSince are used by the server to
+	// note when this job was first seen, entered its current state and
+	// entered its current data movement phase, for recording trace spans.
+	traceStart      time.Time
+	traceSince      time.Time
+	tracePhaseSince time.Time
+
+	// failureCounts is used by the server to track how many times this job
+	// has failed in each class of failure recognised by its FailureRules.
+	failureCounts map[string]int
+
+	// escalateReqs is set by the server when a FailureRule wants this job's
+	// memory and time requirements increased before it is tried again.
+	escalateReqs bool
+
+	// execShell is the shell Execute() ran the Cmd with, so that Behaviours
+	// can use the same one; this is purely client side.
+	execShell string
+
+	// execLogger is the logger of the Client that Execute()d this job, for
+	// Behaviours to log with; this is purely client side.
+	execLogger log15.Logger
+
+	// execOutcome describes how the Cmd exited when Execute()d, for checking
+	// Behaviour Conditions against; this is purely client side.
+	execOutcome *behaviourOutcome
+
+	// execResubmit is set by a Resubmit Behaviour triggered during Execute(),
+	// for sending to the server; this is purely client side.
+	execResubmit *ResubmitOptions
+
+	// execClient is the Client that Execute()d this job, for AddJobs
+	// Behaviours to add jobs with; this is purely client side.
+	execClient *Client
+
 	sync.RWMutex
 }
 
@@ -329,8 +637,9 @@ func (j *Job) WallTime() time.Duration {
 // the environment variables the Job's Cmd should run/ran under). Note that EnvC
 // is only populated if you got the Job from GetByCmd(_, _, true) or Reserve().
 // If no environment variables were passed in when the job was Add()ed to the
-// queue, returns current environment variables instead. In both cases, alters
-// the return value to apply any overrides stored in job.EnvOverride.
+// queue, returns current environment variables instead (unless CleanEnv is
+// true, in which case nothing is added). In both cases, alters the return
+// value to apply any overrides stored in job.EnvOverride.
 func (j *Job) Env() ([]string, error) {
 	overrideEs, err := j.envCurrentOverrides()
 	if err != nil {
@@ -339,7 +648,10 @@ func (j *Job) Env() ([]string, error) {
 
 	if len(j.EnvC) == 0 {
 		if j.EnvCRetrieved {
-			env := os.Environ()
+			var env []string
+			if !j.CleanEnv {
+				env = os.Environ()
+			}
 			if len(overrideEs) > 0 {
 				env = envOverride(env, overrideEs)
 			}
@@ -361,7 +673,7 @@ func (j *Job) Env() ([]string, error) {
 	}
 	env := es.Environ
 
-	if len(env) == 0 {
+	if len(env) == 0 && !j.CleanEnv {
 		env = os.Environ()
 	}
 
@@ -662,9 +974,9 @@ func (j *Job) Unmount(stopUploads ...bool) (logs string, err error) {
 	if j.ActualCwd != "" {
 		for _, mc := range j.MountConfigs {
 			if mc.Mount == "" {
-				err = rmEmptyDirs(j.ActualCwd, j.Cwd)
+				err = rmEmptyDirs(j.ActualCwd, j.cwdBase())
 			} else if !filepath.IsAbs(mc.Mount) {
-				err = rmEmptyDirs(filepath.Join(j.ActualCwd, mc.Mount), j.Cwd)
+				err = rmEmptyDirs(filepath.Join(j.ActualCwd, mc.Mount), j.cwdBase())
 			}
 		}
 	}
@@ -691,6 +1003,36 @@ func (j *Job) noteIncrementedLimitGroups(groups []string) {
 	j.incrementedLimitGroups = groups
 }
 
+// increaseRAMReq increases our RAM requirement based on our PeakRAM: by 1GB
+// or [100% if under 8GB, 30% if over], whichever is greater, rounded up to the
+// nearest 100. You must hold the lock before calling this.
+//
+// *** increase to greater than max seen for jobs in our ReqGroup?
+func (j *Job) increaseRAMReq() {
+	updatedMB := float64(j.PeakRAM)
+	if updatedMB <= RAMIncreaseMultBreakpoint {
+		updatedMB *= RAMIncreaseMultLow
+	} else {
+		updatedMB *= RAMIncreaseMultHigh
+	}
+	if updatedMB < float64(j.PeakRAM)+RAMIncreaseMin {
+		updatedMB = float64(j.PeakRAM) + RAMIncreaseMin
+	}
+	newRAM := int(math.Ceil(updatedMB/100) * 100)
+	if newRAM > j.Requirements.RAM {
+		j.Requirements.RAM = newRAM
+	}
+}
+
+// increaseTimeReq increases our Time requirement to 1 hour more than our last
+// run took. You must hold the lock before calling this.
+func (j *Job) increaseTimeReq() {
+	newTime := j.EndTime.Sub(j.StartTime) + (1 * time.Hour)
+	if newTime > j.Requirements.Time {
+		j.Requirements.Time = newTime
+	}
+}
+
 // updateAfterExit sets some properties on the job, only if the supplied
 // JobEndState indicates the job exited, and if the job wasn't already exited.
 // It also calls decrementLimitGroups().
@@ -717,6 +1059,11 @@ func (j *Job) updateAfterExit(jes *JobEndState, lim *limiter.Limiter) {
 	if jes.Cwd != "" {
 		j.ActualCwd = jes.Cwd
 	}
+	j.Metrics = jes.Metrics
+	j.FailureArchive = jes.FailureArchive
+	j.BehaviourResults = jes.BehaviourResults
+	j.OutputRecords = jes.OutputRecords
+	j.InputChecksums = jes.InputChecksums
 	j.Unlock()
 }
 
@@ -735,9 +1082,24 @@ func (j *Job) decrementLimitGroups(lim *limiter.Limiter) {
 // Key calculates a unique key to describe the job.
 func (j *Job) Key() string {
 	if j.CwdMatters {
-		return byteKey([]byte(fmt.Sprintf("%s.%s.%s", j.Cwd, j.Cmd, j.MountConfigs.Key())))
+		return JobKey(j.Cmd, j.Cwd, j.MountConfigs)
+	}
+	return JobKey(j.Cmd, "", j.MountConfigs)
+}
+
+// JobKey returns the key that a Job with the given Cmd, Cwd and MountConfigs
+// would have, which is the internal identifier that wr reports for it. This
+// lets you work out the key of a command you added earlier without having had
+// to store anything wr told you about it.
+//
+// cwd should only be supplied if the Job was created with CwdMatters = true;
+// otherwise the cwd does not form part of a Job's key. mounts should be nil if
+// the Job was created without any.
+func JobKey(cmd, cwd string, mounts MountConfigs) string {
+	if cwd != "" {
+		return byteKey([]byte(fmt.Sprintf("%s.%s.%s", cwd, cmd, mounts.Key())))
 	}
-	return byteKey([]byte(fmt.Sprintf("%s.%s", j.Cmd, j.MountConfigs.Key())))
+	return byteKey([]byte(fmt.Sprintf("%s.%s", cmd, mounts.Key())))
 }
 
 // getScheduledRunner provides a thread-safe way of getting the scheduledRunner
@@ -773,17 +1135,28 @@ func (j *Job) getSchedulerIgnored() bool {
 }
 
 // generateSchedulerGroup returns a stringified form of the given requirements,
-// appended with a standard form of the current limit groups of this job. We
-// assume that LimitGroups was sorted and deduplicated when it was set on the
-// job (this happens in server.createJobs()).
+// appended with a standard form of the current limit groups of this job, and
+// its MaxPerHost if set. We assume that LimitGroups was sorted and deduplicated
+// when it was set on the job (this happens in server.createJobs()).
 func (j *Job) generateSchedulerGroup(req *scheduler.Requirements) string {
 	var lgs string
 	if len(j.LimitGroups) > 0 {
 		lgs = jobSchedLimitGroupSeparator + strings.Join(j.LimitGroups, jobLimitGroupSeparator)
 	}
+	if j.MaxPerHost > 0 {
+		lgs += jobSchedMaxPerHostSeparator + strconv.Itoa(j.MaxPerHost)
+	}
 	return req.Stringify() + lgs
 }
 
+// setScheduler provides a thread-safe way of setting the Scheduler property of
+// a Job.
+func (j *Job) setScheduler(newval string) {
+	j.Lock()
+	defer j.Unlock()
+	j.Scheduler = newval
+}
+
 // getSchedulerGroup provides a thread-safe way of getting the schedulerGroup
 // property of a Job.
 func (j *Job) getSchedulerGroup() string {
@@ -800,6 +1173,43 @@ func (j *Job) setSchedulerGroup(newval string) {
 	j.schedulerGroup = newval
 }
 
+// runningState returns the state the server reports for this job while it is
+// in the run sub-queue and not lost: the phase its runner last told us about,
+// or running. You must hold at least a read lock on the job.
+func (j *Job) runningState() JobState {
+	if j.phase != "" {
+		return j.phase
+	}
+	return JobStateRunning
+}
+
+// stagesData returns true if Execute() will spend time mounting file systems or
+// fetching inputs for this job before starting its Cmd.
+func (j *Job) stagesData() bool {
+	return len(j.MountConfigs) > 0 || len(j.IRODSInputs) > 0 || len(j.RefAssets) > 0
+}
+
+// uploadsData returns true if Execute() will spend time uploading this job's
+// outputs to writeable mounts, iRODS or the manager after its Cmd exits.
+func (j *Job) uploadsData() bool {
+	if j.IRODSCollection != "" && len(j.OutputFiles) > 0 {
+		return true
+	}
+	for _, b := range j.Behaviours {
+		if b.Do == CopyToManager {
+			return true
+		}
+	}
+	for _, mc := range j.MountConfigs {
+		for _, t := range mc.Targets {
+			if t.Write {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ToStatus converts a job to a simplified JStatus, useful for output as JSON.
 func (j *Job) ToStatus() (JStatus, error) {
 	stderr, err := j.StdErr()
@@ -818,7 +1228,7 @@ func (j *Job) ToStatus() (JStatus, error) {
 	j.RLock()
 	defer j.RUnlock()
 	if j.ActualCwd != "" {
-		cwdLeaf, err = filepath.Rel(j.Cwd, j.ActualCwd)
+		cwdLeaf, err = filepath.Rel(j.cwdBase(), j.ActualCwd)
 		if err != nil {
 			return JStatus{}, err
 		}
@@ -828,6 +1238,10 @@ func (j *Job) ToStatus() (JStatus, error) {
 	if state == JobStateRunning && j.Lost {
 		state = JobStateLost
 	}
+	var lastAction string
+	if j.LastAction != nil {
+		lastAction = j.LastAction.String()
+	}
 	ot := make([]string, 0, len(j.Requirements.Other))
 	for key, val := range j.Requirements.Other {
 		ot = append(ot, key+":"+val)
@@ -838,14 +1252,19 @@ func (j *Job) ToStatus() (JStatus, error) {
 		LimitGroups:   j.LimitGroups,
 		DepGroups:     j.DepGroups,
 		Dependencies:  j.Dependencies.Stringify(),
+		AtomicGroup:   j.AtomicGroup,
+		Queue:         j.Queue,
 		Cmd:           j.Cmd,
 		State:         state,
-		CwdBase:       j.Cwd,
+		CwdBase:       j.cwdBase(),
 		Cwd:           cwdLeaf,
 		HomeChanged:   j.ChangeHome,
 		Behaviours:    j.Behaviours.String(),
 		Mounts:        j.MountConfigs.String(),
 		MonitorDocker: j.MonitorDocker,
+		ReportCmd:     j.ReportCmd,
+		Metrics:       j.Metrics,
+		Archive:       j.FailureArchive,
 		ExpectedRAM:   j.Requirements.RAM,
 		ExpectedTime:  j.Requirements.Time.Seconds(),
 		RequestedDisk: j.Requirements.Disk,
@@ -856,15 +1275,19 @@ func (j *Job) ToStatus() (JStatus, error) {
 		Exited:        j.Exited,
 		Exitcode:      j.Exitcode,
 		FailReason:    j.FailReason,
+		LastAction:    lastAction,
+		AtomicFailed:  j.AtomicGroupFailed,
 		Pid:           j.Pid,
 		Host:          j.Host,
 		HostID:        j.HostID,
 		HostIP:        j.HostIP,
+		Scheduler:     j.Scheduler,
 		Walltime:      j.WallTime().Seconds(),
 		CPUtime:       j.CPUtime.Seconds(),
 		Started:       j.StartTime.Unix(),
 		Ended:         j.EndTime.Unix(),
 		Attempts:      j.Attempts,
+		Retries:       j.Retries,
 		Similar:       j.Similar,
 		StdErr:        stderr,
 		StdOut:        stdout,
@@ -896,11 +1319,7 @@ func (j *JobEssence) Key() string {
 	if j.JobKey != "" {
 		return j.JobKey
 	}
-
-	if j.Cwd != "" {
-		return byteKey([]byte(fmt.Sprintf("%s.%s.%s", j.Cwd, j.Cmd, j.MountConfigs.Key())))
-	}
-	return byteKey([]byte(fmt.Sprintf("%s.%s", j.Cmd, j.MountConfigs.Key())))
+	return JobKey(j.Cmd, j.Cwd, j.MountConfigs)
 }
 
 // Stringify returns a nice printable form of a JobEssence.
@@ -928,16 +1347,35 @@ type JobModifier struct {
 	Dependencies     Dependencies
 	Behaviours       Behaviours
 	MountConfigs     MountConfigs
+	InputFiles       []string
+	OutputFiles      []string
+	IRODSInputs      []string
+	IRODSMetadata    map[string]string
 	Cmd              string
 	Cwd              string
 	ReqGroup         string
 	BsubMode         string
 	MonitorDocker    string
+	RunAs            string
+	Shell            string
+	IONice           string
+	Umask            string
+	Group            string
+	ReportCmd        string
+	IRODSCollection  string
 	Requirements     *scheduler.Requirements
+	Nice             int
+	OOMScoreAdj      int
 	CwdMatters       bool
 	CwdMattersSet    bool
 	ChangeHome       bool
 	ChangeHomeSet    bool
+	CwdTemplate      string
+	CwdTemplateSet   bool
+	CwdBase          string
+	CwdBaseSet       bool
+	CwdLink          bool
+	CwdLinkSet       bool
 	ReqGroupSet      bool
 	Override         uint8
 	OverrideSet      bool
@@ -951,8 +1389,27 @@ type JobModifier struct {
 	DependenciesSet  bool
 	BehavioursSet    bool
 	MountConfigsSet  bool
+	InputFilesSet    bool
+	OutputFilesSet   bool
+	OutputMinSize    int64
+	OutputMinSizeSet bool
+	OutputCheckCmd   string
+	OutputCheckSet   bool
+	OutputChecksums  bool
+	ChecksumsSet     bool
+	IRODSInputsSet   bool
+	IRODSCollSet     bool
+	IRODSMetadataSet bool
 	BsubModeSet      bool
 	MonitorDockerSet bool
+	RunAsSet         bool
+	ShellSet         bool
+	NiceSet          bool
+	IONiceSet        bool
+	OOMScoreAdjSet   bool
+	UmaskSet         bool
+	GroupSet         bool
+	ReportCmdSet     bool
 }
 
 // NewJobModifer is a convenience for making a new JobModifer, that you can call
@@ -986,6 +1443,24 @@ func (j *JobModifier) SetChangeHome(new bool) {
 	j.ChangeHomeSet = true
 }
 
+// SetCwdTemplate notes that you want to modify the CwdTemplate of Jobs.
+func (j *JobModifier) SetCwdTemplate(new string) {
+	j.CwdTemplate = new
+	j.CwdTemplateSet = true
+}
+
+// SetCwdBase notes that you want to modify the CwdBase of Jobs.
+func (j *JobModifier) SetCwdBase(new string) {
+	j.CwdBase = new
+	j.CwdBaseSet = true
+}
+
+// SetCwdLink notes that you want to modify the CwdLink of Jobs.
+func (j *JobModifier) SetCwdLink(new bool) {
+	j.CwdLink = new
+	j.CwdLinkSet = true
+}
+
 // SetReqGroup notes that you want to modify the ReqGroup of Jobs.
 func (j *JobModifier) SetReqGroup(new string) {
 	j.ReqGroup = new
@@ -1042,6 +1517,56 @@ func (j *JobModifier) SetLimitGroups(new []string) {
 	j.LimitGroupsSet = true
 }
 
+// SetInputFiles notes that you want to modify the InputFiles of Jobs.
+func (j *JobModifier) SetInputFiles(new []string) {
+	j.InputFiles = new
+	j.InputFilesSet = true
+}
+
+// SetOutputFiles notes that you want to modify the OutputFiles of Jobs.
+func (j *JobModifier) SetOutputFiles(new []string) {
+	j.OutputFiles = new
+	j.OutputFilesSet = true
+}
+
+// SetOutputMinSize notes that you want to modify the OutputMinSize of Jobs.
+func (j *JobModifier) SetOutputMinSize(new int64) {
+	j.OutputMinSize = new
+	j.OutputMinSizeSet = true
+}
+
+// SetOutputCheckCmd notes that you want to modify the OutputCheckCmd of Jobs.
+func (j *JobModifier) SetOutputCheckCmd(new string) {
+	j.OutputCheckCmd = new
+	j.OutputCheckSet = true
+}
+
+// SetOutputChecksums notes that you want to modify the OutputChecksums of
+// Jobs.
+func (j *JobModifier) SetOutputChecksums(new bool) {
+	j.OutputChecksums = new
+	j.ChecksumsSet = true
+}
+
+// SetIRODSInputs notes that you want to modify the IRODSInputs of Jobs.
+func (j *JobModifier) SetIRODSInputs(new []string) {
+	j.IRODSInputs = new
+	j.IRODSInputsSet = true
+}
+
+// SetIRODSCollection notes that you want to modify the IRODSCollection of
+// Jobs.
+func (j *JobModifier) SetIRODSCollection(new string) {
+	j.IRODSCollection = new
+	j.IRODSCollSet = true
+}
+
+// SetIRODSMetadata notes that you want to modify the IRODSMetadata of Jobs.
+func (j *JobModifier) SetIRODSMetadata(new map[string]string) {
+	j.IRODSMetadata = new
+	j.IRODSMetadataSet = true
+}
+
 // SetDepGroups notes that you want to modify the DepGroups of Jobs.
 func (j *JobModifier) SetDepGroups(new []string) {
 	j.DepGroups = new
@@ -1078,6 +1603,54 @@ func (j *JobModifier) SetMonitorDocker(new string) {
 	j.MonitorDockerSet = true
 }
 
+// SetRunAs notes that you want to modify the RunAs of Jobs.
+func (j *JobModifier) SetRunAs(new string) {
+	j.RunAs = new
+	j.RunAsSet = true
+}
+
+// SetShell notes that you want to modify the Shell of Jobs.
+func (j *JobModifier) SetShell(new string) {
+	j.Shell = new
+	j.ShellSet = true
+}
+
+// SetNice notes that you want to modify the Nice of Jobs.
+func (j *JobModifier) SetNice(new int) {
+	j.Nice = new
+	j.NiceSet = true
+}
+
+// SetIONice notes that you want to modify the IONice of Jobs.
+func (j *JobModifier) SetIONice(new string) {
+	j.IONice = new
+	j.IONiceSet = true
+}
+
+// SetOOMScoreAdj notes that you want to modify the OOMScoreAdj of Jobs.
+func (j *JobModifier) SetOOMScoreAdj(new int) {
+	j.OOMScoreAdj = new
+	j.OOMScoreAdjSet = true
+}
+
+// SetUmask notes that you want to modify the Umask of Jobs.
+func (j *JobModifier) SetUmask(new string) {
+	j.Umask = new
+	j.UmaskSet = true
+}
+
+// SetGroup notes that you want to modify the Group of Jobs.
+func (j *JobModifier) SetGroup(new string) {
+	j.Group = new
+	j.GroupSet = true
+}
+
+// SetReportCmd notes that you want to modify the ReportCmd of Jobs.
+func (j *JobModifier) SetReportCmd(new string) {
+	j.ReportCmd = new
+	j.ReportCmdSet = true
+}
+
 // Modify takes existing jobs and modifies them all by setting the new values
 // that you have previously set using the Set*() methods. Other values are left
 // alone. Note that this could result in a Job's Key() changing.
@@ -1145,6 +1718,15 @@ func (j *JobModifier) Modify(jobs []*Job, server *Server) (map[string]string, er
 		if j.ChangeHomeSet {
 			job.ChangeHome = j.ChangeHome
 		}
+		if j.CwdTemplateSet {
+			job.CwdTemplate = j.CwdTemplate
+		}
+		if j.CwdBaseSet {
+			job.CwdBase = j.CwdBase
+		}
+		if j.CwdLinkSet {
+			job.CwdLink = j.CwdLink
+		}
 		if j.ReqGroupSet {
 			job.ReqGroup = j.ReqGroup
 		}
@@ -1180,6 +1762,31 @@ func (j *JobModifier) Modify(jobs []*Job, server *Server) (map[string]string, er
 		if j.LimitGroupsSet {
 			job.LimitGroups = j.LimitGroups
 		}
+		if j.InputFilesSet {
+			job.InputFiles = j.InputFiles
+			job.inputsChecked = false
+		}
+		if j.OutputFilesSet {
+			job.OutputFiles = j.OutputFiles
+		}
+		if j.OutputMinSizeSet {
+			job.OutputMinSize = j.OutputMinSize
+		}
+		if j.OutputCheckSet {
+			job.OutputCheckCmd = j.OutputCheckCmd
+		}
+		if j.ChecksumsSet {
+			job.OutputChecksums = j.OutputChecksums
+		}
+		if j.IRODSInputsSet {
+			job.IRODSInputs = j.IRODSInputs
+		}
+		if j.IRODSCollSet {
+			job.IRODSCollection = j.IRODSCollection
+		}
+		if j.IRODSMetadataSet {
+			job.IRODSMetadata = j.IRODSMetadata
+		}
 		if j.DepGroupsSet {
 			job.DepGroups = j.DepGroups
 		}
@@ -1212,6 +1819,30 @@ func (j *JobModifier) Modify(jobs []*Job, server *Server) (map[string]string, er
 		if j.MonitorDockerSet {
 			job.MonitorDocker = j.MonitorDocker
 		}
+		if j.RunAsSet {
+			job.RunAs = j.RunAs
+		}
+		if j.ShellSet {
+			job.Shell = j.Shell
+		}
+		if j.NiceSet {
+			job.Nice = j.Nice
+		}
+		if j.IONiceSet {
+			job.IONice = j.IONice
+		}
+		if j.OOMScoreAdjSet {
+			job.OOMScoreAdj = j.OOMScoreAdj
+		}
+		if j.UmaskSet {
+			job.Umask = j.Umask
+		}
+		if j.GroupSet {
+			job.Group = j.Group
+		}
+		if j.ReportCmdSet {
+			job.ReportCmd = j.ReportCmd
+		}
 		keys[job.Key()] = before
 		job.Unlock()
 	}
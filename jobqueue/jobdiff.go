@@ -0,0 +1,143 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of comparing two sets of jobs, such as
+// those of two runs of a workflow, so users can validate a change to their
+// pipeline against a previous run.
+
+import (
+	"sort"
+)
+
+// JobPair is a job from each of the two sets of jobs compared by DiffJobs(),
+// that had the same Cmd.
+type JobPair struct {
+	A *Job
+	B *Job
+}
+
+// JobsDiff describes how a set of jobs B differs from a set of jobs A, with
+// jobs being matched between the sets by their Cmd.
+type JobsDiff struct {
+	// OnlyA and OnlyB are the jobs whose Cmd was only in A or B, respectively.
+	OnlyA []*Job
+	OnlyB []*Job
+
+	// Fixed are the jobs that were buried in A but completed in B, and Broken
+	// are the jobs that completed in A but were buried in B.
+	Fixed  []*JobPair
+	Broken []*JobPair
+
+	// Slower are the jobs that completed in both, but took longer in B by more
+	// than the threshold supplied to DiffJobs().
+	Slower []*JobPair
+
+	// Matched is the number of Cmds that were in both A and B.
+	Matched int
+}
+
+// DiffJobs compares 2 sets of jobs, such as the jobs in the RepGroups of two
+// runs of a workflow, matching them by Cmd. Jobs that complete in both A and B
+// are reported as Slower if their wall time in B is more than threshold
+// (eg. 0.2 for 20%) longer than in A. If a set has more than 1 job with the
+// same Cmd (eg. with different Cwds), only the first is considered.
+//
+// The jobs in the returned JobsDiff are sorted by Cmd, except for Slower, which
+// is sorted with the greatest regression first.
+func DiffJobs(a, b []*Job, threshold float64) *JobsDiff {
+	aByCmd := jobsByCmd(a)
+	bByCmd := jobsByCmd(b)
+	diff := &JobsDiff{}
+
+	for cmd, aj := range aByCmd {
+		bj, found := bByCmd[cmd]
+		if !found {
+			diff.OnlyA = append(diff.OnlyA, aj)
+			continue
+		}
+		diff.Matched++
+
+		pair := &JobPair{A: aj, B: bj}
+		switch {
+		case aj.State == JobStateBuried && bj.State == JobStateComplete:
+			diff.Fixed = append(diff.Fixed, pair)
+		case aj.State == JobStateComplete && bj.State == JobStateBuried:
+			diff.Broken = append(diff.Broken, pair)
+		case aj.State == JobStateComplete && bj.State == JobStateComplete:
+			if pair.Regression() > threshold {
+				diff.Slower = append(diff.Slower, pair)
+			}
+		}
+	}
+
+	for cmd, bj := range bByCmd {
+		if _, found := aByCmd[cmd]; !found {
+			diff.OnlyB = append(diff.OnlyB, bj)
+		}
+	}
+
+	sortJobsByCmd(diff.OnlyA)
+	sortJobsByCmd(diff.OnlyB)
+	sortJobPairsByCmd(diff.Fixed)
+	sortJobPairsByCmd(diff.Broken)
+	sortJobPairsByCmd(diff.Slower)
+	sort.SliceStable(diff.Slower, func(i, j int) bool {
+		return diff.Slower[i].Regression() > diff.Slower[j].Regression()
+	})
+
+	return diff
+}
+
+// Regression returns how much longer, as a proportion of A's wall time, B took
+// to run than A. Eg. 0.5 if B took 50% longer. If A has no wall time, returns
+// 0.
+func (p *JobPair) Regression() float64 {
+	aWall := p.A.WallTime()
+	if aWall <= 0 {
+		return 0
+	}
+	return float64(p.B.WallTime()-aWall) / float64(aWall)
+}
+
+// jobsByCmd returns the given jobs keyed on their Cmd, keeping the first job
+// of any with the same Cmd.
+func jobsByCmd(jobs []*Job) map[string]*Job {
+	byCmd := make(map[string]*Job, len(jobs))
+	for _, job := range jobs {
+		if _, exists := byCmd[job.Cmd]; !exists {
+			byCmd[job.Cmd] = job
+		}
+	}
+	return byCmd
+}
+
+// sortJobsByCmd sorts the given jobs by their Cmd.
+func sortJobsByCmd(jobs []*Job) {
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Cmd < jobs[j].Cmd
+	})
+}
+
+// sortJobPairsByCmd sorts the given pairs by their Cmd.
+func sortJobPairsByCmd(pairs []*JobPair) {
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].A.Cmd < pairs[j].A.Cmd
+	})
+}
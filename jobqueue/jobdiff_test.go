@@ -0,0 +1,93 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestJobDiff(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Two sets of jobs can be compared", t, func() {
+		start := time.Now().Add(-1 * time.Hour)
+		job := func(cmd string, state JobState, wall time.Duration) *Job {
+			return &Job{Cmd: cmd, State: state, StartTime: start, EndTime: start.Add(wall)}
+		}
+
+		a := []*Job{
+			job("same", JobStateComplete, 10*time.Second),
+			job("only a", JobStateComplete, 10*time.Second),
+			job("fixed", JobStateBuried, 10*time.Second),
+			job("broken", JobStateComplete, 10*time.Second),
+			job("slower", JobStateComplete, 10*time.Second),
+			job("much slower", JobStateComplete, 10*time.Second),
+			job("slightly slower", JobStateComplete, 10*time.Second),
+			job("still running", JobStateComplete, 10*time.Second),
+			job("same", JobStateBuried, 10*time.Second),
+		}
+		b := []*Job{
+			job("much slower", JobStateComplete, 30*time.Second),
+			job("same", JobStateComplete, 9*time.Second),
+			job("fixed", JobStateComplete, 10*time.Second),
+			job("broken", JobStateBuried, 10*time.Second),
+			job("slower", JobStateComplete, 15*time.Second),
+			job("slightly slower", JobStateComplete, 11*time.Second),
+			{Cmd: "still running", State: JobStateRunning, StartTime: start},
+			job("only b 2", JobStateComplete, 10*time.Second),
+			job("only b 1", JobStateBuried, 10*time.Second),
+		}
+
+		diff := DiffJobs(a, b, 0.2)
+		So(diff.Matched, ShouldEqual, 7)
+		So(len(diff.OnlyA), ShouldEqual, 1)
+		So(diff.OnlyA[0].Cmd, ShouldEqual, "only a")
+		So(len(diff.OnlyB), ShouldEqual, 2)
+		So(diff.OnlyB[0].Cmd, ShouldEqual, "only b 1")
+		So(diff.OnlyB[1].Cmd, ShouldEqual, "only b 2")
+		So(len(diff.Fixed), ShouldEqual, 1)
+		So(diff.Fixed[0].B.Cmd, ShouldEqual, "fixed")
+		So(len(diff.Broken), ShouldEqual, 1)
+		So(diff.Broken[0].A.Cmd, ShouldEqual, "broken")
+		So(len(diff.Slower), ShouldEqual, 2)
+		So(diff.Slower[0].A.Cmd, ShouldEqual, "much slower")
+		So(diff.Slower[0].Regression(), ShouldAlmostEqual, 2)
+		So(diff.Slower[1].A.Cmd, ShouldEqual, "slower")
+		So(diff.Slower[1].Regression(), ShouldAlmostEqual, 0.5)
+
+		Convey("The threshold controls which regressions are reported", func() {
+			diff = DiffJobs(a, b, 0.05)
+			So(len(diff.Slower), ShouldEqual, 3)
+			So(diff.Slower[2].A.Cmd, ShouldEqual, "slightly slower")
+
+			diff = DiffJobs(a, b, 3)
+			So(diff.Slower, ShouldBeEmpty)
+		})
+
+		Convey("Jobs without wall time don't regress", func() {
+			pair := &JobPair{A: &Job{Cmd: "a"}, B: job("a", JobStateComplete, time.Second)}
+			So(pair.Regression(), ShouldEqual, 0)
+		})
+	})
+}
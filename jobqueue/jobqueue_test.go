@@ -19,6 +19,9 @@
 package jobqueue
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -26,12 +29,17 @@ import (
 	"io/ioutil"
 	"log"
 	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -42,6 +50,9 @@ import (
 	"github.com/VertebrateResequencing/wr/cloud"
 	"github.com/VertebrateResequencing/wr/internal"
 	jqs "github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+	"github.com/VertebrateResequencing/wr/queue"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/websocket"
 	"github.com/inconshreveable/log15"
 	"github.com/sb10/l15h"
 	"github.com/shirou/gopsutil/process"
@@ -106,6 +117,96 @@ func TestJobqueueUtils(t *testing.T) {
 		So(ip, ShouldEqual, ip)
 	})
 
+	Convey("FilterEnv() and cleanLoginEnv() work", t, func() {
+		env := []string{"PATH=/bin", "SECRET=foo", "PERL5LIB=/lib=x"}
+		So(FilterEnv(env, []string{"PATH", " PERL5LIB"}), ShouldResemble, []string{"PATH=/bin", "PERL5LIB=/lib=x"})
+		So(FilterEnv(env, nil), ShouldBeEmpty)
+
+		os.Setenv("WR_TEST_CLEAN_ENV", "leaked")
+		defer os.Unsetenv("WR_TEST_CLEAN_ENV")
+		clean := cleanLoginEnv("bash")
+		So(clean, ShouldNotBeEmpty)
+		var hasPath bool
+		for _, envvar := range clean {
+			So(envvar, ShouldNotStartWith, "WR_TEST_CLEAN_ENV=")
+			if strings.HasPrefix(envvar, "PATH=") {
+				hasPath = true
+			}
+		}
+		So(hasPath, ShouldBeTrue)
+
+		Convey("Jobs with CleanEnv don't fall back to the current environment", func() {
+			job := &Job{CleanEnv: true, EnvCRetrieved: true}
+			err := job.EnvAddOverride([]string{"FOO=bar"})
+			So(err, ShouldBeNil)
+			jobEnv, err := job.Env()
+			So(err, ShouldBeNil)
+			So(jobEnv, ShouldResemble, []string{"FOO=bar"})
+
+			job.CleanEnv = false
+			jobEnv, err = job.Env()
+			So(err, ShouldBeNil)
+			So(len(jobEnv), ShouldBeGreaterThan, 1)
+		})
+	})
+
+	Convey("secretStore and redactSecrets() work", t, func() {
+		dir, err := ioutil.TempDir("", "wr_jobqueue_test_secrets_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "secrets")
+
+		ss, err := openSecretStore(path)
+		So(err, ShouldBeNil)
+		So(ss.names(), ShouldBeEmpty)
+
+		grant, err := ss.set("DB_PASS", "hunter22")
+		So(err, ShouldBeNil)
+		So(grant, ShouldNotBeBlank)
+		apiGrant, err := ss.set("API_KEY", "abc")
+		So(err, ShouldBeNil)
+		So(apiGrant, ShouldNotEqual, grant)
+		_, err = ss.set("bad name", "x")
+		So(err, ShouldNotBeNil)
+
+		content, err := ioutil.ReadFile(path)
+		So(err, ShouldBeNil)
+		So(string(content), ShouldNotContainSubstring, "hunter22")
+		info, err := os.Stat(path + secretKeySuffix)
+		So(err, ShouldBeNil)
+		So(info.Mode().Perm(), ShouldEqual, os.FileMode(0600))
+
+		ss, err = openSecretStore(path)
+		So(err, ShouldBeNil)
+		So(ss.names(), ShouldResemble, []string{"API_KEY", "DB_PASS"})
+
+		So(ss.permit([]string{"DB_PASS", "API_KEY"}, map[string]string{"DB_PASS": grant, "API_KEY": apiGrant}), ShouldBeNil)
+		So(ss.permit([]string{"DB_PASS"}, map[string]string{"API_KEY": apiGrant}), ShouldNotBeNil)
+		So(ss.permit([]string{"DB_PASS"}, map[string]string{"DB_PASS": apiGrant}), ShouldNotBeNil)
+		So(ss.permit([]string{"DB_PASS"}, nil), ShouldNotBeNil)
+		So(ss.permit([]string{"MISSING"}, map[string]string{"MISSING": ""}), ShouldNotBeNil)
+
+		newGrant, err := ss.set("DB_PASS", "hunter22")
+		So(err, ShouldBeNil)
+		So(ss.permit([]string{"DB_PASS"}, map[string]string{"DB_PASS": grant}), ShouldNotBeNil)
+		So(ss.permit([]string{"DB_PASS"}, map[string]string{"DB_PASS": newGrant}), ShouldBeNil)
+
+		env, err := ss.env([]string{"DB_PASS", "API_KEY"})
+		So(err, ShouldBeNil)
+		So(env, ShouldResemble, []string{"DB_PASS=hunter22", "API_KEY=abc"})
+		_, err = ss.env([]string{"MISSING"})
+		So(err, ShouldNotBeNil)
+
+		redacted := redactSecrets([]byte("pass hunter22 key abc"), env)
+		So(string(redacted), ShouldEqual, "pass "+secretRedaction+" key abc")
+
+		err = ss.delete("API_KEY")
+		So(err, ShouldBeNil)
+		err = ss.delete("API_KEY")
+		So(err, ShouldNotBeNil)
+		So(ss.names(), ShouldResemble, []string{"DB_PASS"})
+	})
+
 	Convey("generateToken() and tokenMatches() work", t, func() {
 		tokenFile, err := ioutil.TempFile("", "wr.test.token")
 		So(err, ShouldBeNil)
@@ -159,6 +260,8 @@ func jobqueueTestInit(shortTTR bool) (internal.Config, ServerConfig, string, *jq
 		CertFile:        config.ManagerCertFile,
 		CertDomain:      config.ManagerCertDomain,
 		KeyFile:         config.ManagerKeyFile,
+		CopyDir:         config.ManagerCopyDir,
+		TransferSlots:   1,
 		Deployment:      config.Deployment,
 		Logger:          testLogger,
 	}
@@ -777,51 +880,3485 @@ func TestJobqueueSignal(t *testing.T) {
 			So(waitUntilPidsAreGone(map[int]bool{serverPid: true}, 5), ShouldBeTrue)
 		})
 
-		Reset(func() {
-			killServer(jq, serverPid, serverCmd)
-		})
-	})
-}
+		Reset(func() {
+			killServer(jq, serverPid, serverCmd)
+		})
+	})
+}
+
+func TestJobqueueBasics(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+	config, serverConfig, addr, standardReqs, clientConnectTime := jobqueueTestInit(true)
+
+	defer os.RemoveAll(filepath.Join(os.TempDir(), AppName+"_cwd"))
+
+	var server *Server
+	var token []byte
+	var errs error
+	Convey("Without the jobserver being up, clients can't connect and time out", t, func() {
+		_, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+		So(err, ShouldNotBeNil)
+		jqerr, ok := err.(Error)
+		So(ok, ShouldBeTrue)
+		So(jqerr.Err, ShouldEqual, ErrNoServer)
+	})
+
+	Convey("Once the jobqueue server is up", t, func() {
+		server, _, token, errs = serve(serverConfig)
+		So(errs, ShouldBeNil)
+
+		server.rc = serverRC // ReserveScheduled() only works if we have an rc
+
+		Convey("You can connect to the server and add jobs and get back their IDs", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			var jobs []*Job
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			jobs = append(jobs, &Job{Cmd: "echo 1", Cwd: "/tmp", ReqGroup: "fake_group", Requirements: req, Retries: uint8(0), RepGroup: "test"})
+			jobs = append(jobs, &Job{Cmd: "echo 2", Cwd: "/tmp", ReqGroup: "fake_group", Requirements: req, Retries: uint8(0), RepGroup: "test"})
+			ids, err := jq.AddAndReturnIDs(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(len(ids), ShouldEqual, 2)
+			So(ids[0], ShouldEqual, "9a456dee1e351f82e3d562769c27d803")
+			So(ids[1], ShouldEqual, "2bb7055e49e21ea85066899a5ba38d8e")
+		})
+
+		Convey("Jobs with an affinity prefer hosts that recently ran jobs with the same affinity", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			sgroup := "1024:240:1:0"
+			jobs := []*Job{{Cmd: "echo warmup", Cwd: "/tmp", ReqGroup: "affinity", Requirements: req, RepGroup: "affinity", Affinity: "dataset1"}}
+			_, _, err = jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, sgroup)
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Affinity, ShouldEqual, "dataset1")
+			err = jq.Started(job, 1)
+			So(err, ShouldBeNil)
+
+			jobs = []*Job{
+				{Cmd: "echo cold", Cwd: "/tmp", ReqGroup: "affinity", Requirements: req, RepGroup: "affinity", Affinity: "dataset2"},
+				{Cmd: "echo none", Cwd: "/tmp", ReqGroup: "affinity", Requirements: req, RepGroup: "affinity"},
+				{Cmd: "echo warm", Cwd: "/tmp", ReqGroup: "affinity", Requirements: req, RepGroup: "affinity", Affinity: "dataset1"},
+				{Cmd: "echo urgent", Cwd: "/tmp", ReqGroup: "affinity", Requirements: req, RepGroup: "affinity", Priority: 1},
+			}
+			_, _, err = jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+
+			var cmds []string
+			for i := 0; i < 4; i++ {
+				job, err = jq.ReserveScheduled(50*time.Millisecond, sgroup)
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				cmds = append(cmds, job.Cmd)
+			}
+			So(cmds, ShouldResemble, []string{"echo urgent", "echo warm", "echo cold", "echo none"})
+
+			Convey("Hosts are forgotten about after a while", func() {
+				at := newAffinityTracker(50 * time.Millisecond)
+				at.started("dataset1", "host1")
+				So(at.warm("host1"), ShouldResemble, map[string]bool{"dataset1": true})
+				So(at.warm("host2"), ShouldBeNil)
+				<-time.After(100 * time.Millisecond)
+				So(at.warm("host1"), ShouldBeNil)
+			})
+		})
+
+		Convey("Hosts don't get more jobs than their per-host limits allow", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo 1", Cwd: "/tmp", ReqGroup: "perhost", Requirements: req, RepGroup: "perhost", MaxPerHost: 2},
+				{Cmd: "echo 2", Cwd: "/tmp", ReqGroup: "perhost", Requirements: req, RepGroup: "perhost", MaxPerHost: 2},
+				{Cmd: "echo 3", Cwd: "/tmp", ReqGroup: "perhost", Requirements: req, RepGroup: "perhost", MaxPerHost: 2},
+			}
+			_, _, err = jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+
+			sgroup := "1024:240:1:0^2"
+			So(schedGroupToMaxPerHost(sgroup), ShouldEqual, 2)
+			So(trimSchedGroupMaxPerHost(sgroup), ShouldEqual, "1024:240:1:0")
+
+			job1, err := jq.ReserveScheduled(50*time.Millisecond, sgroup)
+			So(err, ShouldBeNil)
+			So(job1, ShouldNotBeNil)
+			So(job1.MaxPerHost, ShouldEqual, 2)
+			job2, err := jq.ReserveScheduled(50*time.Millisecond, sgroup)
+			So(err, ShouldBeNil)
+			So(job2, ShouldNotBeNil)
+			job3, err := jq.ReserveScheduled(50*time.Millisecond, sgroup)
+			So(err, ShouldBeNil)
+			So(job3, ShouldBeNil)
+
+			err = jq.Release(job1, nil, "")
+			So(err, ShouldBeNil)
+			job3, err = jq.ReserveScheduled(50*time.Millisecond, sgroup)
+			So(err, ShouldBeNil)
+			So(job3, ShouldNotBeNil)
+			So(job3.Cmd, ShouldEqual, "echo 3")
+
+			// only the jobs still on the host are kept track of
+			So(len(server.hostLimiter.runningOn(reservingHost())), ShouldEqual, 2)
+
+			Convey("Runners on the same host don't wait on each other's reservations", func() {
+				jq2, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+				So(err, ShouldBeNil)
+				defer disconnect(jq2)
+
+				started := time.Now()
+				errch := make(chan error, 1)
+				go func() {
+					_, errr := jq2.ReserveScheduled(3*time.Second, "2048:240:1:0^3")
+					errch <- errr
+				}()
+				<-time.After(200 * time.Millisecond)
+
+				err = jq.Release(job2, nil, "")
+				So(err, ShouldBeNil)
+				job, err := jq.ReserveScheduled(50*time.Millisecond, sgroup)
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(time.Since(started), ShouldBeLessThan, 2*time.Second)
+				So(<-errch, ShouldBeNil)
+			})
+
+			Convey("Limit groups can have per-host limits", func() {
+				l, err := jq.GetOrSetHostLimit("disky")
+				So(err, ShouldBeNil)
+				So(l, ShouldEqual, -1)
+				l, err = jq.GetOrSetHostLimit("disky:1")
+				So(err, ShouldBeNil)
+				So(l, ShouldEqual, 1)
+				l, err = jq.GetOrSetHostLimit("disky")
+				So(err, ShouldBeNil)
+				So(l, ShouldEqual, 1)
+
+				jobs = []*Job{
+					{Cmd: "echo disky 1", Cwd: "/tmp", ReqGroup: "perhost", Requirements: req, RepGroup: "perhost", LimitGroups: []string{"disky"}},
+					{Cmd: "echo disky 2", Cwd: "/tmp", ReqGroup: "perhost", Requirements: req, RepGroup: "perhost", LimitGroups: []string{"disky"}},
+				}
+				_, _, err = jq.Add(jobs, envVars, true)
+				So(err, ShouldBeNil)
+
+				lgroup := "1024:240:1:0~disky"
+				job, err := jq.ReserveScheduled(50*time.Millisecond, lgroup)
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				job, err = jq.ReserveScheduled(50*time.Millisecond, lgroup)
+				So(err, ShouldBeNil)
+				So(job, ShouldBeNil)
+
+				l, err = jq.GetOrSetHostLimit("disky:-1")
+				So(err, ShouldBeNil)
+				So(l, ShouldEqual, -1)
+				job, err = jq.ReserveScheduled(50*time.Millisecond, lgroup)
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+			})
+		})
+
+		Convey("You can drain a host, requeueing its running jobs", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			host := reservingHost()
+			So(host, ShouldNotBeBlank)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "trap 'exit 0' TERM; sleep 30 & wait", Cwd: "/tmp", ReqGroup: "drain", Requirements: req, RepGroup: "drain", Retries: uint8(1)},
+				{Cmd: "echo 2", Cwd: "/tmp", ReqGroup: "drain", Requirements: req, RepGroup: "drain"},
+			}
+			_, _, err = jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+
+			sgroup := "1024:240:1:0"
+			job, err := jq.ReserveScheduled(50*time.Millisecond, sgroup)
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.RepGroup, ShouldEqual, "drain")
+			untilBuried := job.UntilBuried
+
+			errch := make(chan error, 1)
+			go func() {
+				errch <- jq.Execute(job, config.RunnerExecShell)
+			}()
+			<-time.After(500 * time.Millisecond)
+
+			started := time.Now()
+			running, err := jq.DrainHost(host, 5*time.Second)
+			So(err, ShouldBeNil)
+			So(running, ShouldEqual, 1)
+
+			other, err := jq.ReserveScheduled(50*time.Millisecond, sgroup)
+			So(err, ShouldBeNil)
+			So(other, ShouldBeNil)
+
+			err = <-errch
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, FailReasonDrained)
+			So(time.Since(started), ShouldBeLessThan, 5*time.Second)
+			So(job.State, ShouldEqual, JobStateDelayed)
+			So(job.UntilBuried, ShouldEqual, untilBuried)
+
+			retrieved, err := jq.GetByEssence(job.ToEssense(), true, false)
+			So(err, ShouldBeNil)
+			So(retrieved.FailReason, ShouldEqual, FailReasonDrained)
+			So(retrieved.UntilBuried, ShouldEqual, untilBuried)
+			So(retrieved.Exited, ShouldBeTrue)
+			So(retrieved.Exitcode, ShouldEqual, 0) // the cmd's trap ran
+
+			running, err = jq.DrainHost(host, 5*time.Second)
+			So(err, ShouldBeNil)
+			So(running, ShouldEqual, 0)
+
+			err = jq.UndrainHost(host)
+			So(err, ShouldBeNil)
+			other, err = jq.ReserveScheduled(50*time.Millisecond, sgroup)
+			So(err, ShouldBeNil)
+			So(other, ShouldNotBeNil)
+		})
+
+		Convey("Clients with an outage tolerance retry requests without the server carrying them out twice", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			compressed, err := jq.CompressEnv(envVars)
+			So(err, ShouldBeNil)
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			jobs := []*Job{{Cmd: "echo outage", Cwd: "/tmp", ReqGroup: "outage", Requirements: req, RepGroup: "outage"}}
+			rid, err := uuid.NewV4()
+			So(err, ShouldBeNil)
+			cr := &clientRequest{Method: "add", Jobs: jobs, Env: compressed, RequestID: rid}
+			sr, _, err := jq.attemptRequest(context.Background(), cr)
+			So(err, ShouldBeNil)
+			So(sr.Added, ShouldEqual, 1)
+			So(sr.Existed, ShouldEqual, 0)
+
+			sr, _, err = jq.attemptRequest(context.Background(), cr)
+			So(err, ShouldBeNil)
+			So(sr.Added, ShouldEqual, 1)
+			So(sr.Existed, ShouldEqual, 0)
+
+			added, existed, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 0)
+			So(existed, ShouldEqual, 1)
+
+			Convey("Frequent runner requests aren't remembered, but ones that change things are", func() {
+				rc := newRequestCache(1 * time.Minute)
+				for _, method := range []string{"reserve", "jtouch", "jphase", "getbc"} {
+					key, _, _ := rc.key(&clientRequest{Method: method, RequestID: rid})
+					So(key, ShouldBeBlank)
+				}
+				for _, method := range []string{"getbcs", "jarchive", "add"} {
+					key, _, _ := rc.key(&clientRequest{Method: method, RequestID: rid})
+					So(key, ShouldNotBeBlank)
+				}
+			})
+
+			Convey("They reconnect if the server is briefly unavailable", func() {
+				jq.SetOutageTolerance(10 * time.Second)
+				server.Stop(true)
+
+				restarted := make(chan error, 1)
+				go func() {
+					<-time.After(1 * time.Second)
+					var errs error
+					server, _, _, errs = serve(serverConfig)
+					restarted <- errs
+				}()
+
+				jobs, err := jq.GetByRepGroup("outage", false, 0, "", false, false)
+				So(<-restarted, ShouldBeNil)
+				So(err, ShouldBeNil)
+				So(len(jobs), ShouldEqual, 0)
+			})
+
+			Convey("Reserves abandoned because the connection dropped are carried out when retried", func() {
+				sleepReq := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+				sgroup := "1024:240:1:0"
+				_, _, err = jq.Add([]*Job{{Cmd: "echo reserved", Cwd: "/tmp", ReqGroup: "outage", Requirements: sleepReq, RepGroup: "outage"}}, envVars, true)
+				So(err, ShouldBeNil)
+				job, err := jq.ReserveScheduled(50*time.Millisecond, sgroup)
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(job.Cmd, ShouldEqual, "echo reserved")
+
+				rid, err := uuid.NewV4()
+				So(err, ShouldBeNil)
+				cr := &clientRequest{Method: "reserve", SchedulerGroup: sgroup, Timeout: 10 * time.Second, RequestID: rid}
+				errch := make(chan error, 1)
+				go func() {
+					_, _, errr := jq.attemptRequest(context.Background(), cr)
+					errch <- errr
+				}()
+				<-time.After(500 * time.Millisecond)
+
+				started := time.Now()
+				errc := jq.conn.socketPool().closeSockets()
+				So(errc, ShouldBeNil)
+				So(<-errch, ShouldNotBeNil)
+				<-time.After(500 * time.Millisecond)
+
+				_, _, err = jq.Add([]*Job{{Cmd: "echo retried", Cwd: "/tmp", ReqGroup: "outage", Requirements: sleepReq, RepGroup: "outage"}}, envVars, true)
+				So(err, ShouldBeNil)
+
+				sr, _, err := jq.attemptRequest(context.Background(), cr)
+				So(err, ShouldBeNil)
+				So(sr.Job, ShouldNotBeNil)
+				So(sr.Job.Cmd, ShouldEqual, "echo retried")
+				So(time.Since(started), ShouldBeLessThan, 5*time.Second)
+			})
+
+			Convey("Runners abandon their jobs if the server is unavailable for longer than their tolerance", func() {
+				jq.SetOutageTolerance(1 * time.Second)
+				sleepReq := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+				_, _, err = jq.Add([]*Job{{Cmd: "sleep 30", Cwd: "/tmp", ReqGroup: "outage", Requirements: sleepReq, RepGroup: "outage"}}, envVars, true)
+				So(err, ShouldBeNil)
+				job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(job.Cmd, ShouldEqual, "sleep 30")
+
+				errch := make(chan error, 1)
+				go func() {
+					errch <- jq.Execute(job, config.RunnerExecShell)
+				}()
+				<-time.After(500 * time.Millisecond)
+
+				// simulate the network between us and the server going down
+				started := time.Now()
+				jq.Lock()
+				jq.args[0] = "localhost:1"
+				jq.Unlock()
+				errc := jq.conn.socketPool().closeSockets()
+				So(errc, ShouldBeNil)
+
+				err = <-errch
+				So(err, ShouldNotBeNil)
+				jqerr, ok := err.(Error)
+				So(ok, ShouldBeTrue)
+				So(jqerr.Err, ShouldEqual, FailReasonAbandoned)
+				So(time.Since(started), ShouldBeLessThan, 20*time.Second)
+			})
+		})
+
+		Convey("Jobs spooled while the server was unreachable can be added later", func() {
+			spool := filepath.Join(os.TempDir(), AppName+"_spool_test")
+			defer os.Remove(spool)
+
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			err := SpoolJobs(spool, []*Job{{Cmd: "echo spooled 1", Cwd: "/tmp", ReqGroup: "spool", Requirements: req, RepGroup: "spool"}}, envVars, true)
+			So(err, ShouldBeNil)
+			err = SpoolJobs(spool, []*Job{
+				{Cmd: "echo spooled 2", Cwd: "/tmp", ReqGroup: "spool", Requirements: req, RepGroup: "spool"},
+				{Cmd: "echo spooled 1", Cwd: "/tmp", ReqGroup: "spool", Requirements: req, RepGroup: "spool"},
+			}, envVars, true)
+			So(err, ShouldBeNil)
+
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			added, existed, err := jq.FlushSpool(spool)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+			So(existed, ShouldEqual, 1)
+
+			jobs, err := jq.GetByRepGroup("spool", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(jobs), ShouldEqual, 2)
+
+			info, err := os.Stat(spool)
+			So(err, ShouldBeNil)
+			So(info.Size(), ShouldEqual, 0)
+
+			added, existed, err = jq.FlushSpool(spool)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 0)
+			So(existed, ShouldEqual, 0)
+
+			added, existed, err = jq.FlushSpool(spool + ".missing")
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 0)
+			So(existed, ShouldEqual, 0)
+		})
+
+		Convey("Corrupt spooled records are quarantined, and the rest still added", func() {
+			spool := filepath.Join(os.TempDir(), AppName+"_spool_corrupt_test")
+			quarantine := spool + SpoolQuarantineSuffix
+			defer os.Remove(spool)
+			defer os.Remove(quarantine)
+
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			err := SpoolJobs(spool, []*Job{{Cmd: "echo spooled before", Cwd: "/tmp", ReqGroup: "spool", Requirements: req, RepGroup: "spool_corrupt"}}, envVars, true)
+			So(err, ShouldBeNil)
+
+			// a record that was only partly written before a crash
+			record, err := encodeSpoolRecord(&spooledAdd{Jobs: []*Job{{Cmd: "echo spooled truncated", Cwd: "/tmp", ReqGroup: "spool", Requirements: req, RepGroup: "spool_corrupt"}}, Env: envVars})
+			So(err, ShouldBeNil)
+			truncated := record[:len(record)/2]
+			f, err := os.OpenFile(spool, os.O_WRONLY|os.O_APPEND, 0600)
+			So(err, ShouldBeNil)
+			_, err = f.Write(truncated)
+			So(err, ShouldBeNil)
+			err = f.Close()
+			So(err, ShouldBeNil)
+
+			err = SpoolJobs(spool, []*Job{{Cmd: "echo spooled after", Cwd: "/tmp", ReqGroup: "spool", Requirements: req, RepGroup: "spool_corrupt"}}, envVars, true)
+			So(err, ShouldBeNil)
+
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			added, existed, err := jq.FlushSpool(spool)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+			So(existed, ShouldEqual, 0)
+
+			jobs, err := jq.GetByRepGroup("spool_corrupt", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(jobs), ShouldEqual, 2)
+			cmds := []string{jobs[0].Cmd, jobs[1].Cmd}
+			sort.Strings(cmds)
+			So(cmds, ShouldResemble, []string{"echo spooled after", "echo spooled before"})
+
+			quarantined, err := ioutil.ReadFile(quarantine)
+			So(err, ShouldBeNil)
+			So(quarantined, ShouldResemble, truncated)
+
+			info, err := os.Stat(spool)
+			So(err, ShouldBeNil)
+			So(info.Size(), ShouldEqual, 0)
+		})
+
+		Convey("Clients and the server know each other's versions", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+			So(jq.ServerInfo.Protocol, ShouldEqual, ProtocolVersion)
+			So(jq.ServerInfo.Version, ShouldEqual, ServerVersion)
+
+			cr := &clientRequest{Method: "getin", ClientID: jq.clientid, ClientVersion: ServerVersion, ProtocolVersion: ProtocolVersion}
+			So(server.checkClientVersion(cr), ShouldEqual, "")
+
+			cr.ClientVersion = "other"
+			So(server.checkClientVersion(cr), ShouldEqual, "")
+
+			cr.ProtocolVersion = 0
+			So(server.checkClientVersion(cr), ShouldEqual, "")
+
+			cr.ProtocolVersion = ProtocolVersion + 1
+			So(server.checkClientVersion(cr), ShouldEqual, ErrIncompatible)
+
+			cr.Method = "ping"
+			So(server.checkClientVersion(cr), ShouldEqual, "")
+		})
+
+		Convey("You can get the RepGroups of all jobs ever added", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			rgs, err := jq.GetRepGroups()
+			So(err, ShouldBeNil)
+			So(len(rgs), ShouldEqual, 0)
+
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo rg 1", Cwd: "/tmp", ReqGroup: "rgs", Requirements: req, RepGroup: "rg_b"},
+				{Cmd: "echo rg 2", Cwd: "/tmp", ReqGroup: "rgs", Requirements: req, RepGroup: "rg_a"},
+				{Cmd: "echo rg 3", Cwd: "/tmp", ReqGroup: "rgs", Requirements: req, RepGroup: "rg_b"},
+			}
+			_, _, err = jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+
+			rgs, err = jq.GetRepGroups()
+			So(err, ShouldBeNil)
+			So(rgs, ShouldResemble, []string{"rg_a", "rg_b"})
+		})
+
+		Convey("Jobs with a ReportCmd record its key=value output as Metrics", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo 42 > reads", Cwd: "/tmp", ReqGroup: "metrics", Requirements: req, Priority: 1, RepGroup: "metrics", ReportCmd: "echo reads_mapped=$(cat reads); echo not a metric; echo ' sample = a b '"},
+				{Cmd: "echo no metrics", Cwd: "/tmp", ReqGroup: "metrics", Requirements: req, RepGroup: "metrics", ReportCmd: "false"},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+
+			for i := 0; i < 2; i++ {
+				job, errr := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(errr, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(jq.Execute(job, config.RunnerExecShell), ShouldBeNil)
+			}
+
+			job, err := jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			So(job.Metrics, ShouldResemble, map[string]string{"reads_mapped": "42", "sample": "a b"})
+			So(job.MetricsString(";"), ShouldEqual, "reads_mapped=42;sample=a b")
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[1].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			So(job.Metrics, ShouldBeNil)
+
+			var buf bytes.Buffer
+			err = server.exportJobs(context.Background(), &buf, exportFormatCSV, []string{"metrics"}, JobStateComplete)
+			So(err, ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, ",reads_mapped=42;sample=a b\n")
+		})
+
+		Convey("Jobs can choose the Shell their commands run with", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo 'a | b'", Cwd: "/tmp", ReqGroup: "shell", Requirements: req, Priority: 2, RepGroup: "shell", Shell: ShellNone, ReportCmd: "echo shell=$0"},
+				{Cmd: "echo login", Cwd: "/tmp", ReqGroup: "shell", Requirements: req, Priority: 1, RepGroup: "shell", Shell: "bash -l", ReportCmd: "echo shell=$0"},
+				{Cmd: "echo 'unterminated", Cwd: "/tmp", ReqGroup: "shell", Requirements: req, RepGroup: "shell", Shell: ShellNone},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 3)
+
+			for i := 0; i < 3; i++ {
+				job, errr := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(errr, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				errr = jq.Execute(job, config.RunnerExecShell)
+				if i < 2 {
+					So(errr, ShouldBeNil)
+				} else {
+					So(errr, ShouldNotBeNil)
+				}
+			}
+
+			job, err := jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			So(job.Shell, ShouldEqual, ShellNone)
+			So(job.Metrics, ShouldResemble, map[string]string{"shell": "$0"})
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[1].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			So(job.Metrics, ShouldResemble, map[string]string{"shell": "bash"})
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[2].Key()}, true, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateBuried)
+			So(job.FailReason, ShouldEqual, FailReasonStart)
+
+			jm := NewJobModifer()
+			jm.SetShell("bash")
+			modified, err := jq.Modify([]*JobEssence{{JobKey: jobs[2].Key()}}, jm)
+			So(err, ShouldBeNil)
+			So(len(modified), ShouldEqual, 1)
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[2].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.Shell, ShouldEqual, "bash")
+		})
+
+		Convey("Jobs can control where their unique working directory is made", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			cwd, err := ioutil.TempDir("", "wr_jobqueue_test_cwd_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(cwd)
+			base := filepath.Join(cwd, "base")
+			home := filepath.Join(cwd, "home")
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo out > out.txt", Cwd: home, ReqGroup: "cwdpolicy", Requirements: req, Priority: 1, RepGroup: "cwd/policy", CwdTemplate: "{repgroup}/{key}", CwdBase: base, CwdLink: true},
+				{Cmd: "echo cleaned > out.txt", Cwd: home, ReqGroup: "cwdpolicy", Requirements: req, RepGroup: "cwdpolicy", CwdTemplate: "{key}", CwdBase: base, CwdLink: true, Behaviours: Behaviours{{When: OnExit, Do: CleanupAll}}},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+
+			for i := 0; i < 2; i++ {
+				job, errr := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(errr, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(jq.Execute(job, config.RunnerExecShell), ShouldBeNil)
+			}
+
+			job, err := jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			So(job.ActualCwd, ShouldStartWith, filepath.Join(base, "cwd_policy", jobs[0].Key()+"."))
+			So(filepath.Base(job.ActualCwd), ShouldEqual, "cwd")
+			out, err := ioutil.ReadFile(filepath.Join(job.ActualCwd, "out.txt"))
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, "out\n")
+			rel, err := filepath.Rel(base, filepath.Dir(job.ActualCwd))
+			So(err, ShouldBeNil)
+			out, err = ioutil.ReadFile(filepath.Join(home, rel, "out.txt"))
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, "out\n")
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[1].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			So(job.ActualCwd, ShouldStartWith, filepath.Join(base, jobs[1].Key()+"."))
+			_, err = os.Stat(job.ActualCwd)
+			So(os.IsNotExist(err), ShouldBeTrue)
+			entries, err := ioutil.ReadDir(home)
+			So(err, ShouldBeNil)
+			So(len(entries), ShouldEqual, 1)
+			So(entries[0].Name(), ShouldEqual, "cwd_policy")
+		})
+
+		Convey("Behaviour results are stored with jobs and recorded as events", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			start := time.Now()
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			bs := Behaviours{{When: OnSuccess, Do: Run, Arg: "echo behaved"}, {When: OnExit, Do: Run, Arg: "sleep 5", Timeout: 1 * time.Second}}
+			jobs := []*Job{{Cmd: "echo behaviours", Cwd: "/tmp", ReqGroup: "behaviours", Requirements: req, RepGroup: "behaviours", Behaviours: bs}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "was killed")
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(len(job.BehaviourResults), ShouldEqual, 2)
+			So(job.BehaviourResults[0].Output, ShouldEqual, "behaved\n")
+			So(job.BehaviourResults[0].Error, ShouldBeBlank)
+			So(job.BehaviourResults[1].Error, ShouldContainSubstring, "was killed")
+			So(job.BehaviourResults[1].Duration, ShouldBeGreaterThanOrEqualTo, 1*time.Second)
+
+			var events []*Event
+			limit := time.After(5 * time.Second)
+		EVENTS:
+			for {
+				events, err = jq.GetEvents(start, []EventType{EventTypeBehaviour}, 0)
+				So(err, ShouldBeNil)
+				if len(events) >= 2 {
+					break
+				}
+				select {
+				case <-limit:
+					break EVENTS
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+			So(len(events), ShouldEqual, 2)
+			So(events[0].Key, ShouldEqual, jobs[0].Key())
+			So(events[0].Msg, ShouldStartWith, `{"on_success":[{"run":"echo behaved"}]} took `)
+			So(events[1].Msg, ShouldContainSubstring, "was killed")
+		})
+
+		Convey("Behaviour output is redacted before it is stored", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			rrs, err := NewRedactionRules(`token=(\S+)`)
+			So(err, ShouldBeNil)
+			server.redactionRules = rrs
+			defer func() {
+				server.redactionRules = nil
+			}()
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			bs := Behaviours{{When: OnSuccess, Do: Run, Arg: "printf 'token=%s\\n' s3cr3t"}}
+			jobs := []*Job{{Cmd: "echo redact behaviours", Cwd: "/tmp", ReqGroup: "behaviours", Requirements: req, RepGroup: "behaviours", Behaviours: bs}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(len(job.BehaviourResults), ShouldEqual, 1)
+			So(job.BehaviourResults[0].Output, ShouldEqual, "token="+secretRedaction+"\n")
+		})
+
+		Convey("Behaviours can be conditional on how the cmd exited", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			bs := Behaviours{
+				{When: OnFailure, Do: Run, Arg: "echo rescued", Conditions: &BehaviourConditions{ExitCodes: []int{3}, FinalAttempt: true}},
+				{When: OnFailure, Do: Run, Arg: "echo wrong code", Conditions: &BehaviourConditions{ExitCodes: []int{4}}},
+				{When: OnExit, Do: Run, Arg: "echo wrong attempt", Conditions: &BehaviourConditions{Attempt: 2}},
+				{When: OnExit, Do: Run, Arg: "echo wrong reason", Conditions: &BehaviourConditions{FailReason: "RAM"}},
+			}
+			jobs := []*Job{{Cmd: "exit 3", Cwd: "/tmp", ReqGroup: "conditional", Requirements: req, RepGroup: "conditional", Retries: 0, Behaviours: bs}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldNotBeNil)
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateBuried)
+			So(len(job.BehaviourResults), ShouldEqual, 1)
+			So(job.BehaviourResults[0].Output, ShouldEqual, "rescued\n")
+		})
+
+		Convey("Behaviours can resubmit failed jobs with modified options", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			opts := &ResubmitOptions{RAMMultiplier: 2, RepGroup: "resubmitted", CmdReplace: map[string]string{"exit 3": "exit 0"}}
+			bs := Behaviours{{When: OnFailure, Do: Resubmit, Arg: opts, Conditions: &BehaviourConditions{ExitCodes: []int{3}}}}
+			jobs := []*Job{{Cmd: "echo resubmit && exit 3", Cwd: "/tmp", ReqGroup: "resubmit", Requirements: req, RepGroup: "resubmit", Retries: 0, Behaviours: bs}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldNotBeNil)
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job, ShouldBeNil)
+
+			resubmitted := &Job{Cmd: "echo resubmit && exit 0"}
+			job, err = jq.GetByEssence(&JobEssence{JobKey: resubmitted.Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.State, ShouldEqual, JobStateDelayed)
+			So(job.RepGroup, ShouldEqual, "resubmitted")
+			So(job.Requirements.RAM, ShouldEqual, 2048)
+			So(job.UntilBuried, ShouldEqual, 1)
+
+			rjobs, err := jq.GetByRepGroup("resubmitted", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(rjobs), ShouldEqual, 1)
+			rjobs, err = jq.GetByRepGroup("resubmit", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(rjobs), ShouldEqual, 0)
+		})
+
+		Convey("Jobs with invalid Behaviours can't be added", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			bs := Behaviours{{When: OnSuccess, Do: Run, Arg: []string{"echo", "bad"}}}
+			jobs := []*Job{{Cmd: "echo bad behaviour", Cwd: "/tmp", ReqGroup: "bad", Requirements: req, RepGroup: "bad", Behaviours: bs}}
+			_, _, err = jq.Add(jobs, envVars, true)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "needs a command string to run")
+
+			compressed, err := jq.CompressEnv(envVars)
+			So(err, ShouldBeNil)
+			_, err = jq.request(&clientRequest{Method: "add", Jobs: jobs, Env: compressed})
+			So(err, ShouldNotBeNil)
+			serr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(serr.Err, ShouldEqual, ErrBadBehaviour)
+
+			job, err := jq.GetByEssence(&JobEssence{Cmd: "echo bad behaviour"}, false, false)
+			So(err, ShouldBeNil)
+			So(job, ShouldBeNil)
+		})
+
+		Convey("Behaviours can add follow-on jobs", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_addjobs_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			bs := Behaviours{
+				{When: OnSuccess, Do: AddJobs, Arg: `[{"cmd":"echo step2a"}]`},
+				{When: OnSuccess, Do: AddJobs, Arg: "next.json"},
+				{When: OnSuccess, Do: AddJobs, Arg: "missing.json"},
+			}
+			cmd := `echo '[{"cmd":"echo step2b","rep_grp":"step2"}]' > next.json`
+			jobs := []*Job{{Cmd: cmd, Cwd: tmpdir, CwdMatters: true, ReqGroup: "addjobs", Requirements: req, RepGroup: "addjobs", Behaviours: bs}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(len(job.BehaviourResults), ShouldEqual, 3)
+			So(job.BehaviourResults[0].Output, ShouldEqual, "added 1 jobs (0 already existed)\n")
+			So(job.BehaviourResults[1].Output, ShouldEqual, "added 1 jobs (0 already existed)\n")
+			So(job.BehaviourResults[2].Output, ShouldEqual, "no jobs to add\n")
+
+			step2a, err := jq.GetByEssence(&JobEssence{Cmd: "echo step2a"}, false, false)
+			So(err, ShouldBeNil)
+			So(step2a, ShouldNotBeNil)
+			So(step2a.RepGroup, ShouldEqual, "addjobs")
+			So(step2a.Cwd, ShouldEqual, tmpdir)
+			So(len(step2a.Dependencies), ShouldEqual, 1)
+			So(step2a.Dependencies[0].Essence.JobKey, ShouldEqual, jobs[0].Key())
+			So(step2a.State, ShouldEqual, JobStateReady)
+
+			step2b, err := jq.GetByEssence(&JobEssence{Cmd: "echo step2b"}, false, false)
+			So(err, ShouldBeNil)
+			So(step2b, ShouldNotBeNil)
+			So(step2b.RepGroup, ShouldEqual, "step2")
+		})
+
+		Convey("Behaviours can copy files to the manager, taking turns", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_copy_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+			defer os.RemoveAll(config.ManagerCopyDir)
+
+			origPoll := ClientTransferPollInterval
+			defer func() {
+				ClientTransferPollInterval = origPoll
+			}()
+			ClientTransferPollInterval = 50 * time.Millisecond
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			bs := Behaviours{{When: OnSuccess, Do: CopyToManager, Arg: []string{"out.txt", "sub/b.txt"}}}
+			cmd := "echo a > out.txt && mkdir sub && echo b > sub/b.txt"
+			jobs := []*Job{{Cmd: cmd, Cwd: tmpdir, CwdMatters: true, ReqGroup: "copy", Requirements: req, RepGroup: "copy", Behaviours: bs}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+
+			So(server.transfers.request("blocker", "otherhost"), ShouldBeTrue)
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- jq.Execute(job, config.RunnerExecShell)
+			}()
+
+			var transfers []*Transfer
+			for i := 0; i < 100; i++ {
+				transfers, err = jq.GetTransfers()
+				So(err, ShouldBeNil)
+				if len(transfers) == 2 {
+					break
+				}
+				<-time.After(50 * time.Millisecond)
+			}
+			So(len(transfers), ShouldEqual, 2)
+			So(transfers[0].JobKey, ShouldEqual, "blocker")
+			So(transfers[0].State, ShouldEqual, TransferStateActive)
+			So(transfers[1].JobKey, ShouldEqual, job.Key())
+			So(transfers[1].State, ShouldEqual, TransferStateQueued)
+
+			server.transfers.release("blocker")
+			So(<-errCh, ShouldBeNil)
+
+			content, err := ioutil.ReadFile(filepath.Join(config.ManagerCopyDir, job.Key(), "out.txt"))
+			So(err, ShouldBeNil)
+			So(string(content), ShouldEqual, "a\n")
+			content, err = ioutil.ReadFile(filepath.Join(config.ManagerCopyDir, job.Key(), "sub", "b.txt"))
+			So(err, ShouldBeNil)
+			So(string(content), ShouldEqual, "b\n")
+
+			transfers, err = jq.GetTransfers()
+			So(err, ShouldBeNil)
+			So(transfers, ShouldBeEmpty)
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			So(len(job.BehaviourResults), ShouldEqual, 1)
+			So(job.BehaviourResults[0].Error, ShouldBeEmpty)
+		})
+
+		Convey("Jobs get their reference assets from the host's cache", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_refassets_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+
+			origCacheDir := RefAssetCacheDir
+			defer func() {
+				RefAssetCacheDir = origCacheDir
+			}()
+			RefAssetCacheDir = filepath.Join(tmpdir, "cache")
+
+			src := filepath.Join(tmpdir, "hello.fa")
+			err = ioutil.WriteFile(src, []byte("hello"), 0600)
+			So(err, ShouldBeNil)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			ras := []*RefAsset{{Source: src, Checksum: "md5:5d41402abc4b2a76b9719d911017c592"}}
+			badRAs := []*RefAsset{{Source: src, Checksum: "md5:00000000000000000000000000000000", Name: "bad.fa"}}
+			jobs := []*Job{
+				{Cmd: "grep hello hello.fa", Cwd: tmpdir, ReqGroup: "refassets", Requirements: req, RepGroup: "refassets", RefAssets: ras},
+				{Cmd: "cat bad.fa", Cwd: tmpdir, ReqGroup: "refassets", Requirements: req, RepGroup: "refassets", RefAssets: badRAs},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+
+			for i := 0; i < 2; i++ {
+				job, errr := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(errr, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				err = jq.Execute(job, config.RunnerExecShell)
+				if job.Cmd == jobs[0].Cmd {
+					So(err, ShouldBeNil)
+				} else {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldContainSubstring, FailReasonRefAsset)
+				}
+			}
+
+			job, err := jq.GetByEssence(&JobEssence{Cmd: jobs[0].Cmd}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+
+			job, err = jq.GetByEssence(&JobEssence{Cmd: jobs[1].Cmd}, false, false)
+			So(err, ShouldBeNil)
+			So(job.FailReason, ShouldEqual, FailReasonRefAsset)
+		})
+
+		Convey("Behaviour sets can be saved, versioned and used to add jobs", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			_, err = jq.SaveBehaviourSet("bad", `[{"cleanp":true}]`)
+			So(err, ShouldNotBeNil)
+			_, err = jq.request(&clientRequest{Method: "savebset", BehaviourSet: &BehaviourSet{Name: "bad", JSON: `[{"cleanp":true}]`}})
+			So(err, ShouldNotBeNil)
+			serr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(serr.Err, ShouldEqual, ErrBadBehaviour)
+
+			_, err = jq.GetBehaviourSet("archive", 0)
+			So(err, ShouldNotBeNil)
+			serr, ok = err.(Error)
+			So(ok, ShouldBeTrue)
+			So(serr.Err, ShouldEqual, ErrNoBehaviourSet)
+
+			bset, err := jq.SaveBehaviourSet("archive", `[{"run":"touch archived"}]`)
+			So(err, ShouldBeNil)
+			So(bset.Name, ShouldEqual, "archive")
+			So(bset.Version, ShouldEqual, 1)
+
+			bs, err := jq.ResolveBehaviours("@archive", OnSuccess)
+			So(err, ShouldBeNil)
+			So(len(bs), ShouldEqual, 1)
+			So(bs[0].When, ShouldEqual, OnSuccess)
+			So(bs[0].Arg, ShouldEqual, "touch archived")
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{{Cmd: "echo bset", Cwd: "/tmp", ReqGroup: "bset", Requirements: req, RepGroup: "bset", Behaviours: bs}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			bset, err = jq.SaveBehaviourSet("archive", `[{"run":"touch archived2"},{"cleanup":true}]`)
+			So(err, ShouldBeNil)
+			So(bset.Version, ShouldEqual, 2)
+			_, err = jq.SaveBehaviourSet("other", `[{"cleanup_all":true}]`)
+			So(err, ShouldBeNil)
+
+			bs, err = jq.ResolveBehaviours("@archive", OnFailure)
+			So(err, ShouldBeNil)
+			So(len(bs), ShouldEqual, 2)
+			So(bs[0].Arg, ShouldEqual, "touch archived2")
+
+			bs, err = jq.ResolveBehaviours("@archive:1", OnFailure)
+			So(err, ShouldBeNil)
+			So(len(bs), ShouldEqual, 1)
+			So(bs[0].Arg, ShouldEqual, "touch archived")
+
+			_, err = jq.ResolveBehaviours("@archive:3", OnFailure)
+			So(err, ShouldNotBeNil)
+
+			bsets, err := jq.ListBehaviourSets()
+			So(err, ShouldBeNil)
+			So(len(bsets), ShouldEqual, 2)
+			So(bsets[0].Name, ShouldEqual, "archive")
+			So(bsets[0].Version, ShouldEqual, 2)
+			So(bsets[1].Name, ShouldEqual, "other")
+
+			job, err := jq.GetByEssence(&JobEssence{Cmd: "echo bset"}, false, false)
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(len(job.Behaviours), ShouldEqual, 1)
+			So(job.Behaviours[0].Arg, ShouldEqual, "touch archived")
+
+			err = jq.DeleteBehaviourSet("archive")
+			So(err, ShouldBeNil)
+			_, err = jq.GetBehaviourSet("archive", 1)
+			So(err, ShouldNotBeNil)
+			err = jq.DeleteBehaviourSet("archive")
+			So(err, ShouldNotBeNil)
+			bsets, err = jq.ListBehaviourSets()
+			So(err, ShouldBeNil)
+			So(len(bsets), ShouldEqual, 1)
+
+			_, err = jq.Delete([]*JobEssence{{Cmd: "echo bset"}})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Jobs can be run politely", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_polite_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{{Cmd: "sleep 0.5; echo nice=$(cut -d' ' -f19 /proc/$$/stat) adj=$(cat /proc/$$/oom_score_adj) > polite.out", Cwd: tmpdir, CwdMatters: true, ReqGroup: "polite", Requirements: req, RepGroup: "polite", Nice: 5}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			defer func() {
+				DefaultPoliteness = Politeness{}
+			}()
+			DefaultPoliteness = Politeness{Nice: 10, OOMScoreAdj: 200}
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Nice, ShouldEqual, 5)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			out, err := ioutil.ReadFile(filepath.Join(tmpdir, "polite.out"))
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, "nice=5 adj=200\n")
+
+			jm := NewJobModifer()
+			jm.SetIONice("best-effort:7")
+			jm.SetOOMScoreAdj(500)
+			jobs = []*Job{{Cmd: "echo polite mod", Cwd: "/tmp", ReqGroup: "polite", Requirements: req, RepGroup: "polite"}}
+			_, _, err = jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			modified, err := jq.Modify([]*JobEssence{{JobKey: jobs[0].Key()}}, jm)
+			So(err, ShouldBeNil)
+			So(len(modified), ShouldEqual, 1)
+			job, err = jq.GetByEssence(&JobEssence{Cmd: "echo polite mod"}, false, false)
+			So(err, ShouldBeNil)
+			So(job.IONice, ShouldEqual, "best-effort:7")
+			So(job.OOMScoreAdj, ShouldEqual, 500)
+		})
+
+		Convey("Atomic groups succeed or fail together", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			var jobs []*Job
+			for i := 1; i <= 3; i++ {
+				jobs = append(jobs, &Job{Cmd: fmt.Sprintf("echo atom%d", i), Cwd: "/tmp", ReqGroup: "atomic", Requirements: req, RepGroup: "atomic", AtomicGroup: "scatter", Priority: uint8(10 - i), Override: 2})
+			}
+			jobs = append(jobs, &Job{Cmd: "echo atom4", Cwd: "/tmp", ReqGroup: "atomic", Requirements: req, RepGroup: "atomic", AtomicGroup: "scatter", Priority: 6, Override: 2, Dependencies: Dependencies{NewEssenceDependency("echo atom3", "")}})
+			jobs = append(jobs, &Job{Cmd: "echo gather", Cwd: "/tmp", ReqGroup: "atomic", Requirements: req, RepGroup: "atomic", Dependencies: Dependencies{NewEssenceDependency("echo atom1", "")}})
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 5)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Cmd, ShouldEqual, "echo atom1")
+			So(job.AtomicGroup, ShouldEqual, "scatter")
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+
+			job, err = jq.GetByEssence(&JobEssence{Cmd: "echo gather"}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateDependent)
+
+			job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Cmd, ShouldEqual, "echo atom2")
+			err = jq.Started(job, 123)
+			So(err, ShouldBeNil)
+			err = jq.Bury(job, nil, FailReasonExit)
+			So(err, ShouldBeNil)
+
+			job, err = jq.GetByEssence(&JobEssence{Cmd: "echo atom3"}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateBuried)
+			So(job.FailReason, ShouldEqual, FailReasonAtomic)
+
+			// members still waiting on other members are cancelled too
+			job, err = jq.GetByEssence(&JobEssence{Cmd: "echo atom4"}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateBuried)
+			So(job.FailReason, ShouldEqual, FailReasonAtomic)
+
+			job, err = jq.GetByEssence(&JobEssence{Cmd: "echo atom1"}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			So(job.AtomicGroupFailed, ShouldBeTrue)
+
+			job, err = jq.GetByEssence(&JobEssence{Cmd: "echo gather"}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateDependent)
+
+			job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldBeNil)
+
+			Convey("But dependants start once every member completes", func() {
+				kicked, errk := jq.Kick([]*JobEssence{{Cmd: "echo atom2"}, {Cmd: "echo atom3"}, {Cmd: "echo atom4"}})
+				So(errk, ShouldBeNil)
+				So(kicked, ShouldEqual, 3)
+
+				job, err = jq.GetByEssence(&JobEssence{Cmd: "echo atom4"}, false, false)
+				So(err, ShouldBeNil)
+				So(job.State, ShouldEqual, JobStateDependent)
+
+				for i := 0; i < 3; i++ {
+					job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+					So(err, ShouldBeNil)
+					So(job, ShouldNotBeNil)
+					So(job.AtomicGroup, ShouldEqual, "scatter")
+					err = jq.Execute(job, config.RunnerExecShell)
+					So(err, ShouldBeNil)
+				}
+
+				job, err = jq.GetByEssence(&JobEssence{Cmd: "echo gather"}, false, false)
+				So(err, ShouldBeNil)
+				So(job.State, ShouldEqual, JobStateReady)
+			})
+		})
+
+		Convey("Jobs with missing input files are buried before running", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_inputs_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+			present := filepath.Join(tmpdir, "present")
+			err = ioutil.WriteFile(present, []byte("in"), 0600)
+			So(err, ShouldBeNil)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo missing input", Cwd: tmpdir, CwdMatters: true, ReqGroup: "inputs", Requirements: req, RepGroup: "inputs", InputFiles: []string{"present", "absent"}, Override: 2},
+				{Cmd: "echo present input", Cwd: "/tmp", ReqGroup: "inputs", Requirements: req, RepGroup: "inputs", InputFiles: []string{present}, Override: 2},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Cmd, ShouldEqual, "echo present input")
+			So(job.InputFiles, ShouldResemble, []string{present})
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateBuried)
+			So(job.FailReason, ShouldEqual, FailReasonInput)
+			So(job.Attempts, ShouldEqual, 0)
+
+			job = &Job{Cmd: "echo runner input", Cwd: "/tmp", ReqGroup: "inputs", Requirements: req, RepGroup: "inputs", InputFiles: []string{filepath.Join(tmpdir, "absent")}, InputCheckOnRunner: true, Override: 2}
+			added, _, err = jq.Add([]*Job{job}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Cmd, ShouldEqual, "echo runner input")
+			So(job.InputCheckOnRunner, ShouldBeTrue)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, FailReasonInput)
+
+			job, err = jq.GetByEssence(&JobEssence{Cmd: "echo runner input"}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateBuried)
+			So(job.FailReason, ShouldEqual, FailReasonInput)
+		})
+
+		Convey("Jobs that need unavailable network access fail before running", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_network_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			So(err, ShouldBeNil)
+			defer ln.Close()
+			open := ln.Addr().String()
+			closedLn, err := net.Listen("tcp", "127.0.0.1:0")
+			So(err, ShouldBeNil)
+			closed := closedLn.Addr().String()
+			closedLn.Close()
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo $https_proxy > proxy", Cwd: tmpdir, CwdMatters: true, ReqGroup: "network", Requirements: req, RepGroup: "network", NetworkAccess: []string{NetworkInternet}, Proxy: "http://" + open, Priority: 2, Override: 2},
+				{Cmd: "touch ran", Cwd: tmpdir, CwdMatters: true, ReqGroup: "network", Requirements: req, RepGroup: "network", NetworkAccess: []string{open, closed}, Priority: 1, Override: 2},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Proxy, ShouldEqual, "http://"+open)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+			content, err := ioutil.ReadFile(filepath.Join(tmpdir, "proxy"))
+			So(err, ShouldBeNil)
+			So(string(content), ShouldEqual, "http://"+open+"\n")
+
+			job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.NetworkAccess, ShouldResemble, []string{open, closed})
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, FailReasonNetwork)
+			So(err.Error(), ShouldContainSubstring, closed)
+			So(job.Exited, ShouldBeFalse)
+			_, err = os.Stat(filepath.Join(tmpdir, "ran"))
+			So(os.IsNotExist(err), ShouldBeTrue)
+
+			job, err = jq.GetByEssence(&JobEssence{Cmd: "touch ran", Cwd: tmpdir}, false, false)
+			So(err, ShouldBeNil)
+			So(job.FailReason, ShouldEqual, FailReasonNetwork)
+		})
+
+		Convey("Jobs whose expected outputs are missing or invalid fail", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_outputs_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "touch empty", Cwd: tmpdir, CwdMatters: true, ReqGroup: "outputs", Requirements: req, RepGroup: "outputs", OutputFiles: []string{"empty"}, Priority: 3, Override: 2},
+				{Cmd: "echo good > good", Cwd: tmpdir, CwdMatters: true, ReqGroup: "outputs", Requirements: req, RepGroup: "outputs", OutputFiles: []string{"good"}, OutputCheckCmd: `grep -q good "$WR_OUTPUT"`, Priority: 2, Override: 2},
+				{Cmd: "echo bad > bad", Cwd: tmpdir, CwdMatters: true, ReqGroup: "outputs", Requirements: req, RepGroup: "outputs", OutputFiles: []string{"bad"}, OutputMinSize: 2, OutputCheckCmd: `grep -q good "$WR_OUTPUT"`, Priority: 1, Override: 2},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 3)
+
+			problems := map[string]string{
+				"touch empty":      "empty is 0 bytes, smaller than 1",
+				"echo good > good": "",
+				"echo bad > bad":   "bad failed its check",
+			}
+			for _, cmd := range []string{"touch empty", "echo good > good", "echo bad > bad"} {
+				job, errr := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(errr, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(job.Cmd, ShouldEqual, cmd)
+				errr = jq.Execute(job, config.RunnerExecShell)
+				So(job.Exited, ShouldBeTrue)
+				So(job.Exitcode, ShouldEqual, 0)
+				if problems[cmd] == "" {
+					So(errr, ShouldBeNil)
+					So(job.State, ShouldEqual, JobStateComplete)
+					continue
+				}
+				So(errr, ShouldNotBeNil)
+				So(errr.Error(), ShouldContainSubstring, FailReasonOutput)
+				So(job.State, ShouldNotEqual, JobStateComplete)
+				So(job.FailReason, ShouldEqual, FailReasonOutput)
+				stderr, errr := job.StdErr()
+				So(errr, ShouldBeNil)
+				So(stderr, ShouldContainSubstring, problems[cmd])
+			}
+		})
+
+		Convey("Jobs can record the sizes and checksums of their outputs", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_checksums_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{{Cmd: "printf hello > out", Cwd: tmpdir, CwdMatters: true, ReqGroup: "checksums", Requirements: req, RepGroup: "checksums", OutputFiles: []string{"out"}, OutputChecksums: true, Override: 2}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.OutputChecksums, ShouldBeTrue)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+
+			expected := []OutputRecord{{Path: filepath.Join(tmpdir, "out"), Size: 5, MD5: "5d41402abc4b2a76b9719d911017c592"}}
+			So(job.OutputRecords, ShouldResemble, expected)
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			So(job.OutputRecords, ShouldResemble, expected)
+		})
+
+		Convey("Cacheable jobs are completed from previous runs with the same inputs", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_cache_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+			input := filepath.Join(tmpdir, "in")
+			err = ioutil.WriteFile(input, []byte("hello"), 0600)
+			So(err, ShouldBeNil)
+			runs := filepath.Join(tmpdir, "runs")
+
+			timesRun := func() int {
+				content, errr := ioutil.ReadFile(runs)
+				So(errr, ShouldBeNil)
+				return strings.Count(string(content), "ran\n")
+			}
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			newJob := func(repGroup string) *Job {
+				return &Job{Cmd: "cat " + input + " && echo ran >> " + runs, Cwd: "/tmp", ReqGroup: "cache", Requirements: req, RepGroup: repGroup, InputFiles: []string{input}, Cacheable: true, Override: 2}
+			}
+			jobs := []*Job{newJob("cache1")}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Cacheable, ShouldBeTrue)
+			So(job.CachedInputChecksums, ShouldBeNil)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+			So(timesRun(), ShouldEqual, 1)
+
+			sums := map[string]string{input: "5d41402abc4b2a76b9719d911017c592"}
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateComplete)
+			So(job.Cached, ShouldBeFalse)
+			So(job.InputChecksums, ShouldResemble, sums)
+			endTime := job.EndTime
+
+			Convey("By the runner, which doesn't run the cmd if the inputs are unchanged", func() {
+				token, err := NewAddToken()
+				So(err, ShouldBeNil)
+				// with a unique working dir that should not be left behind, and
+				// a behaviour that should still be triggered
+				base := filepath.Join(tmpdir, "base")
+				home := filepath.Join(tmpdir, "home")
+				behaved := filepath.Join(tmpdir, "behaved")
+				again := newJob("cache2")
+				again.Cwd = home
+				again.CwdBase = base
+				again.CwdLink = true
+				again.Behaviours = Behaviours{{When: OnSuccess, Do: Run, Arg: "echo behaved > " + behaved}}
+				added, existed, results, err := jq.AddWithToken([]*Job{again}, envVars, true, token)
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 1)
+				So(existed, ShouldEqual, 0)
+				So(results[0].Outcome, ShouldEqual, AddOutcomeAdded)
+
+				job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(job.RepGroup, ShouldEqual, "cache2")
+				So(job.CachedInputChecksums, ShouldResemble, sums)
+				err = jq.Execute(job, config.RunnerExecShell)
+				So(err, ShouldBeNil)
+				So(timesRun(), ShouldEqual, 1)
+
+				So(job.ActualCwd, ShouldStartWith, base)
+				_, err = os.Stat(job.ActualCwd)
+				So(os.IsNotExist(err), ShouldBeTrue)
+				entries, err := ioutil.ReadDir(base)
+				So(err, ShouldBeNil)
+				So(len(entries), ShouldEqual, 0)
+				entries, err = ioutil.ReadDir(home)
+				if err == nil {
+					So(len(entries), ShouldEqual, 0)
+				}
+				content, err := ioutil.ReadFile(behaved)
+				So(err, ShouldBeNil)
+				So(string(content), ShouldEqual, "behaved\n")
+
+				cached, err := jq.GetByRepGroup("cache2", false, 0, "", false, false)
+				So(err, ShouldBeNil)
+				So(len(cached), ShouldEqual, 1)
+				So(cached[0].State, ShouldEqual, JobStateComplete)
+				So(cached[0].Cached, ShouldBeTrue)
+				So(cached[0].Exitcode, ShouldEqual, 0)
+				So(cached[0].EndTime.Equal(endTime), ShouldBeTrue)
+				So(cached[0].InputChecksums, ShouldResemble, sums)
+			})
+
+			Convey("But not if their inputs changed", func() {
+				added, _, err = jq.Add([]*Job{newJob("cache3")}, envVars, true)
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 1)
+
+				err = ioutil.WriteFile(input, []byte("changed"), 0600)
+				So(err, ShouldBeNil)
+
+				job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(job.RepGroup, ShouldEqual, "cache3")
+				err = jq.Execute(job, config.RunnerExecShell)
+				So(err, ShouldBeNil)
+				So(timesRun(), ShouldEqual, 2)
+
+				job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+				So(err, ShouldBeNil)
+				So(job.State, ShouldEqual, JobStateComplete)
+				So(job.Cached, ShouldBeFalse)
+				So(job.InputChecksums[input], ShouldNotEqual, sums[input])
+			})
+
+			Convey("And the manager doesn't accept checksums that differ from the previous run", func() {
+				added, _, err = jq.Add([]*Job{newJob("cache5")}, envVars, true)
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 1)
+
+				job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				err = jq.archiveCached(job, map[string]string{input: "wrong"})
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, ErrBadRequest)
+
+				err = jq.Release(job, nil, "")
+				So(err, ShouldBeNil)
+				removed, err := jq.Delete([]*JobEssence{job.ToEssense()})
+				So(err, ShouldBeNil)
+				So(removed, ShouldEqual, 1)
+			})
+
+			Convey("Or if they're not cacheable", func() {
+				job := newJob("cache4")
+				job.Cacheable = false
+				added, existed, err := jq.Add([]*Job{job}, envVars, true)
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 0)
+				So(existed, ShouldEqual, 1)
+
+				jobs, err := jq.GetByRepGroup("cache4", false, 0, "", false, false)
+				So(err, ShouldBeNil)
+				So(len(jobs), ShouldEqual, 0)
+			})
+
+			Convey("Jobs without inputs or dependencies are completed immediately", func() {
+				noInputs := &Job{Cmd: "echo ran >> " + runs, Cwd: "/tmp", ReqGroup: "cache", Requirements: req, RepGroup: "cache6", Cacheable: true, Override: 2}
+				added, _, err = jq.Add([]*Job{noInputs}, envVars, true)
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 1)
+
+				job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				err = jq.Execute(job, config.RunnerExecShell)
+				So(err, ShouldBeNil)
+				So(timesRun(), ShouldEqual, 2)
+
+				again := &Job{Cmd: noInputs.Cmd, Cwd: "/tmp", ReqGroup: "cache", Requirements: req, RepGroup: "cache7", Cacheable: true, Override: 2}
+				token, err := NewAddToken()
+				So(err, ShouldBeNil)
+				added, existed, results, err := jq.AddWithToken([]*Job{again}, envVars, true, token)
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 0)
+				So(existed, ShouldEqual, 1)
+				So(results[0].Outcome, ShouldEqual, AddOutcomeCached)
+
+				cached, err := jq.GetByRepGroup("cache7", false, 0, "", false, false)
+				So(err, ShouldBeNil)
+				So(len(cached), ShouldEqual, 1)
+				So(cached[0].State, ShouldEqual, JobStateComplete)
+				So(cached[0].Cached, ShouldBeTrue)
+				So(timesRun(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("The server records events that can be queried", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			start := time.Now()
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{{Cmd: "echo events", Cwd: "/tmp", ReqGroup: "events", Requirements: req, RepGroup: "events_rg"}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			err = jq.Bury(job, nil, "testing events")
+			So(err, ShouldBeNil)
+			kicked, err := jq.Kick([]*JobEssence{job.ToEssense()})
+			So(err, ShouldBeNil)
+			So(kicked, ShouldEqual, 1)
+
+			getEvents := func(types []EventType, expected int) []*Event {
+				var events []*Event
+				limit := time.After(5 * time.Second)
+				for {
+					events, err = jq.GetEvents(start, types, 0)
+					So(err, ShouldBeNil)
+					if len(events) >= expected {
+						break
+					}
+					select {
+					case <-limit:
+						return events
+					case <-time.After(10 * time.Millisecond):
+					}
+				}
+				return events
+			}
+
+			events := getEvents([]EventType{EventTypeBury}, 1)
+			So(len(events), ShouldEqual, 1)
+			So(events[0].Key, ShouldEqual, job.Key())
+			So(events[0].RepGroup, ShouldEqual, "events_rg")
+			So(events[0].Msg, ShouldEqual, "testing events")
+
+			events = getEvents([]EventType{EventTypeAdd, EventTypeRetry}, 2)
+			So(len(events), ShouldEqual, 2)
+			So(events[0].Type, ShouldEqual, EventTypeAdd)
+			So(events[0].Count, ShouldEqual, 1)
+			So(events[1].Type, ShouldEqual, EventTypeRetry)
+			So(events[1].Time.Before(events[0].Time), ShouldBeFalse)
+
+			events, err = jq.GetEvents(start, []EventType{EventTypeAdd, EventTypeRetry}, 1)
+			So(err, ShouldBeNil)
+			So(len(events), ShouldEqual, 1)
+			So(events[0].Type, ShouldEqual, EventTypeRetry)
+		})
+
+		Convey("Past state counts can be reconstructed from the event log", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			before := time.Now()
+			<-time.After(10 * time.Millisecond)
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{{Cmd: "echo history", Cwd: "/tmp", ReqGroup: "history", Requirements: req, RepGroup: "history_rg"}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+			<-time.After(10 * time.Millisecond)
+			whenReady := time.Now()
+			<-time.After(10 * time.Millisecond)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			<-time.After(10 * time.Millisecond)
+			whenRunning := time.Now()
+			<-time.After(10 * time.Millisecond)
+			err = jq.Bury(job, nil, "testing history")
+			So(err, ShouldBeNil)
+
+			countsAt := func(at time.Time) map[JobState]int {
+				counts, errg := jq.GetStateCountsAt(at)
+				So(errg, ShouldBeNil)
+				return counts["history_rg"]
+			}
+
+			limit := time.After(5 * time.Second)
+		WAIT:
+			for countsAt(time.Now())[JobStateBuried] != 1 {
+				select {
+				case <-limit:
+					break WAIT
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+
+			So(countsAt(time.Now()), ShouldResemble, map[JobState]int{JobStateBuried: 1})
+			So(countsAt(whenRunning), ShouldResemble, map[JobState]int{JobStateRunning: 1})
+			So(countsAt(whenReady), ShouldResemble, map[JobState]int{JobStateReady: 1})
+			So(countsAt(before), ShouldBeNil)
+
+			events, err := jq.GetEvents(before, nil, 0)
+			So(err, ShouldBeNil)
+			for _, event := range events {
+				So(event.Type, ShouldNotEqual, EventTypeStateChange)
+			}
+		})
+
+		Convey("RepGroup budgets raise alarms and pause the RepGroup once exceeded", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			_, err = jq.SetBudget(&Budget{RepGroup: "budget_rg", Cost: 1})
+			So(err, ShouldNotBeNil)
+
+			start := time.Now()
+			budget, err := jq.SetBudget(&Budget{RepGroup: "budget_rg", CPUHours: 0.0001, Pause: true})
+			So(err, ShouldBeNil)
+			So(budget.Alarm, ShouldEqual, BudgetAlarmNone)
+			So(budget.Since.IsZero(), ShouldBeFalse)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			var jobs []*Job
+			for i := 0; i < 3; i++ {
+				jobs = append(jobs, &Job{Cmd: fmt.Sprintf("sleep 0.5 && echo budget %d", i), Cwd: "/tmp", ReqGroup: "budget", Requirements: req, RepGroup: "budget_rg"})
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 3)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.RepGroup, ShouldEqual, "budget_rg")
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+
+			var buried []*Job
+			limit := time.After(5 * time.Second)
+		WAIT:
+			for {
+				buried, err = jq.GetByRepGroup("budget_rg", false, 0, JobStateBuried, false, false)
+				So(err, ShouldBeNil)
+				if len(buried) == 2 {
+					break
+				}
+				select {
+				case <-limit:
+					break WAIT
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+			So(len(buried), ShouldEqual, 2)
+			So(buried[0].FailReason, ShouldEqual, FailReasonBudget)
+
+			budgets, err := jq.GetBudgets()
+			So(err, ShouldBeNil)
+			So(len(budgets), ShouldEqual, 1)
+			So(budgets[0].Alarm, ShouldEqual, BudgetAlarmExceeded)
+			So(budgets[0].UsedCPUHours, ShouldBeGreaterThanOrEqualTo, 0.0001)
+
+			events, err := jq.GetEvents(start, []EventType{EventTypeBudget}, 0)
+			So(err, ShouldBeNil)
+			So(len(events), ShouldEqual, 1)
+			So(events[0].RepGroup, ShouldEqual, "budget_rg")
+
+			kicked, err := jq.Kick([]*JobEssence{buried[0].ToEssense(), buried[1].ToEssense()})
+			So(err, ShouldBeNil)
+			So(kicked, ShouldEqual, 2)
+			for i := 0; i < 100; i++ {
+				buried, err = jq.GetByRepGroup("budget_rg", false, 0, JobStateBuried, false, false)
+				So(err, ShouldBeNil)
+				if len(buried) == 2 {
+					break
+				}
+				<-time.After(10 * time.Millisecond)
+			}
+			So(len(buried), ShouldEqual, 2)
+
+			err = jq.DeleteBudget("budget_rg")
+			So(err, ShouldBeNil)
+			err = jq.DeleteBudget("budget_rg")
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrNoBudget)
+
+			kicked, err = jq.Kick([]*JobEssence{buried[0].ToEssense(), buried[1].ToEssense()})
+			So(err, ShouldBeNil)
+			So(kicked, ShouldEqual, 2)
+			<-time.After(100 * time.Millisecond)
+			ready, err := jq.GetByRepGroup("budget_rg", false, 0, JobStateReady, false, false)
+			So(err, ShouldBeNil)
+			So(len(ready), ShouldEqual, 2)
+			removed, err := jq.Delete([]*JobEssence{ready[0].ToEssense(), ready[1].ToEssense()})
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 2)
+		})
+
+		Convey("RepGroup start rates delay jobs that would start too quickly", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			_, err = jq.SetStartRate(&StartRate{RepGroup: "rate_rg", Starts: 0, Interval: time.Second})
+			So(err, ShouldNotBeNil)
+
+			rate, err := jq.SetStartRate(&StartRate{RepGroup: "rate_rg", Starts: 1, Interval: 2 * time.Second, Ramp: 2})
+			So(err, ShouldBeNil)
+			So(rate.Starts, ShouldEqual, 1)
+
+			rates, err := jq.GetStartRates()
+			So(err, ShouldBeNil)
+			So(len(rates), ShouldEqual, 1)
+			So(rates[0], ShouldResemble, rate)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			var jobs []*Job
+			for i := 0; i < 4; i++ {
+				jobs = append(jobs, &Job{Cmd: fmt.Sprintf("echo rate %d", i), Cwd: "/tmp", ReqGroup: "rate", Requirements: req, RepGroup: "rate_rg"})
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 4)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.RepGroup, ShouldEqual, "rate_rg")
+			reserved := []*Job{job}
+
+			job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldBeNil)
+
+			delayed, err := jq.GetByRepGroup("rate_rg", false, 0, JobStateDelayed, false, false)
+			So(err, ShouldBeNil)
+			So(len(delayed), ShouldEqual, 3)
+
+			// the ramp allows 2 in the next interval
+			limit := time.After(4 * time.Second)
+		WAIT:
+			for len(reserved) < 3 {
+				job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(err, ShouldBeNil)
+				if job != nil {
+					reserved = append(reserved, job)
+					continue
+				}
+				select {
+				case <-limit:
+					break WAIT
+				case <-time.After(50 * time.Millisecond):
+				}
+			}
+			So(len(reserved), ShouldEqual, 3)
+			job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldBeNil)
+
+			err = jq.DeleteStartRate("rate_rg")
+			So(err, ShouldBeNil)
+			err = jq.DeleteStartRate("rate_rg")
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrNoStartRate)
+
+			jes := make([]*JobEssence, len(jobs))
+			for i, j := range jobs {
+				jes[i] = j.ToEssense()
+			}
+			for _, j := range reserved {
+				err = jq.Release(j, nil, "")
+				So(err, ShouldBeNil)
+			}
+			removed, err := jq.Delete(jes)
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 4)
+		})
+
+		Convey("Limit groups in feedback mode have their limit tuned by a probe", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			_, err = jq.SetLimitFeedback(&LimitFeedback{Group: "fb", Probe: "false"})
+			So(err, ShouldNotBeNil)
+
+			start := time.Now()
+			f, err := jq.SetLimitFeedback(&LimitFeedback{Group: "fb", Probe: "false", Interval: time.Second, Min: 1, Max: 8})
+			So(err, ShouldBeNil)
+			So(f.Timeout, ShouldEqual, LimitFeedbackTimeout)
+			So(f.LastProbe.IsZero(), ShouldBeTrue)
+
+			var limit int
+			for i := 0; i < 300; i++ {
+				limit, err = jq.GetOrSetLimitGroup("fb")
+				So(err, ShouldBeNil)
+				if limit == 4 {
+					break
+				}
+				<-time.After(10 * time.Millisecond)
+			}
+			So(limit, ShouldEqual, 4)
+
+			fbs, err := jq.GetLimitFeedback()
+			So(err, ShouldBeNil)
+			So(len(fbs), ShouldEqual, 1)
+			So(fbs[0].Healthy, ShouldBeFalse)
+			So(fbs[0].Problem, ShouldContainSubstring, "exit status 1")
+			So(fbs[0].Limit, ShouldEqual, 4)
+
+			events, err := jq.GetEvents(start, []EventType{EventTypeLimit}, 0)
+			So(err, ShouldBeNil)
+			So(len(events), ShouldBeGreaterThanOrEqualTo, 1)
+			So(events[0].Msg, ShouldStartWith, "limit of fb lowered from unlimited to 4; probe unhealthy")
+
+			err = jq.DeleteLimitFeedback("fb")
+			So(err, ShouldBeNil)
+			err = jq.DeleteLimitFeedback("fb")
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrNoLimitFeedback)
+
+			<-time.After(1500 * time.Millisecond)
+			limit, err = jq.GetOrSetLimitGroup("fb")
+			So(err, ShouldBeNil)
+			So(limit, ShouldEqual, 4)
+			_, err = jq.GetOrSetLimitGroup("fb:-1")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("The working directories of jobs that fail for good can be archived", func() {
+			archiveDir, err := ioutil.TempDir("", "wr_jobqueue_test_failarchive_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(archiveDir)
+			server.failArchive = &dirFailArchiveStore{dir: archiveDir}
+			server.ServerInfo.ArchiveFailures = true
+			defer func() {
+				server.failArchive = nil
+				server.ServerInfo.ArchiveFailures = false
+			}()
+
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+			So(jq.ServerInfo.ArchiveFailures, ShouldBeTrue)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_failarchive_cwd_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			bs := Behaviours{{When: OnFailure, Do: CleanupAll}}
+			jobs := []*Job{{Cmd: "echo evidence > clue.txt && false", Cwd: tmpdir, ReqGroup: "failarchive", Requirements: req, RepGroup: "failarchive", Retries: 0, Behaviours: bs}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldNotBeNil)
+
+			job, err = jq.GetByEssence(&JobEssence{JobKey: jobs[0].Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(job.State, ShouldEqual, JobStateBuried)
+			So(job.FailureArchive, ShouldEqual, filepath.Join(archiveDir, job.Key()+failArchiveExt))
+			_, err = os.Stat(job.ActualCwd)
+			So(os.IsNotExist(err), ShouldBeTrue)
+
+			f, err := os.Open(job.FailureArchive)
+			So(err, ShouldBeNil)
+			entries, err := tarEntries(f)
+			So(err, ShouldBeNil)
+			f.Close()
+			var clue string
+			for name, content := range entries {
+				if filepath.Base(name) == "clue.txt" {
+					clue = content
+				}
+			}
+			So(clue, ShouldEqual, "evidence\n")
+
+			private := httptest.NewServer(http.HandlerFunc(restFailArchive(server)))
+			defer private.Close()
+			resp, err := http.Get(private.URL + "?job=" + job.Key())
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusUnauthorized)
+			resp.Body.Close()
+
+			resp, err = http.Get(private.URL + "?job=" + job.Key() + "&token=" + string(token))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(resp.Header.Get("Content-Type"), ShouldEqual, "application/gzip")
+			downloaded, err := tarEntries(resp.Body)
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(downloaded, ShouldResemble, entries)
+
+			resp, err = http.Get(private.URL + "?job=" + jobs[0].Key() + "x&token=" + string(token))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+			resp.Body.Close()
+		})
+
+		Convey("Notes can be left on jobs and RepGroups", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			user, err := internal.Username()
+			So(err, ShouldBeNil)
+			user += " (unverified)"
+
+			start := time.Now()
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo note1", Cwd: "/tmp", ReqGroup: "note", Requirements: req, RepGroup: "note_rg"},
+				{Cmd: "echo note2", Cwd: "/tmp", ReqGroup: "note", Requirements: req, RepGroup: "note_rg"},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+			key1, key2 := jobs[0].Key(), jobs[1].Key()
+
+			_, err = jq.AnnotateJob("nonexistent", "a note")
+			So(err, ShouldNotBeNil)
+			_, err = jq.AnnotateJob(key1, "")
+			So(err, ShouldNotBeNil)
+
+			a, err := jq.AnnotateJob(key1, "rerun after fixing ref")
+			So(err, ShouldBeNil)
+			So(a.Key, ShouldEqual, key1)
+			So(a.User, ShouldEqual, user)
+			So(a.Time, ShouldHappenOnOrAfter, start)
+
+			_, err = jq.AnnotateRepGroup("note_rg", "the whole group")
+			So(err, ShouldBeNil)
+
+			notes, err := jq.GetAnnotations([]string{key1}, []string{"note_rg"})
+			So(err, ShouldBeNil)
+			So(len(notes), ShouldEqual, 2)
+			So(notes[0].Note, ShouldEqual, "rerun after fixing ref")
+			So(notes[1].Note, ShouldEqual, "the whole group")
+			So(notes[1].RepGroup, ShouldEqual, "note_rg")
+
+			notes, err = jq.GetAnnotations([]string{key2}, nil)
+			So(err, ShouldBeNil)
+			So(notes, ShouldBeEmpty)
+
+			jobNotes := server.jobAnnotations(key2, "note_rg")
+			So(len(jobNotes), ShouldEqual, 1)
+			So(jobNotes[0].Note, ShouldEqual, "the whole group")
+
+			var events []*Event
+			deadline := time.Now().Add(5 * time.Second)
+			for {
+				events, err = jq.GetEvents(start, []EventType{EventTypeAnnotate}, 0)
+				So(err, ShouldBeNil)
+				if len(events) >= 2 || time.Now().After(deadline) {
+					break
+				}
+				<-time.After(10 * time.Millisecond)
+			}
+			So(len(events), ShouldEqual, 2)
+			So(events[0].Key, ShouldEqual, key1)
+			So(events[0].RepGroup, ShouldEqual, "note_rg")
+			So(events[0].User, ShouldEqual, user)
+			So(events[0].Msg, ShouldEqual, "rerun after fixing ref")
+			So(events[1].Key, ShouldBeEmpty)
+			So(events[1].RepGroup, ShouldEqual, "note_rg")
+
+			removed, err := jq.Delete([]*JobEssence{jobs[0].ToEssense(), jobs[1].ToEssense()})
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 2)
+		})
+
+		Convey("Incomplete jobs can be exported and imported again", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			parent := &Job{Cmd: "echo wrx parent", Cwd: "/tmp", ReqGroup: "wrx", Requirements: req, RepGroup: "wrx", Override: 2, Retries: 5}
+			child := &Job{Cmd: "echo wrx child", Cwd: "/tmp", ReqGroup: "wrx", Requirements: req, RepGroup: "wrx",
+				Dependencies: Dependencies{NewEssenceDependency("echo wrx parent", "")}}
+			other := &Job{Cmd: "echo wrx other", Cwd: "/tmp", ReqGroup: "wrx", Requirements: req, RepGroup: "wrx_other"}
+			added, _, err := jq.Add([]*Job{parent, child}, []string{"WRX_TEST=parent"}, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+			added, _, err = jq.Add([]*Job{other}, []string{"WRX_TEST=other"}, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			var buf bytes.Buffer
+			n, err := jq.ExportJobs(&buf, "wrx", false)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 2)
+
+			var all bytes.Buffer
+			n, err = jq.ExportJobs(&all, "", false)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 3)
+
+			removed, err := jq.Delete([]*JobEssence{child.ToEssense(), parent.ToEssense(), other.ToEssense()})
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 3)
+
+			_, _, err = jq.ImportJobs(bytes.NewReader([]byte("junk")))
+			So(err, ShouldNotBeNil)
+
+			added, existed, err := jq.ImportJobs(&all)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 3)
+			So(existed, ShouldEqual, 0)
+
+			got, err := jq.GetByEssence(parent.ToEssense(), false, true)
+			So(err, ShouldBeNil)
+			So(got, ShouldNotBeNil)
+			So(got.State, ShouldEqual, JobStateReady)
+			So(got.Override, ShouldEqual, 2)
+			So(got.Retries, ShouldEqual, 5)
+			So(got.Getenv("WRX_TEST"), ShouldEqual, "parent")
+
+			got, err = jq.GetByEssence(child.ToEssense(), false, true)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateDependent)
+			So(got.Getenv("WRX_TEST"), ShouldEqual, "parent")
+
+			got, err = jq.GetByEssence(other.ToEssense(), false, true)
+			So(err, ShouldBeNil)
+			So(got.Getenv("WRX_TEST"), ShouldEqual, "other")
+
+			added, existed, err = jq.ImportJobs(&buf)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 0)
+			So(existed, ShouldEqual, 2)
+		})
+
+		Convey("Inconsistencies between the db and queue can be found and repaired", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			var jobs []*Job
+			for _, name := range []string{"a", "b", "c", "d"} {
+				jobs = append(jobs, &Job{Cmd: "echo fsck " + name, Cwd: "/tmp", ReqGroup: "fsck", Requirements: req, RepGroup: "fsck"})
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 4)
+			keyA, keyB, keyC, keyD := jobs[0].Key(), jobs[1].Key(), jobs[2].Key(), jobs[3].Key()
+
+			report, err := jq.Fsck(false)
+			So(err, ShouldBeNil)
+			So(report.Problems(), ShouldEqual, 0)
+
+			// a is lost from the live bucket, leaving its lookup stale
+			err = server.db.deleteLiveJobs([]string{keyA})
+			So(err, ShouldBeNil)
+
+			// b depends on a job that is gone, and can't be found by RepGroup
+			item, err := server.q.Get(keyB)
+			So(err, ShouldBeNil)
+			err = server.q.Update(keyB, item.ReserveGroup, item.Data(), jobs[1].Priority, 0*time.Second, server.itemTTR, []string{"gone"})
+			So(err, ShouldBeNil)
+			server.rpl.Lock()
+			delete(server.rpl.lookup["fsck"], keyB)
+			server.rpl.Unlock()
+
+			// c is lost from the queue
+			err = server.q.Remove(keyC)
+			So(err, ShouldBeNil)
+
+			// d completed, but was left in the live bucket
+			err = server.db.archiveJob(keyD, jobs[3])
+			So(err, ShouldBeNil)
+			err = server.q.Remove(keyD)
+			So(err, ShouldBeNil)
+			err = server.db.modifyLiveJobs([]string{keyD}, []*Job{jobs[3]})
+			So(err, ShouldBeNil)
+
+			expected := func(report *FsckReport) {
+				So(report.Unstored, ShouldResemble, []string{keyA})
+				So(report.BadDependencies, ShouldResemble, []string{keyB})
+				So(report.Unindexed, ShouldResemble, []string{keyB})
+				So(report.Unqueued, ShouldResemble, []string{keyC})
+				So(report.CompleteLive, ShouldResemble, []string{keyD})
+				So(report.StaleLookups, ShouldEqual, 1)
+				So(report.Problems(), ShouldEqual, 6)
+			}
+
+			report, err = jq.Fsck(false)
+			So(err, ShouldBeNil)
+			expected(report)
+			So(report.Repaired, ShouldBeFalse)
+
+			report, err = jq.Fsck(true)
+			So(err, ShouldBeNil)
+			expected(report)
+			So(report.Repaired, ShouldBeTrue)
+
+			report, err = jq.Fsck(false)
+			So(err, ShouldBeNil)
+			So(report.Problems(), ShouldEqual, 0)
+
+			live, err := server.db.checkIfLive(keyA)
+			So(err, ShouldBeNil)
+			So(live, ShouldBeTrue)
+			live, err = server.db.checkIfLive(keyD)
+			So(err, ShouldBeNil)
+			So(live, ShouldBeFalse)
+
+			// a, b and c are all ready to run
+			got, err := jq.GetByRepGroup("fsck", false, 0, JobStateReady, false, false)
+			So(err, ShouldBeNil)
+			So(len(got), ShouldEqual, 3)
+
+			// jobs part-way through being archived are not stored again
+			jobE := &Job{Cmd: "echo fsck e", Cwd: "/tmp", ReqGroup: "fsck", Requirements: req, RepGroup: "fsck_archiving"}
+			added, _, err = jq.Add([]*Job{jobE}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+			keyE := jobE.Key()
+			item, err = server.q.Get(keyE)
+			So(err, ShouldBeNil)
+			queuedE := item.Data().(*Job)
+			queuedE.Lock()
+			queuedE.State = JobStateComplete
+			queuedE.Unlock()
+			err = server.db.archiveJob(keyE, queuedE)
+			So(err, ShouldBeNil)
+
+			report, err = jq.Fsck(false)
+			So(err, ShouldBeNil)
+			So(report.Problems(), ShouldEqual, 0)
+
+			err = server.storeUnstoredJobs([]*Job{queuedE})
+			So(err, ShouldBeNil)
+			live, err = server.db.checkIfLive(keyE)
+			So(err, ShouldBeNil)
+			So(live, ShouldBeFalse)
+
+			queuedE.Lock()
+			queuedE.State = JobStateReady
+			queuedE.Unlock()
+			err = server.storeUnstoredJobs([]*Job{queuedE})
+			So(err, ShouldBeNil)
+			live, err = server.db.checkIfLive(keyE)
+			So(err, ShouldBeNil)
+			So(live, ShouldBeFalse)
+		})
+
+		Convey("Jobs can have scheduler args, which must be valid", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			jvj := &JobViaJSON{Cmd: "echo args", SchedulerArgs: map[string]string{"lsf": "-P myproject", "openstack": ""}}
+			job, err := jvj.Convert(&JobDefaults{SchedulerArgs: map[string]string{"lsf": "-P default"}})
+			So(err, ShouldBeNil)
+			So(job.Requirements.Other[jqs.ArgsOtherKeyPrefix+"lsf"], ShouldEqual, "-P myproject")
+			_, found := job.Requirements.Other[jqs.ArgsOtherKeyPrefix+"openstack"]
+			So(found, ShouldBeFalse)
+
+			jvj = &JobViaJSON{Cmd: "echo args"}
+			_, err = jvj.Convert(&JobDefaults{SchedulerArgs: map[string]string{"lsf": "-q yesterday"}})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, ErrBadSchedulerArgs)
+
+			badReq := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1, Other: map[string]string{jqs.ArgsOtherKeyPrefix + "local": "foo"}}
+			_, _, err = jq.Add([]*Job{{Cmd: "echo bad args", Cwd: "/tmp", ReqGroup: "args", Requirements: badReq, RepGroup: "args"}}, envVars, true)
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrBadSchedulerArgs)
+
+			job.Cwd = "/tmp"
+			job.RepGroup = "args"
+			added, _, err := jq.Add([]*Job{job}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			got, err := jq.GetByEssence(&JobEssence{Cmd: "echo args"}, false, false)
+			So(err, ShouldBeNil)
+			So(got.Requirements.Other[jqs.ArgsOtherKeyPrefix+"lsf"], ShouldEqual, "-P myproject")
+		})
+
+		Convey("Jobs can only be run as permitted users", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			runAsJob := func(cmd, runAs string) *Job {
+				return &Job{Cmd: cmd, Cwd: "/tmp", ReqGroup: "runas", Requirements: req, RepGroup: "runas", RunAs: runAs}
+			}
+
+			for _, runAs := range []string{"root", "#0", "someoneelse"} {
+				_, _, err = jq.Add([]*Job{runAsJob("echo runas "+runAs, runAs)}, envVars, true)
+				So(err, ShouldNotBeNil)
+				jqerr, ok := err.(Error)
+				So(ok, ShouldBeTrue)
+				So(jqerr.Err, ShouldEqual, ErrBadRunAs)
+			}
+
+			// clients say who they are, so a client claiming to be the RunAs
+			// user doesn't get to run jobs as them
+			jq.user = "someoneelse"
+			_, _, err = jq.Add([]*Job{runAsJob("echo runas spoofed", "someoneelse")}, envVars, true)
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrBadRunAs)
+
+			added, _, err := jq.Add([]*Job{runAsJob("echo runas spoofed", "")}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+			jm := NewJobModifer()
+			jm.SetRunAs("someoneelse")
+			_, err = jq.Modify([]*JobEssence{{Cmd: "echo runas spoofed"}}, jm)
+			So(err, ShouldNotBeNil)
+			jqerr, ok = err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrBadRunAs)
+
+			server.runAsUsers["someoneelse"] = true
+			server.runAsUsers["root"] = true
+			defer func() {
+				delete(server.runAsUsers, "someoneelse")
+				delete(server.runAsUsers, "root")
+			}()
+			added, _, err = jq.Add([]*Job{runAsJob("echo runas someoneelse", "someoneelse")}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+			_, _, err = jq.Add([]*Job{runAsJob("echo runas root", "root")}, envVars, true)
+			So(err, ShouldNotBeNil)
+
+			addToken, err := NewAddToken()
+			So(err, ShouldBeNil)
+			_, _, results, err := jq.AddWithToken([]*Job{runAsJob("echo runas ok", ""), runAsJob("echo runas bad", "root")}, envVars, true, addToken)
+			So(err, ShouldBeNil)
+			So(len(results), ShouldEqual, 2)
+			So(results[0].Outcome, ShouldEqual, AddOutcomeAdded)
+			So(results[1].Outcome, ShouldEqual, AddOutcomeInvalid)
+			So(results[1].Reason, ShouldContainSubstring, ErrBadRunAs)
+
+			jm = NewJobModifer()
+			jm.SetRunAs("root")
+			_, err = jq.Modify([]*JobEssence{{Cmd: "echo runas ok"}}, jm)
+			So(err, ShouldNotBeNil)
+			jqerr, ok = err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrBadRunAs)
+		})
+
+		Convey("Jobs are traced when there is a trace endpoint", func() {
+			var smutex sync.Mutex
+			var spans []otlpSpan
+			collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var export otlpExport
+				if err := json.NewDecoder(r.Body).Decode(&export); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				smutex.Lock()
+				defer smutex.Unlock()
+				for _, rs := range export.ResourceSpans {
+					for _, ss := range rs.ScopeSpans {
+						spans = append(spans, ss.Spans...)
+					}
+				}
+			}))
+			defer collector.Close()
+			server.tracer = newTracer(collector.URL, "development", "localhost", server.Logger)
+
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			_, _, err = jq.Add([]*Job{{Cmd: "echo bad trace", Cwd: "/tmp", ReqGroup: "traced", Requirements: standardReqs, RepGroup: "traced", TraceID: "abc"}}, envVars, true)
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrBadTraceID)
+
+			tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_trace_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(tmpdir)
+			tpFile := filepath.Join(tmpdir, "traceparent")
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			traceID := NewTraceID()
+			jobs := []*Job{
+				{Cmd: "echo -n $TRACEPARENT > " + tpFile, Cwd: "/tmp", ReqGroup: "traced", Requirements: req, RepGroup: "traced", TraceID: traceID},
+				{Cmd: "echo untraced", Cwd: "/tmp", ReqGroup: "traced", Requirements: req, RepGroup: "traced"},
+			}
+			_, _, err = jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+
+			job, err := jq.GetByEssence(&JobEssence{Cmd: "echo untraced"}, false, false)
+			So(err, ShouldBeNil)
+			So(ValidTraceID(job.TraceID), ShouldBeTrue)
+			So(job.TraceID, ShouldNotEqual, traceID)
+
+			job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Cmd, ShouldStartWith, "echo -n $TRACEPARENT")
+			So(job.TraceID, ShouldEqual, traceID)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+
+			tp, err := ioutil.ReadFile(tpFile)
+			So(err, ShouldBeNil)
+			parts := strings.Split(string(tp), "-")
+			So(len(parts), ShouldEqual, 4)
+			So(parts[1], ShouldEqual, traceID)
+
+			traced := func() map[string]otlpSpan {
+				server.tracer.flush()
+				smutex.Lock()
+				defer smutex.Unlock()
+				byName := make(map[string]otlpSpan)
+				for _, span := range spans {
+					if span.TraceID == traceID {
+						byName[span.Name] = span
+					}
+				}
+				return byName
+			}
+			var byName map[string]otlpSpan
+			limit := time.After(5 * time.Second)
+		WAIT:
+			for {
+				byName = traced()
+				if _, ok := byName["schedule"]; ok {
+					break
+				}
+				select {
+				case <-time.After(50 * time.Millisecond):
+				case <-limit:
+					break WAIT
+				}
+			}
+
+			root, ok := byName["job"]
+			So(ok, ShouldBeTrue)
+			So(root.ParentSpanID, ShouldBeBlank)
+			So(root.SpanID, ShouldEqual, traceSpanID(job.Key(), "job", 0))
+			for _, name := range []string{"ready", "reserved", "running", "schedule"} {
+				span, ok := byName[name]
+				So(ok, ShouldBeTrue)
+				So(span.ParentSpanID, ShouldEqual, root.SpanID)
+				So(span.Status.Code, ShouldEqual, 0)
+			}
+			So(byName["running"].SpanID, ShouldEqual, parts[2])
+			So(byName["schedule"].Kind, ShouldEqual, traceKindClient)
+		})
+
+		Convey("Client middleware wraps every request", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			var calls []string
+			jq.Use(func(ctx context.Context, call *ClientCall, next ClientInvoker) error {
+				calls = append(calls, "outer "+call.Method)
+				return next(ctx, call)
+			}, func(ctx context.Context, call *ClientCall, next ClientInvoker) error {
+				calls = append(calls, "inner "+call.Method+" "+strings.Join(call.Keys, ","))
+				err := next(ctx, call)
+				if err != nil {
+					return err
+				}
+				return next(ctx, call)
+			})
+
+			jobs := []*Job{{Cmd: "echo middleware", Cwd: "/tmp", ReqGroup: "mw", Requirements: standardReqs, RepGroup: "mw"}}
+			inserts, already, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(inserts, ShouldEqual, 1)
+			So(already, ShouldEqual, 0)
+
+			job, err := jq.GetByEssence(&JobEssence{Cmd: "echo middleware"}, false, false)
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+
+			So(calls, ShouldResemble, []string{"outer add", "inner add ", "outer getbc", "inner getbc " + job.Key()})
+
+			Convey("Including those of copies, and middleware can stop requests or replace their context", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				jq.WithContext(context.Background()).Use(func(ctx context.Context, call *ClientCall, next ClientInvoker) error {
+					if call.Method == "jdel" {
+						return nil
+					}
+					if len(call.Keys) > 0 {
+						return next(ctx, call)
+					}
+					return next(context.Background(), call)
+				})
+
+				calls = nil
+				_, err = jq.WithContext(ctx).GetIncomplete(0, "", false, false)
+				So(err, ShouldBeNil)
+				So(calls, ShouldResemble, []string{"outer getin", "inner getin "})
+
+				_, err = jq.Delete([]*JobEssence{{JobKey: job.Key()}})
+				So(err, ShouldNotBeNil)
+				jqerr, ok := err.(Error)
+				So(ok, ShouldBeTrue)
+				So(jqerr.Err, ShouldEqual, ErrNotRequested)
+			})
+		})
+
+		Convey("Clients can be used concurrently, and their requests cancelled", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			n := ClientPoolSize * 2
+			errs := make(chan error, n*2)
+			var wg sync.WaitGroup
+			for i := 0; i < n; i++ {
+				wg.Add(2)
+				go func(i int) {
+					defer wg.Done()
+					job := &Job{Cmd: fmt.Sprintf("echo pool %d", i), Cwd: "/tmp", ReqGroup: "pool", Requirements: req, RepGroup: "pool"}
+					_, _, erra := jq.Add([]*Job{job}, envVars, true)
+					errs <- erra
+				}(i)
+				go func() {
+					defer wg.Done()
+					_, errp := jq.Ping(clientConnectTime)
+					errs <- errp
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				So(err, ShouldBeNil)
+			}
+			jobs, err := jq.GetByRepGroup("pool", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(jobs), ShouldEqual, n)
+
+			// (a client's first reserve returns immediately if the group has no
+			// jobs, so get that out of the way)
+			_, err = jq.ReserveScheduled(1*time.Millisecond, "nonexistent")
+			So(err, ShouldBeNil)
+			reserved := make(chan error, 1)
+			go func() {
+				_, errr := jq.ReserveScheduled(1*time.Second, "nonexistent")
+				reserved <- errr
+			}()
+			<-time.After(100 * time.Millisecond)
+			started := time.Now()
+			_, err = jq.Ping(clientConnectTime)
+			So(err, ShouldBeNil)
+			So(time.Since(started), ShouldBeLessThan, 1*time.Second)
+			So(<-reserved, ShouldBeNil)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			started = time.Now()
+			job, err := jq.WithContext(ctx).ReserveScheduled(5*time.Second, "nonexistent")
+			So(err == context.DeadlineExceeded, ShouldBeTrue)
+			So(job, ShouldBeNil)
+			So(time.Since(started), ShouldBeLessThan, 1*time.Second)
+
+			ctx, cancel = context.WithCancel(context.Background())
+			cancel()
+			_, err = jq.WithContext(ctx).Ping(clientConnectTime)
+			So(err == context.Canceled, ShouldBeTrue)
+
+			_, err = jq.Ping(clientConnectTime)
+			So(err, ShouldBeNil)
+
+			var jes []*JobEssence
+			for _, job := range jobs {
+				jes = append(jes, job.ToEssense())
+			}
+			removed, err := jq.Delete(jes)
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, n)
+		})
+
+		Convey("The server stops working on requests that time out or whose client goes away", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1025, Time: 4 * time.Hour, Cores: 1}
+			job := &Job{Cmd: "echo abandoned", Cwd: "/tmp", ReqGroup: "abandoned", Requirements: req, RepGroup: "abandoned"}
+
+			_, err = jq.ReserveScheduled(1*time.Millisecond, "1025:240:1:0")
+			So(err, ShouldBeNil)
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			_, err = jq.WithContext(ctx).ReserveScheduled(5*time.Second, "1025:240:1:0")
+			So(err == context.DeadlineExceeded, ShouldBeTrue)
+			<-time.After(100 * time.Millisecond)
+
+			added, _, err := jq.Add([]*Job{job}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+			<-time.After(100 * time.Millisecond)
+			jobs, err := jq.GetByRepGroup("abandoned", false, 0, JobStateReady, false, false)
+			So(err, ShouldBeNil)
+			So(len(jobs), ShouldEqual, 1)
+
+			atomic.StoreInt64(&server.requestTimeout, int64(1*time.Nanosecond))
+			_, err = jq.GetByRepGroup("abandoned", false, 0, "", false, false)
+			atomic.StoreInt64(&server.requestTimeout, 0)
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrRequestAbandoned)
+
+			removed, err := jq.Delete([]*JobEssence{jobs[0].ToEssense()})
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 1)
+		})
+
+		Convey("Jobs can be found by cmd and cwd using keys that can be derived without the server", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			mcs := MountConfigs{{Targets: []MountTarget{{Path: "bucket"}}}}
+			jobs := []*Job{
+				{Cmd: "echo keyed", Cwd: "/tmp", CwdMatters: true, ReqGroup: "keyed", Requirements: req, RepGroup: "keyed"},
+				{Cmd: "echo keyed", Cwd: "/tmp/other", ReqGroup: "keyed", Requirements: req, RepGroup: "keyed", MountConfigs: mcs},
+			}
+			So(JobKey("echo keyed", "/tmp", nil), ShouldEqual, jobs[0].Key())
+			So(JobKey("echo keyed", "", mcs), ShouldEqual, jobs[1].Key())
+			So(JobKey("echo keyed", "/tmp", mcs), ShouldNotEqual, jobs[1].Key())
+
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+
+			job, err := jq.GetByCmd("echo keyed", "/tmp", nil, false, false)
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Key(), ShouldEqual, jobs[0].Key())
+
+			job, err = jq.GetByCmd("echo keyed", "/tmp/other", mcs, false, false)
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Key(), ShouldEqual, JobKey("echo keyed", "", mcs))
+			So(job.Cwd, ShouldEqual, "/tmp/other")
+
+			job, err = jq.GetByCmd("echo keyed", "", mcs, false, false)
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Key(), ShouldEqual, jobs[1].Key())
+
+			job, err = jq.GetByCmd("echo keyed", "", nil, false, false)
+			So(err, ShouldBeNil)
+			So(job, ShouldBeNil)
+
+			job, err = jq.GetByCmd("echo unkeyed", "/tmp", nil, false, false)
+			So(err, ShouldBeNil)
+			So(job, ShouldBeNil)
+
+			removed, err := jq.Delete([]*JobEssence{jobs[0].ToEssense(), jobs[1].ToEssense()})
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 2)
+		})
+
+		Convey("Resubmitting a batch of jobs with the same add token returns the original results", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			existing := &Job{Cmd: "echo tokened 0", Cwd: "/tmp", ReqGroup: "tokened", Requirements: req, RepGroup: "tokened"}
+			added, _, err := jq.Add([]*Job{existing}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			newBatch := func() []*Job {
+				var jobs []*Job
+				for i := 0; i < 3; i++ {
+					jobs = append(jobs, &Job{Cmd: fmt.Sprintf("echo tokened %d", i), Cwd: "/tmp", ReqGroup: "tokened", Requirements: req, RepGroup: "tokened"})
+				}
+				return jobs
+			}
+
+			_, _, _, err = jq.AddWithToken(newBatch(), envVars, true, "")
+			So(err, ShouldNotBeNil)
+
+			addToken, err := NewAddToken()
+			So(err, ShouldBeNil)
+			added, existed, results, err := jq.AddWithToken(newBatch(), envVars, true, addToken)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+			So(existed, ShouldEqual, 1)
+			So(len(results), ShouldEqual, 3)
+			So(results[0].Key, ShouldEqual, existing.Key())
+			So(results[0].Outcome, ShouldEqual, AddOutcomeExisted)
+			So(results[1].Outcome, ShouldEqual, AddOutcomeAdded)
+			So(results[2].Outcome, ShouldEqual, AddOutcomeAdded)
+
+			jq2, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq2)
+			added, existed, results2, err := jq2.AddWithToken(newBatch(), envVars, true, addToken)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+			So(existed, ShouldEqual, 1)
+			So(len(results2), ShouldEqual, 3)
+			for i, result := range results2 {
+				So(*result, ShouldResemble, *results[i])
+			}
+
+			jobs, err := jq.GetByRepGroup("tokened", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(jobs), ShouldEqual, 3)
+
+			_, _, _, err = jq.AddWithToken(newBatch()[:2], envVars, true, addToken)
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrAddTokenReused)
+
+			Convey("A token whose batch failed to be added can be used again", func() {
+				failToken, err := NewAddToken()
+				So(err, ShouldBeNil)
+				_, _, _, err = jq.AddWithToken(nil, envVars, true, failToken)
+				So(err, ShouldNotBeNil)
+
+				good := &Job{Cmd: "echo tokened good", Cwd: "/tmp", ReqGroup: "tokened", Requirements: req, RepGroup: "tokened"}
+				added, _, results, err := jq.AddWithToken([]*Job{good}, envVars, true, failToken)
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 1)
+				So(len(results), ShouldEqual, 1)
+				So(results[0].Outcome, ShouldEqual, AddOutcomeAdded)
+			})
+
+			jobs, err = jq.GetByRepGroup("tokened", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			removed, err := jq.Delete(jobsToJobEssenses(jobs))
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, len(jobs))
+		})
+
+		Convey("Adding a batch with invalid jobs adds the others and reports each job's outcome", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			newJob := func(cmd string) *Job {
+				return &Job{Cmd: cmd, Cwd: "/tmp", ReqGroup: "partial", Requirements: req, RepGroup: "partial"}
+			}
+			live := newJob("echo partial live")
+			added, _, err := jq.Add([]*Job{live}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			badLimit := newJob("echo partial bad limit")
+			badLimit.LimitGroups = []string{"partial:x"}
+			badBehaviour := newJob("echo partial bad behaviour")
+			badBehaviour.Behaviours = Behaviours{{When: 0, Do: Nothing}}
+			noReqs := newJob("echo partial no reqs")
+			noReqs.Requirements = nil
+			jobs := []*Job{newJob("echo partial new"), badLimit, live, badBehaviour, newJob("echo partial new"), noReqs}
+
+			addToken, err := NewAddToken()
+			So(err, ShouldBeNil)
+			added, existed, results, err := jq.AddWithToken(jobs, envVars, true, addToken)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+			So(existed, ShouldEqual, 2)
+			So(len(results), ShouldEqual, 6)
+			So(results[0].Outcome, ShouldEqual, AddOutcomeAdded)
+			So(results[1].Outcome, ShouldEqual, AddOutcomeInvalid)
+			So(results[1].Reason, ShouldContainSubstring, ErrBadLimitGroup)
+			So(results[2].Outcome, ShouldEqual, AddOutcomeExisted)
+			So(results[3].Outcome, ShouldEqual, AddOutcomeInvalid)
+			So(results[3].Reason, ShouldContainSubstring, ErrBadBehaviour)
+			So(results[4].Outcome, ShouldEqual, AddOutcomeExisted)
+			So(results[5].Outcome, ShouldEqual, AddOutcomeInvalid)
+			So(results[5].Reason, ShouldEqual, "no resource requirements")
+			for i, result := range results {
+				So(result.Key, ShouldEqual, jobs[i].Key())
+			}
+
+			got, err := jq.GetByRepGroup("partial", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(got), ShouldEqual, 2)
+
+			_, _, err = jq.Add([]*Job{badLimit}, envVars, true)
+			So(err, ShouldNotBeNil)
+
+			removed, err := jq.Delete(jobsToJobEssenses(got))
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 2)
+		})
+
+		Convey("Jobs can be added to named queues with their own settings", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			_, err = jq.SetQueueConfig(&QueueConfig{Name: "bad name"})
+			So(err, ShouldNotBeNil)
+			_, err = jq.SetQueueConfig(&QueueConfig{Name: "analysis", Limit: -1})
+			So(err, ShouldNotBeNil)
+
+			qc, err := jq.SetQueueConfig(&QueueConfig{Name: "analysis", Limit: 1, SchedulerQueue: "long"})
+			So(err, ShouldBeNil)
+			So(qc.Name, ShouldEqual, "analysis")
+			So(server.limiter.GetLimit("queue.analysis"), ShouldEqual, 1)
+
+			qcs, err := jq.GetQueueConfigs()
+			So(err, ShouldBeNil)
+			So(len(qcs), ShouldEqual, 1)
+			So(qcs[0].SchedulerQueue, ShouldEqual, "long")
+
+			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
+			short := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1, Other: map[string]string{"scheduler_queue": "short"}}
+			jobs := []*Job{
+				{Cmd: "echo analysis 1", Cwd: "/tmp", ReqGroup: "queues", Requirements: req, RepGroup: "queues", Queue: "analysis"},
+				{Cmd: "echo analysis 2", Cwd: "/tmp", ReqGroup: "queues", Requirements: short, RepGroup: "queues", Queue: "analysis", LimitGroups: []string{"other"}},
+				{Cmd: "echo qc 1", Cwd: "/tmp", ReqGroup: "queues", Requirements: req, RepGroup: "queues", Queue: "qc"},
+				{Cmd: "echo default 1", Cwd: "/tmp", ReqGroup: "queues", Requirements: req, RepGroup: "queues"},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 4)
+
+			_, _, err = jq.Add([]*Job{{Cmd: "echo bad queue", Cwd: "/tmp", ReqGroup: "queues", Requirements: req, RepGroup: "queues", Queue: "bad,queue"}}, envVars, true)
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrBadQueue)
+
+			_, err = jq.GetIncompleteInQueue("", 0, "", false, false)
+			So(err, ShouldNotBeNil)
+
+			got, err := jq.GetIncompleteInQueue("analysis", 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(got), ShouldEqual, 2)
+			byCmd := make(map[string]*Job)
+			for _, job := range got {
+				So(job.Queue, ShouldEqual, "analysis")
+				byCmd[job.Cmd] = job
+			}
+			So(byCmd["echo analysis 1"].LimitGroups, ShouldResemble, []string{"queue.analysis"})
+			So(byCmd["echo analysis 1"].Requirements.Other["scheduler_queue"], ShouldEqual, "long")
+			So(byCmd["echo analysis 2"].LimitGroups, ShouldResemble, []string{"other", "queue.analysis"})
+			So(byCmd["echo analysis 2"].Requirements.Other["scheduler_queue"], ShouldEqual, "short")
+
+			got, err = jq.GetIncompleteInQueue("qc", 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(got), ShouldEqual, 1)
+			So(got[0].Cmd, ShouldEqual, "echo qc 1")
+			So(got[0].LimitGroups, ShouldResemble, []string{"queue.qc"})
+			So(got[0].Requirements.Other["scheduler_queue"], ShouldBeEmpty)
+
+			err = jq.DeleteQueueConfig("analysis")
+			So(err, ShouldBeNil)
+			So(server.limiter.GetLimit("queue.analysis"), ShouldEqual, -1)
+			qcs, err = jq.GetQueueConfigs()
+			So(err, ShouldBeNil)
+			So(len(qcs), ShouldEqual, 0)
+
+			err = jq.DeleteQueueConfig("analysis")
+			So(err, ShouldNotBeNil)
+			jqerr, ok = err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrNoQueue)
+
+			got, err = jq.GetByRepGroup("queues", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			removed, err := jq.Delete(jobsToJobEssenses(got))
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 4)
+		})
+
+		Convey("Runners can report that jobs are staging or uploading", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{{Cmd: "echo phases", Cwd: "/tmp", ReqGroup: "phases", Requirements: req, RepGroup: "phases"}}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Cmd, ShouldEqual, "echo phases")
+
+			err = jq.reportPhase(job, JobStateReady)
+			So(err, ShouldNotBeNil)
+
+			err = jq.reportPhase(job, JobStateStaging)
+			So(err, ShouldBeNil)
+			got, err := jq.GetByEssence(&JobEssence{Cmd: "echo phases"}, false, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateStaging)
+			So(server.liveStateCounts()["phases"][JobStateStaging], ShouldEqual, 1)
+
+			got2, err := jq.GetByRepGroup("phases", false, 0, JobStateStaging, false, false)
+			So(err, ShouldBeNil)
+			So(len(got2), ShouldEqual, 1)
+			got2, err = jq.GetByRepGroup("phases", false, 0, JobStateRunning, false, false)
+			So(err, ShouldBeNil)
+			So(len(got2), ShouldEqual, 1)
+			got2, err = jq.GetByRepGroup("phases", false, 0, JobStateUploading, false, false)
+			So(err, ShouldBeNil)
+			So(len(got2), ShouldEqual, 0)
+
+			err = jq.Started(job, 123)
+			So(err, ShouldBeNil)
+			got, err = jq.GetByEssence(&JobEssence{Cmd: "echo phases"}, false, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateRunning)
+
+			err = jq.reportPhase(job, JobStateUploading)
+			So(err, ShouldBeNil)
+			got, err = jq.GetByEssence(&JobEssence{Cmd: "echo phases"}, false, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateUploading)
+			got2, err = jq.GetByRepGroup("phases", false, 0, JobStateUploading, false, false)
+			So(err, ShouldBeNil)
+			So(len(got2), ShouldEqual, 1)
+
+			err = jq.Release(job, &JobEndState{Exited: true, Exitcode: 1}, FailReasonExit)
+			So(err, ShouldBeNil)
+			got, err = jq.GetByEssence(&JobEssence{Cmd: "echo phases"}, false, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldNotEqual, JobStateUploading)
+
+			item, err := server.q.Get(job.Key())
+			So(err, ShouldBeNil)
+			sjob := item.Data().(*Job)
+			sjob.RLock()
+			So(sjob.phase, ShouldBeEmpty)
+			sjob.RUnlock()
+
+			removed, err := jq.Delete([]*JobEssence{{Cmd: "echo phases"}})
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 1)
+		})
+
+		Convey("The server records who retried, removed, killed or paused jobs", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			user, err := internal.Username()
+			So(err, ShouldBeNil)
+			user += " (unverified)"
+
+			start := time.Now()
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo audit1", Cwd: "/tmp", ReqGroup: "audit", Requirements: req, RepGroup: "audit_rg"},
+				{Cmd: "echo audit2", Cwd: "/tmp", ReqGroup: "audit", Requirements: req, RepGroup: "audit_rg"},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+
+			job, err := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			err = jq.Bury(job, nil, "testing audit")
+			So(err, ShouldBeNil)
+			kicked, err := jq.Kick([]*JobEssence{job.ToEssense()})
+			So(err, ShouldBeNil)
+			So(kicked, ShouldEqual, 1)
+			retried := job.Key()
+
+			got, err := jq.GetByEssence(job.ToEssense(), false, false)
+			So(err, ShouldBeNil)
+			So(got.LastAction, ShouldNotBeNil)
+			So(got.LastAction.Action, ShouldEqual, EventTypeRetry)
+			So(got.LastAction.User, ShouldEqual, user)
+			So(got.LastAction.String(), ShouldEqual, "retried by "+user+" at "+got.LastAction.Time.Format("15:04"))
+
+			job, err = jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			killed, err := jq.Kill([]*JobEssence{job.ToEssense()})
+			So(err, ShouldBeNil)
+			So(killed, ShouldEqual, 1)
+
+			got, err = jq.GetByEssence(job.ToEssense(), false, false)
+			So(err, ShouldBeNil)
+			So(got.LastAction, ShouldNotBeNil)
+			So(got.LastAction.Action, ShouldEqual, EventTypeKill)
+
+			err = jq.Release(job, nil, "")
+			So(err, ShouldBeNil)
+
+			var toRemove []*JobEssence
+			for _, j := range jobs {
+				toRemove = append(toRemove, j.ToEssense())
+			}
+			removed, err := jq.Delete(toRemove)
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 2)
+
+			_, _, err = jq.PauseServer()
+			So(err, ShouldBeNil)
+			err = jq.ResumeServer()
+			So(err, ShouldBeNil)
+
+			types := []EventType{EventTypeRetry, EventTypeKill, EventTypeRemove, EventTypePause, EventTypeResume}
+			var events []*Event
+			deadline := time.Now().Add(5 * time.Second)
+			for {
+				events, err = jq.GetEvents(start, types, 0)
+				So(err, ShouldBeNil)
+				if len(events) >= len(types) || time.Now().After(deadline) {
+					break
+				}
+				<-time.After(10 * time.Millisecond)
+			}
+			So(len(events), ShouldEqual, len(types))
+			for i, event := range events {
+				So(event.Type, ShouldEqual, types[i])
+				So(event.User, ShouldEqual, user)
+			}
+			So(events[0].Key, ShouldEqual, retried)
+			So(events[1].Key, ShouldEqual, job.Key())
+			So(events[2].Count, ShouldEqual, 2)
+			So(events[2].RepGroup, ShouldEqual, "audit_rg")
+		})
+
+		Convey("Burying jobs via the web interface is recorded against the verified user", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			start := time.Now()
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			job := &Job{Cmd: "echo webbury", Cwd: "/tmp", ReqGroup: "webbury", Requirements: req, RepGroup: "webbury_rg"}
+			added, _, err := jq.Add([]*Job{job}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			session := &webSession{user: "alice@example.com", subject: "248289761001"}
+			server.webBuryJobs(server.keyToJobs(job.Key(), []queue.ItemState{queue.ItemStateDelay, queue.ItemStateReady}), session.identity())
+
+			got, err := jq.GetByEssence(job.ToEssense(), false, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateBuried)
+			So(got.LastAction, ShouldNotBeNil)
+			So(got.LastAction.Action, ShouldEqual, EventTypeBury)
+			So(got.LastAction.String(), ShouldStartWith, "buried by alice@example.com (oidc:248289761001) at ")
+
+			var events []*Event
+			deadline := time.Now().Add(5 * time.Second)
+			for {
+				events, err = jq.GetEvents(start, []EventType{EventTypeBury}, 0)
+				So(err, ShouldBeNil)
+				if len(events) >= 1 || time.Now().After(deadline) {
+					break
+				}
+				<-time.After(10 * time.Millisecond)
+			}
+			So(len(events), ShouldEqual, 1)
+			So(events[0].Key, ShouldEqual, job.Key())
+			So(events[0].User, ShouldEqual, "alice@example.com (oidc:248289761001)")
+			So(events[0].Msg, ShouldEqual, FailReasonBuried)
+		})
+
+		Convey("The server sheds load by refusing excessive queries", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			var jobs []*Job
+			for i := 0; i < 3; i++ {
+				jobs = append(jobs, &Job{Cmd: fmt.Sprintf("echo shed %d", i), Cwd: "/tmp", ReqGroup: "shed", Requirements: req, RepGroup: "shed"})
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 3)
+
+			acquired := 0
+			for server.queries.acquire() {
+				acquired++
+			}
+			So(acquired, ShouldEqual, ServerMaxConcurrentQueries)
+			_, err = jq.GetIncomplete(0, "", false, false)
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrOverloaded)
+			for i := 0; i < acquired; i++ {
+				server.queries.release()
+			}
+
+			got, err := jq.GetIncomplete(0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(got), ShouldEqual, 3)
+
+			origMax := ServerMaxJobsPerRequest
+			ServerMaxJobsPerRequest = 2
+			defer func() {
+				ServerMaxJobsPerRequest = origMax
+			}()
+
+			_, err = jq.GetIncomplete(0, "", false, false)
+			So(err, ShouldNotBeNil)
+			jqerr, ok = err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrTooManyJobs)
+
+			_, err = jq.GetByRepGroup("shed", false, 0, "", false, false)
+			So(err, ShouldNotBeNil)
+
+			got, err = jq.GetByRepGroup("shed", false, 1, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(got), ShouldEqual, 1)
+
+			tooMany := []*Job{
+				{Cmd: "echo shed4", Cwd: "/tmp", ReqGroup: "shed", Requirements: req, RepGroup: "shed"},
+				{Cmd: "echo shed5", Cwd: "/tmp", ReqGroup: "shed", Requirements: req, RepGroup: "shed"},
+				{Cmd: "echo shed6", Cwd: "/tmp", ReqGroup: "shed", Requirements: req, RepGroup: "shed"},
+			}
+			_, _, err = jq.Add(tooMany, envVars, true)
+			So(err, ShouldNotBeNil)
+			jqerr, ok = err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrTooManyJobs)
+
+			origMaxQueued := ServerMaxQueuedJobs
+			ServerMaxQueuedJobs = 4
+			defer func() {
+				ServerMaxQueuedJobs = origMaxQueued
+			}()
+
+			_, _, err = jq.Add(tooMany[:2], envVars, true)
+			So(err, ShouldNotBeNil)
+			jqerr, ok = err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrTooManyJobs)
+
+			added, _, err = jq.Add(tooMany[:1], envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+		})
+
+		Convey("A particular job can be reserved and run manually", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo manual1", Cwd: "/tmp", ReqGroup: "manual", Requirements: req, RepGroup: "manual"},
+				{Cmd: "echo manual2 && cat", Cwd: "/tmp", ReqGroup: "manual", Requirements: req, RepGroup: "manual"},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+			start := time.Now()
+
+			job, err := jq.ReserveKey(jobs[1].Key())
+			So(err, ShouldBeNil)
+			So(job, ShouldNotBeNil)
+			So(job.Cmd, ShouldEqual, "echo manual2 && cat")
+
+			_, err = jq.ReserveKey(jobs[1].Key())
+			So(err, ShouldNotBeNil)
+			_, err = jq.ReserveKey("fake")
+			So(err, ShouldNotBeNil)
+
+			var stdout, stderr bytes.Buffer
+			jq.SetExecuteIO(strings.NewReader("input\n"), &stdout, &stderr)
+			err = jq.Execute(job, config.RunnerExecShell)
+			So(err, ShouldBeNil)
+			So(stdout.String(), ShouldEqual, "manual2\ninput\n")
+
+			got, err := jq.GetByEssence(&JobEssence{JobKey: jobs[1].Key()}, true, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateComplete)
+
+			events, err := jq.GetEvents(start, []EventType{EventTypeManualRun}, 0)
+			So(err, ShouldBeNil)
+			So(len(events), ShouldEqual, 1)
+			So(events[0].Key, ShouldEqual, jobs[1].Key())
+		})
+
+		Convey("The public status page lets anyone view, but not change, jobs", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			job := &Job{Cmd: "echo public output && false", Cwd: "/tmp", ReqGroup: "public", Requirements: &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}, RepGroup: "public", Retries: 0}
+			added, _, err := jq.Add([]*Job{job}, append(envVars, "WR_PUBLIC_TEST=credential"), true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			// give it some output, which might include credentials
+			reserved, err := jq.ReserveKey(job.Key())
+			So(err, ShouldBeNil)
+			So(reserved, ShouldNotBeNil)
+			var manualOut, manualErr bytes.Buffer
+			jq.SetExecuteIO(strings.NewReader(""), &manualOut, &manualErr)
+			err = jq.Execute(reserved, config.RunnerExecShell)
+			So(err, ShouldNotBeNil)
+			got, err := jq.GetByEssence(&JobEssence{JobKey: job.Key()}, true, true)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateBuried)
+			stdout, err := got.StdOut()
+			So(err, ShouldBeNil)
+			So(stdout, ShouldEqual, "public output")
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/", webInterfaceStatic(server, true))
+			mux.HandleFunc("/status_ws", webInterfaceStatusWS(server, true))
+			public := httptest.NewServer(mux)
+			defer public.Close()
+
+			resp, err := http.Get(public.URL + "/status")
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			resp.Body.Close()
+
+			private := httptest.NewServer(http.HandlerFunc(webInterfaceStatic(server, false)))
+			defer private.Close()
+			resp, err = http.Get(private.URL + "/status")
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusUnauthorized)
+			resp.Body.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(public.URL, "http")+"/status_ws", nil)
+			So(err, ShouldBeNil)
+			defer conn.Close()
+
+			err = conn.WriteJSON(&jstatusReq{Request: "removeKey", Key: job.Key()})
+			So(err, ShouldBeNil)
+			err = conn.WriteJSON(&jstatusReq{Key: job.Key()})
+			So(err, ShouldBeNil)
+			status := &JStatus{}
+			err = conn.ReadJSON(status)
+			So(err, ShouldBeNil)
+			So(status.Key, ShouldEqual, job.Key())
+			So(status.Env, ShouldBeEmpty)
+			So(status.StdOut, ShouldBeBlank)
+
+			err = conn.WriteJSON(&jstatusReq{Request: "details", RepGroup: "public", State: JobStateBuried})
+			So(err, ShouldBeNil)
+			status = &JStatus{}
+			err = conn.ReadJSON(status)
+			So(err, ShouldBeNil)
+			So(status.Key, ShouldEqual, job.Key())
+			So(status.Env, ShouldBeEmpty)
+			So(status.StdOut, ShouldBeBlank)
+
+			jobs, err := jq.GetByRepGroup("public", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(jobs), ShouldEqual, 1)
+		})
+
+		Convey("The status page can resubmit jobs with edits", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			job := &Job{Cmd: "echo resubmit", Cwd: "/tmp", ReqGroup: "resubmit", Requirements: &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}, Retries: 3, RepGroup: "resubmit"}
+			added, _, err := jq.Add([]*Job{job}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			private := httptest.NewServer(http.HandlerFunc(webInterfaceStatusWS(server, false)))
+			defer private.Close()
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(private.URL, "http")+"/status_ws?token="+string(token), nil)
+			So(err, ShouldBeNil)
+			defer conn.Close()
+
+			err = conn.WriteJSON(&jstatusReq{Key: job.Key()})
+			So(err, ShouldBeNil)
+			status := &JStatus{}
+			err = conn.ReadJSON(status)
+			So(err, ShouldBeNil)
+			So(status.Retries, ShouldEqual, 3)
+
+			edits := &jresubmit{Cmd: status.Cmd, Memory: status.ExpectedRAM, Time: status.ExpectedTime, Cores: status.Cores, Retries: 3}
+			err = conn.WriteJSON(&jstatusReq{Request: "resubmit", Key: job.Key(), Resubmit: edits})
+			So(err, ShouldBeNil)
+			resp := &jresubmitted{}
+			err = conn.ReadJSON(resp)
+			So(err, ShouldBeNil)
+			So(resp.Resubmitted, ShouldBeEmpty)
+			So(resp.Error, ShouldContainSubstring, "identical command")
+
+			edits.Cmd = "echo resubmitted"
+			edits.RepGroup = "resubmitted"
+			edits.Memory = 2048
+			edits.Retries = 0
+			err = conn.WriteJSON(&jstatusReq{Request: "resubmit", Key: job.Key(), Resubmit: edits})
+			So(err, ShouldBeNil)
+			resp = &jresubmitted{}
+			err = conn.ReadJSON(resp)
+			So(err, ShouldBeNil)
+			So(resp.Error, ShouldBeEmpty)
+			So(resp.Resubmitted, ShouldNotBeEmpty)
+
+			got, err := jq.GetByEssence(&JobEssence{JobKey: resp.Resubmitted}, false, true)
+			So(err, ShouldBeNil)
+			So(got, ShouldNotBeNil)
+			So(got.Cmd, ShouldEqual, "echo resubmitted")
+			So(got.RepGroup, ShouldEqual, "resubmitted")
+			So(got.Requirements.RAM, ShouldEqual, 2048)
+			So(got.Requirements.Time, ShouldEqual, 4*time.Hour)
+			So(got.Retries, ShouldEqual, 0)
+			So(got.Cwd, ShouldEqual, "/tmp")
+			env, err := got.Env()
+			So(err, ShouldBeNil)
+			So(env, ShouldResemble, envVars)
+
+			got, err = jq.GetByEssence(&JobEssence{JobKey: job.Key()}, false, false)
+			So(err, ShouldBeNil)
+			So(got.Cmd, ShouldEqual, "echo resubmit")
+			So(got.Requirements.RAM, ShouldEqual, 1024)
+		})
+
+		Convey("Settings can be changed while the server runs", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			settings, err := jq.GetSettings()
+			So(err, ShouldBeNil)
+			So(settings[SettingRetryDelay], ShouldEqual, ClientReleaseDelay.String())
+			So(settings[SettingMaxRunnersPerGroup], ShouldEqual, "0")
+
+			settings, err = jq.SetSetting(SettingRetryDelay, "5s")
+			So(err, ShouldBeNil)
+			So(settings[SettingRetryDelay], ShouldEqual, "5s")
+			So(server.jobRetryDelay(), ShouldEqual, 5*time.Second)
+
+			settings, err = jq.SetSetting(SettingMaxRunnersPerGroup, "2")
+			So(err, ShouldBeNil)
+			So(settings[SettingMaxRunnersPerGroup], ShouldEqual, "2")
+			So(server.capRunners(3), ShouldEqual, 2)
+			So(server.capRunners(1), ShouldEqual, 1)
+
+			_, err = jq.SetSetting(SettingMaxRunnersPerGroup, "-1")
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrBadSetting)
+
+			_, err = jq.SetSetting("foo", "1")
+			So(err, ShouldNotBeNil)
+			So(server.capRunners(3), ShouldEqual, 2)
+
+			stored, err := server.db.retrieveSettings()
+			So(err, ShouldBeNil)
+			So(stored, ShouldResemble, map[string]string{SettingRetryDelay: "5s", SettingMaxRunnersPerGroup: "2"})
+		})
+
+		Convey("Jobs can depend on every job with a given RepGroup, including those added later", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			jobs := []*Job{
+				{Cmd: "echo rgdep b", Cwd: "/tmp", ReqGroup: "rgdep", Requirements: req, RepGroup: "rgdep_b", Dependencies: Dependencies{NewRepGroupDependency("rgdep_a")}},
+				{Cmd: "echo rgdep a1", Cwd: "/tmp", ReqGroup: "rgdep", Requirements: req, RepGroup: "rgdep_a"},
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 2)
+
+			getB := func() *Job {
+				b, errg := jq.GetByEssence(&JobEssence{Cmd: "echo rgdep b"}, false, false)
+				So(errg, ShouldBeNil)
+				So(b, ShouldNotBeNil)
+				return b
+			}
+			b := getB()
+			So(b.State, ShouldEqual, JobStateDependent)
+			So(b.Dependencies.Stringify(), ShouldResemble, []string{"rep_grp:rgdep_a"})
+
+			jobs = []*Job{{Cmd: "echo rgdep a2", Cwd: "/tmp", ReqGroup: "rgdep", Requirements: req, RepGroup: "rgdep_a"}}
+			added, _, err = jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			for i := 0; i < 2; i++ {
+				job, errr := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(errr, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(job.RepGroup, ShouldEqual, "rgdep_a")
+				err = jq.Execute(job, config.RunnerExecShell)
+				So(err, ShouldBeNil)
+				if i == 0 {
+					So(getB().State, ShouldEqual, JobStateDependent)
+				}
+			}
+			So(getB().State, ShouldEqual, JobStateReady)
+		})
+
+		Convey("Complete jobs can be queried by the time they completed", func() {
+			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			So(err, ShouldBeNil)
+			defer disconnect(jq)
+
+			req := &jqs.Requirements{RAM: 1024, Time: 4 * time.Hour, Cores: 1}
+			var jobs []*Job
+			for i, rg := range []string{"archive_a", "archive_b", "archive_a"} {
+				jobs = append(jobs, &Job{Cmd: fmt.Sprintf("echo archive %d", i), Cwd: "/tmp", ReqGroup: "archive", Requirements: req, Priority: uint8(3 - i), RepGroup: rg})
+			}
+			added, _, err := jq.Add(jobs, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 3)
+
+			var marks []time.Time
+			for i := 0; i < 3; i++ {
+				marks = append(marks, time.Now())
+				job, errr := jq.ReserveScheduled(50*time.Millisecond, "1024:240:1:0")
+				So(errr, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(job.Cmd, ShouldEqual, fmt.Sprintf("echo archive %d", i))
+				So(jq.Execute(job, config.RunnerExecShell), ShouldBeNil)
+			}
 
-func TestJobqueueBasics(t *testing.T) {
-	if runnermode || servermode {
-		return
-	}
-	config, serverConfig, addr, standardReqs, clientConnectTime := jobqueueTestInit(true)
+			complete, err := jq.GetComplete("", false, time.Time{}, time.Time{}, 0, false, false)
+			So(err, ShouldBeNil)
+			So(len(complete), ShouldEqual, 3)
+			So(complete[0].Cmd, ShouldEqual, "echo archive 2")
+			So(complete[2].Cmd, ShouldEqual, "echo archive 0")
+			So(complete[0].State, ShouldEqual, JobStateComplete)
 
-	defer os.RemoveAll(filepath.Join(os.TempDir(), AppName+"_cwd"))
+			complete, err = jq.GetComplete("", false, marks[1], time.Time{}, 0, false, false)
+			So(err, ShouldBeNil)
+			So(len(complete), ShouldEqual, 2)
+			So(complete[0].Cmd, ShouldEqual, "echo archive 2")
+			So(complete[1].Cmd, ShouldEqual, "echo archive 1")
 
-	var server *Server
-	var token []byte
-	var errs error
-	Convey("Without the jobserver being up, clients can't connect and time out", t, func() {
-		_, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
-		So(err, ShouldNotBeNil)
-		jqerr, ok := err.(Error)
-		So(ok, ShouldBeTrue)
-		So(jqerr.Err, ShouldEqual, ErrNoServer)
-	})
+			complete, err = jq.GetComplete("", false, marks[1], marks[2], 0, false, false)
+			So(err, ShouldBeNil)
+			So(len(complete), ShouldEqual, 1)
+			So(complete[0].Cmd, ShouldEqual, "echo archive 1")
 
-	Convey("Once the jobqueue server is up", t, func() {
-		server, _, token, errs = serve(serverConfig)
-		So(errs, ShouldBeNil)
+			complete, err = jq.GetComplete("", false, time.Time{}, time.Time{}, 1, false, false)
+			So(err, ShouldBeNil)
+			So(len(complete), ShouldEqual, 1)
+			So(complete[0].Cmd, ShouldEqual, "echo archive 2")
 
-		server.rc = serverRC // ReserveScheduled() only works if we have an rc
+			complete, err = jq.GetComplete("archive_a", false, time.Time{}, time.Time{}, 0, false, false)
+			So(err, ShouldBeNil)
+			So(len(complete), ShouldEqual, 2)
+			So(complete[0].Cmd, ShouldEqual, "echo archive 2")
+			So(complete[1].Cmd, ShouldEqual, "echo archive 0")
 
-		Convey("You can connect to the server and add jobs and get back their IDs", func() {
-			jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+			complete, err = jq.GetComplete("_b", true, marks[0], time.Time{}, 0, false, false)
 			So(err, ShouldBeNil)
-			defer disconnect(jq)
+			So(len(complete), ShouldEqual, 1)
+			So(complete[0].RepGroup, ShouldEqual, "archive_b")
 
-			var jobs []*Job
-			req := &jqs.Requirements{RAM: 10, Time: 1 * time.Second, Cores: 1}
-			jobs = append(jobs, &Job{Cmd: "echo 1", Cwd: "/tmp", ReqGroup: "fake_group", Requirements: req, Retries: uint8(0), RepGroup: "test"})
-			jobs = append(jobs, &Job{Cmd: "echo 2", Cwd: "/tmp", ReqGroup: "fake_group", Requirements: req, Retries: uint8(0), RepGroup: "test"})
-			ids, err := jq.AddAndReturnIDs(jobs, envVars, true)
+			complete, err = jq.GetComplete("nonexistent", true, time.Time{}, time.Time{}, 0, false, false)
 			So(err, ShouldBeNil)
-			So(len(ids), ShouldEqual, 2)
-			So(ids[0], ShouldEqual, "9a456dee1e351f82e3d562769c27d803")
-			So(ids[1], ShouldEqual, "2bb7055e49e21ea85066899a5ba38d8e")
+			So(len(complete), ShouldEqual, 0)
+
+			complete, err = jq.GetComplete("", false, time.Now(), time.Time{}, 0, false, false)
+			So(err, ShouldBeNil)
+			So(len(complete), ShouldEqual, 0)
 		})
 
 		Convey("You can connect to the server and add jobs to the queue", func() {
@@ -966,6 +4503,48 @@ func TestJobqueueBasics(t *testing.T) {
 				So(rtime, ShouldEqual, 10800)
 			})
 
+			Convey("You can store and retrieve web interface preferences", func() {
+				token := []byte("a token")
+				So(server.db.retrieveWebPrefs(token), ShouldBeNil)
+				err := server.db.storeWebPrefs(token, []byte(`{"theme":"dark"}`))
+				So(err, ShouldBeNil)
+				So(string(server.db.retrieveWebPrefs(token)), ShouldEqual, `{"theme":"dark"}`)
+				So(server.db.retrieveWebPrefs([]byte("another token")), ShouldBeNil)
+			})
+
+			Convey("You can export reports on jobs", func() {
+				var buf bytes.Buffer
+				err := server.exportJobs(context.Background(), &buf, exportFormatCSV, nil, "")
+				So(err, ShouldBeNil)
+				lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+				So(len(lines), ShouldEqual, 11)
+				So(lines[0], ShouldEqual, strings.Join(exportColumns, ","))
+				So(lines, ShouldContain, jobs[0].Key()+",manually_added,test cmd 0,ready,0,,0.000,0,")
+
+				buf.Reset()
+				err = server.exportJobs(context.Background(), &buf, exportFormatCSV, []string{"foo", "manual"}, JobStateBuried)
+				So(err, ShouldBeNil)
+				So(strings.TrimSpace(buf.String()), ShouldEqual, strings.Join(exportColumns, ","))
+
+				buf.Reset()
+				err = server.exportJobs(context.Background(), &buf, exportFormatJSON, []string{"manual"}, JobStateReady)
+				So(err, ShouldBeNil)
+				var records []*exportRecord
+				err = json.Unmarshal(buf.Bytes(), &records)
+				So(err, ShouldBeNil)
+				So(len(records), ShouldEqual, 10)
+				So(records[0].RepGroup, ShouldEqual, "manually_added")
+				So(records[0].State, ShouldEqual, JobStateReady)
+
+				buf.Reset()
+				err = server.exportJobs(context.Background(), &buf, exportFormatJSON, []string{"foo"}, "")
+				So(err, ShouldBeNil)
+				So(strings.TrimSpace(buf.String()), ShouldEqual, "[]")
+
+				err = server.exportJobs(context.Background(), &buf, "xml", nil, "")
+				So(err, ShouldNotBeNil)
+			})
+
 			Convey("You can reserve jobs from the queue in the correct order", func() {
 				for i := 9; i >= 0; i-- {
 					jid := i
@@ -1080,6 +4659,35 @@ func TestJobqueueBasics(t *testing.T) {
 						So(err, ShouldBeNil)
 						So(job, ShouldBeNil)
 					})
+
+					Convey("You can find out about the scheduler groups", func() {
+						job, err := jq.ReserveScheduled(20*time.Millisecond, "2048:60:2:0")
+						So(err, ShouldBeNil)
+						So(job, ShouldNotBeNil)
+
+						sgs, err := jq.GetSchedulerGroups()
+						So(err, ShouldBeNil)
+						So(len(sgs), ShouldEqual, 2)
+
+						So(sgs[0].Name, ShouldEqual, "1024:240:1:0")
+						So(sgs[0].ReqGroups, ShouldResemble, []string{"fake_group"})
+						So(sgs[0].Requirements.RAM, ShouldEqual, 1024)
+						So(sgs[0].Ready, ShouldEqual, 10)
+						So(sgs[0].Running, ShouldEqual, 0)
+						So(sgs[0].Requested, ShouldEqual, 10)
+						So(sgs[0].Connected, ShouldEqual, 0)
+						So(sgs[0].Since.IsZero(), ShouldBeFalse)
+						So(sgs[0].LastConnected.IsZero(), ShouldBeTrue)
+						So(sgs[0].Waited(time.Now()), ShouldBeGreaterThan, 0)
+
+						So(sgs[1].Name, ShouldEqual, "2048:60:2:0")
+						So(sgs[1].ReqGroups, ShouldResemble, []string{"new_group"})
+						So(sgs[1].Scheduler, ShouldEqual, "local")
+						So(sgs[1].Ready, ShouldEqual, 9)
+						So(sgs[1].Running, ShouldEqual, 1)
+						So(sgs[1].Connected, ShouldEqual, 1)
+						So(sgs[1].LastConnected.IsZero(), ShouldBeFalse)
+					})
 				})
 			}
 
@@ -1182,6 +4790,36 @@ func TestJobqueueBasics(t *testing.T) {
 				So(stdout, ShouldEqual, "c\nd")
 			})
 
+			Convey("Jobs needing more disk than is available get released without running", func() {
+				server.racmutex.Lock()
+				server.rc = ""
+				server.racmutex.Unlock()
+				free, err := diskAvailable("/tmp")
+				So(err, ShouldBeNil)
+				hugeReqs := &jqs.Requirements{RAM: 10, Time: 4 * time.Hour, Cores: 1, Disk: free + 1000, DiskSet: true}
+				inserts, _, err := jq.Add([]*Job{{Cmd: "echo ran", Cwd: "/tmp", ReqGroup: "new_group", Requirements: hugeReqs, Priority: uint8(100), RepGroup: "diskcheck"}}, envVars, true)
+				So(err, ShouldBeNil)
+				So(inserts, ShouldEqual, 1)
+
+				job, err := jq.Reserve(50 * time.Millisecond)
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				So(job.RepGroup, ShouldEqual, "diskcheck")
+
+				err = jq.Execute(job, config.RunnerExecShell)
+				So(err, ShouldNotBeNil)
+				jqerr, ok := err.(Error)
+				So(ok, ShouldBeTrue)
+				So(jqerr.Err, ShouldEqual, FailReasonHostDisk)
+				So(job.State, ShouldEqual, JobStateDelayed)
+
+				retrieved, err := jq.GetByEssence(job.ToEssense(), false, false)
+				So(err, ShouldBeNil)
+				So(retrieved.FailReason, ShouldEqual, FailReasonHostDisk)
+				So(retrieved.Attempts, ShouldEqual, 0)
+				So(retrieved.UntilBuried, ShouldEqual, job.Retries+1)
+			})
+
 			Convey("You can stop the server by sending it a SIGTERM or SIGINT", func() {
 				err := jq.Disconnect()
 				So(err, ShouldBeNil)
@@ -1234,6 +4872,287 @@ func TestJobqueueBasics(t *testing.T) {
 		})
 	})
 
+	Convey("The jobqueue server won't start with a lost contact timeout shorter than the heartbeat", t, func() {
+		badConfig := serverConfig
+		badConfig.HeartbeatInterval = 1 * time.Second
+		badConfig.LostContactTimeout = 1 * time.Second
+		_, _, _, err := serve(badConfig)
+		So(err, ShouldNotBeNil)
+		jqerr, ok := err.(Error)
+		So(ok, ShouldBeTrue)
+		So(jqerr.Err, ShouldEqual, ErrBadHeartbeat)
+	})
+
+	Convey("Once a jobqueue server with a lost requeue grace period is up", t, func() {
+		lostConfig := serverConfig
+		lostConfig.HeartbeatInterval = 50 * time.Millisecond
+		lostConfig.LostContactTimeout = 200 * time.Millisecond
+		lostConfig.LostRequeueGrace = 300 * time.Millisecond
+		server, _, token, errs = serve(lostConfig)
+		So(errs, ShouldBeNil)
+
+		jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+		So(err, ShouldBeNil)
+		defer disconnect(jq)
+		So(jq.ServerInfo.Heartbeat, ShouldEqual, 50*time.Millisecond)
+		So(jq.heartbeatInterval(), ShouldEqual, 50*time.Millisecond)
+
+		jobs := []*Job{{Cmd: "sleep 10", Cwd: "/tmp", ReqGroup: "fake_group", Requirements: standardReqs, Retries: uint8(1), RepGroup: "lost"}}
+		inserts, _, err := jq.Add(jobs, envVars, true)
+		So(err, ShouldBeNil)
+		So(inserts, ShouldEqual, 1)
+
+		job, err := jq.Reserve(50 * time.Millisecond)
+		So(err, ShouldBeNil)
+		So(job, ShouldNotBeNil)
+		err = jq.Started(job, 1)
+		So(err, ShouldBeNil)
+
+		Convey("Jobs that stop being touched become lost, then get requeued", func() {
+			<-time.After(350 * time.Millisecond)
+			got, err := jq.GetByEssence(&JobEssence{Cmd: "sleep 10"}, false, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateLost)
+			So(got.FailReason, ShouldEqual, FailReasonLost)
+
+			<-time.After(400 * time.Millisecond)
+			got, err = jq.GetByEssence(&JobEssence{Cmd: "sleep 10"}, false, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldBeIn, []JobState{JobStateDelayed, JobStateReady})
+			So(got.FailReason, ShouldEqual, FailReasonLost)
+			So(got.UntilBuried, ShouldEqual, 1)
+		})
+
+		Convey("Lost jobs that regain contact are not requeued", func() {
+			<-time.After(350 * time.Millisecond)
+			got, err := jq.GetByEssence(&JobEssence{Cmd: "sleep 10"}, false, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateLost)
+
+			for i := 0; i < 8; i++ {
+				_, err = jq.Touch(job)
+				So(err, ShouldBeNil)
+				<-time.After(50 * time.Millisecond)
+			}
+			got, err = jq.GetByEssence(&JobEssence{Cmd: "sleep 10"}, false, false)
+			So(err, ShouldBeNil)
+			So(got.State, ShouldEqual, JobStateRunning)
+			So(got.UntilBuried, ShouldEqual, 2)
+		})
+
+		Reset(func() {
+			server.Stop(true)
+		})
+	})
+
+	Convey("Once a jobqueue server with a recycle window is up", t, func() {
+		recycleConfig := serverConfig
+		recycleConfig.RecycleWindow = 1 * time.Minute
+		server, _, token, errs = serve(recycleConfig)
+		So(errs, ShouldBeNil)
+
+		jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+		So(err, ShouldBeNil)
+		defer disconnect(jq)
+		So(jq.ServerInfo.RecycleWindow, ShouldEqual, 1*time.Minute)
+
+		jobs := []*Job{
+			{Cmd: "echo recycle 1", Cwd: "/tmp", ReqGroup: "fake_group", Requirements: standardReqs, RepGroup: "recycle"},
+			{Cmd: "echo recycle 2", Cwd: "/tmp", ReqGroup: "fake_group", Requirements: standardReqs, RepGroup: "recycle", Priority: 5},
+			{Cmd: "echo keep", Cwd: "/tmp", ReqGroup: "fake_group", Requirements: standardReqs, RepGroup: "keep"},
+		}
+		inserts, _, err := jq.Add(jobs, envVars, true)
+		So(err, ShouldBeNil)
+		So(inserts, ShouldEqual, 3)
+
+		Convey("Deleted jobs can be listed and restored", func() {
+			before := time.Now()
+			removed, err := jq.Delete([]*JobEssence{{Cmd: "echo recycle 1"}, {Cmd: "echo recycle 2"}})
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 2)
+			got, err := jq.GetByRepGroup("recycle", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(got), ShouldEqual, 0)
+
+			recycled, err := jq.GetRecycled("", false, time.Time{})
+			So(err, ShouldBeNil)
+			So(len(recycled), ShouldEqual, 2)
+			So(recycled[0].Job.RepGroup, ShouldEqual, "recycle")
+			So(recycled[0].Deleted, ShouldHappenOnOrAfter, before)
+
+			recycled, err = jq.GetRecycled("keep", false, time.Time{})
+			So(err, ShouldBeNil)
+			So(len(recycled), ShouldEqual, 0)
+			recycled, err = jq.GetRecycled("cyc", true, time.Time{})
+			So(err, ShouldBeNil)
+			So(len(recycled), ShouldEqual, 2)
+			recycled, err = jq.GetRecycled("", false, time.Now().Add(1*time.Minute))
+			So(err, ShouldBeNil)
+			So(len(recycled), ShouldEqual, 0)
+
+			restored, err := jq.Undelete("recycle", false, time.Time{})
+			So(err, ShouldBeNil)
+			So(restored, ShouldEqual, 2)
+			got, err = jq.GetByRepGroup("recycle", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(got), ShouldEqual, 2)
+			for _, job := range got {
+				if job.Cmd == "echo recycle 2" {
+					So(job.Priority, ShouldEqual, 5)
+				}
+			}
+
+			recycled, err = jq.GetRecycled("", false, time.Time{})
+			So(err, ShouldBeNil)
+			So(len(recycled), ShouldEqual, 0)
+			restored, err = jq.Undelete("", false, time.Time{})
+			So(err, ShouldBeNil)
+			So(restored, ShouldEqual, 0)
+		})
+
+		Convey("Recycled jobs are forgotten after the window", func() {
+			removed, err := jq.Delete([]*JobEssence{{Cmd: "echo keep"}})
+			So(err, ShouldBeNil)
+			So(removed, ShouldEqual, 1)
+
+			recycled, err := jq.GetRecycled("", false, time.Time{})
+			So(err, ShouldBeNil)
+			So(len(recycled), ShouldEqual, 1)
+
+			err = server.db.deleteRecycledBefore(time.Now())
+			So(err, ShouldBeNil)
+
+			recycled, err = jq.GetRecycled("", false, time.Time{})
+			So(err, ShouldBeNil)
+			So(len(recycled), ShouldEqual, 0)
+		})
+
+		Reset(func() {
+			server.Stop(true)
+		})
+	})
+
+	Convey("Once a jobqueue server with a secret store is up", t, func() {
+		secretsDir, err := ioutil.TempDir("", "wr_jobqueue_test_secrets_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(secretsDir)
+		secretsConfig := serverConfig
+		secretsConfig.SecretsFile = filepath.Join(secretsDir, "secrets")
+		server, _, token, errs = serve(secretsConfig)
+		So(errs, ShouldBeNil)
+
+		jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+		So(err, ShouldBeNil)
+		defer disconnect(jq)
+
+		grant, names, err := jq.SetSecret("DB_PASS", "hunter22")
+		So(err, ShouldBeNil)
+		So(names, ShouldResemble, []string{"DB_PASS"})
+		So(grant, ShouldNotBeBlank)
+
+		secretJob := func(cmd string) *Job {
+			return &Job{Cmd: cmd, Cwd: "/tmp", ReqGroup: "fake_group", Requirements: standardReqs, RepGroup: "secrets", Secrets: []string{"DB_PASS"}}
+		}
+
+		Convey("Jobs can't use secrets without their grant", func() {
+			_, _, err = jq.Add([]*Job{secretJob("echo $DB_PASS")}, envVars, true)
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrBadSecret)
+
+			jq.SetSecretGrants(map[string]string{"DB_PASS": "guessed"})
+			addToken, err := NewAddToken()
+			So(err, ShouldBeNil)
+			_, _, results, err := jq.AddWithToken([]*Job{secretJob("echo $DB_PASS")}, envVars, true, addToken)
+			So(err, ShouldBeNil)
+			So(results[0].Outcome, ShouldEqual, AddOutcomeInvalid)
+			So(results[0].Reason, ShouldContainSubstring, ErrBadSecret)
+
+			got, err := jq.GetByRepGroup("secrets", false, 0, "", false, false)
+			So(err, ShouldBeNil)
+			So(len(got), ShouldEqual, 0)
+		})
+
+		Convey("Jobs can use secrets with their grant, and only be modified with it", func() {
+			jq.SetSecretGrants(map[string]string{"DB_PASS": grant})
+			added, _, err := jq.Add([]*Job{secretJob("echo secret")}, envVars, true)
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+
+			jq.SetSecretGrants(nil)
+			jm := NewJobModifer()
+			jm.SetCmd("echo $DB_PASS")
+			_, err = jq.Modify([]*JobEssence{{Cmd: "echo secret"}}, jm)
+			So(err, ShouldNotBeNil)
+			jqerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(jqerr.Err, ShouldEqual, ErrBadSecret)
+
+			Convey("Secrets are given to the runner that reserved the job", func() {
+				job, err := jq.Reserve(50 * time.Millisecond)
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				env, err := jq.getSecrets(job)
+				So(err, ShouldBeNil)
+				So(env, ShouldResemble, []string{"DB_PASS=hunter22"})
+			})
+
+			Convey("But not when the job was reserved to be run manually", func() {
+				job, err := jq.ReserveKey(secretJob("echo secret").Key())
+				So(err, ShouldBeNil)
+				So(job, ShouldNotBeNil)
+				_, err = jq.getSecrets(job)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, ErrBadSecret)
+			})
+
+			Convey("Setting the secret again stops the old grant from working", func() {
+				newGrant, _, err := jq.SetSecret("DB_PASS", "hunter23")
+				So(err, ShouldBeNil)
+				So(newGrant, ShouldNotEqual, grant)
+
+				jq.SetSecretGrants(map[string]string{"DB_PASS": grant})
+				_, _, err = jq.Add([]*Job{secretJob("echo secret 2")}, envVars, true)
+				So(err, ShouldNotBeNil)
+
+				jq.SetSecretGrants(map[string]string{"DB_PASS": newGrant})
+				added, _, err = jq.Add([]*Job{secretJob("echo secret 2")}, envVars, true)
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 1)
+			})
+
+			Convey("Jobs that use secrets can be imported with their grant", func() {
+				var buf bytes.Buffer
+				n, err := jq.ExportJobs(&buf, "secrets", false)
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 1)
+				removed, err := jq.Delete([]*JobEssence{{Cmd: "echo secret"}})
+				So(err, ShouldBeNil)
+				So(removed, ShouldEqual, 1)
+				export := buf.Bytes()
+
+				_, _, err = jq.ImportJobs(bytes.NewReader(export))
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, ErrBadSecret)
+
+				jq.SetSecretGrants(map[string]string{"DB_PASS": grant})
+				added, _, err := jq.ImportJobs(bytes.NewReader(export))
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 1)
+
+				got, err := jq.GetByRepGroup("secrets", false, 0, "", false, false)
+				So(err, ShouldBeNil)
+				So(len(got), ShouldEqual, 1)
+				So(got[0].Secrets, ShouldResemble, []string{"DB_PASS"})
+			})
+		})
+
+		Reset(func() {
+			server.Stop(true)
+		})
+	})
+
 	if server != nil {
 		server.Stop(true)
 	}
@@ -1816,6 +5735,50 @@ func TestJobqueueMedium(t *testing.T) {
 					So(stderr, ShouldEqual, tmpDir)
 				})
 
+				Convey("With a JobScratchDir, jobs get a unique TMPDIR in it that is deleted after they run", func() {
+					scratchDir, err := ioutil.TempDir("", "wr_jobqueue_test_scratch_dir_")
+					So(err, ShouldBeNil)
+					defer os.RemoveAll(scratchDir)
+					JobScratchDir = scratchDir
+					defer func() {
+						JobScratchDir = ""
+					}()
+
+					jobs = nil
+					jobs = append(jobs, &Job{Cmd: "touch $TMPDIR/file && echo $TMPDIR", Cwd: "/tmp", CwdMatters: true, ReqGroup: "fake_group", Requirements: standardReqs, RepGroup: "scratch"})
+					jobs = append(jobs, &Job{Cmd: "touch $TMPDIR/file && echo $TMPDIR && false", Cwd: "/tmp", ReqGroup: "fake_group", Requirements: standardReqs, RepGroup: "scratch"})
+					inserts, _, err := jq.Add(jobs, envVars, true)
+					So(err, ShouldBeNil)
+					So(inserts, ShouldEqual, 2)
+
+					for i := 0; i < 2; i++ {
+						job, err := jq.Reserve(50 * time.Millisecond)
+						So(err, ShouldBeNil)
+						So(job, ShouldNotBeNil)
+
+						err = jq.Execute(job, config.RunnerExecShell)
+						if job.CwdMatters {
+							So(err, ShouldBeNil)
+							So(job.State, ShouldEqual, JobStateComplete)
+						} else {
+							So(err, ShouldNotBeNil)
+							So(job.State, ShouldEqual, JobStateBuried)
+							So(job.ActualCwd, ShouldNotBeBlank)
+							_, err = os.Stat(filepath.Join(filepath.Dir(job.ActualCwd), "tmp"))
+							So(os.IsNotExist(err), ShouldBeTrue)
+						}
+						stdout, err := job.StdOut()
+						So(err, ShouldBeNil)
+						So(filepath.Dir(stdout), ShouldEqual, scratchDir)
+						_, err = os.Stat(stdout)
+						So(os.IsNotExist(err), ShouldBeTrue)
+					}
+
+					entries, err := ioutil.ReadDir(scratchDir)
+					So(err, ShouldBeNil)
+					So(entries, ShouldBeEmpty)
+				})
+
 				Convey("The stdout/err of jobs is limited in size", func() {
 					jobs = nil
 					jobs = append(jobs, &Job{Cmd: "perl -e 'for (1..60) { print $_ x 130, qq[p\\n]; warn $_ x 130, qq[w\\n] } die'", Cwd: "/tmp", ReqGroup: "fake_group", Requirements: standardReqs, RepGroup: "should_fail"})
@@ -3735,7 +7698,7 @@ func TestJobqueueProduction(t *testing.T) {
 			So(info.Size(), ShouldEqual, 65536) // don't know if this will be consistent across platforms and versions...
 			info2, err := os.Stat(managerDBBkFile)
 			So(err, ShouldBeNil)
-			So(info2.Size(), ShouldEqual, 32768) // *** don't know why it's so much smaller...
+			So(info2.Size(), ShouldEqual, 36864) // *** don't know why it's so much smaller...
 			_, err = os.Stat(tmpPath)
 			So(err, ShouldNotBeNil)
 
@@ -3745,7 +7708,7 @@ func TestJobqueueProduction(t *testing.T) {
 				So(err, ShouldBeNil)
 				info3, err := os.Stat(manualBackup)
 				So(err, ShouldBeNil)
-				So(info3.Size(), ShouldEqual, 32768)
+				So(info3.Size(), ShouldEqual, 36864)
 
 				server.Stop(true)
 				server, _, token, errs = serve(serverConfig)
@@ -3806,10 +7769,10 @@ func TestJobqueueProduction(t *testing.T) {
 
 				info, err = os.Stat(config.ManagerDbFile)
 				So(err, ShouldBeNil)
-				So(info.Size(), ShouldEqual, 32768)
+				So(info.Size(), ShouldEqual, 36864)
 				info2, err = os.Stat(managerDBBkFile)
 				So(err, ShouldBeNil)
-				So(info2.Size(), ShouldEqual, 32768)
+				So(info2.Size(), ShouldEqual, 36864)
 
 				jq, err = Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
 				So(err, ShouldBeNil)
@@ -3833,10 +7796,10 @@ func TestJobqueueProduction(t *testing.T) {
 
 				info, err = os.Stat(config.ManagerDbFile)
 				So(err, ShouldBeNil)
-				So(info.Size(), ShouldEqual, 32768)
+				So(info.Size(), ShouldEqual, 36864)
 				info2, err = os.Stat(managerDBBkFile)
 				So(err, ShouldBeNil)
-				So(info2.Size(), ShouldEqual, 32768)
+				So(info2.Size(), ShouldEqual, 36864)
 
 				jq, err = Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
 				So(err, ShouldBeNil)
@@ -3892,10 +7855,10 @@ func TestJobqueueProduction(t *testing.T) {
 
 			info, err := os.Stat(config.ManagerDbFile)
 			So(err, ShouldBeNil)
-			So(info.Size(), ShouldEqual, 32768)
+			So(info.Size(), ShouldEqual, 65536)
 			info2, err := os.Stat(managerDBBkFile)
 			So(err, ShouldBeNil)
-			So(info2.Size(), ShouldEqual, 28672)
+			So(info2.Size(), ShouldEqual, 32768)
 
 			Convey("You can restart the server with that existing job, delete it, and it stays deleted when restoring from backup", func() {
 				wipeDevDBOnInit = false
@@ -0,0 +1,425 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of limit group feedback, where the
+// limit of a limit group is automatically lowered and raised to keep a health
+// probe of some external service healthy.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+)
+
+// LimitFeedback* variables are the defaults for unset LimitFeedback values.
+var (
+	LimitFeedbackInterval = 30 * time.Second
+	LimitFeedbackTimeout  = 5 * time.Second
+	LimitFeedbackStep     = 1
+	LimitFeedbackBackoff  = 0.5
+)
+
+// limitFeedbackProbeShell is the shell that command probes are run with.
+const limitFeedbackProbeShell = "sh"
+
+// limitFeedbackOutputLimit is how much of a failed probe's output we keep to
+// explain why it failed.
+const limitFeedbackOutputLimit = 256
+
+// LimitFeedback puts a limit group in to feedback mode, where the server polls
+// a health Probe every Interval, and automatically tunes the group's limit to
+// keep the probe healthy: each time it is healthy the limit is raised by Step,
+// up to Max, and each time it is unhealthy, the limit is multiplied by Backoff
+// (but lowered by at least 1), down to Min.
+//
+// Probe is either an http:// or https:// URL, which is healthy if a GET of it
+// returns a 2xx status, or a command line run by the server with sh, which is
+// healthy if it exits 0. Either way, the probe is unhealthy if it takes longer
+// than Timeout, so you can use Timeout to back off when a service's latency
+// rises.
+//
+// Interval, Timeout, Step and Backoff default to the corresponding
+// LimitFeedback* variables when 0.
+type LimitFeedback struct {
+	Group    string
+	Probe    string
+	Interval time.Duration
+	Timeout  time.Duration
+	Min      int
+	Max      int
+	Step     int
+	Backoff  float64
+
+	// The following are filled in by the server: the time of the last probe,
+	// whether it was healthy (and if not, why not) and the group's limit.
+	LastProbe time.Time
+	Healthy   bool
+	Problem   string
+	Limit     int
+}
+
+// Validate checks that the feedback is for a valid limit group, has a probe,
+// and has sensible values.
+func (f *LimitFeedback) Validate() error {
+	switch {
+	case f.Group == "" || strings.ContainsAny(f.Group, ":,"+jobSchedMaxPerHostSeparator):
+		return fmt.Errorf("limit feedback needs a limit group name, which can't contain : , or %s", jobSchedMaxPerHostSeparator)
+	case strings.TrimSpace(f.Probe) == "":
+		return fmt.Errorf("limit feedback needs a probe URL or command")
+	case f.Interval < 0 || (f.Interval > 0 && f.Interval < time.Second):
+		return fmt.Errorf("limit feedback interval must be at least 1s")
+	case f.Timeout < 0:
+		return fmt.Errorf("limit feedback timeout can't be negative")
+	case f.Min < 0:
+		return fmt.Errorf("limit feedback minimum can't be negative")
+	case f.Max < 1 || f.Max < f.Min:
+		return fmt.Errorf("limit feedback maximum must be at least 1 and no less than the minimum")
+	case f.Step < 0:
+		return fmt.Errorf("limit feedback step can't be negative")
+	case f.Backoff < 0 || f.Backoff >= 1:
+		return fmt.Errorf("limit feedback backoff must be less than 1")
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the feedback with unset values set to their
+// defaults.
+func (f *LimitFeedback) withDefaults() *LimitFeedback {
+	d := *f
+	if d.Interval == 0 {
+		d.Interval = LimitFeedbackInterval
+	}
+	if d.Timeout == 0 {
+		d.Timeout = LimitFeedbackTimeout
+	}
+	if d.Step == 0 {
+		d.Step = LimitFeedbackStep
+	}
+	if d.Backoff == 0 {
+		d.Backoff = LimitFeedbackBackoff
+	}
+	return &d
+}
+
+// String describes the feedback and its current state.
+func (f *LimitFeedback) String() string {
+	s := fmt.Sprintf("%s is tuned between %d and %d every %s by probing %s", f.Group, f.Min, f.Max, f.Interval, f.Probe)
+	if f.LastProbe.IsZero() {
+		return s
+	}
+	s += fmt.Sprintf("; limit is %d", f.Limit)
+	if f.Healthy {
+		return s + ", probe was healthy"
+	}
+	return s + ", probe was unhealthy: " + f.Problem
+}
+
+// nextLimit returns the limit the group should have after a probe that was
+// healthy or not, given its current limit (-1 if it has none).
+func (f *LimitFeedback) nextLimit(current int, healthy bool) int {
+	if current < 0 || current > f.Max {
+		current = f.Max
+	}
+	if current < f.Min {
+		current = f.Min
+	}
+
+	var next int
+	if healthy {
+		next = current + f.Step
+	} else {
+		next = int(float64(current) * f.Backoff)
+		if next >= current {
+			next = current - 1
+		}
+	}
+
+	if next > f.Max {
+		next = f.Max
+	}
+	if next < f.Min {
+		next = f.Min
+	}
+	return next
+}
+
+// probe runs the feedback's Probe, returning nil if it was healthy, or an error
+// explaining why not.
+func (f *LimitFeedback) probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, f.Timeout)
+	defer cancel()
+
+	var err error
+	if strings.HasPrefix(f.Probe, "http://") || strings.HasPrefix(f.Probe, "https://") {
+		err = probeURL(ctx, f.Probe)
+	} else {
+		err = probeCommand(ctx, f.Probe)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("took longer than %s", f.Timeout)
+	}
+	return err
+}
+
+// probeURL GETs the given URL, returning an error if that fails or doesn't
+// return a 2xx status.
+func probeURL(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("status %s", resp.Status)
+	}
+	return nil
+}
+
+// probeCommand runs the given command line, returning an error if it doesn't
+// exit 0 before the context is done.
+func probeCommand(ctx context.Context, cmdLine string) error {
+	cmd, err := shellCommand(limitFeedbackProbeShell, cmdLine, "")
+	if err != nil {
+		return err
+	}
+	output := &prefixSuffixSaver{N: limitFeedbackOutputLimit}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	// as with run behaviours, we use a process group so that on timeout we can
+	// kill everything the probe started, which might keep our pipes open
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			if errk := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); errk != nil {
+				_ = cmd.Process.Kill()
+			}
+		case <-done:
+		}
+	}()
+	err = cmd.Wait()
+	close(done)
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		if out := bytes.TrimSpace(output.Bytes()); len(out) > 0 {
+			return fmt.Errorf("%w (%s)", err, out)
+		}
+		return err
+	}
+	return nil
+}
+
+// limitFeedbackState is a LimitFeedback being acted on by the server.
+type limitFeedbackState struct {
+	feedback *LimitFeedback
+	stop     chan struct{}
+}
+
+// restoreLimitFeedbacks loads the feedback stored in the database by
+// setLimitFeedback(), and starts acting on it.
+func (s *Server) restoreLimitFeedbacks() {
+	feedbacks, err := s.db.retrieveLimitFeedbacks()
+	if err != nil {
+		s.Warn("failed to retrieve stored limit feedback", "err", err)
+		return
+	}
+	s.lfmutex.Lock()
+	defer s.lfmutex.Unlock()
+	for _, f := range feedbacks {
+		s.startLimitFeedback(f)
+	}
+}
+
+// setLimitFeedback validates the given feedback and puts its limit group in to
+// feedback mode, replacing any existing feedback for the group. Returns a copy
+// of the feedback as set, with defaults filled in.
+func (s *Server) setLimitFeedback(feedback *LimitFeedback) (*LimitFeedback, error) {
+	if err := feedback.Validate(); err != nil {
+		return nil, err
+	}
+	f := feedback.withDefaults()
+	f.LastProbe = time.Time{}
+	f.Healthy = false
+	f.Problem = ""
+	f.Limit = s.limiter.GetLimit(f.Group)
+
+	if err := s.db.storeLimitFeedback(f); err != nil {
+		return nil, err
+	}
+
+	s.lfmutex.Lock()
+	if existing, exists := s.limitFeedbacks[f.Group]; exists {
+		close(existing.stop)
+	}
+	s.startLimitFeedback(f)
+	set := *f
+	s.lfmutex.Unlock()
+
+	s.Info("set limit feedback", "group", f.Group, "probe", f.Probe, "min", f.Min, "max", f.Max)
+	return &set, nil
+}
+
+// startLimitFeedback starts acting on the given feedback in the background.
+// You must hold the lfmutex lock before calling this.
+func (s *Server) startLimitFeedback(f *LimitFeedback) {
+	st := &limitFeedbackState{feedback: f, stop: make(chan struct{})}
+	s.limitFeedbacks[f.Group] = st
+	go s.runLimitFeedback(st)
+}
+
+// deleteLimitFeedback takes the given limit group out of feedback mode,
+// leaving its limit as it was. Returns false if it wasn't in feedback mode.
+func (s *Server) deleteLimitFeedback(group string) (bool, error) {
+	s.lfmutex.Lock()
+	st, exists := s.limitFeedbacks[group]
+	if exists {
+		close(st.stop)
+		delete(s.limitFeedbacks, group)
+	}
+	s.lfmutex.Unlock()
+	if !exists {
+		return false, nil
+	}
+	s.Info("deleted limit feedback", "group", group)
+	return true, s.db.deleteLimitFeedback(group)
+}
+
+// limitFeedbackList returns copies of all the limit feedback, sorted by group.
+func (s *Server) limitFeedbackList() []*LimitFeedback {
+	s.lfmutex.RLock()
+	feedbacks := make([]*LimitFeedback, 0, len(s.limitFeedbacks))
+	for _, st := range s.limitFeedbacks {
+		f := *st.feedback
+		feedbacks = append(feedbacks, &f)
+	}
+	s.lfmutex.RUnlock()
+	sort.Slice(feedbacks, func(i, j int) bool {
+		return feedbacks[i].Group < feedbacks[j].Group
+	})
+	return feedbacks
+}
+
+// runLimitFeedback should be run in a goroutine; it probes every interval and
+// tunes the group's limit, until the feedback is stopped or the server stops.
+func (s *Server) runLimitFeedback(st *limitFeedbackState) {
+	defer internal.LogPanic(s.Logger, "limit feedback", true)
+
+	s.lfmutex.RLock()
+	interval := st.feedback.Interval
+	s.lfmutex.RUnlock()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-st.stop:
+		case <-s.stopClientHandling:
+		}
+		cancel()
+	}()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.tuneLimit(ctx, st)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// tuneLimit probes the given feedback's probe and sets its group's limit
+// accordingly.
+func (s *Server) tuneLimit(ctx context.Context, st *limitFeedbackState) {
+	s.lfmutex.RLock()
+	f := *st.feedback
+	s.lfmutex.RUnlock()
+
+	err := f.probe(ctx)
+	select {
+	case <-ctx.Done():
+		return
+	default:
+	}
+
+	current := s.limiter.GetLimit(f.Group)
+	limit := f.nextLimit(current, err == nil)
+
+	s.lfmutex.Lock()
+	st.feedback.LastProbe = time.Now()
+	st.feedback.Healthy = err == nil
+	st.feedback.Problem = ""
+	if err != nil {
+		st.feedback.Problem = err.Error()
+	}
+	st.feedback.Limit = limit
+	s.lfmutex.Unlock()
+
+	if limit == current {
+		return
+	}
+	if _, _, errs := s.getSetLimitGroup(fmt.Sprintf("%s:%d", f.Group, limit)); errs != nil {
+		s.Warn("failed to tune limit group", "group", f.Group, "err", errs)
+		return
+	}
+
+	change, from := "raised", fmt.Sprintf("%d", current)
+	if limit < current || current < 0 {
+		change = "lowered"
+	}
+	if current < 0 {
+		from = "unlimited"
+	}
+	health := "probe healthy"
+	if err != nil {
+		health = "probe unhealthy: " + err.Error()
+		s.Warn("limit group probe unhealthy", "group", f.Group, "limit", limit, "err", err)
+	} else {
+		s.Debug("limit group probe healthy", "group", f.Group, "limit", limit)
+	}
+	s.recordEvent(&Event{Type: EventTypeLimit, Msg: fmt.Sprintf("limit of %s %s from %s to %d; %s", f.Group, change, from, limit, health)})
+}
@@ -0,0 +1,97 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLimitFeedback(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("LimitFeedback can be validated", t, func() {
+		So((&LimitFeedback{Probe: "true", Max: 1}).Validate(), ShouldNotBeNil)
+		So((&LimitFeedback{Group: "a:1", Probe: "true", Max: 1}).Validate(), ShouldNotBeNil)
+		So((&LimitFeedback{Group: "a", Max: 1}).Validate(), ShouldNotBeNil)
+		So((&LimitFeedback{Group: "a", Probe: "true"}).Validate(), ShouldNotBeNil)
+		So((&LimitFeedback{Group: "a", Probe: "true", Min: 5, Max: 4}).Validate(), ShouldNotBeNil)
+		So((&LimitFeedback{Group: "a", Probe: "true", Max: 1, Interval: time.Millisecond}).Validate(), ShouldNotBeNil)
+		So((&LimitFeedback{Group: "a", Probe: "true", Max: 1, Backoff: 1}).Validate(), ShouldNotBeNil)
+		So((&LimitFeedback{Group: "a", Probe: "true", Max: 1}).Validate(), ShouldBeNil)
+
+		f := (&LimitFeedback{Group: "a", Probe: "true", Max: 1}).withDefaults()
+		So(f.Interval, ShouldEqual, LimitFeedbackInterval)
+		So(f.Timeout, ShouldEqual, LimitFeedbackTimeout)
+		So(f.Step, ShouldEqual, LimitFeedbackStep)
+		So(f.Backoff, ShouldEqual, LimitFeedbackBackoff)
+	})
+
+	Convey("Limits are raised additively and lowered multiplicatively within bounds", t, func() {
+		f := &LimitFeedback{Group: "a", Probe: "true", Min: 2, Max: 10, Step: 2, Backoff: 0.5}
+		So(f.nextLimit(-1, true), ShouldEqual, 10)
+		So(f.nextLimit(-1, false), ShouldEqual, 5)
+		So(f.nextLimit(4, true), ShouldEqual, 6)
+		So(f.nextLimit(9, true), ShouldEqual, 10)
+		So(f.nextLimit(8, false), ShouldEqual, 4)
+		So(f.nextLimit(3, false), ShouldEqual, 2)
+		So(f.nextLimit(0, true), ShouldEqual, 4)
+
+		f.Backoff = 0.9
+		So(f.nextLimit(5, false), ShouldEqual, 4)
+	})
+
+	Convey("Probes can be URLs or commands", t, func() {
+		ctx := context.Background()
+		healthy := true
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer ts.Close()
+
+		f := &LimitFeedback{Probe: ts.URL, Timeout: time.Second}
+		So(f.probe(ctx), ShouldBeNil)
+		healthy = false
+		So(f.probe(ctx), ShouldNotBeNil)
+
+		f.Probe = "true"
+		So(f.probe(ctx), ShouldBeNil)
+		f.Probe = "echo oops && false"
+		err := f.probe(ctx)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "oops")
+
+		f.Probe = "sleep 2"
+		f.Timeout = 50 * time.Millisecond
+		start := time.Now()
+		err = f.probe(ctx)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "took longer than 50ms")
+		So(time.Since(start), ShouldBeLessThan, time.Second)
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of load shedding, where the server
+// limits the resources it will spend on queries and web interface clients, so
+// that heavy use degrades into refused requests instead of running out of
+// memory.
+
+// ServerMaxConcurrentQueries is how many job queries (from clients, the REST
+// API and the web interface) the server will carry out at once; queries beyond
+// this are refused with ErrOverloaded. 0 means no limit.
+var ServerMaxConcurrentQueries = 32
+
+// ServerMaxJobsPerRequest is the most jobs the server will return in response
+// to a single request; requests that would return more are refused with
+// ErrTooManyJobs, and should be retried with a limit or a narrower query. The
+// same limit applies to the number of jobs added in a single request. 0
+// means no limit.
+var ServerMaxJobsPerRequest = 1000000
+
+// ServerMaxQueuedJobs is the most jobs the server will hold in its queue at
+// once; requests to add jobs that would take it over this are refused with
+// ErrTooManyJobs. 0 means no limit.
+var ServerMaxQueuedJobs = 10000000
+
+// ServerWebSocketBuffer is how many updates can be waiting to be sent to a
+// single web interface client before it is considered too slow to keep up and
+// is disconnected.
+var ServerWebSocketBuffer = 1000
+
+// queryMethods are the clientRequest Methods that are subject to
+// ServerMaxConcurrentQueries.
+var queryMethods = map[string]bool{
+	"getbc":          true,
+	"getbr":          true,
+	"getcomplete":    true,
+	"getevents":      true,
+	"getin":          true,
+	"getstatecounts": true,
+}
+
+// webQueryRequests are the web interface status requests that are subject to
+// ServerMaxConcurrentQueries.
+var webQueryRequests = map[string]bool{
+	"current": true,
+	"details": true,
+	"hosts":   true,
+	"export":  true,
+	"stateAt": true,
+}
+
+// queryLimiter limits how many queries can be carried out at once. A nil
+// queryLimiter has no limit.
+type queryLimiter chan struct{}
+
+// newQueryLimiter creates a queryLimiter that allows max concurrent queries, or
+// any number if max is less than 1.
+func newQueryLimiter(max int) queryLimiter {
+	if max < 1 {
+		return nil
+	}
+	return make(queryLimiter, max)
+}
+
+// acquire returns true if another query can be carried out now, in which case
+// you must call release() when it's done. Returns false without waiting if the
+// limit has been reached.
+func (ql queryLimiter) acquire() bool {
+	if ql == nil {
+		return true
+	}
+	select {
+	case ql <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release notes that a query allowed by acquire() has been carried out.
+func (ql queryLimiter) release() {
+	if ql == nil {
+		return
+	}
+	<-ql
+}
+
+// tooManyJobs returns true if n jobs are more than ServerMaxJobsPerRequest.
+func tooManyJobs(n int) bool {
+	return ServerMaxJobsPerRequest > 0 && n > ServerMaxJobsPerRequest
+}
+
+// tooManyJobsToAdd returns true if n jobs are too many to add in a single
+// request, or would take the number of queued jobs over ServerMaxQueuedJobs.
+func (s *Server) tooManyJobsToAdd(n int) bool {
+	if tooManyJobs(n) {
+		return true
+	}
+	return ServerMaxQueuedJobs > 0 && s.q.Stats().Items+n > ServerMaxQueuedJobs
+}
+
+// queueWebUpdate adds the given update to the given buffer of updates waiting
+// to be sent to a web interface client. Returns false if the buffer is full
+// because the client isn't keeping up.
+func queueWebUpdate(updates chan interface{}, update interface{}) bool {
+	select {
+	case updates <- update:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMemoryMonitoring(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("The memory of a process tree can be measured", t, func() {
+		cmd := exec.Command("bash", "-c", "sleep 2 & wait")
+		So(cmd.Start(), ShouldBeNil)
+		defer func() {
+			if errk := cmd.Process.Kill(); errk == nil {
+				errw := cmd.Wait()
+				So(errw, ShouldNotBeNil)
+			}
+		}()
+
+		rss, err := rssMemory(os.Getpid())
+		So(err, ShouldBeNil)
+		So(rss, ShouldBeGreaterThan, 0)
+
+		mem, err := currentMemory(os.Getpid())
+		So(err, ShouldBeNil)
+		So(mem, ShouldBeGreaterThan, 0)
+
+		_, err = currentMemory(cmd.Process.Pid)
+		So(err, ShouldBeNil)
+
+		_, err = rssMemory(-1)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Maxrss can be converted to MB", t, func() {
+		expected := 2048
+		if runtime.GOOS == "darwin" {
+			expected = 2
+		}
+		So(maxRSSMB(&syscall.Rusage{Maxrss: 2048 * 1024}), ShouldEqual, expected)
+	})
+}
@@ -0,0 +1,248 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of exporting incomplete jobs to a file
+// and importing them again, so that a workflow can be moved from one deployment
+// or manager host to another without regenerating its commands.
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/ugorji/go/codec"
+)
+
+// jobsExportVersion is the version of the format that ExportJobs() writes.
+const jobsExportVersion = 1
+
+// jobsExport is what ExportJobs() writes, gzipped and binc encoded.
+type jobsExport struct {
+	Version int
+	Jobs    []*Job
+}
+
+// ExportJobs writes the incomplete Jobs with the given RepGroup (or any
+// RepGroup containing it, if subStr is true; or all incomplete Jobs, if
+// repgroup is blank) to w, in a form that ImportJobs() can add to the queue of
+// this or another deployment. Everything about how the Jobs were defined (such
+// as their Requirements, Dependencies, Behaviours and environment variables) is
+// kept, but not anything about their past attempts to run.
+//
+// Returns the number of Jobs written.
+func (c *Client) ExportJobs(w io.Writer, repgroup string, subStr bool) (int, error) {
+	var jobs []*Job
+	var err error
+	if repgroup == "" {
+		jobs, err = c.GetIncomplete(0, "", false, true)
+	} else {
+		jobs, err = c.GetByRepGroup(repgroup, subStr, 0, "", false, true)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	export := &jobsExport{Version: jobsExportVersion}
+	for _, job := range jobs {
+		if job.State == JobStateComplete {
+			continue
+		}
+		export.Jobs = append(export.Jobs, job.definition())
+	}
+
+	gw := gzip.NewWriter(w)
+	err = codec.NewEncoder(gw, c.ch).Encode(export)
+	if errc := gw.Close(); errc != nil && err == nil {
+		err = errc
+	}
+	return len(export.Jobs), err
+}
+
+// ImportJobs adds the Jobs in r, as written by ExportJobs(), to the queue. Each
+// Job gets the environment variables it was originally added with. Jobs that
+// other imported Jobs depend on are added first, so that the dependencies are
+// kept. Jobs that are already in the queue or that have already completed are
+// not added again, and are counted in the returned existed.
+func (c *Client) ImportJobs(r io.Reader) (added, existed int, err error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, 0, fmt.Errorf("not a wr export: %w", err)
+	}
+	export := &jobsExport{}
+	err = codec.NewDecoder(gr, c.ch).Decode(export)
+	if errc := gr.Close(); errc != nil && err == nil {
+		err = errc
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("not a wr export: %w", err)
+	}
+	if export.Version != jobsExportVersion {
+		return 0, 0, fmt.Errorf("wr export version %d is not supported", export.Version)
+	}
+
+	for _, batch := range importBatches(export.Jobs) {
+		jobs := batch.jobs
+		if err = validateBehaviours(jobs); err != nil {
+			return added, existed, err
+		}
+		resp, errr := c.request(&clientRequest{Method: "add", Jobs: jobs, Env: batch.env, IgnoreComplete: true, SecretGrants: c.grants()})
+		if errr != nil {
+			return added, existed, errr
+		}
+		added += resp.Added
+		existed += resp.Existed
+	}
+	return added, existed, nil
+}
+
+// importBatch is a set of jobs that ImportJobs() can add together, since they
+// have the same compressed environment variables.
+type importBatch struct {
+	env  []byte
+	jobs []*Job
+}
+
+// importBatches splits the given jobs in to batches that have the same
+// environment, ordered such that jobs come after the jobs they depend on by
+// essence. (Dependencies on DepGroups and RepGroups are resolved whatever the
+// order jobs are added in.) The jobs' EnvC is moved in to their batch.
+func importBatches(jobs []*Job) []*importBatch {
+	byKey := make(map[string]*Job, len(jobs))
+	for _, job := range jobs {
+		byKey[job.Key()] = job
+	}
+
+	// a job's depth is 1 more than the deepest job it depends on
+	depths := make(map[string]int, len(jobs))
+	var depth func(job *Job, seen map[string]bool) int
+	depth = func(job *Job, seen map[string]bool) int {
+		key := job.Key()
+		if d, done := depths[key]; done {
+			return d
+		}
+		seen[key] = true
+		d := 0
+		for _, dep := range job.Dependencies {
+			if dep.Essence == nil {
+				continue
+			}
+			parent, found := byKey[dep.Essence.Key()]
+			if !found || seen[parent.Key()] {
+				continue
+			}
+			if pd := depth(parent, seen) + 1; pd > d {
+				d = pd
+			}
+		}
+		delete(seen, key)
+		depths[key] = d
+		return d
+	}
+
+	var levels [][]*importBatch
+	index := make(map[int]map[string]*importBatch)
+	for _, job := range jobs {
+		d := depth(job, make(map[string]bool))
+		for len(levels) <= d {
+			levels = append(levels, nil)
+		}
+		if index[d] == nil {
+			index[d] = make(map[string]*importBatch)
+		}
+		env := string(job.EnvC)
+		batch, exists := index[d][env]
+		if !exists {
+			batch = &importBatch{env: job.EnvC}
+			index[d][env] = batch
+			levels[d] = append(levels[d], batch)
+		}
+		job.EnvC = nil
+		batch.jobs = append(batch.jobs, job)
+	}
+
+	var batches []*importBatch
+	for _, level := range levels {
+		batches = append(batches, level...)
+	}
+	return batches
+}
+
+// definition returns a new Job with the same definition as this one (everything
+// that was, or could have been, supplied when it was added), along with its
+// EnvC, but none of the properties it gained from being run.
+func (j *Job) definition() *Job {
+	j.RLock()
+	defer j.RUnlock()
+	job := &Job{
+		Cmd:                j.Cmd,
+		Cwd:                j.Cwd,
+		CwdMatters:         j.CwdMatters,
+		ChangeHome:         j.ChangeHome,
+		CwdTemplate:        j.CwdTemplate,
+		CwdBase:            j.CwdBase,
+		CwdLink:            j.CwdLink,
+		CleanEnv:           j.CleanEnv,
+		RepGroup:           j.RepGroup,
+		ReqGroup:           j.ReqGroup,
+		Override:           j.Override,
+		Priority:           j.Priority,
+		Retries:            j.Retries,
+		RetryBudgets:       j.RetryBudgets,
+		Affinity:           j.Affinity,
+		MaxPerHost:         j.MaxPerHost,
+		LimitGroups:        j.LimitGroups,
+		DepGroups:          j.DepGroups,
+		Dependencies:       j.Dependencies,
+		AtomicGroup:        j.AtomicGroup,
+		Behaviours:         j.Behaviours,
+		MountConfigs:       j.MountConfigs,
+		InputFiles:         j.InputFiles,
+		InputCheckOnRunner: j.InputCheckOnRunner,
+		Cacheable:          j.Cacheable,
+		OutputFiles:        j.OutputFiles,
+		OutputMinSize:      j.OutputMinSize,
+		OutputCheckCmd:     j.OutputCheckCmd,
+		OutputChecksums:    j.OutputChecksums,
+		IRODSInputs:        j.IRODSInputs,
+		IRODSCollection:    j.IRODSCollection,
+		IRODSMetadata:      j.IRODSMetadata,
+		NetworkAccess:      j.NetworkAccess,
+		Proxy:              j.Proxy,
+		RefAssets:          j.RefAssets,
+		BsubMode:           j.BsubMode,
+		MonitorDocker:      j.MonitorDocker,
+		RunAs:              j.RunAs,
+		Shell:              j.Shell,
+		Nice:               j.Nice,
+		IONice:             j.IONice,
+		OOMScoreAdj:        j.OOMScoreAdj,
+		Umask:              j.Umask,
+		Group:              j.Group,
+		Secrets:            j.Secrets,
+		ReportCmd:          j.ReportCmd,
+		Queue:              j.Queue,
+		EnvC:               j.EnvC,
+		EnvOverride:        j.EnvOverride,
+	}
+	if j.Requirements != nil {
+		job.Requirements = j.Requirements.Clone()
+	}
+	return job
+}
@@ -0,0 +1,85 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMigrate(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("A job's definition excludes how it ran", t, func() {
+		req := &scheduler.Requirements{RAM: 100, Time: time.Minute, Cores: 1}
+		job := &Job{
+			Cmd: "a", Cwd: "/tmp", RepGroup: "rg", Requirements: req, Override: 2, Retries: 3,
+			EnvC: []byte("env"), State: JobStateBuried, Exitcode: 1, Attempts: 4, Host: "host",
+		}
+		def := job.definition()
+		So(def.Key(), ShouldEqual, job.Key())
+		So(def.RepGroup, ShouldEqual, "rg")
+		So(def.Override, ShouldEqual, 2)
+		So(def.Retries, ShouldEqual, 3)
+		So(def.Requirements.RAM, ShouldEqual, 100)
+		So(def.Requirements, ShouldNotPointTo, req)
+		So(string(def.EnvC), ShouldEqual, "env")
+		So(def.State, ShouldBeEmpty)
+		So(def.Exitcode, ShouldEqual, 0)
+		So(def.Attempts, ShouldEqual, 0)
+		So(def.Host, ShouldBeEmpty)
+	})
+
+	Convey("Imported jobs are batched by environment after the jobs they depend on", t, func() {
+		job := func(cmd, env string, deps ...string) *Job {
+			var dependencies Dependencies
+			for _, dep := range deps {
+				dependencies = append(dependencies, NewEssenceDependency(dep, ""))
+			}
+			return &Job{Cmd: cmd, EnvC: []byte(env), Dependencies: dependencies}
+		}
+		jobs := []*Job{
+			job("c", "1", "b"),
+			job("b", "2", "a"),
+			job("a", "1"),
+			job("d", "1"),
+			job("e", "2", "a", "elsewhere"),
+			job("f", "1", "g"),
+			job("g", "1", "f"),
+		}
+
+		batches := importBatches(jobs)
+		cmds := make([][]string, len(batches))
+		envs := make([]string, len(batches))
+		for i, batch := range batches {
+			envs[i] = string(batch.env)
+			for _, job := range batch.jobs {
+				cmds[i] = append(cmds[i], job.Cmd)
+				So(job.EnvC, ShouldBeNil)
+			}
+		}
+		So(envs, ShouldResemble, []string{"1", "2", "1", "1"})
+		So(cmds, ShouldResemble, [][]string{{"a", "d", "g"}, {"b", "e"}, {"f"}, {"c"}})
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of job network requirements, where a
+// job declares the network access it needs, so that runners can fail it
+// quickly with a clear reason if that access is absent, and cloud schedulers
+// can spawn servers in suitable security groups.
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// NetworkInternet is the NetworkAccess value that declares that a Job needs
+// access to the internet.
+const NetworkInternet = "internet"
+
+// NetworkInternetAddress is the host:port that is dialled to check that a Job
+// needing NetworkInternet really has internet access. Jobs with a Proxy dial
+// that instead.
+var NetworkInternetAddress = "www.google.com:443"
+
+// ClientNetworkCheckTimeout is how long a client waits to connect to each of
+// the addresses a Job needs access to, before deciding it is unreachable.
+var ClientNetworkCheckTimeout = 10 * time.Second
+
+// validateNetworkAccess checks that the given network needs are each either
+// NetworkInternet or a host:port.
+func validateNetworkAccess(needs []string) error {
+	for _, need := range needs {
+		if need == NetworkInternet {
+			continue
+		}
+		if err := validateHostPort(need); err != nil {
+			return fmt.Errorf("network access [%s] is not %s or host:port: %w", need, NetworkInternet, err)
+		}
+	}
+	return nil
+}
+
+// validateHostPort checks that the given address is a host:port with a valid
+// port number.
+func validateHostPort(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		return fmt.Errorf("no host")
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("invalid port %s", port)
+	}
+	return nil
+}
+
+// validateProxy checks that the given proxy is a URL with a host.
+func validateProxy(proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return fmt.Errorf("proxy [%s] is not a valid URL: %w", proxy, err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("proxy [%s] has no host", proxy)
+	}
+	return nil
+}
+
+// proxyAddress returns the host:port of the Job's Proxy, defaulting the port
+// based on its scheme.
+func (j *Job) proxyAddress() (string, error) {
+	u, err := url.Parse(j.Proxy)
+	if err != nil {
+		return "", err
+	}
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+	port := "80"
+	if u.Scheme == "https" {
+		port = "443"
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// proxyEnv returns the environment variables that make commands use the Job's
+// Proxy, or nil if it doesn't have one.
+func (j *Job) proxyEnv() []string {
+	if j.Proxy == "" {
+		return nil
+	}
+	var env []string
+	for _, name := range []string{"http_proxy", "https_proxy", "HTTP_PROXY", "HTTPS_PROXY"} {
+		env = append(env, name+"="+j.Proxy)
+	}
+	return env
+}
+
+// unreachableNetworks tries to connect to each of the Job's NetworkAccess
+// addresses concurrently, and returns a description of each one that couldn't
+// be reached within ClientNetworkCheckTimeout.
+func (j *Job) unreachableNetworks() []string {
+	problems := make(chan string, len(j.NetworkAccess))
+	for _, need := range j.NetworkAccess {
+		go func(need string) {
+			address := need
+			if need == NetworkInternet {
+				address = NetworkInternetAddress
+				if j.Proxy != "" {
+					var err error
+					address, err = j.proxyAddress()
+					if err != nil {
+						problems <- fmt.Sprintf("%s: bad proxy: %s", need, err)
+						return
+					}
+				}
+			}
+
+			conn, err := net.DialTimeout("tcp", address, ClientNetworkCheckTimeout)
+			if err != nil {
+				problems <- fmt.Sprintf("%s: %s", need, err)
+				return
+			}
+			conn.Close()
+			problems <- ""
+		}(need)
+	}
+
+	var unreachable []string
+	for range j.NetworkAccess {
+		if problem := <-problems; problem != "" {
+			unreachable = append(unreachable, problem)
+		}
+	}
+	return unreachable
+}
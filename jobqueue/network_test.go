@@ -0,0 +1,136 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	jqs "github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNetworkAccess(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Network needs and proxies are validated", t, func() {
+		So(validateNetworkAccess([]string{NetworkInternet, "db.example.com:5432", "[::1]:80"}), ShouldBeNil)
+		So(validateNetworkAccess([]string{"db.example.com"}), ShouldNotBeNil)
+		So(validateNetworkAccess([]string{":80"}), ShouldNotBeNil)
+		So(validateNetworkAccess([]string{"host:http"}), ShouldNotBeNil)
+		So(validateNetworkAccess([]string{"host:70000"}), ShouldNotBeNil)
+		So(validateProxy(""), ShouldBeNil)
+		So(validateProxy("http://proxy.example.com:3128"), ShouldBeNil)
+		So(validateProxy("proxy.example.com"), ShouldNotBeNil)
+	})
+
+	Convey("Jobs get proxy environment variables", t, func() {
+		job := &Job{}
+		So(job.proxyEnv(), ShouldBeNil)
+		job.Proxy = "http://proxy:3128"
+		So(job.proxyEnv(), ShouldResemble, []string{"http_proxy=http://proxy:3128", "https_proxy=http://proxy:3128", "HTTP_PROXY=http://proxy:3128", "HTTPS_PROXY=http://proxy:3128"})
+
+		addr, err := job.proxyAddress()
+		So(err, ShouldBeNil)
+		So(addr, ShouldEqual, "proxy:3128")
+		job.Proxy = "https://proxy"
+		addr, err = job.proxyAddress()
+		So(err, ShouldBeNil)
+		So(addr, ShouldEqual, "proxy:443")
+	})
+
+	Convey("Unreachable network needs are reported", t, func() {
+		origTimeout := ClientNetworkCheckTimeout
+		ClientNetworkCheckTimeout = 2 * time.Second
+		defer func() {
+			ClientNetworkCheckTimeout = origTimeout
+		}()
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		defer ln.Close()
+		open := ln.Addr().String()
+
+		closedLn, err := net.Listen("tcp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		closed := closedLn.Addr().String()
+		closedLn.Close()
+
+		job := &Job{NetworkAccess: []string{open}}
+		So(job.unreachableNetworks(), ShouldBeEmpty)
+
+		job.NetworkAccess = []string{open, closed}
+		unreachable := job.unreachableNetworks()
+		So(len(unreachable), ShouldEqual, 1)
+		So(unreachable[0], ShouldStartWith, closed+": ")
+
+		job.NetworkAccess = []string{NetworkInternet}
+		job.Proxy = "http://" + open
+		So(job.unreachableNetworks(), ShouldBeEmpty)
+		job.Proxy = "http://" + closed
+		unreachable = job.unreachableNetworks()
+		So(len(unreachable), ShouldEqual, 1)
+		So(unreachable[0], ShouldStartWith, NetworkInternet+": ")
+	})
+
+	Convey("JobViaJSON.Convert() sets network requirements", t, func() {
+		jvj := &JobViaJSON{Cmd: "true", Networks: []string{"internet", "db:5432"}, Proxy: "http://proxy:3128"}
+		job, err := jvj.Convert(&JobDefaults{})
+		So(err, ShouldBeNil)
+		So(job.NetworkAccess, ShouldResemble, []string{"internet", "db:5432"})
+		So(job.Proxy, ShouldEqual, "http://proxy:3128")
+		So(job.Requirements.Other[jqs.NetworkOtherKey], ShouldEqual, "db:5432,internet")
+
+		jvj.Networks = []string{"db"}
+		_, err = jvj.Convert(&JobDefaults{})
+		So(err, ShouldNotBeNil)
+		So(strings.Contains(err.Error(), "network access [db]"), ShouldBeTrue)
+	})
+}
+
+func TestDualStackAddr(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Manager addresses that need no resolving are left alone", t, func() {
+		So(dualStackAddr("127.0.0.1:1234", time.Second), ShouldEqual, "127.0.0.1:1234")
+		So(dualStackAddr("[::1]:1234", time.Second), ShouldEqual, "[::1]:1234")
+		So(dualStackAddr("no-port", time.Second), ShouldEqual, "no-port")
+		So(dualStackAddr("wr-no-such-host.invalid:1234", time.Second), ShouldEqual, "wr-no-such-host.invalid:1234")
+	})
+
+	Convey("A dual-stack manager is connected to by IP address", t, func() {
+		ln, err := net.Listen("tcp", "localhost:0")
+		So(err, ShouldBeNil)
+		defer ln.Close()
+		_, port, err := net.SplitHostPort(ln.Addr().String())
+		So(err, ShouldBeNil)
+
+		addr := dualStackAddr(net.JoinHostPort("localhost", port), time.Second)
+		host, gotPort, err := net.SplitHostPort(addr)
+		So(err, ShouldBeNil)
+		So(gotPort, ShouldEqual, port)
+		So(host == "localhost" || net.ParseIP(host).IsLoopback(), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,710 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of notifications, where the server
+// tells people (via Slack, Microsoft Teams or a generic webhook) when the
+// events it records match the rules they configured, such as a lot of jobs in
+// a RepGroup failing.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/inconshreveable/log15"
+)
+
+// NotifySinkKind is the kind of service a NotifySink sends notifications to.
+type NotifySinkKind string
+
+// NotifySinkKind* are the kinds of NotifySink. Slack and Teams sinks are
+// incoming webhook URLs of those services, and are sent the Notification's
+// Message. Webhook sinks are sent the whole Notification as JSON.
+const (
+	NotifySinkSlack   NotifySinkKind = "slack"
+	NotifySinkTeams   NotifySinkKind = "teams"
+	NotifySinkWebhook NotifySinkKind = "webhook"
+)
+
+// NotifyWindow is the period that NotifyRules without a "within" count events
+// over.
+var NotifyWindow = 1 * time.Hour
+
+// NotifyInterval is the least time between notifications due to the same
+// NotifyRule; matching events in between are included in the next one.
+var NotifyInterval = 10 * time.Minute
+
+// NotifySinkInterval is the least time between notifications sent to the same
+// NotifySink.
+var NotifySinkInterval = 1 * time.Second
+
+// NotifyRetries is how many times sending a notification is retried if it
+// fails, with the wait in between starting at NotifyRetryWait and doubling
+// each time.
+var NotifyRetries = 5
+
+// NotifyRetryWait is the initial wait between retries of sending a
+// notification.
+var NotifyRetryWait = 1 * time.Second
+
+const (
+	// notifyQueueSize is how many notifications can be waiting to be sent to
+	// a sink before new ones are dropped.
+	notifyQueueSize = 100
+
+	// notifyMaxEvents is how many of the events that triggered a notification
+	// are included in it.
+	notifyMaxEvents = 10
+
+	// notifyMaxRepGroups is how many of the RepGroups of the events that
+	// triggered a notification are named in its message.
+	notifyMaxRepGroups = 5
+
+	// notifyCheckInterval is how often rules that were waiting on
+	// NotifyInterval are checked again.
+	notifyCheckInterval = 5 * time.Second
+
+	// notifyTimeout is how long we wait for a sink to accept a notification.
+	notifyTimeout = 10 * time.Second
+)
+
+// NotifySink is somewhere notifications are sent.
+type NotifySink struct {
+	Name string
+	Kind NotifySinkKind
+	URL  string
+}
+
+// NotifyRule describes the events that should result in a notification being
+// sent to some NotifySinks.
+type NotifyRule struct {
+	// Sinks are the names of the NotifySinks to notify.
+	Sinks []string
+
+	// RepGroup, if set, limits the rule to events about jobs in that RepGroup,
+	// or in RepGroups it matches if RepGroupRegexp is true.
+	RepGroup       string
+	RepGroupRegexp bool
+
+	// Type is the type of event the rule is about.
+	Type EventType
+
+	// A notification is sent when more than Threshold jobs (or events, for
+	// events not about jobs) matched in the last Window.
+	Threshold int
+	Window    time.Duration
+
+	re *regexp.Regexp
+}
+
+// NotifyConfig holds the NotifySinks and NotifyRules that say who the server
+// should notify about what.
+type NotifyConfig struct {
+	Sinks map[string]*NotifySink
+	Rules []*NotifyRule
+}
+
+// Notification is what is sent to NotifySinks when a NotifyRule is triggered.
+// Webhook sinks are sent it as JSON.
+type Notification struct {
+	Time      time.Time `json:"time"`
+	Rule      string    `json:"rule"`
+	Message   string    `json:"message"`
+	Count     int       `json:"count"`
+	RepGroups []string  `json:"rep_grps,omitempty"`
+	Events    []*Event  `json:"events,omitempty"`
+}
+
+// ParseNotifyConfig parses notification config, where each line (other than
+// blank lines and those starting with #) declares either a sink, like:
+//
+//	sink <name> <slack|teams|webhook> <url>
+//
+// or a rule, like:
+//
+//	notify <sink>[,<sink>...] when [repgroup <~|=> '<name>'] <fails|has <type> events> [> <n>] [within <duration>]
+//
+// where ~ means name is a regular expression, and type is an EventType. eg.:
+//
+//	sink #pipelines slack https://hooks.slack.com/services/T0/B0/X
+//	notify #pipelines when repgroup ~ 'release-.*' fails > 10
+//	notify #pipelines when has scheduler_error events within 10m
+func ParseNotifyConfig(text string) (*NotifyConfig, error) {
+	nc := &NotifyConfig{Sinks: make(map[string]*NotifySink)}
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	var rules []string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		switch strings.SplitN(line, " ", 2)[0] {
+		case "sink":
+			sink, err := parseNotifySink(line)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := nc.Sinks[sink.Name]; exists {
+				return nil, fmt.Errorf("notification sink [%s] is declared more than once", sink.Name)
+			}
+			nc.Sinks[sink.Name] = sink
+		case "notify":
+			rules = append(rules, line)
+		default:
+			return nil, fmt.Errorf("notification config line [%s] is neither a sink nor a notify rule", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// (rules can refer to sinks declared after them)
+	for _, line := range rules {
+		rule, err := ParseNotifyRule(line)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range rule.Sinks {
+			if _, exists := nc.Sinks[name]; !exists {
+				return nil, fmt.Errorf("notify rule [%s] refers to undeclared sink [%s]", line, name)
+			}
+		}
+		nc.Rules = append(nc.Rules, rule)
+	}
+	return nc, nil
+}
+
+// NotifyConfigFromFile reads the given file and returns its contents parsed by
+// ParseNotifyConfig().
+func NotifyConfigFromFile(path string) (*NotifyConfig, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	nc, err := ParseNotifyConfig(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("notification config file %s could not be parsed: %w", path, err)
+	}
+	return nc, nil
+}
+
+// parseNotifySink parses a "sink <name> <kind> <url>" line.
+func parseNotifySink(line string) (*NotifySink, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("notification sink [%s] is not in the form: sink <name> <slack|teams|webhook> <url>", line)
+	}
+	sink := &NotifySink{Name: fields[1], Kind: NotifySinkKind(fields[2]), URL: fields[3]}
+	switch sink.Kind {
+	case NotifySinkSlack, NotifySinkTeams, NotifySinkWebhook:
+	default:
+		return nil, fmt.Errorf("notification sink [%s] has unknown kind [%s]", sink.Name, sink.Kind)
+	}
+	if !strings.HasPrefix(sink.URL, "http://") && !strings.HasPrefix(sink.URL, "https://") {
+		return nil, fmt.Errorf("notification sink [%s] URL must be http(s)", sink.Name)
+	}
+	return sink, nil
+}
+
+// ParseNotifyRule parses a single "notify ..." rule, as described for
+// ParseNotifyConfig(). It doesn't check that the sinks exist.
+func ParseNotifyRule(line string) (*NotifyRule, error) {
+	bad := func(problem string) (*NotifyRule, error) {
+		return nil, fmt.Errorf("notify rule [%s] %s", line, problem)
+	}
+
+	tokens, err := notifyTokens(line)
+	if err != nil {
+		return bad(err.Error())
+	}
+	if len(tokens) == 0 || tokens[0] != "notify" {
+		return bad("must start with notify")
+	}
+	tokens = tokens[1:]
+
+	rule := &NotifyRule{Window: NotifyWindow}
+	for len(tokens) > 0 && tokens[0] != "when" {
+		for _, name := range strings.Split(tokens[0], ",") {
+			if name != "" {
+				rule.Sinks = append(rule.Sinks, name)
+			}
+		}
+		tokens = tokens[1:]
+	}
+	if len(rule.Sinks) == 0 {
+		return bad("names no sinks")
+	}
+	if len(tokens) == 0 {
+		return bad("is missing when")
+	}
+	tokens = tokens[1:]
+
+	if len(tokens) > 0 && tokens[0] == "repgroup" {
+		if len(tokens) < 3 || (tokens[1] != "~" && tokens[1] != "=") {
+			return bad("must give repgroup like: repgroup ~ 'regexp' or repgroup = 'name'")
+		}
+		rule.RepGroup = tokens[2]
+		rule.RepGroupRegexp = tokens[1] == "~"
+		tokens = tokens[3:]
+	}
+
+	switch {
+	case len(tokens) > 0 && tokens[0] == "fails":
+		rule.Type = EventTypeBury
+		tokens = tokens[1:]
+	case len(tokens) > 2 && tokens[0] == "has" && tokens[2] == "events":
+		rule.Type = EventType(tokens[1])
+		tokens = tokens[3:]
+	default:
+		return bad("must say what to notify about: fails, or has <type> events")
+	}
+
+	for len(tokens) > 0 {
+		if len(tokens) < 2 {
+			return bad(fmt.Sprintf("has an incomplete %s", tokens[0]))
+		}
+		switch tokens[0] {
+		case ">":
+			n, errp := strconv.Atoi(tokens[1])
+			if errp != nil || n < 0 {
+				return bad("must have a whole number after >")
+			}
+			rule.Threshold = n
+		case "within":
+			d, errp := time.ParseDuration(tokens[1])
+			if errp != nil || d <= 0 {
+				return bad("must have a positive duration, eg. 30m, after within")
+			}
+			rule.Window = d
+		default:
+			return bad(fmt.Sprintf("has unexpected [%s]", tokens[0]))
+		}
+		tokens = tokens[2:]
+	}
+
+	if err = rule.Validate(); err != nil {
+		return bad(err.Error())
+	}
+	return rule, nil
+}
+
+// notifyTokens splits a rule in to whitespace separated tokens, where text in
+// single or double quotes is a single token, and > is always its own token.
+func notifyTokens(line string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	var quote rune
+	inToken := false
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, current.String())
+			current.Reset()
+			inToken = false
+		}
+	}
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inToken = true
+		case r == '>':
+			flush()
+			tokens = append(tokens, ">")
+		case r == ' ' || r == '\t':
+			flush()
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("has an unterminated quote")
+	}
+	flush()
+	return tokens, nil
+}
+
+// Validate checks the rule makes sense, compiling its RepGroup if it's a
+// regular expression.
+func (nr *NotifyRule) Validate() error {
+	if len(nr.Sinks) == 0 {
+		return fmt.Errorf("names no sinks")
+	}
+	if !notifiableEventType(nr.Type) {
+		return fmt.Errorf("can't notify about [%s] events", nr.Type)
+	}
+	if nr.Window <= 0 {
+		return fmt.Errorf("window must be positive")
+	}
+	if nr.Threshold < 0 {
+		return fmt.Errorf("threshold can't be negative")
+	}
+	if nr.RepGroupRegexp {
+		re, err := regexp.Compile("^(?:" + nr.RepGroup + ")$")
+		if err != nil {
+			return fmt.Errorf("repgroup regular expression is invalid: %w", err)
+		}
+		nr.re = re
+	}
+	return nil
+}
+
+// notifiableEventType returns true if the given EventType is one whose events
+// are sent to subscribers, and so can be notified about.
+func notifiableEventType(et EventType) bool {
+	switch et {
+	case EventTypeAdd, EventTypeStart, EventTypeManualRun, EventTypeBury, EventTypeBehaviour,
+		EventTypeRetry, EventTypeRemove, EventTypeRestore, EventTypeKill, EventTypePause,
+		EventTypeResume, EventTypeSchedulerError, EventTypeScaleUp, EventTypeScaleDown,
+		EventTypeBudget, EventTypeLimit, EventTypeAnnotate:
+		return true
+	}
+	return false
+}
+
+// String returns the rule in the form that ParseNotifyRule() parses.
+func (nr *NotifyRule) String() string {
+	var b strings.Builder
+	b.WriteString("notify " + strings.Join(nr.Sinks, ",") + " when ")
+	if nr.RepGroup != "" {
+		op := "="
+		if nr.RepGroupRegexp {
+			op = "~"
+		}
+		fmt.Fprintf(&b, "repgroup %s '%s' ", op, nr.RepGroup)
+	}
+	if nr.Type == EventTypeBury {
+		b.WriteString("fails")
+	} else {
+		fmt.Fprintf(&b, "has %s events", nr.Type)
+	}
+	if nr.Threshold > 0 {
+		fmt.Fprintf(&b, " > %d", nr.Threshold)
+	}
+	if nr.Window != NotifyWindow {
+		fmt.Fprintf(&b, " within %s", nr.Window)
+	}
+	return b.String()
+}
+
+// matches returns true if the given event is one the rule is about.
+func (nr *NotifyRule) matches(event *Event) bool {
+	if event.Type != nr.Type {
+		return false
+	}
+	switch {
+	case nr.RepGroup == "":
+		return true
+	case nr.re != nil:
+		return nr.re.MatchString(event.RepGroup)
+	default:
+		return event.RepGroup == nr.RepGroup
+	}
+}
+
+// describe returns a description of the given number of matching events.
+func (nr *NotifyRule) describe(count int) string {
+	if nr.Type == EventTypeBury {
+		if count == 1 {
+			return "1 job failed"
+		}
+		return fmt.Sprintf("%d jobs failed", count)
+	}
+	if count == 1 {
+		return fmt.Sprintf("1 %s event", nr.Type)
+	}
+	return fmt.Sprintf("%d %s events", count, nr.Type)
+}
+
+// notifyRuleState tracks the recent events that matched a rule.
+type notifyRuleState struct {
+	rule   *NotifyRule
+	events []*Event
+	next   time.Time
+}
+
+// count returns the number of jobs (or events, for events not about jobs) the
+// matching events were about.
+func (nrs *notifyRuleState) count() int {
+	count := 0
+	for _, event := range nrs.events {
+		if event.Count > 0 {
+			count += event.Count
+		} else {
+			count++
+		}
+	}
+	return count
+}
+
+// check returns a Notification if the rule has now been triggered.
+func (nrs *notifyRuleState) check(now time.Time, prefix string) *Notification {
+	if now.Before(nrs.next) {
+		return nil
+	}
+
+	// only count events in the window, unless we held off notifying about
+	// them due to NotifyInterval
+	if nrs.next.IsZero() || now.Sub(nrs.next) > nrs.rule.Window {
+		cutoff := now.Add(-nrs.rule.Window)
+		i := sort.Search(len(nrs.events), func(i int) bool {
+			return !nrs.events[i].Time.Before(cutoff)
+		})
+		nrs.events = nrs.events[i:]
+	}
+
+	count := nrs.count()
+	if count <= nrs.rule.Threshold || count == 0 {
+		return nil
+	}
+
+	n := &Notification{Time: now, Rule: nrs.rule.String(), Count: count}
+	seen := make(map[string]bool)
+	var last string
+	for _, event := range nrs.events {
+		if event.RepGroup != "" && !seen[event.RepGroup] {
+			seen[event.RepGroup] = true
+			n.RepGroups = append(n.RepGroups, event.RepGroup)
+		}
+		if event.Msg != "" {
+			last = event.Msg
+		}
+	}
+	sort.Strings(n.RepGroups)
+	if len(nrs.events) > notifyMaxEvents {
+		n.Events = nrs.events[len(nrs.events)-notifyMaxEvents:]
+	} else {
+		n.Events = nrs.events
+	}
+
+	msg := prefix + nrs.rule.describe(count)
+	if len(n.RepGroups) > 0 {
+		groups := n.RepGroups
+		extra := ""
+		if len(groups) > notifyMaxRepGroups {
+			extra = fmt.Sprintf(" and %d more", len(groups)-notifyMaxRepGroups)
+			groups = groups[:notifyMaxRepGroups]
+		}
+		msg += " in " + strings.Join(groups, ", ") + extra
+	}
+	if nrs.next.IsZero() {
+		msg += fmt.Sprintf(" in the last %s", nrs.rule.Window)
+	} else {
+		msg += fmt.Sprintf(" since %s", nrs.events[0].Time.Format(time.RFC3339))
+	}
+	if last != "" {
+		msg += " (most recently: " + last + ")"
+	}
+	n.Message = msg
+
+	nrs.events = nil
+	nrs.next = now.Add(NotifyInterval)
+	return n
+}
+
+// notifier checks events against NotifyRules and sends the resulting
+// notifications to NotifySinks.
+type notifier struct {
+	rules   []*notifyRuleState
+	senders map[string]*notifySender
+	prefix  string
+	log15.Logger
+}
+
+// newNotifier returns a notifier for the given config, whose messages start
+// with the given prefix.
+func newNotifier(nc *NotifyConfig, prefix string, logger log15.Logger) *notifier {
+	n := &notifier{
+		senders: make(map[string]*notifySender),
+		prefix:  prefix,
+		Logger:  logger.New("notifications", true),
+	}
+	for _, rule := range nc.Rules {
+		n.rules = append(n.rules, &notifyRuleState{rule: rule})
+	}
+	client := &http.Client{Timeout: notifyTimeout}
+	for name, sink := range nc.Sinks {
+		n.senders[name] = &notifySender{
+			sink:   sink,
+			client: client,
+			queue:  make(chan *Notification, notifyQueueSize),
+			Logger: n.Logger.New("sink", name),
+		}
+	}
+	return n
+}
+
+// run checks the events received on the given channel against our rules,
+// sending notifications, until the stop channel is closed.
+func (n *notifier) run(events chan *Event, stop chan bool) {
+	defer internal.LogPanic(n.Logger, "notifications", true)
+
+	for _, sender := range n.senders {
+		go sender.run(stop)
+	}
+
+	ticker := time.NewTicker(notifyCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case event := <-events:
+			n.observe(event)
+			n.check(time.Now())
+		case <-ticker.C:
+			n.check(time.Now())
+		case <-stop:
+			return
+		}
+	}
+}
+
+// observe notes the given event against the rules it matches.
+func (n *notifier) observe(event *Event) {
+	for _, nrs := range n.rules {
+		if nrs.rule.matches(event) {
+			nrs.events = append(nrs.events, event)
+		}
+	}
+}
+
+// check sends notifications for any rules that have been triggered.
+func (n *notifier) check(now time.Time) {
+	for _, nrs := range n.rules {
+		notification := nrs.check(now, n.prefix)
+		if notification == nil {
+			continue
+		}
+		for _, name := range nrs.rule.Sinks {
+			n.senders[name].enqueue(notification)
+		}
+	}
+}
+
+// notifySender sends notifications to a single sink, one at a time, no more
+// often than NotifySinkInterval.
+type notifySender struct {
+	sink   *NotifySink
+	client *http.Client
+	queue  chan *Notification
+	log15.Logger
+}
+
+// enqueue queues the notification to be sent. If too many are already waiting,
+// it is dropped.
+func (ns *notifySender) enqueue(n *Notification) {
+	select {
+	case ns.queue <- n:
+	default:
+		ns.Warn("too many notifications waiting to be sent; dropped one", "msg", n.Message)
+	}
+}
+
+// run sends queued notifications until the stop channel is closed.
+func (ns *notifySender) run(stop chan bool) {
+	defer internal.LogPanic(ns.Logger, "notification sending", true)
+	for {
+		select {
+		case n := <-ns.queue:
+			ns.deliver(n, stop)
+			select {
+			case <-time.After(NotifySinkInterval):
+			case <-stop:
+				return
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// deliver sends the notification, retrying on failure.
+func (ns *notifySender) deliver(n *Notification, stop chan bool) {
+	body, err := ns.payload(n)
+	if err != nil {
+		ns.Warn("could not encode notification", "err", err)
+		return
+	}
+
+	wait := NotifyRetryWait
+	for attempt := 0; ; attempt++ {
+		retry, err := ns.send(body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= NotifyRetries {
+			ns.Warn("failed to send notification", "err", err, "msg", n.Message)
+			return
+		}
+		select {
+		case <-time.After(wait):
+		case <-stop:
+			return
+		}
+		wait *= 2
+	}
+}
+
+// payload returns what should be POSTed to our sink for the notification.
+func (ns *notifySender) payload(n *Notification) ([]byte, error) {
+	switch ns.sink.Kind {
+	case NotifySinkSlack:
+		return json.Marshal(map[string]string{"text": n.Message})
+	case NotifySinkTeams:
+		return json.Marshal(map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  n.Message,
+			"text":     n.Message,
+		})
+	default:
+		return json.Marshal(n)
+	}
+}
+
+// send POSTs the body to our sink, returning an error if that didn't succeed,
+// and whether it's worth trying again.
+func (ns *notifySender) send(body []byte) (bool, error) {
+	resp, err := ns.client.Post(ns.sink.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	defer internal.LogClose(ns.Logger, resp.Body, "notification response body")
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("%s responded %s", ns.sink.Kind, resp.Status)
+}
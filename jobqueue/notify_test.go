@@ -0,0 +1,239 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNotify(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Notification config can be parsed", t, func() {
+		nc, err := ParseNotifyConfig(`
+# where to send things
+sink #pipelines slack https://hooks.slack.com/services/T00/B00/XXXX
+sink ops webhook https://ops.example.com/wr
+
+notify #pipelines when repgroup ~ 'release-.*' fails > 10
+notify #pipelines,ops when has scheduler_error events within 15m
+notify ops when repgroup = "my rg" has kill events>2
+`)
+		So(err, ShouldBeNil)
+		So(len(nc.Sinks), ShouldEqual, 2)
+		So(nc.Sinks["#pipelines"].Kind, ShouldEqual, NotifySinkSlack)
+		So(nc.Sinks["ops"].URL, ShouldEqual, "https://ops.example.com/wr")
+		So(len(nc.Rules), ShouldEqual, 3)
+
+		r := nc.Rules[0]
+		So(r.Sinks, ShouldResemble, []string{"#pipelines"})
+		So(r.RepGroup, ShouldEqual, "release-.*")
+		So(r.RepGroupRegexp, ShouldBeTrue)
+		So(r.Type, ShouldEqual, EventTypeBury)
+		So(r.Threshold, ShouldEqual, 10)
+		So(r.Window, ShouldEqual, NotifyWindow)
+		So(r.String(), ShouldEqual, "notify #pipelines when repgroup ~ 'release-.*' fails > 10")
+		So(r.matches(&Event{Type: EventTypeBury, RepGroup: "release-2"}), ShouldBeTrue)
+		So(r.matches(&Event{Type: EventTypeBury, RepGroup: "pre-release-2"}), ShouldBeFalse)
+		So(r.matches(&Event{Type: EventTypeKill, RepGroup: "release-2"}), ShouldBeFalse)
+
+		r = nc.Rules[1]
+		So(r.Sinks, ShouldResemble, []string{"#pipelines", "ops"})
+		So(r.Type, ShouldEqual, EventTypeSchedulerError)
+		So(r.Window, ShouldEqual, 15*time.Minute)
+		So(r.matches(&Event{Type: EventTypeSchedulerError}), ShouldBeTrue)
+		So(r.String(), ShouldEqual, "notify #pipelines,ops when has scheduler_error events within 15m0s")
+
+		r = nc.Rules[2]
+		So(r.RepGroup, ShouldEqual, "my rg")
+		So(r.RepGroupRegexp, ShouldBeFalse)
+		So(r.Threshold, ShouldEqual, 2)
+		So(r.matches(&Event{Type: EventTypeKill, RepGroup: "my rg"}), ShouldBeTrue)
+		So(r.matches(&Event{Type: EventTypeKill, RepGroup: "my rg2"}), ShouldBeFalse)
+
+		again, err := ParseNotifyRule(r.String())
+		So(err, ShouldBeNil)
+		So(again.String(), ShouldEqual, r.String())
+
+		for _, bad := range []string{
+			"sink a slack",
+			"sink a email https://example.com",
+			"sink a slack ftp://example.com",
+			"sink a slack https://example.com\nsink a teams https://example.com",
+			"notify a when fails",
+			"sink a slack https://example.com\nnotify a fails",
+			"sink a slack https://example.com\nnotify when fails",
+			"sink a slack https://example.com\nnotify a when repgroup 'x' fails",
+			"sink a slack https://example.com\nnotify a when repgroup ~ '(' fails",
+			"sink a slack https://example.com\nnotify a when repgroup = 'x fails",
+			"sink a slack https://example.com\nnotify a when breaks",
+			"sink a slack https://example.com\nnotify a when has state_change events",
+			"sink a slack https://example.com\nnotify a when has foo events",
+			"sink a slack https://example.com\nnotify a when fails > lots",
+			"sink a slack https://example.com\nnotify a when fails within -1m",
+			"sink a slack https://example.com\nnotify a when fails often",
+			"sink a slack https://example.com\nalert a when fails",
+		} {
+			_, err = ParseNotifyConfig(bad)
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("Rules are triggered by enough matching events, at most every NotifyInterval", t, func() {
+		rule, err := ParseNotifyRule("notify a when repgroup ~ 'rg.*' fails > 2 within 10m")
+		So(err, ShouldBeNil)
+		nrs := &notifyRuleState{rule: rule}
+		now := time.Now()
+
+		nrs.events = append(nrs.events, &Event{Time: now.Add(-20 * time.Minute), Type: EventTypeBury, RepGroup: "rg1", Msg: "old"})
+		for i := 0; i < 2; i++ {
+			nrs.events = append(nrs.events, &Event{Time: now, Type: EventTypeBury, RepGroup: "rg1", Msg: FailReasonExit})
+		}
+		So(nrs.check(now, "wr: "), ShouldBeNil)
+		So(len(nrs.events), ShouldEqual, 2)
+
+		nrs.events = append(nrs.events, &Event{Time: now, Type: EventTypeBury, RepGroup: "rg2", Msg: FailReasonRAM})
+		n := nrs.check(now, "wr: ")
+		So(n, ShouldNotBeNil)
+		So(n.Count, ShouldEqual, 3)
+		So(n.RepGroups, ShouldResemble, []string{"rg1", "rg2"})
+		So(len(n.Events), ShouldEqual, 3)
+		So(n.Rule, ShouldEqual, rule.String())
+		So(n.Message, ShouldEqual, "wr: 3 jobs failed in rg1, rg2 in the last 10m0s (most recently: "+FailReasonRAM+")")
+		So(nrs.events, ShouldBeEmpty)
+
+		later := now.Add(time.Minute)
+		for i := 0; i < 4; i++ {
+			nrs.events = append(nrs.events, &Event{Time: later, Type: EventTypeBury, RepGroup: "rg3"})
+		}
+		So(nrs.check(later, "wr: "), ShouldBeNil)
+
+		n = nrs.check(now.Add(NotifyInterval), "wr: ")
+		So(n, ShouldNotBeNil)
+		So(n.Count, ShouldEqual, 4)
+		So(n.Message, ShouldStartWith, "wr: 4 jobs failed in rg3 since ")
+	})
+
+	Convey("Counts of events about multiple jobs are used", t, func() {
+		rule, err := ParseNotifyRule("notify a when has kill events > 4")
+		So(err, ShouldBeNil)
+		nrs := &notifyRuleState{rule: rule}
+		now := time.Now()
+		nrs.events = append(nrs.events, &Event{Time: now, Type: EventTypeKill, Count: 5})
+		n := nrs.check(now, "")
+		So(n, ShouldNotBeNil)
+		So(n.Message, ShouldEqual, "5 kill events in the last 1h0m0s")
+	})
+
+	Convey("Notifications are delivered to sinks, with retries", t, func() {
+		origWait := NotifyRetryWait
+		origInterval := NotifySinkInterval
+		NotifyRetryWait = 10 * time.Millisecond
+		NotifySinkInterval = 10 * time.Millisecond
+		defer func() {
+			NotifyRetryWait = origWait
+			NotifySinkInterval = origInterval
+		}()
+
+		var mu sync.Mutex
+		attempts := make(map[string]int)
+		bodies := make(map[string][]byte)
+		sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			mu.Lock()
+			defer mu.Unlock()
+			attempts[r.URL.Path]++
+			if r.URL.Path == "/flaky" && attempts[r.URL.Path] < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			if r.URL.Path == "/bad" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			bodies[r.URL.Path] = body
+		}))
+		defer sink.Close()
+
+		nc, err := ParseNotifyConfig(strings.Join([]string{
+			"sink s slack " + sink.URL + "/slack",
+			"sink t teams " + sink.URL + "/teams",
+			"sink w webhook " + sink.URL + "/flaky",
+			"sink b webhook " + sink.URL + "/bad",
+			"notify s,t,w,b when repgroup = 'rg' fails > 1",
+		}, "\n"))
+		So(err, ShouldBeNil)
+
+		logger := log15.New()
+		logger.SetHandler(log15.DiscardHandler())
+		n := newNotifier(nc, "wr: ", logger)
+		events := make(chan *Event, 10)
+		stop := make(chan bool)
+		defer close(stop)
+		go n.run(events, stop)
+
+		events <- &Event{Time: time.Now(), Type: EventTypeBury, RepGroup: "rg", Msg: FailReasonExit}
+		events <- &Event{Time: time.Now(), Type: EventTypeBury, RepGroup: "other", Msg: FailReasonExit}
+		events <- &Event{Time: time.Now(), Type: EventTypeBury, RepGroup: "rg", Msg: FailReasonExit}
+
+		delivered := func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(bodies) == 3 && attempts["/bad"] == 1
+		}
+		limit := time.Now().Add(5 * time.Second)
+		for !delivered() && time.Now().Before(limit) {
+			<-time.After(10 * time.Millisecond)
+		}
+		So(delivered(), ShouldBeTrue)
+
+		mu.Lock()
+		defer mu.Unlock()
+		So(attempts["/flaky"], ShouldEqual, 3)
+
+		expected := "wr: 2 jobs failed in rg in the last 1h0m0s (most recently: " + FailReasonExit + ")"
+		var slack map[string]string
+		So(json.Unmarshal(bodies["/slack"], &slack), ShouldBeNil)
+		So(slack["text"], ShouldEqual, expected)
+
+		var teams map[string]string
+		So(json.Unmarshal(bodies["/teams"], &teams), ShouldBeNil)
+		So(teams["@type"], ShouldEqual, "MessageCard")
+		So(teams["text"], ShouldEqual, expected)
+
+		var hook Notification
+		So(json.Unmarshal(bodies["/flaky"], &hook), ShouldBeNil)
+		So(hook.Message, ShouldEqual, expected)
+		So(hook.Count, ShouldEqual, 2)
+		So(hook.RepGroups, ShouldResemble, []string{"rg"})
+		So(len(hook.Events), ShouldEqual, 2)
+	})
+}
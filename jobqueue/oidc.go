@@ -0,0 +1,588 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of OpenID Connect logins to the web
+// interface, for installations with multiple users, where not everyone who can
+// see the status page should be able to retry, remove or kill jobs.
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	sync "github.com/sasha-s/go-deadlock"
+)
+
+// ServerOIDCSessionTime is how long users stay logged in to the web interface
+// after logging in with OpenID Connect.
+var ServerOIDCSessionTime = 12 * time.Hour
+
+const (
+	oidcCallbackEndpoint = "/oidc/callback"
+	oidcDiscoveryPath    = "/.well-known/openid-configuration"
+	oidcSessionCookie    = "wr_session"
+	oidcLoginCookie      = "wr_oidc_login"
+	oidcLoginTimeout     = 10 * time.Minute
+	oidcMaxLogins        = 1000
+	oidcHTTPTimeout      = 30 * time.Second
+	oidcMaxResponseSize  = 1 << 20
+)
+
+// webRole is what a user of the web interface is allowed to do.
+type webRole int
+
+// webRole* constants are the roles, in increasing order of permissions.
+const (
+	webRoleNone webRole = iota
+	webRolePublic
+	webRoleViewer
+	webRoleOperator
+	webRoleAdmin
+)
+
+// String returns the name of the role.
+func (r webRole) String() string {
+	switch r {
+	case webRolePublic:
+		return "public"
+	case webRoleViewer:
+		return "viewer"
+	case webRoleOperator:
+		return "operator"
+	case webRoleAdmin:
+		return "admin"
+	}
+	return "none"
+}
+
+// webRoleRequired are the web interface requests that need more than the
+// public role.
+var webRoleRequired = map[string]webRole{
+	"setPrefs":         webRoleViewer,
+	"retry":            webRoleOperator,
+	"remove":           webRoleOperator,
+	"kill":             webRoleOperator,
+	"kickKey":          webRoleOperator,
+	"removeKey":        webRoleOperator,
+	"undo":             webRoleOperator,
+	"killKey":          webRoleOperator,
+	"buryKey":          webRoleOperator,
+	"resubmit":         webRoleOperator,
+	"annotate":         webRoleOperator,
+	"dismissMsg":       webRoleOperator,
+	"dismissMsgs":      webRoleOperator,
+	"confirmBadServer": webRoleAdmin,
+}
+
+// OIDCConfig is used to let users log in to the web interface with an OpenID
+// Connect provider, instead of needing the server's token.
+type OIDCConfig struct {
+	// Issuer is the URL of your provider, which must support OpenID Connect
+	// discovery.
+	Issuer string
+
+	// ClientID and ClientSecret are the credentials you got when registering
+	// the web interface with your provider.
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the URL of the web interface's /oidc/callback that you
+	// registered with your provider. Defaults to that path on the host and
+	// port the user connected to.
+	RedirectURL string
+
+	// UsernameClaim is the ID token claim that identifies users. Defaults to
+	// "email".
+	UsernameClaim string
+
+	// RolesClaim is the ID token claim that lists the groups or roles users
+	// belong to. Defaults to "groups".
+	RolesClaim string
+
+	// Viewers, Operators and Admins are usernames, or values of the
+	// RolesClaim, of users that get that role. Viewers can see the status of
+	// jobs. Operators can also retry, remove, kill and bury jobs, and dismiss
+	// messages. Admins can also confirm that cloud servers are dead, which
+	// destroys them. Users with none of these roles can't use the web
+	// interface, except that if Viewers is empty, everyone who logs in is at
+	// least a viewer.
+	Viewers   []string
+	Operators []string
+	Admins    []string
+}
+
+// role returns the role of the user with the given username and values of the
+// RolesClaim.
+func (c *OIDCConfig) role(username string, roles []string) webRole {
+	has := func(names []string) bool {
+		for _, name := range names {
+			if name == username {
+				return true
+			}
+			for _, role := range roles {
+				if name == role {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	switch {
+	case has(c.Admins):
+		return webRoleAdmin
+	case has(c.Operators):
+		return webRoleOperator
+	case len(c.Viewers) == 0 || has(c.Viewers):
+		return webRoleViewer
+	}
+	return webRoleNone
+}
+
+// oidcProvider holds the parts of an OpenID Connect provider's discovery
+// document that we need.
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// oidcLogin is a login that we've sent a user to their provider to complete.
+type oidcLogin struct {
+	nonce    string
+	redirect string
+	expires  time.Time
+}
+
+// webSession is a logged in user of the web interface.
+type webSession struct {
+	user    string
+	subject string
+	role    webRole
+	expires time.Time
+}
+
+// identity returns who to record as having asked for changes to jobs: the
+// user's name along with the subject their provider verified them as, which
+// unlike their name is guaranteed to be unique and never reassigned.
+func (ws *webSession) identity() string {
+	return fmt.Sprintf("%s (oidc:%s)", ws.user, ws.subject)
+}
+
+// oidcAuth carries out OpenID Connect logins, using the authorization code
+// flow, and remembers the resulting sessions.
+type oidcAuth struct {
+	config   *OIDCConfig
+	client   *http.Client
+	provider *oidcProvider
+	logins   map[string]*oidcLogin
+	sessions map[string]*webSession
+	mutex    sync.Mutex
+}
+
+// newOIDCAuth creates an oidcAuth that uses the given config, filling in its
+// defaults.
+func newOIDCAuth(config *OIDCConfig) *oidcAuth {
+	c := *config
+	c.Issuer = strings.TrimSuffix(c.Issuer, "/")
+	if c.UsernameClaim == "" {
+		c.UsernameClaim = "email"
+	}
+	if c.RolesClaim == "" {
+		c.RolesClaim = "groups"
+	}
+	return &oidcAuth{
+		config:   &c,
+		client:   &http.Client{Timeout: oidcHTTPTimeout},
+		logins:   make(map[string]*oidcLogin),
+		sessions: make(map[string]*webSession),
+	}
+}
+
+// discover gets (and remembers) the details of our provider.
+func (a *oidcAuth) discover() (*oidcProvider, error) {
+	a.mutex.Lock()
+	provider := a.provider
+	a.mutex.Unlock()
+	if provider != nil {
+		return provider, nil
+	}
+
+	resp, err := a.client.Get(a.config.Issuer + oidcDiscoveryPath)
+	if err != nil {
+		return nil, err
+	}
+	provider = &oidcProvider{}
+	if err = decodeOIDCResponse(resp, provider); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(provider.Issuer, "/") != a.config.Issuer {
+		return nil, fmt.Errorf("provider says its issuer is %s, not %s", provider.Issuer, a.config.Issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" {
+		return nil, fmt.Errorf("provider did not supply its endpoints")
+	}
+
+	a.mutex.Lock()
+	a.provider = provider
+	a.mutex.Unlock()
+	return provider, nil
+}
+
+// decodeOIDCResponse decodes the JSON body of a successful response from our
+// provider in to v, closing the body.
+func decodeOIDCResponse(resp *http.Response, v interface{}) error {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, oidcMaxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("provider responded %s: %s", resp.Status, body)
+	}
+	return json.Unmarshal(body, v)
+}
+
+// login redirects the user to our provider to log in, after which they will be
+// redirected back to our oidcCallbackEndpoint. The login's state (and so its
+// nonce) is bound to the user's browser with a short-lived cookie, so that
+// nobody else can complete the login for them, or trick them in to completing
+// someone else's.
+func (a *oidcAuth) login(w http.ResponseWriter, r *http.Request) error {
+	provider, err := a.discover()
+	if err != nil {
+		return err
+	}
+	state, err := generateToken("")
+	if err != nil {
+		return err
+	}
+	nonce, err := generateToken("")
+	if err != nil {
+		return err
+	}
+
+	redirect := a.config.RedirectURL
+	if redirect == "" {
+		redirect = "https://" + r.Host + oidcCallbackEndpoint
+	}
+
+	expires := time.Now().Add(oidcLoginTimeout)
+	a.mutex.Lock()
+	a.pruneLogins()
+	a.logins[string(state)] = &oidcLogin{nonce: string(nonce), redirect: redirect, expires: expires}
+	a.mutex.Unlock()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcLoginCookie,
+		Value:    string(state),
+		Path:     oidcCallbackEndpoint,
+		Expires:  expires,
+		MaxAge:   int(oidcLoginTimeout / time.Second),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", a.config.ClientID)
+	v.Set("redirect_uri", redirect)
+	v.Set("scope", "openid profile email")
+	v.Set("state", string(state))
+	v.Set("nonce", string(nonce))
+	sep := "?"
+	if strings.Contains(provider.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	http.Redirect(w, r, provider.AuthorizationEndpoint+sep+v.Encode(), http.StatusFound)
+	return nil
+}
+
+// pruneLogins forgets expired logins, and if there are still too many, the
+// oldest, so that users who never complete their logins can't use up our
+// memory. You must hold a.mutex.
+func (a *oidcAuth) pruneLogins() {
+	now := time.Now()
+	var oldestState string
+	var oldest *oidcLogin
+	for state, l := range a.logins {
+		if now.After(l.expires) {
+			delete(a.logins, state)
+			continue
+		}
+		if oldest == nil || l.expires.Before(oldest.expires) {
+			oldestState, oldest = state, l
+		}
+	}
+	if len(a.logins) >= oidcMaxLogins {
+		delete(a.logins, oldestState)
+	}
+}
+
+// callback completes a login when our provider redirects the user back to us,
+// returning the id of their new session (to be set as their session cookie).
+// The request must have the login cookie that login() set in the same
+// browser.
+func (a *oidcAuth) callback(r *http.Request) (string, *webSession, error) {
+	q := r.URL.Query()
+	if errStr := q.Get("error"); errStr != "" {
+		return "", nil, fmt.Errorf("login failed: %s %s", errStr, q.Get("error_description"))
+	}
+
+	state := q.Get("state")
+	cookie, err := r.Cookie(oidcLoginCookie)
+	if err != nil || state == "" || !tokenMatches([]byte(cookie.Value), []byte(state)) {
+		return "", nil, fmt.Errorf("login was not started by this browser")
+	}
+
+	a.mutex.Lock()
+	login, found := a.logins[state]
+	delete(a.logins, state)
+	a.mutex.Unlock()
+	if !found || time.Now().After(login.expires) {
+		return "", nil, fmt.Errorf("unknown or expired login")
+	}
+
+	provider, err := a.discover()
+	if err != nil {
+		return "", nil, err
+	}
+	idToken, err := a.exchange(provider, q.Get("code"), login.redirect)
+	if err != nil {
+		return "", nil, err
+	}
+	claims, err := a.verify(provider, idToken, login.nonce)
+	if err != nil {
+		return "", nil, err
+	}
+
+	user, _ := claims[a.config.UsernameClaim].(string)
+	if user == "" {
+		return "", nil, fmt.Errorf("ID token has no %s claim", a.config.UsernameClaim)
+	}
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return "", nil, fmt.Errorf("ID token has no sub claim")
+	}
+	role := a.config.role(user, claimStrings(claims[a.config.RolesClaim]))
+	if role == webRoleNone {
+		return "", nil, fmt.Errorf("%s is not allowed to use the web interface", user)
+	}
+
+	id, err := generateToken("")
+	if err != nil {
+		return "", nil, err
+	}
+	session := &webSession{user: user, subject: subject, role: role, expires: time.Now().Add(ServerOIDCSessionTime)}
+
+	a.mutex.Lock()
+	now := time.Now()
+	for i, s := range a.sessions {
+		if now.After(s.expires) {
+			delete(a.sessions, i)
+		}
+	}
+	a.sessions[string(id)] = session
+	a.mutex.Unlock()
+	return string(id), session, nil
+}
+
+// exchange swaps the authorization code we were given for an ID token.
+func (a *oidcAuth) exchange(provider *oidcProvider, code, redirect string) (string, error) {
+	if code == "" {
+		return "", fmt.Errorf("no authorization code supplied")
+	}
+	v := url.Values{}
+	v.Set("grant_type", "authorization_code")
+	v.Set("code", code)
+	v.Set("redirect_uri", redirect)
+	v.Set("client_id", a.config.ClientID)
+	req, err := http.NewRequest(http.MethodPost, provider.TokenEndpoint, strings.NewReader(v.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(a.config.ClientID), url.QueryEscape(a.config.ClientSecret))
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	tokens := struct {
+		IDToken string `json:"id_token"`
+	}{}
+	if err = decodeOIDCResponse(resp, &tokens); err != nil {
+		return "", err
+	}
+	if tokens.IDToken == "" {
+		return "", fmt.Errorf("provider did not supply an ID token")
+	}
+	return tokens.IDToken, nil
+}
+
+// verify checks that the given ID token was issued by our provider for us,
+// during the login with the given nonce, and hasn't expired, returning its
+// claims. Since we got the token directly from the provider's token endpoint
+// over TLS, we rely on that instead of checking its signature, as allowed by
+// the OpenID Connect spec.
+func (a *oidcAuth) verify(provider *oidcProvider, idToken, nonce string) (map[string]interface{}, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed ID token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("malformed ID token: %s", err)
+	}
+	claims := make(map[string]interface{})
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("malformed ID token: %s", err)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != provider.Issuer {
+		return nil, fmt.Errorf("ID token was issued by %s, not %s", iss, provider.Issuer)
+	}
+	audienced := false
+	for _, aud := range claimStrings(claims["aud"]) {
+		if aud == a.config.ClientID {
+			audienced = true
+			break
+		}
+	}
+	if !audienced {
+		return nil, fmt.Errorf("ID token was not issued for us")
+	}
+	if exp, _ := claims["exp"].(float64); time.Now().After(time.Unix(int64(exp), 0)) {
+		return nil, fmt.Errorf("ID token has expired")
+	}
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, fmt.Errorf("ID token is not for this login")
+	}
+	return claims, nil
+}
+
+// claimStrings converts a claim that is a string or a list of strings to a
+// slice of strings.
+func claimStrings(claim interface{}) []string {
+	switch c := claim.(type) {
+	case string:
+		return []string{c}
+	case []interface{}:
+		strs := make([]string, 0, len(c))
+		for _, v := range c {
+			if s, ok := v.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	}
+	return nil
+}
+
+// session returns the unexpired session that the given request's session
+// cookie is for, or nil if none.
+func (a *oidcAuth) session(r *http.Request) *webSession {
+	cookie, err := r.Cookie(oidcSessionCookie)
+	if err != nil {
+		return nil
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	session, found := a.sessions[cookie.Value]
+	if !found || time.Now().After(session.expires) {
+		return nil
+	}
+	return session
+}
+
+// webOIDCCallback is a http handler for our oidcCallbackEndpoint, which our
+// provider redirects users to after they log in.
+func webOIDCCallback(s *Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.oidc == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		// the login cookie is only good for one try
+		http.SetCookie(w, &http.Cookie{
+			Name:     oidcLoginCookie,
+			Path:     oidcCallbackEndpoint,
+			MaxAge:   -1,
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		id, session, err := s.oidc.callback(r)
+		if err != nil {
+			s.Warn("web interface login failed", "err", err)
+			http.Error(w, "Login failed", http.StatusForbidden)
+			return
+		}
+		s.Info("web interface login", "user", session.user, "role", session.role)
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     oidcSessionCookie,
+			Value:    id,
+			Path:     "/",
+			Expires:  session.expires,
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, "/status", http.StatusFound)
+	}
+}
+
+// webAuthorized is like httpAuthorized(), but when we're configured with
+// OIDCConfig, also accepts requests from users that logged in with it. If
+// login is true and the request has neither a token nor a session, the user
+// is redirected to log in. Returns the user's role, a key to store their
+// preferences under, and their verified identity to record as having asked for
+// changes to jobs.
+func (s *Server) webAuthorized(w http.ResponseWriter, r *http.Request, login bool) (webRole, []byte, string, bool) {
+	if s.oidc != nil && r.URL.Query().Get("token") == "" && r.Header.Get("Authorization") == "" {
+		if session := s.oidc.session(r); session != nil {
+			return session.role, []byte("oidc:" + session.user), session.identity(), true
+		}
+		if !login {
+			http.Error(w, "Login required", http.StatusUnauthorized)
+			return webRoleNone, nil, "", false
+		}
+		if err := s.oidc.login(w, r); err != nil {
+			s.Error("web interface could not start login", "err", err)
+			http.Error(w, "Login is currently unavailable", http.StatusServiceUnavailable)
+		}
+		return webRoleNone, nil, "", false
+	}
+
+	if !s.httpAuthorized(w, r) {
+		return webRoleNone, nil, "", false
+	}
+	return webRoleAdmin, webToken(r), userWebToken, true
+}
@@ -0,0 +1,224 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOIDC(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("OIDCConfig gives users roles by username or group", t, func() {
+		c := &OIDCConfig{Viewers: []string{"viewers"}, Operators: []string{"ops", "bob"}, Admins: []string{"admins"}}
+		So(c.role("alice", []string{"admins", "ops"}), ShouldEqual, webRoleAdmin)
+		So(c.role("bob", nil), ShouldEqual, webRoleOperator)
+		So(c.role("carol", []string{"viewers"}), ShouldEqual, webRoleViewer)
+		So(c.role("dave", []string{"others"}), ShouldEqual, webRoleNone)
+
+		c.Viewers = nil
+		So(c.role("dave", []string{"others"}), ShouldEqual, webRoleViewer)
+	})
+
+	Convey("Users can log in with an OpenID Connect provider", t, func() {
+		var issuer string
+		claims := make(map[string]interface{})
+		mux := http.NewServeMux()
+		mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewEncoder(w).Encode(&oidcProvider{Issuer: issuer, AuthorizationEndpoint: issuer + "/auth", TokenEndpoint: issuer + "/token"}); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		})
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			id, secret, _ := r.BasicAuth()
+			if id != "wr" || secret != "shh" || r.FormValue("code") != "code" {
+				http.Error(w, "bad client", http.StatusUnauthorized)
+				return
+			}
+			payload, err := json.Marshal(claims)
+			if err == nil {
+				idToken := "e30." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+				err = json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		})
+		provider := httptest.NewTLSServer(mux)
+		defer provider.Close()
+		issuer = provider.URL
+
+		a := newOIDCAuth(&OIDCConfig{Issuer: issuer + "/", ClientID: "wr", ClientSecret: "shh", Operators: []string{"ops"}})
+		a.client = provider.Client()
+
+		cookies := make(map[string]*http.Cookie)
+		login := func() url.Values {
+			w := httptest.NewRecorder()
+			err := a.login(w, httptest.NewRequest(http.MethodGet, "https://manager:1234/status", nil))
+			So(err, ShouldBeNil)
+			So(w.Code, ShouldEqual, http.StatusFound)
+			loc, err := url.Parse(w.Header().Get("Location"))
+			So(err, ShouldBeNil)
+			So(loc.Path, ShouldEqual, "/auth")
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			So(len(resp.Cookies()), ShouldEqual, 1)
+			cookie := resp.Cookies()[0]
+			So(cookie.Name, ShouldEqual, oidcLoginCookie)
+			So(cookie.Path, ShouldEqual, oidcCallbackEndpoint)
+			So(cookie.MaxAge, ShouldEqual, int(oidcLoginTimeout/time.Second))
+			So(cookie.Secure, ShouldBeTrue)
+			So(cookie.HttpOnly, ShouldBeTrue)
+			cookies[loc.Query().Get("state")] = cookie
+			return loc.Query()
+		}
+
+		callbackWithCookie := func(state string, cookie *http.Cookie) (string, *webSession, error) {
+			req := httptest.NewRequest(http.MethodGet, "https://manager:1234"+oidcCallbackEndpoint+"?code=code&state="+state, nil)
+			if cookie != nil {
+				req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+			}
+			return a.callback(req)
+		}
+
+		callback := func(state string) (string, *webSession, error) {
+			return callbackWithCookie(state, cookies[state])
+		}
+
+		q := login()
+		So(q.Get("client_id"), ShouldEqual, "wr")
+		So(q.Get("redirect_uri"), ShouldEqual, "https://manager:1234"+oidcCallbackEndpoint)
+		claims["iss"] = issuer
+		claims["aud"] = []string{"other", "wr"}
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+		claims["nonce"] = q.Get("nonce")
+		claims["email"] = "alice@example.com"
+		claims["groups"] = []string{"ops"}
+
+		_, _, err := callback(q.Get("state"))
+		So(err, ShouldNotBeNil)
+
+		q = login()
+		claims["nonce"] = q.Get("nonce")
+		claims["sub"] = "248289761001"
+		id, session, err := callback(q.Get("state"))
+		So(err, ShouldBeNil)
+		So(session.user, ShouldEqual, "alice@example.com")
+		So(session.identity(), ShouldEqual, "alice@example.com (oidc:248289761001)")
+		So(session.role, ShouldEqual, webRoleOperator)
+
+		req := httptest.NewRequest(http.MethodGet, "https://manager:1234/status_ws", nil)
+		So(a.session(req), ShouldBeNil)
+		req.AddCookie(&http.Cookie{Name: oidcSessionCookie, Value: id})
+		So(a.session(req), ShouldEqual, session)
+
+		_, _, err = callback(q.Get("state"))
+		So(err, ShouldNotBeNil)
+
+		Convey("But not with a token for a different login or client, or that has expired", func() {
+			_, _, err = callback(login().Get("state"))
+			So(err, ShouldNotBeNil)
+
+			q = login()
+			claims["nonce"] = q.Get("nonce")
+			claims["aud"] = "other"
+			_, _, err = callback(q.Get("state"))
+			So(err, ShouldNotBeNil)
+
+			q = login()
+			claims["nonce"] = q.Get("nonce")
+			claims["aud"] = "wr"
+			claims["exp"] = time.Now().Add(-time.Minute).Unix()
+			_, _, err = callback(q.Get("state"))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("But only from the browser that started the login", func() {
+			q = login()
+			claims["nonce"] = q.Get("nonce")
+			_, _, err = callbackWithCookie(q.Get("state"), nil)
+			So(err, ShouldNotBeNil)
+
+			other := login()
+			_, _, err = callbackWithCookie(q.Get("state"), cookies[other.Get("state")])
+			So(err, ShouldNotBeNil)
+			_, _, err = callbackWithCookie("", &http.Cookie{Name: oidcLoginCookie})
+			So(err, ShouldNotBeNil)
+
+			_, _, err = callback(q.Get("state"))
+			So(err, ShouldBeNil)
+		})
+
+		Convey("But not after the login expires", func() {
+			q = login()
+			claims["nonce"] = q.Get("nonce")
+			a.mutex.Lock()
+			a.logins[q.Get("state")].expires = time.Now().Add(-time.Second)
+			a.mutex.Unlock()
+			_, _, err = callback(q.Get("state"))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Expired and excess logins are forgotten", func() {
+			q = login()
+			a.mutex.Lock()
+			a.logins[q.Get("state")].expires = time.Now().Add(-time.Second)
+			a.mutex.Unlock()
+			first := login().Get("state")
+			a.mutex.Lock()
+			_, found := a.logins[q.Get("state")]
+			a.mutex.Unlock()
+			So(found, ShouldBeFalse)
+
+			for i := 0; i < oidcMaxLogins; i++ {
+				login()
+			}
+			a.mutex.Lock()
+			So(len(a.logins), ShouldEqual, oidcMaxLogins)
+			_, found = a.logins[first]
+			a.mutex.Unlock()
+			So(found, ShouldBeFalse)
+		})
+
+		Convey("But not if they have no role", func() {
+			a.config.Viewers = []string{"viewers"}
+			q = login()
+			claims["nonce"] = q.Get("nonce")
+			_, _, err = callback(q.Get("state"))
+			So(err, ShouldBeNil)
+
+			q = login()
+			claims["nonce"] = q.Get("nonce")
+			claims["groups"] = "others"
+			_, _, err = callback(q.Get("state"))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of output file validation, where the
+// OutputFiles of a job whose Cmd exited 0 are checked to exist, be big enough
+// and pass the job's OutputCheckCmd, before the job is archived as a success,
+// and of recording their sizes and checksums.
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/inconshreveable/log15"
+)
+
+// ClientOutputCheckTimeout is how long a Job's OutputCheckCmd is allowed to
+// run for on a single output before it is killed and the output is considered
+// invalid.
+var ClientOutputCheckTimeout = 5 * time.Minute
+
+// OutputRecord describes one of a Job's OutputFiles after its Cmd succeeded,
+// providing provenance for anything that later uses the file.
+type OutputRecord struct {
+	// Path is the absolute path to the output.
+	Path string
+
+	// Size is the size of the output in bytes.
+	Size int64
+
+	// MD5 is the hex encoded MD5 checksum of the output's content.
+	MD5 string
+}
+
+// validateOutputs checks the given job's OutputFiles, with relative paths
+// being relative to the given dir, and running its OutputCheckCmd using the
+// given shell and environment, as the job's RunAs user if runAs is true. It
+// returns a description of each problem found, or nil if all the outputs are
+// fine.
+func validateOutputs(job *Job, shell, dir string, env []string, runAs bool, logger log15.Logger) []string {
+	minSize := job.OutputMinSize
+	if minSize < 1 {
+		minSize = 1
+	}
+
+	var problems []string
+	for _, output := range job.OutputFiles {
+		path := output
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		info, err := os.Stat(path)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("%s does not exist", output))
+			continue
+		case info.IsDir():
+			problems = append(problems, fmt.Sprintf("%s is a directory", output))
+			continue
+		case info.Size() < minSize:
+			problems = append(problems, fmt.Sprintf("%s is %d bytes, smaller than %d", output, info.Size(), minSize))
+			continue
+		}
+
+		if job.OutputCheckCmd != "" {
+			if err := runOutputCheckCmd(job, shell, dir, env, path, runAs, logger); err != nil {
+				problems = append(problems, fmt.Sprintf("%s failed its check: %s", output, err))
+			}
+		}
+	}
+
+	return problems
+}
+
+// runOutputCheckCmd runs the given job's OutputCheckCmd with WR_OUTPUT set to
+// the given path, returning an error if it doesn't exit 0 within
+// ClientOutputCheckTimeout.
+func runOutputCheckCmd(job *Job, shell, dir string, env []string, path string, runAs bool, logger log15.Logger) error {
+	runAsUser := ""
+	if runAs {
+		runAsUser = job.RunAs
+	}
+	cmd, err := shellCommand(shell, job.OutputCheckCmd, runAsUser)
+	if err != nil {
+		return fmt.Errorf("output check command [%s] could not be run: %w", job.OutputCheckCmd, err)
+	}
+	cmd.Dir = dir
+	cmd.Env = append(append([]string{}, env...), "WR_OUTPUT="+path)
+	stderr := &prefixSuffixSaver{N: 4096}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("output check command [%s] could not be started: %w", job.OutputCheckCmd, err)
+	}
+	timer := time.AfterFunc(ClientOutputCheckTimeout, func() {
+		errk := cmd.Process.Kill()
+		if errk != nil {
+			logger.Warn("failed to kill output check command after timeout", "err", errk)
+		}
+	})
+	err = cmd.Wait()
+	if !timer.Stop() {
+		return fmt.Errorf("output check command [%s] took longer than %s and was killed", job.OutputCheckCmd, ClientOutputCheckTimeout)
+	}
+	if err != nil {
+		return fmt.Errorf("output check command [%s] failed: %w (%s)", job.OutputCheckCmd, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return nil
+}
+
+// recordOutputs returns an OutputRecord for each of the given job's
+// OutputFiles, with relative paths being relative to the given dir.
+func recordOutputs(job *Job, dir string, logger log15.Logger) ([]OutputRecord, error) {
+	records := make([]OutputRecord, 0, len(job.OutputFiles))
+	for _, output := range job.OutputFiles {
+		path := output
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		md5, err := internal.FileMD5(path, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, OutputRecord{Path: path, Size: info.Size(), MD5: md5})
+	}
+
+	return records, nil
+}
@@ -0,0 +1,233 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of job file permissions, where the
+// runner gives a job's Cmd a umask, and its working directory and outputs a
+// group, so that pipelines in shared projects produce files that the rest of
+// the project can use.
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	sync "github.com/sasha-s/go-deadlock"
+)
+
+// validGroup matches the group names and ids that FilePermissions.Group can
+// be.
+var validGroup = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*\$?$`)
+
+// maxPermissionProblems is the most problems applyGroupToOutputs() describes
+// individually.
+const maxPermissionProblems = 10
+
+// umaskMutex stops concurrent Execute()s from changing our umask at the same
+// time.
+var umaskMutex sync.Mutex
+
+// FilePermissions describes the permissions of the files a job creates. Zero
+// values mean no change is made.
+type FilePermissions struct {
+	// Umask is the octal umask, eg. "0002", to run the Cmd with, so that the
+	// files it creates have the permissions you want, eg. group writable.
+	Umask string
+
+	// Group is the name or id of a group to give the working directory and
+	// output files of the Cmd, eg. that of the project they belong to. The
+	// user the runner runs as must be a member of it.
+	Group string
+}
+
+// DefaultFilePermissions is the FilePermissions that Execute() gives jobs that
+// don't specify their own Umask or Group. Runners set this from the
+// runnerumask and runnergroup config options.
+var DefaultFilePermissions FilePermissions
+
+// Validate returns an error if the Umask isn't an octal umask, or the Group
+// isn't a possible group name or id.
+func (p FilePermissions) Validate() error {
+	if _, err := parseUmask(p.Umask); err != nil {
+		return err
+	}
+	if p.Group != "" && !validGroup.MatchString(p.Group) {
+		return fmt.Errorf("group %q is not a valid group name or id", p.Group)
+	}
+	return nil
+}
+
+// parseUmask converts an octal umask string to a mask. Returns -1 for an empty
+// string.
+func parseUmask(umask string) (int, error) {
+	if umask == "" {
+		return -1, nil
+	}
+	mask, err := strconv.ParseUint(umask, 8, 32)
+	if err != nil || mask > 0777 {
+		return -1, fmt.Errorf("umask %q is not an octal number from 0 to 0777", umask)
+	}
+	return int(mask), nil
+}
+
+// filePermissions returns the FilePermissions the job's Umask and Group
+// describe, with any unset values taken from the given defaults.
+func (j *Job) filePermissions(defaults FilePermissions) FilePermissions {
+	j.RLock()
+	defer j.RUnlock()
+	p := FilePermissions{Umask: j.Umask, Group: j.Group}
+	if p.Umask == "" {
+		p.Umask = defaults.Umask
+	}
+	if p.Group == "" {
+		p.Group = defaults.Group
+	}
+	return p
+}
+
+// gid returns the id of our Group, looking it up with getent if it is a name.
+// Returns -1 if we have no Group.
+func (p FilePermissions) gid() (int, error) {
+	if p.Group == "" {
+		return -1, nil
+	}
+	if gid, err := strconv.Atoi(p.Group); err == nil {
+		return gid, nil
+	}
+
+	out, err := exec.Command("getent", "group", p.Group).Output() // #nosec
+	if err != nil {
+		return -1, fmt.Errorf("could not find group %s: %w", p.Group, err)
+	}
+	fields := strings.Split(strings.TrimSpace(string(out)), ":")
+	if len(fields) < 3 {
+		return -1, fmt.Errorf("could not find group %s: getent gave %q", p.Group, out)
+	}
+	gid, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return -1, fmt.Errorf("could not find group %s: getent gave %q", p.Group, out)
+	}
+	return gid, nil
+}
+
+// start starts the given command with our Umask, if we have one, which it and
+// its child processes inherit.
+func (p FilePermissions) start(cmd *exec.Cmd) error {
+	mask, err := parseUmask(p.Umask)
+	if err != nil {
+		return err
+	}
+	if mask == -1 {
+		return cmd.Start()
+	}
+
+	umaskMutex.Lock()
+	defer umaskMutex.Unlock()
+	old := syscall.Umask(mask)
+	defer syscall.Umask(old)
+	return cmd.Start()
+}
+
+// applyToDirs makes the given directories have the permissions our Umask
+// allows, and if gid isn't -1, gives them that group and sets their setgid bit,
+// so that files created within them get the group as well.
+func (p FilePermissions) applyToDirs(gid int, dirs ...string) error {
+	mask, err := parseUmask(p.Umask)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		mode := info.Mode() & (os.ModePerm | os.ModeSetgid)
+		if mask != -1 {
+			mode = (os.ModePerm &^ os.FileMode(mask)) | (mode & os.ModeSetgid)
+		}
+		if gid != -1 {
+			if err = os.Chown(dir, -1, gid); err != nil {
+				return err
+			}
+			mode |= os.ModeSetgid
+		}
+		if err = os.Chmod(dir, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyGroupToOutputs gives the given group to everything in the given working
+// directory (if not blank), and to the given output files, which are relative
+// to dir if not absolute. Returns descriptions of (the first few of) anything
+// that couldn't be changed.
+func applyGroupToOutputs(gid int, workDir, dir string, outputs []string) []string {
+	var problems []string
+	failed := 0
+	problem := func(err error) {
+		failed++
+		if failed <= maxPermissionProblems {
+			problems = append(problems, err.Error())
+		}
+	}
+	chgrp := func(path string) {
+		if err := os.Lchown(path, -1, gid); err != nil {
+			problem(err)
+		}
+	}
+
+	if workDir != "" {
+		err := filepath.Walk(workDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				problem(err)
+				return nil
+			}
+			chgrp(path)
+			return nil
+		})
+		if err != nil {
+			problem(err)
+		}
+	}
+
+	for _, output := range outputs {
+		path := output
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if workDir != "" && strings.HasPrefix(path, workDir+string(filepath.Separator)) {
+			continue
+		}
+		if _, err := os.Lstat(path); err == nil {
+			chgrp(path)
+		}
+	}
+
+	if failed > maxPermissionProblems {
+		problems = append(problems, fmt.Sprintf("(and %d more)", failed-maxPermissionProblems))
+	}
+	return problems
+}
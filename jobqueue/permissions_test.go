@@ -0,0 +1,123 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFilePermissions(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("FilePermissions can be validated", t, func() {
+		So(FilePermissions{}.Validate(), ShouldBeNil)
+		So(FilePermissions{Umask: "0002", Group: "my_project"}.Validate(), ShouldBeNil)
+		So(FilePermissions{Umask: "027", Group: "1234"}.Validate(), ShouldBeNil)
+		So(FilePermissions{Umask: "0008"}.Validate(), ShouldNotBeNil)
+		So(FilePermissions{Umask: "1777"}.Validate(), ShouldNotBeNil)
+		So(FilePermissions{Umask: "-1"}.Validate(), ShouldNotBeNil)
+		So(FilePermissions{Group: "my project"}.Validate(), ShouldNotBeNil)
+		So(FilePermissions{Group: "-g"}.Validate(), ShouldNotBeNil)
+	})
+
+	Convey("Jobs take unset file permissions from the defaults", t, func() {
+		defaults := FilePermissions{Umask: "0022", Group: "default"}
+		So((&Job{}).filePermissions(defaults), ShouldResemble, defaults)
+		So((&Job{Umask: "0002"}).filePermissions(defaults), ShouldResemble, FilePermissions{Umask: "0002", Group: "default"})
+	})
+
+	Convey("Groups can be given by id or looked up by name", t, func() {
+		gid, err := FilePermissions{}.gid()
+		So(err, ShouldBeNil)
+		So(gid, ShouldEqual, -1)
+
+		gid, err = FilePermissions{Group: "1234"}.gid()
+		So(err, ShouldBeNil)
+		So(gid, ShouldEqual, 1234)
+
+		if _, errl := exec.LookPath("getent"); errl == nil {
+			gid, err = FilePermissions{Group: "root"}.gid()
+			So(err, ShouldBeNil)
+			So(gid, ShouldEqual, 0)
+
+			_, err = FilePermissions{Group: "wr_no_such_group"}.gid()
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("File permissions can be applied", t, func() {
+		tmpdir, err := ioutil.TempDir("", "wr_jobqueue_perms_test")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(tmpdir)
+		gid := os.Getgid()
+
+		Convey("Commands are started with the umask", func() {
+			out := filepath.Join(tmpdir, "out")
+			cmd := exec.Command("touch", out)
+			So(FilePermissions{Umask: "0027"}.start(cmd), ShouldBeNil)
+			So(cmd.Wait(), ShouldBeNil)
+
+			info, err := os.Stat(out)
+			So(err, ShouldBeNil)
+			So(info.Mode().Perm(), ShouldEqual, os.FileMode(0640))
+		})
+
+		Convey("Directories get the umask and group, and become setgid", func() {
+			dir := filepath.Join(tmpdir, "cwd")
+			So(os.Mkdir(dir, 0700), ShouldBeNil)
+			So(FilePermissions{Umask: "0002"}.applyToDirs(gid, dir), ShouldBeNil)
+
+			info, err := os.Stat(dir)
+			So(err, ShouldBeNil)
+			So(info.Mode().Perm(), ShouldEqual, os.FileMode(0775))
+			So(info.Mode()&os.ModeSetgid, ShouldNotEqual, 0)
+		})
+
+		Convey("Working directories and outputs are given the group", func() {
+			dir := filepath.Join(tmpdir, "cwd")
+			So(os.MkdirAll(filepath.Join(dir, "sub"), 0700), ShouldBeNil)
+			So(ioutil.WriteFile(filepath.Join(dir, "sub", "a"), []byte("a"), 0600), ShouldBeNil)
+			So(ioutil.WriteFile(filepath.Join(tmpdir, "b"), []byte("b"), 0600), ShouldBeNil)
+
+			problems := applyGroupToOutputs(gid, dir, dir, []string{"sub/a", "../b", "missing"})
+			So(problems, ShouldBeEmpty)
+
+			problems = applyGroupToOutputs(gid, filepath.Join(tmpdir, "missing"), dir, nil)
+			So(len(problems), ShouldEqual, 1)
+		})
+
+		if os.Getuid() != 0 {
+			Convey("Failure to give the group is reported", func() {
+				So(ioutil.WriteFile(filepath.Join(tmpdir, "c"), []byte("c"), 0600), ShouldBeNil)
+				problems := applyGroupToOutputs(0, "", tmpdir, []string{"c"})
+				So(len(problems), ShouldEqual, 1)
+				So(problems[0], ShouldContainSubstring, strconv.Quote(filepath.Join(tmpdir, "c"))[1:])
+			})
+		}
+	})
+}
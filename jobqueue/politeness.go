@@ -0,0 +1,192 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of job politeness, where the runner
+// lowers the CPU and IO priority of a job's process tree, and makes it a more
+// likely victim of the kernel's OOM killer, so that jobs sharing a machine with
+// interactive users behave politely.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/VertebrateResequencing/wr/internal"
+)
+
+// IONice* constants are the classes that can be given to Job.IONice, optionally
+// followed by a colon and a priority level 0 (highest) to 7 (lowest) for the
+// best-effort and realtime classes, eg. "best-effort:7".
+const (
+	IONiceIdle       = "idle"
+	IONiceBestEffort = "best-effort"
+	IONiceRealtime   = "realtime"
+)
+
+// ionice class numbers, as understood by ionice -c.
+var ioniceClasses = map[string]string{
+	IONiceRealtime:   "1",
+	IONiceBestEffort: "2",
+	IONiceIdle:       "3",
+}
+
+// Politeness describes how a job's processes should be treated by the
+// operating system. Zero values mean no change is made.
+type Politeness struct {
+	// Nice is the niceness (1 to 19, higher being nicer) to give the processes.
+	Nice int
+
+	// IONice is the IO scheduling class (see the IONice* constants) to give
+	// the processes.
+	IONice string
+
+	// OOMScoreAdj is the oom_score_adj (-1000 to 1000) to give the processes;
+	// higher values make them more likely to be killed when the machine runs
+	// out of memory. Negative values require privileges that runners normally
+	// don't have.
+	OOMScoreAdj int
+}
+
+// DefaultPoliteness is the Politeness that Execute() gives jobs that don't
+// specify their own Nice, IONice or OOMScoreAdj. Runners set this from the
+// runnernice, runnerionice and runneroomscoreadj config options.
+var DefaultPoliteness Politeness
+
+// Validate returns an error if any of the Politeness values are out of range
+// or not understood.
+func (p Politeness) Validate() error {
+	if p.Nice < 0 || p.Nice > 19 {
+		return fmt.Errorf("nice value (%d) is not in the range 0..19", p.Nice)
+	}
+	if _, err := ioniceArgs(p.IONice); err != nil {
+		return err
+	}
+	if p.OOMScoreAdj < -1000 || p.OOMScoreAdj > 1000 {
+		return fmt.Errorf("oom_score_adj value (%d) is not in the range -1000..1000", p.OOMScoreAdj)
+	}
+	return nil
+}
+
+// ioniceArgs converts an IONice string to the arguments ionice needs to set
+// it. Returns nil for an empty string.
+func ioniceArgs(ionice string) ([]string, error) {
+	if ionice == "" {
+		return nil, nil
+	}
+	parts := strings.SplitN(ionice, ":", 2)
+	class, known := ioniceClasses[parts[0]]
+	if !known {
+		return nil, fmt.Errorf("ionice value %q does not start with one of %s, %s or %s", ionice, IONiceIdle, IONiceBestEffort, IONiceRealtime)
+	}
+	args := []string{"-c", class}
+	if len(parts) == 2 {
+		level, err := strconv.Atoi(parts[1])
+		if err != nil || level < 0 || level > 7 || parts[0] == IONiceIdle {
+			return nil, fmt.Errorf("ionice value %q has an invalid level; %s and %s take a level of 0 to 7, %s takes none", ionice, IONiceBestEffort, IONiceRealtime, IONiceIdle)
+		}
+		args = append(args, "-n", parts[1])
+	}
+	return args, nil
+}
+
+// politeness returns the Politeness the job's Nice, IONice and OOMScoreAdj
+// describe, with any unset values taken from the given defaults.
+func (j *Job) politeness(defaults Politeness) Politeness {
+	j.RLock()
+	defer j.RUnlock()
+	p := Politeness{Nice: j.Nice, IONice: j.IONice, OOMScoreAdj: j.OOMScoreAdj}
+	if p.Nice == 0 {
+		p.Nice = defaults.Nice
+	}
+	if p.IONice == "" {
+		p.IONice = defaults.IONice
+	}
+	if p.OOMScoreAdj == 0 {
+		p.OOMScoreAdj = defaults.OOMScoreAdj
+	}
+	return p
+}
+
+// apply gives the process with the given pid, and any child processes it has
+// already started, our niceness, IO class and oom_score_adj. Processes started
+// after this inherit them. Returns an error describing everything that
+// couldn't be applied.
+func (p Politeness) apply(pid int) error {
+	if p == (Politeness{}) {
+		return nil
+	}
+
+	pids := []int{pid}
+	children, err := getChildProcesses(int32(pid))
+	if err == nil {
+		for _, child := range children {
+			pids = append(pids, int(child.Pid))
+		}
+	}
+
+	var problems []string
+	if p.Nice != 0 {
+		for _, pid := range pids {
+			if err = syscall.Setpriority(syscall.PRIO_PROCESS, pid, p.Nice); err != nil {
+				problems = append(problems, fmt.Sprintf("nice %d: %s", pid, err))
+			}
+		}
+	}
+
+	if args, _ := ioniceArgs(p.IONice); args != nil {
+		if err = p.applyIONice(args, pids); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if p.OOMScoreAdj != 0 {
+		adj := []byte(strconv.Itoa(p.OOMScoreAdj))
+		for _, pid := range pids {
+			if err = ioutil.WriteFile(fmt.Sprintf("/proc/%d/oom_score_adj", pid), adj, 0644); err != nil {
+				problems = append(problems, fmt.Sprintf("oom_score_adj %d: %s", pid, err))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("could not fully apply politeness: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// applyIONice runs ionice with the given class args on the given pids.
+func (p Politeness) applyIONice(args []string, pids []int) error {
+	exe := internal.Which("ionice")
+	if exe == "" {
+		return fmt.Errorf("ionice: not found")
+	}
+	args = append(args, "-p")
+	for _, pid := range pids {
+		args = append(args, strconv.Itoa(pid))
+	}
+	out, err := exec.Command(exe, args...).CombinedOutput() // #nosec
+	if err != nil {
+		return fmt.Errorf("ionice: %s: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
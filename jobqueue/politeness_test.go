@@ -0,0 +1,94 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPoliteness(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Politeness can be validated", t, func() {
+		So(Politeness{}.Validate(), ShouldBeNil)
+		So(Politeness{Nice: 19, IONice: "best-effort:7", OOMScoreAdj: 1000}.Validate(), ShouldBeNil)
+		So(Politeness{IONice: IONiceIdle, OOMScoreAdj: -1000}.Validate(), ShouldBeNil)
+		So(Politeness{Nice: 20}.Validate(), ShouldNotBeNil)
+		So(Politeness{Nice: -1}.Validate(), ShouldNotBeNil)
+		So(Politeness{OOMScoreAdj: 1001}.Validate(), ShouldNotBeNil)
+		So(Politeness{IONice: "lazy"}.Validate(), ShouldNotBeNil)
+		So(Politeness{IONice: "best-effort:8"}.Validate(), ShouldNotBeNil)
+		So(Politeness{IONice: "idle:1"}.Validate(), ShouldNotBeNil)
+	})
+
+	Convey("ioniceArgs() converts classes to ionice arguments", t, func() {
+		args, err := ioniceArgs("")
+		So(err, ShouldBeNil)
+		So(args, ShouldBeNil)
+
+		args, err = ioniceArgs(IONiceIdle)
+		So(err, ShouldBeNil)
+		So(args, ShouldResemble, []string{"-c", "3"})
+
+		args, err = ioniceArgs("best-effort:7")
+		So(err, ShouldBeNil)
+		So(args, ShouldResemble, []string{"-c", "2", "-n", "7"})
+	})
+
+	Convey("Jobs take unset politeness from the defaults", t, func() {
+		defaults := Politeness{Nice: 10, IONice: IONiceIdle, OOMScoreAdj: 500}
+		So((&Job{}).politeness(defaults), ShouldResemble, defaults)
+		So((&Job{Nice: 5, OOMScoreAdj: 100}).politeness(defaults), ShouldResemble, Politeness{Nice: 5, IONice: IONiceIdle, OOMScoreAdj: 100})
+	})
+
+	Convey("Politeness can be applied to a process tree", t, func() {
+		cmd := exec.Command("bash", "-c", "sleep 2 & wait")
+		So(cmd.Start(), ShouldBeNil)
+		defer func() {
+			if errk := cmd.Process.Kill(); errk == nil {
+				errw := cmd.Wait()
+				So(errw, ShouldNotBeNil)
+			}
+		}()
+
+		p := Politeness{Nice: 7, OOMScoreAdj: 300}
+		if internal.Which("ionice") != "" {
+			p.IONice = IONiceIdle
+		}
+		So(p.apply(cmd.Process.Pid), ShouldBeNil)
+
+		nice, err := syscall.Getpriority(syscall.PRIO_PROCESS, cmd.Process.Pid)
+		So(err, ShouldBeNil)
+		So(20-nice, ShouldEqual, 7)
+
+		adj, err := ioutil.ReadFile("/proc/" + strconv.Itoa(cmd.Process.Pid) + "/oom_score_adj")
+		So(err, ShouldBeNil)
+		So(strings.TrimSpace(string(adj)), ShouldEqual, "300")
+	})
+}
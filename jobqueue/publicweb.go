@@ -0,0 +1,51 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of the read-only public status page,
+// which lets people see the progress of jobs without being able to change
+// anything, so that it can be shared widely.
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/VertebrateResequencing/wr/internal"
+)
+
+// servePublicWeb starts serving the read-only status page on the given
+// listener, returning the http.Server doing so.
+func (s *Server) servePublicWeb(listener net.Listener, certFile, keyFile string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", webInterfaceStatic(s, true))
+	mux.HandleFunc("/status_ws", webInterfaceStatusWS(s, true))
+	srv := &http.Server{Handler: mux}
+
+	wgk := s.wg.Add(1)
+	go func() {
+		defer internal.LogPanic(s.Logger, "jobqueue public web server", true)
+		defer s.wg.Done(wgk)
+		errs := srv.ServeTLS(listener, certFile, keyFile)
+		if errs != nil && errs != http.ErrServerClosed {
+			s.Error("server public web interface had problems", "err", errs)
+		}
+	}()
+
+	return srv
+}
@@ -0,0 +1,221 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of named queues, which let unrelated
+// workloads share a manager while being isolated from each other: each queue's
+// jobs get their own runners, and each queue can have its own limit on how many
+// of its jobs run at once and its own scheduler settings.
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	jqs "github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+)
+
+// queueLimitGroupPrefix is prefixed to the name of a queue to form the name of
+// the limit group that all the queue's jobs belong to.
+const queueLimitGroupPrefix = "queue."
+
+// validQueueName matches the names that queues may have, which can safely form
+// part of limit and scheduler group names.
+var validQueueName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// QueueConfig holds the settings of a named queue. Jobs are put in a named
+// queue by setting their Queue property when they are added; jobs without one
+// are in the default queue, which has no settings.
+//
+// Each named queue's jobs are run by their own runners, separately from jobs in
+// other queues, even if they'd otherwise have been grouped together.
+type QueueConfig struct {
+	Name string
+
+	// Limit is the maximum number of the queue's jobs that may run at once; 0
+	// means no limit. It is implemented as a limit group named "queue." plus
+	// the queue's name, which all the queue's jobs belong to.
+	Limit int
+
+	// Scheduler, SchedulerQueue and SchedulerMisc are applied to the queue's
+	// jobs that don't set their own when they are added, as if supplied to the
+	// "scheduler", "queue" and "misc" options of "wr add". Changing them
+	// doesn't affect jobs that were already added.
+	Scheduler      string
+	SchedulerQueue string
+	SchedulerMisc  string
+}
+
+// Validate checks that the queue has a valid name and a sensible limit.
+func (qc *QueueConfig) Validate() error {
+	if err := validateQueueName(qc.Name); err != nil {
+		return err
+	}
+	if qc.Limit < 0 {
+		return fmt.Errorf("a queue's limit can't be negative")
+	}
+	return nil
+}
+
+// String describes the queue's settings.
+func (qc *QueueConfig) String() string {
+	limit := "no limit"
+	if qc.Limit > 0 {
+		limit = fmt.Sprintf("limit %d", qc.Limit)
+	}
+	return fmt.Sprintf("%s: %s; scheduler %q, scheduler queue %q, scheduler misc %q",
+		qc.Name, limit, qc.Scheduler, qc.SchedulerQueue, qc.SchedulerMisc)
+}
+
+// validateQueueName returns an error if the given name can't be used for a
+// named queue.
+func validateQueueName(name string) error {
+	if !validQueueName.MatchString(name) {
+		return fmt.Errorf("%s [%s]: only letters, numbers, _, . and - are allowed", ErrBadQueue, name)
+	}
+	return nil
+}
+
+// queueLimitGroup returns the name of the limit group of the given queue.
+func queueLimitGroup(name string) string {
+	return queueLimitGroupPrefix + name
+}
+
+// restoreQueueConfigs loads the queue settings stored in the database.
+func (s *Server) restoreQueueConfigs() {
+	qcs, err := s.db.retrieveQueueConfigs()
+	if err != nil {
+		s.Warn("failed to retrieve stored queue settings", "err", err)
+		return
+	}
+	s.qcmutex.Lock()
+	defer s.qcmutex.Unlock()
+	for _, qc := range qcs {
+		s.queueConfigs[qc.Name] = qc
+	}
+}
+
+// setQueueConfig validates the given settings and sets them as the settings of
+// their queue, replacing any existing settings. Returns a copy of the settings
+// as set.
+func (s *Server) setQueueConfig(qc *QueueConfig) (*QueueConfig, error) {
+	if err := qc.Validate(); err != nil {
+		return nil, err
+	}
+
+	set := *qc
+	if err := s.setQueueLimit(set.Name, set.Limit); err != nil {
+		return nil, err
+	}
+	if err := s.db.storeQueueConfig(&set); err != nil {
+		return nil, err
+	}
+
+	s.qcmutex.Lock()
+	stored := set
+	s.queueConfigs[set.Name] = &stored
+	s.qcmutex.Unlock()
+
+	s.Info("set queue", "name", set.Name, "limit", set.Limit, "scheduler", set.Scheduler, "queue", set.SchedulerQueue)
+	return &set, nil
+}
+
+// setQueueLimit sets the limit of the given queue's limit group, with 0
+// removing the limit.
+func (s *Server) setQueueLimit(name string, limit int) error {
+	if limit == 0 {
+		limit = -1
+	}
+	return s.storeLimitGroups(map[string]int{queueLimitGroup(name): limit})
+}
+
+// deleteQueueConfig removes the settings of the given queue, including its
+// limit. Returns false if it didn't have any.
+func (s *Server) deleteQueueConfig(name string) (bool, error) {
+	s.qcmutex.Lock()
+	_, exists := s.queueConfigs[name]
+	delete(s.queueConfigs, name)
+	s.qcmutex.Unlock()
+	if !exists {
+		return false, nil
+	}
+
+	if err := s.setQueueLimit(name, 0); err != nil {
+		return true, err
+	}
+	s.Info("deleted queue", "name", name)
+	return true, s.db.deleteQueueConfig(name)
+}
+
+// queueConfigList returns copies of the settings of all the queues, sorted by
+// name.
+func (s *Server) queueConfigList() []*QueueConfig {
+	s.qcmutex.RLock()
+	qcs := make([]*QueueConfig, 0, len(s.queueConfigs))
+	for _, qc := range s.queueConfigs {
+		c := *qc
+		qcs = append(qcs, &c)
+	}
+	s.qcmutex.RUnlock()
+	sort.Slice(qcs, func(i, j int) bool {
+		return qcs[i].Name < qcs[j].Name
+	})
+	return qcs
+}
+
+// applyQueueConfig puts the given job in its queue's limit group, and applies
+// its queue's scheduler settings to it, if it is in a named queue. You should
+// hold the lock on the Job before calling this.
+func (s *Server) applyQueueConfig(job *Job) {
+	if job.Queue == "" {
+		return
+	}
+
+	group := queueLimitGroup(job.Queue)
+	inGroup := false
+	for _, lg := range job.LimitGroups {
+		if lg == group {
+			inGroup = true
+			break
+		}
+	}
+	if !inGroup {
+		job.LimitGroups = append(job.LimitGroups, group)
+	}
+
+	s.qcmutex.RLock()
+	qc, exists := s.queueConfigs[job.Queue]
+	s.qcmutex.RUnlock()
+	if !exists {
+		return
+	}
+
+	if job.Requirements.Other == nil {
+		job.Requirements.Other = make(map[string]string)
+	}
+	for key, val := range map[string]string{
+		jqs.MultiOtherKey: qc.Scheduler,
+		"scheduler_queue": qc.SchedulerQueue,
+		"scheduler_misc":  qc.SchedulerMisc,
+	} {
+		if _, set := job.Requirements.Other[key]; val != "" && !set {
+			job.Requirements.Other[key] = val
+		}
+	}
+}
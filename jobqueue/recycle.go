@@ -0,0 +1,115 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of "soft" deletion: jobs that are
+// deleted are kept in a recycle bucket for the server's RecycleWindow, so that
+// they can be restored if they were deleted by mistake.
+
+import (
+	"strings"
+	"time"
+)
+
+// RecycledJob describes a Job that was deleted within the server's
+// RecycleWindow, so can still be restored with Client.Undelete().
+type RecycledJob struct {
+	Job     *Job
+	Deleted time.Time
+}
+
+// removeLiveJobs deletes the jobs with the given keys from the live bucket,
+// keeping them in the recycle bucket if we have a recycleWindow. Returns the
+// time they were recycled at.
+func (s *Server) removeLiveJobs(keys []string) (time.Time, error) {
+	deleted := time.Now()
+	if s.recycleWindow <= 0 {
+		return deleted, s.db.deleteLiveJobs(keys)
+	}
+	return deleted, s.db.recycleLiveJobs(keys, deleted)
+}
+
+// pruneRecycled permanently deletes the recycled jobs that were deleted longer
+// than our recycleWindow ago.
+func (s *Server) pruneRecycled() {
+	if err := s.db.deleteRecycledBefore(time.Now().Add(-s.recycleWindow)); err != nil {
+		s.Warn("failed to delete old recycled jobs", "err", err)
+	}
+}
+
+// recycledJobs returns the jobs deleted within our recycleWindow, at or after
+// since, that have the given RepGroup (or any RepGroup, if blank, or any
+// containing it, if subStr is true).
+func (s *Server) recycledJobs(repGroup string, subStr bool, since time.Time) ([]*RecycledJob, error) {
+	if earliest := time.Now().Add(-s.recycleWindow); since.Before(earliest) {
+		since = earliest
+	}
+	recycled, err := s.db.retrieveRecycledJobs(since)
+	if err != nil || repGroup == "" {
+		return recycled, err
+	}
+
+	var matching []*RecycledJob
+	for _, rj := range recycled {
+		if rj.Job.RepGroup == repGroup || (subStr && strings.Contains(rj.Job.RepGroup, repGroup)) {
+			matching = append(matching, rj)
+		}
+	}
+	return matching, nil
+}
+
+// undeleteJobs adds back to the queue the jobs that recycledJobs() would
+// return for the given arguments, removing them from the recycle bucket.
+// The restoration is recorded as having been asked for by the given user.
+// Returns how many were added back; jobs that have since been added again are
+// not counted.
+func (s *Server) undeleteJobs(repGroup string, subStr bool, since time.Time, user string) (int, string, error) {
+	recycled, err := s.recycledJobs(repGroup, subStr, since)
+	if err != nil {
+		return 0, ErrDBError, err
+	}
+
+	// jobs are added with a single environment, so we add back those that
+	// share one together
+	ua := &UserAction{Action: EventTypeRestore, User: user, Time: time.Now()}
+	byEnv := make(map[string][]*Job)
+	keys := make([]string, 0, len(recycled))
+	for _, rj := range recycled {
+		rj.Job.LastAction = ua
+		byEnv[rj.Job.EnvKey] = append(byEnv[rj.Job.EnvKey], rj.Job)
+		keys = append(keys, rj.Job.Key())
+	}
+
+	restored := 0
+	for envkey, jobs := range byEnv {
+		added, _, _, srerr, errc := s.createJobs(jobs, envkey, true, user)
+		if errc != nil {
+			return restored, srerr, errc
+		}
+		if added > 0 {
+			s.recordEvent(&Event{Type: EventTypeRestore, RepGroup: commonRepGroup(jobs), Count: added, User: user})
+		}
+		restored += added
+	}
+
+	if err = s.db.deleteRecycledJobs(keys); err != nil {
+		return restored, ErrDBError, err
+	}
+	return restored, "", nil
+}
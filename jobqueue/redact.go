@@ -0,0 +1,132 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of redaction rules, which hide
+// sensitive parts of the STDOUT and STDERR of jobs.
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// RedactionRules is a slice of regular expressions. Text in the STDOUT and
+// STDERR of jobs, or the output of their behaviours, that matches any of them
+// is replaced with "[redacted]" before being stored or displayed. If a regular expression has a capturing group,
+// only the text matching the first group is replaced, so that eg.
+// `password=(\S+)` leaves "password=" visible.
+type RedactionRules []*regexp.Regexp
+
+// NewRedactionRules compiles the given regular expressions in to
+// RedactionRules.
+func NewRedactionRules(exprs ...string) (RedactionRules, error) {
+	rrs := make(RedactionRules, 0, len(exprs))
+	for _, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("redaction rule [%s] is invalid: %s", expr, err)
+		}
+		rrs = append(rrs, re)
+	}
+	return rrs, nil
+}
+
+// RedactionRulesFromFile reads regular expressions from the given file, one per
+// line, and returns them compiled. Blank lines and lines starting with # are
+// ignored.
+func RedactionRulesFromFile(path string) (RedactionRules, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var exprs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		exprs = append(exprs, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewRedactionRules(exprs...)
+}
+
+// Redact returns b with all text matching our rules replaced.
+func (rrs RedactionRules) Redact(b []byte) []byte {
+	for _, re := range rrs {
+		if re.NumSubexp() == 0 {
+			b = re.ReplaceAllLiteral(b, []byte(secretRedaction))
+			continue
+		}
+
+		b = re.ReplaceAllFunc(b, func(match []byte) []byte {
+			loc := re.FindSubmatchIndex(match)
+			if len(loc) < 4 || loc[2] < 0 {
+				return match
+			}
+			redacted := make([]byte, 0, len(match))
+			redacted = append(redacted, match[:loc[2]]...)
+			redacted = append(redacted, secretRedaction...)
+			return append(redacted, match[loc[3]:]...)
+		})
+	}
+	return b
+}
+
+// redactBehaviourResults redacts the Output and Error of the BehaviourResults
+// in the given JobEndState, in place.
+func (rrs RedactionRules) redactBehaviourResults(jes *JobEndState) {
+	if len(rrs) == 0 || jes == nil {
+		return
+	}
+	for i := range jes.BehaviourResults {
+		br := &jes.BehaviourResults[i]
+		br.Output = string(rrs.Redact([]byte(br.Output)))
+		br.Error = string(rrs.Redact([]byte(br.Error)))
+	}
+}
+
+// redactCompressed is like Redact(), but works on compressed data such as a
+// Job's StdOutC, returning it re-compressed. If there are no rules or the data
+// can't be decompressed, it is returned unaltered.
+func (rrs RedactionRules) redactCompressed(compressed []byte) []byte {
+	if len(rrs) == 0 || len(compressed) == 0 {
+		return compressed
+	}
+
+	decomp, err := decompress(compressed)
+	if err != nil {
+		return compressed
+	}
+
+	redacted, err := compress(rrs.Redact(decomp))
+	if err != nil {
+		return compressed
+	}
+	return redacted
+}
@@ -0,0 +1,482 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of the cache of reference assets that
+// runners share on each host, so that large read-only inputs that many jobs
+// need (such as reference genomes) are fetched once per host instead of once
+// per job.
+//
+// The cache is a directory with a sub-directory per asset, named after its
+// checksum, containing the asset's data and a refs directory that holds a file
+// for each job currently using it. Processes coordinate using file locks, so
+// any number of runners can share the same cache.
+
+import (
+	"crypto/md5" // #nosec not used for security purposes
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RefAssetCacheDir is the directory that runners on a host share to cache
+// Jobs' RefAssets in. It should be set before the first Job with RefAssets is
+// executed.
+var RefAssetCacheDir = filepath.Join(os.TempDir(), "wr_ref_assets")
+
+// RefAssetCacheSize is the number of bytes the assets in RefAssetCacheDir may
+// use before those no Job is using get deleted, least recently used first. The
+// default of 0 means assets are never deleted.
+var RefAssetCacheSize int64
+
+// refAsset* are the names of the files in RefAssetCacheDir and its asset
+// directories.
+const (
+	refAssetLockFile = ".lock"
+	refAssetDataFile = "data"
+	refAssetRefsDir  = "refs"
+)
+
+// refAssetChecksum* are the supported RefAsset Checksum algorithms.
+const (
+	refAssetChecksumMD5    = "md5"
+	refAssetChecksumSHA256 = "sha256"
+)
+
+// RefAsset describes a large, read-only file that a Job's Cmd needs, which is
+// shared by many Jobs and so cached on each host that runs them.
+type RefAsset struct {
+	// Source is the absolute path or http(s) URL of the asset.
+	Source string `json:"source"`
+
+	// Checksum is the expected checksum of the asset, as "md5:<hex>" or
+	// "sha256:<hex>". The algorithm prefix can be left off, in which case it
+	// is determined by the length of the hex.
+	Checksum string `json:"checksum"`
+
+	// Name is the name the asset is given in the Cmd's working directory.
+	// Defaults to the basename of Source.
+	Name string `json:"name,omitempty"`
+}
+
+// validate checks that our Source is absolute or a URL, and that our Checksum
+// is usable.
+func (ra *RefAsset) validate() error {
+	if ra.isURL() {
+		u, err := url.Parse(ra.Source)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("reference asset source [%s] is not a valid URL", ra.Source)
+		}
+	} else if !filepath.IsAbs(ra.Source) {
+		return fmt.Errorf("reference asset source [%s] is not an absolute path or http(s) URL", ra.Source)
+	}
+
+	if _, _, err := ra.checksum(); err != nil {
+		return err
+	}
+
+	if name := ra.name(); name == "" || name == "." || name == "/" || strings.Contains(name, "/") {
+		return fmt.Errorf("reference asset [%s] does not have a usable name", ra.Source)
+	}
+	return nil
+}
+
+// isURL tells you if our Source is a http(s) URL, as opposed to a path.
+func (ra *RefAsset) isURL() bool {
+	return strings.HasPrefix(ra.Source, "http://") || strings.HasPrefix(ra.Source, "https://")
+}
+
+// checksum returns the algorithm and lower-case hex of our Checksum.
+func (ra *RefAsset) checksum() (string, string, error) {
+	algo, sum := "", strings.ToLower(strings.TrimSpace(ra.Checksum))
+	if i := strings.IndexByte(sum, ':'); i >= 0 {
+		algo, sum = sum[:i], sum[i+1:]
+	}
+
+	var length int
+	switch algo {
+	case refAssetChecksumMD5:
+		length = hex.EncodedLen(md5.Size)
+	case refAssetChecksumSHA256:
+		length = hex.EncodedLen(sha256.Size)
+	case "":
+		length = len(sum)
+		switch length {
+		case hex.EncodedLen(md5.Size):
+			algo = refAssetChecksumMD5
+		case hex.EncodedLen(sha256.Size):
+			algo = refAssetChecksumSHA256
+		}
+	}
+
+	if _, err := hex.DecodeString(sum); algo == "" || err != nil || len(sum) != length {
+		return "", "", fmt.Errorf("reference asset [%s] checksum [%s] is not md5:<hex> or sha256:<hex>", ra.Source, ra.Checksum)
+	}
+	return algo, sum, nil
+}
+
+// name returns our Name, defaulting to the basename of our Source.
+func (ra *RefAsset) name() string {
+	if ra.Name != "" {
+		return ra.Name
+	}
+	if ra.isURL() {
+		if u, err := url.Parse(ra.Source); err == nil {
+			return path.Base(u.Path)
+		}
+	}
+	return filepath.Base(ra.Source)
+}
+
+// validateRefAssets checks that all the given RefAssets are valid, and that no
+// two would have the same name in a working directory.
+func validateRefAssets(assets []*RefAsset) error {
+	names := make(map[string]bool, len(assets))
+	for _, ra := range assets {
+		if ra == nil {
+			return fmt.Errorf("reference assets contain a null entry")
+		}
+		if err := ra.validate(); err != nil {
+			return err
+		}
+		name := ra.name()
+		if names[name] {
+			return fmt.Errorf("more than one reference asset is named [%s]", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// refAssetCache is the host-wide cache of reference assets in a directory.
+type refAssetCache struct {
+	dir     string
+	maxSize int64
+	client  *http.Client
+}
+
+// newRefAssetCache returns a refAssetCache for RefAssetCacheDir.
+func newRefAssetCache() *refAssetCache {
+	return &refAssetCache{dir: RefAssetCacheDir, maxSize: RefAssetCacheSize, client: http.DefaultClient}
+}
+
+// entryDir returns the directory the given asset is cached in.
+func (rc *refAssetCache) entryDir(ra *RefAsset) (string, error) {
+	algo, sum, err := ra.checksum()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(rc.dir, algo+"-"+sum), nil
+}
+
+// refName returns the name of the file that notes the given job using an
+// asset.
+func refName(jobKey string) string {
+	return strconv.Itoa(os.Getpid()) + "." + jobKey
+}
+
+// lockFile takes an exclusive lock on the file at the given path, creating it if
+// necessary. Call the returned function to unlock.
+func lockFile(path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		f.Close()
+	}, nil
+}
+
+// acquire makes sure the given job's RefAssets are in the cache, fetching any
+// that aren't, and notes that the job is using them, so that they won't be
+// deleted until release() is called. Each is then symlinked in to the given
+// directory.
+func (rc *refAssetCache) acquire(job *Job, dir string) error {
+	if err := os.MkdirAll(rc.dir, 0700); err != nil {
+		return err
+	}
+
+	key := job.Key()
+	for _, ra := range job.RefAssets {
+		entry, err := rc.entryDir(ra)
+		if err != nil {
+			return err
+		}
+
+		if err = rc.addRef(entry, key); err != nil {
+			return err
+		}
+
+		data, err := rc.fetch(ra, entry)
+		if err != nil {
+			return err
+		}
+
+		link := filepath.Join(dir, ra.name())
+		if err = os.Remove(link); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err = os.Symlink(data, link); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addRef notes that the job with the given key is using the asset cached in
+// the given directory. This is done under the cache-wide lock, so that the
+// asset can't be evicted at the same time.
+func (rc *refAssetCache) addRef(entry, key string) error {
+	unlock, err := lockFile(filepath.Join(rc.dir, refAssetLockFile))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	refs := filepath.Join(entry, refAssetRefsDir)
+	if err = os.MkdirAll(refs, 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(refs, refName(key)), nil, 0600)
+}
+
+// fetch makes sure the given asset's data is in the given entry directory,
+// downloading or copying it if not, and returns the path to the data. Only one
+// process fetches a given asset at once; others wait for it to finish.
+func (rc *refAssetCache) fetch(ra *RefAsset, entry string) (string, error) {
+	unlock, err := lockFile(filepath.Join(entry, refAssetLockFile))
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	data := filepath.Join(entry, refAssetDataFile)
+	if _, err = os.Stat(data); err == nil {
+		now := time.Now()
+		return data, os.Chtimes(data, now, now)
+	}
+
+	algo, sum, err := ra.checksum()
+	if err != nil {
+		return "", err
+	}
+
+	src, err := rc.open(ra)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	tmp, err := ioutil.TempFile(entry, refAssetDataFile+".")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	var h hash.Hash
+	if algo == refAssetChecksumMD5 {
+		h = md5.New() // #nosec not used for security purposes
+	} else {
+		h = sha256.New()
+	}
+	_, err = io.Copy(io.MultiWriter(tmp, h), src)
+	if errc := tmp.Close(); err == nil {
+		err = errc
+	}
+	if err != nil {
+		return "", fmt.Errorf("reference asset [%s] could not be fetched: %w", ra.Source, err)
+	}
+
+	if got := hex.EncodeToString(h.Sum(nil)); got != sum {
+		return "", fmt.Errorf("reference asset [%s] has %s checksum %s, not %s", ra.Source, algo, got, sum)
+	}
+
+	if err = os.Chmod(tmp.Name(), 0444); err != nil {
+		return "", err
+	}
+	return data, os.Rename(tmp.Name(), data)
+}
+
+// open returns a reader of the given asset's Source.
+func (rc *refAssetCache) open(ra *RefAsset) (io.ReadCloser, error) {
+	if !ra.isURL() {
+		return os.Open(ra.Source)
+	}
+
+	resp, err := rc.client.Get(ra.Source)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("reference asset [%s] could not be downloaded: %s", ra.Source, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// release notes that the given job is no longer using its RefAssets, removing
+// their symlinks from the given directory, then deletes unused assets if the
+// cache is bigger than desired.
+func (rc *refAssetCache) release(job *Job, dir string) error {
+	unlock, err := lockFile(filepath.Join(rc.dir, refAssetLockFile))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	key := job.Key()
+	for _, ra := range job.RefAssets {
+		entry, err := rc.entryDir(ra)
+		if err != nil {
+			return err
+		}
+
+		link := filepath.Join(dir, ra.name())
+		if target, errl := os.Readlink(link); errl == nil && target == filepath.Join(entry, refAssetDataFile) {
+			if err = os.Remove(link); err != nil {
+				return err
+			}
+		}
+
+		err = os.Remove(filepath.Join(entry, refAssetRefsDir, refName(key)))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return rc.evict()
+}
+
+// refAssetEntry describes an asset directory in the cache, for eviction.
+type refAssetEntry struct {
+	dir     string
+	size    int64
+	used    time.Time
+	refs    int
+	fetched bool
+}
+
+// evict deletes the least recently used assets that no job is using, until
+// the cache is no bigger than our maxSize. You must hold the cache-wide lock.
+func (rc *refAssetCache) evict() error {
+	if rc.maxSize <= 0 {
+		return nil
+	}
+
+	entries, err := rc.entries()
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].used.Before(entries[j].used)
+	})
+	for _, e := range entries {
+		if total <= rc.maxSize {
+			break
+		}
+		if e.refs > 0 || !e.fetched {
+			continue
+		}
+		if err = os.RemoveAll(e.dir); err != nil {
+			return err
+		}
+		total -= e.size
+	}
+	return nil
+}
+
+// entries describes the assets in the cache, removing the refs of processes
+// that no longer exist.
+func (rc *refAssetCache) entries() ([]*refAssetEntry, error) {
+	infos, err := ioutil.ReadDir(rc.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*refAssetEntry
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		e := &refAssetEntry{dir: filepath.Join(rc.dir, info.Name())}
+		if data, errs := os.Stat(filepath.Join(e.dir, refAssetDataFile)); errs == nil {
+			e.size = data.Size()
+			e.used = data.ModTime()
+			e.fetched = true
+		}
+
+		refs := filepath.Join(e.dir, refAssetRefsDir)
+		names, errr := ioutil.ReadDir(refs)
+		if errr != nil && !os.IsNotExist(errr) {
+			return nil, errr
+		}
+		for _, ref := range names {
+			if refProcessExists(ref.Name()) {
+				e.refs++
+				continue
+			}
+			if errr = os.Remove(filepath.Join(refs, ref.Name())); errr != nil && !os.IsNotExist(errr) {
+				return nil, errr
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// refProcessExists tells you if the process that created the ref file with the
+// given name is still running.
+func refProcessExists(ref string) bool {
+	i := strings.IndexByte(ref, '.')
+	if i < 0 {
+		return false
+	}
+	pid, err := strconv.Atoi(ref[:i])
+	if err != nil {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
@@ -0,0 +1,173 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRefAssets(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Reference assets are validated", t, func() {
+		md5sum := "md5:5d41402abc4b2a76b9719d911017c592"
+		So(validateRefAssets([]*RefAsset{{Source: "/ref/hg38.fa", Checksum: md5sum}}), ShouldBeNil)
+		So(validateRefAssets([]*RefAsset{{Source: "https://example.com/hg38.fa", Checksum: "5d41402abc4b2a76b9719d911017c592"}}), ShouldBeNil)
+		So(validateRefAssets([]*RefAsset{{Source: "/ref/hg38.fa", Checksum: "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"}}), ShouldBeNil)
+		So(validateRefAssets([]*RefAsset{{Source: "ref/hg38.fa", Checksum: md5sum}}), ShouldNotBeNil)
+		So(validateRefAssets([]*RefAsset{{Source: "https:///hg38.fa", Checksum: md5sum}}), ShouldNotBeNil)
+		So(validateRefAssets([]*RefAsset{{Source: "/ref/hg38.fa", Checksum: "md5:abc"}}), ShouldNotBeNil)
+		So(validateRefAssets([]*RefAsset{{Source: "/ref/hg38.fa", Checksum: "sha1:aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"}}), ShouldNotBeNil)
+		So(validateRefAssets([]*RefAsset{{Source: "/ref/hg38.fa"}}), ShouldNotBeNil)
+		So(validateRefAssets([]*RefAsset{{Source: "/ref/hg38.fa", Checksum: md5sum, Name: "a/b"}}), ShouldNotBeNil)
+		So(validateRefAssets([]*RefAsset{{Source: "/ref/hg38.fa", Checksum: md5sum}, {Source: "/other/hg38.fa", Checksum: md5sum}}), ShouldNotBeNil)
+		So(validateRefAssets([]*RefAsset{nil}), ShouldNotBeNil)
+
+		_, err := (&JobViaJSON{Cmd: "true", RefAssets: []*RefAsset{{Source: "ref.fa", Checksum: md5sum}}}).Convert(&JobDefaults{})
+		So(err, ShouldNotBeNil)
+		job, err := (&JobViaJSON{Cmd: "true"}).Convert(&JobDefaults{RefAssets: []*RefAsset{{Source: "/ref.fa", Checksum: md5sum}}})
+		So(err, ShouldBeNil)
+		So(len(job.RefAssets), ShouldEqual, 1)
+	})
+
+	Convey("Given a reference asset cache", t, func() {
+		tmpdir, err := ioutil.TempDir("", "wr_jobqueue_test_refassets_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(tmpdir)
+
+		cacheDir := filepath.Join(tmpdir, "cache")
+		cache := &refAssetCache{dir: cacheDir, client: http.DefaultClient}
+
+		src := filepath.Join(tmpdir, "hello.fa")
+		err = ioutil.WriteFile(src, []byte("hello"), 0600)
+		So(err, ShouldBeNil)
+		asset := &RefAsset{Source: src, Checksum: "md5:5d41402abc4b2a76b9719d911017c592"}
+		entry, err := cache.entryDir(asset)
+		So(err, ShouldBeNil)
+
+		cwd1 := filepath.Join(tmpdir, "cwd1")
+		cwd2 := filepath.Join(tmpdir, "cwd2")
+		So(os.Mkdir(cwd1, 0700), ShouldBeNil)
+		So(os.Mkdir(cwd2, 0700), ShouldBeNil)
+		job1 := &Job{Cmd: "job1", RefAssets: []*RefAsset{asset}}
+		job2 := &Job{Cmd: "job2", RefAssets: []*RefAsset{{Source: asset.Source, Checksum: asset.Checksum, Name: "ref.fa"}}}
+
+		Convey("Jobs share a single fetched copy of an asset", func() {
+			err = cache.acquire(job1, cwd1)
+			So(err, ShouldBeNil)
+			content, err := ioutil.ReadFile(filepath.Join(cwd1, "hello.fa"))
+			So(err, ShouldBeNil)
+			So(string(content), ShouldEqual, "hello")
+
+			err = os.Remove(src)
+			So(err, ShouldBeNil)
+			err = cache.acquire(job2, cwd2)
+			So(err, ShouldBeNil)
+			content, err = ioutil.ReadFile(filepath.Join(cwd2, "ref.fa"))
+			So(err, ShouldBeNil)
+			So(string(content), ShouldEqual, "hello")
+
+			entries, err := cache.entries()
+			So(err, ShouldBeNil)
+			So(len(entries), ShouldEqual, 1)
+			So(entries[0].refs, ShouldEqual, 2)
+
+			Convey("Unused assets are evicted when the cache is too big", func() {
+				cache.maxSize = 1
+				err = cache.release(job1, cwd1)
+				So(err, ShouldBeNil)
+				_, err = os.Lstat(filepath.Join(cwd1, "hello.fa"))
+				So(os.IsNotExist(err), ShouldBeTrue)
+				_, err = os.Stat(entry)
+				So(err, ShouldBeNil)
+
+				err = cache.release(job2, cwd2)
+				So(err, ShouldBeNil)
+				_, err = os.Stat(entry)
+				So(os.IsNotExist(err), ShouldBeTrue)
+			})
+
+			Convey("Assets are kept if the cache is not too big", func() {
+				err = cache.release(job1, cwd1)
+				So(err, ShouldBeNil)
+				err = cache.release(job2, cwd2)
+				So(err, ShouldBeNil)
+				entries, err = cache.entries()
+				So(err, ShouldBeNil)
+				So(len(entries), ShouldEqual, 1)
+				So(entries[0].refs, ShouldEqual, 0)
+			})
+		})
+
+		Convey("Refs of processes that no longer exist are ignored", func() {
+			So(os.MkdirAll(filepath.Join(entry, refAssetRefsDir), 0700), ShouldBeNil)
+			So(ioutil.WriteFile(filepath.Join(entry, refAssetRefsDir, "999999999.key"), nil, 0600), ShouldBeNil)
+			So(ioutil.WriteFile(filepath.Join(entry, refAssetDataFile), []byte("hello"), 0600), ShouldBeNil)
+			entries, err := cache.entries()
+			So(err, ShouldBeNil)
+			So(len(entries), ShouldEqual, 1)
+			So(entries[0].refs, ShouldEqual, 0)
+			So(entries[0].fetched, ShouldBeTrue)
+		})
+
+		Convey("Assets with the wrong checksum are not cached", func() {
+			job1.RefAssets[0].Checksum = "md5:00000000000000000000000000000000"
+			err = cache.acquire(job1, cwd1)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "has md5 checksum 5d41402abc4b2a76b9719d911017c592")
+			entry, err = cache.entryDir(job1.RefAssets[0])
+			So(err, ShouldBeNil)
+			_, err = os.Stat(filepath.Join(entry, refAssetDataFile))
+			So(os.IsNotExist(err), ShouldBeTrue)
+		})
+
+		Convey("Assets can be downloaded", func() {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/ref/hello.fa" {
+					http.NotFound(w, r)
+					return
+				}
+				fmt.Fprint(w, "hello")
+			}))
+			defer ts.Close()
+
+			job := &Job{Cmd: "job", RefAssets: []*RefAsset{{Source: ts.URL + "/ref/hello.fa", Checksum: asset.Checksum}}}
+			err = cache.acquire(job, cwd1)
+			So(err, ShouldBeNil)
+			content, err := ioutil.ReadFile(filepath.Join(cwd1, "hello.fa"))
+			So(err, ShouldBeNil)
+			So(string(content), ShouldEqual, "hello")
+
+			job = &Job{Cmd: "job", RefAssets: []*RefAsset{{Source: ts.URL + "/missing.fa", Checksum: "md5:00000000000000000000000000000000"}}}
+			err = cache.acquire(job, cwd2)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "404")
+		})
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of job metrics, where a job's
+// ReportCmd is run after its Cmd succeeds, and the key=value lines it outputs
+// are stored with the job.
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+)
+
+// ClientReportCmdTimeout is how long a Job's ReportCmd is allowed to run for
+// before it is killed and no Metrics are recorded.
+var ClientReportCmdTimeout = 5 * time.Minute
+
+// runReportCmd runs the given job's ReportCmd using the given shell in the
+// given working directory and environment, as the job's RunAs user if runAs is
+// true, and returns the key=value lines of its STDOUT (with the values of the
+// given secrets redacted) as a map.
+func runReportCmd(job *Job, shell, dir string, env, secretEnv []string, runAs bool, logger log15.Logger) (map[string]string, error) {
+	runAsUser := ""
+	if runAs {
+		runAsUser = job.RunAs
+	}
+	cmd, err := shellCommand(shell, job.ReportCmd, runAsUser)
+	if err != nil {
+		return nil, fmt.Errorf("report command [%s] could not be run: %w", job.ReportCmd, err)
+	}
+	cmd.Dir = dir
+	cmd.Env = env
+	var stdout bytes.Buffer
+	stderr := &prefixSuffixSaver{N: 4096}
+	cmd.Stdout = &stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("report command [%s] could not be started: %w", job.ReportCmd, err)
+	}
+	timer := time.AfterFunc(ClientReportCmdTimeout, func() {
+		errk := cmd.Process.Kill()
+		if errk != nil {
+			logger.Warn("failed to kill report command after timeout", "err", errk)
+		}
+	})
+	err = cmd.Wait()
+	if !timer.Stop() {
+		return nil, fmt.Errorf("report command [%s] took longer than %s and was killed", job.ReportCmd, ClientReportCmdTimeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("report command [%s] failed: %w (%s)", job.ReportCmd, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return parseMetrics(redactSecrets(stdout.Bytes(), secretEnv)), nil
+}
+
+// parseMetrics returns the key=value lines in the given output as a map,
+// ignoring blank lines and those that don't contain an = after a key. Keys and
+// values have surrounding white space trimmed. Returns nil if there were no
+// such lines.
+func parseMetrics(output []byte) map[string]string {
+	var metrics map[string]string
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		i := strings.IndexByte(line, '=')
+		if i < 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:i])
+		if key == "" {
+			continue
+		}
+		if metrics == nil {
+			metrics = make(map[string]string)
+		}
+		metrics[key] = strings.TrimSpace(line[i+1:])
+	}
+	return metrics
+}
+
+// MetricsString returns the job's Metrics as key=value pairs separated by the
+// given separator, sorted by key.
+func (j *Job) MetricsString(sep string) string {
+	j.RLock()
+	defer j.RUnlock()
+	return joinMetrics(j.Metrics, sep)
+}
+
+// joinMetrics returns the given metrics as key=value pairs separated by sep,
+// sorted by key.
+func joinMetrics(metrics map[string]string, sep string) string {
+	keys := make([]string, 0, len(metrics))
+	for key := range metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + metrics[key]
+	}
+	return strings.Join(pairs, sep)
+}
@@ -0,0 +1,200 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of request idempotency, where the
+// server remembers its responses to client requests, so that when a client
+// that lost contact with us retries a request, we reply with the original
+// response instead of carrying out the request a second time. Batches of jobs
+// added with an add token are remembered in the same way, for longer, so that
+// any client can resubmit them.
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/patrickmn/go-cache"
+	sync "github.com/sasha-s/go-deadlock"
+)
+
+// readOnlyMethods are the clientRequest Methods that don't change anything,
+// so can safely be carried out again when retried, and whose (potentially
+// large) responses we therefore don't bother remembering.
+var readOnlyMethods = map[string]bool{
+	"ping":           true,
+	"backup":         true,
+	"getbc":          true,
+	"getbr":          true,
+	"getcomplete":    true,
+	"getevents":      true,
+	"getin":          true,
+	"getstatecounts": true,
+	"getrgs":         true,
+	"getrecycled":    true,
+	"sgroups":        true,
+	"listsecrets":    true,
+	"getsecrets":     true,
+	"getsettings":    true,
+	"getbset":        true,
+	"listbsets":      true,
+	"getbudgets":     true,
+	"getstartrates":  true,
+	"getlimitfbs":    true,
+	"getqueues":      true,
+	"getannotations": true,
+}
+
+// frequentMethods are the clientRequest Methods that do change things, but
+// that every runner makes so often that remembering their responses (which
+// for reserves include the job and its environment) would use too much
+// memory. A retried reserve is simply carried out again, since the originally
+// reserved job may well have been released by then, and the others are safe
+// to repeat.
+var frequentMethods = map[string]bool{
+	"reserve": true,
+	"jtouch":  true,
+	"jphase":  true,
+}
+
+// requestResponse is a response to a client request that is either still
+// being worked on, or has been sent.
+type requestResponse struct {
+	encoded   []byte
+	batch     string
+	done      chan struct{}
+	once      sync.Once
+	abandoned bool
+}
+
+// finish sets the encoded response, which retries of the request will be
+// replied with. Only the first call has any effect, and it's safe to call on a
+// nil requestResponse.
+func (rr *requestResponse) finish(encoded []byte) {
+	if rr == nil {
+		return
+	}
+	rr.once.Do(func() {
+		rr.encoded = encoded
+		close(rr.done)
+	})
+}
+
+// response waits until the original request has been dealt with, then returns
+// the encoded response that was sent for it. Returns nil if we failed to
+// reply to the original request. The bool is true if the original request was
+// abandoned, in which case you should begin() the retry afresh.
+func (rr *requestResponse) response() ([]byte, bool) {
+	<-rr.done
+	return rr.encoded, rr.abandoned
+}
+
+// requestCache remembers responses to client requests for a while.
+type requestCache struct {
+	cache *cache.Cache
+	mutex sync.Mutex
+}
+
+// newRequestCache creates a requestCache that forgets responses after expiry.
+func newRequestCache(expiry time.Duration) *requestCache {
+	return &requestCache{cache: cache.New(expiry, 2*expiry)}
+}
+
+// begin should be called before carrying out the given request. If the
+// request is a retry of one we've already seen (or are still working on), or
+// an add of a batch of jobs with an add token we've already seen, the first
+// returned bool is true and you should reply with the returned
+// requestResponse's response() instead of carrying it out. Otherwise, you must
+// call finish() on the returned requestResponse (which will be nil for
+// requests that don't need to be remembered) with your reply.
+//
+// The second bool is true if the request's add token was already used for a
+// different batch of jobs, in which case you should not carry it out.
+func (rc *requestCache) begin(cr *clientRequest) (*requestResponse, bool, bool) {
+	key, batch, expiry := rc.key(cr)
+	if key == "" {
+		return nil, false, false
+	}
+
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	if found, exists := rc.cache.Get(key); exists {
+		rr := found.(*requestResponse)
+		if rr.batch != batch {
+			return nil, false, true
+		}
+		return rr, true, false
+	}
+	rr := &requestResponse{batch: batch, done: make(chan struct{})}
+	rc.cache.Set(key, rr, expiry)
+	return rr, false, false
+}
+
+// key returns the key we remember the given request's response under, along
+// with the batch its add token is for, if any, and how long to remember it.
+// Returns an empty key for requests that don't need to be remembered.
+func (rc *requestCache) key(cr *clientRequest) (string, string, time.Duration) {
+	if cr.Method == "add" && cr.AddToken != "" {
+		return "add:" + cr.AddToken, addBatch(cr.Jobs), ServerAddTokenTime
+	}
+	if readOnlyMethods[cr.Method] || frequentMethods[cr.Method] || cr.RequestID == uuid.Nil {
+		return "", "", 0
+	}
+	return cr.ClientID.String() + cr.RequestID.String(), "", cache.DefaultExpiration
+}
+
+// forgetAdd forgets the response to the given request if it was an add with an
+// add token, so that resubmissions of a batch that failed get carried out.
+func (rc *requestCache) forgetAdd(cr *clientRequest) {
+	if cr.Method != "add" || cr.AddToken == "" {
+		return
+	}
+	rc.cache.Delete("add:" + cr.AddToken)
+}
+
+// abandon forgets the given request, which was abandoned before we could
+// carry it out (typically because the client went away), so that when the
+// client retries it, it gets carried out instead of being replied to with the
+// abandonment. Any retries already waiting on rr's response() are told to
+// begin() again.
+func (rc *requestCache) abandon(cr *clientRequest, rr *requestResponse) {
+	if rr == nil {
+		return
+	}
+	key, _, _ := rc.key(cr)
+	rc.mutex.Lock()
+	if found, exists := rc.cache.Get(key); exists && found.(*requestResponse) == rr {
+		rc.cache.Delete(key)
+	}
+	rc.mutex.Unlock()
+	rr.once.Do(func() {
+		rr.abandoned = true
+		close(rr.done)
+	})
+}
+
+// addBatch returns a string that identifies the given batch of jobs.
+func addBatch(jobs []*Job) string {
+	var b strings.Builder
+	for _, job := range jobs {
+		b.WriteString(job.Key())
+		b.WriteByte('\n')
+	}
+	return byteKey([]byte(b.String()))
+}
@@ -0,0 +1,143 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the server's handling of request contexts, which let it
+// stop working on requests that take too long or whose client has gone away.
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	sync "github.com/sasha-s/go-deadlock"
+	"nanomsg.org/go-mangos"
+)
+
+// portContext is the context shared by the in-flight requests of a client
+// connection, which is cancelled when the connection closes.
+type portContext struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	users  int
+}
+
+// portContexts tracks the portContexts of client connections with requests in
+// flight.
+type portContexts struct {
+	ctxs map[mangos.Port]*portContext
+	sync.Mutex
+}
+
+// newPortContexts creates an empty portContexts.
+func newPortContexts() *portContexts {
+	return &portContexts{ctxs: make(map[mangos.Port]*portContext)}
+}
+
+// acquire returns the context of the given connection, which will be cancelled
+// if the connection closes. You must call release() with the same port when
+// you're done with it. A nil port (eg. for a message we didn't receive from a
+// client) gets a context that is never cancelled.
+func (pc *portContexts) acquire(port mangos.Port) context.Context {
+	if port == nil {
+		return context.Background()
+	}
+	pc.Lock()
+	defer pc.Unlock()
+	p, exists := pc.ctxs[port]
+	if !exists {
+		ctx, cancel := context.WithCancel(context.Background())
+		p = &portContext{ctx: ctx, cancel: cancel}
+		pc.ctxs[port] = p
+		if !port.IsOpen() {
+			// it closed before we started tracking it
+			cancel()
+		}
+	}
+	p.users++
+	return p.ctx
+}
+
+// release says you're done with the context you acquire()d for the given port.
+func (pc *portContexts) release(port mangos.Port) {
+	if port == nil {
+		return
+	}
+	pc.Lock()
+	defer pc.Unlock()
+	p, exists := pc.ctxs[port]
+	if !exists {
+		return
+	}
+	p.users--
+	if p.users <= 0 {
+		p.cancel()
+		delete(pc.ctxs, port)
+	}
+}
+
+// portHook is a mangos.PortHook that cancels the context of connections that
+// close, so that work on their in-flight requests stops early.
+func (pc *portContexts) portHook(action mangos.PortAction, port mangos.Port) bool {
+	if action != mangos.PortActionRemove {
+		return true
+	}
+	pc.Lock()
+	defer pc.Unlock()
+	if p, exists := pc.ctxs[port]; exists {
+		p.cancel()
+	}
+	return true
+}
+
+// requestContext returns a context for the handling of a client request made
+// with the given method that was received on the given port. It is done when
+// the client disconnects or after our RequestTimeout, whichever is first.
+// Requests to reserve a job are not subject to the timeout, since clients say
+// how long they want to wait. You must call the returned function when you're
+// done handling the request.
+func (s *Server) requestContext(port mangos.Port, method string) (context.Context, func()) {
+	ctx := s.portCtxs.acquire(port)
+	if method == "reserve" {
+		return ctx, func() { s.portCtxs.release(port) }
+	}
+	ctx, cancel := s.timeoutContext(ctx)
+	return ctx, func() {
+		cancel()
+		s.portCtxs.release(port)
+	}
+}
+
+// timeoutContext returns a copy of the given context that is also done after
+// our RequestTimeout, if set.
+func (s *Server) timeoutContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout := time.Duration(atomic.LoadInt64(&s.requestTimeout)); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// abandoned returns ErrRequestAbandoned and the reason if the given context is
+// done, otherwise empty strings.
+func abandoned(ctx context.Context) (srerr string, qerr string) {
+	if err := ctx.Err(); err != nil {
+		return ErrRequestAbandoned, err.Error()
+	}
+	return "", ""
+}
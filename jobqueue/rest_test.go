@@ -20,16 +20,19 @@ package jobqueue
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -202,6 +205,70 @@ func TestREST(t *testing.T) {
 				So(len(jstati), ShouldEqual, 3)
 			})
 
+			Convey("You can watch the status of jobs via the web interface", func() {
+				sw, err := WatchStatus(config.ManagerCertDomain+":"+config.ManagerWeb, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+				So(err, ShouldBeNil)
+
+				<-time.After(250 * time.Millisecond)
+				ss := sw.Snapshot()
+				So(ss.RepGroups(), ShouldResemble, []string{"rp1", "rp2"})
+				So(ss.Counts[StatusAllRepGroups][JobStateReady], ShouldEqual, 3)
+				So(ss.Counts["rp1"][JobStateReady], ShouldEqual, 2)
+				So(ss.Counts["rp2"][JobStateReady], ShouldEqual, 1)
+				So(len(ss.Hosts), ShouldEqual, 0)
+				So(len(ss.Failures), ShouldEqual, 0)
+
+				jq, err := Connect(addr, config.ManagerCAFile, config.ManagerCertDomain, token, clientConnectTime)
+				So(err, ShouldBeNil)
+				defer func() {
+					err = jq.Disconnect()
+					if err != nil {
+						fmt.Printf("jq.Disconnect failed: %s\n", err)
+					}
+				}()
+
+				job, err := jq.Reserve(50 * time.Millisecond)
+				So(err, ShouldBeNil)
+				So(job.RepGroup, ShouldEqual, "rp1")
+				err = jq.Started(job, 1)
+				So(err, ShouldBeNil)
+
+				err = sw.RequestHosts()
+				So(err, ShouldBeNil)
+				<-time.After(50 * time.Millisecond)
+				ss = sw.Snapshot()
+				So(ss.Counts["rp1"][JobStateReady], ShouldEqual, 1)
+				So(ss.Counts["rp1"][JobStateRunning], ShouldEqual, 1)
+				So(ss.Hosts[job.Host], ShouldEqual, 1)
+
+				changes := make(chan string, 10)
+				sw.OnStateChange(func(repGroup string, from, to JobState, count int) {
+					changes <- fmt.Sprintf("%s %s %s %d", repGroup, from, to, count)
+				})
+				err = jq.Bury(job, nil, "test failure")
+				So(err, ShouldBeNil)
+				select {
+				case change := <-changes:
+					So(change, ShouldEqual, "rp1 running buried 1")
+				case <-time.After(1 * time.Second):
+					So(false, ShouldBeTrue)
+				}
+				<-time.After(50 * time.Millisecond)
+				ss = sw.Snapshot()
+				So(ss.Counts["rp1"][JobStateRunning], ShouldEqual, 0)
+				So(ss.Counts["rp1"][JobStateBuried], ShouldEqual, 1)
+				So(ss.Counts[StatusAllRepGroups][JobStateBuried], ShouldEqual, 1)
+				So(len(ss.Failures), ShouldEqual, 1)
+				So(ss.Failures[0].RepGroup, ShouldEqual, "rp1")
+				So(ss.Failures[0].Count, ShouldEqual, 1)
+				So(ss.Throughput, ShouldEqual, 0)
+
+				err = sw.Close()
+				So(err, ShouldBeNil)
+				<-sw.Done()
+				So(sw.Err(), ShouldBeNil)
+			})
+
 			Convey("You can GET the status of particular jobs using their ids", func() {
 				req, err := http.NewRequest(http.MethodGet, jobsEndPoint+"/de6d167c58701e55f5b9f9e1e91d7807", nil)
 				So(err, ShouldBeNil)
@@ -570,6 +637,72 @@ func TestREST(t *testing.T) {
 			So(jstati[0].Mounts, ShouldEqual, mountJSON)
 		})
 
+		Convey("You can POST multipart data to add jobs with an environment and input manifest", func() {
+			inputs := make([]string, 3)
+			for i, name := range []string{"param", "a", "b"} {
+				inputs[i] = filepath.Join(dir, "input."+name)
+				So(ioutil.WriteFile(inputs[i], []byte(name), 0600), ShouldBeNil)
+			}
+
+			post := func(sections map[string]string) *http.Response {
+				body := new(bytes.Buffer)
+				mw := multipart.NewWriter(body)
+				for _, name := range []string{"jobs", "env", "input_manifest", "other"} {
+					content, ok := sections[name]
+					if !ok {
+						continue
+					}
+					fw, err := mw.CreateFormField(name)
+					So(err, ShouldBeNil)
+					_, err = fw.Write([]byte(content))
+					So(err, ShouldBeNil)
+				}
+				So(mw.Close(), ShouldBeNil)
+
+				req, err := http.NewRequest(http.MethodPost, jobsEndPoint+"/?input_files="+url.QueryEscape(inputs[0]), body)
+				So(err, ShouldBeNil)
+				req.Header.Add("Authorization", bearer)
+				req.Header.Add("Content-Type", mw.FormDataContentType())
+				response, err := client.Do(req)
+				So(err, ShouldBeNil)
+				return response
+			}
+
+			response := post(map[string]string{
+				"jobs":           `[{"cmd":"echo multipart","env":["FOO=override"]}]`,
+				"env":            `["FOO=bar","BAR=baz"]`,
+				"input_manifest": inputs[1] + "\n# comment\n\n " + inputs[2] + " \n",
+			})
+			So(response.StatusCode, ShouldEqual, http.StatusCreated)
+			responseData, err := ioutil.ReadAll(response.Body)
+			So(err, ShouldBeNil)
+			var jstati []JStatus
+			err = json.Unmarshal(responseData, &jstati)
+			So(err, ShouldBeNil)
+			So(len(jstati), ShouldEqual, 1)
+
+			jobs, _, _ := server.getJobsByKeys(context.Background(), []string{jstati[0].Key}, false, true)
+			So(len(jobs), ShouldEqual, 1)
+			job := jobs[0]
+			So(job.InputFiles, ShouldResemble, inputs)
+			env, err := job.Env()
+			So(err, ShouldBeNil)
+			So(env, ShouldContain, "FOO=override")
+			So(env, ShouldContain, "BAR=baz")
+			So(env, ShouldNotContain, "FOO=bar")
+
+			response = post(map[string]string{"env": `["FOO=bar"]`})
+			So(response.StatusCode, ShouldEqual, http.StatusBadRequest)
+			response = post(map[string]string{"jobs": `[{"cmd":"echo bad env"}]`, "env": `["FOO"]`})
+			So(response.StatusCode, ShouldEqual, http.StatusBadRequest)
+			response = post(map[string]string{"jobs": `[{"cmd":"echo bad env"}]`, "env": `{"FOO":"bar"}`})
+			So(response.StatusCode, ShouldEqual, http.StatusBadRequest)
+			response = post(map[string]string{"jobs": `[{"cmd":"echo other"}]`, "other": "foo"})
+			So(response.StatusCode, ShouldEqual, http.StatusBadRequest)
+			response = post(map[string]string{"jobs": `[{"cmd":"echo big"}]`, "input_manifest": strings.Repeat("a", restPartMaxSize+1)})
+			So(response.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
 		Convey("Trying to POST a job with a non-existent cloud_script fails", func() {
 			cloudScript := filepath.Join(dir, "cloud.script")
 			uploadedScript := filepath.Join(dir, "cloud.script.uploaded")
@@ -0,0 +1,215 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of the Resubmit BehaviourAction, where
+// a Job whose Cmd failed is re-enqueued by the server with modified options.
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+)
+
+// ResubmitOptions is the Arg of a Resubmit Behaviour, describing how the Job
+// should be changed before it is re-enqueued.
+type ResubmitOptions struct {
+	// RAMMultiplier, if greater than 0, is what the Job's RAM requirement is
+	// multiplied by.
+	RAMMultiplier float64 `json:"ram_multiplier,omitempty"`
+
+	// RepGroup, if not blank, becomes the Job's RepGroup.
+	RepGroup string `json:"rep_group,omitempty"`
+
+	// CmdReplace maps substrings of the Job's Cmd to what they should be
+	// replaced with, eg. {"--threads 1": "--threads 4"}. Replacements are made
+	// in the sorted order of the substrings.
+	CmdReplace map[string]string `json:"cmd_replace,omitempty"`
+
+	// CmdAppend, if not blank, is appended to the Job's Cmd after a space, eg.
+	// "--low-memory".
+	CmdAppend string `json:"cmd_append,omitempty"`
+}
+
+// validate checks that the options are sensible.
+func (o *ResubmitOptions) validate() error {
+	if o.RAMMultiplier < 0 {
+		return fmt.Errorf("resubmit ram_multiplier (%g) can't be negative", o.RAMMultiplier)
+	}
+	for old := range o.CmdReplace {
+		if old == "" {
+			return fmt.Errorf("resubmit cmd_replace can't replace an empty string")
+		}
+	}
+	return nil
+}
+
+// cmd returns the given Cmd with our CmdReplace and CmdAppend applied.
+func (o *ResubmitOptions) cmd(cmd string) string {
+	olds := make([]string, 0, len(o.CmdReplace))
+	for old := range o.CmdReplace {
+		olds = append(olds, old)
+	}
+	sort.Strings(olds)
+	for _, old := range olds {
+		cmd = strings.ReplaceAll(cmd, old, o.CmdReplace[old])
+	}
+	if o.CmdAppend != "" {
+		cmd += " " + o.CmdAppend
+	}
+	return cmd
+}
+
+// MarshalBinary is used when encoding a Job for sending over the network or
+// storing in the database, where we'd otherwise become a map after a round
+// trip, since Behaviour.Arg is an interface{}. We encode as JSON.
+func (o ResubmitOptions) MarshalBinary() ([]byte, error) {
+	return json.Marshal(o)
+}
+
+// UnmarshalBinary is the reverse of MarshalBinary().
+func (o *ResubmitOptions) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// resubmitOptions converts the Arg of a Resubmit Behaviour to ResubmitOptions.
+// Jobs that have been sent over the network or stored in the database will
+// have had their ResubmitOptions turned in to the output of MarshalBinary(),
+// which we also handle.
+func resubmitOptions(arg interface{}) (*ResubmitOptions, error) {
+	var opts *ResubmitOptions
+	switch a := arg.(type) {
+	case *ResubmitOptions:
+		opts = a
+	case ResubmitOptions:
+		opts = &a
+	case []byte:
+		opts = &ResubmitOptions{}
+		if err := opts.UnmarshalBinary(a); err != nil {
+			return nil, fmt.Errorf("arg %s is not ResubmitOptions: %w", a, err)
+		}
+	case string:
+		opts = &ResubmitOptions{}
+		if err := opts.UnmarshalBinary([]byte(a)); err != nil {
+			return nil, fmt.Errorf("arg %s is not ResubmitOptions: %w", a, err)
+		}
+	}
+	if opts == nil {
+		return nil, fmt.Errorf("arg %v is type %T, not *ResubmitOptions", arg, arg)
+	}
+	return opts, opts.validate()
+}
+
+// resubmit notes that the Job should be re-enqueued with the ResubmitOptions in
+// our Arg, which Execute() then passes on to the server.
+func (b *Behaviour) resubmit(j *Job) error {
+	opts, err := resubmitOptions(b.Arg)
+	if err != nil {
+		return err
+	}
+
+	j.Lock()
+	defer j.Unlock()
+	if o := j.execOutcome; o == nil || (o.exitcode == 0 && o.failReason == "") {
+		return fmt.Errorf("resubmit behaviour only applies to cmds that failed")
+	}
+	j.execResubmit = opts
+	return nil
+}
+
+// resubmitJob is used by the server when a runner releases or buries a Job
+// that a Resubmit Behaviour wanted re-enqueued. Instead of being buried, the
+// Job is released with a fresh set of retries, and then modified as per the
+// given options.
+func (s *Server) resubmitJob(job *Job, endState *JobEndState, failReason string) error {
+	job.Lock()
+	if job.UntilBuried < 2 {
+		job.UntilBuried = 2
+	}
+	job.Unlock()
+
+	err := s.releaseJob(job, endState, failReason, true, false)
+	if err != nil {
+		return err
+	}
+
+	opts := endState.Resubmit
+	job.Lock()
+	job.UntilBuried = job.Retries + 1
+	job.failureCounts = nil
+	oldKey := job.Key()
+	oldRepGroup := job.RepGroup
+	if opts.RepGroup != "" {
+		job.RepGroup = opts.RepGroup
+	}
+	jm := NewJobModifer()
+	if cmd := opts.cmd(job.Cmd); cmd != job.Cmd {
+		jm.SetCmd(cmd)
+	}
+	if opts.RAMMultiplier > 0 {
+		jm.SetRequirements(&scheduler.Requirements{RAM: int(math.Ceil(float64(job.Requirements.RAM) * opts.RAMMultiplier))})
+		if job.Override == 0 {
+			// don't let recommended RAM undo our increase
+			jm.SetOverride(1)
+		}
+	}
+	job.Unlock()
+
+	// like jmod, pause so the Job can't be reserved while we modify it
+	if _, errp := s.Pause(); errp == nil {
+		defer func() {
+			if _, errr := s.Resume(); errr != nil {
+				s.Warn("failed to resume after resubmitting a job", "err", errr)
+			}
+		}()
+	}
+
+	modified, err := s.modifyJobs([]string{oldKey}, jm)
+	if err != nil {
+		return err
+	}
+
+	job.RLock()
+	newKey := job.Key()
+	repGroup := job.RepGroup
+	cmd := job.Cmd
+	job.RUnlock()
+	if _, done := modified[newKey]; !done {
+		return fmt.Errorf("resubmitting job %s failed: its modified form already exists", oldKey)
+	}
+
+	if repGroup != oldRepGroup {
+		s.rpl.Lock()
+		delete(s.rpl.lookup[oldRepGroup], oldKey)
+		delete(s.rpl.lookup[oldRepGroup], newKey)
+		if _, exists := s.rpl.lookup[repGroup]; !exists {
+			s.rpl.lookup[repGroup] = make(map[string]bool)
+		}
+		s.rpl.lookup[repGroup][newKey] = true
+		s.rpl.Unlock()
+	}
+
+	s.Debug("resubmitted job", "old", oldKey, "new", newKey, "cmd", cmd)
+	s.recordRetryEvent([]*Job{job})
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the functions needed to run a Job's Cmd as a different
+// user to the one running the runner client, and the server's checks on which
+// users jobs may be run as.
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"regexp"
+	"strconv"
+
+	"github.com/VertebrateResequencing/wr/internal"
+)
+
+// runAsSudo is the sudo executable used to run commands as other users.
+const runAsSudo = "sudo"
+
+// runAsSetfacl is the executable used to give RunAs users access to the
+// directories we create for them.
+const runAsSetfacl = "setfacl"
+
+// runAsDirMode is the mode of the directories we create for RunAs users when
+// giving them access via group ownership.
+const runAsDirMode = 0770 | os.ModeSetgid
+
+// validRunAs matches the user names that Job.RunAs can be. In particular it
+// excludes sudo's "#uid" syntax.
+var validRunAs = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*\$?$`)
+
+// permitRunAs returns an error if jobs added by the given user aren't allowed
+// to be run as the given RunAs user. Jobs can only be run as the users the
+// server was configured with in RunAsUsers, and never as root. user is the name
+// the client told us, which can't be verified, so it is only used to describe
+// who was refused.
+func (s *Server) permitRunAs(runAs, user string) error {
+	if runAs == "" {
+		return nil
+	}
+	if !validRunAs.MatchString(runAs) {
+		return fmt.Errorf("%s: %q is not a valid user name", ErrBadRunAs, runAs)
+	}
+	if isRootUser(runAs) {
+		return fmt.Errorf("%s: jobs can't be run as root", ErrBadRunAs)
+	}
+	if s.runAsUsers[runAs] {
+		return nil
+	}
+	return fmt.Errorf("%s: %s may not run jobs as %s", ErrBadRunAs, describeUser(user), runAs)
+}
+
+// describeUser returns the given user name, or "an unknown user" if blank.
+func describeUser(user string) string {
+	if user == "" {
+		return "an unknown user"
+	}
+	return user
+}
+
+// isRootUser tells you if the given user name is root, or another name for
+// uid 0.
+func isRootUser(name string) bool {
+	if name == "root" {
+		return true
+	}
+	u, err := user.Lookup(name)
+	return err == nil && u.Uid == "0"
+}
+
+// needsRunAs tells you if the given RunAs user is different to the current
+// user, ie. if we need to use sudo to run things as that user.
+func needsRunAs(runAs string) bool {
+	if runAs == "" {
+		return false
+	}
+	username, err := internal.Username()
+	if err != nil {
+		return true
+	}
+	return username != runAs
+}
+
+// runAsCommand returns an *exec.Cmd that will run the given command (as
+// returned by shellArgs()) as the given user, preserving the environment.
+func runAsCommand(runAs string, args []string) *exec.Cmd {
+	return exec.Command(runAsSudo, append([]string{"-n", "-E", "-u", runAs, "--"}, args...)...) // #nosec
+}
+
+// checkRunAs confirms that we are able to run commands as the given user
+// without a password.
+func checkRunAs(runAs string) error {
+	out, err := exec.Command(runAsSudo, "-n", "-E", "-u", runAs, "--", "true").CombinedOutput() // #nosec
+	if err != nil {
+		return fmt.Errorf("could not sudo as user %s: %w (%s)", runAs, err, out)
+	}
+	return nil
+}
+
+// killAsUser sends a SIGKILL to the given pid as the given user, which is
+// needed to kill processes started by runAsCommand().
+func killAsUser(runAs string, pid int32) error {
+	out, err := exec.Command(runAsSudo, "-n", "-u", runAs, "--", "kill", "-KILL", strconv.Itoa(int(pid))).CombinedOutput() // #nosec
+	if err != nil {
+		return fmt.Errorf("could not kill pid %d as user %s: %w (%s)", pid, runAs, err, out)
+	}
+	return nil
+}
+
+// grantRunAsAccess lets the given RunAs user write to the given directories
+// that we created, without letting anyone else do so. If gid isn't -1 (ie. the
+// job has a Group), the directories are given that group and made group
+// writable; otherwise the user is given access with a POSIX ACL, with default
+// entries so that we can still clean up whatever they create within.
+func grantRunAsAccess(runAs string, gid int, dirs ...string) error {
+	for _, dir := range dirs {
+		if gid != -1 {
+			if err := os.Chown(dir, -1, gid); err != nil {
+				return err
+			}
+			if err := os.Chmod(dir, runAsDirMode); err != nil {
+				return err
+			}
+			continue
+		}
+
+		acl := fmt.Sprintf("u:%s:rwx,d:u:%s:rwx,d:u:%d:rwx", runAs, runAs, os.Getuid())
+		out, err := exec.Command(runAsSetfacl, "-m", acl, dir).CombinedOutput() // #nosec
+		if err != nil {
+			return fmt.Errorf("could not give user %s access to %s (set a group for the job, or use a file system with ACL support): %w (%s)", runAs, dir, err, out)
+		}
+	}
+	return nil
+}
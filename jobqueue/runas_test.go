@@ -0,0 +1,124 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRunAs(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Jobs can only be run as allowed users, never root", t, func() {
+		s := &Server{runAsUsers: map[string]bool{"pipeline": true, "root": true}}
+
+		tests := []struct {
+			runAs   string
+			user    string
+			allowed bool
+		}{
+			{"", "alice", true},
+			{"", "", true},
+			{"pipeline", "alice", true},
+			{"pipeline", userREST, true},
+			{"bob", "alice", false},
+			{"bob", "", false},
+			{"alice", userREST, false},
+			{userREST, userREST, false},
+			{userWebToken, userWebToken, false},
+			{"root", "root", false},
+			{"root", "alice", false},
+			{"#0", "alice", false},
+			{"#1000", "#1000", false},
+			{"alice bob", "alice bob", false},
+			{"-u", "-u", false},
+			{"alice", "alice", false},
+			{"bob", "bob", false},
+		}
+		for _, test := range tests {
+			err := s.permitRunAs(test.runAs, test.user)
+			if test.allowed {
+				So(err, ShouldBeNil)
+			} else {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, ErrBadRunAs)
+			}
+		}
+	})
+
+	Convey("Directories can be made writable by RunAs users without being world writable", t, func() {
+		dir, err := ioutil.TempDir("", "wr_jobqueue_test_runas_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		cwd := filepath.Join(dir, "cwd")
+		tmp := filepath.Join(dir, "tmp")
+		So(os.Mkdir(cwd, 0700), ShouldBeNil)
+		So(os.Mkdir(tmp, 0700), ShouldBeNil)
+
+		Convey("Using the job's group", func() {
+			err = grantRunAsAccess("nobody", os.Getgid(), cwd, tmp)
+			So(err, ShouldBeNil)
+
+			for _, d := range []string{cwd, tmp} {
+				info, errs := os.Stat(d)
+				So(errs, ShouldBeNil)
+				So(info.Mode()&(os.ModePerm|os.ModeSetgid), ShouldEqual, runAsDirMode)
+				So(info.Mode()&0007, ShouldEqual, 0)
+			}
+		})
+
+		Convey("Using an ACL", func() {
+			if _, errl := exec.LookPath(runAsSetfacl); errl != nil {
+				SkipConvey("setfacl is not installed", func() {})
+				return
+			}
+
+			err = grantRunAsAccess("nobody", -1, cwd)
+			if err != nil && strings.Contains(err.Error(), "Operation not supported") {
+				SkipConvey("file system does not support ACLs", func() {})
+				return
+			}
+			So(err, ShouldBeNil)
+
+			info, errs := os.Stat(cwd)
+			So(errs, ShouldBeNil)
+			So(info.Mode()&0007, ShouldEqual, 0)
+
+			out, errg := exec.Command("getfacl", "-p", cwd).Output()
+			So(errg, ShouldBeNil)
+			So(string(out), ShouldContainSubstring, "user:nobody:rwx")
+			So(string(out), ShouldContainSubstring, "default:user:nobody:rwx")
+		})
+
+		Convey("Failing clearly if an ACL can't be set", func() {
+			err = grantRunAsAccess("nobody", -1, filepath.Join(dir, "missing"))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
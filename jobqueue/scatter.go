@@ -0,0 +1,143 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of scatter-gather expansion, where one
+// logical task is turned in to a scatter job per input plus a gather job that
+// works on all their outputs.
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Scatter* are the placeholders that get replaced in the commands of
+// ScatterGather jobs.
+const (
+	ScatterInputPlaceholder  = "{input}"
+	ScatterChunkPlaceholder  = "{chunk}"
+	ScatterIndexPlaceholder  = "{index}"
+	ScatterChunksPlaceholder = "{chunks}"
+)
+
+// ScatterGather describes how to expand one logical task in to a scatter job
+// per input plus a gather job that runs once they have all completed.
+type ScatterGather struct {
+	// Inputs are the inputs of the scatter jobs, one job per input.
+	Inputs []string
+
+	// GatherCmd is the command of the gather job. ScatterChunksPlaceholder is
+	// replaced with the shell-quoted, space-separated chunk outputs of the
+	// scatter jobs, in input order; if it isn't present, they are appended to
+	// the end of the command.
+	GatherCmd string
+
+	// ChunkDir is the absolute path of the directory that scatter jobs will
+	// write their chunk outputs to.
+	ChunkDir string
+
+	// Group is the AtomicGroup the scatter jobs will be in, as well as a
+	// DepGroup that the gather job depends upon. If not set, a name is derived
+	// from the commands and inputs.
+	Group string
+}
+
+// Expand returns a scatter job for each of our Inputs, based on the given
+// scatter job, followed by the gather job.
+//
+// The scatter job's Cmd has ScatterInputPlaceholder replaced verbatim with the
+// input, ScatterIndexPlaceholder with the input's 1-based index, and
+// ScatterChunkPlaceholder with the shell-quoted path, in ChunkDir, of the chunk
+// output the job should write to. Chunks are named after our Group and the
+// zero-padded index. The scatter jobs become members of our Group as both an
+// AtomicGroup and a DepGroup, so that if any of them fails, all their outputs
+// are considered invalid and the gather job never runs.
+//
+// The gather job has all the other properties of the given scatter job, but
+// its own DepGroups, and depends on the DepGroup of our Group instead of the
+// scatter job's dependencies.
+func (sg *ScatterGather) Expand(scatter *JobViaJSON) ([]*JobViaJSON, error) {
+	if len(sg.Inputs) == 0 {
+		return nil, errors.New("there are no scatter inputs")
+	}
+	if !strings.Contains(scatter.Cmd, ScatterInputPlaceholder) || !strings.Contains(scatter.Cmd, ScatterChunkPlaceholder) {
+		return nil, fmt.Errorf("the scatter command must contain %s and %s", ScatterInputPlaceholder, ScatterChunkPlaceholder)
+	}
+	if sg.GatherCmd == "" {
+		return nil, errors.New("there is no gather command")
+	}
+	if !filepath.IsAbs(sg.ChunkDir) {
+		return nil, fmt.Errorf("the chunk directory [%s] is not an absolute path", sg.ChunkDir)
+	}
+
+	group := sg.Group
+	if group == "" {
+		group = "scatter." + byteKey([]byte(scatter.Cmd + "\n" + sg.GatherCmd + "\n" + strings.Join(sg.Inputs, "\n")))[:16]
+	}
+
+	width := len(strconv.Itoa(len(sg.Inputs)))
+	jvjs := make([]*JobViaJSON, 0, len(sg.Inputs)+1)
+	chunks := make([]string, len(sg.Inputs))
+	for i, input := range sg.Inputs {
+		index := strconv.Itoa(i + 1)
+		chunks[i] = shellQuote(filepath.Join(sg.ChunkDir, fmt.Sprintf("%s.%0*d", group, width, i+1)))
+
+		jvj := *scatter
+		jvj.Cmd = strings.NewReplacer(
+			ScatterInputPlaceholder, input,
+			ScatterIndexPlaceholder, index,
+			ScatterChunkPlaceholder, chunks[i],
+		).Replace(scatter.Cmd)
+		jvj.AtomicGrp = group
+		jvj.DepGrps = append(append([]string{}, scatter.DepGrps...), group)
+		jvjs = append(jvjs, &jvj)
+	}
+
+	gather := *scatter
+	list := strings.Join(chunks, " ")
+	if strings.Contains(sg.GatherCmd, ScatterChunksPlaceholder) {
+		gather.Cmd = strings.ReplaceAll(sg.GatherCmd, ScatterChunksPlaceholder, list)
+	} else {
+		gather.Cmd = sg.GatherCmd + " " + list
+	}
+	gather.AtomicGrp = ""
+	gather.DepGrps = nil
+	gather.Deps = []string{group}
+	gather.CmdDeps = nil
+	gather.RepGrpDeps = nil
+
+	return append(jvjs, &gather), nil
+}
+
+// shellQuote returns the given string quoted for use as a single word in a
+// shell command line, if it needs to be.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, needsShellQuoting) == -1 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// needsShellQuoting tells you if the given rune is special to the shell.
+func needsShellQuoting(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./=:,+@%", r))
+}
@@ -0,0 +1,82 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScatterGather(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("ScatterGather.Expand() makes scatter jobs and a gather job", t, func() {
+		inputs := make([]string, 10)
+		for i := range inputs {
+			inputs[i] = "chr" + string(rune('a'+i))
+		}
+		sg := &ScatterGather{Inputs: inputs, GatherCmd: "merge {chunks} > all", ChunkDir: "/tmp/out dir", Group: "sg"}
+		scatter := &JobViaJSON{Cmd: "split {input} {index} > {chunk}", RepGrp: "rg", DepGrps: []string{"dg"}, Deps: []string{"up"}}
+
+		jvjs, err := sg.Expand(scatter)
+		So(err, ShouldBeNil)
+		So(len(jvjs), ShouldEqual, 11)
+		So(jvjs[0].Cmd, ShouldEqual, "split chra 1 > '/tmp/out dir/sg.01'")
+		So(jvjs[9].Cmd, ShouldEqual, "split chrj 10 > '/tmp/out dir/sg.10'")
+		So(jvjs[0].AtomicGrp, ShouldEqual, "sg")
+		So(jvjs[0].DepGrps, ShouldResemble, []string{"dg", "sg"})
+		So(jvjs[0].Deps, ShouldResemble, []string{"up"})
+		So(jvjs[0].RepGrp, ShouldEqual, "rg")
+		So(scatter.DepGrps, ShouldResemble, []string{"dg"})
+
+		gather := jvjs[10]
+		So(gather.Cmd, ShouldStartWith, "merge '/tmp/out dir/sg.01' '/tmp/out dir/sg.02' ")
+		So(gather.Cmd, ShouldEndWith, " '/tmp/out dir/sg.10' > all")
+		So(gather.AtomicGrp, ShouldBeEmpty)
+		So(gather.DepGrps, ShouldBeNil)
+		So(gather.Deps, ShouldResemble, []string{"sg"})
+		So(gather.RepGrp, ShouldEqual, "rg")
+
+		sg = &ScatterGather{Inputs: []string{"x"}, GatherCmd: "cat", ChunkDir: "/tmp"}
+		jvjs, err = sg.Expand(&JobViaJSON{Cmd: "echo {input} > {chunk}"})
+		So(err, ShouldBeNil)
+		So(len(jvjs), ShouldEqual, 2)
+		So(jvjs[0].AtomicGrp, ShouldStartWith, "scatter.")
+		So(jvjs[1].Cmd, ShouldEqual, "cat /tmp/"+jvjs[0].AtomicGrp+".1")
+
+		_, err = sg.Expand(&JobViaJSON{Cmd: "echo {input}"})
+		So(err, ShouldNotBeNil)
+		sg.ChunkDir = "relative"
+		_, err = sg.Expand(&JobViaJSON{Cmd: "echo {input} > {chunk}"})
+		So(err, ShouldNotBeNil)
+		sg = &ScatterGather{GatherCmd: "cat", ChunkDir: "/tmp"}
+		_, err = sg.Expand(&JobViaJSON{Cmd: "echo {input} > {chunk}"})
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("shellQuote() only quotes when needed", t, func() {
+		So(shellQuote("/a/b.c"), ShouldEqual, "/a/b.c")
+		So(shellQuote("a b"), ShouldEqual, "'a b'")
+		So(shellQuote("it's"), ShouldEqual, `'it'\''s'`)
+		So(shellQuote(""), ShouldEqual, "''")
+	})
+}
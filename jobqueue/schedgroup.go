@@ -0,0 +1,211 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of scheduler group introspection, to
+// help debug jobs that stay ready without getting run.
+
+import (
+	"sort"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+	"github.com/VertebrateResequencing/wr/queue"
+)
+
+// SchedulerGroup describes a group of jobs that share the same Requirements
+// (and limit groups), for which the server asks its scheduler to run runners.
+// It is what Client.GetSchedulerGroups() returns.
+type SchedulerGroup struct {
+	// Name is the scheduler group, which is what the server's runners are
+	// told to reserve jobs from.
+	Name string
+
+	// Requirements are the resource requirements of the group's jobs.
+	Requirements *scheduler.Requirements
+
+	// ReqGroups are the distinct ReqGroups of the group's incomplete jobs.
+	ReqGroups []string
+
+	// Scheduler is the name of the scheduler the group's runners are
+	// submitted to.
+	Scheduler string
+
+	// Priority is the priority the group's runners were submitted with.
+	Priority uint8
+
+	// Ready is the number of the group's jobs that are ready to run.
+	Ready int
+
+	// Running is the number of the group's jobs that are currently reserved
+	// or running.
+	Running int
+
+	// Requested is the number of runners the server currently wants the
+	// scheduler to run for this group. If this is 0 while Ready isn't, the
+	// group is probably being held back by a limit group.
+	Requested int
+
+	// Connected is the number of runners that have started working on this
+	// group since the server first scheduled runners for it.
+	Connected int
+
+	// Since is when the server first scheduled runners for this group. It is
+	// the zero time if it never has.
+	Since time.Time
+
+	// LastConnected is when a runner last started working on this group. It
+	// is the zero time if none have yet.
+	LastConnected time.Time
+}
+
+// Waited returns how long this group has been waiting for runners: the time
+// since the last runner connected, or since runners were first scheduled if
+// none have connected. Returns 0 if runners were never scheduled.
+func (sg *SchedulerGroup) Waited(now time.Time) time.Duration {
+	if !sg.LastConnected.IsZero() {
+		return now.Sub(sg.LastConnected)
+	}
+	if sg.Since.IsZero() {
+		return 0
+	}
+	return now.Sub(sg.Since)
+}
+
+// sgroupStats holds the server's record of runner activity for a scheduler
+// group.
+type sgroupStats struct {
+	since         time.Time
+	connected     int
+	lastConnected time.Time
+	requested     int
+}
+
+// noteSchedulerGroupScheduled records that runners have been scheduled for
+// the given group, if this hasn't already been noted. You must hold the
+// sgcmutex.
+func (s *Server) noteSchedulerGroupScheduled(group string) {
+	if _, exists := s.sgroupstats[group]; !exists {
+		s.sgroupstats[group] = &sgroupStats{since: time.Now()}
+	}
+}
+
+// noteSchedulerGroupRequested records how many runners we're about to ask the
+// scheduler to run for the given group, returning true if that's more than we
+// last asked for. You must hold the sgcmutex.
+func (s *Server) noteSchedulerGroupRequested(group string, count int) bool {
+	stats, exists := s.sgroupstats[group]
+	if !exists {
+		return false
+	}
+	more := count > stats.requested
+	stats.requested = count
+	return more
+}
+
+// noteSchedulerGroupRunner records that a new runner has started working on
+// the given group. You must hold the sgcmutex.
+func (s *Server) noteSchedulerGroupRunner(group string) {
+	stats, exists := s.sgroupstats[group]
+	if !exists {
+		return
+	}
+	stats.connected++
+	stats.lastConnected = time.Now()
+}
+
+// getSchedulerGroups returns details of all the scheduler groups that we are
+// scheduling runners for, or that have incomplete jobs ready or running,
+// sorted by name.
+func (s *Server) getSchedulerGroups() []*SchedulerGroup {
+	groups := make(map[string]*SchedulerGroup)
+	reqGroups := make(map[string]map[string]bool)
+	get := func(name string) *SchedulerGroup {
+		sg, exists := groups[name]
+		if !exists {
+			sg = &SchedulerGroup{Name: name}
+			groups[name] = sg
+			reqGroups[name] = make(map[string]bool)
+		}
+		return sg
+	}
+
+	for _, item := range s.q.AllItems() {
+		state := item.Stats().State
+		if state != queue.ItemStateReady && state != queue.ItemStateRun {
+			continue
+		}
+
+		job := item.Data().(*Job)
+		job.RLock()
+		name := job.schedulerGroup
+		reqGroup := job.ReqGroup
+		req := *job.Requirements
+		job.RUnlock()
+		if name == "" {
+			continue
+		}
+
+		sg := get(name)
+		if state == queue.ItemStateReady {
+			sg.Ready++
+		} else {
+			sg.Running++
+		}
+		if sg.Requirements == nil {
+			sg.Requirements = &req
+		}
+		reqGroups[name][reqGroup] = true
+	}
+
+	s.sgcmutex.Lock()
+	for name, count := range s.sgroupcounts {
+		sg := get(name)
+		sg.Requested = count
+		sg.Priority = s.sgrouppriority[name]
+		if req, exists := s.sgtr[name]; exists {
+			reqCopy := *req
+			sg.Requirements = &reqCopy
+		}
+	}
+	for name, stats := range s.sgroupstats {
+		if sg, exists := groups[name]; exists {
+			sg.Since = stats.since
+			sg.Connected = stats.connected
+			sg.LastConnected = stats.lastConnected
+		}
+	}
+	s.sgcmutex.Unlock()
+
+	sgs := make([]*SchedulerGroup, 0, len(groups))
+	for name, sg := range groups {
+		if sg.Requirements != nil && s.scheduler != nil {
+			sg.Scheduler = s.scheduler.Backend(sg.Requirements)
+		}
+		for rg := range reqGroups[name] {
+			sg.ReqGroups = append(sg.ReqGroups, rg)
+		}
+		sort.Strings(sg.ReqGroups)
+		sgs = append(sgs, sg)
+	}
+	sort.Slice(sgs, func(i, j int) bool {
+		return sgs[i].Name < sgs[j].Name
+	})
+	return sgs
+}
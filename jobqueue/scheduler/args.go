@@ -0,0 +1,161 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package scheduler
+
+// This file contains the implementation of scheduler args: site-specific
+// options for a particular scheduler that are passed through to it when it
+// runs a cmd, such as extra bsub flags for LSF, or metadata for the servers
+// OpenStack spawns.
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// ArgsOtherKeyPrefix followed by the Name of a scheduler (eg. "lsf") is the key
+// in Requirements.Other for the args that scheduler should use when running
+// the cmd; see ValidateArgs() for their form. Args for schedulers other than
+// the one that runs the cmd are ignored.
+const ArgsOtherKeyPrefix = "scheduler_args_"
+
+// lsfReservedFlags are the bsub flags that the lsf scheduler sets itself, so
+// can't be supplied as args.
+var lsfReservedFlags = map[string]bool{
+	"-q": true,
+	"-M": true,
+	"-n": true,
+	"-J": true,
+	"-o": true,
+	"-e": true,
+}
+
+// reservedMetadataPrefix is the prefix of the keys of the cloud.Tag* metadata
+// that wr gives the servers it spawns, which users can't supply.
+const reservedMetadataPrefix = "wr_"
+
+// maxMetadataLength is the maximum length of the keys and values of OpenStack
+// server metadata.
+const maxMetadataLength = 255
+
+// ValidateArgs checks that the given args are suitable to be passed through to
+// the scheduler with the given Name:
+//
+// For "lsf", args are extra bsub flags, eg. '-R "select[avx]" -P myproject';
+// as for scheduler_misc, they may not contain single quotes. The flags that wr
+// sets itself (-q, -M, -n, -J, -o and -e) may not be used.
+//
+// For "openstack", args are key=value pairs separated by commas, which become
+// the metadata of the servers spawned to run the cmd. Keys may not start with
+// "wr_".
+//
+// Other schedulers don't take any args.
+func ValidateArgs(name, args string) error {
+	if args == "" {
+		return nil
+	}
+
+	switch name {
+	case "lsf":
+		_, err := parseLSFArgs(args)
+		return err
+	case "openstack":
+		_, err := parseOpenStackArgs(args)
+		return err
+	case "local", "kubernetes":
+		return fmt.Errorf("the %s scheduler does not take scheduler args", name)
+	default:
+		return fmt.Errorf("%s: %s", ErrBadScheduler, name)
+	}
+}
+
+// argsFor returns the args for the scheduler with the given Name in req, if
+// any.
+func argsFor(name string, req *Requirements) string {
+	return req.Other[ArgsOtherKeyPrefix+name]
+}
+
+// splitBsubArgs splits the given space separated bsub args, which may use
+// double quotes to surround values containing spaces, in to separate args,
+// surrounding those containing spaces in single quotes.
+func splitBsubArgs(val string) ([]string, error) {
+	if strings.Contains(val, `'`) {
+		return nil, fmt.Errorf("bsub args may not contain single quotes")
+	}
+
+	r := csv.NewReader(strings.NewReader(val))
+	r.Comma = ' '
+	fields, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if strings.Contains(field, ` `) {
+			field = `'` + field + `'`
+		}
+		args = append(args, field)
+	}
+	return args, nil
+}
+
+// parseLSFArgs splits the given lsf scheduler args like splitBsubArgs(),
+// checking that they don't include any of our lsfReservedFlags.
+func parseLSFArgs(val string) ([]string, error) {
+	args, err := splitBsubArgs(val)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if lsfReservedFlags[arg] {
+			return nil, fmt.Errorf("bsub flag %s is set by wr, so can't be used in scheduler args", arg)
+		}
+	}
+	return args, nil
+}
+
+// parseOpenStackArgs parses the given openstack scheduler args in to server
+// metadata.
+func parseOpenStackArgs(val string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(val, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("server metadata [%s] is not in key=value form", pair)
+		}
+		if strings.HasPrefix(kv[0], reservedMetadataPrefix) {
+			return nil, fmt.Errorf("server metadata key %s is reserved for wr's own use", kv[0])
+		}
+		if len(kv[0]) > maxMetadataLength || len(kv[1]) > maxMetadataLength {
+			return nil, fmt.Errorf("server metadata [%s] is longer than %d characters", pair, maxMetadataLength)
+		}
+		metadata[kv[0]] = kv[1]
+	}
+
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	return metadata, nil
+}
@@ -0,0 +1,342 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package scheduler
+
+// This file contains the implementation of burst policies for the multi
+// scheduler: spilling jobs over from one backend to another when the first has
+// too much outstanding work.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sync "github.com/sasha-s/go-deadlock"
+)
+
+// BurstPolicy describes when jobs that would normally be routed to one backend
+// of a multi scheduler should spill over to another, eg. "use LSF until more
+// than 100 jobs have been outstanding for 10 minutes, then burst to OpenStack
+// with up to 20 runners".
+type BurstPolicy struct {
+	// From is the Name of the backend that normally gets the jobs.
+	From string
+
+	// To is the Name of the backend to burst to.
+	To string
+
+	// Pending is the number of incomplete jobs routed to From that must be
+	// exceeded before bursting will start.
+	Pending int
+
+	// Resume is the number of incomplete jobs routed to From at or below which
+	// bursting will stop. Being lower than Pending gives hysteresis, so that we
+	// don't flip-flop. Defaults to Pending / 2.
+	Resume int
+
+	// After is how long Pending must be exceeded (or Resume be met) before
+	// bursting starts (or stops).
+	After time.Duration
+
+	// MaxInstances is the most runners that will be scheduled on To at once.
+	// 0 means unlimited.
+	MaxInstances int
+
+	// CostPerHour is the cost of running one runner on To for an hour.
+	CostPerHour float64
+
+	// CostCap is the accumulated cost at which we stop bursting. 0 means no
+	// cap.
+	CostCap float64
+
+	// Disabled turns off bursting, without forgetting the rest of the policy.
+	Disabled bool
+}
+
+// ParseBurstPolicy parses a string like
+// "from=lsf,to=openstack,pending=100,after=10m,max=20" in to a BurstPolicy.
+// The possible keys are from, to, pending, resume, after (a duration), max,
+// cost (per runner per hour), cap and disabled (true or false).
+func ParseBurstPolicy(spec string) (*BurstPolicy, error) {
+	p := &BurstPolicy{Resume: -1}
+	for _, kvStr := range strings.Split(spec, ",") {
+		kvStr = strings.TrimSpace(kvStr)
+		if kvStr == "" {
+			continue
+		}
+
+		kv := strings.SplitN(kvStr, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("burst policy option [%s] is not of the form key=value", kvStr)
+		}
+
+		var err error
+		switch kv[0] {
+		case "from":
+			p.From = kv[1]
+		case "to":
+			p.To = kv[1]
+		case "pending":
+			p.Pending, err = strconv.Atoi(kv[1])
+		case "resume":
+			p.Resume, err = strconv.Atoi(kv[1])
+		case "after":
+			p.After, err = time.ParseDuration(kv[1])
+		case "max":
+			p.MaxInstances, err = strconv.Atoi(kv[1])
+		case "cost":
+			p.CostPerHour, err = strconv.ParseFloat(kv[1], 64)
+		case "cap":
+			p.CostCap, err = strconv.ParseFloat(kv[1], 64)
+		case "disabled":
+			p.Disabled, err = strconv.ParseBool(kv[1])
+		default:
+			err = fmt.Errorf("unknown option %s", kv[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("burst policy option [%s] is invalid: %s", kvStr, err)
+		}
+	}
+
+	if p.Resume == -1 {
+		p.Resume = p.Pending / 2
+	}
+
+	return p, p.validate()
+}
+
+// String returns the policy in the form understood by ParseBurstPolicy().
+func (p *BurstPolicy) String() string {
+	return fmt.Sprintf("from=%s,to=%s,pending=%d,resume=%d,after=%s,max=%d,cost=%s,cap=%s,disabled=%t",
+		p.From, p.To, p.Pending, p.Resume, p.After, p.MaxInstances,
+		strconv.FormatFloat(p.CostPerHour, 'f', -1, 64), strconv.FormatFloat(p.CostCap, 'f', -1, 64), p.Disabled)
+}
+
+// validate checks the policy makes sense.
+func (p *BurstPolicy) validate() error {
+	switch {
+	case p.From == "" || p.To == "":
+		return fmt.Errorf("burst policy needs both from and to")
+	case p.From == p.To:
+		return fmt.Errorf("burst policy can't burst from %s to itself", p.From)
+	case p.Pending < 0 || p.Resume < 0 || p.MaxInstances < 0 || p.After < 0:
+		return fmt.Errorf("burst policy values can't be negative")
+	case p.Resume > p.Pending:
+		return fmt.Errorf("burst policy resume (%d) can't be higher than pending (%d)", p.Resume, p.Pending)
+	case p.CostPerHour < 0 || p.CostCap < 0:
+		return fmt.Errorf("burst policy costs can't be negative")
+	}
+	return nil
+}
+
+// BurstStatus describes the current state of bursting.
+type BurstStatus struct {
+	Policy *BurstPolicy
+
+	// Bursting is true if jobs are currently spilling over.
+	Bursting bool
+
+	// Pending is the number of incomplete jobs routed to Policy.From.
+	Pending int
+
+	// Instances is the number of runners currently scheduled on Policy.To
+	// due to bursting.
+	Instances int
+
+	// Cost is the accumulated cost of bursting so far.
+	Cost float64
+}
+
+// burster tracks the state needed to implement a BurstPolicy.
+type burster struct {
+	policy     *BurstPolicy
+	bursting   bool
+	highSince  time.Time
+	lowSince   time.Time
+	costTime   time.Time
+	cost       float64
+	demand     map[string]int
+	burstCount map[string]int
+	mutex      sync.Mutex
+}
+
+// newBurster creates a burster for the given policy.
+func newBurster(policy *BurstPolicy) *burster {
+	return &burster{
+		policy:     policy,
+		demand:     make(map[string]int),
+		burstCount: make(map[string]int),
+	}
+}
+
+// from returns the name of the backend we burst from.
+func (b *burster) from() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.policy.From
+}
+
+// to returns the name of the backend we burst to.
+func (b *burster) to() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.policy.To
+}
+
+// setPolicy changes our policy. Accumulated cost is kept. You must validate()
+// the policy first.
+func (b *burster) setPolicy(policy *BurstPolicy) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.policy = policy
+	b.update(time.Now())
+}
+
+// allot records that the given cmd needs count runners on our From backend,
+// and returns how many of those should instead be scheduled on our To backend.
+func (b *burster) allot(cmd string, count int, now time.Time) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if count > 0 {
+		b.demand[cmd] = count
+	} else {
+		delete(b.demand, cmd)
+	}
+
+	b.update(now)
+
+	if !b.bursting || count <= 0 || b.capReached() {
+		delete(b.burstCount, cmd)
+		return 0
+	}
+
+	n := count
+	if b.policy.MaxInstances > 0 {
+		available := b.policy.MaxInstances - (b.instances() - b.burstCount[cmd])
+		if available < n {
+			n = available
+		}
+	}
+	if n <= 0 {
+		delete(b.burstCount, cmd)
+		return 0
+	}
+	b.burstCount[cmd] = n
+	return n
+}
+
+// forget stops tracking the given cmd, eg. because it failed to be scheduled
+// on our To backend, or is no longer routed to our From backend.
+func (b *burster) forget(cmd string, demandToo bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.burstCount, cmd)
+	if demandToo {
+		delete(b.demand, cmd)
+	}
+}
+
+// update accrues cost and decides if we should be bursting. You must hold the
+// mutex before calling this.
+func (b *burster) update(now time.Time) {
+	if now.After(b.costTime) {
+		if !b.costTime.IsZero() {
+			b.cost += float64(b.instances()) * b.policy.CostPerHour * now.Sub(b.costTime).Hours()
+		}
+		b.costTime = now
+	}
+
+	if b.policy.Disabled {
+		b.bursting = false
+		b.highSince = time.Time{}
+		b.lowSince = time.Time{}
+		return
+	}
+
+	pending := b.pending()
+	if b.bursting {
+		if pending > b.policy.Resume {
+			b.lowSince = time.Time{}
+			return
+		}
+		if b.lowSince.IsZero() {
+			b.lowSince = now
+		}
+		if now.Sub(b.lowSince) >= b.policy.After {
+			b.bursting = false
+			b.lowSince = time.Time{}
+		}
+		return
+	}
+
+	if pending <= b.policy.Pending {
+		b.highSince = time.Time{}
+		return
+	}
+	if b.highSince.IsZero() {
+		b.highSince = now
+	}
+	if now.Sub(b.highSince) >= b.policy.After {
+		b.bursting = true
+		b.highSince = time.Time{}
+	}
+}
+
+// capReached tells you if we've spent as much as we're allowed. You must hold
+// the mutex before calling this.
+func (b *burster) capReached() bool {
+	return b.policy.CostCap > 0 && b.cost >= b.policy.CostCap
+}
+
+// pending returns the total demand on our From backend. You must hold the
+// mutex before calling this.
+func (b *burster) pending() int {
+	var total int
+	for _, count := range b.demand {
+		total += count
+	}
+	return total
+}
+
+// instances returns the total runners we have burst to our To backend. You
+// must hold the mutex before calling this.
+func (b *burster) instances() int {
+	var total int
+	for _, count := range b.burstCount {
+		total += count
+	}
+	return total
+}
+
+// status returns our current state.
+func (b *burster) status() *BurstStatus {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.update(time.Now())
+	policy := *b.policy
+	return &BurstStatus{
+		Policy:    &policy,
+		Bursting:  b.bursting && !b.capReached(),
+		Pending:   b.pending(),
+		Instances: b.instances(),
+		Cost:      b.cost,
+	}
+}
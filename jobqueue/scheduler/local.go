@@ -124,6 +124,12 @@ type local struct {
 	runCmdFunc        cmdRunner
 	stopAuto          chan bool
 	recoveredPids     map[int]bool
+	coreUsage         []int
+	firstPinCore      int
+	nodeCores         [][]int
+	coreNode          []int
+	tasksetExe        string
+	numactlExe        string
 	stopPidMonitoring chan struct{}
 	cleanMutex        sync.RWMutex
 	rcMutex           sync.RWMutex
@@ -159,6 +165,39 @@ type ConfigLocal struct {
 	// The unit is in MB, and defaults to all available memory. Specifying more
 	// than this uses the default amount. Values below 1 are treated as default.
 	MaxRAM int
+
+	// ReservedCores is the number of CPU cores (out of MaxCores) to keep free
+	// for the manager itself, so that a fully-loaded machine stays responsive.
+	// Defaults to 0. At least 1 core will always be left for running jobs.
+	ReservedCores int
+
+	// ReservedRAM is the amount of memory in MB (out of MaxRAM) to keep free
+	// for the manager itself. Defaults to 0. At least 1MB will always be left
+	// for running jobs.
+	ReservedRAM int
+
+	// CPUOvercommit is a factor that the (non-reserved) cores are multiplied by
+	// to determine how many cores worth of jobs can run at once, eg. 1.5 on a
+	// machine with 8 cores would allow 12 single core jobs to run at once.
+	// Values below 1 are treated as the default of 1 (no overcommitment).
+	CPUOvercommit float64
+
+	// RAMOvercommit is like CPUOvercommit, but for memory.
+	RAMOvercommit float64
+
+	// PinCores, if true, pins each cmd to its own set of cores using taskset
+	// (which must be installed). Cmds that need 0 cores are not pinned. When
+	// overcommitting, the least used cores are shared. On machines with
+	// multiple NUMA nodes, each cmd's cores are taken from a single node where
+	// possible. Pinned cmds have the cores they were allotted in their WR_CORES
+	// environment variable, and their NUMA node (if all their cores are on the
+	// same one) in WR_NUMA_NODE.
+	PinCores bool
+
+	// NUMABind, if true along with PinCores, pins cmds using numactl (which
+	// must be installed) instead of taskset, also binding their memory to the
+	// NUMA node of their cores when those are all on the same node.
+	NUMABind bool
 }
 
 // jobs are what we store in our queue.
@@ -171,8 +210,8 @@ type job struct {
 	sync.RWMutex
 }
 
-// initialize finds out about the local machine. Compatible with amd64 archs
-// only!
+// initialize finds out about the local machine. Compatible with linux (amd64
+// and arm64) and darwin only!
 func (s *local) initialize(config interface{}, logger log15.Logger) error {
 	s.config = config.(*ConfigLocal)
 	s.Logger = logger.New("scheduler", "local")
@@ -195,6 +234,7 @@ func (s *local) initialize(config interface{}, logger log15.Logger) error {
 			s.maxRAM = 1
 		}
 	}
+	s.applyHeadroomAndOvercommit()
 
 	// make our queue
 	s.queue = queue.New(localPlace, s.Logger)
@@ -463,6 +503,108 @@ func (s *local) recover(cmd string, req *Requirements, host *RecoveredHostDetail
 	return nil
 }
 
+// applyHeadroomAndOvercommit adjusts maxCores and maxRAM according to our
+// config's Reserved* and *Overcommit options, and sets up core pinning if
+// desired.
+func (s *local) applyHeadroomAndOvercommit() {
+	if s.config.ReservedCores > 0 {
+		s.firstPinCore = s.config.ReservedCores
+		if s.firstPinCore >= s.maxCores {
+			s.firstPinCore = s.maxCores - 1
+		}
+		s.maxCores -= s.firstPinCore
+	}
+	if s.config.ReservedRAM > 0 {
+		s.maxRAM -= s.config.ReservedRAM
+		if s.maxRAM < 1 {
+			s.maxRAM = 1
+		}
+	}
+
+	if s.config.PinCores {
+		if s.config.NUMABind {
+			s.numactlExe = internal.Which("numactl")
+			if s.numactlExe == "" {
+				s.Warn("numactl not found; cmds will be pinned with taskset instead")
+			}
+		}
+		if s.numactlExe == "" {
+			s.tasksetExe = internal.Which("taskset")
+		}
+		if s.numactlExe == "" && s.tasksetExe == "" {
+			s.Warn("taskset not found; cmds will not be pinned to cores")
+		} else {
+			s.coreUsage = make([]int, s.maxCores)
+			nodes, err := readNUMATopology(sysNodePath)
+			if err != nil {
+				s.Warn("could not determine NUMA topology", "err", err)
+			}
+			s.setupNUMA(nodes)
+		}
+	}
+
+	if s.config.CPUOvercommit > 1 {
+		s.maxCores = int(math.Floor(float64(s.maxCores) * s.config.CPUOvercommit))
+	}
+	if s.config.RAMOvercommit > 1 {
+		s.maxRAM = int(math.Floor(float64(s.maxRAM) * s.config.RAMOvercommit))
+	}
+}
+
+// allotCores picks the given number of least used cores to pin a cmd to,
+// returning their ids. When NUMA aware, the cores come from the single node
+// whose least used cores are least used, if any node has enough cores. Returns
+// nil if we're not pinning. You must hold the resourceMutex lock when calling
+// this.
+func (s *local) allotCores(n int) []int {
+	if s.coreUsage == nil || n < 1 {
+		return nil
+	}
+	if n > len(s.coreUsage) {
+		n = len(s.coreUsage)
+	}
+
+	var chosen []int
+	bestUsage := -1
+	for _, indexes := range s.nodeCores {
+		if len(indexes) < n {
+			continue
+		}
+		candidates := s.leastUsedCores(indexes)[:n]
+		usage := 0
+		for _, i := range candidates {
+			usage += s.coreUsage[i]
+		}
+		if bestUsage == -1 || usage < bestUsage {
+			chosen = candidates
+			bestUsage = usage
+		}
+	}
+
+	if chosen == nil {
+		all := make([]int, len(s.coreUsage))
+		for i := range all {
+			all[i] = i
+		}
+		chosen = s.leastUsedCores(all)[:n]
+	}
+
+	cores := make([]int, n)
+	for j, i := range chosen {
+		s.coreUsage[i]++
+		cores[j] = i + s.firstPinCore
+	}
+	return cores
+}
+
+// releaseCores undoes an allotCores(). You must hold the resourceMutex lock
+// when calling this.
+func (s *local) releaseCores(cores []int) {
+	for _, core := range cores {
+		s.coreUsage[core-s.firstPinCore]--
+	}
+}
+
 // reqCheck gives an ErrImpossible if the given Requirements can not be met.
 func (s *local) reqCheck(req *Requirements) error {
 	if req.RAM > s.maxRAM || int(math.Ceil(req.Cores)) > s.maxCores {
@@ -773,10 +915,39 @@ func (s *local) runCmd(cmd string, req *Requirements, reservedCh chan bool, call
 		}
 	}
 
-	ec := exec.Command(s.config.Shell, "-c", cmd) // #nosec
+	s.resourceMutex.Lock()
+	cores := s.allotCores(int(math.Ceil(req.Cores)))
+	node := s.coresNode(cores)
+	s.resourceMutex.Unlock()
+
+	var ec *exec.Cmd
+	if cores != nil {
+		coreList := make([]string, len(cores))
+		for i, core := range cores {
+			coreList[i] = strconv.Itoa(core)
+		}
+		coresStr := strings.Join(coreList, ",")
+		switch {
+		case s.numactlExe != "" && node != -1:
+			ec = exec.Command(s.numactlExe, "--physcpubind="+coresStr, "--membind="+strconv.Itoa(node), s.config.Shell, "-c", cmd) // #nosec
+		case s.numactlExe != "":
+			ec = exec.Command(s.numactlExe, "--physcpubind="+coresStr, s.config.Shell, "-c", cmd) // #nosec
+		default:
+			ec = exec.Command(s.tasksetExe, "-c", coresStr, s.config.Shell, "-c", cmd) // #nosec
+		}
+		ec.Env = append(os.Environ(), EnvCores+"="+coresStr)
+		if node != -1 {
+			ec.Env = append(ec.Env, EnvNUMANode+"="+strconv.Itoa(node))
+		}
+	} else {
+		ec = exec.Command(s.config.Shell, "-c", cmd) // #nosec
+	}
 	err := ec.Start()
 	if err != nil {
 		s.Error("runCmd start", "cmd", cmd, "err", err)
+		s.resourceMutex.Lock()
+		s.releaseCores(cores)
+		s.resourceMutex.Unlock()
 		sr(false)
 		return err
 	}
@@ -817,6 +988,7 @@ func (s *local) runCmd(cmd string, req *Requirements, reservedCh chan bool, call
 	} else {
 		s.cores -= req.Cores
 	}
+	s.releaseCores(cores)
 	s.resourceMutex.Unlock()
 
 	return nil // do not return error running the command
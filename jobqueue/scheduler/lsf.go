@@ -23,9 +23,10 @@ package scheduler
 
 import (
 	"bufio"
-	"encoding/csv"
 	"fmt"
+	"io/ioutil"
 	"math"
+	"os"
 	"os/exec"
 	"regexp"
 	"sort"
@@ -35,8 +36,36 @@ import (
 
 	"github.com/VertebrateResequencing/wr/internal"
 	"github.com/inconshreveable/log15"
+	sync "github.com/sasha-s/go-deadlock"
 )
 
+const (
+	lsfBackoffMin        = 1 * time.Second
+	lsfBackoffMax        = 2 * time.Minute
+	lsfSubmitWaitMin     = 100 * time.Millisecond
+	lsfSubmitWaitMax     = 2 * time.Second
+	lsfSubmitWaitTimeout = 10 * time.Second
+)
+
+// lsfSubmission is a bsub submission waiting to be made as part of a batch.
+type lsfSubmission struct {
+	args []string
+	done chan lsfSubmitted
+}
+
+// lsfSubmitted is the outcome of an lsfSubmission.
+type lsfSubmitted struct {
+	jobID string
+	err   error
+}
+
+// bjobsLine holds the columns of a line of bjobs -w output that we care about.
+type bjobsLine struct {
+	jobID   string
+	stat    string
+	jobName string
+}
+
 // lsf is our implementer of scheduleri
 type lsf struct {
 	config             *ConfigLSF
@@ -45,11 +74,20 @@ type lsf struct {
 	bsubRegex          *regexp.Regexp
 	memLimitMultiplier float32
 	queues             map[string]map[string]int
+	allowedQueues      map[string]bool
 	sortedqs           map[int][]string
 	sortedqKeys        []int
 	bsubExe            string
 	bjobsExe           string
 	bkillExe           string
+	bjobsCache         []bjobsLine
+	bjobsCacheTime     time.Time
+	backoff            time.Duration
+	backoffUntil       time.Time
+	bjobsMutex         sync.Mutex
+	backoffMutex       sync.Mutex
+	batches            map[string][]*lsfSubmission
+	batchMutex         sync.Mutex
 	log15.Logger
 }
 
@@ -62,6 +100,29 @@ type ConfigLSF struct {
 	// shell is the shell to use to run the commands to interact with your job
 	// scheduler; 'bash' is recommended.
 	Shell string
+
+	// BjobsCacheTTL is how long the output of bjobs is reused for before bjobs
+	// is run again. Increasing this reduces the load on your LSF master when
+	// many different kinds of cmd are being scheduled at once, at the cost of
+	// reacting more slowly to changes that wr did not make itself. Optional;
+	// the default of 0 means bjobs is run every time it is needed.
+	BjobsCacheTTL time.Duration
+
+	// BatchWindow, if set, makes bsub submissions for the same requirements
+	// (the same queue, memory, cores and other bsub options) that are made
+	// within this long of each other be submitted together with a single call
+	// to `bsub -pack`, reducing the load on your LSF master when many
+	// different kinds of cmd are being scheduled at once. Requires LSF 10.1 or
+	// later with LSB_MAX_PACK_JOBS set in lsf.conf. Optional; the default of 0
+	// means each submission is made with its own call to bsub.
+	BatchWindow time.Duration
+
+	// Queues, if set, restricts the queues that will be automatically picked
+	// between to just these (eg. your site's short, long, hugemem and gpu
+	// queues). Jobs that specify their own queue(s) can still use any queue
+	// they are allowed to use. Optional; the default of nil means all usable
+	// queues are considered.
+	Queues []string
 }
 
 // initialize finds out about lsf's hosts and queues
@@ -92,7 +153,8 @@ func (s *lsf) initialize(config interface{}, logger log15.Logger) error {
 		"Dec": 12,
 	}
 	s.dateRegex = regexp.MustCompile(`(\w+)\s+(\d+) (\d+):(\d+):(\d+)`)
-	s.bsubRegex = regexp.MustCompile(`^Job <(\d+)>`)
+	s.bsubRegex = regexp.MustCompile(`(?m)^Job <(\d+)>`)
+	s.batches = make(map[string][]*lsfSubmission)
 
 	// use lsadmin to see what units memlimit (bsub -M) is in
 	s.memLimitMultiplier = float32(1000)                                                                          // by default assume it's KB
@@ -303,6 +365,19 @@ func (s *lsf) initialize(config interface{}, logger log15.Logger) error {
 		return Error{"lsf", "initialize", fmt.Sprintf("failed to finish running [bqueues -l]: %s", err)}
 	}
 
+	// note which queues we're allowed to automatically pick
+	s.allowedQueues = make(map[string]bool)
+	for _, queue := range s.config.Queues {
+		if _, usable := s.queues[queue]; !usable {
+			s.Warn("configured queue is not usable", "queue", queue)
+			continue
+		}
+		s.allowedQueues[queue] = true
+	}
+	if len(s.config.Queues) > 0 && len(s.allowedQueues) == 0 {
+		return Error{"lsf", "initialize", fmt.Sprintf("none of the configured queues %s are usable", s.config.Queues)}
+	}
+
 	// for each criteria we're going to sort the queues on later, hard-code
 	// [weight, sort-order, significant_change, highest_multiplier]. We want to
 	// avoid chunked queues because that means jobs will run sequentially
@@ -467,6 +542,11 @@ func (s *lsf) schedule(cmd string, req *Requirements, priority uint8, count int)
 		return err // impossible to run cmd with these reqs
 	}
 
+	// if LSF has recently been giving us errors, don't hammer it
+	if err = s.checkBackoff(); err != nil {
+		return err
+	}
+
 	// get the details of everything already in the scheduler for this cmd,
 	// removing from the queue anything not currently running when we're over
 	// the desired count
@@ -479,13 +559,19 @@ func (s *lsf) schedule(cmd string, req *Requirements, priority uint8, count int)
 		return nil
 	}
 
-	bsubArgs := s.generateBsubArgs(queue, req, cmd, stillNeeded)
+	classArgs := s.generateBsubClassArgs(queue, req)
+	bsubArgs := bsubArgsForCmd(classArgs, cmd, s.config.Deployment, stillNeeded)
 
-	// submit to the queue
-	bsubcmd := exec.Command(s.bsubExe, bsubArgs...) // #nosec
-	bsubout, err := bsubcmd.Output()
+	// submit to the queue, along with any other submissions for the same
+	// requirements if we're batching
+	var jobID string
+	if s.config.BatchWindow > 0 {
+		jobID, err = s.submitBatched(strings.Join(classArgs, " "), bsubArgs)
+	} else {
+		jobID, err = s.submit(bsubArgs)
+	}
 	if err != nil {
-		return Error{"lsf", "schedule", fmt.Sprintf("failed to run %s %s: %s", s.bsubExe, bsubArgs, err)}
+		return err
 	}
 
 	// unfortunately, a job can be successfully submitted to the queue but not
@@ -496,70 +582,256 @@ func (s *lsf) schedule(cmd string, req *Requirements, priority uint8, count int)
 	// running. To solve this issue we will wait until bjobs -w <jobid> is found
 	// and only then return. If a subsequent busy() call returns false, that
 	// means the job completed and we're really not busy.
-	if matches := s.bsubRegex.FindStringSubmatch(string(bsubout)); len(matches) == 2 {
-		ready := make(chan bool, 1)
-		go func() {
-			defer internal.LogPanic(s.Logger, "lsf scheduling", true)
-
-			limit := time.After(10 * time.Second)
-			ticker := time.NewTicker(100 * time.Millisecond)
-			for {
-				select {
-				case <-ticker.C:
-					bjcmd := exec.Command(s.bjobsExe, "-w", matches[1]) // #nosec
-					bjout, errf := bjcmd.CombinedOutput()
-					if errf != nil {
-						continue
-					}
-					if len(bjout) > 46 {
-						ticker.Stop()
-						ready <- true
-						return
-					}
-					continue
-				case <-limit:
-					ticker.Stop()
-					ready <- false
+	ready := make(chan bool, 1)
+	go func() {
+		defer internal.LogPanic(s.Logger, "lsf scheduling", true)
+
+		// check quickly at first, then back off so as not to hammer the
+		// LSF master
+		limit := time.After(lsfSubmitWaitTimeout)
+		wait := lsfSubmitWaitMin
+		timer := time.NewTimer(wait)
+		for {
+			select {
+			case <-timer.C:
+				bjcmd := exec.Command(s.bjobsExe, "-w", jobID) // #nosec
+				bjout, errf := bjcmd.CombinedOutput()
+				if errf == nil && len(bjout) > 46 {
+					ready <- true
 					return
 				}
+				wait *= 2
+				if wait > lsfSubmitWaitMax {
+					wait = lsfSubmitWaitMax
+				}
+				timer.Reset(wait)
+				continue
+			case <-limit:
+				timer.Stop()
+				ready <- false
+				return
 			}
-		}()
-		ok := <-ready
-		if !ok {
-			return Error{"lsf", "schedule", "after running bsub, failed to find the submitted jobs in bjobs"}
 		}
+	}()
+	ok := <-ready
+	if !ok {
+		return Error{"lsf", "schedule", "after running bsub, failed to find the submitted jobs in bjobs"}
+	}
+
+	return nil
+}
+
+// submit runs bsub with the given args, returning the id of the LSF job it
+// submitted.
+func (s *lsf) submit(bsubArgs []string) (string, error) {
+	bsubcmd := exec.Command(s.bsubExe, bsubArgs...) // #nosec
+	bsubout, err := bsubcmd.Output()
+	s.invalidateBjobsCache()
+	if err != nil {
+		s.startBackoff()
+		return "", Error{"lsf", "schedule", fmt.Sprintf("failed to run %s %s: %s", s.bsubExe, bsubArgs, err)}
+	}
+	s.resetBackoff()
+
+	matches := s.bsubRegex.FindStringSubmatch(string(bsubout))
+	if len(matches) != 2 {
+		return "", Error{"lsf", "schedule", fmt.Sprintf("bsub %s returned unexpected output: %s", bsubArgs, bsubout)}
+	}
+	return matches[1], nil
+}
+
+// submitBatched waits for BatchWindow to see if any other submissions for the
+// same class of requirements are made, then submits them all with a single
+// bsub -pack, returning the id of the LSF job submitted for the given args.
+func (s *lsf) submitBatched(class string, bsubArgs []string) (string, error) {
+	sub := &lsfSubmission{args: bsubArgs, done: make(chan lsfSubmitted, 1)}
+
+	s.batchMutex.Lock()
+	s.batches[class] = append(s.batches[class], sub)
+	if len(s.batches[class]) == 1 {
+		time.AfterFunc(s.config.BatchWindow, func() {
+			defer internal.LogPanic(s.Logger, "lsf batch submission", true)
+			s.submitBatch(class)
+		})
+	}
+	s.batchMutex.Unlock()
+
+	result := <-sub.done
+	return result.jobID, result.err
+}
+
+// submitBatch submits all the submissions currently waiting in the given
+// class's batch, telling each of them the outcome.
+func (s *lsf) submitBatch(class string) {
+	s.batchMutex.Lock()
+	subs := s.batches[class]
+	delete(s.batches, class)
+	s.batchMutex.Unlock()
+
+	if len(subs) == 1 {
+		jobID, err := s.submit(subs[0].args)
+		subs[0].done <- lsfSubmitted{jobID: jobID, err: err}
+		return
+	}
+
+	for i, result := range s.submitPack(subs) {
+		subs[i].done <- result
+	}
+}
+
+// submitPack submits the given submissions with a single call to bsub -pack,
+// returning the outcome of each of them, in the same order. bsub -pack
+// carries on past submissions it rejects, so even if it exits non-zero, the
+// ones it did accept are returned with their job ids, as long as it reported on
+// every submission. If it didn't, they are all returned as failed.
+func (s *lsf) submitPack(subs []*lsfSubmission) []lsfSubmitted {
+	results := make([]lsfSubmitted, len(subs))
+	failAll := func(err error) []lsfSubmitted {
+		for i := range results {
+			results[i].err = err
+		}
+		return results
+	}
+
+	packFile, err := ioutil.TempFile("", "wr_lsf_pack_")
+	if err != nil {
+		return failAll(Error{"lsf", "schedule", fmt.Sprintf("failed to create a bsub pack file: %s", err)})
+	}
+	defer func() {
+		errr := os.Remove(packFile.Name())
+		if errr != nil {
+			s.Warn("failed to remove bsub pack file", "path", packFile.Name(), "err", errr)
+		}
+	}()
+
+	_, err = packFile.WriteString(bsubPack(subs))
+	if errc := packFile.Close(); err == nil {
+		err = errc
+	}
+	if err != nil {
+		return failAll(Error{"lsf", "schedule", fmt.Sprintf("failed to write bsub pack file: %s", err)})
+	}
+
+	// bsub reports on each submission in turn, to stdout for those it accepts
+	// and stderr for those it doesn't, so we combine them to keep the order
+	bsubcmd := exec.Command(s.bsubExe, "-pack", packFile.Name()) // #nosec
+	bsubout, err := bsubcmd.CombinedOutput()
+	s.invalidateBjobsCache()
+
+	var lines []string
+	for _, line := range strings.Split(string(bsubout), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) != len(subs) {
+		// we can't tell which line is about which submission, so rather than
+		// risk giving a submission another's id, we fail them all so they get
+		// retried, and kill any that were accepted so they don't also run
+		var accepted []string
+		for _, line := range lines {
+			if matches := s.bsubRegex.FindStringSubmatch(line); len(matches) == 2 {
+				accepted = append(accepted, matches[1])
+			}
+		}
+		s.bkillUnmatched(accepted)
+
+		msg := fmt.Sprintf("bsub -pack of %d submissions reported on %d, so they could not be matched to job ids: %s", len(subs), len(lines), bsubout)
+		if err != nil {
+			msg += fmt.Sprintf(" (%s)", err)
+		}
+		failAll(Error{"lsf", "schedule", msg})
+
+		if len(accepted) == 0 {
+			s.startBackoff()
+		} else {
+			s.resetBackoff()
+		}
+		return results
+	}
+
+	var submitted int
+	for i := range subs {
+		matches := s.bsubRegex.FindStringSubmatch(lines[i])
+		if len(matches) != 2 {
+			results[i].err = Error{"lsf", "schedule", fmt.Sprintf("bsub -pack rejected submission %d of %d: %s", i+1, len(subs), lines[i])}
+			continue
+		}
+		results[i].jobID = matches[1]
+		submitted++
+	}
+
+	if submitted == 0 {
+		s.startBackoff()
 	} else {
-		return Error{"lsf", "schedule", fmt.Sprintf("bsub %s returned unexpected output: %s", bsubArgs, bsubout)}
+		s.resetBackoff()
 	}
+	return results
+}
 
-	return err
+// bkillUnmatched kills the given LSF jobs, which bsub -pack accepted but we
+// couldn't match to their submissions.
+func (s *lsf) bkillUnmatched(jobIDs []string) {
+	if len(jobIDs) == 0 {
+		return
+	}
+	killcmd := exec.Command(s.bkillExe, jobIDs...) // #nosec
+	out, err := killcmd.CombinedOutput()
+	s.invalidateBjobsCache()
+	if err != nil {
+		s.Warn("bkill of unmatched bsub -pack submissions failed", "cmd", s.bkillExe, "toKill", jobIDs, "err", err, "out", string(out))
+	}
 }
 
-// generateBsubArgs generates the appropriate bsub args for the given req and
-// cmd and queue
-func (s *lsf) generateBsubArgs(queue string, req *Requirements, cmd string, needed int) []string {
+// bsubPack returns the contents of a bsub -pack file for the given
+// submissions: one line of quoted bsub args per submission.
+func bsubPack(subs []*lsfSubmission) string {
+	var pack strings.Builder
+	for _, sub := range subs {
+		quoted := make([]string, len(sub.args))
+		for i, arg := range sub.args {
+			quoted[i] = bsubPackQuote(arg)
+		}
+		pack.WriteString(strings.Join(quoted, " "))
+		pack.WriteString("\n")
+	}
+	return pack.String()
+}
+
+// bsubPackQuote quotes the given arg so that bsub -pack will see it as a
+// single arg, with the same value it would have been given by exec.
+func bsubPackQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\"'\\$`") {
+		return arg
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "`", "\\`")
+	return `"` + r.Replace(arg) + `"`
+}
+
+// generateBsubClassArgs generates the bsub args that depend only on the given
+// queue and req, and so are the same for all submissions with the same
+// requirements.
+func (s *lsf) generateBsubClassArgs(queue string, req *Requirements) []string {
 	var bsubArgs []string
 	megabytes := req.RAM
 	m := float32(megabytes) * s.memLimitMultiplier
-	bsubArgs = append(bsubArgs, "-q", queue, "-M", fmt.Sprintf("%0.0f", m), "-R", fmt.Sprintf("'select[mem>%d] rusage[mem=%d] span[hosts=1]'", megabytes, megabytes))
+	bsubArgs = append(bsubArgs, "-q", queue, "-M", fmt.Sprintf("%0.0f", m), "-R", fmt.Sprintf("'select[mem>%d%s] rusage[mem=%d] span[hosts=1]'", megabytes, s.avoidHostsSelect(req), megabytes))
 
 	if val, ok := req.Other["scheduler_misc"]; ok {
-		if strings.Contains(val, `'`) {
-			s.Warn("scheduler misc option ignored due to containing single quotes", "misc", val)
+		fields, err := splitBsubArgs(val)
+		if err != nil {
+			s.Warn("scheduler misc option ignored", "misc", val, "err", err)
 		} else {
-			r := csv.NewReader(strings.NewReader(val))
-			r.Comma = ' '
-			fields, err := r.Read()
-			if err != nil {
-				s.Warn("scheduler misc option ignored", "misc", val, "err", err)
-			} else {
-				for _, field := range fields {
-					if strings.Contains(field, ` `) {
-						field = `'` + field + `'`
-					}
-					bsubArgs = append(bsubArgs, field)
-				}
-			}
+			bsubArgs = append(bsubArgs, fields...)
+		}
+	}
+
+	if val := argsFor("lsf", req); val != "" {
+		fields, err := parseLSFArgs(val)
+		if err != nil {
+			s.Warn("scheduler args ignored", "args", val, "err", err)
+		} else {
+			bsubArgs = append(bsubArgs, fields...)
 		}
 	}
 
@@ -567,10 +839,24 @@ func (s *lsf) generateBsubArgs(queue string, req *Requirements, cmd string, need
 		bsubArgs = append(bsubArgs, "-n", fmt.Sprintf("%d", int(math.Ceil(req.Cores))))
 	}
 
+	return bsubArgs
+}
+
+// generateBsubArgs generates the appropriate bsub args for the given req and
+// cmd and queue
+func (s *lsf) generateBsubArgs(queue string, req *Requirements, cmd string, needed int) []string {
+	return bsubArgsForCmd(s.generateBsubClassArgs(queue, req), cmd, s.config.Deployment, needed)
+}
+
+// bsubArgsForCmd returns the given args from generateBsubClassArgs() with the
+// args needed to submit the given cmd added.
+func bsubArgsForCmd(classArgs []string, cmd, deployment string, needed int) []string {
+	bsubArgs := append([]string(nil), classArgs...)
+
 	// for checkCmd() to work efficiently we must always set a job name that
 	// corresponds to the cmd. It must also be unique otherwise LSF would not
 	// start running jobs with duplicate names until previous ones complete
-	name := jobName(cmd, s.config.Deployment, true)
+	name := jobName(cmd, deployment, true)
 	if needed > 1 {
 		name += fmt.Sprintf("[1-%d]", needed)
 	}
@@ -579,6 +865,25 @@ func (s *lsf) generateBsubArgs(queue string, req *Requirements, cmd string, need
 	return bsubArgs
 }
 
+// avoidHostsSelect returns additional select string terms that exclude any
+// hosts listed in req.Other["avoid_hosts"].
+func (s *lsf) avoidHostsSelect(req *Requirements) string {
+	val, ok := req.Other["avoid_hosts"]
+	if !ok || val == "" {
+		return ""
+	}
+
+	var sel string
+	for _, host := range strings.Split(val, ",") {
+		if host == "" || strings.ContainsAny(host, `'" `) {
+			s.Warn("avoid host ignored due to containing invalid characters", "host", host)
+			continue
+		}
+		sel += fmt.Sprintf(" && hname!=%s", host)
+	}
+	return sel
+}
+
 // recover achieves the aims of Recover(). We don't have to do anything, since
 // when the cmd finishes running, LSF itself will clean up.
 func (s *lsf) recover(cmd string, req *Requirements, host *RecoveredHostDetails) error {
@@ -599,12 +904,23 @@ func (s *lsf) busy() bool {
 
 // determineQueue picks a queue, preferring ones that are more likely to run our
 // job the soonest (amongst those that are capable of running it). If req.Other
-// contains a scheduler_queue value, returns that instead.
+// contains a scheduler_queue value (a comma separated list of queue names), it
+// picks amongst those instead, returning an ErrBadQueue Error if none of them
+// are usable and capable of running it.
 // *** globalMax option and associated code may be removed if we never have a
 // way for user to pass this in.
 func (s *lsf) determineQueue(req *Requirements, globalMax int) (string, error) {
-	if queue, ok := req.Other["scheduler_queue"]; ok {
-		return queue, nil
+	allowed := s.allowedQueues
+	userSpecified := false
+	if val, ok := req.Other["scheduler_queue"]; ok && val != "" {
+		allowed = make(map[string]bool)
+		for _, queue := range strings.Split(val, ",") {
+			queue = strings.TrimSpace(queue)
+			if _, usable := s.queues[queue]; usable {
+				allowed[queue] = true
+			}
+		}
+		userSpecified = true
 	}
 
 	seconds := req.Time.Seconds()
@@ -620,6 +936,10 @@ func (s *lsf) determineQueue(req *Requirements, globalMax int) (string, error) {
 	}
 
 	for _, queue := range s.sortedqs[sortedQueue] {
+		if (userSpecified || len(allowed) > 0) && !allowed[queue] {
+			continue
+		}
+
 		memLimit := s.queues[queue]["memlimit"]
 		if memLimit > 0 && memLimit < mb {
 			continue
@@ -633,6 +953,9 @@ func (s *lsf) determineQueue(req *Requirements, globalMax int) (string, error) {
 		return queue, nil
 	}
 
+	if userSpecified {
+		return "", Error{"lsf", "determineQueue", ErrBadQueue}
+	}
 	return "", Error{"lsf", "determineQueue", ErrImpossible}
 }
 
@@ -692,6 +1015,7 @@ func (s *lsf) checkCmd(cmd string, max int) (count int, err error) {
 		if len(toKill) > 1 {
 			killcmd := exec.Command(s.bkillExe, toKill...) // #nosec
 			out, errk := killcmd.CombinedOutput()
+			s.invalidateBjobsCache()
 			if errk != nil && !strings.HasPrefix(string(out), "Job has already finished") {
 				s.Warn("checkCmd bkill failed", "cmd", s.bkillExe, "toKill", toKill, "err", errk, "out", string(out))
 			}
@@ -708,18 +1032,59 @@ func (s *lsf) checkCmd(cmd string, max int) (count int, err error) {
 
 type bjobsCB func(jobID, stat, jobName string)
 
-// parseBjobs runs bjobs, filters on a job name prefix, excludes exited jobs and
-// gives columns 1 (JOBID), 3 (STAT) and 7 (JOB_NAME) to your callback for each
-// bjobs output line.
+// parseBjobs runs bjobs (or uses its recently cached output), filters on a job
+// name prefix, excludes exited jobs and gives columns 1 (JOBID), 3 (STAT) and 7
+// (JOB_NAME) to your callback for each bjobs output line.
 func (s *lsf) parseBjobs(jobPrefix string, callback bjobsCB) error {
+	lines, err := s.bjobs()
+	for _, line := range lines {
+		if !strings.HasPrefix(line.jobName, jobPrefix) {
+			continue
+		}
+		callback(line.jobID, line.stat, line.jobName)
+	}
+	return err
+}
+
+// bjobs returns the non-exited lines of bjobs -w output, running bjobs only if
+// our cache of its output is older than BjobsCacheTTL. Errors start a backoff.
+func (s *lsf) bjobs() ([]bjobsLine, error) {
+	s.bjobsMutex.Lock()
+	defer s.bjobsMutex.Unlock()
+	if s.config.BjobsCacheTTL > 0 && s.bjobsCache != nil && time.Since(s.bjobsCacheTime) < s.config.BjobsCacheTTL {
+		return s.bjobsCache, nil
+	}
+
+	lines, err := s.runBjobs()
+	if err != nil {
+		s.startBackoff()
+		s.bjobsCache = nil
+		return lines, err
+	}
+	s.bjobsCache = lines
+	s.bjobsCacheTime = time.Now()
+	return lines, nil
+}
+
+// invalidateBjobsCache makes the next bjobs() call run bjobs, which is needed
+// after we submit or kill jobs.
+func (s *lsf) invalidateBjobsCache() {
+	s.bjobsMutex.Lock()
+	defer s.bjobsMutex.Unlock()
+	s.bjobsCache = nil
+}
+
+// runBjobs runs bjobs -w and returns the lines for non-exited jobs.
+func (s *lsf) runBjobs() ([]bjobsLine, error) {
+	lines := []bjobsLine{}
 	bjcmd := exec.Command(s.config.Shell, "-c", s.bjobsExe+" -w") // #nosec
 	bjout, err := bjcmd.StdoutPipe()
 	if err != nil {
-		return Error{"lsf", "parseBjobs", fmt.Sprintf("failed to create pipe for [bjobs -w]: %s", err)}
+		return lines, Error{"lsf", "parseBjobs", fmt.Sprintf("failed to create pipe for [bjobs -w]: %s", err)}
 	}
 	err = bjcmd.Start()
 	if err != nil {
-		return Error{"lsf", "parseBjobs", fmt.Sprintf("failed to start [bjobs -w]: %s", err)}
+		return lines, Error{"lsf", "parseBjobs", fmt.Sprintf("failed to start [bjobs -w]: %s", err)}
 	}
 	bjScanner := bufio.NewScanner(bjout)
 
@@ -728,21 +1093,57 @@ func (s *lsf) parseBjobs(jobPrefix string, callback bjobsCB) error {
 		fields := strings.Fields(line)
 
 		if len(fields) > 7 {
-			if fields[2] == "EXIT" || fields[2] == "DONE" || !strings.HasPrefix(fields[6], jobPrefix) {
+			if fields[2] == "EXIT" || fields[2] == "DONE" {
 				continue
 			}
-			callback(fields[0], fields[2], fields[6])
+			lines = append(lines, bjobsLine{jobID: fields[0], stat: fields[2], jobName: fields[6]})
 		}
 	}
 
 	if err = bjScanner.Err(); err != nil {
-		return Error{"lsf", "parseBjobs", fmt.Sprintf("failed to read everything from [bjobs -w]: %s", err)}
+		return lines, Error{"lsf", "parseBjobs", fmt.Sprintf("failed to read everything from [bjobs -w]: %s", err)}
 	}
 	err = bjcmd.Wait()
 	if err != nil {
 		err = Error{"lsf", "parseBjobs", fmt.Sprintf("failed to finish running [bjobs -w]: %s", err)}
 	}
-	return err
+	return lines, err
+}
+
+// checkBackoff returns an error if we are currently backing off from LSF due
+// to recent errors.
+func (s *lsf) checkBackoff() error {
+	s.backoffMutex.Lock()
+	defer s.backoffMutex.Unlock()
+	if wait := time.Until(s.backoffUntil); wait > 0 {
+		return Error{"lsf", "schedule", fmt.Sprintf("backing off for %s after LSF errors", wait.Round(time.Second))}
+	}
+	return nil
+}
+
+// startBackoff notes that LSF gave us an error, so that checkBackoff() will
+// return errors for an exponentially increasing amount of time.
+func (s *lsf) startBackoff() {
+	s.backoffMutex.Lock()
+	defer s.backoffMutex.Unlock()
+	if s.backoff == 0 {
+		s.backoff = lsfBackoffMin
+	} else {
+		s.backoff *= 2
+		if s.backoff > lsfBackoffMax {
+			s.backoff = lsfBackoffMax
+		}
+	}
+	s.backoffUntil = time.Now().Add(s.backoff)
+	s.Warn("backing off from LSF after an error", "duration", s.backoff)
+}
+
+// resetBackoff notes that LSF is working again.
+func (s *lsf) resetBackoff() {
+	s.backoffMutex.Lock()
+	defer s.backoffMutex.Unlock()
+	s.backoff = 0
+	s.backoffUntil = time.Time{}
 }
 
 // hostToID always returns an empty string, since we're not in the cloud.
@@ -0,0 +1,385 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package scheduler
+
+// This file contains a scheduleri implementation for 'multi': routing jobs to
+// one of a number of other schedulers, so that eg. local, LSF and OpenStack can
+// all be used at once.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	sync "github.com/sasha-s/go-deadlock"
+)
+
+// MultiOtherKey is the key in Requirements.Other that, if set to the Name of a
+// MultiBackend, forces a job to be routed to that backend.
+const MultiOtherKey = "scheduler"
+
+// MultiBackend describes one of the schedulers that a multi scheduler can
+// route jobs to.
+type MultiBackend struct {
+	// Name is the name of the scheduler type, eg. "local" or "lsf".
+	Name string
+
+	// Config is the config appropriate for the scheduler type, eg. a
+	// *ConfigLocal.
+	Config interface{}
+}
+
+// RoutingRule describes the requirements of jobs that should be routed to a
+// certain MultiBackend. All the criteria that are set must be met for a rule to
+// match.
+type RoutingRule struct {
+	// Backend is the Name of the MultiBackend that matching jobs go to.
+	Backend string
+
+	// MinRAM is the minimum RAM in MB.
+	MinRAM int
+
+	// MinCores is the minimum number of cores.
+	MinCores float64
+
+	// MinTime is the minimum time.
+	MinTime time.Duration
+
+	// MinDisk is the minimum disk in GB.
+	MinDisk int
+}
+
+// matches tells you if the given Requirements meet this rule's criteria.
+func (r *RoutingRule) matches(req *Requirements) bool {
+	return req.RAM >= r.MinRAM && req.Cores >= r.MinCores && req.Time >= r.MinTime && req.Disk >= r.MinDisk
+}
+
+// ParseRoutingRules parses a string like
+// "openstack:ram>=64000,time>=24h;local:cores>=0" in to RoutingRules. Each
+// rule is a backend name followed by a colon and comma separated criteria,
+// with rules separated by semi-colons. Criteria are one of ram (MB), cores,
+// time (a duration) or disk (GB), followed by >= and a value.
+func ParseRoutingRules(spec string) ([]*RoutingRule, error) {
+	var rules []*RoutingRule
+	for _, ruleSpec := range strings.Split(spec, ";") {
+		ruleSpec = strings.TrimSpace(ruleSpec)
+		if ruleSpec == "" {
+			continue
+		}
+
+		parts := strings.SplitN(ruleSpec, ":", 2)
+		rule := &RoutingRule{Backend: strings.TrimSpace(parts[0])}
+		if rule.Backend == "" {
+			return nil, fmt.Errorf("routing rule [%s] has no backend", ruleSpec)
+		}
+
+		if len(parts) == 2 {
+			for _, criterion := range strings.Split(parts[1], ",") {
+				kv := strings.SplitN(strings.TrimSpace(criterion), ">=", 2)
+				if len(kv) != 2 {
+					return nil, fmt.Errorf("routing rule criterion [%s] is not of the form key>=value", criterion)
+				}
+
+				var err error
+				switch kv[0] {
+				case "ram":
+					rule.MinRAM, err = strconv.Atoi(kv[1])
+				case "cores":
+					rule.MinCores, err = strconv.ParseFloat(kv[1], 64)
+				case "time":
+					rule.MinTime, err = time.ParseDuration(kv[1])
+				case "disk":
+					rule.MinDisk, err = strconv.Atoi(kv[1])
+				default:
+					err = fmt.Errorf("unknown criterion %s", kv[0])
+				}
+				if err != nil {
+					return nil, fmt.Errorf("routing rule criterion [%s] is invalid: %s", criterion, err)
+				}
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// ConfigMulti represents the configuration options required by the multi
+// scheduler.
+type ConfigMulti struct {
+	// Backends are the schedulers to route jobs to. The first is the default
+	// for jobs that match no rule. Required, and each Name must be unique.
+	Backends []*MultiBackend
+
+	// Rules are checked in order, and the first one that matches a job's
+	// Requirements decides the backend it goes to. Jobs can also specify a
+	// backend using the MultiOtherKey in their Requirements.Other, which takes
+	// precedence. Optional.
+	Rules []*RoutingRule
+
+	// Burst lets jobs that would be routed to one backend spill over to
+	// another when the first has too much outstanding work. Jobs that used
+	// the MultiOtherKey to pick their backend never spill over. Optional.
+	Burst *BurstPolicy
+}
+
+// multi is our implementer of scheduleri.
+type multi struct {
+	config   *ConfigMulti
+	backends map[string]*Scheduler
+	order    []string
+	routes   map[string]map[string]int
+	burst    *burster
+	mutex    sync.RWMutex
+	log15.Logger
+}
+
+// initialize creates all our backend schedulers.
+func (s *multi) initialize(config interface{}, logger log15.Logger) error {
+	s.config = config.(*ConfigMulti)
+	s.Logger = logger.New("scheduler", "multi")
+	s.routes = make(map[string]map[string]int)
+
+	if len(s.config.Backends) == 0 {
+		return Error{"multi", "initialize", "no backends configured"}
+	}
+
+	s.backends = make(map[string]*Scheduler)
+	for _, backend := range s.config.Backends {
+		if backend.Name == "multi" {
+			return Error{"multi", "initialize", "multi can't be a backend of itself"}
+		}
+		if _, exists := s.backends[backend.Name]; exists {
+			return Error{"multi", "initialize", fmt.Sprintf("backend %s specified more than once", backend.Name)}
+		}
+
+		sch, err := New(backend.Name, backend.Config, logger)
+		if err != nil {
+			return err
+		}
+		s.backends[backend.Name] = sch
+		s.order = append(s.order, backend.Name)
+	}
+
+	for _, rule := range s.config.Rules {
+		if _, exists := s.backends[rule.Backend]; !exists {
+			return Error{"multi", "initialize", fmt.Sprintf("routing rule refers to unknown backend %s", rule.Backend)}
+		}
+	}
+
+	if s.config.Burst != nil {
+		if err := s.checkBurstPolicy(s.config.Burst); err != nil {
+			return Error{"multi", "initialize", err.Error()}
+		}
+		s.burst = newBurster(s.config.Burst)
+	}
+
+	return nil
+}
+
+// checkBurstPolicy validates the policy and checks it refers to our backends.
+func (s *multi) checkBurstPolicy(policy *BurstPolicy) error {
+	if err := policy.validate(); err != nil {
+		return err
+	}
+	for _, name := range []string{policy.From, policy.To} {
+		if _, exists := s.backends[name]; !exists {
+			return fmt.Errorf("burst policy refers to unknown backend %s", name)
+		}
+	}
+	return nil
+}
+
+// route returns the name of the backend that jobs with the given Requirements
+// should go to.
+func (s *multi) route(req *Requirements) string {
+	if name, forced := s.forcedRoute(req); forced {
+		return name
+	}
+
+	for _, rule := range s.config.Rules {
+		if rule.matches(req) {
+			return rule.Backend
+		}
+	}
+
+	return s.order[0]
+}
+
+// forcedRoute returns the name of the backend that the Requirements
+// specifically asked for using the MultiOtherKey, if any.
+func (s *multi) forcedRoute(req *Requirements) (string, bool) {
+	name, ok := req.Other[MultiOtherKey]
+	if !ok {
+		return "", false
+	}
+	if _, exists := s.backends[name]; !exists {
+		s.Warn("job requested an unknown scheduler", "scheduler", name)
+		return "", false
+	}
+	return name, true
+}
+
+// backend returns the Scheduler that jobs with the given Requirements should
+// go to.
+func (s *multi) backend(req *Requirements) *Scheduler {
+	return s.backends[s.route(req)]
+}
+
+// reserveTimeout achieves the aims of ReserveTimeout().
+func (s *multi) reserveTimeout(req *Requirements) int {
+	return s.backend(req).ReserveTimeout(req)
+}
+
+// maxQueueTime achieves the aims of MaxQueueTime().
+func (s *multi) maxQueueTime(req *Requirements) time.Duration {
+	return s.backend(req).impl.maxQueueTime(req)
+}
+
+// schedule achieves the aims of Schedule(), by passing through to the
+// appropriate backend, or splitting the count between backends if our burst
+// policy says we should spill over. Backends that a cmd previously went to but
+// no longer does (eg. because bursting stopped) are told we no longer need any.
+func (s *multi) schedule(cmd string, req *Requirements, priority uint8, count int) error {
+	name := s.route(req)
+	alloc := map[string]int{name: count}
+
+	var burstTo string
+	b := s.burster()
+	if b != nil {
+		if _, forced := s.forcedRoute(req); !forced && name == b.from() {
+			burstTo = b.to()
+			if n := b.allot(cmd, count, time.Now()); n > 0 {
+				alloc[burstTo] = n
+				alloc[name] = count - n
+			}
+		} else {
+			b.forget(cmd, true)
+		}
+	}
+
+	if n, bursting := alloc[burstTo]; bursting {
+		err := s.backends[burstTo].Schedule(cmd, req, priority, n)
+		if err != nil {
+			s.Warn("failed to burst", "backend", burstTo, "err", err)
+			b.forget(cmd, false)
+			delete(alloc, burstTo)
+			alloc[name] = count
+		}
+	}
+
+	s.mutex.Lock()
+	prev := s.routes[cmd]
+	if count > 0 {
+		s.routes[cmd] = alloc
+	} else {
+		delete(s.routes, cmd)
+	}
+	s.mutex.Unlock()
+
+	for backend := range prev {
+		if _, still := alloc[backend]; !still {
+			if err := s.backends[backend].Schedule(cmd, req, priority, 0); err != nil {
+				s.Warn("failed to unschedule cmd from previous backend", "backend", backend, "err", err)
+			}
+		}
+	}
+
+	return s.backends[name].Schedule(cmd, req, priority, alloc[name])
+}
+
+// burster returns our burster, if we have a burst policy.
+func (s *multi) burster() *burster {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.burst
+}
+
+// burstStatus returns the current state of our burst policy.
+func (s *multi) burstStatus() (*BurstStatus, error) {
+	b := s.burster()
+	if b == nil {
+		return nil, Error{"multi", "BurstStatus", ErrNoPolicy}
+	}
+	return b.status(), nil
+}
+
+// setBurstPolicy changes or sets our burst policy.
+func (s *multi) setBurstPolicy(policy *BurstPolicy) error {
+	if err := s.checkBurstPolicy(policy); err != nil {
+		return Error{"multi", "SetBurstPolicy", err.Error()}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.burst == nil {
+		s.burst = newBurster(policy)
+		return nil
+	}
+	s.burst.setPolicy(policy)
+	return nil
+}
+
+// recover achieves the aims of Recover().
+func (s *multi) recover(cmd string, req *Requirements, host *RecoveredHostDetails) error {
+	return s.backend(req).Recover(cmd, req, host)
+}
+
+// busy returns true if any backend is busy.
+func (s *multi) busy() bool {
+	for _, sch := range s.backends {
+		if sch.Busy() {
+			return true
+		}
+	}
+	return false
+}
+
+// hostToID returns the first id that any backend knows for the host.
+func (s *multi) hostToID(host string) string {
+	for _, name := range s.order {
+		if id := s.backends[name].HostToID(host); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// setMessageCallBack sets the callback on all backends.
+func (s *multi) setMessageCallBack(cb MessageCallBack) {
+	for _, sch := range s.backends {
+		sch.SetMessageCallBack(cb)
+	}
+}
+
+// setBadServerCallBack sets the callback on all backends.
+func (s *multi) setBadServerCallBack(cb BadServerCallBack) {
+	for _, sch := range s.backends {
+		sch.SetBadServerCallBack(cb)
+	}
+}
+
+// cleanup cleans up all backends.
+func (s *multi) cleanup() {
+	for _, sch := range s.backends {
+		sch.Cleanup()
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright © 2016-2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package scheduler
+
+// This file contains the NUMA awareness of the local scheduler: detecting
+// which cores belong to which NUMA node, so that when pinning cmds to cores,
+// each cmd's cores can come from a single node.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvCores and EnvNUMANode are the environment variables that the local
+// scheduler uses to tell pinned cmds which cores they have been allotted (as a
+// comma separated list of core ids), and which NUMA node those cores are on (if
+// they are all on the same one).
+const (
+	EnvCores    = "WR_CORES"
+	EnvNUMANode = "WR_NUMA_NODE"
+)
+
+// sysNodePath is where the kernel describes the machine's NUMA nodes.
+var sysNodePath = "/sys/devices/system/node"
+
+// numaNode describes a NUMA node of the machine.
+type numaNode struct {
+	id    int
+	cores []int
+}
+
+// readNUMATopology reads the NUMA nodes and their cores from the given
+// directory, which would normally be sysNodePath. Nodes are returned sorted by
+// id. Returns no nodes and no error if the directory doesn't exist, eg. on
+// non-Linux systems.
+func readNUMATopology(dir string) ([]*numaNode, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "node[0-9]*", "cpulist"))
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*numaNode, 0, len(paths))
+	for _, path := range paths {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(filepath.Dir(path)), "node"))
+		if err != nil {
+			continue
+		}
+		content, err := ioutil.ReadFile(path) // #nosec
+		if err != nil {
+			return nil, err
+		}
+		cores, err := parseCPUList(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		nodes = append(nodes, &numaNode{id: id, cores: cores})
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].id < nodes[j].id
+	})
+	return nodes, nil
+}
+
+// parseCPUList parses a kernel cpu list like "0-3,8-11,16" in to core ids.
+func parseCPUList(list string) ([]int, error) {
+	var cores []int
+	for _, part := range strings.Split(strings.TrimSpace(list), ",") {
+		if part == "" {
+			continue
+		}
+		bounds := strings.SplitN(part, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("bad cpu list %q", list)
+		}
+		last := first
+		if len(bounds) == 2 {
+			last, err = strconv.Atoi(bounds[1])
+			if err != nil || last < first {
+				return nil, fmt.Errorf("bad cpu list %q", list)
+			}
+		}
+		for core := first; core <= last; core++ {
+			cores = append(cores, core)
+		}
+	}
+	return cores, nil
+}
+
+// setupNUMA works out which NUMA node each of the cores we pin cmds to is on,
+// so that allotCores() can allot cores from within a single node. If the
+// machine has fewer than 2 nodes, or we can't tell, no NUMA awareness is set
+// up. You must have made coreUsage before calling this.
+func (s *local) setupNUMA(nodes []*numaNode) {
+	s.nodeCores = nil
+	s.coreNode = nil
+	if len(nodes) < 2 {
+		return
+	}
+
+	s.coreNode = make([]int, len(s.coreUsage))
+	for i := range s.coreNode {
+		s.coreNode[i] = -1
+	}
+	for _, node := range nodes {
+		var indexes []int
+		for _, core := range node.cores {
+			i := core - s.firstPinCore
+			if i < 0 || i >= len(s.coreUsage) {
+				continue
+			}
+			s.coreNode[i] = node.id
+			indexes = append(indexes, i)
+		}
+		if len(indexes) > 0 {
+			s.nodeCores = append(s.nodeCores, indexes)
+		}
+	}
+	s.Debug("NUMA aware core pinning", "nodes", len(nodes), "usable_nodes", len(s.nodeCores))
+}
+
+// leastUsedCores returns the given indexes of coreUsage, sorted so that the
+// least used cores come first, and otherwise in their original order.
+func (s *local) leastUsedCores(indexes []int) []int {
+	sorted := make([]int, len(indexes))
+	copy(sorted, indexes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return s.coreUsage[sorted[i]] < s.coreUsage[sorted[j]]
+	})
+	return sorted
+}
+
+// coresNode returns the NUMA node that all the given cores (as returned by
+// allotCores()) are on, or -1 if they span nodes or we're not NUMA aware.
+func (s *local) coresNode(cores []int) int {
+	if s.coreNode == nil || len(cores) == 0 {
+		return -1
+	}
+	node := s.coreNode[cores[0]-s.firstPinCore]
+	for _, core := range cores[1:] {
+		if s.coreNode[core-s.firstPinCore] != node {
+			return -1
+		}
+	}
+	return node
+}
@@ -22,13 +22,17 @@ package scheduler
 // on servers spawned on demand.
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math"
 	"os/exec"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	sync "github.com/sasha-s/go-deadlock"
@@ -48,6 +52,7 @@ const (
 	flavorFailedCacheCleanup     = 30 * time.Minute
 	flavorDeterminedCacheExpiry  = 5 * time.Minute
 	flavorDeterminedCacheCleanup = 10 * time.Minute
+	defaultVolumeMountPath       = "/mnt/wr_volume"
 )
 
 // debugCounter and debugEffect are used by tests to prove some bugs
@@ -62,6 +67,9 @@ type opst struct {
 	log15.Logger
 	config            *ConfigOpenStack
 	provider          *cloud.Provider
+	networkGroups     map[string]string
+	flavorScripts     []*flavorScript
+	volumeServers     map[string]bool
 	quotaMaxInstances int
 	quotaMaxCores     int
 	quotaMaxRAM       int
@@ -85,6 +93,7 @@ type opst struct {
 	stateMutex        sync.Mutex
 	rsMutex           sync.Mutex
 	spawnMutex        sync.Mutex
+	volumeMutex       sync.Mutex
 	spawnCanceller    map[string]map[string]chan struct{}
 	updatingState     bool
 }
@@ -136,6 +145,30 @@ type ConfigOpenStack struct {
 	// the flavors in a set with a single entry.
 	FlavorSets string
 
+	// NetworkGroups describes the existing security groups that give servers
+	// particular network access, in the form need1=group1,need2=group2, where
+	// the needs are as found in a Requirements.Other[NetworkOtherKey] value,
+	// eg. internet=web-egress,db.example.com:5432=db-access. Servers spawned
+	// to run commands with those needs will also be in the corresponding
+	// groups. Needs without a group here are ignored.
+	NetworkGroups string
+
+	// FlavorScripts are the []byte contents of extra scripts to run on newly
+	// spawned servers, after PostCreationScript (or a cloud_script), keyed on
+	// regular expressions that the server's flavor name must match for the
+	// script to run, eg. to install something only on large memory flavors.
+	// Matching scripts run in key order. The scripts are text/template
+	// templates that can use the server's {{.ID}}, {{.Name}}, {{.IP}},
+	// {{.OS}}, {{.Flavor}} (name), {{.Cores}}, {{.RAM}} (MB) and {{.Disk}}
+	// (GB).
+	FlavorScripts map[string][]byte
+
+	// VolumeMountPath is where the volume requested by a command (with a
+	// Requirements.Other[VolumeOtherKey] value) gets mounted on the server
+	// that runs it. Only one such command runs on a server at a time. It
+	// defaults to /mnt/wr_volume.
+	VolumeMountPath string
+
 	// PostCreationScript is the []byte content of a script you want executed
 	// after a server is Spawn()ed. (Overridden during Schedule() by a
 	// Requirements.Other["cloud_script"] value.)
@@ -195,6 +228,9 @@ type ConfigOpenStack struct {
 	// recommended.
 	Shell string
 
+	// Version is the version of wr, recorded on the cloud resources created.
+	Version string
+
 	// ServerPorts are the TCP port numbers you need to be open for
 	// communication with any spawned servers. At a minimum you will need to
 	// specify []int{22}, unless the network you use has all ports open and does
@@ -262,6 +298,9 @@ func (s *opst) initialize(config interface{}, logger log15.Logger) error {
 	if s.config.OSDisk == 0 {
 		s.config.OSDisk = 1
 	}
+	if s.config.VolumeMountPath == "" {
+		s.config.VolumeMountPath = defaultVolumeMountPath
+	}
 
 	s.Logger = logger.New("scheduler", "openstack")
 
@@ -279,6 +318,7 @@ func (s *opst) initialize(config interface{}, logger log15.Logger) error {
 		GatewayIP:      s.config.GatewayIP,
 		CIDR:           s.config.CIDR,
 		DNSNameServers: s.config.DNSNameServers,
+		Version:        s.config.Version,
 	})
 	if err != nil {
 		return err
@@ -369,6 +409,7 @@ func (s *opst) initialize(config interface{}, logger log15.Logger) error {
 	s.recoveredServers = make(map[string]bool)
 	s.stopRSMonitoring = make(chan struct{})
 	s.spawnCanceller = make(map[string]map[string]chan struct{})
+	s.volumeServers = make(map[string]bool)
 
 	if s.config.FlavorSets != "" {
 		sets := strings.Split(s.config.FlavorSets, ";")
@@ -378,6 +419,32 @@ func (s *opst) initialize(config interface{}, logger log15.Logger) error {
 		}
 	}
 
+	if s.config.NetworkGroups != "" {
+		s.networkGroups = make(map[string]string)
+		for _, pair := range strings.Split(s.config.NetworkGroups, ",") {
+			parts := strings.SplitN(pair, "=", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return fmt.Errorf("network group [%s] is not in the form need=group", pair)
+			}
+			s.networkGroups[parts[0]] = parts[1]
+		}
+	}
+
+	if len(s.config.FlavorScripts) > 0 {
+		keys := make([]string, 0, len(s.config.FlavorScripts))
+		for key := range s.config.FlavorScripts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fs, errf := newFlavorScript(key, s.config.FlavorScripts[key])
+			if errf != nil {
+				return errf
+			}
+			s.flavorScripts = append(s.flavorScripts, fs)
+		}
+	}
+
 	s.ffCache = cache.New(flavorFailedCacheExpiry, flavorFailedCacheCleanup)
 	s.dfCache = cache.New(flavorDeterminedCacheExpiry, flavorDeterminedCacheCleanup)
 
@@ -565,6 +632,174 @@ func (s *opst) serverReqs(req *Requirements) (osPrefix string, osScript []byte,
 	return osPrefix, osScript, osConfigFiles, flavor, sharedDisk, err
 }
 
+// flavorScript is a compiled FlavorScripts entry.
+type flavorScript struct {
+	regex *regexp.Regexp
+	tmpl  *template.Template
+}
+
+// newFlavorScript compiles the given FlavorScripts key and script.
+func newFlavorScript(regex string, script []byte) (*flavorScript, error) {
+	r, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, fmt.Errorf("flavor script regex [%s] is invalid: %w", regex, err)
+	}
+	tmpl, err := template.New(regex).Option("missingkey=error").Parse(string(script))
+	if err != nil {
+		return nil, fmt.Errorf("flavor script for [%s] is not a valid template: %w", regex, err)
+	}
+	return &flavorScript{regex: r, tmpl: tmpl}, nil
+}
+
+// flavorScriptData is what FlavorScripts are templated with.
+type flavorScriptData struct {
+	ID     string
+	Name   string
+	IP     string
+	OS     string
+	Flavor string
+	Cores  int
+	RAM    int
+	Disk   int
+}
+
+// script returns our script templated with the details of the given server,
+// or nil if the server's flavor doesn't match our regex.
+func (fs *flavorScript) script(server *cloud.Server) ([]byte, error) {
+	if server.Flavor == nil || !fs.regex.MatchString(server.Flavor.Name) {
+		return nil, nil
+	}
+	var script bytes.Buffer
+	err := fs.tmpl.Execute(&script, &flavorScriptData{
+		ID:     server.ID,
+		Name:   server.Name,
+		IP:     server.IP,
+		OS:     server.OS,
+		Flavor: server.Flavor.Name,
+		Cores:  server.Flavor.Cores,
+		RAM:    server.Flavor.RAM,
+		Disk:   server.Disk,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("flavor script for [%s] could not be templated: %w", fs.regex, err)
+	}
+	return script.Bytes(), nil
+}
+
+// runFlavorScripts runs our FlavorScripts that apply to the given newly
+// spawned server on it.
+func (s *opst) runFlavorScripts(server *cloud.Server, cmd string) error {
+	for _, fs := range s.flavorScripts {
+		script, err := fs.script(server)
+		if err != nil {
+			return err
+		}
+		if script == nil {
+			continue
+		}
+		err = s.actOnServerIfNeeded(server, cmd, func(ctx context.Context) error {
+			return server.RunScript(ctx, script)
+		})
+		if err != nil {
+			return fmt.Errorf("flavor script for [%s] failed: %w", fs.regex, err)
+		}
+	}
+	return nil
+}
+
+// securityGroups returns the security groups (configured with NetworkGroups)
+// that a server needs to be in to run commands with the given req, based on
+// its Other[NetworkOtherKey] value.
+func (s *opst) securityGroups(req *Requirements) []string {
+	val, defined := req.Other[NetworkOtherKey]
+	if !defined || len(s.networkGroups) == 0 {
+		return nil
+	}
+
+	var groups []string
+	seen := make(map[string]bool)
+	for _, need := range strings.Split(val, ",") {
+		group, found := s.networkGroups[need]
+		if !found || seen[group] {
+			continue
+		}
+		seen[group] = true
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// metadata returns the metadata that servers for commands with the given req
+// must be spawned with, based on any scheduler args for "openstack" in its
+// Other. Invalid args (which should have been rejected by ValidateArgs() before
+// now) are ignored.
+func (s *opst) metadata(req *Requirements) map[string]string {
+	md, err := parseOpenStackArgs(argsFor("openstack", req))
+	if err != nil {
+		s.Warn("scheduler args ignored", "err", err)
+		return nil
+	}
+	return md
+}
+
+// volumeSize returns the size in GB of the volume that commands with the given
+// req need attached, based on its Other[VolumeOtherKey] value. 0 means no
+// volume is needed.
+func volumeSize(req *Requirements) int {
+	val, defined := req.Other[VolumeOtherKey]
+	if !defined {
+		return 0
+	}
+	size, err := strconv.Atoi(val)
+	if err != nil || size < 0 {
+		return 0
+	}
+	return size
+}
+
+// volumeUsable tells you if a command needing a volume of the given size could
+// use the given server, which is always true if no volume is needed. Volumes
+// can't be attached to our own server, and only one command with a volume runs
+// on a server at a time.
+func (s *opst) volumeUsable(server *cloud.Server, volumeGB int) bool {
+	if volumeGB == 0 {
+		return true
+	}
+	if server.Name == localhostName {
+		return false
+	}
+	s.volumeMutex.Lock()
+	defer s.volumeMutex.Unlock()
+	return !s.volumeServers[server.ID]
+}
+
+// allocate allocates req's resources on the given server, also claiming the
+// server's volume slot if a volume is needed. Returns false if either could
+// not be done.
+func (s *opst) allocate(server *cloud.Server, req *Requirements, volumeGB int) bool {
+	if volumeGB == 0 {
+		return server.Allocate(req.Cores, req.RAM, req.Disk)
+	}
+	if server.Name == localhostName {
+		return false
+	}
+
+	s.volumeMutex.Lock()
+	defer s.volumeMutex.Unlock()
+	if s.volumeServers[server.ID] || !server.Allocate(req.Cores, req.RAM, req.Disk) {
+		return false
+	}
+	s.volumeServers[server.ID] = true
+	return true
+}
+
+// releaseVolumeSlot lets the given server run another command with a volume.
+func (s *opst) releaseVolumeSlot(server *cloud.Server) {
+	s.volumeMutex.Lock()
+	defer s.volumeMutex.Unlock()
+	delete(s.volumeServers, server.ID)
+}
+
 // canCount tells you how many jobs with the given RAM and core requirements it
 // is possible to run, given remaining resources in existing servers.
 func (s *opst) canCount(cmd string, req *Requirements, call string) int {
@@ -577,6 +812,9 @@ func (s *opst) canCount(cmd string, req *Requirements, call string) int {
 		s.Warn("Failed to determine server requirements", "err", err)
 		return 0
 	}
+	securityGroups := s.securityGroups(req)
+	metadata := s.metadata(req)
+	volumeGB := volumeSize(req)
 
 	// we don't do any actual checking of current resources on the machines, but
 	// instead rely on our simple tracking based on how many cores and RAM
@@ -588,8 +826,14 @@ func (s *opst) canCount(cmd string, req *Requirements, call string) int {
 	var canCount int
 	s.serversMutex.RLock()
 	for _, server := range s.servers {
-		if !server.IsBad() && server.Matches(requestedOS, requestedScript, requestedConfigFiles, requestedFlavor, needsSharedDisk) {
+		if !server.IsBad() && server.Matches(requestedOS, requestedScript, requestedConfigFiles, requestedFlavor, needsSharedDisk) && server.HasSecurityGroups(securityGroups) && server.HasMetadata(metadata) {
 			space := server.HasSpaceFor(req.Cores, req.RAM, req.Disk)
+			if volumeGB > 0 && space > 0 {
+				if !s.volumeUsable(server, volumeGB) {
+					continue
+				}
+				space = 1
+			}
 			canCount += space
 		}
 	}
@@ -886,7 +1130,7 @@ func (s *opst) spawn(req *Requirements, flavor *cloud.Flavor, requestedOS string
 	failMsg := "server failed spawn"
 	logger.Debug("will spawn new server", "flavor", flavor.Name, "cmd", cmd)
 	tSpawn := time.Now()
-	server, err := s.provider.Spawn(requestedOS, osUser, flavor.ID, req.Disk, s.config.ServerKeepTime, false, usingQuotaCB)
+	server, err := s.provider.SpawnWithMetadata(requestedOS, osUser, flavor.ID, req.Disk, s.config.ServerKeepTime, false, s.securityGroups(req), s.metadata(req), usingQuotaCB)
 	serverID := "failed"
 	if server != nil {
 		serverID = server.ID
@@ -903,6 +1147,9 @@ func (s *opst) spawn(req *Requirements, flavor *cloud.Flavor, requestedOS string
 		err = s.actOnServerIfNeeded(server, cmd, func(ctx context.Context) error {
 			return server.WaitUntilReady(ctx, requestedConfigFiles, requestedScript)
 		})
+		if err == nil {
+			err = s.runFlavorScripts(server, cmd)
+		}
 		logger.Debug("waited for server to become ready", "took", time.Since(tReady))
 
 		if err == nil && needsSharedDisk {
@@ -1076,6 +1323,9 @@ func (s *opst) runCmd(cmd string, req *Requirements, reservedCh chan bool, call
 	if err != nil {
 		return err
 	}
+	securityGroups := s.securityGroups(req)
+	metadata := s.metadata(req)
+	volumeGB := volumeSize(req)
 
 	if s.cleanedUp() {
 		reservedCh <- false
@@ -1087,7 +1337,7 @@ func (s *opst) runCmd(cmd string, req *Requirements, reservedCh chan bool, call
 	s.serversMutex.RLock()
 	var server *cloud.Server
 	for sid, thisServer := range s.servers {
-		if !thisServer.IsBad() && thisServer.Matches(requestedOS, requestedScript, requestedConfigFiles, requestedFlavor, needsSharedDisk) && thisServer.Allocate(req.Cores, req.RAM, req.Disk) {
+		if !thisServer.IsBad() && thisServer.Matches(requestedOS, requestedScript, requestedConfigFiles, requestedFlavor, needsSharedDisk) && thisServer.HasSecurityGroups(securityGroups) && thisServer.HasMetadata(metadata) && s.allocate(thisServer, req, volumeGB) {
 			server = thisServer
 
 			// *** reservedCh is buffered and sending on it should never
@@ -1143,6 +1393,27 @@ func (s *opst) runCmd(cmd string, req *Requirements, reservedCh chan bool, call
 		}()
 		err = s.local.runCmd(cmd, req, reserved, call)
 	} else {
+		if volumeGB > 0 {
+			defer s.releaseVolumeSlot(server)
+
+			volumeID, errv := server.AttachVolume(context.Background(), volumeGB, s.config.VolumeMountPath)
+			if errv != nil {
+				logger.Warn("failed to attach volume", "size", volumeGB, "err", errv)
+				return errv
+			}
+			logger.Debug("attached volume", "volume", volumeID, "size", volumeGB)
+
+			defer func() {
+				if server.Destroyed() {
+					return
+				}
+				errd := server.DetachVolume(context.Background(), volumeID, s.config.VolumeMountPath)
+				if errd != nil {
+					logger.Warn("failed to remove volume", "volume", volumeID, "err", errd)
+				}
+			}()
+		}
+
 		if s.config.Umask > 0 {
 			cmd = fmt.Sprintf("(umask %d && %s)", s.config.Umask, cmd)
 		}
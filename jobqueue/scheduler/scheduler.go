@@ -60,12 +60,26 @@ const (
 	minimumQueueTime      time.Duration = 1 * time.Minute
 )
 
+// NetworkOtherKey is the key in Requirements.Other for a comma separated list
+// of the network access a job needs, such as "internet" or "host:port". Cloud
+// schedulers use this to put servers in suitable security groups.
+const NetworkOtherKey = "cloud_network"
+
+// VolumeOtherKey is the key in Requirements.Other for the size in GB of a block
+// volume a job needs attached to the server it runs on. Cloud schedulers
+// create, attach and mount such a volume before running the job, and delete it
+// afterwards.
+const VolumeOtherKey = "cloud_volume"
+
 // Err* constants are found in the returned Errors under err.Err, so you can
 // cast and check if it's a certain type of error.
 var (
 	ErrBadScheduler = "unknown scheduler name"
 	ErrImpossible   = "scheduler cannot accept the job, since its resource requirements are too high"
+	ErrBadQueue     = "scheduler cannot accept the job, since none of its requested queues are usable and able to meet its resource requirements"
 	ErrBadFlavor    = "unknown server flavor"
+	ErrNoBurst      = "scheduler does not support burst policies"
+	ErrNoPolicy     = "no burst policy has been set"
 )
 
 // Error records an error and the operation and scheduler that caused it.
@@ -215,9 +229,10 @@ type Scheduler struct {
 }
 
 // New creates a new Scheduler to interact with the given job scheduler.
-// Possible names so far are "lsf", "local", "openstack" and "kubernetes". You
-// must also provide a config struct appropriate for your chosen scheduler, eg.
-// for the local scheduler you will provide a ConfigLocal.
+// Possible names so far are "lsf", "local", "openstack", "kubernetes" and
+// "multi" (which routes jobs to a number of the others). You must also provide
+// a config struct appropriate for your chosen scheduler, eg. for the local
+// scheduler you will provide a ConfigLocal.
 //
 // Providing a logger allows for debug messages to be logged somewhere, along
 // with any "harmless" or unreturnable errors. If not supplied, we use a default
@@ -233,6 +248,8 @@ func New(name string, config interface{}, logger ...log15.Logger) (*Scheduler, e
 		s = &Scheduler{impl: new(opst)}
 	case "kubernetes":
 		s = &Scheduler{impl: new(k8s)}
+	case "multi":
+		s = &Scheduler{impl: new(multi)}
 	default:
 		return nil, Error{name, "New", ErrBadScheduler}
 	}
@@ -369,6 +386,38 @@ func (s *Scheduler) HostToID(host string) string {
 	return s.impl.hostToID(host)
 }
 
+// Backend returns the name of the scheduler that cmds with the given
+// Requirements will actually be scheduled with. For the "multi" scheduler this
+// is the name of the backend the Requirements route to; for all others it is
+// just our Name.
+func (s *Scheduler) Backend(req *Requirements) string {
+	if m, ok := s.impl.(*multi); ok {
+		return m.route(req)
+	}
+	return s.Name
+}
+
+// BurstStatus returns the current state of bursting, for the "multi" scheduler
+// configured with a BurstPolicy. Other schedulers return an error.
+func (s *Scheduler) BurstStatus() (*BurstStatus, error) {
+	m, ok := s.impl.(*multi)
+	if !ok {
+		return nil, Error{s.Name, "BurstStatus", ErrNoBurst}
+	}
+	return m.burstStatus()
+}
+
+// SetBurstPolicy sets or changes the BurstPolicy of the "multi" scheduler,
+// taking effect the next time cmds are Schedule()d. Other schedulers return an
+// error.
+func (s *Scheduler) SetBurstPolicy(policy *BurstPolicy) error {
+	m, ok := s.impl.(*multi)
+	if !ok {
+		return Error{s.Name, "SetBurstPolicy", ErrNoBurst}
+	}
+	return m.setBurstPolicy(policy)
+}
+
 // Cleanup means you've finished using a scheduler and it can delete any
 // remaining jobs in its system and clean up any other used resources.
 func (s *Scheduler) Cleanup() {
@@ -36,6 +36,7 @@ import (
 
 	sync "github.com/sasha-s/go-deadlock"
 
+	"github.com/VertebrateResequencing/wr/cloud"
 	"github.com/inconshreveable/log15"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -54,7 +55,7 @@ func TestLocal(t *testing.T) {
 
 	var overhead time.Duration
 	Convey("You can get a new local scheduler", t, func() {
-		s, err := New("local", &ConfigLocal{"bash", 1 * time.Second, 0, 0}, testLogger)
+		s, err := New("local", &ConfigLocal{Shell: "bash", StateUpdateFrequency: 1 * time.Second}, testLogger)
 		So(err, ShouldBeNil)
 		So(s, ShouldNotBeNil)
 
@@ -386,7 +387,7 @@ func TestLocal(t *testing.T) {
 
 	if maxCPU > 1 {
 		Convey("You can get a new local scheduler that uses less than all CPUs", t, func() {
-			s, err := New("local", &ConfigLocal{"bash", 1 * time.Second, 1, 0}, testLogger)
+			s, err := New("local", &ConfigLocal{Shell: "bash", StateUpdateFrequency: 1 * time.Second, MaxCores: 1}, testLogger)
 			So(err, ShouldBeNil)
 			So(s, ShouldNotBeNil)
 
@@ -420,9 +421,408 @@ func TestLocal(t *testing.T) {
 			So(first, ShouldHappenBefore, second.Add(-400*time.Millisecond))
 		})
 	}
+
+	Convey("The local scheduler can reserve headroom and overcommit", t, func() {
+		l := &local{
+			Logger:   testLogger,
+			maxCores: 4,
+			maxRAM:   8000,
+			config:   &ConfigLocal{ReservedCores: 1, ReservedRAM: 1000, CPUOvercommit: 2, RAMOvercommit: 1.5},
+		}
+		l.applyHeadroomAndOvercommit()
+		So(l.maxCores, ShouldEqual, 6)
+		So(l.maxRAM, ShouldEqual, 10500)
+		So(l.firstPinCore, ShouldEqual, 1)
+
+		Convey("And pins to the least used cores", func() {
+			l.coreUsage = make([]int, 3)
+			cores := l.allotCores(2)
+			So(cores, ShouldResemble, []int{1, 2})
+			cores2 := l.allotCores(2)
+			So(cores2, ShouldResemble, []int{3, 1})
+			l.releaseCores(cores)
+			So(l.coreUsage, ShouldResemble, []int{1, 0, 1})
+			So(l.allotCores(5), ShouldResemble, []int{2, 1, 3})
+		})
+
+		Convey("And pins within NUMA nodes where possible", func() {
+			l.coreUsage = make([]int, 3)
+			l.setupNUMA([]*numaNode{{id: 0, cores: []int{0, 1}}, {id: 1, cores: []int{2, 3}}})
+			So(l.nodeCores, ShouldResemble, [][]int{{0}, {1, 2}})
+			So(l.coreNode, ShouldResemble, []int{0, 1, 1})
+
+			cores := l.allotCores(2)
+			So(cores, ShouldResemble, []int{2, 3})
+			So(l.coresNode(cores), ShouldEqual, 1)
+			cores2 := l.allotCores(1)
+			So(cores2, ShouldResemble, []int{1})
+			So(l.coresNode(cores2), ShouldEqual, 0)
+			cores3 := l.allotCores(3)
+			So(cores3, ShouldResemble, []int{1, 2, 3})
+			So(l.coresNode(cores3), ShouldEqual, -1)
+
+			l.setupNUMA([]*numaNode{{id: 0, cores: []int{0, 1, 2, 3}}})
+			So(l.nodeCores, ShouldBeNil)
+			So(l.coresNode(cores), ShouldEqual, -1)
+		})
+	})
+}
+
+func TestNUMA(t *testing.T) {
+	Convey("CPU lists can be parsed", t, func() {
+		cores, err := parseCPUList("0-2,8,10-11\n")
+		So(err, ShouldBeNil)
+		So(cores, ShouldResemble, []int{0, 1, 2, 8, 10, 11})
+
+		_, err = parseCPUList("3-1")
+		So(err, ShouldNotBeNil)
+		_, err = parseCPUList("a")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("NUMA topology can be read", t, func() {
+		dir, err := ioutil.TempDir("", "wr_scheduler_test_numa_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		nodes, err := readNUMATopology(filepath.Join(dir, "missing"))
+		So(err, ShouldBeNil)
+		So(len(nodes), ShouldEqual, 0)
+
+		for node, list := range map[string]string{"node1": "4-7\n", "node0": "0-3\n"} {
+			err = os.Mkdir(filepath.Join(dir, node), 0700)
+			So(err, ShouldBeNil)
+			err = ioutil.WriteFile(filepath.Join(dir, node, "cpulist"), []byte(list), 0600)
+			So(err, ShouldBeNil)
+		}
+
+		nodes, err = readNUMATopology(dir)
+		So(err, ShouldBeNil)
+		So(len(nodes), ShouldEqual, 2)
+		So(nodes[0].id, ShouldEqual, 0)
+		So(nodes[0].cores, ShouldResemble, []int{0, 1, 2, 3})
+		So(nodes[1].id, ShouldEqual, 1)
+		So(nodes[1].cores, ShouldResemble, []int{4, 5, 6, 7})
+	})
+}
+
+func TestMulti(t *testing.T) {
+	Convey("Routing rules can be parsed", t, func() {
+		rules, err := ParseRoutingRules("openstack:ram>=64000,time>=24h; lsf:cores>=4;local")
+		So(err, ShouldBeNil)
+		So(len(rules), ShouldEqual, 3)
+		So(rules[0].Backend, ShouldEqual, "openstack")
+		So(rules[0].MinRAM, ShouldEqual, 64000)
+		So(rules[0].MinTime, ShouldEqual, 24*time.Hour)
+		So(rules[1].MinCores, ShouldEqual, 4)
+		So(rules[2].Backend, ShouldEqual, "local")
+
+		_, err = ParseRoutingRules("lsf:ram<5")
+		So(err, ShouldNotBeNil)
+		_, err = ParseRoutingRules("lsf:foo>=5")
+		So(err, ShouldNotBeNil)
+		_, err = ParseRoutingRules(":ram>=5")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("The multi scheduler routes jobs", t, func() {
+		rules, err := ParseRoutingRules("openstack:ram>=64000;lsf:time>=12h")
+		So(err, ShouldBeNil)
+		m := &multi{
+			Logger:   testLogger,
+			config:   &ConfigMulti{Rules: rules},
+			backends: map[string]*Scheduler{"local": nil, "lsf": nil, "openstack": nil},
+			order:    []string{"local", "lsf", "openstack"},
+		}
+
+		So(m.route(&Requirements{RAM: 100, Time: 1 * time.Hour}), ShouldEqual, "local")
+		So(m.route(&Requirements{RAM: 100000, Time: 24 * time.Hour}), ShouldEqual, "openstack")
+		So(m.route(&Requirements{RAM: 100, Time: 24 * time.Hour}), ShouldEqual, "lsf")
+		So(m.route(&Requirements{RAM: 100000, Other: map[string]string{MultiOtherKey: "lsf"}}), ShouldEqual, "lsf")
+		So(m.route(&Requirements{RAM: 100, Other: map[string]string{MultiOtherKey: "foo"}}), ShouldEqual, "local")
+	})
+
+	Convey("Burst policies can be parsed", t, func() {
+		p, err := ParseBurstPolicy("from=lsf,to=openstack,pending=100,after=10m,max=20,cost=0.5,cap=10")
+		So(err, ShouldBeNil)
+		So(p.From, ShouldEqual, "lsf")
+		So(p.To, ShouldEqual, "openstack")
+		So(p.Pending, ShouldEqual, 100)
+		So(p.Resume, ShouldEqual, 50)
+		So(p.After, ShouldEqual, 10*time.Minute)
+		So(p.MaxInstances, ShouldEqual, 20)
+		So(p.CostPerHour, ShouldEqual, 0.5)
+		So(p.CostCap, ShouldEqual, 10)
+
+		p2, err := ParseBurstPolicy(p.String() + ",max=30")
+		So(err, ShouldBeNil)
+		So(p2.MaxInstances, ShouldEqual, 30)
+		So(p2.Resume, ShouldEqual, 50)
+
+		_, err = ParseBurstPolicy("from=lsf")
+		So(err, ShouldNotBeNil)
+		_, err = ParseBurstPolicy("from=lsf,to=lsf")
+		So(err, ShouldNotBeNil)
+		_, err = ParseBurstPolicy("from=lsf,to=openstack,pending=10,resume=20")
+		So(err, ShouldNotBeNil)
+		_, err = ParseBurstPolicy("from=lsf,to=openstack,foo=1")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Bursting has hysteresis, instance limits and cost caps", t, func() {
+		b := newBurster(&BurstPolicy{From: "lsf", To: "openstack", Pending: 10, Resume: 5, After: 10 * time.Minute, MaxInstances: 8, CostPerHour: 1, CostCap: 10})
+		start := time.Now()
+
+		So(b.allot("a", 20, start), ShouldEqual, 0)
+		So(b.allot("a", 20, start.Add(5*time.Minute)), ShouldEqual, 0)
+		So(b.allot("a", 20, start.Add(10*time.Minute)), ShouldEqual, 8)
+		So(b.allot("b", 5, start.Add(10*time.Minute)), ShouldEqual, 0)
+		So(b.allot("a", 6, start.Add(10*time.Minute)), ShouldEqual, 6)
+		So(b.allot("b", 5, start.Add(10*time.Minute)), ShouldEqual, 2)
+
+		// still above resume, so keep bursting
+		So(b.allot("a", 2, start.Add(20*time.Minute)), ShouldEqual, 2)
+		So(b.allot("b", 3, start.Add(20*time.Minute)), ShouldEqual, 3)
+		So(b.allot("b", 3, start.Add(29*time.Minute)), ShouldEqual, 3)
+		So(b.allot("b", 3, start.Add(30*time.Minute)), ShouldEqual, 0)
+
+		status := b.status()
+		So(status.Bursting, ShouldBeFalse)
+		So(status.Pending, ShouldEqual, 5)
+		So(status.Instances, ShouldEqual, 2)
+		So(status.Cost, ShouldBeGreaterThan, 0)
+
+		Convey("And stops once the cost cap is reached", func() {
+			b.bursting = true
+			So(b.allot("a", 2, time.Now()), ShouldEqual, 2)
+			b.cost = 10
+			So(b.allot("a", 2, time.Now()), ShouldEqual, 0)
+		})
+
+		Convey("And can be disabled", func() {
+			p := *b.policy
+			p.Disabled = true
+			b.setPolicy(&p)
+			So(b.allot("a", 100, time.Now()), ShouldEqual, 0)
+			So(b.status().Bursting, ShouldBeFalse)
+		})
+	})
+
+	Convey("You can get a new multi scheduler", t, func() {
+		_, err := New("multi", &ConfigMulti{}, testLogger)
+		So(err, ShouldNotBeNil)
+
+		_, err = New("multi", &ConfigMulti{Backends: []*MultiBackend{{Name: "local", Config: &ConfigLocal{Shell: "bash"}}}, Rules: []*RoutingRule{{Backend: "lsf"}}}, testLogger)
+		So(err, ShouldNotBeNil)
+
+		s, err := New("multi", &ConfigMulti{Backends: []*MultiBackend{{Name: "local", Config: &ConfigLocal{Shell: "bash"}}}}, testLogger)
+		So(err, ShouldBeNil)
+		So(s, ShouldNotBeNil)
+		defer s.Cleanup()
+
+		req := &Requirements{RAM: 1, Time: 1 * time.Second, Cores: 1}
+		So(s.Backend(req), ShouldEqual, "local")
+		So(s.ReserveTimeout(req), ShouldEqual, 1)
+
+		_, err = s.BurstStatus()
+		So(err, ShouldNotBeNil)
+		err = s.SetBurstPolicy(&BurstPolicy{From: "local", To: "lsf"})
+		So(err, ShouldNotBeNil)
+		So(s.Busy(), ShouldBeFalse)
+
+		err = s.Schedule("sleep 0.1", req, 0, 1)
+		So(err, ShouldBeNil)
+		So(s.Busy(), ShouldBeTrue)
+		waitToFinish(s, 30, 100)
+		So(s.Busy(), ShouldBeFalse)
+	})
 }
 
 func TestLSF(t *testing.T) {
+	Convey("The lsf scheduler backs off after errors", t, func() {
+		s := &lsf{Logger: testLogger}
+		So(s.checkBackoff(), ShouldBeNil)
+		s.startBackoff()
+		So(s.backoff, ShouldEqual, lsfBackoffMin)
+		So(s.checkBackoff(), ShouldNotBeNil)
+		s.startBackoff()
+		So(s.backoff, ShouldEqual, 2*lsfBackoffMin)
+		s.resetBackoff()
+		So(s.checkBackoff(), ShouldBeNil)
+	})
+
+	Convey("The lsf scheduler batches submissions with the same requirements", t, func() {
+		dir, err := ioutil.TempDir("", "wr_schedulers_lsf_batch_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		// a fake bsub that records how it was called, and says it submitted
+		// one job per call, or one per line of a pack file
+		calls := filepath.Join(dir, "calls")
+		bsub := filepath.Join(dir, "bsub")
+		script := `#!/bin/sh
+echo "$@" >> ` + calls + `
+if [ "$1" = "-pack" ]; then
+  cat "$2" >> ` + calls + `
+  n=$(wc -l < "$2")
+  i=1
+  while [ $i -le $n ]; do echo "Job <$((100+i))> is submitted to queue <normal>."; i=$((i+1)); done
+else
+  echo "Job <1> is submitted to queue <normal>."
+fi
+`
+		err = ioutil.WriteFile(bsub, []byte(script), 0700)
+		So(err, ShouldBeNil)
+
+		s := &lsf{
+			config:             &ConfigLSF{Deployment: "testing", BatchWindow: 100 * time.Millisecond},
+			memLimitMultiplier: 1,
+			bsubExe:            bsub,
+			bsubRegex:          regexp.MustCompile(`(?m)^Job <(\d+)>`),
+			batches:            make(map[string][]*lsfSubmission),
+			Logger:             testLogger,
+		}
+		req := &Requirements{RAM: 100, Time: 1 * time.Minute, Cores: 1}
+		classArgs := s.generateBsubClassArgs("normal", req)
+		class := strings.Join(classArgs, " ")
+
+		cmds := []string{"wr runner -s 'group a'", "wr runner -s 'group b'", `echo "$HOME"`}
+		ids := make([]string, len(cmds))
+		errs := make([]error, len(cmds))
+		var wg sync.WaitGroup
+		for i, cmd := range cmds {
+			wg.Add(1)
+			go func(i int, cmd string) {
+				defer wg.Done()
+				ids[i], errs[i] = s.submitBatched(class, bsubArgsForCmd(classArgs, cmd, "testing", 1))
+			}(i, cmd)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			So(err, ShouldBeNil)
+		}
+		sort.Strings(ids)
+		So(ids, ShouldResemble, []string{"101", "102", "103"})
+
+		recorded, err := ioutil.ReadFile(calls)
+		So(err, ShouldBeNil)
+		lines := strings.Split(strings.TrimSpace(string(recorded)), "\n")
+		So(len(lines), ShouldEqual, 4)
+		So(lines[0], ShouldStartWith, "-pack ")
+		for _, line := range lines[1:] {
+			So(line, ShouldStartWith, `-q normal -M 100 -R "'select[mem>100] rusage[mem=100] span[hosts=1]'" -J wrt_`)
+		}
+		So(string(recorded), ShouldContainSubstring, ` -o /dev/null -e /dev/null "wr runner -s 'group a'"`)
+		So(string(recorded), ShouldContainSubstring, ` "echo \"\$HOME\""`)
+
+		Convey("A lone submission is made with a normal bsub", func() {
+			err = os.Remove(calls)
+			So(err, ShouldBeNil)
+			id, err := s.submitBatched(class, bsubArgsForCmd(classArgs, "mycmd", "testing", 2))
+			So(err, ShouldBeNil)
+			So(id, ShouldEqual, "1")
+			recorded, err = ioutil.ReadFile(calls)
+			So(err, ShouldBeNil)
+			So(string(recorded), ShouldNotContainSubstring, "-pack")
+			So(string(recorded), ShouldContainSubstring, "[1-2] -o /dev/null -e /dev/null mycmd")
+		})
+	})
+
+	Convey("The lsf scheduler only fails the submissions bsub -pack rejects", t, func() {
+		dir, err := ioutil.TempDir("", "wr_schedulers_lsf_partial_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		// a fake bsub that rejects pack lines with "reject" in them, or stops
+		// silently at the first of them if asked to, exiting non-zero
+		bsub := filepath.Join(dir, "bsub")
+		script := `#!/bin/sh
+i=0
+status=0
+while read -r line; do
+  i=$((i+1))
+  case "$line" in
+    *reject*)
+      status=255
+      if [ -n "$WR_TEST_BSUB_SILENT" ]; then exit $status; fi
+      echo "Bad resource requirement syntax. Job not submitted." >&2
+      ;;
+    *)
+      echo "Job <$((100+i))> is submitted to queue <normal>."
+      ;;
+  esac
+done < "$2"
+exit $status
+`
+		err = ioutil.WriteFile(bsub, []byte(script), 0700)
+		So(err, ShouldBeNil)
+
+		// a fake bkill that records what it was asked to kill
+		bkill := filepath.Join(dir, "bkill")
+		killed := filepath.Join(dir, "killed")
+		err = ioutil.WriteFile(bkill, []byte("#!/bin/sh\necho \"$@\" >> "+killed+"\n"), 0700)
+		So(err, ShouldBeNil)
+
+		s := &lsf{
+			config:             &ConfigLSF{Deployment: "testing"},
+			memLimitMultiplier: 1,
+			bsubExe:            bsub,
+			bkillExe:           bkill,
+			bsubRegex:          regexp.MustCompile(`(?m)^Job <(\d+)>`),
+			Logger:             testLogger,
+		}
+		req := &Requirements{RAM: 100, Time: 1 * time.Minute, Cores: 1}
+		classArgs := s.generateBsubClassArgs("normal", req)
+		var subs []*lsfSubmission
+		for _, cmd := range []string{"first", "reject me", "third"} {
+			subs = append(subs, &lsfSubmission{args: bsubArgsForCmd(classArgs, cmd, "testing", 1)})
+		}
+
+		Convey("Rejections reported in order are matched to their submission", func() {
+			results := s.submitPack(subs)
+			So(len(results), ShouldEqual, 3)
+			So(results[0].err, ShouldBeNil)
+			So(results[0].jobID, ShouldEqual, "101")
+			So(results[1].err, ShouldNotBeNil)
+			So(results[1].err.Error(), ShouldContainSubstring, "Job not submitted")
+			So(results[2].err, ShouldBeNil)
+			So(results[2].jobID, ShouldEqual, "103")
+			So(s.checkBackoff(), ShouldBeNil)
+			_, err = os.Stat(killed)
+			So(os.IsNotExist(err), ShouldBeTrue)
+		})
+
+		Convey("Otherwise none are matched to ids, and the accepted ones are killed", func() {
+			os.Setenv("WR_TEST_BSUB_SILENT", "1")
+			defer os.Unsetenv("WR_TEST_BSUB_SILENT")
+			results := s.submitPack(subs)
+			So(len(results), ShouldEqual, 3)
+			for _, result := range results {
+				So(result.err, ShouldNotBeNil)
+				So(result.jobID, ShouldBeBlank)
+			}
+			So(s.checkBackoff(), ShouldBeNil)
+			out, err := ioutil.ReadFile(killed)
+			So(err, ShouldBeNil)
+			So(string(out), ShouldEqual, "101\n")
+		})
+
+		Convey("Nothing being accepted backs off", func() {
+			results := s.submitPack(subs[1:2])
+			So(len(results), ShouldEqual, 1)
+			So(results[0].err, ShouldNotBeNil)
+			So(s.checkBackoff(), ShouldNotBeNil)
+		})
+	})
+
+	Convey("bsub pack file args are quoted", t, func() {
+		So(bsubPackQuote("plain"), ShouldEqual, "plain")
+		So(bsubPackQuote(""), ShouldEqual, `""`)
+		So(bsubPackQuote("a b"), ShouldEqual, `"a b"`)
+		So(bsubPackQuote("say \"hi\" to $USER\\`id`"), ShouldEqual, "\"say \\\"hi\\\" to \\$USER\\\\\\`id\\`\"")
+	})
+
 	// check if LSF seems to be installed
 	_, err := exec.LookPath("lsadmin")
 	if err == nil {
@@ -430,7 +830,7 @@ func TestLSF(t *testing.T) {
 	}
 	if err != nil {
 		Convey("You can't get a new lsf scheduler without LSF being installed", t, func() {
-			_, err = New("lsf", &ConfigLSF{"development", "bash"}, testLogger)
+			_, err = New("lsf", &ConfigLSF{Deployment: "development", Shell: "bash"}, testLogger)
 			So(err, ShouldNotBeNil)
 		})
 		return
@@ -453,7 +853,7 @@ func TestLSF(t *testing.T) {
 		log.Fatal(err)
 	}
 	Convey("You can get a new lsf scheduler", t, func() {
-		s, err := New("lsf", &ConfigLSF{"development", "bash"}, testLogger)
+		s, err := New("lsf", &ConfigLSF{Deployment: "development", Shell: "bash"}, testLogger)
 		So(err, ShouldBeNil)
 		So(s, ShouldNotBeNil)
 
@@ -503,6 +903,20 @@ func TestLSF(t *testing.T) {
 			So(queue, ShouldEqual, "yesterday")
 		})
 
+		Convey("determineQueue() picks amongst user queues if specified, and fails if none are usable", func() {
+			badOther := map[string]string{"scheduler_queue": "!nonexistent!"}
+			_, err := s.impl.(*lsf).determineQueue(&Requirements{100, 1 * time.Minute, 1, 20, badOther, true, true, true}, 0)
+			So(err, ShouldNotBeNil)
+			serr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(serr.Err, ShouldEqual, ErrBadQueue)
+
+			badOther["scheduler_queue"] = "!nonexistent!,yesterday"
+			queue, err := s.impl.(*lsf).determineQueue(&Requirements{100, 1 * time.Minute, 1, 20, badOther, true, true, true}, 0)
+			So(err, ShouldBeNil)
+			So(queue, ShouldEqual, "yesterday")
+		})
+
 		Convey("generateBsubArgs() adds in user-specified options", func() {
 			bsubArgs := s.impl.(*lsf).generateBsubArgs("yesterday", specifiedReq, "mycmd", 2)
 			So(strings.HasSuffix(bsubArgs[9], "[1-2]"), ShouldBeTrue)
@@ -523,6 +937,13 @@ func TestLSF(t *testing.T) {
 			bsubArgs = s.impl.(*lsf).generateBsubArgs("yesterday", specifiedReq, "mycmd", 2)
 			bsubArgs[7] = "random3"
 			So(bsubArgs, ShouldResemble, []string{"-q", "yesterday", "-M", "100", "-R", "'select[mem>100] rusage[mem=100] span[hosts=1]'", "-J", "random3", "-o", "/dev/null", "-e", "/dev/null", "mycmd"})
+
+			delete(specifiedOther, "scheduler_misc")
+			specifiedOther["avoid_hosts"] = "host1,host2"
+			bsubArgs = s.impl.(*lsf).generateBsubArgs("yesterday", specifiedReq, "mycmd", 1)
+			bsubArgs[7] = "random4"
+			So(bsubArgs, ShouldResemble, []string{"-q", "yesterday", "-M", "100", "-R", "'select[mem>100 && hname!=host1 && hname!=host2] rusage[mem=100] span[hosts=1]'", "-J", "random4", "-o", "/dev/null", "-e", "/dev/null", "mycmd"})
+			delete(specifiedOther, "avoid_hosts")
 		})
 
 		Convey("Busy() starts off false", func() {
@@ -634,6 +1055,124 @@ func TestLSF(t *testing.T) {
 	})
 }
 
+func TestFlavorScripts(t *testing.T) {
+	Convey("Flavor scripts only apply to matching flavors and are templated", t, func() {
+		fs, err := newFlavorScript("^bigmem", []byte("install licensed --ram {{.RAM}} --host {{.Name}} --cores {{.Cores}}"))
+		So(err, ShouldBeNil)
+
+		server := &cloud.Server{Name: "wr-prod-me-1", Flavor: &cloud.Flavor{Name: "bigmem.2", Cores: 2, RAM: 512000}}
+		script, err := fs.script(server)
+		So(err, ShouldBeNil)
+		So(string(script), ShouldEqual, "install licensed --ram 512000 --host wr-prod-me-1 --cores 2")
+
+		server.Flavor.Name = "m1.small"
+		script, err = fs.script(server)
+		So(err, ShouldBeNil)
+		So(script, ShouldBeNil)
+
+		_, err = newFlavorScript("(", []byte("true"))
+		So(err, ShouldNotBeNil)
+		_, err = newFlavorScript(".", []byte("{{.Foo"))
+		So(err, ShouldNotBeNil)
+
+		fs, err = newFlavorScript(".", []byte("{{.Foo}}"))
+		So(err, ShouldBeNil)
+		_, err = fs.script(server)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestVolumes(t *testing.T) {
+	Convey("Volume requirements are parsed and limit servers to one volume at a time", t, func() {
+		So(volumeSize(&Requirements{}), ShouldEqual, 0)
+		So(volumeSize(&Requirements{Other: map[string]string{VolumeOtherKey: "50"}}), ShouldEqual, 50)
+		So(volumeSize(&Requirements{Other: map[string]string{VolumeOtherKey: "foo"}}), ShouldEqual, 0)
+		So(volumeSize(&Requirements{Other: map[string]string{VolumeOtherKey: "-1"}}), ShouldEqual, 0)
+
+		s := &opst{volumeServers: map[string]bool{"busy": true}}
+		busy := &cloud.Server{ID: "busy", Name: "wr-busy"}
+		free := &cloud.Server{ID: "free", Name: "wr-free"}
+		local := &cloud.Server{ID: "local", Name: localhostName}
+		req := &Requirements{Cores: 1, RAM: 100}
+
+		So(s.volumeUsable(busy, 0), ShouldBeTrue)
+		So(s.volumeUsable(local, 0), ShouldBeTrue)
+		So(s.volumeUsable(busy, 10), ShouldBeFalse)
+		So(s.volumeUsable(local, 10), ShouldBeFalse)
+		So(s.volumeUsable(free, 10), ShouldBeTrue)
+
+		So(s.allocate(busy, req, 10), ShouldBeFalse)
+		So(s.allocate(local, req, 10), ShouldBeFalse)
+
+		s.releaseVolumeSlot(busy)
+		So(s.volumeUsable(busy, 10), ShouldBeTrue)
+	})
+}
+
+func TestSchedulerArgs(t *testing.T) {
+	Convey("Scheduler args are validated per scheduler", t, func() {
+		So(ValidateArgs("lsf", `-P myproject -R "select[avx]"`), ShouldBeNil)
+		So(ValidateArgs("lsf", ""), ShouldBeNil)
+		So(ValidateArgs("lsf", "-R 'foo'"), ShouldNotBeNil)
+		So(ValidateArgs("lsf", "-P myproject -q long"), ShouldNotBeNil)
+		So(ValidateArgs("lsf", "-J name"), ShouldNotBeNil)
+
+		So(ValidateArgs("openstack", "project=foo, billing=bar"), ShouldBeNil)
+		So(ValidateArgs("openstack", "project"), ShouldNotBeNil)
+		So(ValidateArgs("openstack", "=foo"), ShouldNotBeNil)
+		So(ValidateArgs("openstack", "wr_created=now"), ShouldNotBeNil)
+		So(ValidateArgs("openstack", "project="+strings.Repeat("a", maxMetadataLength+1)), ShouldNotBeNil)
+
+		So(ValidateArgs("local", "foo"), ShouldNotBeNil)
+		So(ValidateArgs("kubernetes", "foo"), ShouldNotBeNil)
+		So(ValidateArgs("local", ""), ShouldBeNil)
+		So(ValidateArgs("slurm", "--constraint=avx"), ShouldNotBeNil)
+
+		md, err := parseOpenStackArgs("project=foo, billing=a=b,")
+		So(err, ShouldBeNil)
+		So(md, ShouldResemble, map[string]string{"project": "foo", "billing": "a=b"})
+		md, err = parseOpenStackArgs("")
+		So(err, ShouldBeNil)
+		So(md, ShouldBeNil)
+	})
+
+	Convey("lsf scheduler args are added to the bsub command line", t, func() {
+		s := &lsf{config: &ConfigLSF{Deployment: "testing"}, memLimitMultiplier: 1, Logger: testLogger}
+		req := &Requirements{RAM: 100, Time: 1 * time.Minute, Cores: 1, Other: map[string]string{
+			"scheduler_misc":           "-R avx",
+			ArgsOtherKeyPrefix + "lsf": `-P myproject -R "select[gpu] rusage[ngpus=1]"`,
+		}}
+		bsubArgs := s.generateBsubArgs("normal", req, "mycmd", 1)
+		So(strings.Join(bsubArgs, " "), ShouldContainSubstring, "-R avx -P myproject -R 'select[gpu] rusage[ngpus=1]' -J ")
+
+		req.Other[ArgsOtherKeyPrefix+"lsf"] = "-q other"
+		bsubArgs = s.generateBsubArgs("normal", req, "mycmd", 1)
+		So(strings.Join(bsubArgs, " "), ShouldNotContainSubstring, "other")
+
+		delete(req.Other, ArgsOtherKeyPrefix+"lsf")
+		req.Other[ArgsOtherKeyPrefix+"openstack"] = "project=foo"
+		bsubArgs = s.generateBsubArgs("normal", req, "mycmd", 1)
+		So(strings.Join(bsubArgs, " "), ShouldNotContainSubstring, "project")
+	})
+
+	Convey("openstack scheduler args limit servers to those with the same metadata", t, func() {
+		s := &opst{Logger: testLogger}
+		So(s.metadata(&Requirements{}), ShouldBeNil)
+		req := &Requirements{Other: map[string]string{ArgsOtherKeyPrefix + "openstack": "project=foo"}}
+		md := s.metadata(req)
+		So(md, ShouldResemble, map[string]string{"project": "foo"})
+
+		plain := &cloud.Server{ID: "plain"}
+		tagged := &cloud.Server{ID: "tagged", Metadata: map[string]string{"project": "foo"}}
+		other := &cloud.Server{ID: "other", Metadata: map[string]string{"project": "bar"}}
+		So(plain.HasMetadata(nil), ShouldBeTrue)
+		So(plain.HasMetadata(md), ShouldBeFalse)
+		So(tagged.HasMetadata(md), ShouldBeTrue)
+		So(tagged.HasMetadata(nil), ShouldBeFalse)
+		So(other.HasMetadata(md), ShouldBeFalse)
+	})
+}
+
 func TestOpenstack(t *testing.T) {
 	// check if we have our special openstack-related variable
 	osPrefix := os.Getenv("OS_OS_PREFIX")
@@ -0,0 +1,64 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of job scratch directories: a unique
+// TMPDIR for every Job that Execute() runs, made within a configured root on
+// fast local storage, whose size is checked against the Job's disk requirement
+// and which is always deleted once the Job's Cmd exits, so that Cmds can't fill
+// up the /tmp of the machines they run on.
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/VertebrateResequencing/wr/internal"
+)
+
+// JobScratchDir is a directory, ideally on fast local storage, that Execute()
+// creates a unique directory within for every Job, to be its TMPDIR. If blank,
+// only Jobs with CwdMatters false get a TMPDIR, a sister of their ActualCwd. It
+// should be set before the first Job is executed.
+var JobScratchDir string
+
+// mkScratchDir creates a unique directory within root for the Job with the
+// given key. If root doesn't exist, it is created such that every user can
+// make their own directories in it, like /tmp.
+func mkScratchDir(root, key string) (string, error) {
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		if err = os.MkdirAll(root, os.ModePerm); err != nil {
+			return "", err
+		}
+		if err = os.Chmod(root, os.ModePerm|os.ModeSticky); err != nil {
+			return "", err
+		}
+	}
+	return ioutil.TempDir(root, AppName+"_"+key+".")
+}
+
+// scratchFull tells you if the scratch directory of a Job that is using
+// usedMB in it has used up its disk requirement (in GB, with 0 meaning there
+// is no requirement), or if the disk holding it has no more space left.
+func scratchFull(scratchDir string, usedMB int64, diskGB int) bool {
+	if diskGB > 0 && usedMB > int64(diskGB)*1024 {
+		return true
+	}
+	return internal.NoDiskSpaceLeft(filepath.Dir(scratchDir))
+}
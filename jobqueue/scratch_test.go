@@ -0,0 +1,65 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScratch(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Given a scratch root that doesn't exist yet", t, func() {
+		base, err := ioutil.TempDir("", "wr_jobqueue_test_scratch_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(base)
+		root := filepath.Join(base, "scratch")
+
+		Convey("mkScratchDir creates it for every user, and unique dirs within it", func() {
+			dir1, err := mkScratchDir(root, "key")
+			So(err, ShouldBeNil)
+			dir2, err := mkScratchDir(root, "key")
+			So(err, ShouldBeNil)
+			So(dir1, ShouldNotEqual, dir2)
+			So(filepath.Dir(dir1), ShouldEqual, root)
+			So(strings.HasPrefix(filepath.Base(dir1), AppName+"_key."), ShouldBeTrue)
+
+			info, err := os.Stat(root)
+			So(err, ShouldBeNil)
+			So(info.Mode()&os.ModeSticky, ShouldNotEqual, 0)
+			So(info.Mode().Perm(), ShouldEqual, os.ModePerm)
+		})
+
+		Convey("scratchFull judges usage against the disk requirement", func() {
+			dir, err := mkScratchDir(root, "key")
+			So(err, ShouldBeNil)
+			So(scratchFull(dir, 2048, 0), ShouldBeFalse)
+			So(scratchFull(dir, 1024, 1), ShouldBeFalse)
+			So(scratchFull(dir, 1025, 1), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,295 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of the server's encrypted store of
+// secrets, which jobs can refer to by name to have them set as environment
+// variables at execution time only.
+//
+// Since the server can't verify who its clients are, a job can only refer to a
+// secret if it is added by a client that presents the secret's grant, which is
+// returned only to whoever last set the secret.
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	crand "crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	sync "github.com/sasha-s/go-deadlock"
+)
+
+const (
+	secretKeyLength       = 32
+	secretKeySuffix       = ".key"
+	secretGrantLength     = 32
+	secretRedaction       = "[redacted]"
+	secretMinRedactLength = 4
+)
+
+// secretNameRegex matches valid secret names, which must be usable as
+// environment variable names.
+var secretNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// secretStore is an AES-GCM encrypted file of named secret values, along with
+// the grants needed to use them. The key is stored in a sister file readable
+// only by the user running the server.
+type secretStore struct {
+	path    string
+	gcm     cipher.AEAD
+	secrets map[string]string
+	grants  map[string]string
+	mutex   sync.RWMutex
+}
+
+// storedSecrets is what a secretStore encrypts and writes to disk.
+type storedSecrets struct {
+	Secrets map[string]string
+	Grants  map[string]string
+}
+
+// openSecretStore opens (creating if necessary) the secret store at the given
+// path, along with its key at path + ".key".
+func openSecretStore(path string) (*secretStore, error) {
+	key, err := secretKey(path + secretKeySuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := &secretStore{path: path, gcm: gcm, secrets: make(map[string]string), grants: make(map[string]string)}
+
+	encrypted, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ss, nil
+		}
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(encrypted) < nonceSize {
+		return nil, fmt.Errorf("secret store %s is corrupt", path)
+	}
+	plain, err := gcm.Open(nil, encrypted[:nonceSize], encrypted[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("secret store %s could not be decrypted: %w", path, err)
+	}
+
+	stored := &storedSecrets{}
+	if err = json.Unmarshal(plain, stored); err != nil || stored.Secrets == nil {
+		// stores written before we had grants are just the secrets; they
+		// can't be used until they're set again, which gives them a grant
+		stored.Grants = nil
+		err = json.Unmarshal(plain, &stored.Secrets)
+	}
+	if stored.Secrets != nil {
+		ss.secrets = stored.Secrets
+	}
+	if stored.Grants != nil {
+		ss.grants = stored.Grants
+	}
+	return ss, err
+}
+
+// secretKey reads the key from the given file, creating a new random key there
+// if the file doesn't exist.
+func secretKey(keyPath string) ([]byte, error) {
+	key, err := ioutil.ReadFile(keyPath)
+	if err == nil {
+		if len(key) != secretKeyLength {
+			return nil, fmt.Errorf("secret key %s is the wrong length", keyPath)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key = make([]byte, secretKeyLength)
+	if _, err = crand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, ioutil.WriteFile(keyPath, key, 0600)
+}
+
+// save encrypts and writes our secrets to disk. You must hold the lock before
+// calling this.
+func (ss *secretStore) save() error {
+	plain, err := json.Marshal(&storedSecrets{Secrets: ss.secrets, Grants: ss.grants})
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, ss.gcm.NonceSize())
+	if _, err = crand.Read(nonce); err != nil {
+		return err
+	}
+	encrypted := ss.gcm.Seal(nonce, nonce, plain, nil)
+
+	tmpPath := ss.path + ".tmp"
+	if err = ioutil.WriteFile(tmpPath, encrypted, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, ss.path)
+}
+
+// set stores the given secret, replacing any existing one with the same name,
+// and returns a new grant that must be presented to add jobs that use it. Any
+// previous grant for the secret stops working.
+func (ss *secretStore) set(name, value string) (string, error) {
+	if !secretNameRegex.MatchString(name) {
+		return "", fmt.Errorf("secret name %s is invalid; it must be usable as an environment variable name", name)
+	}
+
+	b := make([]byte, secretGrantLength)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	grant := hex.EncodeToString(b)
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	ss.secrets[name] = value
+	ss.grants[name] = grant
+	return grant, ss.save()
+}
+
+// delete removes the named secret.
+func (ss *secretStore) delete(name string) error {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	if _, exists := ss.secrets[name]; !exists {
+		return fmt.Errorf("secret %s does not exist", name)
+	}
+	delete(ss.secrets, name)
+	delete(ss.grants, name)
+	return ss.save()
+}
+
+// permit returns an error unless the given grants, keyed on secret name,
+// include the current grant of every one of the given secrets.
+func (ss *secretStore) permit(names []string, grants map[string]string) error {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+	for _, name := range names {
+		if _, exists := ss.secrets[name]; !exists {
+			return fmt.Errorf("secret %s does not exist", name)
+		}
+		grant := ss.grants[name]
+		if grant == "" || subtle.ConstantTimeCompare([]byte(grant), []byte(grants[name])) != 1 {
+			return fmt.Errorf("the grant for secret %s was not supplied; only those given it by 'wr secret set' can use it", name)
+		}
+	}
+	return nil
+}
+
+// names returns the sorted names of all our secrets.
+func (ss *secretStore) names() []string {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+	names := make([]string, 0, len(ss.secrets))
+	for name := range ss.secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// env returns the named secrets as "name=value" environment variables. Returns
+// an error if any of them don't exist.
+func (ss *secretStore) env(names []string) ([]string, error) {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+	env := make([]string, 0, len(names))
+	for _, name := range names {
+		value, exists := ss.secrets[name]
+		if !exists {
+			return nil, fmt.Errorf("secret %s does not exist", name)
+		}
+		env = append(env, name+"="+value)
+	}
+	return env, nil
+}
+
+// redactSecrets replaces any occurrences of the values of the given secret
+// environment variables in b, so that they aren't stored or displayed. Very
+// short values are not redacted, since they would match too often to be
+// worth hiding.
+func redactSecrets(b []byte, secretEnv []string) []byte {
+	for _, envvar := range secretEnv {
+		value := envvar[strings.IndexByte(envvar, '=')+1:]
+		if len(value) < secretMinRedactLength {
+			continue
+		}
+		b = bytes.ReplaceAll(b, []byte(value), []byte(secretRedaction))
+	}
+	return b
+}
+
+// permitSecrets returns an error if the given job uses secrets that the given
+// grants, which came from whoever is adding or modifying it, don't permit.
+func (s *Server) permitSecrets(job *Job, grants map[string]string) error {
+	job.RLock()
+	names := job.Secrets
+	job.RUnlock()
+	if len(names) == 0 {
+		return nil
+	}
+	if s.secrets == nil {
+		return fmt.Errorf("%s", ErrNoSecrets)
+	}
+	if err := s.secrets.permit(names, grants); err != nil {
+		return fmt.Errorf("%s: %w", ErrBadSecret, err)
+	}
+	return nil
+}
+
+// permitModifySecrets returns an error if any of the jobs with the given keys
+// use secrets that the given grants don't permit, since modifying them could
+// change what is done with their secrets.
+func (s *Server) permitModifySecrets(keys []string, grants map[string]string) error {
+	for _, key := range keys {
+		item, err := s.q.Get(key)
+		if err != nil || item == nil {
+			continue
+		}
+		if err = s.permitSecrets(item.Data().(*Job), grants); err != nil {
+			return err
+		}
+	}
+	return nil
+}
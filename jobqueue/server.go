@@ -80,6 +80,26 @@ const (
 	ErrBeingDrained     = "server is being drained"
 	ErrStopReserving    = "recovered on a new server; you should stop reserving"
 	ErrBadLimitGroup    = "colons in limit group names must be followed by integers"
+	ErrNoSecrets        = "server has no secret store configured"
+	ErrBadSecret        = "secret problem"
+	ErrBadSetting       = "invalid setting"
+	ErrBadHeartbeat     = "lost contact timeout must be greater than the heartbeat interval"
+	ErrIncompatible     = "client version is incompatible with the server; use the same version of wr for both (see wr version)"
+	ErrOverloaded       = "server is too busy; try again later"
+	ErrTooManyJobs      = "request involves too many jobs; use a limit, a narrower query or fewer jobs"
+	ErrBadBehaviour     = "invalid behaviour"
+	ErrAddTokenReused   = "add token was already used for a different batch of jobs"
+	ErrNoBehaviourSet   = "behaviour set not found"
+	ErrNoBudget         = "budget not found"
+	ErrNoStartRate      = "start rate not found"
+	ErrNoLimitFeedback  = "limit group is not in feedback mode"
+	ErrNoQueue          = "queue not found"
+	ErrBadTraceID       = "trace ids must be 32 lower-case hexadecimal characters, not all zero"
+	ErrBadQueue         = "invalid queue name"
+	ErrRequestAbandoned = "request abandoned: it took too long, or the client went away"
+	ErrBadSchedulerArgs = "invalid scheduler args"
+	ErrNotRequested     = "client middleware did not pass the request on to the server"
+	ErrBadRunAs         = "not permitted to run jobs as that user"
 	ServerModeNormal    = "started"
 	ServerModePause     = "paused"
 	ServerModeDrain     = "draining"
@@ -101,6 +121,9 @@ var (
 	ServerMaximumRunForResourceRecommendation       = 100
 	ServerMinimumScheduledForResourceRecommendation = 10
 	ServerLogClientErrors                           = true
+	ServerAffinityExpiry                            = 1 * time.Hour
+	ServerRequestCacheTime                          = 10 * time.Minute
+	ServerAddTokenTime                              = 24 * time.Hour
 )
 
 // BsubID is used to give added jobs a unique (atomically incremented) id when
@@ -121,20 +144,44 @@ func (e Error) Error() string {
 // serverResponse is the struct that the server sends to clients over the
 // network in response to their clientRequest.
 type serverResponse struct {
-	Err        string // string instead of error so we can decode on the client side
-	Added      int
-	Existed    int
-	AddedIDs   []string
-	Modified   map[string]string
-	KillCalled bool
-	Job        *Job
-	Jobs       []*Job
-	Limit      int
-	SInfo      *ServerInfo
-	SStats     *ServerStats
-	DB         []byte
-	Path       string
-	BadServers []*BadServer
+	Err           string // string instead of error so we can decode on the client side
+	Added         int
+	Existed       int
+	AddedIDs      []string
+	AddResults    []*AddResult
+	Modified      map[string]string
+	KillCalled    bool
+	Requeue       bool
+	Grace         time.Duration
+	Running       int
+	Job           *Job
+	Jobs          []*Job
+	Limit         int
+	SInfo         *ServerInfo
+	SStats        *ServerStats
+	DB            []byte
+	Path          string
+	BadServers    []*BadServer
+	Burst         *scheduler.BurstStatus
+	Secrets       []string
+	SecretGrant   string
+	Settings      map[string]string
+	BehaviourSets []*BehaviourSet
+	SGroups       []*SchedulerGroup
+	RepGroups     []string
+	Events        []*Event
+	StateCounts   []*StateCount
+	Budgets       []*Budget
+	StartRates    []*StartRate
+	LimitFeedback []*LimitFeedback
+	QueueConfigs  []*QueueConfig
+	Recycled      []*RecycledJob
+	Annotations   []*Annotation
+	Fsck          *FsckReport
+	Transfers     []*Transfer
+	TransferRate  int64
+	// TransferGranted is true if a transfer slot was granted
+	TransferGranted bool
 }
 
 // ServerInfo holds basic addressing info about the server.
@@ -143,10 +190,30 @@ type ServerInfo struct {
 	Host       string // hostname
 	Port       string // port
 	WebPort    string // port of the web interface
+	PublicPort string // port of the read-only public status page, if any
 	PID        int    // process id of server
 	Deployment string // deployment the server is running under
 	Scheduler  string // the name of the scheduler that jobs are being submitted to
 	Mode       string // ServerModeNormal if the server is running normally, or ServerModeDrain|Paused if draining or paused
+
+	// Version is the build version of the server, and Protocol is the
+	// ProtocolVersion it uses to talk to clients. Protocol is 0 for servers
+	// too old to report it.
+	Version  string
+	Protocol int
+
+	// Heartbeat is how often runners should touch the jobs they are running,
+	// or 0 if it wasn't configured, in which case they use their own
+	// ClientTouchInterval.
+	Heartbeat time.Duration
+
+	// RecycleWindow is how long deleted jobs can be restored for, or 0 if
+	// they can't be.
+	RecycleWindow time.Duration
+
+	// ArchiveFailures is true if runners should archive the working
+	// directories of jobs that fail for good, with ArchiveFailure().
+	ArchiveFailures bool
 }
 
 // ServerVersions holds the server version (git tag) and API version supported.
@@ -224,7 +291,17 @@ type Server struct {
 	sgrouptrigs        map[string]int
 	idtl               map[string]int
 	sgtr               map[string]*scheduler.Requirements
+	sgroupstats        map[string]*sgroupStats
+	affinities         *affinityTracker
+	hostLimiter        *hostLimiter
+	drainingHosts      map[string]time.Duration
+	events             chan *Event
+	eventSubs          map[chan *Event]bool
+	requests           *requestCache
+	queries            queryLimiter
+	versions           *versionChecker
 	httpServer         *http.Server
+	publicHTTPServer   *http.Server
 	statusCaster       *bcast.Group
 	badServerCaster    *bcast.Group
 	schedCaster        *bcast.Group
@@ -233,10 +310,42 @@ type Server struct {
 	wsconns            map[string]*websocket.Conn
 	badServers         map[string]*cloud.Server
 	schedIssues        map[string]*schedulerIssue
+	failureRules       FailureRules
+	runAsUsers         map[string]bool
+	redactionRules     RedactionRules
+	webOverlay         string
+	oidc               *oidcAuth
+	tracer             *tracer
+	secrets            *secretStore
+	copyDir            string
+	transfers          *transferSlots
+	transferRate       int64
+	failArchive        failArchiveStore
+	budgets            map[string]*Budget
+	startRates         map[string]*startRateState
+	limitFeedbacks     map[string]*limitFeedbackState
+	queueConfigs       map[string]*QueueConfig
+	portCtxs           *portContexts
+	requestTimeout     int64 // a time.Duration, accessed atomically
+	heartbeat          time.Duration
+	itemTTR            time.Duration
+	lostRequeue        time.Duration
+	recycleWindow      time.Duration
+	retryDelay         time.Duration
+	maxRunnersPerGroup int
+	logFilter          *LevelFilter
 	racmutex           sync.RWMutex // to protect the readyaddedcallback
 	bsmutex            sync.RWMutex
 	simutex            sync.RWMutex
 	krmutex            sync.RWMutex
+	dhmutex            sync.RWMutex // to protect drainingHosts
+	blmutex            sync.RWMutex // to protect behaviour set versioning
+	bgmutex            sync.RWMutex // to protect budgets
+	srmutex            sync.Mutex   // to protect startRates
+	lfmutex            sync.RWMutex // to protect limitFeedbacks
+	qcmutex            sync.RWMutex // to protect queueConfigs
+	esmutex            sync.RWMutex // to protect eventSubs
+	stmutex            sync.RWMutex // to protect retryDelay, maxRunnersPerGroup and logFilter
 	ssmutex            sync.RWMutex // "server state mutex" to protect up, drain, blocking and ServerInfo.Mode
 	rpmutex            sync.Mutex   // to protect racPending, racRunning and waitingReserves
 	sync.Mutex
@@ -261,6 +370,11 @@ type ServerConfig struct {
 	// Port for the web interface.
 	WebPort string
 
+	// Port for a read-only variant of the web interface's status page, that
+	// anyone can view without authorization, but that doesn't let them change
+	// anything. The default of empty string means there is no public page.
+	PublicWebPort string
+
 	// Name of the desired scheduler (eg. "local" or "lsf" or "openstack") that
 	// jobs will be submitted to.
 	SchedulerName string
@@ -354,6 +468,28 @@ type ServerConfig struct {
 	// uploaded. Defaults to /tmp.
 	UploadDir string
 
+	// CopyDir is the directory where files copied to the Server by the
+	// CopyToManager behaviour will be stored, in sub-directories named after
+	// the keys of their jobs. Defaults to a "copies" directory in UploadDir.
+	CopyDir string
+
+	// TransferSlots is the maximum number of CopyToManager behaviours that may
+	// copy files to the Server at once; others queue until a slot is free. The
+	// default of 0 means unlimited.
+	TransferSlots int
+
+	// TransferRate is the maximum number of bytes per second that each
+	// CopyToManager behaviour may copy to the Server. The default of 0 means
+	// unlimited.
+	TransferRate int64
+
+	// FailureArchive is where the unique working directories of jobs that fail
+	// for good (their ActualCwd) are archived, before any of their behaviours
+	// can clean them up. It can be a local directory, or an S3 url like
+	// s3://[profile@]bucket/path. The default of blank means they are not
+	// archived.
+	FailureArchive string
+
 	// Logger is a logger object that will be used to log uncaught errors and
 	// debug statements. "Uncought" errors are all errors generated during
 	// operation that either shouldn't affect the success of operations, and can
@@ -370,6 +506,99 @@ type ServerConfig struct {
 	// If this is unset, nothing is logged (defaults to a logger using a
 	// log15.DiscardHandler()).
 	Logger log15.Logger
+
+	// LogLevelFilter, if set, should be the handler (or wrap the handler) used
+	// by Logger, and lets the log_level setting change what gets logged while
+	// we're running. Without it, the log_level setting can't be changed.
+	LogLevelFilter *LevelFilter
+
+	// FailureRules let you classify the failures of jobs that exited, based on
+	// their exit code, FailReason and STDERR, and decide what to do about
+	// them. Matching jobs will have their FailReason set to the Name of the
+	// first matching rule, and be retried or buried according to its Action.
+	// Validate() must have been called on these. The default of no rules
+	// means failed jobs are retried according to their own Retries.
+	FailureRules FailureRules
+
+	// RunAsUsers are the users that any client may have jobs run as, via the
+	// jobs' RunAs. Jobs can't be run as any other user (since the server can't
+	// verify who added them), and root is never allowed, even if listed here.
+	RunAsUsers []string
+
+	// RedactionRules are applied to the STDOUT and STDERR of jobs, and to the
+	// output of their behaviours, before they are stored in the database or
+	// returned to clients, so that eg. tokens printed by commands aren't
+	// exposed on the status page. The default of no rules means no redaction.
+	RedactionRules RedactionRules
+
+	// Absolute path to a directory of files that override or add to the files
+	// of the status web interface. A request for eg. /js/custom.js is served
+	// from js/custom.js in this directory if it exists there. The default of
+	// empty string means only the built-in files are served.
+	WebOverlayDir string
+
+	// OIDC, if set with an Issuer, lets users log in to the web interface with
+	// an OpenID Connect provider, with what they can do there depending on
+	// their role. The default is that only those with the token can use the
+	// web interface, and they can do anything.
+	OIDC *OIDCConfig
+
+	// Absolute path to where the server will store the secrets that jobs can
+	// refer to, encrypted with a key that will be stored alongside with a
+	// ".key" suffix, readable only by the user starting the server. Secrets are
+	// never stored in the database. The default of empty string means secrets
+	// can't be used.
+	SecretsFile string
+
+	// HeartbeatInterval is how often runner clients should touch the jobs they
+	// are running, to let the server know they are still alive. Clients learn
+	// this from ServerInfo. Defaults to ClientTouchInterval.
+	HeartbeatInterval time.Duration
+
+	// LostContactTimeout is how long the server waits for a touch before
+	// considering a running job to be lost. Defaults to ServerItemTTR. If
+	// either this or HeartbeatInterval are set, it must end up greater than
+	// HeartbeatInterval.
+	LostContactTimeout time.Duration
+
+	// LostRequeueGrace is how long a job must remain lost before the server
+	// confirms it dead on your behalf, releasing it so that it gets retried (or
+	// buried if it has no retries left). The default of 0 time means lost jobs
+	// remain lost until you confirm them dead or their runner regains contact.
+	LostRequeueGrace time.Duration
+
+	// RecycleWindow is how long jobs that are deleted (eg. by "wr remove" or
+	// the web interface) are kept, so that they can be restored with
+	// Client.Undelete() if they were deleted by mistake. The default of 0 time
+	// means deleted jobs are gone immediately.
+	RecycleWindow time.Duration
+
+	// CheckConsistency makes the server check that its database and queue
+	// are consistent, as Client.Fsck() does without repairing anything, after
+	// it recovers jobs on start up, logging a warning if they are not. The
+	// default of false skips the check, since it reads every incomplete job in
+	// the database.
+	CheckConsistency bool
+
+	// RequestTimeout is the most time the server will spend on a single
+	// request from a client or the REST API, eg. getting the details of a
+	// large RepGroup. Requests that take longer are abandoned, and the client
+	// gets ErrRequestAbandoned. Requests to reserve a job are not subject to
+	// this, since clients say how long to wait for one. Work on a request also
+	// stops early if its client disconnects. The default of 0 means no limit.
+	RequestTimeout time.Duration
+
+	// TraceEndpoint is the URL of an OpenTelemetry collector's OTLP/HTTP
+	// traces endpoint (eg. http://localhost:4318/v1/traces for Jaeger), that
+	// the server will send spans to, recording what happens to Jobs from being
+	// added to completing (see Job.TraceID). The default of empty string means
+	// no traces are recorded.
+	TraceEndpoint string
+
+	// Notifications, if it has any Rules, makes the server send notifications
+	// (eg. to Slack) when the events it records match those rules. The
+	// default is that no notifications are sent.
+	Notifications *NotifyConfig
 }
 
 // Serve is for use by a server executable and makes it start listening on
@@ -414,6 +643,18 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 	}
 	defer internal.LogPanic(serverLogger, "jobqueue serve", true)
 
+	heartbeat := config.HeartbeatInterval
+	if heartbeat <= 0 {
+		heartbeat = ClientTouchInterval
+	}
+	itemTTR := config.LostContactTimeout
+	if itemTTR <= 0 {
+		itemTTR = ServerItemTTR
+	}
+	if (config.HeartbeatInterval > 0 || config.LostContactTimeout > 0) && itemTTR <= heartbeat {
+		return s, msg, token, Error{"Serve", "", ErrBadHeartbeat}
+	}
+
 	// generate a secure token for clients to authenticate with
 	token, err = generateToken(config.TokenFile)
 	if err != nil {
@@ -421,7 +662,7 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 	}
 
 	// check if the cert files are available
-	httpAddr := "0.0.0.0:" + config.WebPort
+	httpAddr := ":" + config.WebPort
 	caFile := config.CAFile
 	certFile := config.CertFile
 	keyFile := config.KeyFile
@@ -510,7 +751,7 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 		tlsConfig.RootCAs = certPool
 	}
 	listenOpts[mangos.OptionTLSConfig] = tlsConfig
-	if err = sock.ListenOptions("tls+tcp://0.0.0.0:"+config.Port, listenOpts); err != nil {
+	if err = sock.ListenOptions("tls+tcp://:"+config.Port, listenOpts); err != nil {
 		return s, msg, token, err
 	}
 
@@ -553,15 +794,39 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 	if uploadDir == "" {
 		uploadDir = "/tmp"
 	}
+	copyDir := config.CopyDir
+	if copyDir == "" {
+		copyDir = filepath.Join(uploadDir, "copies")
+	}
 
 	// our limiter will use a callback that gets group limits from our database
 	l := limiter.New(db.retrieveLimitGroup)
 
+	var secrets *secretStore
+	if config.SecretsFile != "" {
+		secrets, err = openSecretStore(config.SecretsFile)
+		if err != nil {
+			return s, msg, token, err
+		}
+	}
+
+	var failArchive failArchiveStore
+	if config.FailureArchive != "" {
+		failArchive, err = newFailArchiveStore(config.FailureArchive)
+		if err != nil {
+			return s, msg, token, err
+		}
+	}
+
 	s = &Server{
-		ServerInfo:         &ServerInfo{Addr: ip + ":" + config.Port, Host: certDomain, Port: config.Port, WebPort: config.WebPort, PID: os.Getpid(), Deployment: config.Deployment, Scheduler: config.SchedulerName, Mode: ServerModeNormal},
+		ServerInfo:         &ServerInfo{Addr: net.JoinHostPort(ip, config.Port), Host: certDomain, Port: config.Port, WebPort: config.WebPort, PublicPort: config.PublicWebPort, PID: os.Getpid(), Deployment: config.Deployment, Scheduler: config.SchedulerName, Mode: ServerModeNormal, Version: ServerVersion, Protocol: ProtocolVersion, Heartbeat: config.HeartbeatInterval, RecycleWindow: config.RecycleWindow, ArchiveFailures: failArchive != nil},
 		ServerVersions:     &ServerVersions{Version: ServerVersion, API: restAPIVersion},
 		token:              token,
 		uploadDir:          uploadDir,
+		copyDir:            copyDir,
+		transfers:          newTransferSlots(config.TransferSlots),
+		transferRate:       config.TransferRate,
+		failArchive:        failArchive,
 		sock:               sock,
 		ch:                 new(codec.BincHandle),
 		rpl:                &rgToKeys{lookup: make(map[string]map[string]bool)},
@@ -578,6 +843,19 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 		sgrouptrigs:        make(map[string]int),
 		idtl:               make(map[string]int),
 		sgtr:               make(map[string]*scheduler.Requirements),
+		sgroupstats:        make(map[string]*sgroupStats),
+		affinities:         newAffinityTracker(ServerAffinityExpiry),
+		hostLimiter:        newHostLimiter(db.retrieveHostLimit),
+		drainingHosts:      make(map[string]time.Duration),
+		events:             make(chan *Event, ServerEventBuffer),
+		eventSubs:          make(map[chan *Event]bool),
+		budgets:            make(map[string]*Budget),
+		startRates:         make(map[string]*startRateState),
+		limitFeedbacks:     make(map[string]*limitFeedbackState),
+		queueConfigs:       make(map[string]*QueueConfig),
+		requests:           newRequestCache(ServerRequestCacheTime),
+		queries:            newQueryLimiter(ServerMaxConcurrentQueries),
+		versions:           newVersionChecker(ServerVersionWarnTime),
 		rc:                 config.RunnerCmd,
 		wsconns:            make(map[string]*websocket.Conn),
 		statusCaster:       bcast.NewGroup(),
@@ -585,9 +863,63 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 		badServers:         make(map[string]*cloud.Server),
 		schedCaster:        bcast.NewGroup(),
 		schedIssues:        make(map[string]*schedulerIssue),
+		failureRules:       config.FailureRules,
+		runAsUsers:         make(map[string]bool, len(config.RunAsUsers)),
+		redactionRules:     config.RedactionRules,
+		webOverlay:         config.WebOverlayDir,
+		secrets:            secrets,
+		heartbeat:          heartbeat,
+		itemTTR:            itemTTR,
+		lostRequeue:        config.LostRequeueGrace,
+		recycleWindow:      config.RecycleWindow,
+		portCtxs:           newPortContexts(),
+		tracer:             newTracer(config.TraceEndpoint, config.Deployment, certDomain, serverLogger),
+		requestTimeout:     int64(config.RequestTimeout),
+		retryDelay:         ClientReleaseDelay,
+		logFilter:          config.LogLevelFilter,
 		Logger:             serverLogger,
 	}
 
+	for _, user := range config.RunAsUsers {
+		s.runAsUsers[user] = true
+	}
+
+	// stop working on requests of clients that disconnect
+	sock.SetPortHook(s.portCtxs.portHook)
+
+	// apply any settings changed while we were previously running
+	s.restoreSettings()
+	s.restoreBudgets()
+	s.restoreStartRates()
+	s.restoreLimitFeedbacks()
+	s.restoreQueueConfigs()
+
+	if config.OIDC != nil && config.OIDC.Issuer != "" {
+		s.oidc = newOIDCAuth(config.OIDC)
+	}
+
+	// tell people about events they want to know about
+	if config.Notifications != nil && len(config.Notifications.Rules) > 0 {
+		n := newNotifier(config.Notifications, fmt.Sprintf("wr (%s on %s): ", config.Deployment, certDomain), serverLogger)
+		events, unsubscribe := s.subscribeEvents()
+		go func() {
+			defer unsubscribe()
+			n.run(events, stopClientHandling)
+		}()
+	}
+
+	// send the spans of traced jobs to the collector
+	if s.tracer != nil {
+		go s.tracer.run(stopClientHandling)
+	}
+
+	// store events as they happen
+	wgke := s.wg.Add(1)
+	go func() {
+		defer s.wg.Done(wgke)
+		s.storeEvents()
+	}()
+
 	// if we're restarting from a state where there were incomplete jobs, we
 	// need to load those in to our queue now
 	s.createQueue()
@@ -615,7 +947,7 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 				return nil, msg, token, err
 			}
 
-			itemdef := &queue.ItemDef{Key: job.Key(), ReserveGroup: job.getSchedulerGroup(), Data: job, Priority: job.Priority, Delay: 0 * time.Second, TTR: ServerItemTTR, Dependencies: deps}
+			itemdef := s.jobItemDef(job, deps)
 
 			switch job.State {
 			case JobStateRunning:
@@ -640,12 +972,27 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 
 			itemdefs = append(itemdefs, itemdef)
 		}
+		s.addAtomicGroupDependencies(itemdefs)
 		_, _, err = s.enqueueItems(itemdefs)
 		if err != nil {
 			return nil, msg, token, err
 		}
+
+		// a previous unclean shutdown may have left inconsistencies that
+		// recovery can't resolve on its own
+		if config.CheckConsistency {
+			wgkc := s.wg.Add(1)
+			go func() {
+				defer s.wg.Done(wgkc)
+				s.checkConsistency()
+			}()
+		}
 	}
 
+	// note our starting state counts, so that past state counts can be
+	// reconstructed without reference to what came before this restart
+	s.recordStateSnapshot()
+
 	// wait for signal or s.Stop() and call s.shutdown(). (We don't use the
 	// waitgroup here since we call shutdown, which waits on the group)
 	go func() {
@@ -672,6 +1019,23 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 		}
 	}()
 
+	// listen on the web port now, so that the web interface is available as
+	// soon as we return
+	webListener, err := net.Listen("tcp", httpAddr)
+	if err != nil {
+		return s, msg, token, err
+	}
+	var publicListener net.Listener
+	if config.PublicWebPort != "" {
+		publicListener, err = net.Listen("tcp", ":"+config.PublicWebPort)
+		if err != nil {
+			if errc := webListener.Close(); errc != nil {
+				s.Warn("failed to close web interface listener", "err", errc)
+			}
+			return s, msg, token, err
+		}
+	}
+
 	// set up the web interface
 	ready := make(chan bool)
 	wgk := wg.Add(1)
@@ -681,25 +1045,32 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 		defer wg.Done(wgk)
 
 		mux := http.NewServeMux()
-		mux.HandleFunc("/", webInterfaceStatic(s))
-		mux.HandleFunc("/status_ws", webInterfaceStatusWS(s))
+		mux.HandleFunc("/", webInterfaceStatic(s, false))
+		mux.HandleFunc("/status_ws", webInterfaceStatusWS(s, false))
+		mux.HandleFunc(oidcCallbackEndpoint, webOIDCCallback(s))
 		mux.HandleFunc(restJobsEndpoint, restJobs(s))
 		mux.HandleFunc(restWarningsEndpoint, restWarnings(s))
 		mux.HandleFunc(restBadServersEndpoint, restBadServers(s))
 		mux.HandleFunc(restFileUploadEndpoint, restFileUpload(s))
 		mux.HandleFunc(restInfoEndpoint, restInfo(s))
+		mux.HandleFunc(restExportEndpoint, restExport(s))
+		mux.HandleFunc(restCopyEndpoint, restCopy(s))
+		mux.HandleFunc(restArchiveEndpoint, restFailArchive(s))
 		mux.HandleFunc(restVersionEndpoint, restVersion(s))
 		srv := &http.Server{Addr: httpAddr, Handler: mux}
 		wgk2 := wg.Add(1)
 		go func() {
 			defer internal.LogPanic(s.Logger, "jobqueue web server listenAndServe", true)
 			defer wg.Done(wgk2)
-			errs := srv.ListenAndServeTLS(certFile, keyFile)
+			errs := srv.ServeTLS(webListener, certFile, keyFile)
 			if errs != nil && errs != http.ErrServerClosed {
 				s.Error("server web interface had problems", "err", errs)
 			}
 		}()
 		s.httpServer = srv
+		if publicListener != nil {
+			s.publicHTTPServer = s.servePublicWeb(publicListener, certFile, keyFile)
+		}
 
 		wgk3 := wg.Add(1)
 		go func() {
@@ -798,11 +1169,10 @@ func Serve(config ServerConfig) (s *Server, msg string, token []byte, err error)
 			}
 			s.simutex.Unlock()
 			s.schedCaster.Send(si)
+			s.recordEvent(&Event{Type: EventTypeSchedulerError, Msg: msg})
 		}
 		s.scheduler.SetMessageCallBack(messageCB)
 
-		// wait a while for ListenAndServe() to start listening
-		<-time.After(10 * time.Millisecond)
 		ready <- true
 	}()
 	<-ready
@@ -1159,6 +1529,10 @@ func (s *Server) createQueue() {
 		for _, inter := range allitemdata {
 			job := inter.(*Job)
 
+			if s.inputsMissing(job) {
+				continue
+			}
+
 			// depending on job.Override, get memory, disk and time
 			// recommendations, which are rounded to get fewer larger
 			// groups
@@ -1190,7 +1564,7 @@ func (s *Server) createQueue() {
 				}
 			}
 
-			if recommendedReq != nil || job.FailReason == FailReasonRAM || job.FailReason == FailReasonDisk || job.FailReason == FailReasonTime {
+			if recommendedReq != nil || job.FailReason == FailReasonRAM || job.FailReason == FailReasonDisk || job.FailReason == FailReasonTime || job.escalateReqs {
 				job.Lock()
 				if job.RequirementsOrig == nil {
 					job.RequirementsOrig = &scheduler.Requirements{
@@ -1201,7 +1575,7 @@ func (s *Server) createQueue() {
 					}
 				}
 
-				if recommendedReq.RAM > 0 {
+				if recommendedReq != nil && recommendedReq.RAM > 0 {
 					if job.RequirementsOrig.RAM > 0 {
 						switch job.Override {
 						case 0:
@@ -1216,7 +1590,7 @@ func (s *Server) createQueue() {
 					}
 				}
 
-				if recommendedReq.Disk > 0 {
+				if recommendedReq != nil && recommendedReq.Disk > 0 {
 					if job.RequirementsOrig.Disk > 0 || job.RequirementsOrig.DiskSet {
 						switch job.Override {
 						case 0:
@@ -1231,7 +1605,7 @@ func (s *Server) createQueue() {
 					}
 				}
 
-				if recommendedReq.Time.Seconds() > 0 {
+				if recommendedReq != nil && recommendedReq.Time.Seconds() > 0 {
 					if job.RequirementsOrig.Time > 0 {
 						switch job.Override {
 						case 0:
@@ -1248,23 +1622,7 @@ func (s *Server) createQueue() {
 
 				switch job.FailReason {
 				case FailReasonRAM:
-					// increase by 1GB or [100% if under 8GB, 30% if over],
-					// whichever is greater, and round up to nearest 100 ***
-					// increase to greater than max seen for jobs in our
-					// ReqGroup?
-					updatedMB := float64(job.PeakRAM)
-					if updatedMB <= RAMIncreaseMultBreakpoint {
-						updatedMB *= RAMIncreaseMultLow
-					} else {
-						updatedMB *= RAMIncreaseMultHigh
-					}
-					if updatedMB < float64(job.PeakRAM)+RAMIncreaseMin {
-						updatedMB = float64(job.PeakRAM) + RAMIncreaseMin
-					}
-					newRAM := int(math.Ceil(updatedMB/100) * 100)
-					if newRAM > job.Requirements.RAM {
-						job.Requirements.RAM = newRAM
-					}
+					job.increaseRAMReq()
 				case FailReasonDisk:
 					// flat increase of 30%
 					updatedMB := float64(job.PeakDisk) / float64(1024)
@@ -1274,11 +1632,16 @@ func (s *Server) createQueue() {
 						job.Requirements.Disk = newDisk
 					}
 				case FailReasonTime:
-					// flat increase of 1 hour
-					newTime := job.EndTime.Sub(job.StartTime) + (1 * time.Hour)
-					if newTime > job.Requirements.Time {
-						job.Requirements.Time = newTime
-					}
+					job.increaseTimeReq()
+				}
+
+				if job.escalateReqs {
+					// a FailureRule wants us to try again with more
+					// resources; we only do this once per failure, so that
+					// being buried and kicked again doesn't escalate again
+					job.increaseRAMReq()
+					job.increaseTimeReq()
+					job.escalateReqs = false
 				}
 
 				job.Unlock()
@@ -1287,6 +1650,7 @@ func (s *Server) createQueue() {
 			}
 
 			req := reqForScheduler(job.Requirements)
+			job.setScheduler(s.scheduler.Backend(req))
 
 			prevSchedGroup := job.getSchedulerGroup()
 			schedulerGroup := job.generateSchedulerGroup(req)
@@ -1418,6 +1782,7 @@ func (s *Server) createQueue() {
 					s.Debug("rac scheduling no jobs", "group", group, "ready", count, "previously", s.sgroupcounts[group], "scheduled", groupsScheduledCounts[group], "todo", countIncRunning)
 				}
 				s.sgroupcounts[group] = countIncRunning
+				s.noteSchedulerGroupScheduled(group)
 
 				// if we got no resource requirement recommendations for
 				// this group, we'll set up a retrigger of this ready
@@ -1521,39 +1886,66 @@ func (s *Server) createQueue() {
 		// calculate counts per RepGroup
 		groups := make(map[string]int)
 		groupsLost := make(map[string]int)
-		lost := 0
+		groupsPhased := make(map[JobState]map[string]int)
+		lost, phased := 0, 0
 		for _, inter := range data {
 			job := inter.(*Job)
 
 			// if we change from running, mark that we have not scheduled a
-			// runner for the job
+			// runner for the job, and that it's no longer on its host
 			if from == JobStateRunning {
 				job.setScheduledRunner(false)
+				s.forgetStoppedJob(job)
 
-				job.RLock()
+				job.Lock()
 				l := job.Lost
-				job.RUnlock()
+				phase := job.phase
+				job.phase = ""
+				job.Unlock()
 				if l {
 					lost++
 					groupsLost[job.RepGroup]++
 					continue
 				}
+				if phase != "" {
+					phased++
+					if _, exists := groupsPhased[phase]; !exists {
+						groupsPhased[phase] = make(map[string]int)
+					}
+					groupsPhased[phase][job.RepGroup]++
+					continue
+				}
 			}
 
 			groups[job.RepGroup]++
 		}
 
+		s.noteBudgetTransitions(from, to, data)
+		s.traceTransitions(from, to, data)
+
 		// send out the counts
-		s.statusCaster.Send(&jstateCount{"+all+", from, to, len(data) - lost})
+		s.statusCaster.Send(&jstateCount{"+all+", from, to, len(data) - lost - phased})
 		for group, count := range groups {
 			s.statusCaster.Send(&jstateCount{group, from, to, count})
+			s.recordStateChange(group, from, to, count)
 		}
 
 		if lost > 0 {
 			s.statusCaster.Send(&jstateCount{"+all+", JobStateLost, to, lost})
 			for group, count := range groupsLost {
 				s.statusCaster.Send(&jstateCount{group, JobStateLost, to, count})
+				s.recordStateChange(group, JobStateLost, to, count)
+			}
+		}
+
+		for phase, pgroups := range groupsPhased {
+			all := 0
+			for group, count := range pgroups {
+				all += count
+				s.statusCaster.Send(&jstateCount{group, phase, to, count})
+				s.recordStateChange(group, phase, to, count)
 			}
+			s.statusCaster.Send(&jstateCount{"+all+", phase, to, all})
 		}
 	})
 
@@ -1589,10 +1981,16 @@ func (s *Server) createQueue() {
 				}()
 			}
 
+			if s.lostRequeue > 0 && !job.killCalled {
+				defer s.requeueLostJob(job.Key(), job.EndTime)
+			}
+
 			// since our changed callback won't be called, send out this
-			// transition from running to lost state
-			defer s.statusCaster.Send(&jstateCount{"+all+", JobStateRunning, JobStateLost, 1})
-			defer s.statusCaster.Send(&jstateCount{job.RepGroup, JobStateRunning, JobStateLost, 1})
+			// transition from running (or staging/uploading) to lost state
+			from := job.runningState()
+			defer s.statusCaster.Send(&jstateCount{"+all+", from, JobStateLost, 1})
+			defer s.statusCaster.Send(&jstateCount{job.RepGroup, from, JobStateLost, 1})
+			defer s.recordStateChange(job.RepGroup, from, JobStateLost, 1)
 
 			job.Unlock()
 			return queue.SubQueueRun
@@ -1604,6 +2002,63 @@ func (s *Server) createQueue() {
 	})
 }
 
+// setJobPhase notes that the runner of the given reserved job has started
+// (phase JobStateStaging or JobStateUploading) or finished (blank phase) moving
+// data for it, sending out the resulting state transition.
+func (s *Server) setJobPhase(job *Job, phase JobState) {
+	job.Lock()
+	from := job.runningState()
+	job.phase = phase
+	to := job.runningState()
+	if from != to {
+		s.traceJobPhase(job, from)
+	}
+	lost := job.Lost
+	rg := job.RepGroup
+	job.Unlock()
+
+	if from == to || lost {
+		return
+	}
+	s.statusCaster.Send(&jstateCount{"+all+", from, to, 1})
+	s.statusCaster.Send(&jstateCount{rg, from, to, 1})
+	s.recordStateChange(rg, from, to, 1)
+}
+
+// requeueLostJob waits for our lostRequeue grace period and then, if the job
+// with the given key is still lost since lostAt, confirms it dead so that it
+// gets released.
+func (s *Server) requeueLostJob(key string, lostAt time.Time) {
+	go func() {
+		defer internal.LogPanic(s.Logger, "jobqueue lost job requeue", true)
+
+		select {
+		case <-time.After(s.lostRequeue):
+		case <-s.stopClientHandling:
+			return
+		}
+
+		item, err := s.q.Get(key)
+		if err != nil || item == nil {
+			return
+		}
+		job := item.Data().(*Job)
+		job.RLock()
+		stillLost := job.Lost && job.EndTime.Equal(lostAt)
+		job.RUnlock()
+		if !stillLost {
+			return
+		}
+
+		_, err = s.killJob(key)
+		if err != nil {
+			s.Warn("failed to requeue lost job", "key", key, "err", err)
+			return
+		}
+		s.Debug("requeued job that remained lost", "key", key, "grace", s.lostRequeue)
+	}()
+}
+
 // enqueueItems adds new items to a queue, for when we have new jobs to handle.
 func (s *Server) enqueueItems(itemdefs []*queue.ItemDef) (added, dups int, err error) {
 	s.rpmutex.Lock()
@@ -1631,20 +2086,90 @@ func (s *Server) enqueueItems(itemdefs []*queue.ItemDef) (added, dups int, err e
 	return added, dups, err
 }
 
+// jobItemDef creates the definition of the queue item for the given job, which
+// depends on the given keys. The item joins the dependency groups of the job's
+// RepGroup and any AtomicGroup, and depends on those of the job's RepGroup
+// based Dependencies.
+func (s *Server) jobItemDef(job *Job, deps []string) *queue.ItemDef {
+	depGroups := []string{repGroupDepGroup(job.RepGroup)}
+	if job.AtomicGroup != "" {
+		depGroups = append(depGroups, atomicGroupDepGroup(job.AtomicGroup))
+	}
+	return &queue.ItemDef{
+		Key:               job.Key(),
+		ReserveGroup:      job.getSchedulerGroup(),
+		Data:              job,
+		Priority:          job.Priority,
+		Delay:             0 * time.Second,
+		TTR:               s.itemTTR,
+		Dependencies:      deps,
+		DepGroups:         depGroups,
+		GroupDependencies: job.Dependencies.repGroupDepGroups(),
+	}
+}
+
+// validateSchedulerArgs checks any scheduler args in the given req's Other are
+// suitable for the scheduler they're for.
+func validateSchedulerArgs(req *scheduler.Requirements) error {
+	if req == nil {
+		return nil
+	}
+	for key, val := range req.Other {
+		if name := strings.TrimPrefix(key, scheduler.ArgsOtherKeyPrefix); name != key {
+			if err := scheduler.ValidateArgs(name, val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // createJobs creates new jobs, adding them to the database and the in-memory
-// queue. It returns 2 errors; the first is one of our Err constant strings,
-// the second is the actual error with more details.
-func (s *Server) createJobs(inputJobs []*Job, envkey string, ignoreComplete bool) (added, dups, alreadyComplete int, srerr string, qerr error) {
+// queue. user is who is adding them, which limits who they can be run as. It
+// returns 2 errors; the first is one of our Err constant strings, the second
+// is the actual error with more details.
+func (s *Server) createJobs(inputJobs []*Job, envkey string, ignoreComplete bool, user string) (added, dups, alreadyComplete int, srerr string, qerr error) {
+	for _, job := range inputJobs {
+		if err := job.Behaviours.Validate(); err != nil {
+			return added, dups, alreadyComplete, ErrBadBehaviour, fmt.Errorf("job [%s]: %w", job.Cmd, err)
+		}
+		if job.Queue != "" {
+			if err := validateQueueName(job.Queue); err != nil {
+				return added, dups, alreadyComplete, ErrBadQueue, err
+			}
+		}
+		if job.TraceID != "" && !ValidTraceID(job.TraceID) {
+			return added, dups, alreadyComplete, ErrBadTraceID, fmt.Errorf("job [%s]: trace id %s", job.Cmd, job.TraceID)
+		}
+		if err := validateSchedulerArgs(job.Requirements); err != nil {
+			return added, dups, alreadyComplete, ErrBadSchedulerArgs, fmt.Errorf("job [%s]: %w", job.Cmd, err)
+		}
+		if err := s.permitRunAs(job.RunAs, user); err != nil {
+			return added, dups, alreadyComplete, ErrBadRunAs, fmt.Errorf("job [%s]: %w", job.Cmd, err)
+		}
+	}
+
 	s.racmutex.RLock()
 	rcSet := s.rc != ""
 	s.racmutex.RUnlock()
 
 	// create itemdefs for the jobs
 	limitGroups := make(map[string]int)
+	var traceID string
 	for _, job := range inputJobs {
 		job.Lock()
 		job.EnvKey = envkey
+		job.Cached = false
+		job.InputChecksums = nil
+		job.CachedInputChecksums = nil
+		if s.tracer != nil && job.TraceID == "" {
+			if traceID == "" {
+				traceID = NewTraceID()
+			}
+			job.TraceID = traceID
+		}
 		job.UntilBuried = job.Retries + 1
+		s.applyQueueConfig(job)
 		if rcSet {
 			job.schedulerGroup = job.Requirements.Stringify()
 		}
@@ -1667,6 +2192,16 @@ func (s *Server) createJobs(inputJobs []*Job, envkey string, ignoreComplete bool
 		return added, dups, alreadyComplete, ErrDBError, err
 	}
 
+	// cacheable jobs may not need to be run at all
+	var rerun []*Job
+	var cached int
+	if ignoreComplete {
+		inputJobs, rerun, cached, err = s.satisfyFromCache(inputJobs)
+		if err != nil {
+			return added, dups, alreadyComplete, ErrDBError, err
+		}
+	}
+
 	// keep an on-disk record of these new jobs; we sacrifice a lot of speed by
 	// waiting on this database write to persist to disk. The alternative would
 	// be to return success to the client as soon as the jobs were in the in-
@@ -1677,6 +2212,13 @@ func (s *Server) createJobs(inputJobs []*Job, envkey string, ignoreComplete bool
 	// Remove the job that created the new jobs from the queue and when we
 	// recover, at worst the creating job will be run again - no jobs get lost.)
 	jobsToQueue, jobsToUpdate, alreadyComplete, err := s.db.storeNewJobs(inputJobs, ignoreComplete)
+	if err == nil && len(rerun) > 0 {
+		var rerunToQueue, rerunToUpdate []*Job
+		rerunToQueue, rerunToUpdate, _, err = s.db.storeNewJobs(rerun, false)
+		jobsToQueue = append(jobsToQueue, rerunToQueue...)
+		jobsToUpdate = append(jobsToUpdate, rerunToUpdate...)
+	}
+	alreadyComplete += cached
 	if err != nil {
 		srerr = ErrDBError
 		qerr = err
@@ -1695,8 +2237,9 @@ func (s *Server) createJobs(inputJobs []*Job, envkey string, ignoreComplete bool
 				qerr = err
 				break
 			}
-			itemdefs = append(itemdefs, &queue.ItemDef{Key: job.Key(), ReserveGroup: job.getSchedulerGroup(), Data: job, Priority: job.Priority, Delay: 0 * time.Second, TTR: ServerItemTTR, Dependencies: deps})
+			itemdefs = append(itemdefs, s.jobItemDef(job, deps))
 		}
+		s.addAtomicGroupDependencies(itemdefs)
 
 		srerr, qerr = s.updateJobDependencies(jobsToUpdate)
 
@@ -1771,7 +2314,8 @@ func (s *Server) updateJobDependencies(jobs []*Job) (srerr string, qerr error) {
 			qerr = err
 			break
 		}
-		thisErr := s.q.Update(job.Key(), job.getSchedulerGroup(), job, job.Priority, 0*time.Second, ServerItemTTR, deps)
+		deps = s.withAtomicGroupMembers(job, deps)
+		thisErr := s.q.Update(job.Key(), job.getSchedulerGroup(), job, job.Priority, 0*time.Second, s.itemTTR, deps)
 		if thisErr != nil {
 			qerr = thisErr
 			break
@@ -1786,8 +2330,13 @@ func (s *Server) releaseJob(job *Job, endState *JobEndState, failReason string,
 	// first check the job hasn't already been released/buried, only attempt
 	// queue changes if not
 	job.RLock()
+	if job.atomicCancelled {
+		failReason = FailReasonAtomic
+		forceBury = true
+	}
 	bury := forceBury
-	if !bury && !job.StartTime.IsZero() {
+	retrying := !job.StartTime.IsZero() && failReason != FailReasonDrained && failReason != FailReasonAbandoned
+	if !bury && retrying {
 		bury = job.UntilBuried == 1
 	}
 	key := job.Key()
@@ -1820,20 +2369,24 @@ func (s *Server) releaseJob(job *Job, endState *JobEndState, failReason string,
 		return errq
 	}
 
+	s.redactionRules.redactBehaviourResults(endState)
 	job.updateAfterExit(endState, s.limiter)
+	s.recordBehaviourEvents(job, endState)
 
 	job.Lock()
 	if forceBury {
 		job.UntilBuried = 0
-	} else if !job.StartTime.IsZero() {
+	} else if retrying {
 		// obey jobs's Retries count by adjusting UntilBuried if a
-		// client reserved this job and started to run the job's cmd
+		// client reserved this job and started to run the job's cmd (unless
+		// it was only stopped because its host is being drained)
 		job.UntilBuried--
 	}
 
 	sgroup := job.schedulerGroup
 	var msg string
-	if job.UntilBuried <= 0 {
+	buried := job.UntilBuried <= 0
+	if buried {
 		job.State = JobStateBuried
 		msg = "buried job"
 	} else {
@@ -1841,14 +2394,125 @@ func (s *Server) releaseJob(job *Job, endState *JobEndState, failReason string,
 		msg = "released job"
 	}
 	job.FailReason = failReason
+	job.atomicCancelled = false
 	job.Unlock()
 
+	if buried {
+		s.recordBuryEvent(job, failReason)
+	}
+
 	s.decrementGroupCount(job.getSchedulerGroup())
-	s.db.updateJobAfterExit(job, endState.Stdout, endState.Stderr, forceStorage)
+	stdo := s.redactionRules.redactCompressed(endState.Stdout)
+	stde := s.redactionRules.redactCompressed(endState.Stderr)
+	s.db.updateJobAfterExit(job, stdo, stde, forceStorage)
 	s.Debug(msg, "cmd", job.Cmd, "schedGrp", sgroup)
+
+	if buried && failReason != FailReasonAtomic {
+		s.failAtomicGroup(job)
+	}
 	return nil
 }
 
+// modifyJobs uses the given JobModifier to modify the jobs with the given keys
+// that are not currently running, updating the queue, our lookups and the
+// database to match. Returns a mapping of new to old keys for the jobs that
+// were modified.
+func (s *Server) modifyJobs(keys []string, modifier *JobModifier) (map[string]string, error) {
+	var toModifyJobs []*Job
+	toModifyKeys := make(map[string]*Job)
+	for _, jobkey := range keys {
+		item, err := s.q.Get(jobkey)
+		if err != nil || item == nil {
+			continue
+		}
+		iState := item.Stats().State
+		if iState == queue.ItemStateRun {
+			continue
+		}
+		toModifyJobs = append(toModifyJobs, item.Data().(*Job))
+		toModifyKeys[jobkey] = item.Data().(*Job)
+	}
+
+	modified, err := modifier.Modify(toModifyJobs, s)
+	if err != nil || len(modified) == 0 {
+		return modified, err
+	}
+
+	var toModify []*Job
+	for _, old := range modified {
+		job := toModifyKeys[old]
+		if job != nil {
+			toModify = append(toModify, job)
+		}
+	}
+
+	// additional handling of changed limit groups
+	if modifier.LimitGroupsSet {
+		limitGroups := make(map[string]int)
+		for _, job := range toModify {
+			err := s.handleUserSpecifiedJobLimitGroups(job, limitGroups)
+			if err != nil {
+				s.Error("failed to modify limit group", "err", err)
+			}
+		}
+		err := s.storeLimitGroups(limitGroups)
+		if err != nil {
+			s.Error("failed to store limit groups", "err", err)
+		}
+	}
+
+	// update changed keys in the queue and in our rpl lookup
+	keyToRP := make(map[string]string)
+	for _, job := range toModify {
+		keyToRP[job.Key()] = job.RepGroup
+	}
+	s.rpl.Lock()
+	for new, old := range modified {
+		if old == new {
+			continue
+		}
+		errc := s.q.ChangeKey(old, new)
+		if errc != nil {
+			s.Error("failed to change a job key in the queue", "err", errc)
+		}
+
+		rp := keyToRP[new]
+		if _, exists := s.rpl.lookup[rp]; !exists {
+			s.rpl.lookup[rp] = make(map[string]bool)
+		}
+		delete(s.rpl.lookup[rp], old)
+		s.rpl.lookup[rp][new] = true
+	}
+	s.rpl.Unlock()
+
+	// update db live bucket and dep lookups
+	if len(toModify) > 0 {
+		oldKeys := make([]string, len(toModify))
+		for i, job := range toModify {
+			oldKeys[i] = modified[job.Key()]
+		}
+		errm := s.db.modifyLiveJobs(oldKeys, toModify)
+		if errm != nil {
+			s.Error("job modification in database failed", "err", errm)
+		} else if modifier.DependenciesSet || modifier.PrioritySet {
+			// if we're changing the jobs these jobs are dependant upon or
+			// their priority, that must be reflected in the queue as well
+			for _, job := range toModify {
+				deps, err := job.Dependencies.incompleteJobKeys(s.db)
+				if err != nil {
+					s.Error("failed to get job dependencies", "err", err)
+				}
+				deps = s.withAtomicGroupMembers(job, deps)
+				err = s.q.Update(job.Key(), job.getSchedulerGroup(), job, job.Priority, 0*time.Second, s.itemTTR, deps)
+				if err != nil {
+					s.Error("failed to modify a job in the queue", "err", err)
+				}
+			}
+		}
+	}
+	return modified, nil
+}
+
 // inputToQueuedJobs shows you which of the inputJobs are now actually in the
 // queue
 func (s *Server) inputToQueuedJobs(inputJobs []*Job) []*Job {
@@ -1869,6 +2533,100 @@ func (s *Server) inputToQueuedJobs(inputJobs []*Job) []*Job {
 	return jobs
 }
 
+// queuedKeys returns the keys of those of the given jobs that are currently in
+// the queue.
+func (s *Server) queuedKeys(jobs []*Job) map[string]bool {
+	keys := make(map[string]bool)
+	for _, job := range jobs {
+		key := job.Key()
+		if _, err := s.q.Get(key); err == nil {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// rejectInvalidJobs checks that each of the given jobs can be added by the
+// given user, returning those that can, and results describing why the others
+// can't, keyed on their index in jobs.
+func (s *Server) rejectInvalidJobs(jobs []*Job, user string, grants map[string]string) ([]*Job, map[int]*AddResult) {
+	valid := make([]*Job, 0, len(jobs))
+	rejected := make(map[int]*AddResult)
+	for i, job := range jobs {
+		if reason := s.invalidReason(job, user, grants); reason != "" {
+			rejected[i] = &AddResult{Key: job.Key(), Outcome: AddOutcomeInvalid, Reason: reason}
+			continue
+		}
+		valid = append(valid, job)
+	}
+	return valid, rejected
+}
+
+// invalidReason returns why the given job can't be added by the given user
+// with the given secret grants, or an empty string if it can. These are the
+// problems that would otherwise make createJobs() (or the secrets check before
+// it) fail the whole batch the job was in.
+func (s *Server) invalidReason(job *Job, user string, grants map[string]string) string {
+	if job.Cmd == "" {
+		return "no command"
+	}
+	if job.Requirements == nil {
+		return "no resource requirements"
+	}
+	if err := job.Behaviours.Validate(); err != nil {
+		return fmt.Sprintf("%s: %s", ErrBadBehaviour, err)
+	}
+	if job.Queue != "" {
+		if err := validateQueueName(job.Queue); err != nil {
+			return err.Error()
+		}
+	}
+	if job.TraceID != "" && !ValidTraceID(job.TraceID) {
+		return ErrBadTraceID
+	}
+	for _, group := range job.LimitGroups {
+		if _, _, _, err := s.splitSuffixedLimitGroup(group); err != nil {
+			return fmt.Sprintf("%s [%s]: %s", ErrBadLimitGroup, group, err)
+		}
+	}
+	if err := s.permitRunAs(job.RunAs, user); err != nil {
+		return err.Error()
+	}
+	if err := s.permitSecrets(job, grants); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// addResults works out what happened to each of the given jobs that were just
+// added, given the keys of those that were already in the queue beforehand
+// (from queuedKeys()), and the results for those that were rejected (from
+// rejectInvalidJobs()).
+func (s *Server) addResults(jobs []*Job, before map[string]bool, rejected map[int]*AddResult) []*AddResult {
+	now := s.queuedKeys(jobs)
+	results := make([]*AddResult, len(jobs))
+	for i, job := range jobs {
+		if result, invalid := rejected[i]; invalid {
+			results[i] = result
+			continue
+		}
+		key := job.Key()
+		outcome := AddOutcomeComplete
+		switch {
+		case before[key]:
+			outcome = AddOutcomeExisted
+		case now[key]:
+			outcome = AddOutcomeAdded
+			// later copies of the job in this batch were duplicates of it
+			before[key] = true
+		case job.Cached:
+			outcome = AddOutcomeCached
+		}
+		results[i] = &AddResult{Key: key, Outcome: outcome}
+	}
+	return results
+}
+
 // killJob sets the killCalled property on a job, to change the subsequent
 // behaviour of touching, which should result in an executing job killing
 // itself.
@@ -1891,7 +2649,9 @@ func (s *Server) killJob(jobkey string) (bool, error) {
 
 	if job.Lost {
 		job.Unlock()
-		err = s.releaseJob(job, &JobEndState{Exitcode: -1, Exited: true}, FailReasonLost, false, false)
+		jes := &JobEndState{Exitcode: -1, Exited: true}
+		failReason, bury := s.classifyFailure(job, jes, FailReasonLost, nil, false)
+		err = s.releaseJob(job, jes, failReason, false, bury)
 		return true, err
 	}
 
@@ -1902,10 +2662,12 @@ func (s *Server) killJob(jobkey string) (bool, error) {
 // deleteJobs deletes the jobs with the given keys from the
 // bury/delay/dependent/ready queue and the live bucket. Does not delete jobs
 // that have jobs dependant upon them, unless all those dependants were also
-// supplied to this method at the same time (in any order). Returns the keys of
-// jobs actually deleted.
-func (s *Server) deleteJobs(keys []string) []string {
+// supplied to this method at the same time (in any order). The deletion is
+// recorded as having been asked for by the given user. Returns the keys of jobs
+// actually deleted.
+func (s *Server) deleteJobs(keys []string, user string) []string {
 	var deleted []string
+	var deletedJobs []*Job
 	for {
 		var skippedDeps []string
 		var toDelete []string
@@ -1937,6 +2699,7 @@ func (s *Server) deleteJobs(keys []string) []string {
 				toDelete = append(toDelete, jobkey)
 
 				job := item.Data().(*Job)
+				deletedJobs = append(deletedJobs, job)
 				if job.getScheduledRunner() {
 					schedGroups[job.getSchedulerGroup()]++
 				}
@@ -1947,7 +2710,7 @@ func (s *Server) deleteJobs(keys []string) []string {
 
 		if len(toDelete) > 0 {
 			// delete from db live bucket all in one go
-			errd := s.db.deleteLiveJobs(toDelete)
+			_, errd := s.removeLiveJobs(toDelete)
 			if errd != nil {
 				s.Error("job deletion from database failed", "err", errd)
 			}
@@ -1975,6 +2738,7 @@ func (s *Server) deleteJobs(keys []string) []string {
 		}
 		break
 	}
+	s.recordUserAction(EventTypeRemove, user, deletedJobs)
 	return deleted
 }
 
@@ -2013,10 +2777,15 @@ func (s *Server) killJobsOnServers(serverIDs map[string]bool) []*Job {
 	return jobs
 }
 
-// getJobsByKeys gets jobs with the given keys (current and complete).
-func (s *Server) getJobsByKeys(keys []string, getStd bool, getEnv bool) (jobs []*Job, srerr string, qerr string) {
+// getJobsByKeys gets jobs with the given keys (current and complete). Stops
+// early with ErrRequestAbandoned if the context is done.
+func (s *Server) getJobsByKeys(ctx context.Context, keys []string, getStd bool, getEnv bool) (jobs []*Job, srerr string, qerr string) {
 	var notfound []string
 	for _, jobkey := range keys {
+		if srerr, qerr = abandoned(ctx); srerr != "" {
+			return nil, srerr, qerr
+		}
+
 		// try and get the job from the in-memory queue
 		item, err := s.q.Get(jobkey)
 		var job *Job
@@ -2033,10 +2802,9 @@ func (s *Server) getJobsByKeys(keys []string, getStd bool, getEnv bool) (jobs []
 
 	if len(notfound) > 0 {
 		// try and get the jobs from the permanent store
-		found, err := s.db.retrieveCompleteJobsByKeys(notfound)
+		found, err := s.db.retrieveCompleteJobsByKeys(ctx, notfound)
 		if err != nil {
-			srerr = ErrDBError
-			qerr = err.Error()
+			srerr, qerr = dbErr(err)
 		} else if len(found) > 0 {
 			if getEnv { // complete jobs don't have any std
 				for _, job := range found {
@@ -2063,7 +2831,7 @@ func (s *Server) checkJobByKey(key string) (bool, error) {
 		return true, nil
 	}
 
-	found, err := s.db.retrieveCompleteJobsByKeys([]string{key})
+	found, err := s.db.retrieveCompleteJobsByKeys(context.Background(), []string{key})
 	return len(found) == 1, err
 }
 
@@ -2084,8 +2852,9 @@ func (s *Server) searchRepGroups(partialRepGroup string) ([]string, error) {
 	return matching, err
 }
 
-// getJobsByRepGroup gets jobs in the given group (current and complete).
-func (s *Server) getJobsByRepGroup(repgroup string, search bool, limit int, state JobState, getStd bool, getEnv bool) (jobs []*Job, srerr string, qerr string) {
+// getJobsByRepGroup gets jobs in the given group (current and complete). Stops
+// early with ErrRequestAbandoned if the context is done.
+func (s *Server) getJobsByRepGroup(ctx context.Context, repgroup string, search bool, limit int, state JobState, getStd bool, getEnv bool) (jobs []*Job, srerr string, qerr string) {
 	var rgs []string
 	if search {
 		var errs error
@@ -2098,6 +2867,10 @@ func (s *Server) getJobsByRepGroup(repgroup string, search bool, limit int, stat
 	}
 
 	for _, rg := range rgs {
+		if srerr, qerr = abandoned(ctx); srerr != "" {
+			return nil, srerr, qerr
+		}
+
 		// look in the in-memory queue for matching jobs
 		s.rpl.RLock()
 		for key := range s.rpl.lookup[rg] {
@@ -2112,7 +2885,10 @@ func (s *Server) getJobsByRepGroup(repgroup string, search bool, limit int, stat
 		// look in the permanent store for matching jobs
 		if state == "" || state == JobStateComplete {
 			var complete []*Job
-			complete, srerr, qerr = s.getCompleteJobsByRepGroup(rg)
+			complete, srerr, qerr = s.getCompleteJobsByRepGroup(ctx, rg)
+			if srerr == ErrRequestAbandoned {
+				return nil, srerr, qerr
+			}
 			if len(complete) > 0 {
 				// a job is stored in the db with only the single most recent
 				// RepGroup it had, but we're able to retrieve jobs based on any of
@@ -2128,26 +2904,83 @@ func (s *Server) getJobsByRepGroup(repgroup string, search bool, limit int, stat
 	}
 
 	if limit > 0 || state != "" || getStd || getEnv {
+		if srerrc, qerrc := abandoned(ctx); srerrc != "" {
+			return nil, srerrc, qerrc
+		}
 		jobs = s.limitJobs(jobs, limit, state, getStd, getEnv)
 	}
 	return jobs, srerr, qerr
 }
 
 // getCompleteJobsByRepGroup gets complete jobs in the given group.
-func (s *Server) getCompleteJobsByRepGroup(repgroup string) (jobs []*Job, srerr string, qerr string) {
-	jobs, err := s.db.retrieveCompleteJobsByRepGroup(repgroup)
+func (s *Server) getCompleteJobsByRepGroup(ctx context.Context, repgroup string) (jobs []*Job, srerr string, qerr string) {
+	jobs, err := s.db.retrieveCompleteJobsByRepGroup(ctx, repgroup)
 	if err != nil {
-		srerr = ErrDBError
-		qerr = err.Error()
+		srerr, qerr = dbErr(err)
 	}
 	return jobs, srerr, qerr
 }
 
+// dbErr returns ErrRequestAbandoned if the given error from a db retrieval is
+// due to its context being done, otherwise ErrDBError, along with the error's
+// message.
+func dbErr(err error) (srerr string, qerr string) {
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return ErrRequestAbandoned, err.Error()
+	}
+	return ErrDBError, err.Error()
+}
+
+// getCompleteJobsInRange gets complete jobs that completed in the given time
+// range, most recent first, optionally only those in the given group (or groups
+// containing it as a substring if search is true). A limit greater than 0 caps
+// the number of jobs returned. Stops early with ErrRequestAbandoned if the
+// context is done.
+func (s *Server) getCompleteJobsInRange(ctx context.Context, repgroup string, search bool, since, until time.Time, limit int, getStd bool, getEnv bool) (jobs []*Job, srerr string, qerr string) {
+	var rgs []string
+	if repgroup != "" {
+		if search {
+			var errs error
+			rgs, errs = s.searchRepGroups(repgroup)
+			if errs != nil {
+				return nil, ErrDBError, errs.Error()
+			}
+			if len(rgs) == 0 {
+				return nil, "", ""
+			}
+		} else {
+			rgs = append(rgs, repgroup)
+		}
+	}
+
+	jobs, err := s.db.retrieveCompleteJobsInRange(ctx, rgs, since, until, limit)
+	if err != nil {
+		srerr, qerr = dbErr(err)
+		return nil, srerr, qerr
+	}
+
+	if getEnv || getStd {
+		for _, job := range jobs {
+			s.jobPopulateStdEnv(job, getStd, getEnv)
+		}
+	}
+	return jobs, "", ""
+}
+
 // getJobsCurrent gets all current (incomplete) jobs.
 func (s *Server) getJobsCurrent(limit int, state JobState, getStd bool, getEnv bool) []*Job {
+	return s.getJobsCurrentInQueue("", limit, state, getStd, getEnv)
+}
+
+// getJobsCurrentInQueue is like getJobsCurrent(), but only gets the jobs in
+// the given named queue, if not blank.
+func (s *Server) getJobsCurrentInQueue(queueName string, limit int, state JobState, getStd bool, getEnv bool) []*Job {
 	allItems := s.q.AllItems()
 	jobs := make([]*Job, 0, len(allItems))
 	for _, item := range allItems {
+		if queueName != "" && item.Data().(*Job).Queue != queueName {
+			continue
+		}
 		jobs = append(jobs, s.itemToJob(item, false, false))
 	}
 
@@ -2160,7 +2993,7 @@ func (s *Server) getJobsCurrent(limit int, state JobState, getStd bool, getEnv b
 
 // limitJobs handles the limiting of jobs for getJobsByRepGroup() and
 // getJobsCurrent(). States 'reserved' and 'running' are treated as the same
-// state.
+// state, which also includes the 'staging' and 'uploading' sub-states.
 func (s *Server) limitJobs(jobs []*Job, limit int, state JobState, getStd bool, getEnv bool) []*Job {
 	groups := make(map[string][]*Job)
 	var limited []*Job
@@ -2183,12 +3016,19 @@ func (s *Server) limitJobs(jobs []*Job, limit int, state JobState, getStd bool,
 			if state == JobStateRunning {
 				state = JobStateReserved
 			}
-			if state == JobStateDeletable {
+			switch state {
+			case JobStateDeletable:
 				if jState == JobStateRunning || jState == JobStateComplete {
 					continue
 				}
-			} else if jState != state {
-				continue
+			case JobStateReserved:
+				if jState != state && jState != JobStateStaging && jState != JobStateUploading {
+					continue
+				}
+			default:
+				if jState != state {
+					continue
+				}
 			}
 		}
 
@@ -2257,21 +3097,34 @@ func (s *Server) scheduleRunners(group string) {
 	}
 
 	doClear := false
-	groupCount := s.sgroupcounts[group]
+	groupCount := s.capRunners(s.sgroupcounts[group])
 	if groupCount <= 0 {
 		s.sgroupcounts[group] = 0
 		doClear = true
 	}
 	priority := s.sgrouppriority[group]
+	scaledUp := s.noteSchedulerGroupRequested(group, groupCount)
 	s.sgcmutex.Unlock()
 
+	if scaledUp {
+		s.recordEvent(&Event{Type: EventTypeScaleUp, SchedulerGroup: group, Count: groupCount})
+	}
+
+	traced := s.tracer.scheduled(group)
 	if !doClear {
+		started := time.Now()
 		err := s.scheduler.Schedule(fmt.Sprintf(rc, group, s.ServerInfo.Deployment, s.ServerInfo.Addr, s.ServerInfo.Host, s.scheduler.ReserveTimeout(req), int(s.scheduler.MaxQueueTime(req).Minutes())), req, priority, groupCount)
+		s.traceSchedule(traced, group, groupCount, started, err)
 		if err != nil {
 			problem := true
-			if serr, ok := err.(scheduler.Error); ok && serr.Err == scheduler.ErrImpossible {
+			if serr, ok := err.(scheduler.Error); ok && (serr.Err == scheduler.ErrImpossible || serr.Err == scheduler.ErrBadQueue) {
 				// bury all jobs in this scheduler group
+				failReason := FailReasonResource
+				if serr.Err == scheduler.ErrBadQueue {
+					failReason = FailReasonQueue
+				}
 				problem = false
+				var buried []*Job
 				s.sgcmutex.Lock()
 				for {
 					item, errr := s.q.Reserve(group, 0)
@@ -2287,15 +3140,21 @@ func (s *Server) scheduleRunners(group string) {
 					}
 					job := item.Data().(*Job)
 					job.Lock()
-					job.FailReason = FailReasonResource
+					job.FailReason = failReason
 					job.Unlock()
 					errb := s.q.Bury(item.Key)
 					if errb != nil {
 						s.Warn("scheduleRunners failed to bury an item", "err", errb)
+					} else {
+						s.recordBuryEvent(job, failReason)
+						buried = append(buried, job)
 					}
 					s.sgroupcounts[group]--
 				}
 				s.sgcmutex.Unlock()
+				for _, job := range buried {
+					s.failAtomicGroup(job)
+				}
 				if !problem {
 					doClear = true
 				}
@@ -2305,6 +3164,7 @@ func (s *Server) scheduleRunners(group string) {
 				// log the error *** and inform (by email) the user about this
 				// problem if it's persistent, once per hour (day?)
 				s.Warn("Server scheduling runners error", "err", err)
+				s.recordEvent(&Event{Type: EventTypeSchedulerError, SchedulerGroup: group, Msg: err.Error()})
 
 				// retry the schedule in a while
 				wgk := s.wg.Add(1)
@@ -2415,11 +3275,13 @@ func (s *Server) clearSchedulerGroup(schedulerGroup string) {
 		s.sgcmutex.Unlock()
 		return
 	}
+	s.recordEvent(&Event{Type: EventTypeScaleDown, SchedulerGroup: schedulerGroup})
 	delete(s.sgroupcounts, schedulerGroup)
 	delete(s.idtl, schedulerGroup)
 	delete(s.sgrouptrigs, schedulerGroup)
 	delete(s.sgtr, schedulerGroup)
 	delete(s.sgrouppriority, schedulerGroup)
+	delete(s.sgroupstats, schedulerGroup)
 	s.sgcmutex.Unlock()
 	err := s.scheduler.Schedule(fmt.Sprintf(rc, schedulerGroup, s.ServerInfo.Deployment, s.ServerInfo.Addr, s.ServerInfo.Host, s.scheduler.ReserveTimeout(req), int(s.scheduler.MaxQueueTime(req).Minutes())), req, 0, 0)
 	if err != nil {
@@ -2554,7 +3416,7 @@ func (s *Server) shutdown(reason string, wait bool, stopSigHandling bool) {
 	s.krmutex.Unlock()
 	if s.HasRunners() {
 		// wait until everything must have attempted a touch
-		<-time.After(ClientTouchInterval)
+		<-time.After(s.heartbeat)
 	}
 
 	// wait for the runners to actually die
@@ -2591,6 +3453,12 @@ func (s *Server) shutdown(reason string, wait bool, stopSigHandling bool) {
 	if err != nil {
 		s.Warn("server shutdown of web interface failed", "err", err)
 	}
+	if s.publicHTTPServer != nil {
+		err = s.publicHTTPServer.Shutdown(ctx)
+		if err != nil {
+			s.Warn("server shutdown of public web interface failed", "err", err)
+		}
+	}
 	cancel()
 
 	// close our command line interface
@@ -22,6 +22,9 @@ package jobqueue
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -36,13 +39,50 @@ import (
 // clientRequest, does the requested work, then responds back to the client with
 // a serverResponse
 func (s *Server) handleRequest(m *mangos.Message) error {
-	dec := codec.NewDecoderBytes(m.Body, s.ch)
+	// (cr.Env and cr.File may be slices of m.Body, so are only valid until we
+	// reply)
 	cr := &clientRequest{}
-	errd := dec.Decode(cr)
-	if errd != nil {
+	if _, errd := decodeFrame(m.Body, cr, s.ch, true); errd != nil {
 		return errd
 	}
 
+	// refuse requests from clients we won't be able to understand
+	if srerr := s.checkClientVersion(cr); srerr != "" {
+		return s.reply(m, &serverResponse{Err: srerr}, nil)
+	}
+
+	// (only clients with the right token get to resubmit a batch of jobs)
+	if cr.AddToken != "" && (len(cr.Token) != tokenLength || !tokenMatches(cr.Token, s.token)) {
+		cr.AddToken = ""
+	}
+
+	// if this is a client retrying a request it didn't get our reply to, or
+	// resubmitting a batch of jobs, send the original reply instead of carrying
+	// out the request again
+	pending, retried, reused := s.requests.begin(cr)
+	for retried && !reused {
+		encoded, abandoned := pending.response()
+		if encoded != nil {
+			m.Body = encoded
+			return s.sock.SendMsg(m)
+		}
+		if !abandoned {
+			return s.reply(m, &serverResponse{Err: ErrInternalError}, nil)
+		}
+
+		// the original request was given up on without being carried out,
+		// so carry out this retry of it instead
+		pending, retried, reused = s.requests.begin(cr)
+	}
+	if reused {
+		return s.reply(m, &serverResponse{Err: ErrAddTokenReused}, nil)
+	}
+	defer pending.finish(nil)
+
+	// stop working on the request if the client goes away or it takes too long
+	ctx, done := s.requestContext(m.Port, cr.Method)
+	defer done()
+
 	var sr *serverResponse
 	var srerr string
 	var qerr string
@@ -61,7 +101,15 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 		// the server just got shutdown
 		srerr = ErrClosedStop
 		qerr = "The server has been stopped"
+	case queryMethods[cr.Method] && !s.queries.acquire():
+		srerr = ErrOverloaded
+		qerr = "Too many concurrent queries"
 	default:
+		if queryMethods[cr.Method] {
+			// we acquired a query slot in the case above
+			defer s.queries.release()
+		}
+
 		switch cr.Method {
 		case "ping":
 			// avoid a later race condition when we try to encode ServerInfo by
@@ -95,7 +143,8 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 				qerr = err.Error()
 			} else {
 				if paused {
-					s.Info("paused by request")
+					s.Info("paused by request", "user", cr.User)
+					s.recordServerAction(EventTypePause, claimedUser(cr.User))
 				} else {
 					// clients are allowed to call pause as many times as they
 					// like, but a single resume call later should work, so we
@@ -120,7 +169,8 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 				}
 				qerr = err.Error()
 			} else if resumed {
-				s.Info("resumed on request")
+				s.Info("resumed on request", "user", cr.User)
+				s.recordServerAction(EventTypeResume, claimedUser(cr.User))
 			}
 		case "drain":
 			s.Info("drain requested")
@@ -159,6 +209,10 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 			// they're supposed to execute under.
 			if cr.Env == nil || cr.Jobs == nil {
 				srerr = ErrBadRequest
+			} else if s.tooManyJobsToAdd(len(cr.Jobs)) {
+				// refuse before we spend any effort copying the jobs
+				srerr = ErrTooManyJobs
+				qerr = fmt.Sprintf("%s (adding %d jobs)", ErrTooManyJobs, len(cr.Jobs))
 			} else {
 				// Store Env
 				envkey, err := s.db.storeEnv(cr.Env)
@@ -166,23 +220,50 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 					srerr = ErrDBError
 					qerr = err.Error()
 				} else if srerr == "" {
+					// when returning results, invalid jobs are rejected
+					// individually, instead of failing the whole batch
+					jobs := cr.Jobs
+					var rejected map[int]*AddResult
+					var queued map[string]bool
+					var thisSrerr string
+					if cr.ReturnResults {
+						jobs, rejected = s.rejectInvalidJobs(cr.Jobs, cr.User, cr.SecretGrants)
+						queued = s.queuedKeys(jobs)
+					} else {
+						for _, job := range jobs {
+							if err = s.permitSecrets(job, cr.SecretGrants); err != nil {
+								thisSrerr = ErrBadSecret
+								break
+							}
+						}
+					}
+
 					// create the jobs server-side
-					added, dups, alreadyComplete, thisSrerr, err := s.createJobs(cr.Jobs, envkey, cr.IgnoreComplete)
+					var added, dups, alreadyComplete int
+					if len(jobs) > 0 && err == nil {
+						added, dups, alreadyComplete, thisSrerr, err = s.createJobs(jobs, envkey, cr.IgnoreComplete, cr.User)
+					}
 					if err != nil {
 						srerr = thisSrerr
 						qerr = err.Error()
 					} else {
-						s.Debug("added jobs", "new", added, "dups", dups, "complete", alreadyComplete)
+						s.Debug("added jobs", "new", added, "dups", dups, "complete", alreadyComplete, "invalid", len(rejected))
+						if added > 0 {
+							s.recordEvent(&Event{Type: EventTypeAdd, RepGroup: commonRepGroup(jobs), Count: added})
+						}
 						if cr.ReturnIDs {
-							jobs := s.inputToQueuedJobs(cr.Jobs)
+							queuedJobs := s.inputToQueuedJobs(jobs)
 							var ids []string
-							for _, job := range jobs {
+							for _, job := range queuedJobs {
 								ids = append(ids, job.Key())
 							}
 							sr = &serverResponse{Added: added, Existed: dups + alreadyComplete, AddedIDs: ids}
 						} else {
 							sr = &serverResponse{Added: added, Existed: dups + alreadyComplete}
 						}
+						if cr.ReturnResults {
+							sr.AddResults = s.addResults(cr.Jobs, queued, rejected)
+						}
 					}
 				}
 			}
@@ -217,13 +298,15 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 						s.sgcmutex.Lock()
 						if count, existed := s.sgroupcounts[cr.SchedulerGroup]; !existed || count == 0 {
 							skip = true
+						} else {
+							s.noteSchedulerGroupRunner(cr.SchedulerGroup)
 						}
 						s.sgcmutex.Unlock()
 					}
 				}
 
 				if !skip {
-					item, err = s.reserveWithLimits(cr.SchedulerGroup, cr.Timeout)
+					item, err = s.reserveWithLimits(ctx, cr.SchedulerGroup, cr.Host, cr.Timeout)
 
 					if err != nil {
 						if qerr, ok := err.(queue.Error); ok {
@@ -235,39 +318,40 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 							default:
 								srerr = ErrInternalError
 							}
+						} else if err == ctx.Err() {
+							srerr = ErrRequestAbandoned
 						}
 					}
 				}
 
 				if srerr == "" && item != nil {
-					// clean up any past state to have a fresh job ready to run
-					sjob := item.Data().(*Job)
-					sjob.Lock()
-					sjob.ReservedBy = cr.ClientID //*** we should unset this on moving out of run state, to save space
-					sjob.Exited = false
-					sjob.Pid = 0
-					sjob.Host = ""
-					var tnil time.Time
-					sjob.StartTime = tnil
-					sjob.EndTime = tnil
-					sjob.PeakRAM = 0
-					sjob.PeakDisk = 0
-					sjob.Exitcode = -1
-					sgroup := sjob.schedulerGroup
-					sjob.Unlock()
-
-					errd := s.q.SetDelay(item.Key, ClientReleaseDelay)
-					if errd != nil {
-						s.Warn("reserve queue SetDelay failed", "err", errd)
-					}
-
-					// make a copy of the job with some extra stuff filled in (that
-					// we don't want taking up memory here) for the client
-					job := s.itemToJob(item, false, true)
-					sr = &serverResponse{Job: job}
-					s.Debug("reserved job", "cmd", job.Cmd, "schedGrp", sgroup)
+					sr = &serverResponse{Job: s.reservedJob(item, cr)}
 				}
 			} // else we'll return nothing, as if there were no jobs in the queue
+		case "reservekey":
+			// return the job with the given key, whatever it's waiting for,
+			// so that a user can run it manually
+			if cr.ClientID.String() == "00000000-0000-0000-0000-000000000000" || len(cr.Keys) != 1 {
+				srerr = ErrBadRequest
+				break
+			}
+			item, err := s.q.ReserveKey(cr.Keys[0])
+			if err != nil {
+				srerr = ErrBadJob
+				qerr = err.Error()
+				break
+			}
+			job := item.Data().(*Job)
+			job.Lock()
+			job.reservedHost = cr.Host
+			rg := job.RepGroup
+			job.Unlock()
+			s.hostLimiter.noteRunning(cr.Host, job)
+			s.recordEvent(&Event{Type: EventTypeManualRun, Key: item.Key, RepGroup: rg, Host: cr.Host})
+			sr = &serverResponse{Job: s.reservedJob(item, cr)}
+			job.Lock()
+			job.reservedManually = true
+			job.Unlock()
 		case "jstart":
 			// update the job's cmd-started-related properties
 			var job *Job
@@ -294,11 +378,31 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 
 					job.Unlock()
 
+					s.affinities.started(job.Affinity, cr.Job.Host)
+					s.hostLimiter.noteRunning(cr.Job.Host, job)
+					s.recordEvent(&Event{Type: EventTypeStart, Key: job.Key(), RepGroup: job.RepGroup, Host: cr.Job.Host})
+
+					// any staging is now over
+					s.setJobPhase(job, "")
+
 					// we'll save-to-disk that we started running this job, so
 					// recovery is possible after a crash
 					s.db.updateJobAfterChange(job)
 				}
 			}
+		case "jphase":
+			// note that the runner has started or finished moving data for
+			// the job
+			var job *Job
+			_, job, srerr = s.getij(cr, true)
+			if srerr == "" {
+				switch cr.State {
+				case "", JobStateStaging, JobStateUploading:
+					s.setJobPhase(job, cr.State)
+				default:
+					srerr = ErrBadRequest
+				}
+			}
 		case "jtouch":
 			var job *Job
 			var item *queue.Item
@@ -328,15 +432,24 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 						job.Lock()
 						job.Lost = false
 						job.EndTime = time.Time{}
+						to := job.runningState()
 						job.Unlock()
 
 						// since our changed callback won't be called, send out
 						// this transition from lost to running state
-						s.statusCaster.Send(&jstateCount{"+all+", JobStateLost, JobStateRunning, 1})
-						s.statusCaster.Send(&jstateCount{job.RepGroup, JobStateLost, JobStateRunning, 1})
+						s.statusCaster.Send(&jstateCount{"+all+", JobStateLost, to, 1})
+						s.statusCaster.Send(&jstateCount{job.RepGroup, JobStateLost, to, 1})
+						s.recordStateChange(job.RepGroup, JobStateLost, to, 1)
 					}
 				}
-				sr = &serverResponse{KillCalled: killCalled}
+				// if the job's host is being drained, tell the runner to
+				// requeue it
+				var requeue bool
+				var grace time.Duration
+				if !killCalled {
+					requeue, grace = s.hostDraining(job.runningOn())
+				}
+				sr = &serverResponse{KillCalled: killCalled, Requeue: requeue, Grace: grace}
 			}
 		case "jarchive":
 			// remove the job from the queue, rpl and live bucket and add to
@@ -344,12 +457,22 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 			var item *queue.Item
 			var job *Job
 			item, job, srerr = s.getij(cr, true)
+			if srerr == "" {
+				if cr.JobEndState != nil && cr.JobEndState.Cached {
+					// the runner found the inputs unchanged since the previous
+					// run, so didn't run the cmd
+					srerr, qerr = s.completeFromCache(job, cr.JobEndState.InputChecksums)
+				} else {
+					s.redactionRules.redactBehaviourResults(cr.JobEndState)
+					job.updateAfterExit(cr.JobEndState, s.limiter)
+					s.recordBehaviourEvents(job, cr.JobEndState)
+				}
+			}
 			if srerr == "" {
 				// first check the item is still in the run queue (eg. the job
 				// wasn't released by another process; unlike the other methods,
 				// queue package does not check we're in the run queue when
 				// Remove()ing, since you can remove from any queue)
-				job.updateAfterExit(cr.JobEndState, s.limiter)
 				job.Lock()
 				running := item.Stats().State == queue.ItemStateRun
 				switch {
@@ -401,7 +524,13 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 				}
 				cr.JobEndState.Stdout = cr.Job.StdOutC
 				cr.JobEndState.Stderr = cr.Job.StdErrC
-				errq := s.releaseJob(job, cr.JobEndState, cr.Job.FailReason, true, false)
+				failReason, bury := s.classifyFailure(job, cr.JobEndState, cr.Job.FailReason, cr.Job.StdErrC, false)
+				var errq error
+				if cr.JobEndState.Resubmit != nil {
+					errq = s.resubmitJob(job, cr.JobEndState, failReason)
+				} else {
+					errq = s.releaseJob(job, cr.JobEndState, failReason, true, bury)
+				}
 				if errq != nil {
 					srerr = ErrInternalError
 					qerr = errq.Error()
@@ -415,7 +544,13 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 				if cr.JobEndState == nil {
 					cr.JobEndState = &JobEndState{}
 				}
-				errq := s.releaseJob(job, cr.JobEndState, cr.Job.FailReason, true, true)
+				failReason, bury := s.classifyFailure(job, cr.JobEndState, cr.Job.FailReason, cr.Job.StdErrC, true)
+				var errq error
+				if cr.JobEndState.Resubmit != nil {
+					errq = s.resubmitJob(job, cr.JobEndState, failReason)
+				} else {
+					errq = s.releaseJob(job, cr.JobEndState, failReason, true, bury)
+				}
 				if errq != nil {
 					srerr = ErrInternalError
 					qerr = errq.Error()
@@ -428,7 +563,7 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 			if cr.Keys == nil {
 				srerr = ErrBadRequest
 			} else {
-				kicked := 0
+				var kickedJobs []*Job
 				for _, jobkey := range cr.Keys {
 					item, err := s.q.Get(jobkey)
 					if err != nil || item.Stats().State != queue.ItemStateBury {
@@ -442,19 +577,22 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 						job := item.Data().(*Job)
 						job.Lock()
 						job.UntilBuried = job.Retries + 1
+						job.failureCounts = nil
 						s.Debug("unburied job", "cmd", job.Cmd, "schedGrp", job.schedulerGroup)
 						job.State = JobStateReady
 						job.Unlock()
-						kicked++
-
-						s.db.updateJobAfterChange(job)
+						kickedJobs = append(kickedJobs, job)
 					} else {
 						s.rpmutex.Lock()
 						s.racPending = false
 						s.rpmutex.Unlock()
 					}
 				}
-				sr = &serverResponse{Existed: kicked}
+				s.recordUserAction(EventTypeRetry, claimedUser(cr.User), kickedJobs)
+				for _, job := range kickedJobs {
+					s.db.updateJobAfterChange(job)
+				}
+				sr = &serverResponse{Existed: len(kickedJobs)}
 			}
 		case "jdel":
 			// remove the jobs from the bury/delay/dependent/ready queue and the
@@ -462,15 +600,48 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 			if cr.Keys == nil {
 				srerr = ErrBadRequest
 			} else {
-				deleted := s.deleteJobs(cr.Keys)
+				deleted := s.deleteJobs(cr.Keys, claimedUser(cr.User))
 				s.Debug("deleted jobs", "count", len(deleted))
 				sr = &serverResponse{Existed: len(deleted)}
 			}
+		case "getrecycled":
+			// get the jobs that were deleted recently enough to be restored
+			var rgroup string
+			if cr.Job != nil {
+				rgroup = cr.Job.RepGroup
+			}
+			recycled, err := s.recycledJobs(rgroup, cr.Search, cr.Since)
+			if err != nil {
+				srerr = ErrDBError
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{Recycled: recycled}
+			}
+		case "undelete":
+			// add back to the queue jobs that were deleted recently enough
+			var rgroup string
+			if cr.Job != nil {
+				rgroup = cr.Job.RepGroup
+			}
+			restored, thisSrerr, err := s.undeleteJobs(rgroup, cr.Search, cr.Since, claimedUser(cr.User))
+			if err != nil {
+				srerr = thisSrerr
+				qerr = err.Error()
+			} else {
+				s.Debug("undeleted jobs", "count", restored)
+				sr = &serverResponse{Added: restored}
+			}
 		case "jmod":
 			// modify jobs in the bury/delay/dependent/ready queue and the
 			// live bucket
 			if cr.Keys == nil || cr.Modifier == nil {
 				srerr = ErrBadRequest
+			} else if err := s.permitRunAs(cr.Modifier.RunAs, cr.User); cr.Modifier.RunAsSet && err != nil {
+				srerr = ErrBadRunAs
+				qerr = err.Error()
+			} else if err := s.permitModifySecrets(cr.Keys, cr.SecretGrants); err != nil {
+				srerr = ErrBadSecret
+				qerr = err.Error()
 			} else {
 				// to avoid race conditions with jobs that are currently
 				// pending, but become running in the middle of us trying to
@@ -491,22 +662,7 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 				}
 
 				if err == nil {
-					var toModifyJobs []*Job
-					toModifyKeys := make(map[string]*Job)
-					for _, jobkey := range cr.Keys {
-						item, err := s.q.Get(jobkey)
-						if err != nil || item == nil {
-							continue
-						}
-						iState := item.Stats().State
-						if iState == queue.ItemStateRun {
-							continue
-						}
-						toModifyJobs = append(toModifyJobs, item.Data().(*Job))
-						toModifyKeys[jobkey] = item.Data().(*Job)
-					}
-
-					modified, err := cr.Modifier.Modify(toModifyJobs, s)
+					modified, err := s.modifyJobs(cr.Keys, cr.Modifier)
 					if err != nil {
 						if jqerr, ok := err.(Error); ok {
 							srerr = jqerr.Err
@@ -516,81 +672,6 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 						qerr = err.Error()
 					}
 
-					if err == nil && len(modified) > 0 {
-						var toModify []*Job
-						for _, old := range modified {
-							job := toModifyKeys[old]
-							if job != nil {
-								toModify = append(toModify, job)
-							}
-						}
-
-						// additional handling of changed limit groups
-						if cr.Modifier.LimitGroupsSet {
-							limitGroups := make(map[string]int)
-							for _, job := range toModify {
-								err := s.handleUserSpecifiedJobLimitGroups(job, limitGroups)
-								if err != nil {
-									s.Error("failed to modify limit group", "err", err)
-								}
-							}
-							err := s.storeLimitGroups(limitGroups)
-							if err != nil {
-								s.Error("failed to store limit groups", "err", err)
-							}
-						}
-
-						// update changed keys in the queue and in our rpl lookup
-						keyToRP := make(map[string]string)
-						for _, job := range toModify {
-							keyToRP[job.Key()] = job.RepGroup
-						}
-						s.rpl.Lock()
-						for new, old := range modified {
-							if old == new {
-								continue
-							}
-							errc := s.q.ChangeKey(old, new)
-							if errc != nil {
-								s.Error("failed to change a job key in the queue", "err", errc)
-							}
-
-							rp := keyToRP[new]
-							if _, exists := s.rpl.lookup[rp]; !exists {
-								s.rpl.lookup[rp] = make(map[string]bool)
-							}
-							delete(s.rpl.lookup[rp], old)
-							s.rpl.lookup[rp][new] = true
-						}
-						s.rpl.Unlock()
-
-						// update db live bucket and dep lookups
-						if len(toModify) > 0 {
-							oldKeys := make([]string, len(toModify))
-							for i, job := range toModify {
-								oldKeys[i] = modified[job.Key()]
-							}
-							errm := s.db.modifyLiveJobs(oldKeys, toModify)
-							if errm != nil {
-								s.Error("job modification in database failed", "err", errm)
-							} else if cr.Modifier.DependenciesSet || cr.Modifier.PrioritySet {
-								// if we're changing the jobs these jobs are
-								// dependant upon or their priority, that must be
-								// reflected in the queue as well
-								for _, job := range toModify {
-									deps, err := job.Dependencies.incompleteJobKeys(s.db)
-									if err != nil {
-										s.Error("failed to get job dependencies", "err", err)
-									}
-									err = s.q.Update(job.Key(), job.getSchedulerGroup(), job, job.Priority, 0*time.Second, ServerItemTTR, deps)
-									if err != nil {
-										s.Error("failed to modify a job in the queue", "err", err)
-									}
-								}
-							}
-						}
-					}
-
 					sr = &serverResponse{Modified: modified}
 
 					// now resume the server again
@@ -612,18 +693,19 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 			if cr.Keys == nil {
 				srerr = ErrBadRequest
 			} else {
-				killable := 0
+				var killed []string
 				for _, jobkey := range cr.Keys {
 					k, err := s.killJob(jobkey)
 					if err != nil {
 						continue
 					}
 					if k {
-						killable++
+						killed = append(killed, jobkey)
 					}
 				}
-				s.Debug("killed jobs", "count", killable)
-				sr = &serverResponse{Existed: killable}
+				s.recordUserAction(EventTypeKill, claimedUser(cr.User), s.jobsWithKeys(killed))
+				s.Debug("killed jobs", "count", len(killed))
+				sr = &serverResponse{Existed: len(killed)}
 			}
 		case "getbc":
 			// get jobs by their keys (which come from their Cmds & Cwds)
@@ -631,7 +713,7 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 				srerr = ErrBadRequest
 			} else {
 				var jobs []*Job
-				jobs, srerr, qerr = s.getJobsByKeys(cr.Keys, cr.GetStd, cr.GetEnv)
+				jobs, srerr, qerr = s.getJobsByKeys(ctx, cr.Keys, cr.GetStd, cr.GetEnv)
 				if len(jobs) > 0 {
 					sr = &serverResponse{Jobs: jobs}
 				}
@@ -642,14 +724,60 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 				srerr = ErrBadRequest
 			} else {
 				var jobs []*Job
-				jobs, srerr, qerr = s.getJobsByRepGroup(cr.Job.RepGroup, cr.Search, cr.Limit, cr.State, cr.GetStd, cr.GetEnv)
+				jobs, srerr, qerr = s.getJobsByRepGroup(ctx, cr.Job.RepGroup, cr.Search, cr.Limit, cr.State, cr.GetStd, cr.GetEnv)
 				if len(jobs) > 0 {
 					sr = &serverResponse{Jobs: jobs}
 				}
 			}
+		case "getcomplete":
+			// get complete jobs by the time they completed
+			repgroup := ""
+			if cr.Job != nil {
+				repgroup = cr.Job.RepGroup
+			}
+			var jobs []*Job
+			jobs, srerr, qerr = s.getCompleteJobsInRange(ctx, repgroup, cr.Search, cr.Since, cr.Until, cr.Limit, cr.GetStd, cr.GetEnv)
+			if len(jobs) > 0 {
+				sr = &serverResponse{Jobs: jobs}
+			}
+		case "getrgs":
+			// get the RepGroups of all jobs ever added
+			rgs, err := s.searchRepGroups("")
+			if err != nil {
+				srerr = ErrDBError
+				qerr = err.Error()
+			} else {
+				sort.Strings(rgs)
+				sr = &serverResponse{RepGroups: rgs}
+			}
+		case "getevents":
+			events, err := s.db.retrieveEvents(cr.Since, cr.EventTypes, cr.Limit)
+			if err != nil {
+				srerr = ErrDBError
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{Events: events}
+			}
+		case "getstatecounts":
+			counts, err := s.stateCountsAt(cr.Until)
+			if err != nil {
+				srerr = ErrDBError
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{StateCounts: flattenStateCounts(counts)}
+			}
 		case "getin":
-			// get all jobs in the jobqueue
-			jobs := s.getJobsCurrent(cr.Limit, cr.State, cr.GetStd, cr.GetEnv)
+			// get all jobs in the jobqueue, avoiding copying them all when we
+			// know we couldn't return them anyway
+			if cr.Limit == 0 && cr.State == "" && tooManyJobs(s.q.Stats().Items) {
+				srerr = ErrTooManyJobs
+				break
+			}
+			var queueName string
+			if cr.Job != nil {
+				queueName = cr.Job.Queue
+			}
+			jobs := s.getJobsCurrentInQueue(queueName, cr.Limit, cr.State, cr.GetStd, cr.GetEnv)
 			if len(jobs) > 0 {
 				sr = &serverResponse{Jobs: jobs}
 			}
@@ -707,6 +835,263 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 			} else {
 				sr = &serverResponse{BadServers: servers}
 			}
+		case "burst":
+			if cr.BurstPolicy != nil {
+				err := s.scheduler.SetBurstPolicy(cr.BurstPolicy)
+				if err != nil {
+					srerr = ErrBadRequest
+					qerr = err.Error()
+					break
+				}
+				s.Info("burst policy changed by request")
+			}
+			status, err := s.scheduler.BurstStatus()
+			if err != nil {
+				srerr = ErrBadRequest
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{Burst: status}
+			}
+		case "sgroups":
+			sr = &serverResponse{SGroups: s.getSchedulerGroups()}
+		case "xferslot", "xferdone":
+			if len(cr.Keys) != 1 {
+				srerr = ErrBadRequest
+				break
+			}
+			if cr.Method == "xferdone" {
+				s.transfers.release(cr.Keys[0])
+				sr = &serverResponse{}
+				break
+			}
+			sr = &serverResponse{TransferGranted: s.transfers.request(cr.Keys[0], cr.Host), TransferRate: s.transferRate}
+		case "xfers":
+			sr = &serverResponse{Transfers: s.transfers.list()}
+		case "setsecret", "delsecret", "listsecrets":
+			if s.secrets == nil {
+				srerr = ErrNoSecrets
+				break
+			}
+			var grant string
+			var err error
+			switch cr.Method {
+			case "setsecret":
+				grant, err = s.secrets.set(cr.SecretName, cr.SecretValue)
+			case "delsecret":
+				err = s.secrets.delete(cr.SecretName)
+			}
+			if err != nil {
+				srerr = ErrBadSecret
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{Secrets: s.secrets.names(), SecretGrant: grant}
+			}
+		case "setsetting":
+			if err := s.setSetting(cr.SettingName, cr.SettingValue); err != nil {
+				srerr = ErrBadSetting
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{Settings: s.currentSettings()}
+			}
+		case "savebset":
+			if cr.BehaviourSet == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			if err := s.saveBehaviourSet(cr.BehaviourSet); err != nil {
+				srerr = ErrBadBehaviour
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{BehaviourSets: []*BehaviourSet{cr.BehaviourSet}}
+			}
+		case "getbset", "delbset":
+			if cr.BehaviourSet == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			var bset *BehaviourSet
+			var found bool
+			var err error
+			if cr.Method == "getbset" {
+				bset, err = s.behaviourSet(cr.BehaviourSet.Name, cr.BehaviourSet.Version)
+				found = bset != nil
+			} else {
+				found, err = s.deleteBehaviourSet(cr.BehaviourSet.Name)
+			}
+			switch {
+			case err != nil:
+				srerr = ErrDBError
+				qerr = err.Error()
+			case !found:
+				srerr = ErrNoBehaviourSet
+			case bset != nil:
+				sr = &serverResponse{BehaviourSets: []*BehaviourSet{bset}}
+			}
+		case "listbsets":
+			bsets, err := s.behaviourSets()
+			if err != nil {
+				srerr = ErrDBError
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{BehaviourSets: bsets}
+			}
+		case "setbudget":
+			if cr.Budget == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			budget, err := s.setBudget(cr.Budget)
+			if err != nil {
+				srerr = ErrBadRequest
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{Budgets: []*Budget{budget}}
+			}
+		case "getbudgets":
+			sr = &serverResponse{Budgets: s.budgetList()}
+		case "delbudget":
+			if cr.Budget == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			found, err := s.deleteBudget(cr.Budget.RepGroup)
+			switch {
+			case err != nil:
+				srerr = ErrDBError
+				qerr = err.Error()
+			case !found:
+				srerr = ErrNoBudget
+			}
+		case "annotate":
+			if cr.Annotation == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			annotation, err := s.annotate(cr.Annotation, claimedUser(cr.User))
+			if err != nil {
+				srerr = ErrBadRequest
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{Annotations: []*Annotation{annotation}}
+			}
+		case "getannotations":
+			annotations, err := s.annotations(cr.Keys, cr.RepGroups)
+			if err != nil {
+				srerr = ErrDBError
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{Annotations: annotations}
+			}
+		case "setstartrate":
+			if cr.StartRate == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			rate, err := s.setStartRate(cr.StartRate)
+			if err != nil {
+				srerr = ErrBadRequest
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{StartRates: []*StartRate{rate}}
+			}
+		case "getstartrates":
+			sr = &serverResponse{StartRates: s.startRateList()}
+		case "delstartrate":
+			if cr.StartRate == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			found, err := s.deleteStartRate(cr.StartRate.RepGroup)
+			switch {
+			case err != nil:
+				srerr = ErrDBError
+				qerr = err.Error()
+			case !found:
+				srerr = ErrNoStartRate
+			}
+		case "setlimitfb":
+			if cr.LimitFeedback == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			feedback, err := s.setLimitFeedback(cr.LimitFeedback)
+			if err != nil {
+				srerr = ErrBadRequest
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{LimitFeedback: []*LimitFeedback{feedback}}
+			}
+		case "getlimitfbs":
+			sr = &serverResponse{LimitFeedback: s.limitFeedbackList()}
+		case "dellimitfb":
+			if cr.LimitFeedback == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			found, err := s.deleteLimitFeedback(cr.LimitFeedback.Group)
+			switch {
+			case err != nil:
+				srerr = ErrDBError
+				qerr = err.Error()
+			case !found:
+				srerr = ErrNoLimitFeedback
+			}
+		case "setqueue":
+			if cr.QueueConfig == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			qc, err := s.setQueueConfig(cr.QueueConfig)
+			if err != nil {
+				srerr = ErrBadRequest
+				qerr = err.Error()
+			} else {
+				sr = &serverResponse{QueueConfigs: []*QueueConfig{qc}}
+			}
+		case "getqueues":
+			sr = &serverResponse{QueueConfigs: s.queueConfigList()}
+		case "delqueue":
+			if cr.QueueConfig == nil {
+				srerr = ErrBadRequest
+				break
+			}
+			found, err := s.deleteQueueConfig(cr.QueueConfig.Name)
+			switch {
+			case err != nil:
+				srerr = ErrDBError
+				qerr = err.Error()
+			case !found:
+				srerr = ErrNoQueue
+			}
+		case "getsettings":
+			sr = &serverResponse{Settings: s.currentSettings()}
+		case "getsecrets":
+			// only the runner that reserved a job can get its secrets, and not
+			// if it was reserved by key to be run manually
+			var job *Job
+			_, job, srerr = s.getij(cr, true)
+			if srerr == "" {
+				if s.secrets == nil {
+					srerr = ErrNoSecrets
+					break
+				}
+				job.RLock()
+				names := job.Secrets
+				manual := job.reservedManually
+				job.RUnlock()
+				if manual {
+					srerr = ErrBadSecret
+					qerr = "secrets are not given to jobs being run manually"
+					break
+				}
+				env, err := s.secrets.env(names)
+				if err != nil {
+					srerr = ErrBadSecret
+					qerr = err.Error()
+				} else {
+					sr = &serverResponse{Secrets: env}
+				}
+			}
 		case "getsetlg":
 			if cr.LimitGroup == "" {
 				srerr = ErrBadRequest
@@ -719,18 +1104,69 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 					sr = &serverResponse{Limit: limit}
 				}
 			}
+		case "fsck":
+			s.Debug("fsck requested", "repair", cr.Repair)
+			report, err := s.fsck(cr.Repair)
+			if err != nil {
+				srerr = ErrDBError
+				qerr = err.Error()
+			} else {
+				if cr.Repair && report.Problems() > 0 {
+					s.Info("repaired inconsistencies by request", "user", cr.User, "problems", report.Problems())
+				}
+				sr = &serverResponse{Fsck: report}
+			}
+		case "drainhost":
+			if cr.Host == "" {
+				srerr = ErrBadRequest
+			} else {
+				sr = &serverResponse{Running: s.drainHost(cr.Host, cr.Timeout)}
+			}
+		case "undrainhost":
+			if cr.Host == "" {
+				srerr = ErrBadRequest
+			} else {
+				s.undrainHost(cr.Host)
+			}
+		case "getsethl":
+			if cr.LimitGroup == "" {
+				srerr = ErrBadRequest
+			} else {
+				limit, serr, err := s.getSetHostLimit(cr.LimitGroup)
+				if err != nil {
+					srerr = serr
+					qerr = err.Error()
+				} else {
+					sr = &serverResponse{Limit: limit}
+				}
+			}
 		default:
 			srerr = ErrUnknownCommand
 		}
 	}
 
+	// don't try to send the client more jobs than it's sensible to hold in
+	// memory at once
+	if sr != nil && tooManyJobs(len(sr.Jobs)) {
+		qerr = fmt.Sprintf("%s (%d jobs)", ErrTooManyJobs, len(sr.Jobs))
+		srerr = ErrTooManyJobs
+		sr = nil
+	}
+
 	// on error, just send the error back to client and return a more detailed
 	// error for logging
 	if srerr != "" {
-		errr := s.reply(m, &serverResponse{Err: srerr})
+		if srerr == ErrRequestAbandoned {
+			// don't remember this reply, so a retry of the request (from a
+			// client that went away and came back) gets carried out
+			s.requests.abandon(cr, pending)
+			pending = nil
+		}
+		errr := s.reply(m, &serverResponse{Err: srerr}, pending)
 		if errr != nil {
 			s.Warn("reply to client failed", "err", errr)
 		}
+		s.requests.forgetAdd(cr)
 		if qerr == "" {
 			qerr = srerr
 		}
@@ -747,7 +1183,7 @@ func (s *Server) handleRequest(m *mangos.Message) error {
 	}
 
 	// send reply to client
-	return s.reply(m, sr) // *** log failure to reply?
+	return s.reply(m, sr, pending) // *** log failure to reply?
 }
 
 // for the many j* methods in handleRequest, we do this common stuff to get
@@ -801,14 +1237,24 @@ func (s *Server) itemToJob(item *queue.Item, getStd bool, getEnv bool) *Job {
 		ReqGroup:      sjob.ReqGroup,
 		LimitGroups:   sjob.LimitGroups,
 		DepGroups:     sjob.DepGroups,
+		AtomicGroup:   sjob.AtomicGroup,
+		Queue:         sjob.Queue,
 		Cmd:           sjob.Cmd,
 		Cwd:           sjob.Cwd,
 		CwdMatters:    sjob.CwdMatters,
 		ChangeHome:    sjob.ChangeHome,
+		CwdTemplate:   sjob.CwdTemplate,
+		CwdBase:       sjob.CwdBase,
+		CwdLink:       sjob.CwdLink,
+		CleanEnv:      sjob.CleanEnv,
 		ActualCwd:     sjob.ActualCwd,
 		Requirements:  req,
+		Override:      sjob.Override,
 		Priority:      sjob.Priority,
 		Retries:       sjob.Retries,
+		RetryBudgets:  sjob.RetryBudgets,
+		Affinity:      sjob.Affinity,
+		MaxPerHost:    sjob.MaxPerHost,
 		PeakRAM:       sjob.PeakRAM,
 		PeakDisk:      sjob.PeakDisk,
 		Exited:        sjob.Exited,
@@ -820,6 +1266,7 @@ func (s *Server) itemToJob(item *queue.Item, getStd bool, getEnv bool) *Job {
 		Host:          sjob.Host,
 		HostID:        sjob.HostID,
 		HostIP:        sjob.HostIP,
+		Scheduler:     sjob.Scheduler,
 		CPUtime:       sjob.CPUtime,
 		State:         state,
 		Attempts:      sjob.Attempts,
@@ -830,13 +1277,48 @@ func (s *Server) itemToJob(item *queue.Item, getStd bool, getEnv bool) *Job {
 		Dependencies:  sjob.Dependencies,
 		Behaviours:    sjob.Behaviours,
 		MountConfigs:  sjob.MountConfigs,
+		InputFiles:    sjob.InputFiles,
 		MonitorDocker: sjob.MonitorDocker,
+		RunAs:         sjob.RunAs,
+		Shell:         sjob.Shell,
+		Nice:          sjob.Nice,
+		IONice:        sjob.IONice,
+		OOMScoreAdj:   sjob.OOMScoreAdj,
+		Umask:         sjob.Umask,
+		Group:         sjob.Group,
+		Secrets:       sjob.Secrets,
+		ReportCmd:     sjob.ReportCmd,
+		TraceID:       sjob.TraceID,
+		Metrics:       sjob.Metrics,
 		BsubMode:      sjob.BsubMode,
 		BsubID:        sjob.BsubID,
 	}
+	job.BehaviourResults = sjob.BehaviourResults
+	job.FailureArchive = sjob.FailureArchive
+	job.InputCheckOnRunner = sjob.InputCheckOnRunner
+	job.OutputFiles = sjob.OutputFiles
+	job.OutputMinSize = sjob.OutputMinSize
+	job.OutputCheckCmd = sjob.OutputCheckCmd
+	job.OutputChecksums = sjob.OutputChecksums
+	job.OutputRecords = sjob.OutputRecords
+	job.Cacheable = sjob.Cacheable
+	job.InputChecksums = sjob.InputChecksums
+	job.CachedInputChecksums = sjob.CachedInputChecksums
+	job.Cached = sjob.Cached
+	job.IRODSInputs = sjob.IRODSInputs
+	job.IRODSCollection = sjob.IRODSCollection
+	job.IRODSMetadata = sjob.IRODSMetadata
+	job.NetworkAccess = sjob.NetworkAccess
+	job.Proxy = sjob.Proxy
+	job.RefAssets = sjob.RefAssets
+	job.LastAction = sjob.LastAction
 
-	if state == JobStateReserved && !sjob.StartTime.IsZero() {
-		job.State = JobStateRunning
+	if state == JobStateReserved {
+		if sjob.phase != "" {
+			job.State = sjob.phase
+		} else if !sjob.StartTime.IsZero() {
+			job.State = JobStateRunning
+		}
 	}
 	sjob.RUnlock()
 	s.jobPopulateStdEnv(job, getStd, getEnv)
@@ -844,12 +1326,15 @@ func (s *Server) itemToJob(item *queue.Item, getStd bool, getEnv bool) *Job {
 }
 
 // jobPopulateStdEnv fills in the StdOutC, StdErrC and EnvC values for a Job,
-// extracting them from the database.
+// extracting them from the database. StdOutC and StdErrC are redacted again in
+// case they were stored before the current RedactionRules were in place.
 func (s *Server) jobPopulateStdEnv(job *Job, getStd bool, getEnv bool) {
 	job.Lock()
 	defer job.Unlock()
 	if getStd && ((job.Exited && job.Exitcode != 0) || job.State == JobStateBuried) {
-		job.StdOutC, job.StdErrC = s.db.retrieveJobStd(job.Key())
+		stdo, stde := s.db.retrieveJobStd(job.Key())
+		job.StdOutC = s.redactionRules.redactCompressed(stdo)
+		job.StdErrC = s.redactionRules.redactCompressed(stde)
 	}
 	if getEnv {
 		job.EnvC = s.db.retrieveEnv(job.EnvKey)
@@ -861,13 +1346,24 @@ func (s *Server) jobPopulateStdEnv(job *Job, getStd bool, getEnv bool) {
 // the given scheduler group). If (and only if!) a scheduler group was supplied,
 // and it is suffixed with limit groups, those limit groups will be incremented.
 // On success we reserve and return as normal. On failure, we act as if the
-// queue was empty.
-func (s *Server) reserveWithLimits(group string, wait time.Duration) (*queue.Item, error) {
+// queue was empty. If host is supplied, jobs with the same Affinity as jobs
+// that recently started on that host are preferred, and we also act as if the
+// queue was empty if the host is being drained, or is already running as many
+// jobs from the group as its MaxPerHost or the per-host limits of its limit
+// groups allow.
+func (s *Server) reserveWithLimits(ctx context.Context, group, host string, wait time.Duration) (*queue.Item, error) {
 	var item *queue.Item
 	var err error
+	if draining, _ := s.hostDraining(host); draining {
+		return nil, queue.Error{Queue: s.q.Name, Op: "Reserve", Item: "", Err: queue.ErrNothingReady}
+	}
+
 	var limitGroups []string
+	var hostLimited bool
 	if group != "" {
 		limitGroups = s.schedGroupToLimitGroups(group)
+		hostLimited = host != "" && s.hostLimited(group, limitGroups)
+
 		if len(limitGroups) > 0 {
 			// it is better to call Increment before Reserve and possibly use up
 			// the limit for up to wait period if there's no item in the queue,
@@ -881,7 +1377,16 @@ func (s *Server) reserveWithLimits(group string, wait time.Duration) (*queue.Ite
 		}
 	}
 
-	item, err = s.q.Reserve(group, wait)
+	var prefer func(data interface{}) bool
+	if warm := s.affinities.warm(host); warm != nil {
+		prefer = preferWarm(warm)
+	}
+	if hostLimited {
+		item, err = s.reserveWithinHostLimits(ctx, host, group, limitGroups, wait, prefer)
+	} else {
+		item, err = s.q.ReserveContext(ctx, group, wait, prefer)
+		item, err = s.holdRateLimited(ctx, item, err, group, prefer)
+	}
 
 	if len(limitGroups) > 0 {
 		if item == nil {
@@ -891,28 +1396,117 @@ func (s *Server) reserveWithLimits(group string, wait time.Duration) (*queue.Ite
 		}
 	}
 
+	if item != nil && !hostLimited {
+		job := item.Data().(*Job)
+		job.Lock()
+		job.reservedHost = host
+		job.Unlock()
+		s.hostLimiter.noteRunning(host, job)
+	}
+
+	return item, err
+}
+
+// holdRateLimited takes the result of reserving from the given scheduler group,
+// and if the reserved item's RepGroup has used up its StartRate, delays the
+// item until more starts are allowed and reserves another instead, returning
+// the first item that may start, or an ErrNothingReady error if there are
+// none.
+func (s *Server) holdRateLimited(ctx context.Context, item *queue.Item, err error, group string, prefer func(data interface{}) bool) (*queue.Item, error) {
+	for item != nil {
+		job := item.Data().(*Job)
+		job.RLock()
+		rg := job.RepGroup
+		job.RUnlock()
+
+		hold := s.startRateHold(rg)
+		if hold == 0 {
+			return item, err
+		}
+
+		// as in reservedJob(), forget any previous run, so that it isn't
+		// treated as having stopped running again when we delay it
+		job.Lock()
+		var tnil time.Time
+		job.StartTime = tnil
+		job.EndTime = tnil
+		job.Unlock()
+		if errr := s.q.ReleaseWithDelay(item.Key, hold); errr != nil {
+			s.Warn("failed to delay rate limited job", "rg", rg, "err", errr)
+		} else {
+			s.Debug("delayed rate limited job", "rg", rg, "delay", hold)
+		}
+		item, err = s.q.ReserveContext(ctx, group, 0, prefer)
+	}
 	return item, err
 }
 
+// reservedJob cleans up any past state of the job in the given item that the
+// given client just reserved, to have a fresh job ready to run, and returns a
+// copy of the job for the client.
+func (s *Server) reservedJob(item *queue.Item, cr *clientRequest) *Job {
+	sjob := item.Data().(*Job)
+	sjob.Lock()
+	sjob.ReservedBy = cr.ClientID //*** we should unset this on moving out of run state, to save space
+	sjob.reservedManually = false
+	sjob.Exited = false
+	sjob.Pid = 0
+	sjob.Host = ""
+	var tnil time.Time
+	sjob.StartTime = tnil
+	sjob.EndTime = tnil
+	sjob.PeakRAM = 0
+	sjob.PeakDisk = 0
+	sjob.Exitcode = -1
+	sjob.BehaviourResults = nil
+	sjob.OutputRecords = nil
+	sjob.InputChecksums = nil
+	sgroup := sjob.schedulerGroup
+	sjob.Unlock()
+
+	errd := s.q.SetDelay(item.Key, s.jobRetryDelay())
+	if errd != nil {
+		s.Warn("reserve queue SetDelay failed", "err", errd)
+	}
+
+	// make a copy of the job with some extra stuff filled in (that we don't
+	// want taking up memory here) for the client
+	job := s.itemToJob(item, false, true)
+	s.Debug("reserved job", "cmd", job.Cmd, "schedGrp", sgroup)
+	return job
+}
+
 // schedGroupToLimitGroups takes a scheduler group that may be suffixed with
 // limit groups (by Job.generateSchedulerGroup()), and returns the extracted
 // limit groups
 func (s *Server) schedGroupToLimitGroups(group string) []string {
-	parts := strings.Split(group, jobSchedLimitGroupSeparator)
+	parts := strings.Split(trimSchedGroupMaxPerHost(group), jobSchedLimitGroupSeparator)
 	if len(parts) == 2 {
 		return strings.Split(parts[1], jobLimitGroupSeparator)
 	}
 	return nil
 }
 
-// reply to a client
-func (s *Server) reply(m *mangos.Message, sr *serverResponse) error {
+// reply to a client, remembering the reply in pending (if not nil) so that
+// retries of the request can be sent the same reply. m.Body must still be the
+// client's request, which we reply to in the same encoding.
+func (s *Server) reply(m *mangos.Message, sr *serverResponse, pending *requestResponse) error {
 	var encoded []byte
-	enc := codec.NewEncoderBytes(&encoded, s.ch)
-	err := enc.Encode(sr)
+	var err error
+	if isFramed(m.Body) {
+		if pending == nil {
+			// we're done with the request, so can reuse its (pooled) buffer
+			encoded = m.Body[:0]
+		}
+		encoded, err = encodeFrame(encoded, sr, s.ch, true)
+	} else {
+		enc := codec.NewEncoderBytes(&encoded, s.ch)
+		err = enc.Encode(sr)
+	}
 	if err != nil {
 		return err
 	}
+	pending.finish(encoded)
 	m.Body = encoded
 	err = s.sock.SendMsg(m)
 	return err
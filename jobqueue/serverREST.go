@@ -26,9 +26,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -47,10 +51,22 @@ const (
 	restBadServersEndpoint = "/rest/v" + restAPIVersion + "/servers/"
 	restFileUploadEndpoint = "/rest/v" + restAPIVersion + "/upload/"
 	restInfoEndpoint       = "/rest/v" + restAPIVersion + "/info/"
+	restExportEndpoint     = "/rest/v" + restAPIVersion + "/export/"
+	restCopyEndpoint       = "/rest/v" + restAPIVersion + "/copy/"
+	restArchiveEndpoint    = "/rest/v" + restAPIVersion + "/failure_archive/"
 	restFormTrue           = "true"
 	bearerSchema           = "Bearer "
 )
 
+// the names of the sections of a multipart/form-data POST to restJobsEndpoint,
+// and the maximum size of the small ones.
+const (
+	restPartJobs          = "jobs"
+	restPartEnv           = "env"
+	restPartInputManifest = "input_manifest"
+	restPartMaxSize       = 1024 * 1024
+)
+
 // JobViaJSON describes the properties of a JOB that a user wishes to add to the
 // queue, convenient if they are supplying JSON.
 type JobViaJSON struct {
@@ -59,10 +75,19 @@ type JobViaJSON struct {
 	DepGrps      []string          `json:"dep_grps"`
 	Deps         []string          `json:"deps"`
 	CmdDeps      Dependencies      `json:"cmd_deps"`
+	RepGrpDeps   []string          `json:"rep_grp_deps"`
 	OnFailure    BehavioursViaJSON `json:"on_failure"`
 	OnSuccess    BehavioursViaJSON `json:"on_success"`
 	OnExit       BehavioursViaJSON `json:"on_exit"`
+	RetryBudgets map[string]int    `json:"retry_budgets"`
 	Env          []string          `json:"env"`
+	Secrets      []string          `json:"secrets"`
+	InputFiles   []string          `json:"input_files"`
+	OutputFiles  []string          `json:"output_files"`
+	IRODSInputs  []string          `json:"irods_inputs"`
+	IRODSMeta    map[string]string `json:"irods_meta"`
+	Networks     []string          `json:"network_access"`
+	RefAssets    []*RefAsset       `json:"ref_assets"`
 	Cmd          string            `json:"cmd"`
 	Cwd          string            `json:"cwd"`
 	ReqGrp       string            `json:"req_grp"`
@@ -71,7 +96,22 @@ type JobViaJSON struct {
 	// Time is a duration with a unit suffix, eg. 1h for 1 hour.
 	Time             string   `json:"time"`
 	RepGrp           string   `json:"rep_grp"`
+	AtomicGrp        string   `json:"atomic_grp"`
+	WorkQueue        string   `json:"work_queue"`
 	MonitorDocker    string   `json:"monitor_docker"`
+	RunAs            string   `json:"run_as"`
+	CwdTemplate      string   `json:"cwd_template"`
+	CwdBase          string   `json:"cwd_base"`
+	Shell            string   `json:"shell"`
+	IONice           string   `json:"ionice"`
+	Umask            string   `json:"umask"`
+	Group            string   `json:"group"`
+	ReportCmd        string   `json:"report_cmd"`
+	OutputCheckCmd   string   `json:"output_check_cmd"`
+	IRODSCollection  string   `json:"irods_collection"`
+	Proxy            string   `json:"proxy"`
+	TraceID          string   `json:"trace_id"`
+	Affinity         string   `json:"affinity"`
 	CloudOS          string   `json:"cloud_os"`
 	CloudUser        string   `json:"cloud_username"`
 	CloudScript      string   `json:"cloud_script"`
@@ -79,6 +119,7 @@ type JobViaJSON struct {
 	CloudFlavor      string   `json:"cloud_flavor"`
 	SchedulerQueue   string   `json:"queue"`
 	SchedulerMisc    string   `json:"misc"`
+	Scheduler        string   `json:"scheduler"`
 	BsubMode         string   `json:"bsub_mode"`
 	CPUs             *float64 `json:"cpus"`
 	// Disk is the number of Gigabytes the cmd will use.
@@ -86,31 +127,74 @@ type JobViaJSON struct {
 	Override    *int `json:"override"`
 	Priority    *int `json:"priority"`
 	Retries     *int `json:"retries"`
+	MaxPerHost  *int `json:"max_per_host"`
+	Nice        *int `json:"nice"`
+	OOMScoreAdj *int `json:"oom_score_adj"`
 	CloudOSRam  *int `json:"cloud_ram"`
+	CloudVolume *int `json:"cloud_volume"`
 	RTimeout    *int `json:"reserve_timeout"`
 	CwdMatters  bool `json:"cwd_matters"`
 	ChangeHome  bool `json:"change_home"`
+	CwdLink     bool `json:"cwd_link"`
+	CleanEnv    bool `json:"clean_env"`
 	CloudShared bool `json:"cloud_shared"`
+	// RunnerInputCheck is as for Job.InputCheckOnRunner.
+	RunnerInputCheck bool `json:"runner_input_check"`
+	// OutputMinSize is the minimum number of bytes each OutputFile must be.
+	OutputMinSize *int `json:"output_min_size"`
+	// OutputChecksums is as for Job.OutputChecksums.
+	OutputChecksums bool `json:"output_checksums"`
+	// Cacheable is as for Job.Cacheable.
+	Cacheable bool `json:"cacheable"`
+	// SchedulerArgs are site-specific options passed through to the scheduler
+	// that runs the cmd, keyed on the scheduler's name (eg. "lsf"); see
+	// scheduler.ValidateArgs() for what each scheduler accepts.
+	SchedulerArgs map[string]string `json:"scheduler_args"`
 }
 
 // JobDefaults is supplied to JobViaJSON.Convert() to provide default values for
 // the conversion.
 type JobDefaults struct {
 	LimitGroups   []string
+	Secrets       []string
+	InputFiles    []string
+	OutputFiles   []string
+	IRODSInputs   []string
+	IRODSMeta     map[string]string
+	Networks      []string
+	RefAssets     []*RefAsset
 	DepGroups     []string
 	Deps          Dependencies
 	OnFailure     Behaviours
 	OnSuccess     Behaviours
 	OnExit        Behaviours
 	MountConfigs  MountConfigs
+	RetryBudgets  map[string]int
+	SchedulerArgs map[string]string
 	compressedEnv []byte
 	RepGrp        string
+	AtomicGrp     string
+	// WorkQueue is the named queue (see QueueConfig) to add cmds to.
+	WorkQueue string
 	// Cwd defaults to /tmp.
 	Cwd    string
 	ReqGrp string
 	// Env is a comma separated list of key=val pairs.
 	Env           string
 	MonitorDocker string
+	RunAs         string
+	Shell         string
+	IONice        string
+	Umask         string
+	Group         string
+	CwdTemplate   string
+	CwdBase       string
+	ReportCmd     string
+	OutputCheck   string
+	IRODSColl     string
+	Proxy         string
+	TraceID       string
+	Affinity      string
 	CloudOS       string
 	CloudUser     string
 	CloudFlavor   string
@@ -120,8 +204,11 @@ type JobDefaults struct {
 	CloudConfigFiles string
 	SchedulerQueue   string
 	SchedulerMisc    string
-	BsubMode         string
-	osRAM            string
+	// Scheduler is the name of the scheduler to route to, when the manager
+	// is using more than one.
+	Scheduler string
+	BsubMode  string
+	osRAM     string
 	// CPUs is the number of CPU cores each cmd will use.
 	CPUs   float64 // Memory is the number of Megabytes each cmd will use. Defaults to 1000.
 	Memory int
@@ -135,13 +222,29 @@ type JobDefaults struct {
 	// CloudOSRam is the number of Megabytes that CloudOS needs to run. Defaults
 	// to 1000.
 	CloudOSRam int
-	RTimeout   int
-	CwdMatters bool
-	ChangeHome bool
+	// CloudVolume is the size in GB of a volume to attach for each cmd.
+	CloudVolume int
+	RTimeout    int
+	MaxPerHost  int
+	// Nice and OOMScoreAdj are as for Job; IONice too.
+	Nice        int
+	OOMScoreAdj int
+	CwdMatters  bool
+	ChangeHome  bool
+	CwdLink     bool
+	CleanEnv    bool
 	// DiskSet is used to distinguish between Disk not being provided, and
 	// being provided with a value of 0 or more.
 	DiskSet     bool
 	CloudShared bool
+	// RunnerInputCheck is as for Job.InputCheckOnRunner.
+	RunnerInputCheck bool
+	// OutputMinSize is the minimum number of bytes each OutputFile must be.
+	OutputMinSize int
+	// OutputChecksums is as for Job.OutputChecksums.
+	OutputChecksums bool
+	// Cacheable is as for Job.Cacheable.
+	Cacheable bool
 }
 
 // DefaultCwd returns the Cwd value, defaulting to /tmp.
@@ -202,7 +305,7 @@ func (jd *JobDefaults) DefaultCloudOSRam() string {
 // properties of this JobViaJSON. The Job will not be in the queue until passed
 // to a method that adds jobs to the queue.
 func (jvj *JobViaJSON) Convert(jd *JobDefaults) (*Job, error) {
-	var cmd, cwd, rg, repg, monitorDocker string
+	var cmd, cwd, rg, repg, atomicg, monitorDocker, runAs, shell, reportCmd string
 	var mb, disk, override, priority, retries int
 	var diskSet bool
 	var cpus float64
@@ -241,6 +344,31 @@ func (jvj *JobViaJSON) Convert(jd *JobDefaults) (*Job, error) {
 		changeHome = true
 	}
 
+	cwdTemplate := jvj.CwdTemplate
+	if cwdTemplate == "" {
+		cwdTemplate = jd.CwdTemplate
+	}
+	if cwdTemplate != "" {
+		if err := ValidateCwdTemplate(cwdTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	cwdBase := jvj.CwdBase
+	if cwdBase == "" {
+		cwdBase = jd.CwdBase
+	}
+
+	cwdLink := jd.CwdLink
+	if jvj.CwdLink {
+		cwdLink = true
+	}
+
+	cleanEnv := jd.CleanEnv
+	if jvj.CleanEnv {
+		cleanEnv = true
+	}
+
 	if jvj.ReqGrp == "" {
 		if jd.ReqGrp != "" {
 			rg = jd.ReqGrp
@@ -313,6 +441,52 @@ func (jvj *JobViaJSON) Convert(jd *JobDefaults) (*Job, error) {
 		return nil, fmt.Errorf("retries value (%d) is not in the range 0..255", retries)
 	}
 
+	maxPerHost := jd.MaxPerHost
+	if jvj.MaxPerHost != nil {
+		maxPerHost = *jvj.MaxPerHost
+	}
+	if maxPerHost < 0 {
+		return nil, fmt.Errorf("max_per_host value (%d) is negative", maxPerHost)
+	}
+
+	politeness := Politeness{Nice: jd.Nice, IONice: jd.IONice, OOMScoreAdj: jd.OOMScoreAdj}
+	if jvj.Nice != nil {
+		politeness.Nice = *jvj.Nice
+	}
+	if jvj.IONice != "" {
+		politeness.IONice = jvj.IONice
+	}
+	if jvj.OOMScoreAdj != nil {
+		politeness.OOMScoreAdj = *jvj.OOMScoreAdj
+	}
+	if err := politeness.Validate(); err != nil {
+		return nil, err
+	}
+
+	perms := FilePermissions{Umask: jd.Umask, Group: jd.Group}
+	if jvj.Umask != "" {
+		perms.Umask = jvj.Umask
+	}
+	if jvj.Group != "" {
+		perms.Group = jvj.Group
+	}
+	if err := perms.Validate(); err != nil {
+		return nil, err
+	}
+
+	affinity := jd.Affinity
+	if jvj.Affinity != "" {
+		affinity = jvj.Affinity
+	}
+
+	retryBudgets := jd.RetryBudgets
+	if jvj.RetryBudgets != nil {
+		retryBudgets = jvj.RetryBudgets
+	}
+	if err := validateRetryBudgets(retryBudgets); err != nil {
+		return nil, err
+	}
+
 	if len(jvj.LimitGrps) == 0 {
 		limitGroups = jd.LimitGroups
 	} else {
@@ -325,7 +499,7 @@ func (jvj *JobViaJSON) Convert(jd *JobDefaults) (*Job, error) {
 		depGroups = jvj.DepGrps
 	}
 
-	if len(jvj.Deps) == 0 && len(jvj.CmdDeps) == 0 {
+	if len(jvj.Deps) == 0 && len(jvj.CmdDeps) == 0 && len(jvj.RepGrpDeps) == 0 {
 		deps = jd.Deps
 	} else {
 		if len(jvj.CmdDeps) > 0 {
@@ -336,6 +510,9 @@ func (jvj *JobViaJSON) Convert(jd *JobDefaults) (*Job, error) {
 				deps = append(deps, NewDepGroupDependency(depgroup))
 			}
 		}
+		for _, repgroup := range jvj.RepGrpDeps {
+			deps = append(deps, NewRepGroupDependency(repgroup))
+		}
 	}
 
 	if len(jvj.Env) > 0 {
@@ -367,6 +544,9 @@ func (jvj *JobViaJSON) Convert(jd *JobDefaults) (*Job, error) {
 	} else if len(jd.OnExit) > 0 {
 		behaviours = append(behaviours, jd.OnExit...)
 	}
+	if err := behaviours.Validate(); err != nil {
+		return nil, err
+	}
 
 	if len(jvj.MountConfigs) > 0 {
 		mounts = jvj.MountConfigs
@@ -385,6 +565,105 @@ func (jvj *JobViaJSON) Convert(jd *JobDefaults) (*Job, error) {
 		monitorDocker = jvj.MonitorDocker
 	}
 
+	if jvj.RunAs == "" {
+		runAs = jd.RunAs
+	} else {
+		runAs = jvj.RunAs
+	}
+
+	if jvj.Shell == "" {
+		shell = jd.Shell
+	} else {
+		shell = jvj.Shell
+	}
+
+	if jvj.AtomicGrp == "" {
+		atomicg = jd.AtomicGrp
+	} else {
+		atomicg = jvj.AtomicGrp
+	}
+
+	if jvj.ReportCmd == "" {
+		reportCmd = jd.ReportCmd
+	} else {
+		reportCmd = jvj.ReportCmd
+	}
+
+	inputFiles := jvj.InputFiles
+	if len(inputFiles) == 0 {
+		inputFiles = jd.InputFiles
+	}
+
+	outputFiles := jvj.OutputFiles
+	if len(outputFiles) == 0 {
+		outputFiles = jd.OutputFiles
+	}
+
+	outputCheckCmd := jvj.OutputCheckCmd
+	if outputCheckCmd == "" {
+		outputCheckCmd = jd.OutputCheck
+	}
+
+	irodsInputs := jvj.IRODSInputs
+	if len(irodsInputs) == 0 {
+		irodsInputs = jd.IRODSInputs
+	}
+
+	irodsColl := jvj.IRODSCollection
+	if irodsColl == "" {
+		irodsColl = jd.IRODSColl
+	}
+	if irodsColl != "" && len(outputFiles) == 0 {
+		return nil, fmt.Errorf("irods_collection (%s) was specified without output_files", irodsColl)
+	}
+
+	irodsMeta := jvj.IRODSMeta
+	if len(irodsMeta) == 0 {
+		irodsMeta = jd.IRODSMeta
+	}
+
+	networks := jvj.Networks
+	if len(networks) == 0 {
+		networks = jd.Networks
+	}
+	if err := validateNetworkAccess(networks); err != nil {
+		return nil, err
+	}
+
+	refAssets := jvj.RefAssets
+	if len(refAssets) == 0 {
+		refAssets = jd.RefAssets
+	}
+	if err := validateRefAssets(refAssets); err != nil {
+		return nil, err
+	}
+
+	proxy := jvj.Proxy
+	if proxy == "" {
+		proxy = jd.Proxy
+	}
+	if err := validateProxy(proxy); err != nil {
+		return nil, err
+	}
+
+	traceID := jvj.TraceID
+	if traceID == "" {
+		traceID = jd.TraceID
+	}
+	if traceID != "" && !ValidTraceID(traceID) {
+		return nil, fmt.Errorf("%s: %s", ErrBadTraceID, traceID)
+	}
+
+	outputMinSize := jd.OutputMinSize
+	if jvj.OutputMinSize != nil {
+		outputMinSize = *jvj.OutputMinSize
+	}
+
+	secrets := jvj.Secrets
+	if len(secrets) == 0 {
+		secrets = jd.Secrets
+	}
+
 	// scheduler-specific options
 	other := make(map[string]string)
 	if jvj.CloudOS != "" {
@@ -436,6 +715,14 @@ func (jvj *JobViaJSON) Convert(jd *JobDefaults) (*Job, error) {
 		other["cloud_shared"] = "true"
 	}
 
+	if jvj.CloudVolume != nil {
+		if *jvj.CloudVolume > 0 {
+			other[jqs.VolumeOtherKey] = strconv.Itoa(*jvj.CloudVolume)
+		}
+	} else if jd.CloudVolume > 0 {
+		other[jqs.VolumeOtherKey] = strconv.Itoa(jd.CloudVolume)
+	}
+
 	if jvj.SchedulerQueue != "" {
 		other["scheduler_queue"] = jvj.SchedulerQueue
 	} else if jd.SchedulerQueue != "" {
@@ -448,6 +735,25 @@ func (jvj *JobViaJSON) Convert(jd *JobDefaults) (*Job, error) {
 		other["scheduler_misc"] = jd.SchedulerMisc
 	}
 
+	schedulerArgs := jd.SchedulerArgs
+	if jvj.SchedulerArgs != nil {
+		schedulerArgs = jvj.SchedulerArgs
+	}
+	for name, args := range schedulerArgs {
+		if err := jqs.ValidateArgs(name, args); err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrBadSchedulerArgs, err)
+		}
+		if args != "" {
+			other[jqs.ArgsOtherKeyPrefix+name] = args
+		}
+	}
+
+	if jvj.Scheduler != "" {
+		other[jqs.MultiOtherKey] = jvj.Scheduler
+	} else if jd.Scheduler != "" {
+		other[jqs.MultiOtherKey] = jd.Scheduler
+	}
+
 	if jvj.RTimeout != nil {
 		rtimeout := *jvj.RTimeout
 		other["rtimeout"] = strconv.Itoa(rtimeout)
@@ -455,26 +761,69 @@ func (jvj *JobViaJSON) Convert(jd *JobDefaults) (*Job, error) {
 		other["rtimeout"] = strconv.Itoa(jd.RTimeout)
 	}
 
-	return &Job{
+	if len(networks) > 0 {
+		sorted := make([]string, len(networks))
+		copy(sorted, networks)
+		sort.Strings(sorted)
+		other[jqs.NetworkOtherKey] = strings.Join(sorted, ",")
+	}
+
+	job := &Job{
 		RepGroup:      repg,
 		Cmd:           cmd,
 		Cwd:           cwd,
 		CwdMatters:    cwdMatters,
 		ChangeHome:    changeHome,
+		CwdTemplate:   cwdTemplate,
+		CwdBase:       cwdBase,
+		CwdLink:       cwdLink,
+		CleanEnv:      cleanEnv,
 		ReqGroup:      rg,
 		Requirements:  &jqs.Requirements{RAM: mb, Time: dur, Cores: cpus, Disk: disk, DiskSet: diskSet, Other: other},
 		Override:      uint8(override),
 		Priority:      uint8(priority),
 		Retries:       uint8(retries),
+		RetryBudgets:  retryBudgets,
+		Affinity:      affinity,
+		MaxPerHost:    maxPerHost,
 		LimitGroups:   limitGroups,
 		DepGroups:     depGroups,
 		Dependencies:  deps,
+		AtomicGroup:   atomicg,
 		EnvOverride:   envOverride,
 		Behaviours:    behaviours,
 		MountConfigs:  mounts,
+		InputFiles:    inputFiles,
 		MonitorDocker: monitorDocker,
+		RunAs:         runAs,
+		Shell:         shell,
+		Nice:          politeness.Nice,
+		IONice:        politeness.IONice,
+		OOMScoreAdj:   politeness.OOMScoreAdj,
+		Umask:         perms.Umask,
+		Group:         perms.Group,
+		ReportCmd:     reportCmd,
+		TraceID:       traceID,
+		Secrets:       secrets,
 		BsubMode:      bsubMode,
-	}, nil
+	}
+	job.InputCheckOnRunner = jd.RunnerInputCheck || jvj.RunnerInputCheck
+	job.OutputFiles = outputFiles
+	job.OutputMinSize = int64(outputMinSize)
+	job.OutputCheckCmd = outputCheckCmd
+	job.OutputChecksums = jd.OutputChecksums || jvj.OutputChecksums
+	job.Cacheable = jd.Cacheable || jvj.Cacheable
+	job.IRODSInputs = irodsInputs
+	job.IRODSCollection = irodsColl
+	job.IRODSMetadata = irodsMeta
+	job.NetworkAccess = networks
+	job.Proxy = proxy
+	job.RefAssets = refAssets
+	job.Queue = jvj.WorkQueue
+	if job.Queue == "" {
+		job.Queue = jd.WorkQueue
+	}
+	return job, nil
 }
 
 // httpAuthorized checks for parameter 'token' and for Authorization header for
@@ -528,7 +877,16 @@ func restJobs(s *Server) http.HandlerFunc {
 		var err error
 		switch r.Method {
 		case http.MethodGet:
+			if !s.queries.acquire() {
+				http.Error(w, ErrOverloaded, http.StatusServiceUnavailable)
+				return
+			}
 			jobs, status, err = restJobsStatus(r, s)
+			s.queries.release()
+			if err == nil && tooManyJobs(len(jobs)) {
+				status = http.StatusBadRequest
+				err = fmt.Errorf("%s (%d jobs)", ErrTooManyJobs, len(jobs))
+			}
 		case http.MethodPost:
 			jobs, status, err = restJobsAdd(r, s)
 		case http.MethodDelete:
@@ -569,10 +927,14 @@ func restJobs(s *Server) http.HandlerFunc {
 // request url can be suffixed with comma separated job keys or RepGroups.
 // Possible query parameters are search, std, env (which can take a "true"
 // value), limit (a number) and state (one of
-// delayed|ready|reserved|running|lost|buried|dependent|complete|deletable),
+// delayed|ready|reserved|running|staging|uploading|lost|buried|dependent|
+// complete|deletable),
 // where deletable == !(running|complete). Returns the Jobs, a http.Status*
 // value and error.
 func restJobsStatus(r *http.Request, s *Server) ([]*Job, int, error) {
+	ctx, cancel := s.timeoutContext(r.Context())
+	defer cancel()
+
 	// handle possible ?query parameters
 	var search, getStd, getEnv bool
 	var limit int
@@ -604,6 +966,10 @@ func restJobsStatus(r *http.Request, s *Server) ([]*Job, int, error) {
 			state = JobStateReserved
 		case "running":
 			state = JobStateRunning
+		case "staging":
+			state = JobStateStaging
+		case "uploading":
+			state = JobStateUploading
 		case "lost":
 			state = JobStateLost
 		case "buried":
@@ -624,7 +990,10 @@ func restJobsStatus(r *http.Request, s *Server) ([]*Job, int, error) {
 		for _, id := range strings.Split(ids, ",") {
 			if len(id) == 32 {
 				// id might be a Job.key()
-				theseJobs, _, qerr := s.getJobsByKeys([]string{id}, getStd, getEnv)
+				theseJobs, srerr, qerr := s.getJobsByKeys(ctx, []string{id}, getStd, getEnv)
+				if srerr == ErrRequestAbandoned {
+					return nil, http.StatusServiceUnavailable, fmt.Errorf("%s: %s", srerr, qerr)
+				}
 				if qerr == "" && len(theseJobs) > 0 {
 					jobs = append(jobs, theseJobs...)
 					continue
@@ -632,7 +1001,10 @@ func restJobsStatus(r *http.Request, s *Server) ([]*Job, int, error) {
 			}
 
 			// id might be a Job.RepGroup
-			theseJobs, _, qerr := s.getJobsByRepGroup(id, search, limit, state, getStd, getEnv)
+			theseJobs, srerr, qerr := s.getJobsByRepGroup(ctx, id, search, limit, state, getStd, getEnv)
+			if srerr == ErrRequestAbandoned {
+				return nil, http.StatusServiceUnavailable, fmt.Errorf("%s: %s", srerr, qerr)
+			}
 			if qerr != "" {
 				return nil, http.StatusInternalServerError, fmt.Errorf(qerr)
 			}
@@ -652,9 +1024,21 @@ func restJobsStatus(r *http.Request, s *Server) ([]*Job, int, error) {
 //
 // It optionally takes parameters to use as defaults for the job properties,
 // which correspond to the json properties of a JobViaJSON (except for cmd and
-// cmd_deps). For dep_grps, deps and env, which normally take []string, provide
-// a comma-separated list. mounts, on_failure, on_success and on_exit values
-// should be supplied as url query escaped JSON strings.
+// cmd_deps). For dep_grps, deps, rep_grp_deps and env, which normally take
+// []string, provide a comma-separated list. mounts, on_failure, on_success,
+// on_exit, retry_budgets and ref_assets values should be supplied as url query
+// escaped JSON strings. Jobs that use secrets are only accepted if a
+// secret_grants parameter, a url query escaped JSON object of secret names to
+// their grants (see Client.SetSecret()), is supplied for them.
+//
+// Instead of just the JSON, the request can be multipart/form-data, with the
+// JSON in a section named "jobs". An optional "env" section is a JSON array of
+// "key=value" environment variables, such as a snapshot of a client's
+// environment, which the jobs will run with just like jobs added with 'wr add'
+// run with its environment. (Jobs' own env values and the env parameter are
+// still applied on top.) An optional "input_manifest" section lists input
+// files, one per line, that are added to any input_files parameter. These
+// optional sections are limited to 1MB each.
 //
 // The returned int is a http.Status* variable.
 func restJobsAdd(r *http.Request, s *Server) ([]*Job, int, error) {
@@ -664,6 +1048,11 @@ func restJobsAdd(r *http.Request, s *Server) ([]*Job, int, error) {
 		Cwd:           r.Form.Get("cwd"),
 		RepGrp:        r.Form.Get("rep_grp"),
 		LimitGroups:   urlStringToSlice(r.Form.Get("limit_grps")),
+		Secrets:       urlStringToSlice(r.Form.Get("secrets")),
+		InputFiles:    urlStringToSlice(r.Form.Get("input_files")),
+		OutputFiles:   urlStringToSlice(r.Form.Get("output_files")),
+		IRODSInputs:   urlStringToSlice(r.Form.Get("irods_inputs")),
+		Networks:      urlStringToSlice(r.Form.Get("network_access")),
 		ReqGrp:        r.Form.Get("req_grp"),
 		CPUs:          urlStringToFloat(r.Form.Get("cpus")),
 		Disk:          urlStringToInt(r.Form.Get("disk")),
@@ -672,15 +1061,35 @@ func restJobsAdd(r *http.Request, s *Server) ([]*Job, int, error) {
 		Priority:      urlStringToInt(r.Form.Get("priority")),
 		Retries:       urlStringToInt(r.Form.Get("retries")),
 		DepGroups:     urlStringToSlice(r.Form.Get("dep_grps")),
+		AtomicGrp:     r.Form.Get("atomic_grp"),
+		WorkQueue:     r.Form.Get("work_queue"),
 		Env:           r.Form.Get("env"),
 		MonitorDocker: r.Form.Get("monitor_docker"),
+		RunAs:         r.Form.Get("run_as"),
+		Shell:         r.Form.Get("shell"),
+		Nice:          urlStringToInt(r.Form.Get("nice")),
+		IONice:        r.Form.Get("ionice"),
+		OOMScoreAdj:   urlStringToInt(r.Form.Get("oom_score_adj")),
+		Umask:         r.Form.Get("umask"),
+		Group:         r.Form.Get("group"),
+		CwdTemplate:   r.Form.Get("cwd_template"),
+		CwdBase:       r.Form.Get("cwd_base"),
+		ReportCmd:     r.Form.Get("report_cmd"),
+		OutputCheck:   r.Form.Get("output_check_cmd"),
+		IRODSColl:     r.Form.Get("irods_collection"),
+		Proxy:         r.Form.Get("proxy"),
+		TraceID:       r.Form.Get("trace_id"),
+		Affinity:      r.Form.Get("affinity"),
+		MaxPerHost:    urlStringToInt(r.Form.Get("max_per_host")),
 		CloudOS:       r.Form.Get("cloud_os"),
 		CloudUser:     r.Form.Get("cloud_username"),
 		CloudScript:   r.Form.Get("cloud_script"),
 		CloudFlavor:   r.Form.Get("cloud_flavor"),
 		CloudOSRam:    urlStringToInt(r.Form.Get("cloud_ram")),
+		CloudVolume:   urlStringToInt(r.Form.Get("cloud_volume")),
 		BsubMode:      r.Form.Get("bsub_mode"),
 	}
+	jd.OutputMinSize = urlStringToInt(r.Form.Get("output_min_size"))
 	if jd.RepGrp == "" {
 		jd.RepGrp = "manually_added"
 	}
@@ -690,9 +1099,31 @@ func restJobsAdd(r *http.Request, s *Server) ([]*Job, int, error) {
 	if r.Form.Get("change_home") == restFormTrue {
 		jd.ChangeHome = true
 	}
+	if r.Form.Get("cwd_link") == restFormTrue {
+		jd.CwdLink = true
+	}
+	if r.Form.Get("clean_env") == restFormTrue {
+		jd.CleanEnv = true
+	}
 	if r.Form.Get("cloud_shared") == restFormTrue {
 		jd.CloudShared = true
 	}
+	if r.Form.Get("runner_input_check") == restFormTrue {
+		jd.RunnerInputCheck = true
+	}
+	if r.Form.Get("output_checksums") == restFormTrue {
+		jd.OutputChecksums = true
+	}
+	if r.Form.Get("cacheable") == restFormTrue {
+		jd.Cacheable = true
+	}
+	if r.Form.Get("irods_meta") != "" {
+		meta, err := ParseIRODSMetadata(r.Form.Get("irods_meta"))
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		jd.IRODSMeta = meta
+	}
 	if r.Form.Get("memory") != "" {
 		mb, err := bytefmt.ToMegabytes(r.Form.Get("memory"))
 		if err != nil {
@@ -707,6 +1138,18 @@ func restJobsAdd(r *http.Request, s *Server) ([]*Job, int, error) {
 			return nil, http.StatusBadRequest, err
 		}
 	}
+	if r.Form.Get("retry_budgets") != "" {
+		err := urlStringToStruct(r.Form.Get("retry_budgets"), &jd.RetryBudgets)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+	}
+	if r.Form.Get("scheduler_args") != "" {
+		err := urlStringToStruct(r.Form.Get("scheduler_args"), &jd.SchedulerArgs)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+	}
 	var rerun bool
 	if r.Form.Get("rerun") == restFormTrue {
 		rerun = true
@@ -717,6 +1160,9 @@ func restJobsAdd(r *http.Request, s *Server) ([]*Job, int, error) {
 			jd.Deps = append(jd.Deps, NewDepGroupDependency(depgroup))
 		}
 	}
+	for _, repgroup := range urlStringToSlice(r.Form.Get("rep_grp_deps")) {
+		jd.Deps = append(jd.Deps, NewRepGroupDependency(repgroup))
+	}
 	if r.Form.Get("on_failure") != "" {
 		var bvj BehavioursViaJSON
 		err := urlStringToStruct(r.Form.Get("on_failure"), &bvj)
@@ -758,13 +1204,26 @@ func restJobsAdd(r *http.Request, s *Server) ([]*Job, int, error) {
 		}
 	}
 
-	// decode the posted JSON
-	var jvjs []*JobViaJSON
-	err := json.NewDecoder(r.Body).Decode(&jvjs)
+	if r.Form.Get("ref_assets") != "" {
+		var ras []*RefAsset
+		err := urlStringToStruct(r.Form.Get("ref_assets"), &ras)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		jd.RefAssets = ras
+	}
+
+	// decode the posted JSON and any env
+	jvjs, env, err := restJobsAddBody(r, jd)
 	if err != nil {
 		return nil, http.StatusBadRequest, err
 	}
 
+	// refuse before we spend any effort converting the jobs
+	if s.tooManyJobsToAdd(len(jvjs)) {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s (adding %d jobs)", ErrTooManyJobs, len(jvjs))
+	}
+
 	// convert to real Job structs with default values filled in
 	inputJobs := make([]*Job, 0, len(jvjs))
 	for _, jvj := range jvjs {
@@ -775,12 +1234,26 @@ func restJobsAdd(r *http.Request, s *Server) ([]*Job, int, error) {
 		inputJobs = append(inputJobs, job)
 	}
 
-	envkey, err := s.db.storeEnv([]byte{})
+	// jobs can only use the secrets whose grants were supplied
+	var grants map[string]string
+	if r.Form.Get("secret_grants") != "" {
+		err = urlStringToStruct(r.Form.Get("secret_grants"), &grants)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+	}
+	for _, job := range inputJobs {
+		if err = s.permitSecrets(job, grants); err != nil {
+			return nil, http.StatusForbidden, err
+		}
+	}
+
+	envkey, err := s.db.storeEnv(env)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
 
-	_, _, _, _, err = s.createJobs(inputJobs, envkey, !rerun)
+	_, _, _, _, err = s.createJobs(inputJobs, envkey, !rerun, userREST)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -791,6 +1264,110 @@ func restJobsAdd(r *http.Request, s *Server) ([]*Job, int, error) {
 	return jobs, http.StatusCreated, err
 }
 
+// restJobsAddBody decodes the body of a POST to restJobsAdd(), returning the
+// jobs in it and the compressed environment they should run with (empty if
+// none was supplied). Any input manifest is added to jd.InputFiles.
+func restJobsAddBody(r *http.Request, jd *JobDefaults) ([]*JobViaJSON, []byte, error) {
+	env := []byte{}
+	var jvjs []*JobViaJSON
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		err = json.NewDecoder(r.Body).Decode(&jvjs)
+		return jvjs, env, err
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gotJobs := false
+	for {
+		part, errp := mr.NextPart()
+		if errp == io.EOF {
+			break
+		}
+		if errp != nil {
+			return nil, nil, errp
+		}
+
+		switch part.FormName() {
+		case restPartJobs:
+			err = json.NewDecoder(part).Decode(&jvjs)
+			gotJobs = true
+		case restPartEnv:
+			env, err = restPartToEnv(part)
+		case restPartInputManifest:
+			var files []string
+			files, err = restPartToManifest(part)
+			jd.InputFiles = append(jd.InputFiles, files...)
+		default:
+			err = fmt.Errorf("unknown multipart section [%s]", part.FormName())
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if !gotJobs {
+		return nil, nil, fmt.Errorf("multipart section [%s] is required", restPartJobs)
+	}
+	return jvjs, env, nil
+}
+
+// readRESTPart reads all of the given small multipart section, erroring if it
+// is larger than restPartMaxSize.
+func readRESTPart(part *multipart.Part) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(part, restPartMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > restPartMaxSize {
+		return nil, fmt.Errorf("multipart section [%s] is larger than %d bytes", part.FormName(), restPartMaxSize)
+	}
+	return data, nil
+}
+
+// restPartToEnv reads the JSON array of key=value environment variables in the
+// given multipart section, returning them compressed.
+func restPartToEnv(part *multipart.Part) ([]byte, error) {
+	data, err := readRESTPart(part)
+	if err != nil {
+		return nil, err
+	}
+
+	var envVars []string
+	if err = json.Unmarshal(data, &envVars); err != nil {
+		return nil, fmt.Errorf("multipart section [%s] is not a JSON array of strings: %w", restPartEnv, err)
+	}
+	for _, envVar := range envVars {
+		if strings.Index(envVar, "=") < 1 {
+			return nil, fmt.Errorf("environment variable [%s] is not in key=value form", envVar)
+		}
+	}
+
+	return compressEnv(envVars)
+}
+
+// restPartToManifest reads the paths of input files, one per line, in the
+// given multipart section. Blank lines and lines starting with # are ignored.
+func restPartToManifest(part *multipart.Part) ([]string, error) {
+	data, err := readRESTPart(part)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		files = append(files, line)
+	}
+	return files, nil
+}
+
 // restJobsCancel kills running jobs, confirms lost jobs as dead, or deletes
 // incomplete jobs. You identify the jobs to operate on in the same way as for
 // restJobsStatus(). However state must be specified, and only one of:
@@ -825,7 +1402,7 @@ func restJobsCancel(r *http.Request, s *Server) ([]*Job, int, error) {
 		for i, job := range jobs {
 			keys[i] = job.Key()
 		}
-		deleted := s.deleteJobs(keys)
+		deleted := s.deleteJobs(keys, userREST)
 		d := make(map[string]bool, len(deleted))
 		for _, key := range deleted {
 			d[key] = true
@@ -837,6 +1414,10 @@ func restJobsCancel(r *http.Request, s *Server) ([]*Job, int, error) {
 			}
 		}
 	} else {
+		var killed []string
+		defer func() {
+			s.recordUserAction(EventTypeKill, userREST, s.jobsWithKeys(killed))
+		}()
 		for _, job := range jobs {
 			k, err := s.killJob(job.Key())
 			if err != nil {
@@ -844,6 +1425,7 @@ func restJobsCancel(r *http.Request, s *Server) ([]*Job, int, error) {
 			}
 			if k {
 				handled = append(handled, job)
+				killed = append(killed, job.Key())
 			}
 		}
 	}
@@ -1007,6 +1589,47 @@ func restInfo(s *Server) http.HandlerFunc {
 	}
 }
 
+// restExport lets you download a report on jobs. Possible query parameters are
+// format (csv, the default, or json), filter (comma separated sub-strings of
+// the RepGroups of the jobs to report on, default all) and state (as per
+// restJobsStatus()).
+func restExport(s *Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer internal.LogPanic(s.Logger, "jobqueue server export", false)
+
+		ok := s.httpAuthorized(w, r)
+		if !ok {
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			http.Error(w, "Only GET is supported", http.StatusBadRequest)
+			return
+		}
+
+		format := r.FormValue("format")
+		var contentType string
+		switch format {
+		case "", exportFormatCSV:
+			format = exportFormatCSV
+			contentType = "text/csv; charset=UTF-8"
+		case exportFormatJSON:
+			contentType = "application/json; charset=UTF-8"
+		default:
+			http.Error(w, "format must be csv or json", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+exportFilename(format)+`"`)
+		w.WriteHeader(http.StatusOK)
+		err := s.exportJobs(r.Context(), w, format, parseExportFilters(r.FormValue("filter")), JobState(r.FormValue("state")))
+		if err != nil {
+			s.Warn("restExport failed to export jobs", "err", err)
+		}
+	}
+}
+
 // restVersion lets you get info on the version of the server and the supported
 // API version (we only support 1 API version at a time). This is the only
 // end point that doesn't need authentication.
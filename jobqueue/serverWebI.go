@@ -21,14 +21,28 @@ package jobqueue
 // This file contains the web interface code of the server.
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"mime"
 	"net/http"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
+	"time"
 
 	sync "github.com/sasha-s/go-deadlock"
 
 	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/VertebrateResequencing/wr/jobqueue/scheduler"
 	"github.com/VertebrateResequencing/wr/queue"
+	"github.com/VertebrateResequencing/wr/static"
 	"github.com/gorilla/websocket"
+	"github.com/grafov/bcast"
 )
 
 // jstatusReq is what the status webpage sends us to ask for info about jobs.
@@ -41,9 +55,24 @@ type jstatusReq struct {
 	// retry = retry buried jobs.
 	// remove = remove non-running jobs.
 	// kill = kill running jobs or confirm lost jobs are dead.
+	// kickKey, removeKey, killKey = like retry, remove and kill, but only ever
+	//                               work on the single job with Key.
+	// buryKey = bury the delayed or ready job with Key.
 	// confirmBadServer = confirm that the server with ID ServerID is bad.
 	// dismissMsg = dismiss the given Msg.
 	// dismissMsgs = dismiss all scheduler messages.
+	// getPrefs = get the user's saved preferences for the webpage.
+	// setPrefs = save the user's preferences for the webpage.
+	// export = get a report on the jobs with RepGroups matching Filter (and
+	//          State, if set) in the given Format.
+	// hosts = get the number of running jobs on each host.
+	// events = start being sent every Event as it happens.
+	// resubmit = add a clone of the job with Key, modified by Resubmit.
+	// stateAt = get the live job state counts per RepGroup as they were At.
+	// undo = restore the jobs with RepGroup (any, if blank) that were removed
+	//        at or after Deleted.
+	// annotate = leave Note on the job with Key, or if Key is blank, on
+	//            RepGroup.
 	Request string
 
 	// sending Key means "give me detailed info about this single job", and
@@ -60,8 +89,98 @@ type jstatusReq struct {
 	FailReason string
 	ServerID   string // required argument for confirmBadServer
 	Msg        string // required argument for dismissMsg
+	Note       string // required argument for annotate
+
+	// Prefs is the required JSON object argument for setPrefs
+	Prefs json.RawMessage
+
+	Format string // csv (the default) or json, for export
+	Filter string // comma separated RepGroup sub-strings, for export
+
+	// Resubmit is the required argument for resubmit
+	Resubmit *jresubmit
+
+	At int64 // Unix time in seconds, required argument for stateAt
+
+	Deleted int64 // Unix time in milliseconds, required argument for undo
+}
+
+// jresubmit describes how the clone of a job made by a resubmit request should
+// differ from the original.
+type jresubmit struct {
+	Cmd      string
+	RepGroup string
+	Memory   int     // Megabytes
+	Time     float64 // seconds
+	Cores    float64
+	Disk     int // Gigabytes
+	Retries  int
+}
+
+// jprefs is what we send the status webpage in response to a getPrefs request.
+type jprefs struct {
+	Prefs json.RawMessage
+}
+
+// jexport is what we send the status webpage in response to an export request.
+type jexport struct {
+	Export   string
+	Format   string
+	Filename string
+}
+
+// jhosts is what we send in response to a hosts request.
+type jhosts struct {
+	Hosts map[string]int
 }
 
+// jresubmitted is what we send in response to a resubmit request: the Key of
+// the job that was added, or the Error that prevented that.
+type jresubmitted struct {
+	Resubmitted string
+	Error       string
+}
+
+// jremoved is what we send in response to a remove or removeKey request that
+// removed jobs that can be restored: how many were Removed, and the RepGroup
+// and Deleted time to send with an undo request to restore them, which must be
+// done within Window seconds.
+type jremoved struct {
+	Removed  int
+	RepGroup string
+	Deleted  int64
+	Window   int64
+}
+
+// jrestored is what we send in response to an undo request.
+type jrestored struct {
+	Restored int
+}
+
+// jstateAt is what we send in response to a stateAt request: the live job
+// state counts per RepGroup as they were At, or the Error that prevented us
+// working them out.
+type jstateAt struct {
+	StateCounts map[string]map[JobState]int
+	At          int64
+	Error       string
+}
+
+// jannotated is what we send in response to an annotate request: the note
+// that was Annotated, or the Error that prevented that.
+type jannotated struct {
+	Annotated *Annotation
+	Error     string
+}
+
+// jevent is what we send for each Event after an events request.
+type jevent struct {
+	Event *Event
+}
+
+// maxWebPrefsSize is the largest JSON object we will store for setPrefs.
+const maxWebPrefsSize = 64 * 1024
+
 // JStatus is the job info we send to the status webpage (only real difference
 // to Job is that some of the values are converted to easy-to-display forms).
 type JStatus struct {
@@ -72,6 +191,8 @@ type JStatus struct {
 	Env           []string
 	Key           string
 	RepGroup      string
+	AtomicGroup   string
+	Queue         string
 	Cmd           string
 	State         JobState
 	Cwd           string
@@ -79,10 +200,16 @@ type JStatus struct {
 	Behaviours    string
 	Mounts        string
 	MonitorDocker string
+	ReportCmd     string
+	Metrics       map[string]string
+	Notes         []*Annotation
+	Archive       string
 	FailReason    string
+	LastAction    string
 	Host          string
 	HostID        string
 	HostIP        string
+	Scheduler     string
 	StdErr        string
 	StdOut        string
 	ExpectedRAM   int     // ExpectedRAM is in Megabytes.
@@ -99,33 +226,43 @@ type JStatus struct {
 	Ended         int64
 	Similar       int
 	Attempts      uint32
+	Retries       uint8
 	HomeChanged   bool
 	Exited        bool
+	AtomicFailed  bool
 }
 
-// webInterfaceStatic is a http handler for our static documents in static.go
-// (which in turn come from the static folder in the git repository). static.go
-// is auto-generated by:
-// $ esc -pkg jobqueue -prefix static -private -o jobqueue/static.go static
-func webInterfaceStatic(s *Server) http.HandlerFunc {
+// hideFromPublic blanks the parts of this status that are likely to contain
+// credentials, for sending to the public status page: the environment and the
+// output of the Cmd.
+func (status *JStatus) hideFromPublic() {
+	status.Env = nil
+	status.StdErr = ""
+	status.StdOut = ""
+}
+
+// webInterfaceStatic is a http handler for our static documents, which are
+// embedded from the static folder in the git repository, or come from the
+// server's configured WebOverlayDir if the file exists there. If public, the
+// status page is served without needing authorization.
+func webInterfaceStatic(s *Server, public bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// our home page is /status.html
 		path := r.URL.Path
 		if path == "/" || path == "/status" {
 			path = "/status.html"
 
-			ok := s.httpAuthorized(w, r)
-			if !ok {
-				return
+			if !public {
+				if _, _, _, ok := s.webAuthorized(w, r, true); !ok {
+					return
+				}
 			}
 		}
 
 		// during development, to avoid having to rebuild and restart manager on
-		// every change to a file in static dir, do:
-		// $ esc -pkg jobqueue -prefix $PWD/static -private -o jobqueue/static.go $PWD/static
-		// and set the boolean to true. Don't forget to rerun esc without the abs
-		// paths and change the boolean back to false before any commit!
-		doc, err := _escFSByte(false, path)
+		// every change to a file in static dir, set managerweboverlay to the
+		// absolute path of the static dir
+		doc, err := s.staticDoc(path)
 		if err != nil {
 			http.NotFound(w, r)
 			return
@@ -151,6 +288,10 @@ func webInterfaceStatic(s *Server) http.HandlerFunc {
 			}
 		case strings.HasSuffix(path, "favicon.ico"):
 			w.Header().Set("Content-Type", "image/x-icon")
+		default:
+			if ct := mime.TypeByExtension(filepath.Ext(path)); ct != "" {
+				w.Header().Set("Content-Type", ct)
+			}
 		}
 
 		_, err = w.Write(doc)
@@ -160,6 +301,32 @@ func webInterfaceStatic(s *Server) http.HandlerFunc {
 	}
 }
 
+// staticDoc returns the content of the static document at the given url path,
+// preferring a file at that path within our webOverlay directory, if
+// configured, over the ones embedded in our executable.
+func (s *Server) staticDoc(urlPath string) ([]byte, error) {
+	urlPath = path.Clean("/" + urlPath)
+	if s.webOverlay != "" {
+		doc, err := ioutil.ReadFile(filepath.Join(s.webOverlay, filepath.FromSlash(urlPath)))
+		if err == nil {
+			return doc, nil
+		}
+		if !os.IsNotExist(err) {
+			s.Warn("web interface overlay document read failed", "path", urlPath, "err", err)
+		}
+	}
+	return static.FS.ReadFile(strings.TrimPrefix(urlPath, "/"))
+}
+
+// webToken returns the auth token that a request (that passed httpAuthorized())
+// was made with.
+func webToken(r *http.Request) []byte {
+	if token := r.Form.Get("token"); token != "" {
+		return []byte(token)
+	}
+	return []byte(strings.TrimPrefix(r.Header.Get("Authorization"), bearerSchema))
+}
+
 // webSocket upgrades a http connection to a websocket
 func webSocket(w http.ResponseWriter, r *http.Request) (*websocket.Conn, bool) {
 	var upgrader = websocket.Upgrader{
@@ -175,12 +342,16 @@ func webSocket(w http.ResponseWriter, r *http.Request) (*websocket.Conn, bool) {
 }
 
 // webInterfaceStatusWS reads from and writes to the websocket on the status
-// webpage
-func webInterfaceStatusWS(s *Server) http.HandlerFunc {
+// webpage. If public, anyone can connect, but only to view the status of jobs.
+func webInterfaceStatusWS(s *Server, public bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ok := s.httpAuthorized(w, r)
-		if !ok {
-			return
+		role, prefsKey, user := webRolePublic, []byte(nil), ""
+		if !public {
+			var ok bool
+			role, prefsKey, user, ok = s.webAuthorized(w, r, false)
+			if !ok {
+				return
+			}
 		}
 
 		conn, ok := webSocket(w, r)
@@ -210,6 +381,7 @@ func webInterfaceStatusWS(s *Server) http.HandlerFunc {
 				close(stop)
 			}()
 
+			subscribed := false
 			for {
 				req := jstatusReq{}
 				errr := conn.ReadJSON(&req)
@@ -218,6 +390,17 @@ func webInterfaceStatusWS(s *Server) http.HandlerFunc {
 					break
 				}
 
+				if role < webRoleRequired[req.Request] {
+					s.Warn("web interface request not permitted", "request", req.Request, "role", role)
+					continue
+				}
+
+				query := webQueryRequests[req.Request] || (req.Request == "" && req.Key != "")
+				if query && !s.queries.acquire() {
+					s.Warn("web interface query refused", "err", ErrOverloaded, "request", req.Request)
+					continue
+				}
+
 				switch {
 				case req.Request != "":
 					switch req.Request {
@@ -239,7 +422,7 @@ func webInterfaceStatusWS(s *Server) http.HandlerFunc {
 						}
 						failed := false
 						for repGroup, jobs := range repGroups {
-							complete, _, qerr := s.getCompleteJobsByRepGroup(repGroup)
+							complete, _, qerr := s.getCompleteJobsByRepGroup(context.Background(), repGroup)
 							if qerr != "" {
 								failed = true
 								break
@@ -272,7 +455,7 @@ func webInterfaceStatusWS(s *Server) http.HandlerFunc {
 						// *** probably want to take the count as a req option,
 						// so user can request to see more than just 1 job per
 						// State+Exitcode+FailReason
-						jobs, _, errstr := s.getJobsByRepGroup(req.RepGroup, false, 1, req.State, true, true)
+						jobs, _, errstr := s.getJobsByRepGroup(context.Background(), req.RepGroup, false, 1, req.State, !public, !public)
 						if errstr == "" && len(jobs) > 0 {
 							writeMutex.Lock()
 							failed := false
@@ -282,7 +465,11 @@ func webInterfaceStatusWS(s *Server) http.HandlerFunc {
 									failed = true
 									break
 								}
+								if public {
+									status.hideFromPublic()
+								}
 								status.RepGroup = req.RepGroup // since we want to return the group the user asked for, not the most recent group the job was made for
+								status.Notes = s.jobAnnotations(status.Key, status.RepGroup)
 								err = conn.WriteJSON(status)
 								if err != nil {
 									failed = true
@@ -295,54 +482,41 @@ func webInterfaceStatusWS(s *Server) http.HandlerFunc {
 							}
 						}
 					case "retry":
-						jobs := s.reqToJobs(req, []queue.ItemState{queue.ItemStateBury})
-						for _, job := range jobs {
-							err := s.q.Kick(job.Key())
-							if err != nil {
-								continue
-							}
-							job.UntilBuried = job.Retries + 1
-						}
+						s.webKickJobs(s.reqToJobs(req, []queue.ItemState{queue.ItemStateBury}), user)
 					case "remove":
-						jobs := s.reqToJobs(req, []queue.ItemState{queue.ItemStateBury, queue.ItemStateDelay, queue.ItemStateDependent, queue.ItemStateReady})
-						var toDelete []string
-						for _, job := range jobs {
-							key := job.Key()
-
-							// we can't allow the removal of jobs that have
-							// dependencies, as *queue would regard that as
-							// satisfying the dependency and downstream jobs
-							// would start
-							hasDeps, err := s.q.HasDependents(key)
-							if err != nil || hasDeps {
-								continue
-							}
-
-							err = s.q.Remove(key)
-							if err != nil {
-								s.Warn("failed to remove job", "cmd", job.Cmd, "err", err)
-								continue
-							}
-							s.db.deleteLiveJob(key)
-							s.Debug("removed job", "cmd", job.Cmd)
-							toDelete = append(toDelete, key)
-							if job.State == JobStateReady {
-								s.decrementGroupCount(job.schedulerGroup)
-							}
-						}
-						s.rpl.Lock()
-						for _, key := range toDelete {
-							delete(s.rpl.lookup[req.RepGroup], key)
+						removed, deleted := s.webRemoveJobs(s.reqToJobs(req, []queue.ItemState{queue.ItemStateBury, queue.ItemStateDelay, queue.ItemStateDependent, queue.ItemStateReady}), user)
+						if err := s.webSendRemoved(conn, writeMutex, removed, deleted, req.RepGroup); err != nil {
+							break
 						}
-						s.rpl.Unlock()
 					case "kill":
-						jobs := s.reqToJobs(req, []queue.ItemState{queue.ItemStateRun})
-						for _, job := range jobs {
-							_, err := s.killJob(job.Key())
-							if err != nil {
-								s.Warn("web interface kill job failed", "err", err)
-							}
+						s.webKillJobs(s.reqToJobs(req, []queue.ItemState{queue.ItemStateRun}), user)
+					case "kickKey":
+						s.webKickJobs(s.keyToJobs(req.Key, []queue.ItemState{queue.ItemStateBury}), user)
+					case "removeKey":
+						jobs := s.keyToJobs(req.Key, []queue.ItemState{queue.ItemStateBury, queue.ItemStateDelay, queue.ItemStateDependent, queue.ItemStateReady})
+						removed, deleted := s.webRemoveJobs(jobs, user)
+						var rg string
+						if len(jobs) == 1 {
+							rg = jobs[0].RepGroup
+						}
+						if err := s.webSendRemoved(conn, writeMutex, removed, deleted, rg); err != nil {
+							break
+						}
+					case "undo":
+						restored, _, err := s.undeleteJobs(req.RepGroup, false, time.Unix(0, req.Deleted*int64(time.Millisecond)), user)
+						if err != nil {
+							s.Warn("web interface undo failed", "err", err)
+						}
+						writeMutex.Lock()
+						err = conn.WriteJSON(&jrestored{Restored: restored})
+						writeMutex.Unlock()
+						if err != nil {
+							break
 						}
+					case "killKey":
+						s.webKillJobs(s.keyToJobs(req.Key, []queue.ItemState{queue.ItemStateRun}), user)
+					case "buryKey":
+						s.webBuryJobs(s.keyToJobs(req.Key, []queue.ItemState{queue.ItemStateDelay, queue.ItemStateReady}), user)
 					case "confirmBadServer":
 						if req.ServerID != "" {
 							s.bsmutex.Lock()
@@ -366,16 +540,124 @@ func webInterfaceStatusWS(s *Server) http.HandlerFunc {
 						s.simutex.Lock()
 						s.schedIssues = make(map[string]*schedulerIssue)
 						s.simutex.Unlock()
+					case "getPrefs":
+						prefs := s.db.retrieveWebPrefs(prefsKey)
+						if prefs == nil {
+							prefs = []byte("{}")
+						}
+						writeMutex.Lock()
+						err := conn.WriteJSON(&jprefs{Prefs: prefs})
+						writeMutex.Unlock()
+						if err != nil {
+							break
+						}
+					case "setPrefs":
+						if len(req.Prefs) == 0 || len(req.Prefs) > maxWebPrefsSize || req.Prefs[0] != '{' {
+							s.Warn("web interface preferences rejected", "size", len(req.Prefs))
+							continue
+						}
+						err := s.db.storeWebPrefs(prefsKey, req.Prefs)
+						if err != nil {
+							s.Warn("web interface preferences could not be stored", "err", err)
+						}
+					case "hosts":
+						hosts := make(map[string]int)
+						for _, job := range s.getJobsCurrent(0, "", false, false) {
+							if (job.State == JobStateRunning || job.State == JobStateReserved) && job.Host != "" {
+								hosts[job.Host]++
+							}
+						}
+						writeMutex.Lock()
+						err := conn.WriteJSON(&jhosts{Hosts: hosts})
+						writeMutex.Unlock()
+						if err != nil {
+							break
+						}
+					case eventsReq:
+						if !subscribed {
+							subscribed = true
+							go s.webStreamEvents(conn, writeMutex, stop)
+						}
+					case "export":
+						format := req.Format
+						if format == "" {
+							format = exportFormatCSV
+						}
+						var buf bytes.Buffer
+						err := s.exportJobs(context.Background(), &buf, format, parseExportFilters(req.Filter), req.State)
+						if err != nil {
+							s.Warn("web interface export failed", "err", err)
+							break
+						}
+						writeMutex.Lock()
+						err = conn.WriteJSON(&jexport{Export: buf.String(), Format: format, Filename: exportFilename(format)})
+						writeMutex.Unlock()
+						if err != nil {
+							break
+						}
+					case "resubmit":
+						resp := &jresubmitted{}
+						key, err := s.webResubmitJob(req.Key, req.Resubmit, user)
+						if err != nil {
+							s.Warn("web interface resubmit failed", "key", req.Key, "err", err)
+							resp.Error = err.Error()
+						} else {
+							resp.Resubmitted = key
+						}
+						writeMutex.Lock()
+						err = conn.WriteJSON(resp)
+						writeMutex.Unlock()
+						if err != nil {
+							break
+						}
+					case "annotate":
+						resp := &jannotated{}
+						annotation := &Annotation{Key: req.Key, Note: req.Note}
+						if req.Key == "" {
+							annotation.RepGroup = req.RepGroup
+						}
+						a, err := s.annotate(annotation, user)
+						if err != nil {
+							s.Warn("web interface annotate failed", "key", req.Key, "rg", req.RepGroup, "err", err)
+							resp.Error = err.Error()
+						} else {
+							resp.Annotated = a
+						}
+						writeMutex.Lock()
+						err = conn.WriteJSON(resp)
+						writeMutex.Unlock()
+						if err != nil {
+							break
+						}
+					case "stateAt":
+						resp := &jstateAt{At: req.At}
+						counts, err := s.stateCountsAt(time.Unix(req.At, 0))
+						if err != nil {
+							s.Warn("web interface state reconstruction failed", "err", err)
+							resp.Error = err.Error()
+						} else {
+							resp.StateCounts = counts
+						}
+						writeMutex.Lock()
+						err = conn.WriteJSON(resp)
+						writeMutex.Unlock()
+						if err != nil {
+							break
+						}
 					default:
 						continue
 					}
 				case req.Key != "":
-					jobs, _, errstr := s.getJobsByKeys([]string{req.Key}, true, true)
+					jobs, _, errstr := s.getJobsByKeys(context.Background(), []string{req.Key}, !public, !public)
 					if errstr == "" && len(jobs) == 1 {
 						status, err := jobs[0].ToStatus()
 						if err != nil {
 							break
 						}
+						if public {
+							status.hideFromPublic()
+						}
+						status.Notes = s.jobAnnotations(status.Key, status.RepGroup)
 						writeMutex.Lock()
 						err = conn.WriteJSON(status)
 						writeMutex.Unlock()
@@ -384,76 +666,82 @@ func webInterfaceStatusWS(s *Server) http.HandlerFunc {
 						}
 					}
 				}
+
+				if query {
+					s.queries.release()
+				}
 			}
 		}(conn, storedName, stopper)
 
-		// go routines to push changes to the client
+		// go routines to push changes to the client; updates are buffered so
+		// that a client too slow to keep up can be disconnected, instead of us
+		// accumulating ever more updates waiting to be sent to it
+		updates := make(chan interface{}, ServerWebSocketBuffer)
 		go func(conn *websocket.Conn, stop chan bool) {
 			// log panics and die
-			defer internal.LogPanic(s.Logger, "jobqueue websocket status updating", true)
-
-			statusReceiver := s.statusCaster.Join()
-			defer statusReceiver.Close()
+			defer internal.LogPanic(s.Logger, "jobqueue websocket updating", true)
 
 			for {
 				select {
 				case <-stop:
 					return
-				case status := <-statusReceiver.In:
+				case update := <-updates:
 					writeMutex.Lock()
-					err := conn.WriteJSON(status)
+					err := conn.WriteJSON(update)
 					writeMutex.Unlock()
 					if err != nil {
-						s.Warn("status updater failed to send JSON to client", "err", err)
+						s.Warn("web interface updater failed to send JSON to client", "err", err)
 						return
 					}
 				}
 			}
 		}(conn, stopper)
 
-		go func(conn *websocket.Conn, stop chan bool) {
-			defer internal.LogPanic(s.Logger, "jobqueue websocket bad server updating", true)
+		for _, caster := range []*bcast.Group{s.statusCaster, s.badServerCaster, s.schedCaster} {
+			go func(caster *bcast.Group, connStorageName string, stop chan bool) {
+				defer internal.LogPanic(s.Logger, "jobqueue websocket update buffering", true)
 
-			badserverReceiver := s.badServerCaster.Join()
-			defer badserverReceiver.Close()
+				receiver := caster.Join()
+				defer receiver.Close()
 
-			for {
-				select {
-				case <-stop:
-					return
-				case server := <-badserverReceiver.In:
-					writeMutex.Lock()
-					err := conn.WriteJSON(server)
-					writeMutex.Unlock()
-					if err != nil {
-						s.Warn("bad server caster failed to send JSON to client", "err", err)
+				for {
+					select {
+					case <-stop:
 						return
+					case update := <-receiver.In:
+						if !queueWebUpdate(updates, update) {
+							s.Warn("disconnecting web interface client that is too slow to keep up", "err", ErrOverloaded)
+							s.closeWebSocketConnection(connStorageName)
+							return
+						}
 					}
 				}
-			}
-		}(conn, stopper)
+			}(caster, storedName, stopper)
+		}
+	}
+}
 
-		go func(conn *websocket.Conn, stop chan bool) {
-			defer internal.LogPanic(s.Logger, "jobqueue websocket scheduler issue updating", true)
+// webStreamEvents sends every Event to the given websocket as it happens,
+// until stop is closed.
+func (s *Server) webStreamEvents(conn *websocket.Conn, writeMutex *sync.Mutex, stop chan bool) {
+	defer internal.LogPanic(s.Logger, "jobqueue websocket event streaming", true)
 
-			schedIssueReceiver := s.schedCaster.Join()
-			defer schedIssueReceiver.Close()
+	events, unsubscribe := s.subscribeEvents()
+	defer unsubscribe()
 
-			for {
-				select {
-				case <-stop:
-					return
-				case si := <-schedIssueReceiver.In:
-					writeMutex.Lock()
-					err := conn.WriteJSON(si)
-					writeMutex.Unlock()
-					if err != nil {
-						s.Warn("scheduler issues caster failed to send JSON to client", "err", err)
-						return
-					}
-				}
+	for {
+		select {
+		case <-stop:
+			return
+		case event := <-events:
+			writeMutex.Lock()
+			err := conn.WriteJSON(&jevent{Event: event})
+			writeMutex.Unlock()
+			if err != nil {
+				s.Warn("event streamer failed to send JSON to client", "err", err)
+				return
 			}
-		}(conn, stopper)
+		}
 	}
 }
 
@@ -502,6 +790,245 @@ func (s *Server) reqToJobs(req jstatusReq, allowedItemStates []queue.ItemState)
 	return jobs
 }
 
+// keyToJobs returns the single job with the given key, if it is in one of the
+// allowed states.
+func (s *Server) keyToJobs(key string, allowedItemStates []queue.ItemState) []*Job {
+	if key == "" {
+		return nil
+	}
+	return s.reqToJobs(jstatusReq{Key: key}, allowedItemStates)
+}
+
+// webKickJobs kicks the given buried jobs on behalf of the given user, for the
+// status webpage.
+func (s *Server) webKickJobs(jobs []*Job, user string) {
+	var kicked []*Job
+	for _, job := range jobs {
+		err := s.q.Kick(job.Key())
+		if err != nil {
+			continue
+		}
+		job.Lock()
+		job.UntilBuried = job.Retries + 1
+		job.Unlock()
+		kicked = append(kicked, job)
+	}
+	s.recordUserAction(EventTypeRetry, user, kicked)
+}
+
+// webRemoveJobs removes the given non-running jobs on behalf of the given user,
+// for the status webpage. Jobs that have dependents are not removed. Returns
+// the keys of the removed jobs and the time they were recycled at.
+func (s *Server) webRemoveJobs(jobs []*Job, user string) ([]string, time.Time) {
+	var toDelete []string
+	var removed []*Job
+	for _, job := range jobs {
+		key := job.Key()
+
+		// we can't allow the removal of jobs that have dependencies, as *queue
+		// would regard that as satisfying the dependency and downstream jobs
+		// would start
+		hasDeps, err := s.q.HasDependents(key)
+		if err != nil || hasDeps {
+			continue
+		}
+
+		err = s.q.Remove(key)
+		if err != nil {
+			s.Warn("failed to remove job", "cmd", job.Cmd, "err", err)
+			continue
+		}
+		s.Debug("removed job", "cmd", job.Cmd)
+		toDelete = append(toDelete, key)
+		removed = append(removed, job)
+		if job.State == JobStateReady {
+			s.decrementGroupCount(job.schedulerGroup)
+		}
+	}
+	if len(toDelete) == 0 {
+		return nil, time.Time{}
+	}
+
+	deleted, err := s.removeLiveJobs(toDelete)
+	if err != nil {
+		s.Error("job deletion from database failed", "err", err)
+	}
+
+	// the jobs may have been found via any of the RepGroups they were added
+	// with
+	s.rpl.Lock()
+	for _, keys := range s.rpl.lookup {
+		for _, key := range toDelete {
+			delete(keys, key)
+		}
+	}
+	s.rpl.Unlock()
+
+	s.recordUserAction(EventTypeRemove, user, removed)
+	return toDelete, deleted
+}
+
+// webSendRemoved tells the status webpage how many jobs were just removed and
+// what to send with an undo request to restore them, if they can be.
+func (s *Server) webSendRemoved(conn *websocket.Conn, writeMutex *sync.Mutex, removed []string, deleted time.Time, repGroup string) error {
+	if len(removed) == 0 || s.recycleWindow <= 0 {
+		return nil
+	}
+	resp := &jremoved{
+		Removed:  len(removed),
+		RepGroup: repGroup,
+		Deleted:  deleted.UnixNano() / int64(time.Millisecond),
+		Window:   int64(s.recycleWindow.Seconds()),
+	}
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	return conn.WriteJSON(resp)
+}
+
+// webKillJobs kills the given running jobs on behalf of the given user, for the
+// status webpage.
+func (s *Server) webKillJobs(jobs []*Job, user string) {
+	var killed []*Job
+	for _, job := range jobs {
+		k, err := s.killJob(job.Key())
+		if err != nil {
+			s.Warn("web interface kill job failed", "err", err)
+		} else if k {
+			killed = append(killed, job)
+		}
+	}
+	s.recordUserAction(EventTypeKill, user, killed)
+}
+
+// webBuryJobs buries the given delayed or ready jobs on behalf of the given
+// user, for the status webpage.
+func (s *Server) webBuryJobs(jobs []*Job, user string) {
+	var buried []*Job
+	for _, job := range jobs {
+		err := s.buryWaitingJob(job, FailReasonBuried)
+		if err != nil {
+			s.Warn("web interface bury job failed", "err", err)
+			continue
+		}
+		buried = append(buried, job)
+		s.failAtomicGroup(job)
+	}
+	s.recordUserAction(EventTypeBury, user, buried)
+}
+
+// webResubmitJob adds a clone of the job with the given key, modified as per
+// the given edits, for the status webpage. The clone runs in the same
+// environment as the original, and uses the edited requirements as-is, but
+// does not keep the original's dependencies. The original is left alone.
+// user is who asked for the resubmission. Returns the key of the clone.
+func (s *Server) webResubmitJob(key string, edits *jresubmit, user string) (string, error) {
+	if key == "" || edits == nil {
+		return "", fmt.Errorf("resubmit needs a job and edits")
+	}
+	if strings.TrimSpace(edits.Cmd) == "" {
+		return "", fmt.Errorf("resubmit needs a command")
+	}
+	if edits.Memory < 0 || edits.Time < 0 || edits.Cores < 0 || edits.Disk < 0 || edits.Retries < 0 || edits.Retries > math.MaxUint8 {
+		return "", fmt.Errorf("resubmit requirements are invalid")
+	}
+
+	jobs, _, qerr := s.getJobsByKeys(context.Background(), []string{key}, false, false)
+	if qerr != "" {
+		return "", fmt.Errorf("%s", qerr)
+	}
+	if len(jobs) != 1 {
+		return "", fmt.Errorf("job %s was not found", key)
+	}
+	orig := jobs[0]
+
+	// since the command can be edited, and we don't have its secrets' grants,
+	// jobs that use secrets can't be resubmitted from here
+	orig.RLock()
+	usesSecrets := len(orig.Secrets) > 0
+	orig.RUnlock()
+	if usesSecrets {
+		return "", fmt.Errorf("jobs that use secrets can't be resubmitted from the web interface")
+	}
+
+	orig.RLock()
+	req := &scheduler.Requirements{
+		RAM:   edits.Memory,
+		Time:  time.Duration(edits.Time * float64(time.Second)),
+		Cores: edits.Cores,
+		Disk:  edits.Disk,
+		Other: make(map[string]string, len(orig.Requirements.Other)),
+	}
+	for k, v := range orig.Requirements.Other {
+		req.Other[k] = v
+	}
+	repGroup := edits.RepGroup
+	if repGroup == "" {
+		repGroup = orig.RepGroup
+	}
+	clone := &Job{
+		RepGroup:           repGroup,
+		ReqGroup:           orig.ReqGroup,
+		LimitGroups:        append([]string(nil), orig.LimitGroups...),
+		Cmd:                edits.Cmd,
+		Cwd:                orig.Cwd,
+		CwdMatters:         orig.CwdMatters,
+		ChangeHome:         orig.ChangeHome,
+		CwdTemplate:        orig.CwdTemplate,
+		CwdBase:            orig.CwdBase,
+		CwdLink:            orig.CwdLink,
+		CleanEnv:           orig.CleanEnv,
+		Requirements:       req,
+		Override:           2,
+		Priority:           orig.Priority,
+		Retries:            uint8(edits.Retries),
+		RetryBudgets:       orig.RetryBudgets,
+		Affinity:           orig.Affinity,
+		MaxPerHost:         orig.MaxPerHost,
+		EnvOverride:        orig.EnvOverride,
+		Behaviours:         orig.Behaviours,
+		MountConfigs:       orig.MountConfigs,
+		InputFiles:         orig.InputFiles,
+		InputCheckOnRunner: orig.InputCheckOnRunner,
+		Cacheable:          orig.Cacheable,
+		OutputFiles:        orig.OutputFiles,
+		OutputMinSize:      orig.OutputMinSize,
+		OutputCheckCmd:     orig.OutputCheckCmd,
+		OutputChecksums:    orig.OutputChecksums,
+		IRODSInputs:        orig.IRODSInputs,
+		IRODSCollection:    orig.IRODSCollection,
+		IRODSMetadata:      orig.IRODSMetadata,
+		NetworkAccess:      orig.NetworkAccess,
+		Proxy:              orig.Proxy,
+		RefAssets:          orig.RefAssets,
+		MonitorDocker:      orig.MonitorDocker,
+		RunAs:              orig.RunAs,
+		Shell:              orig.Shell,
+		Nice:               orig.Nice,
+		IONice:             orig.IONice,
+		OOMScoreAdj:        orig.OOMScoreAdj,
+		Umask:              orig.Umask,
+		Group:              orig.Group,
+		ReportCmd:          orig.ReportCmd,
+		TraceID:            orig.TraceID,
+		BsubMode:           orig.BsubMode,
+	}
+	envKey := orig.EnvKey
+	orig.RUnlock()
+
+	added, _, _, _, err := s.createJobs([]*Job{clone}, envKey, false, user)
+	if err != nil {
+		return "", err
+	}
+	if added == 0 {
+		return "", fmt.Errorf("an identical command is already in the queue; edit the command to resubmit it")
+	}
+	s.recordEvent(&Event{Type: EventTypeAdd, RepGroup: clone.RepGroup, Count: added})
+
+	newKey := clone.Key()
+	s.Debug("resubmitted job with edits", "old", key, "new", newKey, "cmd", clone.Cmd)
+	return newKey, nil
+}
+
 // webInterfaceStatusSendGroupStateCount sends the per-repgroup state counts
 // to the status webpage websocket
 func webInterfaceStatusSendGroupStateCount(conn *websocket.Conn, repGroup string, jobs []*Job) error {
@@ -0,0 +1,78 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/VertebrateResequencing/wr/static"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStaticDocs(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Static documents are served from the embedded files", t, func() {
+		s := &Server{}
+		doc, err := s.staticDoc("/status.html")
+		So(err, ShouldBeNil)
+		embedded, err := static.FS.ReadFile("status.html")
+		So(err, ShouldBeNil)
+		So(doc, ShouldResemble, embedded)
+
+		_, err = s.staticDoc("/js/custom.js")
+		So(err, ShouldBeNil)
+
+		_, err = s.staticDoc("/missing.html")
+		So(err, ShouldNotBeNil)
+
+		Convey("Or from an overlay directory that overrides and extends them", func() {
+			dir, err := ioutil.TempDir("", "wr_jobqueue_test_overlay_")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			err = os.Mkdir(filepath.Join(dir, "js"), 0700)
+			So(err, ShouldBeNil)
+			err = ioutil.WriteFile(filepath.Join(dir, "js", "custom.js"), []byte("custom"), 0600)
+			So(err, ShouldBeNil)
+			err = ioutil.WriteFile(filepath.Join(dir, "logo.png"), []byte("logo"), 0600)
+			So(err, ShouldBeNil)
+			s.webOverlay = dir
+
+			doc, err = s.staticDoc("/js/custom.js")
+			So(err, ShouldBeNil)
+			So(string(doc), ShouldEqual, "custom")
+
+			doc, err = s.staticDoc("/logo.png")
+			So(err, ShouldBeNil)
+			So(string(doc), ShouldEqual, "logo")
+
+			doc, err = s.staticDoc("/status.html")
+			So(err, ShouldBeNil)
+			So(doc, ShouldResemble, embedded)
+
+			_, err = s.staticDoc("/../" + filepath.Base(dir) + "/logo.png")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,206 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of manager settings that can be
+// changed while the server is running, without disrupting running jobs.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/inconshreveable/log15"
+)
+
+// Setting* constants are the names of the settings that can be changed with
+// Client.SetSetting().
+const (
+	SettingLogLevel           = "log_level"
+	SettingMaxRunnersPerGroup = "max_runners_per_group"
+	SettingRetryDelay         = "retry_delay"
+)
+
+// SettingNames are all the Setting* constants, sorted.
+var SettingNames = []string{SettingLogLevel, SettingMaxRunnersPerGroup, SettingRetryDelay}
+
+// ValidateSetting returns an error if the given name isn't one of SettingNames,
+// or the given value isn't valid for it:
+//
+// log_level is one of debug, info, warn, error or crit, and filters what the
+// server logs (if it was configured with a LogLevelFilter).
+//
+// max_runners_per_group is the most runners that will be requested from the
+// scheduler at once for each scheduler group, with 0 meaning no limit.
+//
+// retry_delay is a duration (eg. "30s") that jobs wait after failing before they
+// can be retried.
+func ValidateSetting(name, value string) error {
+	_, err := parseSetting(name, value)
+	return err
+}
+
+// parseSetting converts the given value of the given setting to a log15.Lvl,
+// int or time.Duration, as appropriate for the setting.
+func parseSetting(name, value string) (interface{}, error) {
+	switch name {
+	case SettingLogLevel:
+		lvl, err := log15.LvlFromString(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be one of debug, info, warn, error or crit", name)
+		}
+		return lvl, nil
+	case SettingMaxRunnersPerGroup:
+		max, err := strconv.Atoi(value)
+		if err != nil || max < 0 {
+			return nil, fmt.Errorf("%s must be a whole number of runners, 0 or more", name)
+		}
+		return max, nil
+	case SettingRetryDelay:
+		delay, err := time.ParseDuration(value)
+		if err != nil || delay < 0 {
+			return nil, fmt.Errorf("%s must be a duration like 30s, 0 or more", name)
+		}
+		return delay, nil
+	default:
+		return nil, fmt.Errorf("unknown setting %q (settings are %s)", name, strings.Join(SettingNames, ", "))
+	}
+}
+
+// LevelFilter is a log15.Handler that passes on records at or above a level
+// that can be changed at any time, such as with the log_level setting.
+type LevelFilter struct {
+	handler log15.Handler
+	level   int32
+}
+
+// NewLevelFilter returns a LevelFilter that passes records at the given level
+// or above to the given handler.
+func NewLevelFilter(lvl log15.Lvl, handler log15.Handler) *LevelFilter {
+	return &LevelFilter{handler: handler, level: int32(lvl)}
+}
+
+// Log implements log15.Handler.
+func (f *LevelFilter) Log(r *log15.Record) error {
+	if r.Lvl <= f.Level() {
+		return f.handler.Log(r)
+	}
+	return nil
+}
+
+// SetLevel changes the level records must be at or above to be logged.
+func (f *LevelFilter) SetLevel(lvl log15.Lvl) {
+	atomic.StoreInt32(&f.level, int32(lvl))
+}
+
+// Level returns the level records must be at or above to be logged.
+func (f *LevelFilter) Level() log15.Lvl {
+	return log15.Lvl(atomic.LoadInt32(&f.level))
+}
+
+// lvlNames are the names of log15.Lvls accepted by the log_level setting.
+var lvlNames = map[log15.Lvl]string{
+	log15.LvlDebug: "debug",
+	log15.LvlInfo:  "info",
+	log15.LvlWarn:  "warn",
+	log15.LvlError: "error",
+	log15.LvlCrit:  "crit",
+}
+
+// applySetting validates the given setting and changes the server to use it.
+func (s *Server) applySetting(name, value string) error {
+	v, err := parseSetting(name, value)
+	if err != nil {
+		return err
+	}
+
+	s.stmutex.Lock()
+	defer s.stmutex.Unlock()
+	switch name {
+	case SettingLogLevel:
+		if s.logFilter == nil {
+			return fmt.Errorf("this server was not configured with a LogLevelFilter")
+		}
+		s.logFilter.SetLevel(v.(log15.Lvl))
+	case SettingMaxRunnersPerGroup:
+		s.maxRunnersPerGroup = v.(int)
+	case SettingRetryDelay:
+		s.retryDelay = v.(time.Duration)
+	}
+	return nil
+}
+
+// setSetting applies the given setting and stores it in the database, so that
+// it will still apply after a restart.
+func (s *Server) setSetting(name, value string) error {
+	if err := s.applySetting(name, value); err != nil {
+		return err
+	}
+	s.Info("changed setting", "name", name, "value", value)
+	return s.db.storeSetting(name, value)
+}
+
+// restoreSettings applies the settings stored in the database by setSetting().
+func (s *Server) restoreSettings() {
+	settings, err := s.db.retrieveSettings()
+	if err != nil {
+		s.Warn("failed to retrieve stored settings", "err", err)
+		return
+	}
+	for name, value := range settings {
+		if err = s.applySetting(name, value); err != nil {
+			s.Warn("failed to restore setting", "name", name, "value", value, "err", err)
+		}
+	}
+}
+
+// currentSettings returns the current values of all the settings.
+func (s *Server) currentSettings() map[string]string {
+	s.stmutex.RLock()
+	defer s.stmutex.RUnlock()
+	settings := map[string]string{
+		SettingMaxRunnersPerGroup: strconv.Itoa(s.maxRunnersPerGroup),
+		SettingRetryDelay:         s.retryDelay.String(),
+	}
+	if s.logFilter != nil {
+		settings[SettingLogLevel] = lvlNames[s.logFilter.Level()]
+	}
+	return settings
+}
+
+// jobRetryDelay returns how long jobs should wait after failing before they can
+// be retried.
+func (s *Server) jobRetryDelay() time.Duration {
+	s.stmutex.RLock()
+	defer s.stmutex.RUnlock()
+	return s.retryDelay
+}
+
+// capRunners returns the given number of runners wanted for a scheduler group,
+// reduced to the max_runners_per_group setting if necessary.
+func (s *Server) capRunners(count int) int {
+	s.stmutex.RLock()
+	defer s.stmutex.RUnlock()
+	if s.maxRunnersPerGroup > 0 && count > s.maxRunnersPerGroup {
+		return s.maxRunnersPerGroup
+	}
+	return count
+}
@@ -0,0 +1,172 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the functions that decide how a Job's Cmd (and the other
+// command lines it may have) are run: under which shell, or directly.
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ShellNone is the special Job.Shell value (also usable as the runner's
+// default shell) that has commands executed directly, without any shell, after
+// splitting them in to words the way sh would, respecting quotes and
+// backslashes. Pipes, redirection, variables and the like then have no special
+// meaning.
+const ShellNone = "none"
+
+// defaultBehaviourShell is the shell used to run Run Behaviours when the Job
+// was not executed under any particular shell.
+const defaultBehaviourShell = "/bin/bash"
+
+// errUnterminatedQuote is returned by splitWords() when a command line has an
+// unmatched quote or a trailing backslash.
+var errUnterminatedQuote = errors.New("unterminated quote or escape in command line")
+
+// pipefailShells are the shells we know to understand "set -o pipefail".
+var pipefailShells = map[string]bool{
+	"bash": true,
+	"zsh":  true,
+	"ksh":  true,
+}
+
+// jobShell returns the Job's Shell if it set one, otherwise the given default.
+func jobShell(job *Job, defaultShell string) string {
+	if job.Shell != "" {
+		return job.Shell
+	}
+	return defaultShell
+}
+
+// shellArgs returns the arguments needed to run the given command line with
+// the given shell, which is a shell executable optionally followed by its own
+// arguments (eg. "bash -l" for a login shell, so that things like
+// /etc/profile.d scripts and `module load` work), or ShellNone. Pipelines get
+// "set -o pipefail" prepended if the shell understands it.
+func shellArgs(shell, cmdLine string) ([]string, error) {
+	if shell == ShellNone {
+		args, err := splitWords(cmdLine)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 0 {
+			return nil, errors.New("empty command line")
+		}
+		return args, nil
+	}
+
+	args := strings.Fields(shell)
+	if len(args) == 0 {
+		return nil, errors.New("no shell specified")
+	}
+	if strings.Contains(cmdLine, " | ") && pipefailShells[filepath.Base(args[0])] {
+		cmdLine = "set -o pipefail; " + cmdLine
+	}
+	return append(args, "-c", cmdLine), nil
+}
+
+// shellCommand returns an *exec.Cmd that will run the given command line with
+// the given shell (as per shellArgs()), as the given user if runAs is not
+// blank.
+func shellCommand(shell, cmdLine, runAs string) (*exec.Cmd, error) {
+	args, err := shellArgs(shell, cmdLine)
+	if err != nil {
+		return nil, err
+	}
+	if runAs != "" {
+		return runAsCommand(runAs, args), nil
+	}
+	return exec.Command(args[0], args[1:]...), nil // #nosec running the user's command is the point
+}
+
+// loginShell returns the executable of the given shell, for use in getting a
+// login environment. ShellNone falls back on sh.
+func loginShell(shell string) string {
+	fields := strings.Fields(shell)
+	if shell == ShellNone || len(fields) == 0 {
+		return "sh"
+	}
+	return fields[0]
+}
+
+// splitWords splits the given command line in to words the way sh would,
+// without doing any expansions: words are separated by unquoted whitespace,
+// single quotes preserve everything within them, double quotes preserve
+// everything but backslash escapes of \ " $ and `, and an unquoted backslash
+// preserves the following character.
+func splitWords(cmdLine string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord, escaped := false, false
+	var quote rune
+
+	for _, r := range cmdLine {
+		switch {
+		case escaped:
+			escaped = false
+			if r == '\n' {
+				// line continuation
+				continue
+			}
+			if quote == '"' && !strings.ContainsRune("\\\"$`", r) {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(r)
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if escaped || quote != 0 {
+		return nil, errUnterminatedQuote
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
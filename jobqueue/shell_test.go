@@ -0,0 +1,93 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestShell(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("splitWords() splits command lines like sh would", t, func() {
+		words, err := splitWords(`echo  'a b' "c \"d\" \e" f\ g h"i"j`)
+		So(err, ShouldBeNil)
+		So(words, ShouldResemble, []string{"echo", "a b", `c "d" \e`, "f g", "hij"})
+
+		words, err = splitWords(`printf '' x\
+y`)
+		So(err, ShouldBeNil)
+		So(words, ShouldResemble, []string{"printf", "", "xy"})
+
+		words, err = splitWords("  ")
+		So(err, ShouldBeNil)
+		So(words, ShouldBeEmpty)
+
+		_, err = splitWords(`echo 'a`)
+		So(err, ShouldEqual, errUnterminatedQuote)
+		_, err = splitWords(`echo "a`)
+		So(err, ShouldEqual, errUnterminatedQuote)
+		_, err = splitWords(`echo a\`)
+		So(err, ShouldEqual, errUnterminatedQuote)
+	})
+
+	Convey("shellArgs() works with shells, shell arguments and no shell", t, func() {
+		args, err := shellArgs("bash", "a | b")
+		So(err, ShouldBeNil)
+		So(args, ShouldResemble, []string{"bash", "-c", "set -o pipefail; a | b"})
+
+		args, err = shellArgs("/bin/bash -l", "module load x; a")
+		So(err, ShouldBeNil)
+		So(args, ShouldResemble, []string{"/bin/bash", "-l", "-c", "module load x; a"})
+
+		args, err = shellArgs("sh", "a | b")
+		So(err, ShouldBeNil)
+		So(args, ShouldResemble, []string{"sh", "-c", "a | b"})
+
+		args, err = shellArgs(ShellNone, `echo "a | b"`)
+		So(err, ShouldBeNil)
+		So(args, ShouldResemble, []string{"echo", "a | b"})
+
+		_, err = shellArgs(ShellNone, "")
+		So(err, ShouldNotBeNil)
+		_, err = shellArgs(" ", "echo")
+		So(err, ShouldNotBeNil)
+
+		So(loginShell("bash -l"), ShouldEqual, "bash")
+		So(loginShell(ShellNone), ShouldEqual, "sh")
+	})
+
+	Convey("shellCommand() commands can be run", t, func() {
+		cmd, err := shellCommand("bash -l", `echo "$0" | tr a-z A-Z`, "")
+		So(err, ShouldBeNil)
+		out, err := cmd.Output()
+		So(err, ShouldBeNil)
+		So(string(out), ShouldEqual, "BASH\n")
+
+		cmd, err = shellCommand(ShellNone, `echo '$HOME' a\ \ b`, "")
+		So(err, ShouldBeNil)
+		out, err = cmd.Output()
+		So(err, ShouldBeNil)
+		So(string(out), ShouldEqual, "$HOME a  b\n")
+	})
+}
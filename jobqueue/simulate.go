@@ -0,0 +1,478 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of scheduling simulations, which
+// project when the jobs currently in the queue would complete given some
+// capacity and priorities, based on how long similar jobs took in the past.
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SimulationPriorityField* constants are the job properties a
+// SimulationPriorityRule can select jobs by.
+const (
+	SimulationPriorityFieldCores    = "cores"
+	SimulationPriorityFieldRAM      = "ram"
+	SimulationPriorityFieldTime     = "time"
+	SimulationPriorityFieldRepGroup = "rep_grp"
+)
+
+// simulationMinJobTime is the least time we assume any job will take to run.
+const simulationMinJobTime = 1 * time.Second
+
+// simulationEpsilon is how close to 0 free cores have to be to be considered
+// none, to avoid floating point rounding errors.
+const simulationEpsilon = 1e-9
+
+// SimulationPriorityRule changes the priority of the jobs it selects during a
+// simulation, multiplying their priority+1 by Factor. Jobs are selected by
+// Field: for "cores", "ram" (MB) and "time" (the estimated walltime, in
+// seconds), those with a value of at least Min; for "rep_grp", those with a
+// RepGroup equal to Match, or containing it if Substr is true.
+type SimulationPriorityRule struct {
+	Field  string  `json:"field"`
+	Min    float64 `json:"min,omitempty"`
+	Match  string  `json:"match,omitempty"`
+	Substr bool    `json:"substr,omitempty"`
+	Factor float64 `json:"factor"`
+}
+
+// ParseSimulationPriorityRule parses a rule of the form field>=value:factor
+// (for the cores, ram and time fields, time being a duration like 2h) or
+// rep_grp=name:factor (or rep_grp~substring:factor), eg. "cores>=8:2" to double
+// the priority of jobs that need 8 or more cores.
+func ParseSimulationPriorityRule(spec string) (*SimulationPriorityRule, error) {
+	i := strings.LastIndex(spec, ":")
+	if i == -1 {
+		return nil, fmt.Errorf("priority rule %q has no :factor", spec)
+	}
+	factor, err := strconv.ParseFloat(spec[i+1:], 64)
+	if err != nil || factor <= 0 {
+		return nil, fmt.Errorf("priority rule %q has an invalid factor; it must be a number greater than 0", spec)
+	}
+	rule := &SimulationPriorityRule{Factor: factor}
+	selector := spec[:i]
+
+	if strings.HasPrefix(selector, SimulationPriorityFieldRepGroup) {
+		rest := selector[len(SimulationPriorityFieldRepGroup):]
+		if len(rest) < 2 || (rest[0] != '=' && rest[0] != '~') {
+			return nil, fmt.Errorf("priority rule %q must be of the form rep_grp=name:factor or rep_grp~substring:factor", spec)
+		}
+		rule.Field = SimulationPriorityFieldRepGroup
+		rule.Substr = rest[0] == '~'
+		rule.Match = rest[1:]
+		return rule, nil
+	}
+
+	parts := strings.SplitN(selector, ">=", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("priority rule %q must be of the form field>=value:factor", spec)
+	}
+	rule.Field = parts[0]
+	switch rule.Field {
+	case SimulationPriorityFieldCores, SimulationPriorityFieldRAM:
+		rule.Min, err = strconv.ParseFloat(parts[1], 64)
+	case SimulationPriorityFieldTime:
+		var d time.Duration
+		d, err = time.ParseDuration(parts[1])
+		rule.Min = d.Seconds()
+	default:
+		return nil, fmt.Errorf("priority rule %q has an unknown field; use cores, ram, time or rep_grp", spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("priority rule %q has an invalid value: %w", spec, err)
+	}
+	return rule, nil
+}
+
+// String returns the rule in the form ParseSimulationPriorityRule() accepts.
+func (r *SimulationPriorityRule) String() string {
+	factor := strconv.FormatFloat(r.Factor, 'g', -1, 64)
+	switch r.Field {
+	case SimulationPriorityFieldRepGroup:
+		op := "="
+		if r.Substr {
+			op = "~"
+		}
+		return r.Field + op + r.Match + ":" + factor
+	case SimulationPriorityFieldTime:
+		return r.Field + ">=" + (time.Duration(r.Min) * time.Second).String() + ":" + factor
+	}
+	return r.Field + ">=" + strconv.FormatFloat(r.Min, 'g', -1, 64) + ":" + factor
+}
+
+// selects tells you if this rule applies to the given job, which is estimated
+// to take the given time to run.
+func (r *SimulationPriorityRule) selects(job *Job, estimate time.Duration) bool {
+	switch r.Field {
+	case SimulationPriorityFieldCores:
+		return job.Requirements.Cores >= r.Min
+	case SimulationPriorityFieldRAM:
+		return float64(job.Requirements.RAM) >= r.Min
+	case SimulationPriorityFieldTime:
+		return estimate.Seconds() >= r.Min
+	case SimulationPriorityFieldRepGroup:
+		if r.Substr {
+			return strings.Contains(job.RepGroup, r.Match)
+		}
+		return job.RepGroup == r.Match
+	}
+	return false
+}
+
+// SimulationScenario describes the conditions to simulate scheduling under:
+// the total number of cores available to run jobs, and rules that change the
+// priority of some jobs. When more than one rule selects a job, their factors
+// are multiplied together.
+type SimulationScenario struct {
+	Name          string                    `json:"name"`
+	Cores         float64                   `json:"cores"`
+	PriorityRules []*SimulationPriorityRule `json:"priority_rules,omitempty"`
+}
+
+// SimulatedRepGroup is the projected completion of a RepGroup's jobs, as part of
+// a SimulationResult. Completion is how long from the start of the simulation
+// it would be until all of them complete. Jobs that would never complete (see
+// SimulationResult) are counted in Stuck, and don't affect Completion.
+type SimulatedRepGroup struct {
+	RepGroup   string        `json:"rep_grp"`
+	Jobs       int           `json:"jobs"`
+	Stuck      int           `json:"stuck"`
+	Completion time.Duration `json:"completion"`
+}
+
+// SimulationResult is the outcome of running a SimulationScenario. Completion
+// is how long it would be until every job that can complete has completed.
+// Stuck jobs would never complete: they are buried, need more cores than the
+// scenario has, or depend on jobs that would never complete. RepGroups are
+// sorted by their Completion.
+type SimulationResult struct {
+	Scenario   *SimulationScenario  `json:"scenario"`
+	Jobs       int                  `json:"jobs"`
+	Stuck      int                  `json:"stuck"`
+	Completion time.Duration        `json:"completion"`
+	RepGroups  []*SimulatedRepGroup `json:"rep_grps"`
+}
+
+// RepGroup returns the SimulatedRepGroup with the given RepGroup, or nil if
+// there were no jobs in it.
+func (r *SimulationResult) RepGroup(repGroup string) *SimulatedRepGroup {
+	for _, srg := range r.RepGroups {
+		if srg.RepGroup == repGroup {
+			return srg
+		}
+	}
+	return nil
+}
+
+// simJob is a job as far as a Simulation is concerned.
+type simJob struct {
+	job        *Job
+	order      int
+	estimate   time.Duration
+	remaining  time.Duration
+	running    bool
+	buried     bool
+	deps       []*simJob
+	dependants []*simJob
+
+	// per-run state
+	priority float64
+	waiting  int
+	done     bool
+	end      time.Duration
+}
+
+// Simulation projects when the jobs currently in the queue would complete under
+// different SimulationScenarios. Make one with NewSimulation().
+type Simulation struct {
+	jobs []*simJob
+}
+
+// NewSimulation creates a Simulation of the given incomplete jobs (eg. from
+// Client.GetIncomplete()) as they were at the given time, estimating how long
+// each will take to run as the mean walltime of the given complete jobs (eg.
+// from Client.GetComplete()) with the same ReqGroup, or if there are none, the
+// job's Requirements.Time.
+//
+// Jobs that are already running are assumed to take their estimate minus the
+// time they have been running so far. Dependencies between the incomplete jobs
+// are respected; those on jobs that aren't amongst them are assumed to be
+// satisfied.
+func NewSimulation(incomplete, complete []*Job, now time.Time) *Simulation {
+	estimates := walltimeEstimates(complete)
+
+	sim := &Simulation{jobs: make([]*simJob, len(incomplete))}
+	byKey := make(map[string]*simJob, len(incomplete))
+	byDepGroup := make(map[string][]*simJob)
+	byRepGroup := make(map[string][]*simJob)
+	for i, job := range incomplete {
+		sj := &simJob{job: job, order: i}
+		if estimate, exists := estimates[job.ReqGroup]; exists {
+			sj.estimate = estimate
+		} else {
+			sj.estimate = job.Requirements.Time
+		}
+		if sj.estimate < simulationMinJobTime {
+			sj.estimate = simulationMinJobTime
+		}
+		sj.remaining = sj.estimate
+
+		switch job.State {
+		case JobStateReserved, JobStateRunning, JobStateStaging, JobStateUploading, JobStateLost:
+			sj.running = true
+			if !job.StartTime.IsZero() {
+				sj.remaining -= now.Sub(job.StartTime)
+				if sj.remaining < simulationMinJobTime {
+					sj.remaining = simulationMinJobTime
+				}
+			}
+		case JobStateBuried:
+			sj.buried = true
+		}
+
+		sim.jobs[i] = sj
+		byKey[job.Key()] = sj
+		for _, group := range job.DepGroups {
+			byDepGroup[group] = append(byDepGroup[group], sj)
+		}
+		byRepGroup[job.RepGroup] = append(byRepGroup[job.RepGroup], sj)
+	}
+
+	for _, sj := range sim.jobs {
+		if sj.running {
+			continue
+		}
+		seen := make(map[*simJob]bool)
+		addDeps := func(deps ...*simJob) {
+			for _, dep := range deps {
+				if dep == sj || seen[dep] {
+					continue
+				}
+				seen[dep] = true
+				sj.deps = append(sj.deps, dep)
+				dep.dependants = append(dep.dependants, sj)
+			}
+		}
+		for _, dep := range sj.job.Dependencies {
+			switch {
+			case dep.DepGroup != "":
+				addDeps(byDepGroup[dep.DepGroup]...)
+			case dep.RepGroup != "":
+				addDeps(byRepGroup[dep.RepGroup]...)
+			case dep.Essence != nil:
+				if depJob, exists := byKey[dep.Essence.Key()]; exists {
+					addDeps(depJob)
+				}
+			}
+		}
+	}
+
+	return sim
+}
+
+// walltimeEstimates returns the mean walltime of the given jobs keyed on their
+// ReqGroup.
+func walltimeEstimates(jobs []*Job) map[string]time.Duration {
+	totals := make(map[string]time.Duration)
+	counts := make(map[string]int)
+	for _, job := range jobs {
+		totals[job.ReqGroup] += job.WallTime()
+		counts[job.ReqGroup]++
+	}
+	estimates := make(map[string]time.Duration, len(totals))
+	for group, total := range totals {
+		estimates[group] = total / time.Duration(counts[group])
+	}
+	return estimates
+}
+
+// RunningCores returns the total cores requested by the jobs that are already
+// running, which is a lower bound of the capacity currently available.
+func (sim *Simulation) RunningCores() float64 {
+	var cores float64
+	for _, sj := range sim.jobs {
+		if sj.running {
+			cores += sj.job.Requirements.Cores
+		}
+	}
+	return cores
+}
+
+// Run simulates scheduling the jobs under the given scenario. Jobs are started
+// as soon as their dependencies are complete and there are enough free cores,
+// in order of priority (as modified by the scenario's rules), then the order
+// they were given to NewSimulation(); a job that doesn't fit doesn't stop
+// smaller lower priority jobs from starting. Jobs that are already running
+// carry on running, even if that uses more than the scenario's cores.
+func (sim *Simulation) Run(scenario *SimulationScenario) *SimulationResult {
+	free := scenario.Cores
+	ready := &simReadyHeap{}
+	ends := &simEndHeap{}
+	var stuck []*simJob
+	for _, sj := range sim.jobs {
+		sj.priority = float64(sj.job.Priority) + 1
+		for _, rule := range scenario.PriorityRules {
+			if rule.selects(sj.job, sj.estimate) {
+				sj.priority *= rule.Factor
+			}
+		}
+		sj.waiting = len(sj.deps)
+		sj.done = false
+		sj.end = 0
+
+		switch {
+		case sj.running:
+			free -= sj.job.Requirements.Cores
+			sj.end = sj.remaining
+			heap.Push(ends, sj)
+		case sj.buried:
+			stuck = append(stuck, sj)
+		case sj.job.Requirements.Cores > scenario.Cores+simulationEpsilon:
+			stuck = append(stuck, sj)
+		case sj.waiting == 0:
+			heap.Push(ready, sj)
+		}
+	}
+
+	var now time.Duration
+	for {
+		free = sim.start(ready, ends, free, now)
+		if ends.Len() == 0 {
+			break
+		}
+
+		now = (*ends)[0].end
+		for ends.Len() > 0 && (*ends)[0].end == now {
+			sj := heap.Pop(ends).(*simJob)
+			sj.done = true
+			free += sj.job.Requirements.Cores
+			for _, dependant := range sj.dependants {
+				dependant.waiting--
+				if dependant.waiting == 0 && !dependant.buried && dependant.job.Requirements.Cores <= scenario.Cores+simulationEpsilon {
+					heap.Push(ready, dependant)
+				}
+			}
+		}
+	}
+
+	return sim.result(scenario)
+}
+
+// start starts the ready jobs that fit in the given free cores at the given
+// time, adding them to ends, and returns the cores that remain free.
+func (sim *Simulation) start(ready *simReadyHeap, ends *simEndHeap, free float64, now time.Duration) float64 {
+	var skipped []*simJob
+	for ready.Len() > 0 {
+		sj := heap.Pop(ready).(*simJob)
+		cores := sj.job.Requirements.Cores
+		if cores > free+simulationEpsilon {
+			skipped = append(skipped, sj)
+			if free < simulationEpsilon {
+				break
+			}
+			continue
+		}
+		free -= cores
+		sj.end = now + sj.estimate
+		heap.Push(ends, sj)
+	}
+	for _, sj := range skipped {
+		heap.Push(ready, sj)
+	}
+	return free
+}
+
+// result summarises the outcome of the last Run().
+func (sim *Simulation) result(scenario *SimulationScenario) *SimulationResult {
+	result := &SimulationResult{Scenario: scenario, Jobs: len(sim.jobs)}
+	byRepGroup := make(map[string]*SimulatedRepGroup)
+	for _, sj := range sim.jobs {
+		srg, exists := byRepGroup[sj.job.RepGroup]
+		if !exists {
+			srg = &SimulatedRepGroup{RepGroup: sj.job.RepGroup}
+			byRepGroup[sj.job.RepGroup] = srg
+			result.RepGroups = append(result.RepGroups, srg)
+		}
+		srg.Jobs++
+
+		if !sj.done {
+			srg.Stuck++
+			result.Stuck++
+			continue
+		}
+		if sj.end > srg.Completion {
+			srg.Completion = sj.end
+		}
+		if sj.end > result.Completion {
+			result.Completion = sj.end
+		}
+	}
+
+	sort.SliceStable(result.RepGroups, func(i, j int) bool {
+		if result.RepGroups[i].Completion == result.RepGroups[j].Completion {
+			return result.RepGroups[i].RepGroup < result.RepGroups[j].RepGroup
+		}
+		return result.RepGroups[i].Completion < result.RepGroups[j].Completion
+	})
+	return result
+}
+
+// simReadyHeap is a heap of simJobs ordered by their priority, then their
+// order.
+type simReadyHeap []*simJob
+
+func (h simReadyHeap) Len() int { return len(h) }
+func (h simReadyHeap) Less(i, j int) bool {
+	if h[i].priority == h[j].priority {
+		return h[i].order < h[j].order
+	}
+	return h[i].priority > h[j].priority
+}
+func (h simReadyHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *simReadyHeap) Push(x interface{}) { *h = append(*h, x.(*simJob)) }
+func (h *simReadyHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	sj := old[n-1]
+	*h = old[:n-1]
+	return sj
+}
+
+// simEndHeap is a heap of simJobs ordered by when they end.
+type simEndHeap []*simJob
+
+func (h simEndHeap) Len() int            { return len(h) }
+func (h simEndHeap) Less(i, j int) bool  { return h[i].end < h[j].end }
+func (h simEndHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *simEndHeap) Push(x interface{}) { *h = append(*h, x.(*simJob)) }
+func (h *simEndHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	sj := old[n-1]
+	*h = old[:n-1]
+	return sj
+}
@@ -0,0 +1,132 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSimulation(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Simulation priority rules can be parsed", t, func() {
+		rule, err := ParseSimulationPriorityRule("cores>=8:2")
+		So(err, ShouldBeNil)
+		So(rule, ShouldResemble, &SimulationPriorityRule{Field: SimulationPriorityFieldCores, Min: 8, Factor: 2})
+		So(rule.String(), ShouldEqual, "cores>=8:2")
+
+		rule, err = ParseSimulationPriorityRule("time>=2h:1.5")
+		So(err, ShouldBeNil)
+		So(rule.Min, ShouldEqual, 7200)
+		So(rule.String(), ShouldEqual, "time>=2h0m0s:1.5")
+
+		rule, err = ParseSimulationPriorityRule("rep_grp~a:b:0.5")
+		So(err, ShouldBeNil)
+		So(rule, ShouldResemble, &SimulationPriorityRule{Field: SimulationPriorityFieldRepGroup, Match: "a:b", Substr: true, Factor: 0.5})
+		So(rule.String(), ShouldEqual, "rep_grp~a:b:0.5")
+
+		for _, bad := range []string{"cores>=8", "cores>=8:0", "cores>=8:x", "cores=8:2", "disk>=8:2", "ram>=lots:2", "rep_grp:2", "rep_grp>foo:2"} {
+			_, err = ParseSimulationPriorityRule(bad)
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("Given some incomplete and complete jobs", t, func() {
+		now := time.Now()
+		req := func(cores float64, t time.Duration) *scheduler.Requirements {
+			return &scheduler.Requirements{Cores: cores, Time: t, RAM: 100}
+		}
+		job := func(cmd, repGroup, reqGroup string, cores float64, state JobState) *Job {
+			return &Job{Cmd: cmd, RepGroup: repGroup, ReqGroup: reqGroup, Requirements: req(cores, time.Hour), State: state}
+		}
+
+		complete := []*Job{
+			{Cmd: "c1", ReqGroup: "small", StartTime: now.Add(-5 * time.Hour), EndTime: now.Add(-4 * time.Hour), State: JobStateComplete},
+			{Cmd: "c2", ReqGroup: "small", StartTime: now.Add(-5 * time.Hour), EndTime: now.Add(-2 * time.Hour), State: JobStateComplete},
+			{Cmd: "c3", ReqGroup: "big", StartTime: now.Add(-5 * time.Hour), EndTime: now.Add(-1 * time.Hour), State: JobStateComplete},
+		}
+
+		running := job("r", "a", "small", 1, JobStateRunning)
+		running.StartTime = now.Add(-30 * time.Minute)
+		s1 := job("s1", "a", "small", 1, JobStateReady)
+		s2 := job("s2", "a", "small", 1, JobStateReady)
+		b1 := job("b1", "b", "big", 2, JobStateReady)
+		after := job("after", "c", "other", 1, JobStateDependent)
+		after.Dependencies = Dependencies{NewRepGroupDependency("b")}
+		buried := job("buried", "d", "small", 1, JobStateBuried)
+		huge := job("huge", "e", "small", 16, JobStateReady)
+		blocked := job("blocked", "e", "small", 1, JobStateDependent)
+		blocked.Dependencies = Dependencies{NewEssenceDependency("huge", "")}
+
+		sim := NewSimulation([]*Job{running, s1, s2, b1, after, buried, huge, blocked}, complete, now)
+
+		Convey("Running cores can be found", func() {
+			So(sim.RunningCores(), ShouldEqual, 1)
+		})
+
+		Convey("Jobs are run in order with the given cores", func() {
+			r := sim.Run(&SimulationScenario{Name: "baseline", Cores: 2})
+			So(r.Jobs, ShouldEqual, 8)
+			So(r.Stuck, ShouldEqual, 3)
+
+			// running ends at 1.5h, s1 at 2h, s2 at 3.5h, b1 starts at 3.5h and
+			// takes 4h, then after takes 1h
+			So(r.RepGroup("a").Completion, ShouldEqual, 3*time.Hour+30*time.Minute)
+			So(r.RepGroup("b").Completion, ShouldEqual, 7*time.Hour+30*time.Minute)
+			So(r.RepGroup("c").Completion, ShouldEqual, 8*time.Hour+30*time.Minute)
+			So(r.Completion, ShouldEqual, 8*time.Hour+30*time.Minute)
+			So(r.RepGroup("d").Stuck, ShouldEqual, 1)
+			So(r.RepGroup("e").Stuck, ShouldEqual, 2)
+			So(r.RepGroup("e").Jobs, ShouldEqual, 2)
+			So(r.RepGroup("nonexistent"), ShouldBeNil)
+			So(r.RepGroups[0].RepGroup, ShouldEqual, "d")
+
+			Convey("More cores makes things complete sooner", func() {
+				r = sim.Run(&SimulationScenario{Cores: 4})
+
+				// s1 and s2 start immediately, but b1 has to wait for running
+				So(r.RepGroup("a").Completion, ShouldEqual, 2*time.Hour)
+				So(r.RepGroup("b").Completion, ShouldEqual, 5*time.Hour+30*time.Minute)
+				So(r.Completion, ShouldEqual, 6*time.Hour+30*time.Minute)
+
+				r = sim.Run(&SimulationScenario{Cores: 16})
+				So(r.Stuck, ShouldEqual, 1)
+				So(r.RepGroup("e").Completion, ShouldEqual, 9*time.Hour)
+			})
+
+			Convey("Priority rules change the order jobs run in", func() {
+				rule, err := ParseSimulationPriorityRule("cores>=2:2")
+				So(err, ShouldBeNil)
+				r = sim.Run(&SimulationScenario{Cores: 3, PriorityRules: []*SimulationPriorityRule{rule}})
+
+				// b1 starts immediately alongside running, then s1 and s2 run
+				// one after the other in the remaining core
+				So(r.RepGroup("b").Completion, ShouldEqual, 4*time.Hour)
+				So(r.RepGroup("c").Completion, ShouldEqual, 5*time.Hour)
+				So(r.RepGroup("a").Completion, ShouldEqual, 5*time.Hour+30*time.Minute)
+			})
+		})
+	})
+}
@@ -0,0 +1,238 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of job spooling, where jobs that
+// couldn't be added because the server was unreachable are stored in a local
+// file, to be added later on.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"syscall"
+
+	"github.com/ugorji/go/codec"
+)
+
+// SpoolQuarantineSuffix is appended to the path of a spool file to get the path
+// of the file that Client.FlushSpool() moves any corrupt records in to.
+const SpoolQuarantineSuffix = ".corrupt"
+
+// spoolRecordMagic starts every record in a spool file, so that we can find
+// the start of the next record after one that is corrupt.
+var spoolRecordMagic = []byte("wrSP")
+
+// spoolHeaderSize is the size of the header of a record in a spool file: its
+// magic, followed by the length and CRC32 checksum of its encoded spooledAdd,
+// as big-endian uint32s.
+const spoolHeaderSize = 12
+
+// spooledAdd holds the arguments to a single Add() call that was spooled.
+type spooledAdd struct {
+	Jobs           []*Job
+	Env            []string
+	IgnoreComplete bool
+}
+
+// SpoolJobs appends the given jobs to the spool file at the given path
+// (creating it if necessary), along with the other arguments you would have
+// supplied to Client.Add(). Use this when you can't Connect() to the server,
+// and later call Client.FlushSpool() to actually add the jobs. It is safe for
+// multiple processes to spool to the same file at once.
+func SpoolJobs(path string, jobs []*Job, envVars []string, ignoreComplete bool) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return err
+	}
+
+	record, err := encodeSpoolRecord(&spooledAdd{Jobs: jobs, Env: envVars, IgnoreComplete: ignoreComplete})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(record)
+	return err
+}
+
+// encodeSpoolRecord encodes the given spooledAdd as a record for a spool file.
+func encodeSpoolRecord(sa *spooledAdd) ([]byte, error) {
+	var payload []byte
+	enc := codec.NewEncoderBytes(&payload, new(codec.BincHandle))
+	if err := enc.Encode(sa); err != nil {
+		return nil, err
+	}
+
+	record := make([]byte, spoolHeaderSize, spoolHeaderSize+len(payload))
+	copy(record, spoolRecordMagic)
+	binary.BigEndian.PutUint32(record[4:8], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[8:12], crc32.ChecksumIEEE(payload))
+	return append(record, payload...), nil
+}
+
+// decodeSpoolRecord decodes the spool file record at the start of the given
+// data, returning it and its length. Returns nil if there isn't a complete,
+// uncorrupted record there.
+func decodeSpoolRecord(data []byte) (*spooledAdd, int) {
+	if len(data) < spoolHeaderSize || !bytes.HasPrefix(data, spoolRecordMagic) {
+		return nil, 0
+	}
+	size := binary.BigEndian.Uint32(data[4:8])
+	if uint64(size) > uint64(len(data)-spoolHeaderSize) {
+		return nil, 0
+	}
+	end := spoolHeaderSize + int(size)
+	payload := data[spoolHeaderSize:end]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(data[8:12]) {
+		return nil, 0
+	}
+
+	sa := &spooledAdd{}
+	if err := codec.NewDecoderBytes(payload, new(codec.BincHandle)).Decode(sa); err != nil {
+		return nil, 0
+	}
+	return sa, end
+}
+
+// parseSpool decodes the records in the given contents of a spool file. Any
+// corrupt parts, such as records that were only partially written, are skipped
+// over to the start of the next record, and returned separately.
+func parseSpool(data []byte) ([]*spooledAdd, [][]byte) {
+	var spooled []*spooledAdd
+	var corrupt [][]byte
+	for len(data) > 0 {
+		if sa, n := decodeSpoolRecord(data); sa != nil {
+			spooled = append(spooled, sa)
+			data = data[n:]
+			continue
+		}
+
+		next := bytes.Index(data[1:], spoolRecordMagic)
+		if next < 0 {
+			corrupt = append(corrupt, data)
+			break
+		}
+		corrupt = append(corrupt, data[:next+1])
+		data = data[next+1:]
+	}
+	return spooled, corrupt
+}
+
+// quarantineSpool appends the given corrupt parts of the spool file at the
+// given path to its quarantine file, so that they're not lost.
+func (c *Client) quarantineSpool(path string, corrupt [][]byte) error {
+	qpath := path + SpoolQuarantineSuffix
+	q, err := os.OpenFile(qpath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	for _, part := range corrupt {
+		if _, err = q.Write(part); err != nil {
+			q.Close()
+			return err
+		}
+		c.Warn("quarantined a corrupt spool record", "spool", path, "quarantine", qpath, "bytes", len(part))
+	}
+	return q.Close()
+}
+
+// FlushSpool adds all the jobs previously spooled to the given path with
+// SpoolJobs(), in the order they were spooled, removing them from the spool as
+// they are added. The returned values are the total of those you'd get from
+// calling Add() for each spooling. It is not an error for the spool file not to
+// exist.
+//
+// If adding some jobs fails, the error is returned and those jobs, along with
+// all subsequently spooled jobs, remain in the spool to be added by a later
+// call.
+//
+// Corrupt records in the spool, such as those left partially written by a
+// crash, are appended to a file at path + SpoolQuarantineSuffix and logged,
+// and the other records are still added.
+func (c *Client) FlushSpool(path string) (added, existed int, err error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0600)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return added, existed, err
+	}
+	defer f.Close()
+
+	if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return added, existed, err
+	}
+
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return added, existed, err
+	}
+	spooled, corrupt := parseSpool(data)
+	if len(corrupt) > 0 {
+		// the spool gets rewritten without these below
+		if err = c.quarantineSpool(path, corrupt); err != nil {
+			return added, existed, err
+		}
+	}
+
+	for i, sa := range spooled {
+		a, e, erra := c.Add(sa.Jobs, sa.Env, sa.IgnoreComplete)
+		if erra != nil {
+			err = rewriteSpool(f, spooled[i:])
+			if err == nil {
+				err = erra
+			}
+			return added, existed, err
+		}
+		added += a
+		existed += e
+	}
+
+	return added, existed, f.Truncate(0)
+}
+
+// rewriteSpool replaces the contents of the given spool file with the given
+// spooled adds.
+func rewriteSpool(f *os.File, spooled []*spooledAdd) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, sa := range spooled {
+		record, err := encodeSpoolRecord(sa)
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write(record); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
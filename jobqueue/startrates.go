@@ -0,0 +1,208 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of RepGroup start rates, which limit
+// how quickly the jobs in a RepGroup may start running, so that they don't
+// overwhelm a shared service by all starting at once.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// startRateUnlimited is the number of starts per interval beyond which a
+// ramped StartRate is treated as no longer limiting anything.
+const startRateUnlimited = math.MaxInt32
+
+// StartRate limits how many jobs in a RepGroup may start running in each
+// Interval. In the first Interval, up to Starts jobs may start; in each
+// subsequent Interval, the allowance is multiplied by Ramp, so that a Ramp
+// greater than 1 lets the RepGroup ramp up to full speed, while a Ramp of 1 (or
+// 0, which is treated as 1) keeps a constant rate. The ramp starts again from
+// Starts if none of the RepGroup's jobs try to start for a whole Interval.
+//
+// Jobs that are reserved once their RepGroup's allowance has been used up are
+// delayed until the next Interval.
+type StartRate struct {
+	RepGroup string
+	Starts   int
+	Interval time.Duration
+	Ramp     float64
+}
+
+// Validate checks that the start rate is for a RepGroup and has sensible
+// values.
+func (r *StartRate) Validate() error {
+	switch {
+	case r.RepGroup == "":
+		return fmt.Errorf("a start rate needs a report group")
+	case r.Starts < 1:
+		return fmt.Errorf("a start rate must allow at least 1 start per interval")
+	case r.Interval < time.Second:
+		return fmt.Errorf("a start rate interval must be at least 1s")
+	case r.Ramp != 0 && r.Ramp < 1:
+		return fmt.Errorf("a start rate ramp can't be less than 1")
+	}
+	return nil
+}
+
+// String describes the start rate.
+func (r *StartRate) String() string {
+	s := fmt.Sprintf("%s may start %d jobs per %s", r.RepGroup, r.Starts, r.Interval)
+	if r.Ramp > 1 {
+		s += ", ramping up by a factor of " + strconv.FormatFloat(r.Ramp, 'g', -1, 64) + " each interval"
+	}
+	return s
+}
+
+// allowance returns how many jobs may start in the given interval since the
+// ramp started, the first interval being 0.
+func (r *StartRate) allowance(interval int) int {
+	if r.Ramp <= 1 {
+		return r.Starts
+	}
+	allowed := float64(r.Starts) * math.Pow(r.Ramp, float64(interval))
+	if allowed >= startRateUnlimited {
+		return startRateUnlimited
+	}
+	return int(allowed)
+}
+
+// startRateState tracks the starts of a RepGroup against its StartRate.
+type startRateState struct {
+	rate        *StartRate
+	windowStart time.Time
+	window      int
+	started     int
+}
+
+// take uses up one of the starts allowed at the given time, returning 0, or if
+// none are left, returns how long it will be until the next interval, when
+// more will be allowed.
+func (st *startRateState) take(now time.Time) time.Duration {
+	interval := st.rate.Interval
+	passed := int(now.Sub(st.windowStart) / interval)
+	switch {
+	case st.windowStart.IsZero() || passed > 1:
+		// nothing tried to start for a whole interval (or ever), so ramp up
+		// afresh
+		st.windowStart = now
+		st.window = 0
+		st.started = 0
+	case passed == 1:
+		st.windowStart = st.windowStart.Add(interval)
+		st.window++
+		st.started = 0
+	}
+
+	if st.started < st.rate.allowance(st.window) {
+		st.started++
+		return 0
+	}
+	return st.windowStart.Add(interval).Sub(now)
+}
+
+// restoreStartRates loads the start rates stored in the database by
+// setStartRate().
+func (s *Server) restoreStartRates() {
+	rates, err := s.db.retrieveStartRates()
+	if err != nil {
+		s.Warn("failed to retrieve stored start rates", "err", err)
+		return
+	}
+	s.srmutex.Lock()
+	defer s.srmutex.Unlock()
+	for _, rate := range rates {
+		s.startRates[rate.RepGroup] = &startRateState{rate: rate}
+	}
+}
+
+// setStartRate validates the given start rate and sets it as the start rate of
+// its RepGroup, replacing any existing one, in which case the record of recent
+// starts is kept, but the ramp starts again. Returns a copy of the start rate
+// as set.
+func (s *Server) setStartRate(rate *StartRate) (*StartRate, error) {
+	if err := rate.Validate(); err != nil {
+		return nil, err
+	}
+	r := *rate
+
+	s.srmutex.Lock()
+	st, exists := s.startRates[r.RepGroup]
+	if !exists {
+		st = &startRateState{}
+		s.startRates[r.RepGroup] = st
+	}
+	st.rate = &r
+	st.window = 0
+	s.srmutex.Unlock()
+
+	set := r
+	if err := s.db.storeStartRate(&set); err != nil {
+		return nil, err
+	}
+	s.Info("set start rate", "rg", set.RepGroup, "starts", set.Starts, "interval", set.Interval, "ramp", set.Ramp)
+	return &set, nil
+}
+
+// deleteStartRate removes the start rate of the given RepGroup. Returns false
+// if it didn't have one.
+func (s *Server) deleteStartRate(repGroup string) (bool, error) {
+	s.srmutex.Lock()
+	_, exists := s.startRates[repGroup]
+	delete(s.startRates, repGroup)
+	s.srmutex.Unlock()
+	if !exists {
+		return false, nil
+	}
+	s.Info("deleted start rate", "rg", repGroup)
+	return true, s.db.deleteStartRate(repGroup)
+}
+
+// startRateList returns copies of all the start rates, sorted by RepGroup.
+func (s *Server) startRateList() []*StartRate {
+	s.srmutex.Lock()
+	rates := make([]*StartRate, 0, len(s.startRates))
+	for _, st := range s.startRates {
+		r := *st.rate
+		rates = append(rates, &r)
+	}
+	s.srmutex.Unlock()
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].RepGroup < rates[j].RepGroup
+	})
+	return rates
+}
+
+// startRateHold is called when a job in the given RepGroup has been reserved.
+// If the RepGroup has a start rate, it uses up one of its starts and returns 0,
+// or if there are none left, returns how long the job should be delayed for.
+func (s *Server) startRateHold(repGroup string) time.Duration {
+	s.srmutex.Lock()
+	defer s.srmutex.Unlock()
+	st, exists := s.startRates[repGroup]
+	if !exists {
+		return 0
+	}
+	return st.take(time.Now())
+}
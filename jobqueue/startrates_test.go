@@ -0,0 +1,76 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStartRates(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("StartRates can be validated", t, func() {
+		So((&StartRate{Starts: 1, Interval: time.Second}).Validate(), ShouldNotBeNil)
+		So((&StartRate{RepGroup: "a", Interval: time.Second}).Validate(), ShouldNotBeNil)
+		So((&StartRate{RepGroup: "a", Starts: 1, Interval: time.Millisecond}).Validate(), ShouldNotBeNil)
+		So((&StartRate{RepGroup: "a", Starts: 1, Interval: time.Second, Ramp: 0.5}).Validate(), ShouldNotBeNil)
+		So((&StartRate{RepGroup: "a", Starts: 1, Interval: time.Second}).Validate(), ShouldBeNil)
+		So((&StartRate{RepGroup: "a", Starts: 1, Interval: time.Second, Ramp: 1.5}).Validate(), ShouldBeNil)
+	})
+
+	Convey("StartRates ramp up their allowance", t, func() {
+		r := &StartRate{RepGroup: "a", Starts: 10, Interval: time.Minute}
+		So(r.allowance(0), ShouldEqual, 10)
+		So(r.allowance(5), ShouldEqual, 10)
+		So(r.String(), ShouldEqual, "a may start 10 jobs per 1m0s")
+
+		r.Ramp = 2
+		So(r.allowance(0), ShouldEqual, 10)
+		So(r.allowance(1), ShouldEqual, 20)
+		So(r.allowance(3), ShouldEqual, 80)
+		So(r.allowance(100), ShouldEqual, startRateUnlimited)
+		So(r.String(), ShouldEqual, "a may start 10 jobs per 1m0s, ramping up by a factor of 2 each interval")
+	})
+
+	Convey("Starts are limited per interval", t, func() {
+		st := &startRateState{rate: &StartRate{RepGroup: "a", Starts: 2, Interval: time.Minute, Ramp: 2}}
+		now := time.Now()
+		So(st.take(now), ShouldEqual, 0)
+		So(st.take(now.Add(10*time.Second)), ShouldEqual, 0)
+		So(st.take(now.Add(20*time.Second)), ShouldEqual, 40*time.Second)
+
+		next := now.Add(time.Minute)
+		for i := 0; i < 4; i++ {
+			So(st.take(next), ShouldEqual, 0)
+		}
+		So(st.take(next.Add(59*time.Second)), ShouldEqual, time.Second)
+
+		Convey("The ramp starts again after an idle interval", func() {
+			later := next.Add(3 * time.Minute)
+			So(st.take(later), ShouldEqual, 0)
+			So(st.take(later), ShouldEqual, 0)
+			So(st.take(later), ShouldEqual, time.Minute)
+		})
+	})
+}
@@ -1,3896 +0,0 @@
-// Code generated by "esc -pkg jobqueue -prefix static -private -o jobqueue/static.go static"; DO NOT EDIT.
-
-package jobqueue
-
-import (
-	"bytes"
-	"compress/gzip"
-	"encoding/base64"
-	"fmt"
-	"io"
-	"io/ioutil"
-	"net/http"
-	"os"
-	"path"
-	"sync"
-	"time"
-)
-
-type _escLocalFS struct{}
-
-var _escLocal _escLocalFS
-
-type _escStaticFS struct{}
-
-var _escStatic _escStaticFS
-
-type _escDirectory struct {
-	fs   http.FileSystem
-	name string
-}
-
-type _escFile struct {
-	compressed string
-	size       int64
-	modtime    int64
-	local      string
-	isDir      bool
-
-	once sync.Once
-	data []byte
-	name string
-}
-
-func (_escLocalFS) Open(name string) (http.File, error) {
-	f, present := _escData[path.Clean(name)]
-	if !present {
-		return nil, os.ErrNotExist
-	}
-	return os.Open(f.local)
-}
-
-func (_escStaticFS) prepare(name string) (*_escFile, error) {
-	f, present := _escData[path.Clean(name)]
-	if !present {
-		return nil, os.ErrNotExist
-	}
-	var err error
-	f.once.Do(func() {
-		f.name = path.Base(name)
-		if f.size == 0 {
-			return
-		}
-		var gr *gzip.Reader
-		b64 := base64.NewDecoder(base64.StdEncoding, bytes.NewBufferString(f.compressed))
-		gr, err = gzip.NewReader(b64)
-		if err != nil {
-			return
-		}
-		f.data, err = ioutil.ReadAll(gr)
-	})
-	if err != nil {
-		return nil, err
-	}
-	return f, nil
-}
-
-func (fs _escStaticFS) Open(name string) (http.File, error) {
-	f, err := fs.prepare(name)
-	if err != nil {
-		return nil, err
-	}
-	return f.File()
-}
-
-func (dir _escDirectory) Open(name string) (http.File, error) {
-	return dir.fs.Open(dir.name + name)
-}
-
-func (f *_escFile) File() (http.File, error) {
-	type httpFile struct {
-		*bytes.Reader
-		*_escFile
-	}
-	return &httpFile{
-		Reader:   bytes.NewReader(f.data),
-		_escFile: f,
-	}, nil
-}
-
-func (f *_escFile) Close() error {
-	return nil
-}
-
-func (f *_escFile) Readdir(count int) ([]os.FileInfo, error) {
-	if !f.isDir {
-		return nil, fmt.Errorf(" escFile.Readdir: '%s' is not directory", f.name)
-	}
-
-	fis, ok := _escDirs[f.local]
-	if !ok {
-		return nil, fmt.Errorf(" escFile.Readdir: '%s' is directory, but we have no info about content of this dir, local=%s", f.name, f.local)
-	}
-	limit := count
-	if count <= 0 || limit > len(fis) {
-		limit = len(fis)
-	}
-
-	if len(fis) == 0 && count > 0 {
-		return nil, io.EOF
-	}
-
-	return []os.FileInfo(fis[0:limit]), nil
-}
-
-func (f *_escFile) Stat() (os.FileInfo, error) {
-	return f, nil
-}
-
-func (f *_escFile) Name() string {
-	return f.name
-}
-
-func (f *_escFile) Size() int64 {
-	return f.size
-}
-
-func (f *_escFile) Mode() os.FileMode {
-	return 0
-}
-
-func (f *_escFile) ModTime() time.Time {
-	return time.Unix(f.modtime, 0)
-}
-
-func (f *_escFile) IsDir() bool {
-	return f.isDir
-}
-
-func (f *_escFile) Sys() interface{} {
-	return f
-}
-
-// _escFS returns a http.Filesystem for the embedded assets. If useLocal is true,
-// the filesystem's contents are instead used.
-func _escFS(useLocal bool) http.FileSystem {
-	if useLocal {
-		return _escLocal
-	}
-	return _escStatic
-}
-
-// _escDir returns a http.Filesystem for the embedded assets on a given prefix dir.
-// If useLocal is true, the filesystem's contents are instead used.
-func _escDir(useLocal bool, name string) http.FileSystem {
-	if useLocal {
-		return _escDirectory{fs: _escLocal, name: name}
-	}
-	return _escDirectory{fs: _escStatic, name: name}
-}
-
-// _escFSByte returns the named file from the embedded assets. If useLocal is
-// true, the filesystem's contents are instead used.
-func _escFSByte(useLocal bool, name string) ([]byte, error) {
-	if useLocal {
-		f, err := _escLocal.Open(name)
-		if err != nil {
-			return nil, err
-		}
-		b, err := ioutil.ReadAll(f)
-		_ = f.Close()
-		return b, err
-	}
-	f, err := _escStatic.prepare(name)
-	if err != nil {
-		return nil, err
-	}
-	return f.data, nil
-}
-
-// _escFSMustByte is the same as _escFSByte, but panics if name is not present.
-func _escFSMustByte(useLocal bool, name string) []byte {
-	b, err := _escFSByte(useLocal, name)
-	if err != nil {
-		panic(err)
-	}
-	return b
-}
-
-// _escFSString is the string version of _escFSByte.
-func _escFSString(useLocal bool, name string) (string, error) {
-	b, err := _escFSByte(useLocal, name)
-	return string(b), err
-}
-
-// _escFSMustString is the string version of _escFSMustByte.
-func _escFSMustString(useLocal bool, name string) string {
-	return string(_escFSMustByte(useLocal, name))
-}
-
-var _escData = map[string]*_escFile{
-
-	"/css/bootstrap-3.3.7.min.css": {
-		name:    "bootstrap-3.3.7.min.css",
-		local:   "static/css/bootstrap-3.3.7.min.css",
-		size:    121155,
-		modtime: 1534412155,
-		compressed: `
-H4sIAAAAAAAC/+y93bPjuLEn+D5/BV0dFV3lElUk9XUkhc/6rmdiriPG92X8MBHt2g2IhCR2USRNUuej
-Nbp/+wbxRSCRICmd021vjG/FdesAPyQSiQQSSAKJr7//3X/xfu/930XR1E1FSu9pNp1NV96nY9OUm69f
-D7TZybxpXJw+t+g/FeVrlR6OjRcFYehHQbj0/vqcNg2tJt6f83jagv5HGtO8pol3zhNaeX/581850bql
-mjbH866l97V53tVfVRVfd1mx+3oidUOrr//jz3/6b//xP/9bW+XXr7//nZcX1Ylk6S90Gtd1y2gwnXn/
-m1EWlXn/29NI5zQuMlJ/Ncv9/uuxOWWXfZE3/p6c0ux1U5O89mtapfut/0x339PGb+hL49fpL9Qnyc/n
-utmEQfBx659qPOe6K5LXy4lUhzTfBFdSNWmc0Qmp04ROEtqQNKsn+/QQk7JJi7z9ea7oZF8UrcyOlCTt
-fw5VcS4nJ5LmkxPNz5OcPE1qGrMS9fl0ItXrJUnrMiOvm11WxN+v5JykxSQm+ROpJ2VVHCpa15OnNKGF
-QqZ5lubUZwW2T7RljWQ+ydJDvtmRmra5nNAmL5pPP8VF3lRFVn/7rEjkRU63R9p2+Sa4/nRMk4Tm3yYN
-PZUZaaiBu5LLjsTf27bkiR8XWVFtmorkdUkqmjdXsiFxkz7RCdkciydaXYpz07LQim23q35q0iaj3y67
-okpo5e+KpilOm7B88ZKiaWhy3U3qpiryA+/BZ87UKgiuyT7naXXzmtFN2pAsja/HUHbLdLmiJy/Yckz6
-C91E9HQ9ker7hXP5QxAE2473zQ/7fXCtTyQT2sLKPAQfr/V5N6nP5aUs6rTtnE1FM9K2SaO9WnzcMrlL
-sTlF31JqinLjTxf01NK+iEb706hNSU8HIY1NcK2fDqyXNlVRNJ8vrQD3WfG84V1y5XolWxzSkzcPypfr
-sbooNqSG74qXltM0P2zaHqc5S9r6p+IXVx6efC0r2jFCzk1xjYuETr7vkklZ0UlNTqUx3E5FXtQlielE
-/dIEF9LTdXdumiKfpHl5biZF2fCBUdOMxs2kHYCkokQNN1Z4k+ZHWqXNlvel+EtQ6th7Sut0l1FZAyd5
-YWOaKem+qE5cjQWinSw8xshPzWtJ/8CTv020pIrWtDFS6vPulDbfLlLWpCwpqUge0w0vv43PVV1Um7JI
-84ZWorKfkrQmu4wm3/RqVeJFFEronpwz2bbNhnXZvojPtZ/mOa04J3b6pSRJ0nZesFX6xKAXXVH5bHnV
-WhMfafx9V7yYjSZJWnQt1FRDjVxbmbQsPFVxqNefn087Wn3bbGRlrDV+Xaa5r2uKA12cGxMtxwJTVb3X
-KKniI9qmt42QLaIHrcrtU5olCAcd7zzBj9siGdJYV4GExkVF2rkJ00Gm36zymjZKK6azBT1502XE/rNa
-0NNWjjAvKl+kzrRTcV1kaeLVafZEq2tGDzRPMOVSI9WcHeSAtmbwptVzOfO384JOrzUlGSlrupE/rk0y
-aY5dxdd2kfA/i3MV042HLDWOi13JjP/C3xVpRitmvIwlR13FX+O6/traYLFa+OOJJinxyirNm8vvJxuy
-b032Zkf3RUU1y/G79FQWVUPyZsuXCEeSFM9M1lqWZl4CTy9jKB1e1JVzJRPCJraGJnwq67p/w1Zf3MT/
-dKzo/htvwEWo5+aD9+mDR5qm+tTmfvY+fP6g22EnmmULOCP8//zhw8/kidRxlZbN5oMoOVGZP3ywiH24
-skXJ389FQ1tTcbFU7If1er0tyYH6u4qS736atyuqDXkq0uTatOsmtfZgyuPzpZTP9Ku1nJOmnfbw8q1h
-PZEX/zlNmiNbxmkyPUaT42xSXoqqPJK83sy2z2lSPNebGc/SqbJmCaLTnDztSGUuiaa7Jn+cxqSizWSa
-VEV5Lh+1NKnyTVH6mEJdpxnZ0QwRTxAE16kxbKxRopNhSK9JJvLX0V6r/bDf760yPqdOk66wlnREOEuS
-RKNy/aNYAMTUWAr8+N+z1/KYxkVee/9Osn2W5of6x21dxZtzlX2aTr+26PrrQcH8o4T5FT2cM1JNadF8
-vr3I//VDSvfpy2evNfmk+fQjPe1oktDEL0qat7Prj58n4yk+F/t91BFjf95MwCx/U/Gm0Uo31Zne3IL6
-6fBDB/h/FUDkd9Trp8OPn69ThUXWwe16NixftugeZIQCaOt4vh7Z6rZiHgTG2jpUsycvdyqK5tiaBJI3
-KclSUtOEm+2ifoGYQ0Ve65hkVGuRz6xBWn/vpnkxZf0tCCLyQYeW2blGYTsDRs+VQE3M1MIuHAUkNgqf
-0hyrJIrCyMDFWXFOENwyCE1m8ieaFSVFoKtgbTaP5nGaocC9ATxkpEZ4pAGo+3Su0xjFmW3hKxkUODOA
-R0qqBsUtTIINqVDY0oL59FQ2ryh4ZYDPNcVpPhiwfZqdUJgp6+boZ6Q6UAwaBgCKgkKLXlqjsgGKU3xH
-QaagK3oqnnDm5gbwl6I4+WmOIhc2sjjjLJr9Uuz3KMrskDo95CRDgWaXxMUBRYEeqUiNSjoyu+NYnFDB
-RCHUAxxm9kaTOqiB/ihIgsLM3kiK5zwrSOKTDJVztEDhKNTsknPpBJq9kua74gXFPYC5lLz6cVrFDjGt
-gT6WlKBNmgUAuK8o3o8zs4Pa4eKS08zspNaUoTCzk/YZQRVtNoeTWFIei5yiU+jM7KKnIjufqGtEzJYY
-uO1WFL3C0OcSxZq99fcqLhK0o2ZmR+2IEzkH0xourHkIUaiY5mYP7Qp8WpvPLNiJVDjU7CW2CURxZgfF
-5EQrggLNzmGeKwy2Aixm6DCbmx3CXZ4oEExr7SZRLJ4Q9CKw0XyThIHNvmHOTT+je5xyhIBjmje4GV3M
-EHjlZHuOoH8+1026R235YmGNfRS2BHNZQvPG3cIVhnbzDBYKJKbt7O8zFz5aACzP0rg5V+jQWpq9eCKl
-36o5Lukl6Bj+aQMDzoCpwhV4afYFTVIcBpZoR+Joi9kHzCOJ4kzpu9Yrywew5KOl326En0mFjrPlGvRS
-3fTiVwGY/3qgoWUBUZjZPyU512jLVjPQsgKdyVdzMA1VTv4WdtP74Etbsn1ws7/ozzRG9WT1APv/qSrc
-08xqjcKdo/AhsLZ0bCWJYkN7a+YGR8gK2o2egUW5G2n239/PtG434G78AsxK+8KNBV0YV5Tm9bHAJbfC
-Guhewj08wCb2YOEqIu8Br80uJFVVPDv1Yx0iYKd2rCMEja+Q1jME6lp6ref25OdafK4XQM7sC/T+nKF7
-nfUSQ7NPmSgcjMKXOCMn0qdQIdjUH1JU0CHY02eU7FEYWDOnqBUIA2BUXinz1aHQhQWNs6LGyZrSeiZV
-nuYHd9NXcMbOcbJgziIZzRPUBRECP0BF8qTAHAYh8ALExelEUQMcAlfAiRxyigMjdK5E9TsEHgEJdmh4
-CPwCFW2eqYMLuBAoyrLthBj37YQhXEdnzPnt6mLgJRBwl/IAV4EYPvL7PVpijZU4FlX6S5E3eBnoQkgS
-FGX24+6cZceiQtkGXoQdzfB6QR+2zdqnMWlQyQFnQnM8n3a1QzuAJ0FgXcoBnAlHkifOOTgEDgUGdszu
-IXAqMKyD4bWNdLELfArcEg2YjhC4F4xCLvaBn8EogzcDuByMEs7mzIH3ttih/Q9cD88VzVGvbAjcDg2p
-v9cobgVdYvjmLwTehl2V0n1M8PENHA6tXeTrFgwMfA4JqY+7Al+ghsDzUJKSVnGWot0A3A/ML+30JIfA
-C5Gl+XcUBjwQxwK3NsADUZ7rY5nizQdOvBpvuCn9ww5vsin3usBna+BQaGH+7tUnWXkkO9wgALcCLOJY
-J4XAwSCL8c+TGH7mxjvrmOOsNU2V7s4N6sILgbPBLuSsDXRXzja/FO20BVzIlSTHgdAZzr8VO2cL4HVQ
-eHw+Ap6HrDjgXwPCZQh9paiXNlxGFkH8o0EI3BM5ffaf0zwpnlEwXJ7EBT4LQDcFQd0KIfBSuJYXwEnR
-UsNrBd499jUdBa5htzuAwC9RU1w7VrBbirJ89RP0eygNgWtCoJ2tWs0wuPPbUghdFR15FL3A0K6eAN6K
-uKJJ2rRrTpzzFfw2lNd7fFqB/opzk9EKNQPAVcHPr2DAB2vpX1a0rnEhAycFJZXTcAAXBcO55iLgoGiK
-Zwevc/hJtUEnReCWqBOn3zMEXoljHxSMr/OOHVbCOQCeQHYQpm5o5SC9RuBJke3Qvl0HCHrhhyg2RLBL
-BzZCsCsHFqwN5dF93/HJI1zDSfGQ1g0/TOYus7CPIfR9SAyBx4EXcH5ODNcPYORR6sdFnjpG33ptwxMa
-p8m5wI5R0CgAYytBQaE1bbs+6EbA79HOP24sWAjSJ5rhhjUCDpC2M1EYWAuSusFrXoIPJhQ1GxFwT9C/
-n9l1Ckz2EfBQfGcHfBFYCB2Y6AwND7iU5BmHgU96aX1EHd8R8Eh8zx07twg4JHZk9+rvi+p0zggKB7sf
-1CsTLffm2aFdRuLvrr1HBNwQO4qjzM4hZYmp2f5hbx7XoRW+lYqAw+FYnCvH0Z5oFppnnDJyQoUOPA7J
-ucxc/oYI+BvK9HB49XcE3RxFwOFQx2ldFxU6xIG3YZc2cYEuSiPgatg18QjUy64ZgXrFlDwIiNmMn7FR
-baGq8w7r6CjYJRA3AsVOwGEtAG6PNKZ+VmQZOu8Ab4fC+k07A6HaC5wdNDnH/NwyhgWfR9hVqn4nWwTc
-HKJMjysvAg6PE83P/pGcdufqgM94wPFxKhKSuTcdEfB/FCnOBdh+VwRXVuD4qM85G6zoYieaI9/12Z0b
-DBvaWH5AGANHNlg76Y6VAH25+5nGjfhkj3+zjIAnxCgibkxhpRbuUv2qA/wkRkmH7y4C5zaMMn1KB7wt
-RjmXczGChzqqlOSHjLoLwHMdsoCrNcAHo/BuaQP3iyrh6NIFXJzmdYFPQ9Dnci5pJa4aYOgF3AH0YJf2
-eHcKZGVj3dJ+sMEO/0oE/CsMiy8Bl8GH6+/f+drVFVxqeWfq3X1bfsEvKLsbUQ0p/WN6OGZsT8InmOqw
-I5+CCfv3md+s1Y+Mf/h3mj3Rdih5/0HP9MNE/T35tyol2US7zqvVOi9fzEPj03n0sFiF85m4MvjDbDbb
-otchzPuI8Bqizpu8hNjVK1P0quXlRHJRNa/IbrWFd3f4ddoNu8KnrsuKItFsEa3ibc91H15OXa9dlC8e
-OTeF152Vj8+1X7GvbC3NrUD6xX5f02bjR+ULuFAasDsz4CLrKU2SjF6nMamKc00zfj3vcZo29PRIHtPT
-YYLnsZz0dPArWpdFXqdPdDJlH41ykmaeKKoS2j/N689b8/bOVr/Xxwm3HUkTeTemIkl6rjfL8oVnK9L4
-XWk3dXVPsUepUE3aohdltiZ/c318VCQXlytIlnnTqPYoqamf5n5xbrZ+MYToz+Zy4B+MgJQWwcfrsRI9
-zybwqB244m9hAliSvO+37a4x6Q2klF6ndeUXefbaXRUhu7rIzg3dCgmXL1LA7c/uMqHQPL9NBbedt+xD
-TEXjRs0W3d1DWSNXc9KulcW9cySHjxTFW92QJo0FZ6y/9b5X147hpWLOD9O+n6oiU3eFL+Cy7/QYTqbH
-aDI9zibT43wyPS4m0+Nycgwn/NbZcT45LibHpXtqERdhFvAizDQE95+nx9CbsoMfk/an/BV1iZFKnHWJ
-M5U47xLnKnHRJS5U4rJLXIrErnJVd1e1qrmrWNXbVatq7SpVdXZVyhov/deExEBcrVZGJ0jBDyh7a7je
-KtB7JaLZz+Xi49VQG6ktGvehk/u39eebukXGRWCyP4Za4oxNyW0fRHoq53jW9owWtGHO2zE5zvVFxQNL
-XUyOi4tp9K9MRks9tTVspbJpXuBx2WSUJBdkftNKLuWfQsVm1gCcX8WF4U+nNBfmY7V8KF8+X3gFWkvC
-8uV6FaKy4ky0cjqR6vuERahQl7QjesJMS7x/oLPrlC0H2tUrvwfMDXT7t8hii1U9jyWITH4OW8/lKSJb
-nKTW80WSAOTFc0XKy/MxbSi7vk03PEnyVTzTKiY1hfEWVIYAnssSB6oMyTEp2Zn3XyxklyOgp3NDk4s+
-AbDkskpZWBVjIXYlRqZcgJmJ5mrsYRmsA0GzPscxrWtFM14tZ4mkKTJNmjLRpLlbzKNY0EzzfaEIhqvg
-YS8JtjkmNZZikpovouVakBLn22TeA1kms52kJjJNgjLRoLlcLkLFXkLyQ5dF1vP5PJIkeZ5JUaQZBB/m
-s8Vsfp3uDrBX2MLJ0nnVV10BVYmWxOuwi8su2x1Uh9mgZL8PkgdeB+w5LclVRxzSaDdjdbAORCpY02Qv
-GmH0pPzbRZrsk3W7sNodVIc6pwWiofQKzH5Fiq9ovFuwOkQHI5gooQnlVYCe7lJcFdD5br1bX6fsLj3/
-9ClnOjkDr5Uh28yD8sULPG3NqYcH0labRTY5Z7o5DDBbWGRekU2KzDu3cI8V8rpyAhpcp+xS2Dln15FV
-nAvuI2hn/7q7qZxTgeb7CIgVlNlf/oJtHdyFH7MU35ZIotzxsOhWy5zwony5Jr2tbwV4TZJJYgZ+6fYu
-16Sxoysp08gb02PmkkzzrHktrawgDTNDcrW/DNDlPCUVh0ELxRNUAZplaVmn9RazNaB6k+/woW08j3CR
-kIb4RZUe0pxkPo93MdFjUIl1+5Fm5dYVj8rjxiTNU3bVvD5pNnwdfNw6LVgX+kIZ91YtPW3hydYmcAmy
-mi46/Zc9rmt/R9grsk1G6saPj2mWTLSM0pF+1gtYI0EDilWLliJimWkpfElj7tiNQFoD/phWsFaV8hK9
-VTOSgR54//FvURDOvb8Fwb8FP16nHdyv6BOtap3CtDxnmVg0mcMutMZdYCut3FDLgar1ktGBAcYGaC+G
-MBuOIRwi09rlFKuOcUlYx+DC/rGvadzr2NMyN0An0NuuPohRS1+rzLg1PzLd8Zge/XglSVK1iwfnxkGP
-YuGYb/vjpf2F5lkx+UuRk7iY/In5yEk9+fCn4lyltPL+gz5/6CKpMVpqRonKF29uzB/tnCRXJ6toMafY
-bmK9j/Zz2yt1/b5LxpF2rdhmgOhMc3Vp8Y3SvKaNF3h+yCy+5hCeRovP29HIlmFPZ1qP/secesDMucIw
-weBLLOadObPJKtZsfgabS73a2ShH9HNRJTy80EYEGcoynthaOZHW/o3136L9h/ga4zhGerWsqGdoTYD4
-rw23kmF3y4oynrZ9YR9BtcF12har46rIMha+6ERepEBmc3114L9uOOw6bQcgSbVIds7JOOz6QGA01x2H
-MD+dexHT1SXSF2zhYBdYryO0wHrlKBBGQYCWCENepMvw99k5Td6ttdOqeL4YOF8vytelbUrLQuZnBz+c
-qF9B91NLjdTP7tdM/ZqrXwv1a6l+rdSvB/VrzX+dEll1+yvofmqpkfrZ/ZqpX3P1a6F+LdWvlfr1oH6J
-quuTrLr9FXQ/tdRI/ex+zdSvufq1UL+W6tdK/XpQv0TVL7Wsuv0VdD+11Ej97H7N1K+5+rVQv5bq10r9
-elC/1kj0plZXbWd8r/pd/4EN6LYXHRfRpfty06WGcmyG0yX/v5WWG4jch9l0Jv6vy12reaBLexBpyyVC
-biUyFw8ItaXM1LhbiLQ5xtxcZM4w3mYiM9J4UwLAeJNywFhji58wuoje1uXHs0KRhQqRQwIBQSXJIGuB
-0MXJMh5EBipThlgJxMLJ/VIiIO8LkTF3sj4XiJmT85lARJBzJTIn51JyTsal3PhsrXLqY9shfKyZ/dHm
-hDzH0R0tIuAIR2/UR3/NAWZn1Ef/gac7+qI++isOcHRFffSXAgC5XvD0uZPpOQfMnDzPOCCCPEtBOXkW
-8nKyLKRl9AH/JN72guFM0DtDQkIDgvaKhAYGFO0eAV0bSL2fBODBAKAdJpArA4n2nEAuTaTd1oUBmPc0
-dW4gZz0tnRnIyG4p6IKelpo90dPQYLRr6x+2QLCMHKvFMnKMDaeRY/w6jRyrBhi5lgmnkWt5dRq5tknQ
-yLUNdhq5Vi5OI9eKDxq5VrhOI9c21WXk6pPTyKkst5FTELeRkxDLyMkMt5GTCLeRkwjLyMkMt5GTCLeR
-kwjLyMkMt5FTcnEZOQmwjRzLQY2cynEaOYVwGjmJgEZOpjuNnAQ4jZwEQCMn051GTgKcRk4CoJGT6U4j
-p8ThMHIy3zJy9WnQyGmQISOnQYeMXAd1GLkOMGTkOuSQkeuQDiPXAYaMXIccMnId0mHkOsCQkdPk22/k
-OiA0cr2ujH/QDtyycqwWy8oxNpxWjvHrtHKsGmDlWiacVq7l1Wnl2iZBK9c22GnlWrk4rVwrPmjlWuE6
-rVzbVJeVOyVOK6ey3FZOQdxWTkIsKycz3FZOItxWTiIsKycz3FZOItxWTiIsKycz3FZOycVl5STAtnIs
-B7VyKsdp5RTCaeUkAlo5me60chLgtHISAK2cTHdaOQlwWjkJgFZOpjutnBKHw8rJfMvKnZJBK6dBhqyc
-Bh2ych3UYeU6wJCV65BDVq5DOqxcBxiych1yyMp1SIeV6wBDVk6Tb7+V64AjrJzmf/8H+bgtM8dqscwc
-Y8Np5hi/TjPHqgFmrmXCaeZaXp1mrm0SNHNtg51mrpWL08y14oNmrhWu08y1TXWZuezgNHMqy23mFMRt
-5iTEMnMyw23mJMJt5iTCMnMyw23mJMJt5iTCMnMyw23mlFxcZk4CbDPHclAzp3KcZk4hnGZOIqCZk+lO
-MycBTjMnAdDMyXSnmZMAp5mTAGjmZLrTzClxOMyczLfMXHYYNHMaZMjMadAhM9dBHWauAwyZuQ45ZOY6
-pMPMdYAhM9chh8xch3SYuQ4wZOY0+fabuQ5omTnxBlDfw4vi7Un1Nbkpys2D9i1PnFxpk7oDWFt4jrw5
-IkfLWeXaVSlwcwo5fsjLPDa7Inl9bKpH9ayQlnRUSfuiaABKJXWoIyUJQKmk7pmwB/fxC3CxrSlKx5Wm
-JEmuSBXwhUfWXnByMEKpiL75Iqlt9mklj+Fp7emHKUnERcYewBok148zJWvmuUgOQ4/aa1ebwFCEL+x/
-9XxUWt7Uoe3srqZ4nSou8oS9P4voGJp5RDItvUMzsZKWLqKZnVYu1JhQ72rhj2pBFNY8JO9o59mNQ/KQ
-cnbTkLzjWO5voiYGkZhbok5mdVOlpdbgTd4c/WLvN68l/VQkyecLesptvV9LCuyIelfeeSSeHa3i060X
-F9lPcUbq+vd/aKfnb9YNQvNturjIzqd8yxf/7BSZfI/NoDKRb7PdRJtmmU4ZTKZTfvcRmWZVztGegJOp
-vDJpTc0wRygMUg/MwWZ1BzWkHqESSD0wB7MLDmpdPe4eR9VElNqIVKXCvajjpEflHk0WAaZrAke7UEcD
-hV28eGj/WVoi7rNgaqKyUD0RuZiiwCypD0hdVhaqKw6CWF1SJ5C6rCxUXxwEtbrcl4ZwXTCuDLlVBsAG
-dMZkE1Eag5xba4buMyUBXcdLS23SfF9gOsPTUYVpszBtMdKlPkD6ZjqqJBgdi77UAUjfTEcVA6Mj6buv
-eeF93d3zcuuDjhlQBo01RBM6Qm416L14Fs/pbD+zdEDcJcPUQGWhmiByMWWAWbLfkbqsLFQrHASxuqQO
-IHVZWaiGOAhqdV167vFiOmBc13OrCoANaIvJJqIwBjm3zgzeJST7KI4tteEXBDGtkTmo0vBMTGdAjtQL
-ux6YgyoMTg2pR+qEXQ/MQZUFp9bV4754ieuAfu/SrSkmakBRDBYRPdGJudVk6ELoLo6VlmhxYS7akeRp
-EH7sbge8GAf5edB3j+SJ96lzQqyWK+bwt6g6fRTskLN2A0HcUPRPtbqEKC72tEktB8eUOVH4VYUdqba9
-+5+Oh0exmbVunTqA2H6vD3TsAdk7wD5QHyV7F9cHOuIxAvBy1n44GIbqQtK9D5NbSh5vLdmJ8+6SN9fZ
-Cf7ukkadF3Av8SZJa3dKbxP0bQU1Od9Z8NYaNSnfWVCv8WLcC71FyBqRvqE2UPB4g6xurxErCPw3m+B6
-3ac0S2raXLoPs4Ed9inoAjpl9EDzBFy60yZwWNYRwiUKgf/XvOCm3VHsAlohUQYW7b8rf5Z/TPAwk6cF
-CCOzCoIrCy73U/Na0j/wp6+/vXccPq0G9obErnj5NtESK5KkxTcZF2fOLlQqaQoP+N/WhvD4tVad8j7N
-6Dezl65GHfkB5mu9eOXB9X46nbMmLTP6TUTb+6ntu28XPcAbrFOEncAaaWfxpr5LhLzi3JTnBr8MyqS2
-Mq9/DschXCwW1+m+qE5+XORNVWRupVc3JrWYacvyxQujOyp1hazrUtMTOVB5IXbU5dK+271t2fb/9Uu7
-wQq/3+vEIoHyBBOsCXqwO28aLuqJzZCFAWH1+un10XkPIqYqCJXVqW1+WC7Jnq6ldm6Ce4Q+CbzAe5AZ
-YRBNwtViEs1mk+nyph7pJQQas2FzWJmRmB7Zk2wy4s96vd4WJYnT5nUTgkLtipsNZUdBqw4hjJvKnGrx
-es5lxK3m1obr5X9KUhZXMPk2MdMrSpIiz16/TaT566CeOeSRvRGlLploFQ4SFkFN8qLxSZYVzzS5ylCm
-JtAx2ULjRMqSkorksYhhg+zEJLQ19Ql9SmPql+kLzXwWtHQTfL5o9BPS0G8GJ/rE3aSnnty2LHs+OSti
-krlxpyJvjma2EQpnxuLGcYVhX0j9+uRBHic9AMbmZICCxmkflDM7sUTES9QnWzxYDhQNhhFikVmmSAIo
-kuwwIBITgIjEpuAUiQntF0l2cInEzMFFYmIMkWQHQyTzJbutz7SIcXmxnQjXqVyFTKZs0YFct4YxbYej
-OEqaHluACsr8D91Twpa+xlVtJKplsIUxM2G4UlUbuoBU2SJulQPFWbSWYCIDKStWo3bAWE1Ac7YgNcIX
-RIaAvkDxfxG9oBHxjW4SnJh89XQaGpprvKzRWMrOHhDsfOnn9ovJOxYJTBwGYwGeLXuBdmA/TnQmVnIq
-C6C5muWyyKEleVZXDLNnUDwdIUMyXTJmNKGIMYwh5z5GVFXGiIWpfWz0QPTRjzGhmzmfnx7QZwl986A2
-LOBQ2MpWZZRuN4f25NYnENEkAJG0rh1Qsz5b7ayMFVQnsjY4CzvUkNhd4tQtYwe3op3JVOslSIifwOwM
-QmvJ0UXVO7UHVCWa56zQtudYea3FxvoJgIcXjX2CkHqos6frZAQ3tMPyuXZWWjPQWyO4XhfAV0UNBmTE
-icutHTLeUB5QibUscChPdoDK0xGyZJYdcOV532aBGnt0CG8pWn6MDmWHG3UIygPoEGNP16EHXUzhLWK6
-To+k9veUJu02zLb+Zj7oJXNum0fThZKSZNymrFY33EzLefEXP80T+rKJtpgLiM3c+iwO9zBbK5bzVqwp
-fPpE86YWh8V6hPwF5xyuzgdgToC8QbLsWjKsZnAyGcVjfRqAOQHyIkugSRufTlutEGdoPG3liSarpZuZ
-K+oWywQj70izki80QQZbAGBpWB3W5gHLk+txBKKtNpAMoyCIgG0yaHo7DH8Wx//arsMeflAvG4/Hfbdv
-rbViPyxXu3D5sH1DWcC1ruEkSYrclPnWdTpti0m8RyLdYEBKiLMfUOWtZEMdu1xb5VUeVHmVoam8mYbV
-gao8zENUXkIslTcyUJUXUdVNBntUnuN/G5VH+XE4lhfhW1U+Dki43G3fUBZw7VR5IUPXqaotJvEeiVgq
-r5egVVVUUOFBoqGKMs9WdpEDVV0ka4qup9i0USU3cxAV5wBLwbVkVL1FhH+drR7l5ujfRrkRblDV5q8N
-vFG16cP8YbZ9Q1mDZ6diC/m5zoBtMWk7pWGptY5XS1rW2//pKMhu5izkgscsI19X6isbXDVtvzjdnwvU
-+6kup83afz2xulj9Qnl1J63jc73pp3O8cWbThJ9oDaraC1K3EpSbG4wuKKbpDYSzwxmj6taIeNieDcVZ
-GjsWvGvySyccNyuPppD1G/JGEWNavdzTmd1MbiRzb3GfymAvLNxWpZx47YrFpAu9YzgV3O+OEO1xsnch
-YI3rpSYV994XDnNRUHuOAcoZy1K2zUIIY4inw0c3pCuzr59uY1B8OtA+sKzUzlTH2V+E+qMtD8xhBneG
-ojuaqrTQeugJYXZMb3aRf2/hFXgV3KyHoeWXud5ZUX3qq2hpORGv12k7F/V9zekO1KAfc7oDNr1vz3UH
-bmyfjH0a1vFdyD/VflOc46NPYjZeTyRPy3PG3v7cunPM70lq0XOuaeVzhx0/1MOOYyCptZ1oJYw8JuSO
-Cc++ue+aXNxdm/JDW1rKRkvpfm4s+MaCv8tBL1iv9tN4x2o2m+EvuWpN0fm84JIbdZpnVr54C7DODB1P
-JLiwnK/uE9muyQfOk7Qjxv7QtN2nGXtOIyuP5JM4qfKHpXZGa+BRBXW6ZbpcXInJFcIGQ1xQf+auyf2E
-7sk5ay5Dj/uC1TO7fqCV13pcJglt6iVMl+0/uOWM238GeVt1RtIiSfvPZFXTLUVfphUlzR+nSVWUSfHc
-WsPDIaPjBXUjD4jU7EEMc8TVEZt7rA+c1DYmtcF2S+KDwM1o4Ig+Tebtv2H9eGOfolOLWYEcUFiXybxN
-X57dbd0gRYh2mZveTE7WMf2AzhtAbcahnFfXBicJb7ojyYEOvVc444VueN0Q1BvRZULmBhVdwsYriP3k
-+euHgHwYRbt5YJA3dfkGWlEwT1aAVV2XJf1hXR4lrht5QKSGzE8gR1N0k3usD5zURs9PoIMHgZvRwDF9
-ykQ3rB9v7NOe+UlWgM1PMG/Tl2d3Gz4/WZmb3sz++cnsvAHUZhzKNT+NnSrALCWL4VF02nLgIVtcUxbx
-7mERg9rnMaHz2KCii9p4SrWf/Hy+TuZQEaPFYhktDPJjlBqlNVs/zGdrk1VdqSX9YaUeJa4beUCkhkxU
-IEfTeJN7rA+c1EZPVKCDB4Gb0cARfcpFN6wfb+zTnolKVoBNVDBv05dndxs+UVmZm97M/onK7LwB1GYc
-yjVRjZ0qwEQli7knKv11bMcstYsD60vJfLl7SEhHQhdy9xjzgP6FuyBZQEO5WyYPi47wKEXGCEXLNdnF
-Goe6FjPKwyo8LJxbqoYyQmYjPVnTaY1dS9A4kdEzkN55/ajNONSYFRKTz0DH391fPfMNo4tNNkbGxpkB
-ugSfY8ycjTunf3bROqYPshkBcU4qY0Y2nFFEGfeMAh7Jx1VgH5BkDqumlESzpUFFF6zxDHs/eRqvVyHc
-eq4fFvsgMciP0VaUVrJ4WISRyaqusJL+sM6OEteNPCBSQyYbkKMpt8k91gdOaqMnHtDBg8DNaOCIPuWi
-G9aPN/Zpz1QkK8BmI5i36cuzuw2flqzMTW9m/+Rkdt4AajMO5fQhjZwqoCdJFHNPVCI+UL+irBezuTXw
-5rP9jOhEDGcdj9MzYpaK17MggnZwtQzjcK0TH6PQKCkSR2u5lhd8Gj5RTn2ES3SEoG5jwJYX5uM2MnRf
-qc44InoXqfH+baNTh3CbsbgR/cglNqgSb+rHPs82p446ts2sTU+W1VUOrzbI2/TlDfi09Q7rB21GgZxh
-HMfNB2AukqXcc1GW5t8v1m1SzEnVPQMuy03UL0Mt2oQNTBj+PMlZ6b26P/ald4RDiyG909nfQvK6gPVQ
-7EMF5RZjtohWsfUx+ZwntMrSnPa+uH5FxAWrG62aGq+9EIN9FT4e+xCuDoY8tn8Jlg6X97vW1NVRn7Q6
-usuHb7l31xF/qTXiL3XXAP61/U7a2MlI/fCbwnzR4ObRST1mw+7cNEX+rcMaN2ppTRtHXn3enVI90zyC
-RxJ6kR/tAywUi8hkgUy8tu2kAsFVMER/Nq93muYXLRpGXGQZKWuqZMYVTSa3aDMmUVOhmSK+VfF8ZaGz
-+jFcBVQt7WLZPkknOjtQ4QRVMEFLXH6TntL84O/POT/EQ0lNTXnhkEESdlXJWYzI6QwGvAF57kI21bIq
-Slo1rxve6slTWqe7NEubV1BFD3AU6jqNSUWbvmO3QSd644GO8sVxukp7d2BevngJqY80gansFNPflMNZ
-XPLrO97EnxrBEFe1wJmwX+cSu2oIFkHgLFOgAU40PzuuFLJoUfw0p7pUGAZBsNXHy7Z7l2qrvZe1hJeV
-VXi2SMQIAwfhwAMh2yytGxEuEx4V09aRyiBruVlabrqr6C/b3ryeiFRaqnH8iZ2UGhGyih/ab2dwszy4
-otADA900Za8nMe25yMue2iM+BtabJulTmtBK3nQN1VnEzZp1B5xaEN8LD1hnEn7M0keCRy5rTZHHIljE
-GSXVZlc0x7EHHLWDL1icT5sFuSxBcsz10LL9h64ptu7A/SZV+T4AmbgycG66bGsDNJIfsQJ2DdxHtfVA
-eOuyHNxpALgGu76dGNpC5ARgf6ws9JijODRYVsUhTTb/9X/9uc36a1tsX1Sn6V/SuCrqYt9MD+0IpXnz
-ieaMuT/sSVbTz1e4VWSToGnrOYQ458yR45DN5Np7Z1t5Ol6hjpS0w3RgRPUuCeEoahfRvaOoFWv7Rzfx
-79MXmoCL5OrMMrAB63Vw1eYiKEeHSM6lx+3vZJqTpx2pfFanOBntKSICdYmLvKF5s/nwQTenML6mbXS1
-DGF3u/oNRgf5MJvV1s56UN0rsl+p6j3rLmpj4vGGZdYDdymVtseYaNsNuXIZG5HIcQ/FJqjtYsTNG366
-FtaiP13poGJs4JH8zVC+tkvFsjXPTMeqXamjLrwKjfJFBV3QWsj2uF+AjLpEq6O87idaSssyb4qIayFy
-I9gURbYjlZm7ALleV4OeojOl0vXLYrAvBejRIvfoIPdokDPe3jN2yVy6edF80mNNf+YpXaBgngAXvJ8v
-qNNI70wtfjW4uORG3lh5U5R8/Co2zEkKZFo1dxXZcjD0EC73LbTOUdtMF0NGHuTHoQEQMKLP+EQx0EWC
-Wp/4oZgm95AAwnunzhNV93WhrYhv7CXPUgRrJmPLGgunr3LMZnyxoGaomgcYLfDBHsQskks/mTCCdMLI
-IOTg+7e45tLHQOc3vsU1LJc4yLzDLr2xTNHl4gVlwSDUBOG16FY4PTQCT1AZY8rNDPea4hFaf3Md270u
-t3U+9XkdQ1Z/ps4B/9LbhC+j2/FlhCgQI8zCQobgrmbg5HbELNkzQcLFT88E55pU5uXL1jXVaXnoZDdy
-PgJc9s2Fg1PfbdOx3QDQ9AGlewcbhpK905jdQcth1d6vO38VA2f3sl7/z+e6SfcpTUy3uj61cD97Rl6L
-cyM2td0XNeaV39S0JBVpKErZmgfNHBAxAX1HU7Lz8TpAhl+KxCdCHG9uGrvN4k8JaYjoafHhpv7GSuI3
-/MfhRYBWN1iboG+tx1HUGcCXeW4rGjfCPAef8ah0+t7Cvd3lauNWDI2K+aCq1svjwpEiwTEQvrqQfZo3
-3fkSSB9x4emXBGcw/PYjiNMBc5HoHH2QXZPzefDXj57paIEDY7djBBBtzdgwnb389aBdnI4sInmGMUId
-7DhRNhujoFBk3HdlxlDs07k2d0DnIARW+WvE+XWw7sCMUrZRzRgbULiXvx70jcrm4hnXDYsdJ2qksg2J
-zFI2r0fTEM3S9yudKe+bXYeXhUilN5e6d3sworlybTI6fghC9TL0alDvNz/98SA7rAn+hMnIh4MQXq3w
-4c4ZAjlV46I25swRcrrIIucIv9SLMx7c8kVktJ7lgP7An62ZzmywcBfL4iGEtqobAIPtiY3WnoKD2zK4
-Zxos/Gu7bXvlar3c5+4sfe9n03TlujvCsSMbLo65mwdkPLIHR2B7/LE3OEX75AcfrISTJPIFSw3uAP2y
-ilop5FwOhmO+JORTDoZVDl00U3ygQvOsz1TvNdphuDKE9Tt1peN2a0snJ08gxp0VagscIWJlHrN06DUd
-iXskg+/umFZgIfgyzqeov3tORaBGT5LSTlYYBzSsXLPGvtMd2/c4m8E48MQxiYn+h8aISnI+3kzhBRd+
-5EVQz8mT/34HmWRfPKanw6VzQivl8Buyqy/OBzzZW8wS1iqSfvTN0D2lohL6SMxh0nOG47boZ554chPU
-hh0mb2Xt8f+BLdEOOGGpWofaeYZytYs6oUcizE1vAC0gXTCj4yrHOGA/Oneg5iqBj8Y6inTdZ/a+hep6
-TnuH1Vq3OgkMHyfpvHi9R0ccbUA8kZ37cXyjgusodHcyyFp5u9pva5YLAbXMidN1G47PO2T4SHqH/Jgx
-9xs32hwg7C4N56VMswzMTGZG11bYdxLxJUsv4OizCQCNs5L1FtmZY4IadAbArxsSf8dHa5elscyiTNvf
-4FyzxXV4Urh3Lvg1poDbRv7oAa+Jxjl73j8h9I+LMWPiV5gE3nsC+A0aiQ76hux8cVCSvarulySH91kM
-jAgfai94GRNQQ+Gn7Xs+3/FzjFi46C4m8sJ+9pCdeu1bCw2etERmuuHTmeI0rjZ/qpOY6roQOLqysI6u
-tClyVeq/bNgNlKy7UaOy6rgqsqzdQLAwvPo5V3z5N/DyQMAPsESLxUT+/zR0voeAo63msktTkuPXEdOU
-ISstmr1xinf0cRnIjXVpi6nw79JTWVQNyZut5hvWUsHbhNquQfROhx2SgChwxY8Pg7LgkHFTlG4Ij96P
-YoYeP3w3Zti2SL0GFugHmsmLfIhZvL/1EJQvn/lrzUWV0rzhm8mM5Ekdk5J26vCeXEVBwEJwt1MaSXNa
-+fvsnCaPNlkXgo9xLb+vLCj11uDs/wxcB2B5dLUV8GLc9wLHyNj0MTwHaNTglwpUXW09gNcTjFPy+p20
-WTDMzkBVAyy2EOP+gSkMrJKLmmugY8ogEWpriZbEriJ5orsWdBupHE4L4XDq+7TOnzXWyWqLji7N7Zgy
-CzOviblwGBL6Y6eRnkFrYgO4upuwiz20FE/yHKnjtgHTFSWvtfzoo61oHro/NUtu9hX7knP7BaVbI9gb
-TbIvaxrZ3jSNi9xvFzjYXe8o6l4ctD9rhVZtHbkvHWHzuezhsSX6wlh3qnpy8iQIblbTVm995SkV2cLZ
-qr/uENjvCYcBpt+6eZK8rXTelBfSc1165ROVfYKLrVqMl0D6VYF3+u1rGxeHHrwkNhkqYQhyI+cIdu43
-AjLvKe+cQ3qLgbmlH2u5g7V4LMPq1mqU5XvVBorw8wMniFvZFoiyGXPNvqhOF8vZ3zuZ+I7ZxJrRhlf9
-rs3pm3cGk/fbNvSSGmGci+r0bg9u2TTf+OCWk2D/g1tGsXsf3HIRgUdd3DjkVMg4MHxwy1Wq58Eto8hd
-D26ZFNQ7S0byOz+4hVYpH9yyK3Y8uIVTwU98IETveHDLoHLDg1uDJtQanZYX9OqC6l/dLQ/kqGlBdyHo
-s3Zg7+n7djm3m2doNNy+seANjjF8b9xbJdxH/HY3O+TavDuvAGxfZ+h0sAyipJUJA/ShRqvUS22Umtul
-jAU0fWkMPPIc5GLUgrYlZH/etfcJyuE3QpvZre2OKOJx4jEFtA2M5sGyVxdmsf80iZhjoVNm5JUX6VF+
-aP/BcJOr9h8sDXZp4FiCEwiWiDjG/J7MPt0Pn0PAyLE+HMGaWhPegB1oCTj00feqz5iWtOTMzwqDqBEM
-9nzCR45Q9OsBo6fHIhmHG8Ol6xwLOwF6l14Yx8pkoEJxIsJdYIBXAXKGTxykr+3r7eIPDw/O4pYfFQKY
-Fb1pWDPBayd7BjBjutE4BzRG1QZXJUg1PZvacYN79Pb2trLvNg3gdYyaGwaK3tu+d55FHJWMm1oGC9/d
-yLsnIWdb2aXxEUpph8hkb4JBtLqH3kcSRhB15o+vz44TOgLpjhs6wPFNRWBHqUak+ROtaopMs1EEQ6MH
-D+0/WBRf/6yT9l8/FkgJxwwfksGmC0hLX/8MsAaXQCPhA43BV0F3twdfBfWiRjB409GkAW1wrYIGcGO4
-dE1A8/n8Tu3AVkH6UMcLDPA6sAoapt+7CmJBox3FrVUQBCCroDBo//V3J1gF9WDGdCO2CupVtcFVEFKN
-y4aBiHM3TXQ93yjE8ex7Rsvwem14Jhq9ZLut7LvNWWOXbLcXvbd97zzljV+y3VP47kbePWM626qvrwb0
-0l6AoHMXXLW5qDoWbnb++CqdC7c+5ODCzcXxTUVgd12nu4qSJK7Op536CvcAPsLpxwZHxA5mcV7Rx+RV
-VfohW/PDjoH5kqWbHd0XlTqkxSMtbbUtgopl+fVvQUCCDwYJeSZTX7mX5JDm7CAG/lkDvfnEGu+hh3E7
-enarQG47Xs2EuiT9UR231oVgeF9rMOwvC3M7Nkiv6/4IaIlxYQ22yshkLcQ+F/T51l2BhcxqtJttFgta
-HuPgTqe9XWs3bcJkEQfT7mC8AMsBVwe35ksTY+50wb7RrB+WivACbBaa1zLrzOihiTRSaWara4MXmtDH
-dOU1Br1C3Sbi6Rib0JI5cu3mG1m9lDURaNcEx9wIRF46B73tZwdrWhFpfGa5K1yMXYN7uCP5cLzpo3jZ
-O8aX5mgTtF3D3M7uHenL/pEO665PtmR5miHZkUFRbNI9IrXz+0Q66xXpDGuWW6RWdq9IZ/0ilXXT6tJr
-VO1QFdb1YkbFw+0ryxDt4L8Z033GnXfbHDuBOHyFUp73W6jmecAsyJTb7yXzwtOcvjRdi/ifrFHa90sF
-Liv6lBbnWiugkrRC/LCWAIDZ0kwyW4LPkUYGq+WOye065ec7zK5SnTSN6MmbLtv/mdGTNsJWi49GIJSV
-KxCKeg7A0K7h+Cw7UlP+opPR5dNoQU9XwrkWUpJ/jXuGQEhGxFcT7d/QU9m8gqtFLJapOP5irQ/lrSFB
-oOfLM1vyGqCfjhXdq50JluV82pV9MZbkxCv2F/ctQwOHVWtmuaqNHpbBOpDkkBfAjXepAQ6r1sxyVctf
-hpfk4CvAxsu1OgirUEt3uu/Ym8+SEPLWp/ECJcBhdZpZzhgB7A1YpSHWW37GW3MmDNUjPcdVJ3/V8Spe
-s8OPlnWv3ehH82fli7eybe2vOhXIx4iwkQUtAjtlw5rlHtK81X1D2nrMTBSSb5pJGsZJLfnA2ZUIBuTs
-xP+6c3ZqzTBnJW3oSS7qJTvdNWS1hXg03yiUq3fH5G+Sl2UNG4djvgiocRJnAa9Td+zo+0+2Kvn5fNoV
-TdUF3GJnmmbI0fiZfZqKJfGGpPmRVmnjMueqGm96DCfan8fwYhDQofAMILiLEoVA5aMg0Io/Hit9vabe
-Jm7/XfU7I6rExL4oYoun90ImEpBLa7mifgHBqYUDvo4rSnN+2c0+4IX31PzB7qk5Oxr3xhbqj2vxFi4D
-0B6rJ7u+Wc7Y0fbmeD7tcpJmjqdn5i7fGh615O4lqv56lvaIHwd506j2KKmpn+Z+cW7AQ4AO0CBCa73H
-osFMugQRHEYbs/o9kO6qPumKyHdEtCQ1tXUpWEgWuQrp2JnGpGR+P+3q0lb/fEYyWjUX/SLYrTenMQch
-o+od5+axVjD8OYj/x364ddUOcZb3WE7Ej7N1zltBvpTw8UlBOEnrU1qzVfvETEp31sMBM7ygN42zosbK
-ixyXcWtNtThiyeYwTAJqeSe7JV4tZ9juIdnvgwSeqEyWdB0vASkPnRDjNY12MwjV5S9Xn7vFvF2t8By2
-DFRLtlXwgL/vTZM99FntYvqwD3U6OGNkSUNq1IdyNV9Ey7VEgef+H8gyme2weSPeP9AZYGxP6C6OASmc
-t/2KhrsFhCLsLZeLsBOa+dQ3Wc/n8wjjLkpoYr3JvovjJDQp4czR+W4dBwCJ8PYwny1m8+sf5cT4nb7u
-K3KitVdWxaGide3zK7ZVWtL6sq+Kk76IVco9Z86La1OguYEXXK9/9ItflfyvSHsqKV6022rYbDgcIsz1
-QWjoYpX1bKPz3pSN7Phn5x2s0N/qMVIW2KrHdxd1az3HFsLps+5pnq9d6DLevhyLRKw6a5g3XXJrDKw5
-yHTmmHITmpRMulSR4pnytW4bSv74E8G+eixwvkjoYYJcZFt89qLFx4lmSq2/F8FHR0l3zgrQAH9/ti8Z
-+8X/D5n+p+cYeUyWjTc2E80D0xFr5pgqqd6Vk4kiASikrI/k6YlvcLEZ0ovkI9Zemu/TPG3YuLm90M0l
-4DgbdGr1D0CMwL8G4r8GosUx0LsBr+aA0sHS/9K4f2nckMYNe7YHlA4h8C+9+5feDend4KeNAbWzy/9L
-6/6ldYjWMZ82vCgtkrGXccUzEyx/wv/j74rk9QI31r8UxWkTXnWI8qWzuG0ip9j9TOMGhqHU86bp6eB3
-PmoYrZtDmadOMPSoPUluHuUItYrbBLMAu5UNviBoBdo2TOzCoh4kCCv4NNcUpSTFv9JdHFEnZHUsdBj8
-1M9SJeZIScvspSe2xUJrcVo38GiLdXSl+4LVH1SfhwMC37tuD5OPhEu/7Zwn4GDMG4MDURFsmu7IFW97
-eZLAmib8ZTxLrN1VSruMBxOkVjiIOfH6LT9iy1UeHwHp/IwNXpUo5Mi0bomOPfTLHHRXh6j0FyA75xc/
-TQQ/EMsTQRNnjjov4Mq/76Qk+9rqounu0AE27ynI+B9SCfXRYzzLrexv53eglINZK7wDfhBg4kh39bHI
-dZx57otY7jj/i1dwQ68NFHic1ieSZbeX6y12s26NKjbA61Dp/sL9Sn1nsSGOB0qzwkPjydVR+LjoF1Vv
-mREjKV4lCbWPvdz4ydO2JJKAwyzg9N1kbjV/g+VAHzkrdllEld9nGQGRAZB5Cd8p8IB9TnYy1J1N6AcM
-NczQoQHWZZ2jUKOEYU7L/ZOxkJQ5GXN9ssjf8KkcEXFb2sW4TdlB4GZV7i00qMdtaWdfs8zeHtaK9yFM
-3XUJNp7T2X7mYNKtuFpub0tGqaxe1TBkuOm3aKqQi6mpXFcs2rcdn0BkKgi4eEfpu8ncrLVD5QYVVxBw
-9rjM7+1tk8gAyOhCt8DJPopjN8NuPTYBQw0bpc2gzlGoUcK4Qa2lpAy1Fvpkkb/p2A0iZF7exTxG3Unk
-ZpUeKDao0by8s99Fdm9/GyT6MUbPOQVNd3GMajOn4lZmI3+gTaNU2axwDGiMGG7QYykjQ4+FDjn7fMgn
-h6697YcrjQuP12lJcitibhSMd5r1Hv7EzgDxI0shOLIUmMd/nCDBMPf/6idVZYaUlu0YHBPreviOIXYJ
-ENSNPsslguKA/Rov16RNRi+98YS1I1pL++yoRkbtKu00ftmtSwV/IuVEMZzrfVE03dVGXdADR+DMcOTI
-64wDlyyR+505zR61ATCRSZxTGcBHh9iHhy0q1vQ7gqxV5mK/yLG1Xle3qta9zl6fS3oES6NpXYxnlN40
-FIz6O2+31+MJH9OScZTgU3Pvo13GEP9yiwqM+YTQG84ZVH1HVeLUp24kvpjDGAM7OqTh5+dFLvKXX9G6
-LPKaXZZiKc7RhtL2xEUFkyqeatflgWsOvXd3ri5i5pPULBcdd1bO5Y0D50ZGHpvWFJoplZvTWwi3Cjea
-8Bt4uqnWfzbxek3yPtIerOf4m/Tqu7VnsJ7jOylTL8fvJ+P3k+Jb5NSj/r+qiiN2+tfQ8PeoZkTX/CbV
-uFvzbtJ/N/m+mwTfIKPLXdO3FqtFcOBkzbVCvHdFeBMvonv0hGqUHvYTbVdvI4ney8z4+v75xDramr2p
-juN71NEr2Xdqx0AdfdbvfUT+TlJ9J8HdLZvLP0aT3z5dDIr8V56R3qcVg1319imw18a9i0TfR2j3yuUy
-HLdM25+3jfwCdvpWjtZ/Jo9fNKyzy3XQxekoNIfWmLXT5NYSR8MNBsYyz6LJkA9CAS/ybTAHIc7QY1M9
-9s7wCPw4Ct6qxA3UFXwc9XZ9dwN1BR9nMO+U1SgC93IwSp6jCNzLwSiZjyKAmjT54t+wtjrncVTcI9Ca
-bG9Bj6KtSe0W9CiDeJ+URqrpXeXHSHKkkt5Vfoy0R6qobbLku3MDSmdO8oM6CjauQ6oxljoKP96uCv31
-3UzgeHtn3M8BLgP4lWawP/WFzXB36ujj0CQykjaGvqcv+2q7tfzx9mF9d/1o+wf6saPnuOrSfeDCvsvy
-W0FavoeeWHBEpoelvpiFVXQoG6nOEeCtQ7F9HwUnN5W7YSlsUrQ+5jk45jhYsYdUbB2X0OtWcVexIN0a
-AHw97X2uDhwWGEtzpBBH0/PMSI6SKfzdIZQQLuNHp4wR1lSMWUf4dw3jEPEdt0r6yN4o5TEkvVtCZqKE
-7hW0yZ0KIYKGVDMwLnW+K1hbH+VblXoESSBuyZrjrLuD1t2qbTDI42egUeI6gEvWdwWfc5K9UdCD9KCU
-BVOOc9oYoXtFbLKmokWgIe8MjEPQ9wXT66N8o6zHkISTtWDNcX7YQeteiZsMykAJaBQ/HeKQ933hAXsI
-3yjuERShtAVjjlOuOKl7hS3Zo6cdTfTF5dBFcXHmtQsYHcDQfTZRz0oRxwAtIEtA0lMWnxDJ4JEIkIyn
-NKFF1xqyq4vs3PAQou0qV57j5VfotcvJekC/bmVtNSBc7l7XFxA6eLGcRouPCHq+e51B8KpFPtMsu5zS
-3IgTqI6Crh2RY/vXevoik87af7cHTRw4VzwA5e3ymMb8/Vw0yFPe5gAEcQl5eT/rjh9Hczw+NMPVJyMU
-rwljXngeUFaLCN4XCNsZ+z0IxCMjxunqwGOrw32aNbTakKw8kk9FSeK0ef1DFHzeit+baST4kPea+R/G
-MXdVQ39IdbyyhV6ZuofPm64C2ZUlJRXJY/HuWjeKQQ2djm0CT99jnoqEZH5R0vxiDXyW1w26ffpCEzHi
-hLfJGnny/nYYLIKtHufeiggqmyDT/TquiixruW+Kc3zcFuem7TbF5HRPEuoJhpOUZMXhggS8NJL2RXXy
-pjMRitoOZy3/snEayEUJVsSBGWnop2DiR4uPn7f+qe7PL3qze/KkUNK8TyRW2aCPp6CHocDFTfBZVyLB
-jNIl/0X2t0p55dG8Ta5tO8XncC0SuAwSw4qJ9/2QcvgFDSTSZJyl5aabxM152MqzpuL1em2n6hNf9Nme
-4Tqlxu+AzMoXbw0mYHgFBMdIwbSNSKqivHfYzgPsSdIggPTZaLygE5c2b9nF0vwyZrYTxcTDuUbsdacH
-hr1DA4rK4OOaa8uPOjXSr5hY14BkqH3VBna5xlI487oNh8JLIC3fWpgUbrNwH5bZCk6IPdX5pf0fy8Gn
-R8m3GPC6V0NsEuqpSKwMs/Vfup/mW42yBJ+yd6TyT5TU54o6Vmj+er1uTTkf04t20SOkvDCCRXN63RPM
-1rMPxpzBs5ZBF4KavcHh6dOLnCfQ0bYQV3N6h5sDpEQgQ+HMWLQthPf1OtJ4zyTfa46fNkWRNWmJSK4b
-lKsALNvZ+mZPTmn2uvnw7zR7ok0aE+8/6Jl+mKi/J/9WpSSb1CSv/ZpW6R59IkdGpapOJDMWTvPANSJg
-yB/977ohVYOvevTFVpfQmRWWltGmoRV7fKcdOoKv56JK/F1FyXcjBUM9V6RUCcZDPryFQ1MWbzOvi6uS
-6CLn3LXW5651h2+K0ngRUI1WNibYSlZCjSBq4jlb63kaCRYhy5ykDbAebQ0hbLDhp7n5IEsUwIeWHm4P
-fN6ugpHvELJOUlXFM6L+ICR7YG4xkOuJXJHZNGr0gQeqMo3fIvhoCkSzMWK+4m8oecZlJN0yanUxGrBC
-9RDRFk7bMhzb2+pk9AdaaYeAu79OtD721Sj4uDWj2DFNd9Zk1MYPW2H1YTKV1ckVzWB9gaxxqx3pQKvj
-AsIqHK0yVmWmuwapznc1Ui7atB7EG3lHnc6OtLXm/jrLomR7YpfzxlqCLsHOUZuOVkvdnyIt2NsM4fz/
-UEP4W22U4ji+Y6PkXrAFYC0WYQs2BGSZdaGYzEzrWs73mTKXW2Z9uJv5whjroV2NfGZ/zSW3ni+2IMYj
-+91DYOYVd8fLtKv2X8+2aNf+AyJWs6/XjdBuU9153xgzCvE4ZVPEBPy9IfsGHeDmovVtBt2sEtwfD20m
-BVOiTZsPH8yZy+ykpig7wiJCKntEH53uWZZtIzuPgJZqKOHiM5gnuzvFFiOC/64/t5YSbVXjvA8IP9o7
-4a7qmHLLlhsWnDfSGBZaqw1brbVbT3e0nJndXkbMlvOW8oGHN9lgRms0XhMnq7f5lq6WOoyaO00QRgaQ
-hIMX0WzhDhjf270s8eMB2lQEO1s8vTait0E9+vJJa7iW7Gy2xodotLiL3q2QnS0e5IW1OCZVca6xR5K7
-PLHZcXke2RcjyyVuFn6cDkRjZoYccVLLh5XE64geWzuYrmkUMpCP8/coHlxE89LT4WKsbaQHg2QZf3xT
-LUv8WfJ58snyL7fJF1ww49zzy74HJ00P/dL57GQPPW1Fsycx9Z/SOt2lWbtb114nc2TJ0iWt6pLyUEZh
-wLfFVhIufxEBaSpip6OQnL40F7ES7vHgz5JPrV5OXP54Pb+fGR5ZHUWUFX0ax4s/xIw/jpseoQwxKtZn
-o9gN+ljlmVebTxeLOQv8ClOZ8ECUfZziRfpSbyDs2kNpz+ei5C5i4Yrld93tuwk4usHqAVdj+TK4rxZ9
-kKDstsagKrJbDwEs9If8ht/tsz8Iw2cEl5/tRbiWH3we9TnXaheX0OiXRFh/mC5pLzASgiAIP3utEMc9
-8/FWioLRjh6jP2F+jaYoJ9zZ0P7aV8Xpk1nT50lTfLLq+jzirY+m8PjEOpp10T1lVRzSZPNf/9efW7p/
-lZPC9C9pXBV1sW+mqg62lf9T29N1U/3hxx8eAv5/P048midaRtBl/HdR+K+vJf1DaDSkoiUlzYb/x39B
-dIGPAunbUk8gb+9XDy6AAErpDepxI8U3qAfXBaghi/vVo5f1t6tH4FKPh/dRD3XiBKaPe8/f/Q1DfZzW
-v2bAWrzpIXstj2lc5H58pE9VkfvAOvQg4SJIQRkKmD4zs8cCtjsZ6UBcqNV3mnOfD/M+2B6etzSsY8ix
-bxxdw7BA5PeDrg65aXNUMlKU8hNP9zWW/dZdqtxZau4P+mhudnRfVFR5XX78WxTM1j/2MomWIT8aK4Qk
-jUlTVDXS/dJBEuh7eOVMXmzlV+KPW/ydHfEVzHzHRCwGrFd7EJa8LL2gCtf5mbpDiJ1jL5QnAlpG84Z9
-Im/XJq5jW5pL/W/roQWI7YTV3CLCAxjC/YpqkFyfigZEWgMi3TPpuIzQdbUMOGf1mQw993Grn4MU0ee0
-3lPHUtmw1Q9TGgco37ygs5lmpyAu0E8v98Z1XFGa8+2xfUjhn2vClKcTul6cBdDXo4Y9WyTP3nF2/PUm
-xf6pUKheJaY14dSMtFlAKNDMNQwu+lW7a3eQxpcvhT12adyLNRkA8XmOo5qiyHak0gvKJAmLM0qqffoi
-MepvBSjyhqQ5rfx9dk4ThQPJFtwCKkiS+ceiSn9pMzIvUSStdFmA+X+0LJ5gyKQPIsno549kOSPNBPKj
-XSZQpElgTp5kfvtTS96RSh2s1yBGMoCb9ZmJJtTEqMySHLry/I8uS57s7/JVigQpRyn/KQym8RSe7iu9
-QVuBHlo6N6BiTgUZ0flYP2NdqnWjo7vQjjF7wpC9LWhNuOKzUSuIza5ojtcpNyTiHJz5ScuYhLSzqr7a
-sl31RxK1E+u/S09lUTUkb67aq4gc0P7U849p0vV2a4f0zPpYPJtc6blpzlyaGb1Yrs3rlJk3Rryd/DfB
-18AjW/trnPW12jL89re7TcDYTmjuZJzs9+kLOKZ6/aN/qv2nlD63MGG7EvqUxpQb2etUtMfPDhP1+5R0
-v+tT9/uldtbekeEdO9FT+DoOSYLYU4KkwNIqCWLrE5ICS6skiH2pkRRYWiUB9QXiUKcW1VGL1XLFFjOI
-KKGSsfkHA7IMHVg5UX5VPOvIRENOmqO7XEyzTCs4qiXYUL6ZBhcrWPrfSQUwpCeiFMGSUyxEVT3rdWjU
-U59G9p0G7Ok7iHL3XX3S+84q5+y7m9s3ukdvpzy+n++lfXfv85PFsJ4wbHeSWkWnZGT3a8Ce7ocod/ef
-Er37rXLD3T+6gbf3/3jSdyjArcTv1oCQHdDVSGaHkX2tAXv6GqLcfZ0d9L62yg33NdKU23sVI3JH/7nJ
-3NpT1tzPF0U9K5Ob5xNBUZtdhykOKKggqY3YYZJKZqKwpgKwsBJrWaV5M7Am4RhHkX4dN7E9ao4A3ZrO
-wLqyY6WhvpvgUYsxrOFDIwKggerfUM/goEHx97frntElCAl969Wl63/5/wIAAP//cgwQYEPZAQA=
-`,
-	},
-
-	"/css/wr-0.0.1.css": {
-		name:    "wr-0.0.1.css",
-		local:   "static/css/wr-0.0.1.css",
-		size:    1896,
-		modtime: 1534412155,
-		compressed: `
-H4sIAAAAAAAC/4RUW3ObOhB+z6/QTOfMtHUd8AUTkycZMMZ3cGKbvMkgQEYgLsLYdPrfz/jS07RpcuBJ
-u99+++03K91Thjycg+93AACwQ24U5KxMvCaJUYAVUOb0s4c4Ui5nISD+4w4VuNf9ZovUWGg01CGcwqUF
-4VIQBDE1V4unSJp3vA4WDrIozZ701FwZKUMTRFOnN9vPOvN6Mw6ckdT3UZllqbrc9zVLNOy9Ix4nKzYU
-/EZdyIsl6R527X4yrNzFlkr+ttV388kpL3slT5z2cnp6COCvTx0JqigN18O5Zq0HwxnpVrDSz5lQCFPN
-jdehZ6wjc+SlnhEEziYNsXE8OBurRBsp3lUwbFjWWK3OXNWF8jIbhMPpCgb72E6TbBWo5XQCMwnq5qxb
-a+tK7BxwWya+FviR7mCxG2mmpkp1zp2JjdobK3ifd2wMQgOugyQL05GJ7UOhoSpLCjHDc6lx6vdk7kxP
-xB04uqw7kQM/4JoTGOwZD6eLhTmGETGC4z41JmQyi3a1vJr3ahNVY1erzEl/I4zM1dEY1N7oqRzMF7E7
-Xqsn9kwjWdXhBz3OPmSWadvDZ8g6+7lhRitvlC3Nh6e6s5akukQacrWTaTWcfDBqhJtlPR7WC8tCGB02
-RGZZsjZDqI6kAYzOnBdiCKEFYQEhfD6Zqrp4MWLcyseOFNl2JIemGI9X6+ftmA87ZEFlTnkxeCnm/jMO
-9tDpmFIRDB1JmmTetmUEJjT9SFetM9+FFMLBdZTBkxuYphShl27krtt6Nh2TNE5ewo2xRYsF4XEbba1u
-IC/bKm/rdhfOSiqK0V4KREgfONSc9qmnNrpD0/xghsPPGSTdtqMeOclZehqp6xWHnWCOBJkfjxWtDm1S
-rJDrPLsN2uVQswyrFR/SAXxf/+rhpl+O7KHxlE2nZZaY7ng9ETUMW7lQdkYjdasKaHIcp8fQrntbIsI5
-fNE2qBHpEMqvbgz88ni59iEmQcgV0Gqlx2ukIh4PFdDqnQM/7u45S5sxygOS3F6K66HJWaoA6QbaMc5Z
-/FfcNaWAtnjF3t27lLgR2lF8AzYrvIsIb5YFzpsFptjlCkhYgh+B8BVck+BzgXyUk2/ADXMW4y9gl7Oq
-wHkBvgpXlpjV71DErCaUorclUchj+j99I5ZkJc5Z/reWxTvFpt4SGz9hbpkXLFdAykjCcX41IcKnA6LF
-zYIUeR5JgouhQATi1dhfLipA/L3Mo2/fbZfRc5tPvnT+r+UcH3kTURIkCnDxtf057rOENwtSYwXI0j+P
-b0TcUJQhrgCKff6HmvR4kXqRe9uBV9I8Dr5fW1S3/dox6j2CP1Deb5tymfE3HbeZY+Yh2twx73TDswPO
-fcqq5lEBqOTsNazgHnhbcdHio5jQkwJilrAiRS6+LXxIOG5eAgpIc3xhK+89xjn+qXDHcg/n/+1yKz0C
-DxUh9sCnfr//ymoPuyxHnLDktg13P/4NAAD//0Z35XJoBwAA
-`,
-	},
-
-	"/favicon.ico": {
-		name:    "favicon.ico",
-		local:   "static/favicon.ico",
-		size:    198,
-		modtime: 1534412155,
-		compressed: `
-H4sIAAAAAAAC/2JgYGRgZBAQYGIAgQ0MDAxiDAwMGgwMDAIMDAwKDBB5EHBgQAAmKP3jxw+G8vJyMJsN
-CbM/YMAK+D8wMHBtQGBqAUAAAAD//0jU623GAAAA
-`,
-	},
-
-	"/fonts/glyphicons-halflings-regular.eot": {
-		name:    "glyphicons-halflings-regular.eot",
-		local:   "static/fonts/glyphicons-halflings-regular.eot",
-		size:    20127,
-		modtime: 1534412156,
-		compressed: `
-H4sIAAAAAAAC/4ywVVDeYZPt+8fd3V/c4cU9uLu7u7sECO7u7u4eHIK7OwSCBIK7kwCn5ptTU7v23Oxf
-1XPxrF7dvaoLlQBAVBEAIAFIABr4L2CA/wYCSIIAAGhAQeX/FyCB/6n8N7SYgUzA/4IOkAYUAF1ABZAB
-ZAFxQBlQAtQBECADmAIOgBXgANgCToA14A4AACqgBlgC1oAn4ACYAm4AAPgAWoAl4Aa4A7aAM+AEgAA2
-gAUAA2CADxAAVP4z6b9+/6dqAzgDHoD5f/xe/9PBAvAAYEAAcARMAXvA8j8eK4DlP/vNAHaABeD6z+MF
-OAB2gA8AAN7/5+Sg/5UbAMTUpVX+71tAAdXbgCIWoEgASF1hz57GzS9hbJbSeRJNxQlCgCV+i7HqoFwr
-XSNFAeLiZ0bjMMhujmrs7vaT0ypyFFyMGdYOp9oMtqvaGaySV8l5yzCclHUrpZoVAS1W6NHJRJoS2yNd
-z1G1zXtyHWz7rOQfH6wF3VPuNZA8BCmp9jHpaXCwdN1RxTZkSi+nvM5JlxZUX10TuthaD9b4cENFMJij
-Q2SkUyAe8L+rU7xI2T1AbxRxi3OkldEURA6SvtRoc7ENLhNtr4sVNppOn6maqkVosj62+zH8CJm+BkKE
-MkQsHl5sqIpJ2SY1fmY6mTOap/wSLa7sRcBL15sMKhNRXTSvNyWMXP3l8JzE/mVKvmkCGsLxnhaElDpN
-IB4pozhSZyUWrRPP1K8LgXc35FJlWZKEeBXfqfmyAUHKsJRG6PuJ6BgyPW2Li67M5iQ5xoKeG3aIYuND
-biqOqKOxha1VMopbPRSbgZwJd6pGUp5DSl0ODe0ve1gkc5M0uqoSykUxapai1IKO8V3o4CXUbZPV+Egt
-Ljct2xhpj3opLN7Td/J0KWuNK4V9pHjpN4zKFn3BygdGAdHYsFQoj3oshGuY1ICf074ttmkwZEeniATG
-gnuUQA4+2Tc+ooO6cuHR7mRYgsohJGQ1vwoN+0uBX9/H89Y1DZQK9F5yVZl7jFbckwJ57qyQwARZU2Pq
-HKLI2yjGTknBJtrEPCqBmC5ZPQ978La1l63kN9YbrHvGLj2DS9B6QcxCw+vlAdp6lKz7nCB5KcCkDdQT
-B+G7C7kEhvvpjyARAou9+B24AEJoLIbLZKPbEv4NJw4KdChepPnRtjs7RqQ5liDJImHAknsT2/L5y/BX
-/qwc6Dl+nvro98P5G/DU9e4K3d1Zrfxy9HBVXYT2lsuUBQpRMKQJkwPfpm/ysUJdovHRzOi+IJ+kNvkJ
-PL/RDnCYEKdYAoEiXtgyaGFf4SX0qLqBMI7gaagoCR92XmRomFDtIJC4D5cPPrw3XIiYSSmVOBXt7ylJ
-h3Dir0WrNj/OiVeCUJAk5BCp6FzyYfvgrlRF00S3o0UzRGjHqVBsoFyIp8BzWphgkEl+t3t/jeUS1SQn
-FYUHu5BVWxZnmGiYn4Z9BhzY3w1fpuwJfWQBsvTahEiBprnNfcWmwhAirVJUsJSwOLbFRK2NthQynTw0
-NphNqQJCoBTTOMaD4ydlEejg7vwNf+NM6zfMGRKDl+WilIlkIEiZKsGPflNbyIW/4dcahpRJTD874dgI
-+1aq+Plg3JLROssUzkgkVFbxS8aLNPyHoKBwCf7pcYDvAlF3SfURJGH5l78sLqTHSpbsZ7HXa23dvxh1
-L9xxfzrwDw1P915/R96u9eSqrvh1teAx4JluxNs/w31yTwY3Eabh+PVGdZIa98Mz4OEuhzqAzW0py/Td
-ezfHKOnVuFqvNF4v37QktAVMvnkCE1/c8ab0ZFLOkoDi6TrQfHymK1ZukE1UdR8eNoWxXxW0gnMWjqUP
-Z3kTe3X1wS9SdCZHK4n3M5ipkYOMxNPMTiTepWpw7BDvBqvNqmpyF+c+8Op7h6U2Hx3CPVH3EWRR26/w
-6NY/QrF4DmnSpBV7EDyY1uh2hKAD9VBF7jGIpqIHcOgn311lhvJ0clX+v8t4h8oGsV5UONOaneC7xiKk
-UMGKY0iIgnGx1Ww6aC01UZdiq1ejprdGRmu+ItflHdSu+BjFMQ9ifENokp6NmmCXc8n4dDc4EcNIEdJ7
-tGqt+EJ7rXJEGE+sM8JDi1OjRQwdUDzOUQZsppqIsCTJ6pMu+0NVFvHktSHl3nhvDifUgNyvI/mnQbjD
-NdrhwX6LlgMVcX/H345HToeU/SHocFSMnLyetBjhSceWNH9/BOk76Stg7E+QvKYUNFP7iYlLbCnwQxiR
-F8WGLJuNZfPTTH6iRV2FWHOkMnMsvHi2nQTWj/9ZuOkKNUOxVdA73QrRDpFSnzyUtZiFvC3mZkm251K5
-CoN24Cng+afpm6LBr/SuVJycyfJOEVRz9I8+epQg8yJFn3bXX5MCTZQ4FDqoNTNENj34p1e198LPDZfz
-7Mt6jy4L+5CoAr4N+NyYo9raWT7AqUoi1tG1K5NHFt+fO1ceysw81LAdVvEhlQCzumVk7jnzpB1TLF40
-KUjsZaIGFjB+kOvWQN78B48P4QpagAORcotkVoZ1SsVnRoYW2FiYSDckapxj33bwK5IGjvLQ7oUrMJ3i
-ydpkjkmpIPRMBdgBfbAxcZcjyHVUQ8VDYaARjmwbUaydQyA4hVk9zujvVkRSOOzCITO1q+U89sV5wMCY
-qmttJW6dgxOFbX8BHE1SMtyfRVVLYSkHVPnrKoo3UXfsAbodDhJdAYpihYKtFcsgjyNi2hE1X0FFbfK6
-XfUYDio3GD0CXP6oFLNyZlQj4vEw1lAq1fcOV7RHOWgxWm2LfWlR5daqqd8mLLN0CLsYBc3wzmUkHNhP
-YuCXmanJ5Be2o6IZF1S6hpFLOoYM50ejyfEa+9QIOROv2zi4g2b9ZNjYYnF/Pmj8PKGbzaNTyrA80ach
-1azo35SooJK7SR4BGFQWQwV+jKsfI/Od5g3HQQsWhZhoQ2e2QVGS7pBujcXwiQf33MJ/vpE0RmBbjovI
-MOcAZj3hi2MZ4u1sAg1p2ScsKBgAthcLUvNkP5WJxlVtZ/LrE9nQv1eQnHbvyPYmtdi0XqMsmHNk82UM
-y4jfXpPp5EmcINroTFtLqvqzfMuEuFSqzowkGbOf7hZLeXNKuRppgl1wdFdkRQc32zMZCL6xDlibTJ7T
-BuV4JNOwGTywVSrE8c9HJIZRRJInq7SPQZQMI6GfcLBufKwYlb2MN+W7Bkd5m8iKwvYeSiBm0Te54pWK
-L1gdBGGhLyqnYq2Dmk/Bj+y67HwNHEpN0VYYv4lc9sKqok8NgCzxheExU5unZ5om7iOXaZODrWDfVusQ
-MUQbNat6AK2g3hBLANlwhtLF29iDLtcF2RXq7EfiZgfT/NylmYV9rFr0qljC9MmWhvDmBXtxXOYALHuj
-+ZFU2Ff2LJxsavi62XlxnHkn+EG+ogXsuHBw7u32M58kDeW6Q4qGsfGA+kKPdTu9pQ3rVgcU4zMlvxSJ
-tLPHsz2XtJmbAJa4GeZ0G/Y2/8jUEmVf72QeAg2w+htQxYASxy669f5MCc929HHAUoqEr3WNQq4JOlUZ
-h2qjD8aaTeKwYvbikoeLhuissnnvAIuu55ZULtj1OKm71CF85hnJ4EPWBC5Id0jFDFjtAP33ITIJCCAK
-akJ9nMUezOjdWZAIEoD888QFNGk6WDHXDFxQUQXnwc2GjGIzHAwZbxsMXIt8USTMq2FDxgBX+Qn9SXqm
-LwhCapUvok6kEkcceknmi1G8eeo97+U0h0A4C5J09rCbHdKqlJZIoHe45U1tZMoy8B4o30MiB6ipfqxz
-eEwiIGhgihH6phQstSHYahLKLe1g6peM+2BaY1kQh5GKuV1TjeKTW7pGBadQyH3ZQy9FQKY3yA8oDomJ
-5oBPQqWDN0I5KFIbCuKl/LIGZ5YLcygTdCBNy2OMbLVcaz7X7SEBOIuK7oZG0LlmiwnivpuMQ9UTm2hF
-/Xs5natNq17LIhhieDP7Ne8Zi61Sz4nlJZLJmD4FzQKfmHKlerRSTqUVgllMkH4pIg5GRLpUK1kmht5O
-UtFPJsHw5depkMGntDwcRIK8cy/6IvwGIzmhrfxFBFV2g8rfva7AlvAAURISU728hR4sGieMW8qXbmrG
-1B4vIp9zO1PtTHMF/fEPNapOtY26Dnm+QMGTMRdxiDFJxn9mLEm4DVdJfoaIM24ML2VZ3EnF46QVhVZZ
-4y5vicWOa2+kjXdi26vdey0vqRZTthyTJp1x1wSyN+Yrvnp+krmpMoY+ZbF1UyW8amVeUOeWRQ2e/pV8
-n+xxYabJA9xInILK+zJ+JwJl02BeH6iann2JB9dUIzTwEPfcVsTc4FdS8hXS0MEE2OlMvMflDn034zQ4
-lnP2e0KSzxPD7fSCWprvKqruQZhZ5MDbTw19gzcSe03IbK8LmamobdcUeJe9ijDZoUtZ/PPRTHp4pFgz
-MRQEdJ00lW6ltvAcDzTfSwR2FNk/QphCZaAKMiJi1Nao96phgW8RZjF5e64fy3a2vyIqTVsoKRDq8Aq/
-sEH0hiQ1sR2K5pa6sK1d7qiEVCpjEo5DY5GcKGd6+jyrPrw2dc0hSs230L3Z7IRVK9MqSvhMKVzKY1BN
-fM+z+UOD8hpbsQANsMVdj3Dm5qrbFm3/dpORw01RafIAJBSnBdwUf4cwpj1lIjJk7BVebmRyeeKxU8HV
-WKFzpMiDdgVfVVoUjt0WzWNl6YOz1o5wjc7JLEy/IFKP7i22miHRFb5liMAzJbNbMQVQVPD8VBNokFwk
-SRCoq0+T0Tmie+vCLMQj5oxh14MiO9yBpwU0an7+eIoNDoNGS+tjl2P8NCKndCZKCMtN0w3g3GBeD72T
-1iZjuCHfHRZNJwTT8AZZHfqZ9Pa97Gk/CaVIqPCm0H5iCYHW1CpA1tXf0B9sVb6kTkFRdk0fdXEOL0ku
-iKnpNowZcVreQ+a/h1Qq8XYmajHpi8VG4IxUXUlQButEvBi2MBGsnRzUKoi5K4l/wUNhLLl0nj0z8qm6
-qEQsekZNT3qzE79g4UQb0MUwjTb2s4SoEGUXcku42WAEomHq29auKqGL+VSUe0TJyZEQ17+16U6pEELK
-WYbhZB5LO+U0U/v8i2fDxa5j/aZmjSmzAZ+DYYqcAGHEwRxTDYrlgkVs94+FcFWvXLgxhYPXlvdDZjN1
-MJEdwGdbRsoeUz3tP7cy/voVRpxAD5t+RIFDp9kQSZtbREIxG0fjRZAlrNbzXHpOPu6Cd1PC/WFroRlK
-+0+yW/iOwS6PnsHq9Oed1jKFLH5FSdyjenFlg8X36C/iCRUTmsF2SbuTlpAFkxPLElgwCA24HASK4x7a
-5irXjTARGXfL0dyUHKrrkDlO+9HV4U1yBt3FwzAEPAWUx6XNJhtB8ufnZaDqdXgHLShuJJplMg2ShBSE
-XwFXWw4sKfn9uMUgwawsMRXEUoY2cl81vZFDy8Xsnv7+zQ5ojWbKUvFTBRms5jr8CdwaeO1R+i2J3Ajc
-51SXBl1NyskpLjY/bbmghDJOoxErTILWmV4cvSaMp5nFr9wZy8ka7NkWc5wyQmdiYaRLMZPodWjf8mh2
-Qs+GmExVhiu9GPnZcf8E3A0ygHubSqIsKiZThx7XQi0QkIdCXvanySlEGaPdgCQZJ+Ti8T130cPBia1M
-TZSgd0jolzKzIpnUn0rcH1QsCw4D3wT6REoxig+wbeUDh0EGdUsh/F6k0aqS4wA/CTyT/3uNahFIFwzp
-P06Nv4ZLIHKJ1QTaDj50NT9eXrRwtWmsPant8toWn5WG04qAbarubGSmW4SKcsQIh0lhw+xpOHDl5ZOX
-ylgbLkOkYG+++6bmhut7n8BkZ1Q+4DptAB2+k0HpQhDaw/hoYkXikNOKRdyXeMBvj9TmkcElGUaobCo7
-jCaHW6eCIuLiXi+Zbcd2yCnAjfn0wGoqM8fSO7/cpAHolLmkKsR2wo8o8Jb1SR2HbefYVkGgOlyyZWC2
-6G7E8E6iUMfjkrMOvvrVV6Eujd4WnebFbh6RjOnkBXXDmUWl94UDOEqTQ9A+Lj3dh7Aq2md9Py9PRsxl
-+fCtWxg+un9c5Yd25VHzr5H0KEzD/vKMCREXVR6Kq8+mrmMdTW2j04UvaUU3uhYu+vWbJJhGljZqAtPT
-Rt8HO7YtQ/WBatx36fto3wtECMJua0FwCJ2Q1npQ4RkKZL/jTf0otCY9Us+lw1zRXAp+/4xtHiR+usXv
-iqjsqr4Aq4pXO2miS4jvUGtqsH4SQdRfGsqTRNLC07hDysvYQvJMxgjJ50AmoFGSiHZv3fvKwOjVvWB7
-VnjTfLAoZgRk7VBX4UpwG9qUrk1LY+b3uyiLiz8+Ocn2/FY5oZ/XFWQpgqsbm9oKnMEmJLyyuopig+oO
-5odruxN72cw9kkshtHNbcaP8TCSfEnVNSceLmy9j1bz4nMv0Ac+f0OzQHXZBhRnjRei27R3QaGJ0Cr+/
-w8L7I1GYVudUNHIywVaY8Vamla+k4tmC+DgrjlYw4pbstBejm0iRIau5GZIWNG5KiSLMOa1jZlJL1jUV
-J81a1cYlgZaPmti7cEJxruQxervDiTZQcj1bPLeL2fD5z7EEmefKGimfu6jbLogfmY21I6WJ1JwHd6qb
-I3ji0NFTUZWoowjEVHxL/KOKX+4k13ZMoSC2xyCda4NnCo2GcDYEIQlkvX9hALrWrok87voRZDcpNwjM
-XxfYNrq4IggOTUd+K8LK56wn/VETG9cNiriXcv3WdXDc/MB7DTHQwjCh5HGURMuia4YdlpZbNnujhzNL
-+tdrVB6RbsoQhmYyD4s4hoAOtYhkNbv8nZS9kR+8ihJ06Fw2l7gYTqwibysmw6l506XDhJVQEq+PF3VD
-hYwIrnfUaT7q2WhLDAHdlVudQ3Ch4dxAQYObi7TUUKp5z6g3F2+nflVWQkp4NA1rxiW7mzyQdBAxi6kk
-TqD5PkCoYKWRuJ83QQ1iYEJWeEHLnrfz+jKXe7BJ2IohLu1QTLdvT+hqyCGEjrDkCK+Ax3n2guwUSoaV
-Ct702T89JoZGGmYS8CPi/wBIr5Qf3MKS6z0RaXHr6MUNUfyTBXO6AqHHdaI0ruFYZ+obsmoT9JPuMO6I
-fAw+S7tgMTt6R9iLfZlK0f4nm68LiTZ3io5lNJyjMeGcQUhBkdtb1p2FWn9oPHTvt0grIOeVxdXBrbot
-DyEWcDyKXvFwStd29N7nz6KTdHUHzoEoZBSzJZP6FWuIkia5aR8x5zyWvp0jiRH5Pb+YO1QHDCu4fzJi
-iZmbzooYyQ7xtyz1tP366CcRCoXMzKmPclcvPJx3IjGHxxh/XUbgJkYJmT1W/l0sFsbZF1V4XKRAVF41
-AAcn7fJbLNqVVTDdV/T3svggRHkPKAhmRTSgCxN30mcBjMTthmdrUzprQ1Yme7HRoRwCpcTEj+o8Pmh/
-cbz1NMp63+kn04SNw/TTdyHEestqosc3s3F5LojrPUnqsQVbw23AifXQA8PExCFiEBsWNpsBXobbjkwD
-rZjUQxqa1edlWkRFhC5sRPbEBWXJspv89czE24uJX/QiOQ+tC1WpFMT5DcwH4TvuKp6Ed3FI5QZc/INE
-VTQh0I1QTUspelEMPDxMr219HqHzPxUjR7uE/0JSMrjxpVYZwcXbDh4kCskgkouZRFZTKHcorm9DwxH/
-71wMRKZq9j5fjc6LFM5/m0ptFRDsBcEa63CgfrhwsgTeOfmAXLgBmxKkdrRTCGroVIqM0zQ97DXJWQeh
-cMynX5Sdykghry44X2ecyvLWFvt9dPLrapzcm9EhYUc1qZzbfUAL+pqi+XWGk3rrlDubpoM5QQUcoTMw
-DlMv0hPtuEope+AvCWJ/eOmsEN48pZ3HcyZQrb/ejq57lBoPfw0YmE1LQBymPoQLb/pL4piEGqGAeLJH
-Lkt1qCOowUgyxVGfZ+F6D48o8aR4tOIx88jubyWpRaePQ3BgmKxkYZaYJ8Gh7kKLplYoXUdK4pgpX5Ir
-mKeRdLjZBHzr47Ld7viON9WVuTfj0hlV+4NyBJIGzU4KDwWH5EHvOy1sWkMK+zX1V75iVUr2WxlD7zrR
-qG9jXlaVBxsL+O0n1G+tp3dPOsXLOR0X9Gk6xkXqqOAjj1K65rJU5hXMq6CDplQ7CBmXleflDK88+GvW
-zLmHk+rvOFhFTHwN3oImL5KpUYiXpJAXUk14ptaiC5upRQvRHtVt9A55loi5LzMCp8jtT1R83YXrV3FN
-t7B+f8Rf8aqigbw3OvqacWEyjMbbgmF8l9AfvbKwUr+JUwhf6iJj6yoyC8w40pZuHfTp+bMs3NwMC4WH
-IgpXM7KehDsnxSwwBmyP2iVWj9ZGH2953v/ZyJUt8iP2DWhSO/3hMB2rw5JjmSH84gEOnccMCsAdsIxT
-ifKdnjUs0bywRglcyBcybha+7Sb63s1iR8lo4mCbXIxAKhaDenugyJVLtgRC6v6BPposveQSV8BTa4oY
-sZZClkitq4+vKc33J/tubFnEAMZM4ox4QZc4PMUvOt+v7AZVUgLckIm4BkEVX0Sk+sIo5SV+Y7JTdcHR
-9K5ajpB61YwuabC34CnON7s/TK4fRIV55nC9+4iU85Z0hv7KvStR5bwpYT0gHcXAGK9D3hlPTRkiHuSa
-jCx8Wo/GC6enSShGrykCB+9cMu9i/au6HUJvwzW8uHiSN2GK76Vg3N/TfN8cXnQxr73+tAk9XVugWmSf
-oggjaIPgi0rI8vWRjXF2/jg63zcW7qCwJC3WcWX+PQ6itm8JxVh6xOrIRM3f8/z4N7YCUu4/8ka/opLF
-2FeYX+FGlSlzCyjPie0LZZpp3bIgqP2wj0WEcL5FKvuEM2qW1hLa3wB9w7h2lqVgRHlANPSODF6Xqhn0
-ne84bHgyEUydfGzhAOf/JcOzeY4b5TA8UyO1CoR5y/zBi80Fjcvn3msiHw5Liq4YVMYLdTuNqNOj6Rol
-GJ1VbpCVOqanvOfufvJDMaWDBsqOmGYeZWQNFvIZgaWSuhC1aEllSkwhwOhwF+LzVTpqOiqZw2VfIrIu
-nf+yc52wVO9H6WAXdFW1oMFt7XiRbWPVT+2ZiKqJIXQKujTiUP790QPf2OoMLJ2MgLPgOTCMCr60IW/S
-dUhvTV1oXJSaqqISjgZQ4Wq1ow0VQL4DTlOjXk3OB9/4eElLfWWVemHOnfd+8wxhfbgiYwiEOIdjTBlD
-MHgc4hWJ4i6pHfqobovtMRds20MlxFWADBUwj9fPIf37HAP3cxNuqBvJyQ2hUBVHnda5VNUT/06jwQ/e
-dSkpyThv9kdiaKg6tEVNjyg0w3ATv+Mdr66nL/unilgtyoWMjQm+Q5t9issJ1qeCgg2iuaQZt+XPRGAp
-FiRMHpaN/sUfiUFIKx7iX32P/JVIwXmqm08yeFnahBre7aubPvdZjC5HHE/cKFKkFR5DJgM+kVP6HjVf
-9paF1fAJ2z3br5KtYCPamXfD6JwMFLXZ3RPXpO0Vlo2zZ4Ap7yk9etmIOqzRgXBoC+MA5XefJfbGBqW3
-NunQojvmPRc3+abQwRG5qz70U9PIBvIwQD2x0oBveB/LZcQf1OdYxVWNjk4PIYyRpIVk5p/jOcBjlQrT
-TXDh3ZT3Z+SGCYP/WJfUEvlPRKAaXCB1GuKd/kBu/BUKOtGkM8j6j1StKI7vdymVcszcO5LCAXSSFZ/c
-9iu/eVw4uMsnixCf4fo1b4hh3oSsDPjNND8/KIUvoIYQizqsYHbxQ0AhubvqUIHgF1OewvYJKVPYHd2e
-o/rFC2bu8MbREZXQoj91Pg7sWr6D5wkNwkh4GiEvx+8tRCXkEp2jGB/kcyRoAy2nx9nrAjl9etncM1Tv
-uBHWxdOunpYt5JZAfP8i6dHuzQ3d9cGbZwmZ71l9DLmY6u818VcnZX9SCAlduBvAy+aJcuy/RUa126gX
-K8yve40JjZapFo8p4Rn4pSsb/uBzJxRfO32ZlRB4e4UsTwooM+E7t9ERA92SaCbUykGJGS+0RGW9Tkdd
-Ude8p0C0eePRdlG1nnj5U2oIonHD8R7XyCu2zyczb3JpMva3F9NewoohTo/UKIUnvySYyV0MK3vRTlz4
-x4YxMijG9drToVlEoXzLRRffmm4WKWoddG1q7YKmeTVVo/7WhjhNvqa1EJrsjsHhzV6RsGjawt7ymYBn
-6i3zxxe9GI81Pl8VfAMpVQHaNAwQgCooHy7Lx5zGWQMpSbyqIDr+y5Ln8PmFE2NvaZxdilJ0e/+h3kie
-VKxFI9QC01LT0u11TEBgrDIQr8C3EYqvW1ps+wOxUkmzftm4vQ6jkT4DLzRJjnsnkC/ZimHqRh0BvRmE
-QZJIygbwbEn5d4qpUU3idHxlIkCFHmtm+TGwSiPk6eq+j39frCwxeieWSzbxoBZGIHS8Odmaafmy0TcZ
-uUck1lAHdVi3JlNf3IAFEYVIUsPu4AlFm2+DwWNE+akeTl+qY8vOwqICWBazikitymNNYiRTnRrSEW5Q
-7/lnsE+S3GDpsqHDuIjnwzXzegy+yr78ZmPDVmJlZTToSXUpt1sZuX6wCErDn9vHB7SnHHFOc4dlhaB3
-+zA7luF1oVL1fEiLIVYUF6JV3nlbUiHYWJHkpGsfR4w0EbCZVCYs2JQbsO9hjeq+7rq4pmE5/AhGLWW3
-2Vmkegm087p9if2ogSaBqJ95LJDNeaKOjKmq3D9ZMdT6AzuHtYbIAtDqM2pjavISDdbrJxxFQEicwaa0
-njDt9sD0M6MJ6Q8cbQ7BQiIpIyJ6f4YW/ZA8oz/iaH6pO7xH+yFHA1lucb0ZstOuNlRcB5upnmHA0gRK
-55Kjko2nuU/G33Gu6SCTmWgNyAU5RPbN+X4JIkTU9IFnfZS5XfAUyBGW9mjwE95DaxW41yiOq5EBHSp8
-+Eu98e5HQN20fIsq9kPDf8siceHfZfe4mI0QVCgThxQ1aeldnsoaOyAdjjxPza8MY6RFiM6MJg3pqnHG
-UgNLkAqL/hV3kP2APIZv1GOra8+VErbbr2x7fTwk9TMC8vAkv1tIy+Z912jC6C6mocS0lPuGvDaCPFlq
-7FixDdimtC6IcjzL61M454hvashJ7hZwwk+bUz2E2e3Em+lOO6GaetjoAvBhJrrSRMHYE78t4W1Dvlfd
-0oWbdlO6uPdqJ901D9kys92NL5pl1zJNJWicmjvWHCGbwRHbePHZ2WBsP3DUepxwzonA+kI8yTc3/znL
-jrBaJLMbyRg1oOJS+RdO3muj1p5KpXKKqIibuphjktL6WQM3IP4gbkTNvmnoiYQKoeyeHqmg4ZN6iY22
-41KhG7RNTJ6riTGW59c0Swss4KZA75fNf1W4ATEmNDlMyOGcxvLWAFO79OgiT95hVFJcHlicqz8x+utR
-qYaZSls4OE3hNT6yYGiiI1Z3wnCqNG0ftxh/HF4w77UkM+ceMzXcwPiRsxqMDv2bDykcKmympUH6oV43
-CmQPgn3sB3Q/k0lGwbLt6PS1tDtPlwcBP9ZAbWe16XahUFiPlM2O5BnMkBqqJumx0ULthEJ58kRqpCsn
-q0wstvg47sXWsjR2VtHMWLKzdJ81AcgtCa3OVbFMOCe2BUHVRyFxHjRbBpf5JxsmswVdXacwS+lMh7oR
-x9LbyZ6DRbJ0aAH6g+TsQlOz70gHL52pZTakJJtHUTSy2WVnfrwBnHlxiEg3dqjodAzBwRCmouEaVzBq
-H8JmW+Aw2dRW+fN+lU/EzrqCIEVkOOzYv0bmrXltulYQ2BxxB8wNXXKd991Y5s3Pbi8CH1BuMIGeBWfS
-nqKDGmzCqiHRe7wSMS5fQ77iRjCSESbDLJkXVFzEiHCTT+s7T4+sWMGXruu51GNgAusHky0nJlRjqo8T
-5BEtyvUhoH3O2pge//i6cVfb1xgFo44NY5zxIEXGgrF+VbOWLkqe1VDIjQsdFGguoYItVyzVH8dXdeS9
-tuekx6+OAemRr8+jzyvbz5GeZOFQOyVnx8vbCG4QGHScvL5u+HG6Ar8Z2Ia/4ivZr1HxQdutPeChT9lC
-wLgQM0a5Ghb9abI+sc22Slu8el1Cq7AKXylf7y+qKlvvSf5i2jG9WOnCvTh/ufK3o/3lhEuXQmFLIjXu
-lSXGrXdDCy11FzoZB5uwBX9X3Rile2vK/mPesMeJ5k0mvhPNtzKggi95uAKxwgUFh4q4knMzJC2etsSv
-m6YIorP2c1rRoHCu6XQxsqqB/MK1wRY+fw80P0LHYpNDO40k1oykUd9S6I9KWuAHDSsbxm2uQHxXjM7S
-VUBainHeFW8r239W+8OAfI9EAL5yz9qE7QCZZL8fJz3LjhpdHNTlnM/BwA1+7pCMg8vaSIAoMPQ4jGZZ
-Z6YGo+iSRsuPGztZeRiCOvAYtKTEycY85FKaRjRWMBlxywy09ZJ49+9GDtbRrYmkFqBb9iA3jRB/FVhh
-57eDuHIwZYXa118puZ9YRB7ZfZeGBbRzTD7FWEeyVWFgXmIIrS+b/uHYxBDYfZXLXyZoClJqEdUp46jZ
-gWF6LMsFkOQPuU9SzrmJErdaNhTBDRoiRQnhyn7FpVmc9lh2ZKX+FHVWHouFaT/DNP+F4AvC4zL/w/9l
-GoHdsErwh8ss8Y3+0kituYk3O2xusIA/otLWgTSpv35A3nScW+T7n7Rh8oFZwmE0wyIzTy80krlEKgSM
-SGfN8aG84AB+lnB0WGyFvx4ZJ2eWmf9A40RoQSVyc/0qiA5U6lj6vqgZiQGYaq4cfKcp3Q/tfOeq8NdY
-06xJjhTp1FNl0Y0RvTyFeFCoOQjDV2RcivIKhafJlRSyu4nSYQgnOhq/Egh1FH5I3exUaqHYJbsYFbMq
-DlsP/uzxa+/q8X5AvGB46Q4q4EP9vMmcUu6fihKcpjDFJEN7GPx4Ft2/uo5y/dFTEsFCphiYiQWwizg+
-nUe6/DDaok313k13fBq3h/BR5kX6R6V/X0uDN/jPXzdE7+u7CbuxPzWGW2Yq65GccLHq6zCq0kyzQzTH
-cMHcrnwxtiRVhvenFxwWvvg0hE1g6V8kU5FnzSC2asWXfmpIXLAwG0M5UgaV9LLesh+5//jUD3wMaHTC
-Aarh3Z/p6EPMRHebNZJeAkmxs6n8h304sYWEsCZiDvPTnUUXcZXjcJN1uc6qO2CaWMh06L8ft0wRUsLF
-72OHYUb57kyR5qnZgzbT6Z9Uw5JY/8wdwEdY3d4m7weJ3V2jUdUirEFucEa4Dlm85hodHNn52/Yiudtv
-ckM7aW2eKgv4MDRTNUqT9xavcnbYGknynTW2CB2nDJ6VPac6GX4eqXYBfZPF315vMdFsDcQm5EksnQ5i
-J777K52vnSHleOanXFMOG4qQOTyK61HZqvwx7fvdWe0B5vzKDv6H4o6xsVZzwCTqOUauCre0IXY4n/GU
-YXQRJaLlPpkcFGLwS2/X9DdP9UV/NHQ1dufBYlRrDzezxrVb8wDeoWZWItKeJ35qXMB5E5vMkFwVneBc
-c0LIXV/SMglqq5naSkanqy3S/OmfEvQ6OWGyS8tPaWAQpZLBTmukEplif+N3BD+qLr1AIrPUESyMxQMe
-+Y1Mac+mdEBbOplnhQ+CuVkit2M6bu8LPY3ALZgkct5CUziSd1Qn4LqQbZE9MQfvZ2+VR1CULLDhc8IF
-NTLgB0V37ht2hrhN++Pvp37RaKh9AvyJ3kL4BubF6awt082oWTbbTGRsyey+SXcz3UONY6LMfrDSzcU/
-y2j5sib03RVWRP29NrrHJ0rVh0xpWIspT8I5jeq+QKMXx9aSH9SvEXGj5HRZPPLE1lCn4SpCJ1g2qC9k
-8+n79pNJmoxxQ2Ms+kuWMI2x8wtQva3ig4kFiZ9HFNISn3NVpbmCDIJr8x+nNki19PhlJiDRLE1kEWVD
-G+x3h1Er9rRwcpmC43kpSkBVbOhUyHOin8+qzQYu6F6AQi2sfAtIgZDf5mAOoYOXRqCH6SVQad5/Ka4q
-qGhB1zBk4hm0NP+FeE6CIMVpI4O87N6KzcOxn1FYhFncgot08NNkr1sto1jj0ttqtlWAW+z+y2gWCh8N
-DuwEEczBT9jFre0QSJg5eOGUzOcX+gGDqrigYEEa5ZmIbOIbLgQMhWE5z3IR3YKdHSaSpPPQlq3+n9DG
-3nBD4Cwoo1M2ntsb+JsnjAuXXz2RDF8k0nh/F2Jk/WsypqfSHO2+n8JRayO3I1SWelj74he1QJaSjNTj
-wdTITBxupvKdydFEgoK10PdG9ABlNg1yo/xsV1VPepVG0zDJx9IO2ucP1pGCt9djAHpLnhC9yIYYUeMa
-PPeUMLkAFzYHq4u7CKRHqLKeOg86VHVa5UY2dkOKU+GGg8/J36tPPWHM/VC910w7KBRT1JYMHL/3v9yZ
-5Z8ECpy5MbVshVCBSOxdba+ojbGqkbb42wTvNGvlDqFR+32jF4bOQlBkRTwkIyO9CM5W/xYy/rkhKUpq
-iYQeoMk8QlWc3P1lPXk3hZco36w4l0kPbWhwkOeiH4rTQ1CWbMvLIguD4UAfrJCFkK6mXWhFE7iSoG33
-daBLLnrOlyycX6TKj2HZzLth1kaGU6M18GakH/Lm+HPW3f/aCRL5Sa22gk3/yXIHFxCDDcMHI2D4gM0y
-lGPNSL8BRT2R/2S/OjewFN1aZbRE9qQUBKvd8cWMzZefxD2iylkCUT7+qaV7iN1b3/8L30GDl7ai1EGj
-5y+TLuJzrFlTn0hzm9XaR80O7GAThqkIy7+GykVatNzvkQNQoT4rFqTCvTKGxm6Poj2ljL4YAxb1UrmN
-MggjU7GCZaQQ4eNtJKXQq5Bj0Hj2mu5SvgPdd/OmMmvjfHDMrXlJQFaotVeIGVwsmdDyuQIrRoFkDz16
-0whLVp8Pnx0Wtdhv+iKqzLhT0WmxksQ/FwihPwkLITxAZixmS5Ci6JJVBXrxamZPMnTJpY9yn19Nf0eR
-LvFp24FhrMqIdqVoc5t09nz7h5GuqSRU67nbzgtcQzdMEJynFhBssYWC9wf9KDmxy7CqpXRlXkU25BY4
-UDhsA5I9dBdKNm3sYWQiVeZv19Hwnt/ZeJb39qDLI3S3JTIptl0yRyoEaFvZILmDxqwyXTzxIWPxih76
-ob9gfEjlOkuMqtpWOe3PpMQzzx8ji6hQHuYE1kaCMCtsUu1Av0Jgo0aDrNraxVHxM0AZwaE8Q00QaXga
-XDJaOpMul0+TkPaYDiP1ca0NhtlbX3Vg3cbohWe7l8WU5HKQxUKfXw77KKfhcvks8bHTABqaHmNw+jKZ
-UENOYKALjh524iR3ZY1N2SY91A3KTGaoKchsIA7nUojOhT7yYPPosk4ghkUJPZ0pdVj70giZAcpmpTtG
-PPPK8ZtkNg9gi/64F8k9GAILOfxsOi176TXb4uMSyiAaaI5NyRnyiff+N651HL5I6YnmxoS+YSQ6qlSm
-VNsyN/QrJo+dQ/E1fBPgvIMlaWPsxAGqR4i1cxGu4TxxYdcekIQhobXXMP6NQlPaRD6RYaflkCVzudxj
-bD+Zsz9x8DTV+opmqvsaV1RMBHKfEznUD4eWpphaNtNdVfi5XatTUWObiLIqnpSF4coLcUvz5puUpGWq
-63cyhkopK/K9MpAsv549K8ER9p78wQIiTp8NIVwUfW+sJAfu00n+5OZuFXbMcj2/5Yj1Cwn6rkUopGOE
-pw6/z1SHQdiBy51S8t/3alKHfMZQGUbzcQ/18faQP2TY4gPN+pd7ARxxs5YD37ibQPEGrUxzt5NhWev+
-Zbp30Bg7lccvWZVehnW0f/1/FBSew9NcZlwoPrA3gSzQH+sAIgv3PR8oZjo6Hu60alMYKJ3cPkeh1avl
-mB+/VaMyBakga2qAoH/3qjCkQxFZYd0GSZCpiCvINa62pzKnWZF1sdG2sLS2ZWlUiRSlfuoMWoneUrGp
-rE0eghdTg/uqJlMperidOaSMNy5u9Ll0XSxYdt9PO2tl/9lDcT0iCEIftmFORTRPN0yuMYHsiZSTI/Mk
-NKKm5GiMUHm6S+EiR2pU4z/Qy6bnvXYt0RFpCvSSNuBTUIw438aMYN2bbiUYTbRlvmc3YASbXXBvjsBg
-w1RngeA7GyYU8rO0/fT0QBUiTYeOrvt/vSomO+igJQYNGFccjk6x/2KDGcq1yncjm7QcV8liKv/YjCX+
-2WBVN/FvNTIQZM4Oqx7mPu9kr1biyA+hgEPRwHQuvIeFjnNwOxy1LArBJH+EHpVhR4rFcQkVBa3lVhXh
-R0L+Gp6H9Mud6P1gLSW9K1wEjvM8LTTZGdjv0CnMa2coXMKp/KqDvgYzIZ2Wxz5hYBDTGOw59tN0mxAv
-+PuAKlzCo8Rrlrwphyb/aifxSdTLwtneLmaIRyVi5ELKu19nHEfmk6i3w6X+5rhGZmpHTcbJ+FDMTREg
-sav6EDuv5MzJVcllh9zctP1+dW3V3gBM/rjEGzQTrTc0LbG1uBy6QY+pr0KLoBi5W8eyJYczVbVZEVdS
-7msuiLkOIXzUvURM9YHFlKIaebHO5MPFLihHT9Yz0a4lawrXEjuw7mwuutiitDOYDsmsCrJ8lb79S4yk
-j9XKgkay128YWwPGwu4O0PFjz5WoDhNGinmhuI/hGBGD27fDZhpAlw9AG4MFzCjDwJB1kykKWRD25rN0
-3KBOZEjEZtN/0JKEPHM9wayrCY3UQD3oXzNUHlrHNWhvcqRY7dxIDvsv0fkoJrT4fKIeIw+bzk9CrLYF
-TWWmwbCnfexvaJRJzGlJlptlRZxPHKMhN5cew9C8ldl60DzwEXO2e3VTgvy2i8JSHrFS9hmB4sjatFln
-EFZgCFtIIsEDHQu7zFf0uDTwT0xtSlusWNTYnAxLePF5RF9Znqg5VLEmKHf8jrosFvlQVBAmiAHmPH5H
-K7kpvxbT4jOyUCLYwe5J5KL2mhqxejsq/ygfBWdOL2rEHX/nuiHJ3WamkzrSaBfx/eRl9lkEnzfirrLs
-ZU4YqUipm9cab0X6xdqtSqcJGy9IPbSn6B/sIVE6iqgLcjJcazdnpC/DUxfnfvDOXiXTcH3XiAen9KbT
-yslzci/Oo4U62hHWF6urNNUM8Tv0eVJtTT0/dfhNAaVEeJ+jvqlvTeMyNN29DEj1AN96Z6MrVmyOJhto
-6h7ZtMiEDiNQkdl0QvrbqlP7TN9JyRYYIaPXZWpl3NHc6TYB5e4mZDJrwPjNoPkfnkPvr597Hz3f5vAJ
-ESYApF98z1L7xF4FMoHD/fahcnr/lJRBR22O8HrB+4OqBZdLZQim6n/Prd5wgxHpMVTFGdlxES1Ssor1
-G997EGauoQYfZSg8GH6hDJozmM2PbZVAPDe6NlUxwXzPvv+NRQmhnlVIWXLomYuNRrtU98jPWU1i6l4O
-Vd0vLqGYJFWJ5T/Z6VhtPMymYPi0r5uFN2UwUPnOUKaTF/PF4y2jsXMG1zq2ckOCOLqwNOblBA3aQoRG
-v4mAnncYf05AMM/vfopXECNjglFL2qBJJquhgsQoHrrRNDPWNMlyh5kz7c19S25FFIHIYA85yUZRP8TA
-BPsGM7WX8oeLBJceH4+1QMuzmPaB49xhtKuGjk4yNbgL8Oou+B3xSvPaYSiJ1IX3k9PwrxR6oazcYdlr
-Rl4YzY56gjFmxVUo54RaNLgIsrFcAuYf47SjZRf0CkyVLkKo9fOC8ssto6JfGhgWjWPeC6CdDbcKHxz1
-aNwHTor4BR42mqcQlB7gKpz2DMbTjLaZUOnZfjn83k8xFbuG0tPbggjTT99DgO7MR/irp760dNirqWuz
-vRsxP+UdGPQYC5+j3mnQwFrGIOyruhD7aZKkw3lNbN3ldvlTvxI+Rlz7z09p3L/YLJW6YRdgVf+/aiuh
-IZLx+t79FVdhX0PpO9H3r5MJmRajrqmmleRFzElYzRCZKfUaZVGIuqSpxjIbs2M9HI+nZCjPbgLpdUq9
-VTD0RCUC/UvUUZzfrZJiHjXKgnZkts7pO+cOl4/Hv9QoshWZofCQQu2jNoVPRqjHei4Mu64jPnU82clz
-6rvfwIdFoiOQpmeu+0AqIINy17B6x0uqzw25kN7IFcnJRPstxiqViqa/CNbyj/fo0TXxJkW85NNbhiOF
-7aKAesqzI1iztXE2Jgb8Ud6bzGoINk6j3gRPn/NCGzSEqYdBUC38Is2HK0zMey9BluaKKje8nmWOTVoL
-1vFxfMQyp0/cDcSY02jHsd/Q+oWgWrmA0OBMUbOlkYQ/7/MXTcF1c9kDWvwp+eHGRcQOliSTXbuIGPNT
-VxnpuF54SO8msxjghENyKhPpwVNRClkBql0j4bdgyIYYrMtoeUsEGOUSHNDsc6sSGt1q3/FTqu7XKS6x
-Y8Ji784FrS7qKDtPAXpcB6nvzqZHF9M4uTyJ9z6nnylbnRuczNGT1Of5IJiIG6r5tS5WX3f78Sw6d5sv
-PYMSEtWjoZMe5RojGDxPbp5uhAO4eZ3gSbKlkiITyO02Gy4USK6kuIrJw3YgVX3/W+fizgQO7OcSJ5GN
-m5cpZi/UqcOpObmenZhhcFdCRTXhXKDPgUQjfvunWYaGT6KvrnoI2S638RN96JRz04oL/JcsykQRlRL1
-ZYZ3s211A1eksVifW10r1S+CFPeBHS2/S1B0ibmTR+9kySRRATdjntuopSZkrRWZYXKgdVT22C1OUuE4
-gZuggFypB8B8cqWgTSG2KEqFMP1wVfTqHkWLFSULob587O7uR7j1ar3E6UtAchKUXGiOFggeZtrybthl
-d1GmJq1zS4nUSYWVeTLJ8pSlzGbNke+CHcVGuhOI5iLD547cVVBgyVOUJeJdgYd0JSMcLOPUTHY6dJss
-JqBw56ug+7stIlR3h/1NCa+kjJ5EWJ0kG/ctJJzlza0PrMDR6MijRTL0Lg+SkW5ibfiq2j98yg7/l1Xb
-u1aKnrh58LcFPbE7aXodAYTUORYyj/99whhUt5fqTNu06I4slq9JkEF0RGf/0TZExgLkYViu4BeyGxYB
-shyyc1HWebjjpgf2qZboPFNeCSq8Tyhpba9wypnEvyh8Dfajo7SwEP+ip503CvOcg0QO3mcQ6Ayt7S8Z
-v7FCIdA5ueJGdl/I01mVzK4h7YwVLnQWuJD8e5lhXyGb4NBt+r1z6g20uEh8WEi3ubJTzUx+S5bHWm4U
-rad4kqRRCvGwJqBVgrN8d1+9JyRfaLjhdL3Iw3PG/nbxI79c/TvM93NSJudVOzdWd+Q8DdQPaEWNTJqn
-BaIbVSsDNvZiG1oacw+lijdajVEfnt5gQ/a/IAHpWVRIhKnw85LfeP4aNRCMjPzUrKCIk4q/Ma2fbBpD
-SBDJo2/zhKnIhPfTQYWCK7cznEOoyyuvMagDs2nrn6nTmQiTTGC0gP27fPJi7VjlapI92mjQ/iy/+60n
-xMTY1FLFZsRrIhRdm9F1QwI+8vfyvCH6rBeekw3AajBo0dGh/G6p64pttNaDYJtkSS8U+gTeZgBlSgGM
-XFnakUHIvaRDIbcUcokYF8VZybexCMpfqxY7JfkW+mul3uTEJLm84SZsOSXwGIGyQu7XtdPp4cOicbJU
-PUjGP6eYSnlCfu5NqHg0lcyWgO25uIInLs2ZOVBm2pWkuOavi/5kLTU1Pzp01GHQZBQOui0Hyc+itzBp
-kHgmoKAQzRmjvsBfYCF2Z+cfHeWiu/yk/iGRpELypd1l2Qv4Bd3CJa9geeLnAW64S/46LqkkQbXHkC/g
-S9zDlROjlAK5LyZ7v2ntFBcUyKI06b/evrfotAATn6Y2Yapeprc+JGcu04IOcpxd1HmB3Z7ahbNMwrQX
-MEbmnmD6SgoxNWSWFg+mrNAL6xTFRsGDtfT3DlmZkUbR7GNz6JxWkNjgGQ7TKnVN2fHQr/fx3JFMU9VU
-iIK4vlERy3fQU7fJSGHZP2VTBWtebDbzqoXB2sa/uQhU3PrdP8hQZkhDKStBkgufl8KceHypVkWA93eZ
-si9L+nlf5Up+bK3B9LRqN77ADj08ges+Hs95GRXjQZRmd4ja4TSWywovO6mcJQlJPh3rJPWYy4lZFdER
-pYhGQ7rCFkaUktkf7rNMVWnpzeiVrX4zjYiKbOYIY2hJzYvccpgEqzIzhIFI/nbdipEU5z6EcW8MhuUu
-JbqjH0pUbWOXE4s423iHQA7T9+BryAilTatsXcljqiyZedDvswKGI9ZT2g2zSIfEYsTIhkVyKq7caMTC
-3P3TVXNQmiqz162rLpXFowrYIrflQBLHgjPzB5qfkS1zvcsYdM7V5K3BNhVJ2/J9Mv/OQGDX3KiUStFF
-rSi4XlqXNquZrxSqiMrQ4T+R2cdh9Xr6C12H9vogKS0ockXMVHtgmRpSh5C86a9UZg1IzEx8yGJ2AeUP
-+l8lyBa9WIriQVT6Ms5lWEn42r8qFr1MOf0Ox5tNuNgv0d67I2FbSbkEQgjCdZo+TZ0B+QiBLO5U6kE1
-uoEQmLjlscVwH74YXuQBdrffEqTmz+jlIptRcX/6J9HLrqTWBSMs0HDh6CZElSilgmU7CnJ9iR1JJKE/
-onukhKqbw8dy6o/S1UOCrBA5p3grBWnbwi7tks4SHphrsrZo4cezBJc0zG7Zd7yCJAc8On+4Fi9xuCpL
-WBhfLviZsOah2dichTzNmAyl8Bn/Y7MR1fUnYH09R6r91aF5Bs88m969MCL6Cy1t1ZnudvqMIpXKbSNC
-Y2ZdRU0ApxKGpZPZ+wuNgtyLLTqhuAmMaKkRUXPmMmV00IgOHm+mR+bTgYxwEDa8xeAP0+GRDA11jS0o
-U9IqmUKwyNJVDN7NvSZ9VOmfayMiy29yZjx41OvbadSo/LnLYz9EgMxGxQQ4xtsevK9p7q4JQE3xucLM
-0vvLj32kKUzdGgJCMBo2eDjtWfqfRDEC7peR6jNRbSSMqPYb9njZn/wjm1IIBRPwkONQORy/C9kF7fu+
-6RP6HfcyZFOIxvuCw+f4RX5hDJSwVYnyY+V/vzmOucTbLrNZI9IZvioV/3ubmlrKdxLFOzGNffIdd0Uy
-7PUOf3JUc23D0pNeX7ja0YOZa9otLUCOKGWnZlkLn93Blp5of7VAsHOpVfiOTiIiEK/iSoA+Jd8ZBKKr
-L8ua0iEilv0hS1WRkXJLVUM/QGtLN1DDkFJh/J2wUBJBvq2GoY9TPNZ+751wHu3Z215Z9iZLykqVineu
-NV+ADszI0sfXMow9hErM57kDS7U/BH66SPRASmbjAUdfWE1UoKCMp/w1Vf4upIHKdOIo0BT0gOOESZyd
-TASUv0x3jr6TzlVmoplfCvLdgN8Ez8ZuUIz7hKxBOAMYqlo0vwhHxZq3gwapsa2WOEy4ZqWAnfaMALzo
-ShN4botJljN2E+G5AVTCU0PIMKxvF8r4bWCjelHIH/JtSgaqx3SyCH6TY8hx1y76Z3k1S5yjVNw+lSo0
-qyD+FjJagMczDA4Wevxsf3clEOEPbGUbzeljodp9A2SDxQDtCMpsG6Ufg/YLdOD3PdIDB4KiDokx1crV
-mQQWBj3Jq0+UHCjz0B2oUwie773+kJxBSL+DVkOc3QwSGaCfLhFlMGF0yls38IloJyjtiYS11QYikx1V
-AB+kTarNVMMkwupGgAQFdz1MSn4QFDDBKYCivxRjA8uOPC6KPjMZbRVNkoRUJaiCYmHdfpqC/EyfSW5G
-qQdVLgx2hmtbgdjXb2yEkIQostHZpnpqRyYNXz5T/0C/TNnox7b8iELtI1P6eUGVdJfx5yY1TqiQBBeP
-d6GKwgghUmvTXEpi5dona7DyfVFNB6dV/FEndb9gjoVfCj70hytTRNgfNnqzYrFuNgiUu8oWxmLVAvo7
-4zHlr5Kl8a0IiAQPhf+i+7u7nEg1XEDEMGE539hMrvIpURHXSy1wmfvWyinDlG/3D8JyPLCvJ/i5FmIL
-WQXNwE20jvnOrrKlu6iDYgd4cVLa0f9Ykg3sCRrNX7h2K5i9cjX0Z0P2HvKbcM72sKCa8UAP/3KZOev5
-LXYyPdMi9PEQePlViM86o2pKa8Q0sv5+15yZPsYeU70pYpJi50QPcRiQVH7GFq6R+LAvzfPDRDlqZuu0
-56ZXO5zJkpadnOau1HUx0hxEr8ckFz0QOfXzCbROwqA0YkUIWZeM1vBtGh9JmeoRgeSXSqA1SFTlxgn1
-YPqz4qJBbvuIqEReQWIhTTgoBrc/YvT/AQMO/PEK8yRAeW6dEIhfGR4ddZxV/AG6Ew9D0uDtS6hfFwZL
-NjKY7EJ8Chxeh4GiglSybXK9F46hTERnyr/LZpspIS0c7vr6kNNviqSQY2i7fcntQG/gWxAYcuBFXSCQ
-6y9p2ldKOMFPGGdi6tOBRtZlgigvlMJFzqAI53lP8R6ZTEKNnF1Ja1TaiWHM4GJWjhHM1QrPzgoJMry5
-k5nWgSXNYgS7isJq+qrUZ4aMJ4LA8YcyhQItNrX/G5hE/ATtu6LyShdaZScJt29CaTId/PsbK114O1O+
-EFDPGQiDDqV7ox57SnWkbbIMhGZeTApTFTDDGZGSzcR/fm/Hx+vm/C3r99APU7hFY/QqgHZsAABwT23z
-gRRAEwD2dgktDh9T4/9EOzxVHAvIQ68RElnX4BUZvu771m5BKaRweE/9QKVp5UyKkMI3D5dFYEtch0pg
-9DnbAlUk/AkFcLonsNWPoN0BhYzTM8EQdg0rAJNunJgPJadsCFOKfdwFAYlBxRjsTmowKg+xpujXszQ4
-2M8Czmkl0QWHFoqU0jjR41A1wmOJjCPF6FQkf0a7PySyl4BMfqpJng5RTg9f8k0DQwpUbolMimApZXzI
-kIshZJrk89ry7ATckVvsc/uRRJRcVgdvFY2CBmdGHfucCI5HKDGoIMbMG09KQpnJEQBK20ZSJXDhnQaO
-HY0zTvBQIEPpU+rz0utAcE0UlADN3XZBAGYsLRMgK65Ig0Z0uyyftocG83dmA0GoEoAAqbKL5o4pecHz
-7l6txrh9AshOxStzOBYEWhaCJGqHTkaz8dfiaSPabMjWCBbiaIGWtP9QITlnZR0MXQIG8GnLB6j2aPLv
-54AB9hpmk3YWJwX0g2wX4fAh6ugceW7bT9vkXTPjAGmGmdGPwA5GzQmbgMgFUGtjnFzniApg5/UP3hZA
-3hUIOTIBxA16v7VY4/o7Xdup4WmqJVsbNYsUlIDgcKw4USBji8jlZNvwXNFMb7HkAjtqUNoviq34bv1n
-gaLzW+XScULSUVA79pgsGFaTZdnn0DPcUB5ytCcC2Lf1NFn/qYYgOLsPBvdbJQvN9qZjkwgKGV6IYNX3
-sAmaiwYZDlAaHwVqTD7KoO1xF4D3wJQ6NlOVl47RXUvOzyKgpQiuZ1umnQnlz5FIkxsdHpjiA0KsNchW
-BhxFcdtMSoyVWHtDvIi5p0K9xQPZ0iEIpVCrSeGQHXE5u/hMbHiWrsqqN9IbPtaklttdQNUhQDlIA5Qh
-qu3kyHDAHsmZFdUkCeI/3tUpm6uB3I6oBWywBi8isZTAzIGWryuTQGB9fTpc95Wv0Ak4lXpRZ1Ojvxgr
-8pKkv8GSQ4SjfYAbUjqf9Uj+VUYCXKFY/pJn9sAYL+II64AEQVolEWMfMRDVd2xFVAAFlg93WAygCFpO
-aKWghQjEeWYyRMYggMO4GIkmdq5Mk3HuHjTGN5Xxp/vKevrwXJBpShF5wOhKLWuvTsQzvaDrCaMtvAVz
-kdFKFzUWiQ+XHinZVjCZThcw3WTaXNObZDBkLanjRdpbbWajXKNVbcF4EbLS0kOrUjwoYKrRleYdg3A0
-XiEGnWjUUegLAGCi+YEhbAiTIH7Gmew6SofJoPFs/Fex/oA5y7jMWlhCFD3ryGwpYGqeHaplVkqz4FWA
-swKGRyGuc9jnMdQ/xrwzhKjDii6zHn1iG0lh2eo24APKlQYWDR6cdD/owIDeUwd4Wkonw3AKaessphIu
-iI/xrBKQ2FIHBwEyVGA1AJgtUgpCeHLmV0gD9gxKULBlI0JiiXyTr5Qtsf6QoZDsi1uyhKDGHORQwuL9
-hRqkqEVoi7GzwosoNVOcoh2VZtVy5BzDGS9df8scsNFJxooNzBXWZJTeRSP6ok+uU/ozlznTu124Frqz
-gGUegr/brtXJuS45F1+MYuplp+a+TYwUtIE5Yh4TGSNljakokqYRLYggMJ2n0tdSYbHgxh2EOfm6logG
-IgL+ibH+/f1VLOHCJfp+vljo3IAf9peU63qAFdu9eyc2W0CEdFtXJf3RKgsuZCd2UiB7lNLwaB6KBKYh
-3kFlZJJDqkV9u3g9RVsOAXzvQiQ3SqEqIEIt4QAMLD0RazeUW1+26i3QSfSWDKIVq4CSh0o1ZdbMtsQB
-tBN7DcjtKAm0hjsQjQUUV013p2CrsAKAy35w3EGgsHoVDiA4hwLuZuYTKSnijOLCKN78QAmpCMSqB9mF
-hdkDPOHuheQuYSVOIPLsbhTpQGJ6rcMZh8jRGsC1vz4aGsCQ6xgD9CWFh4AFVAcqP2xnYr9k9hDIPILE
-teP6wHc5TmGsxRO8ODs8XiolnZt50jp0EUSl0pVaPBxAGwX8iTCqqKvkgnE0seTQ7WxcDZYfFYYxhg7u
-yRuf0wBgL54kEklKINKTc04pvDsHOkE7kikk15UKsFd3ohJ5JUty3kl2D1xitlYHmQajXBxurWR7wN70
-yDYVdBC7kHad7dcvfqIA/CpP1u0KETdVFj6jOPsXcoJBQzy6E2rpikXioi1qDxzn2OeJt6j8eHPuKYGN
-zESim5YxvMOML8/KcZNwKirMuBjAJALZkY0TLAsW2+ENg7NCGvXIvHDE+g5rCRtNF2hwiEvqNxfGFVXo
-pMNd8PjhA2gmGIQtGROeJAOI6Y67ga+Uk1nqkBujO+Bxy+k2d5V63ffWV/vuy4TWrUGmaLLsRH+ckQNe
-UvYD3h7JASKtxnMPNQBm/fzudw2Iv+QrEOdRJhIfEZIOLznIgpacuHdOHmLH6+n8kJAZrQ/j/LDYenvl
-2ByV0/5ZF8U+Cl1ORdrBsWMs354jDEJG/TowzdgvLUUYE8i+xsKMx9eD6xFGXOqM9ORJp3t07ORBu1qJ
-Q5lPDgdSwxt1a6UMafqU9CmFeXRrZJ1OuCYWm3anFUGxmYgMUAJ7zdbu9MtQJ5rzkj4CyOrgeALgxoZg
-LtwE5CUsOzrUv9k6D46pGqutv+1hRvGnbxpUUasQfXYj9Oya16MCkfbaUWvoxifdc8TU09YfCNj3En6F
-D7XezXo1aB9NxFHKkgbhWT5DGYXoB8qNmQCE6L5pt8wCHRhVsaDTTkYjEUoYMHWMBc5D8OTwOGuTCCEK
-Zqvp7Kd2DLl7fxpF8S8fz+vmSUtJRcs+DLpwt3lkhhPMZQkKyr4SlD169IY6QDfWSuD3xA6zzHzGxjVn
-Bw84wO54DMUz50+xjB2q/oDEENzcBwr+Mx+ASDGL87HYhEYuoHm0Znq07FcGSU0bC/HZGYMYEcZqW4EX
-LnfmJQiEaT8B0oZVEsLoqWZ8fUArBFs4lWs3Q3ikmFOQhe1FTwIS3q9woCTkF5f84ee+Uea7K5kZygM6
-uDzhXYG2AbhL4jOPi1QtebLCzRYIW07R1nq0tYQ7ebOKpC1IWv6qWV4VodQupU0AKgjUJ2g40u1BhQQu
-sE7vMhZyiZxMQpwLNzpPco+SqX2REkOJU8uaUxk55AFKcSPsoxJXSX0qOMtEIRuIuCMJERRnI1mPPhM4
-GGCVCuwV0JLC8QMMP2GFgTISSCwfXvHjxCeDj+8/uP8DkA5euIjmA6dug2jjT8aSrQcav2k80Rmq5t1Z
-D2EyySsemfzMNmGwRrHiYTzMIYTbMKyJMr1dgWM672W8S6SsHFiYWJhbVWfpTxKvdRc1adR5UGNW2VS6
-njUfUgFJ+p9BNvJP1LhpDaT9QwSOXIfxHoWX41EPWhONTYRExMaD09oBkM9CIViWxDr00OMIHFwhxxQY
-XsGFIhR7oUUgFFZhB3gkUB0JFxhcJAIMs0RCQlTe00Z06J9+mcN7T4ceBAC8DXcA7/ieNWEj+AdgkKs9
-BBYAEWeBEoAR0IETsAFZ/RkyPh2HsU1Hry1HrWuPw6iq2zFUvmKm/IVMGAq5YCrZgKtWAqxYCq3eKqV4
-qmXCp9aKnVoqY2CqVsFT1WKlusVKVYqSqhUd0Co2oFRLQKh6cVDE4rbJxWiTisdnFYbMKt+UVacoqvZR
-VTSCpzkFTJIKrWMVTcYqkIxUuxipUiFSLEKjyIVFsIqIoBUPvipDfFR6+PRw8PRG6PRA6PQ06PQq4PQQ
-4Pf1se4Fse2FsJaG2KzhsVjzYrAGgBxMgC6ZAFQyAHlgAK7AAVVwAmLgA+XABesAChUAEKoAG1QALKAA
-JUAFGmAH5MAL6IAVkAAjoAA/QAB0gACk+BlHTIjrxZ4AU8Ap9gGew7+gepP/dAfPskZ5FdeOACAaADE0
-AExoAIzQAOGAAYMAAWYAf5kAsMgFZcAoLgEpcAhLgEBcAeLgDJcAWLgCZUAFFQAIVA9ioHaVA2iYGkTA
-xSYF+TAtSYFiRAKJEAlkQB8RAFpEATEQA6RADJEAGETgBHnAuPOA0acAQ058DTncNObY05ojTmMLbMIW
-BcCwOwWBuCwNgWBjiwMEWBbiwLMWBUCQKISBMiQDMJAMIkAtCQCYJAHokAZCQBaJADwkANCQAcHA+A4H
-OHA3Q4GmHAyA4GIFAugoFoFApQoFGFAFQUAThQBGDAC4MAIAwPUGAHSJ/AGc3gQdi+RHFq3p1E9Sl4np
-xLLiSe8eVPF5U43NTVc1MlxUxm9S5aVLVpUwyI6sBOC7GoKMKAotoCSygJKaAYCUDaQCITamhKJ3iOpI
-oqkOhqP5+o9HEvmtoBFPICByfgcH4GV+Bi/gXf4Ft+BWfgUv4FB+BTegU3oE76BL+gSXoEZ2BBdgO/YD
-r2A5dgXRIwpKpBrcF6dCzU6CLJDk184fxdOtsiewAAABAwFgAAAVoSfijxCCYARcTFT+8NkCQRhwEkJz
-gSlyhSHVCuIoCgPSBGmCYAEAAP//WMexnJ9OAAA=
-`,
-	},
-
-	"/fonts/glyphicons-halflings-regular.svg": {
-		name:    "glyphicons-halflings-regular.svg",
-		local:   "static/fonts/glyphicons-halflings-regular.svg",
-		size:    108738,
-		modtime: 1534412156,
-		compressed: `
-H4sIAAAAAAAC/+y9bY8kyZEm9n1/hWkE6IMEr3Ezf9eSe4BuDwcBKumAO52gT8IcOWQSiCUvOHG5q/r1
-gj1mHplVXRlZXdUz3bMiOOyMivDw8Bdzd3t97Df/5l/+aaHzj3/96U9/+fNvv+OH+B39tP3w59//sPzl
-zz/+9rs//+W7f/MPf/eb/+4f/49/+5/+7//w7+in8x/pP/yf/8v/9r/+W/oufP/9/5X+7fff/+N/+kf6
-j//53xM/8Pff/7v//Tv67rRt//V//v77f/7nf3745/Twl7/+8ft//9cf/uvpT7/76fv/+J///fda8B//
-0z9+/9P5j8wPv99+/x39w9/9Rqv+l39a/vzTb195X2KMWv67f/i73/zTj9sPv/9h++EffvP9fvl3v/n9
-j3/46R/+7jd/+MufN/rT73/73R+X/1e/+Jc///T/nH5Y/rD86c9//OmvP/7xvy0//PU7Ov3lr396Cj/8
-/hz+5bffscSIFui74Q8//O5H+m9//tP2U/ivP/41/PhPs8APP/3uxz9vv/1u1Pgd/f5H/ytIjt/R99qs
-P/3005/+/MeADz//Qole5pVnt5/k52/9tz//6Xd/+f2Pv/2OXr37P35Hv//td481RmKOceVCkVKmwA9l
-S5FCeigLMwVeOVIQ4vZQKHB8KJtd9YdyDiJ54dKJS18bNeJOfeNBoS4cq5ava6PQSa/HFrq+uAR9Rf85
-iWStP5JWR6E9lE0/QUE/t1YKmfXVVtaoL6B5FFLetH0UUlyCtpHXIPoxa6C9vYW9ztPeTnxZW9QITdkC
-GraEvbXaxKb3KFStgry5XDq6i4ZEVGuDsIXL0Kza3oAGU6hrwKiGlElHNaRIOqqBmXjVSsRaShhV1IhR
-1cbuQ7SGZg3qFPqm7arWWjQW3ajEY+vEfU4F+osvRGsjad2bDQ5GNlSylmJkuWgDKeVNRzXFhYl5FeJI
-+yvbrOe0N89bh/nlvmkzltmyrsQwqG5o9z72ZxtBjjR7u+0jsGKuW6H69CrJ/k9GsrkYyZ4kxlVYybZg
-/LNWlkEWRWkzlXhK5biM1qHkzfbMbm/hUvqk1aCue+W0qoBv2W18y0rjW9oc1HNUyNojTPutbS9H2puz
-teT1Eq+P2//w3//LD/Hvbz4qf2/j2qX4uJboa1K0d2UL1su0hFQz6T9KP1g5Oh79JLX7GwmrJumYLIFF
-ya9GlO5Gwpy3IEKhngJLOQd8sJV3v95sQfLzBXlZ+Jz7qo+vFsLVSuOW6RT0+3OxJKpbIs6Lfl0/XkmJ
-mzhvIlRP+lVt8+e9xH0NhVjQUt0AdSAxjl0XMOYvbRjvgvEX3Thywm7JOuhc89ptoek+gH1JH+h9xt2c
-iPtNCtAT8e9fnGS1xIPi/LI4p3hUXj6v+vSZ1eeX5XNKB8XLy+JJykHx+rK4cD0o3j6veP+kr1UOio9P
-aq9HI/PDy+LtoHL5w2dVXv7wWeP4w+98M2k5E/Poq2TB7qsk3MdacXpmqpWCxHEK3HmNlP3Mpi66PrMe
-QxVnZumUcTpWPYgkb6GwLeNVz8JIIePEx3ach14KypdEIXW9R6Gi1jQo9PFQTqnhwEdTdE+OtsWUaHta
-jKcc68sSg0LLlHRJJtkKtsbBWwVDUnQbL4OCDNpyw96ziva8RN90vP1arlPmzftox1+PJ93JlEMI3HRT
-lU5Bmu5dUQ9/bvpnxtUaJFacIJLtDE6VsvIBgzilLeRCEvMpCLdFO6F9YJaVKTeqZNuXbizzWcqrROqN
-ChOXpK3Vwz89lFW/zJlJBn4P9pf/8nuf+yydlAfG4VsbDhLskluvFBJvpRGmaks6dBU8AIWqY1N1NHWo
-NpvVdg5c9aDWf3WnT5WCP9qClWblwChUnMf2QL9QQAb2SRwK1ohT4NYXYxfjSfq7Tq7U9egp8+ix87Er
-h4XLpufO2I+H9uzcaTh3lGGo7TNOkObHzme+lI3Bc36rTX6rPZQnY/5jVPYG7FRaa6ZIXYc1pU2UxpnH
-GqlnCv5n1THVAqIsT7pNEMLiBCElUovx1OOHmbEe388/3W5q4v/iTdXhAPGeA99n+ZQzZGv0YPLthyvK
-cTJJJI6HsnbW1+n63nZVGh873WFVtYj2/8wHHOCpzOeDCV+w5tiXrTGhMyUwdPs98r3TWgNCu8Ooamt6
-jE+PeSciH4kaKcmlmx18WdO9RPc0FsrKQ7aNdcvVOv3Xbq+h6BaeKVxe3MJVhTYQeR+Hio1wdmSz1/C5
-gH2ItOLU8J1SNvsXt2gtuiPT/sZ2qcfGIMd4m2jKH3738myE4K5kFOngxQrWSouVmIhjHytjRUu0nW1g
-wjD1rNvjqodpphGJ86prXYsWwjrcOk483x1ZqdXuYMPZi51C0wltwzYOnVHskxWcZ9NxHJTRfV3nG0dl
-liuOWcEoCqEYFzSZBJu0VJ38drS26h9+mDxBA0+AU6VSUHoTdFiaixjJxGPd47m3JVc9SXM9pbii12ly
-+KyFQQxZVBKI8ZzFF0Wh5GeLFj2luOTaqWU90XEzYQMF2ZnQi1ZI31ImXSs4eiPUH1j7fWEWUmob+pY0
-NFtLB99Ecdi1tgTuQsyyBB6FSsrnUHN9opuD08Dr2R5pwhfzbemrqCBQ9ADo+6nUp45CH/qzlxLF06MW
-6bKIViBTknAFiKtk9mez8uRMVUFLzgWrjSPZ35s/f3oc3KlyR+VWd9fTVN/Vduu7wV6G3sTv6QapZci+
-LLssBFWC/vP02FWq6bLsUice+3BswQfoFDBm81BEjzfr/zLFrG4HY5/aiHsS1fPyTwfz94f94FDJTjiu
-4EqNT1x0tQQuaWUwFuAylEOz3yWMQWHIEoQzCedlFBpVC3OmJMT5SStO1GNcQmVwO/lSvHKmqoUSNxp1
-CQnfZVl0Q0np9rL8Me6bUIuRqp5kpZjsf3QIzTPo6AiSEpVallBiJK1U1+dRSyafkGonjtyWmvUoSmsa
-upxrorjpiZDHOfSk8gIOiQzWe9hpy/ijYt9vyoij9cpoOhfYyiZoopbcsMtRl7WJHgGcOvVzEV5CBddf
-yjnUWPVT2Y6QMOKmJ5Cy2f6J6p8o+yceXv3IQ7n6TDvXpCxV6jZ4ZdBWoHbLR2OU9iMj6ibKqzLvkVj5
-jjQ2nBMQrbB5X86OTaUeHgw9qSzKrGIlZqbQdKOrskjDJpxXSegVmh0yRs6vRTdmFXBU3BAIbxRyNXFr
-w15MEpegVWlNgVkFJpVaqn6pLbqPGNu9Bmu7NovS2KyhZG23LpC1XUU2LbTZ73b97Polq+jJBieKrKF3
-fKDq3Ol6YxOiVLTE7W3+7k9mWf3VH5V7uGk5LRbm77wfrNzRlE3FWs2DRh5r7gQCH9bujQVKV5xnprGS
-uHHEgNe8QQyMOGW1iZJA981OSbRZR2joTiY2cmI0IKb8LqBj22qzkqpOSxXqWBmg0pat3CyGCvx9PafJ
-a8YX8UFlFDKBsUpbU1YgGsFRzZvOuUTa2I5165nTYjyk7jpFx9iw13FSFjWt0CGbgnhsrmYfC8vQ/Sid
-srDtVuAXrad+doek61byWDhBScBrM6o1lgNrVntH1UonEmUR/DUVsjDqZIUCyBF8QlHOIEXKuien3EgK
-ozTUe9mYA0mkzEeWcdTt9q+424+5NUh9QXJceGi1WZbQIEpWWngk4jQWZZUC54hHInpDTz59MeVFNylh
-PhrF/vdXtiwVAFlUjBVtWO9b112O5RzEmJAMjQCF0bdQdUeT87QvRdKdjZ3B1IFfUtHXE/Q/uvUQNBGb
-lzuHkZ4bhNrFIGT652ve5FNFAF7Hd+dntXp81T5aYZyBAglFzq2sIWF3q4OK0KadGf1svdOudx0x2bT/
-vR8N3AHvOTuw7b1S+e7Cxb2rs17B68oIvpYjT6zynRIPq4zZW8GzExjJ6w+E/QveyLS38dLESwtLiXTV
-w9lBvJpm4/YGb+FZ8263T9vebz46ekb14LV6ezTKeweDno/GuwdD94GbDb/9jOTgtdef3SZgjjtTZASc
-7yiY8n0FU76jYMpHCqbH+su2hA6aUiKI5JsYki/XEHp/Sw7oiF/QkXxcUSkfUVS+3cb9ZVpyREf9mxkU
-1yJ/mXbQB2enfQsj0t/eEPrZ5yZ9KwvHG0Jff26+2pDQ2/Y9+Wb3vfbxlrSP7Xvfyhqfm82XGRH6V7TG
-2xfY9drPYU/8MfJU1+VaKLe2lMZUGl8plhsU0U3F4tbWPj0S266Qbz1RaD3DAbBPFr87H96XkHqm1F/q
-zRsq1Tq1Qm4XpTcUSo3N+1HvNX3SjrqRvRsjZuLY08INLRlXFgQoP11aDlKh8uvLvLjq2SzYYeSxivrF
-lvGid1I7SW12YWbvl+PQraRW5TX5ODwUjMRDQTO0OcEvtJD5KzYrpI2ZFdjkaKUuJHVa5tcXa497uUIS
-2tzN7GgAy77DQm3Y1tHh7NouCrLiCrIEbZnp9uBPkrq5FbSHT1W2KSphj9fmwYY3Dq2z3xw0rUDf/0RR
-u4ZhutPeTTdprSGOadNWkjUOjSfuffMLc931x7TtL8Fz1KrSLcSUtpJWuOKYkraYunm6aJj/ToaJpm72
-Cxu2P90ub3CtpD9QOOqjnN3gHXBVt7A/Q1dQ/ukxS6GhguahwqOV0+6Z+KpC5BV9CD1TiLRypVu6pTQ5
-1Jk01HKkVLmpUzk1aItuKFwOiLb+jWi/RaJNUqi7W9XnEeUzekuf0hu9iZ6OSGZqsksxHeyJPy7u84f0
-Dj1GGq2cudaV9TCqQtIEboeYdfgbsJOpHsewiQQRzClcb+2D3Whd0qYPrZxd4o1ZAgX0RX8PD93dvevx
-WQjf27QV4q4sta6gTxg0uWIZlU3bGkRsIrlSUUq3ISw2QAWmRhu50vyqNH9uj0sj3F/dAU8HQOKGz3A5
-nM6+W7Wl+HweE91dqrujGT6gusfmdH9vs44f22fBLL7eAj0uyt0W5I+1IB8px6WA5T1ugHyoATRNCJ+5
-9Me1+YVbXlOiSC1TKEtC1Ics2pg1DxiKRqaQht6ShVOmHlfdxHMnjrptxrKEDi+lvOjCWKWYD9egMNLC
-iC0qoifRqk8SwU1stUCboH8gPgUlYPa2QBx4AnslQSte/CtrgPMsm2eyNQAN40VrSyu2dW2FNz2g7cE6
-x7TCqIzvanMD+q9/FJThgnpk1QZwRi1WSbLvhM5rqBEuvAxGG03T/odEZdVPZ5y8pE0XBAAVptQRxiMI
-49FtJiWq1NJiDxchuJJkPcf0zUQ2strl3Kgy9iT9GqdEocdFW5kLKXOENhbiBT2QFcOsfTKPp86F9DQk
-MzJTqGkLFc4uusVdruZD/a1JH9j9cLmyRwckJvHa+UHXQYpxzXoyNDNr6o4u8KfUGy+jSm7wdoE5xtsc
-klZwNp9G+D/rXUS/wRw6jDPYnUWtUefpLv70qJUrj3mGz2pEJFu4tHELl6a7GIoOhb36LezfpLPuf0Or
-TdOc1NxO0nYbSrn9qN1+NG4+OpiQqTB3168qkWrsFvTVTTayQV9q1LWFZ6/7bimLXO7xyHK4cyadzAQ/
-5o9VVJq16FVnsqPxkBcm6J2/mZQTLqRjU47m1vJhh2fmAy7olMuVCdJ7f6TtKIeznp7v9A3BAZEuTNEV
-q3TFQV3xVe9jse5xWNt+cXl4eWPWMv3dpa1gqqLzVBYx8IyxCheGa7+id/FeqMqvrp+H+dbTY3FZ9Pho
-b+XEhxvavbP/zhJ6z9EvUz2kezLFUyh64GambG4fgj8C/tJHObExkxLHAqoHT1NlCTJ1i/3psXAxh0dp
-VgIBp9JQInA7pNHyUhC5p67MJZ54sDkYi5Xh3Y4u0GolbQnMoPDjrBSS6O+mv1ytwHyuj/VduFG5N+BD
-0W84i3dLYmHl8vI9SkjHE00n89N4z1Qff5hb0SPozO/RKUj9297xM+4dfH/zSM19xuok8D7JW+D3iWPv
-dfLWAvM5IyLKiLs6aad2lttUQQdk0f5GFj8DWdQkNDgv+8T6vPqMb2ESwSno3AX5mI5SmlU0eQuni83I
-ZJnUY8QDkqLNKOxox5g6CJkqiLYTuXFUFt0QuC3cK4VaBL6OmZq5imfxmBgK2YJMVADVqYLIFFKzYD7o
-mVjQ8kYZ0YQQrRATqJIUk9UlhRrlsXAvpJ9LQEhA1Ezb0Kr29Nhb353V6j5qQxvs8fJL6FA3RUOtYEJw
-LAKimi5letFN9m66NL0a1MW8vXm5q7j9YSw4o2FTgjM4B75u7NW+Ualtmbxt3jS0lokNduLIziHjb+v4
-59jec6QucRGEkYy4unMugzGysNekjNJ8js0b3rvdfXdZSOozZand2LzEwaSmKXaP3IhjjQvip1IxG9hF
-NgqcziFVuaN3rrIjMczwIK0E2gdOiMoa1CoFSZ1afddUvGsmTlN7+UwLTF+BEFceCJfPFpRwNDn8eZPD
-xzAZWuDG5GQojHSbJMSJpEFgBd46OSc3SejovmsQHnVhUjU2h96lrl+D1RlS7sRAIALWUbpY12VHEQCB
-iHLK1dp9hVUzMXD00XWU3sMepycWwoj4r4TAztAqScbvaqN2SI1Hkz71DLyr5eNH9fLx/Yp5jrtfdr/n
-itwP/bL7bU/kvivmX3VFTm+Rnu8NwrFS6PYATPY7f+jr+c7nb9sltPPta3a+feXOl6/Z+fKVO5++ZufT
-1+v8wf6Ydn8lU2qb+1ZPFAoM/tkMDogc1TsO/tJxNsBXoYwNcY6cGR5kboa+0s2HK+W8mxvqHWXveGau
-uFgr8HadJoaLvn+7WAFcS5+9Qxfsh11/5EG3cHjdg+TzDJK/QEekQ5V+ytdHC98jLd360bSzXt07IYqs
-mSINCoLQ/wotgiSV0cCYqDSmZyeMZxj/iljGMbZgfhOlO1pBsJBEA+CpwCcAI1KaBbX5I04GGkTCmD3E
-TieEtm5WlovXrX+sobtfB6oCV1AMcRBgecY8KY8K8ZIziZxz6qsIlUJdaFgc56CsjVKRl3IincycVIoz
-CIsOFxnYQBzTgpUytMIKLxoxeBzOm/3dVo4J3jeJuvv0dToK60rluSxWJtMhE3aHh3068cZJP65/Ox6J
-SAMczAd2Dnub9XNGxo6/Y4yewTpJNgAK/TXm0dk2e+xrJyHE7g2tofvN4Wp4KCINvbZO21DoSOjwZFj1
-RCxihWtc4fiUKdQNwd7nkMEThm5QUdlCL+vJwILMBylT3ZTE89kKd9I/Nr3/9Ni/ZOX0ovYDopj61yRM
-nfMipRM3mRGGYjGNumqeuVL4PWXuCZztfC9Ai41oSs7vty5cgd1VwB3ozl1rN0gjie5uCYMihbYo156y
-ubA2c5fFz7K7YS3z4qoQ7aX8/Ymp2Uwr08grYECBogIer5ZKmex986JtW4PLLQ9t7RL84pMC9trFI7cd
-wUr8GFP7lUxXq9U4YKDEMvZ1juuAGmKQ/sqw5hVgKFAQuDuQKeO0NLwxeA3mTFCp0hJkkKQJLvpgUbFb
-nuilDehk1IREhjY1CbwMxVwiOlAfiPs2TAOmtcnaCAG8hwzEVDqOqlJ1zCcV4dAxNHVlnK5tEC7SqDby
-xXxPgMOzhQGPRoid7aqfp5CuOrmELCAlYOMO4qoid1+Z9bBSUmRK0tdIGDTrSd2C7aJ6RFWymOSxAc5m
-0frSpZfvpB36lRPPB2iHnhHPQzHyqQ/lMwhoXLmqDPeoCDOW1a/ys3sVvJaBsD09qlTvYfOzFC7EHUvO
-aT5LswJ9hFDhMoNs4dgS3FHGCqLyfvAseYPDDL6d7XUHghvP9LUyG6md1uM4nuet54X1+2kWfjEochXz
-u78m10/SZZBmjPHr3ZAb3ZDbz5oZsG+85EO/N+LYOybHC5jcs+9d6n2loc8etvlQ5kO5PBxHb146+dqr
-+UZnwCt6jYOtvsE2Ya/chm+Tf2KwfWAcYTRkvvbgkhiX2oVC7fIaFFWu2GzyqzhX+mLtsjDdhjY85QZQ
-JENaUYFhtR3UxTN2B6vGL6Q5vviR6eX+fL5hEhpfnLP4UB7N8hW63T/8rVKp6HGSKUtdEDbWTPv6OYNK
-P9uoTik/eU9PXcra9GBp+o1yDsO9yw2WM+S0BO3wbXSzcYB3MKPEICA6JvOL6qZf23Rr0xduC8/jnmqk
-H2OGtEJmjATq88JJ2ZHxJhjoH2POV6icNnp3PGSYS1xCLpFyznYRcoZumN8THJcviONiLQg55938MFx5
-PGZMWesUUmQwU27dLQuE5NhX451sGMsmhgJ20UzhRnWM+bIA1ravgE+jRrksoSdC5YzZH3PuhyswuJnz
-+bUJXHga1/DV2UYudQ3ZeBN/sM2yAOugqwrm+/vr/ra9vL9bX7O+f6Jcr4fKdb6tXD8MHnpblMmbvkiX
-T9ZDZJFe/PQq8dJnt4OH3RAepiXcsz6kaQl39N26Lz/od5DVwF/zt8Bwui08uOX+gGLrM/Q19yorlWA0
-3jGnm61+BPSEMjYphkK8AC8No3LktFzv+wnrKX7kKHxRJr7ir4wGCHUlKoMb0h2+bxk41E302rqjD688
-g/W0f4HXovxCS3ENw9Vmpo7DbFYjCYBuX19fF7JrXKKIlQjX15ci9rka05oRWpBg/U46BglZNfDrt/VX
-f5IW3Oxf3Dqa390NqTMxj7GkDu1jXyUCGLzq3iArcKAHpXqZwDY9BhfkSTmHWk8hczvXupZkZ1JOMKh2
-RLl1lTzkFNLgNSAtRtZvCQUxO6vJknVarg0ZtW0ZVLQVHUL7SqoFX6lAsc1UZGWAtUI/r42LlLRlDViY
-Y6hIW08jPT1mrlSETylHWgzLrhyugCkYt5KoZl4LBDacEgj7q77hFaNW3d6Aahhy9HsWHmgrNPoB0xu0
-v838klTsK8ZCQPlrxwCM1NX2S6DSBgOE5yiG563yRh+6tJotrSRzZQHOuIt+RbJhoM3cMZI3kw6H7UCu
-MQa0HQz34p6b58a0ZARPrwVo5rbNiCHfdcxSRxdH3qBPFYu6CoXJEcGHwRl2Cs3dAiZAuQVM6nQZew5O
-K421QTsIiOassmjZwXndWtB3YwG6alZ3rJlkXUJYpvbqFJg7PEftE3CL5bp2M2AzQOq3zPY51zigJo+L
-dITG6tb8cT5ERv4x5ikF99aMFxXTqvdkBzyrqG9uEzna7C4BknhPQ4V5xMokCnVsUAPUcxjNpnq0lTss
-CCrqjL5wS9QTvLEa2eJnKHqNi/AjJrlVfwNwG3GEF1y/wBua9641sJxLO2U54mJKvHZ+Hg6ZDXYoGOA0
-eGOjW483D8rwsBKZThg+zSRbSI2i4yLslnLBMg4J7i3RdDOL3pErLQLHCMuKsk62n9uWtyEzwMaMjAPn
-XuIEXbCERA1rAZ8P4u0J7s+is7MZ4r0twH1BByB0A/Y8+ZGwS+S7J4Cy3x3eeosuZ938m11xbadWEMoS
-Wln81tEQ72Em/GWHuH75Ia4//xBzbaScOKJZWSw23EJ3it1lKYv/IsKpI77paIRfIsDUe7LA/bDdei9i
-5R7ISDeNxocbYszScUvegKDWP96S/tEhgasPf4Eh4XsQMPw+wJOSrqXKtyD48McRfN5GSV9o2D5CSeKk
-9C2MybdPSvlahV7iYT2/wIblOJ33GvKldiw6boueCuleW37+LevpUSn6zoi8gcroo0vviJDKi8Ptl9oK
-fh2bku9J38agfPu7Un1JTF9kuX9g1NJsyS/DoNCbmLavPyT9i40IfYyky7cyIm9kYumX4WK/hRFxqwd9
-Vb7+YK+55AW4WKUvZmkDe/hm9qHii658vCHlY4tOVED+REKe7gNiwjDaWxxD5IPtTR8eOPfdoK8qdB9Q
-Yv/WTr1xsCS+FTpkB/a/8nORAzXNflp8fYr89gly7HYI0ze2EteknymJgsgmQqGkXcUH7RtuWUZYEc+E
-h3f0lgg0ciW5ZTIx4c8Nz9yhJiHpiV/4Lwx2B02tU0GMVLjgbxkWJHwD3gJJG6jDyRY76Y4JiU1tiGSl
-+lLAW3qPLXlY4rO/xEi2lnjDw+s0Gi21RXIj7rIIXBITI8cKl7rI0Jf6IqlSkN7hsDU9k7JQ79nsmwNu
-jsgiNfLMojvKFoan4MOVXaRBAwmnBqwGuH80QDtqDoLy6phBwwgRiHtOq8pbMTAO7hb9BxMSBSl9RQoX
-A45EphYzIDHcNNpA1qpMocoaBsLfy8zCi1xhZoEMgw0pErYmlr4hqxghd3h1F8EaSTJyoXZKmbhqlwdM
-CVk2To1g42EELqT69FhhsRktrwHFomfkolALbcGw/tpW90xd82nery0l7p4NC1dtt8YcrZP6rwKrp6Vz
-L/mLxmQfjdmueCqZWEbRPTA0IaM+hhW3wU02brBkcd+6R3G0rRlY48zrKtXxgTl5ck74PbmnkM6mPWXe
-kAawI7mYWQkw5Db8aQ0904yMgPWSkW2twXQ1hp4iMG9wNatjHbYp1BnTgbReWx2WvvehbJ1nOq+tW2av
-QVurHgjhkVJJN4JMjXUfR7pCsrzc7paQPZWtuPkUGcoYhg8bayyQpJusbBkPHBwWyw8Bsj7CBDAI3Q5F
-lkopG1ZBgCuCTNvdWEMBUoJOjhs9zRKMSBpP+VqpD6okjVcDMKjEvIZO7F45h1t2eZYGOHJaOOreVLQy
-KiRopCUMDQwHDzgBWL/Ykn7DrtSQTA65aRfLKr4EGIuZr2X3HONaYIaNnuSTewN+7imk2u8FnpU7gWfl
-KPAMB8NMu5OSWGi17kfDQpGKGdRsWxqy54LRMtGf0zAzK+aqtB1pQMtcbm6XsjiiBvWYlpCqkP6jbBFT
-KHEphbjFJRXlCQ43uD2gZQ6lhbAjsydcJRBihlNtlJ9zHDGGrDwHx3QZQnk2hO2LDiE9dsnEsaUl6Xmc
-Ir+Cs27QVVlpMFZ4z9UZ554tdzrzWWLCqmFz0KC4hdZ3H7Mhe4VVGbkN525pK0cqgxJyAW/IyN/Zzird
-PjbsbVictXnS+2oOFjlSfihnJEpF4rOHPZ+s+5IcTXr7ZNJLXKu5MejOn3ZgeV0/efys66eOy/IRpEn/
-Raa+5kQ1jSWBvyrPAOkddJEjwEz5KgQKbjWTKIpylyXJJcJpetihniVI7iS5X4Ux6bgYpL5WbnV/+l1D
-jHFifFOKgTolzMSdxss0wpeUvVKjpScGXEN+BUfSgyQg7rd0yVVsmYpn7uN4a6H48wKX3uYewuYfrPW5
-CAcVVnuZoTnPDM17K19zq36e59hAJclTNJ+4lUu0Q7AOXPIlW7bkWUX5JIuBpTrQZ9FAOBN59mXUhUGx
-vfDzwS3reGnavYvtnfqSq3JyaWXlJQxXj+PGnoXS4hF2GdLvbsHLmX961j01p/4FFArvkjVb/Ezlx1v6
-/XONzZcfQvoiY7i78OCUjCV+se4NodwjoJ05g7lLiHlPZZnP3j8JR12aYqtkPf5aXjqC40Y391DGxifV
-HHykLmF/HrwA3P1wCmMRSr8eX/Fk9FbkqCHPfC7iG7Au+8dT8/T7+Q9/uZbQu0gyP/MqK/FNrekfGJc7
-1pejtpYrX4jKBjlgn7GkvO4ezBE0H5zoISMVY9js19ZLmAvGEgb7q1vwyrT2GRpU0lHBK3J90Y6jztRd
-uoLCJ8oCbdUN2AqAxsd7M7PAdBX4aHKWIJRT066pEAuNgu0YnuWY3aF45jm+lNcxK+XlUIxLcTTBdJHP
-BuThMiQPZckV/q6RrGrMYaS9hm2vdpbkq4KfTeCTS+6fobX/eDaOj9POMl8/v7fneyroXlUy4aVECDa+
-Kgr5/l634MnSWXuWZVWhPZoTJjHbOSAV7/vrerRU4unr/vTIBVGov5iv4rtGZPfE7oCqWkJCYoSSlv0i
-WHLu0ZdQilApstjv0WLulyDXmqnkpi/rWCvp8uik9aWSCF+YF/7gqF7+tSsuyyVW+tmMhqspvcblAajd
-vYI7BV0R0BX9MPiqcqfQMzK9UKnRV7EApdsFJgFeiHK7kCq6cUEnerXM0azLr3zWZyZWi1v7/Mmk64kq
-8d3zcDTGv3aTQANs12A9XcQMKhbvOa7QX8IEjgkDeFN4hbarkga3BMgI/w+xcP67PK8peFWvlpw14Zl/
-wEJQx8SvuaDRvFooKw1oBfo/VO6F9n7sQDivlvH3r/t6RAH5V04BJVeqkpbQM/WyXnRb1HY00yVwGqT/
-TGhP6NM3/EuLZFGuj9ddf0LB0z32JbVCqZlKzZVewTKaWK1e6fVH270UkL382ge9dxpKZAKMlTI8jEV5
-aUtQV7qnBQzZ4q/1g2CvkC7PI/EFPydOvMIqpBw/81r1AQwq0q1J3QUC3llzC/yVbnY0HYJGIWXHMVe5
-mjx8x4TqsSuwsfriHnWzkwnG0iIrTQmYq9ayp6gBQnJ0xLYTt7TanFHfkonnDYF5rg7WMSTPFWSIUT2R
-xxCWtoUyYLvDsGUAapdBJjE3y/lwP8ibPxzk/VrE9QHh/upTNuwxae8ZW/oCg4smtD3zzlELDie3FQcA
-epEJ9PNqSbd78Ukn6Bn8Rbmkd/hMGtrzQr4xj+DIwDsMottvv1iYPcwe905W6t0JMPV15JbBl65r3sL1
-R605H8lqODJZekd8DVVPg3m0rAF9b9B7ErzqizpSMdFVhdvVl87WhRuJFWOkPtI5cO0f6mfta0CeZDhF
-DCS/25AOj3mc9PFHpqv2VSuunbzGzb9xtna/PjTHdFb7Wjvp9sFDt++W9co+9r6pqJ3gKFER4621bcEq
-P1of/W95E36GvAmtDOqSFgfpuWEFZR0WlrzMi1sFJ9bPDWOpvq7V7BXeLFkzVd0SXrOqXlfidXxSCO+/
-ani97or15GWZIyr8W/aOn4UKu1DrfeEI/596i7ySwE2Id8tzN6LpsMRHIfhovGLx1YqtXpdk9rz1S9MN
-ciw8GvFon0cOI/7/jxwsUVCkimYXWkpvVLpWEct+t5anx1ELdR5LKD2T/rMia2wVEj2VqrwlxwMAict8
-62gupjo0DSpdliJCWdrKyE4kPukmHtVzkMGnUo610fIG//cSUddejnetuQnKYbYjWEMCEjtt+PeoN7ua
-LwGLZqA/wTs0s4sl2TwJmX0nzA/BJ0P2pPAA+ahn7bO2+Diz5ZF5TrtrvdUCVq0D+xypqseuUOtCzJWX
-rHxeETnItyWDz2jtB62fBXgTg2/k3NKWeENmzq37KbdG/tzkidoKbcStzHL7gNzKLDfbeSOznI5WKe+y
-fIxyTWv8xWhtRRZo7UVHMnk93Q3fSKtS6X84hx/d1fehrADBVymAR7pAXrWNtRmpbFwbDSBywRaW5SxI
-p/R59Fiv7X7yhoCS9KojRnYPi5Fp5CVIHqT/vHo8zSPIXKoaQdHGfdGXJA+vBPZWmFzjtHfCEmeOsPF+
-2Er6xGTodttlIKH2/B55CmKC3xk84S5nL05Q7nby9kvHvI6V3bk4zhwD4vqnozFvuxlMX4XC9kt88ssN
-yYsRMTCK+mWIg35e6rg57Bx/7VbCFrNZCSX2NUDfaVtj9v0z5YeyQLEaZQW0uBsPEljW1C7Wouz60jap
-RNi1jVqBvS9IK2AaSN16v23dI8fdx6raVrYO5DWkIPAPyYvOHZztORLS86eFARzOHUEFnAihTXkVmEYo
-IURhZVZ+JQ9KBS+gvOBeIUkrK6ucOrVVErk2t+qR0wg4YYsdZFi5Yq5e2UCjWNKif+WVKQzql0XVHPrp
-VPkwXw59VtKNTyepmYvxBSIc+NiGYnWgv3tvIiB0h5GbhNLWqK+RMg0qskDb19cCkNpBuaxCiSB+JEu9
-YiBscKiGywVsoOIpNAc8NdpYAtcK+MgpUps3LAuFchIeE+WT2iZA+CzKJow1JEf4RKgIOO2nx1wTDaSK
-aM0Q9G17rlQ8M6csBtmX0wpY+UjiuKLIr5lmf81z1hPFWeJ9PcReaGc/TVKfPTO8h4hmT1l/Z95Tg1v4
-6RBzj2Pa3doqQnri2inRxKoF2TaEyJD5yNt5kQSwhyYViW1xiEAxnMKpHfWEgUrudV7idWyD0UAgIStZ
-SGAEbv4aKhJOAt+W15ARhSMWZoAEfFe5fHKEUgapLIsFJQTJhqTmhx8DSXONVDNlURkPbuLAl8yFNsTe
-lb7yABRgoawERxUW0lIsbSoUsI2YWRc1p7hp65T0VhWymHqk3jeYrqoODqIZc1FmDaF9G8ugxBvDKz/p
-wSqUHS5Y5aE4utKW7jvoWZqnDQJPdY9oricFRqOe6mK2siUgIiatoShTCrIQF1whZfZ9SjqsWt0cx5hg
-WNJCmGKVTKtFv/a6CfjcxHMBY7KLdhhAoXqQN5yZYD4b1niGoMdr04OFbLbr2LQ5DZGPAyPYq8VJWuRT
-AcxmjchQM46IdbdEl6QnI6+IK63No1CQm8jZDrYzLe8wmtk44egZkgxn1oBBq8WghBSV2KYKLs1cl2li
-fe4X2eJpsxgIXzYmTP/O+sxmfGySKGkf9YRRwkPQZsRAWdxW34ogjtMWWhmb4Vzm8eBptZX8MqaowrxS
-O4XeZrQNIj2DAQjnMmOV19AsnCwgvEWXUGJy6WWslQB52VTKM9RTEt4yU84WagfylT06BzE/FsULP12n
-KuCZWgqO6CCpgfvYfOVFDz0NI20BOg8dLINcZkT/GhZ8jzhx2eiMs3YCFKcyKtlpClh46EX2kJ/iok2N
-W64e8Yql1x8sFkOH4mIHsmiauAEiV3eibNvY2MCIrgiDxmTpSkVkRkHQj1UdUqVmAnmzvKCFeiHxvFTW
-YtF6sbIgyqGvlS1sUsox4/LM2h9HXpFbjqtx0OhChhV9YzeRy9xiwlA6xk+W/ZEyGhZKdLnKyR96ECni
-sWcV4briML+mNLk3wf+QvQQK4DV7C5WNWf+wT84KEQ+7zV80tdsapFFom+9e1eefsFEQBAH23qnLMPd9
-g+ln2dDAFbjZDdic7HFVuqN1PbQQ+qlHSu92ob+9kyAavgyz6eS1g++YAaQOLrwifA0R4xXx9vmimOAJ
-LDwIdnswl6mCA2yM6N4um8V2dYbkjqSCG6c0o1rJ/1D2X9ckcsjIXH4t2m82mOLVIgUG5TmumZUih6XK
-DVxVgBhPjzl3atKWgJRD1X1rywRGh+eFATHr+8hq3mnAFFenriN5sPEG6GpuS1IJYTU/cZzIyU6i6per
-bla6X3Xd6KFKtbiGThI3k1XkaCHseFlDANiR+6JrE+5UDHmEdQWSsxPZMHjrFXIxj83SADLAeoVanYqg
-V0nvTZS3VuPgMOOLikLCJ8796XHkTK3FBZAJyqjrBo2kSxi6IX7IcAFJogZPauEOHrpH6daoAy1IHz38
-SN4MnUA7vORK8CdpBIxjFUHaNpQT4VskUTIhujyCX3l6TBypw0b0dnJmyJKEYzbC+mHBjhno6UB8ZjvN
-9JsLUibCLG2BfNOmzDs+N1RZt9YcXRbdz0TALMS8iFCvS0iUP0ywU5ODBRjjUsFh1roCRZ1yJ5wLuXt4
-gj5XjrdC3kxiorEBShjiuRY7KRXkFfjDBvlNKUPsq/XpkUsB4krK5Xw7rcuxLbsVfX0JORdqkp4e86hw
-OpE4PammYmHqFeCxJUUI+Spkd6R0/UC7sgTkXTuxqxfCRb+gFUkRrUYiuYJh6hcOxno3e7umcq04JaOy
-V3lzNukcUhpLqklHt8y4Fl13yXJtamHE3ZmhInrq+2pRvoRIjlSgajoHqWlFqMhQ2RDCYTqHmncdl3Jc
-w7QjS5AIPsAugsqy4OeGa2nANMo443VEcuimnc5Sk33SVGKerl8bYz5zezZ+Jn+RjeeUCgpHb5OUc0q6
-yVRRiuZYNx2anI+GdLxU/t4L+ihI8XoYtwD3dH6WdvY180k8l2Ng1PvtOKzCxWWHun7FdEKfGUzRiqFN
-OrTrRGl6nuIi3X5Ubj9qtx+N2492bcDrzci3m5FjpFvtyLfbkW+3Q263Q263Q24Ph9xuxuuPblM571En
-DO/RWhZTQKebcW/78+DK9j3m6yokyeIwxhKUbdd/ntnWPjsg5yRxLHWCgGW+Wj8vlPVPj0WPML5yTF8C
-Iy+SnILEcTcs96ARlqotqYz1BcdJ0MSupytPD/aFTUiVE5dx0NuDmeWrpHkzaO3D+XLKUOZApdp0lTHx
-3Rl0jto/Ndb8VtupeFLusxxuff0L13fZStNl/BKF1mZGqz7cShBGNefuPjZlq0JrztQWPQFbnvZPnP72
-SJ/0QVqydOp2oGo9KmhbxdAwnWfqRm33OUg7g03d2PjADXzhxqD8zQTaFD1S0ACEwD9u2bhz2ZQDzvov
-7Dw7rEiaWmYPyuRmqXFYx3ErlPomDiMU3fJadARP6XgjStdBxJk96C3wGJdoN1xcQuz0WSlMpfBRzVfh
-yUytjwWpwyywzl/3OLqx7B/wTx7VW3ZQ0ezQebneMe7zJSRMrsJkbQYW3S0mo+PhtMnSsOkve4H5/BUD
-H8LWcuRTSF23qlRo9Lzs1R5YRxHwlpWEelkEkbzmH30Hx6CUae5ytwlryeYtW2aLbYNEN4zSDtf+LutW
-W6ojrzBHAsSPYXiVtiBpWZVTH3WVTCaLQbGuPcyyBzPnpjKfMTkAkrKk9Q4xdQo1pUXFEP1USeVgErcD
-iMvpLX7bC2W74Xri+8y0WL2vAt5RDWWm44mQKi2cWjlvplH5mQyy1+X7Gu/72z2Rg9tzIIpLBtorfNjb
-qdPS4Vl0QuZdB8G2JD571f14H5mSkNiurN1WCvC8wPfbpHLTnlBTt+bZHo8Y8KywxTJLGYhlaLN1wLJs
-3uqjZu4eomC7PmeR1vhL7CL6lS++rmVymyNV6jU7DyXeacMdmcvMtQJhL7NrOS44AFegAWcuDJRUFbMu
-8mvcz7eJdDLrtCqNb+Ps5G+/y3xuutT9xR3NBMJcHsgSzQCxvAFUcDQYl2RMdaj8sARuQi2J7lWS4P1v
-9sPUoWo7hYrk4bZ0uzELWhAMZBMKLY0Tc+rOlUz+LN6D7eI7sF0vWMeXuHdzUV24vu3CC3oC3ReJoNEu
-eprAgK88PBq6nTdkR2Q4xkZ5577cj/m+aPLWEmozpVNIfclIvVdX1xp6Yrs52vkU2in0y/4LR5Y8rWl6
-ShVKqcG6lQor43SuwC8jJliWzT7owVQVolFc0IJiuc5032zF+MA1ZDKzvYXsZapI5Mj2BzK7MdLhubqg
-GhXnusGSqRsgV6PndjQhaXd0TPuJjS6axlZHzqhjgUNTaqtnrmNoGGUCgcJmbQqqtrDpSdOJ28pD5zft
-s+uyVprJC4FLDA8EaOCSY4pUV4puFpskeEfmpHAxa88akIcQQLjFbXiyhd4fZubYlnYvD9hT4XFp7goL
-hpBw+naYiKWbDTTOHWVLyVU31iluaYFequkO0xq1SpzjpoPRAVlJugtU4oFUrEkMEZOhjATUy6osrM6W
-UPC8mHpdV+jHdMtMtpLHnsUQIEkIM7WLNGB5PprWyTrrCh65LiKZWrfsvCJ6aMGhS1sMNN6y7BdhPptl
-w3w5A4Y1L1qMuEe7CLiCz5CXDa3ry/ZuypStdlQ+L/x+8HLzNa9mr9e/dNTV8kKDeipxzRE7ntOYHqs9
-ux5vCdIBiX9K0m8X9H21mMqyoSdQGmdzioU/Rp9EnD1S1sMbQZNZVOAPtSJVnVKXngO8amHd0cHsdXck
-L83xDhOigGtaOCsLWJehxNRXTiSdZqwtcCYlPpH1mnEGRWyVStYFVwkuNbo3Lwx7NJJd5kVKpNTKWccg
-5BKXEkmknOE1VeKbk5iUj0PllHdhubA8D8jVfilfBcP6jFmek7Ps8wb3t0E2n5cpzuZeYt6LkxROQakD
-lMKdnGJ2779b74DrMvOGTZCbEHJfgs3jEnxecZAAqVNne07+IKeGzckDnmVAOI3EFirwRI8TW+xrz9Bj
-TRUtWcJOYUhIoIS3TDJcJmWeCtzvlmAEd8r6t+iOYxR5CsJHmn25gJ5W6i3BRiAJsPdcgXtfFzZ3N488
-SGSx8PBud8sOQBMCC59SlLUjpSVopG8FYZXRREwAUgNCIQ3tVtVeMoWUhm7xNqzDtTSe1lKUmcjiW8CI
-1HkLPdHI5yIwazWaPKuYHc2T3Q7R+fLB6uMkIy36uZQGrRUzAL8cthNFVCRKPvuuXNSfEre51+RSwbXU
-k8MUGC9RiO1HtpDJMvxS3oAxDfcVS5tcKLeFcyXWg61X6ukX3hnoXVvDFCNzg7lciaSaOlcowGUoOR9i
-gkH3/cIaKnGr0znkHGzKwKz0iqTGsGl39klmg17pvtZ9goxMkhPNGroh3uNYT2PyIcxKbUpsSnypKzEu
-MLMLm8nWJRFK2b2vBMSttC1iqRmoPj2mpkd8wVwF7olWbnCVUsJs8HIUdNpdEzOQITJAxTcoV/CvuUXB
-6QkkU/03NzsOTyK8osPYR+MGX0deWDdC7ayMDI//Ps4FkNZ6AKaq/PYvRjb07hNlPAv4GXltsMMygLw2
-x1E5YxdI9hdifUKF+GSKVaeFM4gjwku3M3y0ECnT0wkUFaARm+33nUP0iCDOJuSnmsBmwMetpIVh448I
-YkoIYlIiht+oIfWwHkZn3c8i9Uyp65a2KbmUbGGAowkgrg0xaeZF1604QjOI/L981l3jHNo51LPtGpAl
-qyWSDnAJxL8OMOLEhCUDf8tidt7cCMyess91QbbrXi3HhtKKEkjHaXbWfU7vwetDp7ZCnDXeX89I2yI9
-IctXT2lzm4RSfAnJ/QH0qnfDZ12hZ9m8p1hBtiv4FQpOynocGm0HoxIoKzj1M/YhpyhX3Ygl4reQNqe+
-JThREhADRcUZJdVJvkwCEaia7gUEy+axoisFf8MpuJ50UbAyb7ZKNls0+zIynbatrS3M5fb0mGI3IL9J
-VouTmZMdohkaKSna3j/JdAPVOgmDnp24jdZB+FgADcvhOkE2b7ZgNl9A5GuKgepjbjFGvIvTtBG4EbvO
-6pFlIr0I5ukrQzMsUt4UzFMuwTzlKJinXIJ5yluCecq2X7wezFM8mGdwpppkCdK0eNqjYj0+N1wCdDm1
-E4p9JN5GcDilNpfBjM714NzZCmuEReaGO6G5nORXPgmlMnUI8g2tSJcwTg/09DjP/bk/9sBK2maQp86R
-tPKekCbM7dmmZg+c3uOmj6I3OaVf+QT0UpCXQMeWpJX1EmNLHvEbuNrjMJ8HC/q18dn2MGlO7RwMc+gd
-QWfSUMMelTyDkuU46CzlX/kM1FYcevReqJiOEHaQS/S40eo2Q7f3Wdqj+xG3bWHbc4Ztgj1me4ago+J3
-AkZxeoGWVyeagkXS5Kodx0giXsWiEHrekPHF/NmvLq8KhKv3wqU2v8zVCvhzew+v5Wrf3vaLy8PLG7OW
-p8cqTdkDvuIhTLSKVxyZuAu/ZVPT51Y8GWVQ3CkJChMEvvkmj1rydNa0MDWmXXrbzE22+yPlX2zrGbyb
-UAYALROySo20IQ5FFxqCpuBSia0SAUKs8oAgFYaPZdlM+ue82tq1TE6b54NS/tyd7QVxkBbclcxBI3VP
-5cZMa6PZaUD5eRCYBeKEBOWfkieST8HeZ0nglJRsTC0dXHMxD3uMtQypL5oBcyiTxhAfE4Q4uIbkZs3M
-Y1PeO2EvgqKf+owS8egQHZJhMYs2CTym5VqZsjjjMbDHZLFou5BcwF4RUJiUwULyHfIguDRtb9MIB/V5
-mgDUtp6ymUxWk4ohFJutylT2ULHzbk1gy9OF7UUow2FVkIak9LXqQgXDumo7DKsBabiMH/Q5TQ9lNZ9V
-+CxkCx4y4oV9smx8QTXXaVqF2Fyf0bYZY4zGCuIwOxhiGbSx2QWtCgQNVYLp2GK0ugXVzcCUlM1hXwem
-606S6ortOFvT3anMWpYnXiNbVI8RbWL3pIc+0uyIeZC0zVKZCZhYAqqjDSVP02lgi2TMrh+yfIxULmG9
-8EgXyKFU1oKxHr6XgD4lW5yVypmZSaLlf7CQxvj0WDnRcIQ5BMhn2bACDWI2edYyaFDKHifJ0bO9aaOI
-oQNEz/T3EshFFl64KWvqQbd5l0XkeA+ue45N3dFLW7lmGqJCFSB0pUCa6tDWB1GeqjCxVKWtPlxsspyF
-FsqiQ4pUivZI2swdyXpoVHiSD+w5Mzqgzo2T53Aq76DSG3O8RA0VStX9QACQFSOVMVb9DEcGmKcgZoFN
-yCx3pNn299/R6S9//dNT+OH35/Avv/2OReK1Y+Ko9x0T+b5j4idW7pduiHzkhmjmco6jznxBM/HRdOJt
-X7Cd9NGGVm1nme0s1+1M39B4ijYzzWYmbyYdUEt/6fM5DhU1t72xxrGCb3vVDNEi0gbDkdP9hgSO9ED5
-t5TC49AxIY2r9CK7k+mdqbjjC2VueaFMh+5LDmS9le84NN2Zp+KWr2cUj294ot7sjk8fp2u57xWVrxNE
-PcPbec3PyWFZeAy4ib4GD7NDx7wKpjIGfD69oiM/7RTf5FH8ZRBg9g59DgLMdWcO8YFqptyOQXVmA34J
-UB1vN32CqsOxMUnjxTpk/fGxuso8lT4rhfdLLzZHZPJGfDqoPtgv2nZAwLwHaepJP2RlI1/D3s6QuAfi
-qtpmIegjevw9ymzh2R+zCK7ne2GvbV7qFUoRnjNwsYyRbvbtbb/wh9ul/KwD+4G2O4IhYMvfEg1sxXZb
-gHkU3uEt4o5+4AW3vcj1i3ZdzMFUmW+2l+z9cH09n4fLO0+PGbauEcsqlqfAgxerqaAs4LUiaD3MRyY0
-gM3xx2kAT69PVpPr02P7rJqnCMLwqpKBZQsbby5uLwOAQDAHRODlYHCqmcV4eg/6tV2iiP69IrIQXDd4
-PbP+D3DEKhXUhQnIG7ogVRZZwZVb1/YuJUi6LY57Xer7EBlP2N2vaQ7SJ4PVMViv1EwfrfpgRU01arEw
-3yXXoRvYOpj0P124sjYanUIRQgi0UlEGLgNsChl2pAweNpJFQGN3tzy8kiY5p76F3C1qVq/mkl1Datqr
-BlwESBBxhecGnMnLfMPiPDObai5PurNl75YvpR+gWhKgEcIo2zD5PK0NPgRSqPBScif9/5qqO/M05GeA
-09BUgrqnN6BdEAxrUg40TGI2mtSXkBCRkvrFg9a3x7IrP9hhU0za9N81SaOUEqXKlGpbEzx9AbZXEIA/
-19eKFQFfvxUOfdQum7EluQb8BNtciAOPALelT28BS+QHbBXa4LgGYADDK4Fznm5tU/H3sKsEzcY3PD+C
-YZDmYogv2D9h9WkI7sYWOqDAH4n6WHXCWzdP+AabEde0FGYqzGtjagLz7ahKcaUQj06lrN1wkgxcBhVU
-w7wBBs3mRFYQt+AR7DCrGxiES3NLyB1WzkpXc1N90TTzDG1uu7cz9Wip7ArvPGjkseZOVQV9aFti3tji
-jC3W1/1MJhB8zVsDWwLNhGcfR2pig+lCSva98cYGsKf8Et+5oc/wQPsKxKNVCb3bBgk1TLZys5gpZ+z9
-YVAh5o6bZkJ1wyaSDs1sQ6Qxzu9MNQO2XCJtbJklrGfROYz89NhHpdFkDSlh5duTMVd+rvO4Lcm0Tg+7
-KtljtgdUV/qTo6uWcyIrXjJUE7W4DquaD6np3RwO17PTy55ow/yICkPBWotr72qC+2sri6U7nzGFupZy
-3iz63V/K2bK/l01snZsikcXgG1LVUvoSQLVky5lyWjNlYiFOa04qDQDqp8qGvGT6RkHMdK5mtcl5VZkX
-YDHsSBYS6Y60n6fCv1nye+G8Ylp1xrE2ar1k+akVSjwDJDMBQwAvEIYdkUKhWyphuNwBq6cgso6U0+Wu
-PNooNODR30iybnbQl/SxANTEHBkRcg7Hq0FgcGeaH/98rdq+zX4W6Ymkp7UWwp3u2AIVoR110eo4ml6o
-m1qo7b2iZ90qQNwgC4XVevUffHbe1gvAx1Cttxr2ULZ5sRTlieCDWAo1g8hYzE96CQMHyiJwQs990bFI
-/Qg3Kl/MA+a4vcLNiKuYbhKKpb4pJSA3iy6acxgl3ZMH6x31gVUhTClCg6w7b+6GBAYuuSZCYv89uKjF
-iHgLxNpUFVB1QqTkVZekfiddnJwt2WuDe8vl8qoELvHICuB5uLrcCxyN3lTsjainRC+Lz7Hs6RGxoW8H
-cQeIyNFDHpZWJfTapgaChYQRYwSskaY0BJ48LRKVSOOSYqMUuxbW4RSVQRepjaQhcJIcHuQqo++nIuz9
-yJ08NTMNio3cz6H1VTniQgayhXqa8VHgDdi3VT0je96gZWZLz1H03DVwKzC7Y1P5LBfssjZI2KslmtTc
-kHiA05qyimf4oxoAEAwhtofLNZSZuPumucyZFhXYpKJ76mjn0Jo5mTZEFkXjrBKSf6zY7aPVSdjBPLsR
-3O9OnMZasA8Zk8ii26bt5OWcbCqpryFDHx/aIDM0NQGIVk26MetHmjiTOJSZb1B0aG3V8tMbAk9pcMGS
-dm7txJPyW8ln0SMWq9K4P2YzmYhbMR523tQk5mrH0FCBv8SHPRA4b+53CjsBu9HHz8BIyrHCZSxk15Jl
-pVGO8CCESYAAJOfhEshapZLloAxwyeK6aYfJgyxl0HAlUWKHJZI7p8uuXKuswnVzp1uDAstxN18qw7MB
-EjB7shXda3Q+YZVpxt7DtABBxbC7qh/XY2LLtDFXKTAEYQfJbG7b2O0jO26RHeo1GfRmqABCDBXqe2SS
-4wzUAvds1pY7t74ztjboo8CvHx+EEoaFVqjeqSfPXMGwpCUhtugPFexihn2uIwxENxN442v/cWgCasWw
-b2fgq/H+Sit52nhhUhDbSlXWMt+uZPhlZoo2VEGApRmTWvWkQbCHNhbCMOeyZoJYym5+Mp1LouF/im1C
-nM39ANZVlXcKFeVSDM8vReKMqDcRtvCwWtHADKuqsrQqETlMTXbPUzBeenpeh6htOMQbr6NR7yQ1UT/y
-HSlxj+22KLrnAZfsasg92LLE42BLfz41cqaPQxjnebjeNQxgeHx2OOiYOuGLo8IXjNgsVygSnpDzGlnk
-Eq13LhN+AKG7v/S49ciux3aknrNcY7DoqbKr9Iu/iKYnA+NZBLyED+U0V+hwHtkrinwlMvGoyXf3ySp4
-62Tq4OZXx/ZobNLXHJudUvM1ycreJdinrvsvXvbS63Gz1wedzl+r0/zJ8ny9R4eL5HDMDnpdvtZu6cKB
-dVbmM3lOBsmfpcszh4cyKG8xKOjzldVrN6+5s7g9OxiB+rXmXfYh+KS5V0TxSjfjHIJPh2fMEfhkVA9G
-oL10W88xrgwYOinKBxnys3a+ulETzLkB4Zpi3Lx0TDkzcPRrKZSJ/twQZPGUcMMtoFrmcnO7lLXw87Gn
-kj4OhC93AuHLQRz8jBq5GQh/MHr99dFDnIypiAw5V/aBejGC8mwE2ycjmGwE62UE0ycjmGhkQplNp0xL
-fEOj95hVXmpxESCaj7g6GqD+IJIdv0vYn5u/suuU6jO4wN0saTc2L3E0ReP1KUpG4BjwvE1Krp85PW8h
-8DQJvF4I/BubouRZ2/Nut59oUsFsLbAuo6vJdf7NYdmbcfzNCsznOoPVUhm7rvhgimr8yCpKl2mq19OU
-bqyivK+h+ivagx5Lj1RHWvZpwCxU93jtW5hT5p2e/swmt9lcLHOKbPVh3jabxaPp4evguC+/yb0XWzCX
-A2yT4+FE4Pu9Lx8EoD6m1KnXtiTRk7GbC7Ce/NVTQeB3Cfvz4AVg2UL4FSIsykyJ/N6UbVKuQUnZEmRR
-ONId1yn9tJFUaJVlUBhrB89SzOTmhi/oNc3abXAQ/FAAlcQO3CWXRB1lakr3LOTuJw2PeN0u2JRm074s
-u/2wuON9OYWxhEEdxnKmkCnJKsCBdgfzNkggFlsysxPSArn2I7H5JGMsKmVeW6FeSWBJk2ShFdKVardh
-XsMMuy5cRonr6XDc0mvb1AfDEUGHH9tXcvl4K950mtHxXjl+zWtit4BNn7B7c5sPfcI4DuI4FiV6UP6n
-NvwZ7lmuzEdX3rxXqG6LVpLMIoZqb7uJZeX/TKFU30BX9O4D68y5m6ICMsbov4hQcAeMsj4Pmul1ZeAP
-iUVMwF7FSOqDECJETjRHzhSzZj/7Yy8S9rfCdV3+h3uM9EYs/hZeQl1W1dXlfDzLX+rYE7YqtTi4ADI8
-Wf3F91MzYls7Wbb9irZnRS6v6lUx73BLOgXzDlqkHN7l6vp5mG+51WJk950AylUDcD6nZm4buJhPcFHs
-iT7wH7t7NHu7MP5WpNdU4klyccSqmYdFD5MlpJ4oZKlXAnvaF574wktNj5ZqEXqFQvcUk1iYjI0kHYKJ
-FVcbxLdg0kKHcwhKSxcw6GKannJXd1WnBF+6H+dJz+qcykTnG4hctiO2noLkbCBjE2uvGSgXQsP3jKsz
-E5/vvOL77mVzjraMp6cegNDAHvREOfFqO795hDSPr+H8bQzZLraPSk3SkuqgVMerGcU9Yv7K4fZZ0uqC
-JC0CJ6/QZ5xhdwegvgQZQsDQfumkigDxmZbyWUrqLz1GrwwR3R2jKTcDYDxKWliZsCzu0gukUTNE5KU1
-Cq2Z5dt8NwCO9gynsRqm2tNjLQUBymb5jjdchBdATQw965xMncHcORfEyug83LScxE4SVXQt2VDHYJiX
-t44vfWSA741veyb0SoxLGUjchL5Smra+yTIsrMPRDniOq+6aD9v0RBDDUbMbNuY3GYgimQomsOqUOtuS
-3b0tm7ozL2ESw15RNh8FSkWpoR0TMX0hKr47yPwc7DYtIfdE0vSAQK/GOGdJJ71YmHU7GADMGPXpUYtm
-0c0yxaX2ROMQWLrJNRS2Ob/0+wmuDfs5nkLzpIjPrIG3hNPoaTPsno+Ssb5Idlkc5n1PPmDK5mk84Pua
-4ZZedgdLkvPQPQ2eXQOuS6OsZi2Hm+LwtCgBSP6wobeTcsC/fN8emZNQTf1Z1ESb6fCuNvEMNCPhZ6Eb
-NGM3JApJTJ4ms5s93372UAPd3Nmz0NsJsKjw3MYio5GM9srZcjT4+VNakqpDGpGPMy2BUyZOeRca7KS9
-iotmAGkL8K9qPYUk42vMgY5eabI0iCLPHKzKw553AYgyFlvKtMyro9Je36FchSR4fKn5sHRTQeemDPas
-rlnVawWtGnvodW/7iTUbMqt7Xo72gkekUT4lDQAGp3IfMqi4wemXp4Pu2bb5DSzNAUw2TVPcdYIO40L2
-swmmuBQPcbLlXcn0udVPB98goKsuvrJeMoqQGwfBqEhFSDuE4K+xE46Z/ARjdwXcHWdgywv3lGv5wGfn
-klzoNSS2S9NNSLjh0VKmR4t8pkdLa1cxhvAqukdJlsxG7mRtclssEoGUN5zWd5ELi4dLIqx3x5+fltxw
-nLy49R1pfU/r0z1djTsUTQjlPNPV9Lelq7m2JR+k4fHBcDTTd6rmy6S7PGt75WDolJM5PStB3IwU1Mbv
-/hwhuyvMER9sNb50n0UUBnjIdKNB0SbsVNjTxeXP0qfmK5A5Pt9hrvYsZVWIY0uLtKoTvkI7hPTOykpY
-LBOfle7qPdqUe0Gdo83U00yGJG58EABB8HcGUCr0PueE/I56TsGT3gOCiopPM1udyGWY5IKimU1c4XSG
-fjuaJdemCk6btt2UupkT7AymgzflEgzC9Rxybh/tslYRMmVLgc4PEz/dew3gm0wGg0c1nq25AlBEeMQS
-y2Zw4u77KAZbVx7KzCn4Wu+T9T65pJJKPCOgEjXQ1lCf1VZmbemyle1JgZ+N5VVtzxqZtJ7rKrM9OKC/
-Hp8JRlgE0Bg77HyYuPMhYaBCm3+z54hXuaj3fo2Op2MP99Wkm7w2Xiu1NVvq2gpF6g5/v8GpWCnqnEZ2
-piQZCEhqlokVgT1bN/9saKwvlcGD2ODxdUK3BHAsvWZkRJR8ttYhMKc4Gksx5EFlIgrJuVTbWbTnaF3Y
-P+Zez2gCGmZnLRqIdNCz+VuYXfLqjkZ9B/hrxY7q1MobzrCIWBqVqfp5dPMdnLvh4oyIVmVnzngDNEG5
-jwyQ7kSClGMgiXmmThclbTMqdf+moodXjmYoPHNNSwbIdToHrunF6Tl9na7eT6Of9fC7G0XeZjYh87Gc
-f7r9btowThwP18su2zd9sy2s+3aVVf9ulCOUYQDedY2hx99basvg+dSjJ5X1pPfcV3MFz0KiW6TUvsFW
-KkijMAhF7BPYO4an+WZZeOibqa6WX508xb4ZLiwiDjlT4CGhTYVR16B5wgTBEo+kq55rwPbPBpQgrIIR
-7cvKTLBlJ4d7eU8bOsEREFstU8vT5ssIUbDc2WwxxRWQMZew1YgsDog2dWihI7funl4Avq8D8Lxtigec
-LUS7bWiPQeZYnvKQo2UzD6ltlm1iASLQDo9xRT1pHuPDpP5kGT8ceMtQnnG29DNnqDcZMEzICakCCJx6
-8zno0+dV91m1RKs7eOVh1j5m5QnmzUSZhjvhk7Zc6layRVn1OJP4c5yBV9oUbKxt6vLMblPWUinSaJZa
-o0J48mwH0jdobDOOGGjWllQRa5WmiGCsYZ4sWVYZzQJ0PsQnWx1IGavT7yxotu+nmM6WVCsTu/AgJNjm
-ZWgfEPEydDEe0c0zLUq7RodBKNq+k8JxenpLnl66Tz6/AZdcKeaF2d6Jc6PrF8eItmSUiL88DdfnA+BY
-QoBXJSJ6mWLWJKL3Sb+9XEeT8WtZJF9PUrSjCB37OM2UjG+qtnmt7Z77oTlIp3ugO3wfzefIb77XqwRm
-GBttGMNNZubp98T9+/H7ajZ/KILXYG++yOzvh64+sxubPd8PfhuWMcMtx8xK254hEMlFZ3TlLb67Du8Z
-Kff0tG1HV7qcpFfL5olAEbOUMan3ve57+5aH7JmUGPg6kRz6/YlDPl857P9sQ9Z/HVSW4svAlvIaUb2t
-9MFwjG95OPLMM8jFEgyy6+2s/6XMNIR6/9izZcRvtaO7QqX5tLWr+KMZ25LiGlK/oLjkNDOmcOwGWxHb
-VgH9eOJko/GYrhYI6W3bn2unLPqSvmPVaC1WdU743NFQ8r/ijTodx0/ZppOun/H9VTbkWx2xmbVTdpNG
-ut6d3zoslxT3n0bKvW/E0rdMYwBGBFjc1YEGRDdXlr+IPExvHcYWeS9Dbx6r52jjg1c2fy5Pvdg27pdo
-fTgx9e6B4uJQtpfLqwLh6r1wqc0vgb1mmQL1OfBX7bXc7NvbfnF5eHlj1rI77SFtj08THzvt8e60x584
-7fHutMc3nfZ4d9rjT5z22J32+nUY3a5E2U/YXQmipH7RIb1husrfpuvnmy6MPjKkqSg+OUcX3K5mza10
-fL0g09UZ8gou6MGUXkxrSY/SsTIw8yXuGvuNXcsPILQMU82IxHnOfiwUOpQuhim4hf1if6ayZ79YXm9k
-vkn3M9+YF6LIGrpBg6VK5m9cKnGqBj81KCdiA9qOHfBnaxAA/wghMwXCSQdxVzHZ3Ck2/SOPp8ciBcag
-5+kVwsyvcJU/4DrDQpgpFix5gOd4QNbIPf+Gw4QhgYDliPDnBiMPJ+GZQuBZBoE9gcAb8geMvs+pPJ9T
-3mf0Mp/wfu2DuEzgy1jnfFadRhtSYFtUHXDguxlyiTKxrVOq8ZLogmeqVYMkgTsuU0i1nMJYQxs2azB9
-tH2pvXHm+vXMQeFiWkdMnoHZwy+0JqFcm2dwkD4zOPjkbHuyB0vUcJVm45IhYrvkjfg0m8SeTGJPtvEi
-lQSIwWZ0teM1EyZcGzOQJdaA9De3Ih2FKIxxrXzxlQ0RQ5p72rWXfyhfkxBzEZGmLV3KhGdv4K98hVB8
-bwm6Ubbcy2J1u0MS4/MUF2MdhgvTJuKiQywChG7tA4lzDVMNWDCGy8mlGHHW3ZjBFmnkUIRi0I0CH7WC
-oi07RpBB2e2QeAUxScUy9oHh0c2G76Stm1znTZXaWesAQEzyivUCWvPoTSBD1jNke7F1wJ2ykjuOOKYA
-lKZaXOaBL2jLdHllu9S0Fk9XASUrBQZcPhMD6F8XgRGeAdvCb42P9hOJF0ARjgY1iwxiAKK3OZEaH8oG
-nKKB3WG/uVoGOIT38tWoi9u30+UPu7ZiNg6OdQ94Wijr/DP6las7a0/A2rm8uV3VeP2l6zY8PeYGB77i
-ts8Of2LkP0POkGgEJW4DEc9BPQAqhChlcTBS9owIS6oUBhKJw9PQDLsySGSVRqPS6MS1ri1SY72i0Vdm
-MqAgz+3nyR+WMJBe3FCcOCPArZFp5vPTY2GhITNfOPyHA6DGYIzpk/43/Ou3rOGJoPjfzHq0aGfHqg8I
-QwDLvqhYQSt3Kt0yPowOtbtSWbesKEhsYW0mhAdeWiyvtbg5lpON4PDt2uFAcb35dchjB3xytNDr6+tC
-Vy9bjhK+vMqWLq4DgY+BLOsOCeABuyEseUZxcJQIDQwIOkIqToxX26HfzNF1GboBrAblCFMJQgTM+yvD
-Pj1I1o48HTYVmAlMhM1DtkQnniHD0pjkReluuJ9GJ55+KbyDPAFVUVaLmlNaA75xXc0jkvGH3rW2pxmS
-YFCxacKFXbU/fdp+Qgc6MfiBlD2G0s9krAJbBECntD6k6z5U4HFpHxrx0b4iV6da3yXOrnxtn0bfPHWv
-qcdzK7fYoVNprqe3MRtXWBhPuxzPVa4DFdxFXm+/8AcqYoaDUQ5O/TSKf01ET89xj4cYh7tsem00ingi
-A4AhFQgHRc//8b6R6FXMfUD7fNEBzFuXMIncbQTK0QiUctJpCX1+rMOT7h4rVc49ng5zu0u8mO+quF1o
-tpjjRTyy8zd90vzpygZPpUYGJSfbRPt9mKmcUtyarfcrGXk3D+rIn7O53liG9mZMqxjSpNWH6nIkMVFO
-5THJcEZDevB4Cj2aErhMrJdDOVvis8T2/Kb8uaePRwKn+DG3v2NPS5CHR+ce2dr6JTT1GKnUOO1IeZFc
-SbJKGlIpST0nyTeNfbsLYRVg9XKsG2IEcobrDOBv9EbOGxDr6tNjRmLseNk7zlzloinDb5WjGX0W3/iv
-Y0b7Gye0wPvn2tgzgfZ95W1hX4vhaoEGLNn7sLT9wLL7nslm+fBsj339dqBeLjVZBqi8IiUXgklqce8Y
-iKSnwNLqGrIB3kfTBTTSUnjd3hZKg0qmNJCxPg093LJtkBL7VfCmsyzdPEwWg5KU1fyShGf2vIkYihnP
-7tfYJq0Y+ri/b68LOGXzqfMY0d1ouque6A26J+EdsRFQWfrmocLlkhD2xskih0L6nrP0U10KWtB/8QbQ
-VQv6V2nBVQNc5/Vzf58O56B8zRHov0AD6N4cyNdeBj9PA+gz5uBrDsHBbsXP4LSvvUAuSJyp+JnSDaR5
-7HLXQP4rIMgnMVBiKEbg351PoTUBhHVh3OSUURqFBTi7qEer2VA5UK1TucICnW3iK5eKL+quRtNfrbsd
-In1Rf7Uv6K4m/CyVN1yiI/wHLixKnyzKCR67xkL0yUKg5ASxS3Y8Jc4rW4ZURIlC0OmQs6WcknTjg9xN
-t15A7VXkX4L5rGblX1vXPzuAlwOSfEK2h8NstmyQqC9YbqJEgkxDHSmtik1qe31S6W2z6mE6ngl8uiIi
-bfouD5b47c7vc7fgzhZiwNIplLQBTlA6sMrtYj7Bhf6WpKU3/7G7T481CrXRT7muSD3kibL65IVgGFkN
-bhu+3j2dgoobaRDHpve4pzVS6cZkwZBiJsAOwKYvsCITYQ3+/B6kX3TG8icrUuVyA4iDSRT6K0kWFmP5
-gjDSnfakLKYhNkARz2+GP65St+DGGqkKpUyc08Lg/PXCtMnmF88VuQmkUk0k9ZeYevoSc/9rnPpnfgPi
-ypDAlc+B0zjNjIDF0VDgGVYihQFTT6LQBIkjKnLXWZwOn0JJEQl19SC11Hit7ZsuN2Qr04nOM5SI0/QR
-QC4ry3CUWbs4+s83OfRtz079ZGEeSPOtr0guX6nZvJgHn/sp7HbBkgleenFriZqcWz8IGJqRojnGtcAO
-CE/9tjWh0NIpcJMrpzj+1E/uk1s4mdPU7bMn1LBQkdIdkYxgnIr+HGaNzd7ADfcZ757x2iq5FD0a0mdJ
-cfluUtzdEexImTPuRR/H40jnAtoeh4z1Xb66p0WA4SfZbOpmnfLUl8kTwbntP+0ZTTgtoXfq/RSK5CX0
-RqF394xIrq9IplDwPZ6SbPovPxR8UiTr98eROvq2XFEy3NOuPLKTtuPiUiP5aD4/8TXuMXrqjgtNbVfE
-dhEMDqFI5R4E5JeqwlETb9Iy/AbrhOx5sQHQsx3AlO3ug3qwqj0N1CSqw4ihu6R9uqCjf9k6b0+6TIEv
-92jx+LULhdoTEuEnpuSG+S3MqwvcyCn8f9RdbY7ktq7dCjegiUhJlAQ8ZAeziADvzasfxr0wYtSPXv0F
-DyW7qj9cNdON3ORHMu6qsk3L+qDIw3Ny4yUUrlTYHHnBz5MQzhj/LtKtuXT1qyHLn3irvntEArBR7XlJ
-GVVjsqSYKMXsCpEps69as0s+Wib6L68SslMCxVJJc10Ct07FhZoBz4HqYdpQe0ic7yh+8RmIOpxDMSU/
-P4wLQOZt1OGl8duLSrkt+L950zePeLzcm7g2iBhmeW0cujUw7+V765XiJZRezprCw990QkHcrukWpp3r
-J+P5p4AZybts2W128BFsKdVyLZ0HGijGa8sfpQ3TkTaUN2nDPGaH5+6qp9GX/uE0fWNDvcXDy2n5lciu
-ZdEycezlwr2M/WAG7A5OXzYPfmsHqGMbSmBZsKt3YrsgEcObI0HPTKcaagboIdqmAuWrvFqfRSKecloL
-Na9cZ3ZcxUgwsq5DgAp7hgh4RDCfNjsvlTPcZAeoXIIK2ATLVJngCmSjI0DtEpIyLiGDogXgzKF0/Gbi
-S2Pa6x7EZwHNkVNzmXuNYYbMdXCYuYK3kJmCvr3Nk3cRJS51DW0UBksjRul16QSOeGiZpQT9Ls6UT+ek
-C5urwdQqFSWG5CIzdQA0XKuNHYPwbRSS2rIoMa3MEMYmnyD55Si9fxMfDHsXfe0IHT386OD3wcnpQt25
-IHt338ITsUXZXfDMNvKWDGhoXblTowygkZeYg2XTloK20/h47bfOEZjMrUFfEyAY4stBmxN1heKpM4nV
-TZVC5QWF6MJyaZIeTFHHqou6YHuLoFytCBEm5+MO+JIUMEspa8hCOkKIWkdaEmHS4Lk32lwcTIeb4tm6
-mazL+eU710Rd2iW0iA40eUUySF8TCCKwhWRrrlD4wgks1CPX1TYIlmP2A0wG0oViizOAQALaVJLmDCcV
-ROiwsORdZS8MnVhkxo7Dm1/gEEfOFJL3s/xw//7lO6dIqXVauJjTG20O4g68TUax+t6fvfvJCEes4tLH
-h8Csz2Jp0URlyeAh7XDO1a9CAVi/4ODK2s1NaTbWO+CFyLNy7PvuNiZIRVs3isSAL0YIuPmYhFQfdM6h
-Q/zyPbVIqZpDEMXdeq+1J/OWymI9IyffLRSSIdLrhe0+wS7KNjEwyNhtQuhrkCkSV9CzKQMN6MCyDpVl
-62jtbFBNaLtCoPEhnptjX2v36cweoCHY4PFDm/rHjF1c/to5c5Vdhw8l/oMGcQFgTFeFhC4FHmKd3QlS
-5lVTxN95OjM69KIDluhQugOUsWZ8m8DPUF2DO+PND3ycb75KntLt/qkr8rk+Ml/D5wDOdewivuIytfxS
-auZSy1VRbFh+LcN44WoWfPjtaeeoKBL/+HR3kfrOGqDJxml0sVHAGaARtdkI6D4XObErYwsF0h1zHYI6
-IBDoIpAz5SFrz3wJoslvdLCjvLkRfcmdkp7QnUraS1JZiLm3S21xbejxNlEob4pFol5D5jkxB/eyMJOC
-UragTsI+hUpkJ8QF+qCL1qFKPutQLqFUWUPzdSNXaNB28z3M18AMLpHqiCIMYLEZ1L+Vq1vRlNScjLrZ
-+cqzIsgml6E0P9D4Q4C1HPLEtU1lVpp69f4Yme0+84dLUKdogZR9TpeiEX9ITjarFSDZZRjne/WE6lXB
-ZZ0tBtzkMGD8xO1iefle1Zy4ZEOtLmzbRz0DWaZ9F2vOT9pnQcQubANmPjEKsfodNZCtoi1twanU78Qq
-QI0LIdKWZiFiTNa43PpmF226Yy6vQaRMr8G85dysm9nxXYYpN5KKFTm3q8gDun9J8vqxOrqZjX938QWx
-XvyfQgMWKDszyz/h8fbdtvRMVesS1HxvaclLblC9UW3kwIQ8AJEoDIPQuvl8uS2jUif3o9ZvZ8Ccc4Fj
-6hEZp7YlapAM0iVXynHBUNOyqLmhLU1tzjZwqQEeOYisVa+c6zI+LYt/aCtrrmfPOre25qe3W1BWcFQW
-UFthP5jfDN9xuo7bK49Rv/RqHL/ycieNUV4JLn2R/cUTxl94tfQXNMbOuDoBYv1zsJD+y8gYs6D+5QbQ
-KwvSf7EJTl5TfUVnD/WYEx3J8pSOZHksGVMeabgdaqP6mFVIH4gt6RtNHLoRxdEndPb6aJtTYZPHmaDy
-iOzzNBOUb+n5nuOmlfQmofVAP2E7Md7vXP4Zp9ObrMJPg2IuZbRx+YecetYR+l1pQquruexa7XgUiW+Y
-WtoW9o/Kao49sG9eJVTM4bDfzL/V00XtI6aMD2gw9qqTj1k03mfJGMUYP3cz2mmCf/JuQAz2bo5QPh/b
-2wc4/Swrikkom7+6cqVIpZKevKk892g1F2q1LdqJ+yqFlLLTSEo383tZvZqTXIwP06JvZtYwFEC4DeZA
-RN2wGWur9Skku9ZZmea8vnjrKs55qcmDhIBcMYXUt1BlJHiCMyk2r4VtmcR5W6Gdb9s6gZeqQnnzmPnq
-/LNOrOkUwfYO7Fw7uoBPczV3O3qchgQUDFQY+8LqGzulOoCZ2rzeNpEQp0tw2ZMByiffe7URXl5RhIxC
-9Ois/85p3htyeOJlu0rMS1VU1VkLFM9AjQDhQdQ5SDvNLq96XqyBIPXWEOVKl9bZ/+KeFverxIkjx1UQ
-YxVPGqrj9kEXKaeDON9JowyN01HMnSnVDUShozp6aF4EEUQNEIaaOU8x2ykONuZD8uSEFJgnkzc4995h
-8r3l8f1Wrm1Q0qxB8FpSHQTFiDHOsFgZFrpud5t+j28EPWRmp2L+d0apuLPbgK7Lta3WOEogZeQzxkCs
-7iZJpAT66j2rHrLv/hCZfBWqRVBJPO860DnF/K5iXtE1y0yiYDCDFDtOotjkysj+DHNmGPTuZicSnGXQ
-oZ+96J26No7tatkbshRKh4aPoAvZwJC6BBtfS5DWqeZ6CdoHi0At2ctmE8DNBPbk7sXHHn5sTq4w5zNP
-JXn6U2rC7TOi9winiI9GhIHrt4IpqupFelrUi1kRNk55xLFVd5gfsnq4bkK5Igtp54VTppRlGVXcQtZc
-k15y8qY8AR34RN3UA8/o7HWlG50DeUrn4JmneYSEYD71F9VRk+CjAmInjkieDDGgiqRedRRPQlLCewkK
-rNXZbqFI6NLe3GYmqdGwczyUkvWStSGOSLopcfaUWkPYLCNfqJOmoTJJwm4GbD6pL47rvIjqAm3CkrBg
-2FyhIIA4BKxtgkbgMPoSpxsid/mwTYdlFPSS+F2zKqYe4jR4l+sczlmQ+JKCDHGOpOkSMKYa1dxsYZ2p
-DHn5rqmTNfMlcK8Lx0SiZxvjnF9TuE+1i+gKFAP7X8bu55GgaX9SJvakE/XzPvSsJfkpS+ic+P/UlDrj
-9JNBb3LsnbV3eTUw/xYNWr+sPR81Jz1oT323Pb13vt81T9pab9r6mS4zpRHOlF4eyzWXg7Bu1vOUTzfd
-oPCPMPLT/bo97Nf1Z/t1PfwDmrR+e2mTS9nIs4rXX9PVzoTX7zSv7ruaWVmewNmOzvwpoO2plSeN3V4x
-2R9aV7/eojsVXP78tT7z3DeVaO/EG+jpy3B5RbSJcNUHb/2krfvtAvlBzy5/m56tJz3779+xS9wFjVxp
-LOSeyf7naCAggmy3lrDd8A2iw7wbSt52ubNC/sE2frAyUts4d9kv+q4a1pl9c7ubUiFNBVeyC0kn6diP
-YQfKzijkrPU7BQVPMqLxO386PNxb/dMzK272YthzH0hrB2zInkcu8bYJP1C6263wp/BTS3QSJDlLCZe9
-di4ValqOlhXnSptWTCiJ8NwTeQB93GIbt9zb86068JkV+aaetkLnqskStNsmQtJVhJfMaYQ+/MA+PNyK
-NmLX7aGgqZTyJuLxTgHJHHh3RMv3VLjudU1PA8GSPVYyQyUeKdkDJYiTjDAJ30rNuTpR2OWJPE4SbgIl
-M06yazNNaSZXZqqu4+WqTIiWlMFVgy3+DJUgUkLbESkZgZI9TnKESTxKUsb80+ZjnzTs9NZ2ztlPR5Je
-tdF9JCmPSBL/fCSplCku8aWRpL2RPMYgv9y3Thq57kOFiWNvF9Y4wY1uacUUGbLaSNa8zIMVu0whfL/5
-6mCjWfetvCTqS8iVKde3SuXzcwalWaJ+ZuYurY6KyGlmGnSEHRezO93oqbsQb3MD8B1yl91XjT4tVRol
-ALxVsrVAM9ljhnGAfb09qn274Ydnhu7eQWyVWhMECQqCymI9Sz3Cn4Yht9bdiARPjeC97Tq57ZKufglW
-u6bNlIlCXe7ezng50LahM3yE7lyZ3Kj3dDQjAOVQX06H0eoiblhNEsy7baswGwv/wSyfPXianPCi9xfv
-EvVUMVt8bOaP9uPHbayR5aezJHlu+PagF4CpeRNFvUgd0lqF2BoW1btKBUpJm9p4VzgrDj2Lk7g5bdnV
-3GSz5U3zhgzz5hjUwMIgmsRrTY5MdZU7W/98JXRMGXBECFg6Jq2lLViL1kiTY7H4qArFAUZlA8kP0Nuu
-tQWOUfeBBnYTwNw0DvKIIZU65G/ND0Mda6jWPY+7M5pTqcclVJAusvk26JnMZbMTQYMtSpo3oKAAiSuC
-pIXXqnHaIP0lW3FCRfuiuIQ1cD/cxYdW80BXihvyIvwx/RH/KDtVhsx4icav2emAvl6gcV8bgOCAfwtU
-zTiR9KuWJ/R188MMOR+cHnPGPpuk+Yf+8cfrp64lrqlTJO1Tk20UN7WMKFFKS6gAbnG99hIXL5AuQxD+
-UPTliDyDJGKULPfNRcl0sPqLe62Lr0DXI8Ly249//2uzf//3/378+fv//Pbn9f9/p/8EAAD//3zuxsnC
-qAEA
-`,
-	},
-
-	"/fonts/glyphicons-halflings-regular.ttf": {
-		name:    "glyphicons-halflings-regular.ttf",
-		local:   "static/fonts/glyphicons-halflings-regular.ttf",
-		size:    45404,
-		modtime: 1534412156,
-		compressed: `
-H4sIAAAAAAAC/+z9e3wb1ZU4gN8zc0czGkkzkjUjWbYleyRbsmzZTiRLSuJETkJIQuI8ICEkBEJCBkIC
-JCYkIZTwDm/ShhYcwnvDKw2EmEdpKdnSTUvRlm1LqcsWCq2dBb7ZbstSGgo41vj3uXckWVachN3+9vP7
-5+dkZu69c3XPuee+zjn33DMIEEIyugGxqHvmzMXzLm/a9T5CaAghVDNrxukzYQbCCEElQqhuwaK2+JpX
-Lr0DIZiAEFq5+vJV3e/t/48bEYL7EbJsXL1lUx1qZL5ESLAjhPCaVVd2Dw8jFiHBiRAS1lx29cXXnn9o
-A0JCJUL3PnHJRat0bl7b7xB66CBCKHXJJRetEmdgF0IPHUUI1V9y+aatb79RtxKhhysRYjddtmH1qg3H
-7vslQo/djRAz7vJVW7thHfodQnvI7+vWr7r8ohdjjz2I0J73EWL3dG+4ctMPH/8oidBTNyAk/vqqiy68
-+CeVixci9PxihJAFIVp79G9T9vyKPN/acuNm83nlLvpGQgiRmiPEIEAAHgSEGohBCLH4LtiJOIRZO/MW
-Quhc8wlPoDjzA/ITDo38MSXhs+eeMx9NRXXDw+yPh50IcQq6poBJ/q+OxkgBLEIohhASEYL3zTz4EsTS
-UAyx+XQMvQihS9BUxKFGVIea0WPoyTqxrq3ugrpn6/45WBGqbhgKQ3gwYo3I/Wy/td/XH+2f2H9G/7L+
-lf1r+6/uv6N/14B1wDcQHZg4MHPgjIFlA2sHrh64Y+C+gd2HLYethz2HfYe1w5HDEw+fcXjZ4ZVfDg8P
-UzybKCR0AkjQz/U7++v6x/VP7V/Yv7L/wv7u/hv6dw7AgHOgbmDcwNSB2QMLB1YOdA/cMLBzYNdhdNh6
-2Hm48rB2OHR43OGphxeakIb/Y/i94YuHL+wX/vjhH7/3x7V/XPYH7v3ce/73fO9q77rfler8dTV1njpn
-nViHa43aL2u/qP1r7X/VflS7rXZLbXftulq9dnXt+bXn1J5ZO7f29NoZgT8E3g+8E/ht4JeBXwReD7wW
-eCXwoqCOaoH/2z877RN352NJSknzIu3dhBBqJg2NEHosf5G++mT+4vPZRTKmEKprMy/kRqjuAvNCCkJ1
-z5oXUhGq+2fzQh6EghXmhbwIharNi/TyhiHzQj6EwmBeqAqh8KB5oWqEIlbzQjUIRWTzQn6E+gGhfoJ7
-AKF+DqF+kkdDqN+JUL8PIRRGqL8Oof4oQmgcQv3kmogQmoRQ/1SE+s9ACM1AqH8hQv3LEELzEepfaV5o
-KUL9FyLUvxYhtAyh/m6E+q9GCK1CqP8GhPrvQAhdilD/ToT6dyGENiM0AAgNEByuRWjAidAAweFWhAbq
-EBogOOxEaGAcQgMEhwcQGpiK0MBMhNATCA3MRmiA4PMMQgMLERog+OxHaGAlQgMEh+8jNNCN0ADB4V8Q
-GrgBoQGCw5sIDZBy70MI/RahgV0IDexGCP0eocMIocOkDf+A0GGreaGPEDrsROiwByH0MUKHKxE6TPD8
-C0KHNfNC/43Q4RBChyMIoU8ROjwOocME588ROjwVocMEz2GEDi9E6PAyhICUvdK8wI3Ql8PmBQqCz130
-QqAi+Ps+eiHwIAtD+hHp/OX9H4rTFnOK/mz+kh3jDR41Df7//5A5fr/2n+WUOdSvWxQop8xC124Ew8OI
-QQxqRAguYesQi3jU+jygtskv8Bj9Jf68hXt/8gssg/4SR8+zJJkjyS/wFhia/AKQ9IRLczUkXKFGmGoc
-+fRTtm6ov5F5CwHSkY67cBdajhDEPbUQAD+kM1ALHosMibjH61EViwwSNIM3ADJYwm2gKhbeEgqG26AV
-pgAvQRuEU50QCoYj4WR7qhMy0AGRVuiElKcWku2pdIpZKodtNlF+hPcI63iGf6RfdDEWlgHGJfY/wjP8
-OsHDPyKL0eDXzIe7/oEfj5mvOG4oPUIIkeqrLi3u8YNiCWkuxdIMwXDSpQXDU6A9ldBc7Sk4lFX9sEL1
-+1Vjj1/Nqn5jD4nACj/uOj5Nzec2YXUjhKfiLnS6CYsHC6/FPSax1ZGQCXcKaMGwSdpkMRSOQDjVAfFa
-8MQ7IcVOHBQ5wbjXwoFdtFi2WirFa8FdCMm2e22y8ZdCtBjawlmMewVOHPRVPCEowhMVuEskhdjtwmOC
-Xaf352yybHuuJMFugcsEseIJQXiiAtF+xOG78XOog/Qjr8cbam9lQ0GJ5TVes6garwVwIp5hkwnadSKt
-EAmlE6l0KJ3IQDrFHPmse7Lle6H2qRO97uYJ8XEyXKMbfRbo0Y0+67iJ09tVz8Sp7aHnAhde+vQvt9RP
-t7+rdwP3nn6rkqndjJ/750vWtswdp8m2QOuCuB6P63M2TW+W5eC4Oc3PXLr5kXH7rtLbkvo5tzTsIHPn
-NbSNe1EGzUIItHiAU2tAVSSuGfivRXZXe8rrSsRTyfZwCJ6FFaetmz7OYwewe8ZNX3faCwzU5Ul7p028
-T5SNz/PRzSMh+eU3p7a0TMW9VfWd8QVN9Tabrb5pYXxqQ5VJ3wM2SRYPlBAcfi/KWaNv+pLFUwm9s7AT
-dzEHkYyQu9hFzY45yNaR7jbUr/r9zEHa5+iNrhtZhNgjuBc1oGlk5SGVJMOZDPsEKUZLtqc66JglYztE
-SvSqiscbAMXCS0AzhCOt0J5KZ2AKsEf8anz1mWeujqv+of6RcHZauqauriY9DXYWQri3R/Xry+8Lhe5b
-3uNXe3pUf48Z0/1qT1afvLrSHp8ct1euntzTUxLRy3hxNJ7MiJ9BDB/C0wj/BHGPN0XGqkkCgju5wRfr
-vxeLtW7dt29rrmLBFWdPZ36Hp121Stq/9er9Gxeefqlk9+3+9/xaquNefABVEW4JkVmulBYQCWfAUwvp
-FKtFWObvdVbxdvhNQAkcWxhQAtB3m2itqxc3dFu/AVe//BP8ojvkfDXnaVCbmtQG5k/fd9YrVue9Pc6h
-QDfjQXkuksDrQjbkRSGUNluQB0uQjGYveBQeLOGkqz1lhZRHdSmWCITbefDQ6qUhVRjucR++TuSE3L8J
-CrlxLAiCYHwpiCJ7NwiCKJLIeXCZwInHbhI5AS4jw9u8yBjP/asgMGlBfM/4UlBIXk5kwiJnfAqCwIm5
-98hPBEG5wbiXlAWXCQIZ7gLhLIY/Hf4U/xD/EFmRAxFZkuCkyBBJuyFiBc7N9oel3Q7H6ncuCOZ2v8Nc
-bNQaL67HP3TslsLSee+sNDblHniHuXhoXTcsKM67vbiX9GdwlVDfBRpzcNDsXIOGHfcyd+be86vxuOpn
-wkyY/lZCAu7CD6FahKDBpBhvaYVUOuV1caWR9hR0M7dExi2aueD2+nMWzJ6e27t0Wj7oZ796XA4Eh/4l
-fabXF718o6/SyVgfzO1pWqlWRddfUVXpYq4PN9H+Yh3+Er+Kn0Z+RDjXeIBRea/C0xWTtKElFOGDrUx7
-BnBQAiUA8Qy0h2Fn9uWb5wPMB7cXPB1VUbfxl6u+yTH6yzfPn38zbOy9d8mSe8kNPz3/5pd1httxtfFJ
-VUPlJEWBinkA829+OfvyzcvNPPf23kvn3KX4AJ5B5APIMAFGVSSWb8XJ9gwTAOaOcdds3bw0ddrV2/dm
-Mk/fsvW0Cedu2Xo1+70Zs7mWRVumTrz6hutfOPvsF66/4eqJU7csauFmU07jN2gFvgbfS+QPcKfiXto1
-ObfHEmngLOG0uyGcSsCum3mVvxGeVC0uY99NvOwyvmvsc8n8TcaTLovC1sEqt9t41GX/ytig2Ox//8Ju
-U2DnMbsLMSXlB08CgQt5+bQ3kg5FTgEL/vbp63P+5Sdzfvyn+ScGq3/z8F13f3T3b0r7WZjOG3TaJNMb
-RybAkSk03UAmNzPMLN3WmwnbrXCx1S6TgSSb4XCmF/f2bhtsvWSu6LV8zHnF80VZFs8XvdzHFq8495LW
-wW29CCFHHmYXkpEbhVAENaM2lEBpNAvNRQvQInSOOQOQfksnMLK8qIlkFIIW1aV4Elo8lXS1hxNqIsmp
-iaRW8uS10XnK35MZQjaxls/Wmcdz75EoExZl+Up9qFJn6/L3gZI3Q/89ko67ZPHYx+QVrhJlXddl0dhD
-orCCRLO6nr+VvSgkm/OdyVPJqBa1oc7jaxuF0XE4xfs47KQcVzdZ4S4vieT++URvKDfWTdmwkUQazu09
-0RuErMfhvgBdgDag69G3EHInzA7UnO8o2ini8H+cP15Y6P3w5FjBoc//0QyEiMXIGDej+x97b0pX+bEy
-Qu/jKB2F0RwP/IPvSynHHCTh3AzV7zdu+Z+lj02esSs9dqopF4RRDB/BdciLkNVc7ZU8d9DemV/94ddM
-h6DwzwvC0F95lTduEITneUV4hvfAB0yHGRn6VBDgBjPXMzwCtB6tx9PxdCrbeD0Kb6VLtiUYsYIl2Abh
-9rQ1D8JL4LK3vyAIxudgE4QXBJU3jhpHeVV4QRDAZnxupoEd7LyKO1486XvBLMfOIxZ5h/8Lv453Ix9q
-QGfkV0+ydiqFtfP4lZOjrVdswiJjHjIbj3KrsPunL940bz7EBOFyXhWMvqu+eeFLN86bdyNsePH+pUvv
-f3H30qXGwR5RnkumqLmyOBLEu+fd9OKFO642fiuowmWCAM3z5t34Eilu6e78b0NlP8kHUVl9kl+zPmX8
-+tfCfSmZVvNT7NfA+DaKI72Z+iIv8uLdlL9qKUi2BXm2PcUlPRWqIjHBVibZXpFWJVBNZJPtYSask85J
-biA//fbyB9/50zsPLn/76TXbNr5+5PWN23Bv6ZQ58FTGePcHm995cPnyB9/Z/AOIZJ5KZ7duen3jxtc3
-bc0ijPQin5dfC8pxOVWcKxvLGAoVlY0/nCKIe/NLoUiWRaNbFnMVdNX7RJSNPlk0MnQBOyTKWVkcJOHB
-Av2qUDXuwT1oFlpAOIdahkgEHi/v9dQyHlWRGYsMXgsf4S0yYwkF25hwG/DhSDoSbmPCyfZOJtUJkVTa
-m051MqmKoEXxxFPtzFItGubGt3C3TmleyPokuJ/j7ge5kl04bsKtXCzOhZtrCzkmxxayPgezy2LZxTh8
-7MK2SbdwLeO5cPPWF6677oXrcA93P0g+ZuG4ibdysfGWcHMg2Bi2jI9xt06Jncn6JGYXx+1iJB97JsnR
-Ms4SbqrTGsOWcQR6bCFDoRu7r3v+uuueR7zZTpyC3MiLNNSAoqiFCl6072oF/jxBbl5VS0Jxdfa6EqP+
-wVGIRTvALcrHXpJFcHdEIQaxjmhuRrRDz/9xSkdUl8W5c0VZp6lG31B/tKMjytbpuRnMwdILAbIjxAn4
-14Tfd5PJCjyWZhhRzxRDoWC4grlMUHlmpcCJB2hrkmYeFOUDIsesY1byfO4xQcz9C232q4w7aGfoFBFD
-6k/lZBeZhYFyWCUMSMKcfJilpDZ5UVtlzxpU/bjX6I525LaQBNxlzvODZJ6g4283klE1GmfOE+aAG2Oy
-U10lfZyOgf/4kzmizAe8doQMKHIztsVJZcyeKuPdxTzk0WHmef3I6w5ZNP4ii/ECf0b79PBDCHEK7kU2
-InVFgQ2xGiTYBJsANsFySu7j6GfR3Mdw4NfqHuUt45Hq/mrYaXTjXqMPYkY3uZtjozCmVdRWGM+JEYGW
-qksKo7hAwA4odCEmnFX9ryk2h/GKI0xuNuU1P7OYomoynnFRZrfLdJ7JeJ1Gt9MJO51eOESEwlKmUxYP
-HBDlMlqP/zq05llLMMIWUD0JsXtE+Q5VdPzREXb80SGqd5yC4l6n8RenE9xOL+n/VCP/dXFrcI/q1KFg
-OO1OxU+C2/8zERJHUDwhbmD8xek1/kLp5vYS/Ip7CrQdfWiiyaN73XlhmjRbJJx0t6fdGeC0oIVnLZ6O
-vMK4A+J5nW88FWFbYYD5RHSzP+QFzu479pI9wFiAf4V1M7tyfWIFcw5jF3tENxNm3OJg/tkj2plzmArc
-K4m5TZU+4xzyIwE7fPBdXyVztyhlJZuRsUmSeL+Yv9ngkE0qo2fkZG3tpUvyqYbTXTmXK+esrDwh7c7/
-kb3e/iO7jax2NgTFdbURIUinin28E8KhIvRkooASk4izL97G22TR6LFh/pbLb9yUB90zAm/zj3H9bTy2
-GT2ibONv6crD3rR5BOnCmg4UdhXhPMaAroYoRDcBHwpGeLP2Cari9CTiGTBxsRm7bJi/dT3BpYfC/y/z
-8dCt5C1cInLCLetv2vT6xjxeu2yyjb91bh6l3GvkvuVWgRPhElseY7pDXr7Wj8hOjx4vA3LlkmzZOp8o
-45r4/+P31xJ5Nz8FsT8eeo5E2LNEWc4J8fysJL9NWIU82/Cjfzh1NHeSlcURoHp+7h51y/5jiSNjvouO
-eSIVJNvDIS1o8UNodGOETGUcoRC7ffMio++sLTpdn7eTe25vQMkqAbYuu2jzlrOyJIEkM0ujHT1kHezJ
-rxFEjrZSfSedeEOk1eNe0gt4iQ0FWyHSyoSCdH8gnHRlIJ0KsN4Ak4inU9fGCRHYH2POyWqnTR1fU9XZ
-FInGZgXOnssLFk7ybO3wNra6J+Joy+n+NQt5qrb4BU6I8jjG4jKWWywdC+sdDkmSZXkix/IyvJw+h2VY
-m0UBSZLOEfK7pbgX70IaGo+m5Xn5Ec6zFeokKIlngC1jTbVy1vXDI394fDUl9o4PjcEPd9Dg6sebHhPM
-vRU7s7IYxLtWP/6HTUYfnZVj1/9648ZfX1+IbfrD47kf2wWjnWSGfyP3kXChHX+DZ7EfIJXM3QUtaKQh
-XMClA+JcOpXX0ppzYbANeFPu64RIXu6DacBY7XarYag+kwH2MUu38gof5PmtW3k+yCvkSeNXXWXG2dZn
-LTzhpHnLs5K5FG8/QVYzLghFnNmNFOe2E+HckKA7kLxFhmAbtEI6lWyPhFOdkBoDU3gbi5aV51psrJX3
-86xl9nwLK/jHwk8H643/9MD1VgCLl3cLa67bvk5QeA9X2DX/Kb6Q/TNdoecSjsbcBeUtfKiIRNjEgvsf
-Ys2+wdost82YyouslW8SGMuGjrSFsTbxuZu+dnX+DNYX31375mIitVsUoeeFc5+dZVUsGvejr1VP1WLW
-01Fc761IRT7kp3x+C+pAnei0vG4SQUINqZoacmkQUhOq5gq5QkmNc2n0HxdKJsgFCZfmSpCnGlJDyVAy
-kczn4gs5yFNLanBU141uHY4yB7NZoy+rM0uPvQQx2AmxoX5dZw7qem5G1rBndTia1bO6noVYbgbEdF1n
-t+u6Ydd1OEo4TxLTmaWGneSAWFanTGkfCZFshh2O6kafqYbMFtP7qEayoNcjdWcRT/lfIudUo1oyC6ZD
-roQ75EpAyRO0pOYNJRPefH1M9SbszGbJI5bNDvXngeTjWfbIUOXo69hCXV9O/sy+L+KncC9yIBUha1HD
-425PJXDYFA/hKLNPEIxxgiLkloAswmkdizo6FnXg3twSQSEvBGYf/F6Uf9HRsbijo9B3Re5tWq4PaeUl
-a/lYG1jhRFBYSzE+AfbmZhi/OQHUYnQC7GMOjuAAxXU/TOVF1VVgvMjaIoOFrCqlSz8ZzDF24tyKqYIo
-i7kZvoqpAicyB8koIEtgxV0VuHfu0DYznX1ZlM2cQ3Po8vgrX8VdFRRudniYrmVigbuwEn6ebocaGSPj
-JyLEsZvgoHEavt7UtbHFfqCi09HZBF/K2xK2lqx9nUA5WrIcyiABQZiM5+a8Zp5wvCW8A42TepJS8hVN
-kfxsyphhc8J8Xppo8YpwSPRaMhK/CJywpEf04PnYI+beyweKygqqlcirMJ437JRVtonMUtFGeGbCLBi/
-qVQvI5PGZWoltIiy0e2wPmR10Jss5rYUSzKLu1OUc3tHWOjRe5Fk9EephJ+IB5ja4+W1DAY1kcR5zUV9
-3KNYguF2OJqdcH57PW/pISv+UCW59zDWSOJ8yOrGr350220/us347yWTJy+ZjLuiHR2qUsodCNWnJ5vJ
-oJx5G8kpTCYZzbUhSdvlAKojI9IKHm8AakHVkoSgmoW3BJiEqiVT6Qx0ArAay3wXblQaKxqsonFBMuY6
-y7h6UtBmbYoHjO9MqLFX8Tx861lYvAcfGKqKN7lVYKZPP6flD/csC7bW2KdPr6yxNzgc7Ce5c+FfzX5B
-5P9PcS+KoDi1aQhYVEViQ1oyA4QXwJEkR1V9EsM3UEuAVqYZ8jYBzcD8lXMnx3U0+dNLL79snGGPdoDF
-5tWq4N8nbl6dqW1pcRrfumv+WlfTosyW3y0869oVjzI3Al/pT0w6q/W0zfPTVcv4RCPbUanU2JxWfhZE
-z7jytMTySc3W8avmRDvaq4eeu2jhuTfSPo8Qu4Hybwi8EhC5zEsJ404RLMORJHs5s2g6v90am2PYfyjt
-Z1nZXqt6HRbcO9GdbBx6ZXyjZdUq51nsVFeV22m1WXiWmWjqB76X1w800PU51N7KEYnUY7ZDhiVSqcSR
-tYELuRJ8JOEKpTklLth87nClLxv3+YxuLAWrPFlfZdjtswlx9sixB+fOvfbauXOvZberdaoksDD0nMcH
-jK4DZ1Ul9ixgBUmtUyGWHep/+mm27mnEIG34e/hbXwcPN5/U1Eha1ZK46+R4nPa0WXwRjdyWEjSYO4to
-HLvpWhPj/D5Wb/k+VqnM8D+Nx5mldPNgL1UfHaFWG5U0PGLBQbVIfvXYS/kNhoI21ug7dfjr4MyeAsfy
-94MlGx4vluD2wQnS/7+BMyRHy2flcfcp3sNRvzpCaqO7lNjZUmoTREZwZ18uqr6N/zxFEP2f9I8SOqpf
-I/w/prUw5n4bkdH/z/fcTrknN7LtMK1kOJSQ/B9KLyXV2MT536UiZBl+KE9Tlu73N6FJaA5CWsjVwYyu
-cnlzczxlnblT9ArYqes9I0DhKN21tpPKDj6dzcIhiNGUPnIvmWFO1DsGr52rzz1wgh6St3jllKL9Qr4+
-5f3D5JLLKkTkgUj6VBUaaWp2ja4PPVdSI9yWzT799LGPv0aVjr2Eu0pRz+pzr732xNWiPHgW97JHkIwq
-jtdHWZm5zCe1sVhtrqI2FsPbqdBxJFab20ISmTtrqVwCsSIPSejjpXsS5bYcrjQXAbcL53mmGF5aUVVV
-cWxvRVXVnC+gaQ40/Hno7e7587vn466qiiE7ecserajSc7Uv3/wQZIx+OLpp/oYFCzaYeP8ecTiF70Ee
-FEAI4gFQFV4CGch6n2zPMJ7C9tLtm3925czTX0ilrNqKpZdHJ8+48tHvf/+mm75/E75n6883X/mty/+z
-MyNoF19y/+ztm5c+fvXsn9z0gxtv/EHJfuFuWqfRWlVwmYrNci3qXKrKLFOcDm1gl1D9JSlzM2LZP3Jy
-Xl9pclR8K5tsz3CsxHgD2OshHHc40spEwulUKMw0qWcsOGd+piGxpPvazsUPbptT39l1zsIzDnqjjSxU
-a+Nr3KqHsTBWK1cRtVfVMYLAybO/tfmqKzffdvqsG1bMDGNu0updy06/bcuVW7fszBna3JsmNUyLhCSB
-tzuDYiWPndOiy162QVEWeBNfhuyoiXIa6QwToFbPTIMW95aa2kyBdGlXAT4N7JHLGIui+iWPyFkuy3Gw
-M55+1dhJWF04Gu3Ivvq40f3kK6+wE4yef0rDavbzK4FhsCx5mmzilVnHqx1RwiZ2RF/OGi89+corT8LO
-J18x/t3oie+D1Xk93XdwF6pGKSKlWPhSDV1aLdGj5u0HI8mwxPIZSzqZAVgBa59o/0kRl1eepLgw18Mi
-QTCe5W1QNVFfvLRKbJ14xtSZTW6w4a5vpwoY7V5TwKfa+A2v8sYvLdj6JjAOzzit48Jp7X6Fh7dFbkSf
-+DCVJ5JU+isnm1pKthHbhrZS24YVsGLyaS8Yj5fjCz+Hu3iVv4jnc3/hVf5Lnr+IV/kNgoK7vM8XsD09
-+/si+RJwl5kn92ee/9L86Qaeyooilb+fIry9tbiHqCaS7UUDzCkQUkdtYyVDqmmKmYaUxw+JZCgYhgwI
-giIA5jlxX1YUChaY2X0ixxtDBbPKA1lqi0lei9kDIsf2FDPuFzFvDBGyfsFjcX9pRvIKrLzKAzZf5fn9
-7biL8PumFrVoIlqieBzUVT+8JauqbIwzJz1q/jQHXpcEwvQIErxuzCkwnSX2l9TuvaTc0sJHQYjnIRSg
-jAnpOIBjwH0Y+XAvTiCV7rIWIJk0pvBxb758RZbhzyZEXDWqzH6pidwKAE1dAPLgBE4hG0LuEovav7Gt
-FRVDb1f4fDhuSLaAzZBsEsFGorbROrudrge1BSqcWLk7OJaBFHukuBlcwsGTsImTjrto+QhKN5O1uIdQ
-bSQz+3JJIYVzCT7sLtCpvcRKOF8xLxxVCX0InVRjHGkVRoeXVUEy6qQmchNUeNnokARSW0FCCIYNxOAX
-8QKq+U/HPapbsYT4YDjJkjLNGym4cMOVuhLgaCszxnhJZYy4rHKqDG8z9ObH88HkqcGvwveMSbKVPCZK
-VoU9olplIwMvK1bJmAIvU3pAjO6x07482sqjWCdXe4o9q7iA01rBW7SLmXJSaY2MDrNcBulkXcezkQ1V
-U91B0W66nD9hnoPPZavn2Cceqwyfy025L0qEMDwx96Hs8chMjZybXcI6ALp9eIjdjOcgDiErw1uB3Ww8
-Bitfz/2OaWS3G48aj/6MaaSmyQigY3gIN+bzQsQKadyYe/dnsIpkCtEQrPrZqPU2UbbecqH8hoypYVIL
-aqcE1T2FmyHZHi5fi6/VVb+59UJxzgfL1uYflr3OB1HZ+l9bvv5rJirmhH4cbOZ0yriZbGkZROO6Ejqe
-rM44KEMkHJTBotQCT26eeCd4qdV7exukya28zh/5rFu3Wn3WHVbytO6wlsXLcNk/dq5ifBR+9eU0KCLn
-iacpSuEgX46QuphXhbt4/jMyg1/F82TtKifI+LN5/i5BEf4qCHCVmR2V2Ql0oLnlPSIosarSAZ50Bsxz
-D3xQ4ni6I59hk+2trLvYXcZsI+j0T54e15SbA5zFX6t4JTf4pKoKt50Bh7SfDzSGKoOJGZMnjRhOjUb7
-HlugbUHcxzkVp1rh9fACLyk1TT7B5WyccF6qbXZTtTX3H6UWaOyo/pQZs4ePYMuOdPbQ6L7WDK7jK9Mz
-ouOkOydz545YhMwtR33JCFpGX/nmKLXSGLGDaEMXmDOTHwLgLco7EvBFG8RWiBTNEDOQTran3MV3Eozs
-MQfAW9xBzUC6+JtWYBw9orxmVzXdBz5UvWsNxW1Uwis0acbF3r0kaa/34hnlCbj3RL8dSTB+ccKfFxLK
-7Ck6T2JPwXtPupFYC+mTWas8MJXnt23j+am8Sp4qXxY/ofnFuSf6RT5+nI3NuFPUYTTzeVKkb17H88aP
-BUX4uSCs41V+wesnQfRaksN4jeff5FV+nSAseL0ct0qkHT+uQVW8EPaa5hnJ9rFkrGnM3LWf535wyZWb
-Xt94PPhNW9cyc6e9lftBZuPrm640eQcvWoR72a8I7+CmbFXpmUbCbEUY0ellwlSsfs/rzP3dycyEZxyi
-anxgGit/oIoOeMYRLvAii/BuszxrkQ8pPRXJXM+ITlKMN/celdjDXvavxtmOsMM42yGqEDIPQoZUkZb3
-fYTw+Xh3vryyo5Zk3Wb2jYkNvmMUCCcjOvPlefH5dE9n5AxngY038QvnwTtGIYV7i/V3Fihi1vf7eDc+
-37THLtSXCFc4PaqyK3qfvLGeiy2/7Y3rvPjs0qKZ+hmb5619/uErZvJgUcWSOUZFzaZVJeF+Ro2jFOT5
-Q9otCxv3FvYsiKl+VVrxlaAI63j+qxWSYGhGX3katZfrUwVpxVc8v05QhK9WSOqxhX4VYmWJdL0/A52B
-1+A1eXzyg7vIopojY0ws2b/nAaojOOTsx+OIVxbgjaCQ++sYOJavf7VEFj9uvfC6TcOztDtVsl6Mvdq9
-+JZSiSfhkJJSQngSrhx/onXth37FeFvx+xVoVfyGfbQNNVdsszPQ2WgVugRtyJ+ZJv+9nnSqE1LpVMJM
-YSh6BQ2ZxQ+aK+TS6OZQYe6Pp5Jk2klncH7tpnuFXCRM9w5J3E3JHzWFBRdgq8JxHs9PVdkv2MI/9Xhd
-qnecwyFhnwCyOCW/iBndWaM7v6ZNsUlWHycxFtVTyzz8FI/F34sim2CdRvJ5wckmWFH8fzY2YNwhyrCT
-WSqLxh24FzOM6NjisKlWh2eLQ+Q1l1OtvCZmteULPQAxo+9AHpqFw+Nu8Cms4HFJV+/hRUm8T8T7Huax
-+C1REoc2FuxqYWdBP2DHB/BTqBmdgRCZjCyMhFshEubDEj1enmxPZyCdgUgrWSgzFm/YQpVbEuYl8KTS
-GSadYdMZwDuEKqZl+mWr7rrwmzMmTHFhV6J6d5WnSTi70l3HWhnnpPGRs1add9s1P24Q/LXhPVMyE87b
-tOwsb6VU0aL57rxi+cxVly6PejB+inULLxgfZO9bOt4l2Gx2AKa6enelOzg1LMxRXZNr0xdcf0tnYk6y
-IVTdNsNaGW67QtMCqbMWrElOmIwjjYHlU8c3TL3g+rPjCgJ0PgrgjfhX1HZCggD2tvIZSAdYCy9h3hIJ
-p0l7t0LEI7HhCKl3BqctAVKhViYSzrDMjkitGJkwa3odrhYrRScL1gpp5Z2z6yxOR2bOooxndrq2wmJz
-RJqDVs/8aaKanLd8ZdvCNQyDOY/W0ShY/JF4Za1cGQzzcvCMC5fhX512w0XnzZhYb+cneCcEUqHxDdGK
-ppvveXrXnPSaWZPqLB6bxcFirm7CrPNWbZ54yb4Nc/28Wv/Q+d5k++TJpweTq8+c4LE6WqdOO1s7e/cl
-Z0bp+Bx+H63DfXgm5RLOpOOTUyQu2Mq1ZzAXtlCDmSBpzFSYJw3oDeB4hk2nwpEwR6Kegq6PUIGxPPHw
-NesXTKypmbRg/TUP7yGRSTU1Exesv4aRZYvg6/2y1yvw0sxzHdjmiZ97wc2333zBuXGPDdvPzQ2HZKu1
-YYKzRQpJrBxrsQXxzJYzzrvwvDOa6L2lNPK+bHFPzGzr7d3WkXZb5NOvdI6vqJ+5Ysb4caevOL2+Yrxr
-c6BebquYMc6B5aDcIMfOqMBk2A+/jxDuw71IQRHUgboQ0kJpetSSS8S96YQ7HQCqziSdl9SqIV9pb7oV
-KBW8xVozZp0X3lu7/LtblsVoNR8+a8q0unvDbZfdEVm47v6zWudtCduwPZU7k1a5oml21yXJ6KXf8gr8
-tmJ17R6W1HarsmrTNy6sVs3KylcbZ33v7Hfaq87duH511f7xt3dWjHfN3EgrGph85rSJjgcqnl5PKt9d
-rKhtCc7Xkx1+BCGuAj+DbKgSNSIUgZTHm5egG0aMADXK2poH5YPhNginzmVuUiYozE1qdcPgZw3VIMIy
-OnPCMuO0S8rmXx031XLasf66WTO2mydqmN/80a8MkZl3SPHnbUhUOtc2kr7lUV1gHtCMNLjonErWIDKN
-pl2cxTyxCa52pmvbUrhU9lS6jN16lfsXv3BX6cZuV6VHhktx79KMsd94VRqvRBWH/Svjq/OVqarTesMN
-Vqc6VTkf+K/sDiWqjJfgdFhEJBRX0TbSX3Jms9Q6ahE6By1HF6DVBSlh9An6ERv3ktMRaiKZKLu4r5lG
-NSFx1X/sJb8apzI0HPWDbKor9OLf0LaxgoQdiJun7uOEX4rHVb/RnaugvNMn2ZGfjx3M68d93FV4Fz3r
-lC5wCVRRClphUYMEqS9fkIoSWkmuSNpL3uINX8nyV5L6aG6v8QGB/2tm6V/V3Hd3fOPXVHEQYqvMDH/9
-3o5vPKriHR9LTdLHkqD25Paa2qelPapg3L/jG6ZaaF7x/fd2fKNHFUbpM0ft7IArZPIzOnuEGq4+a7yl
-Rzs6cJdplWL0Qcy0TCnYgND2tyI33c0tNq+WKGlZOuslXZqqqDUQsMQzZA2bAj1ZuuXE/Np8gmypbOy6
-+KEfP3RxV2OlBWIs71BqI6npqUitW+Y4IA00OEgIQO5Gt9EXPWPdkvMyjY2Z85asOyMKsUFrhdocadCq
-PU7B6vTWaA2RJtUlDOY9xPThLvYQ4VKAt0IEcNfPjEeNR19nIvDgG7DKePQNJkLp8gmex75v6q7SVsDz
-cu/l1VCsmnuXibxhPAqrTPuPM5HOXcAeQQqKFnhUOuop95fqAJfW4KWnFDSvlj+kQMQouJf5k+rvUQWp
-2DDGzUYNDcCN/076XP4le8Sv5t5VJbO54Z4ffCWpRneWcPMkkbSjBSH8CO4lcpybslaEl2clCGleLe7J
-nwekbjryT+qXhg0Fw+2pCStcNTjM3lAZ4HQupuVuG8d4ad/3N5EmafLXVvgt3L+mlEAA93qcexoqjQa1
-OetvGhkifjWuWEX2X/xN/tIzEl3mmRlQtWTCVOCqnObScO+xhdmOKMSiHbkZJMIe0amddHao3/wt9z7u
-QnbkRMjNugo/1VQONMhm6U+bJsFRwmAdWwgxts7oJr2xI5rVzbNPcDpC7KX4AKqm489CbY5KmyTfBsw1
-lNyjGiEfwY99Jam5pwtUZ5aZpKb+9GbgA+yl+bLzo5bQNS8g5/s8u/crSfpKVnN7VdkMMUtVduVHclT+
-SLYqu3YpVjkfUe+7T7WiEdsIMpZMS1MEbCuEqLUdV6ZQ5PIzHr7nWa5eya1U6rlncYtpW0bHrfGMruuw
-k3nf29Ko5vx6R5QSWY92ZAtz1fBDRR8bIfOcDZ0C6OhnG0JujyUUJEtXhJkC7cn2DHRAA6jBVnYKtGeY
-NF7b5Pc3+fXc+eE21qVyzGLFj9OMzZ5lHh+qpMZEqt9vo4ro58yzX+rQ7qzxZ6dQo8KZ0iCDnbJj0Ogb
-BJs7WhOvalTsQPvPHITwxbgXxej+GG27AHjd3gTpzLyEQ8FWTMQAMpem3RlIExHLk4inJQiGmegKpcJS
-segbcucWl7umqkII6OseXTBx03lnZ9rstoqqGrdrS6f8jUUVFnd9vD053p6eOQH3qu4G9/XzjFf2+6ON
-PLbVeCpEyVUhcVhojPr3w6x51yl17qpjP7JYxk+ZggApSMEP4Yfoybc07yX/Ijz5l46Qf960N83W9c//
-00sv/Wl+f8uDD7aUhNkXjksyw3T90Gkf0FALmlJYM3lTGUHGtMlLmFN0J5MBrwyuhDfhTmrpZPkxUObO
-eOPkGpjdONn/iTPmHeRn1tZOaUkr96x0jK/We/Tb3xk0MvHclqJpAe49M9Zz7ZkxvcVv7K3U4j5ffQR2
-1lf/9Gc1jXrfb407dLjq2oEDRl/e8oAqsEttRLtQHRqHJpt4e915DUrRBqKNyRtKprjynSM+5KqFRDKi
-JVlm6aA35vzEP7nR+EHN5MZ49XjHynuUdMuUbbkRQxXmgtv1Hj0eh0ODuEurNPb6W/TYmVv4ntiZjTU/
-+2l1PeyM1Ot6KaK6ccdv+w4MXAtXmXTmJLwAVVMfEwgaUrUQABnyEyZrGrIS1NJel1dzm0e3wiGtiW3F
-kXAnRMqrADpcWutZ7wD7YmhdfNn6xetutlTLxj7+qRppkg4NJPam6q7wGBMYkbGyPMuxYHd9e0luwYht
-x9qPnZ5uR1i+umvnfGO/2rjHwThcDeJsw7oMFqmN8TgwDMsxPs/3F31Y1giF8bwA+VEKzUQIuAAu2FhE
-zNk+oZkm9rzXw0tcExPSVNpQWtrVcFx9fg/rGUFxOp7ljX1yteXmdWdfftliaD3bBo71njnfdtkZlmN5
-1sqKYDR8INdYboLwOmMfs3CkPy34GDiny2vL/c2xp1GFRfN3dl0thx3dnue+7/ExHMswoDeqxv5l8MWf
-j6sPWkV4C04qtBEig4ALQKFObcBSX1O14Gr3JtvDFtVVwxIJsg14WqNWKDfMgz/snE/ouMeR+5urQbQW
-qP2zUsL+Gb5YRmjfU2Jjw0mj2lMqbep3xmxVY7/Z5jlbyX4OO5xDFvYIp9Az5mdQjoHO56H8/G6u1EkX
-byESQ9qdwQVDcB5YbzrlDXA1jOpSPCnTBYsrAitK0AS5SGFnnvSMg5A+d8efFxVJHo+TtlhWahg09PG6
-xetJ6y62k9athUsZwS3bnzKbHn5l7F/ybZcdzAZnRMaY4Klwq2+SbgENOmKQB3nwA/gBJKOG8n2ghrDF
-P7IhpiqedEOqHQ7/2VQpmQ/m1uPOW+IHim/Jw5h43OFNdFK4DrAoXo4QqkjjZHu4DC40lJ4+pedRy+Ae
-GTnImgeNTg4X2HDQPPrpKli7J+Kp8vrOPa7Y8vpOBbfTOYoqJ4fLUWsXqvbzxNPuVH4jqKy+u44/njsa
-7qtjHcplUSWqxPfj+5GMetBTZZAbJJa3hHkJeIsnf1c8fDwA3gDjDTBpj0RDikXxetIZJt0KkVaIBCWG
-t0TCqWQrRFKtYMlrfshP2FrIMJFwBtIZNh1Oxz3xVLqVjbRnmHAkAJ2QamUiFl4JgNrKRDKQ9sQzAB8e
-+e2D55334G/NB+hyc80kUZ4iK50s6wZ2PGetsQTB7vHxzmCbTe2c6OcVH+tQQm6eu2RzQKtnHePs8umu
-itbGmDMhY7BEMbC2qgqv222zOC2N1Rbeo7otHGPjWNbhxRYrX+mxOIU6l9cqNmnTbJgBlq1N2+ycPzbB
-UaE5K4TOJidbxYoVogvfX8SNPFx2F29lWAvHR2Sblw9u9AS31FhnVYoKFiQntrYGsBxucqnVNZivEO3M
-Rn99pk4AnnfagLHZQl4YDw4rI9f6GMXnrwk4LQC8aK90OTBrb6hzVni8DqlJ8YkVFazV5o0oQQtIVjsP
-DhZzNfX2Chte44o3smC12WwNvGh3FfYtGHwA30J9xqW8nDftsfASRArOTyCcYo4+8U0jd88xy8J5k2/t
-yD0rN8sbZJlZGlyAbznvojuPXLfstuagzZN71ulcLzc7mXMu+ldqH7kS9+Jni/6d0qjjeNtfLqQmyg0h
-OU3VxkrLi6eU5cWubHaoclSCYYej5Ul9EMPPjjDCup49YaR4Zr+Lnv13mbiaGBCJlneB1sNup4YQ2wg7
-0pE19rDbca+fMsW5GUZfFo7C0TxvZPJ0XlRNRm1eBUI3XZIhVXMVfUQkkqAWaglHs9EOIiUZ9qxhJ1JP
-RzQLR83a4N6OqN4RNboJjx/tMB1C5GZkqRg0SpZQUTOaVvCeNWozcpTXqLJdlTH3NrhRp6cLpyHj5kbF
-G+bmxBsrJNXPJvNbG2+YWxtvrJAEoy0fKGyBvCEozJt0p8Pc3cgXgHtH/04tWrWOBpILF8GpBQx+Obp4
-UkTBNhfvxXvpXmcIdaDTjrMWKN3xLBzboc9QMMIX1LSpTiacbMelr4Phdvj9Zx88esEFj37w2QePrVjx
-GPys/7UNG14jN6Olpa6upQ66WurqPJKcdiVmJ6K13wCrcRt9wfjpA+8t/viDzz549Bzzx6/1v2apa9E0
-kkUT7/HW1CcS9XXjuetAkrxP1NFf0v8Ihn+HzsWv4INoOUINkKKqVepWFPES9gYgEU9DKtIKoSBv4UEi
-wxhRqwhvgLQxL5meaiPhVNrcnMmng0TmaSYIv10u8Fsu8PiC08Z1anXG7XIg0OJ2cNZl6TkJpr7eyfsr
-qiodVcYzcrRGglnB0LRWSY5NcUiVIXvjVevvunSJNGHqzC3BcVUzYHhW4/JLF86ev0Z2TZhuBOQmp5Pd
-D+8sv+jCB11YqYprtcYdcrU/2Zj2Z6aT0iNOxWuvMp6ToSYqwbxgSAMhHW1bHbI1bV1/x8VdfPXUifN/
-NAOGZ1emtfpbzzn/e9ONepmR5XB+DBf8/80YywMgF5TYYCtLlk4lwHg9BRV8OoOT7eET+AeEkNYZb2nS
-ItGWzuBpG6qnxRMyL09qHq85gonoVDsWmqOdfu+6k/gRbAmnkuFwMhXeMM87YU77eJmXJje1VlaOa5xq
-w9Zo04ymuHvB5YhBE4c/w1fhHyEvas17kKS+hsNtkI6AlzcnZTbtGW0k6qkF3gvMM69O3zF9QtBf21px
-wRfGNX1r3TumT4fqirWidcf06cZH0384fcf06Wk/eQ234B9N3zF9RdOlQX+yteKCL41r+tZV7Jj+w+lQ
-bcxcK1hJ0Pho+vQd01+dnk63uS74Em4xZb9s0Q+MivwjZ8Lzk0cGeJfmQgV7a8u+B/RJpipx8sUZZqkx
-vGTKlCVTcG+gNa4OzaIKjFfURMs+6szGM4W8LfFJ6DH3l0lFwRUBzg0RjcxFqTh7K1TL/iap3vjPPuPf
-jCNvQuJtiIEtJPub8D8ZH8tNfjlkfG70vW384k2ohPY+8NZLps6KhQhyYSvuR3E0lZ5nVgPYG2plIy5v
-AKuKhVdDSboXlQiA1xUxu0866QOJVZUA6/V4XRnc3sowB8OnnzU5xjyKHf5EZGpw8sJ04wsP6j3nNPrY
-u7hz5lS3Tp8/ri5+9iWT/E1NquQO+3zWSzzR8cFKXyKN++dz7ljn2un1rbFq3viFg3e3TNYzd+2zz1vg
-PKfx0iXzLnTCAkHytU1cmprZPbvJMs+ogAos+VNhf7NPESzsW8bPGaunrv209gZffo/gYvZevB/NyVvB
-h9pbmVDQwpP+Dl6PN6GGPBbewmcgkfIk4mmv6WWAbidGMlw6A5FQMhGOtDLUB/H4xbtX7I5M7qhqWKxE
-q6sqLexfXxdUtSUVvNBTd3dwUTJem5pQU+ddtykcWVLpTeNK3m13WcfZ6z94yqvyIiO11K/C+xfeNb+x
-M1jhV2MTq+aMa7JyevymQCYuyhAMfMftFSBYi9kqu+0e1sk5bC4x/a1ZettEpXnGtC5tKR3XvuFhvCvv
-NyOvIyrq9xKcqfULFbWtO0cp+bKM13zmUyHmkQZleVDysNudX0nq0DZ2u6nwwwQO7dt8cfUOJUP0rHc5
-TDWRdPMJVUumQ0mNPaLrOsRye0cDhnv01fRMdxZizEFdh6ND20Zg69lsrmJQ7xnUzXOnBdgyPafcMEY9
-i/Bceax4wkOU13ZpASTF6Q29tL49FN6xhQQXPZstwKV8j4xUet77OLgQcoWSCXcoeUKIev5c+mhghh12
-Eliw0wRVBss3NqwCFJZCPQ7WwTwcCq8Ulgnm2EuwUzdtEwgs09dK4ST78RQl9dKSWuEqhwZHs+Yxet3o
-hp064TJLgWazlFsj/78mPC2psYVrjNqxdeYpfQqMgM1mTwSPGeWHE8EpfMfACti552D+xAWzxjwYAEfp
-Tk8+lR4QyBYODZB3I7q+ApzmU0Ma5VZoBSnzlSeN7sdfPcjoJVCNDQW3Ql35owxPws7HXx0F/qZRjoX+
-V7hoI/QnuDz+auGcRykFDArAnoeGuw7uOQFC2UqnifX/ki55XjoVL6VLGS7HSIWNbhMfkziPv3pwz3Ft
-8ytCPGfOVdyDoefRavNnTIqY5M/i513W5P38jBznKPEXyYQLOFGt3CECyMiofv9rMMPpNA46PeZWnKfQ
-YlSTZCJDg/9pfCiFJeNDSVR7qFVajyqSNeldxLD/jh/J+9jkLQzfEPfwQQF4SzNEwvS8uhaMhNMpRPhR
-1mcVWPZ+BmKVLgmML4y/YbtktVo5ZmEkYrztFUTnXHC8ylmd+BGr6LIZi3gIVRg/N17nZMGmWj9av96t
-Kkug7TcWZ3F/yiilTV62LLROUdYsPStU5ktzRd4RboaS/5DpFJf0o78eR5w8WUhL0aA5rsYkTqnN2/hS
-m7ei/E2ks9CJDlklNOYTgpcqXW5k5ai8Q5ZhwuWSYPz3Q+VnrJ6EWN4rpUAzyzvkKM2sGn2FXnVv1nin
-MA2Q/i0hCe/Be/Jy1IklqIKIBAOfvPvw+ec//K75gJ98fOiKKw6Rm3HNYxs3PrYR7ym+JI9Z5stDHx+6
-ciN5PzKmCD1qR743cZznvKKzRbIK0+9NfK6IknGjFHEYtzis6qDfuIU9Qs0C2DV0n9/Y45GNJbIM+2QP
-rFD9Q9vMTWU9Hi8Zy88W4FrNs08lblXTkIHRcJlZ8A1JVI8GPVmlclCxSXC9FMl9VQL3EWOeXDeoBPzq
-oCbDASczdP0I1ALMuUhBARQc26+tlx1d1bvhCl7l7+L53N94lTfe5/m7BJXvG4HJ/AauMBNzfxMECAmK
-QHK/PQK34Hv6QbrvGEIRhNzUmJKPhNupzM9KEOKozJ8eXePbvik1SfP65kmM521JZcCv5p5RBemtB/qM
-KSMosMPflKR5fV1y0wNvS4Ka+64SAFV668G+3KRSmuNiW8sFPKi23021E5F0JJxyF5UUZS2+gjmnUOiD
-b0vCT7rkJokANQZLW70UwQfeltTcwnmSRKtgLB3BhEF4+M/UrwaHBIQarODSrKAC7jX8xs+MK/CzuQTz
-zBpQjCvgabZm6KuP2VdQ8RsJZl/1maeXmsGlFcwpOkg4wYeSCTIj9ORm0BMlg4Tr0nX2yNBzqh92msfu
-lQD1k5M1++Hp+AMq91TR85a8eaxijGLhOEttSMVxF2P89gKrz3qfkS0FCL3reN44r8RQG6K8yh65j+S2
-fmc0HrlfUkPt5SOG2hDlKW4Ojs/jliE15iOtwJ8It1NZwuOuNbfKDU7j78b3R2H695myfPPNsjxTjpJn
-VC6Ls0eMv625VWYl4+9laN99op/k4yZ9VSpHkDYj9HVpeUPU8iq4C9ZLI+xbsj2Fu4w9qt+wl2Lco5dZ
-dvjZI0afX+0pQ2+vXx1UpY8l6WNJHSzdRx2FTyQcHJOkbm/5jNRJ2/tJqUkyvjLWl2L0XyYaql5iVWJ0
-PynLxhdlON3ykawOKoGAMqjKH8kUnyzd17VSHjavsR1tr+VWtST1wnBsoR9/x3SzoMNO2s17VL/RV7A+
-J0IIQhbEomvxfryLjnM/9ZnbMcqaIknLLy63XtKB0tSyQi1YWnCh5IinAPbpLyXpS0klXLKaazNNUrK6
-btz0haSSQaVKX8jsjbquJxS/X0mUWEsREdC4xTwLn829OUosvDpbcg5eL+ofzhzxw2ZulbZi0jINcU8t
-uBSJkWH0mwxLbXJcCddkyDCFcHuGZVeA4mugaz+1lmgwBjw+BlS/3yaOpAIvuh2Kj2F1zqrKXh8A9b+C
-x2+qUGqPUcMK2AFCdW0yAGtqZ9zjszuMTE3n+KjNZkyjb1/Dcmvr1Bo4xPAuh4283wMrjgsXfVJ1oQRC
-oAYlVi06kElRl8GeRMGZTHu4DZKaSvgQjfCRSdxLkaV+ZOb6DLtvbt6fTNzng51zfXDUNxd3TQBOVKSh
-2z0+YCZMcDTAncaWBscEM5ndWkg2tsCdDY4JRd1tF7UHqkF1qB41ovYCzxbikhEtTTcHRn8WQksCl2zg
-VC3JUXU6r0W0dIJ5HK5S/WuNd7NGtw5XGQ0QoxxtH2WNYsxB0n2GKknIrxp36MYdOuzMrmWP+NWeCy/M
-6qOYbT2bNb645fFbfpbNXvyl6tf1LALkRE78KH4UhekK6jG/DxYAL/1WGP0wWLDVEmnl0q49rlbpLsni
-uurioR1XuRLuH7t51+Oso3V814KLLt7aMeeqmXG3Ddj/3uOyuA8p411bvjl00RYXlm6X2lx7AAtqXfK0
-1R1zdm+78uypzlE2V6G8V0ZcC3SotMIUCNfxVGlIBleGq6C+q1Qo+5oHJNszDAz94KnNemfMavUrP1f8
-O4xDO2iAdYzvuHjTz7vPCnsA4HJJNUe5KsHljCeMu1StsTEoilm1kg2yQfXblZXfVoNskK1Us9gRqW+u
-Mz5019SIYtz4tRxXqIeKPiUuQ1scOzRfqY8xB7UkRcBrEVfRsLNgleHV0tSMYYQJwl3Z3N4s/Rt6zvSu
-Y79jaM4d9oKHnWML/czBLJXbs9mhfn+TX9dN0zZqu1a0YSzMcX6ETP6a9OuSkJZ3yKwyB/VoB8Q6YI35
-zFUThlmPUgNJc8eJJORmMAejHUP9QL0bUHGs6PNERm5US/2Fj+EHQ3NxITXh0pKJZMilcS4+5ErwroQr
-yxzc1tu7LTdjW29vJbvdsBeUOlTLoeu4q3ebYSdZ4Oi23iyBn6XO/CBGHtTVH3VG/bXxyGu6aAucBA/T
-+PXEWJD2yatNiljgUThQaWJsSrgSqmb6ziLPMWlAKGA+xyJBAahO9+JGw/VQDwFj1bwFHHB8TU1YRt8Y
-cAx7T09Pzxj09aK6E9GX1VwadVGheDqg4BctVAaV2jTqN4UnpqI3GfaboqmJ4TFpDEf1JdOmLYaY0bd4
-2rQl/9v+Zmr22FAycbL+dqoOR/V8hl0v8fNDcPBRWSpyAiyof0wTPuXpj8chN4M0J1Uu5vaSfj0mDkY3
-HKUIZAnGehkdalCQzs9jtTofSqaToeTJiPCG/kaBBG+MCd7ozsazPXqRAmR+sSIrfho/TeXmhpOdtST8
-jRZJpjXo//T3j6xY8cjvzQe8/iGRi8ntMtOHJ6EyfrqYgTy6zAyHPjz0FnWxaepXS2FH6Bd6Twhd5b1q
-KDKiRj4xDnGdcLmEYTwxDnpcj9NpQdcRYoY/G36YfouujvpmyX+NjszpJe6UC5+ly4A7cbwh9aiv1d1g
-Ojr6w8LuJdOYC/d943irFvoZu7/uN7UwVy44/VLJXaHvuzD3omnfctTpPFp0OU/xewj/BE9DNZT7yePn
-VSw8PbU+CjfvcV7nU+lUHP6+/uVYc+vV+2DrucatjgaHcbN1wRUEu+9+PNpy6Y1Kt4+gJ+/fuvqJGrjE
-6TS+YyLo1r+7OvftD0sNof6t0l30F9qFL6ffPE5qkICEldxAM49/a4SMzEHYaXy2j/zvM/ro8zPYGSeC
-pV8lPQZiMM94kbzUi8sfAnQ1QjiNH6TyRtzjDdCvHdJPBQYjavEzFkkLpQI9q5NKxL3thCTM0hXfqVp2
-TUfcunZOusV0INWSnrMWt01cO4erYB7HD16xzHLzuVPW1Pqr5qwNHDPhHgusnVOhqEuTc9YyjJPb9Djl
-9SJIwXfgh5ALNaHpaA66EF2DEHgqGF6CYDgSZirSGYh7uKBpZFQLnkSc0D6dgTYIR8LeUS/oCTma7sm7
-cU3T9xLQo4hmjlS4DcjvLPQAVvkL9rMNCxZsWPTu++8uKgSMKRhf/XwQO+z1LPZaqmfNXGlzsFbWxtdg
-buHmSmx3BDH2WCrOnGi30/SvVt+9evXdq5eQtEk2M00w8zrsQbxjVCHCSOnsZxuM3xq/3bDonHMWbYBm
-aCahGgg+f7Xdw1rBs3LmrGpWtYccFnwPU7l5oYOkqpPOrGBVW8jO40ECdbWqTjrThc2UYq760h8XS6R7
-JIVvetiRjDx0tkRE+kxqeTsVCCXZBB9JJBMsnTo5KoEyByH2kvGssV0WmU6IZfU9fX179JeMzfSzFOJP
-2DpjezbL/E2Ujb7sUH9fX9awyyLclf1J4dxoAa4V2ZEbVVHrd810TkuBuqnT5DRL2dWoKfcmCVhjT+69
-PNTMHn1P3z7jYVGGbYwgiw+ydbkvYUXuPTzLBLwtm+0zdFlMp0V5YYndMnsUCfndp5Ca8GquUJpLxAMM
-mY5ZV4IwxglWAw3fuUfXjb49fbmKbHWVJkEstzerS1oVc+Wnnw71QwyOsnVZHWJ9fdjVXK8be+BQfbML
-G91kURq1R+BDceqFvPS7TSGtRMPMJYsrVCRNyaCaKl8wPeAxd+r5Y0qmx7e4H640Hc2D++/GDzuiSzMQ
-yyzNvdSXzRJRPD5i7BlX/YM9HdGh/mgH/jvMhBnRjp7M0qW5j/v26Hvy/PBBimNqxAb9xDiGXPTjCQUd
-9Gg04dBYaGazklZVbTq5NfGEh06IJ2G8CBU7omzdKEwRYof3IMR5cB/1Y1M72pONu/wItJpIMt9mrvXW
-xY7hWJ2XudYzdUbZsbv+LN5/rDecTofxwojRNfqsc6lf7BN8767cY6P7/8ffu4PvDhZ9fhRv8EAxOPSn
-sVL/Jxlw12hvIT2jPSqfMPXrvx8Zp5yCfChKV2jqc53yB7xGD1cmNNrfTiIr0g33FZnxNdOjjUNDjdHp
-NeMzsELXs0ZmRHK801EiOeLerL4gvX1RIrFoe3qBntWHth0vQOIibhzyoWbzC2ceNdTOax5VMT+WrEUI
-guFkO1s4kMVpDcly/MYtnHhoMkyqYGF2lA8eyJ37QlCMwmz2gIleD7iH5oC7pwRBTumIRnc0hAWX5rnn
-Ho/mEsLGff4mPxF2/U30s40UyYI94MOIRwqqN22JzE0aymopXi1FVvWTEM/Rs25dz7rvtwabI8bBSHPI
-+IWJlePOMnEbP7yOZDX+a+Kkrksv7Zo00dh4HNFGaCajGhQzecIAFM5De9OR1NfFa2nb8sSGhzYEd254
-PFh7auw45dK7UvM3bJifOfNbGx6vMsSTYDnS7ypQBQqiGELAqZoSYL1aK9WXpE+GGOHTGzo757lzn0ye
-Mv3yb80x9pyIZL3/dNfEi8/snO1b9M+LF//Tlrl46LrjsMl/l5rSLJo/DREwPzadSps9yxtSybig14hs
-w7Sd1dGaMrpT/x/WzgU+ivLe+///zOz9fssmIUv2QnazIdnsM3tLCCSQkIQEBASE2CoiLjflJhCoirxq
-qbXWWl+16eXVyovVIqV0RaXaV/t6a5tazzkeS49arUWrnrT10NZSq0Am5zPP/De7CamnPZ9DmH2emZ35
-zTOz3+c28zz/fyKPd2QvqT7CH5cMLle2Lx8cVAlaXH1JVpazl1Qvjiu92QTvzy8fHFyOdywf5M9H+BgN
-tbxrVvPe5L5TaZKFXxuYFTKoYHXguMnWUKx8rKwkHudD7d89aauusw2Mnl5li1bbTiq/VguWw3mcn+f2
-8cbngozO51YUTtrqptkGBmzT6mwn+QbyU1CyKVwFEWgq+ooOYIQbktD7xsOMllb+RKfMBlFoPDYsBpcP
-5tviZ+Jt9DnoEkfibWeXtv2J10jiCA+kRYPL+VhbPt52+aBibYur/bJ4m2ItnxYJIiCA9JB0BGzgIktr
-XgeGNON9skks8YInhcO1tZ9VakfPBoMYq71YOYIrsVn5zkExyF9PSl8avaj24trPCvrai2uxvlbZqhzG
-JF50blqpLS+odZAU4mONm8vnD+q1mRdkE00sm3/tSrmK9btPCj1ljPmU7b6Y8SmjxydcHWgIXO/zHBr9
-Lq+Xk8PNvFIWPo9nPDU1HkXvqdHsotWM3ibzr1Tm+b7lY5Bt4IRaaklqVjoy1KrDCQ7jeONyPzY6zMoX
-1EoB92Dj8OghdYNmqJ9vEIOjz5gdwhJsXMFbdYpV802nUqWcGOY+2/I6r9QLPbCSbPVzowK5LB9bUOGv
-MNhxJnKDGXw+XQeqv3/MUFyLao9N+US1XCzK56tNNp/yznMLb7+yz+p21XaZzVUes87ht8YFceWcdkNL
-TUwM+/K+StHKGp6rjFtorXpF432emoBvr6Vpm9Pg+9ku7C7Vor3zrrq1z643h4LhumlOo95obZzTsSq3
-N/o7tf3RYzfOVcN7+9dlDFGf7AusWnKZ3bPusjJXRfqxe8be03mlfWCCGZCG2bAM1mrvKrQn0XXFucFq
-ZjXo9NHY+AAQv3Z5mr2VaCxadGGgdqG4edcO9HPX3byqrTNw5w8dfFRxLIHCjfhtT8IetIvOL56beWXX
-gunrxZwvUOMZrfWHTQ2zlBPtAwNPLPW4G252WoIWN94wt6lpbpPi+Jo7ON3jrGmq7kjaQqmlzXvjN9Wb
-ML6+NlRnC7jmeHSC12Bxivv/arQ4o44G22eGw1kVL1/UsF/yOroG2ofbB47U+FpSTqdlvqvmp02qsPL6
-TR6zy+2JBEXdI3P6ZnbXm5q/2nx9zcC06EyHyZJxhrwLTFZp3IeB1CoV4EJYw23VFC2ZVdgFB8rTdT6v
-XRcpvWU737Gqq3xYiGbOIKUWNL6Q3C5kcumEGMGyuHD1kNmxZCNiMJFwuvTudHJWQ8AfzjbNjVTz1k6e
-f36vZKTte7T5FTTUxD0W5LNllY/xqtKaVHCYt4S29CcuaKrV60WHt15e3NR26ewGn0VYWFLSnLIKA2Vu
-WhfyPMNfOfHXNcr2shX1/kggSAXpKPghrtZ/xbK/6OkjlhDUrCuF7ZJDrQQTOjHiOSFaV147w+0O3Lhs
-9PllN9UYK+uvFYK3Xb+yd2ZAr79HSN4jGIPxvotC9z4nHb12pbIm9f3OzlV7dg/UfOpHKTyw8tqv+6NN
-TXVW65//rHM01rOI8izqqP7bz+u/MmuY4/6wx38afH1ow5EVcaU9vuIILsj6hnxZnffIBsUaX7Eijqc3
-HDlX+ZYvm/W9BedreitSf0NzzSNDkzWHHlE6p9IE8OrsUjV4oVot8esMPr8vZsjkMulou9DBff/XZXR1
-OvvoTcrNi/NDQ3lE1EnNdwk34SXKQeUgrsbVwpjy7uHZJ4d+cvdXhofOSDa9WS/Wz8Npo2sffPCuBx7g
-7OJpqSAGwaBZoHZPmplQDJ/cOzCwdwBHygMxOMDDsv/aOOg7AAS172coWrXWNNyTQpIp/y8t4qEyXB5Q
-HsuX9Zf+YYv/12vtBXJlM3V8Qi9k+9TxCfNitLSk/r4Rg+e9XlyNp/nYSesDTzwh/KzkrKVt9OGy2Zi9
-eIdUoL0ewNN8vF5p33yxS4yNvgCNh0V+r9S2TFtxxtFM9KWmCrWZSG2o9SImh0OakVZZnvyp2YQ9b7u0
-SO01lC2TVgFAj42gE0ek+8EANnqa3wCA01FvSGAW5QpfOFrn1UfSE+Ii2ZyoSMnCras6Olat6uhQPt5b
-2FsQnizsLYw+PR4tBBoC9aJLur/r2LHOzmPHlKcLewt71a+VE+Oxn/Nhd7dLADj2GAxLd4s3Qif37OjH
-6circNnntUszMexK56JhQ4XPORPDCTGTTo1PclFrLzs2oyEUM+gTmBV+213lxQaLUa+rdhhRsk2LdcTv
-fNzsXeGzPH57si9WZRAMjiq93mjBBm/1Iq/PHPGmjovXHW/0RswV4laP3q28Zql12D22QLanRa7BYKVf
-kir9ysmG2cu7IjaP3VFrxnq3waOL1Fforc88Y9VV1Ecm+hStg65P9t2ifify7/wpXyQj829EXyQTkgqf
-7MtldH6V0VzpdOWrbNZhOe9yVpqNVXj6v/QwM+p2eMw6VFZZbCjLVfgQ6swex1D52NujEOCz/gEz4VgC
-mxEjqJkj84UyanMq5K/QR3w4DUuT2zSjHuJI1XR7hU5Sft2tnHD7TLUWC7pYm7sb6+bYQjHl/ZuvwYfH
-3b2MqK1LOWgPmy3C70ftEZ/Jo9PJ8sxE4cHwPBk/jyuVw6O/KTkbEcvyei/3xjA5p0fCBtQb9IYJljUi
-4ZiWZoN+kmHVWDgWjUUw6ol4yp2OCD09Np3yC5fbHbVaTOgyWq3GnNNs7kBzq8VlreQ+CFuNVovRVVXl
-QOWkzv6jDdzN1rhrkmXWUUfQ43bqbLK6t2y0+u1V93+9ttLFV6xG2WDFqmkzhPetig6N5eWYSPMpAUOZ
-84ZCFGf3lb/lkgpnl0qFszfR+NXt6hVsKVvpxUZpUX6Y+mo+rd9Wcl5O728nlKNTvtPDKd6weUKZkPwJ
-Jx5Wk6bemtK5Fet5Zx89lC+NtyuII9p7Le38qXGvNmonuIhbpDxdrpCLpyFPknntBPlimu7DRrWbpxzU
-ujZ5LVAOFo2NKye0F+kTfwO/eg9SEVfKP9WbxUnb8vn80MQrVw6Wd5AL+fMueuJ9gAnzU/n1l4x/+Cad
-LRXKjI8ey6QMrpBLGNAuShu3rN1sXE3XO6wCMPGe+Mrv1zCNI5ji+kMRV0r3d1y/VMjnR3dPvAPnjv6D
-dwCxEZLiNukGcKitLD1dY0r2Y4VXvFxx+912p9Pu9uMpu5BR3P5pVeJI1TS/4rY3aHbH54Es7pf2gYWP
-tSUr/tE0LsFTgYaGgOK2C9fhqYArfq4y7grgKToOZJwn7RP3c7v5ZWZT8+JI3KUe1WBX3AGXuJ9L2FWx
-4nGN0j5xm3a+0nPqySdR066eH0/Ztd/ZyO9zPejBDIA+zDRhRs3uwpOji/Be5Qrp0LmTYlB497jy1K+e
-eeZXZ18dLssffP4y095s+EIu7nUSU+l2XRvydrxaSCfEjCuldnZSrpBUKL7oP7u0WKfk+SguXqfki3XK
-uZPifj64JK9Yi/7BflnuHyw6XomM+9AVR6BOS8sn1XGldIrB8hRQrTaeAjGIjaXJVGVpEPD8NJw7qSVW
-8zGwXtwh9YNbyzUGbXB3mFwqCE8dcJqtShJfspqdB2xmJW4w4Ctmqb/CqiSVpLXChK8YvUZ8xaQy1A7r
-xZFxLXI5oPYZciaMZnH5AZsZXzEYlLjZpsriS0rSapb6TUrc6DUqcVOFFV/Cl6wVarrWw7DUL+5QtYDb
-+9HA4h10E0rpiklHiXvKE2grJpuzth7bpX5xRNUyIW9Ox0yomQNUkbsSXzFNuB7xrYlJdGoJ5xy9Nvax
-NEcqgA5mASBv1en4s4Rsrl2YLmkziMPRSFgfjiXEZkxguzQd/bFoVhxR22wr876oYYnOEq5fmuptmOnQ
-uVBy+xtSFzTaZvVZ7L05Z+tluajB4NA76hracz2s9i6rTVKbfIXhGs+zKOgNBp2jNrNwS5ehamHvJf2d
-Lp3OYKi6atfmGZGOjX25oMcg6nVPuys17ofBJ+6nccnj9dJMdPE575FSM/6M5iVpaMxTrZZ93xmfEhFQ
-rMdMTp9wa7GKyJNuFS9v7SWLeTpXXYg7aUZfLnNGOBWbMzpfGBidn69FW14qXNh47tOD4vHrz1VK6E+P
-Xpr35vlcVhQu2PacdNlljtl/gVqpoCb7pd07v1oMxy4cu0f3hs4LAEYt72i2GXTesc/xzwuVE7o3tFmx
-Zf/ux8e5LUhQe3GqqlRQS4Dx+HbhVvVogGKIDwNgNwC8SstBAFTL9QVaqH5HGtdJBRhWFzytbhv7oKQ7
-9keK26UCmLT9x36uLePnLl+iUgG2SgXwly3qOaoptFLolwpj95TifIEpjpt8nX9rydM1FNczpW1jj0kF
-CE19HE9D+bbX6dyDk7aby/TvLd6v0rYxRSqoiPFz3iIVsG3Stfg/4TqL275PS/GcfWXfqculUmHsDVru
-m+K+qL/NGKXnQqkA+tJ32C0VAMuuuV8qgHeKe3G5VBgbVfPXpKWS7/O8dh4NybFXIQ+tMAyAMchD1YS/
-0r/X+Ke9bIs0gWz+DAEAnHwtP3bPxD8wgWnsg7EPIA/XQIz2Vfc7OEFlmG9H+DIAPyoPEgyraYM83sG/
-y2Pj2GOTcpVaZTSqbQWQNV+AMKyW+rAeXqOjG+jvGIzACC7+hL9vCXohLOwTbhaOCO+IPeJW8Tbxecko
-ydJa6aD0hu4S3Q/0O/SnDUHDWsMLxunGtcYfmrKm/abjpjfN9eZF5rvNb1uSllstL1iN1gFbg221rWC3
-2zfaH3XUOx50vOd0O1c473AedjW4druOu83uFvde99Oeas8uz0veBu8J32zfId+LFVJFtmJNRaFC8S/z
-P1gpVCYqr6wcqixUnqxUquqrNlbdXfV81S+rTlVnqzdWP1794bTV0x6tkWvurnk7UBOYH9gcuDvwZOC9
-6Ynpu6c/Xbu29uVgT/CHITm0O/R4eG54KPx4xB4JR1oi10aejZycIcxIzlg9Y2jG23XpujV1T0a90X3R
-92JLY0/GPqpP12+vPxI/EH+xIdrQ37B/pnnmtY3Gxo2NP2h8v6mm6Zam04n6xPrEwcSrzfbmnuZ9zcea
-P0q2J/cnX2ZutoYdZSPyDHm1/A35zVQ41Z7amDqerkyvSR9Kv5xpzHwzM5Jtz+7NvpGrzi3O3Zk70eJs
-6Wy5uWW41dza33pL64uzamZtnvWNWW+2HWh7Y3bl7KWzD87+aI485/32We23tZ/oqO/Y2nG448zcBXNv
-mPvjecK8fZ2VnYc6T3VVdnV27e460PXCfOf8NfOPzv+w+1Pdx3v0Pbt6Hu35sDfbu7v3eO+HCyoWyAsW
-L7hywc0LXuxz97X0re7b33e4X+hP9C9TS230w7c127m8dEdeks/lb2WBU/5jKts9vC0gAkomALgS3BRH
-qIe9FBfADt+iuAiL4BjFJWgBheI6+AxmKa6HLB6luBEq8B2Km6ACz1DcAjMEJ8WtMENIU9wDM4QBig9D
-hVBMw08hKdypxV8ToVp4ds+ePYkNm6/ZvnHTFdu27kxcsW0LdME22A7XwA7YBBtgI+yCIDwEQZAhCQzS
-EIS1cA0EoR8uh60QhIWwDXbD5Xz/qyABQZgHm2EzBMsUdvK1dbAT1sEO2A3rIA8J6IVFcDEshQXQB12w
-BBbDcgjCArgcNsN62AybYCtsgJ2wDNbBBhiEzfwsDBKQhCS0QhtcBAthFSyGtim1zldqmqT196YgOOm4
-lfw6dsIm2MbvQXmalnKNJL9bpa0bYRvsgiv4/rvHj0hADpLQBlvgcrgK1vF91kOCn3ktyJCADF9aIMXH
-If33rnLqX2rqrXv4XwI2wGa4BrbDRthEqd4JCR7b8j+2zypYB2thPd+6a/yerOJ0BGEJXMG3qtet3s8s
-zIIU/0xCrozHnvHjl8PVMAibYAdnbXPxmR3A2GMgwxT/0K828VAEEWxgRwmGUId6NKARTWhGC1rRxt8/
-OtGFbvTAH9CLPqxAP1ZiFVbjNKzBAE7HWgxiCMMYwRlYh1GMYT3GsQFnYiM2YQKbMYkMZUxhGjOYxRy2
-YCvOwjacjXOwHTtwLs7DTuzC+diNPdiLC7AP+3EhLsILcDEuwaV4IS7D5bgCL8KVuAoH8GL8FH4aL8FL
-cTVehmvwclyLV2Ae1+F63IAbcRNeiVfhZtyCW3EbbsercQfuxF04iLtxD34Gr8Fr8Trci9fjPvxfeAPe
-iDfhZ3E/fg5vxs/jLfgFvBW/iLfhl/B2/DLegf8b78S78G78Cg7hV/Fr+HX8Bv4fvAfvxW/ifXgA/y8e
-xPvxW/gAPojfxkP4EB7G7+AR/C4exe9hAR/GY/gIPoqP4XH8Pj6OT+AP8P/hk/gU/hD/Pz6Nz+Cz+Bw+
-jz/CH+NPcBh/ii/gz/BF/Cf8Z/wXfAn/FV/Gn+MJ/AX+G76Cr+Jr+Et8Hd/AX+Gb+Gs8iW/h2/gbfAff
-xffw33EEf4u/w9/j+/gfeAr/gH/EP+EH+Gc8jX/BD/Gv+BF+jGfwLJ7DUVRwTAABBUEQBUnQCXrBIBgF
-k2AWLIJVsAl2wSE4BZfg1vOikmmBbBjcuimZnJdUQzmZLIaMQpnCFIVpCjMUZinMUdhCYSuF87RQ7tHC
-TI/UPbhjm7bSOZ+HKdbJw0xPFw+zdPJsj3ZwjsRySS7STYnrpsR1U+K6KVHdlKhuSlQ3JaqbEtWdZEkK
-SYeRDiMdlqaQ9BjpMdJjpMdITyY9mfRk0pNJTyY9mfRk0pNJTyY9mfRSpJcivRTppUgvRXop0kuRXor0
-UqSXIr006aVJL016adJLk16a9NKklya9NOmlSS9DehnSy5BehvQypJchvQzpZUgvQ3oZ0suSXpZ0sqST
-JZ0s6WRJJ0s6WdLJkk6OdHKUrhzp5UgvR3o50suRXo70cqSXI70W0mshvRbSayG9FtJrIb0W0mshvRbS
-ayG9VtJrJb1W0mslvVbSayW9VtJr1fQYcc+Ie0bcMy1TdjPinxH/LFk8roVCLR2M+GfEPyP+GfHPiH9G
-/DPinxH/jPhnxD8j/hnxz4h/Rvwz4p8R/4z4Z8Q/I/4Z8c+If0b8M+KfEf+M+GfEPyP+GfHPiH9G/DPi
-nxH/jPhnxD8j/hnxz4h/Rtwz4p4R94y4Z8Q9I+4Zcc+Ie0bcM+KeEfeMuGfEPcuSHvHPiH9G/DPinxH/
-jPhnxD8j/hnxz4h/Rvwz4p8R/4z4Z8Q/I/4Z8c+If0b8M+KfEf+M+GfEPyP+GfHPiH9G/DPinxH/jPhn
-xD8j/hnxz4h/VuS+lXRaNR1Zq6S6ZcoPMuUHmfKDTPlBpvwgU36QiX+Z+JeJf5n4l4l/mfiXiX+Z+JeJ
-e5m4l4l3mTiXiXOZOJeJc5k4l4lrmbiWiWuZuJaJa5m4lolrOVU8ns5PXMvEtUxcy8S1TFzLxLVMXMvE
-tUzlukx8y8S3THzLxLdMfMvEt0x8y8S3THzLxLdMfMvEt6zx3dPS06MfZD0ZxtQgO28eb7SuWPqcH+A/
-AwAA//+aFzycXLEAAA==
-`,
-	},
-
-	"/fonts/glyphicons-halflings-regular.woff": {
-		name:    "glyphicons-halflings-regular.woff",
-		local:   "static/fonts/glyphicons-halflings-regular.woff",
-		size:    23424,
-		modtime: 1534412156,
-		compressed: `
-H4sIAAAAAAAC/2S0Y3Ad7vP/fcLGaWw7jRvbTmOjsW3bVmPbthubJ1bj5MR2zj2f3/d+9r9mXvOaa/bJ
-vndm10NRUhIAAQAAALqBANT/3Kj3v///+yQl1RQAAAgtAABA+B929OlbUuISkgAAhCsAACABAACkEOIA
-aEVVZjYAACIVAABIAAAAQ4tOmygTOyNHAABiCwCAHAAAYJ03ag+CTNxdSQEA6BwAAAD9f9BCvlgYuTgC
-ANAFAAAA7j/AYACUha2XOQAAXQEAKOAAAKklfvqDDpZmRqYAgPIAAABgBwAAnDAKzGuWlmZGAIDyw//f
-HyWCODSapZ2rJwCg8h0AgFAEAKBcF8dIDW0dTIwAAFVDAACSBgCAZHF4/zNrZ+TpCACoR/6X4f9yWAPW
-7I3szAAA9QoAACIPAIAqamYoyHZ0cHEFADSlAQBoRgAAYaGr+IjDw8zYHADQ9QQAALD/MYSrpgQA/G+K
-0wJFc/953j3I7X92SffcMDE0NTT8B2UMD/vd0NjQ1NAUEyqZwZAYCgAjDZAF/Fc3PzPPGRiYHBicHnIN
-CgoiQxD9yWTEYrwBcQhV+j0QPooIRHQ19m+g4AYsdB8EhhR2I0daFjaSpKRCQIoFsKYhIgEAAM+Ny1Qh
-S1URwrGA9BIr1eHRr6g4u91edvbG2nkGFKdJJbkQUqdFpEUtCXz9JlpoTDZ4iAimUmBliH5LMCk5igRh
-jng7Qm1p566VvjiwUEf28bVXDxattYv79V/K3HWvx/VVtkd/aGRW5DgqSpAGeaMkJBZMmaM6sP6dYBVq
-n2m2nDbEAiVZbohdEdG+mm//V0fMlUqAT4+FIQ0Z+uaEUpPCq7q0xHKBbHXNZHUwfVVTVZU2ilPqN54Z
-k5wjfpzOafW8PR4iIBnRgjLRkkzuinbuahjj3H1DkZzY459TnB/wyyWDk+p0f5R5atgrZauKyrQdM/R/
-OYWP8YzBMDmFlXtpYGggnNvXYK7S5S7wdCc/T+o+zvqkVJU+dbn3a9QcrWav9NU81UpqGv8g+uGzLdi+
-Gtdh5HsQ+EHffmjxqtz3h+AuuodB7i6Yn2ZucumuhJ8sYaLYGajtsZKDM9t0v+V1zO9EzIOa68Oo/3T5
-lWKS8Hqj75BDVNcvE7N+TcZiy4k/zQUXHAY7E7e35mMdJMUAhFDUqsAwaFHC6TEthFMO6A57whjxYQAq
-sDeYFNIqi2qdAnrsyGLlwhmAiO3aFQnSRjVs60S4ftXTGbiEsBkJ6Cyi1SJAurVEST/o2UAGkDL3t7uK
-hCEYxNxgGhE0jqtKdNpiQShNYaxHCU/86aI+8eHxIeohdVy8NdMOWAqobCTcqfEB6iFkXDxPpEcFT8IM
-/najQ6OUE5RYBls2/sCkIIXwMRa2hokbTM772QKD8KSwE6V+ysVETp8OlAiGFgiK7NG8eQJcnwKFWQ/H
-adHrPW7poi8ARTNnJpeR6Qo1jX8UPg+HM8JPj7/k86i6eRKuyWtbmhHQvJ9LtbyU5JgPmYJsM2ATMOId
-B/0u06dt/gP48FfBF/MT+OXL8ium8a++lOvnmdpp0C7xLiBhARX3v3vw3x6BwQBIz42RXnhfguVWFkEP
-7LgVPDsK7TYz3WQq7R1GbRVCGuen0GHtceu2BnGq76TwQQzCa6JrcgQ61tLrGyXC+OjGkKWlJYxhKg+T
-5z9K7d8t5bL964+mzot70spKKVcO+U5mLVsxTs/Bn18G5ldbnS7XnSf2Jzk+s51IUy0sSJ2xNsbckqa8
-+VTyq6lD5HMfrF81Ii/LqUOEiCLH2ijFMLGCDPjja6lD0AcY8YEUOY6hOuPsj2v5X8MccUR2RrumcClm
-TR+kQuM537zGBOJKgl5keMXwo8ZIU8S3Dh5p7eFkSuI8CSLlkZKYIFmLyDBDlWgyZcWjXJOkrQOF5dmT
-L8KUwh9SLGnwSOnG0YwX6dyl47UYzF9ZF0z0aJr2TaRmUFYGGkPdMhIEYdHOY4lvWdcJEf0HXT/+Cd3e
-hp7hvpB5Fr7A/YVS++0EvU8s5EQgChGxOS8ZnFRC8T1Wnqq3UnxgIZu8/hBTSLv25TO6a6x+IKTrG8m4
-tZAQt+CvBVUbM7vQpjIR7ebWJnHj74XsyOuydCV/+P4Id+Jbp4woUSJkXNM4WdZxG2af8xlhx4KgYIO0
-ZmCHOMXiXiX36UNNp6CwtT9N9hpxN8hxgd9ig19D516xijdfsGiELEflazQO0mNXGHoFTZLMpn6aoWlA
-dS5ahqOSCoi9Jw0EZAWEn435Hz56ivXm6tHSOhkLI+FidrDfRHmGy1FbrgbNoiGXYlSy67FBd401onL+
-YvVlBPLVzp1bZ2Nep00ga7sBhcP3rEFX2QvVnm9lj+F5Vh2H0eVfdnL3SOeb63HiLxJPKB5lTj/B0+1M
-3T0mIh7CbeyuTPEWB7cXHzYWLRPODT7+m0uatv41PuAVW022btDTjR/SEBvXPCexEdu0XiDnd4fvqd7C
-zZQtaLcYhUT4O+fSw6oTO9KRhKTVFnXZC5eN6MaPrnNaXPcsclmuwB0T1oC/DNUByyRc6aJ3iA7y56cZ
-owlATtRhnHZOXYa04xWqW6s9HeT+CIZkW0G1cMc7ueYcVSmLGj1B5Ntciu4XkSc4ip+x1WsSrfFewTaD
-V5zumHUXaBsnp2F/u05ww/lg3VB/LpER6MgQznJfYGQoAMJthIiaudvEMoo1nXwML8Too+ah0SwFpROD
-oLdY+9F/uzyt2+xHB1xY0uVk59U5WFcpKtJ0I5D5m2rdWsy9i9I+hN2Hf06y8CPbKGp7TpqV11Q2jpYP
-13LYlCGhpguYIh403SxBzZULkl5+/PhwHRU7YXRAGKsKhwwlYj4cevxt43C91RYAbutxuV3+Yd4AnlXc
-9W50ddx0+8bFmOkXZvp4+cUzAg/l8g8uILLSDJ80tlmPh0maPZmMJaFUVY90sjHAhi3zmlzIypErRw8S
-h827fB0ItN1pWHAV6LwK03w90SN7l/NS1LKyUx+8IOmzd3DPvkBF2hzD52wi5u09PUJmwx5FvJfXsRHB
-/RQhnDHYdxLDPc7cSYWnVoI5Joq8xjKSHAWVylSxbB0IflHjXr8GhCkj4gRRSBYrf3Yv2B4LIos045HM
-RjjRZyheBYZ+D96r/TzAnQPHLIyXxR3/FmbjKsOath1mqAgIToBx+cJ5iVo47YMx/zaO2Me578gF+bFV
-KZT7hgFW+MqOjWpkdY8UydhiiyrTp7QmD47hw09y37S18Xc3Yul/t7gotS5+2/L+ym7bVhHnBVvgVK4e
-98M1mpxzJo+MeHlAv1E9WOzTDvWFQK9R0LTMuom23lFcCLz+FhYKERyVXIP71DCOZckjMcLfI6sxkmd3
-Rddy70Ep/IDgHZF8erJ8buKJubY2aPXcif3oKzzznp/za9G0h4v34/JrW8Xptk6pH53NIp69tuqVroyr
-7e9qG/27Q2hV+jniCrr+kPtQjc2PMpoh0JJ46JLoMjYmwBdVZl2beDKHI0mFc03yDH+QHK/bQmxnJDdF
-cxLBeF4d1QORtPtLGjZ/7z7lbLI6DPrzg2QAEQws2jQYbxr0W628Xg23oe7eHu783td9CTP+j58RWe80
-h2+YZN+CVbDCIxyGZYtl1/1uwsCT9GJ7m8t2kz81y2t71i9zWrXdIZvnbItxs+6gj9Mmb77Rdqql79y+
-8/UmGivCoe+oq89KN80N7v57ern/YM2xwz8SQ+t/f/9E+1itYuoRi7Ez4JJbaXInyyZ+c8DAutW4CzV2
-rHj3iGwS+6zq2iWL4QPSEMJyrYozuXcCx4hWEoDI9jGPDiQ6jPZN5yXLfxvMXr/NKQV/v4u2cmAnivaX
-1z5kDvC2+/L4nQu+I8HO7Vu7sPia683/+iwPUNmZI3fdKVii/uUA+SuwlI6daHRCAbFZbFOKDa06Ss+6
-aZI70ZSRcEJYkGJT0dWgbeCCo+eH5wxOhhkek8Gefshqz7+mWYxNqQLubINj0gc7nNhn2qVRV1Z43GeX
-TJhpY3O8ZtlsiIbdRXikdByqpNikAoh9/ihADXv8jjTkPvQLaur2iLBZFuiZW2wcH3gXzdCNA8vBP0ib
-fmTTZMKhXSfT/9V2MbMWkL7/KvLypu87spp1ZMv5I3tZ4EVa48AExbhFbjlGtIVz14r9DxJFSwwe7u9N
-3EpfapJ7wV/uytE2FHWOmn8EDvvdurhS8TelJxpUKJFPnnOvkKGadTkuYjIRM5GnZ6YtCrHha6Faz4io
-bljnDgN4/Hoa5t8nMDBgxr7e5qiFKbFJqDsqCMCdz7GFtjWpNwIoS6wqMreJAXd+PMdrFLizc8HV3u9C
-2EKs3tR1D8ON+L+/G228mvhiKH2LldG93OZ1m+crqP86HLPtX2AUtGGP/8Q7lnrELr7VXrDz1XlI9PiH
-7If7nrIgbb+gIsAo2nee+fiB5A97ZNeTWB7FtJ7YCM80MPRYpuCNZsCQ3n3OkogRQIVdfNuANsldR4yD
-BYcfJfFZjS1VM0xP7wt2sfB1AN41Rg90eit+4oDx+wlWalhc0vq89zfOmGpyDzR/BbUZNMLHhHpJ2/DL
-1iZv7/rYjw1KlGxb9iAgqEm1+x8nnzjod9xoNO11RoSHE3vbP88nepuuMTAP0FkH2TLBvG4jevB7wNe0
-CXHQSxY4SRP4PvGbc7XogjnVHqOITatAKR+hj5VFq0JoF2LctSDVC5rAl18IFL0sqea3bAb+XKX1vAg5
-dkH5lwrwMgkYkd+wDRWeeJeioaXLBiBr5ySb1nhuRQ/Ld3iv9/I/9yxQ859XVG3aHbwv12yy2+4pjlSP
-HfKnKxwCTH6WBIZm94sUZdIsddqt9rVZLdms2k/91UlkXPR601FCnhoTQFQ3yaQJi54E1QtRjyecyhXi
-pUu4FdvO8p1VPQvyx0G6/3SF8IHhZlz3hRyiQXipNgO9GPnjrlyMSPOwN42jefYUrUKJjDA2C394IRur
-sQuzYF4YU+iLx4ovLcBBp/RC2VoHiqtaOfDCiFjM/3saVu29UCLFRvPmDeUra2Pc80X3kv5OTO6M3F7i
-s0gk6qMX2cKBrSJvFYHJ+SQSsieycnyQ5qrQp396GoS5ibLrurSX/WL0onLXKNf1/m+KWKyklmwNnfWh
-RWzn0kJs5yIk+Ib0x2tHM6uEVDvZ4gZmIOa2lvc7elpa3VXf2Xq2vXcaWqs7+pZwo2cm4OES9yGbeJKR
-wsqgYA7D85Y+iJKubnYAV/Mj+aBEYEVRz/z18D5QVzMgtiXl6UvLX6NGqBoMd/WFO4+KaChvZlXDy+vO
-vrJLAgVJKRQjoPKFep/xxwiaAmxS8qzPrQ1xtC0pu59pDEcNR+NDJS92ITEwR1hUGzWD1C5mHqQ/ZkAZ
-/cqMN/N6vWi+W7WjwDcUiLfBV30/gZITBl/aMAmXGMr52iCmRZC7rNFROlB+QwEa1QrwR5S8CdngD4A8
-gef9chfrrzfpavUSJXDEkHePLq5JWGK9IQmYl0x1p9jlCYdCYAY9cWtInleysGIu/av9LQeFQ/BwKTR5
-7BkkWOz3BqQIlOICLgTracY6kjEJoxN+oHfAXX10N6Iav3pMFJL88NlyqEpYJqql6AbGKQ0pL9bZD1dz
-ciKY6ASh1SaciEcVlu4nD9IykTbkY3Z9Jwhyu+azj1HwW8D+GzPySSTUnRNchZb3/dB90jVvwvMSJNmd
-e9HkM/FjHDMNI7fp9bthEcKqQD70Kvm3AmcYpblA2wzxK43ptl1ASX9j5p9uIdgDwCUJhA6O8HmRNPiw
-IUwfKxe/Sxc/o/TnwFMAR+A01XdFRAKIih4QCd2OnkFowsIRqQ7fW4ZgnORzl6s5fjYjvkyX91IdElrg
-CSDW680tiHXLLdOBH+uId8LBiNfNaRczLnjZ487LBsqGdD8mJ8aPRcjavala68fAKeTxNoyfRjdqdu33
-60MpSpevDfz8LgQhtjtOkteq+vDPFu1JGWwOkyTKezHzT2Oyeq0Sel/JOeCKQRdlwJZjF9e14+ElB0f5
-yDbMbhunbr9d6z9ri6DOioa6IlvBXPqnWwh0eCw5RWoE0sYo7uet3CIY3rGGMByHftCKzWNRhNyRnX+m
-Q5/qISM9e6lEj58S8/vGDFUBog1vodkq+RGryJlsZNrp4L6YiFZWS2dMNLcAPEA+ZKRIEK5hq5HYCK/s
-CJzu7nOI4ivH9BNxdHP1OrCdVw9VtMZzDJKo5MPjpusIJcN4wgybyDhwjt++lvn2V0LCCUSY9a8261xF
-NWWFffDdO0HS7nI56dVRo8VNkC7Cag9r3Zzsna40/anpUDv4bE0WBE2bqFKo7XdV13H41Tm+mEFSYkLc
-CYlpKcb3YuRVbRmbSB0rhuTEx8WsKpVuP7H46EVjcP+Ov8XjAbWaqtvmNbVIKGnIzod5TjLq5qj1myIx
-gVPZiybXU2wN5MT8BKm8H6hEvfDrtNw1ANAFfYXI7rgDe/r3DBjnJA75zMa81jkwbSXw2ApVu6D1vhWO
-hSheLJLIv/nWy5hbJyi4wrrZ7x4lPYItdIhh3pnNwQ28Rwr/oprhzKacaNqicSySN5Cu39eaNXbEkEnW
-wHOyXznu/xa0cyZyxl16JJdqQWMVTKbuHb6nKpEFWyWzukv0/rjDgbn+/PlL4Lb73dJxZkdjS6gwm/ZC
-YoQU/C+rt79Pg6i02fl9Rmin5WyMCyev7vNx52NKo3gjJmqif78Vk6nlQPGlc4uvAfb5T0nBgmMJAJE9
-L/HXmYqOHRN0miLwRZBUwa0vQZ6mZsKao7JqfwnOkISvRcE1RkR5q00hNJvg+sHrXOMy85zf8HtSMjby
-N0o7uh2vrJKN5gWt0MxNcaMrXqLpPejtIOiqLGinbtZc2SdJp2ZWDoiSQ8Yd245TvZCwn1H+WoXHjGyO
-kmDxG7OosxXmgFtzDDLY+lO2ul6rE95/KQKQQ5af/3w+qLZ8XMMMAjHj2hDp8YeV0CIjYcNuUEVnelH0
-r2a1l8R/DqxZmyITvC3BrgDpjz1890E9UvJzvX6vBFNJNlwdcUMRcwIsq4ec0KEOAUXe9LC1eW373g7B
-2Des/rInHiyfLKpzQLMUJQ+qEujURAphUVlR+IECZCooyO/rLz9Ck+E/aDp6cwb4SlCoyKgry9ER6W6I
-pWHHnUivuMwnnJ6kmcrjj5su5idOgsjWJDbG4s2bL5IYvn/wLGeFUPKkkh5L+/hjW33LC9yEz9dpvoGk
-U/QNTiAjj/gQZ5KLWlRGMmJbRqCqLM7CZdOMJSlFnwjWQz2P9znDps98FPXh2mfIQOFBZ3qhMfMgVYcy
-qAjRrE+g3qHsdubpaT1xNpF6FH04IfSwrSm7Ax8m2z7xfyy89WUGoE+2Q1zhqSoPHgSHRSZYXpCRWvUv
-VLgfXM3KQiLzNgdoC33cUrz+6fi9ZMfe9O6kexPx+Tn1/o7ot8rQI3H/KOKjcUQ4n98VGZVThzmr5Jsn
-/nRkUSnEo1A5MiZjKuRTAg1FV/eONsk8eDMPz6ynSxpZLfu6mBDSmXuu+y/HnB1KSnDqG8TfaeK/yoFy
-BfcGBUg4sXar+504pOFlhNmK6WMkr6QQjFweIEz9qWkY0GDoA/82ivpP69EEhuEJosNI3pR+7ZRWpxH/
-ApbmTCw45lK7pJqRVlRhsWjsdLEtLMlAJ/72s2SvEs8py29vsY/Deg/RZSwC6SoW2hemSbSPeD20v2/w
-2ZI7YtcmJo2aI34P+zi2KyBihmGp0RfGsOSp16aqtWWskzXDWJlTYgPzgbSa+yVOpSpivEoFRj8FjJsW
-EEmd5VhpGLt9Cmae/kifTvZKE36yTu7RGK7bEM7Vm3kWUSb3MHVaxvtshRuW4EielSB6Z0sMsdS9mHB4
-EpkrLAzDQIcfJvT5neTu9ql+Xv9RLavJGUQdlBvR/0WEe25Os+moNNuJoYpfbihqZye96M4zzL4XGWpw
-YUcaJw5PiVYIkREdxBBIsxVKTBqK6HihOVF7hW2xZqrBLN/Gl1a1qoCiI197C/t4nwKqbS2SFvQJZNgd
-IsGvjmvvwhyczWisOlh9zse4qGmfzVBWdUDetN16f3WZ6Lf8hHwFC4n0R7I5VS3NGw5scxSEBOZ80wlM
-oIahB6VoscpzQPCucxadQ7egPc0F/1jf3gUpouq7mbVbxz1ihIwQVFAJXW/2UC+tLRhpCvlcF00R1U6x
-6X75TSwSaArN7sgO4wcY3y3OZmbjlNrMoWEw6gXEde8Yc0W5yFzU2JhTz2ZvmYM6Pf1r+tvtLzIVGXme
-NTZOIGaRU/1NIjXJx9O96Iz16ELZ0BWcNRunzCP5FC7v1c+5SkMIaXQJsOpnBhIeOpMZTZifWnCZ+tb6
-UrOPK9zWFbBPv2zzqUlesphI4JYeeYCbdKRSoIUNsiEyTXr4Dk44FrHohVCKVOwtp+KRk2jgi7NTvuL2
-uNxfHcVZTQynV4ZR7ukgVGYtx4O4m7lLtSzMifGkRgt+wcHBEroMzChdWe5iaAdDBCK+N6Cq3pLqPA5k
-ed9owFPKwB56CgRVVril/82047Sv7FQfnKS/HB7uhYAEy6+rDuUQrd0x/MqdMfoTvK+FE24+cS7UJ+Xb
-BdaqVmWxISpkFHqJYK9zL7+QQtI4dSkhwQ8Zw2NB2FHQM80kJ3/ej5DL3KVhcADETxmNO4vluboEL8X4
-9z/oVcqUmqLudYSbcNLw7ldhRae6n3MJ8GzgH5/FSAmTNHDgoQ7OXx274fxv4Le8zbGriT9tRGXpyBFs
-qWDV26OKLFwecLNWOGuB3p/91OL3z9ppU3PBIWjReP+2psnlGcbIVFdswTLNRP31C8dKJeecKx3dxiaa
-vMmj8R8luaOx+6224Tz619xOB8EXwWLlZ2SxbTSI58IiASL1im7g+YrDKgQyoe8KAXphsgoztTPmY3l0
-1Wi7f6YEHoETHkIif34JzqGmheRNDfb4vVkr4/OPJTiOFRjM3t7RYnQH+vU39vayXof+6E9hIxr754Qo
-2fTMrtIX2oJjJT2ckH4lo3buzL0IOb+LYlCeufFKZmFXe38o2DELTmyZpdl7Ht3Z6LBcGJX4MKx1mY52
-6DyY3bDtuw3bJCbDe6Tl8b8sQo7BcZj/90VFcUP35T8a8mZNuSMcuJwaKe/YK3VEomjkc1Y3s9tvPnsx
-VJlK1+B9WrWWqr3K//VYf712+0j08bnp3/CaNWfwmA+FZ/JvcYo4q93lfn7n/Li364CgfectrulzcLvl
-1x8V9f156OxlFSCMWqo2FTfR5kBtyZ3ctMvPIad+NFN9g0plNWOab6SIOcPeBJ713+iupFtS4iQXK5VC
-7dRndXqslalztDpvR4OOUB3yd/lZOqwntKV6GrHjgTDeIP3oCJivdUpuk5UN6hVLzEOv/O2ex6wZj9k8
-nykQD5pBRs/xXxfz3c6jLDtd96PlgWCilKGlYrIJWp1LEZe48c3ysVmvv6LRPQMEH39isd64VfCtgMWi
-tRachJE+U+p2ZcmuXppkxlmOY3WmyPYjzF7TwXuTPcLuI0vMld2JQKoxk8/O9KcqgnzPWwn7ZN6PI3bt
-oc9H/MGmHgYvHcZjG+e29DcTaeqnMYPxyjmxLprxOhoDtYA6lLHnFI6unKv7+TFQBqYzHW00rczpomwm
-ofoqSSw1ReB2LBky5g9tBSaw7HW1hJaBb/Dg4I58GkS1h3BL4kbfxV2hjovFrvbScUtXZBjJh14VYOGq
-FcYTBMwvaPrbj+e5RBDa6tUF9Zkuf/0o5uWQGZbMf6s8qUhJ96wZx1lKaEe1HV7nvB2+bPqYmhmx3Lt8
-k0yS6FP4oRhl/JXgCO6GE5HYCpyM3bFAnJ+d/WyK7IMuKbe6u45KzPHvEinpKcq821Ukt3OgvyKDDFnS
-VRvIiUZPuLOrMZVeUGNdogxDgJ/4roSNB0xkRzfAnH53MTcRgfrZvssQCdISqb1TigcZ79u4XCVq/wuP
-gUfN8pKOtPxUOpz5NoWx/8M4WDNRwK906Z0Por8vGLO1glyH2pMfQpXQS1UDU/tyhVqg6os9v4caL+mU
-VfaQw80/6JRhqb/E+9rsNc3CQFlUj7lQaTKUKu6HY7AziZmvkj1kKWmJWnK4iY6gUNHJdZrZXZ4G+W/d
-pBPlSizmMEgObcDBHoZvdOM/YieZ4idmvfIlnqe0pZODgypTq4YBCVFLyiqC3CP3xceCxsCwJUQPRfkS
-0jDQ/Ju5Ix5MghFyGYOQ9L2F9Ks5Ww1OZKuK8VVYkzyCO7zgjvqAqkNmWoMBKzNDViTJcEqRu0l1g/Yd
-ZIGUHeTDwEhFIg7Qv0PELg4d5F2FNCU0xlyWDGYHzsKwRAEwIryscaY7HPdsmq5gg9rYqp+kEapHzSQC
-VXaNz0bmmMnxFZC57jjcmmwHdfNNncWSqLVnEnG2qGVyEQzJBB3fM/jKHo9kGROMc/8NMjIy/WvROFTY
-GGEXrOyRtO5PY77iGOianQtpqy9uCinaceXwGxQU+6LP5qfZfc4whWs9FmIwGXzp+7g2TZAyPz/66GFb
-DGLxyMU/4Ly/x8u4k6+xJN8yf5Q7LFq9A8+2OfItjy2P9c+1KS2bVpiAK1n7JBRjshas+qjAT88TIxm0
-4mKH+9eaNe1RVY8acGcEbFmhMnSRuehqT5hWQwrZoNYXSYDapnchy/nfqtbXbPX6R1LV1i8ujXowBDKm
-nSiJJidfNro9vraOepG3Kh7xnJqFIG2KJXRY2JUk+T4M1k6v2nlve0zJrYr+ye67/GZbpm/Zu7xnhO6y
-CxbDN5pf6r51gfWd+N8k/vHfssPQoWNWQ6ikxUSjYRegOsLu0kd3uKtTlFjADA/R31pk3LVbT2TSCwT5
-b4VUXKCJRKlufQ6nzH6fuRJBkg2W9jj8KfzLzbnO2COzIW48MGAy1nPATzfE8Iarqzc55qVEp3xqEj3b
-VcnWEn4ZGqO5TJ6J7pd6sF9/aaGN0xbN2rb7yNljpKSy82r7WyyKz1TJvar+vc65/CuYdlX4gP5N1XyQ
-2CA47Yfbk5LZbxuK+Ns/eEwT3GezE8EwVHGJMMsF9QxBYVXCf7KL1eSKS74thHWV4+cjuBom7SZFagFF
-xPnKgvGNw0uCfB2xdkW69rlu/Jy+YS1i9Z9BsyEIa78bEm1/8y+9+tuK2RYdiWMFrJHrfWexzn9e1F5G
-o0XOe9lUCX0bWvI3iKxYLMflDhEMOxU4kCTPmsoR0T2KWRJ3endXzoEVg7yK1nr01XmMcVF8Dvvi/zVW
-9m4CUpLxzUM73VyJKnpjQ1ohwY07Lxgeqc+FmCdgDXsjUvaFujqPlZTWg9FFvppTS7DJ2Oml3F8G/kzu
-xbjVWlqHSQdzgOOm6kTCYp1GfndfOrv/feC63x0XfsGPc79hmwqc+4Sz+oZDVT1ph5+0Ft1k3p0ep9T2
-hmPg8+Xg7o7itWGuAfRgNzvywGxGJieH0THF69edmlMrEZusJV/PJhObHs0GwxytURhczYEQgNc6EJ3J
-ieZweCrT7P31Q57dAMciTI1zZ1U5vfb1TwzRoV195VAihOX7e1F/ilQN+jVH7HFP5L5Ig55aSNotksVz
-B2ZD8err1cIZpvmHRsRHieNoRU0198dxjHZOxMuT7dc3BQN/Ca8V2/uaGUTmgKeF6pOBsehO23jlDE6F
-avTK/GinVHJeP+luM6ODnQFGCe1I8tovOG/yh/EEDTlJjsDkpEnuKmYuBNcFUIL4aaA9hTg9TfrA4JYB
-lszqe3NG80vrhw4RsZ8jjoyanak8HNUzV69wKbTYDi+x5FeRqBfV97uAPMAo6BH1KPlgFbFeTpVfrlvF
-7HxkuHrbxaHL5mXCdzzXOeKfwZJLl9tltn/7W9rmtUfKr/bfrRogJcQRTr4Y7b9603qjtvN2Zbdz5zLF
-F+Rx4ZG4Qyo3COhsANT2WShXlwqW5wMDNP/kvcfW7CsshAW4bb5hCxyJAHkTbMJQrk6YvweveeTvcWnj
-z/wGEfuXbeuUOxaUTV5VfZGVBC4V6/KrLZXYE4cYlCsFAPEEQXi9Q+YZc6a/68uHzZJ80NPHYJs9fKXb
-PxfGEabN3lzARLOZNkbOGOMmr7a1aeMBI01re0su7Qd51D3Jd7Je9pABj919FxfJBLEu+wTLtOnfLPfv
-JGHJgoh7jQowvTpN1aQkDXcRgdf2YpcBObFINcND9PhoFOgczIpPkOpQAQu/9Gh/wjoMC4fpoqNyoRrG
-KFhwe87tWtSY8Znred32pvuar5RUouwkVWzqn1tx1FU4cThRrwh20nQ97N9f9+wdNSzs4q7/5Ph59mEz
-pw2qTcohgtgV0MznOtn/MRvj5fklM2vGX9OX8j6olDBk2agFb3QOcl/tCF4iH32NNpE1eRvMdiCRnlYM
-F3PTb5bnSKqrhgoKBy6O2KSklN0spIXflz2Glut73kd3EBxHI2bA+mOAKar4yJJgdzbpRDnwuSugmfGV
-amAukR8fpQmzFiQpWKJw8RtEaR8mHS/8FiRdXBe38BR8cztHbU8iJ+j0Xed6qEO20zL7GQ4y0+1d8Rna
-LHXnD7XZt2socfI8lFeTWxmmEPOEA2IW/iWM2uAfZRmKp4ZY6DujgIsz231BH9p+MxpOyR4qYvRAjHge
-XYducOW3k/LV2dwt0xstJM54nCzqGQHw/oxf+uVuwwAz/UyCHgB6KpWuOWSCw0LxLsM2d6msHq5mzW99
-nZVXUPEzWnss4c6F9o8jJ1F+sC56cK4os+tJZBD9dAGjPS8WWiNELzL/fNkNZhzcyynd+Nt3xJq9Hwk7
-Df5Ov/0+D0fOHTxdUQXl6+qvQ75ppCFL7o7vSvUIm87yrI7q8t+6MPdc1m0y8XNdNd6suNyXizZNYOaZ
-XieKotO0e2gFf+TEN8/2H34065y1Fn/saTb9XU5fF2Rr6hW6ZBtvrm985s4+qclbXhLwebwwt0gwcRFr
-3jrKWbY7OCogTNucc97vQ0F9NCvoo4jNjoKUEIy8+2DnaMVEd3Lg3ZMtxsRegb7o0apuMLBy6EpRYvCM
-aTVv5WX2KrjtqbfnKc/VuF8oVS3nEvh+1nV3dZrshsp4qddBBflH7miuTN29bZaghwGve785MPRyBKQj
-QLjinv2+b1PfX/Jrgf7H73Kmz43o/MeVvG6rz6yNEAEPZOHSY9siLLsM56cHwU2cXMXasUkHBg1gU/Lq
-+USuNxI1Lw2Y/WaWo2IJP4wMtMxz6igDzyIsozujRKxOTl7psk50JWPvHqGCI3gr0hGfMcHjekx5UvGT
-uToroNnXpvg7t1eL1VVNbpYE8nvK7su2Cff9oMQqmfKp3mawHyKVSFaQOftFgQt9nQh3XBlRsEjzGvRs
-INGWoHhdogAHiptH28rjEEmXdTPIXgoZ4A0jxJaqjFrFLCNwlbFp/in9eQr5zD5i33WEnaKfedwXGEmi
-bXdTJUXePMF2qEQosS0dkDlj3umOqWOf941/MAsyXclK5PeiE3NzvBL6hPhy/w38QQUlZTwBkT/VLy9L
-5y1FUwW9JMavWLt82shDYb3mrgSK3lBYIGi0J2Xx1/WJbL4iIfDoQNDoB5i01U0WpwKanpYqaLBIUilU
-dhhSMxUutTS6rPclbfcmiSGAki7h5BmC7tsROIPorTkZDPJbBWcgoIjq1ab9m/vdff02hvXV51GqcaZP
-yhK+oIZRqig2FK3sd9p3ztE4P7jvI43qQH+YicXHB/14mpQ34TxWsQ+CeFQAmhx946jF3DpYYFdF4CVt
-+VSIjbk6xs7mSqS10eY9h6is6vjjvWgYaeGInC0b7uI6FfXZ6E7TVFhmdrMoZeB/uPg3DR/K6kQ8u5s1
-KyspmrPLVIR0TZjmFynkTfQxVmPzSi2/0NJiMk/AUVezccln6ERli8RQEG/CtmWTzznUnmX1Pktegftm
-eYayf6IPTCUmeZZ06k3IrpQy0MY2lf+DdzLbwKIYk/n6HrHzt1VTdUvUDeJ9//yIUk5yQHZcX53gL6aV
-B6FZ86bBsC3WjHtjGPw/8d6yn4WN4qQXpRoIEA7CXCrCnNH8A18QVDKSlWssIDtrAeRE3YB4v18BUfuL
-H5vRJRxcnWACR5g7mEqv2GF3dmECfli7+dq9frS/33nURu5gHYXiNqBUuIject9KIaJZFrOzWjaU56Q6
-dK3xQ2vmicd312e8rXaf241TWt4Icv1DbpjpPMSghPYbr+FXK4xKGb8xR4tB19mnZL4zDf/ZlEay5vxZ
-EZg8D90R/d0R+iUpRc7ZEh3zfG5u8NuVVhpI5mQ03noXoT/YofaU9e8ID0I+LCaEOVAv3n+DF4+xDLiQ
-IleQwF5J0MglCqs98xQ1HR7vBRMAR5UtLuZvlnJqN7AjvKC/APcGBaYacP6qhFxZd2FXg6qDzxwh2a+b
-v1+RYtxXt0h8FJy7fJcOVnT8prEiepE4qP/gw0MNNuDsJPGdt0t+/HaE+FtJXl6GC3Isucxq8nsdxuMZ
-EjmNGjwmjN/owbUQKXv3PU5ndhiUb0SZruRY7Zsrthvz+m8vPp3UHqFNkmLpcLk6Fs1c75JTE4qS9fV5
-usB4fyZC57/R2MOlPyZFoxqioRqJqWUbUkKkClhep3Q/5w/XP2CSmGYL4S6c2/MVm7Ft4rf5NMT+RBU4
-aFWQvuHNPMnhbeaq7jYTnGM5t7kGH102XYbyegdr6TW+edrFbMpagjNRPTswb+Z/PY9ZbnJ8r0cslTs1
-DEOF4fMyhXJkkjDJZm/SyhPnZ4RosbVt3bRyZOo2IF7bd1xe5Y1DXndLYL7qfvrAymSqHtHWvXa2xUus
-/33M3pF10DtBbOM9S+kv7hSmz8Ws9pv/NKE1sqd054BdDqWc8mY0LQNMmtl9Q+2RUv102I4cnlepWkjS
-gccFhgPDtfrKxRh8xEW/ZMfv13NdKrgoSOF87r2egBtOLceGUD1SwzqHDopaWPSFmlpQoTNQzK2NZjA5
-jKDjreLS5stOITjFetge7B0G/yCVpCybF1wI3KbRPZoaTGGC35/cr+GdfpkcAONb3r9ibAXc1vcu9IX4
-Cs33XxklliH9Dy+e9gjm7OfcX5b9OII65lYJqbqzzvueP9/SLk4Vl7ubTc/j+gVCdSCpINw7409Jl6Cb
-5zGR7evl0gsw3Ujnp1PoZctCQbkUrIeWoW6s+NLOF/PwDt2ffuBU7EeCR1WdfTeBtpgrj3K+Mvu5dEz1
-NcTrYPzhg8M/GB+GBZLbJ9fjbfm9EoT2Qt2lX++71TbU3UUz67Y8BdqPV/nxxG+XcabV4RYi+zhD3L9U
-UaX2MEHNk+L5LDwKSVgicj5EO4FyP7ejQyFrCau+A3R/68MkU2mye69sRrrxnOn5H70dndv/BE6A/G8n
-vK2c00Jvz5BHjh4qUrR+2uwbKrkMg162Jj/2k+3Hjq43BAjs0F67/urHAENkp4gJbFxCZ+7VlnhMMxch
-H820fxn65dUzv+urU2CO3htn8KlME1akzA/guDIUbdjmw1nSPxWtUsiK/iBAoG0phLSS8tTz85dZaZZs
-33YXVnuecgvfXKntAMlWbCAgNtY+T4NzhhAShRnlczIr9jwOvYdQ0LXLryzSU9+W9j2iDD08GV/7155a
-aE3Xi/RDVifQNiXnfv5+ftEKMtFOtlMGhpwkou4SaJIcG5cwQlfOSyKhQBYGYYKg5XAfOZbhTBlZjNFC
-9itOEkOgB9NXKlLUfhwn/b7WCfXcMpkCw8xb0xu+vx9F+iX4eqLs+3fIj0km0m+o0juA8hXWqkAitTP3
-aU2fhFdqeayOY9BcraxCMSpIroKoZ/fdbSo0RORviTEDh6M4mH8lBr2W6BxClBJgufJSj4NH0KiqpwYo
-ndGG3Ikea5CV8/vfe80kUscO1rBPUn/AxvV22796224fadhuSzrD2LhOrNC0ltEIqaDWhcLS6ISISSzL
-EJfLeUF1w8BbGM0+sRB/xXzgFBrsgfwKHKEl+MIe5Zx8FvfUmF+Frq3IqS5Hd1hnd5LBxreMO8A+p9Hs
-abypVrSQzR+kRdHco/oDk84yHVtvqRvFGn98akzHq3Bmcjr1Tv+IzDwsKc+7Kw6rLj/qszBtgt5+QVVc
-YGnxuHRCLrsXGgspEkr4b0gqqfuREDrk6WpfyWHgHTkPnK3coN6iinQOTaeoU0UElXOJB3rgIBN/gp4i
-nYCIxnvMl0mW48iXeyLrmPiNSZZKxsprKbKi3MsySF2H34T28GVgqQSgR7SSXLcwFEv5OmmViJIsobrk
-L0yLYReQVSGgSf/QoFiCtxvzBX2/XlHBat1JNVsaQusMin+2oQhPyPUSDKu4oKOWDzAu/zLFsM2f4GwL
-zh6jdIrvNc0gB5nPrtj9seDLyWW8fAqdxhCLpckpXTlz62SA7S4snSX1xkwXMWSEHFvv6flLueKJ5G6S
-MmTD1g5bpuuZ5qhnsmGM9XGZYjsbuG1FaNnq0pqzbuV+g4plNJQ9BPquwGDxfbjC9jb6r0LevzTvpS38
-5mwuWfcd4PrAVJJGhIrmXMyv5j/bhw6z7HhRtBuMoa1aogaLYrbUGvYPjfb5/X1/7UnF2XVtCDnzmsOz
-L34lrFK3dvmZl6f81O5R9pgXpkxRp6Qdq3Jo42X80JHqCaOgjhlBD2cmCvNDTBMa3v3lN1raE8x1KUKm
-0PLvAxrO8ulFU7D4+FrISqvJcZLo8xXKObtHJG298J/x19O9kNtcY/ZsZzOj5e0h6trl+REnXzdIFSz6
-SMEc3HomvNCPIQs/evaFQEalXAtHB506IO7E6yMrY7CTNFX4qKQNjc+lLgVxMklCizY40JP/VGjZLCRt
-BybCr8GHHl+IRkHHPXZXkYi8lI5/OAEKbP0yVKJTzskvjT8OlOKpW5LEV4hYUd2DVJAqfxRmK4EmlCBB
-i6JURmilHHNp9D9MVrP51Uq+CkWIwlO3HE4Cwf1uApIsuYUwAH0nF/ohtV6AYAD5TF+G02Ohbiup4RlX
-taGe6eggVtGQx6QlNtoaF73aOhO16nQdLAfSJai30SWoP3iSSyemtQ0a4HtC7loqvLQ/xQNQGdzmqgCt
-axkoSeiwyzFJX9onL1hjgu2Z+hN2pZ55xeavzeOXsGl3bxkyLuf5skJxhlsqIR5UBtP7Jc/zfvvfzB7g
-GLy88JH03JcuV/eL9wU88BFw+n5jtpaSUlWVwsALzZZKXoof7hGvufU2VrkuYdYS6r19jyYZurxV3xhz
-ZGV3g4bMoDTs29XdzZFm53qE40KTufMvjfKXAeM6g3YzXV5xXilO8zljN38kWYZ2YZkFat5xCnvIpWBO
-d1hyMqDDR7CKtZL/y8WJaD+uxN4QqLWbPb1NxDRrELlbuo/dT0MU6Pv3cBUknOKdvjU/v9cEX+ayeI9h
-69Ks/8BnY2PbClpyrjEgdwkpPMtN3hJcpl4OrWHUXd31GZG1Wmzh0Q3ebm0er+xkPNFvNN0pam0yWURq
-FbFv3ewMObQ48QMlRxD1dHMSW6pdp51/TKS1CP2EcknY2DawqX2LlQeZoGvjIsFEshKd62A0tdjrBjyX
-hhhvt/RJ7IbHniyXQM141HT9s/kJNM/8cxQpGI74BTZXOIT4viwefBq2XNBhdHSLKX5zhpTg70f9hApX
-81e4p5jje5wbErSFfUU2seJ4EX9nLY0hIrXxUk1PIyPnv+c6vhCSvyhaz+RJpvRNRoUeix87xNqpZS3u
-K0DXdvnmmzKt85qVDptbdUDAbiXqS+1Hu9HVKVBAWuX3kDBRWfHwD+sUtvQnNfqoweJTqosl5pkOh9GS
-gv7GJa+9CBBycXr3VTZrCJCHmSubtODLf5Rr7zO2heR3yuarw9ASaOVH8Bel+AfRYr3LzjcZ6VzeE+di
-+JAb+BIvK0ne7aOOm+tD97jnKazPAdrYflOMOkViPHJeSJSyAge7NfDIhalCKvfRIhJfSZ4OjxtosvJD
-WsxCDy9bDgaXmT4q/hOYGWdB38d4L3O68JTeWAQd+fuvRBFEToyK0xWKCs27bqn5o5DUcoB6Qq8IukOS
-uzG8CCXY7FG/c5k5AthfVexQKeQbAkzcUd63S2PPxJIqig3hupXK5P4Zt/HzB3IPFzR7BJQ3ND0qXSHl
-dozswJqjeOtVyq83OGs8Dsd5J0K8vvYUswRxc+bcxVnB2c2PqNOWf6lUZ2lDqBYRmq0vHT5h6vfnvBYM
-IPvo+g7bpv5W9SQ9tPw510yaFYyaW6rFjwWJ4Gq/f3d4M7mXbkVvYtmnoIzkma3u3S7HRj/1KawfOAbG
-NDFxyfXVDrQcFgc9GkOiyzI5PYeKEh92gu0LA72VX4EcXlneFk/hfm/Ef0u3oD5EUiuVGF+UmN9QA9ES
-dqoBF6HodwXLnNPf6DALPTmYoVz5WLPQHFNYU+ULungeRbaafZ9B2wt0n617R1da+nZxQe/3NpISZ9Iw
-zLfsfrwdDHgO8gpVDnd13Zzo+toHCvccRVMgFjfd7F2vxepIcw2QZV+GUMfhjgQjlEj5b6vr6tzdbWv0
-plsiZDwgEwKn784RR7IhxdyyDuOzi/tfc6eMpfzc7EuQS/tMliAubtHBhgcIE1xwxSWWjoZ15AMvkvIW
-Fr24oo8l2kd4vnzlzWD1LE1j+hp6uOe+IZRsKBHxJxfhw5Mh4smuscM7wnBGUvcaxGb06B5l6ZiJQgVF
-C5xOfvromwye+FznAJbxSW0sxBNYpl6WNjV36u14kfP5klev52JPplXmOnsGe2NPuZwqRUmLqa26VyhR
-hp092nr5RVh5WborxIu9hjnTbVMMedZlw/VDM25hMNHeu1KZu3U/TA4G0m6Gmf39g62unuaw34RQ6tgC
-dDIjWpgqPPqTXorlcufVYdUFr5V2bFNqOCFIXKxVL8ym9FLPhFzBNhMrWG0f6ps0ycDMnl4MxP/yG7wr
-QKq7ERK57KCekc3HDh85di7/kVB7MzSRmHBLJKaRou8Tf4qNc6EzwwJghH97FRFIw4c7RJCEeqmjPP+R
-uvR0S6XegaUT51VUO7iTHt+w3qSyjAzTLhrSRQeF2f9cGr3vocaN/UbyF512j8Ro/V3l72wxIRQ940BV
-+igq8boLfqAzhzsyCExm4KrDi8U8JofFQNM4CJJEJkbmCewWONg4lorkB5R3fBO7XMB3I0ghp+xi7Ltq
-kny+V2U7ob3HmZBEYFGu3PqhlZgVdOMxztCtL2D8bKnrlCcaoyyTDIuE/FX+xkdH/nckNmuJZ2uR7SDr
-gXPIWeiEZMnPNhpWA2lMwPh7h25GazGadT0xXBF7oIh36n1mLOHzd7kNG0J0ZSE18KAuVcoEZYNckfIj
-Y/2C7W9hW2Z4aj5wxsRFQ8WUOLpzlZDSbwzTYgnWT/9P7/R9A5kuf+MGnOdyoPPWaY9ZoVt0j3lnrdIf
-5pnlLootJAnwjyxVvQupAOIIQiLGNRX2irhq+CTVuvsV1bp7jcP+JD7E3NlxejTDXakOzDN6Dpqxw3fT
-TPkuhIx6sH/JxigI/l8YR32qZBbu4qilsWEBQi8oKOeteZA98FPXmNIftV/qpPjXnAiWFuXd5vOu/0Mw
-IrGfbneS5q7fEaj6998Y76F0Tru3RWp/xVuqnWNr5j3nGWrf3/iqkQmji14YB/wnWLmM+/Tyc9kv1ZsM
-le2yytne3TMlOTfV7cXrgxMnC77JGr4JUhqakP5PU8T8dsa23W2VOXFjU6N3QaKd1DNPldO11lkFWQFe
-nRd6zgItx7cFNCd1PHtt0ofmdFngipqtMWCY8xK8BxQmVuIEGR+fu0slUDQTEZdrKTCvn+bO5vyIvvrN
-yrm1IvI52viLuULu/QcwVXN4QSXzQpEUJm4aPGJJSWYYw/qoW+qM2FdOUvYeT5ttpKeWqQLdlHX30YFH
-rpQnpK99wCcEhbg8uBCGfu4lp6K1r3mnKBbgfR9TDAKiw21VzkknWivZf+7rNlQc9UcH853NREjdp0w5
-zeJs6jANiJR+MtHnCjMX0mkqSysv71evq4fJk4SzGYQ1UTKoCSjvgI4AkLSy/hOiMkXqShGyiQ1IASAB
-kKaY8OZ1QxQ3JHXRd3/xx+pdzfpj1EOMiyWbKN3eLP6R5QS/B6ZnclV+CpHzasEZb/j2T0QQZgXCP+mC
-epIctPD75gqJCfiSribtK1z7pKITafZ3Zy4lIR6eWpLZ491CX0XG1nZMjuZo+CaqA/ZWePyxZpAiM/ur
-/G8llyOYZDQ1oGC/vHL2sDjtG2LGxsOrLPcdWBKrde4HnbWYMUK2wne3NTYtEzRuylvXBr8can6hjicU
-HltNcJl/L+Ri/0zS0aZ9yhEPi8hfAWpNAZcxYP9Aeg70b43gz7MlvyIhmxd1WpIgu/AXN2hXCSCIvgNh
-Q47qDvnRFfZ2C39ZB+XWsU22eQiVNF40WzOoHbVPiRTmQ6TAlqQVMo9WZ0YeOqHdWxK/4PITWqfJr6SO
-OeK0n50l1TZTA74GsgkpxopKvh1soGEYbO2Yr3S4O4WZR7i9yqGMr5G+pgGy6ZwfgfPW4u4cngswcGlO
-HCnWZFend2sp8IuXh5s84D6W/CIFkxKd+IN+tveLZpPL3DMztZ8qIghwiXbKiixBnj4klwOuKvvlFneV
-GWGx+33Ty5IhlzU8fGnpuCswz8Qyu7E68qGr5qgmW0oTYdQcVm33XF656NcGz53N3G/Wiz72LpJJOO2q
-W1LG0uCL9+OgVsQy1fomHNVoxpIc5clOrVUQxdjbszr8jLqeVO2iGt50ExKoKtiNmEbQFy6aOv0Y0rHk
-oy8e0UQ/628EVnm342gqjWE6E3DP8VOQTe0Oat79NLKzKklOo7QZQK5vT3w9W+s8tXk9g9Eo2TC710Hw
-zbx6DzIckWnm4iLvlUVQmZhueBcymDkTD5Jd0mwFpETowrxs8LtkDcCEach860fG+c8JYaT11gGp+Hfs
-m5CUSEN3OROPQoJMmRRbI+eELirb7BIJl02EBRC2M6o4jll7iBpJRZ6VcVYmdlm7cHNYqCl7Ylk21J8x
-UehkcelwmA5EtRwmWFL94G25NvqvqeV2l1M9u2p8yT3eRN7iVHZId8LuOS3k8ED+Dr6YDZRu5/Tir+Ub
-qvTDJqxhzhsjUET7de/ne+dWaYDUzCDhv7nZtdex17GFcpyaAuJL3wbY/VKLsIWQv8G54Zblie4XTq6y
-z7KwYytyY1ebIOkOA/eo+N6c2mYLx9typu0VoYDznI4p0Q7wzgFLh4HTM3ebr/mFqi5hj2s68GhNzLN4
-MTWnsyzg6+pytSbDvH6+E+HMAO9VUjQCIU8J0wq9cv77N01JR5VddYRK3pmQAvGYWL+DiZn1IPeUieuv
-oM2A/LdJP/aBde835t6nbwTpkvyhidVKp/HFeknj6jU0kCZC84vo7Nkussg4h8GTJ9bWguXCgdXKzj2M
-G1srJV+z6r8dOMeWSaacuB6VWEHxUyU/Zjch+5xJTDirZBDO9UhBtPTu2C1sPUHs6ia0aCIfpNPFPG8/
-n2KP9ce8n9c+umthUPlYaAoJBA43FyCI6BnMOJY5q7o1gEYM006W0+j0D+dNSsIe4zUNUk0dzEuKK9bP
-vkTo098lYWTsxuAplLzxzkn4ZCs8oCbjsfzyCvgLkt4Q8wdQYb2R1NDjAW/fXKGhIyL9iw0HxxullKj4
-hBDrOGh3aOHcKlIpEFPHxt2Xo2XGby8peBiEpHJfC2uM2eX0P5UtgPm4KPqLErr/kBwtJ+vLIyA3pXCy
-MQv//TAs2BoeznAsEBoR3hMkQ/kOteFHjomJMLPY2zHpLAAUGxDnuCblZOsr09EGnUIfAWAAQhj/VhGb
-V6xupkTiKmIQ1DBoXyQQ1f/IuuE385H7Va6hLHdRpIFi5CNNKg4nz5bSizUO786WNL8U37Op18VkgJlv
-m2Nu3PQnIXnKxyffjG6UDei98WkrAuRrfWWXEiIgeg3izSsdWftR3x9DWQbY/XMgE8Uim9U9K77N0jtn
-aXxroo/7xTMXGLraXI+jdPMWOlJN4HK75dgWCZHzWz42KiA6IPpuZu/nug65H0Yk9RKE84Niv6/tI2jM
-v3J73KZfrA+XWdNakB9t6kN1BNqT4P8j5ayC4nD2No2E4O4QJAQIDoHgHhwyeGBwZ3DXwULQBAvubsFh
-cAguwXUGl2ABBp8BgrN1/vXV7jm152q3n4uut6v6d/N29eUDJendA8AtdZLr0tLjaO5AbCbEXapshiRv
-8ukj/SgqjJmpNAjlhAh6RLh/24oFwMN0EuYaD7J+uFI8vhfLmR9fY6eMLx0n0rf8yeQ9ZO/dahcfH9xl
-vTN2eMxyX8dQB/uQ0HCPlAXgeE5uL+sr84B8nllu998/pu343HC25ZSfS/b34JzDdOBKUdMxDvrKIfq9
-f+j2vaUi0kJCjJ4/BBjte9+PtnjCxJ0sssHZ9hcwrHX9zRqoMbhreE/ufDdsdSGwNM/YtrUqfxHFy6an
-g/W5fTOV88OjY1zVAa/X9x6c6Ve4coSA7+HYvDIkOgNSus44DUrmPnfm0k9xlcWXT6jL5ErDJGzLXGBL
-JlzY/rlNS9uD7Mai7Ib97+fOpizhmXmCQ63Qvh/aEsNM9gXAghaxNpW1GDaLKL2Q7usc2rudsvH9cvH3
-mNp0h7fpSJOg9GnKnGPTp62q6XdrPqfQ5rinldt04RzomOSy221guRG16lRPjs1Jf3MAew2lzdrD+sOb
-2DB7G1s+ZKbaOZnqtjuEieNa8U01KVtFmxKWg6pinB1qbFsU8ODNbHTrNkbz9+IL+vU4x6zudCxBBah2
-iebkVAztTiS2qRtDgVyFjesz/MQm4rNY6n5dgNDIavXkqzNB6GSuZ+LQcKZIDHAaV01o8VzP6Pu+5Mv9
-5SA9zrhnu8nyxd5N1dhsp9ntWEPDbMGRTf3Erw0p4mS1T7qPh5E2VXrcqzIryrSRcJXz5aNq+il5CcrJ
-TKNf7AvfJ3s3pxecTC0HgDaIZXX1hvBqphA0NZNimdiwRctvFVnj0jx/lP8q44DAQIvh85nlJNlT0ugg
-gAZGmQ3BI7D/QblcPdSjYAa5kq9P+EP8wo7I66IT5OWlce3vLSCZ1v/AsVhyOrG31FMgh1rQr6jkaSeq
-ejrZZVhPy53i+CXJ5CM3LJjQbsrevpAH/l50UstDsOyiS4VLbz35OcLUwZYwpdMsCMqVfFKtJSQ9Ibj3
-rK63H9I3DcPZyBuPqzRYzlHP3mOxAY+vuQ//OJPqMzzAL0hW97r4Nv8uixHFAg/GgvqUJ3nA6D9tt5FZ
-VYWv3NaXPq4aanidJf2Ibdfl45HjONYnQ0u3R+c8aWBea2rv4nkOVzRO4bjGCvyYA37NK93KTsaWzIc7
-uo1DDtB5z5Y1km5Hf74UAOnITPpzwX36dtEu/s/XI1e48L2VHPVcHvWYNGGMEGT7IgnyNtDbkioaVizT
-9BQ38brR8nEgs7DwoXPGNsALfIMV8e7TnKy0ZKxMztYWNga1F0vGburX3ok9FHvvrGQjfHXEiP2WfD9u
-Q7TSuq7MN7FpN3KJB7Yrq3ng4ejwJ2e0OP6V6QZhcPaKBuIEYHqtON1k8MnWV8nzoS/5TaKXWD/v18qw
-1JapveAGDc8Nk+ZfiovP0/vriXV5bHcPbKYjlDF9DdIfye4TmHt1Xox1ReDpg3WlnI/fhpjRlH+hvbOO
-rZ2B7ouVEg6iCNZ+fGEXIo5epyy8pg8Cw0GDhsPDhpYC8AB+a8Hvqg5lg6IbVpw7r24TjX3cwfsQecvl
-qUvLgorhlgQQ4y2TE5rMYlmu2x+tD12WKI+rOoxaJ1LEnzGSOamssUPdIDTdpyxBdR9fJ+e+yqQkDYtC
-7NMTZFlZ0/sqo7tmtA3/IV10o/7sx6Z73O02eIDld9qzSepG9QZOiSJm7aOF755PYC3LcK6laL32sbFM
-rcR5TlN7/AS/xO2TNDrCaeBoAb/uHgyq566k/9oSAWVw0Fw+rbBsvVVatNC+6rljJTR1H/q9K+U9L1KS
-6sY6QDNfOxuOX2JOeAJQ0sknqy2sRZLQ0i8TrTVbuNZf7BSSrumvZRs2WQwUTSznr9e36gnmo45p93jy
-qwOxV92VPmAXdGsUJI+pzYHQ8A4vz3t7P+g/PbLrgIyvlm/hefOFTrvjf69NwNe9EpsQV26zpz1u3JuG
-7GHZ4nw1gDvFfM1rmqaBMh37GpD/xQPqY8/gar2J2r24DnxY9+33nQqkI6Gm++gfG/iwTbawFaGhbkAw
-B32UcIwl3Fg5QvrcgXq52UkgNUGTDj/nbKbb99WZLj/k3OGbASqx+AIKe4cTvvy4t42/lwah7s1ZworD
-QEvhw8WnFuWakx93tDnAa9BoNddVgn4Txuh0kSlZ/Eq/d0vhayOTJZ8UxYN9spRAnJ1aLaWtajtzth9v
-sMO0f74VBFbIl09gahVQjbkDxoVA91ZvLR/vvBvRMcTepl8+Z9Yqs+GF00q3+VPDmkvvWsaFioN5EDY/
-qo1cKLDb0Cu/PXOEfG5A/VTlb+g54FUgehaT3aYd8lpp+TEurrUi7jYZAGBe8YsHf65mNoHPB8wM0H2U
-eAi6JY/bf78xTPNb1ZAGdgUPngPoxcJS7fwpFhmlZLzjOsdOMPGz9iJOSeM4G3uG0Q8ur0y20Df5Fd01
-RBwe0mZL6/Xlnju13E/GGUqOPHxrY3jGjnN3w6u6N0ppjK+yzJruHsp3Lsmv/bNajDGpNXpcoOwqk2pK
-2uVw5aFQ95S2NZWV2aGXPDjWnETeMtNNlK+u5rEY4d2OactDJUGipXDdKh8humsOvxJQiw2GsHR65kA1
-NOhKpxztkAzGfV5wQipL+Qlj9QH1ywkDOa4GMcBJFR/mtkp8AqtcL384hWHkG/imwQ7tcuyF7o1tZa8N
-rbhaPRwUc3QZ9r7MABxEHDWOqgTfxTzpdv58dNfvMlt2OVwyp0wVrmVpVKs6WDvUX/NMs+bDzPqyJdY5
-a97UokEHTN2gv0WfY/Bk3nn/U1rsWUflXRFKkT53t6ZHELwfUvwNr5xe9IoHes9aoJmVaWqL00gmwtah
-X6hSNICxav6qushcRtAiKBrcDLd/0mPsdgtHhWhCuzjF0opJrI0dBpgkHyw+O5AXfzuv2WXXL4cyu18H
-/FpZmMxZdGNP13u4qg0sFrZzyK2OR5u83iZJb7wi6Cwd3WZu/Xh0kLFQOEYomk1jfH0XvxLqYsTYUOIY
-4KQU83WQxxkv3Dn4l256b4dCTO7qbeGsZcJITYtzuzlOZzoJsS/Cxf+PJms6tZhm3UfVpZM0g/i7kMPe
-WJjZqVsmnbr+j6+kedlgvdVjCp3FMBPhBUKYwop1Mos9Ydt+ZnXgBR56N+HdwfKEAlQf0JeciSnssY3f
-u6H84UfTuNCT+SeldsPoP1Mo+DeiBer8Tvzrq6MRygYZXTRsWUPpxHPQZXtfqpyu9WSjxw3zSivDH2na
-4gZgSV2POR/lhCdR4iMrHtzCBQhcaffbHGmHUCeHG+xhKoIu7iX6206j7RixO96MuUrgEkZpTpOcwWwE
-4CJjAcNrYob7bYdJsQtXgMzDOR6u1Q11hoZl2xP5n6d+FYZZdYooHoYUP2rKPvVQ3HZF0aOxo+xc9eca
-NeSmUUfxBnDeQ3L18pBWf8oZSYuXUL+eUD9ctmTAiZbQPSbt+ah6VTS4CJoANnuPaz6217GMpOuZ9qzS
-Hh5gGdWE3MYwtrZehkP1owvqQsQP7/T/4Df60fs02I/wRi3SA+l1BBr2MEd+pvBXl7z4iwqtMOyWUIyv
-k+TTQxqxpGoHj8dOk/qlz+/6z3oOwPwJpMunMtg0It1xScxwU/S4p24stC44tN5hx3gxo8c6QOXD3bSx
-cLWDo8C/io7P3dKImM5N8kG+35EwyTYWJb3GcujmWb6H180VZvd3Wqm+VTxb9EX+XOgerz9idFr64sVJ
-567IeS1AZl6LEDrFMR7e/zhyXVh4IrcLvI1zpCn6C5EGEIIpdVH8pRt+wwzHBwy8FxvZn3cwD00mUzcv
-36Vx76Q/WSQ8CztcfglOmRzcwuGx3hncwumkwk8bfDtP9aq1qy3rVXlHsh70BlzsBVQLeVrmd5xot6aU
-LioHf3lVfgcubVpGU4ul4OmQ4ips+2BY1mPuHk6f/rbDMU35wN2/6L7+T4kgfV23g5TFryH0IQxjueUQ
-2JuxfWXxN26/brpmhJ063veQB6Wg4y6oaLcJU8SGo9ZvfOMrv885Z94+lqx6+VKvr+du9jrx5M0xcduu
-Gc/LWZ/KCM/zABC9lITV8NYAfJyonc6sqnhoRuPHyjrI73L/QpD1l3Tv+thEwVtxMCpJR/gvi6k/Fkti
-4PnEggOtYyJFQvjXokTcWlOSw36A4ixKfeOz6Oo+aw1FseyP1LjwVpgCpmX+feRyl3FD4I7AOkghiIlz
-TCqsz+51zemKbKslQTspr5vwz5lTgkzJNXB6X0W3s30KS/pkfpYAu6lGlsNPCbOdTwkt6gZCAtaeKh7F
-mU0iCulEDhdU1ZuPtZ/Om8I5psnwBQSmA31wu3cOxKGrGsOHtAQfZC5yb7nSH7jyZ42tfK2DhBx58ryX
-HCcUGUSfGt5O7ObyzwjJm9g0JPqtKXWrvmhUo+dZ7AqKvNCpColkDpPB8yImYcoS50dhGK1AgbdzlMSn
-xK6+L6dfe2cWtkvsdyXcCNMqt3RoAkYXl2+KZoeXr6BpXXWJ176ZpkgIEI2E2vxUwcniPg7ZK/1u6YH9
-QWJjQRRh7QbOWVPRq7xHPmuJOYVz0h55Htrjwd5IkpHNHutWn1eHYnJqZ8yyVW4DyDbk5Zi3cdrHCOks
-9icasOEadOqgQYd3xJh5ohkt2ZPog2sgd6DjAXV4kGLV0MH5rXURPXnJ4sKcVcs3pWSu5j97rOnzPEvb
-GTQ8pqOtvr5WcWFSvQpNJGYmAvd5n8ijGwLpd7wCVF/CbCv3K794m93yUHYYhz7TdTxxwK8h+qmSE0NB
-UCf1fRhZnCsnnWnt2abeRDHp6WEits+SZrFUcOQXTyyaojLdFgc6fXCwWUuJaxRIZyaIu+ZB/kKX1voL
-7jq3LgFlr6zFCNzAthkNgpFB/0uAS1NW18oZsoIP+/ketNMW9IW2K9X5t8Ir5g/x4b5FuUOVImtSVdWi
-NAlpVLuJx22q+tuhFfUapVe8os+alapyo0DZAK5MGSsuLwIFDNU6snHdEYeubtFjlFQRrbGL1PkrLNYf
-IYy7ylj5BU+afU7Baiwvh/NuMd997p7tbMd/4D6wGZHHA9OR+qmHZncCvcG5Ses8ol27QV8B/Va5hKw5
-YnXwFEPBnp35TJFW0hsWXFgzrOsG3bkx4+eZq/OV3Ifrl1hiM7sLW1FOTWcZIplTm4MnJcHI8bWyIgUI
-eo9Dqm75Zt9JiYFyhsFWp8+NS5ieqYq6iQlWf/yeHNAR9GbIE9eqV+c++kMSHfPXgVzJJAbmhErHmyAr
-pXbW67+8PzbB+52bQfu7Pftjw5jNZqezPkJkEqEkwm2kXdSoj6RUTSo0ekpKaJm5L+5AHE6X+cbaQB2I
-bkxMLftyfxaawmnJGItBgfclsi9YK1gKWNIWTTY9E9FAs2AuOk+j/yXZkSNKxgz2jcgN0IZuwlfGERbK
-smPRmyxADDyJDVVrBzwEXgZEFitCUzrD7hJxjlu7rYWNYC6IMpjTc9Jk9bB84sPaxPRfmZIV56uMkekL
-kZj87HpCG8Y6q6Gfl28wv4OUs2cGramtZYJ5vjlMOA04jWZ/R9+10bbyO1WY2A1lY8OePMlUC83LnHbu
-/0kFP1M6AqbIChN3L+pckbc2v5IxQnVaWeRhoR45ovqDASt2QQXIe2JGm7jQfTbTYjhtTWVcEey1lwbf
-Q5UedA+U0ASoIWW6uTI22Af2TaGLXNFh9R5obbmvcDT4JepnDlIPqeacOBt763kpuPrSWL621zhDfjP+
-nF/NAi65ZAemKWRlt/w1yYiPLnL6PlCTeZmTNzxt5CyRFPimHbzIGS4YXcQozEppLKhzyZ0R8PRATSBL
-pLBJvmi6/Iqm7bjaK1ltF69/fIqmZnOfCgNwUWHq4W7y5VTReUOOhf8p3Ed17pxdn3sqSIMSwGQOE3WC
-cB5LRrAwVe0fFSNrdBEZb0X6UcHKAlpz3zfvZytuDY41g/VaEpcgB+r2xz8n13CF42Hng6vZPYxHU0/S
-vu/giyj/4xI0t0ZMSk+QMEy7IlNl82zQNcO3PhhceFE9Sp8/v1oQr2aS4cFEJ1UmQ7F6gXfwP3fIbzdx
-WNGfTSy6BlDIZFFjUL8lvDTzWwnS6VJFlyXKg+lIJJoTx64sU8VUvcAlfhHzeeUWhaLfkl2IYhs3Ok5K
-Rn6EdJDpAB1o7JpcQPg6qiZ+83iqPeDXIZdZ97LrPgiUnAFaYcxof6MaSaBn4CblLfd+npVjWQJNkjmW
-HP2Y9Lwb9K/nJ8PDl1/kTgxHRN1xM15dPMAvVsWHxdxeejEpJ3zaw7A5WCPsRhfche0/4OqB2P4aye4Q
-+9WITq+/Xtho+qsAyE+Ab6afkb9/Uo9knc9bPPd1aPR6ymyE5pHZTIcdfHSLlTr7FhXpm3gWeSWl9HvZ
-zRZsTjoZiNVEu7H94gHDtNJieejnjdRu7K/zFvZfrhW4rqWQL4A1EKc2yTdnmnZt99kTrr9VMdqq7b/R
-3qVDYcCvtLqVu+rCXjEBKmkK6canihTPVEciO8Sf8DtGJ+idLSdvxWzT1RtYkx7RHUQnDP7px3zmSz8A
-j5fKlO9uK1QtYykjJQOesZKxwCdp4WM1ZXVqIdXf32c5mDQIid4jcMQ74mlhauE9xjlWOS4X2RVJErl2
-z3JvcXPZ+fmrtG9vQDDiiLgV1ZfyLzs9QR6WKfeGyAZ+YGAgdaDRjeBN+oU0KREJFnEGY72qplr9R7ds
-ovTvEZpxM6gW7FskW7hMhkz68s7y+tF5xFbEAqRqRKuM1xoSyoGZL5LD8+TTBt9/xSchZH5ScVC2VVZP
-lGDyV0PkpmTqRkIJKlzn3OeMrGOty0YkklITvRMnE3OZOpWuM3PyjDPXUG+Zb9VwXHDuyeLIxsl82UTZ
-ADorhRPl1SVOhcalgcq3oTjJ30JVc8bepypVslZiVwZA5aFwaF4F5o8zredCiyKtggZFz9A/1NyC3Lw1
-FM48Jxx0BLl4uSZirl42XuJePQf2B6WH3HOdhdPlZ2Vm5T0qz1GhubIxfSxyrwdeDHhH8sbqxm7H2lKv
-C+jxtwhM8W3YMlkkmUP6d5Mq4g8l5iltfWwFbX0nSiceJ95mmjUQ1btB7BpFLEZtDm2WLfyHVYc5fjmk
-wSSPMBZ8nZid0Jwolh/qBSFBViCbRSvfIdMBqeng1EdeTMKol1FW8R/jvdVJ1DlzNkTVRMRF00V53aT2
-mH4n/YZM7WU7phwJCQu1krRyu2yuTa6Nr7W2hyCJkHyIc+QIwg4pssO+a79Fvb+83T4WM6o2zYmFku9Z
-KfqPLRSVEqUchfgf1SraP/5QVBQUFBkUHpR/XJ41SV3qaLJEv559CxLt8BLD++E4uLS8UUxfdIflL96o
-Mn9L80C4ewQevH77VWNLYGkJeINFocjQt1Fez/fkaxDKGPFuwxYUIi4sIC7QidLvF/nyQoFPjvozBZ67
-5H7tOM0f92Lgr/enZJj1MUpDsswXFX54i4YfLkRIVsK2seWbfYdeI6K2VSYOLYZ0dtu2sW6BF7hFd74E
-dfWXqzw9GHxV0rfdrmnr6o3bS3EdoJU4moVZGVI6435ZavIm8995nsQUltHJOA+O5rTlPzzXC4OyuY6r
-+CoMwsYt8OTPd2tHmkeaAf6K4wCfrRcG1vVDOn8bol6d8MfuFQ0e8gVnSmE28e58o3FXjc2jUMKxf4W4
-lHice2P1GNvER3bUSk0lF6wEIUh4poVghona2OCp+VTV1cxAMIxWWgPoWfXY5n+8c0h9FwH4aujuEUon
-3MAFIlFbK30C7h1k09Rc7fEdniDSsidWtQwCoDiVj9mAPAOKjPaZFrDk6PiiRb2YkYPCelE+djUE94Ph
-seGzpMvlg2eb+SQBJ7Hr5CMadCIh4V/9OMM+peiAqBS3jsMoyz+smyAfR0VV4OjM3r5/0bgxVMWdKzo7
-NX3/Z1FmOJ1mNUM2UmMakSYSAuw2pbWLPcLp2ochpni5D1MHenA9uBmNzN85ZvBMsullGeH8THrwJQgF
-nXrQPJLP+dCozApsa84mwE8qIIDbyE/bKIDpyU/pKUCI5GdANqLgQohwG7FiIFQxjS9svshHcIhiCMLf
-A5NKgVk2wLQ9YB5B5Lsc4vf2DWicEBLORpwqCE1V40tHCIVjI8ERhP6oEZUWQkzbiJ0Noc5uxDCCkBs1
-4q9C6FYFkrRibTF9rPHE36W5x4JArPW4nXOjLsdT9B+tNa4tXa8tzK6tQgh+yxBsmxNsaRLsuMX/Vo7f
-tovfAsbv+Kn/llfftla3ICn3oCn3fAnyoAB5Eux70O97olJ6EFN6Yqd7UKd7Yuh7kOt74i960C16ogt7
-kAp74rZ60LZ6Yvp6UPp6Ev71YPiLRCFEEBEisRIQVAnIFxoIMg0k3jTi1TQSjRdBwovEqUPQ1CFfuiIo
-XJEEZwj6MyQqA4KYAYmdj6DOR2KYIcjNkPibCLpNJLo0glQaiduDoO1BYoYgKEOQhM+2pbFc4x026430
-BtPV+gviR8mA5KvJO5vHRtTlXidNIRHaOc/djK87SgoB2nVTDcXje/GIFMAeAoov3E7eCv/jsrWw1rHq
-Jr6SbXgyabSenUs63CyNRzvbnEq10Ozv6LdIEew/6yBtLKDH6auQRb942Vb2S1Cy8lALkOAA1p0vztpj
-6/TpV1/6WunIexzRVU95ndRlzbus9m9bneV/weH/hrc1fXX8ZO/aI6C9a83U5V+R85jcB0c8Irdf3aK8
-XmeJ/Rh6DP1ZZVv3n7j8Fyz+g9nTiFO5v4X3ds9a6htr3GsV07D1a7rC55mQA+lLhjvCx6gnuef/x7ge
-9c/omaADKS+Gjv8Tya7/PycnVJzS+tCcqDs8KwM23JKN3KtcTilqp4+kD/8dqUOpQ8lDif+N2L/zqUzq
-7O+R2H8e3oZIHor/d5qDWuE1etNTdnvEOmUHBzeErZXLrv/60FF1NQcoUVD+VwAAAP//6hR79YBbAAA=
-`,
-	},
-
-	"/fonts/glyphicons-halflings-regular.woff2": {
-		name:    "glyphicons-halflings-regular.woff2",
-		local:   "static/fonts/glyphicons-halflings-regular.woff2",
-		size:    18028,
-		modtime: 1534412156,
-		compressed: `
-H4sIAAAAAAAC/wAVQOq/d09GMgABAAAAAEZsAA8AAAAAsVwAAEYJAAECTQAAAAAAAAAAAAAAAAAAAAAA
-AAAAP0ZGVE0cGiAGYACMcggEEQgKgqkkgeVlATYCJAOGdAuEMAAEIAWHIgeVUT93ZWJmBhtljDXsmI+A
-80Cgwj/+vggK2vaIIBusdPb/n5SghozBk8fY3CwzKw8ycQ3LRhauWU8b7AQmPrHpsWLSbaQ1gVqO5kgk
-sapZihmcvXvsSAlqZIYL1YkM/LIl97nZp395IqcEA/f21yuNQLmMXb2rZZ/7e/rS+3aQoE5jiykOu275
-k8k/fj/okKRo8gD/nl/nJmkfxsrIHdGdBcGkiz+6PvzlXksg+3a0LRtj240x7fSAEokyS6Dhebf1LCdu
-5KvgAAco8DNFd2ngQgUXgqAmqf8L6c5UtGxo2DBNGtLY2tKGZOVZ2HLx77Kss250ad5d3Xl1cpW0vK77
-me4TVlhzag6hop7lZ01uGarTmUiBV5Wpw9QIIHIy9D5pVGBWN7jNUiixqMnPGuD/K6BvNvMnY8XIQrCP
-5gbrNOe31s653X+Hg4vjv5quVAldYVtRZDwzd3E4LI6F7nJUSRahOOESHI4wPkW4P/kqRajnl6aVI8/6
-NyeN7N39hlMJDAtvY/vKt+1fizcmIyrRKym9s6DQKzRhAbBBNrZjjOd5sdmjhmYoYhlG6ebk/+m0JDt7
-IFlBwzF2UC10R/j/jOHAsRXNIvuwldsBQ8JmLSBXgveuAprUmc51S9awSwjjI63tDuSs1ipLhjzb/AQg
-KNHf69T31/9a/mDZqwzltVuXJepZBVSKrHslr8mKJIitEKBze2/v7RmcF/KIgxjVu+92dCJw4Jw0YMjq
-36mKz6R9bwxg47PdFPonbhRl3D4K5EceNXMAevNfTvMKklBL06Z2bVXeC8m+e3q93PLu8/+fGfh/+IyH
-IjNgbA2SHAOWVyPUkL1eGEArjSwHY7nJa2+pjUFPG3AVbnW1p9R685Z6Sin13M6lHveY2zHHfeHh/089
-3n+ttoB4vlLGxGDBSolgp3GDFaWCVXMvvyv4a9J2xzF4bBrd3+dqEmwFlkVs7FxuRIzIw8a2r1aGseb/
-0Gpnm3taZOWJCHo3jwsUNf/fIQR4bcI1b8JbBxy9v3Xv+ya3rzHagkgQQmtB4uwIcXLqzlKQxA2jt7AW
-jyhcZ2j0EBTIN4ns0op5jz2GSLVa81VQaOnQJDgQUmfTBcQYgHrCZ82tyU46i+AAMXWsJNyFr6Shnj5S
-/V3l+hSXDqasIp/0Zje8lwv1S69efyeYquu9M5MrRS+8xF6JWVU1XahOQhcu3sqLpdI438Urzs2POI/5
-LHyJe018jEGKEeV1YXzQYYiSf+yO1d7LhdWdJQAKf2xLR6JQ7SwXTnUU5tzUa/5j7zhtWEDa02T/F8yY
-P3/x/NrzoudZ0ybP/nvq9pT4s8fPDj/bUNworhRHil22v8/G5K/kT+SP5Lfk1+SX5AZyLbmSXExGyQg5
-lywmp5N55DhyrPu0+zP3H9yfuD9wv+8+6n7b/br7FXPo5P8Fi54S0BCi00THCKR68zH6oT8SXFU1FnE9
-rdl00XrUkg6GJlqQbmqiJeltTbQifbyJ1nRr3kQbundooi09/22iHb1CE+3p9Tc28fSugyY60rvJcXQi
-C9YxOpMVrOvQlaypdTv0IktfoS9KZNZjMJZssvUcMB2yxSdeAxZCtvk4VkO21XpnsAayvawPBlsgO8r6
-ZOwK2VnWF2J/yIN1HQ6HvKl1O5xAnip9AQZ5iXwMLqmsJ0M+E1xnPRvyOeBW68WQrwG3W2+GfGfwoPVe
-kB8MnrY+ivxkvAo5rc/H++QX7tjF+JQKKkV8QaUOj+MbKk2tW+NbKm1P3A7fUel6HD9Q6W7dGz9SKVmP
-wW9UJlvPAVUqi5U1EMBT2QxNQgv+7AShpfBbsxMKrYTfb1lEaK0Y1Xvs0Sx9MTxmjSYCNmikGIYnj4F/
-B8qlVSNWqAjeEa28H6GlRftEfyJUwaXeqdAGokFEOYP/ZUK5OqkHBhXEJQ8CT5zBINLQBBPxgofYRhJ1
-im4gFjc/JVIDRzQihLhmqWfHwUbquoEgDmE9gpEts9VRl+G9eStCvSzE+NAyw8sT1oU1opWH8JmEjHhu
-oQUVzqoEZiohobPm62zifEdYUfgg3oNVcJTkCsVFdSDCQJ4Bj6blLfCABB9Eby42WVr2gi0mYT5mEj+b
-AKuTTo9OnKIJXdRPL147XNoOwkrKDc9CBsdFc0pyGQSqkBkBoMSa9cYPFCfyhWcSL+Pj0UIXJZ+hHm8g
-H0P16rpulTeL3DoFfPV5g0t0sib3JKfYc698ufV3UIj5xFxpXb4kWhJAKwHNDLa21YA5MHhdu3K4rSW+
-yNUr9gdSVaxFbYcrFtywqqM7d6B1rMA5L0m8BdQ3yDfVprlR/mx1XKZ50A5XixBOKes4idywdlnuKnW0
-bQKUobG/6eKp4gS6bSgJZgbKRb3y/0c4sgyiaiNJrL1SjswX+XoMI3G437ffAQYJhClZoNckiwvh0JuG
-Y18lv20teyEwLWALO+HlhazxFGh5VvXkwV1IdiEJzx90HGG9XEvvxRAeBqVbzDF7GgMi52ogNkDsljNU
-MCWlE78P6c6YIsfUmcZaSYZH5AabU5P3jYIusxHEzqNwB4HG06xTxjFl6fvZk8TYm535DFnBHv92uzga
-CGSxXLFCoRdsoVP7/lIpBtIT04bn+a+WroALewJJitOG9NIlnZSvPvsw0I7aprNc8CeUY2e9MiU0oFGO
-RKEKMM2SM0KyIslNjtWOJoDbimhJFcfC2qfSUmcQt01FpKGpobaaDUm9zigHqd7VNVWWRF0MffIdmQdi
-7Tgkl4fsOKg+8+FYIAGyB2iVImwetc6A4mocnS4liNuAGEhIxy0LSZqm3bgjMZIdQwE09d5Z3gE3hO3u
-rhLtWd2WoVYMbwgaPlDKXaE2v7cHmPaZTzT/N2YaDb1+ABgeQUpkWUbVwoDKLpbeb/XD/nkpCcY4bMYL
-tjIyjmWKnB+m0jFIG6FbAXSJsEAhyIUMMlyAQLgINQbE2ZPKJVrX7vzba96SCAZh9Z2u3ED6LmBuqDPK
-T0aMohBSKPOFpbb3/71aAWtMawVGIO1IV2pZHw1JpOo11+cqE/E22s5ltVNiay6kvDVGLBfsLpUCTjDf
-1JmSuYB8lIZWpoB8fH4FTvSHKAkgNLed7NpdLOwaSnB8fvl4ZdPJQajUHKGvNYiIL7vau1Ok/QTk9JTQ
-dvLX3Hk/m/myJ192fHLqhMtY3Ab47kjpUcoFsLUVBcSTQkA9C91YrN/6rEITGDnLNLOYq8NUqdhCiUKp
-Y6CtwRirSJFQo84rgvKJgV+Tk9VZSNkjrCSqy8pgoOxG+KPxQjvjtcIr2xGUhUJQUrA0zLwgdAStOnQI
-9SJaE0W6Sl4hWMLHk+CscTRfZFRXKDXk3IAEp+X/5B+42kmxlFXFh9JBzXr+QFU2/24uV0dY/cDBBehI
-7FJLwBbbGiYIJ3N3TbFqisqOmIuxPJ+UsZgzpimAlp1gI0ZAEgwYDEYg1KLgCP7Ydo1vzWIkeAwH7yuy
-4Lx1+ya0fYl8ylgYJlvZqpA4RostuUUmLz6KLxfRR8UuYep6XoreL4PU/n0pnBGyE5LzJ5N4qZEkTz08
-AcfCepmkb+Sn4UE5TR/YnSYd8n7uoZm5MxlytQUzZ5+cpie/ONKjXLAttk1EesjoEZj4a7rNNYb5sbRB
-Ct3C/apHOankfDEt2CEgxzg3+xBbnH/0pCxtUu51fKY1N64KHD1Y/pGkLJhhSqfZGxabuF50tE6bNNPY
-XGYQ0IRdQXobSF4CN7eqRpXoHP6VmYQmayIbTFU+few+53JC5Vgo24Kq64ICVJolv6sLSqoIv4StZGhL
-xB+U87ZQk7JLwR5URmFBhzNISIZDW3I7YZvAtmQCt5kXhxqVNTTIzAyJl2xMhGsDakcPGnuh7DifaH7k
-jwcNZlJAA9Ds/B45d+BCqKTg0DDrC3pT9fSw4v8nl6AUAmE3A4JA3UBOm7GK3ca5bJFiGGozD2hOBBPu
-slj2i0Yvye1lonOj2Sf6ikRzUavxPP5rXtPtHfLXvLL9iFpBU0+oaRdkulNK43gcTjREvbPAS9MhtLnU
-+Qkh2at2iaxoQWDbRZa3WBCQlQACvMotDaJQDe3EOp+C29GkG39D6jrCwlfNelO9c8RkTww6CBC2X7+r
-1Mtgijp0wWHOt9CRCx6lhrLN2LP6ohaBrg28SVnwBDTHDCMgEJD4KtIczSs8A+pxAG6wb9QAuHUKVQgE
-zGN3d4/zeCRktbPwG8a/Dp19z4H71sE5NMz9mu38AzlwrCpUOvolRxVR5oVeYZ+LFYcQ5APdyyeo52WD
-HvRi9qgEFBSKbC3V3CpY3UznJSrFuggZuC6F2orIXIpAcFIkVOUqS9YYzQW9CLhocIfAiMjowYLf46Zt
-+sEbkeItL5NvU9ozjt/CRY3gz850b3+4B55959C2Vodv9QdlSgtgPJkk9tl07dgSvd/8HwmqXWcq31qb
-D4S1NnGwwPlskgT4fhv3Ra+rCoZT+rgvipL5aaPEVMZ0zWuCx67gslfdw74M3D0/arkAR6LSzNRVVQVB
-Ssb1Dv2bAhxghtJi1MuRl4NHwoj1Uc1Bz6upgfHDls4VxtrsY4P76r1Xy++pFegDV1NtCN3ArWezutpG
-y/GqkSapXhb1+tiY1KGINjtDMTo924hQieS6FNVgytqckFZW/5Md1EWdxjUitGhPq1jgfhQbq97YTjNf
-NdOBXbp6Lf6t5JJDV9PddNSljYLTiLTQGMtl3F2wXLaUqb8dVq8ZE5aL/2PUIx1tW8Zrdd6XrV/KsSKp
-yfZzjUizf/Q8fXjvsQKFbTBi5XgBSNNxYh+RYTN0ZudNVNvRzypdSbsYHAoV3n3XKBz6vpwsTZSEjZY9
-igndQIxKQdvG0GSJkKCsyz/CpzZQVrH2Ww1kVuN29OY0ap7S35uRbEhc4vfUFozF6HuY2PICTfTlvciY
-XLqdjeUBWf7cgYAcHYFgOU3DYEQTYoc8wQUSO2EjevKGkTyKeCIG8yyoZIJnQ2m/YJFjkpsWOsEBBcji
-SbTiPmp3t8x9SgXIyXqnjV46Vi4d/TrX/tqLE3u/zbwGKMiyQvfmyxzJpgOSyfN4jjwYHkRiIyJTo6F7
-9JJQ+Uh1vU6BLxPre3I2BTt3VbYT5tDyEnPWUBfQnpM8pOdYwOBZ4nPUxPfeTXh1sIcUXJpiAJHac7gk
-EY6YEXiOyiiiiS9efANeKhgwan5t4Kw7I7clSoTeTTSdx3CYUU3XrPA6OhpiXEMyZ2YBsLBdvXrSUDhU
-mSBVqpNRYtbodLqDHUMcvVSfPgpwoDgrNmdfMpZszqE2p0jyEQgg2s4Ax4YPSJ069w1kmzzmQ83pNrOv
-2KTqL6u/Nn/jRTrCS4uUIstga0qpPJvPxqLkPQj5dp43hKXiTjW3tWCw8pu2SnSLEtlcark2zYUlAw7L
-njf0KqUnD6UQlVWV2TSxOuIbWCsN5FwCYgD8kkUKEeTs9N5hZq6KeIwfk33BiTErcJmLQqXLMO428hfi
-lOX9njNy9UEkG04Umn62EvQjs2SqfQjH16SfUDdo90g3YqNGqp7Cp4WCrDjwEQ0es1A++EJ0GR5HTtAU
-FY6i8G3kAYJ49ECPagmFkbh8e8BzORIZ4Ls9D/53UtkvratvREpzNRZ6PpM7iid43fFFBtBxFV4Gcule
-PUcaP72FOUHqoQZ/5pbHQeRfl6MG7UsltUTJrjp1aWtqa+5JGGXJ5r0arEf61Z0jKqGGKbVqbQaR4Xy9
-dKO5fWABSuapWtiI6db3FwcDSA89NO6de2ffgaK+KaFxWIhNQSwXmkj4jDcY+zGJ61YipdkUD28s51kj
-aBL9/PfdqFMX8l/qO4vNYV/Ul1peY240oq0QjaCCSLhFq64/iauwEX3RCsidobut3O682aQ9fUKeV3be
-qlVl8OVomheD2gBHHYqTRpCFiZHmO51AMlOl2AGcgEDLZiAF/sLL/G7N4jLQI42O5h658RNm3Vk6Xb9K
-eeUISF0arZUtt5hH14x3Z3YnoQcE4nyIxDBl8QrDXzeI8NKQq24rZh7f2bji4Fk8q+cozQqqP/bskhCp
-kXny+aEld22sK2oOgyYmIeiiY5NeoXUnnWL8JvFon202EATCpJrO+7kqMgw/HLRBx0kcq7bGsjVGBle+
-2Jlb4sacBqhC9VV670nORZSTIZJtOovS+5x4aNRll93Hrm68enxdJQyNkG0R2XLBVbhGjdqvkAWU+RF/
-rjHGCx2JfTshD24gRr4moGfy2vH/UImG3QGvrxsbOybX9qmc+O8YJCS4GulGqykaLnSbQu1RqDOmjr0V
-KJ5DPfq30+SmWMDO2GVz1Dvdafurtq3ZikC80Qh+/E7tyRsbzqFFAX/rCdRTUosUBBShiGidXOnoo/rB
-QmXxbxi6hr2coLS5zgFiVNEWhAZuzpIRanUCub7AGwkHZ0Dk9ycEcVHrlI5ueC51NmJWVSbUDJtduTvb
-76oVIUNfDIQWBgsIno01xireerkdybr7bYBSUXWRqnGCkuAWprFQ/NpaMIO2fW3xvKHMBsr1br2mXm7V
-T3LJVKbiwZG1zjqfVeMn12jA5qcwbg9aoXBeGVLpfERGql9iXPJAltZtgYLoREXrOIEAxntv6B5HTYnh
-oJwBcbjdzwZ93O5TZCAWFK4PQywb+wRpwNyaReodEorpL7Dew4tbGGQ4XY7XLE1DSZrO0PNfdZcsXVaZ
-gWPxIpfkpHAYsAZnHUDsYCJ5KYssO0KzXmWtnmwQ2ggEoaoyJ4AuKJ3N0MSY4nk+4C0afM5orRjcE9PE
-d5r6/uo7qWrlpegdku3VjRjR0mnUvbHkr+pfGQhvfCFA9inJot0eqsQ9f9nMjFNQep2X6R0fiCohen0p
-vHzGp1R9vWoYkYZFo3RDrFrloW6MjRe9f8O9nCrVnvXJNNuG171buamxC745GrvQrgWojuiIF5EGkt2T
-9Yx6YFcIbRRl9G+Ci3xqOGqt7zXhGJA5vPa1QC76mkW/GFbML8xaVwVAF3yXgWZf5xBcIiQde+EFnJF2
-EKHg8oPznMDIL7gG8rY7YdcWHDpTZaZpM1TkR8sQKuvO/YNduMahL8xoFMAyHUMzMiS/0wEO9L/8MX2/
-jESkzU5Yyfj+dOw/Rs+d7X5uLFBqOQ8u7pY+16P8qM17Cjn9f8lFTi12fDNohhTykUPF0LhFlJWHIFhU
-4OLLO1CWJMM9jUrWLQ/d1Wfdlf35aWd6fnGXKEHpPDpoEzGxObMz4U7szL31UYmL48d9Q0zYf5BX+d+n
-wteO3H6DEhvhDRLaYpmlIoaBh818xzR1fe7wrdcB2WOZeYAE4IvINrChMv9bIKXY1lxkuCy10o7Vs2KB
-EWv5pMxE5eS+JTBU3Hitrns9O/bUt4uGASiEaQiHC43YTFO3+BPfMb2Y+P2p0TP/Ts9oL6Q2P+YnRV72
-fv/G1FCuf3tzWuwbmVrTS5TEnhNCe5JEzHT4Jom91HqS0/cptRdVb2H5NVGmM4+RyJeIcn6/jpG+CqYB
-9Nn5Rl0RoCS6POgE+nRtKJp9DPvDz01CQIeeW5xHeOwIzkbTBWgQOACbI32I9CyjI8CYdQv9TGF6KN5R
-aLE0JdN4AW0EYFUT4JXVuS5FEajjdjFhkp40Dl8nL1uoZLF7RnioSco1OZ6MDINE9RE86uwmkDhWiEXz
-RmfJyNkL6IqYI/VJkeSfjTJTss3u/18GD+OpXVFxQROabojRX/BRGecHEj5i3pg0Z6EZqK0TsS2uATAm
-B0UjY6bcaTi/CXZSL9U0/xhynorrCJpQN5WjSwNzT1cFtU4z1Y8edkVcYnGGf/tR3zUYEo1audq9Vnk1
-B12NE73W9uBoLwlpKcX7naaOLS+0sOOha7VOrNGOvsjEHBMjZewpIlAX7fH8CAl7/UtTUZB4ibK4naY+
-YeMmte22jjxhLOumjBdIRUjP8vOJDQIcXZQlLGVEnrNVfle7bP0XjwPam6s7Y77hmJP3B2D+nT8gob5w
-kU0Nsgts6+ouglCyVzf1BqHZo8guGi/0V5wjO1f1ZCqWOno7RTKGqJ/u9uP6aqEH+DkTecncQcdTkFM4
-6HXAjLbgrDtmWTi7bSBL0a/o7NSE1LaJzaE+LIQXoA4NX+hnpbTxLW3hYzzXGG5d0KctFK41kTJjqLmh
-rvF6Daw3ZCBQnHrzE+UBtRng8vCyVoT2k/ulTx1Qdma8Uv4MUqTTxuCwkzmGWg0tn8Ee3mQShveumoi/
-Q5ua8fPHYCz2YXTBPRMUh2s/dqLtNCNQDeikQswWCKGa2KW4L1sX9QZzLjxhFTBlxnuPtCaOonb+EPKh
-YX4BHWUBCNDzOIvoKWbksRwX224UeQaS6gJm5EJQHEz5dfGzSXmySBg9U/gy9tEdlNIiW8PIKNnCvE9A
-7XoqSbi6QMX2MJfkqiOY49zgLBrQAAKt9MVJJFGhz3kNDWP00Z5GDethj9+eA3Yisu8OfFLH3JgJJ1ec
-E0agDHg/Ef4rYU6DTfauj0vOYMZEBd4DL+i3bmY6WLhJODpICbFJUm1dm0v0ujZpDiD8QFUSz0gqTu3Q
-bwhGrOD9O5axqZvhh48iAledcaO+ZFyT74qIiZHQjSpDPSPjMs82eJQ37DxUz9UbCjd5iNRyVT4tYkgp
-ERHJunrvICd9tte23e53nCEEF3LBWM4RWoq1CbQuOpJWbtcTO+4t7j6KOuEKHQI2AeBy/72HDh1VwWNz
-1TRrrBFWV6x7kvqJ8COtD5g135EwwULd4+zHYNyd/zB1mtEiLlHKxh+sm2RCtJgwo5Qd9ZhDntBy9R5d
-7e/gI+26UTkIbHGc4AJOXvTWs42v6fRofqBOVVy0ILwxNpoKfunoFZMc4ZRTkW6HVPIEbKKRXP5USNKy
-2pst2cl+qkd+KSSFb1E3Hi3rr0PvEbDMAcjsfXESJS8cYZmms3ZPsKp8W3E0loKKkrN+QmMtJE7cGzc8
-VhiFSEWAH2ktmZwX6FLIRpMMR05N4HvQIjOVkAz7NDmHWxWEajygkOG4HaxX060LyuNo1fiYAr9skW7b
-BsMg/MjYUdKo2olHB2NxqO9Ad68vZSBx/6PMFeYBZ84crsg8iKPNxhAPOiCg6uFh6ZK3opF1rxDqzfGU
-lV9Qi2AM3flie0XrHOGmSSgWz9lPV0fdHOarZkV5wNzpQUJhX57fO08IXo5EUaPiJ+i1c/Pl5wzu0Ozz
-YETuI9Gaaa86GNG02yvfFlkBe6l70nDlJrbFXN8aUmGemsDBl2cQ/s+eMP/BH2f671T5TM5pPCefN/YP
-pj/ABdII51gxucDPQ+/WCmGlv+nubjBvuXIx0QyZHhcvVa2liZ0F9QvOb48vDz/pleKZr2H501+scBXq
-j0jWsQ1H9ey0oKbCOJ/doz8zRokw8AeYgNlgJcP3z5HE0zyNCkeaXdS9nBk4YmzNjyUtLMIpfSWeA0qU
-Oha5WQKt0mrQGxBUzTvQq8i2NcWSPp42HL2fkHfSew+cVumkgy4mE6P2KIYOb7mpKvVuPKfYbjkGoQbB
-SpYKImGHB6kL0JQIzd0roYYLYcovu/26uvA7N3pE2FrOtxF713SPTQlNcJejCWnYmmu8TlB3iNiRzbrw
-SGBUDfYkMjMbloZmHtP2wNDaMJp6H8bIO62hpp7nIvBdjPKqgiqOWbKk6RAs5FGhV4HYG+AO9LhsU+m1
-xsVPjnJXJDUGXUuhVtm7QuIWhdyahUm4GIoYa9p83z2yJsFb1Ojq3tHexTU4RdNSpDDei0drq3MbU+7x
-wW7j8m4RbnXj+vFFeEuN0H9y9KKsjH2Hfm0f8dlgEI5HNAJ1e9DR8T1dNmakAPfiCNeoCkJv1h4mPA2Z
-w7FjOzKgrhBQJMPHg3ttV19jG571wqonQjbQij8kvV56W49DA5cdWbndrZnppWrQTvN+C/6m264wBb67
-m/p0oq8G+rDb4oQ2LyktiTF/OnAkROqlhciXCq4QGg4KLCezhvx54PWx+MF2mMQghW6ci0azVNfRgZlb
-BCdhpk1izkpduyWQJsOuEKxsYzYCJsLoSXBG5ZDEDajcb/CMaYMGqsTJ/uMVNbGg+CdyqOTL5XKRKHG8
-7+iQ+q7r7r56NsGw9p7uySg189DhRQ704Mmi1Z9sE1wdhUzxnWu6N6uwMcVZNF4pAmLZl8KmOPm8efjG
-j6rk2wpOntg9g5s5elSWXltUJIdka8IZnA1R4mlLJeGINo61kPxxtenn9czuZk98A+Da4GPQOCSVamle
-dhsEcv4CLlFRUiLiWeFyxIrj4vW4DajDa/iSpd5yn7q8Sw6IorU8UUmJIhG3QLTv6lIQFDkN9sAPL72r
-GFwmN1l9bYln0oo3u5wceja4LU35dT2CwOks9f5OM09cujaMw2FEQY673q7wTGRecuvJLy6uPvug5ugK
-Trdl7c8IUmkT+zSmvtUhM1L5oroVkCKNNKaIyPH6mm6ZYuFtyS15W1impv/P8S4ixvQZIZT43FFLr+VF
-XAdOj+u1NGfVoNed+AWnv6aD77FhTqZwgg0+ayk5wcEwiEKNWurMQnMK9qV5ihlyjpplcqspdq+irkTz
-63TocnaBXPt2+Vut/D7zcrVKbZyBApYKYZzyq7XMvJt+dd0X6urVj7o+tXJNWpywmGPtQjz44w9gKVx5
-13R8243v/3InPIYYGgb0mOA++dfW/uNb5sOOl++t6Gg36/qt/lrFEASMOH9jYUmBIbkNtHDiop/NzK4A
-LLYPR8PtC7trB6A1QMjZ9PcIG/9g9Mlpdw2I0m7Qnh04cJ92vyDnyRPpKo+dssInTwoL3R3U/IqyFKDd
-QVvILqGkco8WaPNUDXBSPys7y//zXBEqSItzTHHe5utVmrlmluI6cWwtxIekDPEqNiGFaOcry6wEAHto
-t4n2LSBqZ7FryU1NyddQI+O25Dq8fZGxuHsv3evuVsvfxbZDXeyYmeq3JluzVyTaqwEDXt8j4Pu4tjRm
-HVdhXA2LBcE17PDourpNWzaevRwpVKczl5UbFZt+/Nodzg6tyRLUwArjOi4gWpSmvAKoYHPeaSjNUvSp
-UYW8ssx8L/pg+QppbM9esEwjoKf3HfJmpC3x1zstQzsTX9ze+Sr5e0BFTUNvb8OCX6ScxsP1Nxe+VPbj
-cnF63Ea1JRfXr3yZmlU8WqTcb8ETW1RBPY6EBNAnRFBKXbQ7LFU5Ga+1ylGbsdNwip5rBvE0foAd6uEG
-weIGXwWNQ6pemXFFosWukJxiDYFTR3Pa+N/tf1mFnTJOlkEOrtJ17a4fJfDwU0SEgiDXaGoJCv95Ozkk
-37RJQajVaOQERU+PzBGE4bLLfQqoFmeJs6yFFJcvKyD51YOT7zWdSlnKIEDkB0f6+I2N/L6C6q5mMhSQ
-orQEl1mgxOcvuMLfvJl/ZYTft7mxfHbeLxYfuCLe/9Vw5YDYfuWIi/FU4/Q4Hk9L83Iq0g+e3SoNhoMd
-wBM0aGngQFGbmTNnIh/RBmqynxw69CT7lTsdOpT9pGbgzfyW94wsZL2urnrNyMia2cbUjOq6swOwqxp1
-Jeegy6N9T/Ums76CaRkyD1XoLAtAAs1r6moPJXU/2xrjNKdOnEtt9t750GQ/NcndkzvKMJlZ753a/GV9
-c1r0gBuHqj5FxqtVc14U3Zx2e6B/6wSkpmZRPMSQoYlWUPzvw8pUDmbNpu4/pZD1bdhw2VAqAMgmAab3
-0FGHR4n5e2OcA0rv8UVQGGUyKY54UL0wBUEG0d/NAftNyapaSLZqlSIR17si2UEFrNBDK3pxiW0EVhF6
-4ZaeBfNVJdhDtQA6FkAxDubj8Fe5igzuWxF5Kc5KQPdvsWIlDPdqlBVBPilOD9LHgNRpf+e8JJJB84jA
-7HRgPsw/ZjBnAP9IMzZw6DbhzER8+wRNm+QM4fYQNE6NobAKnJIgNEq9StqDHq8KtWoHpJ6YxocBtPNc
-De1woDPTGfgcjqM4jcCmqtHjltCv75QTu602cK4R+VY/OqwkgnNE+cBO+hK1Dsa5kTLvkm6SLLaESN1P
-XIJbuPjVuJv2S9ktKZ2rV365aeltmT8Y/66DVNA6sMzw3rpV1mVZjNPjii0jZEplKa+x2s9aqtU1lD/4
-JLvmDqFcZKlXGTy3ubksyYZ/hpo7r9i3uMM1zc3yU7jVuK+8GpdUq1SW8ZrOCMyEZiiBUFkOsHY9UQ1+
-RFh/Kge83w/dOPjovqlzLQnCCAXLqK7OgAU1NQIMrQ1YolKlbCBRQ88IGOEZpM4M4ZP4A9HAbHzy/TXO
-e/vTplRcdOq8lSvp76Nlu27F27iLksJQc9PoH2z7MxWZnflVT6lb/Nvux1q7yVMz5cCd7p+dKujsLJiq
-ht86w5taH/6+xtRMiZushtUFU52d9BUnzLXm4yoH9fKMKkCo+BmdH8Sxfnhnbm8ysbkZ4RaI4i0KhYwg
-s1ezFIqrvVYcADvkcFrlBDmNPxN+hBirJKs2nzyUtVFygmJROCbzFHNlG5XJRWKv2lEULLf+XnxCsrXv
-56KY71ZkrFYttijcXeMgLu/oy444HxIvcWhWoRtuUq7zrlHIRIkq+VUoKjFo5zEUw2DYnVFMEnsHhYFV
-agsLYBfg0iKabx4zANy75plWqAJsBYW1OhwJ0e3qwtjADWphBEZh4BCeRa22zJ5aiItnMbG3evywzDLW
-oNU6BM1BddlaSWY2loMBMtV0dysIiomJF2YZgadEj4se78noEaqpEUNMLX0UZ7u1WhizMD7ShPN4SqL9
-/8U+XO6QwetRibhB2l9DtmmCaN/SYg9sXQ0FGoc23tXeHdw0HioOmkHLrxbJsPxxWImkBDeEG7sUWfJY
-LoAtvora1biVYcmHw1biaBeslmlLZ5XUz3FOs1LEhk4ochEnwV284CXZmISPha30jYhAM9TNgM7CgWqn
-Flqs90qGLh87/ONubd36r9XOLFP7+9gEMHivs8MfAfX42M27o09GBzMzrdKntoWrPCQn2w67uEeXRSu0
-2n2lpc7z+vOnhScx8GYzm8b90nnQNd0vJqRanFwaUkL0N2Rt7fRd5rw4p6fCXM39AYQz34KEyKqYQPfs
-b7/7VOm/M2V1XhIdt1dAiqoV/JSWjqZlN2yWHgchQuMswHOC5OYx3M3fJJrkG/Kv21qn4ybZFJLnPwOv
-4mRD6eEgnShZ0KZTbT6CSiImcHTe3IiqUOOHhANCGwFGrBT4tJ3aBLHg2fg0jEfhNZwJdF4dxIYkr97y
-ai1h46CNZxpewQ7KkEOkEpaFg0ECc9ZUPWuhVFMsfA6AcuDlD5o5SbcPvULPmAfQrIb2JwHC7HZHAEG2
-zhFAkM10BBDAzGhR1U5qhiYYgAXlVD3OA3h0OzJdrxJQoXxULQcJTMOeg5LJ57/xZTEU4929BFfDWsWa
-Kk1ySDU/hPGCPeAA/dFvsAOsIuvGOdFLNc74Pasna8ktKgeVhOhBphIPFkV8Cf4g3iBx0pQTkV8/XKM3
-JR72jnxNNrBmqiuTkyuSUyp951cAX9xdM6qo+rZmbdyu2NLLs9LcbSB3IZaX7vflLttSI4nprKo7xu0f
-+qaxcaBx8zcxigHW5CTCld2Z1a9fGcDzaUvgJuxKqc6sTa6KrPbeGsdlbRLlVsQ1UH/PMD4Uvr4gUZ0V
-57U1qoZXlalIrUlo1xrl+Sb5NNKNSWzTRTd94nPI6cRtW2PIvuwBooR8jWReCaLs9yVVdukBMQ+mRAeT
-sj6TLuhUrNIbNyrpPXSDWrhfp+OfvjHQpTo9MHBa+5oGNtKLik4EhHQXFAAo5Rd17Q4exp2tOyDHQtJd
-s5EkgGuh2oyAwi7ze6pGxCoDEi9VHVqSH8ZOCPwS56CmfG9xisoVS5dHO17W5L6eOU6n+2Uf/+14S4sM
-kqGoXId3aP748X6h8vJaAnBI1GKREovN5Im4Hgy7iNtba7Y44snNzGv34i5iWA8uUb5YcAK4eA5ZYV61
-GALQIpjRI+ufGJnjQrMQd25ipL8R8+WQddPwoOltNZ5Gsg+9fj7H0DgfBYCtwWL9+o7kTjrdcBs0C7UB
-W2d2XgpCvdNG0FV6+yk/nLw2MI/QRsnJBziYggDCLwQyoIxDCDiojK4+GJ1OOEfuj80lEGzzJegf3TW6
-RkiYezSENmgcBKeO77g0jiXGASMNN7jomx3xjs36y3gM82+63E4gdKpclSffyKgPDagg+uZFo42O5r0w
-I4MS72q4TsOjVu/TuWTgP1dsY1eQgdfwiwvE7QrFvr3WtbV1+y2TBrt9DzKEMqi2pUVOkL99I4fktbUy
-SF5hM/D1uxmlcrvBcXOnpLCIhC2PUzMmyAQU7/SEZrTth6MOzOvOZndsLpo9V/g45YQs9eDSY0gD4a5q
-nmNU6rFXrg6R16AFc4E5DvIwnu6UWuBEzk0Rk/q+QzKSWk2Sjd37kGRqtYx0nxYiOMA6Z+17LsaxsNAx
-RmI2gzHHOCIGedSmPpj1vwySrVfAOaPrINNWmhqKivYLr2DXEmq//a4Wmo+/VPKUlJGRgDxJEaO9TdSx
-VyclrWYbJrhceeRa62RrAc206PlSBHnRaneY5gUVffmI0IDP31s4whfUjQKGu6PHYkLtIKknZCdt/G/7
-Eic8nRH4fEXUys016vU6FbO52otvvJqpyT6ytXIsboOpacCtwQ0NPFSquFO5uZ8+pRZks4Ug//TpcU6n
-qt0MLmcEKyDvUwfCGuu8DVH6+beBvusPCQ2B4UsCYUIIAb6M2+A/X+2L21GNRSCHk7VyuIb/aqTugmg+
-9JVFppDTmzsTj0Od1603f4WLHLdeca8KxmBVr2X6Iy2fmBi3O29KmMSL49LmjtSdPikLx/2CO0pn7aPP
-f9etOVI7T2ftoh/F/WlJN/p9l+I4S6GSnB/bgQRxpmqPudFl2JOjK9mXJ27xz7drM4vBrbsH/GVGz4ED
-+wWe7A6FMLGa8q/fViOp7cZwpU1BemJeUI73Vs91pNt+3jF1upfSk5V3Hm7ICV6bLklJl6GKXxzGzNp2
-ZFeuyPaP885bUSzN3ugrTA8EvmKCFu2+yQKl5YTGxIdxvP4NOatWHH3vCZTOj1bRdzRxVeQzJmrbxLFI
-WWK8IPy5iAsVv3QVdI1UnPWIN8+B8pKr2WEWckJ3UDk/Kdt1lemLVC/ZYaOVjkExOZYRsWuqTQpc0+RQ
-3d9zmzzYVGGejdDjQII8P03iCygQf+oIvC6hLCclPyzHJYFhHH5lzgXrEo7AnY5V4ZYwtc0velHV9ijR
-uP2T96RhmayqcDouNqtqwv9kRkBcVq40psl/e9NSaez+GQuIzTjpr8mqBm51/a5G75hNX4anPaa99Vo4
-4aQDSOPuimyHc3k1ayX1zHwXKPBpOQILItk25Lp91It+V0uE258EkWhZqWuKyvYXpBOXXOD712yTUm0P
-jru0JtINuh3mpvHY8jC+78Fi+11nyhOUtb4iwufegERe/bLmvt6MqGr/sRVKKimemjYDqLUYiy1ZYtlo
-1uD38ukKWv2v6d89BN6RpkEsjsoojp1LI9AJDZayT2bISgIbOu47vkmGvschNgFZaSb7ZNng1iVtrjg2
-I6r2mVGBtdLUzFdfkRUb9kGbdn0/K+hH4ZrK+gljYw4qEP9t+/SSZ2DSPoUO9XGx2Csc+6M92Vs1xM2U
-t7bW1z+yOaNXwMkrXv1vr15F4OM4c4Ep5Y9m5wuXMmH05gEWrVGfBXgBGn+kF7dph+kmCU5FPiJeTmHk
-YZ87ZorZzDldTkUmCXQYXrDAQ0waeifiZYU4WlLxB3MmNt4CsjdfAB/8w6NjeUqekTEaDcT+QFRasD9T
-AEQy+woah3zUUPXUy0/TjOlcZKoaUu/e8Ps3ekjV+IPusTlpyAMAi1Ejtb+2gnpys/NjLvI09oZH/VKd
-EzTOyHF4pvC+PDJ+WJJotfduCOEZ4xngqbOoBsUyiGF1Qq1OQ9EAK5uia5dY8zAO0Q0YE2FqNW4DPt6J
-qPWyEmUz9gcRdt6nF9P06TylPoGwX7KfkKAH2wx1SDqgBJBYUp3/JX454QQhNPb8b9EP0bym6BwCADOF
-uuKUOD+2giDOHzEBZBoj79TR/ByWmkEmi4SEe0EhaTYLi4zt3C9YYZ2foxrhBeOHpD0SVxaJO3zvBPDk
-GimBINBnFr5+ow0/Kr7mgr3DIH2/49qniEsRdMw+NXytRY610O7R3NUup/30QQf7mgtR8Tb8+g0CB7KA
-vig2GgoKNtGUxjcAltr3PDn5+V/wlUPBDGYxDxn+69CO6Wk4FQa+robluywNVrs0JMCfdXTJ+Jz4o8Zp
-wSwuYHY2cgnio/KOUA2vGr1nRkKQyY7HCnQb8sPn2g1DATO9O5gMHwQYLLxvw4KT5uOceHwJCi9L801w
-qTFTX76RWC5m91aNqoYjvFU+yJLI9YgjQvbxXbUNQRUdj5FJVm/AzNCGz7XAkRQVv/xHVFYxbnIro85P
-WMJTlSULi5sEwrO2mWanT1pb21/9OZz7EZFQrd+w9yAPe0dsEW6RBSXfI9rbaMBkd79IoPk9hn8guHmp
-ZS/tqle8GbO0tj5/0izT9qywSVAsKk1WlfCEfsK6SybjZRWixIu7+00G7L2jPfIpFotxRr+gU7bfCBsF
-tCLJR9HrVJpGmY0quUxYLGiKW5e0upOnd453tO1l8VdRRdl42uu6DD/h6JN7EF7ahkWOeO9ou51p/bsF
-oteCjxKESpSzw8BIjwelfPNe2c2TioXJZSpeidCvLuN12nhFmejry2Ij7jubkvTUnTxdel1c7YPXAoGo
-f3faTrtob7xjaHG4RZijPR665+ITNFExH7g3Dv3d51f8vcyTbMOVNo/hp78UrRJIRV/Mo6D5cXn/iR7h
-C1kGUo6k26saPHg91GNT31gVeSE9MPs4x5fzeNYMmJ30/j8fsXt9ov/A7t9GX4T84cegmXr4r4lrdKnJ
-sfCIN7PK2oJ8dPunK2Gubbg8eAdlJILpZZaP48mNqtc8Wxy5VPem/49YWxz+4ZobC55/+AOj2fYAG79z
-ux1Ww8yLq96nVZ7JKhGz4Yxol1OpSz1GZctzdyB1Welvzd/Zr25RqxezPU4bRTpb0ih/F3Rd5Q1r13zn
-QJHZv3VaXDl7aIGxj3YQfxiAFNrcldOGLtqh+nNhg4kkdSufcbkZdzoj4x/mP+Vl+lSJMz3QFKwH0LvQ
-IbVw7FBMYM06hZPd0FIDOwzYZwjKrgudBkZoYZ3OkDuvFAcTzBOGNUlloCsYltvY9bsODJ3XYnQwNkFX
-NDBUzWhKY2M8JgPAbUpjY+AKuBAMjQfzoU8cG0Nuq1c//PlOB8Jp/u6+b10oWNCE+59790x67Jj02Tu/
-8NjxZ7nvfMeP5z4Y5Dl+bDRz5lZ5+a2ZYIrXVd+bLPmf/vHXxSNfynW0+StEZerq7Zng6U3Z/KJ+A2iz
-carrsoeStyNZ+srm8Xr8JDvbDDXNrzkktcsgerIdPv8Kvipq9U+fjfiM8dsknNAkTy+vwA8Vw3hS7b2D
-wnT9Zi19Kp5v78mm+NnMfDOGTTsVeN6or1WUlbVsLy4U8X5Yx46vWeG8NJl4Mybm69d4riI7pCSNS0n2
-kjXbZNqtDL3K4fz6i353W8rUTRkfOU/Y4yU00uFRqBx96RlTXp7sdJad6EDRy+YOd1ubWTst3fb/jcC6
-czuiYr7Nd0gtKgUM75aWw2ltvbZJyggtth9/MWUvlX74qFROTq4u8nCy3/ApSCT766tX799+j87wA5C1
-ycam7bxPCiig6TnohizZDV1nTTZyHeorhCO7ByWD4C9z/HevQRicJBH1jHHGNMsRB08+CmQ5ffedEyvw
-0SSMc/Sas/0/AzCjmRRhLD6deYu52ohzPPD+PYYs8ItjXypc4oNE7bzcfcgyGU3tsM3MVDgXLxLtNOZn
-5ifapp6d4jgn+30ii0PiAyqEXDm9I1mPHz56JI7m9tQ3Y1tzk3wiJH27CXltzBbv1cCrelF4IDW3JeWg
-b/nlkyRqhmvQznASKfF4vcT7LTq6htCYfD+dmG/j+Ganh2dGcsCe3zIVGopTkcda94wCEXF9cYiKtQmF
-b4AdHyx3ecVPoWfKE5BDRjHWbJjnnycG7Uw1VDP18jP70fB5qqZNiTnaMiJzlJjyNRR1G0SVizbA1C1K
-7IlVCIZiBXO6zxgKq08pg8wWd7hSDS0y5i81Ztw8qkJRzDQWa4yY6pCtnUe5CRMfKSXfvA7jPGQexuDE
-qsSe7bwBM8gyC2COHBphAhLYw12pqlN7o0sl9FxdpjMIJoGKcBKEk66uG9q42huIlEPVuKIM/Zyp64a2
-kyz3wA3a+V7pVNDZ2ze/aLw1mXX7bETAo3jat7Yfl/EDTCdEtgbwhBhywzYd+nYMGdW3ZmNc/qP9p7Vn
-QeoFkcKds6CGskAAP7a9nsLYf8GRCZyVR0bmwVYRQbdsLLa1xDqnvqCVaSN+TlX75pNEVn43vo9rt0tg
-GiGIUByW7E1Ys/xSzcYkI+5UaWloqJ6ub23VmMU8LjhVbcc8ks4z79PpGEVT5DQM3Kud+p9WHjmy8ie9
-mWJ20nu/ofg/7lZW3v2jM53XO5RVJ9askQLAtTFS2Vbpe0LH9MbuaZ8H67ofNEMLUmjc6YpyNn6YH9OW
-kEqUpR9Q4M2O1fdNH4cMCwQ3R4zQAC0sEE5Mb7z0PJ+yttGjeuf3lZUySCYSfBYks7KSvDx7DQam2pyT
-S+RfnObW/21tU4wpPn9yks+bZkAHHz2a4kJGmYvvQ0IAsamJiYOHJieHRn0ZQKkm08j/GQSEedd1YuLQ
-wcnJQz8nqx7q5fHnGFMB5jQ5K5fDk+SxQ/ius+1Jw67wpNkfjCvX55jrZgUvUqsGVeoNzBLuQwuwAUZ1
-OhRDESqjfQyGVDofurZ9e8Lc3b0B4rK31HWqztcX+JWsZVshrpY++j8Li8QP5f3auLgix00KOGd6g/Qw
-XEhrg9QGWrM6xGjlAq0bfpkDQBOqKx30I6tOneoM1mZqvucYebXu5Ytpb8AhhEL3Cf7x9LeTsVInqTU+
-2hMDYNryWyEawsRUGIhgbR9DAZqdC0mF0Z3DfbhuCo8+V98Q9AEhTX0YVcthdvW2ATSQgDMpIRAEpwEO
-axtjyIIasvNt/j+Sjgnd5WTvGHeV43YXqyHXlDtYz6HbqH29HTjtdnSV69Ai07wjDGvCdhdYikoXmbFb
-k2ydtlta3ZlNw4Cn8cMWWEMHM2zqllsNw1RhvFZqi6GF2sq7peUYAYzRrCLFkxfR8gt0OhWCKJ7q4KbI
-wTy+CAZjWvN2ZZf9UZvH7lSFn6BxSOGRaXug0umKgFHln5MnwZPDlruTaaD2UNj277+t6PzIA6/h7W1L
-ykHnSYr1pBmPkEJGgwqjFQU9iYm1B+LWB1Thhb224CjiD5wmVFMQnz8v79iBQTrWtx6su9CeVqco+PdA
-d+8PRgdhXuOmXYWMteRvXSrT8Tk5FhasUr9pDuHxX9TymMCZ/s7LMnZNk4DYYFCnk/RmA6a0BntRBlnP
-FqvtSH8jVjd2xTfM0rCgcT5A4POrGH51yZjXhkF4sMMvgwKreNkIsEL+4DOjxKDZ9ImddIPKwXkdhmIw
-jJ4WbkdgBMEMGPIERdoEROzZjRrkQZLUOgzGUNgQBXdJH9M3z+wQblfT9zJFRDxoGESQJlqYiMMJzqA3
-zTPhJvrNHOspTETLNDvcN+jm0bQ/JK3uy2tA2QMi9r8iTCZ+p/n2MR3KumarMTSKyrF87trZN09zjx7N
-ffrGTDE76d0/wnsxJJAXgwOvdymZgDEYfdDgMOh+N4TaIwgLRRA1iqpgHdJxJm8Nx2933s0Ly9Nfk4Xp
-tIqq1DhRMdsaj0fzu7vz6/nTyYr56vkwGTjl1wJouORXv2WgmCu6slzq5RPUiYZSi9TKF5PDVT93ruBl
-2fTvT9kZj91TeBKBFkFV1syefzOYfAk9V0G1zd3FUp0OClDxsHRPJVEiMVnXlB0ZIXNvJSWtXp0Uev9f
-aG4sBP17P9TcBR/4IkwcrBc1sV9ENqnu7AQr6u/Ky1MYYsY8geCnzGdmSsv0pTDkYuxf56HReNQtG+0L
-oxg7iUir4uPi4leROkeYTfBpxEVlzEl1qq52Sl1+bcjZ39hRSExLa+y7ymhinkE+fS4oaJXcIoLz41Vd
-ojlJ7Whf7lavQIebR1oQMEMK3HAVE2IN8xs645lMDDONoXROKqpODL0yv9MhvDOMjQ1DYRizl3luLpXK
-3cmLf1fiYMyz3H0YsVFCG8xDj6rDaSDBoTgqCALD73s1N4m57AVPI2FUossdQr2fgr1V7W/+aacw5w3z
-X8vw0fleCkNoclV9fnLITBkgMfJ6/z4uLvY9HCUWR8Gam0eMowvr/G8gmZCHDBiMRel1kVCzBVBz2Jje
-uOjzOK3wA/wF/lCon3UmO+bKKozr+XxpJqT/UGLbyJuwspho0ju0W5eAfBh5KmODVppohtK80ij/lH7O
-Fl9BlXFVMre9//RHSVHHM2CuXsp2/j3uQKwP3EsnpLXQh+jLWiMINHNKAj0PuqQ6c1kFqegJFHPapWLC
-eWoMr+u3G1MfX0XcgyKOqouKQJ5+gp/nuQg+rTg2uvEjznmx2uTlW+/oY/JT74Sl2cWslpCU8vIjrVNK
-lEda+655GXZ2Et3fU/nRjxrmiZ1wuHdhVJqez/XFLxMsHxQKOSdKa3YlJS6Gfm/yW8zznyDooaf8HJwT
-wlKxQmqin1PoyIAqJCf46IWBCKlww6dTpXUAC+Ar5wc5GFys7V9mK+Xy/Pk49RB1XCy2yhSP03Tm5fBw
-ntGN0B5r2K4TSjBo8yhdGE4RhFHIdvOzVx+sgcfMN/MMlTirgzY63Nbdo8/iC7fxV2OTr1lfaT76rIzd
-IpHfUqEQ5/WS4oEo02UYXd42+LmqBFJBJVWXNia0Rl2UvTdAzLNrM1gNaIE/jMFL7+ATrgTeAB5RpDKZ
-Qghrvls8b6UtWw0RAHN+nxzuMK+NXVScsMMywc3kr2jK8d1KxnHuS7l2p6ufKDMySha6/hrtLy9XCIUa
-vCzjrBnDztt67wsRj2QkMtFjQbRrUJQPuQGXCaeUS/8rgO6tRWOlC9vCAdwH4FtRnvng8/T5+2n6lxZF
-ZBpWHMP1eFI4GZrkQtA12swWxGEXPTqigUtRmLadA+fTHFygsEDGVrteO0tyzAmXTRh7/PcT8cZ7fyP+
-80OPd30Te14s7RunJDBSY/9cb76rUb3RvMHXpVD8yiTpAYYbWcp2cOCuPj8PLv8fgMMuS6HIS0Fijsx/
-Nv3exBQfNb9/t2vykmWOK12yRhY8SMtlIqo7e3dOiXl4L8bX5QcmZuaqhC9YWhhbn6Q3u5q2YyXfxYA1
-vWSVWV+feSLQq9+eozJcMzfXCpYLGmtcxOudsnxGAk8gipIPtDY4iqjx8IWRnJzD7/y9F4SN/25L8Bd6
-UiKPDhmD/Yeglp8/LzfQMzKaOtCw4T6OsGX2V0gEqVXyq/sHME/d16e+NYW0+P8NpPru5GUzSIeuY2/H
-PmwWXTC2MrGIY/25h91Iyjmae1oNe3NP9QSWIaVBLP43hj/FtzMAd+S/jkEcCuBGatr/uDi4QhbtJjhV
-JAYRR4WhwgC12d/pJBu1WTWYghiGDw5G4hFMhTVux+yy2PIxlpQ+Agxx87oyo6MuqzaTA2WX6QruDey8
-2vWXnCuYlkAvrKLwmbVr7WJ74Pcoj8U3B9BpPRulyXtszY2s3YKt4s7mv6bvGaA4qwOFMWedKAO7/BPo
-Jc4C02gv60Vmtk250o3ddJ8ANQ8fFL2fGsy8dme9bwPaIOp+AeCpm1dLaeeItlUHq9/Yo92WrXesUlOC
-RexG7d9UH6yyJaoNYD3tFxiL+HwqPTGC8iqO+RYfu/23U6dY9qyAHgBXBqj5t9he6vLTdylsH5bD1mZR
-nUjj+owoFFlT/vSEU8uMS4+35jHe2OLtV121Zpmft7A315qzXiYP/XD0QFQnLuzAJTONs5WEww2ana+0
-397yuxH5Nc96YVRmNtHrqUE13BlMlfNYzKHmt8F88QhMuH8tn+WWzrcH6sQaVAMW12d7QSmq7q2uRtEV
-lS4iFGj8GJP5aqKgQTsQLvDifsL+oIVv3iWw6Az9RyMa0X0mXQIa3te+Y9xgD0O7aEg5eG5OhrdZIM4S
-F2zwsmPeFAOkXCt2XEWfpavGpzGmRDlLt1jhKTJiAS6/862TTvQH2hf9V6KnjtxR16kkpS8Fo5QQfDZ0
-w7CZwzMyFNSbo7Q3oaEXpgCWMrjR29C4eXX8MGXX8YApkk7YdWgnZJy2w+6E9YEffnhZickLHgCQkbQ+
-xNojDGKIImsz0w7wwgwDvIOuzDoToDm/uqJ2Ew+I+gYk0J9D3ToUyilIucs+CdWmmnrH2xw7ZZNkXGoX
-bRpmn+QYT89hJfI5mpGIBGNLm3jT0A/bpcUha6klSIHeRG6TEH/8e1ncIpMXe25f1n0K3yk5gz0NXy/7
-Hodazijp+T4WbPq2rfdZngaf1lb3ixsGZ1Ejp9+tOlHEAZUXyGJ3B4PH99Ukvht6d7Hw2a6LI7+7439V
-FJg/fMXw70cI1M4FhGjBHn96/Hv5b7okd/HXz5ydtLoEHCl8VmiJ2j+7jA0UWlaPN74ln/xH+28vo9eG
-h/TpRc8iuUvTsoWg7BW1CGwGpXA3Ns4AHy16DSHBbIA0F24+lLwkXOHXelY/c3r7cWVq7FHn6+ldbYub
-BOZe5j1etQ3kp+2tpSGgsrpshfRIQjRzTAtpOX3eHTKiXtf9S9A1BMVPQvop6O1PDa3ndl5+qhLqtf3v
-3YB4ppNybVweS9omR17QBzWPQ+fQEEwbvH0mRrrylMDL4qhCXUuGFKFuM4aGfM1zR2p5GvAWa/5Pr7UW
-2mLlc9y95mFXP1I28BKht7K+SpDCx2Zo49oyCQzEymxCUxynXD2iatXVVpceyiqUH/RZppMUXqKiwZkE
-hMu6XhpFKQfV6CqUA1y9nwSgCgPCFIJy1HIoYaIdQPgFFgiENm7UjBSjP6V92mRMsKkXHqaBFppnog5J
-HXZx2E5j0MdhjZHGrmuMzfdtTIz2f2PBQSGksWhkuKOxVuvwtbnO8ndjAz2ipuaGluHmFpbVc1/SOor/
-0peVonNMH8vto2c+kZyXMdwqNC0l8BMmy/UwEQhVYhaDKUXcrI2GKhliuNTWBjUxl8TvhOcJ5CvoKzuY
-jjyFq4aSYCFxsGbNGs5N+SrGLFsvR0srEwd7FwTDEpeujSw+Q+JME4GMGwHznVIlJWOMs8LGw36RkidF
-R4+GQYmuR7rCPYdowhLkn5SOsDgcOklE7E4CKcVXzLvhQUYpdWN3J3FozVgfw6iyTEBhhL5+jDbMUGMy
-TOMipUEIhTJi7MhVCZnAJowTgIv/DK859kEj01FMTwcErDqBRRSAOWunqZKRxhpm3ktGlWI5M3S6TCRj
-icuscP9Mev+gvzXUZBLacAii/vD527A+JGCcLvfvqx1+WPMHPbYXAKHjP4SgGE6QFM2wHC8QisQSqUyu
-UKrUGq1ObzCazBarze5wutwer88PIIJiOEFSNMNyvCBKsqJqv334n9AN07Id1/ODMIqTNMuLsqqbtuuH
-cZqXdduP87qf9/tBCEZQDCdIimZYjhdESVZUTTdMy3Zczw/CKE7SLC/Kqm7arh/GaV7WbT/O637e7/eH
-ERTDCZKiGZbjBVGSFVXTDdOyHdfzgzCKkzTLi7Kqm7brh3Gal3Xbj/O6n/f3/wBiJJyrdFYmZ67Plu1/
-wXI+vzzZefPlP4+SGZOYjftm/nud57S3naMAibURm54l7rXvx8x+31r7/mHFele+v7z33PXdMp8dtXN2
-kO/AzPIdkJnZZVcdqwCJtZHgAgAAAABARERERCQiIiIiYmZmZmbWfQOQWBsJDtNPAYQwxhhjRERERESs
-tdZamzZX8jA4QtbnEEkbpQ8AAAAAAAAAAJATAAAAgy4BSKyNBFcIAAAAAAAACqLfiBPH0DmgAIl1hCql
-lFIqSl59gB4UxDpNlLQkSZIkSdIORoKLmZmZmXnRn5773vPAX1fNxv08Rzz+AwAAAQAA//926MNhbEYA
-AA==
-`,
-	},
-
-	"/js/bootstrap-3.3.7.min.js": {
-		name:    "bootstrap-3.3.7.min.js",
-		local:   "static/js/bootstrap-3.3.7.min.js",
-		size:    37045,
-		modtime: 1534412156,
-		compressed: `
-H4sIAAAAAAAC/8y9a3fbuNUo/L2/gsLMwwAjiJaTXp5SYbQyyfR9c047M2cm065zXLUFSfDi0KSGpO2k
-lvrbz8KVAAlKdnpZ54stkgC4sbHvewO8+GrxC+8r7+um6bu+JXvv7kXwIviNB4u+34cXFzntY/UsSJob
-xFq/afaf2jIveu/5+vJy9Xx9+Wvv/X3Z97TF3rs6CVij35cJrTuaerd1SluvL6j3h3fvvUrc/oX31cUv
-ygwC9jQra5qCKOo/7WmTedf/65a2n1BftM29V9N775u2bVoINJDPOu9/kDvyY9KW+95r6c+3ZUs72Q+g
-zTK7rZO+bGpI0AO47ajX9W2Z9GBzR1ovjkiQ1cH1z6x10O2rsofAA+hqvVNXAUCbMoPx1Xr38rnvx1eX
-u5e/PRwuo4jd8v3fsh+XO/bk+e7l5eHAbr968bkge3e07cqm9i6D3waXXtN6RZkXDJnxbe9VzT3HH6l1
-u18CdIQSTXh+tuqBF0P0wKZOorRJbm9o3QdJS0lPv6kou4JALzJAOI4e/kTjD2X/viV1V7IRQnA/uvNN
-nQL8h+bvZpte/6bs6Xfms8bq6jWjtv3sMMdN1rSQQZ94Ze3FqMzgXVOm3noRRSTo+k8VvUp2qKX9bVs/
-0DoN46tkd9yIG4vLI19uenNbkZ5aUEQac7HATxItLnEa9UXZbQhk/1DQ1BSCuBvNXPdED0m0WB8RJy0a
-mfcPBwJTFPRtmee0hSTobvf7pu2DYYIBrVOkQPU62r8vb2hz20OKY4QZAEdMoDGoa5Aohgi7Hvg+JAG9
-Y8vd7WlSkioYTSR6iMs6ff9pT8M58HBKK5oz1J1sVZA6rWhoYrTMIIFx0JM2pz0Kyk5gVK6UFweiz3fx
-tfzVBmS/rz7xZpi0OafUDh2P6PhUeo/Rg3wNGyygJClMNIrFlkuMaZQEKekJW+eAVLTtAdrQw2FyF9OI
-MXcqJ4IBe3OdD6Ir9n16Fe+ChFQVTBjY4k3PrthIq7Tsbsqui4AYbvcM2zRIYMwIDoKkKpMPACecBIKk
-ajqKjps0+OM3P/z47rtvI8ClNMBp8P6H19/++O79u+++/evbn354zX5El79a4zTYt03fMLhEf+tFGlMJ
-RA95kNKeIWigVcC7pMGAj6ClN80dhWJGVOMui2hA+r6FgM9QLDZAm+xwgMOzoqUZYI0z38+Clu4rklB4
-EXwFt9EXV3/5c7f76kt0gQFAgpHyiEDwBYiiKNte7cIMbWLfj4N9y6n5Lc3IbdVDhPOgonXeF4cDzCM5
-0Y4JcAk1a6HmxOT+N3dc2vF2xuQQjoOyk8N+L15CU4jYsHLibyrSdRCUNZjjtTwoSCebZSSlAG3zGemR
-IKc8gs7lRGECETpKAcOFGQd7M/yMYjxcBG+auuvb26Rv2ig1H9TNm6bOqjLpTTkl+cQYjWrJo9SFQZUa
-bZw5VmRfMjqdkNu/gWFTg2HTgTXj275vak5doImvadJb/BgzTp42l6ycCGGTMWbumzyvKIiieEsDcQFR
-yFg66Gj/Y096CuOBpwd+wil64FB/SYU+jRgjC95t9qxNF5GAfuxpncKHI06Ct9/87vVPv3//I05ls7L7
-fUPSss6jxeVxk0wYfegSPVSi5Xv6sQ+BvAiCALCBh1VQIDuUHEjLjsQVTYFUdhpwjtmS0/n+tgdoC+5I
-BUJQ9DcVwJlCO9rEywgwAACub6sqihhTM/VFP/a+r7CtbwGcXtEdRAgbKo4wYD9+MleZt5IDXsW7rYnB
-q3gXspsIAwMBfLXgGIVM/JE0FcyYICGEEsZ2+7bZwwQv1giFdi/fnwxziVOL+xMlBV/z8YbRLhE6YkGa
-a2Qvg6CjaETKhMEY27jX0ksqC9kTCILtwO6ZMEqlyFOLaY+RlXWqV28DWpKWDYiiRIAKGEgAbaG6Tgqa
-fKApQMxUiBaXTBCKIQKS9OUd1YJfCjZ1F9uv1cjWDVAoBo+bj5P3+/4UgEU0mskgTNWQGka7ocDT+PV4
-/AZ8bniEie8nhgosSJ1TgI606qg3mi/XaqQtyWrf0q5j4y/Ov+ARgAs5nwo5L5Z+Y/xWkl5cWaI+sZ6c
-l/VywPScsJcDDtLeos+/KAJl1ox+UzJI7EQbgIN2jvsaoE0szKRUS16EzeZlB59xQr5iVBNJWt5hz7yp
-aWz3jGnrZGoiGPIMK1WxTYdlzprktgMoTE3eCe/KrowrKnuoS4CCrGy73rSUZH90FGjjlw60eXF1234u
-OoVpOIdIm5AEPPjiL/wHLGu0/fIi6Fn7OGBIQ/8Wa3pGOSekbW47WnH1PKcDpVLBTvWNcB5N7extHGZB
-V5UpHal3/UKHgq9vb2LamqMw+QxjFOZbepXvIAqzoKx72t6Riqn9PbntKERB8ilhtoBL9Sfzqv/Lsk7L
-hPRN2zmltIZ1NTQEI7NBegAckDRiqlHcYFNnOmq4o+A2bn0phIp5p+zpTWfckO8JPtBPcUPa1PdtSBlJ
-f6Cf0ua+DizsKu3Nm8sWWLlFRXMn8Gy+gs/B9xcQNHXf3CZF15O2B2Xt6aCE+iHDEsgFzQ0DgNnn7QmA
-+LskOEOvipI7eqIXX2bZy22EubytX6/XlnmmFiL8FX2BOSChRAi+b8k+XKyxwna4WNvWgkRkZDJlmcHF
-BZdKh55+7ElLyUUpGJpIkRD0JP+W3FCEHrr7sk8KSIL7okwK9JCQjnovfiOMHSYcmfHWUvJhI578Vjyp
-6cdeP0mF8AwFzx/JRKgebag53iw7U7n4hwM06Vcr74HJkoqS9p28hNZDmxWMPgtjTG22KfLvaK+Hs1aX
-TVEaac5xkQq4mFPLaf+upzfv6pR+tFbFlIeSq0iwJy3zL1GQFGWVtrSGIGDPtL3EGwYlGw2Sw8HkUuR8
-8e+a9m3ZUv5a4/04tsw/E0omftIIsCUDUUR8fx1FUXI4gFqYy8zIUbaWAEfYk6tLZl2mCrsKO4xkdcRG
-+qB68O3qMrzEWQSTJUX/NR1zM8VSQH+G2cRGtlArgrRcbLvmZ4k2p1xlL9L2KzeaF5C8ck35cCAv1zoi
-ZUrW7VjyUAjYI1t8GEowZqqEoCMKE4aZgUQH/RHqF1BIXiVbsSShQCceIYmgEZb4YI/isvXY0pR44Rzg
-cW4GSOLA92cihyNb9VwQEw8SFDLvaqSUzrK5PVcGqWm3siU0V2eyYhQKZI5x1tK7p44j0DNyptmjkccv
-eOE8ATK3WbK6g6lhjCkzcSyU4CJS7BpvQUUzRiU84wJwKcLTZQYzl4/hIOVocckZ9zrKrtY7/GGIg/Fp
-2RT90NKK9DR9zxVLeI1TBWlYHDkvuQnjA8KLD84gGse6Dc8a51K1Sw6ZGEzayZ08eJR7yudbSZPU6q4l
-M7qaipYM7dCm8v3K4dBy0+/Gxt2TUKd9Lxe7zfmOfIWYx54NIMUIs4UMmizraP+nMu0LTIfHBcKZdUXP
-5zAyC41XMS52wXVT1jwxhhzYwNTuoR7YHXFp0KAZ+zFZckpLN+iI18yhcgZJnVYYQiGkM6GKzAX/iEGw
-m6pvENKkKoW4kFaWm65IYGNdKVddXbucdf3svLuuh5UO+zjhpFxubMbmCXRG5w8HmI5i88j3U2dgfqkD
-8yORM7h24s255d9lyqmj8gfChZ0q4KS96huANoXvw3xQFiIOxYMDGc4RLnw/c7mUTOMWItIztk83s+EM
-jfLBAwdXA0A7gOnTeqz6hnfCBN6Xddrci+5VQ2weIyqw1zJdMqBv9wydzk+pQEmCE4XLz8uI8VFxGsUz
-NJHoJ5omkpM0oWUaTNHRyCl9fvYtaaqK7Ds6iRekQ7wgOR0v2CwooxcRFPH9i65o7g9FmVIVBUG+D9Vz
-Tmt2qk+DYGX7RARhJt+nQwPpI0MDrqyAOUOlEKUcisg4KKyB3F2xtYrAF8+WcVCmy2dgh2ebGgtu9Xgm
-3zdoJDu2MLjwzL+RprG40Ab699L3EUYuSdPXbUle1+kb+Xopge10gzXLkV+mFlBAJtMxj0+CvuBJ0MEt
-FyNwj9tMVqXlDa07y7tSIfo5nXzPNK5B/Ft5JwQF5Taa/QZGf04b1MK28rsc7ytrJWJjrAxOgXxlN4gr
-0+vck5pLSNMRrbGniJsRsXSMqO9T7QvAOKJOZuTpVwtgJGHKDJOoaO4Dq9+8vZghvMhm7EUbpERIP4pB
-wW0hHB8OLiB5Jkrnj/lb9eJCtLHBsCyFAV7DUjAxdZXv4BqZUX/6cU/qlIf91yNmdY+dgnP9beYTJnth
-Es75GXBgp3OgHiMhNgkAnC9bz5g/fEVre0mPnGxcVqzyPQqxYEJ3sVmUEQkSckOrN6Sj8Ap0SdtUFcC5
-shVXzLxwONwTk1UFdAoV23tCGp3N33rH1Xp3Ve7Q8Xi0GbawfL15hn0Evw68UShP6xG8ESO8iGd4w4j6
-zFL3VTKeabKDCA0+w//P5dRsDs+iJic1c4KaoefLMT9M6TE929nFDHTCDKep+CkcAoyMTlGmKZ1Q/Qn3
-bcvr0SYYX6MzVEw/g4pDanDXmHKnyWbW7OoUoW6FVA05qwNmyVgjasXuckugwzhAwkF/dtoGkbYDeLZ0
-DCEsEmE1TooEkiH0wqzOzWlrI4YUYaqS8yhgptZohnN9ndFWMsLehrjpOGEOjJWWG0gfLxI018uq8lFd
-NtaVdh3ltavcRz97hFuphj1b9KMHnUldmqts5C6N5RIaYcYjTSde3EaYF3wN8yhz2iVFlG9V+jhUruYm
-Uc5j8fl+kttL2iQOPwknUeL7F19cvV79H7L6+046Gsms/2QXu6WsN4GJtihT5osL+2ebhrFOK5guVoIe
-Et9/EUVRIlI9hwMkkA51epjADJ1N1cYwZZ7WKHQlAgzUIPZmT2tRuOH7sjqRvZlxkO/PZKeScXbK90mQ
-NHVPyrqD9Gq9wzrbfzhAqiVwMlWbadvs0+a+BtwPS+aq9VI3Y4GMVN00aiVnZQS3h7dKDWC9FzHH21iF
-9GmObmo6unpgtEkt93N4Y8rdz/xUsWk6LTalEdBjrGKSfGAXAGfRiFn1e3bPGHfNVqHaWDCcMXTc5BNf
-LD+hjSaSgBlWlJdnBKoiDXuhLk7TDoaWAGN6ZCMkEOFFjh4enVX2/UVmlFDU5C4m7aomVk4EzhXKp+Wd
-HRGd4hoFZd3Rtn+d9YyooJGGVnW9SFr3Tr7jcTZFk+nUvRpWo0CivsVhKAqy3NBJocoMh/TtrYiVWlpr
-jkG0X2DBctQl90eLECZZ7YQb1RfwxX8ffrk+PP/N4cVzpISmTFz7/uKxYmUk1ThRTOuAkqDrm/33bbMn
-ORH8iRfpWeqTIjKPqJP6FrnvP//NYhDC/FoLZeUSGbeYb8tNpGxaRIRTswKbkYoiFOBVZVg3PdSwIlWU
-5BGAy4iqxIimxxta34JlwcEsraLB66iUOWgtfzcv/tuA8PrV2vevVyv8y7V592WpE6e+f71c4n9cHw7w
-OlojXAb0Z3g9nRFzruQUuLmhoNtYV8puUdeWTZOPnp23afSwxTmbRg9q1DKfbzIg2cua9saI8hK+Q2NM
-Z8fzQ2YOyYnG1Tdn+8m2j+k4JhTnOE81nZhtfk4f2lsHtNq7aVJSAcZoM1Vi9EzUN7OiQmI4nJk1YLlT
-iW6zq3gHUxTmPFLn+xn/D9OT5V6j+qwv4yb9FBlag12PsvBmZVhakqrJ3cljDvpKtNCFI0q5mAVf3Y9M
-Cpth2rbMy5pUXzfpp++JVS7GIy4xaXm2UHnKZV43Lf1aDv2GkadOhSmXjNlLPR0HOyxQmUlH656pz4ak
-0NEdO2q9ZyJObAix8URSxPHJBVkveEHW16/f/M+3P3z3/V/ndsiYNVsKvaPiLMwoYVKkNbZrRtVAcl1E
-lJzZr7rwg5EVGVcWWNHhWOkyTrB0qvslXY/shvg4Dp8pfFJkEQvjkRnT2aKphaQQXl77oyIelafvaD+5
-xznAMIsEaQhFKVrQLiF7netvaVf+fcj8mxV+Qk7KvVLGpMebqMTt3TO7iI9hXBUimsw2lAEKSTgZ3tyH
-MIpD8m63+1OdRiW6ZQeHQZjflLq5bc1TeQJQRYQOiemsGUhdMR2x82hjPNNFaWqrlPGM7Pe0Tt83DFgh
-soyHnF6RlB3vmz1cI76v4vq2699ynEKEqQnHuCohdUUX+RaqNKB11rQJ/R2vUla+/tTAnCd4uk2NtT1T
-4JA6RI0yiIeUxNNKDsLHDjpieTu+HKMH9942R/BYKcnZsLHJxL4/E0QedrpIZr88yaCW8ZRlcoplPQ/Q
-ZMec6DfD1/LpLI+N2Vg3dvPx3Pa8uSCo3Kr3lOyDljN/YG88GcSdoRx7BDimEJM3IquE4OxSDJsQzNtT
-BUzQgxoriiJVSKxKUgd+nn/IcKgrkAfpMqPXlT+gtLk9X055k/C+JmRnqfp2vlJ9Kqdd82dOGVHOjaGt
-FYyhrTlHr2Pon33faH6Cl+bmt7ULR0RjB+wCRL5T+qd9Snql5kKjP4NqPIBD/HCyc2e8R4aEQMm8giJS
-81s8byl/IrYGvuZ6g+/ohvqmaUYQB9UMgTg1lQlm2WuVUp1mSxXIiv/V9RAmddjX9iviyeBjK+2MaBH/
-QwB0xs9N2OpFQ17dJcfokDfUkD0hXCWWRvX0wJKiwQoYLLmn2GUu3rIM4qnhwzNm8IQTgkLeQokXLoWS
-25ZZMsIE8H0Iup70ZTLeZ6Lmth2LskDsiDKkL0R64ybC2ZhGxtbMiIJGFs0iVjG3bDtq6dYm8azKOOW6
-oDCGckuizkvb5PTlQEdOmjfTV6JWwrKTbI6CovQDouPmn1CrZzCRfyYmcoUJCaIt5gxBOZELthFrdzSf
-nSkK4kfGcBEmMuqv5mLOQVKVtJZ595GETboOPuxJmpZ1/nua9aFYVMaF77rv7mibVc09LziQuwpsZz4E
-AMveP7DRw7nei7nuU5lqi+v5GpQJ6GNYpoPbHuUUv0KTBWVdUwGgKDdR9RSzCM5p/3VzW7NXv+G4/oEm
-PUQbEsUBr5xf/YH0RUDiju+gznp0dCMqsmI4cuE4JC+JM5jC791Q0t221NBn093485Pek7aj7+reEMAc
-t0AicyVq/9HhsMaXa+nrj6M9Ntz8XJzAXIzDAcj8jYM4Tr8Yk6Vj5i6ycU7yzOCu2YzGHqN3isKTCnBD
-goSJpW/JDZVxg5Wey0oODqxABleJkCB9ZJShCVbEJAt7kxPrzcSClLVFWbFRcGwVjHMINsNPVSrOL1x1
-4uLB+ci3GO3slm4x3GweXwdWXPu5h02+o6NdiM6+jjL7vJzvZGm5nd6Xpt6Q1x8iwiKcGC4uvpDpIop8
-nxrV5movMdqI1A4BPAk/ca4zuaPLDqvZcXx3oEz2HFumdrCBvXrYNO770/3mPOwzFLkLI+Q/uDG7b5qq
-L/cnjk1ZUN+/SGnXt80nu376cID2pms1mGPP9WzFtCO8zgttRIb5055O64+NO7TmSTDjTi+2lhh3+KZb
-cQaKsQNaRePNndPjRmVd9hDoWTG4Hh9+HseWSV3e8ERQuFhjzgTs/SHomz3AHa0oY/RwcYl7erNnRlD4
-7GVa3nlcYkUaCq9tGG+qy1eONivSts09ePXyIi3vnA24WtUN+N9nWJKm3KXsyQME+rKvKFPoKa3Ip3CN
-i/6mYmDKCg7KYb4r6T2zCcMHPRPARKC2A8L1aLMww62VUcHpsENMLauKP3M6iCd5lGRURq9q0L8T11Bt
-R/xSgWd7COqu7xPI2Px3ChpnK7R13h7K8GzwpHsxaa7wIwMJk7fYtPjAZTXDMF8U9oOvS7i4POKxDVrW
-XU/qhLHXoAYHJTLe0qsgmR5X+Df16G+eaOzd3Ha9F1OPnx+XlTT17gtae2wRS1KVfy/r3ANLvVZL4DU1
-P+tR2nEKHk+ImAVA+jg/ai+KqhYdDmTkCUK5kThbrTZqmxG9ynbMKtR1SDmac1YN0LATB3ZwRSgc6RBu
-lJMFbkh9SyqwiHIBQhHpEw7yrXEgAQhV5A3gctqGHz+g2jS3PZiUPMNiCZ4KM3+znfAwBiyfPiAHU4Xp
-js4OgiP+6trFYrbHD1q0SBQO8o55BoJTsvLjeyZr4HQHvNS/ncPcGSTspJeUAs6t0lNYjddMslHSqIiZ
-puZi0Penh4ioJ1D+ih541lBeYaY51QVT+Y5JivMVJ2Arn+fhaOyDH2C17E61Fr5PhBVg3TUMGyZtkyuy
-W0QpA/mK7KIUTeHiVOU4Oiz2DGEjUoSDoNnGIYGxHadBg5Vg0KEGXpRTMhE0Hmw8kLFaFr4gQviRb8UJ
-Qtiagcyw8F0mUvReaR6OInFgzlbabOoYjx3fYZ8EfbmHaFQQfDgA3jMxrA8R7DLvRLxtCPmueLUnNlEm
-DBt71BYnmgcltY1u8GSdepEyhdwbbqcAstFEsu+IXeMiFKoGIx4tu3cCa+/bWyvKooQ88UoVBRQNkY6B
-SnQTdXTrYj0c2Wq9hAukfyctlhn8f5YOmZ44QYiXCC8Sexl08Z/3OPJibzhPX0yMPZK+BMhjAlO5FdfA
-nMCGBvN750abapT7Nl5NmSnp3ojKFKgOEpLm5VzxSYy0k67LlCfxvua+pu3bmTDUxDSTR/a5XclFqkLG
-gz2Es0i6M3uoz4XIaf/Tu7fQmKSqw9AzxJl0vkseUMWz59WltEvaMqZp/GloqJZE+yq+b+6al0UF0vZR
-6zHoQHsTh3Jxtu7bhuHMz6OYoCx098NldPHnbktu+2b75257UeLrSJaEFmhz7fuwiAodZygx4NKYeVkM
-OfqIWx6z7Jt9uMYVzfpwjdOy2zMXB8RVk3wAR2SenuBmYMcxQtot2majhMqkhThmbCgRtv2Hub14ooeo
-jbIIni3Lh2EfbiMC6BDhKpqcE3Fj3pLh6DKD14K16qjAzXQk25dCmyICcdP3zQ2IosL3PwTiannzqpE/
-txzvwseVbfpmv7p52bD/W9VdHWoim/CLZfWqCfiGWn3yCf8n27Dfq+plw39sZf+wwPYJFrW1hjzOsNfz
-ekOqhGcb0u84GmCBP+AK36jNRPt99el7RXVwjwuB4p+nQUdqyLgNPbV/EiypWC1MJ3EJKTCJ2PVK7iik
-Z9ItfbmfplqymezKz08rTfh5cryXjZBRNeSQCJUii0bpmOgy85bce5gPQe9URIVvSJuX9Uow7OUa4WKu
-CacH3mZTdt+Sb2GOfB/mvA6ZXxeIS4O12IW1X0Y5FkH/ZVRgFcpl4lMSAAMPD54Bs/7y0CqVGMQGTyO0
-zW3Ns5PNHgkxYt0W+QWGxxhhWTWVjvNu5RRR11NEbSQPJb5/vYgy7mb0zT4SE8tW12P+/6NkU5HAoelb
-WvUEJjjGJb5GG8FBW4UOcRlKNHEulWfnXPTN/iD4VO9nwjdRtX3+leRCuixDdsE4O1te4zqqtsCYDgiB
-OROwSeXMdKWSFNav27a5hzeYTf6q3uFqklkYmlnRQl2OywNfUrgnWm7wCNuv1l/ByxW5iNES/BfQTYR8
-4g2Zpphka6RinUkAClKPNdKlA7shqjLWDrjJs4aUIij6m2orzlQOQU8/9mDHUELsBC1ja249N3tPrIPH
-wPVkLuhMTZmxSUmY/AtLWvETVaRKxIPkYiJoVMb12m06OPfLDlJOZmQtu0adxtSXe1HnPapuc1pxE5ma
-I7zIT+158bJHH9mePV6Kpk8sDVSxBFcQ2l46Ff84k2veQLXXlEdmuXEjg+sLZYcRMwGjMmor2Z5vv5t/
-jqfD681CssWETwYT2xGhGfHGJO6hzAu7FDKKR5VWG+nsMRGdRuDr797+b7H1UOz+4cfHzCV/xVHiVJgT
-vg+pFf6h+IE/CKnMEFMu17A4xSOk0pZZUSbhjkjVqMrI5o9//P/0hrLE9OUmz3EepVvL5DyG2ZbBFsZK
-IjJt9yBSkmG6nc1y61rcw2GU7lUPwtgo2EVHXLJ3i2kOZWL8mr1TzHR4IG5AdAwZeMOedxNpBS5xPl3O
-sVU1EtTD1pDBdiQCK0L5xPLlWCokrp9iAerF81Vy8fyo7Emz3yo92UGajs43XTxfpRfPze6r5Bg+qqF6
-z3GCBqf6deBCSEabKja6omcwtuUSUEl8M3kMd8pBpmAOh7XhSM5a9WUGLzgfHBg4UucTpELeAtvZKpf0
-hUttg6hby3RTvMy5hc95JuK/V0VYvuK/lrlEKGPFoYG+vSpFPY/cpCbXJMMf5M9ltkw21y9zYcIIbo3E
-1eo6/PAqF3zMRzeeLXOxWKsPaoeiRyfr5hbB4zP6VSBWoloziL1ZfCx5DwfocJqTgD/eyv/CLY65Ayxv
-TZnsp3dvI7uE1yPL6B//gJf0118JE5TUaXMDEdrcF2VFh8LAnCop8vWndylbWA39aHtLuXeefsL0tj4X
-lDUanf+g0pcIXy6iwU+RGw8nySYzZ/Q31dfOOyVN3ZVd7zWZRz+SpK8+eZfMHFpV9I5WnlyTBbBD4eyt
-IwfGNhxHh/eKp8ZvqYBEeHdkz4kM66Rcm8TTYzeMZKbdXO7gnG9/6dpv9I18OtvLvDq9Yck6PHgT+z5M
-oscGS/F/PFKKcLwdwqJBIipERzfwJAi6TUQSAyaMn4RfnSDBW9O4/XZoEg4dR+smaiFmyqatYKtZkaAM
-wTKlo8Jpq5Y8AEsiUCzN17cDPuQDTCQTiv+DAS+uhYFJFEWrK50DVzfMCoij/cEJSeYb80IVJ8lLV3mS
-enS+QEmNKUuU/mPlLvtmz0Pm/5JyFzXYv6zcRdSb6GFFvYk4ZmvA2TRd/73oMHxOUK3DdQfQ6Y8ImSbd
-3OoOe28fjLIVVbSnMrvqSAPhB4QAzNSxqNmdqmOx61eKF6O+Up++enlRvHjlGFvvRLULXI7IZOPHTV03
-R3b1qkX7/3y2+nMDDUZCWGcAhuCDja7HBh9GnYddvcORyUMUnR+k5Bp4zACJfpcIig8vTdwRD2e4w5NO
-vHuC/NUQHQ7zDRy5pac4r8P53RrdUwfo5EJqCzJ2W5Cm/ahR7zYc40A22Opfwngk3HjUN9G/3ApS1o+p
-MiSqN+aFUhny0qUy1KPzKkON+XkqIxk+znZqj75wYL7RFWYEJmITr9V4K/zkMDlxjGs8/bibKnqJRgaz
-3FUHAFoCL6jJnVeV3iuPqJJo7kd30ZXMkYn2w7U4y1qYT5O9/iLmqs/Es+bHC6TEnSBWHbr9p/FHVNom
-oV1n1Ra1NGtpV6jolmwyOZrqM7X0AIlTTye+n4w+92PALpRxfEIZJ0wZJ+K03niiHWOjZlOgPrxcs/W0
-uPxHE7tzzGRhe7xJ5HDgjtoN+QjtknGzET4TAhKtkA2eXJxpwlx+S0OG4wFOo/XmUSRm0ZISgSYKuPFZ
-dn/ifAHdsxdHS4O9DDwMHx20idIIW1lnCnDZY/ERCm7IZJd8bJ5qJglkqEGPx9XqF3/5IjBqyAkcapL4
-N0rVSVDZpJb76irjRMRDFymmu93hIEzpoGtaoxSC23dKkl2tdyvm3B+nZ7KpIGAX7G87UbnFsIbVaQLm
-/csd3wIZW9+44Dw4H7uYRbN9+KIAwrAsRuucRo7my2TleomKI6rNkXKCurhBzEtFpUxBpgP/JuktokTG
-uAb5E7+KVPmEly8iSKLsKtPnE+1UyQcfm/QUEh7hyn0/fkkZek2GnZGk3KWDomKVDLWoZLXaoHwRZVdk
-5/vxq4jyH/Kr1FHErpeXu8OBvYn9msDCeo5WUT2zvPRfTKGLbdCM42AVdyyf2ed8P1vG/FRwedam0Uyc
-HC6fP8P8a31InhfRQVCVwPXph02qjpQYnyE0nJ8G0ygdzlirSuO4O9e3Is1zrxQibM00whafvr0zfiQh
-5Cx+qvuycqldfO4TIpaRowHZ2JfKmtE3LFMnHj88b+wMIw+beMZfFbAwY54F5fjWABtJnbc896EBLTnV
-uZkxjv+5Dw187p4UEosPb4/uWX72Y47hTywuEmAYhzA9dS/HuXo08wU4ieKB8m8reWibyScIIP4RBltH
-8a9v8Q9zjHRVGqVz3+bQ54jihT4lVLCt48tAMuWQ2F/QCSvS9R6R31sYZWQ58sdHr3AlZuZvVRWeqzXl
-rdncqHn0e/yIdC4/p3H+EOzCPP9XCdbYFDoA6Q8p6AYFLvSBOCbDDNA9sIUOjaQ2n5YTB/G4ky73cfQR
-mDjyM5uT04Ifp5gaGfyMf6PdKaTkWr7ybArzXnlnpJtw4J1nNTPYmaiYPaA7dojwzxhpjXCx5QkPqwYm
-HtXKMId2EiUADIqzWih+vAr6bHys+RFI6rv4eZQOS6K/NVREdPYza7mGdvr9+MNhsTDGEzdVQmUz9Cxm
-PzSfPa1qIeOf1J9UT9ghYhJv1A8dGiaxMyxM4seEhEl84ptGjr2bctekUiHi5HJ04pM/DI7Zza5ieScf
-/TnZZ19ydYr/g1+5J1lWfnz0R+5F66fEp0991v6R37D/UlqcxGVvoVHQgUNoYNj+EGtBkw8qUY3H5+c+
-rfufyr7giur3TbN37pMyDmbkw1rbSm/rfWmesbgv61qXOBiugvlKOGNh/PDNj9+8jwB/icf/rvpmL3/J
-qghr66gMQ6yxwGEoLMFJ5HH0nf9RjYG5Nqb3p5wxMw0lSlFyu49y5pgWZzPm3pgsxTCxpmsVXqrnfJee
-LvdwtZXjbReQLgd8v4wyXlrp+7pSmDXIX0ZklRp3ZUW6KPMxh8dlVGxpyEfB11GxzcN4Y7/S9+nLSBUC
-inup75fL61fsJcM7pnVLJgXYifIJhVhepvngxOdoJJ1YWkow/8aMJzsWNJ5Z0M0cFLKogziO6HCyjzld
-Y/PHOd7Fl+jEG1w41FOwgy+WwT0cOnMyQsHFKCODLEplRRfOIx2CM5WGHmgcJNZP0EZJX11zl/o+zCIa
-pQg7YvWUV51xAEZl/s7mGR9MwTnuIcldx2cY28Mcx5jibKiYlCywiAr0IOh6YKzxeT28qpqXyQJdlSyp
-bQmLLViBZRECgPC1NvfLJQgGfTRfp3mN8PXJo8ktgVuY0tbYXbDVZUsG2UIUTjf0n+GhcihYLbUvJaeK
-gQQDIGtyR2ubg5O3eAFXvopXGbPsTTuJj7EZfipbSWguh7UkHpy3l8Ro8+GBE4EAMa+zHxwcvlSAdM12
-pH4cDg9HLOWlvPU1xxI/5FSW1gu8jRqgUbf3zd7s0zd785G285gqs8IQm/8bAAD//y+jxWq1kAAA
-`,
-	},
-
-	"/js/jquery-2.2.4.min.js": {
-		name:    "jquery-2.2.4.min.js",
-		local:   "static/js/jquery-2.2.4.min.js",
-		size:    85578,
-		modtime: 1534412156,
-		compressed: `
-H4sIAAAAAAAC/8y9f5PbNrIo+v/9FCOuD0NYLY3kJPvuUsawHDvZTTaJs3Gy2RyKToEkSHFGIjUU5RlH
-5H72V2gAJEhRdvbe86peuTwiid9Ao9Hd6B/XTydXt/848vL91btn82fzz67qKyci+ttXxTGPWZUV+VV9
-dXsvvs2LMr3eZhHPD/zq6fX/miTHPBI5HAYhOVlFeMujyqK0er/nRXK1K+Ljltv2hYQ5f9wXZXXw+q+U
-zeMiOu54Xnmhw2CyIG7XEDlliTPpspBqUxYPVzl/uPqyLIvSsVT/S35/zEp+uGJXD1keFw9XD1m1uWJX
-uqRFViWvjmV+FTqMNC7+daxjHvMky3lsTXR3ZXlP/rjVJjtAf+TvWHkVUT+A2Og8cBrND2K6IKHRPCry
-iFWQ0mi+Px42sKHRPMtj/vg6gYyeGril2bwq3lRllqdwR7P5hh1eP+Q/lMWel9V72IpMO2rhWlmQ034f
-1FjEROTzJJ9neVZhSgMFvX7rrw/r41dffvXV+vHFIpjWg/cn1yns6fXb2e4wu4Z7ej1z/HXMZr8H5DrN
-oBxvLJxXxc/7PS9fsgN3SLMSLdN8vi+LqhCTR08SctwdREV+qMpjVBWlm8OBbzk+WhZseZ5WG3cBVfGi
-LNn7brXbhvg8YtutI6aeNJDyqgcReujH7XZCmbe4YZ7I6bOp+JnL+gNXfgvcfmViMd5ULLrrVSlWNKT5
-fMfLlGPWuTEAhwDroGe+L/m71wjiFIEjFHkr/ihf9QuEDXAWbUa7Phcp2A4w0sCO7ceyYXVth518vmN7
-pw+PIURtdiYHGkIkwICQBhAcR+Z3UDGfs/1++171p0wRng+igiQrD9WlCvi9syANbNkHs8yWpAF+PzLd
-xmpBRKds6oilDN1FO9eDfkY3dGHb4U3k+bi4URC4fiCqz+OLo2wXq67P11XCg5vCoSgrN5qLHzjscdqi
-uXxoIJ/zx4rnMcWdpp6N9sRwGIh5j4FDAiltJ9FfBHV9amBDl5B1n/Wwb+lkuUoEGguLYstZ3iHN1Lad
-W5r2KtuoyqZTAmdYNq3rfJ4dvtL9SkldOyk9NQQ2lNLMtp1UAuxmNiOr7GazEhVliSN3ksN6LREi+hVe
-ZfkVIxFN/RDxnfhJJ5TGonu2LX5Eqz9sWZbLeXZi0TCn4jNucCcmhHgO9xxOJ0uBHW27S4yIF4lVdNvv
-Zl2YempANE/13Du3kEBMiPuuyOKrheoNZolJCzxpt3DOiT/uWR4XrjourKmzm37Hqs28FJ93DiHzku+3
-LOLO9frVdQqWRSA7/MhZ/N6dLICLw6YHw8ODSOzjvCj2JiA20K3HyAa39CeLUprPxTpiNWpqXPyrJwqy
-wy/yRLqMC22bUUrZXB5doqLvjzteZtHI7hOZ2wPI0bM26RaGEdsOZ3tWHvhX24JVTkimyxu6ENUaS3Re
-9SpLHA2dE2Ngdc3meRHzn97vuYRVOSCHEaJaX4qyzNyjtj25k3iNgWV8t4iRYhboTiPcK1Z2+EF/eJ1Y
-RksGeGuQkfBEKQ3rum01xBX5crev3l8csVFXW796WDQg2r60apQyj00tyz3bz6yuTQDRX73Mv1VdI0Fd
-62KuTm8g3RYh2375jm3PewoR5e/YdsXEspTZzmEEmG07S4QbRaA41vHArw5VmUWVRTwnpPE8Kjmr+Jdb
-LvCDYx2iMttXFoFwjicfg3i+4SwWJwnP45ebbBs7IZnvWcnz6vsi5vOS74p3XKcQNxLr3kDEdnwrCIqx
-GWLtrtyDtTvMrG6b3kOJOy7m37OdWdggWCS8iXQB7Pp5XhXfFg+aihGr3f9ydm63NB/EdCEg9CC6fhKL
-HlGmcPkquolXsUSooVogPw4gBvEjmpksSVhydtfw7YFfidKxBJmPltDwyRoQi/YhWLIs1xHw1E1UIXCZ
-oDHu+IDeMojZsK79YDXEJs7BUXiYEeJp8igC64BYw4RKQWkx4qZyHBEwQiBqIMvP2zRIFtnr0Jst3Y2m
-XhhEoruiqUFXxZTJ7k5DfYLGdAH8fBGYz6fTgIZ+3I5K56EcxCYp+f6sV7qBGLgg7xO6EMevbmpDJ9Eq
-vUlWyXRKYjoJHeYnASQE4gmlG9vmSKvg1xal8CF1J1tSrUBKF7ChftBCFYJFN574Jl2l0ynhVLSWBpBC
-REAuELftjWyRk1ULU6mEqY8WUP1LFO3nB7ARtPYxi90l7Mvi8f34DoBEFT0DgtC2xX4QFEJIGTAqGjYp
-EkY8J6aKHm9pDXhGIKHn5BtTPQsl4Qax4qmcYQUEKdW56Dpl5o84ZMTvdAqJJhYEynhwX7GKz/PiAQ7H
-veBC3W1DYATbvnm/C4st0jhJ7su3eVbxklVFGdDo7JMYMdL21heSoLv6/rgLeXklz9krPRdXuC2uRD+u
-fuTpl4/7K7nTJDWhGraQBK0c68oiAx408y1fov4raxpOrcAKzvAYWekyV4fu4J9MmG1bEr4sASsCN7Zk
-eHtar0ZolGhwbHuTpWsxMRKVqs5OK8dB90AjvBG0+2yJ4NmIrlR05HjSJDRsIINbuIMt7CCHAvZwDyUc
-oIIjtQ7Z779vuTVdPhUEmJhFeGfy4g90AY90Ae9pyhwCv8ufF/Lni3HuVpBOAoS3dLIgsGjgJV0+f/7p
-El7RUzPkzr8U+OEr+uV8X+zhr+JXMPl/0w9f0y+VLOAbegmHLaC3z6NVJA8P5keB6IqiJK4itRKzZQN/
-p1a04dEdj2vJUvO4Zof3eVSzY1UkRXQ84NN+y97XghEti+2hjnnCyzrODizc8rjeZHHM8zo77Ni+3hbF
-vt4dt1W23/K62PO8LjmLi3z7vlYylbg+RMWexxZ8Sy1/vX58tlivq/W6XK/z9ToJLPiOWo7nrtfr9bz2
-1+uHWVD7b9frx8Vitl4/skVAphZ8T6312rem306tp441/W5qEcdz1bv/9O2TevLvwKNEffHcT5yu0rfi
-95OAPCWf1GtrmLC2RMraqlW9pFa1rNeBBa+p5XYNrteO4/znVZN6mOIQf70Ogtqafj+1yFNSz5+S9Vo0
-DT9QAZZyXzvfTq2pBVZqEfiH+d16i32cYsVvVaUB0a2Qp3IM0yeq8I8jhZ+C/LEIvBlLdvyb6b9FF7+d
-WqTN+lMvK9VZ367XwSdrK3jqmbOHbf9slnhN4J/Dxr6bWk8sAr/Q09ev3F7an9TUWwRefvvizZt+6no9
-79J/evHXfqpMqv2ngUh+8dNPP7qDdr8n8MObL39+9XqY8JrAy799/e2gM66DgI1ijXrLDlWdVxvxfyZe
-yMyJBHlaF8lMIC4FLmp++Due10Uc147jT2dBTZz1On5K8rqDWJWg3tfreEpq0k4mgoaVCaK5KLaDkYqd
-8PepRZ6oLDnn8eGlFCW5IysrF9btesXv67Sqt3JE3QD7Y3A8d7Zex8TDrhsdczzqv50F9RPVxQb+Ra9F
-r7J8f6wUsqlFZ1jJWR0eq6rIyZPrDH6l128361g8/je9fuu/PQXT9Wl9eLr2c1Zl7/jV+uEansja/uT4
-AjtMSe2sH6akXs/1B/LkGn6j1/7038E1MEavP6nX6+sUQtYDNdx4/nods1kSnJbw5wYH4dVyhKSe4wAE
-zEaMjlJd1Fo8WtNw9ufPP//0z5oMEhRcXNeRF7qLm9iTp/U8KYvdyw0rXxYxd+IpliDuaOLNzXJRf/75
-s7/8GZaLZ5/acf35nz99tiANxMwkbnYOaVZV+f70N0XefEm/lvTMuzkCn+CVDgT6b1/65rsWcbbcdBOx
-Kto4nJHT3+gJ63W/VLm8/vnzV9UsA9VsSEgzyhIwg8RePWyyLRdHk6Kqp9OArFqKOpotm6ZpiY2E4YTH
-wGVdCWzUUV7gEf4gztlwXjzkvHylj+xHGnphOyL3L4IijsWa+EHLb0wM3njC6no5ofTRtv8if5b4qo/N
-WFQw4bbtOKLiXmN1HbrvyITS3LZ3TkggFFRLDnuC9w1LVa9T0Cdz/siRUxVHc0ILfxlgnr9Q0RbeTji3
-NJynvFLM8Rfvv46dhJBeR27nWUwpTdqPkg6/JRBLdjBLnAeU+D2MVGXblRPCLbHtj9UjOlT4zwKdrqEs
-BrOLhy/e/8RSwQuLkQH2EAf3aUBsO+rnfLllh4PkocMLKR9trc0pRgNxkyVONL8/MNuevPDZ1LqyAtt2
-Jvd1PbmfV/yADKdcC1zTBxrCgbKVnipDvBRe4OvJybmT6/KiqsosPFbcsbLYIsS7o3ctd8wYWOv1E9si
-bjg/DDPDHT0SKGnqMAIbWuotsaX/lN28I571J2t651p+FtNPrOnd1PoksNR+2cxmpPQ3Ad2KIU7vmSPe
-yOpAy/ltkeWOBRaBB/qbHrNtF8wJDakJqetQzNaBCJxxNs0Pc7xveaOuV15st84BJ1gihPfk1CRZzrbb
-96c7SulRLKGUwwwmpWkaVXnmdEKXf4D1ZCnOK9zL3QYXZLSUegvWtf0cOpHY8i3/hqAZiaGTm3gesWjD
-v8X5s+2Yb3nFr0KfzQ+bLKkcEkDoY96Act2XsGtyw0yxkH8M6GQBrEvPmHmPM5BWxdk7i6y6GZxMmBMS
-NUmtNGKybCfLXAIxZxfFWBBSwVsbyO+W9ZGo4uBqi+ANoaL3JXzw2YzEc1ZV5d9YHm+5H/k8CKgx7Lte
-baFtM4hpZNtSXqexpXwPjXfn3+H8UBzLiH+dx/yxrl+SmfNvNvyGSLaHqCIiuxbRaJ7zx+pNFm6zPBXY
-LzK4ktmyFat4S3e27Hq8NRequ6dqh3Bhu2p2E+kN5CbFvOOlIqXMmN/d/1X9jtFAXVuSjsE3cqG93Gxv
-wxyzSQ2mdBqCmRRBLPvDIaHM8QPQCw8hgZQmfTBIZzMS+ZwmfhoIPCiggE6cWPyIZ0Ia8a/tUtHbC7Y9
-donNRtG9bbMmogmbK7kHPTWQiPfs8K/vvqWjtwQOGx6ejLSctmqhvRz1rL/99N23fbzsTpYN7LBVXula
-Rnh/Dill3nlr7rv2Skce2eLsTQ1gT4fd8ZycplDQfJgAezpJnJwA3k/FPGHHbfXPjD8Q2xYAs59QKnYP
-n7M4/vIdz6tvs0PFc156558c65hvCxZbEDOYLInLxVZm0QZziQqNV8cq8i47IRDhvkcUfKCZATym9DvS
-5ya1MgsmbHCctckWaUSNY0t+sW5TUq8x5stiJzGmRYhq7pxkEDykAuDzVtuTnv63PNTySzSDLClInAtd
-LHpdZESQPkeYDCoUddX12FfnOOymaMxz4nmS5fH861cD0YygK0Z20pC2s+19h2wGtFp3lRR5fhS4ftA0
-INrbVrzst9jusPa0DRlEXQWja3ZOzQic3DTEddSJ2g7uf6BZOciL2KXtiTwlz7/JHrYzIijLd2x75KrP
-oPr604u/0nHY9cYEdR9bI6P4RZrXRerTC8/JJ9YKii9cAPkBcLqAhF6sHO8/n4pDhZGTPk0TvJAg4pyO
-DNSl6PeonaVY0z5Jo+cHxSb0MlX+fzhJRg177yK93s0HlGLs9+KPJN67HT6cRcGxOINNfb6b85yX4rSg
-1nN2Jann49T65Ob5Nbt5LiUO3efZulyvP7naHdh2WzxEbF8dS04/+eTmebGXEm4lD8Vv1/LjzfNr+fnG
-Ana+1pbfr+4t/eSToMVttn0vF8fyn759EtBOLvlJvbbWKLEarVT3pKuqrnVVnQTUc3Ev1FLsc6muLP43
-leMfq+3f9EI5V0mJR8p0SaMl2Z+wuenTkaLzP82n/vTfAZ42g+W9QHlLaoushryVAEkLLCmKxq4MLo77
-2XNxxoH16tI0iXQaj60dlpQSs1bEfGnSeI4C8rFJ00lguVqOfqGWp+A+WgR0SZg/dcV8EbFpdoLh4Aed
-X2+gAy10Ul0X8wce3mXVd/28ImFX/D7ytRjLeRh8FDtysGLRPM4OUZHnCKyYnx5aVQtkmaB79w8TsTtw
-bKUa24Ra8FrAwj29byfekNbdKya3FtRE2XLPvTylmSfUM1LMo2InmC5NBv5QHDLRcQIVDevayJZXLMsP
-xBsTYf2lxyV5bEgOuoKbCvsM3sq4DorreuJMYiloig3llYkTtU173aMTE5dd6rptL/9sX0xFJanhmZMl
-Tqj4sZD2xAIiBQ8YrWWyanlY+IKG3lk9zLxS2grmebGS4tDJxT7NJuGlpPa48mLXiekYj0Apdc7lbsS7
-PAUhcZewtMWsS1W8V1zQzzwWK3SpEDYUe2J8eV0P+kEpfWfblfMOGPFmSzeUucJLuULiLd077xvnDhiZ
-iZ+QuAv3MzsWpZdjC3RpYqNWNaFbNqQajNeU+iyADfXDQEor63rSivZwRG2nvaXLxUsy1kFRmJtiQSU2
-WEWUrTp+3oCfdH7MpeAlErnC8VwbM5fiVf04oJRu/Dgg8XTawcEdwzTAFFdle4eaHfp56S4ayImbN5Aw
-jfHGL2NRhJwft1v5JyRmkRZ/ni3GGBxqMS9DMW9L9v4EFv3kyVIc+HCGmW17j6LJsBVNlnU9KSXWCZG8
-MYWVISEon5Mb6tBpjqF8pa5HUK0AVY2NlOi6+9BimlY0oyX85NR0MxRCLqfHZ4E+s24WOFMaI43O7kdm
-SeuRJwxZ1EEVHy6MoM9pT6g1UEsIIBE89is5S2ZOGOQkHseLhMleE6F9Rb0JpYmXuCYnXdeTvTfgk0Li
-OgkdYU9wIZP5Yc+jLMl47CWSP3FRpCfGj2qf9ENqn9ab93nFHq8wJ1wd85JHRZpnv/P4ij/uS344ZEXu
-XllTJqf0mGf3R/6mKMdEIAZ7gZt6SyfRPOYVj6pXx/02i1jFD3BHFX58UwlKRLBeqGzgLARJIhKcLwhs
-NfMRUuYngvnAE8NPBDyLJcJDPCHEEEYypfOMsidYEg1sdyjlBNYApwnO5E/8cVR/g1oWIr7EOHjFSBIp
-zRdIqq7/In+W+CoZ7zONMtQrxAvQvGpRovkRlS4ZZXO87ETCccVW4oMpt4ymFJVq9c3Bp7Lpz0xsKXv6
-T7H0Ml83b3jRhXWEHSPbQCylWRJZHOjJkG27ny9AEsE/HPgxLtwNA8Qu7i/Qgbp7akAwd+K35Fu8KHVP
-1o3lnuKsdK0OCVtKCX+yaMC6GklvwJq2n0v+LiuOBzX6Xtl/X8rUNLAv+VcoLHBPeMs+JnzwlwEVfwaC
-A2D+pwF1xN+6Zv5n+PfzoK5NHUSVVTAsCIPPBAxiQUvsDP/TAG8JoAVk+Iw06gL/g33p4Quw8mojG1gG
-bU2fEk/1Tm9oh/mLQHT8s4BOHfHjiS6Lxz8Hdb0k7rOnjsXf8VxW9inq2caxfiOi7Oey7P8TTJn/v88y
-uOLHtoctNlpbYWznTETzti1mR4PaL3OcA3U9JOrwxEZ0cUCeyEn7U+5Gtv2zzB6JUyqkqRPBZEHkS9Sp
-+BKrFUrPQjLTzwQXZiHqXXRzGIoRPwu0hRN+MVfrU0IaAdAShH568Vf34xKncfm/lJt4Z+qAk55A5j/W
-8G0apX1y3q/36gKylWHXtRP2lA6ct60GDZtaUtOgfkIsManvHQYj/QrlGoygtagTuxgvdf1xMdtQxKbk
-vxbBvdaQBgZ7t6dq237WVxNUne9ObFg3SYVc7gneTsybG3oOnwqkbskPnqAzI1ene3yCr2/Va2TbC0op
-b+EsIq71tEs0E25mS9d6YqZJcOpgUTb1b5XFEbiCt1D0g0CHBPHGsNLa7Gtd8xZOdc3TJdY9tWaWO1kS
-gSDP0Y226FFKDBSxC9JpHdhDSq0tO1Tm99lnBDbUUkpE2BM9veLAi9UUeedgM5mY3IIB8KInmexHTyeS
-JhNKU88yTjxr5BS477MdJd0Ijmp8t8CBTjLbnmygotIe4x5P6ESTE3ty2rUMw47u/H0g2NGNt7u8/0pX
-jHw3pGsny1VB99Qq8i1qkDLbnhS23RtO0+7/LHEK6qfevXHiu/dzMf34HEBq2wfRu3vY0p1/DOraET9o
-A3VHt/5OUV9fvxJJvXfMc0vvfBagyklObwUWpPTBtm/9ZQCVIHFv/WcB7MTTvaGP4+dBOx3TqaCEbVtM
-S107Fc3pgtR1Md8XeweVSPozYdvTaWXbO+QiT6J56j9ADlWwksYCLeVysG1nR8P/b4ZGoJL2Bn98HP/h
-iquB4jD+z4agJ6cKCMj56ltHVDPKcRhxXVf/FVNKF7ZdXcc3dNE0IydvJ9VHahiptQNOUjw/8EoSRAef
-DdgXg46wjrm6SOXxlaxAUvqt9r9/DDzkQLjmz5aeE1GfAQPLgjAAs62BlrHDhvyQecnMOjuChCKjdOFq
-OabfiEPKT5HmiQM6cSLxg18aMnasiuoWEIlULshDOTfuKS8qdzMm+PUDUBbRm3P9ke5yQ0xHfwwCs7Vq
-YimNNdvPwQ8EGmX98WxmM+IkNPU3gaRUNmI4ofhJSH8wwCHpzmMkaSAWPLOoPoGIAH7E10kk4bppCGzY
-YTjGEW0DU04RGsx3Q0Dz3hdqYWeUEJxX7IQmq1PXobwuEVxXXSPn2p55TJx5ot0ty9MLbSptJYaUwiUA
-xvIIvsA+Qq3BmeLFKi6uUEdk74VzrGmoe/W427oiQXRgmCa/txrvNBo0F4nDQZoXdCQsE8e35l6HAtKh
-SgzpBKQNgYqVPatyU+mxiJgU13bPYl9ueveY8qRfSuuxLG6gLIpRK3VGKS0aQNX8S+n5nEWCAVRSadt2
-JtjkV6jPX3fPjqA4JxOBF1AMzeabkid1/W82r1iIWj1oDY33E+Pksr69QGzfgH79eOZFA+rmaJS2/4Na
-PaHoP5trI4bakhd1RpK+PWtAP433zVTQMt/aCnA6oKtQDYLv9tX7XpV/SI6AdqkamJ7/eczMU/ZhpLeT
-9nSZY+to5LvhLObl2Nh+VZu1nVPSAE7gWOZ/jWSWWk3/l8tk6EZpcDM+hQ2gZvq5Keywqktt2rYlaujq
-t21Hch9OSIeMDhLSRDA6usxQVKh9FeQG/tOT5C8CRI+DZEMi6oezpcjD74c5Og7KX9xEXjQN3QhzvuP5
-eW2Gdc8qRJMe+oyw4R0/awgUcfyh4suPFN9WYx1t7Rdp29fVbCYIoJWuJu5Vk/7haqbT+Hk4XguquGgA
-z6sNNcD9vjWNPpUszgp3spBoJCwexXOSbbn43bPD4aEoY/Gc7VgqPjako8rCgG6ZE5KuusMx3GWVyF/y
-A6/O8+9kfq00t2cOOTV7Zngr0Royh67HPXIMhQB7JqgqQYnd8Tz7ndNRAtI0WaO/a4lCljh3pFWNW7h3
-rZx2taEMMkEx3YrGtfhNUznkFNn2xOH0R6l/viHoU8G2nQ3dqEq4vwg0o1zXGwKZkuhSPyDiyJwsweH0
-TVsDmmdyrW8Licx+kiLvSFqpY6UG+XaFd/1doy1rLlcCjU31NBLR4V/8NDD6fOungRyJeHI4juOjvUhB
-3ce4/FLrWeJMImVP3c7wRqW7G6+TvBH3d4dBRtq5bzqguGf6AJAIcgE9uwfLWkU34SqcTkk8RctWeUHQ
-Keu0NZWsb2QSzuOsBE4j2zaFtYI7gYQ+djdnoTx3vB6vn3Sy+9CPA802hsYVdHs3xFSJgbgglT3RMOk/
-QIIAmZ5XPVK3bTNVS3vD3BeNX+7WKUucWxpKBi/UDF5Gb/2wx+D13jGPs6GZqNa2Ny2DuvGXQe9m038W
-0I3/DMcictM7wG/n/TWUeA89jdmOFevNWCsYY+da2uiFyueByjhy/LtMHDNtixUbwabAqTZcX3FlqS45
-CD8OIDJuGNp6jswUROm6BKvkB7ChC8g6gL2l0s47bJ27OAllilUSCCVyEqynrp1UX//ALeo/iJcNMb2n
-tF14Z3TBYKli254Ihs62nZi+Y05MCHDbnnD5jYtvIj/pqUYrPNmTZVEUSvgBFDTVY9nTpK4r5oR1bT21
-YNNpcfibwN0gd3hPJ6yuJ4lth97ePTJnDztgWD2UNPJ4XTuJx9yirmPi+YGbuveo427bkXMPpcwZk9Mt
-PTKnhJxA7NyCmFiRcEdv+4BwJ5jPLb3173BGSz/37wLBf96rpy0hTXvXJehz+SAa8AO4azVg+vWVsr5b
-uQj3/p2oaMWRGpJKd7eQkeYjxZ1byr1vnAS2xN2JTzezpW07iX8repiKH9E9uYtLHDClNPVKfeVXgK6f
-uCUB7qkepFBCRlxtdJJC2VNGf+ijUMDDsIPJlMZzfcHl452CwPACdNO6NpLEeQkZTb2luxAzdYF1Rspz
-A5MFge2FTN84IUieWGXcUd8UmnT7fJIK2K3raELprdgUTkgj0kHancrubtVD5wRMig14E6ySm2yVScvt
-qD/WTI2V7KhfMufAnB2BiAQrbagVtRSIkVtNs7xvFV/VUUgg8o+BnGlOp9NsldzwFZct99rlut2edOwd
-c7KbpW3LbuCjOP1aWXY2WxLtYUGdxtaVvNDKZs9klZ711HItqzG8JWkToQj4TWbbD12VGXBCILnh8msr
-Hm+/4uFLmp2mb/U5jj3sAOyxbwLTiloMtx83C9ODhCbF7mSZLRRwDyVdwIFaCwsqmti2H8BR7Kx39BYe
-BapBXVytjuwIlHNH4D19mFLJlLzzlm7PYVRdz5fwO33Ue1Ksy5302SWVjNK6viOrw4TS321budja0kf/
-EJDVYTqVqMG2t+RU0AWkdb0900rK69rZOVtBAk32WtJxT5lfTKd4+t47W1Eyhw0hJ0X/bYmSHIvePND3
-pIls23G2dHIvWrPtcjaDxLYrnR1xVjmlB4hsW/S3xC61zYWyuXungqOYWtLd55c3CyUxPsxmpPIPQV0f
-8a8jfuhXUskjI2R1FCjnSBqNRzI4ErizbYG+j+062nbZ+nYRINpTmHCyTiFBjg1u6TsCVdNp3YuzhriJ
-zrehidQ3y7bjlLvSuEBvLy8Mun2SkJO8CUzRRrKDPK0qNZuRhD4wJ/SjgEDiHwOv1alwuX5aJfSFw+BR
-nIXihExatQHKDFXvrFMo6Fst44krump4w6BjfqFsm0FBxWymDqN524w4haR+GEfpvyDYCo3oJa1WCGqr
-MO57CeiT7+aZbVtfvxJ4wLnDewOimPXWoELa45jGZ3uxl1qEdIv39IiQUKGRdgYYzp3GbibboYSiIRH9
-Jf4igEmrYMdXOV5k92R90CGXW81YSEJdj7PJ6C9z06ReG1x6C3dwyGezGfZTjDYLwBjIlt71EKugnHdU
-Dsbf4hmc0N0Hx6QMPW/1OThu8KlMX2/1yZzBUowx6ZSbmXNLYMKGdrcCWIDriyMFXk5e1xuHQUGIk6A2
-FXCYhHX9YatTArwBU82IHrUto0W0kpFS3kUzlCOcqyrRyWQLO4dAX5/zgsHP8gPqseM2nSOK6C2R37cv
-2JQ8oZ/8SVoXWGD9SQqeOpnfQOIk8gvet65vmZQ/1Shr3fAs3VT1QxZXGwsu3LVHnlRQc4eaaGC1V8J9
-+ZW3dJ9JG65Ol+1MY3t0aChou0YDB2MwffV53AsW+jS0PjJumbUduCp5aZx1rQR9k8uCvm4utL0dKlpd
-WjjlyGzQrU7pXvXs7zDq3GF1tgJ+GKDk1xvMuItutsZ1AmNDJzA2dQIJJKxxGFnluOtphS4m9yWtOm0w
-9cm3XEu6rNyXrXQpN9X/9AutjK+QS4d6lVayQxda//ru21dFRCv5CHmn31m1j6h6ebzg8wIPuVZrMtKX
-JgyFGkS6UOjk25qxN74oaiVHy52DE+m7V3XksfZkjht4d9HnkR+MiNaHtsxsggLoSNds6N096AlFFNvD
-6PBIr98+1+5E1oen62vvxvHc5+vr9fKmJk+u4T29fjv337p/WvvrOQRPn1x3kqDf9XxlidNzWha211L5
-PC35vqfvI3gJrbqhXfhBDKgRGzV47oZnyrwj9fQ5nK7wuY817OD7Vu24rVLyEcp93iqk5pd2bT7Ucud/
-T/BOsguNruUCVIX+IjCuxRxGLTcvKgf1pIhFQEqH9LGF2i8deyX30FD1GtWRPD8OXD9w+1kcBmoE4dgI
-+nCEDpANh73OCfUtxxTvTEfEaquIT+YCdCpZ5IJzZrEx1JQbFxAorqaLVoyI0NWai3A/DAAdU3dSKyVZ
-NQvJWXAYYP5O9h7TMwfJS09jFScmbkxQnq3oTcys37zeG7qnYC6DuNXi+7g/6t+Vw2gkKydLnO/xS9AP
-FlwQdLA6dm82UTnP1ehs+6El4UQJt+2FNvltpC76C/hCuh5aH546z/31w/qXYHpD/Lc3wdNauSN6it6H
-XtLWkfoouHNIkDHoQQCKk2hU1y9GOimRJrWeSzZ6Edi2dSOfNec6Wwad+70b+qnnS8YftRQC9wvtfgbQ
-NoT7IrsmhgUNF86lx3XPCes6IgpQiHvm6jps05AZ0G5sQhpeZfmhYnkkupx7Yku7IZiu0CGfoxdgQXBg
-SQi1+hju4xHnOjEuKzzKNcLWzn07h9LbJb/K8quQ9JAuuvfmAfHUgxOKNzkq1FnkgF9MR+EtN0XjoVk2
-958JFs22k961sWNsfLrEjSg4oYSA6cidxtDbK5SB2ZxhX+b0iunqZPZ+M8QdOsbszuZ5yVn83lO/CNtO
-TlynzdFpnushdF1rH/tDYPqJiJXVnmAdppBPs3ppXIuJbQAvaO7Ecgu9khtIztyh3pf8neO5P+dVtq3R
-2PcavqQnVIcreY43fFJt5SCexUmPN3wlf+dOFjJoQIuTN+ww5ohf98xku01cMo5opUcagTfZip0hW+mr
-32eBiWwbiLbFgZse7Pski7LiMo+IRBwRKW0RUF2fnxIe1tO5nMe5dxed1B99ByOIxMEqsu1IED2rgf0V
-uklqlQ6WS9t2Ui+VCjBK13VoUH7hUEWHvOSUtHfJvZuzAX5OugsSk2BFwQrqIsT8cVQpwxtxDKzoihwt
-i+SWINrZrzwGNAoTGNJVuAuRpXowZsRTq18eBIsvQOrFdutonO/Olg2wOB53Bn0WTcEYWS/yQ8orh4As
-jL5lWRx/MYwYYVbK4tjR/pcHQQfcwbuGXEZIY/pn/Up2dUiWL/tkeXfprrzMnkZ0TrSqx7lJa6gMzUzc
-rWyd1OYeG+LRYWBeYZI2N6KAS/rmw2Lo1Tkf6oyozGL4Pa1f0kiEcSnvUMVZ1f1iu704hJHqP5T9Qgsf
-H7PZDg5a1PQHpmqotC2KHuTL6Lq8cxxzkev61BCDr8fAIi1SHi1vigFEZo21x13RyNTulNd7xg+Amc4K
-m2bgqhh9J7OAjrhHokZMk7C7ALVwuno69Z8TvGeMCMS2fYZmYrxwbHmeGK8eDJx9I1Dnl6jb29v3nMCr
-TgrG5yV/x0vUJ4JhjBSiKcq/0uv1m+l12m3ev3Xb7tSKotUOVUarzl9Rmgl97d3QjwIqjiEIm3z+km23
-IYvuDj27OkZHkKpo0e1O0QaU5WV7SyuPKLwini0ho4OTklM2L/KIQ0xDOlms9LXrSmQnp4imWo6qJAXT
-6ea5PhZI4m/0NVXkLwKIBH2HCoVoilgV+9f5V2x7kPoqSedcerIkDZvv+K4o36MeyGRJIKSTJXAUnkae
-H7jorf6WnnqIvFO4VVfZYa/u2RJSfbR1yqlXsYPghwsRDoLm9AiwiHj6osG2b+cbdnAiUtftcemi0qfm
-Y7vjXrvLjohtx05EGtIYfslBdTTT8NSA9B43Mi4NLq1X9JHLCi21iahgUyQJF6IqsyAFSKLlxREsCWxu
-aGTbm9ms0W0Paa321O5qY5AgVZEY1sQDhcneSqCukapeiejGwjnRVF6woHVoL/fIEk+SBraFedwOK4rq
-2pGV6bZF/tG6JhwVA/kvWdWL6NAhX46AGOHuRMV8pdzraSVf4kZBB12CqDPWU9Q90tHbuW50GFTJKDfa
-37hpMcitGcDmFU94WY4q3/q+VfJDsX3HLbDiIucWGMjEscRGv5KbziKg88ZWAKIg+tEEK2HZ9mPlblGP
-FsvlRZUl7y1xbBVpyQ+HQVldLBCTau15HuOZFtPToWLV2JRFDbDtA3t/GI0HJoZl7Ky56K5zNqvVxjzq
-tIvKNluHmvVsGi77xhGFunxLaZ9fk9SZ+FlxP/GXQeCcNZvadjoeWGvFJDPc1Se4r1124AINqUekb+Xc
-IhMg5pvIeRCsIa6hmgfxLtaGuJGf+ItgagnIswJsF71jRF2tMqBdKgN2tF1qSEOASV+WpMssiBd8/FAA
-thZIGcTEjZsGuHEOxvN9hrqWYnHgfI5ZN8eJ/0wcWIn/abCK5bTSVFDXsMG5jGNzliO6aSD0l29Z4D8L
-tK46hP4zfBcogQDHCQlGwkvw0aniXuyO79h+fpq2OxwdmekZ4xhOR0Vi48DxFu1hw8e0sRcQnQXDIBC3
-TkIhoUvplvoD8BK7C0gpGtJ7zDUgm4iZ/LgpqGCIZVSwSDwNI5TdLL2zLrox2mNkXqrAEvGcaMCdzZK6
-TjV4tt+bRmohojuMmyVyvxlq1+oAYXDbf73rva5iJQmN/DAYzIX41E2H+WbuINGPW8iI2kHi9U6cm3ID
-pe0Gms2S1hPecCSigDgI2s2hCMKvpUgDxTV0NNofJhndkthMQ1aH5NuAY0vAIr+wrHKXsCm2sUzoEYae
-qljkmk5d9eZMFqSRxXvZ0VHNZOHNZkYxFENh1aSunfYFfetORHbb7uW/WdS183VvVmLwcwx7kmBEqTTl
-ysVBaduOALHBV8fC2izBYcdkXiRJ+4UMmOJvHHKKlcPdgefNV6+/U7ZR3xYs5rEF3xBg43ml081vRB/l
-DJFmsCR0xLLrazFS2ttQVlTs9lteSTVeWcUbcZzVNTaiaEIzxbYn8dDr1Dwu3kRlsd166BH1p2zHi2Pl
-qD4R14lHXI1eGPB5Rj1aQuDrFuRCvAsZgCEC79/pucEzJK328JiCKaWRGeKNGlQwOXHBToj9vbnK8quI
-/B2r3UDkbwKYLLDq1qS1F79PlOzHC4plBMPJApVUndRzujs27XmZuM4tDSG8hOtuDfFThH7YlNi51ZEN
-0YoQIki92I11HK5NABvQSYZeLPeYe+vpfhA380L0lyCwX9LAt/SjF1PS/Yn5Ppka11Yd+H/nkJOMoimD
-F8q7T/E0/W6OYY0a/KVL+M6MwVryVEBDeSnQV3c2m77RfLOpgEauElhJpwSdSSqY+aDV238oM9QSUeLf
-JEuPpXoXlEW/9gbQYcpZnN9vu1CAp2alBFn9on0vDacGvkXW3fnAUML/aCjhefcF0DRw4NWlMF4q8KsY
-k/b/eX5xy/0woNGqH/xNfI77ccr4MOKtIcPsAhR6vSbdwZgFbdr74IdBAyyK+OHgflBxwgyBGI5IWkLb
-brNEnqOuIFMunUZBt6MVIaWS8nkb5w+9Zbn64qJSvehKRl7khuScVe4FIUMd4wFkmAhFqid2gyGYVe8M
-sUiohNtFuwyJF9NQK8CGKJYyOi16zGl/FIARHxMvpn4IPHCdmHKIaSy/+nHgxj3hD2oRxudahMpzb+LH
-fhQEjWMugeigEXpSBoP4ELArtRdV5yBVEGIbdnjFKjYmMh7fae2chrY9GfYnJI3cqN8jwfYdvFa/P6hb
-15O8cn26buq1r58D8uQa/kGv/Rez/w5MSdqPfZg0l/DM6T3qPVMrZhWbWdPQ1EiePbEtcmZCPNApisn5
-lW1ETlX5/hRRqyqPeMxH3mThWgnbHvTr0rXEwSPf0BvPNJpa8nUauT9o7zueujb95s3r71GEtPpfhhe3
-1wbsy/Mwon0fZ1HTuyY7WzSV7fVcJeJN1PfGWwNxv0zvYHw9l+hA90Htt1fDIuT0WpFVyi3cbx+q9fth
-rb9drPb7XrUN6StrxOcFzL2vroYgpXir2ynN9WBGHiuGHBjJjNeIkxKpnZIYd2eT72UKWGoSBbAcBF2K
-EtGzjZv6kbR+EQ/znO04LJAybJ0sSeCUomsTecRKtvQ5IfCjNMrx40CQGd8jXAy7AIKob88HJSQ/Cxgr
-zwRksA1+V0KajjVO3L8PQ4i3M4t63QZ+l8pGkZ4wEADWPn9kv5kIvYtkh1vWnAhBlbX1xxcLRVTOkkwe
-zSY30Vnto1MiSSHZrIwtSVbGNMXo76pbxZkljWm6Vm3bnFUMt94oT5VwzkLLFMWXjeyGQcz0s/VT+6Rd
-QrlZ9E65P/Ij//ChzjwnpGhKlTxaZGphEQti+n13cke2ja5ue5G5RQ5jQ5u6AxEhbtzJ3DFQU+crPObn
-3SInDLMk+iA9pFL0HH5UvksMwQenUWcgSvP5b5jtb0Vxd2j9nHQTlM9VaxKTrKwsb6WSVMVzMCqMZzM0
-VHNER6hSNdR+Ts2yBPTJjHcaoAUhkKJV2yS2bUQ+KCBHcZAjEF7X13ECXE0/5rBWLd6UCxEhDm+nPIKT
-FL9fFswOxWIGWvXDdqmjQMYQGaLZsVWS/Xw2CPM6MXSvHBnfVUwgCER4BvPPI0+vrcbUrSP5lnp1P7A3
-zdK4wVZ9KFB7AdQaMts2V05sUdQg6ECj2zwjsPnhDThaSbTlrPzHB+tRMCkhHvxgVJpqWqIsgfcFDvKc
-g7SnfrIxYX82i+ua9wQyCfhJILbB5YXTaJRR1TszEI1CComfBsB6wIpIIpLwLo606RTUGwLhpuOSNw4B
-bkoflKjsDb32p7PAE5Rh/HQ9r8k6njqe6/MvA0xYx9OaXKswRYNAlW91hEVCa+JY0zdTizg+m/3+X8HT
-Nm7iz9S3fir2Flg/ZummssD6oqiqYmeB9S1PKiuAf14K/xrWNQMrL3IuZRoRbkErzg77LXtvEYwy0qoT
-DdzgCrDo6NhftDClCwO0hJQ+W8CGxufODa/ieXQsHdOE25AeRgr74sVoRsXc3oojKELHlA5mkFGG/TDw
-LMu19o8WgTs6SKprkTCh9Na2pxmx7Z+kdmHbgjSrv7PtO//TAG0iT7f0tq7FK+g7sjs6zep6uYqLq4Qm
-dW3NP7fg7pomkM8P1futdNl7N73VV8fJhFInEd2+zpRKS2Lbs1naUjTi6Lmj07u6FlUvBPr3l4F3N3XE
-73RJnkb+s8Cdir8QC8ibH/OsorcQzw8VKyt6B/Gc5zHlhADH4MIqfKX2NVGj9wkdsBLVxN1ZMCXXGLTy
-Sb2+djz3lr1jNY92jMjw9tcZPKEn6aHG9Zdg6agUOl4u/UQ/fXJjgdVFmwig2nAWy0IoF5Hp6jGAqNi6
-/rM28XlUbNOyOO5ltvbNKFGVvQJVWMTvVaX4aGaNXf/TYdbnVamylzcjZX5TxhmuvwDLAssKmtWTebGv
-sCMUH7MihydzLEqfzKukKCr6ZK47Kx4ZZhKJYvgi80a8xN3O+M08Y/544CrvQiwiaR5O3PGahtEZvJGA
-DboGP7ggAslbuxbsehsG32co9DNs9Rn7QzGeJYnP/CgAK90WIdt++Y5tLbTJkrg3HKYRgkAdMnr9vLb/
-5K0fpivDeCG65CSgi6JNQ2VApRHWVyVL0ZKKSAP8gi5g33V2f1OsCqlDmVDmFwEk0v9WQkZlvwkhemJ2
-kBjW+kngJqQN3BgyyR4nhJxSikavvcAf41ZesKHOrxJZJaSufQmg5NwBcEaf+Jugrp/MNTxDalhLZQKd
-5PNNtdv+UHKtREqmmUAsdzTzF4FhW58Knk87l1x1o0t7oVlTujW1rlLTbxu1LMmWKBtnPbyf+CPqbKH3
-7e2gBHS2twndtaa+Md57KT0NwSvdzJaE23Znaarn+JYax1QyOKYS0ePfnP60JwQsifIsFLUz5qREcBF3
-RldS/050RYXeSLRPKIu0pjpJi9G3zeTsPjy+DIAh7cd/iS+AQUTjSwFmovEAM4j20cN/P1kHwQGrC4cT
-jQedEXMS9roXEdhKF2Yvt0XOxdYSv7iikwUZvLUgpL2eQdgz4NNBlW8en1+3zxZs53mB1b+UpShaGF2o
-uWdZp252Ykav397x99fAmTwMd8XxwOt9keUVL2ullbzj+bGOS5bWcVnsSR1ts+juGhIs47+dB08xhPTc
-mU9JTQykg0FAW01qM1CnGVDTiM8pZWstxdPzeackY4yczkJpdo5HUEsANv3Lp84w6ozgjVDuEtd1BFq4
-Rrq7qZBg/RusP/Q3ASSGgm2WKJXeWBrvU8o9wUYKNlXX5aoEwUueSRJF7hjiNq96NbpCgEufq5xuutC2
-k86xj+k1OLFtJ6Uc+OgNsyMvUhmBCyonDfB5esximuIPOoDB9xx/plN0533O+fB3PK+Qulc6mhxivEdr
-VBo9yWPKPZ2rXvfcJ5uHEeyggD16ZVCML1KeJTlF8017eZzgZCX6ixh6a96AAf3kQKLBQMDJaCk7dyB1
-bbxJJ0MpLVWV8npRv41cAo9RFbnAw3Ja1MU2j1GnupI2bTJJMA1ooqijbRvqE0pGItAeUh8WMa8JLCuA
-26GzgdvZjKArAzwDQ/82kOqkBb2nG38ZwJ46G/+Z8rqvzLTn2k6bQIFOd3Xn0LiVbf0iqOtTAwV1uLed
-x3zLU3E4vd9zdzsPszxGMXtdF3Cx7B3tZAnouquAosxSrONeyp9jUAvoRiCWyJULBnopXd4Pdo9GDBeM
-PJU9EQGBmw97FnF3r2zQ54I7Sgg4O5r5hZgf9SSIm107upfFMa/oArYC2R/3tq0eOuPNPaRkIvZlXZ/f
-r9v2yJ17ASkRRwKLBXOCv7q6OwJ3GoA1wPY/UDkdAht4O63BOejvdAoLuCOuIiPuUPyHyyE3nxjlZEGa
-0UuzP7YLu2sD2+7tSds29xM5/WdAmyXO/xXcktOHwDb+CNhKAMAWN+g0rBd6zHLe1uv1nFhTDUPr9dzx
-3PnT9XpeE4tMLUc8PSEWShzprj++ZDYjd3TnJwFMuG3fTyi9m2vor2uUlYilxe9y7Te2PdmoyOHzFoZJ
-Xce2LfMZpl3W06eW1P+adN8RrjWYJLAU8NWVGcDNbAZbJQ4UgC6fOtAkq9S2J7vubkSQoqyMi4dcZNfP
-usAeWsSp9kduKtw4DIouh5ad4l5s2kvvQpy8GdFL2ooqi6kADgTVyYKshteMGUJlm9uSjVxJoLTQA6VC
-uH0VqRZ2kuzR6enI404QqCEb0cG7pc733eWApZtBEwtfeisNlDBkCJxt6qmRHurQApB1YIqeh6Vcb3I3
-35f8lep5XfdeTQspOd/ktGmbUxjkYOaCW3GkaILdSejGDwXFbtsTNs8OP5TFnqXoz/hNVez3PHYIObF5
-dCxLnleqY8mcb/kOoq6etDuCD35kVPj1bsfjjFV8tGY2L1sAxwLdq9wAaW8DOEw18jq8pamYMFYxmuIP
-xNRxhhOdtlstkKYvsnhdp7q3RB2+akiZqZ2Al+glPxy3FY217QLqVWIrryQ97WCMk6rYGyN0COm8EM33
-xaHSK2bb/ffeCoJuDq/g5Wxevt/Uththfz+jLpb0XS2Aa2PbmRnz3ULaXZrNSO4sO3zPvnfYXDruJeJE
-k4/Pl1oLakKVsTvNetZEaH+p/ED0Wslat9CoIVjXw2aJFEHE0in8YrVRco+EolzDJOOm1pUFrbwl9jne
-p/o8oEnv3Pdyh0uLUGX8mKGZgjKMl0kqFFrWhkIjICtsvTKt4hbVKX3+k4AMN+tWJG7axd08D8ezY2Nt
-iVBd5W5IQyBFEf/+4FpsW/2dv78Kj2G45YeriOUR34pZu4qqciuSevvuKuYVy7YSo/2wYQd+teMVE/nQ
-/xCPVT68phKfJRhcVdmOv6nYbn/1LuMPVw+bLNpY+iS9sggk2aO8jBLUGn+vnlUvo42AuQ0rXxYxv7rj
-78V/8TyoYnuuTjZw14IN4xbCJ+2Jcq4r97pHN5yrVgiwpgFkS/v9kkB6JX8OV9E243n1L/X761WRJAde
-/Uv9/nq1Zyn/F/799eoQlZzn/1K/v15VhWIxPz4kw5cvDdVGWQ3GiU1pf26CIceeoWtdvTWH5vAx3mcO
-I9EnNJqHRfweVJ1dZVMHxToH1BH9lidVXeM9o/lhQWYylyxj5DI/YDw4nJa29l97tf9U7HuV4/ug7i6P
-8b5AfhGXuq7bDZwiHpcAsLRTb+k+s1PvU/czO/WeuQu53En2OFT8Y37e6Si13G/fn6TALJBSBhuqjIAl
-XPtJsNrUtTP8SDeUt8JGqRlhAFo8SOp2RiPY8s0cAVFb8xb7g9YMUynE7ZKA4TVVPpdkUCpOYd4nFUO8
-WEP3HcyPAlQY6ZQwJdjg3MlHGhP4VDpGlxBloN82T5toekLbKBNIb9NaGkNKXNaAOjfd07ZgsXvKiy8Q
-NWFsOBmd4KT42ZFLKDHYCaUZc4g0y55jEeXxIJGxCWCyNBUADLLcwhxZbjUQbo/lx1qivZZECdWQePx4
-O8WxshrAQ+kDLVn6asii8gRUfu2kXqUo3BP9S0pQSRu1pweRa9Cf9h5l1BCgu0lQiwcWQ/I15ElR8mMu
-F8ekIvqKep0PCElNCH5U0EFZzrYIfgghvS9z2TrKBttypGkaqRLe0u9nGtSj6vSiwTEte6ntpfYAvWiA
-33M2IjN7DlrMVJ0HjV73tf8M1G1WaAA/ZAdFpf0gaTYe09bFWPvJQE7niXIs3eQIKtBLmbvRDlRkmxBK
-iQRKOaQYTOkVtYcvlUPQr4IzyosHR1KcUgWiw3CCVSeuiTKkYp16M1W5DQ8urkqG83G7GwZj9L38/gEy
-XWZ4k+2OSGK4kyX0CeBzY7rzBVpdWo2UAbPtiUpuW8FwHQMyu4EBlf2fNHw+rg81fUbOy7bHZuk/6cQH
-ZvljvRkr6igIO+9to23oTniciakuXQufi3e8tCQ5teXsHdefj5UFSuqvsqs3WUC9qCI6CZHo0JB/wOsG
-9NTDvyFo8YsbKgr5TM84AqkzDhIZGbQtHvMtE9iqw6P6FopBjGsttPCXJ6bYKEnLDJoS43FROKgyIYGo
-OVOI6sVN0Toc2paEtfpI+L2BIud/ODssRYEkuWREIDg6dr45xJd2Utp40OZMQe4M5QvyOiDuOGwvbmdo
-as2tqZHkdkmGtzCIWzYaWmdoZxqnypvAVZZfMaliL9rlEAK75J4pnEhmu/Nh24rWQzTFDiFsFZQizZlH
-dHNJmXMgS1JTHkmlTKV3dMfo9XPHm7CSszos66jY1nwX8rjelHW2S2s82Ottlt/Vgu+q96xkO+K0eiLo
-L4ysr2+u0wy2ojZ5X1o/R52X+rkoep3BjtFrdc23Pjx1PNd/S4Oarg9P9e3fnFxnkDN6/bYqj3x97cyf
-kmsoxIf14enzieO5a//lqxc/vVj79WxGavEhWAfi+WZ9ePrE1Jffsz4zZqoqWKjbYZEeGXPu/yU0b6+t
-qrTIJV0LCzU/LOIvAhSNGzeiA2Wo4SWtKijo0EHojY4GFlvSkWzVpfA/U+vamioSgPVDb7Seo5SoWUki
-WmshT296fxm4moI5a8Gs9cBGpDJSeL46i3uBXExfiO4khu4sgZRKtY8QEgK3NOkE6kpQqsVWkHa3Vat2
-d90qj1ELiOmtz4OhTol5UxcCB5HHjwLSvO53akNfG53KqOlDZEPgtepjRkgzjGXReWC/EESqi/kU2XYb
-lwrXAbl/eY3dBv9yO9exkQoGJbYnvte1E/YutWnfeywZC5BBTiFNFM73AwhVMHrjymNHF1B05ox7WsyW
-cI9uLKHsm/jfy9uPui5uliNmUPe2PTF1AGx7pzjKe9KFFe+jqjaQLJvze0cAp207GIPw3hAVckhQQcUh
-hMCRoctmPGywQ4VUZmaOwLCLYKDcMVkCmkamlJu7eokReTulFdMYIiWQ1nWsZHYb5ZDnN4cbGiH3CCo6
-uM6quNmtdtMpuaUcdhNK9+j1PpfKCc4tTBYYdiETiEfqzGzgN+e2q5AQiLSd5S6AW9jh2DKE8Tu68Ted
-d8TBCGX3NlAyIlZzlbV92YiqlHLKramcMmm34W1fp0r0ryUo7uAWg2jMD2Xk5fPf+Du2/bncijz6WSYS
-N593tYimOu2d1iaiYGBZhqCYnUdTMQLDAKeh13PY6jJI6GKlogbElPtJQFbJdEqiukanAIb5qJh5znLc
-5L9hGJa47+Yw6o00HkxpTFDh5zcnNlaoV4VCl0o3h/TdkGkLJVOnatyDlJ6cOwbW8yfLm+fXT57dWNIF
-X84vkUXqpob1dV8QdX1AFxc9dTpDHRo5d6av4WX/gzR/kN6WHUakjDylvzkbAgn9TeBM6Q8wGYbxqZiT
-+HEAqR8H0u8veu/DClBP9jd5CqTiaXOxmkOvGrw2w4No0wXmEb0xNmdqxE5gzElhktn2b+L87xZzI/XV
-8zO7EzNsS4za5z0KH4GwlTk4EWUtHGaJ8y0afKMT0cj/vhPg4Sd9vnWWrvoL9+PAGxBtGGWlf5uIWjn6
-NnFlNqCowybyXxsGr475qgnIc/o+LnbfsTzbu0cGMbqjH/WN1pLuhtXOBzOSkYCIKs/Q4Iqdm/MyT7r9
-dqRzUEnnYqhIckbvLtWNzRCKB9/+MvykZaSmtiHrDJfYmREHaUDSeCNiuiMbsFUwEOku6Vnzy5Fvfxl+
-0oTcvp3YVV/5jgmiHp3q/f+ia1l+4GX1BYrvBObqudQTHZWSvf+4n1K8bGDxwYdhwwg4oj2WVKOC1f/5
-5npO3EXTZ57CWqeseC+tTjMpRfHDgKBLl6H3d6d/nDH0LC149p6SbJ+1HDlBDLsO7ZpzsnSZ8hpBaegx
-N5TDEBTFqN0F0jM9Ez9xyv3BLS5hRVk/ocKKpN4NU56+repZxOI2QGCrLTrqWYAJUpR1XgwnT3ytLM0+
-pCwdSP2IvjI0I6uqfI8L1/IWahSRHMVyEOxlsF6hXC9TxVXsE7owbJ+bUMGXRnFyf6PJ8mVcpMiHvic3
-LZjrtPc/AOctCzMA7lWnWK029vPF2cCkp2RpAaW6onTWtRNlYNoyE8VzclA/Fa4lnyyNs8Qn9WiBua9c
-SyIL/fUFbmULd7Slx/9iu3UtYy6sj7rbZH13xujfHv2hJO011WwJG7pYJTdU+kSJ6AY9SnVmenIzCMor
-d7i/CYgfBk7U6ZvGEEnHub2daTjQjFthzAODR0ZPAjZcK9wW0Z0FX7x+9at+Meyo3vc4z/zMPoARDTw/
-FU6I96mCtJIWTeibsjPfau3q5/LcdwgY8Tt/N4QIMUT0kfmsi22AginVGWUiJtlWkfLAqPPA6jp3rOdZ
-UrIdv8K/YVHGvKSfLD65wnAx+CTjx4jH6xur3/vBDTGBkD4gkzdwvQrh/KHMKu6IbYbuq9G9ge7eA2sH
-SACHQSMCERqQvGD0+u2OlWmWX8MXbGBep0zqvMn+kUi7uqk2q3vJ6Pk2Gjh+bzn0jD/0gqUXe57zEueQ
-EQknL4vd/ljx+A1ajIWkgVeMnktSlfEcpFoYkkjN7dRPAsqkvZmfBNA90tBPghWnUat5Ky2TzdJGblGR
-4XjlS0bP3DWthqYM6k4aQxuO2SnA5kIC6uzItslJPcxDFt2lZXHM45fbbE8ttdizsHi0YDPQ+B8vYsEW
-UVWJvA5Oab8eSul4c5Cqz9Hh8BN/rKglgdZdrBBk3f+9f1xJkHUXq6rYu4vVlieVO/vLX/7yl/3jas/i
-OMtTd7GSUDUTeZYiQQVucll4KLbHilsSVbR03MZw9JU53Xy0PZk98PAuwwHMDtnvohXZOfFlNdsVv19I
-Gv/adkjH81opzOAi1lH9d9mxKlZqEpbGAMUzju2/1Mx8vvgvsT6GJYcFX/aloqkyxJDyzD7Ib8ShaC3/
-SzoMq4o9JNR6tn+UrsVkZ77lSQURtT7Tn7Fl0DMlM6GZK7WwO9zIa6SKfpksfEo6xn0Lp332yLc60NYI
-5Zg56P8oLB7f4Kz+yLfZBU+vyj0YuryFqAGs+ruuJx8rwsUxL2v/rp2CjxVKhoXOmtKBXjZ/xMqoOyf6
-APlHANTYdKsLn0ehbrFq9127oyyIRhZaf5MnirWwWnBQX5b7xxFAhJBO0BvNV9uCVc4IQEbEbIecwwxs
-eh8iARQNaRrHdGPPLklvsI/t1NKorl8yKRGJPDwRtFsulO86IanryA8DpVcBltgnqeGCI+1dB54LgNC0
-w7BIlqTbZDsfgqRDbPsLRT6nxLZfMB1cCa2HNmrTcbqZ77L8F3xJxAt7/EVtR/3d+KrL0VSMsN248lts
-luFglEoMHdXUS6eW5RqBmP/au+059byEdfyOjrim7hW0Ey63DW5AQ3LBSKhBIuFvaPoliJwar5AcbzKL
-fM4CMp+Sa/ia0VOLTK0Ovb/LDlmYbbPqvWttsjjmuQUa2DVlB98wetryquLlmz2LBJwLEE6KvPpFnjLW
-Z4uF1cDfGfWtX3B/WWC9tsD6rvjdAmt3sAL4ll04YBWUdR70mFYwEwf/t2ygW0ZlPOLi5/1eM0VT7XB+
-KUiqv7Nzpz+iNvp35kfBNIRhvd1afT+IB69s7cMueJOH4WR37NFZQOw/C2ZOhHp1UydGw3604nfDrsrX
-41a+NKKUOrGnjm7L1Ue/RbzPXEtGKkSnG0v0FbtYfXaTrJIpfUYsuePVlY2TTluvB9H0Z7RrWKAn/dhz
-2kp13lnnIUGhLKtXRtc9Oc+vOorZp9YvMpSiLEZcsxdjNXcfJ2e9/mDNI5HNfxgskshJzRljc6liijW5
-+u1vCKqQUI3CrI7O6LmOaI9MCyZLSJAAXNxQXtfKclBFiFJoMyHgLG6MVLyh0dRqGBDQiIqTLiBqLPCi
-s52fnc8OqWuO0pWuAk6NU4CTul60bqWmGr4E6hyDJoghIVMBlt0M/oOdxcvpwg8sEPPryAI36SqdTklM
-mZ8G6Lnh/Rbd/vtpoMwAY7CKbdxybGjvKw839Q1CD/PXdceE1bUzyEXbQJeDBNv+pxOTrk11L9RvFn5n
-TtzecRIiXf/9E/3FaecgkW3zupY3uoPS3ItcCQCxwXwSFdYtRX34CzMR2nY7rLOeWyNfR4Yeemp+LFfW
-NX5dEx0OSt+1EHi4eu+ezp1O4i2G4vUQRC2VuWWmlds7a2m5EWoUtv5G3BPLsx0qLX1d8RIf0IZBOgjd
-Hnfda5Jtt69VN8Trlj/+tSwe9PObTZnld/jWnROTBWyznP+tfSu6CiQphQ/7DcsxDNVDFhcP+PT71xi/
-SDwVxQ71X6MDKpEd3JOViJ0hoP5wwE1iNYATPKLmI/V1Ph3cKP3vwTvTDF/Ly8Jm6EgyGxJIocQh2Cl0
-aOD03ul3zNmQut4I5IMpUus5xPgm7esmAMNXZ2rbVsorK8uvTELK4TRVzqcmS4gJ8bibCcrLSVpbZsNR
-Ihoic32cRYTYtoxK50RUer3hRLAyOUKBRUBKfSPbjtTJoZNUXdGUclHHmS+bTefLhpwzuALC5e5H15Sd
-s7aOwbXQ+tcPA2pl+YaXGV6X2bZ1GMwCxbutVHlkxED/umTUOrIjErrdDwgpzhb2f3w5+2toLt0Cuq62
-Ts/UuRITRF3ljm2VQzT0XPoNw0zfYDQDiS+juo48J+kfElILa1HX2eGrLM8q7iTES+p64XLickPk6VtS
-VGCBOkODM9GkMTY6xDim7lzk/U0dded+mIhtS31e42z2Xomhfm1ENm2r+kFPQkPc9rnV1z6M9EG7bIpt
-W5/wsW2r4zGGP3raG/4E0rNNo1wxObyj9VB1Wx/VaPtueGYCJCgjSPWdpp5JQ1ZA/8qc7fycgYbRi5HQ
-c4xllvi9q8siaC2W8uoLsZeyPH2J1h8/8qjCgGtJNcM5P3Vl3EUzMv8frqQhkqTorOLPhiYZ35GxSeHG
-hcHJzrUMSJaL42Km+ZCvGPjteKWnsICYfVAqtpI9tyzQbLllgWLVFXnZXIZxNhVQLu+kXTPSSKv5gUoA
-p0YgzHMHEpFhsOT6UbD6TCkJcJ9Nf/bjQFSf+HFQ14kfz57h78IQZzbwgnVBCp1BzwS6o98zMrwhHyK5
-y5dcA05fDETQNyqCsvarrK0B5VbinZU4V3RQ4od+GgQdtAuqCM+iNhRDM/RFHHmmtzFUytFbpUGF23Mv
-nKSBw6Z4GGGZ/6GujPCuf5PF/HIe0kBVpOl2TBnACotiy1luemRVPllFwyr0ylw0oJ+Hd/v/lI14MgIq
-0eX0qyza9KMk/NhjC7WAjD9c/cg6UwIMa2vka/L5Tw+c5/RHBma+vsnBjwxEOXcsRID2Tb/lO22isS+L
-PY20lu4hy1NkddRz55JJKgqjq7ADDbWiOysrfd/+oByqo7WPrC6PdRxWdP2WDIiFqCMWGoiO5fkNoRzl
-XsJ/293OCktQaimvpPKr0sMwy7Td79IbKI/5aETpjzRmTsA8PkrqWBmbFQcqyAU5Z74xl4G+dh8WfMpg
-AcvxNG2nhrXqa/vigTp6Vmfd7JOn4bR769d3qPheXdqanzq9RWljrevX0VttG50qeVHrLffSpLbpMihK
-A2fQawCpmQZmffTU2l8NqAu1Nq1LHaTSRZ9N3S8Z0Ag/+7LWQHv/UZnl8azSvH5W1wk1DpOzIb+D4EYF
-a8eOVSHO/NALXYFpDoMO5vPkEWe1rf/si8OIe7Hrg04aJKYqHaAD9+4QGB0FMi8Pbotee0PBtCmGykMT
-st7kdzakdOQzEiin4ZgHAxGbED/09BfHOqgNYRDLnMTZzsbVDhs4PIiD+zxt/vkMRXBRcXDYU3z84Wty
-/cww4rOwrIWB5B/pGUhCu0BUxat4w+AnBj8rh1vyqKgFFq8F7iZPruGfjF53PlWfGA61fmEm0WREhjFw
-2RvtvLUh8IZRZWbWyWP+1ddal/SFusBjjYosj5JARU3QZ7OQRFSQE8B9LbSbRgHlfid+iwLKjCiyDp8r
-dpvqewdxtHfd+HVUydX5jc0rcerwUjI3fkC0Xa2ZZD21AsFELlCRWVELKQotp9LrP/eTQOKeSJz1rTTM
-uND/7/GriNYfIp74O4qOZBTZjf4PO1D8p6BX7lsf2VbyKBYSfc3hAtY16kv3nVOjh22Q23EzP+aYGNu2
-071gTJ2N4TYazBfTu29XBoMONgS6L9MpbOcyYp4JIGPfukKzGXQ+t7GranrretP3Y42hcs50szSDl2EA
-FsXl4Z264OTnxTteJtvigfpF+wzd47+M518DuB3xsQt3tBWy3Xrt1JtSwbr+nakIGCigc29BUfei0J2W
-oZmcmRTqiD4WnYywxxGgSrruG+Zrx9JeZoxNrZGvK+8vAnPUZsrSTPnVTHkWNEpGqALDoGQ4FPvyZ2XU
-wqW6rbrYwSTpglfiGeTt0aecs/dEr7nlWhJksZx8nlBBlE3uDcvYez8OSFTkVZYf+WpPJ4tm58cBvbft
-e2QvOlo7Vr58bnWwCkXsm856doQYC+IY6zlcuP6K3KqgLPeenvIsv7q3bWdP7+fyC3HvTY/0ekvCqcHw
-/o7ORyd7AntPIHtNQ29l1DXTcEykapIaztMlwWC6HWoxAOJRsUw7YvIhOz8MSGOs4o6k9Ffm7D0xj+4C
-YtgSwJT7unbER5oqcmtv206KdK7+on6NC4m4rvUOxLCOS3fRM9R5MmazhL2JpIneWfyFCDkyCUnokbbj
-3BJ5/ZAImJWJVLCXBCLtQEd0P4E21EwUDORXsRRYSQYYZVbklNBUaWk7CenKxkHXzYTI3ta1o1qNAsBQ
-yVxBn+gwNY6b385xPR4ev6ExpVRtNPynmz7WR/a09lyFVEdD+tGTxa4k2r3lqtWfp29YXYsDHCJq3Ovd
-yiUUB/n0tiXKZzLawXX3pa4XkNDlLMZbk4zeysOwUxRVrLL+7qfBvDzmTidh2pixGBn4t5CAWJDlTWLb
-mRe5zqbnJV5kCdB1QQO30t8F+mqXLm6YcmNjmoeFBIp9ZXybLOCkDAW+RDrMPTUgKTL3jOlrICKgLafV
-XX/GD27Yfnwt+Qo3gnbO3HZW9US5UTtnIKfC9QNQXnXFu9uP8iylLIrZ/X+Z+/rmto00z//3U5B9PhTa
-bFGUk7raA7eNyiTOxDN2kht7ZrNHYVNooEFCAgmKpCQqAr/7VT9Pv4KQk9mrq7o/bBGNRgP99vTz/otz
-dqPEF8Aa1z+nQQ+AK9E3sAN2gO2UQCagkrISA8eTc7+4GSu5SHtzmACttF69EFOLUNzjmXW1tfNb4Pxe
-eRGM8fkUXzG1H88nlomM0mSlUS2DYi1eqYP2BtOXwFe8yuPbwRGhIeMV7CjLhN2wnJnHLS8WQvlBJDsF
-r1qfYbpB8UK/GW5QqMo36uuOT/pJ0GGXDsB3nW/jW/YfkPMseJHpAwi0UeRfmjx28Eh1hHQNu9gu55qZ
-tZ9v6nVywxAlwv80xRPdOFBRfctca1xPXap+m34Z3EgNN6pL1W9Lf3QZXtHTZvqNMZ+5GM1fc/ZsmOTk
-mbwmyWIIuQN1J25LwD0zdP8eF1qiY9YlgYF/aHZiuvGkr9H0oWdpqkEkkE1Pcu4g7ue+n/EMIvx78U0F
-V7Se+dx+kfHwEjLrhUUWn0Vg6IdegMnif+eZu+6jzaQh/Tdt5DQJb8CONs7b+62UJR9UcgLMyDnqUojD
-nzyb2U6KrhsXUaRh3LwhjKLcUbTc0EysalDWvndkJYqEB6dsHuSwiNuqSmeJs6npr3LVUvczcT/VAYvC
-q+ruPvV+L1ytLPHKnf4O9SQl7oko0j90NrhYX3KUhMpp25S8tFuAuZ8hhk9ALNqmpKrhtimdikk1pl/Z
-g2TR5fSkiHKgzK7yUn5uX87RoLNGQezDPyjIC9bIzWaGeVRsmWIVYEvK2NrMxYnlOhOEvveSeZD3meQc
-kY1gaGOsGaIaIVvxq1ZNh2uMVXQeS4MWgKmJqnpT71dgHRNAMgEI2BqgpnifL5nsukpPESS8cYropdYW
-4sjqSmxJ+wddsCcGcATh9XPD2yH9FnFhv2YQmQYTP/joNCKKcp0sYhhP52VwL4GuPFqhF0Uhjg2MPFD/
-PcTlW4UuntBLyBqo/odPj6IyXkI2C5vFFELyl+cV/2Hdc9xDeMa7QM+Lizmt1COKCusYPat6jCK4ZfaT
-YoGhQK0unNQCXDmvWGVywEp2Rel8LCAWYRCqCGd+UDk2NokvDRDQl8aUFf5YKQ52YcaVZADaEg5zFo5z
-mZaOFQJm36xJwP0N8J7U5GKaOhhX/OsFyY/V8nCDKnBQBQ6qjttTYykyu9JzcPsR/liqVuw4ChhHVJDN
-5ksNOF4C4Lj6X39scOHRJrPcTadONDCMawmdoQzOUDrvm8cN9FW1WYhsrv/6R1Fg4UGNb9cNmZ2KYQdP
-3N6GjP2SxwJGEhv2Y5f2TV3K79rHTfJLrvUGlEHh37dQBN+viz6jQUwV625Spuju+43zLsI2TlD+0/3B
-uwEt4Q3dkLunmzv9fojTOVE3vRSGREP3cDXyRWZYwOK2T3QhWBGM71gZ1oTTtc7FvxV23U0mNOcFpPWL
-tccybtvCLquLC3ZF54XVs2mVcbuNQYNr/BAcR8pDmwB+hOFR1HuMWSDfHWKauBqqSd0QpHB6yBt+9RVz
-tf2efla05bNi3vby8F5Xj+2ghM1Q0y5w5l4rObrl2Oc/55R9xohL8wTwDvx537SPyf+YzViV7w/Jm9nM
-Kdm/ns30qV3KJvcg9H1gUQHsTsCnKFlNJAKRqeBM8I6Jwi0Z7xQO1OqFYovLl/pkakkM430RzqSPRMLE
-+S1MqgQOjeLLIQhoXCOUznXCGpem0GCO/LThBJPtAmA1a5TkgIhGsuSFzuAEMCMmUa+isFDtO1tQ2Jvs
-C33R7+DkQEzaLA2pwpop/PiHuc/tFxkAklwwIbjO5Z8fDrsfIJh/HjBoqvyL7gYbePRlm75L5fNPAPq5
-h15C1Tz7rlC75LTiimf4CjHN/hX/vAH86TMECR4gXNncQykIulvjxxBrsI8gDwVgnYse1pHiKWFUra9Y
-HOAmqGPBhDKkuUgs6ojvRAGHCC8wYiAYFzUViXTectL3GSy5tN5ygtK0TOI8RM8RrJgQteKxkaVtRKd4
-giYM+Cg0UHJM5wydwlBGjcCSGlBrCLI1XU4QcOJ5P+S3OvaXZxTpRYton2GWLL3Uzemr1/Dct8OdwQpp
-uFSzOzAU5vTCWgwUsnzGKvUNwsnMCLrbw7bW8Qa8WsjJJKMlqkO239dHEJAL9sJUF9QoZsdXmHM8THRV
-wDLPBX8egrO3tBYEg95yKGjSG40CFQc2I+H5NyGOo+7r5fXj5HJJB5keIbS3o10BcygKBXHnm2fFYcUh
-49MMH5CW7y/0M2lv5yQI1IvNUyZNXHIh0GqLueCQjHYmLVWnU6i/uqxZqSvmnbqjikKapmbqd2gaWtO/
-TNN+Dpv5Mk3zon0Wbq3kWdflWZ+2nX3ff422jV4kVe4D4IwGUuX8YURG2b94ROiPE5kcHCP/IEFRlTEd
-uyEXuUDX72HXkB75IYdcgFMx8XSw4DD5fnNQ0sKMJoUwWc6sUavryvNCyOG4k1U6Sy6uFKHQw5M8k6rd
-kYSsDuvm+3ZHGCmafL8nCf5VD5MTGKe8Mx4IvedeYY7+F7qV+5kOnA1fBD4W/pVtEUaLITO3//22RRTF
-ovfwH31LT1rSM0UY2cm8/GnTPBFG1vnxA+wRNUyyaXQEmb76WfsqMLJrHz9t840qbxv9634vP+ZbwghE
-5v8JY1uYiW15V9aYLDFjAZtgVjHmzQmSZ4CIqQmHFPxycX243l1vrqvMz89YiSADln/ua38zH5MOpp52
-HSGnkEcqy2/VvSFXMz8zofO99HSN9GWqoSQp7VloXhHnnpQtWCV03gudfm4o+UhOn4WnA+66hQFRRFX0
-otYIhpJXIi4oK7kiHEXgVzAiEzkhI0JtljApmLqmz0sPg1AslnAcOnf/EZlU8CCk7CgnHK/mKyW/7ep1
-XFImx5yvtCva2XizFT2dgmQyOsr1//GIe2/5nUEfnyVDCeRcRa5MXwj5/2ma8HJost5eXNGSl7YVe4f9
-304eqgz6k+cZR/SAzF/y2RV+tkdepIhV7m0QrUUJ5k+J4aGRZGDyCzf53lcGk1/YyVc8pqAnmnxBHwcL
-MZxvXtBnNL3oV8F5PjDpgleLUk2SnK7yPX6JoKkM+qUkANdzoa3vLgl9FNnxM1k7uetAFP1o3ToZ+fVX
-e6T9+iuxKdT3AUk8K7ITLbouR7aUkMTXe4ftAvWkmKtJd2t4D/PZXHC17HK17MKdUOqdMLj+YXMM7gG7
-zgWsbwONObdgmPq4WAp+eb27XLIVnBvHNzN7dkwulyEr+ZA3L9Egk1tqDoFoL5CIkvcWJhtemHCMzc+y
-oIEHSJmGS9Qs4oe8iSlNcmbwMSWHqMOeKUqmcqJuOM8SHdiKVlw5lBjPpOwiJMknhJwoZYo/e8gbz6Va
-A231i4ez02bggqvZTOGxmcKtUckICHQQOAZtoXwnDSV2Obn8j5EDXyK/+BnL88+ICy5gVbuPoGmRxAWX
-Ws8zGCFiluAS4OE1IGmhxq0wgULeUrIhn2AN/GNssP4YHwdnDC7MmkTrxIU5dfthhfvhdNIYj+dvCvJO
-8tz4lDPU0PkMZMW19uxCe5EZaKvZW8mWvAIVRgJgWVUqJ1eJMTKow/mtTFdJlcpkNl+9rec1ejEUvFzU
-GYudqqzraq7jrQM1WeqpyRKjK+lxbeY+WN7iceGzuOZe1409pYNfhREDsU6oSaapNiRuLmZFLTHXEFTC
-5pRdngWvBYoGN6gV7LRbaQJyfK9apHvLiwtacrlYZiwunUDh0qK5la1bxXBt9YGK1IH5bjyjfr6s3kTy
-iyvKqtMp4PO1KtHpOHv8d7CxMz6g6vF8TUz3Upth2u9AnJsxBepsQr1OTqsKYlX4RsiW8SJ5OktTrrdK
-StoNSYwylGqqX2ulgYbCgT+dwasRzf2Ovrqc292q86Oyc/wanX3KoiGDQ7PG8uQL2XVlxu74LVJswXRm
-8xTxaRPBdt49iwEAFRw+nwPiTBYZZK3iNZdcNc6+GnMue/HO/vW41vLv3eQMJ5eCi6ZlB6fACEZRvON3
-3jvZHd9N0VWDsp2GAqWs4d6jCTL8aqQnd0xw4eGrKNrkgavcsXOw6CgSkKNw/xk/jVfpm+Qr5o0B3zlc
-WSY88ELuVUoH0UR3v4smqjVQBoBQ57gRDgjK/OQQfWuo+kJkib+VCwa6lPYMhPIOUzVWXTduzeh3nf2p
-jYOSFRpYElWmVRSN26kBhtJuKP9eb8r2MTagfje8DRBXu+6OmQm/mdxR8Ilf+ZBU89W8V7JFSraCpOJz
-RXljeYaZRqNI16v9vHJdZ1AK8cO6LqcnJ3rEK75F2SOKxuIl0EvcC3z59iq9SVoLGav6sjbIn6s+7Cc+
-lBl03JUBISWUraNorcd0xQrK1ryJotWiybw7UfQhXlHQkOhZDx6xpWiQF2cIOU4JhR9/p2ZXDMDuxFTN
-tDGuRZH7rd+3RUuhmfyuG3+ATCANavIsrygXdzCMwSoANGCpulYrHm7RZLA6HSCx3ev8jqkW4qFbesHb
-JmrqRkBxDRoh5wWPE0ck3S5nBUNzQA/QaHai89774xKTjAp6lhz6jNj24ateBB8xbasPtal4deEPGv76
-JV89L2a3SIcaLNh4ZoOT3flJ1KkxgiNkpE+VkTlQRk2bl6Od3Ne/yREGYI0QXWwEqGWjUjT4AyCCyvZx
-g7/ut/hXCYEjiyo0MkBCIwc6NHJAQ6NilW+WcoQn/mh/L9b1YXQrn6DdW/m03cn9Xv24347kbtfuRgUC
-e67l5j4EZjw39IdWAGemOFOdz1DqhkNS55EtDJKXHs6BSV+pDn5hyl2PFQ/hOh0LID6gndXDz0m7MVB3
-9WaUuzsYBlus4mfE2rOIeAiI5wPXDS2T0CVAE3u9ymPBLJpcCHdMT/P+ySCQi7rf9gNkdWLiHhnWQE1e
-phxB54oNOAdBz3GZMr8uixWjTidXkB0d0aT/6y++uJrLNGhe0iQuB+Ho3Nfo6A3VAEhEwIzdCJ5Pm7ZA
-R/JbYZw7WKNk81QxYqDq/8unn34cYgBVOdaIlXwK7hFw+cvHD+eJUkGJJ7ruzO9NUI+hhMTLBQeqlk+/
-++njz6rBHcWGv9+160/wODB18ni4PK4bQnVa5ZI+F8aXxKVeHRcvIPlAkzvYiV4I2GYKJTF5v3nIm7oc
-/fLxQzIiE0DKgnFbC37536avX12yjeCX8SKNMvorX/xnlL2+ZC1wuNPXKU0Wo+tD9jpe/Of17nqTvaav
-LpdrtjVmM9HeH7p8u1X/LvaHdpcvZTedXMCO3NftpqvqRnY7ue8e63IpDzR5dcnu9ON/fve5++HdN9/R
-V5dsp8quL68vL9le8OcTO8D/94KT15fExBaS14SyhwG/jJzQ+YMA0wy/wb9Od//o6+5Dn5TzibQOjOpd
-cxPwCMbeUAEQ6uL6yuOCGqWpsfySCYT6LGZZGpe8tNnpuo68JgxNvTkEaS0yal2cC0qT/j1gpgo/XOgo
-+l6qkIoi55wfhBuIZbzC+7VNLbFYZei1BzQth5w2i8CdTT9yw1fau/Ul38+brqu6Ti5usrRKx3HNb8xR
-l8QC4L8Vb+a8t28oW6r/xlf0RFltpWG/8mKW0a4bS3BRj6IlrADX7yfRF5Y30/wmP36Sh0O9We6nVZMf
-dDgKALnb0CRBnd5oUWRRFMdyUWRpnpRdF5f8+UTposjgpsv058FHjmeI0ONlCvxNvIh4gtapPeSHsp3T
-rC55rRZGrbpaW2nJQWlD8sp8uq7Xmk8H7dLf5H7bbvbyB5mXchcTnVD/4jMCbKn1WFLr4bqiSvQDJ1f1
-P3L5pZIW7GxIOhc7md+eNNZ9vRkVtILPwmA+21iBQkat0cOKdvMgdxBFAhaFibqR0eeKS93iElJ4SnqC
-GMelmegqjasxdjyK3IdUlBWLyuVwscP7jRjM1KTEZrXcb/2h1bsLxuF2cZUh/AsEo3kfTG8Wy741MOjQ
-MptX/NZMirGKQOZIxeTCDHxfy6bcLypELhkoz7iggOUCOJHqE78HN3UlQnC/AM5/0wUK4WrMe716La6V
-Sk0MOjFjmRrGKKrBpK8mZ8lvFjVMRpV13c2CvIafbLykHhAZqAOkz7OtFleZzublmlip+bStwBVYiTgf
-z9IlgJgl6j8IGwC4NFWH3doZVa1Sb3ktoaZ6/TKK8gU5rHbt455kVPBlLLR3tjpD8VofjI1Nl7o/KJEi
-OPoY/EmWaZOQH9sRTuEeDLe7dq0W5YSMDq0ahZOxM5l29vfAhxCmhj4RJy+5Xl4c6geZzFiT7w8f27Ku
-allCcNwhhyA5n9gkz/e7JtGnDwMZhvz53WfC6v2HtsibZGvEa4HZB4q2oQwRqJLxjG13rfoOgPZR1GX/
-tCkwxx5sbcRFVpJfjdzO5fHi8fHxomp364v7XSM3RVvKcg4o8Ht54H///P3FvxKmuKztQYcZ3QsEt0G+
-Y9vkinEFLAwsUT8JO6rr4E3rho0sq8Ju9pAw1qugSnSNm/wh14BFJ/Pt++RZtXl5LY7r5lpc4isvr4X6
-e4ntXV4L9fdaXJ5YuIfwYWIKf/n4gehemKLP8ngwn2XKFGeHX6B3sxoB+ESSIP+F3NcI+qwGGi9VKyTx
-2EZdrnqeOObwxLyzBafezNXxoMRVuzjuX3AhEumTiOEECw8tygRNnkQclgIwkSpw2FyPIt4LCoWfd/lm
-v213B1V40IW9CMoh5dkZSmcBx2SPvLJb1rC1O1vvt/EzhoHy9VR3uevWbOsuIR7cZTVppzd393L3RNNN
-3NLEKEXvggheQO77Nm8akRe3+5i0m0KO1nLd7p4IZXu+nqode7//ti0lqMUOwCKq/x74jD1yUuSbQjay
-JOzIn3cyL58+wR6fsbMjcyCjTF3FbzjnD3i+rejzij+frJW1FRhlt6R0tRDnICxcLN5kJ8FXi7x35xQo
-mwUaOsRJfdM3TRN+1n4gYxV8VLpMrP/O3+TdvdwfzjrixMzeJxgG5qHr4pzfK8bmHtwPc3ZY5HBAodFd
-SdC7upQfNbcxiJTVdfHa8iM8N8+6yRkeWwhaf/P2gdqY+5xC+sDFHuH0RYasxuhoYinzxVHPeQ+CluVC
-LfYBP6aue/QAh+UUKsaCst/imbFVn07qe+5sqPSRulC2nRKI2XGqTwV+xPjPI0pU/AjBnmw9vd81PI5F
-18HPrtN0nyoh0trP1mDMs5c7wTzaPyGXl4SyNaoBi+laHlZt2XWFtoutbQlWYWvH3xi3ClcEYgR9WUQh
-JNPm3fW02LX7/XftOq839PlmWJpS5+8NClTQQ6Yv8A8LGuEPvV5NlCzW7g9jDE32btxAuT7Sn+hz2I4i
-m3WlexVF66l3JjpvDitxmHr6AQ7UOTejokZ2l5eQ3jxvKGVHRS/ZmhXsSBnudL1SjvNbo3pXb8Uzmd1i
-esrNFNmAyQTY/0CpR4AiHvLdwc0k/gmzkbM1OGdo1KvxneYEsCplZoz9WrDLdP/0/QmPG/1kRVMSkYSk
-hE50d7XjKF6pNxZ5sZIGdxlX7MY9XtlluRGMvLr6lZPJrZhMaFJNBl9DbA3VeF0ZfghCwX0GCXjh47RP
-qGLyvrowdS4+1ZtCEnb2JChFD/nyS4382G7kxUe1hIirTSnzFo4bR3XlsVBaVV74ZXT4TYFMxYJWKBt6
-4BtgtYi/UUG4WU81E7YI72Tpi3cmmq0Pi1PCRmRyLyZkPrrjs+nsiiSE0MQ1A6HcIOY2kLZkusJjhQ58
-L6w3vL1oEOtyPUXgqk9yU8KicZdoa2zZka0px0HEPWQ3kaa1dP7ICfwk9kOeNTlNrjSnfsVscPXViR4X
-TRavzUdIflRMjN6pcBofp+4051dqZ257exAwuBZHts7O9vZ6Cry0mocDxgK9nYH546XcXKYnRFcn9MTs
-sxRp4wO/YnK6V2LCgf1GHUUDvFJ1zFGQa0ZP89/iiyv2RE/odAVXSkixPBvxckD+hkoWq3VhDTuwe/bI
-nngxfzPm/CGK4gf+htVKmAzim2rKpDHILPmq6whhwbiJt7P062TGbrh4y9/MZlH01Wz2VnTdV7OvOeeC
-qSm/57+JeM2OkKfhnn+jLu7Zkd1QdpPGvX3/yI9DyogP+f5gdzqh7HGIRPBHyl54Xu1o+5je3vyRUvYG
-P7TryA/vvvlOicJIQdMnTjatyW+f6P5g6WFtPiSJn/g98BOSNfweqeaB3+PRzm74+EBpEh/4ExuLKHrq
-uviJEy1gzt6CVzSfUQqbH7gSLuxPQJKJi657UiwAu0nvgmQeLVs07IkdM5rc+dk8WrVmn9ghc40qDire
-K4ZXT2aw2m9SXO9aaE3g6h1+o1r+7CZtEtXcDhKAeS/JqGop7m2cb/U2tJvn4sIceKBHHjru2q0PiXwE
-TlZJSi+FoWyMOz8rGAH5isIzn0BMfMkRBJ8xJnWHghs4nizlgTCybfeH8xzVfXtTGHUbamvBjw3cIkpI
-2GwkIpR5XG4REPRypl0wDG1OJCoPCmboHKQvqPc/KwnbJgqIopzqzzdQ1INOKfhO/1WoSLCvM2NhlARX
-Vo1wxYw6BUymvSQKj7t8+03TvJxN8w84v3qez7q50OuZ0hNFiBo4+8ANCvOVnsGc06m8i2fUgyQ01cJY
-gwCyVFehTPTxPjGcE/LxoNCWI4ar5msRyjXnA6UOWMFCWGqUSJ0eVvX0/WYzDIj9zw8aNDUwbF9Ig2C8
-gAsurDo5duHIaeFmgybCA+LUXz/oHxh89/xl83hxPuci9CWlCb7qfhO+LLTA4rQOQCF7TnXoDCzaEjIE
-Oid7i5OJDp0OBJ+edFoP4yW53U1NQhidRO580sa9ioB5pJYMbJmhW3wQCt3LXP921nUhsAyWLOXBJUvf
-x9QauVHP8ifBL//7m9nlkn0r+OX14jp7dcm+A8/i9HpzuWTvtLkMjfE6fK2r1/kSLGvyAEY2iGf7/ouB
-b7fyaSk39LJ27Maf+zr1s5zfmtIKD3xVUdGi67414VE0LeOcSZqo1iZkQSbxma7JhnfJVCiWdUIywiTG
-81Oryi66zjw5VoKXelh9RImZj8K0HYLa90loTixkhi1qQZAPulQibmovwxDvZepJRWyccEVKiKL1C+OQ
-mnHUtf79b+/V6dluEMB0QjiZDNwR9BTgAyMfEWr3lGQZGK58ydVL5ZdDLn/UpWmPnvCIMfMV4CfgpgJv
-N+a8oe3Ae2kF/yzigkH6QDXLLlUR+sFFni7jT4KRCekfMHu5q/Om/m0QUlBL5zo0QFfEflHIouwXvURC
-Bmm+jrxG0iG1ldy5Ouep72yn4z5O1KQLGjxCYO0FJFENnxpzTZHqfUwS5zMcRd/r/RD4rtMoGr8TDtPZ
-EC9wa+268S/mlqJiQd8CuFzPT38eIieiNtGtkIKm6I5fDLjjP6s+JOj3yGARJLmd0e8EI9e76w1wWMlA
-1WK4KqIFI/EN1vBxtfPTMSipyUtqn09/+fjhh8Nhq4VSLUEJ+nxCwviD4M8zSDBx9ebNV8mb2dcn9r6/
-cdRLYjpvpkW72/Px+L2IIvJYH1bf7mQpN4c6b/ak3ozeC9bAg/y9gGr6Y60QFp85e7gsddh616nGx8LX
-WKXP+wC73vfn5UJ/HIDFtlu5idHljwlQ9ggUS9XVXoJbKgRZ7veP7a5U7M1xtUMDiLNe+oWrxTLjXsFi
-mc2F1ctG0Wra1+YOlcXuEUAhdn3rOrkgv1zo6ZHlBYBUZ10XD5ZzEs6nTq4Kny3p6lz9sGRysczovBg6
-Wb11U4CZouSraQt+b/ADtbHqF4jq8AtEXRDv0IsNHBqZVkVwzvN0ZVQUiRbroNQEuli94kpLYmkVz5iu
-SROE/FXlbOUJferGD2JhirKuG6yGViRAillZG7FW3MIdOuBW5NWUx0P6LOpNvntKXPEpeQZ7U1jxxFbT
-QeNCrMQ2dVePZBFT5o9nEZveutwSdoxTN9plMjje3qR9DUjFTvkA8FmDypYiikpIU80K9X6cLdSxrFC/
-IgJtHnqHYKIto3apQe1SaI1LfTqzD8BrCkWlfGgYz5xlraQoWWlzqLNlslFg7nyhXBbrwfLjhbsTWEX1
-2y6vRZwmqtVOVaRYDKbQwH4JhkjdzLAkgnLgO3VS5JTlJ0eWrd0wttJjIBIY9iRHvTFkxUANMiag8CgD
-3MMcMkoupfYdjpoOvgP8JgLjgw4dmhuL/DkxVZIP+Tds7S2heNA/axt3kk/xzrd4zfa7IskVdT3RabuJ
-CfjJag1OSGeE8Sc0fvksj6Iq9ugCapa+nn0NZxBeqq6WoDQNsv8IJZB+YdEZb8W/CMV8/lXwy5jT6zRO
-edS9ot11ip6K3nq82bebbUIKbQ1F4/bWGEfPQVH+ItAZHHQ2GD4xIb+ivt7nYhY5+Hvlg+tCvQPs31vC
-gjCVHjaamEIlk3Pur5q/gWONpuR+15DkLMhNaN08gLQJX5kOkZ4uIuR3XRwUs+XeCdaOKCLqr2P5Vl1H
-sCeK4w6V6LE0PTDj2eP+w5s07RXESsAOStgqFYtVxtV/lkn6K5hVyETa6mbARM+YYwbOWFp0dRQo4FR2
-Lkl6Y6GbQsbPueSlc/+UEzJ6zPejTXsYqZUEytjlYpadWDgkHLVykB1cZkz957e85NZD+8TKgUTblnhU
-mKndpT2JJU2wORDPoPPh0Be9oVQr+X6/iiUFjL9gZiqq9ugS1D9LXjkEDasc9Mk7uGoAontfLwmx+APn
-bR648Q6GlGuPCUUTBRddV6KzBD+idwBkAx0XUbRw8F3pQvQsrHJxldEsiSUv8niRZwgZW0WRSYQYRaqz
-jkRtpmu5W8pYia6+6oMiq/wBgj03U0Xu5vbXULeHMnZ+sN7LH8RwusG559CIeBsrnvvZANy+wziw0hio
-DQrzilIGOergasbUdV/kDp1REjGQoxcgSzj5+adPn9VStqoUIz8HSlNFWHp6U/Rv0o5etAcUkNPnyq10
-tpyq2nGZqgOorB/U6aPVat7agnjZqt6UcYk6MLM9iigKZbnlmdYrSO5YdZ3nPQgso2B5BgAe2sPCar2d
-1Zk5lTzr6fN9y0BgM2DOYpb9bphGKH3DeZAPadp0lsZyWKG93MltbFOI+qKWEdGBTGOefj2rJw/+W/TU
-rTqMKadpnvxP3sdp9cLMThutkYNoiZ/g58sZk5zDk4dcrRHSCWUNh8D7NX8+zYlifOtCI5UYvMepqc3J
-Tjb5oX6QhLIVb/RXxJgsWDcNhhRWu4IG8BrZDY8dHLt6QdeRqj5C6jl+C7DHk9o/Me8PLURgsps0Lnlj
-vyIGxMvpod0yyUuAaaRJvPRRQSvadbMQTbpWRWfbE7RWwqReL4JExitKDT6smEKi13it/uLVxUr9P1m6
-KupDoI76oa8vVvBHHXjkfq9olJJ3UyUd15ulee+aJg2M1hpxozzdU9ufXPqlfAqO3wV9ffKC0l7P29Qu
-HpPYVphwYIx811YJJvnzod0mM9YYGE1eQv5r3+4BmeJcBoJqWuob+nxgG2CP8nqzjyHvWaxm7wXwTVbw
-jyKuKIMXSxjqYrrNl/I/8IsvxLSA+p/bLX6WxJHGWr/0an0ALM9EnphZRUmImmEMMCbHKrPBb6zs9d6E
-CtjFiyu98HdVKjASf6hvSayVcTgLP2vzARN+KRyPLhGg+g6k8xQ8+XNbTbHuanTMfoOaiAH6ud1ajHlV
-DcZnoJ4aHFdRjzkucmj7wnYQ8bc+K7qsmoQB0Qsdm+9XBaxWQK48nZjf339KE3o2WsYUBu5ThmINEDcw
-jAUPGsrcde/yU5hU2OLAJcRbQoRZ3Dgs1wvwhVC4oIoi//OzbMDlFyBLwZirWqo4HA/z/saWaZVWC5El
-+aLMgC+Mq7Sy2HZxkVb+BkgkK1KZVP7WofAslwBFWp6l+OtnhlZnMJDwL8MlAwDutj7K5mc9/GwwLLJQ
-HBEiP1P2p9zkhUQW25H4hcgQeLdw3K83WRpY3SI6w/JNNBTReT5mfMiC5NabjdyRSW50FolghCSkvT9A
-8amXnBdmsPRn0M1Sf/QUL+0lvbYcKeTPL2DvIhCBxFADA2mX6L3omM7+4ggMWudcg1ASnSG5fdq7IEgG
-ySTPgLUQlrVAwbFPqy1IkJiKtnwCAa1tGvU8k8GVqYC7zFQIr9zLqZlNAIjCHSsAN9pCOqKr0JKelPyQ
-lro+qxjqxk5niMCi9vUroZOG4e9yHVTNCjDnnj3Se6Cq7BP0xExGgd/BaUA2UgMu3G9eeMo+AylVe6sn
-9V5PXr8m2niuCgRTst3r1wS6sB+2RkFtzWiaYco35SfZVChL5WX5JyWPEvOsh8kBiYCjCP9O83VpfscE
-bXOELbIBBO2N5hl+FDyf3vwvVZP9pH6/cst0037bbqqmLg58iE+evlL0GzjOV/wngeAKui17R1/+KCjb
-nJiAJC66TD22UcV0/i//JwAA//9HBa+xSk4BAA==
-`,
-	},
-
-	"/js/knockout-3.4.0.min.js": {
-		name:    "knockout-3.4.0.min.js",
-		local:   "static/js/knockout-3.4.0.min.js",
-		size:    59822,
-		modtime: 1534412156,
-		compressed: `
-H4sIAAAAAAAC/7T9eXPbtrcADP/vT0HxzTBABDNy23tvf6QRTdY2bdK0TboqagYAQYoVRcokZccR+d3f
-wQG4ik577/M8004sYl8ODs6Ohw9mZ9YD6/s0E9vsUFrfsWv2VuTxvrSSmOcsv7Wuv3S/cheqFBLYelvK
-a5lab1kayLzIUuvc2pTl3nv4cGva+LtwRbZ7qCq8ioVMC+lZr1++s5Apd3Nz42Z7mRbZIRfSzfLoYaLL
-FQ93cXluPtz9Zo/PrAcPz85QeEhFGWcpwtax+0jx8Zrl1kdabuKiqtCCyGuWYGSrbxuTA/3oBpk47GRa
-ktf0o5uy6zhiZZaTa/rR/fung8xvyQv60f3u7Zsf/EHDdvNhU0rL273MQiuQYZxKx9F/XbYLlvonWtny
-4z7Ly8Imdi6vDnEu7TVJsWdn/G8pyl4jpqDjnGbtsuCQyGWK9A/XFK0q8wN7KfrobjN6rHGNu+H+QN7g
-Y/NlfYcYEfiYy/KQp1Z6SBJKKasq0wmz4tR6t2SUUuHNLuq23i+Iq3pqRQPf1O7W/RhUFQooc5lbyPJd
-vJPZoUT9fJr6HOGaCIzrrtVfP9+qSCTLm9YC7J/0oGr3mvsNMXIm8FE4jphR+nJpcxlmuXy6YWkk1VqK
-pdp993uOGPbg57cMVkR/vFLpXXu/68VSqzSjVLXqbuEf1Cv0hy4Ec6C6SUEkDVbF2pfuz1WFIDHhjgM/
-XrOVWC9R4P7IkSCMdIm495uqTsn5uamLPenmK7GuqraedIvlMWYeq73APQjEMO6N6nu1NiQgEh+ZG6z4
-mh7jNC69dnU5iciWJGSnh74huc/cXX/PVPIVrLhAEcKY7OksmFE6uyKP6Sz34xA9ripRVfsZpRv82HGY
-e83cxwxhx0E51DwwxNzQFZs4CX7IAlkgjslsoRpbqtoqM2CIE1M4x5gwV3IklxLtyBX2doRj7KlyH5mq
-vKH7msDiHGOP19iAzVFkaZlnSfFMFkKmAUvLJ3EaxGlUeLNFXdf+GXM3bsnUUswufNXgn/B7UauJMmof
-Un1eA3vWHroflj94x9pnLqe9lRP4GGY50lvO3WKfxCWyXRsTSRkJ6cIPLwM3kWlUbs4v/HA+x5LKVbAK
-12tf/e0y12sqVPvfdO0ztXH4CFAQqLxrmRdxllIbUK2tRoNsk2iTNh/7zM32qomCHgMZyvyXfcBKWXiz
-C3Io5Js0uf2BlfG1fH4t01Ilq9YZ7e15Cz8cMcK7aQqFFxhm7oYVb27SH/NsL/PyFgnsOACOCnw76BO6
-dhwijgdNcMynmkAwWa4aadAA61oLdGvM/fBhn2dl9uED5RPFZAfyANHQnrtjpdigHFfV2WqtputeoaBN
-Jd2uqg6Yu2doQziRuMa+qk837t9ZnCLbsjEASkiP7TC81bqO06JkqVDA8jjP2S2J6NTt8PZ2x7OEbOmx
-Jgk91v529dpxHr6IcxlmH98//OJh7JayKNFr91DI/HEk0xIv7e/lLc9YHsCO2Z79y0u9d/aaruytvD3s
-baL+BtlNqn/tc1kU9trfuq+zQ2G2mtoiicXWCniif+xUnqqkfx32+u8uu5b6V3Ytc/PrUOofMi2btESy
-a2k3gG/Z2OdoS3oQbDbfgHkHA3RB1InRyb64DHwxn+NkpZZ6TVmNfVVsR497Ax4CULc6wmRDD47Th1XT
-KKNfEk4PrsglK+XzRKobHdlBfG1jIugZdyNZmuTiye07Fv3AdhLZsRq2G6epzL999/oVtd9//FLMzs9X
-cWhFpfXyuWXPrfmcze31o8v40eXD+NHlbCXTIA7X5+fvP34pbSJWi7XfwuxXl2zJvLRGmOT04fu384dR
-i56Et7LZodzItIxFXN5+KLOtTG3y8K8PH36WVwdZlL/KPA5jwdT83qlc9MF9gJf3Hq7JlTdc3OGCsvGC
-cjhQ6nIkmXeyLX34NOAJoOsCWKsUN04D+fFNiM3E7sh2BUsSaNb/hwHFoT7ilPKmTWGW5vyiJm/5YJRD
-BLtQWLVpMLiUfqAb5Lp7hXyCNQlw07D69M96FE5NXjFviL51y+rAZ5DgLy6DpcbkQqKAXGBvQSkNHIe7
-xSYOS0W7vOuNkuMjp7yqVuve1FdroofLx8NdPNJ9CcJXwRorImJ/KDYIvhrwETUJ+Wi7mCLOpjs5WZOm
-TdQsSK/h79n/g4bbFttxs9G4c3YKZtwa40bMoLbL9vvkVsONTApp/QN6YM1aDa4Hsh9uqrozVSOSnum1
-Vv9+4opmUPv7SC5hN6Epgb2gqtr9luQC12TLvJDIj6VMA0+Q35kXkD+YFy7V1zOPk6cTc5yxFup8PYNj
-TQJY1OCOK1OtIhKrYE2bZSVssFXZEMxUWz53wzgvyqeKjvIxc3NA1YqiQv0sXJO/xQhI1TL8igZHFPHV
-Yu046l83u0ll/szwQVV1wNN49A64FmovZRpA74i59zRE96dzGJ+94clumiQbulq37R71NaAac0WSpXqq
-swX2N2YHl6qzHfZ2uDZ9bWpyFow7A1BQQKDIVYGHfYsTQB9MR20SrsmVmEQe3E2zQL673cvliq89rjpY
-tGRfN03FCizWZEOlu2e5TEs1FbKjC5J0A9hdJv5uPscbN04LmZdPgG9BYrVbE4n9nSIpsxwlNBjXGMBC
-sNqtcV2TTxOgygYj4/RrhYzbOcDRaIdXVdxvyjsOU3DSZc4o5QoGzeFZkAsNW/7FZb8O62jcicom71yt
-WldPr62gqkd1jfQbgT6ab7rwFYcHZ8GcaHImqHBT+bF8G/MkTiPfZAW4rjusUYjR0vzPo80SmMrHZZnH
-/FBKZCsKR5QysAlXvEfzSXlN7vWXdoKRVqxzurRtj7llHu+W+g9SzZTZ2zKP0whhN5f7hAmJHv61el+8
-/8gW63nV/rr3MCK2jWuSBlNI27ab09WcnUfNsixnF2q4B17ojhakJcHUbtfktZgAjN6tPFvAhlyoubSw
-0eRd+PrWzdKSxWmB21E0KejLQb0l6228x3BTfadSG5TzY1bEajhNaxf/TendpRDDzsV/G3hjjsNm9EzB
-E+13ZZZnNmM1SYfItIEDl7mvBeKED/FfKxAy2A/X5KepBmYz1cJbbhjXlOOaPJ6CCzVEt9QUZ++nW2av
-shuZP2WFVJTFb3eMMkuf53mWL3tUb5nfHtuF1xdpuYkLwvIIRl7gWgBzI/Cx3OTZTdeMGoD5qc6L8Ova
-4zXp5CkjPGe66clb1GR/M1dqTe4NRz0t+ZnsnWNfj437NVngmuzvuMpNhyD72TjObiXWPuAyw+i6E3xt
-VZ0FVTW7VjRiUFUdtTtrqF3uskCzVK/iopSpzFXZgQCgK1mWTGygsB5UQvv7LDURygnDNQmpnaX2XPiD
-aigkCQbO84WbMcRJb3W4G8hhwRrXQBPp9dHLZT/Js5tC5laQySK9X1rFYb/P8tIaT8PKcqvXs23oq9P5
-IkEkmV2Y/GvEscvjNFDJuCbPxreoonR4Vc26+wIPxif1cbF2h6K0uLSY9ezNa0uVtW42MrXUGsVpZJV5
-HEUybwan5X52nO4PIOlUa/QYcawuoxIuJc232pSK4ZFZInUBqzIkoLbYSLHl2UebUrX1ds6COLMpDbAX
-UI22/gFiZtdVFeBp3qjlLAEGpstwN4iLvTp3ulRAB7VQArI7W/GZhoHHJHDjNC51viCzhfr/I1m0/80u
-uv8XhGMy6kSdilNQebeRAB5JLAOr2Zcx3AzaaaBEHRe18rDk2PxFXeb0+Qjj3KxM7zfS52BqfHeBsgEN
-BSXSrFAPOE0uUmhHTOPKbxHHyzOOsMJpn/hnCnG31KU4n0Q7G184DjqVwKslYUWhztASbWjvcxUsbRYE
-tmdrYsxeEy1pEhOSJoaPG4M1uhYU/sDYs/XFfdop3BqcFfJXliwl6qUS2yTbMANPIk7sNlcn4pr8eTcL
-LJCAu9kQMUFVBYqIwQG1bb/Bw2GPzVD4eyar6ssZlS1G0MLcHv2FJF628t3V+KbVp+Od/FhqyhWvsSfd
-gJWMBoAsfxZIXav5mGJhbsp2kgK9/T+P6AarG5G5O5lHsiXhCnQiD7oENGNB5fv2/Ey3M7fvP3xkY4UL
-u3uzrsnPYnCd/+dSXT+I0YshgeP16Q7C3KK8TaQqqH+5n7JsR/sfait+GLYdh8gQvrwtehMH5cYffNHF
-6JvXNdkEo13lZkc59kW3uR3bs1ornsuXl1T4cj7HgaaRZcuxBTX5dTC8pi5Xde+SNzVSgR6HzmvyB5+i
-iaKlFoYihj1Wkzzw/ptSuiFF4P0P/HjqbYgUd3KNhqG9S6oHlwnGiigVTJMsd5cu5ceS5ZLZGGNyJml3
-/szxE8spqk6DIKWinpqg0JJcXQrXwNmSHe1pBBaXdOfvzs+xYdkcx7C18OV3DK0MJvDYySC54yC98fcY
-4hgvXzjOCwWYhVyav4hjD6XyxnrRNGebXuw5xxhhT0vKnp+iRHX5v6iq2QtX9xuHt8Pb/ylL06y0wjgN
-rO/evvmhK4ewa73NdtLiGusXFpJu5BLr5XPr0voaW0HWvwNidTzVtZzcEosfSus2O1iCpVZ2LXOh2lGk
-rsVvFeGjbgpmFVobnctQ5jIV0ioz6+8iS79w/y6IFWQ3aZKxgPFEWmGe7ayellkVA/1yU97GrdiwN1fU
-HChYjZqEvS0x6gZJZVUda1+rHYDPZ7tCJZEdlW6ciuQQyBexTIKiqkClJ4Ri/xUO6+6ZBuQcxw6zfNdR
-RAK3gouECpdB5ySkuwFEhX54ft4WjKCuFEiQ3SpcY7Kl0aD41t+en+PNKlpt1wCpawo/r1lykH5zMwSa
-SstP5eswROznBicpkiJht9ROs1Tafm5GSRM/d3ey3GQBtfdZUdotMkqtOLUCLE6bNmeYCKDwqL2Jg0Cm
-NhH63KVE6FHCGJ9zs0PBKl1jTPKhDAf7HG1GqgktZLirW/9s1K9v+mV+0y/3x73U2D+4PAtuB+k59qVi
-x3dxWcp82fuNcuzl5hNhf5p3ynuXixHzNO0C21TXCGtt4KGMk8JWREc/wWV5zm5fZPlzJja2Jkkm8tW9
-rnPf8qnspJS5zv9+ovlvZPksLso4FeWvanH0MNx3E0291GoDXSA7zX/N9jovnKj846HYPE4SXSCfGMjP
-sEAvS7nTZV4Ny2jJrs7SvwfZIZzOl/qoBr/F5ea7Ikt/VBBLQKAsxKB8JMsXWb7Th9q0Oiyxl3L7hhcy
-v1boRxf5NJyYOhCqGzPr4YgAb3e5cpiby0jxcpqX+palQdJs0n5QrsVjXUvPh4PIwTgCcjbDPsosihL5
-LNspAHxaFE8VValL8mEbA8YOCjwbrv4hvcnZfrwcwwXTiHAArs+G2xwEb/LxNu+HHamTJD+WT7O0bMfy
-Z1sERtH23dyFPc2W4oKrCt2RQ8/6171GIwqdg9BMoetGCNNI3U5tWloaoVGC9AQ3miGmY21bkcTCyBna
-wuQCTxjMGHmzroEWCvv0FC6y15d/Mg6J61qLKSRVhMKUVYCi5CPdzRaE82ris21V2Yp6UBfWtqpmcrVd
-a7IhahYg9XV5am8zey7mc18VosdWMqs+604HZH/4sM0+fLDnQLM8Y6XE6sQpHIkwkfRYN4rVSJaD+7iV
-HCmeulskCWLZ1JOrYA0ir5NLPA6RnFGaVpWpqj4w/F5gNfQzWROwSPLGQMAp6BwbwneJApnIUlpyxdeg
-ndTWPLMF9mYXNXnpTcDDfG7Ng7qu+7AcZLtnrGTm/OOJLBcGZAroDyNqunMPecf+6QtUqpVFnATYh1Vy
-HMX+SkW06uxCZxPZ6XVk39ajXXOOAlhztZS403/IFh5JQhd+cikb/iGZz7FcJWsUGMjTU2g+X8Bn+vxj
-KfOUJWrCKisOUbgKWi5sDV0psO/pyAIqfazSegwp+Vpxtz2Vhxp63TEV0n2pgevCmy3I1+qf/4DlwVlo
-0uCzgbxsoGQRI9V6KyIRY2I5STgT256srK2jaBWmwKTRadRkL/qAKhsRJDfQnQCvqe46lBCJyYLSpNPB
-tLunQDxVrOe9ATuh9mnVyfTWjoOA0A37ibgRFRjzmZyhgNzFTz2wccdtJnTRY3v85HIHG64otWTdqu14
-TToNlje0x7mn+Ni+YmqophrQRBzr4zkAl+FRVYT1iejuWgOZKu04vQ+0YmtsDMfuUQ2O9/y+us0kdgn6
-gEIL6lMdy3vm1HaFVGr3NT7UKu1ZXOyzghlS58XniqgrUf+WDWCZWm7GPltRD+GOunuB/bEpIKerBbFt
-YttrIujqgtiXpbrJH9nEvnxofq5JQFdftlmXpSKJH12WuSmVP7p8aNK6KlK3prVs1u6QlPE+kfR+8+u+
-rqzzVYWQHsuNZIEnCDSm/oZZVqq/ubf6Yty/7nvcbRl4ASk3XkC0aNiT6keUZ4e9J2tyFtGvH8EWPwW4
-3/UM5bp7RiEj0DRca3rt23evX2FJe19QGEwtZFJIdeaQygcoQSsBhhqOI1eLdSfk2FD17W8GkH9xMaO0
-n9SeUR9vBhmjipu7jswG16BsOCrsiasKSXrAfS5WpuVvcRpkN1Ul3UCG7JCUv8bypqo+krwROAg8lM2T
-KyqnjQj2/p6inOZGKPrwr0u0Yuef1nO8sh6tH6p1CFf56mK9riru53SvFmFP7ThKs1wGl0F8/cie71cX
-67mY71dfrOf25UNI9CcO9kbbdr3dxNfLqwFb1stBe4w9FDmOHJS4wuTsqmcbtieR41zdtY5XBun5+fm5
-j6/olZuw5iKSRl7VS+uZw7ZYUIIppvt0bGfa2S+IE1mtsUkGOkXdPUYsNOsJEpAAzUmjd8bkGoM8fVPu
-EiRG5jf6FtwpkmckrVW34sKXrXUDiA6HxhLBSip0idEpD/NtuUte5CzatQT57oRmV2V03lPgLF7fYZUq
-DKkm1Iy/plR0GqmjNs9k7ms3EUhnAEeKfb1aodpluFmPr4QniAi8sFG3aQK+11qzKCE9W5CIit6m9UQp
-fni5BftejqJVuFZEdGMG1JAJN3ws7Z0gEtiQSPijkXulya21Z0XRoxKsMrO2mbuTuyz+BLaC5rdE2OjU
-OEVfffGfr/7z3//zxX/++wG6mL9m5UbxeUG2QxhXC9zBxMV/457BwAWe/9+r+mLF15SZibfmlNvsgxqg
-Z8/53G4sJ2vycSzY16stVgy4ihBUEEPSKTskQXrfSBpZemupdq2buNxYL59Z9pzNbdf6UeYbti+suLxf
-WCzJJQtuLS5lah1Ss1CBa2O/p0gPDRMEVDoY9WFyNlvUYZyyJLk9GnJeDa2uye0USRYq8kiBZ9jRPxFd
-kC0NG1CJLrd+1Ng2JTRcRWv3lSA7ukrWvtTkWs7QjgBR8Nr9KBCUEQHZYT/p4Jnatp8MMHxyF2ZKcF2T
-RExIqRGjrMXD75tNeu8h98ESv1/fe4jxkq0u1lou3EmaenCnzuvr0+QGHCHbveETJdqd0GU+iokygDpe
-y12m2HldLhGfa8vIKR6nQecDUOiKt1DxjztwiiaEcafoOJOg6Nj50aX0FaLZ0WAVzeeaq40eadL5v55/
-eUnnc4GPEZWAve9xZED1/rsss3YHsbFyKQ5gnn/fYmEpc2ufZ0IWRZxGluKEbatkxdYCsqNwFfnMc8m2
-NaeyViC6Q41iaqNvg3sc7u0+A4aPHGE/orIltumi7ul7FiSkFySiiwYnFWIjg0Mic++j+/pQwkJq2YzM
-l6fc7bRttQ+8+bg6Yhi7mf5AnBxZq5JTvNOU0KKnBTUSJ2SHWWbjukYCewfHsbMUjnFRslJqi3A7Tq2T
-YWllgI3/zRSasj53T1vvyXnU+CYKqFPhH8b2QiOGxOe6HEO4nijcvz45HqqS+kY3YCHzGxvwRqBu/cNt
-N1Jd5sFKzufrzjcinM9rIlgqZDJom53T8Fz67BGNHIddSsdBwYppIQVW3FgBss13sijjNPJGfACj8jwa
-AVvPKncXeLzDFgq24Qz+0U9ohw057m9skJkf0ucsT2515g5kAbeMgjVTWSZyrPF0m4zn1yw5ADBSYURp
-sPyt1u4JOqqN9Di5yeOy15A89TU7+5w7G0cSfNnqWq0XK+WreNd3ruo8wogkoW+nhx2XeV+rGFDhoUAR
-Z7p1sEbVehI1X8Hp7MIPqZ1mZRze/raRqXZiK96W2d6mVC5/9X7xmfuOoQkNZIgYCbAaHPgB5TKYMOhZ
-KPoRnxiP3G+q3Le0mFzmmg5hQsh9WVjlRlqgBrHul/lB3icWl4IdFBFVWnFhpVmr0ZOBola0XhlaZYmV
-haGVpUJaMlWcGNzF3BW8qhCHeS8IH0yrEVZNoA4FIBrAkcRAaitoAqWPq1fu7YGrk85lXqCU2EGcl7cK
-s2BcE11itGvMlVcHlsTl7VNtgpjTM5slN+y2sCkVSwVR3ne1ltW+o8fWKMa7IDbPMsXW2d4F0VvuXRBN
-HnkXRrLXrCmciluA/GtBxzpX0BHGjHIffkWcCv3re0ED/etn7cb2jTb/swPNydvah3CLwXFM9KTI2/7t
-17SwaFpFUOE7Olhc5v7BdPPPsP/MzTl8GDn1S2obVEye0WM+pHKZ+z0Fv7kfOL2oye93mPeB7DygQVW9
-9DUVpa4UNXAkiVhyY5SGvYHRnBF4Sff7VbBWBJd0XzJF279kSEG9L90CvgsQF+pyiruFH3S1btI0MxB2
-l1IDEz2o6Y8cfEupUKMVlNKXxqPzk0DAk8HHjwr8jE1K4v6iLuaOv9KLvRLrTiIKt7MfUsU/+fO5xKH7
-c1WFbqSYvpYCVW3JNECKnPuBTciPoeUfeE1+mSL2TDZDeEYpq8kn0W9iPm9rvxveMp2GA6w+vkUCK4RG
-Il+437KqQuoPcE2jVSMTafQ3zZ1sKe/jUuG+BmQXOE5EgaNFERUIq/M8u/CFW3IUkgh8T75lKKSR2mLh
-vuIDQ0xoRiqIjijztwqehfv9sIysKhRSRoT2+h16B2O1tj+yO1fv+xVbO85Z+9tcfjV5K8ZC1dbHyRTm
-awMp6qepV1UL4zGyANrxmQaf70eqaoOx1LYpbn7e+kvguu9kUfIRGjN2ytDmGKFV1XQ61KwbF5hpOPC5
-ZlSe9e1YOelx37dsxddT8pgQxBEh8ExVJQYTABdY9IzYRQMvNnnm/o6b5EZ5+6ynuoWMSJYGxLR559Ps
-kJaq3FuhdQhbpgf8O0PPyKlaTxX6zg1T+sxn7kbQO2z7Z/SMTUqPmfv7Hekn8G8ugGaGjRb0O0P8xMXb
-Uc4GpnCtGLXkLiddfOwsuCRlQ65AkWj7bI9wjxkA92TDBvzCPU60u1N2AsTSODi5G4E4HtIJbxQ90J+I
-gvQ0KkBowURpscIKpKJuZSpiCbacbmQc9qT7jSCccPepgDv/qaDzeQjH76YPxaCV65u7I0yY4dQDIoFN
-bxGkANT4eIAaYRKNfM3dgWd6Td5+psxb1uN0RbbbH0oZgCr5ozYDuGbTmW4ky2e9CRswNA7xd9SJi5dp
-XMZa0H/N3LdNQS3v7LenSlyB4Z17o7HocyBP/+DI/pCwUhba+MJWLfxAB/5jfYgAH6W7tNSAasXq+brT
-E68Wa3BXcyOGMFF5tJ9HhBsyhHXgAl+NLgMBZdPg6vm6hkrcnEXFtTTmF0gQffaaM6gIDHNq/2BIkCc9
-j3a379CuTvQtcxtCVatoO2QC6/OEHsf4zfuOlHdcnWqgJBxABqDIUyLSlMY1OYv+F+XHl01dj7HTk3Y1
-1PC/pcz9wY0aTbhxOLf9J6tv1yrLZ+4bRif9NYwZL68qTkGfzVUdStPl7MIzv8VytvBUE0glKLTvM/db
-Omkz/UbHZ/gByjxhE4WmDOIdx3TF3B/6ThiTJZ44Dnd/hUHNGkI5G9iK/NBiyaENybdt+m+KpVPJwwJP
-2KDEZwp0Hbphamvo+wY9IfZeyq1Nnrhlk3JmA//zLStA+iEDlRuytgLk/hYnic5WuRHkJsPVazyIe1aA
-nWV9Vc2QrQ+oDZETTlm15iiCiFgG2tci1jgl/gQGkqnVTcsCM6lO8ZzqBGJlOQAN/Gj5GeDL1O4h3p5J
-BQcJAyBtfZDMYT6WORNbMF0xnCrIe2rf1KBHLRs58W3taAu3RJgYkdWU145CHmDAP2WSC/5tik+A6But
-Y2XYCFwjKlbh2pdIUZIInLtbRbnmsRSGM5dphIlo/BtDcoFJeH6O666CpgIV6uuZTev5PU6SE3IQRNnH
-k4mKzk6nHYEvOr/KZhIme9hda2wCebrvAc9sCiwue27uNfZW65oEsijz7PYOEs+M7v+6A725dCYgYmB6
-Kn15fo4DJFZSXy8ruXY/mFHR2aI34Xaw43Vt5g+IDYqbgugEwys49PgdhT6/UibQwcRKAQh0G9K694MA
-DZw7p8RBeoFNq4hhtRqB46AOApsdUJyqIO06TFwY5mAp5GguUeZeIXuf7S0FxFYur2VeSAviF1hFlpeW
-Bi3rkEJaP4DHKBAKtLzia3oi/GvG19tm+PUrRIzpW5gZlaJieU5t3SZAWt0EsEle0FiiXaGVDWfEXv+v
-Btjbl+nudQcDpA+oywYEpyWO7glGoyNZQC9CjSaWwRZ81jB2EzKoKbkUHXJ86l6cUfqyqubz7dhMb+zq
-6TeEfePuAL5NQBr7oZZ+RpS7vw/gXfTKC10WPHN/ZMhm3XRt3LNxmIVVdXG5xSGckZijgAjC3Q1XbEdY
-B1Q0/W3pwpeOI1tkOSWKk2TYU43rWrVGj7Uv+tHPOlV2j3BUJQkI+TbcLUBRRLXn8oy7v/JGujS7IHpM
-JCJb8LfnbsHIjnL3JfPV7wG7lzhO0nlz+oMBUuC/A4Rr/0zVHlTcOc7uroozcAqfWNuqQpG7BXuz2QWu
-fTXwEzFgC2C7JiyKgYdk1Zh5remxKFl5KDxGgO7wuMZanqhrxd04zmzb6BxXaxJ1ERfK9gok39CFX9zE
-xv9JsEJatkIitvcNjXz93WANT6F1QRe+uCzB+2eHbBYEigoKVmJNvpkLo8QyFffZHto5vzAJpp3IcXbI
-1srVwCZ89c2afDOsqhGW7QkKeuhdnCL9g31EC7J4FKwW62U0V3889Q8mUV//ekEpLZeR11YWcxSsLtZV
-tVAlSUnFvDz/gnxD21YjUmLyo1qpVP3zE/3CF5ff+PO5IGfz+U9YXEaOk2oyYTh6CLiDibgsHefHtkC7
-Mj9BNqC1QKCU/NjM1NjVeHpr65AmdW3ky0XH5IHyCdi7nSKY7wYVeSebN4790wpmcKMAj9z9AIWfum8a
-Xx/Qn1jM6AHKzGLWNnMbBtc6pIksCnDfKfZSxOGtxaz7UOm+pTk613oZQoGbuNioFnLJAtAtiEOeK6IW
-2ibGRUgbQaS3FvjWyFLmhSJQeyxcbdhPiX0UuW+rKnILhYZ+Urcn2Pf0LpvIfVdPOHguA8o9BALpY024
-46DAVeOiZxwDkpwginSJIXH+49hkY8NKS3fd159kIXz0Vs4YcIQ0cGG1SESP77yUvPVmC/Iz82YX5AVX
-//6s/vkVEiC+29+BpwdC9twTVRVoAx4Se4FrVAO/bSSYJb4stAl9oErFVQUY8oYNC6q8G0Z2HPT/JPeO
-NXnlLQgX2iDAl6tiTSNfur+KyYBn4RTHLyc4ftlyF5J8wr6iwHO5RJH7K6OzBYncQv0ZNHIPYzVaxf53
-Or/BDSEJY/9WeiBBehC5seOgyH3Bda9xz7H1DEVurNWjqmChFmfUe1UZAIN2pMshCmLr9B+5MYncHe+T
-KxLiSPZMqmHrP03KLR7fJfNfFWv3VU1+HMp/jdteU+BX5jhCbc0mLoaA+ti6r5b7vtUeXeAO1RHnEtz2
-ZdCYMFzL5NbWhJtqNF+xNQ38wP1G01wwkvncD9yUUQHKhpr8xE7Ux2p0bYVcR3XRnmBxiIJxtCQG/AEN
-VmxNhBsz9xcw7WIYtxFLasKDE0HMC+Y4s6aXn5nh8V7AoPjnFvNtVS0ueyt7lpw2/pobLz4mVHuHE39e
-5gre6z9u49q4v2tSnwekUduBQhLUKyYv0XltZMuYeYxsvYHKZIvUSVHw08W2aeozzTbgmjDhnRjxQreK
-Nj3VmivKa3FJxRINlOHNLLjQ3In+/TntuDoJs4WO9upx9wVbclh6r8thdymaVsUaWOMbBvScgM1Tf7TZ
-nzpdMx1zRX3gqgqALtP0t3BfcLNH2xbZaxtXS8DRvvBVi6D4zG91yZ9EX9Omsy9q4b6qqrOmqbomP4l/
-GPGvbJl6M+G+IpIevxUQUZa8Zp5wc5JwT7ivQBji/sLR8RvhSRJx7w+y0+XeMi8AtVYOJLD7SlHRmtn6
-UYDKRHNMpZr2OyK1VLbxJj0lsFWhkciRCPcdlUS4xbJVW3pNVNrJFjD2g8/lE5vdsK0i38mPY39ttbxN
-oKy/g05ctOfLoIl+sOfYUzT1iZaTCIiXO+Nu0aibhPuakd8x4e5btT31UJTbsX6rYu0j5r4FMHmlbp/C
-TMEQAvqMsD7r/u5E8Wmup3dMDxW26IybPXjBTlTp3/P2mLzrUOTbVsH+qlGd18OT3J1JNW49X+7mrY7N
-zUf6QO5uVQl9c3B1Gri7481NsxeIuzFRSSo31xwZB2jiWt+v109xP/qPvtfqmtyjx+Iz2l+hBqjOWOD+
-7DiBGmij/6eU42OgGwQc/raqhF7uwIyBBGoMJIAlIUKvP5iea1bN+JY8U/TWaMZyxd1vmA4OqmUCcsKd
-V9IAbiQIqnYQSLoxw36iLifuJ+pKkm7KfF2IJjWuA/dnNcxTwA76gF2Ts5efQVUKm1RVsxAz2lwMit0T
-HafXgPDkbqp0NSiF6LkbM/KN+vsNI6n6m7KawH7jGoSSav3uOJEpsVmRSLm3IcoEuxvQAM4QVxv1D0cD
-jsEPvWOgloSxIawwfOyWgDnOEPHMIL64ERudirE+DfQePxu9h+JzVj9rVccn/WPnM/d38VkdxQ7kzX/8
-UyHH4atirf91f2X1UD1nQxn/DBIbLWY5VBrsWqXC016t30Wb/OMhl/2sP8RICQg6hk9aZfCp0TF8anQM
-n3oGPZ/cbZsYF49FGV9DKmdt8rT28ZNRPaaCnlh/TZHsrdkCc3fAVh4VbajNNnV4iUbWXxPtl3XIQfAy
-rIbNeu5HS5COnIoGsaZDEuFjRKOqSuWNFfiMgnh0SjPCNMvSC7dXVawfa/RnGT3/uB8lPmOlHCVpM/Zh
-4tkPYLc1KvlE23UNY31uKTuJcLpcrb1jrXgEdi0RI1vsCzW7kS1bQEMTLMUIXRpespG9tIZk+tPYD5ov
-4+dhvtqN9LYQMXwgPzFr5zUSnCaAk6edfCJw/QywD1U3M0rT5cbjKID9qDvmZDsV2/t0+oPAxL647ALF
-gIuEwP6kqUSZfff2zQ+Ow5Gtf9rGKwMkTTqOqqpdDwSumjaTt4W6PeDjJaerdc3cmx7Aa3BfTPhmDxih
-3/phyrYZDIloqYNi0fU6ankFS0urzCyRpdcyL91e1BCw/59Uby18rThxnIvFI6GXg3LUC5YDp0bP/kS2
-ow7fDcT06WkbnnOTXSueubFnUYj5Wn4m2nG7auoILy5psDRLBwoHr8s3QXeNRuJlGzkX12Tgc90GmR21
-3WlVeNsFX3tp3XMSUvNVqOFG9BLe/KCS9K8hxmDu3/R4GAYd1OeniRzXnB/N99tewyNCrFPtU75hxTPt
-PP0GCoHdxIcPy74rNHODVnTwkWPvf4wj4BL8XtsoUxC5Smt7bew4Z5/JdbUcLJbBkrtGTuuW8mPpmc9G
-4AlOjs3AYfXa8KMQPmPJ3L/dA+LN+Faj/DX20pFI0TJd1DXZnMZM/ocVHCKoVjjc4KC+h/hw1UiKffuz
-Sw5qbccx3jX/sD++mQQVI7HpZ0YgFLvw2Va1tbFuuLPS7sy0hWfb9UAgbfZHXUzwoEhVnZnbSGBB057O
-8/yChHQBAvdmTEbavvXDy8ifz0Mch2hLxzuqo/WoplUXWy27SfFR0tA4h+jIlotLKqtKaJ/9i0vuFvEn
-iUfwQOVovbpAb8JUxoLadre+df+E6raeq3u/UINTR/PvO/JA/qjNkAjM6a5yINrsFdwAmbK5w8atRTAQ
-3Mq/+OJLOM1iw/KnWSAfl2iBIQAWN/r0C3J+gbHfi2rOjaeTxCQnW5W2p6AvClr7OZvYnebgMV2QW/+W
-BqvHa38+f6xRaElvh736Z3GIvvpKAYy+ZR7RPT6aEOm542ybsLzHrbz1cqOf2ZoXJWxce8dDuk2zm9TL
-q6qXjv2cqhGqofoiS8s4Vce38VT8r6/bLmd7x5nljnNBKd22gv6cbrXh37jyV/+jajrOYwUwt83wUKlm
-en7RvJYRKmZgFq3K1WK9bpYWHP0Ue2mU17d4foGHa9tbSvJYwf8ttR/a2PtK4d+yqvTWlVX1H62Ymc/3
-3lcXTd5/mTwoszw/3+tFacxlv/xqBkP/8j8zKIdu6e1ww7e6+9su3rMw3pgru8wVsNkhSxRJrcOVkB5B
-pKDk4V9o6a3ufWDnn9are+9v1g8q5M4xeu++Lx4M09+v3Pn7Ncb3HsbkTFJNcSL7va1a+Ou9/f79+/fr
-Sv3r4gfv7eo+pN8fJN+vHkLqw0Hqw5sH1eqv9+8Ljzxcr/4i7+37xxrhh97q/fv1+gFkjRJ1+bVN7MjG
-JKQPV+/X2L7/+PxPdv5pcf6fD/fW83sPSUSPdgy+CSb0G/zUqM72Lmp4nKQROpbMW62JZN6W3HKPk196
-F4cku94B3XTmvzsQEDxutI1MXYfmFR4TocgEYgdV6z6XxhfOcWZI0n4KkoSTTSvkhTp0u+JrvKOStJYa
-guzwckdnFx5K6M4AYoDJjhoUlyxnF16yulgv7TdAwiF7rj7nNlY/vlh7O0wSuvMTx4kM9N4HR9X73Xw/
-fMJHe76b2/TDp9rG9R7Co5zZp0Jkey7ntlUrTDJqzJ5LXO/oro0TF2plLKCinUa9j4WaepYX5DHdgetD
-nEY/Qiw5dZJOQoPKJUcSe9KIRW6HIUYRU6RYVTHX4BgwMk0O8LRN1GrnN8g2BoYgcP8AuDkvbGIf7Xlk
-UBKxsZlX6zHbZtSEBXe/TjJmAsxDIGpog6jjTYjxmjwfkyhEklDbUTmOpqLxjLlP1GVQVSHECkaYUnUX
-gjapwZKIUwH03PQEMYgBgjVW/yDZj6YmP8IrOnGW/ixVcbXshLmbu7Ob3dIJ6m6LA1Zmah3Nu1OfqQsG
-DBo8X6mxaYPgjXvLP1cplz/qs9K8c6Ur/fK5nj6UN9lv7HZYQzY1/i6y9Pnnp35HEfNqQaO1aQH5ZVpm
-TdwxPTR0px27gayv+wFW1aGEWJrhkmlqmfXc+gc86V0NbP9VAwE4zPXebGDgMpuo+1dSOXhWQCsiEIjg
-w/Nzoi63sH19JvGT1iD/jEOpcD6vQXJ6d9hMkWTgESyyHRh0lixSPCYgNM+y5/1R+/2nbXpq/15oQ/1i
-Vms8tFy0BpFLsRK9hxl681Ir0/uC1jWiOjhO48yv1tJ48FPaOtU+1aNGtsq2MSw0iWi4fPiXqfe+eLDN
-0NJ7X8zR6n3x/u16jvHyffFAN3bvoffwrzvL3HtItqPG3j/cZie1TeK9hyShx0PizRYkSyBiE3NDevzT
-O9aki+Iw5eijAHEZQGDaXryHmnzs4SQtUOCKEz4KOnrdbhBr92zyjZHBgx36iRHAWCbSh8A16b9i0uhy
-dYfmHTwxtK3sx7gKwdAp6OJTJPr9uX6EgMEbI4GJW9EOImBIRzrdiZEJIywPu7MlQQYgBK/2tRG5luy0
-cO/hGo+NA2qSSJwGWl2qZViKuydD5HAIg8+l+IfSw+dsAv3u4E6Y9eiGeyfszAYrUFVnAg3XRPuPstHB
-61quST+n3w3XunBGJeq9FzRoyXH+RXc1eSc8TvbBdFCIaUxpSKtoEBSCbHs7pKE0WWk5QoDXzSt8g7Bs
-OtZ1kJl4K3QzDt/ih3TTr6DtuECNFYcobOpGOGqdN5v7niu+xcQmlCgEq47tMqRbL6KrcG20wCjE4GGo
-uGz/ZhMnEoV0ELcc1xB+JIKAEJEaYu94bRVXdtnG9NjO5zhaBkOwClfbNTmLsBcMwEkl41p3uRk2q4iP
-HtN9HeflgSVNfDV1e4bTWS5LkuxGBv0bPXT/vKOw3O3L2yYGmUIkd5TTs3kcmrivoRuJO0oqit1EVVUH
-5Y5ShSybIKJqJXKZ6goBG8v3fjrRrL4Q9GiURkb7wNyfOnknOSoA+pa1VNCUdLD3bIUWX114A9++odwO
-2QEr2bmi6Gys6GeQkj/NdvssVUiH7eSLLNdvb2EtFvraa49j6L4DSe3ZUAYHKuuOHSomJbTapLorZOLs
-gCAraCS1QC2OSoAxrg7h3SGGyH3DUUAUDT27wP3eW/rs/4NRkOOQlwH10WdGthiMzDT1v9vEz2zfxPbs
-ITLHSEJqdOATszphRjrThpAaCCUR5XMERs/DyVfVmW1jsgW+GG0h5o/BUQnZUfsmLjfontD+gPioP9Xw
-FZvYIOWjIv8U9QyM9tyu69r2EzoM5t40YhP7nnn1wyY77Os+adJIRLb6LmsjymiKdOPuZFGwSFL7l1T7
-c2YWLIZlGJvCfZ82C6OtFxVZyuf2+/S1rqq+23bIxq+1jf1PbqMkMr79P3V4rmFx8+w6DjSu+elfsAin
-fDerp3kBhgY0/vABqKfgQuHeQMTLCefpiX44wiuxrmvcp7sFkWQz5QpHz7pwH0joWAZgDKHKA8WjxgA+
-I21zoSbjG58IND6MuhXtBkB4/8XnaBBagWzGb3YITELa3w+yo2FP5AKBA+MQ7cyTg5JufKxbGT7qQYyx
-e0gk9ie6qf+h+lbBsQLCdujbYVSI2YJswHS7C8fmQzjT0N028e82jhMo3NxNxx1dBIpelnSHhH4tmpMA
-u8UmOyRwWfZCRPnScWb5qgm1v3YcvZSzTW+ISWf6ARLlY016ViudL7z1J9KG1rNgtVk3XvFnU6KwDfZD
-xUK6EBfKcZAJTrfB5vEYk0PGrMdK6EBU5xczagRhkgg8yV2KbMfjVIISM8wUsaDYzOZQd6Fbyo28tTbs
-WlrMErciiUXnwH2rjrZsJD6WjbH/J4LgN0S2D/lhYuTeIOvekFB4YY2xr5aBzhZ139u/59YAcYXJpnnw
-sa+Eu8La3gjmupsMkKdmCJbr7Yza8KFWGe9kAeFnNtIq2E42ryG5dhNsWCuNruASmu0cB4CsFIBnNeXp
-60eR+mbfrd03jmjQ2TBth2crp1t34sKtqrOQ7Clzn/SxHIposAyQVHd4rg/XFh6hBrv1H+Gfnu368P3z
-yHH2YG5cVWhvYjipAR18oJNhcNd0P+WyNxGGGu0R1oFvJ59uWbF1TQo6gYE1Mtsv9wh7ERiBAN6id7Si
-WJVrxciochtWTJUDs+Co9jc0QVFj/LWZMKYQbijgMS2y0b8PYOlNQircrbz1dajIwWuXM/0AfLg+9ao1
-oGTdt+fh3L7fwBmX1gGcbONyYxkKtwGpwsQTnLBuCBRMqVvmxFrxLECcXKMQk4JIF659BXdxiMA46u4H
-7eFAHGYn8RFfN6DfHgZkzw9z22JpYMFksMVyaZX5LTzGBjYMqg8raPvo6hrnhMHJsXrnzmCNHolglVkk
-yw3Ei5o8dQcaKqQxFatVzXhwJjZTizOAfEPE7BoiZtcSMfd7RIy+3NpNte+rdVAILVqF6/n9Pv1yf942
-QXa+uuWbcHlT14Z3oJSmPcJj03dLdZzB88fM/WXJPU0A/YI4rs+Yqy4R87jKUQcw8WYL0rxIpH/v9gkr
-pZFhnVwh02ZuwYqvVfFf6CnpqllyIB9COu2UP9MydrIj0RhPaRwdLuGRNZKYgLQ77IslEgpPCYWn+r4a
-G8U0RI6DNu6PNMLYQxv3nhbeFOoi3bj38iwraUI27jajDPsqhd08YyWjO/UBL4IlfqDaWAVrmmDwLlQt
-k6R7KkkXbODjZsrCf2csx3/iaIdrEmvDtsi4augRkug0/Bc0uQN1jft4aFq4a4xkJp935G2oqh3h2N+1
-qswz4/oHXdIU11ib//zSC9dlJKuKpjLxQk7MghrepYEq7VIgyPi9NLPgTTPcb5Mo1+vWpRQU8fY3+IZ2
-TupdIdf4AqI2CSzFA8QmZqJBYQpWm5Frk1ljJKrPOkwFVn4oCRUdeATtT38QVGQKrDXIwuAUEF/14v3v
-u9++uv1PbSW/mDAe6xMQewXkDfy7j4H9B2FT725siJqzvQ60xk5282J0QxlytxPja5tM0G8qgsVn7rPT
-wHEBVQV69rvANYL8lGMwFmOu5COXY0VH9rv+evBdVRHaIIYJb/r9edzA7NpxPrp//3SQ+a3joGvafGjP
-Ysf5fA/Da2ybaW/EVlRiAXvRef5ZGh+rG/k2O+TWdSxvrF0WyMS3CimyNJgs2z1KCpEteFV9bENywtNN
-/nY0zy2n/04QYaQMjZNPKdoHxxu6CkxF+m8XdLa2Wz7MaFj1FABlypYZHntT1TBemhPspfWApzY3BAgE
-m0d9BquqMn7mUznvslH8XDlVrCVp32WNRPE7Nt1ck/+ss4EGTPQiA5Z/2zSv5mHSvhMYxATojkhGbdxl
-xcOOXMYEXoYrsfY42fi75c79HUnsoR1ViUYG8R3RySQYWGz2WFA0C6pqFrjFbSo2eZZmhwL7kWrhCCYj
-8Bq1x8mfwpO1b0zYVA/+pqrkcjdhns+xd2YCVPYup+mS6kYgG6rgcKCj5PgokQ3iyDSMI5us2HpAEIul
-RHaSsaCVV6oyRKzJSJegY1xwLZDQXMNIqhGQUDFmm6pCio+PXNWszIv2QjA8Et24+jLAPY7jikbmreYr
-nbA33iFXjasxCZoYBP2R7ZfaPE2LDBV/uwwRw143oBpjhTwcB+3pbEFmkVsc9qCRfgXje/5RSG1IN+aI
-mxWxzES0ryM8lnurZVrwtmCcXmdbIJA32gWSM7El2ify1uB0lW1q9CAkuVVUrlY09AasTZn1tOozLRE4
-1iTSETIjOnzDKOiC6UVj0A7wMloFa4/74TJ0/xTLE9ZCotDtAFRt8SnInZYR0GtNfh9G8TRgrbm+77gH
-jxS0cKDFH8alARYWsEWEx6nq6oMc9eM0F5wNnzKxkcGzdlS6/O98jAbGDxlpsrYnBMPHBaX0/PxWEYlb
-zQpv1XLf0i8g4kFDVPsBDVx1dbyGm6NchkiQcnCY1BC+4/o8vTPVbLLi4+O0bRulzI8QnF4vQtgPoNHg
-zkZ/bbq3ydlEs6tkPWxwZIVvVMPTvhzBVGBghf44UGnds85TtVfJGgcI/nQFG35Gh6Nq3nxqZR5+cBp6
-x5LmgQW7XWmovYTxd8tPAuwxZP+iLZWsNr0nah5Lcu+wWzZxvb0e7bVjSNtaG3VA9+rrSSltINUWM1vu
-xSGCN5f1o3IY96odWJcx9bBHV6aX17faaJfqo9s8t9E8lrE8G7qwjLI95jgX8OxcS5GMBMmKgJ16UjqX
-V4c4l8s3igZqPlD/C6NVW2xNhN6dQhaWSdPvs6aZ9fj1M4NPrbiwFB6GJ8U97VTbncsJqRNvOPcTBH0f
-3nK4r/fdr9tDDOinefnwlFCfjcJZv0yvWRIHloDL8pAzvTJZrtsFR+3IPfCT+JbdSEDHoWbWvCTR9A6x
-GrYrvqZCD+vAp2RY21OHdl08G3JEBtWqBv0zjfrUAkLRPzlcEfrKH5nacTTRw3K7YlpHj2syIAZOogTo
-Q6y2xw+R7DOP2t6DSO1jYhpqsOBQKxbiI6eRoXlP4U3gEDUvy+AOn4hT96GwfZMGTpzApuavqP/+Sr8N
-14iXsPqg7Sf56MJb+/prOeiql+EJxbOV+vUybUTdfwIGBYMBT83MmLoeeu+hPbl9Ce/OJkvVQIIVlTUw
-9mpe5e8eLhGGSOAdCmwKqZ7UOThrVqZXpkFQHZYUZp/ai2UALsBAo0jzOAE2QWYSamthQ3cbndzRDdTr
-a7n5Or3L4+Ln7nwQfSxOSx3SYXtZcFrGKGg1TaeL/QlNdXSofnO8l/FE0O3n6YWWtBrrjPWTzTY2Vibm
-LZpJNXdI5KSamwwsdkG6RHS4rKcMhWQi4hxzW/WUEWliEk3X0Of0TEDotTYoGGcIL1Ur+l2DabG8QAJh
-XJs3D8ByFqwS+8GzEVaIicAgPAlisRFWse/l7MaErVI/adhTSBg5qUr3jnVdf8Zmgp76UjdXeA8hC+w4
-oN8Srb+Bfa56t1fGFU+dYnMOzLlb25Ta9pxXVfNuG9gG68MN/jm4CxGrjRD6L7pJ7YNqDJPkyDBpSz9n
-B6LF9lsTHf5YA+/vttA8uFzuPx3Iz60O6O1WSp2lFrPEoSizXYsIwAJLP3re1riP/TN9hI8p20lvSzQc
-exxJEkII9LYsjZan8JHUXtLzljA+M0ZR7//nkVlGNH3vTt/p46dWBm6Lk1Hs6kH7mDQtjAmeKRniSDwt
-KEf62Cl0QCKwk4ysOIUoVyOY1kHlh6ON+lHJ0V0jwZ8XTxhhHDy+1rAbE/TJgODhc/u+tWGFIqpayrNx
-vD4wHRk5dAN4fhPXZ0O5QP82Z+4In7chhpbNM8IkIEcDWB5v9QzaLlbU2Atq3dTCZ27Qg6GjYs86PBOS
-iGxJMvIHaRRcuePkTUwpf4rN0HouNaAc+1dUi9qh55xcGcHsr2hkYhvinrRd8de+QoAjMGh0E/Dm3ZaU
-/undnSy3NPHA/U6VTOCNd0zK9lsfJR33a7Yd7twPWXcILVXRav07TQivlF7R+Vz6Bm2gLem/NhOH6Eo7
-9W202GQ2EoI2V7zog8h2fmbft7Ev0BacIoz8JVCHnexJiX1JdxOaAxQRTobPc9zr4QW///muDwt0D65o
-EQiNNUqpe0q4sFXqH+/WVqqLEAz4QvfPHiSp6xHpGbylRxuegLI9/VehVpvYYZbbnr0pd8mLLLdrAEVW
-ljk9av3unV7O+sYDRGyiioy0CE2xoFnCmcL5QRtYIKiqQG2PH0E4Fh3YtyUWBPa7Gwbc098ukaBvV2JN
-ouVUeY+Daz72oqribtGnPAQJ+g80+raCJls/hAEvwilschYtbdsbFKwV+TY0ngxcsZFiKwN6BMMRb2W8
-j4mtls1ek+HpnYoc2DD1piUS0v0yQtjT+MuEqQd92SypKok7w43EvWlsc5orM1/uFL3i7fyrGaXhEknz
-XPGeqfNAZgtM2s8rMrvAmFzRUJtGmTIS+xAO5wlDO+w4O7RtTdU37nOGdiQgthmtrdus+1zwKVz47ezo
-Ztm6kgVEraq3XQZeBO5EGgUqciwVE4INiFSGmp5N8P2lASvtdjTMw56pcj1V9rop1PhqY7KlZ7oJnn20
-KeUu2Lsm1M5ZEGdtClAeVZU0OyEQJhu61dAj0A6PuSyS0xnaOI5WXqq/OsqmWvsNbHZK9jSpqo2fQAym
-FJgjBV+HNL46SHU8wdwCDZ7l6YItA0aWAysV2FHEiS2SWGxt/cbhDoWDQjuaalSxcSVrN2lmLqHeat6B
-ARoX5h7V21hVB64oin+JOMxzoTSYCEQbLP8Ro6gfvA3OgD0UNN7LjZ1sVdk21qAPBRsndVEUMD1yNrvA
-J6k06GoECs51MJ3A1Q9p/dvJBQqlBXEBj29NYCu7ybOxFyh01XzDYx3NB6gmdPcm6Y7+uwEaq5xJoOnt
-WDur6wmCo+cQaIK6oz6ej7qmo77ky2xepA+FgsOIjPmQHdmo1lbR2uCwxsw3b+iQXlxUGhrFed6qwqWC
-342hcCXJu7Bm8NLaDgIu7XOY1jPN3C7HCQh7Z2pH1JroPZ9d4FpfToAnorn95MB5AvEmEDePn+kkHcug
-KLO9InBZZKJiniTBsejWOcxyycSGHmPh/Vv6WgPUJ24Q/kxB9PiNu2D4usjR9OOJltx8nkZxqjjS39yC
-6y3svR7SVghcsAhghRe4rCBxKpJDIJ/pQO4y8AJ3nETg+nscqLzmJ9ExqXToUy9w+5+6/M/wLltTRX+Z
-Wq/7dV63NUxy+/uOmdX16cXbt9dpeYQGqZ719sWNhQ66MnG6mqMw1VR72E7aMrUMoi1ZCwHwnpyi0toE
-jXk3rAgzcSgmD+OAfpAKBwPWaupAzNU4jZqo6CEdPS0NIkMGQa0MC2YDs6bPN1jVRTR0ByU683WVxbPg
-tpY0gvcHQnWGfUMbhIo2aEZiEx3odTTAV8w8nUOlf+fYL4wnpOwZcSviJRqmXPTuOdNnOE6K02EiTw65
-TaIu5UyXyw6lSp7c9warz2YDqmZy8AqD3zFhoHORugMgA+JzquEgTGZwS0zXUzmDLYQdaNogmhIEfMz0
-7b7iJFi2s/e6+a0x7q77Fso6qHsB330Q7BV+MShc7pIxeLb+E//Am5A77i3mPoVbXD++8D2y49DG+m+a
-lTaZXQA8fY/AjcMms4VKmvQkYFNMGbyApjbyFT3W/lkvds3JQdNqPhN7xhh9Pz7RWpjarRRJXUWxLPTr
-nWVmvX3+6vnTd32bVbDUX1w2EeF93JADaPFvObtpvDTiKnrSyO9ZF+mGTBn9tuFrajyhzTIuSkaR5Z86
-XQRLjhj2urs/WLIVX3uir4xqXpF/7DgbrCPQAKacIMhhjxsNwL6NsKLxQcs97HXAGyRXi7WRTxQCvtRh
-f+w4s7CxAx6djLMmcuIaN3ou7jZG7CShi1kbsN9xtkvuFiLPkuRdtveMYWKHA8iG9kf/OEmym1/SQpaK
-WhhyHyRvipq9eDm6Q23si8ZA9Yrs6QosW9B2qcUyIUcSDvvf7gF7p2GqHGffyOf/IVwVxr6ijXqxWFoS
-YtdOHO3oardWHIqBoR2ZoFXy9smr7g0sgyJ5+74MrjExa2HG9JTpWFcY+hlAwbgEJoY32DnObgaBl65a
-CvAVNiYxj3Wc4T6JMRCa8sE70QzX/tmORv5wVI87KkSNbMq0fDDGUXm0G+lHI7QgIENUQPiZqgYyhYJd
-PqP01ZJ76RobHu4JB4+Jgdw8wscungja09nGcaLVYt3u9HKloUAl4rW3WpPH8MqPHBMD40e6TRAyDM+t
-vlrC2fmTIUnu3B5zmCVJMfZQBM9NDgsbdpyIXmEjLMSYiIka8AA9vBfR9i9aD+yVXNdEkF2zsn0T9uU/
-oJaz2QJ76ki1qycRNr8vm0Svy/2/RIObUbpfLdZtKxA2bFTKRKp/xoBBb59kgQ3/AWwZE8f5YnEJj2Qw
-XqDkvIeHNCfSftKk4Sy6SGwDE9ug7fxNc9014ipTQ8v+FAl6l8yqEyeYJ4hHbIoEMUjYeGhdId5T0BZP
-bt9prVAHYQN00i2P44QDNIb1sgB1KRV1OZqKou3qu4i2wR1+Nn2HN2Zo//oKH/P3RB2qdjMUt38nZ6Z3
-/X+5NiauTyc2a1fG75Zt1kgvC2Fe0/L7ECI7qm8MCoaeK8rbfyvRAFfZf5bzdmHvGuFuVWnGGgc6/B30
-CXLaljcuDnwXf04IMf0kiNqHE7ci/eRHmOUWs3S77S63L/K13LYN8YcNjOvS9oBaMo/LA7ejeKSAGtWO
-EUwQPpI/BCB6Hckf2Kn8gZ2IH9rVAO+B/9eJ7D9ZR2Rr9hP6mS0G0u04RB8d56Obsus4YmWWYx3Revzw
-QqPiBcX9iyRjJWKrizWuiaAf3Wwvc6aagR/utcwLkJFA6ZdpiUY56jwFtNereyhk/jiSaanOGQpMXAyI
-HIeWM7HJs53ELn5gGnj/EK3+stYPsFWwkOXxwxgrtNSr+SLOZZh9bAs+xDqs4MVCK19xI4Vu/BW2vd8J
-veMJvwG77CPRnEfthiDIFmPHARMtMqYTmqD9Qy69qxyQqAlprh0gAhJ1knzU4sQ4SxvknOChx+XWBInW
-IPUy3R8mzpjq59jzvx3gfTHyH+6bEggUINw/8MM4l9eabFMp18vRIxZb8lUrgZ/pt8gPVLQRQvsRiG8R
-PhZVha6pySXF+EmMknzVd9AuET4OHtYosH9NC5oarftZEwn20Ova3DUBwiQidrtctqJAtJtn1z+5Jh/p
-f6gJVXvrlX4DREuUILuJgHZyZTJ8NDQJ2BI25bRN1EewFiVf00bhpZraytvD3iYlJvojyG5S9YnJ15dt
-uR3i5COUCHIWQZSQW6yosgTZsZ5Eicl/PZKO01lk0o65XaJ+47fQ0p4VpWw+hGriFmPv4uKRWA4Le189
-CvVYn715/fhQZk+z3T6RqnLZjinIs333rX9j7Cdt7H5SajXFdmCk015eHfSaa0slxBqcO8f6oQmWwEeB
-+ht5xvpaik7DMnHrmMhjuDmk9jb7oCt8sOfWfD5S0bwUfqtBDHBtsPiggFHwa/D+35Nh2ipYTUNxqcbU
-xi2zV9mNzJ+yQqrruVNizYzKynGMDqtJaIi2VcsMk3DAyuqnmxQRTmcXZKsDAqkNPpHxg0s9RPcBhJQz
-UFsTrRR6x5E0PNrgRfVt81Ti7MLvk48NbTMg+YxONcS1D8zl06r6F4sAwTma+VbVmZ2FIejw2KHMhAHO
-quJumOU7x2mz1eeozPnFrHX7H5/qBjUD4XDnicfHCBTw5ERcOShx0S+hBZX9Ao6TIFzjiWc52mskgTzt
-lgfQBaxpMFz73ioP8GdC4A0hKkzw2TiN0H/hTlKq9Wu+cQMaMwA9kri3keZWmEFcZUkp3eJRrzvSE/io
-WwCiNDeEew8/NSqou0QuJKTD2OWaFTxTDHPthwj7UVVJaFAPbnwbhWSh6LEJoVFPZmR04aZxCffqDu0G
-CMuUAV/AY1+P6okpZgUfOxSnMYNBb9dxEf97TSOR1E6zFJ5F0cQ1GAMl7FZxJTO5HKVS2/ZmgeNIzU8O
-s6ChockD+LYGK74eY0pDnSv6oacZAToXNCy9N30EOXHLP9Y+U40GvWdQatMeIGqjwlZw+Ka/w2rN3vT8
-XnMQqDT2NG+zQy4kPRvEt+qxCG+uZZ7HgbSmqtnYH7euJSXfsWv2FjwezDNeWf4kycSW/mM3n68/0eGO
-beVoMhPs7Qgbc2MSefCbSA0jO2neBPm4MzRma+TcmUt3FoXaUvHaTVEAYdsuho6rXw+8TEd1riCc+KTV
-VdtnY33NT9djuE90aFhPJD6at5JP1w0xciYHb1dOwkrb0rjnuGgK6gCspUzplNoB2FvNDii0NKwUp9Fy
-tvDuHqLAoHdFdly03dj4dBXArPiOZfj8IgTYF1QgTUchQOxGMaKwzUnfjVcuR/ZQz2oT5r6Bt3A4/Zyj
-2FYHkt+4t1ztfBNBM6FaD0p2dOHvOmXIrnk6fkP5arduIokkYyvSjbkJcpqsNmv/DpeqHA7Ihp7lCFrT
-nkYDsN80voPqRORjCUJcdGApYd5WkMkC/BKLw36f5SXYE9+355u5fb8VLqhjE6dWXHb1C3W8a6Af3Q8f
-yvwD2/EyLTrU2kQTI00ssdbz+JTxt3rhyaZiv81tq7YUfVuT+/Y8GBJGc/s+tttHZv4BqaEAz5uI7g/R
-JVqx80/r+fvgATZRY5ezNvTb++IBfV88wCsdAv39+XqOlh5VBe3VX+/t9YP3dvX+/uqv9/fXD97fr1Z/
-PVo/wHiJH7wv5njcDFrZ99fYRKV9sMTvv3wYxSSgD/vhbd9zKNmWacLTPowaVdqbwSN5ZyagwcRxBoK9
-qsT4eI2iS7S32zfcDQItaYNIpcEgInffQsC8TY8GUSJEE4SpDXO2gbQQ17itEUyrNrki7cwyWNvMMtGB
-if3/M7GMG5Hoc3GXAcRr94ajnv9r+1x1MIi7DEHjFTI/pa8bc8nvGEoIJ1uwbmnDefdAXKGKb7j7XIwD
-T15r77JrN6Ujtat+bI2eeitozMpp43KoBrG88Aa8rFh+4XV+hDDKs9Zd0Hjvde6DzU21/NL7qjbD6eHa
-YRCQ5k23DsnzpeYyvC/6SZpd8ew4TWX+7bvXr2z/jgeR+hdSuuJrI5doy60Wa7/XjBr60qjJdR0isNfW
-psadoCf8n9sf7JNZQYCXUYgzcGq8Y3idJMp0yueiL17qUjsXA5AA+t3+NaqI4UhSMHmefDTPTT+/aKgf
-40NombT7N68q9GVvL7qt977qJXMvHcrHBDn+zb3+uuuoKopmGUg8DWyyJrP3DlRLGZ1kncDRHTM79nev
-t956ej53v+X66Rnu/s2BZv+WU/XbbWGke9/K/ZbXJ3skyPHbqXn2bnhNLMC5vcaTWW6Q7RrLIQIzvqMc
-S7P0dpcdis6VWy/NP/uYG57S1y/fj2MaCuxzeBKC4xmlwsd8KuzhmQ7wVg+iWATmVdv+kksKuteQDkK1
-g61RFyWEjOLNpXQ7EcsxxUcw2594MFNQLfyPs0PRBDcOaNpEn2NgKIoYpVTC3ILVYl1VHBOVpIUswSro
-xldVApRjzZD1+zqzhur2FaMbLkUTG99DzU8SaiHDJ4YEiTCuRyP+F/FpmPsz114x2P+/1H7t3grECcRi
-1yfRDKYeRN/oXVktpmbeon3gYslWCxMau2f9Ahx/SLbkCh+v6FX7AMieIu44gX7FQiOMgcidpPTKHRK6
-VRUBleu+ESgkKdljP6TpiHlA0JfKU5So0f21qqmwpwYOWz3gabHVYn1HeJ53IyIUlFfNwqQWy3N2a2Vh
-G2xHEZsQ/MM47DdRc2xDWLQhoT3j3sRJCDUWg5cMTWmIJQP+AVfijpJxlGa5fMfySJa6+PABrEmOT6/h
-6ywAdk9iH155Ucu8xeSqbxLa2PEMEo1kJiRbrX5b4+5VwLBvxjQM3aVDrUFwqskwDEsOOMLj2hfBZ+4z
-PqZP+Ezh4BkaxZt7M1JBDiFJ71qcbmQel1aYZzt49HDIVmE/omCX+5gP40xtNDRv6KZxdkSbU1DFJwEK
-38rSYidcjDaTaR9fHEKzjX04NCfQYhwu9UkK0LbnZHgSsy5SdJcYx+MTbTy+xvIYk5SqLYqMDjUCC0/w
-TUlJRDbYH4AhpVeOg7Y0InoEjWfU2WT8udneGCOlHO3B13bvOOP2lvsejvf2dS+MxIhKVkTrY262IyCD
-hkwQtm0/xAAJwFfvqne57fVtoM5Rgf1oCOSDT8TVhVDQIW47aEagoFdT5o2MRC4rxm5n4M1LRd0YLqjl
-ZqR97N6S2pT29BCTgBQKI6tqxWf2mrcq/6parf0ps7LOng5xuuLrxsnyezbNX0UnBub/0s7MP+ukt0+4
-wRFbwsmBRGS/7nnSbZvIdNuRqUwbWGZKG3Ti2zElgguqKtC+jebxEBOdDuR1GkHHqaUJkUCxXCpJrR1R
-2CkYopH77zbSMrUsrSbqWS/sExanxEqz9Dxrn0vWN4J7vxFuBOM3Uzj29ST+BkMNNJDPYT0eFLTBOP9P
-9qgjlxFS+I2uOFK9zxa+1Cqgk+UTS0GPtYciKmAViR2HasEE6DHM2q9U4hpjEjiOMQ+O0zNdpLHRdiEd
-Y6MnVikKyWDst1o21exSUnVyUVRVXDUnGmt82BJB1J3nBUsUUoiXpuuEUwewIMJlBfZCUH495rrJEJ4K
-0C+baGAIqfRRMQi9DOYBExaHhbt1nMLdorE+P3ScUEcjCL2051pQnsgF+VAIZ44YyG2bU8kV8WEeLVPk
-2cZlAeJEe0aaJ03+pUCspfk72ZeVyqI0cXxP5GKdCYoZt3FTNe9Ytt6xneDxWRPfYnRvkTO15nqdAnEi
-F4VQB82ER/Q/MW7VwG5I+v9n7Gp6W+Wx8D6/gqAqxcMJF97Z4Z4bTdJ2Ne/MLF6pC4QqCE5IIeEzJLqB
-/z7yB4QmuZrZtASMMT7G9vl6ng3aVKTYsJeIiAUnEOQlprmRUO1btGmKa2/rU9PcCk5R5bdBTJWh8ZJY
-+6xhEaYSUIGm6neifq9VFqCxBYdQhrxSSTbKTNx2ioJj93sL6+BzkdOYiBXc7w6QqsPgLEFUYzgMshZZ
-3r0SARXm80PbOnDEg5mbDtTQwFn0Alcn4hc80Ng0BS9LgzUo9NUaPZ/AGRMjh9isCBSYGjbEc4fQ4gXP
-tDBNUnuFj8UiXoQe11YQMfKKueMvGvHPTYzGK/y2PUItTrTtkZiOW5iOG5uOJOIUbGAC9ixWRK8F5jRu
-24KSHPde7HuFP584UMxmOaI6wx+iKMESr6ez9PFS1UF9rFymWEEjbz4vfBDCcIuOuPFQydzhFS+q31ey
-UZWE3nwe95XEHXGN+byA+TyGrVWJIKe2VSyZ/a16yepArI9DQwq/d+pGa6OCFKZbK8oO9T93+139Z9aw
-ajZz7L8druzgVskaVlbMGPYro/EumZtw4DQfExhevtfrss5lMp8PA7F+RxiJ/8OCr6Jj+0GzCMUT9CCK
-+CvoEkcp0oERwWMejE71hRi52gaP9S6tRFp6UDKRHitsDXyk/w/YuQSGlFvPh0n8CCw5xrURQdrrt3s+
-84rXsV9Gse1Km9lDTCDp1QsVcuJFEEPK1Yn9VbFWgQ38hhEiNjzcdV4Rj0fJ+oG7h6Ubyxk7dg9dd2Mb
-Uxl+/T6Eb19wxP+pJqhRr0jm/8sJC2/tixgrvvq9eqGPJ0JPVhEajWkOyvXJWguw86McjCdCf/VH3wC1
-lV8xJFdWtYekamsv8vtwVn7Mq78FvIxAXPkSGMUb3AhBJJgMGnk5WgMZrAnf4kFxc1Isw0MORAGThzks
-X0HXL/S70Mi5/n/zCRE48nFTow0N2nDmP37xuUUELfev+yoJQj0fTrBCG5bwRpdYeSufrkyTKJX6DZdy
-NoelJT/rXsfux777Jk11BhdQ7cPJWvIf1lLgUJ+sJR4GK4ySzXV8Jt8yGWYzY5AaDFKDk/UV8Fl1cRIb
-KXfjrbjo4TSbnUUhlRl7Bl4/IYTWpnmj4auJyN0bK6j5aBlflh+v+zZFPCx4iTfiGie8fAXuUkXAFaEb
-WP8SA62DR40s29bIZcPIyBzJYA1HQuPhTUUy52sf2HKG7z2wCKwndzLm8bs6E7mQyltOFEbgnZ7wVy+2
-C+8CvrMZUKtPEBoMtqIXIQX+vRBZaY02rZDf4NU+LbH6Rkf2jpXoqWqKWErM6O3aYFDBO6HTk/Wxns1S
-EQcnahaiFZUDvyaSuOMb+cJGPniFNl1dmc5Mc0W4TGczg//j0o5kl13TX19HJ/4RRZCTEamZnGnvqcDe
-y2wvJt4/gzxXdKN8vuG3fdzRgT32ZOPU4fuTjzv797+/n30ck3EPky/A5AwFf7SwXWkurMhioo4MiU87
-9kkIjLo0O0hix6lNxoh1NMRQubnpDeakcvB/WFWoGv0h7DyGPKd67xDUu4bdbz8/7hxZId6FtEhc9fWN
-zX/atO20sep9PryHLWLJdxvDfkF5xaqDrTzIcnYYAZBccbk+P/UBWesPlZobkEu/E3D4WvvbIIdx56v9
-4xa3bXukYoaW1qU/fgZ39C+lpkKstWyjSbBx0U6NqwVZpmVpZGn/SRmfO475tgwiwQcxLulYtmXnJdOy
-UuMtKi2VT5FgqOIP8pLxvcFOwCHQSdK2RjJIsm11HRLeUb3pVdouLpck+xTRKk+7mu2tJFMaqtLMuk43
-E1O/XH6ocl2nE3j0SK6I0RA9xYDhU4ZNP2Vcbqt1WQeboS0qoYKIW/jrKkoZyhQp4V+Zsb3Nt4p2jU4I
-bayNwrmqrpTgGuukGP/fyKNhPZQdss4iphmjwapdtIE2OzB1ESNAtK7T1XOCKHoUViIQxoRX3NBfpOtV
-hOngM++EH3G9T5+1XYQKQ/SnZCk7/339Qxb+qZOO2i/BbGaMhrhqIF74MHf1z0+5Zjw5Wttqz8+E6h1M
-vpfnkuvLS+Y2h2gXHdZpVjFX7zRdee3CB9OSNFUpU0cocnCfhAv7NTT6b/6r4GP1r32e3n/3jURG7qTv
-SvyZ/DcAAP//16uOGK7pAAA=
-`,
-	},
-
-	"/js/knockstrap-1.3.2.min.js": {
-		name:    "knockstrap-1.3.2.min.js",
-		local:   "static/js/knockstrap-1.3.2.min.js",
-		size:    17645,
-		modtime: 1534412156,
-		compressed: `
-H4sIAAAAAAAC/8w7a3PkNnLfU5X/gMHdjYgThpLsSirFEa1aa/fKzvm8ztmVfNCqtkACJKHhEGMS1CMz
-/O8pAAQJPkbS7top74cViEej32h0Y87+ugCbQsSbSpZkBy78r/2vQCblLjg7S0idbwpWxpWfcpnVkc/F
-2d+7yWfgALwYga/OL75efXV+8e/gTSnZFvws2Y4UT/UGHIAF9fDw4IsdKypRlzHzRZme5TxmRcWqsy2X
-q/YD/PXsX/9lkdRFLLkoPIL2sK4YqGTJYwnXPPFgXVCW8IJRuAjl046JBGzEcjnXf/dfNSufEPE2Arft
-NcsrBhQcuwkM7fSS/Vrzki2XUER3LJb9CHvciVJWMyNbQeucIeK1iz2omSlqCRHu+u5+VZtDZLbfD7bv
-sDXoHw4L0/DJliKZleIBFOwBvCtLUXrwWtQ5BYWQIBcxkQzEdVmyQoJYFJI9SlCyhJWsiBmQAlhcAClo
-yw3ACyBKyko1ngtCQS9QiNZmb++mJwNb7G8xQU3j9cLB0Ug8dgjEXoT296QEcSgzXq1jn1dvcp4WjIZR
-38ax36L/E0lZGLlfOPalkCS/FnUhw8j5wLG/Iyn7mf+vWmKbaj571DPZo51TmdXEj8V2V0tGe+wNflFI
-/Lp4KMnOc/dDmLoDdg+0LpmsywL8g8jMjxnPveiMogZhReC3JN685RWJckaPbNkuvwjDcEC6Z2H8TZQP
-pKSvAzMCoYH2VLcwU6HwCp3VPPEWY3w9ZPhBxnith99kdYGaRoNtUZ2DPKLi1cBPFfCm0yJqtYhaLYpJ
-nnsUR2itupNwIs3TTmrU35JHBbayUvN6qdEhHmcRWl2gv0anFw1Oe6CdDj8H1ejClhdedBqvLjAdiAA1
-635JGHVNTP1I1AUl5VMYdU1MfcpLpndXs/sPTAfa3W5yRD0SUXqG3ze3OAoTD+E4TL0I4SyM1vE3YbbO
-Tk8R8Xd1lXnKuTAvwxnOwjAccQZ1ZEbfXCyXig9VxhPZropWFxj6vg8RwvHlkHQ129kgPu2nkgZh6lcs
-Z7GxfNfhDzEgflFvI1aiBlOldLyspCt2OlHkw2EI4aJd+gOZrpwo6njxWJq9cjLl/3CM9ko1WyRDgvWX
-EdXh0H7y6k0s+T0LF4u4IX4teV75dcF/rdn3dKzDJNzDTbUywysYnDdWAq5eWqGoXdzpmNxEt4eDp/6E
-5whHp6p1eto0HsL91kqXfyrFjpWSazWS4j9/xhNr2jfryCc5K2V4ckn5PYhzUlUh1F0gIZQBXkBAiSSr
-iBc0hHFVBUCdZxhItt3lRLIA8KJg5S/tJ/zmQ3F5Rvn9Nye4Bf69Gg9PLqNaSnV4mE3iXFSsBU55teXd
-zhCQkpNVxillRQhlWTP4zVLyLavWl2cGitpl52KmZBKALasqkjL4zeXZTiMQk1LUFcvDk8vFagU2YoA3
-5TGRoqws8mC1UoDVzLONaL8cxlhoK02yIhUAACzkRJSMxFkAuGTbyqzW4w4ENTTg6AOXWQD+vCNKJ/1Y
-FPeslKz0/qzmIAw0x/eAaA0LwJ95Qdmjh0AYgnPQWBT0NnybupCJlKVaWpVxoP7DgOQyUP+N1rkEikIS
-7tA2O8lyISY7HeKM5ro86bm9BwXZso7U6ubi1lfcsLz/kWwZ1vgHQBPfq9i7IuXFaOlwDE8xUP9IIln5
-T1ZQVg5XOwPYzHpD6cyUN5RiEDEl2X+yrbgfIeGOgKaX+IQTnTYNRrSZOIJwvgftoT62I1MOK+GVIn9W
-m12buFYhZWFtf2JLsRlWtmTN2V2pdgpPLonVipwlEvSqYWbAOYXMSpYEgFPQtMNVzikL4a5k951NVTvS
-uQoei2JlRi/P1IBmA1H/d9uXPM2+cP+CPcrj+5vRwf4uS77vnEl4cinyGY9hxwc4DX1Gt3vO5xD/cKI7
-JSlTJj+c9G6DU2wHNS0rKfSwdhagedmLXJ7lXBMl8lZJtoKSfHgs6K4V5SQX6fRUMP3XVU/EZGmnUfPO
-x0zKGKGshEN9tCpuBt2zZmw8z4GOBH06AlgNPQd27Nd4EoBECNkjM7Tv6eZm9pHth6BeRGDgTjqH0Jmp
-3vBbQZ+mpp3Jbe6Y9mTV3zQi/Wmp6NTe2CcmLjI+hWgjCuGfoCUykgWIpDJTviXlkM1xzuNNAAwEDAwO
-7cQfSMRypYBkevIe34WyhNS5hDOkqbDCAB3FFpo+s1NH7ndan/rYRIU2ITQf8NlIxUB7ZaSSfT11r7kl
-PPu6NTkViipc6s576J4jTqE1aeUWuairAPTXbkDbgFd1utHz6OB3+DuJSPQ9BLSSM5fMSUygfeREApGe
-2jnKXpFJp7Da2RynqdAMOkLPKKb/fJJaQK+kKrGzXybs8qzOe5Hygii1H8u17R7HkMre7HXRMfGc26Wb
-amXHV4m6LcEZBv728tcXs9eyysx9pQZM/NmUIf09+RhLuhkrrX3/Lyz5vUxiwJAjNwx9ax0yY0qyPe3t
-/fRVpBrnNCDVRhn9hd76699ZltbmXhDnH98jvCTXT7L6nLxs9L8ZS34grzZ7jddnMUM7TO0uS5GWrKqG
-caftXUWkhKAUOev7dJc+hO9JXrMtL0J4PughjyG8OD+HZmMH60o+5fpe+sCpojwi5f+oloqX+4i7h1SI
-BxVU6/ZMTE0KviWS6UjcxXhVyZLvGFVr26Za/eFEU6d6I1L+8rRjw6vHVL4fTqpyJYr8Sa1RYvpOhx0j
-2Y4XG3vWSHfC+cuxaXJ4xzFC6y48Jsw8waS7fP+sqz2Vr+gqUhu+DtJ9bcKsv+PbJJra3JmJ4z7rZcbM
-n8Nh32DTvJnAug2Pjuh1F2EYkjKtt+rC7uesSGV2dXTJDbkN7gWn3nMzwhg1ThbQJbVF/3x20xlo7WZz
-ZBHUNJiM+GpSHW5CUa8keS4e7Jx/soeSS16k4eLiGARlZlLoULdgD4D4KhS5Z8NJL671Y1FUsqxjKcpw
-fvLLMLZk0+1rlCmcSYMaLJ9XOi9CL9Prp0zOaqnVvBty+woohNIZKEqDeeKNRX/51bTKZ0kBvNK1vl0p
-7jllFKJ1pHXsFUiUOus0Sw1PvAV5ZteCbOe2pixnkr2WCbzLM7175NWcJSwWz4LiRSVJEVslnJtjstrK
-Q/Ei/Y4UNGdl1Was97zgMphUzfY2C/aWVTErKCnkt2Z9FSzOmwbXO6qu3N3CGFPMcIJTkxXPMMd3YeTF
-CG9C6iGch5tO9a6KOs+DF+jB27622C9FhwPss+G61B37haBMuf4wDGcL3z8KypZL9b//7od3/3j34y8f
-f3z/9t3hcIFQFm4xDzfWSbaZ72Djt60G37U6cq0OGs/svuJFIoBpUlKkrGw/qjqOWVUBiPCdUnB3UVcK
-QOORFTz1XGKfdppQtYlbDe8pXbxM6fX7fziU/geaKrJBqlULUGW6Zh4xUFeMAhXGACq2gOVM2yEQpYqx
-7nkpa5J33RCtM1twwFypk1PFCPY6Wb3FOh/9HJNHOeq8wYqi4BXsaRrilzoF3fmwDKd+XDIi2XXGc3pt
-av4e72s77FGygnr76ba9qr3XWfkK4dicIi3l71rCzYHBqDWL1p4W5zOm1pVP9m3GpQr2Khzp8ppA5xsh
-HueeWwbCccYYjhn2kjEpzl/bqkgwPRz2nAbPVdHhn2Bf3o18TlGDmqbB0+LPGOU+o/uHQBrrDHEQ+W3y
-TuukpsQposyxnRXykwmIp8j3p6TZdeB9x07UOFCuHehdeFyvfKtWeDM6Sc27AEOOilC2hqsBv4lvl0tP
-/Wmtsmt2HF8up30eR+hwIJPOrvxKw8ha1+Ic7xtMsYKCsNlRQ1RoHA4eHVWfQjLqQJg2ysMvuJXW1Im1
-A0Boa1XHcfuciKqbZVfJsG4OonXsc3rFfU5D1QpU60q1+uNGdaFA9/F2YFCM9tRFsitJQPPMIg/3LbSp
-FW+8O3/ciaHtgWjOjNSaaTeGTgXEKjMfKLOjdf1A13c43A3qhf0IHpcRneVGYqOVWptGFjFcYg760bJR
-ydEtLvar3cpiV1gcDb+hFA+Kiv24292p5uSc6Owbzh8YOcL7T7P4BscIZ/3xfufHVYXw50RTxnaZtv2k
-103mi/ZcWivn0LkALzGn1MRFZCzeROJxHOg5O2gn47wSitG+YvIXvmWiluOnYHq6b2pnapVCLw2Zf09y
-D+EsZL5Jc3nIz0hlgxx9uYdIG7OOfncepLyKchUxIxXuW/oSZHkpEvCmLMmT2Zd3VkhU5/cFZY/vB+tw
-itbZcrlSt1V+lZhXNSkKssPB9B0OiV/tch4zj+ML1Og3jomXoQafI+1o2pJFBcMwZD6RsvSgqRWKNM0Z
-RMsl8xNeUA/yYlfLwHIXovaaYm4NPVrUQ1OCZrxYC+dYNCaK/MmEZJp6FYuJqGLlPYlypoGa3EQFeAHU
-dRbEpFIMZ74oFHgVo0I8xhonhgv6qWXXu+iJV6EW/CJ8IyGkeTNr17CqVQTi8+p9R4Vm1Zfs1DPkVbzQ
-9nLc7nBi7CINB7Jcp2AszitXa5I5rblKjikNI3HmDXdn4epiEc5re4qpr2lDOPIo6k3N7DS0NqzOT2P/
-jIasQYH3POzEGDHCyVF4A0Igai253UPN0JssFgrYZEz1vYAwapr2zSL8+HEjPn60dTk9878V6R8/wvXE
-zd1AnQaEt+F+LFL9+iy6SW71uzxNt9n8rdiqi9J11XqpCKtZeHFhznP3aasS/EuLKV6cIw0ipLOe2DwF
-GN8AdK++GULc1f4DCLHSIx7VklXBvhQ5C2D7ZKDBw/K9DVadSuynBqoNdgv3A4DfCvr06eCGhfgBQFMa
-/6y7QLDvy9IBvNb1ZOwWwAP4fgO/PKzWeLox9fjaWGXiIch0tOUhX30dDiZROP900OPdqY1w/soQ3QTZ
-zwXWMcLxchl/TjxtVOhwWHBfiX7qd80E/SJeTWjj6wqQkg0jbKPALQt9iNbext+wp0iQkh4OTpKi+zFA
-P46Wy8yeNCTSr2kgXrUGuA33vUFwv2urOLT7GNtC7t35wy7shPVmBKGhuqs1boe7QjMHjfWZ+6bjSi0d
-jrmLzQhCgQqDnwlDzSOI+Rh0+xvFoIbNd37vVtQkvbO3UU11NipNLvyotQDoPnWdEyT373nFo5wdDjM/
-1HBG1Ul/ba/pXT86HLq2p52np45r1J4xN6SWIhFxXd0GphaOfP2pn+z/Pvh4hg/mJco8IxyULxQiG23/
-y+WAlC8O+ddz2T1mt1gudexvpOdcDSwhV1qSMFCEMDh/MXDeVDhnkn15H/wbtj/nCC7OcV9uDhbn2NZQ
-VVtXvPZaPgE0zxtwTtSHrnniiMSbAC5z8mst1hC31d4ALkvTM+OstavGmY37R27vOUp6n83UkT0TZHL3
-3bobbv7ytGM25OxnONFmH15CtI58Xv1Yb1nJ4yFID2mtHr6s1+7sTifoqcfRcUfgPGjB2Zw3uPs8b/CS
-Mh7hKitd1XD1wUi9k21ZjkSrOiaSpZ8jWVb+8YUavyRUFe/8ZvKkr5CnRi5VQfRO7MR9j87f2dNsAO23
-8yZpAhufKIfTxcDURL9vebUTFcnVWWPa7JrkuVILL8aDH5HoUAlG3T7qKk3thwcpq2QpniBq0PHSUq83
-Kny7G17M8Cb07myUpUKErth0NCjrpqPg+Bwl+r2OmnqQaN9t7XS2WUCHR2+JJH7KpBfjFLn1rDvf/Cyh
-GCRd4uUS8qJipdSHWhjrSoc6WUSSOIdzx0I8UbY5vOY1b4vw3bjUgamIddFVId3WOb59+p56ObJ3osiD
-f4Knub16Vh500GEhnxE0TkJdMb4meVyr3ej7JKmY9Db+Liex3uVwMC/PIdZTfxIVN6qDqS9qycyTkv7z
-O6ZmK3fAfLLb5U8/WUhegufgomadHw5ePp/LbXFdwb5CZMVXGfHhHGE+jJHaRcCKzBUNLpRG2nRk+w6H
-0xCenOanJxB0PwbY+Jnc5qE6VC1neeXBoD3JlZkUHmoU88Usd9fianQ7EVat8QYFHu+MbPNpFDjm6xlK
-9C9ariL3CwW8u9MjXxTMM6+UIT65GT70tXBvT4bBVOcC2lAFNehIvGIfNY1v0HYAmlMJmr/mVY+KUNrX
-QsHioq0pQmwfGQXq0vZSXtRkON1EYxYeR68/rZzzbd/gDKf67ILmN3B90JoibnI6YYK5b19PPf+TVgeZ
-U/gX2Dg/mG7BdzFjNzVtM0eTq173zKpLscWkACSO2U6a9JqBWZms4x2LZWUybtC8h2rHwc66zRO0/mRK
-LHqGnqYd1mB0af9VP07mpjbc/RjzavDobQVPowDCZsZvOkrEPyvCYv19K3tdzn9Ox0tCuZjN1L86X6pB
-fEayFLb1hJl0sQbpOATyXH0gDiOPdPUBW76ITWpxHfe5f2KS/4cD8xKTgUcv5GMH5/3hACFOtY2a++KJ
-RvbGmBI8OU38kulzwDuDZyk++fABnqDTE3h7gtapfU/msTDtHBhmvnK6SkIeGj36aAsYrpz7znSc61Q3
-wMBjDnbQd0AcA/1sbnVxccQxmoTkM/UddZ2c0Z8ZVwANrC9VH3MCuPX6yN+V7J4V8q3xjp6JJ2KnroXW
-zFvEs1qgU7gv5V+7LPIgbXuMZSKXfPe7B7vtPibYbT9eDnZH588wzE3Do7FqYupv093X2ThGyLoYIUUB
-61BLUdM0aP1/AQAA//+BYYzv7UQAAA==
-`,
-	},
-
-	"/js/wr-0.0.1.js": {
-		name:    "wr-0.0.1.js",
-		local:   "static/js/wr-0.0.1.js",
-		size:    4979,
-		modtime: 1534412156,
-		compressed: `
-H4sIAAAAAAAC/5RYbXPjthH+rl+xVS4WeZIpynZsxz5dJnZyyc30Lp27XGc6tjsDkSsRIxBgAdCymnN/
-e2cBvunNbfRBpoFnF7sPHuyCGo9hwR9RApPAtGZrUHOYC8WsAZsxCyxNoSzAKvjPJI5HoNGWWhpgkCit
-0RRKplwuvHFvPCZ7Lq2BVYYaAVmS0ZDNsHbLNIJWpUwxJbcMVpkSCLLMZ6hHYMok80vbDMmhVZYJWHEh
-YIaATyyxYg2TOI7g/RxyLuEtxCNCUxZr4LIoLSw0MouaPEmIYVZaWHGbkUPWLK/RlMKCQGM8kLxxA1wa
-iywFLhONzPhAcy5HwGQKNlMGQTC9QO0CrC0psw1WxBo0pmWCKQQ24wZytoaZRrYE7jjR6IysUpAz6fib
-oVArchdGvUemoUCdoLSfXMgapjAvZWK5koHnc+Sw8EcPAIAMkjIvBUwhvm6GZsyg4BI3R9vstoatgSnc
-PfihudIQuHEHAw5vqp2MBMqFza6BD4d1APTxAQynFeyOV56auU8V/VP4wGwWud0I3EzYIqtQYLppc9wk
-00L5HIJKB3B01C5bD5CbN12aGsOGgeHUuzgmcCcKj6Ioci7b4edeZ9JERWmyYNOwQ3k3fg947vXquNsQ
-3kLcjY/yV49ux+8eNmlxJ+I3Pxe3U/t2yoV3YJ/aEGq29lBEHwqjynGLGvq00QynUPnaRD13eNtIJMUd
-BR5KxMXwQiKVXN7LFJ9g6uA7cVSYvzM6Hj7SymIPsNCqUJoOmgeT1bhNdtegTqaWdYJcBB0vr1u5bQts
-DkFLxbD19LZjsmdfOiu2MjpuWT20C7Vu2/TbFFvzTetOfNM9kOeurn2PcEtc956ve729XcbVGd9jNIIp
-i0JVlXaGddVjCzQjMAkTSOUyB6Oqiuvaw9qZshpdNxq/1iMTJW7U0M/kZ6OEUgwjyNlTt4BWferlEvjS
-wSJoKV3UKew5EzTfzAYNckxNLYTXFE/nMPha646fB4Z1Eelw7VEN2x9dMw0mJ6dnYWTVT6VmLt+w07+N
-1dS4EyUfUVt6JO4MJkqmxu2H4Jj6DgwMsjJnEjSylM0EEic5szDXKoeUrY3vkELwysEIzJIXBbktpcQE
-jWHaNbiCaWt6PsKo0Moquy6wE2VngyCoeSWqqIW2fcqtWp21uVBKB0Th5flZHIctKtuGfOshMIbT8w1k
-voskBIzhvAszB2DfelgbXklKGwyum1LvAt6q8oSiA0VTQxikMOhuLlllB0wywme7+PwAPid8vos/FJKL
-x+ziXV6U2Lbic7PZ0IMtbuAYDKl7Eje0d8Nz6+VmsEfcaakPKptZ/BOqDlCwgqqM4TJxt8svkj8BFirJ
-wkbzpOW9ct+rWmbx/1asg0pcgYs7fd3hwolVOdEQLlqg/VWV2gQhvIFJDD/AIB5Qc9iavNoe6Siay463
-D1yWFg/6a6evdsc68sek4/Oz5/WQz3b6anfsemOLq9l3pRD/QKYD2tzPbi+DMDLlzFgdHJ+EMIT+uA9D
-Ok1VjEraLKCJSTtbT1byGEIfaNjxO4T+Ff1D7NTPBpNGYbdeOvDhBrgkKfxyA0rD7zckf1ZQR9ecWaee
-YOZaopKQWVtcjcfGsmRJl4+5UKsoUfmYjU/ii9OT+PvJ+Pziu/jyNNxVUT57//PtXhE5yvm/sdKSOz5n
-l99dnHfu65sFyT8LtQjILoRxOzCJT85C2CQ+8O4rVKFWDjUCHgJtwTv+hGlw4s4tHVCg7b0b3AxGMFi6
-7w/u+xf3/fvN4MF1uorKhBXcMkELuBdArg29bVl6MVPz5rQ6Ji1bovT95AUuJ/HJeXx50VDpJdKhsl3y
-Ha32V79Yp+XXzFb5E6tRkjH9ow1iyvhLUaC+Zcbrxk0bwRMMJuF179mltULI2CNdPGbcv/1mTKZ0taAm
-yJYIM6WssZrRi7MSlhcGVkov3SsoLKVKlqq0+9I2h/I+v7w4jyenTeJLFVWL/+rXNlG1FEyrDLnk9qpN
-HAXmKO3IX4x+TKglK71dxYVK3PV4qaLScmGiUq40K36bGdSPVAqDDfMgDEcb90RV0GLUCv54vm5v+407
-fLIo06CCjeBwHlGF6fSKg15c1GFnvVd1umHtLmjd7fpLVf5RpfgTN4UyTEQsTf0z3jIhZixZtvTtKOmF
-NfspGqvVut9J4rm+v3niqrCuOs4KwRLn6Ar6VhX9lmGr+WKB+gr6GQmkX/XK6rhRtwIG/ypRr+maxXK0
-qEf/W13fx5PJ2VmlLXdjXqD9W+3gZv2R5d0eF0iWY7dClZoks+IyVauI9oJQUaZx7lOV3p7+RBpdesH4
-7v7u/uFhvBhB//7+1VG/02Q0LtxbHHXKT7j4+akI+nc/HD1QqXa+htAPpsHdP4++eXgdfj36+s3XV2G/
-o0T/ww6p0LmK8AmToNT17wt0j/lLhakvDyBLIXan704eGkR9mat7FiYqxS+f3t+qvFASpQ1aozbP+6HL
-Efph6ArjfwMAAP//4MqlMHMTAAA=
-`,
-	},
-
-	"/status.html": {
-		name:    "status.html",
-		local:   "static/status.html",
-		size:    66335,
-		modtime: 1550137006,
-		compressed: `
-H4sIAAAAAAAC/+x9a3cbN7Lgd/+KCvfekLRJSkomu7mSqRxbcma8sSdeOzOz9+jozAXZRRJWN8AB0KS5
-uf7vewD0k+wHutWUlZz4gyWRQKFeKBQKQNXzr65/vvrlP9+9gpUK/Msnz/UP8AlbTnvIepdPAACer5B4
-9lfzZ4CKwHxFhEQ17YVqMf6+l/laUeXj5T/ewwdFVCifn9gPnqQtvhqP4eP/CVHsYMEFbIigPJQQKupT
-tRsBYR4wRA89mO1gxrmSSpD15KOE8TgzkpwLulYgxXzaO/koTz7+S8McfzP5ZvKnSUDZ5KPsXT4/sc32
-EXgZgzU4rAVKZIooypkZX6qdT9kyP6ChfKXUeoz/Culm2vu/47+9GF/xYE0UnfnYgzlnCpma9l6/mqK3
-xN5+b0YCnPY2FLdrLlSmw5Z6ajX1cEPnODZ/jIAyqijxx3JOfJyeZYH5lN2BQH/a05iiXCGqHqwELqa9
-k7mUJwnbxt9Ovp38L8OPuZS9Cv4Vdali4U+Mz+94qAwHcYNMwYow75Bv+wPdRR3H307+NDl1G8fKSnEI
-yB3CLFSKM2lEpVaULSVsubiDb8ZbsoMZqi0ig3gc0yyhzgE3y4WzybeTb2qx+8ADBL4AHgrgWwZLZCiI
-Dyv01yhgEbK51qoa3d2K8enkdHK2N5S7vBMAqZCfn6Qz9/mMe7ss6h7dAPWmPUY2PZj7RErz+4wIsD/G
-Hi5I6KseCO6j+ZIuzQTJ6FACKoKg1ZlQhmKvzX67aAiNX2Fby6M1YXsdZoIwr5e1LrpRwVgnHt3soZn/
-KPrzkCHSAO7VUbTXHoXgQvbAI4qMZ5R5096CCyTz1TlkWtSwhfgoFJj/xx5hS60/xEOgrIxH6+yICj+p
-c/g3/YlWonUTvhQTNyOeRLHBMtIy33dNWabzmjD0wfw/3hLBKFuW9CrsadSsuo/+98EQUtkkmfR3HOji
-HN4JPvMxgOkUer3cBK+EEMboeVwp9HKsVZz7iq7P4VcwC+c59F8vtI2TQCV8DKUCAgqDNRdE7PT6wXCu
-6IaqHVApQxzZxgFKSZYIW+r7sORAjGHcAVUS/cWkD597lwFdrhTMEDwk3vOT8NKN+JM77kRrllNfPQyr
-flmhQNgSCQTW0Yih1AuSYYrV1Qm8VpYvjBvyQ4meXlpEyICrFQr4yGdyAq/ZBqXSVg+BKggIC4nv74Au
-YMdD8OkdjmCGejbAiiplx0H4r580cKr+K1qnLLepBMbB50b5Q0lmPnbH84KJXT0n9HpQMyH+SgI8j8zw
-gZXRX5qVStvf5zNRDer1dSmg19cNwLwrB/POHcz9pvAbLpXx28hclaJzTRROFNc/BsMEs3pZW4UBtVvj
-tGf/SJaimWIwUyy2n+vQ98dCT+HcrJj7dH53Dv8mOFeTOWcLKoJrJJ41b73L16ovQaBRZDvv7TAOLHOZ
-+Pec9HEPZHMeMoUCvVIeR23d5V4yAJDfohwjG9Oh+CpsSMlXru5ERieidUkOhhMf2VKt4BLOCtFy4mHk
-Djgx0aMyoFK+jTDoXV7bD+CF7xezsZRtdRSdFlN0b4dI+2TxeMUeWfJtg8XA2bW6j3tlXKz5Cr3QRwGv
-tavi5gJkWH2lp+xgWKoyZf9uFlRIBQL1prt6wv+oWxbP+lt3fJ0sZfWS3XrZTvdOB8S9lctm1vK9A8fe
-EMuwwbCNobyndDUVMZKlGBrACU6gaICyY1f3uLYqMVWO1r7GGezEzmfZc7h5NFGKKKp1Dmenp/9+kfBj
-i74P+r+xDEDx9TggYllo97KgbKNzOAUSKn5RZiVX3x10uIA18bSFOofT3uVrNufB2keF+QjDjHhL7B0q
-D2ULX8tqorgifjp9Tlbf1e9cM9RlIWttz8M1an/qarQFXwqUspcndTzjSvHgvBJOGazxjAjI/jGWStC1
-nvp6e4n57+KlIooNxd/NiMjRadDT+7NIDxKaPfTJ7t1cz/Zn0P93sz9qZCvykNCz/HM3G8WGYh9qajOi
-D558Mev/hcS0RuYhUx2JKoLWubAiuFlxRR/9xgRG2YK3lpZA4nUzqQykjqVkYKYS0vKhbPno5dNeGiHr
-RhYh03O4a2lYqKk8og9+Y/PF7pxay8jnshvTpgF1LCENMhWPnwk6PUIZ3VMOs1B0Y7hmoaCdOwMWaCoL
-+/eDSeG4YZmnT5+aMPgOFVDtFwfI1B51WR0QfAvWz6xx25PzM3/8SY6/K/PXF1wEOR0JZwFV5yDwXyFK
-9R7XfxY8XDt6xpStQzVe1vQ4OF3MdBsTz+Oxt674cqkVOjppiD5NjgSnPbMdt6cP094rplAAYUC150EX
-FAUoDsSXHCSiORqwZ4HAF0B8H+Y8CAjzJBDPQw+2VK1ArYjKQJj0LtM/XHbVzw0x0U5Ua3Ky79KsNsgL
-7ufm5Yb4IWqW1/K6knMzxXruW+X9YGh82mwRt2rQu8wNtvR36xWdcwbJb+O1T3bjORVzP3Mc4bhLrmZm
-5bzTvGxz7Kz/He6YM6ZMcqHIzMdY8V3CiivRaG9eeEZdMKz+bBDfXxj4IzGEX0GgCgUDf0I9uAShf/wA
-Z3AO4zP4PKzZw9eGA6pin43iAG6xgDLLnzH2TjEC19BAg/CAW1Sg68hAp9tOMBEtYi5GFTgGRFAyNqYn
-oGzaO819Qj5Ne2enp5Xuw2EQYQRxEG1NBDI1kSu+fY9rY5+u7RZ+BEQpocH00/EY3/ZzAF08kP2p2y4U
-UeGBtI5CNI9f1juCvzHVKApc1KhH1KVSQXJg2ylJuyBIpZrcI/7xeFWFsgU/tp4chkwqdeS9bl6hHxlw
-bXSjTdilQi9aRlwelUYcW/57QZpq6dsQSZX8Y3CtpN8q0FMl/7YxnsdrE6KT8iNrxUFYqFIt3nBZtWak
-wNooRYvAUoVG3COm9GV14mHkfhCGqpT7SxMGqpB8Cq6N5FuFsipk3zKK9RjkfrTtAyrck3fV3iBp3XJz
-gKrjzYEGmNscoHr8m4NwPkcpjz2V4zN+9+l8FfWo0IE80DZaEEPoTg1iiKkexJ98EUVwi2U/qeNVEpfy
-UBHqy/oYemFUxV5sKw+G5K7fSGmEnrsL1z83D00QplPoR7vvPvz3f+c+jbZa/VHcWe9ccj2NJ55+vxY0
-IGKXb2J9s7SRNX25NtZk742vV/G0VzS9ct1ihXA8V7nH/T6nqFvB/azAmLGqqFlZNJBvUCx8vh1/Ojfx
-wF6TCRUQ3798TsvCgFdb7yWRmbByabNEw+bc5+IclgJ3mXAg1b+awdzoc7O3+7blLQ+Zks1sSjeczHMz
-MHiUXsazaLbnThsOHXOlS65lwh3uNsSXrvPEa0Kwpy5fKIXBWsnnJ55q0tM7lEEMSkvB85y10j8SZa8+
-rXGu0IP3L952QF0M7v2Lt5Ng9vrVlb2Q+pgI/YUG2CGlGtxE8etQmAeaR6M3Y23e2/NZ9K6pvGvuzDTh
-XMy9ZEjQYzZjX8TCMhueoyZ1pf780p2NLVjpapZa6doVF9iFrTBwjq9PbzmjiotrPr9DAV9Nod8/vkZF
-g4IdtVONytGTWe0eizplWP8jof57JJKzI3M8M+ah49to7KwQ3wncmAQSmo5QYAsxNuVeOUVfdUFRJIwF
-F1+CpiIjkKpI72F0uLESv/pE9cpwdJOhx4E597CVtShawqnS4I7H1yJO6RG1rp62UA+/nVJ/UN7PoWrO
-tdjONu50OEE1Aq0mZeHVp0wEq+wVj1zx7QflTfRXA5OXYQR9i0d/2Lv82lcXusnXS3Xh+mCq07lexKav
-umCUpoxxhpqyhyep2UxqPpvuOw9eCfFl58ErIR7FPHglxOOeB/dl1O97HrRCrtWq+w7JXfPoQOmiq8G1
-jA7cb+3VA7faMN/L5BjutdszV7JQg2zLw8esbR8UEQq9jpQtgpZ7HH1EbWvl1DKvM3INrMdM7D+I76vG
-8bdSemNwreNvD0T21bu/dUh1BO2xE/0XLlVHFP8lujvzCCmE1+86JNJmUnqY9dCMd613og2Sgt17PbQ8
-u+5wNbR0/J7WwHe0qwXhnX1O8RiDRl/FYaOvv4ZBEpLsCTSJ4rxe7qS9F9+nzH9q7tQN/3BKHtM6XRRo
-toJqGZM91rrfffS5azLf0A3GpNoMPw9P7B+Owh+Owh+Owh+OwuNwFNIVJbpSbT9sHCts6QW0ix63ihw/
-sjDv41SNNzSgyr6ZPr74M4M9Yh3IYPl7lfp1/E7++DJPhnrEEk9w/B3L29zynlN8GJEnoz1uqSdo/q4E
-3/heJ9s0vmnX9Hp1c/G8Ypv7SaXpnb/m+Xy3D3Bj5y88QLhaEbbsLHqiQUYQH6vH+hJXZEN5KB7AXKVj
-PWJjlSL5e12jflYrFNFNZvkQ17ElD8UczeVpKkzisMesAIY9vxHZO4Bt91BlwbkyD6mRiAX91OIJ4wca
-UJ802+o+K3sMFAFLb9zb8itxXrTW9xvtTv1+Nx1NNjZJAgSM73zCoISO7C1OQ8jQ1BwT6UXehb3Ie7wA
-zr2ugKcxjTjnUDP7cZxyF+8x4Bs0eZt6l/YPt9RuHfPEJlJ5PBx5h8z7ogxJMw49JjVZf1kliU8HHwFH
-fqK+byvEfBFWND+Cil6P/rKiEj7yGZD1GomQpkDRCGahsvW75jz0PVOwLESTWjNTCc0UPwMZzldgyn8x
-VFsu7ihbxrb3AujCJOHUI1AJZK5sPa8FZTgCGhUFE7hBoaJ6YCZZmTSUkVDxgCg6N322K2QGWFxmjEpY
-0E/oTeLXrE4FNo6sCNdIvN7llf0Drp3LPXWsEHGgvPHb5JQBNsdolvaGbps7gx0Nzo+E+u0sTiOcomQB
-DkgpYZZJJXbN0fmCL6rrUkbUDddBEmRiUpxCwD1SkGtiP2mqaXYOvx4MuaGSznw8j+C91e3+bj8bHTT2
-KPH58krKc+gbiGMZ9A+b2RKp5/CrwUD/9MkM/dwYfzFt4DN8Puw/495O92KmmF8/0+sl93a/YLD2icL+
-KAJvv7+Osm4UwLMbiGKIP5rv6mDmQH428ZMDQUXlcdMkxicrFfg9U/+qhISi1LO5dEp6QgyG5gg5mjLF
-BumFQFPeUYbRL1vCzHJQ4vtbfDLlhVZYnqwlV4goSf8cJX7GbOboXmlWvzhLcwSm96TOEGP92ziTdXpF
-vMxep2R83eAqu9UxOx29xKJemucklFiK/CL3jtCi/8OTdtM+dzzrQGKLceq/3NeuaSPtenBVASIwW/zx
-h4YkF7k0pXy4015oufyslzRQtmSr9rxmCMTmuI2rqmpC54EnQSq+BvyE81BRtrwAslAoQI+gHbQtoQpC
-pqgf+3dSqyIPMEpzNixNMdJOxMKs+vXEmXbEB75IJRhNtQ3uBTuipK2aHm5cy8ByRVIfmdJuKqF+C0IO
-q4k3MbF5m16T7D/x03r1c3buWO2tKycpCKh6YejK3U9QIsThCOIceVbGkzlZU0V8+v/Q1AN8g0qhsInE
-gPh+v+eQY/7IiC+ILxtiflaLdyOrG0twOv2yImzGifuzwGknEZczMNRE1fwi17F3eUXYHCv25oW+azyL
-D91XqTweqhMUojsXViqvqf/qL0cQebLKa+LKxmO5+LFx1zlnCpkynX8O1TpUul+Jb3nIMp8GVC3tDQ6D
-cwcs85fNOdaETX1zrwbsRYu+k7uPbFPu6/vLvxMhGzDNi1Ildscy79gsS64o7Lrjm9eCb+nlkc5Yh+uH
-4h3FTtiG64Z8m6Vn2F1xbYarI3MtPWfugGczXDXkmfUpu2KXgXZkhplzWSg8Te6Ag4aChjxEtumMgzFy
-x+PfK7ahgjPNMPg7EZTM/E7mK7JNJd+cdxNFo5RtJIpqExk3r2xHUbzzjbpUlbpu4GOtxP4n0TE6NWjq
-X4vosRu1r+d8vbuAb07P/uf4m9Oz7+HPyPTG9D1KJGK+sheIM+cGeyhZ+Omn+1pbwPqPZEPsp3to3fEJ
-X2v/WU48XKD429ojCiVMzTboIk/kyQlsKG4D7qFvjrA9Ktc+2cUnImH+eD4uFGXC/qH8O8XtW911MCya
-HkSARH+hR15ReZgbQ385UfwOGUxhieodESRAheLl7q8kwEHPfNcblvQk2oZQtozKrU0N5TOJYqNnxwsh
-yG5Q1tf2QSG4aNZxRjzzblA0HDAu2d+sVxzc2e9V2iFKpRznu4Yp9PvVTaMDnNp2P78o+X5LfP8XGqDV
-M+HWSvOB4RZqyCcKrT88hW+/O714UsalgG/wJfE+GMnANNXTAfWKVLNAnBGUtBaa/byst/4XlUmzDSev
-r/UmmXrFOWA+F9D4uZKeqCx8jppALivJibXskJj5Cr3XUoboQlDSePJWLjVVgVzen6y41iZMS1BIkm+f
-72n76XCCnxQyb/ArJDpxvq8jn4ejMrBx9u6OAduU310DjTILdgzWpBDvGGaUq7xzcdkKbUdTgyPAjotC
-HUEZjgA1KldzBHU4Bg+47/3TVErsn8Nplc78c86DdahQtzu0ShfVVummb8e4tWttBMpLTWiZ4aQLGOxB
-ymNz67SG5ACkJN+W2N3im8Pa5TL9YApFOKHXvzVx4oMvYwtZ+LW1c8VfRdaq8Etjcwq/iSzHbdHSHzPV
-EnIJp1X80xQHoa/o2qdm6T87PYUTy4TybGwnJ7BFkHPimytG//G9uWi04dQDArNwCZTBjHMllSDrpLZJ
-FbiZ3kVtV3S+ii8YydBXGo5aIZjLLOOAS6UbVsFZ+HR+h8IcEIUK+ALwE5UK2RxHgBtzH4mHy5XGn+EG
-K4FZDnLtE52dnlby0PDCgymsUcyRqQ/6bzG4GWSY+7RCp4YjqGma0bC6xom+1TZMta+uaayLde1SzRze
-juA/vh9eVPJN8JB5Wca9Nx+IgWXoCL6pAFDETm1AbwcR2JvT2ybdM+tbCuKsAYhkGUu7f9Oku12t0s7f
-NugcL0pp7z816B2vPWnv78p6l9jOchOsN7Dl9iSy4CUtPjuufeV7m/gN6hRubmu2iW84vzObvl/LVruD
-EtDN9qN0ybjAaIAnBRZHooJwbWzeFmeSz+/wsOycNu5byjy+nfwDZx9MI5hOp6AFt6AMq/dsmb37ZB3K
-1aD3nzwUMBN8K1GAx1GaavcyXK+5UJCMIYtCCZ8BfYlV423jzWoCaNDbSnl+ctKDZ+DzuUmVMllxqRgJ
-EJ5B7zz3jcHiGfROLOb/3MofTGRj2osXRvNnibpGOEw442sTKan1SLK9pFa9//3h579OpNKrCl3sBr/G
-pRDOoTcPhTB3vD8Py6ZLHVpzn8v8NrUWsUMRXnHG0HZX3OhPQBhZooAVkTBDZKaU4Ve9YdWq/vTpU70w
-2ivEa+775t6SEjtz0xfHKLX2U2lv18yTMSeTSYmpqCY9KNijV+6wP0quRWgEsiZC4gAnHlFkWNpDTxbd
-a7Ii8ucteyf4GoXaDfo/Ch6Y4E1/WDViPDFNmIeFwQyFtDdT5vZxY2VPsYSpQfqmH5uM/m1lD7MoRuGn
-yoaaMGGiC71nxPef9eqosMY2CWzl7HV14t1ojieeet5e7nNWLIdtUEks9U3BGDdieXvrhGSjgX91uszb
-p3qPLpYjt9bHicI8WFTmQaI0DxS1eYgozsNEdYq0DNXxh0nq4h2fnLKgVdP5cC8oFYEod02+V//y4JK7
-/t2Xk1EBz/YgMlVA74OHOTnZBxC52I5AHKJfLaJhjk5e0bLTOlBW6AAkQBvEzEp2YCms2vCZ45awKry2
-h3kSWct+ng+qpd9k42mZT3OhtPTzTBQt/TANU+yNaa3q/ueJGSyNuLWOwHUTkWsRoWsC6zCYtx+xawKt
-VXCvTbCvCbC9uKBr8K99MLBwBhyE10rmQ0W78uhf4VypaFUa8yuaR5WYJ7OqolV2jtXGDlvHEhupRDxl
-zBtWC1NvV7XqN4OjiH2EEasTEAWE7WDNKVMN56KiAY7A40B8Hzyc23tgGnpor6o0mkJUSHURxXsE2te/
-VMbvX1borxvBs/ySPECgTCrC5ij1xEyn6qiR3QkVUAWBNhFlQYYydbjDnYn6pb7laM9LHGX8vVHiuY1S
-H2yUelOjrF80yns4t+56uuACBho7ClM4vQAKz+H7C6DPnjVZIw6Wf03rDb29NW9F4gguvW0KM+enJDAz
-8JoV6Pn8pPuWx2fg898vAx39tEJPsDqK3yyq32GUvzrqb6OjMT0O3C+JPR0EqSY+sqVawRjOHJDSlix6
-9ckXwEMBvgE9Sp4fguRCARceChdoQSiVMdo2CGnTP2wxeoa7wiC+iFgTn4yjm1wovUBKroEQX3LQjDML
-IAPKEqvpAmxvp+bG8oODlUaSq9FrbS4WggcjULyyodxSNV8NbMA2DRA7mYE5kZgJ/jnNEo1U8V7IbZbN
-BJK7C2fUkoBhW+QSB/QI6EVhxnaoRT7vMdCKA5MtEYsd7SOgZoOZ7fCyrv0RkIqjn+3QircTnSFWYxnS
-y0fmZHb/KGP/5GYIX3+dPcm92W9wWwzhF54YkjoAN3s9buEyPkG64qGeey7G6OQEorNm4833Fe+DEoRJ
-qihno2Q1UivKltIFHBEYb7LNKmVOBs1iYeYekLl56oqe9tCc8FNuK4M7o8Z7jKpXoj3xuwwynbqHc+yG
-oSEZ7uGln2cfca4m2s2spmIYeytNkHclwDVCeL8Wzqd7uSU8M+/ciG6ziOt/it9nGW9gZNsv54VoNlzQ
-WyHaZGEvQLLR0t4OwUZLfBGKzRb5Vkg2WOwLMGyy3LdCr9GyX4Bgs4W/FYrpUeaTRiGCpUmH5H7HooLK
-NMR5cYTQSAsTEp0hfzGGJJHhL8iPz/dxIEsP4Ey4BH6AMziH04taJ1R7wi681FtZhtvIcdY/BkMYt/F7
-YiiXDXwCM17U0SGY4rxoJ2GIABUNUGZ8VQlzwoAIQTexA+oKzvipF7DFvu+Dj1F+TM4QlhzUSvBwuRpp
-P9YVYEDEnZZq4lojrAVuKA+zGLtCM9kxTSIxTTFl4BOFwtn7+wqabFyazNNKd6/kdmz7mVrrgxfTlo3O
-dEbczQHsW3jWeFfRWPVb4dUOrSfu8/x0eH/b2dZ0OlhMxV3ErvhAcXOYn99DX7REPHMVsvBWqeON0ub3
-QpNpkrwnhq+/BnsBtOjpsmOUQNuwUEbXhE0qKfSAMyC5g37XPT2BNRGKzkM/c4v1AojnGbOpJERYOq1z
-26ikZsKquMam6xJne0UzJpeAeui+KJm7vvHImjVxxmOTQY+Eio9dQVEWHdY635aZ4ZKw6Pq8LUJ74dyX
-8e3Bu/cUjiMgy8JsgdP7X1zKnA8lIn4GgwHjW+PMGKKHcAJnp6enjnh+dmxX+JjenjUwvh02XX33IDVe
-iPb6wxSihx0S1WumtNj8dgyOtYAIQXZvovBPCfk2OtTsaLLoHDYzVqsT2VIB3dDb5qqbqEaDvcWokc51
-6wA/0FTrbj59dgvgJguWnWaazKMtv6/fOb3moKovAalJqkSMcZ0RL0pEMQIugDB7lYyyZR2stKfNWkql
-sbwLyvCJ2wL1Wr4knluMcj/phjNHncOnBQlBYjSv+7fDI8ntrVy2FJxJthH6KCB6TGTkFx2F14FTPLoz
-ZXaFW+yL9EAjzeRTe7ZsYXh6Q058ibXtM8lscmlH6lb3IpubpCyJjDg8e0ZdAwlSw4kB3FDXAxMapzWx
-eqFl5xxgl3TyhkhlDHlk8KI/65QrA8E48YO8Q+/UNxWUEiG6nzEeN4Zk/YkIN2fZJUlm3N8xaUmdZ6Xm
-eB/e5IY1Mop7p5+4wkjEvP8c4EALHAFawRdDi5Vi1NUalswyY3Az2YBaL2SO7yU/Fz4TJnMV12kxmzcR
-F7QjyYWispfOpuH7NC9W4lIsuAhe+WZ7UqaDc84k93Hi8+WgF4HSOyGBa7BP6pIXuTEag+Gw8g1ozfva
-vs3/1h9BjPL5Pvzyl7cnJ/D06VNzTWqHCmiwtuShF189j97IjpJHz6sic1/yVntfCGb3LKO85ODRxQIF
-Mpt0ztyBLc2DYfNfGPNeJ0C54tt4i39tTxmzQow7Vz8AX/GtaWV2xkmfUXrwWfTO+8IFoeg8sVOU4jPK
-lki9N6t5dwjZ88i2yESxgy7RMb6glpkNKtP5Ciib+6GHMj3bbIXtGy67FKU5hGzJuJfmfLBDZKIDx5bo
-XEUHeR0ilJwNNkQphVaEzMi+XK9NvpRs0qr8j6R1w7BHqwyG2X9RUMRU5EzCIoWYXDxpEXMpyt1Yv4Tn
-+Ta4aZgvJbp+bqQ0oV5ZHNdcGIuLhB0knqzivMlawNeglaRqF5MgEQEupwSapcks6lKVLrOYsTWNbXSj
-eaaaQxIywrh44kqHEU19c0PGPqMv7uUZxa9js65RhoSRLS13HilPoZP0uYlfo7hJdZsppFCWGjZXFOEg
-BG0rUVxUd46qHLimbU3rGzj3WPHtB5VbULSfNoKPfFaTPSeLoelUlXgmwWzwkc9udOvbmuZZ5g1M5ZWO
-BGceUdjL9cU8yddmaCa4qE5CsyTCK77NIJWVRZ0U7HC62SQDoYqzeeI6Zex1/GahJAHwPdjqtWTrdSY/
-lTNTvZSpSf8qlnpHZWlSVqEsrfL6HmyNyiy04WtapaIJa+2AMW8TGJXszVPYKX/TAgwlabrzJSCacTcu
-yNCYuylWTXgbDTe40cxNQVTa2T36OuWtqdVQTORBqYhmjE3rNDRmrS0g0YCryVhGZ033yPeoVNoDCjtl
-LbJNMYl7FSSasTUu4tCYqa/YpglLo3EMQ1+xTRUb9+jphImcmdeH+uOolqstrSWjaFgRqMjJ1P3yN0JK
-Sgoc1HRtJonDeq2u/ly+fmpZRNy22o8Zl4SJLXccG9/hzrGlSLx1p+bSevFObW2BzwaNr7jnCjutSurY
-wTwHOmjrHNr4yGe/8Bd7Us1OtVEkzVEkqMqpl1OP6K+B/VE1DfPdoqp50XDO3e5wZ6b8T7hz75TEt3XP
-eIPn3t1ojelrwwTOHWOtsEbK6NM9Os+512DsVMUMgB+TP91B2HKLhm4aUJ8IeAZnDQJq2fqJWX0jvl+m
-XyafjVkYM6a1NOJTAah2818Z2koCA+X6XnMctnfCUqKPNUDioEOZStZ0j5XmvFK9aoD8mDFV1WpWAag8
-n2vdVYovKcOf9DJUYoNaEfukXOclWo0PaQehZfdgagdBySYBSedgZIkDVOrwlJsgUxn7PSqxa+JdHi6Y
-dpXsCw2pn/wydEM/im/1LR7R8ctVVIbZFUid+1rHgoBvUM/mjvigwfXT3xpzQvcy1uULseIa14+JE+lx
-75dgxjtk3mPixruoLvmXUQyf7B6XatirCQ/LjJ+o342puKO+349/NuSAQSI+539Y+q+ReJ3SH8FtyoIr
-2y2h3iSP0Mh1xwanuIdFQ9p7uSS+pUsleEi8Wk4eVMOrKWnnenAXDZFcr+2PwP7y+vo8Uwyv1CcrvKKb
-9Bu25VZUfx0lkPhOaEnw3DY8rK83kPS+vIlhy2V/BG/l8hyi26Yu3Igwii6ouoca8gTJY1Ek+zVUJNeA
-b25rkc/7weZC6CaIrlQcFBe92C9wStZrf/eSmgVLDuQmGMG/Dfr/w5aV6A/zZXPSiq/2rxn3dpdPnpvi
-tZdP/n8AAAD//6Z48fgfAwEA
-`,
-	},
-
-	"/": {
-		name:  "/",
-		local: `static`,
-		isDir: true,
-	},
-
-	"/css": {
-		name:  "css",
-		local: `static/css`,
-		isDir: true,
-	},
-
-	"/fonts": {
-		name:  "fonts",
-		local: `static/fonts`,
-		isDir: true,
-	},
-
-	"/js": {
-		name:  "js",
-		local: `static/js`,
-		isDir: true,
-	},
-}
-
-var _escDirs = map[string][]os.FileInfo{
-
-	"static": {
-		_escData["/css"],
-		_escData["/favicon.ico"],
-		_escData["/fonts"],
-		_escData["/js"],
-		_escData["/status.html"],
-	},
-
-	"static/css": {
-		_escData["/css/bootstrap-3.3.7.min.css"],
-		_escData["/css/wr-0.0.1.css"],
-	},
-
-	"static/fonts": {
-		_escData["/fonts/glyphicons-halflings-regular.eot"],
-		_escData["/fonts/glyphicons-halflings-regular.svg"],
-		_escData["/fonts/glyphicons-halflings-regular.ttf"],
-		_escData["/fonts/glyphicons-halflings-regular.woff"],
-		_escData["/fonts/glyphicons-halflings-regular.woff2"],
-	},
-
-	"static/js": {
-		_escData["/js/bootstrap-3.3.7.min.js"],
-		_escData["/js/jquery-2.2.4.min.js"],
-		_escData["/js/knockout-3.4.0.min.js"],
-		_escData["/js/knockstrap-1.3.2.min.js"],
-		_escData["/js/wr-0.0.1.js"],
-	},
-}
@@ -0,0 +1,133 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of summary statistics about the work
+// done by managers, for periodic reporting.
+
+import (
+	"sort"
+)
+
+// JobStats summarises the work done over some period: the jobs that completed
+// and those that were buried.
+type JobStats struct {
+	Completed int     `json:"completed"`
+	Buried    int     `json:"buried"`
+	CPUHours  float64 `json:"cpu_hours"`
+	WallHours float64 `json:"wall_hours"`
+
+	// FailureReasons counts the buried jobs by the FailReason* they were
+	// buried with.
+	FailureReasons map[string]int `json:"failure_reasons"`
+
+	// RepGroupWallHours is the total wall time, in hours, of the completed
+	// jobs in each RepGroup.
+	RepGroupWallHours map[string]float64 `json:"rep_group_wall_hours"`
+}
+
+// RepGroupWallTime is the total wall time of a RepGroup's completed jobs, as
+// returned by JobStats.TopRepGroups().
+type RepGroupWallTime struct {
+	RepGroup  string  `json:"rep_grp"`
+	WallHours float64 `json:"wall_hours"`
+}
+
+// NewJobStats creates a JobStats from some complete jobs (eg. from
+// Client.GetComplete()) and the bury events over the same period (eg. from
+// Client.GetEvents()). Jobs that aren't complete and events that aren't bury
+// events are ignored.
+func NewJobStats(complete []*Job, buries []*Event) *JobStats {
+	js := &JobStats{
+		FailureReasons:    make(map[string]int),
+		RepGroupWallHours: make(map[string]float64),
+	}
+
+	for _, job := range complete {
+		if job.State != JobStateComplete {
+			continue
+		}
+		wall := job.WallTime().Hours()
+		js.Completed++
+		js.CPUHours += job.CPUtime.Hours()
+		js.WallHours += wall
+		js.RepGroupWallHours[job.RepGroup] += wall
+	}
+
+	for _, event := range buries {
+		if event.Type != EventTypeBury {
+			continue
+		}
+		js.Buried++
+		reason := event.Msg
+		if reason == "" {
+			reason = FailReasonAbnormal
+		}
+		js.FailureReasons[reason]++
+	}
+
+	return js
+}
+
+// Merge adds the stats in other to these stats.
+func (js *JobStats) Merge(other *JobStats) {
+	js.Completed += other.Completed
+	js.Buried += other.Buried
+	js.CPUHours += other.CPUHours
+	js.WallHours += other.WallHours
+	for reason, count := range other.FailureReasons {
+		js.FailureReasons[reason] += count
+	}
+	for rg, hours := range other.RepGroupWallHours {
+		js.RepGroupWallHours[rg] += hours
+	}
+}
+
+// Run returns the number of jobs that ran to completion or were buried.
+func (js *JobStats) Run() int {
+	return js.Completed + js.Buried
+}
+
+// FailureRate returns the proportion (0..1) of the jobs that ran that were
+// buried.
+func (js *JobStats) FailureRate() float64 {
+	if js.Run() == 0 {
+		return 0
+	}
+	return float64(js.Buried) / float64(js.Run())
+}
+
+// TopRepGroups returns the n RepGroups with the most wall time, most first. An
+// n of 0 or less returns all of them.
+func (js *JobStats) TopRepGroups(n int) []*RepGroupWallTime {
+	top := make([]*RepGroupWallTime, 0, len(js.RepGroupWallHours))
+	for rg, hours := range js.RepGroupWallHours {
+		top = append(top, &RepGroupWallTime{RepGroup: rg, WallHours: hours})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].WallHours == top[j].WallHours {
+			return top[i].RepGroup < top[j].RepGroup
+		}
+		return top[i].WallHours > top[j].WallHours
+	})
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
@@ -0,0 +1,80 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestJobStats(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Given some complete jobs and bury events", t, func() {
+		start := time.Now().Add(-10 * time.Hour)
+		complete := []*Job{
+			{RepGroup: "align", State: JobStateComplete, StartTime: start, EndTime: start.Add(2 * time.Hour), CPUtime: 4 * time.Hour},
+			{RepGroup: "align", State: JobStateComplete, StartTime: start, EndTime: start.Add(1 * time.Hour), CPUtime: 1 * time.Hour},
+			{RepGroup: "call", State: JobStateComplete, StartTime: start, EndTime: start.Add(30 * time.Minute), CPUtime: 30 * time.Minute},
+			{RepGroup: "call", State: JobStateRunning, StartTime: start},
+		}
+		buries := []*Event{
+			{Type: EventTypeBury, Msg: FailReasonRAM},
+			{Type: EventTypeBury, Msg: FailReasonRAM},
+			{Type: EventTypeBury},
+			{Type: EventTypeAdd, Count: 10},
+		}
+
+		Convey("NewJobStats() summarises them", func() {
+			js := NewJobStats(complete, buries)
+			So(js.Completed, ShouldEqual, 3)
+			So(js.Buried, ShouldEqual, 3)
+			So(js.Run(), ShouldEqual, 6)
+			So(js.FailureRate(), ShouldEqual, 0.5)
+			So(js.CPUHours, ShouldEqual, 5.5)
+			So(js.WallHours, ShouldEqual, 3.5)
+			So(js.FailureReasons, ShouldResemble, map[string]int{FailReasonRAM: 2, FailReasonAbnormal: 1})
+
+			top := js.TopRepGroups(1)
+			So(len(top), ShouldEqual, 1)
+			So(top[0], ShouldResemble, &RepGroupWallTime{RepGroup: "align", WallHours: 3})
+			So(len(js.TopRepGroups(0)), ShouldEqual, 2)
+
+			Convey("Stats can be merged", func() {
+				other := NewJobStats(complete[2:3], buries[:1])
+				js.Merge(other)
+				So(js.Completed, ShouldEqual, 4)
+				So(js.Buried, ShouldEqual, 4)
+				So(js.FailureReasons[FailReasonRAM], ShouldEqual, 3)
+				So(js.TopRepGroups(0)[1], ShouldResemble, &RepGroupWallTime{RepGroup: "call", WallHours: 1})
+			})
+		})
+
+		Convey("Empty stats have no failure rate", func() {
+			js := NewJobStats(nil, nil)
+			So(js.Run(), ShouldEqual, 0)
+			So(js.FailureRate(), ShouldEqual, 0)
+			So(js.TopRepGroups(10), ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,415 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of StatusWatcher, which follows the
+// same stream of job state changes that the status webpage uses, for the
+// benefit of terminal dashboards.
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"time"
+
+	sync "github.com/sasha-s/go-deadlock"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// StatusAllRepGroups is the pseudo RepGroup that StatusSnapshot.Counts
+	// uses for the counts of all incomplete jobs.
+	StatusAllRepGroups = "+all+"
+
+	statusWSPath           = "/status_ws"
+	statusThroughputWindow = 5 * time.Minute
+	statusMaxFailures      = 10
+)
+
+// StatusFailure describes jobs that recently became buried.
+type StatusFailure struct {
+	RepGroup string
+	Count    int
+	Time     time.Time
+}
+
+// StatusSnapshot is a copy of what a StatusWatcher knows at a point in time.
+type StatusSnapshot struct {
+	// Counts has the number of jobs in each state for each RepGroup, and for
+	// StatusAllRepGroups (which excludes complete jobs). Reserved jobs are
+	// counted as running.
+	Counts map[string]map[JobState]int
+
+	// Hosts has the number of running jobs on each host, as of the last
+	// RequestHosts().
+	Hosts map[string]int
+
+	// Throughput is the number of jobs that completed per minute, averaged
+	// over the last 5 minutes (or since watching began, if more recent).
+	Throughput float64
+
+	// Failures are the most recent times that jobs got buried, latest first.
+	Failures []*StatusFailure
+
+	// BadServers are the servers currently considered bad.
+	BadServers []*BadServer
+
+	// Messages are problems reported by the scheduler.
+	Messages []string
+}
+
+// RepGroups returns the RepGroups in Counts, excluding StatusAllRepGroups,
+// sorted by name.
+func (ss *StatusSnapshot) RepGroups() []string {
+	rgs := make([]string, 0, len(ss.Counts))
+	for rg := range ss.Counts {
+		if rg == StatusAllRepGroups {
+			continue
+		}
+		rgs = append(rgs, rg)
+	}
+	sort.Strings(rgs)
+	return rgs
+}
+
+// statusMessage is a union of the different things the status websocket can
+// send us.
+type statusMessage struct {
+	// jstateCount
+	RepGroup  string
+	FromState JobState
+	ToState   JobState
+	Count     int
+
+	// jhosts
+	Hosts map[string]int
+
+	// BadServer
+	ID      string
+	Name    string
+	IP      string
+	Date    int64
+	IsBad   bool
+	Problem string
+
+	// schedulerIssue
+	Msg string
+}
+
+// statusCompletion records when some jobs completed.
+type statusCompletion struct {
+	time  time.Time
+	count int
+}
+
+// StateChangeCallback is a function that StatusWatcher calls when the server
+// reports that count jobs in repGroup moved from one state to another. from is
+// JobStateNew for the initial counts reported on connection.
+type StateChangeCallback func(repGroup string, from, to JobState, count int)
+
+// StatusWatcher connects to a server's web interface and keeps track of the
+// job state changes it reports. Get the current state with Snapshot().
+type StatusWatcher struct {
+	conn        *websocket.Conn
+	started     time.Time
+	counts      map[string]map[JobState]int
+	hosts       map[string]int
+	completions []*statusCompletion
+	failures    []*StatusFailure
+	badServers  map[string]*BadServer
+	messages    map[string]bool
+	stateCb     StateChangeCallback
+	done        chan struct{}
+	err         error
+	closed      bool
+	writeMutex  sync.Mutex
+	sync.RWMutex
+}
+
+// WatchStatus connects to the web interface of the server at webAddr
+// (host:port, where port is the ServerInfo.WebPort of a Client) using the same
+// caFile, certDomain and token as you would for Connect(), and starts following
+// the state of all jobs.
+func WatchStatus(webAddr, caFile, certDomain string, token []byte, timeout time.Duration) (*StatusWatcher, error) {
+	tlsConfig := &tls.Config{ServerName: certDomain}
+	caCert, err := ioutil.ReadFile(caFile)
+	if err == nil {
+		certPool := x509.NewCertPool()
+		certPool.AppendCertsFromPEM(caCert)
+		tlsConfig.RootCAs = certPool
+	}
+
+	dialer := &websocket.Dialer{
+		TLSClientConfig:  tlsConfig,
+		HandshakeTimeout: timeout,
+	}
+	header := http.Header{}
+	header.Set("Authorization", bearerSchema+string(token))
+	conn, resp, err := dialer.Dial("wss://"+webAddr+statusWSPath, header)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sw := &StatusWatcher{
+		conn:       conn,
+		started:    time.Now(),
+		counts:     make(map[string]map[JobState]int),
+		hosts:      make(map[string]int),
+		badServers: make(map[string]*BadServer),
+		messages:   make(map[string]bool),
+		done:       make(chan struct{}),
+	}
+	go sw.read()
+
+	err = sw.send(&jstatusReq{Request: "current"})
+	if err != nil {
+		sw.Close()
+		return nil, err
+	}
+	return sw, nil
+}
+
+// RequestHosts asks the server for the number of running jobs per host; the
+// answer will be in a subsequent Snapshot().
+func (sw *StatusWatcher) RequestHosts() error {
+	return sw.send(&jstatusReq{Request: "hosts"})
+}
+
+// send sends a request to the server.
+func (sw *StatusWatcher) send(req *jstatusReq) error {
+	sw.writeMutex.Lock()
+	defer sw.writeMutex.Unlock()
+	return sw.conn.WriteJSON(req)
+}
+
+// read handles everything the server sends us until the connection breaks.
+func (sw *StatusWatcher) read() {
+	defer close(sw.done)
+	for {
+		var msg statusMessage
+		err := sw.conn.ReadJSON(&msg)
+		if err != nil {
+			sw.Lock()
+			sw.err = err
+			sw.Unlock()
+			return
+		}
+		sw.handle(&msg, time.Now())
+
+		if msg.ToState != "" && msg.RepGroup != StatusAllRepGroups {
+			sw.RLock()
+			cb := sw.stateCb
+			sw.RUnlock()
+			if cb != nil {
+				cb(msg.RepGroup, msg.FromState, msg.ToState, msg.Count)
+			}
+		}
+	}
+}
+
+// OnStateChange sets a callback that will be called (in the order the server
+// sent them) for every change in job state counts the server reports, after
+// it has been applied to our Snapshot(). It is not called for StatusAllRepGroups
+// changes. The callback must not block for long.
+func (sw *StatusWatcher) OnStateChange(cb StateChangeCallback) {
+	sw.Lock()
+	defer sw.Unlock()
+	sw.stateCb = cb
+}
+
+// handle updates our state based on a message from the server.
+func (sw *StatusWatcher) handle(msg *statusMessage, now time.Time) {
+	sw.Lock()
+	defer sw.Unlock()
+
+	switch {
+	case msg.ToState != "":
+		sw.handleStateCount(msg, now)
+	case msg.Hosts != nil:
+		sw.hosts = msg.Hosts
+	case msg.IP != "":
+		if msg.IsBad {
+			sw.badServers[msg.ID] = &BadServer{
+				ID:      msg.ID,
+				Name:    msg.Name,
+				IP:      msg.IP,
+				Date:    msg.Date,
+				IsBad:   msg.IsBad,
+				Problem: msg.Problem,
+			}
+		} else {
+			delete(sw.badServers, msg.ID)
+		}
+	case msg.Msg != "":
+		sw.messages[msg.Msg] = true
+	}
+}
+
+// handleStateCount applies a jstateCount message. You must hold the lock.
+func (sw *StatusWatcher) handleStateCount(msg *statusMessage, now time.Time) {
+	counts, exists := sw.counts[msg.RepGroup]
+	if !exists {
+		counts = make(map[JobState]int)
+		sw.counts[msg.RepGroup] = counts
+	}
+
+	from, to := msg.FromState, msg.ToState
+	if from == JobStateReserved {
+		from = JobStateRunning
+	}
+	if to == JobStateReserved {
+		to = JobStateRunning
+	}
+
+	if from != JobStateNew {
+		counts[from] -= msg.Count
+		if counts[from] <= 0 {
+			delete(counts, from)
+		}
+	}
+	if to != JobStateDeleted && !(to == JobStateComplete && msg.RepGroup == StatusAllRepGroups) {
+		counts[to] += msg.Count
+	}
+
+	// initial counts come from JobStateNew, so we only treat transitions
+	// between real states as events
+	if from == JobStateNew || msg.RepGroup == StatusAllRepGroups {
+		return
+	}
+
+	switch to {
+	case JobStateComplete:
+		sw.completions = append(sw.completions, &statusCompletion{time: now, count: msg.Count})
+	case JobStateBuried:
+		sw.failures = append([]*StatusFailure{{RepGroup: msg.RepGroup, Count: msg.Count, Time: now}}, sw.failures...)
+		if len(sw.failures) > statusMaxFailures {
+			sw.failures = sw.failures[:statusMaxFailures]
+		}
+	}
+}
+
+// Snapshot returns a copy of the current state of all jobs.
+func (sw *StatusWatcher) Snapshot() *StatusSnapshot {
+	return sw.snapshot(time.Now())
+}
+
+// snapshot is the implementation of Snapshot(), treating now as the current
+// time.
+func (sw *StatusWatcher) snapshot(now time.Time) *StatusSnapshot {
+	sw.Lock()
+	defer sw.Unlock()
+
+	ss := &StatusSnapshot{
+		Counts:   make(map[string]map[JobState]int, len(sw.counts)),
+		Hosts:    make(map[string]int, len(sw.hosts)),
+		Failures: make([]*StatusFailure, len(sw.failures)),
+	}
+
+	for rg, counts := range sw.counts {
+		c := make(map[JobState]int, len(counts))
+		for state, count := range counts {
+			c[state] = count
+		}
+		ss.Counts[rg] = c
+	}
+
+	for host, count := range sw.hosts {
+		ss.Hosts[host] = count
+	}
+
+	for i, f := range sw.failures {
+		fc := *f
+		ss.Failures[i] = &fc
+	}
+
+	for _, bs := range sw.badServers {
+		bsc := *bs
+		ss.BadServers = append(ss.BadServers, &bsc)
+	}
+	sort.Slice(ss.BadServers, func(i, j int) bool {
+		return ss.BadServers[i].Name < ss.BadServers[j].Name
+	})
+
+	for msg := range sw.messages {
+		ss.Messages = append(ss.Messages, msg)
+	}
+	sort.Strings(ss.Messages)
+
+	// forget about old completions and average the rest
+	cutoff := now.Add(-statusThroughputWindow)
+	var keep []*statusCompletion
+	completed := 0
+	for _, c := range sw.completions {
+		if c.time.Before(cutoff) {
+			continue
+		}
+		keep = append(keep, c)
+		completed += c.count
+	}
+	sw.completions = keep
+
+	window := statusThroughputWindow
+	if elapsed := now.Sub(sw.started); elapsed < window {
+		window = elapsed
+	}
+	if window > 0 {
+		ss.Throughput = float64(completed) / window.Minutes()
+	}
+
+	return ss
+}
+
+// Done returns a channel that is closed when the connection to the server is
+// lost.
+func (sw *StatusWatcher) Done() <-chan struct{} {
+	return sw.done
+}
+
+// Err returns the error that caused the connection to the server to be lost,
+// if it has been. It returns nil after you Close().
+func (sw *StatusWatcher) Err() error {
+	sw.RLock()
+	defer sw.RUnlock()
+	if sw.closed {
+		return nil
+	}
+	return sw.err
+}
+
+// Close disconnects from the server.
+func (sw *StatusWatcher) Close() error {
+	sw.Lock()
+	sw.closed = true
+	sw.Unlock()
+
+	sw.writeMutex.Lock()
+	err := sw.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	sw.writeMutex.Unlock()
+	errc := sw.conn.Close()
+	if err == nil {
+		err = errc
+	}
+	return err
+}
@@ -0,0 +1,507 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of tracing, where the server records
+// what happens to jobs as OpenTelemetry spans (from being added, through
+// becoming ready, runners being scheduled for them, being reserved and running,
+// to completing), and sends them to a collector such as Jaeger, so that the
+// end-to-end latency of a workflow can be analysed.
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	"github.com/inconshreveable/log15"
+	sync "github.com/sasha-s/go-deadlock"
+)
+
+// TraceFlushInterval is how often the server sends the spans it has recorded to
+// its TraceEndpoint.
+var TraceFlushInterval = 5 * time.Second
+
+const (
+	// traceMaxPending is the most spans the server will hold on to while its
+	// TraceEndpoint is unreachable; beyond this, spans are dropped.
+	traceMaxPending = 100000
+
+	// traceBatchSize is the most spans sent to the TraceEndpoint at once.
+	traceBatchSize = 1000
+
+	// traceTimeout is how long we wait for the TraceEndpoint to accept spans.
+	traceTimeout = 10 * time.Second
+
+	// traceParentEnv is the environment variable that the runner uses to give
+	// Cmds the W3C traceparent of the span of their run, so that Cmds that are
+	// themselves instrumented can add their own spans to the trace.
+	traceParentEnv = "TRACEPARENT"
+
+	traceServiceName  = "wr"
+	traceKindInternal = 1
+	traceKindClient   = 3
+	traceStatusError  = 2
+)
+
+// ValidTraceID returns true if the given string is a valid OpenTelemetry (W3C)
+// trace id: 32 lower-case hexadecimal characters, not all zero.
+func ValidTraceID(id string) bool {
+	if len(id) != 32 || id == "00000000000000000000000000000000" {
+		return false
+	}
+	for _, c := range id {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// NewTraceID returns a new random trace id, suitable for using as the TraceID
+// of all the Jobs of a workflow.
+func NewTraceID() string {
+	return randomTraceHex(16)
+}
+
+// randomTraceHex returns n random bytes as a hex string.
+func randomTraceHex(n int) string {
+	b := make([]byte, n)
+	if _, err := crand.Read(b); err != nil {
+		// (extremely unlikely, and non-random ids are still usable)
+		return fmt.Sprintf("%0*x", n*2, time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// traceSpanID returns the id of the span with the given name for the given
+// attempt at running the job with the given key. It's deterministic so that
+// spans recorded at different times, and the runner, can refer to them.
+func traceSpanID(key, name string, attempt uint32) string {
+	return byteKey([]byte(fmt.Sprintf("%s.%s.%d", key, name, attempt)))[:16]
+}
+
+// traceParent returns the W3C traceparent of the span for the given attempt at
+// running the job, or blank if the job isn't being traced.
+func (j *Job) traceParent(attempt uint32) string {
+	if j.TraceID == "" {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", j.TraceID, traceSpanID(j.Key(), string(JobStateRunning), attempt))
+}
+
+// traceSpan is a span recorded by a tracer.
+type traceSpan struct {
+	traceID  string
+	spanID   string
+	parentID string
+	name     string
+	kind     int
+	start    time.Time
+	end      time.Time
+	attrs    []otlpAttribute
+	problem  string
+}
+
+// addAttr adds a string attribute to the span, if the value isn't blank.
+func (ts *traceSpan) addAttr(key, val string) {
+	if val == "" {
+		return
+	}
+	ts.attrs = append(ts.attrs, otlpAttribute{Key: key, Value: otlpValue{StringValue: val}})
+}
+
+// traceJobRef identifies a traced job that is waiting on runners to be
+// scheduled.
+type traceJobRef struct {
+	traceID string
+	key     string
+}
+
+// tracer holds on to the spans the server records, and sends them in batches
+// to an OTLP/HTTP endpoint.
+type tracer struct {
+	endpoint string
+	client   *http.Client
+	resource []otlpAttribute
+	pending  []*traceSpan
+	dropped  int
+	awaiting map[string][]traceJobRef
+	log15.Logger
+	sync.Mutex
+}
+
+// newTracer returns a tracer that sends spans to the given endpoint, or nil if
+// the endpoint is blank.
+func newTracer(endpoint, deployment, host string, logger log15.Logger) *tracer {
+	if endpoint == "" {
+		return nil
+	}
+	return &tracer{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: traceTimeout},
+		resource: []otlpAttribute{
+			{Key: "service.name", Value: otlpValue{StringValue: traceServiceName}},
+			{Key: "service.version", Value: otlpValue{StringValue: ServerVersion}},
+			{Key: "deployment.environment", Value: otlpValue{StringValue: deployment}},
+			{Key: "host.name", Value: otlpValue{StringValue: host}},
+		},
+		awaiting: make(map[string][]traceJobRef),
+		Logger:   logger.New("tracing", endpoint),
+	}
+}
+
+// record notes the given span, to be sent with the next batch. Does nothing if
+// the tracer is nil.
+func (t *tracer) record(span *traceSpan) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	if len(t.pending) >= traceMaxPending {
+		t.dropped++
+		return
+	}
+	t.pending = append(t.pending, span)
+}
+
+// awaitSchedule notes that the given traced job became ready in the given
+// scheduler group, so that the next scheduling of runners for that group can
+// be recorded in its trace.
+func (t *tracer) awaitSchedule(group string, ref traceJobRef) {
+	if t == nil || group == "" {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	t.awaiting[group] = append(t.awaiting[group], ref)
+}
+
+// scheduled returns, and forgets, the traced jobs that have been waiting on
+// runners to be scheduled for the given scheduler group.
+func (t *tracer) scheduled(group string) []traceJobRef {
+	if t == nil {
+		return nil
+	}
+	t.Lock()
+	defer t.Unlock()
+	refs := t.awaiting[group]
+	delete(t.awaiting, group)
+	return refs
+}
+
+// run sends batches of spans every TraceFlushInterval until the given channel
+// is closed, when remaining spans are sent one last time.
+func (t *tracer) run(stop chan bool) {
+	defer internal.LogPanic(t.Logger, "tracing", true)
+	ticker := time.NewTicker(TraceFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.flush()
+		case <-stop:
+			t.flush()
+			return
+		}
+	}
+}
+
+// flush sends all pending spans to our endpoint. Spans that can't be sent are
+// kept to be tried again next time.
+func (t *tracer) flush() {
+	t.Lock()
+	spans := t.pending
+	t.pending = nil
+	dropped := t.dropped
+	t.dropped = 0
+	t.Unlock()
+
+	if dropped > 0 {
+		t.Warn("dropped spans because the trace endpoint couldn't keep up", "count", dropped)
+	}
+
+	for len(spans) > 0 {
+		n := traceBatchSize
+		if n > len(spans) {
+			n = len(spans)
+		}
+		if err := t.send(spans[:n]); err != nil {
+			t.Warn("failed to send spans", "err", err)
+			t.Lock()
+			t.pending = append(spans, t.pending...)
+			if over := len(t.pending) - traceMaxPending; over > 0 {
+				t.pending = t.pending[over:]
+				t.dropped += over
+			}
+			t.Unlock()
+			return
+		}
+		spans = spans[n:]
+	}
+}
+
+// send POSTs the given spans to our endpoint as OTLP JSON.
+func (t *tracer) send(spans []*traceSpan) error {
+	export := &otlpExport{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: t.resource},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: traceServiceName, Version: ServerVersion}}},
+	}}}
+	otlp := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		out := otlpSpan{
+			TraceID:           span.traceID,
+			SpanID:            span.spanID,
+			ParentSpanID:      span.parentID,
+			Name:              span.name,
+			Kind:              span.kind,
+			StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+			Attributes:        span.attrs,
+		}
+		if span.problem != "" {
+			out.Status = otlpStatus{Code: traceStatusError, Message: span.problem}
+		}
+		otlp = append(otlp, out)
+	}
+	export.ResourceSpans[0].ScopeSpans[0].Spans = otlp
+
+	body, err := json.Marshal(export)
+	if err != nil {
+		return err
+	}
+	resp, err := t.client.Post(t.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer internal.LogClose(t.Logger, resp.Body, "trace response body")
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("trace endpoint responded %s", resp.Status)
+	}
+	return nil
+}
+
+// jobSpan returns a span in the given job's trace, as a child of the span
+// covering the job's whole life. You must hold the lock on the job.
+func jobSpan(job *Job, spanID, name string, start, end time.Time) *traceSpan {
+	key := job.Key()
+	if spanID == "" {
+		spanID = randomTraceHex(8)
+	}
+	span := &traceSpan{
+		traceID:  job.TraceID,
+		spanID:   spanID,
+		parentID: traceSpanID(key, "job", 0),
+		name:     name,
+		kind:     traceKindInternal,
+		start:    start,
+		end:      end,
+	}
+	span.addAttr("wr.job.key", key)
+	return span
+}
+
+// traceTransitions is called when the given jobs change from one state to
+// another. For jobs with a TraceID, a span is recorded for the time they spent
+// in the state they left (or for the times they spent reserved and running,
+// when leaving the running state), and a span covering their whole life when
+// they complete or are deleted.
+//
+// Since the times jobs entered their states aren't stored, spans of jobs that
+// were added before the server last started only cover the time since then.
+func (s *Server) traceTransitions(from, to JobState, data []interface{}) {
+	if s.tracer == nil {
+		return
+	}
+
+	now := time.Now()
+	for _, inter := range data {
+		job := inter.(*Job)
+		job.Lock()
+		if job.TraceID == "" {
+			job.Unlock()
+			continue
+		}
+		since := job.traceSince
+		job.traceSince = now
+		if job.traceStart.IsZero() {
+			job.traceStart = now
+		}
+
+		switch {
+		case from == JobStateRunning:
+			s.traceRun(job, since, now, to)
+		case from != JobStateNew && !since.IsZero():
+			s.tracer.record(jobSpan(job, "", string(from), since, now))
+		}
+
+		if to == JobStateReady {
+			s.tracer.awaitSchedule(job.schedulerGroup, traceJobRef{traceID: job.TraceID, key: job.Key()})
+		}
+
+		if to == JobStateComplete || to == JobStateDeleted {
+			span := jobSpan(job, traceSpanID(job.Key(), "job", 0), "job", job.traceStart, now)
+			span.parentID = ""
+			span.addAttr("wr.rep_group", job.RepGroup)
+			span.addAttr("wr.req_group", job.ReqGroup)
+			span.addAttr("wr.queue", job.Queue)
+			if to == JobStateDeleted {
+				span.problem = "deleted"
+			}
+			s.tracer.record(span)
+		}
+		job.Unlock()
+	}
+}
+
+// traceRun records spans for the time the given job spent reserved by a runner
+// before it started running, and the time it then spent running, for a job
+// that was reserved since the given time, and has now changed to the given
+// state. You must hold the lock on the job.
+func (s *Server) traceRun(job *Job, since, now time.Time, to JobState) {
+	started := !job.StartTime.IsZero() && (since.IsZero() || !job.StartTime.Before(since))
+
+	if !since.IsZero() {
+		end := now
+		if started {
+			end = job.StartTime
+		}
+		s.tracer.record(jobSpan(job, "", string(JobStateReserved), since, end))
+	}
+
+	if !started {
+		return
+	}
+
+	end := job.EndTime
+	if end.Before(job.StartTime) {
+		end = now
+	}
+	span := jobSpan(job, traceSpanID(job.Key(), string(JobStateRunning), job.Attempts), string(JobStateRunning), job.StartTime, end)
+	span.addAttr("wr.host", job.Host)
+	span.addAttr("wr.attempt", strconv.FormatUint(uint64(job.Attempts), 10))
+	if job.Exited {
+		span.addAttr("wr.exit_code", strconv.Itoa(job.Exitcode))
+	}
+	if to != JobStateComplete {
+		span.problem = job.FailReason
+		if span.problem == "" {
+			span.problem = "did not complete"
+		}
+	}
+	s.tracer.record(span)
+}
+
+// traceJobPhase records a span for the data movement phase (JobStateStaging or
+// JobStateUploading) that the given reserved job has just left, if it is
+// traced. You must hold the lock on the job.
+func (s *Server) traceJobPhase(job *Job, from JobState) {
+	if s.tracer == nil || job.TraceID == "" {
+		return
+	}
+	now := time.Now()
+	if (from == JobStateStaging || from == JobStateUploading) && !job.tracePhaseSince.IsZero() {
+		s.tracer.record(jobSpan(job, "", string(from), job.tracePhaseSince, now))
+	}
+	job.tracePhaseSince = now
+}
+
+// traceSchedule records, in the traces of the given jobs, a span for an
+// attempt to schedule the given number of runners for the given scheduler
+// group, which started at the given time and had the given outcome.
+func (s *Server) traceSchedule(refs []traceJobRef, group string, count int, start time.Time, err error) {
+	if len(refs) == 0 {
+		return
+	}
+	end := time.Now()
+	for _, ref := range refs {
+		span := &traceSpan{
+			traceID:  ref.traceID,
+			spanID:   randomTraceHex(8),
+			parentID: traceSpanID(ref.key, "job", 0),
+			name:     "schedule",
+			kind:     traceKindClient,
+			start:    start,
+			end:      end,
+		}
+		span.addAttr("wr.job.key", ref.key)
+		span.addAttr("wr.scheduler_group", group)
+		span.addAttr("wr.runners", strconv.Itoa(count))
+		if err != nil {
+			span.problem = err.Error()
+		}
+		s.tracer.record(span)
+	}
+}
+
+// otlp* are the parts of an OTLP/HTTP JSON trace export request.
+type otlpExport struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,134 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTracing(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Trace ids can be validated and generated", t, func() {
+		So(ValidTraceID("0af7651916cd43dd8448eb211c80319c"), ShouldBeTrue)
+		So(ValidTraceID(""), ShouldBeFalse)
+		So(ValidTraceID("0af7651916cd43dd8448eb211c80319"), ShouldBeFalse)
+		So(ValidTraceID("0AF7651916CD43DD8448EB211C80319C"), ShouldBeFalse)
+		So(ValidTraceID("0af7651916cd43dd8448eb211c80319g"), ShouldBeFalse)
+		So(ValidTraceID("00000000000000000000000000000000"), ShouldBeFalse)
+
+		id := NewTraceID()
+		So(ValidTraceID(id), ShouldBeTrue)
+		So(NewTraceID(), ShouldNotEqual, id)
+	})
+
+	Convey("Jobs give their runs a deterministic trace parent", t, func() {
+		job := &Job{Cmd: "echo traced"}
+		So(job.traceParent(1), ShouldBeBlank)
+
+		job.TraceID = "0af7651916cd43dd8448eb211c80319c"
+		tp := job.traceParent(1)
+		So(tp, ShouldEqual, "00-0af7651916cd43dd8448eb211c80319c-"+traceSpanID(job.Key(), "running", 1)+"-01")
+		So(job.traceParent(1), ShouldEqual, tp)
+		So(job.traceParent(2), ShouldNotEqual, tp)
+		So(len(traceSpanID(job.Key(), "running", 1)), ShouldEqual, 16)
+	})
+
+	Convey("A nil tracer does nothing", t, func() {
+		var tr *tracer
+		So(newTracer("", "development", "localhost", log15.New()), ShouldBeNil)
+		tr.record(&traceSpan{})
+		tr.awaitSchedule("group", traceJobRef{})
+		So(tr.scheduled("group"), ShouldBeNil)
+	})
+
+	Convey("Tracers send spans as OTLP JSON, keeping them while the endpoint is down", t, func() {
+		var up int32
+		var bodies []map[string]interface{}
+		var contentType string
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.LoadInt32(&up) == 0 {
+				http.Error(w, "down", http.StatusServiceUnavailable)
+				return
+			}
+			contentType = r.Header.Get("Content-Type")
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			bodies = append(bodies, body)
+		}))
+		defer collector.Close()
+
+		logger := log15.New()
+		logger.SetHandler(log15.DiscardHandler())
+		tr := newTracer(collector.URL, "development", "localhost", logger)
+		So(tr, ShouldNotBeNil)
+
+		job := &Job{Cmd: "echo traced", TraceID: "0af7651916cd43dd8448eb211c80319c"}
+		start := time.Unix(100, 0)
+		span := jobSpan(job, "", "ready", start, start.Add(time.Second))
+		span.problem = "oops"
+		tr.record(span)
+
+		tr.flush()
+		So(bodies, ShouldBeEmpty)
+		So(len(tr.pending), ShouldEqual, 1)
+
+		atomic.StoreInt32(&up, 1)
+		tr.flush()
+		So(len(tr.pending), ShouldEqual, 0)
+		So(len(bodies), ShouldEqual, 1)
+		So(contentType, ShouldEqual, "application/json")
+
+		encoded, err := json.Marshal(bodies[0])
+		So(err, ShouldBeNil)
+		for _, expected := range []string{
+			`"resourceSpans"`, `"scopeSpans"`, `"service.name"`,
+			`"traceId":"0af7651916cd43dd8448eb211c80319c"`,
+			`"parentSpanId":"` + traceSpanID(job.Key(), "job", 0) + `"`,
+			`"name":"ready"`,
+			`"startTimeUnixNano":"100000000000"`,
+			`"endTimeUnixNano":"101000000000"`,
+			`"status":{"code":2,"message":"oops"}`,
+		} {
+			So(strings.Contains(string(encoded), expected), ShouldBeTrue)
+		}
+
+		Convey("Jobs waiting on runners are remembered per scheduler group", func() {
+			tr.awaitSchedule("", traceJobRef{traceID: job.TraceID, key: job.Key()})
+			tr.awaitSchedule("a", traceJobRef{traceID: job.TraceID, key: job.Key()})
+			So(tr.scheduled("b"), ShouldBeEmpty)
+			So(len(tr.scheduled("a")), ShouldEqual, 1)
+			So(tr.scheduled("a"), ShouldBeEmpty)
+		})
+	})
+}
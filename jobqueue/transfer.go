@@ -0,0 +1,497 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of copying files from runners to the
+// manager's machine, as done by the CopyToManager behaviour. So that lots of
+// jobs finishing at once don't saturate the manager's network, the manager
+// only lets a limited number of transfers happen at once, queuing the rest,
+// and runners limit the bandwidth each transfer uses.
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/internal"
+	sync "github.com/sasha-s/go-deadlock"
+)
+
+// TransferState* are the states a Transfer can be in.
+const (
+	TransferStateQueued = "queued"
+	TransferStateActive = "active"
+)
+
+// ClientTransferPollInterval is how long a client waits between asking the
+// server for a transfer slot when CopyToManager() has to queue.
+var ClientTransferPollInterval = 2 * time.Second
+
+// ServerTransferLeaseTime is how long the server waits to hear from a client
+// about its queued or granted transfer before forgetting it, so that runners
+// that die don't hold on to transfer slots forever.
+var ServerTransferLeaseTime = 1 * time.Minute
+
+// transferRateChunks is how many pieces we split each second's worth of data
+// into when limiting the rate of a transfer, so that the data flows smoothly.
+const transferRateChunks = 10
+
+// Transfer describes the copying of a job's files to the manager's machine.
+type Transfer struct {
+	JobKey string
+	Host   string // the host the files are being copied from
+	State  string // one of the TransferState* constants
+	Since  time.Time
+	Bytes  int64 // how many bytes have been received so far
+
+	lastSeen  time.Time
+	uploading int
+}
+
+// transferSlots manages the transfers the server is allowing to happen at
+// once, and those waiting their turn.
+type transferSlots struct {
+	max       int
+	transfers map[string]*Transfer
+	queue     []string
+	sync.Mutex
+}
+
+// newTransferSlots creates a transferSlots that allows max transfers to be
+// active at once. max of 0 means unlimited.
+func newTransferSlots(max int) *transferSlots {
+	return &transferSlots{
+		max:       max,
+		transfers: make(map[string]*Transfer),
+	}
+}
+
+// request asks for a transfer slot for the job with the given key, queuing the
+// request if none are free. Returns true if the job now has a slot. Clients
+// should keep calling this until it returns true.
+func (ts *transferSlots) request(key, host string) bool {
+	ts.Lock()
+	defer ts.Unlock()
+	now := time.Now()
+	ts.expire(now)
+
+	t, exists := ts.transfers[key]
+	if !exists {
+		t = &Transfer{JobKey: key, Host: host, State: TransferStateQueued, Since: now}
+		ts.transfers[key] = t
+		ts.queue = append(ts.queue, key)
+	}
+	t.lastSeen = now
+	if t.State == TransferStateActive {
+		return true
+	}
+
+	if ts.queue[0] != key || (ts.max > 0 && ts.active() >= ts.max) {
+		return false
+	}
+	ts.queue = ts.queue[1:]
+	t.State = TransferStateActive
+	t.Since = now
+	return true
+}
+
+// active returns the number of active transfers. You must hold the lock.
+func (ts *transferSlots) active() int {
+	var n int
+	for _, t := range ts.transfers {
+		if t.State == TransferStateActive {
+			n++
+		}
+	}
+	return n
+}
+
+// expire forgets transfers that haven't been heard about within
+// ServerTransferLeaseTime, and aren't currently receiving data. You must hold
+// the lock.
+func (ts *transferSlots) expire(now time.Time) {
+	var queue []string
+	for _, key := range ts.queue {
+		if t := ts.transfers[key]; now.Sub(t.lastSeen) > ServerTransferLeaseTime {
+			delete(ts.transfers, key)
+		} else {
+			queue = append(queue, key)
+		}
+	}
+	ts.queue = queue
+
+	for key, t := range ts.transfers {
+		if t.State == TransferStateActive && t.uploading == 0 && now.Sub(t.lastSeen) > ServerTransferLeaseTime {
+			delete(ts.transfers, key)
+		}
+	}
+}
+
+// uploadStarted notes that a file is being received for the given job, which
+// must have a slot. Returns false if it doesn't.
+func (ts *transferSlots) uploadStarted(key string) bool {
+	ts.Lock()
+	defer ts.Unlock()
+	t, exists := ts.transfers[key]
+	if !exists || t.State != TransferStateActive {
+		return false
+	}
+	t.uploading++
+	t.lastSeen = time.Now()
+	return true
+}
+
+// received adds to the bytes received for the given job.
+func (ts *transferSlots) received(key string, n int) {
+	ts.Lock()
+	defer ts.Unlock()
+	if t, exists := ts.transfers[key]; exists {
+		t.Bytes += int64(n)
+	}
+}
+
+// uploadEnded notes that a file is no longer being received for the given job.
+func (ts *transferSlots) uploadEnded(key string) {
+	ts.Lock()
+	defer ts.Unlock()
+	if t, exists := ts.transfers[key]; exists {
+		t.uploading--
+		t.lastSeen = time.Now()
+	}
+}
+
+// release frees up the slot of the given job, or removes it from the queue.
+func (ts *transferSlots) release(key string) {
+	ts.Lock()
+	defer ts.Unlock()
+	if _, exists := ts.transfers[key]; !exists {
+		return
+	}
+	delete(ts.transfers, key)
+	for i, queued := range ts.queue {
+		if queued == key {
+			ts.queue = append(ts.queue[:i], ts.queue[i+1:]...)
+			break
+		}
+	}
+}
+
+// list returns copies of the current transfers, active ones first, then
+// queued ones in the order they will be granted slots.
+func (ts *transferSlots) list() []*Transfer {
+	ts.Lock()
+	defer ts.Unlock()
+	ts.expire(time.Now())
+
+	var active []*Transfer
+	for _, t := range ts.transfers {
+		if t.State == TransferStateActive {
+			tc := *t
+			active = append(active, &tc)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].Since.Before(active[j].Since)
+	})
+
+	transfers := active
+	for _, key := range ts.queue {
+		tc := *ts.transfers[key]
+		transfers = append(transfers, &tc)
+	}
+	return transfers
+}
+
+// transferCounter is an io.Reader that tells a transferSlots how much of a
+// job's data has been read.
+type transferCounter struct {
+	r   io.Reader
+	ts  *transferSlots
+	key string
+}
+
+// Read implements io.Reader.
+func (tc *transferCounter) Read(p []byte) (int, error) {
+	n, err := tc.r.Read(p)
+	if n > 0 {
+		tc.ts.received(tc.key, n)
+	}
+	return n, err
+}
+
+// rateLimitedReader is an io.Reader that reads no faster than rate bytes per
+// second.
+type rateLimitedReader struct {
+	r     io.Reader
+	rate  int64
+	start time.Time
+	read  int64
+}
+
+// newRateLimitedReader wraps r so that it is read no faster than rate bytes
+// per second. A rate of 0 or less means unlimited, and r is returned.
+func newRateLimitedReader(r io.Reader, rate int64) io.Reader {
+	if rate <= 0 {
+		return r
+	}
+	return &rateLimitedReader{r: r, rate: rate}
+}
+
+// Read implements io.Reader.
+func (rl *rateLimitedReader) Read(p []byte) (int, error) {
+	if rl.start.IsZero() {
+		rl.start = time.Now()
+	}
+
+	chunk := rl.rate / transferRateChunks
+	if chunk < 1 {
+		chunk = 1
+	}
+	if int64(len(p)) > chunk {
+		p = p[:chunk]
+	}
+
+	n, err := rl.r.Read(p)
+	rl.read += int64(n)
+
+	due := rl.start.Add(time.Duration(float64(rl.read) / float64(rl.rate) * float64(time.Second)))
+	if wait := time.Until(due); wait > 0 {
+		<-time.After(wait)
+	}
+	return n, err
+}
+
+// copyDestination returns the path on our machine that the given file of the
+// job with the given key should be copied to, which is always within our
+// copyDir.
+func (s *Server) copyDestination(key, path string) (string, error) {
+	if key == "" || strings.ContainsAny(key, "/\\") {
+		return "", fmt.Errorf("invalid job key [%s]", key)
+	}
+	rel := filepath.Clean("/" + path)
+	if rel == "/" {
+		return "", fmt.Errorf("invalid path [%s]", path)
+	}
+	return filepath.Join(s.copyDir, key, rel), nil
+}
+
+// restCopy receives files from clients copying a job's files to us with
+// CopyToManager(). The only method supported is PUT, with job and path
+// parameters. The job must have been granted a transfer slot.
+func restCopy(s *Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer internal.LogPanic(s.Logger, "jobqueue web server restCopy", false)
+
+		ok := s.httpAuthorized(w, r)
+		if !ok {
+			return
+		}
+
+		if r.Method != http.MethodPut {
+			http.Error(w, "Only PUT is supported", http.StatusBadRequest)
+			return
+		}
+
+		key := r.Form.Get("job")
+		dest, err := s.copyDestination(key, r.Form.Get("path"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !s.transfers.uploadStarted(key) {
+			http.Error(w, "job does not have a transfer slot", http.StatusConflict)
+			return
+		}
+		defer s.transfers.uploadEnded(key)
+
+		if err = os.Remove(dest); err != nil && !os.IsNotExist(err) {
+			s.Warn("restCopy could not remove old copy", "path", dest, "err", err)
+		}
+		savePath, err := s.uploadFile(&transferCounter{r: r.Body, ts: s.transfers, key: key}, dest)
+		if err != nil {
+			http.Error(w, "file copy failed", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		err = encoder.Encode(map[string]string{"path": savePath})
+		if err != nil {
+			s.Warn("restCopy failed to encode success msg", "err", err)
+		}
+	}
+}
+
+// CopyToManager copies files to the machine the server is running on, storing
+// them in a directory named after the given job's key, within the server's
+// configured CopyDir. Relative paths are taken to be relative to dir, and keep
+// their relative location when stored; absolute paths are stored by their
+// basename.
+//
+// This first waits for the server to grant a transfer slot, since it only
+// allows a limited number of transfers at once, then sends the files no faster
+// than the server's configured TransferRate. Returns the paths the files were
+// stored at on the server's machine.
+func (c *Client) CopyToManager(job *Job, dir string, paths []string) ([]string, error) {
+	key := job.Key()
+	rate, err := c.transferSlot(key)
+	if err != nil {
+		return nil, err
+	}
+	defer c.releaseTransferSlot(key)
+
+	httpClient, err := c.webClient()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make([]string, 0, len(paths))
+	for _, path := range paths {
+		local, remote := path, path
+		if filepath.IsAbs(path) {
+			remote = filepath.Base(path)
+		} else {
+			local = filepath.Join(dir, path)
+		}
+
+		savePath, errc := c.copyFile(httpClient, key, local, remote, rate)
+		if errc != nil {
+			return stored, errc
+		}
+		stored = append(stored, savePath)
+	}
+	return stored, nil
+}
+
+// transferSlot waits for the server to grant the job with the given key a
+// transfer slot, returning the rate in bytes per second that it may then send
+// data at. Call releaseTransferSlot() when done.
+func (c *Client) transferSlot(key string) (int64, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		host = localhost
+	}
+
+	for {
+		resp, errr := c.request(&clientRequest{Method: "xferslot", Keys: []string{key}, Host: host})
+		if errr != nil {
+			return 0, errr
+		}
+		if resp.TransferGranted {
+			return resp.TransferRate, nil
+		}
+		<-time.After(ClientTransferPollInterval)
+	}
+}
+
+// releaseTransferSlot lets the server know that the job with the given key has
+// finished with the transfer slot it got from transferSlot().
+func (c *Client) releaseTransferSlot(key string) {
+	if _, err := c.request(&clientRequest{Method: "xferdone", Keys: []string{key}}); err != nil {
+		c.Warn("failed to release transfer slot", "job", key, "err", err)
+	}
+}
+
+// webClient returns an http.Client suitable for talking to the server's web
+// interface.
+func (c *Client) webClient() (*http.Client, error) {
+	if len(c.args) < 3 {
+		return nil, fmt.Errorf("client has no connection details")
+	}
+	tlsConfig := &tls.Config{ServerName: c.args[2]}
+	caCert, err := ioutil.ReadFile(c.args[1])
+	if err == nil {
+		certPool := x509.NewCertPool()
+		certPool.AppendCertsFromPEM(caCert)
+		tlsConfig.RootCAs = certPool
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}, nil
+}
+
+// copyFile sends a local file to the server's restCopy endpoint, to be stored
+// at the given remote path of the job with the given key, no faster than rate
+// bytes per second.
+func (c *Client) copyFile(httpClient *http.Client, key, local, remote string, rate int64) (string, error) {
+	params := url.Values{}
+	params.Set("job", key)
+	params.Set("path", remote)
+	return c.putFile(httpClient, restCopyEndpoint, params, local, rate)
+}
+
+// putFile sends a local file to the given endpoint of the server's web
+// interface, with the given parameters, no faster than rate bytes per second.
+// Returns the path the server says it stored the file at.
+func (c *Client) putFile(httpClient *http.Client, endpoint string, params url.Values, local string, rate int64) (string, error) {
+	f, err := os.Open(local)
+	if err != nil {
+		return "", err
+	}
+	defer internal.LogClose(c.Logger, f, "copy to manager source", "path", local)
+
+	u := "https://" + net.JoinHostPort(c.host, c.ServerInfo.WebPort) + endpoint + "?" + params.Encode()
+
+	req, err := http.NewRequest(http.MethodPut, u, newRateLimitedReader(f, rate))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", bearerSchema+string(c.token))
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer internal.LogClose(c.Logger, resp.Body, "copy to manager response")
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return "", fmt.Errorf("copying %s to the manager failed: %s: %s", local, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var msg map[string]string
+	if err = json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		return "", err
+	}
+	return msg["path"], nil
+}
+
+// GetTransfers returns details of the transfers to the server's machine (by
+// CopyToManager()) that are currently happening or are queued, active ones
+// first.
+func (c *Client) GetTransfers() ([]*Transfer, error) {
+	resp, err := c.request(&clientRequest{Method: "xfers"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Transfers, err
+}
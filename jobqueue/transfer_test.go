@@ -0,0 +1,105 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTransfers(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Transfer slots are granted in order up to the maximum", t, func() {
+		ts := newTransferSlots(2)
+		So(ts.request("a", "host1"), ShouldBeTrue)
+		So(ts.request("b", "host2"), ShouldBeTrue)
+		So(ts.request("c", "host3"), ShouldBeFalse)
+		So(ts.request("d", "host4"), ShouldBeFalse)
+
+		list := ts.list()
+		So(len(list), ShouldEqual, 4)
+		So(list[0].State, ShouldEqual, TransferStateActive)
+		So(list[1].State, ShouldEqual, TransferStateActive)
+		So(list[2].JobKey, ShouldEqual, "c")
+		So(list[2].State, ShouldEqual, TransferStateQueued)
+		So(list[3].JobKey, ShouldEqual, "d")
+
+		So(ts.uploadStarted("c"), ShouldBeFalse)
+		So(ts.uploadStarted("a"), ShouldBeTrue)
+		ts.received("a", 10)
+		ts.uploadEnded("a")
+		So(ts.list()[0].Bytes, ShouldEqual, 10)
+
+		ts.release("b")
+		So(ts.request("d", "host4"), ShouldBeFalse)
+		So(ts.request("c", "host3"), ShouldBeTrue)
+		So(ts.request("c", "host3"), ShouldBeTrue)
+		So(ts.request("d", "host4"), ShouldBeFalse)
+
+		ts.release("d")
+		list = ts.list()
+		So(len(list), ShouldEqual, 2)
+
+		Convey("Transfers not heard about are forgotten", func() {
+			origLease := ServerTransferLeaseTime
+			defer func() {
+				ServerTransferLeaseTime = origLease
+			}()
+			ServerTransferLeaseTime = 10 * time.Millisecond
+
+			So(ts.uploadStarted("a"), ShouldBeTrue)
+			<-time.After(20 * time.Millisecond)
+			list = ts.list()
+			So(len(list), ShouldEqual, 1)
+			So(list[0].JobKey, ShouldEqual, "a")
+
+			ts.uploadEnded("a")
+			<-time.After(20 * time.Millisecond)
+			So(len(ts.list()), ShouldEqual, 0)
+		})
+	})
+
+	Convey("A max of 0 means transfers are unlimited", t, func() {
+		ts := newTransferSlots(0)
+		for _, key := range []string{"a", "b", "c"} {
+			So(ts.request(key, "host"), ShouldBeTrue)
+		}
+	})
+
+	Convey("Reads can be rate limited", t, func() {
+		data := make([]byte, 1000)
+		r := newRateLimitedReader(bytes.NewReader(data), 0)
+		_, limited := r.(*rateLimitedReader)
+		So(limited, ShouldBeFalse)
+
+		r = newRateLimitedReader(bytes.NewReader(data), 4000)
+		start := time.Now()
+		read, err := ioutil.ReadAll(r)
+		So(err, ShouldBeNil)
+		So(len(read), ShouldEqual, len(data))
+		So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 200*time.Millisecond)
+	})
+}
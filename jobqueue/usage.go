@@ -0,0 +1,159 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of resource usage reports, comparing
+// what completed jobs requested against what they actually used.
+
+import (
+	"math"
+	"sort"
+)
+
+// ResourceOverRequestFactor is how many times more than their 95th percentile
+// usage the median request of a RepGroup's jobs has to be for
+// ResourceUsageReport() to consider that resource over-requested.
+const ResourceOverRequestFactor = 2.0
+
+// Resource* are the names of the resources compared by ResourceUsageReport().
+const (
+	ResourceMemory = "memory"
+	ResourceTime   = "time"
+	ResourceCPUs   = "cpus"
+)
+
+// ResourceDistribution summarises the distribution of some resource amount
+// over a set of jobs.
+type ResourceDistribution struct {
+	Min    float64 `json:"min"`
+	Median float64 `json:"median"`
+	P95    float64 `json:"p95"`
+	Max    float64 `json:"max"`
+}
+
+// ResourceComparison compares the requested and used amounts of a resource.
+type ResourceComparison struct {
+	Requested ResourceDistribution `json:"requested"`
+	Used      ResourceDistribution `json:"used"`
+}
+
+// OverRequested returns true if the median requested amount is at least
+// ResourceOverRequestFactor times the 95th percentile of the amount used.
+func (rc ResourceComparison) OverRequested() bool {
+	return rc.Requested.Median > 0 && rc.Requested.Median >= rc.Used.P95*ResourceOverRequestFactor
+}
+
+// ResourceUsage is the report of ResourceUsageReport() for a single RepGroup.
+// Memory is in MB, Time is in seconds, and CPUs are in cores (where the used
+// amount is the job's CPU time divided by its wall time).
+type ResourceUsage struct {
+	RepGroup string             `json:"rep_grp"`
+	Jobs     int                `json:"jobs"`
+	Memory   ResourceComparison `json:"memory"`
+	Time     ResourceComparison `json:"time"`
+	CPUs     ResourceComparison `json:"cpus"`
+
+	// OverRequested lists the Resource* that the RepGroup's jobs requested
+	// far more of than they used, so that their requirements could be
+	// reduced.
+	OverRequested []string `json:"over_requested"`
+}
+
+// ResourceUsageReport takes some jobs (eg. from Client.GetByRepGroup()) and,
+// considering only the complete ones that actually ran, returns a report for
+// each of their RepGroups on how much memory, time and CPU they requested vs
+// how much they actually used. The reports are sorted by RepGroup.
+func ResourceUsageReport(jobs []*Job) []*ResourceUsage {
+	type samples struct {
+		reqRAM, usedRAM, reqTime, usedTime, reqCPUs, usedCPUs []float64
+	}
+	byRG := make(map[string]*samples)
+	for _, job := range jobs {
+		if job.State != JobStateComplete || job.Requirements == nil || job.StartTime.IsZero() {
+			continue
+		}
+		s, exists := byRG[job.RepGroup]
+		if !exists {
+			s = &samples{}
+			byRG[job.RepGroup] = s
+		}
+
+		wall := job.WallTime()
+		var cpus float64
+		if wall > 0 {
+			cpus = job.CPUtime.Seconds() / wall.Seconds()
+		}
+		s.reqRAM = append(s.reqRAM, float64(job.Requirements.RAM))
+		s.usedRAM = append(s.usedRAM, float64(job.PeakRAM))
+		s.reqTime = append(s.reqTime, job.Requirements.Time.Seconds())
+		s.usedTime = append(s.usedTime, wall.Seconds())
+		s.reqCPUs = append(s.reqCPUs, job.Requirements.Cores)
+		s.usedCPUs = append(s.usedCPUs, cpus)
+	}
+
+	reports := make([]*ResourceUsage, 0, len(byRG))
+	for rg, s := range byRG {
+		ru := &ResourceUsage{
+			RepGroup: rg,
+			Jobs:     len(s.reqRAM),
+			Memory:   ResourceComparison{Requested: distribution(s.reqRAM), Used: distribution(s.usedRAM)},
+			Time:     ResourceComparison{Requested: distribution(s.reqTime), Used: distribution(s.usedTime)},
+			CPUs:     ResourceComparison{Requested: distribution(s.reqCPUs), Used: distribution(s.usedCPUs)},
+		}
+		if ru.Memory.OverRequested() {
+			ru.OverRequested = append(ru.OverRequested, ResourceMemory)
+		}
+		if ru.Time.OverRequested() {
+			ru.OverRequested = append(ru.OverRequested, ResourceTime)
+		}
+		if ru.CPUs.OverRequested() {
+			ru.OverRequested = append(ru.OverRequested, ResourceCPUs)
+		}
+		reports = append(reports, ru)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].RepGroup < reports[j].RepGroup
+	})
+	return reports
+}
+
+// distribution returns the ResourceDistribution of the given values, which
+// will be sorted.
+func distribution(values []float64) ResourceDistribution {
+	if len(values) == 0 {
+		return ResourceDistribution{}
+	}
+	sort.Float64s(values)
+	return ResourceDistribution{
+		Min:    values[0],
+		Median: percentile(values, 50),
+		P95:    percentile(values, 95),
+		Max:    values[len(values)-1],
+	}
+}
+
+// percentile returns the nearest-rank pth percentile of the given sorted
+// values.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
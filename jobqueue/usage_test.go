@@ -0,0 +1,93 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/VertebrateResequencing/wr/jobqueue/scheduler"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResourceUsageReport(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	Convey("Given some completed and incomplete jobs", t, func() {
+		start := time.Now().Add(-1 * time.Hour)
+		var jobs []*Job
+		for i := 1; i <= 20; i++ {
+			jobs = append(jobs, &Job{
+				Cmd:          fmt.Sprintf("greedy %d", i),
+				RepGroup:     "greedy",
+				State:        JobStateComplete,
+				Requirements: &scheduler.Requirements{RAM: 8000, Time: 4 * time.Hour, Cores: 4},
+				PeakRAM:      i * 100,
+				StartTime:    start,
+				EndTime:      start.Add(time.Duration(i) * time.Minute),
+				CPUtime:      time.Duration(i) * time.Minute,
+			})
+		}
+		jobs = append(jobs, &Job{
+			Cmd:          "tight",
+			RepGroup:     "accurate",
+			State:        JobStateComplete,
+			Requirements: &scheduler.Requirements{RAM: 1000, Time: 1 * time.Hour, Cores: 1},
+			PeakRAM:      900,
+			StartTime:    start,
+			EndTime:      start.Add(50 * time.Minute),
+			CPUtime:      45 * time.Minute,
+		})
+		jobs = append(jobs, &Job{
+			Cmd:          "waiting",
+			RepGroup:     "accurate",
+			State:        JobStateReady,
+			Requirements: &scheduler.Requirements{RAM: 100000, Time: 100 * time.Hour, Cores: 100},
+		})
+
+		Convey("ResourceUsageReport() compares requested and used resources per RepGroup", func() {
+			reports := ResourceUsageReport(jobs)
+			So(len(reports), ShouldEqual, 2)
+
+			So(reports[0].RepGroup, ShouldEqual, "accurate")
+			So(reports[0].Jobs, ShouldEqual, 1)
+			So(reports[0].Memory.Requested.Max, ShouldEqual, 1000)
+			So(reports[0].Memory.Used.Median, ShouldEqual, 900)
+			So(reports[0].CPUs.Used.Max, ShouldAlmostEqual, 0.9)
+			So(reports[0].OverRequested, ShouldBeEmpty)
+
+			greedy := reports[1]
+			So(greedy.RepGroup, ShouldEqual, "greedy")
+			So(greedy.Jobs, ShouldEqual, 20)
+			So(greedy.Memory.Requested, ShouldResemble, ResourceDistribution{Min: 8000, Median: 8000, P95: 8000, Max: 8000})
+			So(greedy.Memory.Used, ShouldResemble, ResourceDistribution{Min: 100, Median: 1000, P95: 1900, Max: 2000})
+			So(greedy.Time.Used.Max, ShouldEqual, 1200)
+			So(greedy.Time.Requested.Median, ShouldEqual, 14400)
+			So(greedy.CPUs.Used.Median, ShouldAlmostEqual, 1)
+			So(greedy.OverRequested, ShouldResemble, []string{ResourceMemory, ResourceTime, ResourceCPUs})
+		})
+
+		Convey("ResourceUsageReport() ignores jobs that haven't completed", func() {
+			So(ResourceUsageReport(jobs[21:]), ShouldBeEmpty)
+		})
+	})
+}
@@ -32,14 +32,18 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/VertebrateResequencing/wr/internal"
 	"github.com/VertebrateResequencing/wr/jobqueue/scheduler"
 	"github.com/dgryski/go-farm"
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/shirou/gopsutil/disk"
 	"github.com/shirou/gopsutil/process"
 )
 
@@ -60,6 +64,12 @@ var cr = []byte("\r")
 var lf = []byte("\n")
 var ellipses = []byte("[...]\n")
 
+// cleanEnvPath and cleanEnvKeys are the basis of the environment used to get a
+// clean login environment in cleanLoginEnv()
+const cleanEnvPath = "/usr/local/bin:/usr/bin:/bin"
+
+var cleanEnvKeys = []string{"HOME", "USER", "LOGNAME", "SHELL", "LANG"}
+
 // generateToken creates a cryptographically secure pseudorandom URL-safe base64
 // encoded string 43 bytes long. Used by the server to create a token passed to
 // to the caller for subsequent client authentication. If the given file exists
@@ -163,9 +173,47 @@ func decompress(compressed []byte) ([]byte, error) {
 	return buf.Bytes(), err
 }
 
-// get the current memory usage of a pid and all its children, relying on modern
-// linux /proc/*/smaps (based on http://stackoverflow.com/a/31881979/675083).
+// currentMemory gets the current memory usage in MB of a pid and all its
+// children. On linux (any architecture) this is the proportional set size from
+// /proc/*/smaps, which fairly accounts for memory shared between processes.
+// Elsewhere, eg. on macOS, it is the resident set size from the operating
+// system's process information.
 func currentMemory(pid int) (int, error) {
+	var mem int
+	var err error
+	if runtime.GOOS == "linux" {
+		mem, err = smapsMemory(pid)
+	} else {
+		mem, err = rssMemory(pid)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// recurse for children
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return mem, err
+	}
+	children, err := p.Children()
+	if err != nil && err.Error() != "process does not have children" { // err != process.ErrorNoChildren
+		return mem, err
+	}
+	for _, child := range children {
+		childMem, errr := currentMemory(int(child.Pid))
+		if errr != nil {
+			continue
+		}
+		mem += childMem
+	}
+
+	return mem, nil
+}
+
+// smapsMemory gets the current memory usage in MB of a pid (not including its
+// children), relying on modern linux /proc/*/smaps (based on
+// http://stackoverflow.com/a/31881979/675083).
+func smapsMemory(pid int) (mem int, err error) {
 	f, err := os.Open(fmt.Sprintf("/proc/%d/smaps", pid))
 	if err != nil {
 		return 0, err
@@ -199,26 +247,42 @@ func currentMemory(pid int) (int, error) {
 	}
 
 	// convert kB to MB
-	mem := int(kb / 1024)
+	return int(kb / 1024), nil
+}
 
-	// recurse for children
+// rssMemory gets the current resident set size in MB of a pid (not including
+// its children), for systems without /proc/*/smaps.
+func rssMemory(pid int) (int, error) {
 	p, err := process.NewProcess(int32(pid))
 	if err != nil {
-		return mem, err
+		return 0, err
 	}
-	children, err := p.Children()
-	if err != nil && err.Error() != "process does not have children" { // err != process.ErrorNoChildren
-		return mem, err
+	info, err := p.MemoryInfo()
+	if err != nil {
+		return 0, err
 	}
-	for _, child := range children {
-		childMem, errr := currentMemory(int(child.Pid))
-		if errr != nil {
-			continue
-		}
-		mem += childMem
+
+	// convert bytes to MB
+	return int(info.RSS / 1024 / 1024), nil
+}
+
+// maxRSSMB converts the Maxrss of the given Rusage to MB. Linux (on all
+// architectures) and the BSDs report it in kB, but macOS reports bytes.
+func maxRSSMB(rusage *syscall.Rusage) int {
+	if runtime.GOOS == "darwin" {
+		return int((rusage.Maxrss / 1024) / 1024)
 	}
+	return int(rusage.Maxrss / 1024)
+}
 
-	return mem, nil
+// diskAvailable returns the disk space available to non-root users on the file
+// system that holds path, in GBs.
+func diskAvailable(path string) (int, error) {
+	usage, err := disk.Usage(path)
+	if err != nil {
+		return 0, err
+	}
+	return int(usage.Free / (1024 * 1024 * 1024)), nil
 }
 
 // get the current disk usage within a directory, in MBs. Optionally, provide a
@@ -402,6 +466,58 @@ func stdFilter(std io.Reader, out io.Writer) chan error {
 	return done
 }
 
+// FilterEnv returns only those environment variables (in os.Environ() format)
+// whose keys are in the given keys. This is useful for capturing only some of
+// your environment when adding Jobs with CleanEnv set.
+func FilterEnv(envars []string, keys []string) []string {
+	wanted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		wanted[strings.TrimSpace(key)] = true
+	}
+
+	var filtered []string
+	for _, envvar := range envars {
+		pair := strings.SplitN(envvar, "=", 2)
+		if wanted[pair[0]] {
+			filtered = append(filtered, envvar)
+		}
+	}
+	return filtered
+}
+
+// cleanLoginEnv returns the environment variables that a fresh login of the
+// current user would get with the given shell, starting from only a few basic
+// variables of the current environment. If that fails, just those basic
+// variables are returned.
+func cleanLoginEnv(shell string) []string {
+	basic := []string{"PATH=" + cleanEnvPath}
+	for _, key := range cleanEnvKeys {
+		if val, set := os.LookupEnv(key); set {
+			basic = append(basic, key+"="+val)
+		}
+	}
+
+	cmd := exec.Command(shell, "-l", "-c", "env -0") // #nosec
+	cmd.Env = basic
+	out, err := cmd.Output()
+	if err != nil {
+		return basic
+	}
+
+	var env []string
+	for _, envvar := range bytes.Split(out, []byte{0}) {
+		// a login might print other things before env's output, so only keep
+		// lines that look like variables
+		if eq := bytes.IndexByte(envvar, '='); eq > 0 && !bytes.ContainsAny(envvar[:eq], " \n\t") {
+			env = append(env, string(envvar))
+		}
+	}
+	if len(env) == 0 {
+		return basic
+	}
+	return env
+}
+
 // envOverride deals with values you get from os.Environ, overriding one set
 // with values from another. Returns the new slice of environment variables.
 func envOverride(orig []string, over []string) []string {
@@ -441,6 +557,13 @@ func calculateHashedDir(baseDir, tohash string) (string, string) {
 // there were problems making the directories.
 func mkHashedDir(baseDir, tohash string) (cwd, tmpDir string, err error) {
 	dir, leaf := calculateHashedDir(filepath.Join(baseDir, AppName+"_cwd"), tohash)
+	return mkUniqueDir(dir, leaf)
+}
+
+// mkUniqueDir creates dir if necessary, then creates a uniquely named folder
+// within it starting with leaf, and in that creates 2 folders called cwd and
+// tmp, which it returns.
+func mkUniqueDir(dir, leaf string) (cwd, tmpDir string, err error) {
 	holdFile := filepath.Join(dir, ".hold")
 	defer func() {
 		errr := os.Remove(holdFile)
@@ -485,12 +608,12 @@ func mkHashedDir(baseDir, tohash string) (cwd, tmpDir string, err error) {
 		break
 	}
 
-	// if tohash is a job key then we expect that only 1 of that job is
+	// if leaf is from a job key then we expect that only 1 of that job is
 	// running at any one time per jobqueue, but there could be multiple users
 	// running the same cmd, or this user could be running the same command in
 	// multiple queues, so we must still create a unique dir at the leaf of our
-	// hashed dir structure, to avoid any conflict of multiple processes using
-	// the same working directory
+	// dir structure, to avoid any conflict of multiple processes using the
+	// same working directory
 	dir, err = ioutil.TempDir(dir, leaf)
 	if err != nil {
 		return cwd, tmpDir, err
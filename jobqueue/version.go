@@ -0,0 +1,89 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the implementation of version skew detection, where
+// clients tell the server what version they are, so that the server can refuse
+// requests from clients it can't understand with a clear error, instead of
+// them failing in confusing ways.
+
+import (
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+// ProtocolVersion is the version of the protocol clients and the server use to
+// talk to each other. It must be incremented whenever a change means that
+// clients and servers of different versions can no longer understand each
+// other's requests and responses.
+//
+// Version 2 frames requests (other than pings) and responses so that large
+// byte payloads are sent outside of the codec encoding.
+const ProtocolVersion = 2
+
+// ServerVersionWarnTime is how long the server waits before warning again
+// about the same client being a different version to itself.
+var ServerVersionWarnTime = 1 * time.Hour
+
+// versionChecker remembers which clients we've warned about, so that we don't
+// warn about every request they make.
+type versionChecker struct {
+	warned *cache.Cache
+}
+
+// newVersionChecker creates a versionChecker that repeats warnings about a
+// client after expiry.
+func newVersionChecker(expiry time.Duration) *versionChecker {
+	return &versionChecker{warned: cache.New(expiry, 2*expiry)}
+}
+
+// checkClientVersion checks the version of the client that made the given
+// request. Returns ErrIncompatible if the client uses a different
+// protocol to us. Clients too old to say what protocol they use, and those
+// using our protocol but with a different build version, are allowed, but
+// warned about in our log.
+//
+// ping requests are always allowed, so that clients can find out our version.
+func (s *Server) checkClientVersion(cr *clientRequest) string {
+	if cr.Method == "ping" || (cr.ProtocolVersion == ProtocolVersion && cr.ClientVersion == ServerVersion) {
+		return ""
+	}
+
+	key := cr.ClientID.String()
+	_, warned := s.versions.warned.Get(key)
+	s.versions.warned.SetDefault(key, true)
+
+	switch {
+	case cr.ProtocolVersion == 0:
+		if !warned {
+			s.Warn("client did not report its version; it may be too old to work correctly", "clientid", key, "method", cr.Method, "server", ServerVersion)
+		}
+	case cr.ProtocolVersion != ProtocolVersion:
+		if !warned {
+			s.Warn("refusing requests from incompatible client", "clientid", key, "client", cr.ClientVersion, "protocol", cr.ProtocolVersion, "server", ServerVersion, "server_protocol", ProtocolVersion)
+		}
+		return ErrIncompatible
+	default:
+		if !warned {
+			s.Warn("client is a different version", "clientid", key, "client", cr.ClientVersion, "server", ServerVersion)
+		}
+	}
+	return ""
+}
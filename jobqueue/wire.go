@@ -0,0 +1,261 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+// This file contains the framing of the requests and responses that clients
+// and the server send each other, which keeps large byte payloads out of the
+// codec so that they are neither copied while decoding nor decoded at all
+// until used.
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/ugorji/go/codec"
+	"nanomsg.org/go-mangos"
+)
+
+// wireMagic starts every framed message. Its first byte is a binc nil, which
+// can never start the encoding of a clientRequest or serverResponse, so we can
+// tell framed messages apart from the plain codec encoding used by older
+// clients (and by all clients for pings, so that servers of any version can
+// tell them their version).
+var wireMagic = []byte{0x00, 'w', 'r', 0x01}
+
+// wireBlobMin is the length at or above which byte payloads are sent as blobs
+// instead of being encoded inline.
+const wireBlobMin = 1024
+
+// wireJobSizeHint is roughly how many bytes a Job takes to encode, for sizing
+// message buffers.
+const wireJobSizeHint = 512
+
+// wireHeaderPoolMax is the capacity beyond which encodeFrame() buffers are not
+// kept for reuse, so that a rare huge message doesn't pin lots of memory.
+const wireHeaderPoolMax = 1024 * 1024
+
+// wireHeaderPool holds the buffers that encodeFrame() encodes in to.
+var wireHeaderPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, wireBlobMin)
+		return &b
+	},
+}
+
+// errBadFrame is returned when a framed message is malformed.
+var errBadFrame = errors.New("malformed message frame")
+
+// wireField identifies which []byte field of a message (or of one of its Jobs)
+// a blob belongs to.
+type wireField uint8
+
+const (
+	wireFieldEnv wireField = iota + 1
+	wireFieldFile
+	wireFieldDB
+	wireFieldJobEnvC
+	wireFieldJobStdOutC
+	wireFieldJobStdErrC
+	wireFieldJobEnvOverride
+)
+
+// wireBlob is a []byte field that can be sent as a blob. owner is 0 for a
+// field of the message itself, 1 for its Job and 2+i for its Jobs[i].
+type wireBlob struct {
+	owner uint32
+	field wireField
+	ptr   *[]byte
+}
+
+// jobBlobs returns the blob fields of the given job.
+func jobBlobs(owner uint32, job *Job) []wireBlob {
+	if job == nil {
+		return nil
+	}
+	return []wireBlob{
+		{owner, wireFieldJobEnvC, &job.EnvC},
+		{owner, wireFieldJobStdOutC, &job.StdOutC},
+		{owner, wireFieldJobStdErrC, &job.StdErrC},
+		{owner, wireFieldJobEnvOverride, &job.EnvOverride},
+	}
+}
+
+// wireBlobs returns the blob fields of the given clientRequest or
+// serverResponse. If withJobs is false, only the fields of the message itself
+// are returned.
+func wireBlobs(v interface{}, withJobs bool) []wireBlob {
+	var blobs []wireBlob
+	var job *Job
+	var jobs []*Job
+	switch m := v.(type) {
+	case *clientRequest:
+		blobs = []wireBlob{{0, wireFieldEnv, &m.Env}, {0, wireFieldFile, &m.File}}
+		job, jobs = m.Job, m.Jobs
+	case *serverResponse:
+		blobs = []wireBlob{{0, wireFieldDB, &m.DB}}
+		job, jobs = m.Job, m.Jobs
+	}
+	if !withJobs {
+		return blobs
+	}
+	blobs = append(blobs, jobBlobs(1, job)...)
+	for i, j := range jobs {
+		blobs = append(blobs, jobBlobs(uint32(i+2), j)...)
+	}
+	return blobs
+}
+
+// encodeFrame appends to dst a framed encoding of the given clientRequest or
+// serverResponse: wireMagic, then the 4 byte length of the codec encoding of v
+// without its large []byte fields, then that encoding, then each large field
+// as a blob of 4 byte owner, 1 byte field, 4 byte length and the bytes
+// themselves.
+//
+// Fields are temporarily set to nil while encoding v, so with withJobs true
+// (which also sends the fields of its Jobs as blobs) v's Jobs must not be in
+// use by anything else.
+func encodeFrame(dst []byte, v interface{}, ch codec.Handle, withJobs bool) ([]byte, error) {
+	var sent []wireBlob
+	var saved [][]byte
+	for _, blob := range wireBlobs(v, withJobs) {
+		if len(*blob.ptr) >= wireBlobMin {
+			sent = append(sent, blob)
+			saved = append(saved, *blob.ptr)
+			*blob.ptr = nil
+		}
+	}
+
+	// (the codec writes from the start of the buffer it is given, so can't
+	// append to dst itself)
+	bp := wireHeaderPool.Get().(*[]byte)
+	defer func() {
+		if cap(*bp) <= wireHeaderPoolMax {
+			wireHeaderPool.Put(bp)
+		}
+	}()
+	header := (*bp)[:0]
+	enc := codec.NewEncoderBytes(&header, ch)
+	err := enc.Encode(v)
+	for i, blob := range sent {
+		*blob.ptr = saved[i]
+	}
+	if err != nil {
+		return nil, err
+	}
+	*bp = header
+
+	var head [9]byte
+	dst = append(dst, wireMagic...)
+	binary.BigEndian.PutUint32(head[0:4], uint32(len(header)))
+	dst = append(dst, head[0:4]...)
+	dst = append(dst, header...)
+	for _, blob := range sent {
+		binary.BigEndian.PutUint32(head[0:4], blob.owner)
+		head[4] = byte(blob.field)
+		binary.BigEndian.PutUint32(head[5:9], uint32(len(*blob.ptr)))
+		dst = append(dst, head[:]...)
+		dst = append(dst, *blob.ptr...)
+	}
+	return dst, nil
+}
+
+// isFramed tells you if the given encoded message was made by encodeFrame().
+func isFramed(b []byte) bool {
+	return len(b) >= len(wireMagic) && string(b[:len(wireMagic)]) == string(wireMagic)
+}
+
+// decodeFrame decodes a message made by encodeFrame() in to v (a
+// *clientRequest or *serverResponse), or a plain codec encoding of one.
+//
+// Blobs are not copied: v's fields are set to slices of b, so b must not be
+// reused while v is in use. Returns true if any were, false if everything was
+// copied out of b, so that b can be reused immediately. With copyJobs true,
+// the blobs of v's Jobs are copied, so that only v's own fields refer to b and
+// its Jobs can outlive it.
+func decodeFrame(b []byte, v interface{}, ch codec.Handle, copyJobs bool) (bool, error) {
+	if !isFramed(b) {
+		dec := codec.NewDecoderBytes(b, ch)
+		return false, dec.Decode(v)
+	}
+
+	b = b[len(wireMagic):]
+	if len(b) < 4 {
+		return false, errBadFrame
+	}
+	headerLen := binary.BigEndian.Uint32(b)
+	b = b[4:]
+	if uint64(headerLen) > uint64(len(b)) {
+		return false, errBadFrame
+	}
+	dec := codec.NewDecoderBytes(b[:headerLen], ch)
+	if err := dec.Decode(v); err != nil {
+		return false, err
+	}
+	b = b[headerLen:]
+	if len(b) == 0 {
+		return false, nil
+	}
+
+	shared := false
+	fields := make(map[wireBlob]*[]byte)
+	for _, blob := range wireBlobs(v, true) {
+		fields[wireBlob{owner: blob.owner, field: blob.field}] = blob.ptr
+	}
+	for len(b) > 0 {
+		if len(b) < 9 {
+			return false, errBadFrame
+		}
+		key := wireBlob{owner: binary.BigEndian.Uint32(b[0:4]), field: wireField(b[4])}
+		blobLen := binary.BigEndian.Uint32(b[5:9])
+		b = b[9:]
+		ptr, known := fields[key]
+		if !known || uint64(blobLen) > uint64(len(b)) {
+			return false, errBadFrame
+		}
+		if copyJobs && key.owner != 0 {
+			*ptr = append([]byte(nil), b[:blobLen]...)
+		} else {
+			*ptr = b[:blobLen:blobLen]
+			shared = true
+		}
+		b = b[blobLen:]
+	}
+	return shared, nil
+}
+
+// encodeRequest encodes the given clientRequest in to a (pooled) message ready
+// to send to the server. Pings are not framed, so that servers of any version
+// can understand them. The request's Jobs are encoded inline, since they
+// belong to the caller.
+func encodeRequest(cr *clientRequest, ch codec.Handle) (*mangos.Message, error) {
+	msg := mangos.NewMessage(len(cr.Env) + len(cr.File) + wireJobSizeHint*len(cr.Jobs) + wireBlobMin)
+	var err error
+	if cr.Method == "ping" {
+		enc := codec.NewEncoderBytes(&msg.Body, ch)
+		err = enc.Encode(cr)
+	} else {
+		msg.Body, err = encodeFrame(msg.Body, cr, ch, false)
+	}
+	if err != nil {
+		msg.Free()
+		return nil, err
+	}
+	return msg, nil
+}
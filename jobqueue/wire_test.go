@@ -0,0 +1,152 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package jobqueue
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWire(t *testing.T) {
+	if runnermode || servermode {
+		return
+	}
+
+	ch := new(codec.BincHandle)
+	env := bytes.Repeat([]byte("e"), wireBlobMin*2)
+	std := bytes.Repeat([]byte("o"), wireBlobMin)
+
+	Convey("Requests are framed with their large payloads as blobs", t, func() {
+		cr := &clientRequest{Method: "add", Env: env, File: []byte("small"), Jobs: []*Job{{Cmd: "echo a", EnvOverride: env}}}
+		b, err := encodeFrame(nil, cr, ch, false)
+		So(err, ShouldBeNil)
+		So(isFramed(b), ShouldBeTrue)
+		So(cr.Env, ShouldResemble, env)
+
+		got := &clientRequest{}
+		shared, err := decodeFrame(b, got, ch, true)
+		So(err, ShouldBeNil)
+		So(shared, ShouldBeTrue)
+		So(got.Method, ShouldEqual, "add")
+		So(got.Env, ShouldResemble, env)
+		So(got.File, ShouldResemble, []byte("small"))
+		So(len(got.Jobs), ShouldEqual, 1)
+		So(got.Jobs[0].EnvOverride, ShouldResemble, env)
+
+		Convey("Blobs are views of the message, except for Jobs' when asked", func() {
+			b[len(b)-1] = 'x'
+			So(got.Env[len(got.Env)-1], ShouldEqual, 'x')
+		})
+	})
+
+	Convey("Responses can send their Jobs' payloads as blobs", t, func() {
+		sr := &serverResponse{Job: &Job{Cmd: "echo a", EnvC: env}, Jobs: []*Job{{Cmd: "echo b"}, {Cmd: "echo c", StdOutC: std, StdErrC: []byte("err")}}}
+		b, err := encodeFrame(nil, sr, ch, true)
+		So(err, ShouldBeNil)
+		So(sr.Job.EnvC, ShouldResemble, env)
+		So(len(b), ShouldBeGreaterThan, len(env)+len(std))
+
+		got := &serverResponse{}
+		shared, err := decodeFrame(b, got, ch, false)
+		So(err, ShouldBeNil)
+		So(shared, ShouldBeTrue)
+		So(got.Job.EnvC, ShouldResemble, env)
+		So(got.Jobs[0].StdOutC, ShouldBeNil)
+		So(got.Jobs[1].StdOutC, ShouldResemble, std)
+		So(got.Jobs[1].StdErrC, ShouldResemble, []byte("err"))
+
+		b[len(b)-1] = 'x'
+		So(got.Jobs[1].StdOutC[len(std)-1], ShouldEqual, 'x')
+	})
+
+	Convey("Messages without blobs don't share the buffer", t, func() {
+		b, err := encodeFrame(nil, &serverResponse{Path: "/a"}, ch, true)
+		So(err, ShouldBeNil)
+		got := &serverResponse{}
+		shared, err := decodeFrame(b, got, ch, false)
+		So(err, ShouldBeNil)
+		So(shared, ShouldBeFalse)
+		So(got.Path, ShouldEqual, "/a")
+	})
+
+	Convey("Plain codec encodings can still be decoded", t, func() {
+		var b []byte
+		enc := codec.NewEncoderBytes(&b, ch)
+		So(enc.Encode(&clientRequest{Method: "ping", Env: env}), ShouldBeNil)
+		So(isFramed(b), ShouldBeFalse)
+
+		got := &clientRequest{}
+		shared, err := decodeFrame(b, got, ch, false)
+		So(err, ShouldBeNil)
+		So(shared, ShouldBeFalse)
+		So(got.Method, ShouldEqual, "ping")
+		So(got.Env, ShouldResemble, env)
+	})
+
+	Convey("Malformed frames are rejected", t, func() {
+		b, err := encodeFrame(nil, &clientRequest{Method: "add", Env: env}, ch, false)
+		So(err, ShouldBeNil)
+
+		_, err = decodeFrame(b[:len(b)-1], &clientRequest{}, ch, false)
+		So(err, ShouldEqual, errBadFrame)
+		_, err = decodeFrame(b[:len(wireMagic)+2], &clientRequest{}, ch, false)
+		So(err, ShouldEqual, errBadFrame)
+
+		bad := append([]byte(nil), b...)
+		bad[len(bad)-len(env)-5] = 99
+		_, err = decodeFrame(bad, &clientRequest{}, ch, false)
+		So(err, ShouldEqual, errBadFrame)
+	})
+}
+
+// BenchmarkWireDecodeAdd shows the allocations the server makes decoding a
+// request to add jobs with a large environment, framed or not.
+func BenchmarkWireDecodeAdd(b *testing.B) {
+	ch := new(codec.BincHandle)
+	cr := &clientRequest{Method: "add", Env: bytes.Repeat([]byte("e"), 256*1024)}
+	for i := 0; i < 100; i++ {
+		cr.Jobs = append(cr.Jobs, &Job{Cmd: "echo", Cwd: "/tmp", RepGroup: "bench"})
+	}
+
+	var plain []byte
+	enc := codec.NewEncoderBytes(&plain, ch)
+	if err := enc.Encode(cr); err != nil {
+		b.Fatal(err)
+	}
+	framed, err := encodeFrame(nil, cr, ch, false)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for name, encoded := range map[string][]byte{"plain": plain, "framed": framed} {
+		encoded := encoded
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := decodeFrame(encoded, &clientRequest{}, ch, true); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
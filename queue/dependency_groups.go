@@ -0,0 +1,208 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package queue
+
+// This file implements named dependency groups, which items can join and
+// depend upon, so that an item can depend on every member of a group without
+// knowing their keys, including members added after it was.
+
+// DepGroupPolicy describes what happens to the items dependent upon a
+// dependency group when a new member joins the group after they were added.
+type DepGroupPolicy int
+
+// DepGroupPolicy* constants are the possible DepGroupPolicy values.
+// DepGroupWaitForLate, the default, means that items still in the dependent
+// sub-queue will also wait for the new member to be removed. DepGroupIgnoreLate
+// means that items only ever wait for the members of the group at the time they
+// were added. DepGroupRecallForLate is like DepGroupWaitForLate, but also
+// switches items in the delay or ready sub-queues back to the dependent
+// sub-queue.
+const (
+	DepGroupWaitForLate DepGroupPolicy = iota
+	DepGroupIgnoreLate
+	DepGroupRecallForLate
+)
+
+// SetDepGroupPolicy sets the DepGroupPolicy of the given dependency group,
+// which affects members that join the group from now on.
+func (queue *Queue) SetDepGroupPolicy(group string, policy DepGroupPolicy) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	if policy == DepGroupWaitForLate {
+		delete(queue.depGroupPolicies, group)
+		return
+	}
+	queue.depGroupPolicies[group] = policy
+}
+
+// DepGroupMembers returns the keys of the items currently in the queue that are
+// members of the given dependency group.
+func (queue *Queue) DepGroupMembers(group string) []string {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+	keys := make([]string, 0, len(queue.depGroupMembers[group]))
+	for key := range queue.depGroupMembers[group] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// joinDepGroups makes the given item a member of the given dependency groups,
+// applying the groups' DepGroupPolicy to the items already dependent upon them.
+// Returns the items that were switched to the dependent sub-queue, keyed on the
+// sub-queue they were switched from. You must hold the queue's mutex lock
+// before calling this.
+func (queue *Queue) joinDepGroups(item *Item, groups []string) map[SubQueue][]*Item {
+	item.depGroups = groups
+	recalled := make(map[SubQueue][]*Item)
+	for _, group := range groups {
+		if _, exists := queue.depGroupMembers[group]; !exists {
+			queue.depGroupMembers[group] = make(map[string]*Item)
+		}
+		queue.depGroupMembers[group][item.Key] = item
+
+		policy := queue.depGroupPolicies[group]
+		if policy == DepGroupIgnoreLate {
+			continue
+		}
+		for _, dependant := range queue.depGroupDependants[group] {
+			if dependant == item {
+				continue
+			}
+			if from, switched := queue.addLateDependency(dependant, item.Key, policy == DepGroupRecallForLate); switched {
+				recalled[from] = append(recalled[from], dependant)
+			}
+		}
+	}
+	return recalled
+}
+
+// addLateDependency makes the given dependant item also depend on the item with
+// the given key, if it is still in the dependent sub-queue, or if recall is
+// true and it is in the delay or ready sub-queue, in which case it is switched
+// to the dependent sub-queue and the sub-queue it came from is returned along
+// with true. You must hold the queue's mutex lock before calling this.
+func (queue *Queue) addLateDependency(dependant *Item, key string, recall bool) (SubQueue, bool) {
+	var from SubQueue
+	s := queue.shardFor(dependant.Key)
+	switch dependant.State() {
+	case ItemStateDependent:
+	case ItemStateDelay:
+		if !recall {
+			return from, false
+		}
+		s.delayQueue.remove(dependant)
+		dependant.switchDelayDependent()
+		s.depQueue.push(dependant)
+		from = SubQueueDelay
+	case ItemStateReady:
+		if !recall {
+			return from, false
+		}
+		s.readyQueue.remove(dependant)
+		dependant.switchReadyDependent()
+		s.depQueue.push(dependant)
+		from = SubQueueReady
+	default:
+		return from, false
+	}
+
+	dependant.addDependency(key)
+	if _, exists := queue.dependants[key]; !exists {
+		queue.dependants[key] = make(map[string]*Item)
+	}
+	queue.dependants[key][dependant.Key] = dependant
+	return from, from != ""
+}
+
+// setGroupDependencies notes that the given item depends on the given
+// dependency groups, and returns the given deps plus the keys of the groups'
+// current members. You must hold the queue's mutex lock before calling this.
+func (queue *Queue) setGroupDependencies(item *Item, groups []string, deps []string) []string {
+	item.groupDeps = groups
+	for _, group := range groups {
+		if _, exists := queue.depGroupDependants[group]; !exists {
+			queue.depGroupDependants[group] = make(map[string]*Item)
+		}
+		queue.depGroupDependants[group][item.Key] = item
+	}
+	return queue.withGroupMembers(item, deps)
+}
+
+// withGroupMembers returns the given deps plus the keys of the current members
+// of the dependency groups the given item depends on, without duplicates. You
+// must hold the queue's mutex lock before calling this.
+func (queue *Queue) withGroupMembers(item *Item, deps []string) []string {
+	if len(item.groupDeps) == 0 {
+		return deps
+	}
+
+	seen := make(map[string]bool, len(deps))
+	merged := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		if !seen[dep] {
+			seen[dep] = true
+			merged = append(merged, dep)
+		}
+	}
+	for _, group := range item.groupDeps {
+		for key := range queue.depGroupMembers[group] {
+			if key != item.Key && !seen[key] {
+				seen[key] = true
+				merged = append(merged, key)
+			}
+		}
+	}
+	return merged
+}
+
+// leaveDepGroups removes the given item from our lookups of dependency group
+// members and dependants. You must hold the queue's mutex lock before calling
+// this.
+func (queue *Queue) leaveDepGroups(item *Item) {
+	removeFromGroupLookup(queue.depGroupMembers, item.depGroups, item.Key)
+	removeFromGroupLookup(queue.depGroupDependants, item.groupDeps, item.Key)
+}
+
+// changeDepGroupsKey updates our lookups of dependency group members and
+// dependants for the given item changing its key from old to new. You must hold
+// the queue's mutex lock before calling this.
+func (queue *Queue) changeDepGroupsKey(item *Item, old, new string) {
+	for _, lookup := range []map[string]map[string]*Item{queue.depGroupMembers, queue.depGroupDependants} {
+		for _, items := range lookup {
+			if val, exists := items[old]; exists && val == item {
+				delete(items, old)
+				items[new] = val
+			}
+		}
+	}
+}
+
+// removeFromGroupLookup deletes key from the given groups in the given lookup,
+// deleting groups that become empty.
+func removeFromGroupLookup(lookup map[string]map[string]*Item, groups []string, key string) {
+	for _, group := range groups {
+		if items, exists := lookup[group]; exists {
+			delete(items, key)
+			if len(items) == 0 {
+				delete(lookup, group)
+			}
+		}
+	}
+}
@@ -23,10 +23,9 @@ package queue
 // This file implements the items that are added to queues.
 
 import (
+	"sync"
 	"sync/atomic"
 	"time"
-
-	sync "github.com/sasha-s/go-deadlock"
 )
 
 // ItemState is how we describe the possible item states.
@@ -64,9 +63,12 @@ type Item struct {
 	ttr           time.Duration
 	readyAt       time.Time
 	releaseAt     time.Time
+	buryReason    string
 	creation      time.Time
 	dependencies  []string
 	remainingDeps map[string]bool
+	depGroups     []string
+	groupDeps     []string
 	mutex         sync.RWMutex
 	queueIndexes  [5]int
 	iid           uint64
@@ -76,20 +78,22 @@ type Item struct {
 // remaining in the current sub-queue. This will be a duration of zero for all
 // but the delay and run states. In the delay state it tells you how long before
 // it can be reserved, and in the run state it tells you how long before it will
-// be released automatically.
+// be released automatically. BuryReason is the reason given to BuryWithReason()
+// if the item is currently buried.
 type ItemStats struct {
-	State     ItemState
-	Age       time.Duration
-	Remaining time.Duration
-	Delay     time.Duration
-	TTR       time.Duration
-	Reserves  uint32
-	Timeouts  uint32
-	Releases  uint32
-	Buries    uint32
-	Kicks     uint32
-	Priority  uint8
-	Size      uint8
+	State      ItemState
+	Age        time.Duration
+	Remaining  time.Duration
+	Delay      time.Duration
+	TTR        time.Duration
+	Reserves   uint32
+	Timeouts   uint32
+	Releases   uint32
+	Buries     uint32
+	Kicks      uint32
+	Priority   uint8
+	Size       uint8
+	BuryReason string
 }
 
 func newItem(key string, reserveGroup string, data interface{}, priority uint8, delay time.Duration, ttr time.Duration) *Item {
@@ -141,18 +145,19 @@ func (item *Item) Stats() *ItemStats {
 		remaining = time.Duration(0) * time.Second
 	}
 	return &ItemStats{
-		State:     item.state,
-		Reserves:  item.reserves,
-		Timeouts:  item.timeouts,
-		Releases:  item.releases,
-		Buries:    item.buries,
-		Kicks:     item.kicks,
-		Age:       age,
-		Remaining: remaining,
-		Priority:  item.priority,
-		Size:      item.size,
-		Delay:     item.delay,
-		TTR:       item.ttr,
+		State:      item.state,
+		Reserves:   item.reserves,
+		Timeouts:   item.timeouts,
+		Releases:   item.releases,
+		Buries:     item.buries,
+		Kicks:      item.kicks,
+		Age:        age,
+		Remaining:  remaining,
+		Priority:   item.priority,
+		Size:       item.size,
+		Delay:      item.delay,
+		TTR:        item.ttr,
+		BuryReason: item.buryReason,
 	}
 }
 
@@ -188,6 +193,16 @@ func (item *Item) Dependencies() []string {
 	return item.dependencies
 }
 
+// DepGroups returns the dependency groups this item is a member of.
+func (item *Item) DepGroups() []string {
+	return item.depGroups
+}
+
+// GroupDependencies returns the dependency groups this item depends upon.
+func (item *Item) GroupDependencies() []string {
+	return item.groupDeps
+}
+
 // UnresolvedDependencies returns the keys of the other items we are still
 // dependent upon.
 func (item *Item) UnresolvedDependencies() []string {
@@ -239,6 +254,19 @@ func (item *Item) setDependencies(deps []string) {
 	}
 }
 
+// addDependency adds the given key to the keys of the other items we are
+// dependent upon, as an unresolved dependency. This only records the dependency
+// on the item; it does not trigger any dependency related actions or updates.
+func (item *Item) addDependency(key string) {
+	item.mutex.Lock()
+	defer item.mutex.Unlock()
+	item.dependencies = append(item.dependencies[:len(item.dependencies):len(item.dependencies)], key)
+	if item.remainingDeps == nil {
+		item.remainingDeps = make(map[string]bool)
+	}
+	item.remainingDeps[key] = true
+}
+
 // resolveDependency takes the key of an item this item depends on, and marks
 // that as a resolved dependency. Returns false if this item is not currently in
 // the dependency sub queue. Otherwise, if all of this item's dependencies have
@@ -261,6 +289,14 @@ func (item *Item) restart() {
 	item.readyAt = time.Now().Add(item.delay)
 }
 
+// restartAfter is like restart(), but the item will be ready after the given
+// delay instead of its own.
+func (item *Item) restartAfter(delay time.Duration) {
+	item.mutex.Lock()
+	defer item.mutex.Unlock()
+	item.readyAt = time.Now().Add(delay)
+}
+
 // touch is a thread-safe way to (re)set the item's release time, to allow it
 // more time on the run sub-queue.
 func (item *Item) touch() {
@@ -374,6 +410,13 @@ func (item *Item) switchRunBury(timedOut ...bool) {
 	item.state = ItemStateBury
 }
 
+// setBuryReason is a thread-safe way to note why the item is being buried.
+func (item *Item) setBuryReason(reason string) {
+	item.mutex.Lock()
+	defer item.mutex.Unlock()
+	item.buryReason = reason
+}
+
 // update after we've switched from the run to the dependent sub-queue
 func (item *Item) switchRunDependent() {
 	item.mutex.Lock()
@@ -384,12 +427,26 @@ func (item *Item) switchRunDependent() {
 	item.state = ItemStateDependent
 }
 
+// update after we've switched from the delay, ready or dependent to the bury
+// sub-queue
+func (item *Item) switchWaitingBury() {
+	item.mutex.Lock()
+	defer item.mutex.Unlock()
+	item.queueIndexes[0] = -1
+	item.queueIndexes[1] = -1
+	item.queueIndexes[4] = -1
+	item.readyAt = time.Time{}
+	item.buries++
+	item.state = ItemStateBury
+}
+
 // update after we've switched from the bury to the ready sub-queue
 func (item *Item) switchBuryReady() {
 	item.mutex.Lock()
 	defer item.mutex.Unlock()
 	item.queueIndexes[3] = -1
 	item.kicks++
+	item.buryReason = ""
 	item.state = ItemStateReady
 }
 
@@ -399,6 +456,7 @@ func (item *Item) switchBuryDependent() {
 	defer item.mutex.Unlock()
 	item.queueIndexes[3] = -1
 	item.kicks++
+	item.buryReason = ""
 	item.state = ItemStateDependent
 }
 
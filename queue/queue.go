@@ -85,11 +85,11 @@ delay queue.
 package queue
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"time"
 
-	sync "github.com/sasha-s/go-deadlock"
-
 	"github.com/inconshreveable/log15"
 )
 
@@ -118,6 +118,8 @@ var (
 	ErrNotReady      = errors.New("not ready")
 	ErrNotRunning    = errors.New("not running")
 	ErrNotBuried     = errors.New("not buried")
+	ErrNotWaiting    = errors.New("not delayed or ready")
+	ErrNotReservable = errors.New("not delayed, ready or buried")
 )
 
 // Error records an error and the operation, item and queue that caused it.
@@ -158,31 +160,29 @@ var defaultTTRCallback = func(data interface{}) SubQueue {
 // Queue is a synchronized map of items that can shift to different sub-queues,
 // automatically depending on their delay or ttr expiring, or manually by
 // calling certain methods.
+//
+// Items are divided between shards by the hash of their keys, each shard with
+// its own lock and sub-queues, so that operations on different items don't
+// block each other. Operations that involve more than one item, such as those
+// dealing with dependencies, lock the whole queue instead. Reserve() considers
+// the ready items of every shard, so still gets the next item of the whole
+// queue.
 type Queue struct {
-	delayTime              time.Time
-	ttrTime                time.Time
-	Name                   string
-	items                  map[string]*Item
-	dependants             map[string]map[string]*Item
-	delayQueue             *subQueue
-	readyQueue             *subQueue
-	runQueue               *subQueue
-	buryQueue              *buryQueue
-	depQueue               *depQueue
-	delayNotification      chan bool
-	startedDelayProcessing chan bool
-	delayClose             chan bool
-	ttrNotification        chan bool
-	startedTTRProcessing   chan bool
-	ttrClose               chan bool
-	readyAddedCb           ReadyAddedCallback
-	changedCb              ChangedCallback
-	ttrCb                  TTRCallback
-	mutex                  sync.RWMutex
-	readyAddedCbMutex      sync.Mutex
-	closed                 bool
-	readyAddedCbRunning    bool
-	readyAddedCbRecall     bool
+	Name                string
+	shards              []*shard
+	dependants          map[string]map[string]*Item
+	depGroupMembers     map[string]map[string]*Item
+	depGroupDependants  map[string]map[string]*Item
+	depGroupPolicies    map[string]DepGroupPolicy
+	readyNotifier       *pushNotifier
+	readyAddedCb        ReadyAddedCallback
+	changedCb           ChangedCallback
+	ttrCb               TTRCallback
+	mutex               sync.RWMutex
+	readyAddedCbMutex   sync.Mutex
+	closed              bool
+	readyAddedCbRunning bool
+	readyAddedCbRecall  bool
 	log15.Logger
 }
 
@@ -197,15 +197,22 @@ type Stats struct {
 }
 
 // ItemDef makes it possible to supply a slice of Add() args to AddMany().
+// DepGroups are the names of dependency groups the item will join, and
+// GroupDependencies are the names of dependency groups the item will depend
+// upon, such that it will be dependent on every other item in the queue that is
+// a member of those groups (see SetDepGroupPolicy() for what happens when
+// members join later).
 type ItemDef struct {
-	Key          string
-	ReserveGroup string
-	Data         interface{}
-	Priority     uint8 // highest priority is 255
-	Delay        time.Duration
-	TTR          time.Duration
-	StartQueue   SubQueue // blank, or one of SubQueueRun or SubQueueBury
-	Dependencies []string
+	Key               string
+	ReserveGroup      string
+	Data              interface{}
+	Priority          uint8 // highest priority is 255
+	Delay             time.Duration
+	TTR               time.Duration
+	StartQueue        SubQueue // blank, or one of SubQueueRun or SubQueueBury
+	Dependencies      []string
+	DepGroups         []string
+	GroupDependencies []string
 }
 
 // New is a helper to create instance of the Queue struct.
@@ -218,32 +225,85 @@ func New(name string, logger ...log15.Logger) *Queue {
 		l.SetHandler(log15.DiscardHandler())
 	}
 	queue := &Queue{
-		Name:                   name,
-		items:                  make(map[string]*Item),
-		dependants:             make(map[string]map[string]*Item),
-		delayQueue:             newSubQueue(0, l),
-		readyQueue:             newSubQueue(1, l),
-		runQueue:               newSubQueue(2, l),
-		buryQueue:              newBuryQueue(),
-		depQueue:               newDependencyQueue(),
-		ttrNotification:        make(chan bool, 1),
-		startedTTRProcessing:   make(chan bool),
-		ttrClose:               make(chan bool, 1),
-		ttrTime:                time.Now(),
-		delayNotification:      make(chan bool, 1),
-		startedDelayProcessing: make(chan bool),
-		delayClose:             make(chan bool, 1),
-		delayTime:              time.Now(),
-		ttrCb:                  defaultTTRCallback,
-		Logger:                 l,
-	}
-	go queue.startDelayProcessing()
-	<-queue.startedDelayProcessing
-	go queue.startTTRProcessing()
-	<-queue.startedTTRProcessing
+		Name:               name,
+		dependants:         make(map[string]map[string]*Item),
+		depGroupMembers:    make(map[string]map[string]*Item),
+		depGroupDependants: make(map[string]map[string]*Item),
+		depGroupPolicies:   make(map[string]DepGroupPolicy),
+		readyNotifier:      newPushNotifier(),
+		ttrCb:              defaultTTRCallback,
+		Logger:             l,
+	}
+	queue.shards = make([]*shard, numShards)
+	for i := range queue.shards {
+		queue.shards[i] = newShard(queue, queue.readyNotifier, l)
+	}
 	return queue
 }
 
+// shardFor returns the shard that the item with the given key belongs to.
+func (queue *Queue) shardFor(key string) *shard {
+	return queue.shards[shardIndex(key)]
+}
+
+// lookup returns the item with the given key, if in the queue. You must hold
+// the mutex lock before calling this.
+func (queue *Queue) lookup(key string) (*Item, bool) {
+	item, exists := queue.shardFor(key).items[key]
+	return item, exists
+}
+
+// lockKey locks the shard the item with the given key belongs to, or the whole
+// queue if exclusive is true, returning the shard.
+func (queue *Queue) lockKey(key string, exclusive bool) *shard {
+	s := queue.shardFor(key)
+	if exclusive {
+		queue.mutex.Lock()
+	} else {
+		s.lock()
+	}
+	return s
+}
+
+// unlockKey undoes lockKey().
+func (queue *Queue) unlockKey(s *shard, exclusive bool) {
+	if exclusive {
+		queue.mutex.Unlock()
+	} else {
+		s.unlock()
+	}
+}
+
+// lockItem is like lockKey(exclusive = false), but also returns the item with
+// the given key. If the item is not in the queue, or the queue is closed, it
+// unlocks and returns an Error for the given op. If exclusive is not nil and
+// returns true for the item, the whole queue is locked instead, and the
+// returned bool will be true.
+func (queue *Queue) lockItem(op, key string, exclusive func(item *Item) bool) (*shard, *Item, bool, error) {
+	locked := false
+	s := queue.lockKey(key, locked)
+	for {
+		if queue.closed {
+			queue.unlockKey(s, locked)
+			return nil, nil, false, Error{queue.Name, op, key, ErrQueueClosed}
+		}
+
+		item, exists := s.items[key]
+		if !exists {
+			queue.unlockKey(s, locked)
+			return nil, nil, false, Error{queue.Name, op, key, ErrNotFound}
+		}
+
+		if locked || exclusive == nil || !exclusive(item) {
+			return s, item, locked, nil
+		}
+
+		queue.unlockKey(s, locked)
+		locked = true
+		queue.lockKey(key, locked)
+	}
+}
+
 // SetReadyAddedCallback sets a callback that will be called when new items have
 // been added to the ready sub-queue. The callback will receive the name of the
 // queue, and a slice of the Data properties of every item currently in the
@@ -280,14 +340,16 @@ func (queue *Queue) readyAdded() {
 		queue.readyAddedCbMutex.Unlock()
 
 		go func() {
-			queue.mutex.RLock()
 			var data []interface{}
-			for _, il := range queue.readyQueue.groupedItems {
-				for _, item := range il {
-					data = append(data, item.Data())
+			for _, s := range queue.shards {
+				s.rlock()
+				for _, il := range s.readyQueue.groupedItems {
+					for _, item := range il {
+						data = append(data, item.Data())
+					}
 				}
+				s.runlock()
 			}
-			queue.mutex.RUnlock()
 			queue.Debug("new ready items, triggering callback")
 			queue.readyAddedCb(queue.Name, data)
 
@@ -344,14 +406,9 @@ func (queue *Queue) Destroy() error {
 		return Error{queue.Name, "Destroy", "", ErrQueueClosed}
 	}
 
-	queue.ttrClose <- true
-	queue.delayClose <- true
-	queue.items = nil
-	queue.delayQueue.empty()
-	queue.readyQueue.empty()
-	queue.runQueue.empty()
-	queue.buryQueue.empty()
-	queue.depQueue.empty()
+	for _, s := range queue.shards {
+		s.empty()
+	}
 	queue.closed = true
 	return nil
 }
@@ -359,17 +416,18 @@ func (queue *Queue) Destroy() error {
 // Stats returns information about the number of items in the queue and each
 // sub-queue.
 func (queue *Queue) Stats() *Stats {
-	queue.mutex.RLock()
-	defer queue.mutex.RUnlock()
-
-	return &Stats{
-		Items:     len(queue.items),
-		Delayed:   queue.delayQueue.len(),
-		Ready:     queue.readyQueue.len(),
-		Running:   queue.runQueue.len(),
-		Buried:    queue.buryQueue.len(),
-		Dependant: queue.depQueue.len(),
-	}
+	stats := &Stats{}
+	for _, s := range queue.shards {
+		s.rlock()
+		stats.Items += len(s.items)
+		stats.Delayed += s.delayQueue.len()
+		stats.Ready += s.readyQueue.len()
+		stats.Running += s.runQueue.len()
+		stats.Buried += s.buryQueue.len()
+		stats.Dependant += s.depQueue.len()
+		s.runlock()
+	}
+	return stats
 }
 
 // Add is a thread-safe way to add new items to the queue.
@@ -401,40 +459,35 @@ func (queue *Queue) Stats() *Stats {
 // Add() returns an item, which may have already existed (in which case, nothing
 // was actually added or changed).
 func (queue *Queue) Add(key string, reserveGroup string, data interface{}, priority uint8, delay time.Duration, ttr time.Duration, startQueue SubQueue, deps ...[]string) (*Item, error) {
-	queue.mutex.Lock()
-	item, err := queue.newItemForAdd(key, reserveGroup, data, priority, 0, delay, ttr)
-	if err != nil {
-		queue.mutex.Unlock()
-		return item, err
-	}
-	queue.handleItemForAdd(item, startQueue, delay, deps...)
-	return item, nil
+	return queue.AddWithSize(key, reserveGroup, data, priority, 0, delay, ttr, startQueue, deps...)
 }
 
-// newItemForAdd prepares a new item for Add() and AddWithSize() methods. You
-// must hold the mutex lock before calling this.
-func (queue *Queue) newItemForAdd(key string, reserveGroup string, data interface{}, priority uint8, size uint8, delay time.Duration, ttr time.Duration) (*Item, error) {
+// newItemForAdd prepares a new item for Add() and AddWithSize() methods, in the
+// given shard. You must hold the lock of the shard (or queue) before calling
+// this.
+func (queue *Queue) newItemForAdd(s *shard, key string, reserveGroup string, data interface{}, priority uint8, size uint8, delay time.Duration, ttr time.Duration) (*Item, error) {
 	if queue.closed {
 		return nil, Error{queue.Name, "Add", key, ErrQueueClosed}
 	}
 
-	item, existed := queue.items[key]
+	item, existed := s.items[key]
 	if existed {
 		return item, Error{queue.Name, "Add", key, ErrAlreadyExists}
 	}
 
 	item = newItem(key, reserveGroup, data, priority, delay, ttr)
 	item.size = size
-	queue.items[key] = item
+	s.items[key] = item
 	return item, nil
 }
 
 // handleItemForAdd checks dependencies and then pushes the item to the desired
-// subqueue. You must hold the mutex lock before calling this. It will unlock.
-func (queue *Queue) handleItemForAdd(item *Item, startQueue SubQueue, delay time.Duration, deps ...[]string) {
+// subqueue. You must hold the lock of the item's shard before calling this, or
+// of the whole queue if there are deps. It will unlock.
+func (queue *Queue) handleItemForAdd(s *shard, item *Item, startQueue SubQueue, delay time.Duration, deps ...[]string) {
 	// check dependencies
-	if len(deps) == 1 && len(deps[0]) > 0 {
-		queue.setItemDependencies(item, deps[0])
+	if hasDeps(deps) {
+		queue.setItemDependencies(s, item, deps[0])
 		queue.mutex.Unlock()
 		queue.changed(SubQueueNew, SubQueueDependent, []*Item{item})
 		return
@@ -444,59 +497,67 @@ func (queue *Queue) handleItemForAdd(item *Item, startQueue SubQueue, delay time
 	case SubQueueRun:
 		item.switchDelayReady()
 		item.touch()
-		queue.runQueue.push(item)
+		s.runQueue.push(item)
 		item.switchReadyRun()
-		queue.mutex.Unlock()
-		queue.ttrNotificationTrigger(item)
+		s.unlock()
+		s.ttrNotificationTrigger(item)
 		queue.changed(SubQueueNew, SubQueueRun, []*Item{item})
 	case SubQueueBury:
 		item.switchDelayReady()
-		queue.buryQueue.push(item)
+		s.buryQueue.push(item)
 		item.switchRunBury()
-		queue.mutex.Unlock()
+		s.unlock()
 		queue.changed(SubQueueNew, SubQueueBury, []*Item{item})
 	default:
 		if delay.Nanoseconds() == 0 {
 			// put it directly on the ready queue
 			item.switchDelayReady()
-			queue.readyQueue.push(item)
-			queue.mutex.Unlock()
+			s.readyQueue.push(item)
+			s.unlock()
 			queue.changed(SubQueueNew, SubQueueReady, []*Item{item})
 			queue.readyAdded()
 		} else {
-			queue.delayQueue.push(item)
-			queue.mutex.Unlock()
+			s.delayQueue.push(item)
+			s.unlock()
 			queue.changed(SubQueueNew, SubQueueDelay, []*Item{item})
-			queue.delayNotificationTrigger(item)
+			s.delayNotificationTrigger(item)
 		}
 	}
 }
 
+// hasDeps tells you if the optional deps argument of Add() and AddWithSize()
+// was supplied with any dependencies.
+func hasDeps(deps [][]string) bool {
+	return len(deps) == 1 && len(deps[0]) > 0
+}
+
 // AddWithSize is like Add(), but the item also gets a "size" property.
 // Size alters the way priority is handled. For items with the same priority,
 // the next to be Reserve()d will be the item with the highest size. If they
 // also have the same size, then they will be Reserve()d in fifo order.
 func (queue *Queue) AddWithSize(key string, reserveGroup string, data interface{}, priority uint8, size uint8, delay time.Duration, ttr time.Duration, startQueue SubQueue, deps ...[]string) (*Item, error) {
-	queue.mutex.Lock()
-	item, err := queue.newItemForAdd(key, reserveGroup, data, priority, size, delay, ttr)
+	exclusive := hasDeps(deps)
+	s := queue.lockKey(key, exclusive)
+	item, err := queue.newItemForAdd(s, key, reserveGroup, data, priority, size, delay, ttr)
 	if err != nil {
-		queue.mutex.Unlock()
+		queue.unlockKey(s, exclusive)
 		return item, err
 	}
-	queue.handleItemForAdd(item, startQueue, delay, deps...)
+	queue.handleItemForAdd(s, item, startQueue, delay, deps...)
 	return item, nil
 }
 
 // setItemDependencies sets the given item keys as the dependencies of the given
-// item, and places the item in the dependency queue. Note that you can be
-// dependent on items that do not exist in the queue; the item will remain in
-// dependent queue until you add items with the given deps keys and then
-// Remove() them.
-func (queue *Queue) setItemDependencies(item *Item, deps []string) {
+// item, and places the item in the dependency queue of the given shard it
+// belongs to. Note that you can be dependent on items that do not exist in the
+// queue; the item will remain in dependent queue until you add items with the
+// given deps keys and then Remove() them. You must hold the mutex lock before
+// calling this.
+func (queue *Queue) setItemDependencies(s *shard, item *Item, deps []string) {
 	item.setDependencies(deps)
 	queue.setQueueDeps(item)
 	item.switchDelayDependent()
-	queue.depQueue.push(item)
+	s.depQueue.push(item)
 }
 
 // setQueueDeps updates the queue's lookup of parent items to their dependent
@@ -512,16 +573,29 @@ func (queue *Queue) setQueueDeps(item *Item) {
 }
 
 // itemHasDeps returns true if the item has unresolved dependencies according
-// to the queue's lookup of parent items to their dependent children.
+// to the queue's lookup of parent items to their dependent children. You must
+// hold the mutex lock before calling this.
 func (queue *Queue) itemHasDeps(item *Item) bool {
 	for _, dep := range item.Dependencies() {
-		if _, exists := queue.items[dep]; exists {
+		if _, exists := queue.lookup(dep); exists {
 			return true
 		}
 	}
 	return false
 }
 
+// entangled returns true if the given item depends on, or is depended on by,
+// other items, directly or by dependency groups, so that changing it may
+// involve changing those other items. You must hold at least the read lock of
+// the mutex before calling this.
+func (queue *Queue) entangled(item *Item) bool {
+	if len(item.Dependencies()) > 0 || len(item.DepGroups()) > 0 || len(item.GroupDependencies()) > 0 {
+		return true
+	}
+	_, has := queue.dependants[item.Key]
+	return has
+}
+
 // AddMany is like Add(), except that you supply a slice of *ItemDef, and it
 // returns the number that were actually added and the number of items that were
 // not added because they were duplicates of items already in the queue. If an
@@ -534,41 +608,68 @@ func (queue *Queue) AddMany(items []*ItemDef) (added, dups int, err error) {
 		return 0, 0, Error{queue.Name, "AddMany", "", ErrQueueClosed}
 	}
 
-	deferredDelayTrigger := false
-	deferredTTRTrigger := false
+	deferredDelayTrigger := make(map[*shard]bool)
+	deferredTTRTrigger := make(map[*shard]bool)
 	var addedReadyItems []*Item
 	var addedDelayItems []*Item
 	var addedDepItems []*Item
 	var addedRunItems []*Item
 	var addedBuryItems []*Item
-	for _, def := range items {
-		_, existed := queue.items[def.Key]
+	recalledItems := make(map[SubQueue][]*Item)
+
+	// first create all the new items and have them join their dependency
+	// groups, so that items depending on those groups get all the members
+	// being added at the same time
+	newItems := make([]*Item, len(items))
+	for i, def := range items {
+		s := queue.shardFor(def.Key)
+		_, existed := s.items[def.Key]
 		if existed {
 			dups++
 			continue
 		}
 
 		item := newItem(def.Key, def.ReserveGroup, def.Data, def.Priority, def.Delay, def.TTR)
-		queue.items[def.Key] = item
+		s.items[def.Key] = item
+		newItems[i] = item
 
-		if len(def.Dependencies) > 0 {
-			queue.setItemDependencies(item, def.Dependencies)
+		if len(def.DepGroups) > 0 {
+			for from, recalled := range queue.joinDepGroups(item, def.DepGroups) {
+				recalledItems[from] = append(recalledItems[from], recalled...)
+			}
+		}
+	}
+
+	for i, def := range items {
+		item := newItems[i]
+		if item == nil {
+			continue
+		}
+		s := queue.shardFor(def.Key)
+
+		deps := def.Dependencies
+		if len(def.GroupDependencies) > 0 {
+			deps = queue.setGroupDependencies(item, def.GroupDependencies, deps)
+		}
+
+		if len(deps) > 0 {
+			queue.setItemDependencies(s, item, deps)
 			addedDepItems = append(addedDepItems, item)
 		} else {
 			switch def.StartQueue {
 			case SubQueueRun:
 				item.switchDelayReady()
 				item.touch()
-				queue.runQueue.push(item)
+				s.runQueue.push(item)
 				item.switchReadyRun()
 				addedRunItems = append(addedRunItems, item)
-				if !deferredTTRTrigger && queue.ttrTime.After(time.Now().Add(item.ttr)) {
-					defer queue.ttrNotificationTrigger(item)
-					deferredTTRTrigger = true
+				if !deferredTTRTrigger[s] && s.ttrTime.After(time.Now().Add(item.ttr)) {
+					defer s.ttrNotificationTrigger(item)
+					deferredTTRTrigger[s] = true
 				}
 			case SubQueueBury:
 				item.switchDelayReady()
-				queue.buryQueue.push(item)
+				s.buryQueue.push(item)
 				item.switchReadyRun()
 				item.switchRunBury()
 				addedBuryItems = append(addedBuryItems, item)
@@ -576,14 +677,14 @@ func (queue *Queue) AddMany(items []*ItemDef) (added, dups int, err error) {
 				if def.Delay.Nanoseconds() == 0 {
 					// put it directly on the ready queue
 					item.switchDelayReady()
-					queue.readyQueue.push(item)
+					s.readyQueue.push(item)
 					addedReadyItems = append(addedReadyItems, item)
 				} else {
-					queue.delayQueue.push(item)
+					s.delayQueue.push(item)
 					addedDelayItems = append(addedDelayItems, item)
-					if !deferredDelayTrigger && queue.delayTime.After(time.Now().Add(item.delay)) {
-						defer queue.delayNotificationTrigger(item)
-						deferredDelayTrigger = true
+					if !deferredDelayTrigger[s] && s.delayTime.After(time.Now().Add(item.delay)) {
+						defer s.delayNotificationTrigger(item)
+						deferredDelayTrigger[s] = true
 					}
 				}
 			}
@@ -609,20 +710,24 @@ func (queue *Queue) AddMany(items []*ItemDef) (added, dups int, err error) {
 	if len(addedBuryItems) > 0 {
 		queue.changed(SubQueueNew, SubQueueBury, addedBuryItems)
 	}
+	for from, recalled := range recalledItems {
+		queue.changed(from, SubQueueDependent, recalled)
+	}
 
 	return added, dups, err
 }
 
 // Get is a thread-safe way to get an item by the key you used to Add() it.
 func (queue *Queue) Get(key string) (*Item, error) {
-	queue.mutex.RLock()
-	defer queue.mutex.RUnlock()
+	s := queue.shardFor(key)
+	s.rlock()
+	defer s.runlock()
 
 	if queue.closed {
 		return nil, Error{queue.Name, "Get", key, ErrQueueClosed}
 	}
 
-	item, exists := queue.items[key]
+	item, exists := s.items[key]
 	if !exists {
 		return nil, Error{queue.Name, "Get", key, ErrNotFound}
 	}
@@ -632,11 +737,13 @@ func (queue *Queue) Get(key string) (*Item, error) {
 // GetRunningData gets all the item.Data() of items currently in the run sub-
 // queue.
 func (queue *Queue) GetRunningData() []interface{} {
-	queue.mutex.RLock()
-	defer queue.mutex.RUnlock()
-	data := make([]interface{}, 0, len(queue.runQueue.items))
-	for _, item := range queue.runQueue.items {
-		data = append(data, item.Data())
+	var data []interface{}
+	for _, s := range queue.shards {
+		s.rlock()
+		for _, item := range s.runQueue.items {
+			data = append(data, item.Data())
+		}
+		s.runlock()
 	}
 	return data
 }
@@ -644,11 +751,13 @@ func (queue *Queue) GetRunningData() []interface{} {
 // AllItems returns the items in the queue. NB: You should NOT do anything
 // to these items - use for read-only purposes.
 func (queue *Queue) AllItems() []*Item {
-	queue.mutex.RLock()
-	defer queue.mutex.RUnlock()
-	items := make([]*Item, 0, len(queue.items))
-	for _, item := range queue.items {
-		items = append(items, item)
+	var items []*Item
+	for _, s := range queue.shards {
+		s.rlock()
+		for _, item := range s.items {
+			items = append(items, item)
+		}
+		s.runlock()
 	}
 	return items
 }
@@ -668,7 +777,8 @@ func (queue *Queue) Update(key string, reserveGroup string, data interface{}, pr
 		return Error{queue.Name, "Update", key, ErrQueueClosed}
 	}
 
-	item, exists := queue.items[key]
+	s := queue.shardFor(key)
+	item, exists := s.items[key]
 	if !exists {
 		queue.mutex.Unlock()
 		return Error{queue.Name, "Update", key, ErrNotFound}
@@ -678,6 +788,9 @@ func (queue *Queue) Update(key string, reserveGroup string, data interface{}, pr
 	var addedReady bool
 	item.SetData(data)
 	if len(deps) == 1 {
+		// the item remains dependent on the members of its dependency groups
+		deps[0] = queue.withGroupMembers(item, deps[0])
+
 		// check if dependencies actually changed
 		oldDeps := make(map[string]bool)
 		for _, dep := range item.UnresolvedDependencies() {
@@ -698,7 +811,7 @@ func (queue *Queue) Update(key string, reserveGroup string, data interface{}, pr
 		if len(toRemove) > 0 || newDeps > 0 {
 			// remove any invalid dependencies from our lookup
 			for _, dep := range toRemove {
-				if _, exists := queue.items[dep]; exists {
+				if _, exists := queue.lookup(dep); exists {
 					delete(queue.dependants[dep], key)
 					if len(queue.dependants[dep]) == 0 {
 						delete(queue.dependants, dep)
@@ -719,15 +832,15 @@ func (queue *Queue) Update(key string, reserveGroup string, data interface{}, pr
 				pushToDep := true
 				switch iState {
 				case ItemStateDelay:
-					queue.delayQueue.remove(item)
+					s.delayQueue.remove(item)
 					item.switchDelayDependent()
 					changedFrom = SubQueueDelay
 				case ItemStateReady:
-					queue.readyQueue.remove(item)
+					s.readyQueue.remove(item)
 					item.switchReadyDependent()
 					changedFrom = SubQueueReady
 				case ItemStateRun:
-					queue.runQueue.remove(item)
+					s.runQueue.remove(item)
 					item.switchRunDependent()
 					changedFrom = SubQueueRun
 				case ItemStateBury:
@@ -736,13 +849,13 @@ func (queue *Queue) Update(key string, reserveGroup string, data interface{}, pr
 					pushToDep = false
 				}
 				if pushToDep {
-					queue.depQueue.push(item)
+					s.depQueue.push(item)
 				}
 			} else if len(deps[0]) == 0 {
 				// switch to ready queue
-				queue.depQueue.remove(item)
+				s.depQueue.remove(item)
 				item.switchDependentReady()
-				queue.readyQueue.push(item)
+				s.readyQueue.push(item)
 				addedReady = true
 			}
 		}
@@ -755,7 +868,7 @@ func (queue *Queue) Update(key string, reserveGroup string, data interface{}, pr
 		if item.state == ItemStateDelay {
 			item.mutex.Unlock()
 			item.restart()
-			queue.delayQueue.update(item)
+			s.delayQueue.update(item)
 		} else {
 			item.mutex.Unlock()
 		}
@@ -765,7 +878,7 @@ func (queue *Queue) Update(key string, reserveGroup string, data interface{}, pr
 		item.ReserveGroup = reserveGroup
 		if item.state == ItemStateReady {
 			item.mutex.Unlock()
-			queue.readyQueue.update(item, oldGroup)
+			s.readyQueue.update(item, oldGroup)
 		} else {
 			item.mutex.Unlock()
 		}
@@ -774,7 +887,7 @@ func (queue *Queue) Update(key string, reserveGroup string, data interface{}, pr
 		if item.state == ItemStateRun {
 			item.mutex.Unlock()
 			item.touch()
-			queue.runQueue.update(item)
+			s.runQueue.update(item)
 		} else {
 			item.mutex.Unlock()
 		}
@@ -803,23 +916,32 @@ func (queue *Queue) Update(key string, reserveGroup string, data interface{}, pr
 // this will fail.
 func (queue *Queue) ChangeKey(old, new string) error {
 	queue.mutex.Lock()
-	defer queue.mutex.Unlock()
 
 	if queue.closed {
+		queue.mutex.Unlock()
 		return Error{queue.Name, "ChangeKey", old, ErrQueueClosed}
 	}
 
-	if _, exists := queue.items[new]; exists {
+	if _, exists := queue.lookup(new); exists {
+		queue.mutex.Unlock()
 		return Error{queue.Name, "ChangeKey", new, ErrAlreadyExists}
 	}
 
-	item, exists := queue.items[old]
+	oldShard := queue.shardFor(old)
+	item, exists := oldShard.items[old]
 	if !exists {
+		queue.mutex.Unlock()
 		return Error{queue.Name, "ChangeKey", old, ErrNotFound}
 	}
 
-	delete(queue.items, old)
-	queue.items[new] = item
+	// move the item to the shard of its new key
+	newShard := queue.shardFor(new)
+	delete(oldShard.items, old)
+	newShard.items[new] = item
+	if newShard != oldShard {
+		oldShard.detach(item)
+		newShard.attach(item)
+	}
 
 	if val, exists := queue.dependants[old]; exists {
 		delete(queue.dependants, old)
@@ -833,8 +955,23 @@ func (queue *Queue) ChangeKey(old, new string) error {
 		}
 	}
 
-	for _, item := range queue.items {
-		item.ChangedKey(old, new)
+	queue.changeDepGroupsKey(item, old, new)
+
+	for _, s := range queue.shards {
+		for _, item := range s.items {
+			item.ChangedKey(old, new)
+		}
+	}
+
+	queue.mutex.Unlock()
+
+	if newShard != oldShard {
+		switch item.State() {
+		case ItemStateDelay:
+			newShard.delayNotificationTrigger(item)
+		case ItemStateRun:
+			newShard.ttrNotificationTrigger(item)
+		}
 	}
 
 	return nil
@@ -842,16 +979,9 @@ func (queue *Queue) ChangeKey(old, new string) error {
 
 // SetDelay is a thread-safe way to change the delay of an item.
 func (queue *Queue) SetDelay(key string, delay time.Duration) error {
-	queue.mutex.Lock()
-	if queue.closed {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "SetDelay", key, ErrQueueClosed}
-	}
-
-	item, exists := queue.items[key]
-	if !exists {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "SetDelay", key, ErrNotFound}
+	s, item, _, err := queue.lockItem("SetDelay", key, nil)
+	if err != nil {
+		return err
 	}
 
 	item.mutex.Lock()
@@ -860,29 +990,22 @@ func (queue *Queue) SetDelay(key string, delay time.Duration) error {
 		if item.state == ItemStateDelay {
 			item.mutex.Unlock()
 			item.restart()
-			queue.delayQueue.update(item)
-			queue.mutex.Unlock()
-			queue.delayNotificationTrigger(item)
+			s.delayQueue.update(item)
+			s.unlock()
+			s.delayNotificationTrigger(item)
 			return nil
 		}
 	}
 	item.mutex.Unlock()
-	queue.mutex.Unlock()
+	s.unlock()
 	return nil
 }
 
 // SetReserveGroup is a thread-safe way to change the ReserveGroup of an item.
 func (queue *Queue) SetReserveGroup(key string, newGroup string) error {
-	queue.mutex.Lock()
-	if queue.closed {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "SetReserveGroup", key, ErrQueueClosed}
-	}
-
-	item, exists := queue.items[key]
-	if !exists {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "SetReserveGroup", key, ErrNotFound}
+	s, item, _, err := queue.lockItem("SetReserveGroup", key, nil)
+	if err != nil {
+		return err
 	}
 
 	item.mutex.Lock()
@@ -891,14 +1014,14 @@ func (queue *Queue) SetReserveGroup(key string, newGroup string) error {
 		item.ReserveGroup = newGroup
 		if item.state == ItemStateReady {
 			item.mutex.Unlock()
-			queue.readyQueue.update(item, oldGroup)
+			s.readyQueue.update(item, oldGroup)
 		} else {
 			item.mutex.Unlock()
 		}
 	} else {
 		item.mutex.Unlock()
 	}
-	queue.mutex.Unlock()
+	s.unlock()
 	return nil
 }
 
@@ -924,47 +1047,156 @@ func (queue *Queue) SetReserveGroup(key string, newGroup string) error {
 // able to later, you can manually call Release(), which moves it to the delay
 // sub-queue.
 func (queue *Queue) Reserve(reserveGroup string, wait time.Duration) (*Item, error) {
-	queue.mutex.Lock()
+	return queue.reserve(context.Background(), reserveGroup, wait, nil)
+}
+
+// ReservePreferring is like Reserve(), but if any of the ready items in the
+// reserveGroup that share the highest priority have data that prefer() returns
+// true for, one of those will be reserved in preference to the item Reserve()
+// would have returned. prefer() is called while the queue is locked, so must
+// not call any methods of the queue.
+func (queue *Queue) ReservePreferring(reserveGroup string, wait time.Duration, prefer func(data interface{}) bool) (*Item, error) {
+	return queue.reserve(context.Background(), reserveGroup, wait, prefer)
+}
+
+// ReserveContext is like ReservePreferring() (prefer can be nil), but stops
+// waiting for an item to appear in the ready sub-queue if the given context is
+// done first, in which case no item and the context's error are returned.
+func (queue *Queue) ReserveContext(ctx context.Context, reserveGroup string, wait time.Duration, prefer func(data interface{}) bool) (*Item, error) {
+	return queue.reserve(ctx, reserveGroup, wait, prefer)
+}
+
+// reserve is the implementation of Reserve(), ReservePreferring() and
+// ReserveContext(); prefer can be nil.
+func (queue *Queue) reserve(ctx context.Context, reserveGroup string, wait time.Duration, prefer func(data interface{}) bool) (*Item, error) {
+	queue.mutex.RLock()
 
 	if queue.closed {
-		queue.mutex.Unlock()
+		queue.mutex.RUnlock()
 		return nil, Error{queue.Name, "Reserve", "", ErrQueueClosed}
 	}
 
 	// pop an item from the ready queue and add it to the run queue
-	item := queue.readyQueue.pop(reserveGroup)
-	if item == nil {
-		if wait > 0 {
-			ch := make(chan bool, 1)
-			queue.readyQueue.notifyPush(reserveGroup, ch, wait)
-			queue.mutex.Unlock()
+	item, s := queue.popReady(reserveGroup, prefer)
+	if item == nil && wait > 0 {
+		// ask to be notified when something is pushed to the ready queue, then
+		// check we didn't miss something pushed before we asked
+		ch := make(chan bool, 1)
+		queue.readyNotifier.notifyPush(reserveGroup, ch, wait)
+		item, s = queue.popReady(reserveGroup, prefer)
+		if item == nil {
+			queue.mutex.RUnlock()
 
 			// wait until something is pushed to the ready queue or we hit the
 			// timeout
-			tryAgain := <-ch
-			if tryAgain {
-				queue.mutex.Lock()
-				item = queue.readyQueue.pop(reserveGroup)
-				if item == nil {
-					queue.mutex.Unlock()
+			var tryAgain bool
+			select {
+			case tryAgain = <-ch:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			if !tryAgain {
+				return nil, Error{queue.Name, "Reserve", "", ErrNothingReady}
+			}
+
+			queue.mutex.RLock()
+			item, s = queue.popReady(reserveGroup, prefer)
+		}
+	}
+
+	if item == nil {
+		queue.mutex.RUnlock()
+		return item, Error{queue.Name, "Reserve", "", ErrNothingReady}
+	}
+
+	item.touch()
+	s.runQueue.push(item)
+	item.switchReadyRun()
+
+	s.unlock()
+	s.ttrNotificationTrigger(item)
+	queue.changed(SubQueueReady, SubQueueRun, []*Item{item})
+
+	return item, nil
+}
+
+// popReady removes the item that should be reserved next in the reserveGroup
+// from the ready sub-queue of whichever shard it is in, as per
+// ReservePreferring(). If there is such an item, it is returned along with its
+// shard, which will have been locked. You must hold the read lock of the mutex
+// before calling this.
+func (queue *Queue) popReady(reserveGroup string, prefer func(data interface{}) bool) (*Item, *shard) {
+	for {
+		var next *Item
+		var nextShard *shard
+		for _, s := range queue.shards {
+			if item := s.readyQueue.peek(reserveGroup); item != nil && (next == nil || readyBefore(item, next)) {
+				next, nextShard = item, s
+			}
+		}
+		if next == nil {
+			return nil, nil
+		}
+
+		if prefer != nil {
+			var best *Item
+			var bestShard *shard
+			for _, s := range queue.shards {
+				if item := s.readyQueue.peekPreferring(reserveGroup, next.priority, prefer); item != nil && (best == nil || readyBefore(item, best)) {
+					best, bestShard = item, s
 				}
 			}
-		} else {
-			queue.mutex.Unlock()
+			if best != nil {
+				next, nextShard = best, bestShard
+			}
 		}
 
-		if item == nil {
-			return item, Error{queue.Name, "Reserve", "", ErrNothingReady}
+		// another reserver may have beaten us to it since we peeked, in which
+		// case we try again
+		nextShard.mutex.Lock()
+		if next.state == ItemStateReady && next.ReserveGroup == reserveGroup {
+			nextShard.readyQueue.remove(next)
+			return next, nextShard
 		}
+		nextShard.mutex.Unlock()
+	}
+}
+
+// ReserveKey is like Reserve(), but reserves the item with the given key,
+// regardless of its reserveGroup or priority, and without waiting. The item can
+// be in the delay, ready or bury sub-queue; reserving a buried item counts as
+// kicking it.
+func (queue *Queue) ReserveKey(key string) (*Item, error) {
+	s, item, _, err := queue.lockItem("ReserveKey", key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var from SubQueue
+	switch item.state {
+	case ItemStateDelay:
+		s.delayQueue.remove(item)
+		item.switchDelayReady()
+		from = SubQueueDelay
+	case ItemStateReady:
+		s.readyQueue.remove(item)
+		from = SubQueueReady
+	case ItemStateBury:
+		s.buryQueue.remove(item)
+		item.switchBuryReady()
+		from = SubQueueBury
+	default:
+		s.unlock()
+		return nil, Error{queue.Name, "ReserveKey", key, ErrNotReservable}
 	}
 
 	item.touch()
-	queue.runQueue.push(item)
+	s.runQueue.push(item)
 	item.switchReadyRun()
 
-	queue.mutex.Unlock()
-	queue.ttrNotificationTrigger(item)
-	queue.changed(SubQueueReady, SubQueueRun, []*Item{item})
+	s.unlock()
+	s.ttrNotificationTrigger(item)
+	queue.changed(from, SubQueueRun, []*Item{item})
 
 	return item, nil
 }
@@ -972,32 +1204,24 @@ func (queue *Queue) Reserve(reserveGroup string, wait time.Duration) (*Item, err
 // Touch is a thread-safe way to extend the amount of time a Reserve()d item
 // is allowed to run.
 func (queue *Queue) Touch(key string) error {
-	queue.mutex.Lock()
-
-	if queue.closed {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Touch", key, ErrQueueClosed}
-	}
-
 	// check it's actually still in the queue first
-	item, ok := queue.items[key]
-	if !ok {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Touch", key, ErrNotFound}
+	s, item, _, err := queue.lockItem("Touch", key, nil)
+	if err != nil {
+		return err
 	}
 
 	// and it must be in the run queue
-	if ok = item.state == ItemStateRun; !ok {
-		queue.mutex.Unlock()
+	if item.state != ItemStateRun {
+		s.unlock()
 		return Error{queue.Name, "Touch", key, ErrNotRunning}
 	}
 
 	// touch and update the heap
 	item.touch()
-	queue.runQueue.update(item)
+	s.runQueue.update(item)
 
-	queue.mutex.Unlock()
-	queue.ttrNotificationTrigger(item)
+	s.unlock()
+	s.ttrNotificationTrigger(item)
 
 	return nil
 }
@@ -1005,41 +1229,51 @@ func (queue *Queue) Touch(key string) error {
 // Release is a thread-safe way to switch an item in the run sub-queue to the
 // delay sub-queue, for when the item should be dealt with later, not now.
 func (queue *Queue) Release(key string) error {
-	queue.mutex.Lock()
+	return queue.release("Release", key, nil)
+}
 
-	if queue.closed {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Release", key, ErrQueueClosed}
-	}
+// ReleaseWithDelay is like Release(), but the item will become ready after the
+// given delay instead of its own delay (which is unchanged, and used for
+// subsequent releases). A delay of 0 switches it straight to the ready
+// sub-queue.
+func (queue *Queue) ReleaseWithDelay(key string, delay time.Duration) error {
+	return queue.release("ReleaseWithDelay", key, &delay)
+}
 
+// release implements Release() and ReleaseWithDelay(), using the item's own
+// delay if the given one is nil.
+func (queue *Queue) release(op string, key string, delay *time.Duration) error {
 	// check it's actually still in the queue first
-	item, ok := queue.items[key]
-	if !ok {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Release", key, ErrNotFound}
+	s, item, _, err := queue.lockItem(op, key, nil)
+	if err != nil {
+		return err
 	}
 
 	// and it must be in the run queue
-	if ok = item.state == ItemStateRun; !ok {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Release", key, ErrNotRunning}
+	if item.state != ItemStateRun {
+		s.unlock()
+		return Error{queue.Name, op, key, ErrNotRunning}
+	}
+
+	if delay == nil {
+		delay = &item.delay
 	}
 
 	// switch from run to delay queue (unless there is no delay, in which case
 	// straight to ready)
-	queue.runQueue.remove(item)
-	if item.delay.Nanoseconds() == 0 {
+	s.runQueue.remove(item)
+	if delay.Nanoseconds() == 0 {
 		item.switchRunReady()
-		queue.readyQueue.push(item)
-		queue.mutex.Unlock()
+		s.readyQueue.push(item)
+		s.unlock()
 		queue.changed(SubQueueRun, SubQueueReady, []*Item{item})
 		queue.readyAdded()
 	} else {
-		item.restart()
-		queue.delayQueue.push(item)
+		item.restartAfter(*delay)
+		s.delayQueue.push(item)
 		item.switchRunDelay()
-		queue.mutex.Unlock()
-		queue.delayNotificationTrigger(item)
+		s.unlock()
+		s.delayNotificationTrigger(item)
 		queue.changed(SubQueueRun, SubQueueDelay, []*Item{item})
 	}
 
@@ -1050,70 +1284,105 @@ func (queue *Queue) Release(key string) error {
 // bury sub-queue, for when the item can't be dealt with ever, at least until
 // the user takes some action and changes something.
 func (queue *Queue) Bury(key string) error {
-	queue.mutex.Lock()
+	return queue.bury("Bury", key, "")
+}
 
-	if queue.closed {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Bury", key, ErrQueueClosed}
-	}
+// BuryWithReason is like Bury(), but also records why the item was buried,
+// which will be found in the item's Stats().BuryReason until it is kicked.
+func (queue *Queue) BuryWithReason(key string, reason string) error {
+	return queue.bury("BuryWithReason", key, reason)
+}
 
+// bury implements Bury() and BuryWithReason().
+func (queue *Queue) bury(op string, key string, reason string) error {
 	// check it's actually still in the queue first
-	item, ok := queue.items[key]
-	if !ok {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Bury", key, ErrNotFound}
+	s, item, _, err := queue.lockItem(op, key, nil)
+	if err != nil {
+		return err
 	}
 
 	// and it must be in the run queue
-	if ok = item.state == ItemStateRun; !ok {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Bury", key, ErrNotRunning}
+	if item.state != ItemStateRun {
+		s.unlock()
+		return Error{queue.Name, op, key, ErrNotRunning}
 	}
 
 	// switch from run to bury queue
-	queue.runQueue.remove(item)
-	queue.buryQueue.push(item)
+	s.runQueue.remove(item)
+	item.setBuryReason(reason)
+	s.buryQueue.push(item)
 	item.switchRunBury()
-	queue.mutex.Unlock()
+	s.unlock()
 	queue.changed(SubQueueRun, SubQueueBury, []*Item{item})
 
 	return nil
 }
 
-// Kick is a thread-safe way to switch an item in the bury sub-queue to the
-// ready sub-queue, for when a previously buried item can now be handled.
-func (queue *Queue) Kick(key string) error {
-	queue.mutex.Lock()
+// BuryWaiting is a thread-safe way to switch an item in the delay, ready or
+// dependent sub-queue to the bury sub-queue, for when the user knows the item
+// can't be dealt with before it has even been reserved.
+func (queue *Queue) BuryWaiting(key string) error {
+	// check it's actually still in the queue first
+	s, item, _, err := queue.lockItem("BuryWaiting", key, nil)
+	if err != nil {
+		return err
+	}
 
-	if queue.closed {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Kick", key, ErrQueueClosed}
+	// and it must be in the delay, ready or dependent queue
+	var from SubQueue
+	switch item.state {
+	case ItemStateDelay:
+		s.delayQueue.remove(item)
+		from = SubQueueDelay
+	case ItemStateReady:
+		s.readyQueue.remove(item)
+		from = SubQueueReady
+	case ItemStateDependent:
+		s.depQueue.remove(item)
+		from = SubQueueDependent
+	default:
+		s.unlock()
+		return Error{queue.Name, "BuryWaiting", key, ErrNotWaiting}
 	}
 
-	// check it's actually still in the queue first
-	item, ok := queue.items[key]
-	if !ok {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Kick", key, ErrNotFound}
+	// switch to the bury queue
+	s.buryQueue.push(item)
+	item.switchWaitingBury()
+	s.unlock()
+	queue.changed(from, SubQueueBury, []*Item{item})
+
+	return nil
+}
+
+// Kick is a thread-safe way to switch an item in the bury sub-queue to the
+// ready sub-queue, for when a previously buried item can now be handled.
+func (queue *Queue) Kick(key string) error {
+	// check it's actually still in the queue first; if it has dependencies
+	// we'll need to look at other items
+	s, item, exclusive, err := queue.lockItem("Kick", key, func(item *Item) bool {
+		return len(item.Dependencies()) > 0
+	})
+	if err != nil {
+		return err
 	}
 
 	// and it must be in the bury queue
-	if ok = item.state == ItemStateBury; !ok {
-		queue.mutex.Unlock()
+	if item.state != ItemStateBury {
+		queue.unlockKey(s, exclusive)
 		return Error{queue.Name, "Kick", key, ErrNotBuried}
 	}
 
 	// switch from bury to ready or dependent queue
-	queue.buryQueue.remove(item)
-	if queue.itemHasDeps(item) {
-		queue.depQueue.push(item)
+	s.buryQueue.remove(item)
+	if exclusive && queue.itemHasDeps(item) {
+		s.depQueue.push(item)
 		item.switchBuryDependent()
-		queue.mutex.Unlock()
+		queue.unlockKey(s, exclusive)
 		queue.changed(SubQueueBury, SubQueueDependent, []*Item{item})
 	} else {
-		queue.readyQueue.push(item)
+		s.readyQueue.push(item)
 		item.switchBuryReady()
-		queue.mutex.Unlock()
+		queue.unlockKey(s, exclusive)
 		queue.changed(SubQueueBury, SubQueueReady, []*Item{item})
 		queue.readyAdded()
 	}
@@ -1122,34 +1391,68 @@ func (queue *Queue) Kick(key string) error {
 
 // Remove is a thread-safe way to remove an item from the queue.
 func (queue *Queue) Remove(key string) error {
-	queue.mutex.Lock()
+	// check it's actually still in the queue first; if it's entangled with
+	// other items we'll need to update them as well
+	s, item, exclusive, err := queue.lockItem("Remove", key, queue.entangled)
+	if err != nil {
+		return err
+	}
 
-	if queue.closed {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Remove", key, ErrQueueClosed}
+	var addedReadyItems []*Item
+	if exclusive {
+		addedReadyItems = queue.disentangle(item)
 	}
 
-	// check it's actually still in the queue first
-	item, existed := queue.items[key]
-	if !existed {
-		queue.mutex.Unlock()
-		return Error{queue.Name, "Remove", key, ErrNotFound}
+	// remove from the queue
+	delete(s.items, key)
+
+	// remove from the current sub-queue
+	switch item.state {
+	case ItemStateDelay:
+		s.delayQueue.remove(item)
+		queue.changed(SubQueueDelay, SubQueueRemoved, []*Item{item})
+	case ItemStateReady:
+		s.readyQueue.remove(item)
+		queue.changed(SubQueueReady, SubQueueRemoved, []*Item{item})
+	case ItemStateRun:
+		s.runQueue.remove(item)
+		queue.changed(SubQueueRun, SubQueueRemoved, []*Item{item})
+	case ItemStateBury:
+		s.buryQueue.remove(item)
+		queue.changed(SubQueueBury, SubQueueRemoved, []*Item{item})
+	case ItemStateDependent:
+		s.depQueue.remove(item)
+		queue.changed(SubQueueDependent, SubQueueRemoved, []*Item{item})
 	}
+	item.removalCleanup()
 
-	// transfer any dependants to the ready queue
-	addedReady := false
+	queue.unlockKey(s, exclusive)
+	if len(addedReadyItems) > 0 {
+		queue.changed(SubQueueDependent, SubQueueReady, addedReadyItems)
+		queue.readyAdded()
+	}
+
+	return nil
+}
+
+// disentangle is used by Remove() to transfer the dependants of the given item
+// that no longer have unresolved dependencies to the ready queue (returning
+// them), and to remove the item from our dependency lookups. You must hold the
+// mutex lock before calling this.
+func (queue *Queue) disentangle(item *Item) []*Item {
+	key := item.Key
 	var addedReadyItems []*Item
 	if deps, exists := queue.dependants[key]; exists {
 		for _, dep := range deps {
 			done := dep.resolveDependency(key)
 			if done && dep.state == ItemStateDependent {
-				queue.depQueue.remove(dep)
+				s := queue.shardFor(dep.Key)
+				s.depQueue.remove(dep)
 
 				// put it straight on the ready queue, regardless of delay value
 				dep.switchDependentReady()
-				queue.readyQueue.push(dep)
+				s.readyQueue.push(dep)
 				addedReadyItems = append(addedReadyItems, dep)
-				addedReady = true
 			}
 		}
 		delete(queue.dependants, key)
@@ -1166,36 +1469,8 @@ func (queue *Queue) Remove(key string) error {
 		}
 	}
 
-	// remove from the queue
-	delete(queue.items, key)
-
-	// remove from the current sub-queue
-	switch item.state {
-	case ItemStateDelay:
-		queue.delayQueue.remove(item)
-		queue.changed(SubQueueDelay, SubQueueRemoved, []*Item{item})
-	case ItemStateReady:
-		queue.readyQueue.remove(item)
-		queue.changed(SubQueueReady, SubQueueRemoved, []*Item{item})
-	case ItemStateRun:
-		queue.runQueue.remove(item)
-		queue.changed(SubQueueRun, SubQueueRemoved, []*Item{item})
-	case ItemStateBury:
-		queue.buryQueue.remove(item)
-		queue.changed(SubQueueBury, SubQueueRemoved, []*Item{item})
-	case ItemStateDependent:
-		queue.depQueue.remove(item)
-		queue.changed(SubQueueDependent, SubQueueRemoved, []*Item{item})
-	}
-	item.removalCleanup()
-
-	queue.mutex.Unlock()
-	if addedReady {
-		queue.changed(SubQueueDependent, SubQueueReady, addedReadyItems)
-		queue.readyAdded()
-	}
-
-	return nil
+	queue.leaveDepGroups(item)
+	return addedReadyItems
 }
 
 // HasDependents tells you if the item with the given key has any other items
@@ -1203,8 +1478,8 @@ func (queue *Queue) Remove(key string) error {
 // you're removing it because it was undesired as opposed to complete, as
 // Remove() always triggers dependent items to become ready.
 func (queue *Queue) HasDependents(key string) (bool, error) {
-	queue.mutex.Lock()
-	defer queue.mutex.Unlock()
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
 
 	if queue.closed {
 		return false, Error{queue.Name, "Remove", key, ErrQueueClosed}
@@ -1213,159 +1488,3 @@ func (queue *Queue) HasDependents(key string) (bool, error) {
 	_, has := queue.dependants[key]
 	return has, nil
 }
-
-func (queue *Queue) startDelayProcessing() {
-	sendStarted := true
-	for {
-		queue.mutex.Lock()
-		var sleepTime time.Duration
-		if queue.delayQueue.len() > 0 {
-			sleepTime = time.Until(queue.delayQueue.firstItem().ReadyAt())
-		} else {
-			sleepTime = 1 * time.Hour
-		}
-
-		queue.delayTime = time.Now().Add(sleepTime)
-		queue.mutex.Unlock()
-		if sendStarted {
-			queue.startedDelayProcessing <- true
-		}
-
-		select {
-		case <-time.After(time.Until(queue.delayTime)):
-			queue.mutex.Lock()
-			len := queue.delayQueue.len()
-			addedReady := false
-			var items []*Item
-			for i := 0; i < len; i++ {
-				item := queue.delayQueue.firstItem()
-
-				if !item.isready() {
-					break
-				}
-
-				// remove it from the delay sub-queue and add it to the ready
-				// sub-queue
-				queue.delayQueue.remove(item)
-				queue.readyQueue.push(item)
-				item.switchDelayReady()
-				items = append(items, item)
-				addedReady = true
-			}
-			queue.mutex.Unlock()
-			if addedReady {
-				queue.changed(SubQueueDelay, SubQueueReady, items)
-				queue.readyAdded()
-			}
-			sendStarted = false
-		case <-queue.delayNotification:
-			sendStarted = true
-			continue
-		case <-queue.delayClose:
-			return
-		}
-	}
-}
-
-func (queue *Queue) delayNotificationTrigger(item *Item) {
-	queue.mutex.RLock()
-	if queue.delayTime.After(time.Now().Add(item.delay)) {
-		queue.mutex.RUnlock()
-		queue.delayNotification <- true
-		<-queue.startedDelayProcessing
-	} else {
-		queue.mutex.RUnlock()
-	}
-}
-
-func (queue *Queue) startTTRProcessing() {
-	sendStarted := true
-	for {
-		var sleepTime time.Duration
-		queue.mutex.Lock()
-		if queue.runQueue.len() > 0 {
-			sleepTime = time.Until(queue.runQueue.firstItem().ReleaseAt())
-		} else {
-			sleepTime = 1 * time.Hour
-		}
-
-		queue.ttrTime = time.Now().Add(sleepTime)
-		queue.mutex.Unlock()
-		if sendStarted {
-			queue.startedTTRProcessing <- true
-		}
-
-		select {
-		case <-time.After(time.Until(queue.ttrTime)):
-			queue.mutex.Lock()
-			length := queue.runQueue.len()
-			var delayedItems, buriedItems, readyItems []*Item
-			for i := 0; i < length; i++ {
-				item := queue.runQueue.firstItem()
-
-				if !item.releasable() {
-					break
-				}
-
-				// obey the ttr callback
-				moveTo := queue.ttrCb(item.Data())
-				if moveTo == SubQueueRun {
-					// increase this item's time to release to a year from now,
-					// but keep it in the run queue
-					item.tempDisableTTR()
-					queue.runQueue.update(item)
-				} else {
-					// remove it from the ttr sub-queue and move to another
-					queue.runQueue.remove(item)
-					switch moveTo {
-					case SubQueueDelay:
-						item.restart()
-						queue.delayQueue.push(item)
-						item.switchRunDelay(true)
-						delayedItems = append(delayedItems, item)
-					case SubQueueBury:
-						queue.buryQueue.push(item)
-						item.switchRunBury(true)
-						buriedItems = append(buriedItems, item)
-					default:
-						queue.readyQueue.push(item)
-						item.switchRunReady()
-						readyItems = append(readyItems, item)
-					}
-				}
-			}
-
-			queue.mutex.Unlock()
-			if len(delayedItems) > 0 {
-				for _, item := range delayedItems {
-					queue.delayNotificationTrigger(item)
-				}
-				queue.changed(SubQueueRun, SubQueueDelay, delayedItems)
-			}
-			if len(buriedItems) > 0 {
-				queue.changed(SubQueueRun, SubQueueBury, buriedItems)
-			}
-			if len(readyItems) > 0 {
-				queue.changed(SubQueueRun, SubQueueReady, readyItems)
-				queue.readyAdded()
-			}
-			sendStarted = false
-		case <-queue.ttrNotification:
-			sendStarted = true
-			continue
-		case <-queue.ttrClose:
-			return
-		}
-	}
-}
-
-func (queue *Queue) ttrNotificationTrigger(item *Item) {
-	queue.mutex.RLock()
-	if queue.ttrTime.After(time.Now().Add(item.ttr)) {
-		queue.mutex.RUnlock()
-		queue.ttrNotification <- true
-		<-queue.startedTTRProcessing
-	} else {
-		queue.mutex.RUnlock()
-	}
-}
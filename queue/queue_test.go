@@ -19,9 +19,11 @@
 package queue
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"sort"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -376,6 +378,53 @@ func TestQueue(t *testing.T) {
 					})
 				})
 
+				Convey("Or release them with a custom delay, which doesn't change their own", func() {
+					delay := item1.Stats().Delay
+					prepareToCheckChanged()
+					err := queue.ReleaseWithDelay(item1.Key, 20*time.Millisecond)
+					So(err, ShouldBeNil)
+					So(checkChanged(SubQueueRun, SubQueueDelay, 1), ShouldBeTrue)
+					So(item1.State(), ShouldEqual, ItemStateDelay)
+					So(item1.releases, ShouldEqual, 1)
+					So(item1.Stats().Delay, ShouldEqual, delay)
+
+					<-time.After(10 * time.Millisecond)
+					So(item1.State(), ShouldEqual, ItemStateDelay)
+					<-time.After(20 * time.Millisecond)
+					So(item1.State(), ShouldEqual, ItemStateReady)
+
+					err = queue.ReleaseWithDelay(item2.Key, 0)
+					So(err, ShouldBeNil)
+					So(item2.State(), ShouldEqual, ItemStateReady)
+
+					err = queue.ReleaseWithDelay(item2.Key, 0)
+					So(err, ShouldNotBeNil)
+					qerr, ok := err.(Error)
+					So(ok, ShouldBeTrue)
+					So(qerr.Op, ShouldEqual, "ReleaseWithDelay")
+					So(qerr.Err, ShouldEqual, ErrNotRunning)
+				})
+
+				Convey("Or bury them with a reason, which is forgotten when kicked", func() {
+					prepareToCheckChanged()
+					err := queue.BuryWithReason(item3.Key, "broken")
+					So(err, ShouldBeNil)
+					So(checkChanged(SubQueueRun, SubQueueBury, 1), ShouldBeTrue)
+					So(item3.State(), ShouldEqual, ItemStateBury)
+					So(item3.buries, ShouldEqual, 1)
+					So(item3.Stats().BuryReason, ShouldEqual, "broken")
+
+					err = queue.Kick(item3.Key)
+					So(err, ShouldBeNil)
+					So(item3.Stats().BuryReason, ShouldEqual, "")
+
+					err = queue.BuryWithReason(item3.Key, "broken")
+					So(err, ShouldNotBeNil)
+					qerr, ok := err.(Error)
+					So(ok, ShouldBeTrue)
+					So(qerr.Err, ShouldEqual, ErrNotRunning)
+				})
+
 				Convey("Or remove them", func() {
 					So(item2.State(), ShouldEqual, ItemStateRun)
 					prepareToCheckChanged()
@@ -610,10 +659,10 @@ func TestQueue(t *testing.T) {
 
 					<-time.After(25 * time.Millisecond)
 
-					So(queue.runQueue.firstItem().Key, ShouldEqual, "item1")
+					So(nextToRelease(queue).Key, ShouldEqual, "item1")
 					erra = queue.Touch(item1.Key)
 					So(erra, ShouldBeNil)
-					So(queue.runQueue.firstItem().Key, ShouldEqual, "item2")
+					So(nextToRelease(queue).Key, ShouldEqual, "item2")
 
 					<-time.After(30 * time.Millisecond)
 
@@ -721,6 +770,26 @@ func TestQueue(t *testing.T) {
 				So(qerr.Err, ShouldEqual, ErrNotBuried)
 			})
 
+			Convey("You can bury them when ready", func() {
+				err := queue.BuryWaiting("key_0")
+				So(err, ShouldBeNil)
+
+				stats := queue.Stats()
+				So(stats.Items, ShouldEqual, 10)
+				So(stats.Ready, ShouldEqual, 2)
+				So(stats.Buried, ShouldEqual, 1)
+
+				err = queue.BuryWaiting("key_0")
+				So(err, ShouldNotBeNil)
+				qerr, ok := err.(Error)
+				So(ok, ShouldBeTrue)
+				So(qerr.Err, ShouldEqual, ErrNotWaiting)
+
+				err = queue.Kick("key_0")
+				So(err, ShouldBeNil)
+				So(queue.Stats().Ready, ShouldEqual, 3)
+			})
+
 			Convey("But you can remove them when ready", func() {
 				err := queue.Remove("key_0")
 				So(err, ShouldBeNil)
@@ -749,6 +818,56 @@ func TestQueue(t *testing.T) {
 			So(qerr.Err, ShouldEqual, ErrNothingReady)
 		})
 
+		Convey("You can bury them when not ready", func() {
+			err := queue.BuryWaiting("key_0")
+			So(err, ShouldBeNil)
+
+			stats := queue.Stats()
+			So(stats.Items, ShouldEqual, 10)
+			So(stats.Delayed, ShouldEqual, 9)
+			So(stats.Buried, ShouldEqual, 1)
+
+			err = queue.BuryWaiting("fake")
+			So(err, ShouldNotBeNil)
+			qerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(qerr.Err, ShouldEqual, ErrNotFound)
+		})
+
+		Convey("You can reserve a particular one when not ready, or when buried", func() {
+			item, err := queue.ReserveKey("key_1")
+			So(err, ShouldBeNil)
+			So(item.Key, ShouldEqual, "key_1")
+			So(item.Stats().State, ShouldEqual, ItemStateRun)
+
+			_, err = queue.ReserveKey("key_1")
+			So(err, ShouldNotBeNil)
+			qerr, ok := err.(Error)
+			So(ok, ShouldBeTrue)
+			So(qerr.Err, ShouldEqual, ErrNotReservable)
+
+			_, err = queue.ReserveKey("fake")
+			So(err, ShouldNotBeNil)
+			qerr, ok = err.(Error)
+			So(ok, ShouldBeTrue)
+			So(qerr.Err, ShouldEqual, ErrNotFound)
+
+			err = queue.BuryWaiting("key_0")
+			So(err, ShouldBeNil)
+			item, err = queue.ReserveKey("key_0")
+			So(err, ShouldBeNil)
+			So(item.Stats().Kicks, ShouldEqual, 1)
+
+			stats := queue.Stats()
+			So(stats.Items, ShouldEqual, 10)
+			So(stats.Delayed, ShouldEqual, 8)
+			So(stats.Running, ShouldEqual, 2)
+			So(stats.Buried, ShouldEqual, 0)
+
+			err = queue.Remove("key_0")
+			So(err, ShouldBeNil)
+		})
+
 		Convey("But you can remove them when not ready", func() {
 			err := queue.Remove("key_0")
 			So(err, ShouldBeNil)
@@ -957,6 +1076,56 @@ func TestQueue(t *testing.T) {
 		So(item.Key, ShouldEqual, "key_large")
 	})
 
+	Convey("You can reserve items preferring those with certain data", t, func() {
+		queue := New("prefer queue")
+		defer func() {
+			errd := queue.Destroy()
+			So(errd, ShouldBeNil)
+		}()
+
+		_, err := queue.Add("key_1", "g", "cold", 1, 0*time.Millisecond, 100*time.Millisecond, "")
+		So(err, ShouldBeNil)
+		_, err = queue.Add("key_2", "g", "warm", 1, 0*time.Millisecond, 100*time.Millisecond, "")
+		So(err, ShouldBeNil)
+		_, err = queue.Add("key_3", "g", "warm", 1, 0*time.Millisecond, 100*time.Millisecond, "")
+		So(err, ShouldBeNil)
+		_, err = queue.Add("key_4", "g", "hot", 2, 0*time.Millisecond, 100*time.Millisecond, "")
+		So(err, ShouldBeNil)
+		_, err = queue.Add("key_5", "g", "hot", 0, 0*time.Millisecond, 100*time.Millisecond, "")
+		So(err, ShouldBeNil)
+
+		prefer := func(want string) func(data interface{}) bool {
+			return func(data interface{}) bool {
+				return data.(string) == want
+			}
+		}
+
+		// priority is never overridden by preference
+		item, err := queue.ReservePreferring("g", 0, prefer("warm"))
+		So(err, ShouldBeNil)
+		So(item.Key, ShouldEqual, "key_4")
+
+		item, err = queue.ReservePreferring("g", 0, prefer("warm"))
+		So(err, ShouldBeNil)
+		So(item.Key, ShouldEqual, "key_2")
+
+		item, err = queue.ReservePreferring("g", 0, prefer("hot"))
+		So(err, ShouldBeNil)
+		So(item.Key, ShouldEqual, "key_1")
+
+		item, err = queue.ReservePreferring("g", 0, prefer("warm"))
+		So(err, ShouldBeNil)
+		So(item.Key, ShouldEqual, "key_3")
+
+		item, err = queue.ReservePreferring("g", 0, prefer("warm"))
+		So(err, ShouldBeNil)
+		So(item.Key, ShouldEqual, "key_5")
+
+		item, err = queue.ReservePreferring("g", 0, prefer("warm"))
+		So(err, ShouldNotBeNil)
+		So(item, ShouldBeNil)
+	})
+
 	Convey("Once a thousand items with no delay have been added to the queue", t, func() {
 		queue := New("1000 queue")
 		defer qdestroy(queue)
@@ -1073,6 +1242,20 @@ func TestQueue(t *testing.T) {
 			}
 		})
 
+		Convey("Waiting to reserve a group can be cancelled", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			t := time.Now()
+			item, err := queue.ReserveContext(ctx, "1001", 5*time.Second, nil)
+			So(item, ShouldBeNil)
+			So(err == context.DeadlineExceeded, ShouldBeTrue)
+			So(time.Since(t), ShouldBeLessThan, 1*time.Second)
+
+			item, err = queue.ReserveContext(context.Background(), fmt.Sprintf("%d", dataids[0]), 5*time.Second, nil)
+			So(err, ShouldBeNil)
+			So(item, ShouldNotBeNil)
+		})
+
 		Convey("You can change a group with SetReserveGroup()", func() {
 			item, err := queue.Reserve("1001", 0)
 			So(err, ShouldNotBeNil)
@@ -1276,6 +1459,25 @@ func TestQueue(t *testing.T) {
 			depTestFunc(queue, false)
 		})
 
+		Convey("Dependent items can be buried, and stay buried once their dependencies are met", func() {
+			So(queue.Stats().Dependant, ShouldEqual, 5)
+			err := queue.BuryWaiting("key_4")
+			So(err, ShouldBeNil)
+			stats := queue.Stats()
+			So(stats.Dependant, ShouldEqual, 4)
+			So(stats.Buried, ShouldEqual, 1)
+
+			err = queue.Remove("key_1")
+			So(err, ShouldBeNil)
+			four, err := queue.Get("key_4")
+			So(err, ShouldBeNil)
+			So(four.Stats().State, ShouldEqual, ItemStateBury)
+
+			err = queue.Kick("key_4")
+			So(err, ShouldBeNil)
+			So(four.Stats().State, ShouldEqual, ItemStateReady)
+		})
+
 		Convey("HasDependents works", func() {
 			hasDeps, err := queue.HasDependents("key_8")
 			So(err, ShouldBeNil)
@@ -1475,6 +1677,100 @@ func TestQueue(t *testing.T) {
 		})
 	})
 
+	Convey("Items can depend on dependency groups, including members added later", t, func() {
+		queue := New("dep group queue")
+		defer qdestroy(queue)
+
+		ttr := 30 * time.Second
+		added, dups, err := queue.AddMany([]*ItemDef{
+			{Key: "b1", Data: "b1", TTR: ttr, GroupDependencies: []string{"a"}},
+			{Key: "a1", Data: "a1", TTR: ttr, DepGroups: []string{"a"}},
+			{Key: "c1", Data: "c1", TTR: ttr, DepGroups: []string{"c"}},
+		})
+		So(err, ShouldBeNil)
+		So(added, ShouldEqual, 3)
+		So(dups, ShouldEqual, 0)
+		So(queue.DepGroupMembers("a"), ShouldResemble, []string{"a1"})
+
+		b1, err := queue.Get("b1")
+		So(err, ShouldBeNil)
+		So(b1.State(), ShouldEqual, ItemStateDependent)
+		So(b1.UnresolvedDependencies(), ShouldResemble, []string{"a1"})
+		So(b1.GroupDependencies(), ShouldResemble, []string{"a"})
+
+		addA2 := func() {
+			added, _, err = queue.AddMany([]*ItemDef{{Key: "a2", Data: "a2", TTR: ttr, DepGroups: []string{"a"}}})
+			So(err, ShouldBeNil)
+			So(added, ShouldEqual, 1)
+		}
+
+		Convey("By default, dependent items wait for late members as well", func() {
+			addA2()
+			So(len(b1.UnresolvedDependencies()), ShouldEqual, 2)
+
+			err = queue.Remove("a1")
+			So(err, ShouldBeNil)
+			So(b1.State(), ShouldEqual, ItemStateDependent)
+			err = queue.Remove("a2")
+			So(err, ShouldBeNil)
+			So(b1.State(), ShouldEqual, ItemStateReady)
+			So(queue.DepGroupMembers("a"), ShouldBeEmpty)
+
+			Convey("But ready items are not affected by late members", func() {
+				addA2()
+				So(b1.State(), ShouldEqual, ItemStateReady)
+			})
+		})
+
+		Convey("Late members can be ignored", func() {
+			queue.SetDepGroupPolicy("a", DepGroupIgnoreLate)
+			addA2()
+			So(b1.UnresolvedDependencies(), ShouldResemble, []string{"a1"})
+
+			err = queue.Remove("a1")
+			So(err, ShouldBeNil)
+			So(b1.State(), ShouldEqual, ItemStateReady)
+		})
+
+		Convey("Late members can recall ready items", func() {
+			queue.SetDepGroupPolicy("a", DepGroupRecallForLate)
+			err = queue.Remove("a1")
+			So(err, ShouldBeNil)
+			So(b1.State(), ShouldEqual, ItemStateReady)
+
+			addA2()
+			So(b1.State(), ShouldEqual, ItemStateDependent)
+			So(queue.Stats().Dependant, ShouldEqual, 1)
+
+			err = queue.Remove("a2")
+			So(err, ShouldBeNil)
+			So(b1.State(), ShouldEqual, ItemStateReady)
+		})
+
+		Convey("Group dependencies survive updates to the other dependencies", func() {
+			err = queue.Update("b1", "", "b1", 0, 0*time.Second, ttr, []string{"c1"})
+			So(err, ShouldBeNil)
+			So(len(b1.UnresolvedDependencies()), ShouldEqual, 2)
+
+			err = queue.Remove("c1")
+			So(err, ShouldBeNil)
+			So(b1.State(), ShouldEqual, ItemStateDependent)
+			err = queue.Remove("a1")
+			So(err, ShouldBeNil)
+			So(b1.State(), ShouldEqual, ItemStateReady)
+		})
+
+		Convey("Group membership follows changed keys", func() {
+			err = queue.ChangeKey("a1", "a1b")
+			So(err, ShouldBeNil)
+			So(queue.DepGroupMembers("a"), ShouldResemble, []string{"a1b"})
+
+			err = queue.Remove("a1b")
+			So(err, ShouldBeNil)
+			So(b1.State(), ShouldEqual, ItemStateReady)
+		})
+	})
+
 	Convey("Once some items with dependencies have been added to the queue en-masse", t, func() {
 		// same setup as in previous test
 		queue := New("dep many queue")
@@ -1491,12 +1787,12 @@ func TestQueue(t *testing.T) {
 			Data: "2",
 			TTR:  30 * time.Second,
 		})
-		itemdefs = append(itemdefs, &ItemDef{"key_3", "", "3", 0, 0 * time.Second, 30 * time.Second, "", []string{}})
-		itemdefs = append(itemdefs, &ItemDef{"key_4", "", "4", 0, 0 * time.Second, 30 * time.Second, "", []string{"key_1"}})
-		itemdefs = append(itemdefs, &ItemDef{"key_5", "", "5", 0, 0 * time.Second, 30 * time.Second, "", []string{"key_2", "key_3"}})
-		itemdefs = append(itemdefs, &ItemDef{"key_6", "", "6", 0, 0 * time.Second, 30 * time.Second, "", []string{"key_3", "key_4"}})
-		itemdefs = append(itemdefs, &ItemDef{"key_7", "", "7", 0, 0 * time.Second, 30 * time.Second, "", []string{"key_5", "key_6"}})
-		itemdefs = append(itemdefs, &ItemDef{"key_8", "", "8", 0, 0 * time.Second, 30 * time.Second, "", []string{"key_5"}})
+		itemdefs = append(itemdefs, &ItemDef{Key: "key_3", Data: "3", TTR: 30 * time.Second, Dependencies: []string{}})
+		itemdefs = append(itemdefs, &ItemDef{Key: "key_4", Data: "4", TTR: 30 * time.Second, Dependencies: []string{"key_1"}})
+		itemdefs = append(itemdefs, &ItemDef{Key: "key_5", Data: "5", TTR: 30 * time.Second, Dependencies: []string{"key_2", "key_3"}})
+		itemdefs = append(itemdefs, &ItemDef{Key: "key_6", Data: "6", TTR: 30 * time.Second, Dependencies: []string{"key_3", "key_4"}})
+		itemdefs = append(itemdefs, &ItemDef{Key: "key_7", Data: "7", TTR: 30 * time.Second, Dependencies: []string{"key_5", "key_6"}})
+		itemdefs = append(itemdefs, &ItemDef{Key: "key_8", Data: "8", TTR: 30 * time.Second, Dependencies: []string{"key_5"}})
 
 		added, dups, err := queue.AddMany(itemdefs)
 		So(err, ShouldBeNil)
@@ -1724,6 +2020,112 @@ func TestQueue(t *testing.T) {
 		So(<-rmErrCh, ShouldBeNil)
 		So(<-rCh3, ShouldBeTrue)
 	})
+
+	Convey("Many clients can add, reserve, touch and remove items concurrently", t, func() {
+		queue := New("concurrent queue")
+		defer qdestroy(queue)
+
+		workers := 20
+		perWorker := 250
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		reserved := make(map[string]int)
+		errCh := make(chan error, workers)
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := 0; i < perWorker; i++ {
+					if _, err := queue.Add(fmt.Sprintf("%d.%d", w, i), "", w, uint8(i%3), 0, 30*time.Second, ""); err != nil {
+						errCh <- err
+						return
+					}
+				}
+				for i := 0; i < perWorker; i++ {
+					item, err := queue.Reserve("", 1*time.Second)
+					if err != nil {
+						errCh <- err
+						return
+					}
+					mu.Lock()
+					reserved[item.Key]++
+					mu.Unlock()
+					if err = queue.Touch(item.Key); err != nil {
+						errCh <- err
+						return
+					}
+					if err = queue.Remove(item.Key); err != nil {
+						errCh <- err
+						return
+					}
+				}
+			}(w)
+		}
+		wg.Wait()
+		close(errCh)
+
+		for err := range errCh {
+			So(err, ShouldBeNil)
+		}
+		So(len(reserved), ShouldEqual, workers*perWorker)
+		for _, count := range reserved {
+			So(count, ShouldEqual, 1)
+		}
+		stats := queue.Stats()
+		So(stats.Items, ShouldEqual, 0)
+		So(stats.Ready, ShouldEqual, 0)
+		So(stats.Running, ShouldEqual, 0)
+	})
+
+	Convey("Items keep their place when ChangeKey() moves them between shards", t, func() {
+		queue := New("shard queue")
+		defer qdestroy(queue)
+
+		oldKey, newKey := "a", "b"
+		So(shardIndex(oldKey), ShouldNotEqual, shardIndex(newKey))
+		_, err := queue.Add(oldKey, "", "data", 0, 0, 30*time.Second, "")
+		So(err, ShouldBeNil)
+		_, err = queue.Add("c", "", "data", 0, 0, 30*time.Second, "")
+		So(err, ShouldBeNil)
+
+		err = queue.ChangeKey(oldKey, newKey)
+		So(err, ShouldBeNil)
+		So(queue.Stats().Ready, ShouldEqual, 2)
+
+		item, err := queue.Reserve("", 0)
+		So(err, ShouldBeNil)
+		So(item.Key, ShouldEqual, newKey)
+		So(queue.Touch(newKey), ShouldBeNil)
+		So(queue.Remove(newKey), ShouldBeNil)
+		So(queue.Stats().Items, ShouldEqual, 1)
+	})
+}
+
+// BenchmarkQueueTransitions measures the throughput of items being added,
+// reserved, touched and removed by concurrent clients; each op is 4 state
+// transitions.
+func BenchmarkQueueTransitions(b *testing.B) {
+	queue := New("bench queue")
+	defer qdestroy(queue)
+	var n uint64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			key := fmt.Sprintf("key_%d", atomic.AddUint64(&n, 1))
+			if _, err := queue.Add(key, "", "data", 0, 0, 30*time.Second, ""); err != nil {
+				b.Fatal(err)
+			}
+			item, err := queue.Reserve("", 0)
+			if err != nil {
+				continue
+			}
+			if err = queue.Touch(item.Key); err != nil {
+				b.Fatal(err)
+			}
+			if err = queue.Remove(item.Key); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
 }
 
 func depTestFunc(queue *Queue, changed bool) {
@@ -1839,3 +2241,19 @@ func qdestroy(q *Queue) {
 		fmt.Printf("queue.Destroy failed: %s\n", err)
 	}
 }
+
+// nextToRelease returns the item in the run sub-queue of any shard of the given
+// queue that will hit its ttr first.
+func nextToRelease(queue *Queue) *Item {
+	var next *Item
+	for _, s := range queue.shards {
+		s.rlock()
+		if s.runQueue.len() > 0 {
+			if item := s.runQueue.firstItem(); next == nil || item.ReleaseAt().Before(next.ReleaseAt()) {
+				next = item
+			}
+		}
+		s.runlock()
+	}
+	return next
+}
@@ -0,0 +1,324 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+package queue
+
+// This file contains the shards a Queue's items are divided between, so that
+// operations on different items can proceed concurrently.
+
+import (
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+)
+
+// numShards is the number of shards each Queue divides its items between.
+const numShards = 16
+
+// shard holds the items whose keys hash to it, along with its own sub-queues
+// and delay and ttr processing. Its mutex must only be used while also holding
+// the Queue's mutex read lock; holders of the Queue's (write) lock may work with
+// every shard without locking them.
+type shard struct {
+	delayTime              time.Time
+	ttrTime                time.Time
+	queue                  *Queue
+	items                  map[string]*Item
+	delayQueue             *subQueue
+	readyQueue             *subQueue
+	runQueue               *subQueue
+	buryQueue              *buryQueue
+	depQueue               *depQueue
+	delayNotification      chan bool
+	startedDelayProcessing chan bool
+	delayClose             chan bool
+	ttrNotification        chan bool
+	startedTTRProcessing   chan bool
+	ttrClose               chan bool
+	mutex                  sync.RWMutex
+}
+
+// newShard creates a shard of the given queue, the ready sub-queue of which
+// will notify about pushes using the given notifier, and starts its delay and
+// ttr processing.
+func newShard(queue *Queue, notifier *pushNotifier, l log15.Logger) *shard {
+	s := &shard{
+		queue:                  queue,
+		items:                  make(map[string]*Item),
+		delayQueue:             newSubQueue(0, l),
+		readyQueue:             newSubQueue(1, l),
+		runQueue:               newSubQueue(2, l),
+		buryQueue:              newBuryQueue(),
+		depQueue:               newDependencyQueue(),
+		ttrNotification:        make(chan bool, 1),
+		startedTTRProcessing:   make(chan bool),
+		ttrClose:               make(chan bool, 1),
+		ttrTime:                time.Now(),
+		delayNotification:      make(chan bool, 1),
+		startedDelayProcessing: make(chan bool),
+		delayClose:             make(chan bool, 1),
+		delayTime:              time.Now(),
+	}
+	s.readyQueue.notifier = notifier
+	go s.startDelayProcessing()
+	<-s.startedDelayProcessing
+	go s.startTTRProcessing()
+	<-s.startedTTRProcessing
+	return s
+}
+
+// shardIndex returns the index of the shard that items with the given key
+// belong to, based on an FNV-1a hash of the key.
+func shardIndex(key string) int {
+	h := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		h ^= uint32(key[i])
+		h *= 16777619
+	}
+	return int(h % numShards)
+}
+
+// empty clears out the shard's items and sub-queues, and stops its delay and
+// ttr processing.
+func (s *shard) empty() {
+	s.ttrClose <- true
+	s.delayClose <- true
+	s.items = nil
+	s.delayQueue.empty()
+	s.readyQueue.empty()
+	s.runQueue.empty()
+	s.buryQueue.empty()
+	s.depQueue.empty()
+}
+
+// detach removes the given item from whichever of our sub-queues its state
+// says it is in.
+func (s *shard) detach(item *Item) {
+	switch item.state {
+	case ItemStateDelay:
+		s.delayQueue.remove(item)
+	case ItemStateReady:
+		s.readyQueue.remove(item)
+	case ItemStateRun:
+		s.runQueue.remove(item)
+	case ItemStateBury:
+		s.buryQueue.remove(item)
+	case ItemStateDependent:
+		s.depQueue.remove(item)
+	}
+}
+
+// attach pushes the given item to whichever of our sub-queues its state says
+// it should be in.
+func (s *shard) attach(item *Item) {
+	switch item.state {
+	case ItemStateDelay:
+		s.delayQueue.push(item)
+	case ItemStateReady:
+		s.readyQueue.push(item)
+	case ItemStateRun:
+		s.runQueue.push(item)
+	case ItemStateBury:
+		s.buryQueue.push(item)
+	case ItemStateDependent:
+		s.depQueue.push(item)
+	}
+}
+
+func (s *shard) startDelayProcessing() {
+	queue := s.queue
+	sendStarted := true
+	for {
+		s.lock()
+		var sleepTime time.Duration
+		if s.delayQueue.len() > 0 {
+			sleepTime = time.Until(s.delayQueue.firstItem().ReadyAt())
+		} else {
+			sleepTime = 1 * time.Hour
+		}
+
+		s.delayTime = time.Now().Add(sleepTime)
+		s.unlock()
+		if sendStarted {
+			s.startedDelayProcessing <- true
+		}
+
+		select {
+		case <-time.After(sleepTime):
+			s.lock()
+			len := s.delayQueue.len()
+			addedReady := false
+			var items []*Item
+			for i := 0; i < len; i++ {
+				item := s.delayQueue.firstItem()
+
+				if !item.isready() {
+					break
+				}
+
+				// remove it from the delay sub-queue and add it to the ready
+				// sub-queue
+				s.delayQueue.remove(item)
+				s.readyQueue.push(item)
+				item.switchDelayReady()
+				items = append(items, item)
+				addedReady = true
+			}
+			s.unlock()
+			if addedReady {
+				queue.changed(SubQueueDelay, SubQueueReady, items)
+				queue.readyAdded()
+			}
+			sendStarted = false
+		case <-s.delayNotification:
+			sendStarted = true
+			continue
+		case <-s.delayClose:
+			return
+		}
+	}
+}
+
+func (s *shard) delayNotificationTrigger(item *Item) {
+	s.mutex.RLock()
+	if s.delayTime.After(item.ReadyAt()) {
+		s.mutex.RUnlock()
+		s.delayNotification <- true
+		<-s.startedDelayProcessing
+	} else {
+		s.mutex.RUnlock()
+	}
+}
+
+func (s *shard) startTTRProcessing() {
+	queue := s.queue
+	sendStarted := true
+	for {
+		var sleepTime time.Duration
+		s.lock()
+		if s.runQueue.len() > 0 {
+			sleepTime = time.Until(s.runQueue.firstItem().ReleaseAt())
+		} else {
+			sleepTime = 1 * time.Hour
+		}
+
+		s.ttrTime = time.Now().Add(sleepTime)
+		s.unlock()
+		if sendStarted {
+			s.startedTTRProcessing <- true
+		}
+
+		select {
+		case <-time.After(sleepTime):
+			s.lock()
+			length := s.runQueue.len()
+			var delayedItems, buriedItems, readyItems []*Item
+			for i := 0; i < length; i++ {
+				item := s.runQueue.firstItem()
+
+				if !item.releasable() {
+					break
+				}
+
+				// obey the ttr callback
+				moveTo := queue.ttrCb(item.Data())
+				if moveTo == SubQueueRun {
+					// increase this item's time to release to a year from now,
+					// but keep it in the run queue
+					item.tempDisableTTR()
+					s.runQueue.update(item)
+				} else {
+					// remove it from the ttr sub-queue and move to another
+					s.runQueue.remove(item)
+					switch moveTo {
+					case SubQueueDelay:
+						item.restart()
+						s.delayQueue.push(item)
+						item.switchRunDelay(true)
+						delayedItems = append(delayedItems, item)
+					case SubQueueBury:
+						s.buryQueue.push(item)
+						item.switchRunBury(true)
+						buriedItems = append(buriedItems, item)
+					default:
+						s.readyQueue.push(item)
+						item.switchRunReady()
+						readyItems = append(readyItems, item)
+					}
+				}
+			}
+
+			s.unlock()
+			if len(delayedItems) > 0 {
+				for _, item := range delayedItems {
+					s.delayNotificationTrigger(item)
+				}
+				queue.changed(SubQueueRun, SubQueueDelay, delayedItems)
+			}
+			if len(buriedItems) > 0 {
+				queue.changed(SubQueueRun, SubQueueBury, buriedItems)
+			}
+			if len(readyItems) > 0 {
+				queue.changed(SubQueueRun, SubQueueReady, readyItems)
+				queue.readyAdded()
+			}
+			sendStarted = false
+		case <-s.ttrNotification:
+			sendStarted = true
+			continue
+		case <-s.ttrClose:
+			return
+		}
+	}
+}
+
+func (s *shard) ttrNotificationTrigger(item *Item) {
+	s.mutex.RLock()
+	if s.ttrTime.After(time.Now().Add(item.ttr)) {
+		s.mutex.RUnlock()
+		s.ttrNotification <- true
+		<-s.startedTTRProcessing
+	} else {
+		s.mutex.RUnlock()
+	}
+}
+
+// lock takes the Queue's read lock and our own lock.
+func (s *shard) lock() {
+	s.queue.mutex.RLock()
+	s.mutex.Lock()
+}
+
+// unlock undoes lock().
+func (s *shard) unlock() {
+	s.mutex.Unlock()
+	s.queue.mutex.RUnlock()
+}
+
+// rlock is like lock(), but takes our read lock.
+func (s *shard) rlock() {
+	s.queue.mutex.RLock()
+	s.mutex.RLock()
+}
+
+// runlock undoes rlock().
+func (s *shard) runlock() {
+	s.mutex.RUnlock()
+	s.queue.mutex.RUnlock()
+}
@@ -23,24 +23,34 @@ package queue
 
 import (
 	"container/heap"
+	"sync"
 	"time"
 
-	sync "github.com/sasha-s/go-deadlock"
-
 	"github.com/inconshreveable/log15"
 	logext "github.com/inconshreveable/log15/ext"
 )
 
 type subQueue struct {
-	mutex                    sync.RWMutex
-	items                    []*Item
-	groupedItems             map[string][]*Item
-	sqIndex                  int
-	reserveGroup             string
-	pushNotificationChannels map[string]map[string]chan bool
+	mutex        sync.RWMutex
+	items        []*Item
+	groupedItems map[string][]*Item
+	sqIndex      int
+	reserveGroup string
+	notifier     *pushNotifier
 	log15.Logger
 }
 
+// pushNotifier holds the channels to notify when items are pushed to one or
+// more subQueues.
+type pushNotifier struct {
+	mutex    sync.Mutex
+	channels map[string]map[string]chan bool
+}
+
+func newPushNotifier() *pushNotifier {
+	return &pushNotifier{channels: make(map[string]map[string]chan bool)}
+}
+
 // create a new subQueue that can hold *Items in "priority" order. sqIndex is
 // one of 0 (priority is based on the item's delay), 1 (priority is based on the
 // item's priority or creation) or 2 (priority is based on the item's ttr).
@@ -53,9 +63,9 @@ func newSubQueue(sqIndex int, logger ...log15.Logger) *subQueue {
 		l.SetHandler(log15.DiscardHandler())
 	}
 	queue := &subQueue{
-		sqIndex:                  sqIndex,
-		pushNotificationChannels: make(map[string]map[string]chan bool),
-		Logger:                   l,
+		sqIndex:  sqIndex,
+		notifier: newPushNotifier(),
+		Logger:   l,
 	}
 	if sqIndex == 1 {
 		queue.groupedItems = make(map[string][]*Item)
@@ -75,30 +85,37 @@ func newSubQueue(sqIndex int, logger ...log15.Logger) *subQueue {
 //
 // If timeout duration passes before a matching item is pushed, the ch will
 // receive false and not be used again.
+//
+// subQueues sharing a notifier all notify the same channels.
 func (q *subQueue) notifyPush(reserveGroup string, ch chan bool, timeout time.Duration) {
-	q.mutex.Lock()
-	defer q.mutex.Unlock()
+	q.notifier.notifyPush(reserveGroup, ch, timeout)
+}
+
+// notifyPush implements subQueue.notifyPush().
+func (n *pushNotifier) notifyPush(reserveGroup string, ch chan bool, timeout time.Duration) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
 
 	var chans map[string]chan bool
-	if val, ok := q.pushNotificationChannels[reserveGroup]; ok {
+	if val, ok := n.channels[reserveGroup]; ok {
 		chans = val
 	} else {
 		chans = make(map[string]chan bool)
 	}
 	id := logext.RandId(8)
 	chans[id] = ch
-	q.pushNotificationChannels[reserveGroup] = chans
+	n.channels[reserveGroup] = chans
 
 	go func() {
 		<-time.After(timeout)
-		q.mutex.Lock()
-		defer q.mutex.Unlock()
-		if chans, ok := q.pushNotificationChannels[reserveGroup]; ok {
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		if chans, ok := n.channels[reserveGroup]; ok {
 			if ch, ok := chans[id]; ok {
 				ch <- false
 				delete(chans, id)
-				if len(q.pushNotificationChannels[reserveGroup]) == 0 {
-					delete(q.pushNotificationChannels, reserveGroup)
+				if len(n.channels[reserveGroup]) == 0 {
+					delete(n.channels, reserveGroup)
 				}
 			}
 		}
@@ -109,11 +126,14 @@ func (q *subQueue) notifyPush(reserveGroup string, ch chan bool, timeout time.Du
 // reserverGroup and send true on the registered channels if so. You
 // must hold the mutext lock before calling this.
 func (q *subQueue) triggerNotify(reserveGroup string) {
-	if chans, ok := q.pushNotificationChannels[reserveGroup]; ok {
+	n := q.notifier
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if chans, ok := n.channels[reserveGroup]; ok {
 		for _, ch := range chans {
 			ch <- true
 		}
-		delete(q.pushNotificationChannels, reserveGroup)
+		delete(n.channels, reserveGroup)
 	}
 }
 
@@ -152,6 +172,39 @@ func (q *subQueue) pop(reserveGroup ...string) *Item {
 	return heap.Pop(q).(*Item)
 }
 
+// peek returns the item that pop() would remove, without removing it.
+func (q *subQueue) peek(reserveGroup string) *Item {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	itemList := q.items
+	if q.sqIndex == 1 {
+		itemList = q.groupedItems[reserveGroup]
+	}
+	if len(itemList) == 0 {
+		return nil
+	}
+	return itemList[0]
+}
+
+// peekPreferring is for a subQueue based on item priority. If prefer returns
+// true for the data of any of the items in the reserveGroup that have the given
+// priority, the one of those that would have been popped first is returned
+// (without being removed). Otherwise returns nil.
+func (q *subQueue) peekPreferring(reserveGroup string, priority uint8, prefer func(data interface{}) bool) *Item {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	var best *Item
+	for _, item := range q.groupedItems[reserveGroup] {
+		if item.priority != priority || !prefer(item.Data()) {
+			continue
+		}
+		if best == nil || readyBefore(item, best) {
+			best = item
+		}
+	}
+	return best
+}
+
 // remove removes a given item from the queue
 func (q *subQueue) remove(item *Item) {
 	q.mutex.Lock()
@@ -246,13 +299,7 @@ func (q *subQueue) Less(i, j int) bool {
 		return q.items[i].readyAt.Before(q.items[j].readyAt)
 	case 1:
 		if itemList, existed := q.groupedItems[q.reserveGroup]; existed {
-			if itemList[i].priority == itemList[j].priority {
-				if itemList[i].size == itemList[j].size {
-					return itemList[i].creation.Before(itemList[j].creation)
-				}
-				return itemList[i].size > itemList[j].size
-			}
-			return itemList[i].priority > itemList[j].priority
+			return readyBefore(itemList[i], itemList[j])
 		}
 		return false
 	}
@@ -260,6 +307,18 @@ func (q *subQueue) Less(i, j int) bool {
 	return q.items[i].releaseAt.Before(q.items[j].releaseAt)
 }
 
+// readyBefore tells you if item a should be reserved before item b: the one with
+// the highest priority, then highest size, then the oldest.
+func readyBefore(a, b *Item) bool {
+	if a.priority == b.priority {
+		if a.size == b.size {
+			return a.creation.Before(b.creation)
+		}
+		return a.size > b.size
+	}
+	return a.priority > b.priority
+}
+
 func (q *subQueue) Swap(i, j int) {
 	var itemList []*Item
 	if q.sqIndex == 1 {
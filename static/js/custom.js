@@ -0,0 +1,5 @@
+// Sites can override this file (see managerweboverlay in wr_config.yml) to
+// extend the status page. If it defines a wrCustomise(svm, $nav) function, that
+// is called with the page's knockout view model before bindings are applied,
+// and the jQuery element of the (empty) list of links at the right of the
+// navigation bar, eg. to add links to site-specific help.
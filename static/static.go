@@ -0,0 +1,29 @@
+// Copyright © 2020 Genome Research Limited
+// Author: Sendu Bala <sb10@sanger.ac.uk>.
+//
+//  This file is part of wr.
+//
+//  wr is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Lesser General Public License as published by
+//  the Free Software Foundation, either version 3 of the License, or
+//  (at your option) any later version.
+//
+//  wr is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Lesser General Public License for more details.
+//
+//  You should have received a copy of the GNU Lesser General Public License
+//  along with wr. If not, see <http://www.gnu.org/licenses/>.
+
+// Package static holds the files of wr's status web interface, embedded in to
+// the wr executable.
+package static
+
+import "embed"
+
+// FS contains status.html and the other files in this directory that it
+// uses, with paths relative to this directory (eg. "js/wr-0.0.1.js").
+//
+//go:embed favicon.ico status.html css fonts js
+var FS embed.FS